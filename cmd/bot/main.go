@@ -2,19 +2,32 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"math"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/eth-trading/internal/api"
 	"github.com/eth-trading/internal/auth"
+	"github.com/eth-trading/internal/backtest"
 	"github.com/eth-trading/internal/binance"
 	"github.com/eth-trading/internal/config"
 	"github.com/eth-trading/internal/execution"
 	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/logstream"
+	"github.com/eth-trading/internal/marketdata"
+	"github.com/eth-trading/internal/models"
 	"github.com/eth-trading/internal/orchestrator"
 	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/scanner"
+	"github.com/eth-trading/internal/secrets"
 	"github.com/eth-trading/internal/storage"
 	"github.com/eth-trading/internal/strategy"
 	"github.com/rs/zerolog"
@@ -24,31 +37,53 @@ import (
 func main() {
 	// Setup logging
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	// Keep a tail of info and above for the API log stream
+	logStream := logstream.New(logstream.DefaultCapacity, zerolog.InfoLevel)
+	log.Logger = log.Output(zerolog.MultiLevelWriter(
+		zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339},
+		logStream,
+	))
 
-	log.Info().Msg("Starting ETH Trading Bot...")
+	// Load configuration: defaults, then the file, ETH_BOT_* environment
+	// variables and -set flags, each overriding the one before
+	configPath := flag.String("config", "config.yaml", "configuration file")
+	var overrides settingOverrides
+	flag.Var(&overrides, "set", "override a setting as key=value, e.g. trading.mode=paper (repeatable)")
+	flag.Parse()
 
-	// Load configuration
-	cfg, err := config.Load("config.yaml")
+	cfg, err := config.Load(*configPath, overrides...)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Containers may be configured through the environment alone
+		log.Warn().Str("path", *configPath).Msg("Config file not found, using defaults with environment and flag overrides")
+		cfg, err = config.Load("", overrides...)
+	}
+	if errors.Is(err, secrets.ErrResolve) {
+		// Running with the defaults would silently drop credentials
+		log.Fatal().Err(err).Msg("Failed to resolve configured secret")
+	}
+	if errors.Is(err, config.ErrInvalidOverride) {
+		log.Fatal().Err(err).Msg("Invalid configuration override")
+	}
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to load config, using defaults")
 		cfg = config.DefaultConfig()
 	}
 
-	// Initialize PostgreSQL database for user/auth data
-	pgCfg := &storage.PostgresConfig{
-		Host:            cfg.Postgres.Host,
-		Port:            cfg.Postgres.Port,
-		User:            cfg.Postgres.User,
-		Password:        cfg.Postgres.Password,
-		DBName:          cfg.Postgres.DBName,
-		SSLMode:         cfg.Postgres.SSLMode,
-		MaxConns:        cfg.Postgres.MaxConns,
-		MaxIdle:         cfg.Postgres.MaxIdle,
-		ConnMaxLifetime: cfg.Postgres.ConnMaxLifetime,
+	// One-off commands
+	if flag.Arg(0) == "scan" {
+		os.Exit(runScan(cfg, flag.Args()[1:]))
+	}
+	if flag.Arg(0) == "secret" {
+		os.Exit(runSecret(cfg, flag.Args()[1:]))
 	}
+	if flag.Arg(0) == "migrate-data" {
+		os.Exit(runMigrateData(cfg, flag.Args()[1:]))
+	}
+
+	log.Info().Msg("Starting ETH Trading Bot...")
 
-	pgDB, err := storage.NewPostgresDB(pgCfg)
+	// Initialize PostgreSQL database for user/auth data
+	pgDB, err := storage.NewPostgresDB(newPostgresConfig(cfg))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to connect to PostgreSQL, authentication will not be available")
 		pgDB = nil
@@ -73,14 +108,23 @@ func main() {
 			JWTSecret:          cfg.Auth.JWTSecret,
 			TokenExpiry:        cfg.Auth.TokenExpiry,
 			RefreshTokenExpiry: cfg.Auth.RefreshTokenExpiry,
+			RequireTwoFactor:   cfg.Auth.RequireTwoFactor,
 		}
 		authService = auth.NewService(authCfg, userRepo, sessionRepo, tradingAccountRepo)
+		// Users' Binance and TOTP secrets are stored only when they can be encrypted
+		keyCipher, err := newKeyCipher(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid key encryption key")
+		}
+		if keyCipher != nil {
+			authService.SetKeyCipher(keyCipher)
+		}
 		log.Info().Msg("Authentication service initialized")
 	} else {
 		log.Warn().Msg("Running without authentication - PostgreSQL not available")
 	}
 
-	// Initialize SQLite database for trading data (will migrate to PostgreSQL later)
+	// Initialize SQLite database for trading data
 	db, err := storage.NewSQLiteDB(cfg.Database.Path)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize database")
@@ -89,14 +133,35 @@ func main() {
 
 	// Initialize data service
 	dataService := storage.NewDataService(db, cfg.DataService.CacheExpiry, nil)
+	switch cfg.Database.TradingStore {
+	case storage.StoreSQLite:
+	case storage.StorePostgres:
+		// Candles, trades and positions in PostgreSQL; the rest stays in SQLite
+		if pgDB == nil {
+			log.Fatal().Msg("database.tradingStore is postgres but PostgreSQL is not available")
+		}
+		stores, err := storage.NewPostgresStores(pgDB)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize PostgreSQL trading data")
+		}
+		dataService.SetTradingStores(stores)
+		log.Info().Msg("Trading data stored in PostgreSQL")
+	default:
+		log.Fatal().Str("tradingStore", cfg.Database.TradingStore).Msg("Unknown trading data store")
+	}
 
-	// Initialize Binance client
-	binanceClient := binance.NewClient(&binance.Config{
-		APIKey:    cfg.Binance.APIKey,
-		SecretKey: cfg.Binance.SecretKey,
-		Testnet:   cfg.Binance.Testnet,
-		Timeout:   30 * time.Second,
+	// Merge the traded symbol's overrides over the global settings; the
+	// manager applies later changes to the file and settings API
+	configMgr := config.NewManager(*configPath, overrides, cfg, func(c *config.Config) {
+		loadSymbolOverrides(c, dataService)
 	})
+	if _, ok := cfg.Symbols[strings.ToUpper(cfg.Trading.Symbol)]; ok {
+		log.Info().Str("symbol", cfg.Trading.Symbol).Msg("Applied per-symbol configuration overrides")
+	}
+	cfg = configMgr.Current()
+
+	// Initialize Binance client
+	binanceClient := newBinanceClient(cfg)
 
 	// Test Binance connection
 	if err := binanceClient.Ping(); err != nil {
@@ -105,6 +170,12 @@ func main() {
 		log.Info().Msg("Binance connection successful")
 	}
 
+	// Observer mode records and charts market data without trading
+	observer := cfg.Trading.Mode == "observer"
+	if observer && (cfg.Schedule.Enabled || cfg.CopyTrading.Follower.Enabled) {
+		log.Fatal().Msg("Observer mode does not trade, disable the mode schedule and copy-trading follower")
+	}
+
 	// Initialize orchestrator first (for handler creation)
 	orchCfg := &orchestrator.OrchestratorConfig{
 		Symbol:           cfg.Trading.Symbol,
@@ -112,6 +183,7 @@ func main() {
 		PrimaryTimeframe: cfg.Trading.PrimaryTimeframe,
 		Mode:             orchestrator.TradingModePaper, // Will be set properly later
 		InitialCapital:   cfg.Trading.InitialBalance,
+		Observer:         observer,
 		EnabledStrategies: cfg.Strategies.Enabled,
 		EnableWebSocket:   true,
 		BroadcastInterval: time.Second,
@@ -127,97 +199,407 @@ func main() {
 		binance.WithReconnectWait(3 * time.Second),
 		binance.WithPingInterval(30 * time.Second),
 		binance.WithMaxReconnects(20),
+		binance.WithCompression(cfg.Binance.WSCompression),
 	}
 	wsClient := binance.NewWSClient(wsHandler, wsOpts...)
 
 	// Initialize indicator manager
-	indicatorCfg := &indicators.IndicatorConfig{
-		RSIPeriod:     cfg.Indicators.RSIPeriod,
-		MACDFast:      cfg.Indicators.MACDFast,
-		MACDSlow:      cfg.Indicators.MACDSlow,
-		MACDSignal:    cfg.Indicators.MACDSignal,
-		BBPeriod:      cfg.Indicators.BBPeriod,
-		BBStdDev:      cfg.Indicators.BBStdDev,
-		ADXPeriod:     cfg.Indicators.ADXPeriod,
-		ATRPeriod:     cfg.Indicators.ATRPeriod,
-	}
+	indicatorCfg := newIndicatorConfig(cfg)
 	indicatorMgr := indicators.NewManager(indicatorCfg)
 
 	// Initialize risk manager
-	riskCfg := &risk.RiskConfig{
-		MaxPositionSize:         cfg.Risk.MaxPositionSize,
-		MaxPositionValue:        10000, // $10,000 max position value
-		DefaultPositionSize:     0.05,  // 5% of equity
-		MaxRiskPerTrade:         cfg.Risk.MaxRiskPerTrade,
-		MinRiskRewardRatio:      cfg.Risk.MinRiskRewardRatio,
-		MaxDailyLoss:            cfg.Risk.MaxDailyLoss,
-		MaxWeeklyLoss:           cfg.Risk.MaxWeeklyLoss,
-		MaxTotalDrawdown:        cfg.Risk.MaxDrawdown,
-		MaxOpenPositions:        cfg.Risk.MaxOpenPositions,
-		MaxPositionsPerSymbol:   1,
-		MaxLeverage:             cfg.Risk.MaxLeverage,
-		EnableCircuitBreaker:    cfg.Risk.EnableCircuitBreaker,
-		ConsecutiveLossLimit:    cfg.Risk.ConsecutiveLossLimit,
-		HaltDuration:            time.Duration(cfg.Risk.HaltDurationHours) * time.Hour,
-		AdjustForVolatility:     true,
-		HighVolatilityReduction: 0.5,
-		MaxCorrelation:          0.7,
-		TradingHoursOnly:        false,
-		TradingStartHour:        0,
-		TradingEndHour:          24,
-		AvoidWeekends:           false,
+	riskCfg, err := newRiskConfig(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid risk configuration")
 	}
 	riskManager := risk.NewManager(riskCfg)
 
 	// Initialize strategies
-	strategyMgr := strategy.NewManager(nil, indicatorCfg)
+	strategyMgr, err := newStrategyManager(cfg, indicatorCfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid strategy regime configuration")
+	}
+
+	// Scripted strategies load before anything lists strategies; a script
+	// that fails is skipped and retried once its file changes
+	var scriptLoader *strategy.ScriptLoader
+	if cfg.Strategies.Scripts.Dir != "" && !observer {
+		scriptLoader = strategy.NewScriptLoader(cfg.Strategies.Scripts.Dir, strategyMgr)
+		if err := scriptLoader.Load(); err != nil {
+			log.Warn().Err(err).Str("dir", cfg.Strategies.Scripts.Dir).Msg("Some strategy scripts failed to load")
+		}
+	}
 	log.Info().Int("strategies", len(strategyMgr.GetStrategies())).Msg("Strategies initialized")
 
+	// Live evaluations share a bounded worker pool with backtests, ahead of them
+	evalPool := strategy.NewEvalPool(cfg.Strategies.MaxConcurrent)
+	strategyMgr.SetEvalPool(evalPool, strategy.PriorityPrimary)
+
 	// Initialize executor based on mode
-	var executor execution.Executor
-	mode := orchestrator.TradingModePaper
-	if cfg.Trading.Mode == "live" {
-		mode = orchestrator.TradingModeLive
+	newLiveExecutor := func() execution.Executor {
+		if cfg.Trading.Futures.Enabled {
+			return newFuturesExecutor(cfg)
+		}
 		liveExec, err := execution.NewLiveExecutor(&execution.ExecutorConfig{
-			Mode:      execution.ModeLive,
-			Symbol:    cfg.Trading.Symbol,
-			APIKey:    cfg.Binance.APIKey,
-			SecretKey: cfg.Binance.SecretKey,
-			Testnet:   cfg.Binance.Testnet,
+			Mode:              execution.ModeLive,
+			Symbol:            cfg.Trading.Symbol,
+			APIKey:            cfg.Binance.APIKey,
+			SecretKey:         cfg.Binance.SecretKey,
+			Testnet:           cfg.Binance.Testnet,
+			DustMinValue:      cfg.Trading.Dust.MinValue,
+			DustExcludeEquity: cfg.Trading.Dust.ExcludeFromEquity,
+			MaxRetries:        cfg.Binance.Retry.MaxRetries,
+			RetryDelay:        cfg.Binance.Retry.BaseDelay,
+			RetryMaxDelay:     cfg.Binance.Retry.MaxDelay,
 		})
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to initialize live executor")
 		}
-		executor = liveExec
-		log.Info().Msg("Live trading mode enabled")
+		return liveExec
+	}
+	if !execution.ValidClockMode(cfg.Trading.PaperClock) {
+		log.Fatal().Str("paperClock", cfg.Trading.PaperClock).Msg("Unknown paper clock mode")
+	}
+	// Local order book from the depth stream; paper market orders walk it
+	var orderBooks *marketdata.Books
+	if cfg.Trading.OrderBook.Enabled {
+		orderBooks = marketdata.NewBooks(binanceClient, cfg.Trading.OrderBook.Depth, cfg.Trading.OrderBook.MaxAge)
+	}
+	newPaperExecutor := func() execution.Executor {
+		return execution.NewPaperExecutor(paperExecutorConfig(cfg, cfg.Trading.InitialBalance, orderBooks))
+	}
+
+	var executor execution.Executor
+	var liveExecutor execution.Executor
+	mode := orchestrator.TradingModePaper
+	if cfg.Trading.Mode == "live" {
+		liveExecutor = newLiveExecutor()
+		if cfg.Trading.Arming.Enabled {
+			// Live trading starts disarmed; it is entered through the arming sequence
+			executor = newPaperExecutor()
+			log.Warn().Msg("Live trading requires arming, starting disarmed in paper mode")
+		} else {
+			mode = orchestrator.TradingModeLive
+			executor = liveExecutor
+			log.Info().Msg("Live trading mode enabled")
+		}
+
+		// Shadow paper account for live vs simulated execution reconciliation
+		if cfg.Trading.ShadowPaper {
+			balance := cfg.Trading.InitialBalance
+			if equity, err := liveExecutor.GetEquity(); err == nil && equity > 0 {
+				balance = equity
+			}
+			orch.SetShadowExecutor(execution.NewPaperExecutor(paperExecutorConfig(cfg, balance, orderBooks)))
+			log.Info().Float64("balance", balance).Msg("Shadow paper account enabled")
+		}
+	} else if observer {
+		// An idle paper account stands in for the executor; nothing trades
+		executor = newPaperExecutor()
+		log.Info().Msg("Observer mode enabled, recording market data with strategies, risk checks and orders off")
 	} else {
-		paperExec := execution.NewPaperExecutor(&execution.ExecutorConfig{
-			Mode:           execution.ModePaper,
-			Symbol:         cfg.Trading.Symbol,
-			InitialBalance: cfg.Trading.InitialBalance,
-			Commission:     cfg.Trading.Commission,
-			Slippage:       cfg.Trading.Slippage,
-		})
-		executor = paperExec
+		executor = newPaperExecutor()
 		log.Info().Float64("balance", cfg.Trading.InitialBalance).Msg("Paper trading mode enabled")
+
+		// Outage drills exercise recovery against the paper account
+		if cfg.Trading.Chaos.Enabled {
+			injector := execution.NewChaosInjector(cfg.Trading.Chaos.FillDelay, cfg.Trading.Chaos.PartialFillRatio)
+			executor.(*execution.PaperExecutor).SetChaos(injector)
+			if err := orch.SetChaosMode(injector, cfg.Trading.Chaos.Interval, cfg.Trading.Chaos.Faults); err != nil {
+				log.Fatal().Err(err).Msg("Invalid chaos configuration")
+			}
+			log.Warn().Dur("interval", cfg.Trading.Chaos.Interval).Strs("faults", cfg.Trading.Chaos.Faults).Msg("Chaos mode enabled")
+		}
+	}
+
+	// One entry signal per strategy per cooldown of primary candles
+	orch.SetSignalThrottle(cfg.Strategies.SignalCooldown, cfg.Strategies.SignalCooldowns)
+
+	// Entries signaled on outsized candles wait a candle or trade smaller
+	if err := orch.SetVolatilityThrottle(volatilityThrottles(cfg.Strategies.VolatilityThrottle)); err != nil {
+		log.Fatal().Err(err).Msg("Invalid volatility throttle configuration")
+	}
+
+	// Entries wait a candle and go ahead only if still confirmed
+	if err := orch.SetEntryConfirmation(entryConfirmation(cfg.Strategies.EntryConfirmation)); err != nil {
+		log.Fatal().Err(err).Msg("Invalid entry confirmation configuration")
+	}
+
+	// Account-scoped WebSocket updates go to the owner of the traded account
+	if err := orch.SetTradingAccount(cfg.Trading.AccountID); err != nil {
+		log.Fatal().Err(err).Msg("Invalid trading account")
+	}
+
+	// Timeframes built from the 1m stream, including custom ones
+	if err := orch.SetCandleAggregation(cfg.Trading.CandleAggregation); err != nil {
+		log.Fatal().Err(err).Msg("Invalid candle timeframes")
+	}
+
+	// Alert when strategy parameters drift from the optimizer's best
+	if err := orch.SetParamDrift(orchestrator.ParamDriftConfig{
+		Tolerance:          cfg.Strategies.ParamDrift.Tolerance,
+		ReoptimizeInterval: cfg.Strategies.ParamDrift.ReoptimizeInterval,
+		Lookback:           cfg.Strategies.ParamDrift.Lookback,
+	}); err != nil {
+		log.Fatal().Err(err).Msg("Invalid parameter drift configuration")
+	}
+
+	// Users' own trading accounts run isolated executors beside the bot's
+	if authService != nil && !observer {
+		orch.SetAccountExecutorFactory(newAccountExecutorFactory(cfg, authService, orderBooks))
+	}
+
+	// Higher-timeframe trend filters or weighs primary-timeframe entries
+	if err := orch.SetConfluencePolicy(confluencePolicy(cfg)); err != nil {
+		log.Fatal().Err(err).Msg("Invalid confluence configuration")
+	}
+
+	// Keep long-running paper tests across restarts
+	orch.SetPaperStatePersistence(cfg.Trading.PersistPaper)
+
+	// Residual balance conversion only applies to the exchange account
+	if cfg.Trading.Dust.AutoConvert && (liveExecutor != nil || cfg.Schedule.Enabled) {
+		orch.SetDustConversion(cfg.Trading.Dust.ConvertInterval)
+		log.Info().Dur("interval", cfg.Trading.Dust.ConvertInterval).Msg("Dust conversion enabled")
+	}
+
+	// Stop loss orders can be canceled on the exchange behind the bot's back
+	if cfg.Trading.StopGuard.Interval > 0 && (liveExecutor != nil || cfg.Schedule.Enabled) {
+		orch.SetStopGuard(cfg.Trading.StopGuard.Interval, cfg.Trading.StopGuard.AlertAfter)
+		log.Info().Dur("interval", cfg.Trading.StopGuard.Interval).Msg("Protective stop verification enabled")
+	}
+
+	// Warn when a stage between candle close and order runs slow
+	orch.SetLatencyBudgets(orchestrator.LatencyBudgets{
+		CandleToAnalysis: cfg.Trading.Latency.CandleToAnalysis,
+		AnalysisToRisk:   cfg.Trading.Latency.AnalysisToRisk,
+		RiskToOrder:      cfg.Trading.Latency.RiskToOrder,
+		AlertCooldown:    cfg.Trading.Latency.AlertCooldown,
+	})
+
+	// Thin markets cost entries the most slippage
+	orch.SetLiquidityFilter(orchestrator.LiquidityFilter{
+		MinQuoteVolume24h:   cfg.Trading.Liquidity.MinQuoteVolume24h,
+		MinVolumePercentile: cfg.Trading.Liquidity.MinVolumePercentile,
+		Lookback:            cfg.Trading.Liquidity.Lookback,
+	})
+
+	// Scheduled mode switches need an executor for every mode they use
+	var modeSchedule *orchestrator.ModeSchedule
+	if cfg.Schedule.Enabled {
+		var err error
+		modeSchedule, err = buildModeSchedule(cfg.Schedule)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid trading mode schedule")
+		}
+
+		orch.RegisterExecutor(mode, executor)
+		if liveExecutor == nil && scheduleUsesMode(modeSchedule, orchestrator.ScheduledModeLive) {
+			liveExecutor = newLiveExecutor()
+		}
+		if liveExecutor != nil {
+			orch.RegisterExecutor(orchestrator.TradingModeLive, liveExecutor)
+		}
+		if mode != orchestrator.TradingModePaper && scheduleUsesMode(modeSchedule, orchestrator.ScheduledModePaper) {
+			orch.RegisterExecutor(orchestrator.TradingModePaper, newPaperExecutor())
+		}
+		orch.SetModeSchedule(modeSchedule)
+		log.Info().
+			Str("timezone", cfg.Schedule.Timezone).
+			Int("windows", len(modeSchedule.Windows)).
+			Msg("Trading mode schedule enabled")
+	}
+
+	// Entering live mode requires the arming sequence
+	if cfg.Trading.Arming.Enabled && !observer {
+		orch.RegisterExecutor(mode, executor)
+		if liveExecutor != nil {
+			orch.RegisterExecutor(orchestrator.TradingModeLive, liveExecutor)
+		}
+		orch.SetArmingPolicy(cfg.Trading.Arming.Countdown)
+		log.Info().Dur("countdown", cfg.Trading.Arming.Countdown).Msg("Live trading arming enabled")
 	}
 
 	// Set orchestrator components (orch was created earlier for handler)
 	orchCfg.Mode = mode // Update mode based on config
 	orch.SetBinanceClient(binanceClient)
+	orch.SetMarketScanner(scanner.New(binanceClient, strategyMgr.GetScorer(), indicatorCfg, nil), scanConfig(cfg))
+	if cfg.Trading.Futures.Enabled {
+		// Order forms check quantities against the futures market's rules
+		orch.SetSymbolRulesSource(newFuturesClient(cfg), "futures")
+	}
 	orch.SetWebSocketClient(wsClient)
 	orch.SetDataService(dataService)
 	orch.SetExecutor(executor)
 	orch.SetRiskManager(riskManager)
 	orch.SetStrategyManager(strategyMgr)
+	orch.SetEvalPool(evalPool)
+	if scriptLoader != nil {
+		orch.SetStrategyScripts(scriptLoader, cfg.Strategies.Scripts.ReloadInterval)
+	}
+	pnlLocation, err := time.LoadLocation(cfg.Risk.PnLTimezone)
+	if err != nil {
+		log.Fatal().Err(err).Str("timezone", cfg.Risk.PnLTimezone).Msg("Invalid P&L timezone")
+	}
+	weekStart, err := orchestrator.ParseWeekday(cfg.Risk.WeekStart)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid P&L week start")
+	}
+	orch.SetPnLPeriods(pnlLocation, weekStart)
+	orch.SetSubBalance(cfg.Trading.AllocatedCapital)
 	orch.SetIndicatorManager(indicatorMgr)
+	orch.SetTapePolicy(cfg.Trading.Tape.Window, cfg.Trading.Tape.LargeTradeValue)
+	if err := orch.SetPriceArbiter(cfg.Trading.PriceFeed.TradeStaleAfter); err != nil {
+		log.Fatal().Err(err).Msg("Invalid price feed configuration")
+	}
+	if cfg.Trading.Entry.OffsetBps < 0 {
+		log.Fatal().Float64("offsetBps", cfg.Trading.Entry.OffsetBps).Msg("Entry offset cannot be negative")
+	}
+	orch.SetEntryPolicy(orchestrator.EntryPolicy{
+		MakerFirst: cfg.Trading.Entry.MakerFirst,
+		OffsetBps:  cfg.Trading.Entry.OffsetBps,
+		Timeout:    cfg.Trading.Entry.Timeout,
+	})
+	if orderBooks != nil {
+		orch.SetOrderBooks(orderBooks)
+		log.Info().Int("depth", cfg.Trading.OrderBook.Depth).Msg("Local order book enabled, paper fills walk the book")
+	}
+
+	// Semi-automatic mode: approved signals wait in the inbox for confirmation
+	if cfg.Trading.Inbox.Enabled {
+		orch.SetInboxMode(true, cfg.Trading.Inbox.Expiry)
+		log.Info().Dur("expiry", cfg.Trading.Inbox.Expiry).Msg("Trade idea inbox enabled, signals require manual approval")
+	}
+
+	// Precomputed indicator series; the API can also run the job on demand
+	indicatorInterval := time.Duration(0)
+	if cfg.IndicatorStore.Enabled {
+		indicatorInterval = cfg.IndicatorStore.Interval
+	}
+	if err := orch.SetIndicatorPrecompute(indicatorInterval, cfg.IndicatorStore.Timeframes, cfg.IndicatorStore.Indicators); err != nil {
+		log.Fatal().Err(err).Msg("Invalid indicator store configuration")
+	}
+	if cfg.IndicatorStore.Enabled {
+		log.Info().Dur("interval", indicatorInterval).Strs("timeframes", cfg.IndicatorStore.Timeframes).Msg("Indicator precompute enabled")
+	}
+
+	// External monitoring alerts the operator when heartbeats stop
+	if cfg.Heartbeat.Enabled {
+		if cfg.Heartbeat.URL == "" {
+			log.Fatal().Msg("Heartbeat enabled without a URL")
+		}
+		orch.SetHeartbeat(cfg.Heartbeat.URL, cfg.Heartbeat.Interval, cfg.Heartbeat.MaxSyncAge)
+		log.Info().Dur("interval", cfg.Heartbeat.Interval).Msg("Heartbeat to external monitoring enabled")
+	}
+
+	// Copy trading: push approved signals to followers, trade those of leaders
+	if cfg.CopyTrading.Publisher.Enabled {
+		followers := make([]orchestrator.CopyFollower, 0, len(cfg.CopyTrading.Publisher.Followers))
+		for _, f := range cfg.CopyTrading.Publisher.Followers {
+			followers = append(followers, orchestrator.CopyFollower{Name: f.Name, URL: f.URL})
+		}
+		if err := orch.SetCopyPublisher(cfg.CopyTrading.Publisher.LeaderID, cfg.CopyTrading.Publisher.Secret,
+			followers, cfg.CopyTrading.Publisher.Timeout); err != nil {
+			log.Fatal().Err(err).Msg("Invalid copy-trading publisher configuration")
+		}
+		log.Info().Str("leader", cfg.CopyTrading.Publisher.LeaderID).Int("followers", len(followers)).Msg("Publishing approved signals to copy-trading followers")
+	}
+	if cfg.CopyTrading.Follower.Enabled {
+		if len(cfg.CopyTrading.Follower.Leaders) == 0 {
+			log.Fatal().Msg("Copy-trading follower enabled without leaders")
+		}
+		if cfg.CopyTrading.Follower.MaxDrift < 0 {
+			log.Fatal().Float64("maxDrift", cfg.CopyTrading.Follower.MaxDrift).Msg("Copy-trading price drift cannot be negative")
+		}
+		if err := orch.SetCopyReceiver(cfg.CopyTrading.Follower.Leaders, cfg.CopyTrading.Follower.MaxSkew, cfg.CopyTrading.Follower.MaxDrift); err != nil {
+			log.Fatal().Err(err).Msg("Invalid copy-trading follower configuration")
+		}
+		log.Info().Int("leaders", len(cfg.CopyTrading.Follower.Leaders)).Msg("Following copy-trading leaders")
+	}
+
+	// Backtests submitted through the API run on a worker pool
+	orch.SetBacktestWorkers(cfg.Backtest.Workers, cfg.Backtest.QueueSize)
+
+	// Backtests are priced with the account's fee tier
+	fees := backtest.FlatFeeSchedule(cfg.Trading.Commission)
+	if len(cfg.Trading.Fees.Tiers) > 0 {
+		fees = &backtest.FeeSchedule{
+			Volume30d:   cfg.Trading.Fees.Volume30d,
+			BNBDiscount: cfg.Trading.Fees.BNBDiscount,
+		}
+		for _, t := range cfg.Trading.Fees.Tiers {
+			fees.Tiers = append(fees.Tiers, backtest.FeeTier{
+				Name:      t.Name,
+				MinVolume: t.MinVolume,
+				MakerBps:  t.MakerBps,
+				TakerBps:  t.TakerBps,
+			})
+		}
+		if err := fees.Validate(); err != nil {
+			log.Fatal().Err(err).Msg("Invalid fee schedule")
+		}
+	}
+	orch.SetFeeSchedule(fees)
+
+	// Split capital between strategies
+	if cfg.Allocation.Mode != "off" {
+		// Budgets are keyed by the names strategies put on their signals
+		strategies := make([]string, len(cfg.Strategies.Enabled))
+		for i, name := range cfg.Strategies.Enabled {
+			strategies[i] = strategy.CanonicalName(name)
+		}
+		weights := make(map[string]float64, len(cfg.Allocation.Weights))
+		for name, w := range cfg.Allocation.Weights {
+			weights[strategy.CanonicalName(name)] = w
+		}
+
+		orch.SetCapitalAllocator(risk.NewCapitalAllocator(&risk.AllocatorConfig{
+			Mode:              risk.AllocationMode(cfg.Allocation.Mode),
+			Weights:           weights,
+			MinWeight:         cfg.Allocation.MinWeight,
+			MaxWeight:         cfg.Allocation.MaxWeight,
+			RebalanceInterval: cfg.Allocation.RebalanceInterval,
+		}, strategies))
+	}
+
+	// Changes to config.yaml and the settings API reach the running bot
+	configMgr.Register("risk", func(c *config.Config) error {
+		riskCfg, err := newRiskConfig(c)
+		if err != nil {
+			return err
+		}
+		riskManager.UpdateConfig(riskCfg)
+		return nil
+	})
+	configMgr.Register("indicators", func(c *config.Config) error {
+		indicatorMgr.UpdateConfig(newIndicatorConfig(c))
+		strategyMgr.GetIndicators().UpdateConfig(newIndicatorConfig(c))
+		return nil
+	})
+	configMgr.Register("strategies", func(c *config.Config) error {
+		return applyStrategyConfig(c, orch, strategyMgr)
+	})
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go func() {
+		if err := configMgr.Watch(watchCtx); err != nil {
+			log.Warn().Err(err).Msg("Config file watcher stopped, changes to config.yaml need a restart")
+		}
+	}()
 
 	// Initialize API server
 	apiCfg := &api.ServerConfig{
-		Port:         cfg.API.Port,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		CORSOrigins:  cfg.API.CORSOrigins,
+		Port:            cfg.API.Port,
+		ReadTimeout:     30 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		CORSOrigins:     cfg.API.CORSOrigins,
+		CacheTTL:        cfg.API.CacheTTL,
+		WSCompression:   cfg.API.WSCompression,
+		StatusPage:      cfg.API.StatusPage.Enabled,
+		StatusRateLimit: cfg.API.StatusPage.RateLimit,
+		LogStream:       logStream,
+		BackupDir:       cfg.Database.BackupDir,
+		Config:          configMgr,
 	}
 	server := api.NewServer(apiCfg, orch, authService)
 
@@ -225,6 +607,9 @@ func main() {
 	if err := orch.Start(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to start orchestrator")
 	}
+	if authService != nil && !observer {
+		restoreAccountExecutors(orch, authService)
+	}
 
 	// Start API server in goroutine
 	go func() {
@@ -250,6 +635,13 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop users' account executors, keeping demo balances
+	for id, equity := range orch.StopAccounts() {
+		if err := authService.UpdateDemoBalance(id, equity); err != nil {
+			log.Error().Err(err).Str("account", id.String()).Msg("Failed to save demo balance")
+		}
+	}
+
 	// Stop orchestrator
 	orch.Stop()
 
@@ -265,3 +657,527 @@ func main() {
 
 	log.Info().Msg("ETH Trading Bot stopped")
 }
+
+// buildModeSchedule converts the schedule configuration into a mode schedule
+func buildModeSchedule(cfg config.ScheduleConfig) (*orchestrator.ModeSchedule, error) {
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+	}
+
+	schedule := &orchestrator.ModeSchedule{
+		Location:     loc,
+		DefaultMode:  orchestrator.ScheduledMode(cfg.DefaultMode),
+		Policy:       orchestrator.CarryPolicy(cfg.CarryPolicy),
+		NotifyBefore: cfg.NotifyBefore,
+	}
+	if err := validateScheduleValues(schedule.DefaultMode, schedule.Policy); err != nil {
+		return nil, err
+	}
+
+	for i, w := range cfg.Windows {
+		window := orchestrator.ModeWindow{
+			Name:   w.Name,
+			Mode:   orchestrator.ScheduledMode(w.Mode),
+			Policy: orchestrator.CarryPolicy(w.CarryPolicy),
+		}
+		if window.Name == "" {
+			window.Name = fmt.Sprintf("window-%d", i+1)
+		}
+		if err := validateScheduleValues(window.Mode, window.Policy); err != nil {
+			return nil, fmt.Errorf("%s: %w", window.Name, err)
+		}
+		if window.Start, err = orchestrator.ParseTimeOfDay(w.Start); err != nil {
+			return nil, fmt.Errorf("%s: %w", window.Name, err)
+		}
+		if window.End, err = orchestrator.ParseTimeOfDay(w.End); err != nil {
+			return nil, fmt.Errorf("%s: %w", window.Name, err)
+		}
+		for _, d := range w.Days {
+			day, err := orchestrator.ParseWeekday(d)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", window.Name, err)
+			}
+			window.Days = append(window.Days, day)
+		}
+		schedule.Windows = append(schedule.Windows, window)
+	}
+
+	return schedule, nil
+}
+
+// validateScheduleValues checks a scheduled mode and optional carry policy
+func validateScheduleValues(mode orchestrator.ScheduledMode, policy orchestrator.CarryPolicy) error {
+	switch mode {
+	case orchestrator.ScheduledModeLive, orchestrator.ScheduledModePaper, orchestrator.ScheduledModeHalted:
+	default:
+		return fmt.Errorf("invalid mode %q", mode)
+	}
+	switch policy {
+	case "", orchestrator.CarryPolicyFlatten, orchestrator.CarryPolicyCarry:
+	default:
+		return fmt.Errorf("invalid carry policy %q", policy)
+	}
+	return nil
+}
+
+// scheduleUsesMode reports whether any part of the schedule selects mode
+func scheduleUsesMode(schedule *orchestrator.ModeSchedule, mode orchestrator.ScheduledMode) bool {
+	if schedule.DefaultMode == mode {
+		return true
+	}
+	for _, w := range schedule.Windows {
+		if w.Mode == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSymbolOverrides replaces config file overrides with those saved
+// through the settings API
+func loadSymbolOverrides(cfg *config.Config, ds *storage.DataService) {
+	value, err := ds.LoadSettings("symbols")
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load symbol overrides")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var saved map[string]config.SymbolConfig
+	if err := json.Unmarshal([]byte(value), &saved); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted symbol overrides")
+		return
+	}
+	if cfg.Symbols == nil {
+		cfg.Symbols = make(map[string]config.SymbolConfig, len(saved))
+	}
+	for symbol, override := range saved {
+		cfg.Symbols[strings.ToUpper(symbol)] = override
+	}
+}
+
+// settingOverrides collects repeated -set flags
+type settingOverrides []string
+
+func (s *settingOverrides) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *settingOverrides) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("expected key=value")
+	}
+	*s = append(*s, value)
+	return nil
+}
+
+// newPostgresConfig builds the PostgreSQL connection settings from the
+// config
+func newPostgresConfig(cfg *config.Config) *storage.PostgresConfig {
+	return &storage.PostgresConfig{
+		Host:            cfg.Postgres.Host,
+		Port:            cfg.Postgres.Port,
+		User:            cfg.Postgres.User,
+		Password:        cfg.Postgres.Password,
+		DBName:          cfg.Postgres.DBName,
+		SSLMode:         cfg.Postgres.SSLMode,
+		MaxConns:        cfg.Postgres.MaxConns,
+		MaxIdle:         cfg.Postgres.MaxIdle,
+		ConnMaxLifetime: cfg.Postgres.ConnMaxLifetime,
+	}
+}
+
+// newBinanceClient creates the spot REST client
+func newBinanceClient(cfg *config.Config) *binance.Client {
+	return binance.NewClient(&binance.Config{
+		APIKey:    cfg.Binance.APIKey,
+		SecretKey: cfg.Binance.SecretKey,
+		Testnet:   cfg.Binance.Testnet,
+		Timeout:   30 * time.Second,
+		Retry: binance.RetryPolicy{
+			MaxRetries: cfg.Binance.Retry.MaxRetries,
+			BaseDelay:  cfg.Binance.Retry.BaseDelay,
+			MaxDelay:   cfg.Binance.Retry.MaxDelay,
+		},
+	})
+}
+
+// newFuturesClient creates the USD-M futures REST client
+func newFuturesClient(cfg *config.Config) *binance.FuturesClient {
+	return binance.NewFuturesClient(&binance.Config{
+		APIKey:    cfg.Binance.APIKey,
+		SecretKey: cfg.Binance.SecretKey,
+		Testnet:   cfg.Binance.Testnet,
+		Timeout:   30 * time.Second,
+		Retry: binance.RetryPolicy{
+			MaxRetries: cfg.Binance.Retry.MaxRetries,
+			BaseDelay:  cfg.Binance.Retry.BaseDelay,
+			MaxDelay:   cfg.Binance.Retry.MaxDelay,
+		},
+	})
+}
+
+// newIndicatorConfig builds the indicator settings from the config
+func newIndicatorConfig(cfg *config.Config) *indicators.IndicatorConfig {
+	return &indicators.IndicatorConfig{
+		RSIPeriod:  cfg.Indicators.RSIPeriod,
+		MACDFast:   cfg.Indicators.MACDFast,
+		MACDSlow:   cfg.Indicators.MACDSlow,
+		MACDSignal: cfg.Indicators.MACDSignal,
+		BBPeriod:   cfg.Indicators.BBPeriod,
+		BBStdDev:   cfg.Indicators.BBStdDev,
+		ADXPeriod:  cfg.Indicators.ADXPeriod,
+		ATRPeriod:  cfg.Indicators.ATRPeriod,
+
+		KeltnerPeriod:        cfg.Indicators.KeltnerPeriod,
+		KeltnerMultiplier:    cfg.Indicators.KeltnerMultiplier,
+		SuperTrendPeriod:     cfg.Indicators.SuperTrendPeriod,
+		SuperTrendMultiplier: cfg.Indicators.SuperTrendMultiplier,
+		IchimokuTenkan:       cfg.Indicators.IchimokuTenkan,
+		IchimokuKijun:        cfg.Indicators.IchimokuKijun,
+		IchimokuSenkouB:      cfg.Indicators.IchimokuSenkouB,
+	}
+}
+
+// newRiskConfig builds the risk limits and sizing from the config
+func newRiskConfig(cfg *config.Config) (*risk.RiskConfig, error) {
+	riskCfg := &risk.RiskConfig{
+		MaxPositionSize:         cfg.Risk.MaxPositionSize,
+		MaxPositionValue:        10000, // $10,000 max position value
+		DefaultPositionSize:     0.05,  // 5% of equity
+		MaxRiskPerTrade:         cfg.Risk.MaxRiskPerTrade,
+		MinRiskRewardRatio:      cfg.Risk.MinRiskRewardRatio,
+		RequireStopLoss:         cfg.Risk.RequireStopLoss,
+		MaxDailyLoss:            cfg.Risk.MaxDailyLoss,
+		MaxWeeklyLoss:           cfg.Risk.MaxWeeklyLoss,
+		MaxTotalDrawdown:        cfg.Risk.MaxDrawdown,
+		HighWaterMarkMode:       risk.HighWaterMarkMode(cfg.Risk.HighWaterMarkMode),
+		MaxOpenPositions:        cfg.Risk.MaxOpenPositions,
+		MaxPositionsPerSymbol:   1,
+		MaxLeverage:             cfg.Risk.MaxLeverage,
+		EnableCircuitBreaker:    cfg.Risk.EnableCircuitBreaker,
+		ConsecutiveLossLimit:    cfg.Risk.ConsecutiveLossLimit,
+		HaltDuration:            time.Duration(cfg.Risk.HaltDurationHours) * time.Hour,
+		AdjustForVolatility:     true,
+		HighVolatilityReduction: 0.5,
+		MaxCorrelation:          0.7,
+		TradingHoursOnly:        false,
+		TradingStartHour:        0,
+		TradingEndHour:          24,
+		AvoidWeekends:           false,
+	}
+	// Paper accounts start from a known balance; live accounts seed the
+	// high-water mark from the first equity reading
+	if cfg.Trading.Mode != "live" {
+		riskCfg.InitialCapital = cfg.Trading.InitialBalance
+	}
+	stopLossPolicy, stopLossPolicies, err := parseStopLossPolicies(cfg.Risk.StopLoss)
+	if err != nil {
+		return nil, fmt.Errorf("stop-loss policy: %w", err)
+	}
+	riskCfg.StopLossPolicy = stopLossPolicy
+	riskCfg.StopLossPolicies = stopLossPolicies
+	riskCfg.StopLossATRMultiplier = cfg.Risk.StopLoss.ATRMultiplier
+	sizingModel, sizingModels, err := parseSizingModels(cfg.Risk.Sizing)
+	if err != nil {
+		return nil, fmt.Errorf("sizing model: %w", err)
+	}
+	riskCfg.SizingModel = sizingModel
+	riskCfg.SizingModels = sizingModels
+	riskCfg.KellyFraction = cfg.Risk.Sizing.KellyFraction
+	riskCfg.KellyWindow = cfg.Risk.Sizing.KellyWindow
+	riskCfg.KellyMinTrades = cfg.Risk.Sizing.KellyMinTrades
+	riskCfg.TargetVolatility = cfg.Risk.Sizing.TargetVolatility
+	riskCfg.FixedNotional = cfg.Risk.Sizing.FixedNotional
+	riskCfg.Compounding = risk.CompoundingMode(cfg.Risk.Sizing.Compounding)
+	if !risk.ValidCompoundingMode(riskCfg.Compounding) {
+		return nil, fmt.Errorf("unknown compounding mode %q", cfg.Risk.Sizing.Compounding)
+	}
+	if cfg.Risk.Sizing.CompoundingStep < 0 {
+		return nil, fmt.Errorf("compoundingStep must not be negative")
+	}
+	riskCfg.CompoundingStep = cfg.Risk.Sizing.CompoundingStep
+	riskCfg.CompoundingBase = cfg.Risk.Sizing.BaseCapital
+	if riskCfg.CompoundingBase <= 0 {
+		riskCfg.CompoundingBase = cfg.Trading.InitialBalance
+	}
+	return riskCfg, nil
+}
+
+// paperExecutorConfig returns the configuration of a paper account
+// starting from balance, filling market orders against books when set
+func paperExecutorConfig(cfg *config.Config, balance float64, books *marketdata.Books) *execution.ExecutorConfig {
+	execCfg := &execution.ExecutorConfig{
+		Mode:            execution.ModePaper,
+		Symbol:          cfg.Trading.Symbol,
+		InitialBalance:  balance,
+		Commission:      cfg.Trading.Commission,
+		MakerCommission: cfg.Trading.MakerCommission,
+		Slippage:        cfg.Trading.Slippage,
+	}
+	if books != nil {
+		execCfg.Book = books
+	}
+	if cfg.Trading.PaperClock == execution.ClockEvent {
+		execCfg.Clock = execution.NewEventClock(time.Time{})
+		execCfg.SequentialIDs = true
+	}
+	return execCfg
+}
+
+// newStrategyManager creates the strategy manager with the configured
+// regime blacklist and routes
+func newStrategyManager(cfg *config.Config, indicatorCfg *indicators.IndicatorConfig) (*strategy.Manager, error) {
+	strategyMgr := strategy.NewManager(nil, indicatorCfg)
+	if len(cfg.Strategies.DisallowedRegimes) > 0 {
+		disallowed, err := parseDisallowedRegimes(cfg.Strategies.DisallowedRegimes)
+		if err != nil {
+			return nil, err
+		}
+		strategyMgr.GetScorer().SetDisallowedRegimes(disallowed)
+	}
+	if cfg.Strategies.RegimeRouting.Enabled {
+		routes, err := parseRegimeRoutes(cfg.Strategies.RegimeRouting.Routes)
+		if err != nil {
+			return nil, err
+		}
+		strategyMgr.GetScorer().SetRegimeRoutes(routes)
+	}
+	return strategyMgr, nil
+}
+
+// applyStrategyConfig pushes the strategy settings that apply without a
+// restart: regime filters and routes, throttles, entry confirmation and
+// confluence
+func applyStrategyConfig(cfg *config.Config, orch *orchestrator.Orchestrator, strategyMgr *strategy.Manager) error {
+	disallowed, err := parseDisallowedRegimes(cfg.Strategies.DisallowedRegimes)
+	if err != nil {
+		return err
+	}
+	var routes map[strategy.MarketRegime][]string
+	if cfg.Strategies.RegimeRouting.Enabled {
+		if routes, err = parseRegimeRoutes(cfg.Strategies.RegimeRouting.Routes); err != nil {
+			return err
+		}
+	}
+	if err := orch.SetVolatilityThrottle(volatilityThrottles(cfg.Strategies.VolatilityThrottle)); err != nil {
+		return err
+	}
+	if err := orch.SetEntryConfirmation(entryConfirmation(cfg.Strategies.EntryConfirmation)); err != nil {
+		return err
+	}
+	if err := orch.SetConfluencePolicy(confluencePolicy(cfg)); err != nil {
+		return err
+	}
+	orch.SetSignalThrottle(cfg.Strategies.SignalCooldown, cfg.Strategies.SignalCooldowns)
+	strategyMgr.GetScorer().SetDisallowedRegimes(disallowed)
+	strategyMgr.GetScorer().SetRegimeRoutes(routes)
+	return nil
+}
+
+// confluencePolicy converts the configured higher-timeframe confluence
+func confluencePolicy(cfg *config.Config) orchestrator.ConfluencePolicy {
+	return orchestrator.ConfluencePolicy{
+		Mode:          cfg.Strategies.Confluence.Mode,
+		Modes:         cfg.Strategies.Confluence.Modes,
+		Timeframes:    cfg.Strategies.Confluence.Timeframes,
+		MinStrength:   cfg.Strategies.Confluence.MinStrength,
+		Weight:        cfg.Strategies.Confluence.Weight,
+		MinConfidence: cfg.Strategies.Confluence.MinConfidence,
+	}
+}
+
+// volatilityThrottles converts the configured volatility spike rules
+func volatilityThrottles(cfg config.VolatilityThrottleConfig) strategy.VolatilityThrottles {
+	rule := func(r config.VolatilityThrottleRule) strategy.VolatilityThrottle {
+		return strategy.VolatilityThrottle{
+			RangeATR:   r.RangeATR,
+			Action:     strategy.VolatilityAction(r.Action),
+			SizeFactor: r.SizeFactor,
+			Confirm:    r.Confirm,
+		}
+	}
+	throttles := strategy.VolatilityThrottles{
+		Default:    rule(cfg.VolatilityThrottleRule),
+		Strategies: make(map[string]strategy.VolatilityThrottle, len(cfg.Strategies)),
+	}
+	for name, r := range cfg.Strategies {
+		throttles.Strategies[name] = rule(r)
+	}
+	return throttles
+}
+
+// entryConfirmation converts the configured entry confirmation
+func entryConfirmation(cfg config.EntryConfirmationConfig) strategy.EntryConfirmation {
+	conditions := make([]strategy.ConfirmationCondition, len(cfg.Conditions))
+	for i, c := range cfg.Conditions {
+		conditions[i] = strategy.ConfirmationCondition(c)
+	}
+	return strategy.EntryConfirmation{
+		Interval:   cfg.Interval,
+		Conditions: conditions,
+		Strategies: cfg.Strategies,
+	}
+}
+
+// parseRegimeRoutes converts the configured per-regime strategy names,
+// falling back to the built-in routes when none are configured
+func parseRegimeRoutes(cfg map[string][]string) (map[strategy.MarketRegime][]string, error) {
+	if len(cfg) == 0 {
+		return strategy.DefaultRegimeRoutes(), nil
+	}
+	routes := make(map[strategy.MarketRegime][]string, len(cfg))
+	for r, names := range cfg {
+		regime, err := strategy.ParseMarketRegime(r)
+		if err != nil {
+			return nil, fmt.Errorf("regime route: %w", err)
+		}
+		routed := make([]string, 0, len(names))
+		for _, name := range names {
+			routed = append(routed, strategy.CanonicalName(name))
+		}
+		routes[regime] = routed
+	}
+	return routes, nil
+}
+
+// parseDisallowedRegimes converts the configured per-strategy regime names
+func parseDisallowedRegimes(cfg map[string][]string) (map[string][]strategy.MarketRegime, error) {
+	disallowed := make(map[string][]strategy.MarketRegime, len(cfg))
+	for name, regimes := range cfg {
+		for _, r := range regimes {
+			regime, err := strategy.ParseMarketRegime(r)
+			if err != nil {
+				return nil, fmt.Errorf("strategy %s: %w", name, err)
+			}
+			key := strategy.CanonicalName(name)
+			disallowed[key] = append(disallowed[key], regime)
+		}
+	}
+	return disallowed, nil
+}
+
+// parseStopLossPolicies validates the default and per-strategy stop-loss
+// policies, keying overrides by canonical strategy name
+func parseStopLossPolicies(cfg config.StopLossConfig) (risk.StopLossPolicy, map[string]risk.StopLossPolicy, error) {
+	policy := risk.StopLossPolicy(cfg.Policy)
+	if policy != "" && !risk.ValidStopLossPolicy(policy) {
+		return "", nil, fmt.Errorf("unknown stop-loss policy %q", cfg.Policy)
+	}
+
+	policies := make(map[string]risk.StopLossPolicy, len(cfg.Strategies))
+	for name, p := range cfg.Strategies {
+		if !risk.ValidStopLossPolicy(risk.StopLossPolicy(p)) {
+			return "", nil, fmt.Errorf("strategy %s: unknown stop-loss policy %q", name, p)
+		}
+		policies[strategy.CanonicalName(name)] = risk.StopLossPolicy(p)
+	}
+	return policy, policies, nil
+}
+
+// parseSizingModels validates the default and per-strategy sizing models,
+// keying overrides by canonical strategy name
+func parseSizingModels(cfg config.SizingConfig) (risk.SizingModel, map[string]risk.SizingModel, error) {
+	model := risk.SizingModel(cfg.Model)
+	if model != "" && !risk.ValidSizingModel(model) {
+		return "", nil, fmt.Errorf("unknown sizing model %q", cfg.Model)
+	}
+
+	models := make(map[string]risk.SizingModel, len(cfg.Strategies))
+	for name, m := range cfg.Strategies {
+		if !risk.ValidSizingModel(risk.SizingModel(m)) {
+			return "", nil, fmt.Errorf("strategy %s: unknown sizing model %q", name, m)
+		}
+		models[strategy.CanonicalName(name)] = risk.SizingModel(m)
+	}
+	return model, models, nil
+}
+
+// newFuturesExecutor creates the USD-M futures executor used for live
+// trading, with leverage capped by the risk limit
+func newFuturesExecutor(cfg *config.Config) execution.Executor {
+	leverage := cfg.Trading.Futures.Leverage
+	if maxLeverage := int(math.Max(cfg.Risk.MaxLeverage, 1)); leverage > maxLeverage {
+		log.Warn().
+			Int("leverage", leverage).
+			Int("maxLeverage", maxLeverage).
+			Msg("Futures leverage exceeds risk.maxLeverage, capping")
+		leverage = maxLeverage
+	}
+
+	futuresExec, err := execution.NewFuturesExecutor(&execution.ExecutorConfig{
+		Mode:           execution.ModeLive,
+		Symbol:         cfg.Trading.Symbol,
+		Commission:     cfg.Trading.Commission,
+		APIKey:         cfg.Binance.APIKey,
+		SecretKey:      cfg.Binance.SecretKey,
+		Testnet:        cfg.Binance.Testnet,
+		Leverage:       leverage,
+		MarginType:     cfg.Trading.Futures.MarginType,
+		HedgeMode:      cfg.Trading.Futures.HedgeMode,
+		MaxFundingRate: cfg.Trading.Futures.MaxFundingRate,
+		MaxRetries:     cfg.Binance.Retry.MaxRetries,
+		RetryDelay:     cfg.Binance.Retry.BaseDelay,
+		RetryMaxDelay:  cfg.Binance.Retry.MaxDelay,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize futures executor")
+	}
+	return futuresExec
+}
+
+// newAccountExecutorFactory builds the executors of users' trading
+// accounts: a paper account starting from the demo balance, or a live one
+// on the user's own Binance keys
+func newAccountExecutorFactory(cfg *config.Config, authService *auth.Service, books *marketdata.Books) orchestrator.AccountExecutorFactory {
+	return func(account *models.TradingAccount) (execution.Executor, error) {
+		if account.TradingMode != models.TradingModeLive {
+			balance := cfg.Trading.InitialBalance
+			if account.DemoCurrentBalance != nil && *account.DemoCurrentBalance > 0 {
+				balance = *account.DemoCurrentBalance
+			} else if account.DemoInitialCapital != nil && *account.DemoInitialCapital > 0 {
+				balance = *account.DemoInitialCapital
+			}
+			return execution.NewPaperExecutor(paperExecutorConfig(cfg, balance, books)), nil
+		}
+
+		if account.BinanceAPIKey == nil || *account.BinanceAPIKey == "" {
+			return nil, models.ErrBinanceKeysNotLinked
+		}
+		secretKey, err := authService.BinanceSecret(account)
+		if err != nil {
+			return nil, err
+		}
+		return execution.NewLiveExecutor(&execution.ExecutorConfig{
+			Mode:              execution.ModeLive,
+			Symbol:            account.TradingSymbol,
+			APIKey:            *account.BinanceAPIKey,
+			SecretKey:         secretKey,
+			Testnet:           account.BinanceTestnet,
+			DustMinValue:      cfg.Trading.Dust.MinValue,
+			DustExcludeEquity: cfg.Trading.Dust.ExcludeFromEquity,
+			MaxRetries:        cfg.Binance.Retry.MaxRetries,
+			RetryDelay:        cfg.Binance.Retry.BaseDelay,
+			RetryMaxDelay:     cfg.Binance.Retry.MaxDelay,
+		})
+	}
+}
+
+// restoreAccountExecutors restarts the executors of accounts that were
+// running when the bot last stopped. An account that fails to start is
+// logged and left for its owner to restart.
+func restoreAccountExecutors(orch *orchestrator.Orchestrator, authService *auth.Service) {
+	accounts, err := authService.GetExecutorAccounts()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load account executors")
+		return
+	}
+	for _, account := range accounts {
+		if err := orch.StartAccount(account); err != nil {
+			log.Error().Err(err).Str("account", account.ID.String()).Msg("Failed to restore account executor")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/eth-trading/internal/config"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// runMigrateData implements `bot migrate-data`: copy candles, trades and
+// positions from the SQLite database into PostgreSQL before switching
+// database.tradingStore to postgres. Returns the process exit code.
+func runMigrateData(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("migrate-data", flag.ContinueOnError)
+	sqlitePath := fs.String("sqlite", cfg.Database.Path, "SQLite database to copy from")
+	batch := fs.Int("batch", 1000, "rows copied per transaction")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if _, err := os.Stat(*sqlitePath); err != nil {
+		log.Error().Err(err).Str("path", *sqlitePath).Msg("SQLite database not found")
+		return 1
+	}
+	src, err := storage.NewSQLiteDB(*sqlitePath)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open SQLite database")
+		return 1
+	}
+	defer src.Close()
+
+	dst, err := storage.NewPostgresDB(newPostgresConfig(cfg))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to connect to PostgreSQL")
+		return 1
+	}
+	defer dst.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := storage.CopyTradingData(ctx, src, dst, *batch)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TABLE\tCOPIED\tALREADY THERE")
+		for _, t := range report {
+			fmt.Fprintf(w, "%s\t%d\t%d\n", t.Table, t.Copied, t.Skipped)
+		}
+		w.Flush()
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Copy failed; rows copied so far are kept and skipped when run again")
+		return 1
+	}
+
+	if cfg.Database.TradingStore != storage.StorePostgres {
+		log.Info().Msg("Set database.tradingStore to postgres to use the copied data")
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/eth-trading/internal/config"
+	"github.com/eth-trading/internal/scanner"
+	"github.com/rs/zerolog/log"
+)
+
+// scanConfig converts the configured scan filters
+func scanConfig(cfg *config.Config) scanner.Config {
+	return scanner.Config{
+		QuoteAsset:     cfg.Scan.QuoteAsset,
+		MinQuoteVolume: cfg.Scan.MinQuoteVolume,
+		MinATRPercent:  cfg.Scan.MinATRPercent,
+		MaxATRPercent:  cfg.Scan.MaxATRPercent,
+		Timeframe:      cfg.Scan.Timeframe,
+		Candles:        cfg.Scan.Candles,
+		MaxCandidates:  cfg.Scan.MaxCandidates,
+		Top:            cfg.Scan.Top,
+	}
+}
+
+// runScan implements `bot scan`: rank candidate symbols and print them.
+// Returns the process exit code.
+func runScan(cfg *config.Config, args []string) int {
+	scanCfg := scanConfig(cfg)
+
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.IntVar(&scanCfg.Top, "top", scanCfg.Top, "number of symbols to show (0 = all)")
+	fs.StringVar(&scanCfg.Timeframe, "timeframe", scanCfg.Timeframe, "candles used for regime detection")
+	fs.Float64Var(&scanCfg.MinQuoteVolume, "min-volume", scanCfg.MinQuoteVolume, "minimum 24h quote volume")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	indicatorCfg := newIndicatorConfig(cfg)
+	strategyMgr, err := newStrategyManager(cfg, indicatorCfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid strategy regime blacklist")
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := scanner.New(newBinanceClient(cfg), strategyMgr.GetScorer(), indicatorCfg, nil)
+	report, err := s.Scan(ctx, scanCfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Market scan failed")
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("Scanned %d of %d %s pairs on %s candles\n\n",
+		report.Candidates, report.Pairs, scanCfg.QuoteAsset, report.Timeframe)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tSYMBOL\tPRICE\t24H VOL (M)\t24H %\tATR %\tREGIME\tCONF\tBEST STRATEGY\tSCORE")
+	for _, r := range report.Results {
+		fmt.Fprintf(w, "%d\t%s\t%.6g\t%.1f\t%+.2f\t%.2f\t%s\t%.2f\t%s\t%.2f\n",
+			r.Rank, r.Symbol, r.Price, r.QuoteVolume/1e6, r.PriceChange, r.ATRPercent,
+			r.Regime, r.RegimeConfidence, r.BestStrategy, r.Score)
+	}
+	w.Flush()
+
+	if len(report.Rejected) > 0 {
+		reasons := make([]string, 0, len(report.Rejected))
+		for _, r := range report.Rejected {
+			reasons = append(reasons, fmt.Sprintf("%s (%s)", r.Symbol, r.Reason))
+		}
+		fmt.Printf("\nFiltered out: %s\n", strings.Join(reasons, ", "))
+	}
+	return 0
+}
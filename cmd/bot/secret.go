@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/eth-trading/internal/config"
+	"github.com/eth-trading/internal/secrets"
+	"github.com/rs/zerolog/log"
+)
+
+// newKeyCipher returns the cipher users' Binance secrets are stored with:
+// auth.keyEncryptionKey when set, otherwise the master key. Nil when
+// neither is set.
+func newKeyCipher(cfg *config.Config) (*secrets.Cipher, error) {
+	if cfg.Auth.KeyEncryptionKey != "" {
+		return secrets.NewCipher(cfg.Auth.KeyEncryptionKey)
+	}
+	return secrets.MasterCipher(cfg.Secrets.MasterKeyEnv)
+}
+
+// runSecret implements `bot secret encrypt`: seal a value read from stdin
+// with the master key and print the enc: reference to paste into
+// config.yaml. Returns the process exit code.
+func runSecret(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "encrypt" {
+		fmt.Fprintln(os.Stderr, "usage: bot secret encrypt < value")
+		return 2
+	}
+	fs := flag.NewFlagSet("secret encrypt", flag.ContinueOnError)
+	multiline := fs.Bool("multiline", false, "encrypt all of stdin instead of its first line")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	env := cfg.Secrets.MasterKeyEnv
+	if env == "" {
+		env = secrets.DefaultMasterKeyEnv
+	}
+	c, err := secrets.MasterCipher(env)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid master key")
+		return 1
+	}
+	if c == nil {
+		log.Error().Str("env", env).Msg("Master key not set; generate one with: openssl rand -base64 32")
+		return 1
+	}
+
+	var value string
+	if *multiline {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read value")
+			return 1
+		}
+		value = string(data)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			log.Error().Err(err).Msg("Failed to read value")
+			return 1
+		}
+		value = strings.TrimRight(line, "\r\n")
+	}
+	if value == "" {
+		log.Error().Msg("Nothing to encrypt; pass the value on stdin")
+		return 1
+	}
+
+	encrypted, err := c.Encrypt(value)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encrypt value")
+		return 1
+	}
+	fmt.Println(secrets.Encrypted(encrypted))
+	return 0
+}
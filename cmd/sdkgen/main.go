@@ -0,0 +1,114 @@
+// Command sdkgen generates the Go client in pkg/client and the TypeScript
+// client in web/src from internal/api/spec. With -check it fails when a
+// generated file is stale instead of writing it, for CI.
+//
+// Either way it first compares the spec with the routes the API server
+// registers, and fails on routes missing from one or the other.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eth-trading/internal/api"
+	"github.com/eth-trading/internal/api/spec"
+	"github.com/eth-trading/internal/sdkgen"
+)
+
+func main() {
+	root := flag.String("root", ".", "module root")
+	check := flag.Bool("check", false, "fail when generated files are stale instead of writing them")
+	flag.Parse()
+
+	if err := run(*root, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "sdkgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(root string, check bool) error {
+	if err := checkRoutes(); err != nil {
+		return err
+	}
+	m, err := sdkgen.NewModel(root)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		path   string
+		render func() ([]byte, error)
+	}{
+		{"pkg/client/types_gen.go", m.GoTypes},
+		{"pkg/client/endpoints_gen.go", m.GoEndpoints},
+		{"pkg/client/messages_gen.go", m.GoMessages},
+		{"web/src/types/api.gen.ts", func() ([]byte, error) { return m.TSTypes(), nil }},
+		{"web/src/services/client.gen.ts", func() ([]byte, error) { return m.TSClient(), nil }},
+	}
+
+	var stale []string
+	for _, f := range files {
+		data, err := f.render()
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		path := filepath.Join(root, filepath.FromSlash(f.path))
+		if check {
+			current, _ := os.ReadFile(path)
+			if !bytes.Equal(current, data) {
+				stale = append(stale, f.path)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("stale, run make sdk: %s", strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// checkRoutes compares the spec's endpoints with the server's routes
+func checkRoutes() error {
+	registered := make(map[string]bool)
+	for _, r := range api.NewServer(nil, nil, nil).GetEcho().Routes() {
+		if !strings.HasPrefix(r.Path, spec.Prefix+"/") || r.Method == "echo_route_not_found" {
+			continue
+		}
+		registered[r.Method+" "+strings.TrimPrefix(r.Path, spec.Prefix)] = true
+	}
+
+	var problems []string
+	specified := make(map[string]bool)
+	for _, e := range spec.Endpoints {
+		key := e.Method + " " + e.Path
+		if specified[key] {
+			problems = append(problems, "route specified twice: "+key)
+		}
+		specified[key] = true
+		if !registered[key] {
+			problems = append(problems, "route not served: "+key)
+		}
+		if e.Method != http.MethodGet && e.Method != http.MethodPost && e.Method != http.MethodPut && e.Method != http.MethodDelete {
+			problems = append(problems, "unsupported method: "+key)
+		}
+	}
+	for key := range registered {
+		if !specified[key] {
+			problems = append(problems, "route missing from internal/api/spec: "+key)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("spec does not match the server:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
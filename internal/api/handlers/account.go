@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/eth-trading/internal/api/middleware"
+	"github.com/eth-trading/internal/auth"
+	"github.com/eth-trading/internal/models"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// AccountHandler manages users' own trading accounts and the isolated
+// executors that trade them
+type AccountHandler struct {
+	authService  *auth.Service
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(authService *auth.Service, orch *orchestrator.Orchestrator) *AccountHandler {
+	return &AccountHandler{
+		authService:  authService,
+		orchestrator: orch,
+	}
+}
+
+// AccountResponse is a trading account and, while it runs, its executor
+type AccountResponse struct {
+	*models.TradingAccountResponse
+	Executor *orchestrator.AccountStatus `json:"executor,omitempty"`
+}
+
+// ListAccounts returns the caller's trading accounts
+// GET /api/v1/accounts
+func (h *AccountHandler) ListAccounts(c echo.Context) error {
+	if h.authService == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Accounts not available"})
+	}
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := h.authService.GetTradingAccounts(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	resp := make([]AccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		resp = append(resp, h.accountResponse(account))
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"accounts": resp,
+		"count":    len(resp),
+	})
+}
+
+// CreateAccount adds a trading account for the caller. Accounts created
+// with Binance keys need the caller's two-factor code.
+// POST /api/v1/accounts
+func (h *AccountHandler) CreateAccount(c echo.Context) error {
+	if h.authService == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Accounts not available"})
+	}
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.TradingAccountCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if req.TradingSymbol == "" {
+		req.TradingSymbol = "ETHUSDT"
+	}
+	if req.BinanceAPIKey != nil || req.BinanceSecretKey != nil {
+		if err := middleware.VerifyTwoFactor(c, h.authService); err != nil {
+			return err
+		}
+	}
+
+	account, err := h.authService.CreateTradingAccount(userID, &req)
+	if err != nil {
+		return accountError(c, err)
+	}
+	return c.JSON(http.StatusCreated, h.accountResponse(account))
+}
+
+// GetAccount returns one of the caller's trading accounts
+// GET /api/v1/accounts/:id
+func (h *AccountHandler) GetAccount(c echo.Context) error {
+	account, err := h.ownedAccount(c)
+	if err != nil {
+		return accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.accountResponse(account))
+}
+
+// UpdateAccount changes a trading account's settings. A running executor
+// picks them up when restarted.
+// PUT /api/v1/accounts/:id
+func (h *AccountHandler) UpdateAccount(c echo.Context) error {
+	if h.authService == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Accounts not available"})
+	}
+	userID, accountID, err := accountParams(c)
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	var req models.TradingAccountUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	account, err := h.authService.UpdateTradingAccount(userID, accountID, &req)
+	if err != nil {
+		return accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.accountResponse(account))
+}
+
+// LinkKeys stores the caller's Binance API keys on a live account, the
+// secret encrypted
+// PUT /api/v1/accounts/:id/keys
+func (h *AccountHandler) LinkKeys(c echo.Context) error {
+	if h.authService == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Accounts not available"})
+	}
+	userID, accountID, err := accountParams(c)
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	var req models.BinanceKeysRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	account, err := h.authService.LinkBinanceKeys(userID, accountID, &req)
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	log.Info().
+		Str("user_id", userID.String()).
+		Str("account_id", accountID.String()).
+		Bool("testnet", req.Testnet).
+		Msg("Binance keys linked to trading account")
+	return c.JSON(http.StatusOK, h.accountResponse(account))
+}
+
+// StartAccount starts an account's executor and keeps it running across
+// restarts of the bot. Live accounts need the caller's two-factor code.
+// POST /api/v1/accounts/:id/start
+func (h *AccountHandler) StartAccount(c echo.Context) error {
+	if h.authService == nil || h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Account executors not available"})
+	}
+	account, err := h.ownedAccount(c)
+	if err != nil {
+		return accountError(c, err)
+	}
+	if !account.IsActive {
+		return accountError(c, models.ErrAccountInactive)
+	}
+	if account.TradingMode == models.TradingModeLive {
+		if err := middleware.VerifyTwoFactor(c, h.authService); err != nil {
+			return err
+		}
+	}
+
+	if err := h.orchestrator.StartAccount(account); err != nil {
+		return accountError(c, err)
+	}
+	enabled, err := h.authService.SetExecutorEnabled(account.UserID, account.ID, true)
+	if err != nil {
+		h.orchestrator.StopAccount(account.ID)
+		return accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.accountResponse(enabled))
+}
+
+// StopAccount stops an account's executor. Paper positions are closed and
+// a demo account keeps the resulting balance; live positions stay open.
+// POST /api/v1/accounts/:id/stop
+func (h *AccountHandler) StopAccount(c echo.Context) error {
+	if h.authService == nil || h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Account executors not available"})
+	}
+	account, err := h.ownedAccount(c)
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	equity, err := h.orchestrator.StopAccount(account.ID)
+	if err != nil && !(errors.Is(err, orchestrator.ErrAccountNotRunning) && account.ExecutorEnabled) {
+		return accountError(c, err)
+	}
+	if err == nil && account.AccountType == models.AccountTypeDemo && account.TradingMode == models.TradingModePaper && equity > 0 {
+		if err := h.authService.UpdateDemoBalance(account.ID, equity); err != nil {
+			log.Error().Err(err).Str("account_id", account.ID.String()).Msg("Failed to save demo balance")
+		}
+	}
+	disabled, err := h.authService.SetExecutorEnabled(account.UserID, account.ID, false)
+	if err != nil {
+		return accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.accountResponse(disabled))
+}
+
+// ownedAccount loads the account named by :id, checking the caller owns it
+func (h *AccountHandler) ownedAccount(c echo.Context) (*models.TradingAccount, error) {
+	if h.authService == nil {
+		return nil, errAccountsUnavailable
+	}
+	userID, accountID, err := accountParams(c)
+	if err != nil {
+		return nil, err
+	}
+	return h.authService.GetTradingAccount(userID, accountID)
+}
+
+// accountResponse adds the running executor's state to an account
+func (h *AccountHandler) accountResponse(account *models.TradingAccount) AccountResponse {
+	resp := AccountResponse{TradingAccountResponse: account.ToResponse()}
+	if h.orchestrator != nil {
+		if status, err := h.orchestrator.GetAccountStatus(account.ID); err == nil {
+			resp.Executor = &status
+		}
+	}
+	return resp
+}
+
+// errAccountsUnavailable is answered when running without the auth database
+var errAccountsUnavailable = errors.New("accounts not available")
+
+// accountParams returns the caller's user ID and the account ID in :id
+func accountParams(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, models.ErrInvalidInput
+	}
+	return userID, accountID, nil
+}
+
+// accountError answers a failed account request
+func accountError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return err
+	case errors.Is(err, errAccountsUnavailable), errors.Is(err, models.ErrKeyEncryptionDisabled),
+		errors.Is(err, orchestrator.ErrAccountExecutorsDisabled):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrAccountNotFound), errors.Is(err, models.ErrUnauthorizedAccount):
+		// Other users' accounts are indistinguishable from missing ones
+		return c.JSON(http.StatusNotFound, map[string]string{"error": models.ErrAccountNotFound.Error()})
+	case errors.Is(err, models.ErrAccountAlreadyExists), errors.Is(err, orchestrator.ErrAccountRunning),
+		errors.Is(err, orchestrator.ErrAccountNotRunning):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidInput), errors.Is(err, models.ErrAccountInactive),
+		errors.Is(err, models.ErrDemoCapitalRequired), errors.Is(err, models.ErrDemoCapitalOutOfRange),
+		errors.Is(err, models.ErrBinanceAPIKeyRequired), errors.Is(err, models.ErrBinanceSecretRequired),
+		errors.Is(err, models.ErrBinanceKeysNotLinked), errors.Is(err, orchestrator.ErrAccountIsBotAccount),
+		errors.Is(err, orchestrator.ErrAccountSymbol):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	log.Error().Err(err).Msg("Trading account request failed")
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/risk"
+	"github.com/labstack/echo/v4"
+)
+
+// AllocationHandler handles capital allocation endpoints
+type AllocationHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewAllocationHandler creates a new allocation handler
+func NewAllocationHandler(orch *orchestrator.Orchestrator) *AllocationHandler {
+	return &AllocationHandler{orchestrator: orch}
+}
+
+// AllocationResponse represents the current capital allocation
+type AllocationResponse struct {
+	Mode              string                    `json:"mode"`
+	RebalanceInterval string                    `json:"rebalanceInterval"`
+	Allocations       []risk.StrategyAllocation `json:"allocations"`
+	Timestamp         time.Time                 `json:"timestamp"`
+}
+
+// GetAllocations returns each strategy's capital, usage and buying power
+func (h *AllocationHandler) GetAllocations(c echo.Context) error {
+	allocator := h.allocator()
+	if allocator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Capital allocator not available"})
+	}
+
+	cfg := allocator.GetConfig()
+	allocations := h.orchestrator.GetStrategyAllocations()
+	if allocations == nil {
+		allocations = []risk.StrategyAllocation{}
+	}
+
+	return c.JSON(http.StatusOK, AllocationResponse{
+		Mode:              string(cfg.Mode),
+		RebalanceInterval: cfg.RebalanceInterval.String(),
+		Allocations:       allocations,
+		Timestamp:         time.Now(),
+	})
+}
+
+// GetAllocationHistory returns recent reallocations, newest first
+func (h *AllocationHandler) GetAllocationHistory(c echo.Context) error {
+	allocator := h.allocator()
+	if allocator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Capital allocator not available"})
+	}
+
+	limit := 50
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	return c.JSON(http.StatusOK, allocator.GetHistory(limit))
+}
+
+// Rebalance triggers an immediate capital reallocation
+func (h *AllocationHandler) Rebalance(c echo.Context) error {
+	if h.allocator() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Capital allocator not available"})
+	}
+
+	snapshot, err := h.orchestrator.RebalanceCapital("manual by " + requestActor(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, snapshot)
+}
+
+// allocator returns the orchestrator's capital allocator, if configured
+func (h *AllocationHandler) allocator() *risk.CapitalAllocator {
+	if h.orchestrator == nil {
+		return nil
+	}
+	return h.orchestrator.GetCapitalAllocator()
+}
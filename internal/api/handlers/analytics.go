@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// AnalyticsHandler handles performance analytics drawn from trade history
+type AnalyticsHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(orch *orchestrator.Orchestrator) *AnalyticsHandler {
+	return &AnalyticsHandler{orchestrator: orch}
+}
+
+// GetRegimeMatrix cross-tabulates strategy performance by the market regime
+// detected when positions were entered
+// GET /api/v1/analytics/regime-matrix?strategy=&days=90
+func (h *AnalyticsHandler) GetRegimeMatrix(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	days := 90
+	if v := c.QueryParam("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 365 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "days must be between 1 and 365"})
+		}
+		days = n
+	}
+
+	matrix, err := h.orchestrator.GetRegimeMatrix(c.QueryParam("strategy"), days)
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, matrix)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/eth-trading/internal/api/middleware"
+	"github.com/eth-trading/internal/auth"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// ArmingHandler handles the live-mode arming sequence
+type ArmingHandler struct {
+	orchestrator *orchestrator.Orchestrator
+	authService  *auth.Service
+}
+
+// NewArmingHandler creates a new arming handler
+func NewArmingHandler(orch *orchestrator.Orchestrator, authService *auth.Service) *ArmingHandler {
+	return &ArmingHandler{
+		orchestrator: orch,
+		authService:  authService,
+	}
+}
+
+// ArmRequest re-authenticates the user before arming live mode
+type ArmRequest struct {
+	Password string `json:"password"`
+}
+
+// DisarmRequest optionally records why live mode was disarmed
+type DisarmRequest struct {
+	Reason string `json:"reason"`
+}
+
+// GetStatus returns the arming state and live trading requirements
+// GET /api/v1/trading/arm
+func (h *ArmingHandler) GetStatus(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetArmingStatus())
+}
+
+// Arm verifies the user's password and starts the arming countdown
+// POST /api/v1/trading/arm
+func (h *ArmingHandler) Arm(c echo.Context) error {
+	if h.orchestrator == nil || h.authService == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Arming not available"})
+	}
+
+	claims, err := middleware.GetUserClaims(c)
+	if err != nil {
+		return err
+	}
+
+	var req ArmRequest
+	if err := c.Bind(&req); err != nil || req.Password == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Password required"})
+	}
+
+	if err := h.authService.VerifyUserPassword(claims.UserID, req.Password); err != nil {
+		log.Warn().Str("user_id", claims.UserID.String()).Msg("Live arming rejected: password verification failed")
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid password"})
+	}
+
+	status, err := h.orchestrator.Arm(claims.Email)
+	if errors.Is(err, orchestrator.ErrArmingChecksFailed) {
+		return c.JSON(http.StatusPreconditionFailed, status)
+	}
+	if err != nil {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, status)
+}
+
+// Disarm cancels a pending countdown or leaves live mode
+// POST /api/v1/trading/disarm
+func (h *ArmingHandler) Disarm(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	claims, err := middleware.GetUserClaims(c)
+	if err != nil {
+		return err
+	}
+
+	var req DisarmRequest
+	_ = c.Bind(&req)
+
+	status, err := h.orchestrator.Disarm(claims.Email, req.Reason)
+	if status == nil {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
+	"github.com/labstack/echo/v4"
+)
+
+// AuditHandler serves the audit trail of order, configuration, strategy
+// and risk actions
+type AuditHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(orch *orchestrator.Orchestrator) *AuditHandler {
+	return &AuditHandler{orchestrator: orch}
+}
+
+// GetAuditLog returns audit entries, newest first
+// GET /api/v1/audit?category=&action=&actor=&resource=&accountId=&from=<ms>&to=<ms>&limit=100
+func (h *AuditHandler) GetAuditLog(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	filter := storage.AuditFilter{
+		Category:  c.QueryParam("category"),
+		Action:    c.QueryParam("action"),
+		Actor:     c.QueryParam("actor"),
+		Resource:  c.QueryParam("resource"),
+		AccountID: c.QueryParam("accountId"),
+		Limit:     historyLimit(c),
+	}
+	switch filter.Category {
+	case "", storage.AuditOrder, storage.AuditConfig, storage.AuditStrategy, storage.AuditRisk:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "category must be order, config, strategy or risk"})
+	}
+	if v := c.QueryParam("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid from timestamp"})
+		}
+		filter.From = time.UnixMilli(ms)
+	}
+	if v := c.QueryParam("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid to timestamp"})
+		}
+		filter.To = time.UnixMilli(ms)
+	}
+	if !filter.To.IsZero() && filter.From.After(filter.To) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from must be before to"})
+	}
+
+	entries, err := h.orchestrator.GetAuditLog(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load audit log: " + err.Error()})
+	}
+	if entries == nil {
+		entries = []storage.AuditEntry{}
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// recordAPIAudit records an action the caller took through the API,
+// failed with err unless it is nil
+func recordAPIAudit(c echo.Context, orch *orchestrator.Orchestrator, entry storage.AuditEntry, err error) {
+	if orch == nil {
+		return
+	}
+	entry.Actor = requestActor(c)
+	orch.RecordAudit(entry, err)
+}
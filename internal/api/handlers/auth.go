@@ -173,6 +173,9 @@ func (h *AuthHandler) GetMe(c echo.Context) error {
 		Role:     claims.Role,
 		IsActive: true,
 	}
+	if status, err := h.authService.GetTwoFactorStatus(claims.UserID); err == nil {
+		user.TwoFactor = status.Enabled
+	}
 
 	return c.JSON(http.StatusOK, user)
 }
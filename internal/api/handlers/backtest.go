@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/eth-trading/internal/backtest"
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/storage"
 	"github.com/eth-trading/internal/strategy"
 	"github.com/labstack/echo/v4"
 )
@@ -21,6 +26,9 @@ func NewBacktestHandler(orch *orchestrator.Orchestrator) *BacktestHandler {
 	return &BacktestHandler{orchestrator: orch}
 }
 
+// higherTimeframeWarmup is the number of extra secondary bars loaded before the start date
+const higherTimeframeWarmup = 200
+
 // BacktestRequest represents a backtest request
 type BacktestRequest struct {
 	Symbol         string   `json:"symbol"`
@@ -28,10 +36,64 @@ type BacktestRequest struct {
 	StartDate      string   `json:"startDate"`      // ISO 8601 format
 	EndDate        string   `json:"endDate"`        // ISO 8601 format
 	InitialCapital float64  `json:"initialCapital"`
-	Commission     float64  `json:"commission"`
+	Commission     float64  `json:"commission"` // Flat rate on every fill; ignored when fees is set
 	Slippage       float64  `json:"slippage"`
 	Strategies     []string `json:"strategies"`
 	RiskPerTrade   float64  `json:"riskPerTrade"`
+
+	// Maker/taker fee schedule; defaults to the account's configured schedule
+	Fees *backtest.FeeSchedule `json:"fees,omitempty"`
+
+	// Funding and borrow costs of holding margin or futures positions;
+	// spot backtests leave it unset and pay none
+	Carry *backtest.CarryCosts `json:"carry,omitempty"`
+
+	// Secondary timeframes provided as context (e.g. ["4h"] while trading 1h)
+	HigherTimeframes []string `json:"higherTimeframes,omitempty"`
+
+	// Fetch candles missing from local storage from Binance and persist
+	// them before running, instead of only using what the bot has cached
+	FetchMissing bool `json:"fetchMissing,omitempty"`
+
+	// Replay with inputs lagged one bar and report lookahead bias
+	LookaheadAudit bool `json:"lookaheadAudit,omitempty"`
+
+	// Compare with this many random-entry control runs sharing the exits,
+	// sizing and costs (0 = no benchmark); a seed makes them repeatable
+	BenchmarkRuns int   `json:"benchmarkRuns,omitempty"`
+	BenchmarkSeed int64 `json:"benchmarkSeed,omitempty"`
+
+	// Per-strategy regime blacklist (e.g. {"stat_arb": ["TRENDING"]});
+	// defaults to the live configuration when omitted
+	DisallowedRegimes map[string][]string `json:"disallowedRegimes,omitempty"`
+
+	// Concurrent positions (default 1), the largest fraction of capital
+	// one may take (default 95% split over maxPositions) and the cap on
+	// same-direction exposure as a fraction of capital (0 = none)
+	MaxPositions          int     `json:"maxPositions,omitempty"`
+	PositionAllocation    float64 `json:"positionAllocation,omitempty"`
+	MaxCorrelatedExposure float64 `json:"maxCorrelatedExposure,omitempty"`
+
+	// How stops and targets fill within a bar: "worst_case" (default)
+	// checks the high/low and assumes the stop fills first, "ohlc_path"
+	// follows the likely open-high-low-close path, "close" only the close
+	ExecutionModel string `json:"executionModel,omitempty"`
+
+	// Known exchange downtime during which nothing fills
+	MaintenanceWindows []MaintenanceWindowRequest `json:"maintenanceWindows,omitempty"`
+
+	// Capital entries are sized from as equity grows: "full", "fixed"
+	// (initialCapital) or "stepped" every compoundingStep of gain;
+	// defaults to the live configuration when omitted
+	Compounding     string  `json:"compounding,omitempty"`
+	CompoundingStep float64 `json:"compoundingStep,omitempty"`
+}
+
+// MaintenanceWindowRequest represents exchange downtime in a backtest
+type MaintenanceWindowRequest struct {
+	Start  string `json:"start"` // RFC 3339
+	End    string `json:"end"`   // RFC 3339
+	Reason string `json:"reason,omitempty"`
 }
 
 // BacktestResponse represents a backtest response
@@ -44,20 +106,35 @@ type BacktestResponse struct {
 	Trades         []BacktestTradeData   `json:"trades,omitempty"`
 	MonthlyReturns map[string]float64    `json:"monthlyReturns,omitempty"`
 	StrategyStats  map[string]StrategyStatsData `json:"strategyStats,omitempty"`
+	Lookahead      *LookaheadReportData  `json:"lookahead,omitempty"`
+	Benchmark      *BenchmarkReportData  `json:"benchmark,omitempty"`
 	ExecutionTime  string                `json:"executionTime,omitempty"`
 	Error          string                `json:"error,omitempty"`
 }
 
 // BacktestConfigData represents backtest config for API
 type BacktestConfigData struct {
-	Symbol         string   `json:"symbol"`
-	Timeframe      string   `json:"timeframe"`
-	StartDate      string   `json:"startDate"`
-	EndDate        string   `json:"endDate"`
-	InitialCapital float64  `json:"initialCapital"`
-	Commission     float64  `json:"commission"`
-	Slippage       float64  `json:"slippage"`
-	Strategies     []string `json:"strategies"`
+	Symbol         string               `json:"symbol"`
+	Timeframe      string               `json:"timeframe"`
+	StartDate      string               `json:"startDate"`
+	EndDate        string               `json:"endDate"`
+	InitialCapital float64              `json:"initialCapital"`
+	Fees           FeeData              `json:"fees"`
+	Carry          *backtest.CarryCosts `json:"carry,omitempty"`
+	Slippage       float64              `json:"slippage"`
+	Strategies     []string             `json:"strategies"`
+	MaxPositions   int                  `json:"maxPositions"`
+	ExecutionModel string               `json:"executionModel"`
+	Compounding    string               `json:"compounding"`
+}
+
+// FeeData represents the fee tier a backtest was priced with
+type FeeData struct {
+	Tier        string  `json:"tier"`
+	MakerRate   float64 `json:"makerRate"` // Effective rate after any BNB discount
+	TakerRate   float64 `json:"takerRate"`
+	Volume30d   float64 `json:"volume30d"`
+	BNBDiscount bool    `json:"bnbDiscount"`
 }
 
 // BacktestMetricsData represents backtest metrics for API
@@ -83,6 +160,35 @@ type BacktestMetricsData struct {
 	StartingCapital   float64 `json:"startingCapital"`
 	EndingCapital     float64 `json:"endingCapital"`
 	NetProfit         float64 `json:"netProfit"`
+	TradedNotional    float64 `json:"tradedNotional"`
+	Turnover          float64 `json:"turnover"`
+	TradesPerMonth    float64 `json:"tradesPerMonth"`
+	AvgExposureTime   string  `json:"avgExposureTime"`
+	TimeInMarket      float64 `json:"timeInMarket"`
+	TotalCommission   float64 `json:"totalCommission"`
+	TotalFunding      float64 `json:"totalFunding"`
+	TotalBorrow       float64 `json:"totalBorrow"`
+	MakerFills        int     `json:"makerFills"`
+	TakerFills        int     `json:"takerFills"`
+
+	MaxConcurrentPositions int     `json:"maxConcurrentPositions"`
+	MaxExposure            float64 `json:"maxExposure"`
+
+	MaxDrawdownDuration string  `json:"maxDrawdownDuration"` // Longest time below the high-water mark
+	LongestFlatPeriod   string  `json:"longestFlatPeriod"`
+	UlcerIndex          float64 `json:"ulcerIndex"`
+
+	DowntimeBars int `json:"downtimeBars"`
+	ReopenExits  int `json:"reopenExits"`
+
+	SpikeEntries     int `json:"spikeEntries"` // Entries signaled on a volatility spike
+	DelayedEntries   int `json:"delayedEntries"`
+	DroppedEntries   int `json:"droppedEntries"` // Delayed entries the next bar did not bear out
+	DownsizedEntries int `json:"downsizedEntries"`
+
+	HeldEntries        int `json:"heldEntries"` // Entries held for a confirmation candle
+	ConfirmedEntries   int `json:"confirmedEntries"`
+	UnconfirmedEntries int `json:"unconfirmedEntries"` // Held entries dropped
 }
 
 // BacktestTradeData represents a trade in backtest results
@@ -99,6 +205,10 @@ type BacktestTradeData struct {
 	NetProfit     float64 `json:"netProfit"`
 	ReturnPercent float64 `json:"returnPercent"`
 	ExitReason    string  `json:"exitReason"`
+	Commission    float64 `json:"commission"`
+	Funding       float64 `json:"funding,omitempty"` // Negative when received
+	Borrow        float64 `json:"borrow,omitempty"`
+	ExitLiquidity string  `json:"exitLiquidity"` // maker or taker
 }
 
 // StrategyStatsData represents per-strategy stats
@@ -111,13 +221,112 @@ type StrategyStatsData struct {
 	Contribution float64 `json:"contribution"`
 }
 
-// RunBacktest runs a backtest
+// LookaheadReportData represents a lookahead-bias audit for API
+type LookaheadReportData struct {
+	Suspicious       bool                    `json:"suspicious"`
+	Reasons          []string                `json:"reasons,omitempty"`
+	BarsCompared     int                     `json:"barsCompared"`
+	SignalMismatches int                     `json:"signalMismatches"`
+	MismatchRate     float64                 `json:"mismatchRate"`
+	BaselineReturn   float64                 `json:"baselineReturn"`
+	ShiftedReturn    float64                 `json:"shiftedReturn"`
+	ReturnDecay      float64                 `json:"returnDecay"`
+	TotalViolations  int                     `json:"totalViolations"`
+	Violations       []LookaheadViolationData `json:"violations,omitempty"`
+}
+
+// BenchmarkReportData represents the comparison with random-entry control
+// runs for API
+type BenchmarkReportData struct {
+	Runs               int     `json:"runs"`
+	Seed               int64   `json:"seed"`
+	EntryRate          float64 `json:"entryRate"`
+	StrategyReturn     float64 `json:"strategyReturn"`
+	RandomMeanReturn   float64 `json:"randomMeanReturn"`
+	RandomMedianReturn float64 `json:"randomMedianReturn"`
+	RandomStdDev       float64 `json:"randomStdDev"`
+	RandomMeanTrades   float64 `json:"randomMeanTrades"`
+	Percentile         float64 `json:"percentile"`
+	PValue             float64 `json:"pValue"`
+	Edge               float64 `json:"edge"`
+	EdgeLow            float64 `json:"edgeLow"`  // 95% bootstrap interval
+	EdgeHigh           float64 `json:"edgeHigh"`
+	Significant        bool    `json:"significant"`
+	Reason             string  `json:"reason,omitempty"`
+}
+
+// LookaheadViolationData represents a failed lookahead check
+type LookaheadViolationData struct {
+	Time   string `json:"time"`
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// BacktestJobResponse represents the state of a queued backtest and, once
+// completed, its result
+type BacktestJobResponse struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Status      string          `json:"status"`
+	Progress    float64         `json:"progress"` // Percent complete
+	Error       string          `json:"error,omitempty"`
+	SubmittedBy string          `json:"submittedBy,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	StartedAt   *time.Time      `json:"startedAt,omitempty"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"` // BacktestResponse
+}
+
+// RunBacktest validates a backtest and queues it, returning the job ID
+// immediately. Progress is broadcast as "backtest" messages.
+// POST /api/v1/backtest
 func (h *BacktestHandler) RunBacktest(c echo.Context) error {
 	var req BacktestRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
+	btConfig, historicalData, err := h.prepareBacktest(&req)
+	if err != nil {
+		return httpErrorJSON(c, err)
+	}
+
+	config, _ := json.Marshal(req)
+	run := storage.BacktestRun{
+		Name: fmt.Sprintf("%s %s %s to %s", btConfig.Symbol, btConfig.Timeframe,
+			btConfig.StartDate.Format("2006-01-02"), btConfig.EndDate.Format("2006-01-02")),
+		Symbol:         btConfig.Symbol,
+		Timeframe:      btConfig.Timeframe,
+		StartDate:      btConfig.StartDate,
+		EndDate:        btConfig.EndDate,
+		InitialBalance: btConfig.InitialCapital,
+		Strategies:     h.getStrategyNames(btConfig.Strategies),
+		Config:         config,
+	}
+	job, err := h.orchestrator.SubmitBacktest(run, btConfig, historicalData, requestActor(c),
+		func(id int64, result *backtest.Result) interface{} {
+			response := h.convertBacktestResult(result)
+			response.ID = strconv.FormatInt(id, 10)
+			return response
+		})
+	if errors.Is(err, orchestrator.ErrBacktestQueueFull) {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Backtest queue is full, retry later"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to queue backtest: %v", err)})
+	}
+
+	return c.JSON(http.StatusAccepted, BacktestJobResponse{
+		ID:          job.ID,
+		Name:        job.Name,
+		Status:      job.Status,
+		SubmittedBy: job.SubmittedBy,
+		CreatedAt:   job.QueuedAt,
+	})
+}
+
+// prepareBacktest validates a request and loads the data it runs over
+func (h *BacktestHandler) prepareBacktest(req *BacktestRequest) (*backtest.Config, *backtest.HistoricalData, error) {
 	// Validate request
 	if req.Symbol == "" {
 		req.Symbol = "ETHUSDT"
@@ -128,12 +337,67 @@ func (h *BacktestHandler) RunBacktest(c echo.Context) error {
 	if req.InitialCapital <= 0 {
 		req.InitialCapital = 100000
 	}
-	if req.Commission <= 0 {
-		req.Commission = 0.001
+	fees := req.Fees
+	switch {
+	case fees != nil:
+		if err := fees.Validate(); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	case req.Commission > 0:
+		fees = backtest.FlatFeeSchedule(req.Commission)
+	default:
+		fees = h.orchestrator.GetFeeSchedule()
+	}
+	if req.Carry != nil {
+		if err := req.Carry.Validate(); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 	}
 	if req.RiskPerTrade <= 0 {
 		req.RiskPerTrade = 0.02
 	}
+	if req.MaxPositions < 0 {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "maxPositions must not be negative")
+	}
+	if req.PositionAllocation < 0 || req.PositionAllocation > 1 {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "positionAllocation must be between 0 and 1")
+	}
+	if req.MaxCorrelatedExposure < 0 {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "maxCorrelatedExposure must not be negative")
+	}
+	if req.BenchmarkRuns < 0 || req.BenchmarkRuns > backtest.MaxBenchmarkRuns {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("benchmarkRuns must be between 0 and %d", backtest.MaxBenchmarkRuns))
+	}
+	executionModel, err := backtest.ParseExecutionModel(req.ExecutionModel)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	compounding, compoundingStep := h.orchestrator.GetCompounding()
+	if req.Compounding != "" {
+		compounding = risk.CompoundingMode(req.Compounding)
+		if !risk.ValidCompoundingMode(compounding) {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "compounding must be full, fixed or stepped")
+		}
+	}
+	if req.CompoundingStep < 0 {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "compoundingStep must not be negative")
+	}
+	if req.CompoundingStep > 0 {
+		compoundingStep = req.CompoundingStep
+	}
+	maintenance := make([]backtest.MaintenanceWindow, 0, len(req.MaintenanceWindows))
+	for _, w := range req.MaintenanceWindows {
+		start, startErr := time.Parse(time.RFC3339, w.Start)
+		end, endErr := time.Parse(time.RFC3339, w.End)
+		if startErr != nil || endErr != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "maintenance window start and end must be RFC 3339 times")
+		}
+		window := backtest.MaintenanceWindow{Start: start, End: end, Reason: w.Reason}
+		if err := window.Validate(); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		maintenance = append(maintenance, window)
+	}
 
 	// Parse dates
 	var startDate, endDate time.Time
@@ -149,44 +413,81 @@ func (h *BacktestHandler) RunBacktest(c echo.Context) error {
 	}
 
 	// Get data service
-	dataService := h.orchestrator.GetDataService()
-	if dataService == nil {
-		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Data service not available"})
+	if h.orchestrator.GetDataService() == nil {
+		return nil, nil, echo.NewHTTPError(http.StatusServiceUnavailable, "Data service not available")
 	}
 
 	// Get historical candles
-	storageCandles, err := dataService.GetHistoricalCandles(req.Symbol, req.Timeframe, startDate, endDate)
+	storageCandles, err := h.loadCandles(req, req.Timeframe, startDate, endDate)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to fetch historical data: %v", err)})
+		return nil, nil, err
 	}
 
 	if len(storageCandles) == 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "No historical data available for the specified date range"})
-	}
-
-	// Convert storage candles to backtest candles
-	backtestCandles := make([]backtest.Candle, len(storageCandles))
-	for i, sc := range storageCandles {
-		backtestCandles[i] = backtest.Candle{
-			Timestamp: sc.OpenTime,
-			Open:      sc.Open,
-			High:      sc.High,
-			Low:       sc.Low,
-			Close:     sc.Close,
-			Volume:    sc.Volume,
+		if !req.FetchMissing {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "No historical data available for the specified date range, set fetchMissing to download it")
 		}
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "No historical data available for the specified date range")
 	}
 
 	historicalData := &backtest.HistoricalData{
 		Symbol:    req.Symbol,
 		Timeframe: req.Timeframe,
-		Candles:   backtestCandles,
+		Candles:   toBacktestCandles(storageCandles),
+	}
+
+	// Load secondary timeframes, with extra history so their indicators are warm
+	if len(req.HigherTimeframes) > 0 {
+		historicalData.HigherTimeframes = make(map[string][]backtest.Candle, len(req.HigherTimeframes))
+		for _, tf := range req.HigherTimeframes {
+			if tf == req.Timeframe {
+				continue
+			}
+			duration, err := backtest.TimeframeDuration(tf)
+			if err != nil {
+				return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			htfCandles, err := h.loadCandles(req, tf, startDate.Add(-higherTimeframeWarmup*duration), endDate)
+			if err != nil {
+				return nil, nil, err
+			}
+			historicalData.HigherTimeframes[tf] = toBacktestCandles(htfCandles)
+		}
+	}
+
+	// Entries wait for confirmation as they do live; candles shorter than
+	// the bars are loaded with enough history for their indicators
+	confirmation := h.orchestrator.GetEntryConfirmation()
+	if confirmation.Enabled() {
+		interval, err := backtest.TimeframeDuration(confirmation.Interval)
+		if err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		bar, err := backtest.TimeframeDuration(req.Timeframe)
+		if err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if interval > bar {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+				"Entry confirmation waits for %s candles, backtest on %s or a longer timeframe", confirmation.Interval, confirmation.Interval))
+		}
+		if interval < bar {
+			confirmCandles, err := h.loadCandles(req, confirmation.Interval, startDate.Add(-strategy.ConfirmationBars*interval), endDate)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(confirmCandles) == 0 {
+				return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("No %s candles for entry confirmation in the specified date range", confirmation.Interval))
+			}
+			historicalData.ConfirmationCandles = toBacktestCandles(confirmCandles)
+		}
 	}
 
 	// Get strategy manager and selected strategies
 	strategyMgr := h.orchestrator.GetStrategyManager()
 	if strategyMgr == nil {
-		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
+		return nil, nil, echo.NewHTTPError(http.StatusServiceUnavailable, "Strategy manager not available")
 	}
 
 	allStrategies := strategyMgr.GetStrategies()
@@ -209,32 +510,106 @@ func (h *BacktestHandler) RunBacktest(c echo.Context) error {
 	}
 
 	if len(selectedStrategies) == 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "No valid strategies selected"})
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "No valid strategies selected")
 	}
 
-	// Create backtest config
-	btConfig := &backtest.Config{
-		Symbol:         req.Symbol,
-		Timeframe:      req.Timeframe,
-		StartDate:      startDate,
-		EndDate:        endDate,
-		InitialCapital: req.InitialCapital,
-		Commission:     req.Commission,
-		Slippage:       req.Slippage,
-		RiskPerTrade:   req.RiskPerTrade,
-		Strategies:     selectedStrategies,
+	// Filter regimes exactly as live scoring does unless overridden
+	disallowedRegimes := strategyMgr.GetScorer().GetDisallowedRegimes()
+	if req.DisallowedRegimes != nil {
+		disallowedRegimes = make(map[string][]strategy.MarketRegime, len(req.DisallowedRegimes))
+		for name, regimes := range req.DisallowedRegimes {
+			for _, r := range regimes {
+				regime, err := strategy.ParseMarketRegime(r)
+				if err != nil {
+					return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+				}
+				key := strategy.CanonicalName(name)
+				disallowedRegimes[key] = append(disallowedRegimes[key], regime)
+			}
+		}
 	}
 
-	// Create and run backtest engine
-	engine := backtest.NewEngine(btConfig)
-	result, err := engine.Run(historicalData)
+	return &backtest.Config{
+		Symbol:            req.Symbol,
+		Timeframe:         req.Timeframe,
+		StartDate:         startDate,
+		EndDate:           endDate,
+		InitialCapital:    req.InitialCapital,
+		Fees:              fees,
+		Carry:             req.Carry,
+		Slippage:          req.Slippage,
+		RiskPerTrade:      req.RiskPerTrade,
+		Strategies:        selectedStrategies,
+		LookaheadAudit:    req.LookaheadAudit,
+		BenchmarkRuns:     req.BenchmarkRuns,
+		BenchmarkSeed:     req.BenchmarkSeed,
+		DisallowedRegimes: disallowedRegimes,
+		RegimeRoutes:      strategyMgr.GetScorer().GetRegimeRoutes(),
+
+		MaxPositions:          req.MaxPositions,
+		PositionAllocation:    req.PositionAllocation,
+		MaxCorrelatedExposure: req.MaxCorrelatedExposure,
+		VolatilityThrottle:    h.orchestrator.GetVolatilityThrottles(),
+		EntryConfirmation:     confirmation,
+		Compounding:           compounding,
+		CompoundingStep:       compoundingStep,
+		ExecutionModel:        executionModel,
+		Maintenance:           maintenance,
+		EvalPool:              h.orchestrator.EvalPool(),
+	}, historicalData, nil
+}
+
+// loadCandles returns the candles of a timeframe opening within [from, to],
+// fetching those missing from Binance when the request asks to
+func (h *BacktestHandler) loadCandles(req *BacktestRequest, timeframe string, from, to time.Time) ([]storage.Candle, error) {
+	if !req.FetchMissing {
+		candles, err := h.orchestrator.GetDataService().GetHistoricalCandles(req.Symbol, timeframe, from, to)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch %s data: %v", timeframe, err))
+		}
+		return candles, nil
+	}
+
+	result, err := h.orchestrator.GetBacktestCandles(req.Symbol, timeframe, from, to)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Backtest failed: %v", err)})
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to load %s data: %v", timeframe, err))
+	}
+	if result.Partial {
+		return nil, echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("Could not download all %s candles from Binance, retry later", timeframe))
 	}
+	return result.Candles, nil
+}
 
-	// Convert result to API response
-	response := h.convertBacktestResult(result)
-	return c.JSON(http.StatusOK, response)
+// compoundingName names the compounding mode a backtest sized with
+func compoundingName(mode risk.CompoundingMode) string {
+	if mode == "" {
+		return string(risk.CompoundingFull)
+	}
+	return string(mode)
+}
+
+// toBacktestCandles converts stored candles for the backtest engine
+func toBacktestCandles(candles []storage.Candle) []backtest.Candle {
+	converted := make([]backtest.Candle, len(candles))
+	for i, sc := range candles {
+		converted[i] = backtest.Candle{
+			Timestamp: sc.OpenTime,
+			Open:      sc.Open,
+			High:      sc.High,
+			Low:       sc.Low,
+			Close:     sc.Close,
+			Volume:    sc.Volume,
+		}
+	}
+	return converted
+}
+
+// httpErrorJSON writes an *echo.HTTPError as the usual JSON error body
+func httpErrorJSON(c echo.Context, err error) error {
+	if he, ok := err.(*echo.HTTPError); ok {
+		return c.JSON(he.Code, map[string]string{"error": fmt.Sprint(he.Message)})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 }
 
 // convertBacktestResult converts backtest result to API response
@@ -272,6 +647,10 @@ func (h *BacktestHandler) convertBacktestResult(result *backtest.Result) Backtes
 			NetProfit:     trade.NetProfit,
 			ReturnPercent: trade.ReturnPercent,
 			ExitReason:    trade.ExitReason,
+			Commission:    trade.Commission,
+			Funding:       trade.Funding,
+			Borrow:        trade.Borrow,
+			ExitLiquidity: string(trade.ExitLiquidity),
 		}
 	}
 
@@ -288,6 +667,29 @@ func (h *BacktestHandler) convertBacktestResult(result *backtest.Result) Backtes
 		}
 	}
 
+	// Convert lookahead audit
+	var lookahead *LookaheadReportData
+	if report := result.Lookahead; report != nil {
+		lookahead = &LookaheadReportData{
+			Suspicious:       report.Suspicious,
+			Reasons:          report.Reasons,
+			BarsCompared:     report.BarsCompared,
+			SignalMismatches: report.SignalMismatches,
+			MismatchRate:     report.MismatchRate,
+			BaselineReturn:   report.BaselineReturn,
+			ShiftedReturn:    report.ShiftedReturn,
+			ReturnDecay:      report.ReturnDecay,
+			TotalViolations:  report.TotalViolations,
+		}
+		for _, v := range report.Violations {
+			lookahead.Violations = append(lookahead.Violations, LookaheadViolationData{
+				Time:   v.Timestamp.Format("2006-01-02T15:04:05Z"),
+				Check:  v.Check,
+				Detail: v.Detail,
+			})
+		}
+	}
+
 	return BacktestResponse{
 		ID:     "bt-" + time.Now().Format("20060102150405"),
 		Status: "completed",
@@ -297,41 +699,119 @@ func (h *BacktestHandler) convertBacktestResult(result *backtest.Result) Backtes
 			StartDate:      result.Config.StartDate.Format("2006-01-02"),
 			EndDate:        result.Config.EndDate.Format("2006-01-02"),
 			InitialCapital: result.Config.InitialCapital,
-			Commission:     result.Config.Commission,
+			Fees:           convertFees(result.Config.Fees),
+			Carry:          result.Config.Carry,
 			Slippage:       result.Config.Slippage,
 			Strategies:     h.getStrategyNames(result.Config.Strategies),
+			MaxPositions:   max(result.Config.MaxPositions, 1),
+			ExecutionModel: string(result.Config.ExecutionModel),
+			Compounding:    compoundingName(result.Config.Compounding),
 		},
-		Metrics: &BacktestMetricsData{
-			TotalReturn:      result.Metrics.TotalReturn,
-			AnnualizedReturn: result.Metrics.AnnualizedReturn,
-			SharpeRatio:      result.Metrics.SharpeRatio,
-			SortinoRatio:     result.Metrics.SortinoRatio,
-			CalmarRatio:      result.Metrics.CalmarRatio,
-			MaxDrawdown:      result.Metrics.MaxDrawdown,
-			TotalTrades:      result.Metrics.TotalTrades,
-			WinningTrades:    result.Metrics.WinningTrades,
-			LosingTrades:     result.Metrics.LosingTrades,
-			WinRate:          result.Metrics.WinRate,
-			ProfitFactor:     result.Metrics.ProfitFactor,
-			AvgWin:           result.Metrics.AvgWin,
-			AvgLoss:          result.Metrics.AvgLoss,
-			LargestWin:       result.Metrics.LargestWin,
-			LargestLoss:      result.Metrics.LargestLoss,
-			AvgHoldingTime:   result.Metrics.AvgHoldingTime,
-			Expectancy:       result.Metrics.Expectancy,
-			RecoveryFactor:   result.Metrics.RecoveryFactor,
-			StartingCapital:  result.Metrics.StartingCapital,
-			EndingCapital:    result.Metrics.EndingCapital,
-			NetProfit:        result.Metrics.NetProfit,
-		},
+		Metrics: convertMetrics(result.Metrics),
 		EquityCurve:    equityCurve,
 		Trades:         trades,
 		MonthlyReturns: result.MonthlyReturns,
 		StrategyStats:  strategyStats,
+		Lookahead:      lookahead,
+		Benchmark:      convertBenchmark(result.Benchmark),
 		ExecutionTime:  result.ExecutionTime.String(),
 	}
 }
 
+// convertBenchmark converts a benchmark report for the API
+func convertBenchmark(b *backtest.BenchmarkReport) *BenchmarkReportData {
+	if b == nil {
+		return nil
+	}
+	return &BenchmarkReportData{
+		Runs:               b.Runs,
+		Seed:               b.Seed,
+		EntryRate:          b.EntryRate,
+		StrategyReturn:     b.StrategyReturn,
+		RandomMeanReturn:   b.RandomMeanReturn,
+		RandomMedianReturn: b.RandomMedianReturn,
+		RandomStdDev:       b.RandomStdDev,
+		RandomMeanTrades:   b.RandomMeanTrades,
+		Percentile:         b.Percentile,
+		PValue:             b.PValue,
+		Edge:               b.Edge,
+		EdgeLow:            b.EdgeLow,
+		EdgeHigh:           b.EdgeHigh,
+		Significant:        b.Significant,
+		Reason:             b.Reason,
+	}
+}
+
+// convertMetrics converts backtest metrics for the API
+func convertMetrics(m *backtest.Metrics) *BacktestMetricsData {
+	return &BacktestMetricsData{
+		TotalReturn:      m.TotalReturn,
+		AnnualizedReturn: m.AnnualizedReturn,
+		SharpeRatio:      m.SharpeRatio,
+		SortinoRatio:     m.SortinoRatio,
+		CalmarRatio:      m.CalmarRatio,
+		MaxDrawdown:      m.MaxDrawdown,
+		TotalTrades:      m.TotalTrades,
+		WinningTrades:    m.WinningTrades,
+		LosingTrades:     m.LosingTrades,
+		WinRate:          m.WinRate,
+		ProfitFactor:     m.ProfitFactor,
+		AvgWin:           m.AvgWin,
+		AvgLoss:          m.AvgLoss,
+		LargestWin:       m.LargestWin,
+		LargestLoss:      m.LargestLoss,
+		AvgHoldingTime:   m.AvgHoldingTime,
+		Expectancy:       m.Expectancy,
+		RecoveryFactor:   m.RecoveryFactor,
+		StartingCapital:  m.StartingCapital,
+		EndingCapital:    m.EndingCapital,
+		NetProfit:        m.NetProfit,
+		TradedNotional:   m.TradedNotional,
+		Turnover:         m.Turnover,
+		TradesPerMonth:   m.TradesPerMonth,
+		AvgExposureTime:  m.AvgExposureTime,
+		TimeInMarket:     m.TimeInMarket,
+		TotalCommission:  m.TotalCommission,
+		TotalFunding:     m.TotalFunding,
+		TotalBorrow:      m.TotalBorrow,
+		MakerFills:       m.MakerFills,
+		TakerFills:       m.TakerFills,
+
+		MaxConcurrentPositions: m.MaxConcurrentPositions,
+		MaxExposure:            m.MaxExposure,
+
+		MaxDrawdownDuration: m.MaxDrawdownDuration,
+		LongestFlatPeriod:   m.LongestFlatPeriod,
+		UlcerIndex:          m.UlcerIndex,
+
+		DowntimeBars: m.DowntimeBars,
+		ReopenExits:  m.ReopenExits,
+
+		SpikeEntries:     m.SpikeEntries,
+		DelayedEntries:   m.DelayedEntries,
+		DroppedEntries:   m.DroppedEntries,
+		DownsizedEntries: m.DownsizedEntries,
+
+		HeldEntries:        m.HeldEntries,
+		ConfirmedEntries:   m.ConfirmedEntries,
+		UnconfirmedEntries: m.UnconfirmedEntries,
+	}
+}
+
+// convertFees describes the fee tier a backtest used
+func convertFees(fees *backtest.FeeSchedule) FeeData {
+	if fees == nil {
+		return FeeData{Tier: "none"}
+	}
+	return FeeData{
+		Tier:        fees.Tier().Name,
+		MakerRate:   fees.Rate(backtest.LiquidityMaker),
+		TakerRate:   fees.Rate(backtest.LiquidityTaker),
+		Volume30d:   fees.Volume30d,
+		BNBDiscount: fees.BNBDiscount,
+	}
+}
+
 // getStrategyNames extracts strategy names
 func (h *BacktestHandler) getStrategyNames(strategies []strategy.Strategy) []string {
 	names := make([]string, len(strategies))
@@ -352,22 +832,103 @@ type BacktestResultSummary struct {
 	Sharpe    float64   `json:"sharpe"`
 	MaxDD     float64   `json:"maxDD"`
 	Trades    int       `json:"trades"`
+	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
-// GetResults returns all backtest results
+// GetResults returns the most recent backtests, queued ones included
+// GET /api/v1/backtest/results?limit=50
 func (h *BacktestHandler) GetResults(c echo.Context) error {
-	// In real implementation, would fetch from storage
-	results := []BacktestResultSummary{}
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Data service not available"})
+	}
+
+	limit := 50
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	runs, err := h.orchestrator.GetDataService().GetBacktestRuns(limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	results := make([]BacktestResultSummary, 0, len(runs))
+	for _, run := range runs {
+		summary := BacktestResultSummary{
+			ID:        strconv.FormatInt(run.ID, 10),
+			Symbol:    run.Symbol,
+			Timeframe: run.Timeframe,
+			StartDate: run.StartDate.Format("2006-01-02"),
+			EndDate:   run.EndDate.Format("2006-01-02"),
+			Sharpe:    run.SharpeRatio,
+			MaxDD:     run.MaxDrawdownPct / 100,
+			Trades:    run.TotalTrades,
+			Status:    run.Status,
+			CreatedAt: run.CreatedAt,
+		}
+		if run.InitialBalance > 0 {
+			summary.Return = run.NetProfit / run.InitialBalance
+		}
+		results = append(results, summary)
+	}
 	return c.JSON(http.StatusOK, results)
 }
 
-// GetResult returns a specific backtest result
+// GetResult returns the status and progress of a backtest and, once it
+// completed, its result
+// GET /api/v1/backtest/:id
 func (h *BacktestHandler) GetResult(c echo.Context) error {
-	id := c.Param("id")
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Data service not available"})
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid backtest ID"})
+	}
+
+	dataService := h.orchestrator.GetDataService()
+	run, err := dataService.GetBacktestRun(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if run == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Backtest result not found"})
+	}
 
-	// In real implementation, would fetch from storage
-	_ = id
+	response := BacktestJobResponse{
+		ID:          run.ID,
+		Name:        run.Name,
+		Status:      run.Status,
+		CreatedAt:   run.CreatedAt,
+		CompletedAt: run.CompletedAt,
+	}
+	if run.Status != orchestrator.BacktestQueued {
+		response.StartedAt = &run.StartedAt
+	}
 
-	return c.JSON(http.StatusNotFound, map[string]string{"error": "Backtest result not found"})
+	// Progress is only tracked in memory, for jobs of this process
+	if job, ok := h.orchestrator.GetBacktestJob(id); ok {
+		response.Progress = job.Progress
+		response.SubmittedBy = job.SubmittedBy
+		response.Error = job.Error
+	}
+
+	switch run.Status {
+	case orchestrator.BacktestCompleted, orchestrator.BacktestFailed:
+		result, errMsg, err := dataService.GetBacktestResult(id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		response.Result = result
+		response.Error = errMsg
+		if run.Status == orchestrator.BacktestCompleted {
+			response.Progress = 100
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
 }
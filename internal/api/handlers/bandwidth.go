@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/api/websocket"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// BandwidthHandler reports WebSocket traffic
+type BandwidthHandler struct {
+	orchestrator *orchestrator.Orchestrator
+	hub          *websocket.Hub
+}
+
+// NewBandwidthHandler creates a new bandwidth handler
+func NewBandwidthHandler(orch *orchestrator.Orchestrator, hub *websocket.Hub) *BandwidthHandler {
+	return &BandwidthHandler{orchestrator: orch, hub: hub}
+}
+
+// BandwidthResponse represents WebSocket traffic on both sides of the bot
+type BandwidthResponse struct {
+	Exchange orchestrator.StreamBandwidth `json:"exchange"`
+	API      websocket.HubStats           `json:"api"`
+}
+
+// GetBandwidth returns bytes in/out and message rates per stream
+// GET /api/v1/metrics/bandwidth
+func (h *BandwidthHandler) GetBandwidth(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, BandwidthResponse{
+		Exchange: h.orchestrator.GetStreamBandwidth(),
+		API:      h.hub.Stats(),
+	})
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/eth-trading/internal/binance"
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/strategy"
 	"github.com/labstack/echo/v4"
 )
 
@@ -23,12 +26,13 @@ func NewCandleHandler(orch *orchestrator.Orchestrator) *CandleHandler {
 
 // CandleData represents candle data for API
 type CandleData struct {
-	Time   int64   `json:"time"`   // Unix timestamp in seconds
-	Open   float64 `json:"open"`
-	High   float64 `json:"high"`
-	Low    float64 `json:"low"`
-	Close  float64 `json:"close"`
-	Volume float64 `json:"volume"`
+	Time    int64   `json:"time"` // Unix timestamp in seconds
+	Open    float64 `json:"open"`
+	High    float64 `json:"high"`
+	Low     float64 `json:"low"`
+	Close   float64 `json:"close"`
+	Volume  float64 `json:"volume"`
+	Session string  `json:"session"` // Trading session the candle opened in
 }
 
 // GetCandles returns candle data
@@ -55,6 +59,11 @@ func (h *CandleHandler) GetCandles(c echo.Context) error {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
 	}
 
+	// Historical ranges are served from storage, backfilled from Binance
+	if c.QueryParam("from") != "" || c.QueryParam("to") != "" {
+		return h.getCandleRange(c, symbol, timeframe, limit)
+	}
+
 	// Fetch candles from orchestrator
 	storageCandles := h.orchestrator.GetCandles(symbol, timeframe, limit)
 
@@ -62,12 +71,13 @@ func (h *CandleHandler) GetCandles(c echo.Context) error {
 	candles := make([]CandleData, len(storageCandles))
 	for i, sc := range storageCandles {
 		candles[i] = CandleData{
-			Time:   sc.OpenTime.UnixMilli(),
-			Open:   sc.Open,
-			High:   sc.High,
-			Low:    sc.Low,
-			Close:  sc.Close,
-			Volume: sc.Volume,
+			Time:    sc.OpenTime.UnixMilli(),
+			Open:    sc.Open,
+			High:    sc.High,
+			Low:     sc.Low,
+			Close:   sc.Close,
+			Volume:  sc.Volume,
+			Session: string(strategy.SessionAt(sc.OpenTime)),
 		}
 	}
 
@@ -91,6 +101,11 @@ func (h *CandleHandler) GetCandlesBySymbol(c echo.Context) error {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
 	}
 
+	// Historical ranges are served from storage, backfilled from Binance
+	if c.QueryParam("from") != "" || c.QueryParam("to") != "" {
+		return h.getCandleRange(c, symbol, timeframe, limit)
+	}
+
 	// Fetch candles from orchestrator
 	storageCandles := h.orchestrator.GetCandles(symbol, timeframe, limit)
 
@@ -98,12 +113,68 @@ func (h *CandleHandler) GetCandlesBySymbol(c echo.Context) error {
 	candles := make([]CandleData, len(storageCandles))
 	for i, sc := range storageCandles {
 		candles[i] = CandleData{
-			Time:   sc.OpenTime.UnixMilli(),
-			Open:   sc.Open,
-			High:   sc.High,
-			Low:    sc.Low,
-			Close:  sc.Close,
-			Volume: sc.Volume,
+			Time:    sc.OpenTime.UnixMilli(),
+			Open:    sc.Open,
+			High:    sc.High,
+			Low:     sc.Low,
+			Close:   sc.Close,
+			Volume:  sc.Volume,
+			Session: string(strategy.SessionAt(sc.OpenTime)),
+		}
+	}
+
+	return c.JSON(http.StatusOK, candles)
+}
+
+// getCandleRange serves candles between the from and to query parameters
+// (Unix milliseconds). A missing bound defaults to limit bars from the other,
+// and to defaults to now.
+func (h *CandleHandler) getCandleRange(c echo.Context, symbol, timeframe string, limit int) error {
+	interval := binance.IntervalToDuration(timeframe)
+	if interval <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid timeframe"})
+	}
+
+	from, to := time.Time{}, time.Now()
+	if v := c.QueryParam("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid from timestamp"})
+		}
+		from = time.UnixMilli(ms)
+		if c.QueryParam("to") == "" {
+			to = from.Add(time.Duration(limit) * interval)
+		}
+	}
+	if v := c.QueryParam("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid to timestamp"})
+		}
+		to = time.UnixMilli(ms)
+	}
+	if from.IsZero() {
+		from = to.Add(-time.Duration(limit) * interval)
+	}
+
+	result, err := h.orchestrator.GetCandleRange(symbol, timeframe, from, to, limit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if result.Partial {
+		c.Response().Header().Set("X-Candles-Partial", "true")
+	}
+
+	candles := make([]CandleData, len(result.Candles))
+	for i, sc := range result.Candles {
+		candles[i] = CandleData{
+			Time:    sc.OpenTime.UnixMilli(),
+			Open:    sc.Open,
+			High:    sc.High,
+			Low:     sc.Low,
+			Close:   sc.Close,
+			Volume:  sc.Volume,
+			Session: string(strategy.SessionAt(sc.OpenTime)),
 		}
 	}
 
@@ -214,3 +285,96 @@ func (h *CandleHandler) GetIndicators(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, indicators)
 }
+
+// GetPriceSources returns the price the bot acts on, the stream it came
+// from, and what the trade and kline streams last reported
+// GET /api/v1/price/sources
+func (h *CandleHandler) GetPriceSources(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	return c.JSON(http.StatusOK, h.orchestrator.GetPriceArbiter())
+}
+
+// GetTape returns the order-flow tape: recent trades aggregated into
+// buy/sell volume per bucket with large trades highlighted
+func (h *CandleHandler) GetTape(c echo.Context) error {
+	bucket := time.Second
+	if b := c.QueryParam("bucket"); b != "" {
+		d, err := time.ParseDuration(b)
+		if err != nil || d < 100*time.Millisecond {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid bucket, use a duration of at least 100ms (e.g. 1s, 5s, 1m)"})
+		}
+		bucket = d
+	}
+
+	limit := 300
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 && l <= 5000 {
+		limit = l
+	}
+
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	tape, err := h.orchestrator.GetTape(bucket, limit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tape)
+}
+
+// GetOrderBook returns the top of the local order book and, with a
+// quantity, the estimated fill price and slippage of market orders for it
+func (h *CandleHandler) GetOrderBook(c echo.Context) error {
+	levels := 20
+	if l, err := strconv.Atoi(c.QueryParam("levels")); err == nil && l > 0 && l <= 1000 {
+		levels = l
+	}
+
+	var quantity float64
+	if q := c.QueryParam("quantity"); q != "" {
+		v, err := strconv.ParseFloat(q, 64)
+		if err != nil || v <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid quantity, use a positive amount of the base asset"})
+		}
+		quantity = v
+	}
+
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	book, err := h.orchestrator.GetOrderBook(levels, quantity)
+	switch {
+	case errors.Is(err, orchestrator.ErrOrderBookDisabled):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrOrderBookUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+// GetSymbolInfo returns a symbol's exchange trading rules (tick size, step
+// size, minimum notional, precisions) for validating orders client-side.
+// Rules are cached for an hour unless refresh=true.
+// GET /api/v1/symbols/:symbol/info
+func (h *CandleHandler) GetSymbolInfo(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	rules, err := h.orchestrator.GetSymbolRules(c.Param("symbol"), c.QueryParam("refresh") == "true")
+	switch {
+	case errors.Is(err, binance.ErrSymbolNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// maxCopySignalBytes bounds the body of a pushed signal
+const maxCopySignalBytes = 64 << 10
+
+// CopyTradingHandler handles leader/follower copy trading
+type CopyTradingHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewCopyTradingHandler creates a new copy-trading handler
+func NewCopyTradingHandler(orch *orchestrator.Orchestrator) *CopyTradingHandler {
+	return &CopyTradingHandler{orchestrator: orch}
+}
+
+// FollowerRequest registers an endpoint to push signals to
+type FollowerRequest struct {
+	Name string `json:"name"`
+	URL  string `json:"url"` // Follower's POST /api/v1/copy/signals
+}
+
+// GetStatus returns the followers signals are pushed to and the signals
+// received from leaders
+// GET /api/v1/copy
+func (h *CopyTradingHandler) GetStatus(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	return c.JSON(http.StatusOK, h.orchestrator.GetCopyTradingStatus())
+}
+
+// AddFollower registers a follower, restored after restarts
+// POST /api/v1/copy/followers
+func (h *CopyTradingHandler) AddFollower(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req FollowerRequest
+	if err := c.Bind(&req); err != nil || req.Name == "" || req.URL == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "A name and URL are required"})
+	}
+
+	follower, err := h.orchestrator.AddFollower(req.Name, req.URL, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrCopyPublishingDisabled):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrFollowerExists):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil && follower == nil:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Registered but failed to persist: " + err.Error()})
+	}
+	return c.JSON(http.StatusCreated, follower)
+}
+
+// RemoveFollower stops pushing signals to a follower registered through the API
+// DELETE /api/v1/copy/followers/:name
+func (h *CopyTradingHandler) RemoveFollower(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	name := c.Param("name")
+	err := h.orchestrator.RemoveFollower(name, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrFollowerNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrCoreFollower), errors.Is(err, orchestrator.ErrCopyPublishingDisabled):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to remove follower: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"status": "removed", "name": name})
+}
+
+// ReceiveSignal trades a signal pushed by a leader. Requests are
+// authenticated by their HMAC signature rather than a user session.
+// POST /api/v1/copy/signals
+func (h *CopyTradingHandler) ReceiveSignal(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxCopySignalBytes+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Failed to read signal"})
+	}
+	if len(body) > maxCopySignalBytes {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "Signal too large"})
+	}
+
+	req := c.Request()
+	result, err := h.orchestrator.ReceiveCopySignal(
+		req.Header.Get(orchestrator.CopyLeaderHeader),
+		req.Header.Get(orchestrator.CopyTimestampHeader),
+		req.Header.Get(orchestrator.CopySignatureHeader),
+		body,
+	)
+	switch {
+	case errors.Is(err, orchestrator.ErrCopyFollowingDisabled):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrUnknownLeader), errors.Is(err, orchestrator.ErrBadSignature),
+		errors.Is(err, orchestrator.ErrStaleSignal):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrDuplicateSignal):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
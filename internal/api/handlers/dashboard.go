@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/eth-trading/internal/execution"
@@ -43,6 +44,15 @@ type PerformanceData struct {
 	TotalTrades      int     `json:"totalTrades"`
 	WinningTrades    int     `json:"winningTrades"`
 	LosingTrades     int     `json:"losingTrades"`
+	Turnover         float64 `json:"turnover"`
+	TradesPerMonth   float64 `json:"tradesPerMonth"`
+	AvgExposureTime  string  `json:"avgExposureTime"`
+	TimeInMarket     float64 `json:"timeInMarket"`
+
+	DrawdownDuration    string  `json:"drawdownDuration"` // Time below the equity high-water mark so far
+	MaxDrawdownDuration string  `json:"maxDrawdownDuration"`
+	LongestFlatPeriod   string  `json:"longestFlatPeriod"`
+	UlcerIndex          float64 `json:"ulcerIndex"`
 }
 
 // TradeData represents a trade for API response
@@ -148,15 +158,66 @@ func (h *DashboardHandler) GetPerformance(c echo.Context) error {
 
 	state := h.orchestrator.GetState()
 
+	activity := h.orchestrator.GetActivityMetrics()
+
 	performance := &PerformanceData{
-		MaxDrawdown:   state.MaxDrawdown,
-		WinRate:       state.WinRate,
-		TotalTrades:   state.TotalTrades,
+		MaxDrawdown:     state.MaxDrawdown,
+		WinRate:         state.WinRate,
+		TotalTrades:     state.TotalTrades,
+		Turnover:        activity.Turnover,
+		TradesPerMonth:  activity.TradesPerMonth,
+		AvgExposureTime: activity.AvgExposureTime,
+		TimeInMarket:    activity.TimeInMarket,
+
+		DrawdownDuration:    activity.DrawdownDuration,
+		MaxDrawdownDuration: activity.MaxDrawdownDuration,
+		LongestFlatPeriod:   activity.LongestFlatPeriod,
+		UlcerIndex:          activity.UlcerIndex,
 	}
 
 	return c.JSON(http.StatusOK, performance)
 }
 
+// GetVersionPerformance returns realized performance broken down by the
+// strategy parameter version each trade was entered under
+func (h *DashboardHandler) GetVersionPerformance(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	report, err := h.orchestrator.GetParamVersionPerformance(c.QueryParam("strategy"))
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetSessionPerformance returns win rate, volatility and volume broken down
+// by trading session
+// GET /api/v1/dashboard/performance/sessions?strategy=&days=30
+func (h *DashboardHandler) GetSessionPerformance(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	days := 30
+	if v := c.QueryParam("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 365 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "days must be between 1 and 365"})
+		}
+		days = n
+	}
+
+	report, err := h.orchestrator.GetSessionPerformance(c.QueryParam("strategy"), days)
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
 // Helper to convert execution position to API position
 func convertPosition(pos *execution.Position) PositionData {
 	duration := time.Since(pos.OpenTime)
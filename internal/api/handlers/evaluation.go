@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// EvaluationHandler reports the strategy evaluation worker pool
+type EvaluationHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewEvaluationHandler creates a new evaluation handler
+func NewEvaluationHandler(orch *orchestrator.Orchestrator) *EvaluationHandler {
+	return &EvaluationHandler{orchestrator: orch}
+}
+
+// GetEvaluation returns the evaluation workers in use and the queueing
+// delay of primary and background evaluations
+// GET /api/v1/metrics/evaluation
+func (h *EvaluationHandler) GetEvaluation(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	stats, ok := h.orchestrator.GetEvalPoolStats()
+	if !ok {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Evaluation pool not configured"})
+	}
+	return c.JSON(http.StatusOK, stats)
+}
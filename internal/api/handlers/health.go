@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// HealthHandler handles liveness and loop health endpoints
+type HealthHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(orch *orchestrator.Orchestrator) *HealthHandler {
+	return &HealthHandler{orchestrator: orch}
+}
+
+// HealthzResponse represents the detailed health response
+type HealthzResponse struct {
+	Status    string                       `json:"status"`
+	Running   bool                         `json:"running"`
+	Uptime    string                       `json:"uptime"`
+	Loops     []orchestrator.LoopHealth    `json:"loops"`
+	Heartbeat orchestrator.HeartbeatStatus `json:"heartbeat"`
+	Timestamp time.Time                    `json:"timestamp"`
+}
+
+// Healthz reports the health of the internal trading loops.
+// Returns 503 if the orchestrator is stopped or any loop is dead or stalled.
+func (h *HealthHandler) Healthz(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	state := h.orchestrator.GetState()
+
+	resp := HealthzResponse{
+		Status:    "healthy",
+		Running:   state.IsRunning,
+		Loops:     h.orchestrator.GetLoopHealth(),
+		Heartbeat: h.orchestrator.GetHeartbeatStatus(),
+		Timestamp: time.Now(),
+	}
+	if !state.StartTime.IsZero() {
+		resp.Uptime = time.Since(state.StartTime).Round(time.Second).String()
+	}
+
+	if !h.orchestrator.IsHealthy() {
+		resp.Status = "unhealthy"
+		return c.JSON(http.StatusServiceUnavailable, resp)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
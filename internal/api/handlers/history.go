@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/labstack/echo/v4"
+)
+
+// HistoryHandler serves persisted trade and position history
+type HistoryHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewHistoryHandler creates a new history handler
+func NewHistoryHandler(orch *orchestrator.Orchestrator) *HistoryHandler {
+	return &HistoryHandler{orchestrator: orch}
+}
+
+// TradeHistoryData represents a persisted fill. Partial fills of one order
+// are merged at their average price.
+type TradeHistoryData struct {
+	ID              int64   `json:"id"`
+	OrderID         string  `json:"orderId"`
+	Symbol          string  `json:"symbol"`
+	Side            string  `json:"side"`
+	Type            string  `json:"type"`
+	Quantity        float64 `json:"quantity"`
+	Price           float64 `json:"price"`
+	Commission      float64 `json:"commission"`
+	CommissionAsset string  `json:"commissionAsset"`
+	Strategy        string  `json:"strategy"`
+	ExecutedAt      int64   `json:"executedAt"`
+	Session         string  `json:"session"` // Trading session of the fill
+}
+
+// PositionHistoryData represents a persisted position
+type PositionHistoryData struct {
+	ID            int64   `json:"id"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	EntryPrice    float64 `json:"entryPrice"`
+	Quantity      float64 `json:"quantity"`
+	CurrentPrice  float64 `json:"currentPrice"` // Exit price once closed
+	UnrealizedPnL float64 `json:"unrealizedPnl"`
+	RealizedPnL   float64 `json:"realizedPnl"`
+	StopLoss      float64 `json:"stopLoss"`
+	TakeProfit    float64 `json:"takeProfit"`
+	Strategy      string  `json:"strategy"`
+	Status        string  `json:"status"`
+	OpenedAt      int64   `json:"openedAt"`
+	ClosedAt      int64   `json:"closedAt,omitempty"`
+	Session       string  `json:"session"` // Trading session of the entry
+
+	PnL *PositionPnLData `json:"pnl,omitempty"`
+}
+
+// PositionPnLData breaks a position's P&L into price move, fees, funding
+// and slippage. Costs are positive; slippage is included in grossPnl.
+type PositionPnLData struct {
+	GrossPnL        float64 `json:"grossPnl"`
+	EntryCommission float64 `json:"entryCommission"`
+	ExitCommission  float64 `json:"exitCommission"`
+	FundingCost     float64 `json:"fundingCost"`
+	EntrySlippage   float64 `json:"entrySlippage"`
+	ExitSlippage    float64 `json:"exitSlippage"`
+	NetPnL          float64 `json:"netPnl"`
+}
+
+// GetTrades returns persisted trades, newest first
+// GET /api/v1/history/trades?strategy=&from=<ms>&to=<ms>&limit=100
+func (h *HistoryHandler) GetTrades(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var from, to time.Time
+	if v := c.QueryParam("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid from timestamp"})
+		}
+		from = time.UnixMilli(ms)
+	}
+	if v := c.QueryParam("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid to timestamp"})
+		}
+		to = time.UnixMilli(ms)
+	}
+	if !to.IsZero() && from.After(to) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from must be before to"})
+	}
+
+	trades, err := h.orchestrator.GetTradeHistory(c.QueryParam("strategy"), from, to, historyLimit(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	result := make([]TradeHistoryData, len(trades))
+	for i, t := range trades {
+		result[i] = TradeHistoryData{
+			ID:              t.ID,
+			OrderID:         t.OrderID,
+			Symbol:          t.Symbol,
+			Side:            t.Side,
+			Type:            t.Type,
+			Quantity:        t.Quantity,
+			Price:           t.Price,
+			Commission:      t.Commission,
+			CommissionAsset: t.CommissionAsset,
+			Strategy:        t.Strategy,
+			ExecutedAt:      t.ExecutedAt.UnixMilli(),
+			Session:         string(strategy.SessionAt(t.ExecutedAt)),
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetPositions returns persisted positions: open ones, or closed ones
+// newest first
+// GET /api/v1/history/positions?status=closed&limit=100
+func (h *HistoryHandler) GetPositions(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	status := c.QueryParam("status")
+	if status == "" {
+		status = "closed"
+	}
+	if status != "open" && status != "closed" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "status must be open or closed"})
+	}
+
+	positions, err := h.orchestrator.GetPositionHistory(status, historyLimit(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	ids := make([]int64, len(positions))
+	for i, p := range positions {
+		ids[i] = p.ID
+	}
+	breakdowns, err := h.orchestrator.GetPositionPnL(ids)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	result := make([]PositionHistoryData, len(positions))
+	for i, p := range positions {
+		result[i] = convertPositionHistory(p)
+		if b, ok := breakdowns[p.ID]; ok {
+			result[i].PnL = &PositionPnLData{
+				GrossPnL:        b.GrossPnL,
+				EntryCommission: b.EntryCommission,
+				ExitCommission:  b.ExitCommission,
+				FundingCost:     b.FundingCost,
+				EntrySlippage:   b.EntrySlippage,
+				ExitSlippage:    b.ExitSlippage,
+				NetPnL:          b.NetPnL,
+			}
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// HistoryImportRequest selects what to import from the exchange
+type HistoryImportRequest struct {
+	Symbols []string `json:"symbols"` // Defaults to the traded symbol
+	Until   int64    `json:"until"`   // Unix ms; defaults to the bot's first recorded trade
+}
+
+// StartImport starts importing fills from the exchange's order history,
+// tagged as strategy "imported"
+// POST /api/v1/history/import
+func (h *HistoryHandler) StartImport(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req HistoryImportRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	var until time.Time
+	if req.Until > 0 {
+		until = time.UnixMilli(req.Until)
+	}
+
+	report, err := h.orchestrator.StartHistoryImport(req.Symbols, until)
+	if err != nil {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, report)
+}
+
+// GetImport returns the progress or outcome of the latest import
+// GET /api/v1/history/import
+func (h *HistoryHandler) GetImport(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	report := h.orchestrator.GetHistoryImport()
+	if report == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "No import has run"})
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// historyLimit reads the limit query parameter (default 100, max 500)
+func historyLimit(c echo.Context) int {
+	limit := 100
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 && l <= 500 {
+		limit = l
+	}
+	return limit
+}
+
+// convertPositionHistory converts a stored position for the API
+func convertPositionHistory(p storage.Position) PositionHistoryData {
+	data := PositionHistoryData{
+		ID:            p.ID,
+		Symbol:        p.Symbol,
+		Side:          p.Side,
+		EntryPrice:    p.EntryPrice,
+		Quantity:      p.Quantity,
+		CurrentPrice:  p.CurrentPrice,
+		UnrealizedPnL: p.UnrealizedPnL,
+		RealizedPnL:   p.RealizedPnL,
+		StopLoss:      p.StopLoss,
+		TakeProfit:    p.TakeProfit,
+		Strategy:      p.Strategy,
+		Status:        p.Status,
+		OpenedAt:      p.OpenedAt.UnixMilli(),
+		Session:       string(strategy.SessionAt(p.OpenedAt)),
+	}
+	if p.ClosedAt != nil {
+		data.ClosedAt = p.ClosedAt.UnixMilli()
+	}
+	return data
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// InboxHandler handles trade ideas waiting for manual approval
+type InboxHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewInboxHandler creates a new inbox handler
+func NewInboxHandler(orch *orchestrator.Orchestrator) *InboxHandler {
+	return &InboxHandler{orchestrator: orch}
+}
+
+// InboxResponse represents the trade idea inbox
+type InboxResponse struct {
+	Enabled bool                     `json:"enabled"`
+	Ideas   []orchestrator.TradeIdea `json:"ideas"`
+}
+
+// IdeaDecisionRequest optionally records why an idea was approved or rejected
+type IdeaDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// GetInbox returns trade ideas with their reasoning
+// GET /api/v1/inbox?status=pending|approved|rejected|expired|failed|all&limit=100
+func (h *InboxHandler) GetInbox(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	status := c.QueryParam("status")
+	switch status {
+	case "", "all", orchestrator.IdeaPending, orchestrator.IdeaApproved, orchestrator.IdeaRejected,
+		orchestrator.IdeaExpired, orchestrator.IdeaFailed:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid status"})
+	}
+
+	limit := 100
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	return c.JSON(http.StatusOK, InboxResponse{
+		Enabled: h.orchestrator.InboxEnabled(),
+		Ideas:   h.orchestrator.GetInbox(status, limit),
+	})
+}
+
+// ApproveIdea executes a pending trade idea
+// POST /api/v1/inbox/:id/approve
+func (h *InboxHandler) ApproveIdea(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req IdeaDecisionRequest
+	_ = c.Bind(&req)
+
+	idea, err := h.orchestrator.ApproveIdea(c.Param("id"), requestActor(c), req.Note)
+	if idea == nil {
+		return ideaError(c, err)
+	}
+	if err != nil {
+		// Confirmed, but the risk check or the exchange refused the order
+		return c.JSON(http.StatusUnprocessableEntity, idea)
+	}
+
+	return c.JSON(http.StatusOK, idea)
+}
+
+// RejectIdea discards a pending trade idea
+// POST /api/v1/inbox/:id/reject
+func (h *InboxHandler) RejectIdea(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req IdeaDecisionRequest
+	_ = c.Bind(&req)
+
+	idea, err := h.orchestrator.RejectIdea(c.Param("id"), requestActor(c), req.Note)
+	if err != nil {
+		return ideaError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, idea)
+}
+
+// ideaError maps an inbox lookup error to a response
+func ideaError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, orchestrator.ErrIdeaNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrIdeaNotPending):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
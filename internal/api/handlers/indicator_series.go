@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
+	"github.com/labstack/echo/v4"
+)
+
+// IndicatorSeriesHandler serves precomputed indicator series
+type IndicatorSeriesHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewIndicatorSeriesHandler creates a new indicator series handler
+func NewIndicatorSeriesHandler(orch *orchestrator.Orchestrator) *IndicatorSeriesHandler {
+	return &IndicatorSeriesHandler{orchestrator: orch}
+}
+
+// IndicatorSeriesResponse holds precomputed series in columnar form
+type IndicatorSeriesResponse struct {
+	Timeframe string                 `json:"timeframe"`
+	Columns   []IndicatorColumnValue `json:"columns"`
+}
+
+// IndicatorColumnValue is one series with open times in Unix milliseconds
+type IndicatorColumnValue struct {
+	Name   string    `json:"name"`
+	Times  []int64   `json:"times"`
+	Values []float64 `json:"values"`
+}
+
+// IndicatorCoverageResponse describes which series are stored
+type IndicatorCoverageResponse struct {
+	Timeframe string                                   `json:"timeframe"`
+	Available []string                                 `json:"available"`
+	Stored    []storage.IndicatorCoverage              `json:"stored"`
+	LastRuns  []orchestrator.IndicatorPrecomputeResult `json:"lastRuns"`
+}
+
+// PrecomputeRequest selects what a precompute run computes
+type PrecomputeRequest struct {
+	Timeframe string   `json:"timeframe"`
+	Columns   []string `json:"columns"` // Empty = configured columns
+	Full      bool     `json:"full"`    // Rewrite all values, not only new bars
+}
+
+// GetSeries returns precomputed indicator series
+// GET /api/v1/indicators/series?timeframe=1h&columns=rsi,atr&from=<ms>&to=<ms>
+func (h *IndicatorSeriesHandler) GetSeries(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var columns []string
+	if v := c.QueryParam("columns"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if !indicators.ValidSeriesColumn(name) {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Unknown indicator series: " + name})
+			}
+			columns = append(columns, name)
+		}
+	}
+
+	var from, to time.Time
+	if v := c.QueryParam("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid from timestamp"})
+		}
+		from = time.UnixMilli(ms)
+	}
+	if v := c.QueryParam("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid to timestamp"})
+		}
+		to = time.UnixMilli(ms)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.After(to) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from must be before to"})
+	}
+
+	timeframe := c.QueryParam("timeframe")
+	series, err := h.orchestrator.GetIndicatorSeries(timeframe, columns, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := IndicatorSeriesResponse{
+		Timeframe: timeframe,
+		Columns:   make([]IndicatorColumnValue, 0, len(series)),
+	}
+	for _, column := range series {
+		value := IndicatorColumnValue{
+			Name:   column.Name,
+			Times:  make([]int64, len(column.Times)),
+			Values: column.Values,
+		}
+		if value.Values == nil {
+			value.Values = []float64{}
+		}
+		for i, t := range column.Times {
+			value.Times[i] = t.UnixMilli()
+		}
+		response.Columns = append(response.Columns, value)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetCoverage lists the stored series and recent precompute runs
+// GET /api/v1/indicators/series/coverage?timeframe=1h
+func (h *IndicatorSeriesHandler) GetCoverage(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	timeframe := c.QueryParam("timeframe")
+	stored, err := h.orchestrator.GetIndicatorCoverage(timeframe)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, IndicatorCoverageResponse{
+		Timeframe: timeframe,
+		Available: indicators.SeriesColumns(),
+		Stored:    stored,
+		LastRuns:  h.orchestrator.GetLastPrecompute(),
+	})
+}
+
+// Precompute runs the precompute job for a timeframe
+// POST /api/v1/indicators/precompute
+func (h *IndicatorSeriesHandler) Precompute(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req PrecomputeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	for _, name := range req.Columns {
+		if !indicators.ValidSeriesColumn(name) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Unknown indicator series: " + name})
+		}
+	}
+
+	result, err := h.orchestrator.PrecomputeIndicators(req.Timeframe, req.Columns, req.Full)
+	if errors.Is(err, orchestrator.ErrPrecomputeRunning) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// LatencyHandler reports trading pipeline latency
+type LatencyHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewLatencyHandler creates a new latency handler
+func NewLatencyHandler(orch *orchestrator.Orchestrator) *LatencyHandler {
+	return &LatencyHandler{orchestrator: orch}
+}
+
+// GetLatency returns per-stage timings from candle close to order against
+// their budgets, and the most recent budget breaches
+// GET /api/v1/metrics/latency
+func (h *LatencyHandler) GetLatency(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetLatency())
+}
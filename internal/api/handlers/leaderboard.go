@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// Bounds of the leaderboard's ?window=
+const (
+	defaultLeaderboardWindow = 30 * 24 * time.Hour
+	maxLeaderboardWindow     = 365 * 24 * time.Hour
+)
+
+// GetLeaderboard ranks strategies by rolling Sharpe, win rate, expectancy,
+// max drawdown and trade count over the positions they closed in a window
+// GET /api/v1/strategies/leaderboard?window=30d&sort=sharpe
+func (h *StrategyHandler) GetLeaderboard(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	window, err := parseLeaderboardWindow(c.QueryParam("window"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	sortBy, err := orchestrator.ParseLeaderboardSort(c.QueryParam("sort"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	board, err := h.orchestrator.GetStrategyLeaderboard(window, sortBy)
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, board)
+}
+
+// parseLeaderboardWindow reads a window in days ("30d"), as a Go duration
+// ("12h") or "all"; empty selects 30 days
+func parseLeaderboardWindow(s string) (time.Duration, error) {
+	switch s {
+	case "":
+		return defaultLeaderboardWindow, nil
+	case "all":
+		return 0, nil
+	}
+
+	var window time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q (e.g. 7d, 30d, 12h or all)", s)
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q (e.g. 7d, 30d, 12h or all)", s)
+		}
+		window = d
+	}
+	if window < time.Hour || window > maxLeaderboardWindow {
+		return 0, fmt.Errorf("window must be between 1h and 365d")
+	}
+	return window, nil
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/logstream"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// logKeepAlive is the time between SSE comments that keep idle proxies
+// from closing the stream
+const logKeepAlive = 15 * time.Second
+
+// LogHandler streams application log events
+type LogHandler struct {
+	stream *logstream.Stream
+}
+
+// NewLogHandler creates a new log handler
+func NewLogHandler(stream *logstream.Stream) *LogHandler {
+	return &LogHandler{stream: stream}
+}
+
+// StreamLogs tails structured log events as server-sent events. Recent
+// events are replayed first; a reconnecting client's Last-Event-ID resumes
+// after the last event it saw.
+// GET /api/v1/logs/stream?level=warn&category=order&tail=100
+func (h *LogHandler) StreamLogs(c echo.Context) error {
+	if h.stream == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Log stream not available"})
+	}
+
+	filter, err := logFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	tail := 100
+	if v := c.QueryParam("tail"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > logstream.DefaultCapacity {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid tail"})
+		}
+		tail = n
+	}
+	var afterSeq int64
+	if v := c.Request().Header.Get("Last-Event-ID"); v != "" {
+		if seq, err := strconv.ParseInt(v, 10, 64); err == nil {
+			afterSeq = seq
+			tail = logstream.DefaultCapacity
+		}
+	}
+
+	// Subscribe before replaying so nothing logged in between is lost
+	sub, unsubscribe := h.stream.Subscribe(filter)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	var lastSeq int64
+	if tail > 0 {
+		for _, event := range h.stream.Recent(filter, afterSeq, tail) {
+			if err := writeLogEvent(res, event); err != nil {
+				return nil
+			}
+			lastSeq = event.Seq
+		}
+	}
+	res.Flush()
+
+	keepAlive := time.NewTicker(logKeepAlive)
+	defer keepAlive.Stop()
+
+	var reportedDrops int64
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-sub.C:
+			if event.Seq <= lastSeq {
+				// Already sent during the replay
+				continue
+			}
+			if err := writeLogEvent(res, event); err != nil {
+				return nil
+			}
+			lastSeq = event.Seq
+		case <-keepAlive.C:
+			if dropped := h.stream.Dropped(sub); dropped > reportedDrops {
+				// Tell the client it fell behind instead of silently skipping
+				fmt.Fprintf(res, "event: dropped\ndata: {\"dropped\":%d}\n\n", dropped-reportedDrops)
+				reportedDrops = dropped
+			} else if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// GetLogs returns recent log events as JSON, oldest first
+// GET /api/v1/logs?level=warn&category=order&limit=100
+func (h *LogHandler) GetLogs(c echo.Context) error {
+	if h.stream == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Log stream not available"})
+	}
+
+	filter, err := logFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	limit := 100
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 && l <= logstream.DefaultCapacity {
+		limit = l
+	}
+
+	events := h.stream.Recent(filter, 0, limit)
+	if events == nil {
+		events = []logstream.Event{}
+	}
+	return c.JSON(http.StatusOK, events)
+}
+
+// logFilter reads the minimum level and category query parameters
+func logFilter(c echo.Context) (logstream.Filter, error) {
+	filter := logstream.Filter{MinLevel: zerolog.InfoLevel}
+	if v := c.QueryParam("level"); v != "" {
+		level, err := zerolog.ParseLevel(v)
+		if err != nil || level == zerolog.NoLevel {
+			return filter, fmt.Errorf("invalid level: %s", v)
+		}
+		filter.MinLevel = level
+	}
+	switch category := c.QueryParam("category"); category {
+	case "", logstream.CategoryApp, logstream.CategoryOrder, logstream.CategoryHTTP:
+		filter.Category = category
+	default:
+		return filter, fmt.Errorf("invalid category: %s", category)
+	}
+	return filter, nil
+}
+
+// writeLogEvent writes one event in SSE framing with its sequence as the ID
+func writeLogEvent(res *echo.Response, event logstream.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil
+	}
+	_, err = fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Level, data)
+	return err
+}
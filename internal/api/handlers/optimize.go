@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultOptimizeTop is the number of ranked runs returned by default
+const defaultOptimizeTop = 20
+
+// OptimizeRequest runs a parameter search over the backtest described by
+// the embedded request
+type OptimizeRequest struct {
+	BacktestRequest
+
+	// Parameter ranges keyed "<strategy>.<Field>" or "indicators.<Field>",
+	// e.g. {"trend_following.FastMAPeriod": {"min": 5, "max": 20, "step": 5}}
+	Params map[string]backtest.ParamRange `json:"params"`
+
+	Mode      string `json:"mode"`      // grid (default) or random
+	Samples   int    `json:"samples"`   // Random mode combinations
+	Seed      int64  `json:"seed"`      // Random mode seed, to repeat a search
+	Workers   int    `json:"workers"`   // Parallel backtests, capped at the CPU count
+	Objective string `json:"objective"` // sharpe (default), sortino, calmar, net_profit, profit_factor
+	MinTrades int    `json:"minTrades"` // Runs with fewer trades rank last
+	Top       int    `json:"top"`       // Ranked runs returned
+}
+
+// OptimizeResponse holds the ranked runs of a parameter search
+type OptimizeResponse struct {
+	Objective     string                `json:"objective"`
+	Mode          string                `json:"mode"`
+	Seed          int64                 `json:"seed,omitempty"`
+	Combinations  int                   `json:"combinations"`
+	Runs          int                   `json:"runs"`
+	Failed        int                   `json:"failed"`
+	Best          *OptimizationRunData  `json:"best,omitempty"`
+	Results       []OptimizationRunData `json:"results"`
+	ExecutionTime string                `json:"executionTime"`
+
+	// Strategies whose parameters of the best run were recorded as the
+	// optimized values drift is measured against
+	Recorded []string `json:"recorded,omitempty"`
+}
+
+// OptimizationRunData represents one backtest of a parameter search
+type OptimizationRunData struct {
+	Rank      int                  `json:"rank"`
+	Params    map[string]float64   `json:"params"`
+	Score     float64              `json:"score"`
+	Qualified bool                 `json:"qualified"`
+	Metrics   *BacktestMetricsData `json:"metrics,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// Optimize runs a grid or random parameter search and ranks the backtests
+// POST /api/v1/backtest/optimize
+func (h *BacktestHandler) Optimize(c echo.Context) error {
+	var req OptimizeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if len(req.Params) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "No parameters to optimize"})
+	}
+	objective, err := backtest.ParseObjective(req.Objective)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if req.Top <= 0 {
+		req.Top = defaultOptimizeTop
+	}
+	if req.Workers <= 0 || req.Workers > runtime.NumCPU() {
+		req.Workers = runtime.NumCPU()
+	}
+
+	btConfig, historicalData, err := h.prepareBacktest(&req.BacktestRequest)
+	if err != nil {
+		return httpErrorJSON(c, err)
+	}
+
+	optimizerConfig := &backtest.OptimizerConfig{
+		Base:      btConfig,
+		Params:    req.Params,
+		Mode:      req.Mode,
+		Samples:   req.Samples,
+		Seed:      req.Seed,
+		Workers:   req.Workers,
+		Objective: objective,
+		MinTrades: req.MinTrades,
+		Top:       req.Top,
+	}
+	result, err := backtest.Optimize(c.Request().Context(), optimizerConfig, historicalData)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Optimization failed: %v", err)})
+	}
+
+	response := OptimizeResponse{
+		Objective:     string(result.Objective),
+		Mode:          result.Mode,
+		Seed:          result.Seed,
+		Combinations:  result.Combinations,
+		Runs:          result.Runs,
+		Failed:        result.Failed,
+		Results:       make([]OptimizationRunData, len(result.Results)),
+		ExecutionTime: result.ExecutionTime.String(),
+	}
+	for i, run := range result.Results {
+		response.Results[i] = convertOptimizationRun(i+1, run)
+	}
+	if result.Best != nil {
+		best := convertOptimizationRun(1, *result.Best)
+		response.Best = &best
+		response.Recorded = h.orchestrator.RecordOptimizedParams(optimizerConfig, result)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// convertOptimizationRun converts an optimizer run for the API
+func convertOptimizationRun(rank int, run backtest.OptimizationRun) OptimizationRunData {
+	data := OptimizationRunData{
+		Rank:      rank,
+		Params:    run.Params,
+		Score:     run.Score,
+		Qualified: run.Qualified,
+		Error:     run.Error,
+	}
+	if run.Metrics != nil {
+		data.Metrics = convertMetrics(run.Metrics)
+	}
+	return data
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/eth-trading/internal/execution"
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
 	"github.com/labstack/echo/v4"
 )
 
@@ -20,6 +23,7 @@ func NewOrderHandler(orch *orchestrator.Orchestrator) *OrderHandler {
 // OrderData represents order data for API
 type OrderData struct {
 	ID             string  `json:"id"`
+	ClientID       string  `json:"clientId,omitempty"`
 	Symbol         string  `json:"symbol"`
 	Side           string  `json:"side"`
 	Type           string  `json:"type"`
@@ -30,34 +34,137 @@ type OrderData struct {
 	FilledQuantity float64 `json:"filledQuantity"`
 	AvgFillPrice   float64 `json:"avgFillPrice"`
 	Strategy       string  `json:"strategy,omitempty"`
-	CreatedAt      string  `json:"createdAt"`
-	UpdatedAt      string  `json:"updatedAt"`
+	CreatedAt      int64   `json:"createdAt"`
+	UpdatedAt      int64   `json:"updatedAt"`
+
+	Transitions []OrderTransitionData `json:"transitions"`
+}
+
+// OrderTransitionData represents one status change of an order
+type OrderTransitionData struct {
+	From   string `json:"from,omitempty"` // Empty for the first status
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+	At     int64  `json:"at"`
 }
 
-// GetOrders returns all orders
+// GetOrders returns persisted orders, most recently updated first
+// GET /api/v1/orders?symbol=&status=&strategy=&limit=100
 func (h *OrderHandler) GetOrders(c echo.Context) error {
-	symbol := c.QueryParam("symbol")
-	status := c.QueryParam("status")
-	limit := c.QueryParam("limit")
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
 
-	// In real implementation, would fetch from executor
-	_ = symbol
-	_ = status
-	_ = limit
+	status := strings.ToUpper(c.QueryParam("status"))
+	orders, transitions, err := h.orchestrator.GetOrderHistory(c.QueryParam("symbol"), status, c.QueryParam("strategy"), historyLimit(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	result := make([]OrderData, len(orders))
+	for i, order := range orders {
+		result[i] = convertStoredOrder(order, transitions[order.OrderID])
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetOrder returns a persisted order with its status history
+// GET /api/v1/orders/:id
+func (h *OrderHandler) GetOrder(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	order, transitions, err := h.orchestrator.GetStoredOrder(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if order == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Order not found"})
+	}
 
-	orders := []OrderData{}
-	return c.JSON(http.StatusOK, orders)
+	return c.JSON(http.StatusOK, convertStoredOrder(*order, transitions))
 }
 
-// GetOpenOrders returns all open orders
+// GetOpenOrders returns the executor's working orders
+// GET /api/v1/orders/open?symbol=
 func (h *OrderHandler) GetOpenOrders(c echo.Context) error {
-	symbol := c.QueryParam("symbol")
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	orders, err := h.orchestrator.GetOpenOrders(c.QueryParam("symbol"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
 
-	// In real implementation, would fetch from executor
-	_ = symbol
+	result := make([]OrderData, len(orders))
+	for i, order := range orders {
+		result[i] = convertOrder(order)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
 
-	orders := []OrderData{}
-	return c.JSON(http.StatusOK, orders)
+// convertOrder converts an executor order for the API
+func convertOrder(order *execution.Order) OrderData {
+	data := OrderData{
+		ID:             order.ID,
+		ClientID:       order.ClientID,
+		Symbol:         order.Symbol,
+		Side:           string(order.Side),
+		Type:           string(order.Type),
+		Quantity:       order.Quantity,
+		Price:          order.Price,
+		StopPrice:      order.StopPrice,
+		Status:         string(order.Status),
+		FilledQuantity: order.FilledQuantity,
+		AvgFillPrice:   order.AvgFillPrice,
+		Strategy:       order.Strategy,
+		CreatedAt:      order.CreatedAt.UnixMilli(),
+		UpdatedAt:      order.UpdatedAt.UnixMilli(),
+		Transitions:    make([]OrderTransitionData, len(order.Transitions)),
+	}
+	for i, t := range order.Transitions {
+		data.Transitions[i] = OrderTransitionData{
+			From:   string(t.From),
+			To:     string(t.To),
+			Reason: t.Reason,
+			At:     t.At.UnixMilli(),
+		}
+	}
+	return data
+}
+
+// convertStoredOrder converts a persisted order for the API
+func convertStoredOrder(order storage.Order, transitions []storage.OrderTransition) OrderData {
+	data := OrderData{
+		ID:             order.OrderID,
+		ClientID:       order.ClientOrderID,
+		Symbol:         order.Symbol,
+		Side:           order.Side,
+		Type:           order.Type,
+		Quantity:       order.Quantity,
+		Price:          order.Price,
+		StopPrice:      order.StopPrice,
+		Status:         order.Status,
+		FilledQuantity: order.FilledQuantity,
+		AvgFillPrice:   order.AvgFillPrice,
+		Strategy:       order.Strategy,
+		CreatedAt:      order.CreatedAt.UnixMilli(),
+		UpdatedAt:      order.UpdatedAt.UnixMilli(),
+		Transitions:    make([]OrderTransitionData, len(transitions)),
+	}
+	for i, t := range transitions {
+		data.Transitions[i] = OrderTransitionData{
+			From:   t.FromStatus,
+			To:     t.ToStatus,
+			Reason: t.Reason,
+			At:     t.At.UnixMilli(),
+		}
+	}
+	return data
 }
 
 // PlaceOrderRequest represents order placement request
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// Parameter sources the apply endpoint takes with ?source=
+const (
+	paramSourceOptimized = "optimized"
+	paramSourceSuggested = "suggested"
+)
+
+// GetParamDrift compares each strategy's configured parameters with those
+// recorded by the optimizer and, when re-optimization runs, those it suggests
+// GET /api/v1/strategies/drift
+func (h *StrategyHandler) GetParamDrift(c echo.Context) error {
+	if h.orchestrator.GetStrategyManager() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"strategies": h.orchestrator.GetParamDrift(),
+	})
+}
+
+// ApplyOptimizedParams reconfigures a running strategy with its optimized
+// parameters, or with the re-optimized ones when source=suggested. The
+// change lasts until restart; update config.yaml to keep it.
+// POST /api/v1/strategies/:name/apply-optimized?source=optimized|suggested
+func (h *StrategyHandler) ApplyOptimizedParams(c echo.Context) error {
+	if h.orchestrator.GetStrategyManager() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
+	}
+
+	source := c.QueryParam("source")
+	switch source {
+	case "":
+		source = paramSourceOptimized
+	case paramSourceOptimized, paramSourceSuggested:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "source must be optimized or suggested"})
+	}
+
+	report, err := h.orchestrator.ApplyOptimizedParams(c.Param("name"), source == paramSourceSuggested, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrStrategyNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Strategy not found"})
+	case errors.Is(err, orchestrator.ErrNoOptimizedParams), errors.Is(err, orchestrator.ErrNoSuggestedParams):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Failed to apply parameters: " + err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "applied",
+		"source": source,
+		"drift":  report,
+	})
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// RateLimitHandler reports Binance request weight usage
+type RateLimitHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewRateLimitHandler creates a new rate limit handler
+func NewRateLimitHandler(orch *orchestrator.Orchestrator) *RateLimitHandler {
+	return &RateLimitHandler{orchestrator: orch}
+}
+
+// GetRateLimit returns request weight used, waits and throttled responses
+// per Binance REST client
+// GET /api/v1/metrics/rate-limit
+func (h *RateLimitHandler) GetRateLimit(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetRequestWeight())
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eth-trading/internal/api/middleware"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// Report output formats selected with ?format=
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	reportFormatHTML = "html"
+)
+
+// ReportHandler manages how users' generated reports and exports are
+// formatted
+type ReportHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(orch *orchestrator.Orchestrator) *ReportHandler {
+	return &ReportHandler{orchestrator: orch}
+}
+
+// ReportLocaleResponse is a user's report locale and the presets available
+type ReportLocaleResponse struct {
+	orchestrator.ReportLocale
+	Locales []string `json:"locales"`
+}
+
+// GetLocale returns the caller's report locale
+// GET /api/v1/reports/locale
+func (h *ReportHandler) GetLocale(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	user, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	locale, err := h.orchestrator.GetReportLocale(user.String())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, ReportLocaleResponse{ReportLocale: locale, Locales: orchestrator.ReportLocales()})
+}
+
+// UpdateLocale sets the caller's report locale. Fields left out take the
+// value of the locale's preset.
+// PUT /api/v1/reports/locale (body: {"locale":"de-DE","timezone":"Europe/Berlin"})
+func (h *ReportHandler) UpdateLocale(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	user, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req orchestrator.ReportLocale
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	locale, err := h.orchestrator.SetReportLocale(user.String(), req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, ReportLocaleResponse{ReportLocale: locale, Locales: orchestrator.ReportLocales()})
+}
+
+// reportFormat returns the output format requested with ?format=
+func reportFormat(c echo.Context, formats ...string) (string, error) {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		return reportFormatJSON, nil
+	}
+	for _, f := range formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("format must be one of %s", strings.Join(formats, ", "))
+}
+
+// requestLocale returns the locale to format a generated report in: the
+// ?locale= preset when given, else the caller's own
+func requestLocale(c echo.Context, orch *orchestrator.Orchestrator) (orchestrator.ReportLocale, error) {
+	if name := c.QueryParam("locale"); name != "" {
+		return orchestrator.LookupReportLocale(name)
+	}
+	user, err := middleware.GetUserID(c)
+	if err != nil {
+		return orchestrator.LookupReportLocale(orchestrator.DefaultReportLocale)
+	}
+	return orch.GetReportLocale(user.String())
+}
+
+// localeError answers a request whose locale could not be resolved
+func localeError(c echo.Context, err error) error {
+	if errors.Is(err, orchestrator.ErrUnknownReportLocale) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load report locale: " + err.Error()})
+}
+
+// writeCSV sends rows as a CSV attachment separated the way the locale's
+// spreadsheets expect
+func writeCSV(c echo.Context, filename string, locale orchestrator.ReportLocale, rows [][]string) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = locale.CSVDelimiter()
+	if err := w.WriteAll(rows); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// riskReportColumns head the rows of a risk report table
+var riskReportColumns = []string{
+	"Day", "From", "To", "Signals", "Approved", "Executed", "Rejected",
+	"Daily loss peak", "Weekly loss peak", "Drawdown peak", "Positions peak",
+	"Circuit breakers", "Top rejection",
+}
+
+// riskReportRows formats daily risk reports as table rows, the day in
+// progress first
+func riskReportRows(reports orchestrator.RiskReports, locale orchestrator.ReportLocale) [][]string {
+	all := append([]orchestrator.RiskReport{reports.Current}, reports.Reports...)
+	rows := make([][]string, 0, len(all))
+	for _, r := range all {
+		if r.Day == "" {
+			continue
+		}
+		rows = append(rows, []string{
+			r.Day,
+			locale.Time(r.From),
+			locale.Time(r.To),
+			strconv.Itoa(r.SignalsGenerated),
+			strconv.Itoa(r.SignalsApproved),
+			strconv.Itoa(r.SignalsExecuted),
+			strconv.Itoa(r.SignalsRejected),
+			locale.Percent(r.LimitPeaks.DailyLoss * 100),
+			locale.Percent(r.LimitPeaks.WeeklyLoss * 100),
+			locale.Percent(r.LimitPeaks.Drawdown * 100),
+			locale.Percent(r.LimitPeaks.Positions * 100),
+			strconv.Itoa(len(r.CircuitBreakers)),
+			topCount(r.Rejections),
+		})
+	}
+	return rows
+}
+
+// topCount returns the most frequent key of a histogram, e.g. "RiskManager: Daily loss limit exceeded (4)"
+func topCount(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	top := ""
+	for _, k := range keys {
+		if top == "" || counts[k] > counts[top] {
+			top = k
+		}
+	}
+	if top == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d)", top, counts[top])
+}
+
+// riskReportPage renders daily risk reports as a standalone HTML page
+var riskReportPage = template.Must(template.New("risk").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head><meta charset="utf-8"><title>Daily risk reports</title>
+<style>body{font-family:sans-serif}table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:4px 8px}td{text-align:right}td:first-child,td:last-child{text-align:left}</style>
+</head>
+<body>
+<h1>Daily risk reports</h1>
+<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// writeRiskReportHTML sends daily risk reports as an HTML page
+func writeRiskReportHTML(c echo.Context, locale orchestrator.ReportLocale, rows [][]string) error {
+	var buf bytes.Buffer
+	err := riskReportPage.Execute(&buf, struct {
+		Lang    string
+		Columns []string
+		Rows    [][]string
+	}{Lang: locale.Locale, Columns: riskReportColumns, Rows: rows})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// shareTradeRows formats a share bundle's trades as CSV rows
+func shareTradeRows(bundle *orchestrator.ShareBundle, locale orchestrator.ReportLocale) [][]string {
+	rows := [][]string{{"Strategy", "Side", "Opened", "Closed", "Entry price", "Exit price", "Quantity", "P&L", "Return"}}
+	for _, t := range bundle.Trades {
+		rows = append(rows, []string{
+			t.Strategy,
+			t.Side,
+			locale.Time(t.OpenedAt),
+			locale.Time(t.ClosedAt),
+			locale.Number(t.EntryPrice, 2),
+			locale.Number(t.ExitPrice, 2),
+			locale.Number(t.Quantity, 6),
+			locale.Money(t.PnL),
+			locale.Percent(t.ReturnPct),
+		})
+	}
+	return rows
+}
@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/eth-trading/internal/orchestrator"
 	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/storage"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // RiskHandler handles risk management endpoints
@@ -47,6 +53,7 @@ type RiskStatusResponse struct {
 	HaltReason       string  `json:"haltReason,omitempty"`
 	IsWithinLimits   bool    `json:"isWithinLimits"`
 	Warnings         []string `json:"warnings,omitempty"`
+	Periods          *orchestrator.PnLPeriodStatus `json:"periods,omitempty"` // Calendar day and week the loss limits cover
 }
 
 // GetRiskStatus returns current risk status
@@ -74,6 +81,10 @@ func (h *RiskHandler) GetRiskStatus(c echo.Context) error {
 		IsWithinLimits:   limits.IsWithinLimits,
 		Warnings:         limits.LimitBreaches,
 	}
+	if h.orchestrator != nil {
+		periods := h.orchestrator.GetPnLPeriods()
+		response.Periods = &periods
+	}
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -87,6 +98,7 @@ type RiskConfigResponse struct {
 	MaxDailyLoss          float64 `json:"maxDailyLoss"`
 	MaxWeeklyLoss         float64 `json:"maxWeeklyLoss"`
 	MaxTotalDrawdown      float64 `json:"maxTotalDrawdown"`
+	HighWaterMarkMode     string  `json:"highWaterMarkMode"`
 	MaxOpenPositions      int     `json:"maxOpenPositions"`
 	MaxLeverage           float64 `json:"maxLeverage"`
 	EnableCircuitBreaker  bool    `json:"enableCircuitBreaker"`
@@ -111,6 +123,7 @@ func (h *RiskHandler) GetConfig(c echo.Context) error {
 		MaxDailyLoss:         config.MaxDailyLoss,
 		MaxWeeklyLoss:        config.MaxWeeklyLoss,
 		MaxTotalDrawdown:     config.MaxTotalDrawdown,
+		HighWaterMarkMode:    string(config.HighWaterMarkMode),
 		MaxOpenPositions:     config.MaxOpenPositions,
 		MaxLeverage:          config.MaxLeverage,
 		EnableCircuitBreaker: config.EnableCircuitBreaker,
@@ -127,6 +140,7 @@ type UpdateConfigRequest struct {
 	MaxRiskPerTrade      *float64 `json:"maxRiskPerTrade,omitempty"`
 	MaxDailyLoss         *float64 `json:"maxDailyLoss,omitempty"`
 	MaxTotalDrawdown     *float64 `json:"maxTotalDrawdown,omitempty"`
+	HighWaterMarkMode    *string  `json:"highWaterMarkMode,omitempty"`
 	MaxOpenPositions     *int     `json:"maxOpenPositions,omitempty"`
 	EnableCircuitBreaker *bool    `json:"enableCircuitBreaker,omitempty"`
 }
@@ -142,7 +156,12 @@ func (h *RiskHandler) UpdateConfig(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
-	config := h.riskManager.GetConfig()
+	if req.HighWaterMarkMode != nil && !risk.ValidHighWaterMarkMode(risk.HighWaterMarkMode(*req.HighWaterMarkMode)) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "High-water mark mode must be trailing, monthly or deposit_adjusted"})
+	}
+
+	previous := *h.riskManager.GetConfig()
+	config := previous
 
 	// Apply updates
 	if req.MaxRiskPerTrade != nil {
@@ -154,6 +173,9 @@ func (h *RiskHandler) UpdateConfig(c echo.Context) error {
 	if req.MaxTotalDrawdown != nil {
 		config.MaxTotalDrawdown = *req.MaxTotalDrawdown
 	}
+	if req.HighWaterMarkMode != nil {
+		config.HighWaterMarkMode = risk.HighWaterMarkMode(*req.HighWaterMarkMode)
+	}
 	if req.MaxOpenPositions != nil {
 		config.MaxOpenPositions = *req.MaxOpenPositions
 	}
@@ -161,27 +183,118 @@ func (h *RiskHandler) UpdateConfig(c echo.Context) error {
 		config.EnableCircuitBreaker = *req.EnableCircuitBreaker
 	}
 
-	h.riskManager.UpdateConfig(config)
+	h.riskManager.UpdateConfig(&config)
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditConfig,
+		Action:   orchestrator.AuditRiskConfig,
+		OldValue: orchestrator.AuditValue(previous),
+		NewValue: orchestrator.AuditValue(config),
+	}, nil)
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "updated"})
 }
 
-// GetLimits returns current risk limits
+// LimitUtilization represents usage of a single risk limit
+type LimitUtilization struct {
+	Name        string  `json:"name"`
+	Used        float64 `json:"used"`
+	Limit       float64 `json:"limit"`
+	Utilization float64 `json:"utilization"` // Percent of limit used (100 = at limit)
+	Breached    bool    `json:"breached"`
+}
+
+// RiskLimitsResponse represents the consolidated risk limits view
+type RiskLimitsResponse struct {
+	Level          string              `json:"level"`
+	IsHalted       bool                `json:"isHalted"`
+	HaltReason     string              `json:"haltReason,omitempty"`
+	HaltUntil      *time.Time          `json:"haltUntil,omitempty"`
+	IsWithinLimits bool                `json:"isWithinLimits"`
+	Breaches       []string            `json:"breaches"`
+	Limits         []LimitUtilization  `json:"limits"`
+	Drawdown       DrawdownResponse    `json:"drawdown"`
+	RecentEvents   []RiskEventResponse `json:"recentEvents"`
+	Timestamp      time.Time           `json:"timestamp"`
+}
+
+// GetLimits returns risk limits, drawdown and recent events in a single
+// response with percentage utilization for each limit
 func (h *RiskHandler) GetLimits(c echo.Context) error {
 	if h.riskManager == nil {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
 	}
 
+	eventLimit := 10
+	if l := c.QueryParam("events"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed >= 0 && parsed <= 100 {
+			eventLimit = parsed
+		}
+	}
+
+	state := h.riskManager.GetAccountState()
 	limits := h.riskManager.GetRiskLimits()
-	return c.JSON(http.StatusOK, limits)
+	drawdown := h.riskManager.GetDrawdownInfo()
+	config := h.riskManager.GetConfig()
+
+	response := RiskLimitsResponse{
+		Level:          determineRiskLevel(state.CurrentDrawdown),
+		IsHalted:       state.IsHalted,
+		HaltReason:     state.HaltReason,
+		IsWithinLimits: limits.IsWithinLimits,
+		Breaches:       limits.LimitBreaches,
+		Limits: []LimitUtilization{
+			newLimitUtilization("dailyLoss", limits.DailyLossUsed, limits.DailyLossLimit),
+			newLimitUtilization("weeklyLoss", limits.WeeklyLossUsed, limits.WeeklyLossLimit),
+			newLimitUtilization("drawdown", limits.DrawdownCurrent, limits.DrawdownLimit),
+			newLimitUtilization("openPositions", float64(limits.PositionsOpen), float64(limits.PositionsLimit)),
+		},
+		Drawdown: DrawdownResponse{
+			Current:          drawdown.CurrentDrawdown,
+			Max:              drawdown.MaxDrawdown,
+			RecoveryRequired: drawdown.RecoveryRequired,
+			IsAtPeak:         drawdown.CurrentDrawdown == 0,
+		},
+		RecentEvents: toRiskEventResponses(h.riskManager.GetRecentEvents(eventLimit)),
+		Timestamp:    time.Now(),
+	}
+	withHighWaterMark(&response.Drawdown, h.riskManager.GetHighWaterMark())
+
+	if config.EnableCircuitBreaker && config.ConsecutiveLossLimit > 0 {
+		response.Limits = append(response.Limits,
+			newLimitUtilization("consecutiveLosses", float64(state.ConsecutiveLosses), float64(config.ConsecutiveLossLimit)))
+	}
+	if state.IsHalted && !state.HaltUntil.IsZero() {
+		haltUntil := state.HaltUntil
+		response.HaltUntil = &haltUntil
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// newLimitUtilization builds a limit utilization entry
+func newLimitUtilization(name string, used, limit float64) LimitUtilization {
+	lu := LimitUtilization{
+		Name:  name,
+		Used:  used,
+		Limit: limit,
+	}
+	if limit > 0 {
+		// Negative usage (e.g. a daily profit) shows as an empty gauge
+		lu.Utilization = math.Max(used/limit, 0) * 100
+		lu.Breached = used >= limit
+	}
+	return lu
 }
 
 // DrawdownResponse represents drawdown information
 type DrawdownResponse struct {
-	Current          float64 `json:"current"`
-	Max              float64 `json:"max"`
-	RecoveryRequired float64 `json:"recoveryRequired"`
-	IsAtPeak         bool    `json:"isAtPeak"`
+	Current          float64    `json:"current"`
+	Max              float64    `json:"max"`
+	RecoveryRequired float64    `json:"recoveryRequired"`
+	IsAtPeak         bool       `json:"isAtPeak"`
+	PeakEquity       float64    `json:"peakEquity,omitempty"`
+	PeakAt           *time.Time `json:"peakAt,omitempty"`
+	HighWaterMark    string     `json:"highWaterMarkMode,omitempty"`
 }
 
 // GetDrawdown returns drawdown information
@@ -198,10 +311,80 @@ func (h *RiskHandler) GetDrawdown(c echo.Context) error {
 		RecoveryRequired: info.RecoveryRequired,
 		IsAtPeak:         info.CurrentDrawdown == 0,
 	}
+	withHighWaterMark(&response, h.riskManager.GetHighWaterMark())
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// withHighWaterMark adds the drawdown peak to a drawdown response
+func withHighWaterMark(response *DrawdownResponse, hwm risk.HighWaterMark) {
+	response.PeakEquity = hwm.Peak
+	response.HighWaterMark = string(hwm.Mode)
+	if !hwm.PeakAt.IsZero() {
+		peakAt := hwm.PeakAt
+		response.PeakAt = &peakAt
+	}
+}
+
+// CashFlowRequest represents a deposit or withdrawal
+type CashFlowRequest struct {
+	Amount float64 `json:"amount"` // Positive for deposits, negative for withdrawals
+	Note   string  `json:"note,omitempty"`
+}
+
+// ResetHighWaterMark sets the drawdown peak to current equity
+func (h *RiskHandler) ResetHighWaterMark(c echo.Context) error {
+	if h.riskManager == nil || h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
+	}
+
+	previous := h.riskManager.GetHighWaterMark()
+	hwm, err := h.orchestrator.ResetHighWaterMark()
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   orchestrator.AuditHighWaterMarkReset,
+		OldValue: orchestrator.AuditValue(previous),
+		NewValue: orchestrator.AuditValue(hwm),
+	}, err)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	log.Info().Str("by", requestActor(c)).Float64("peak", hwm.Peak).Msg("High-water mark reset via API")
+	return c.JSON(http.StatusOK, hwm)
+}
+
+// RecordCashFlow records a deposit or withdrawal against the high-water mark
+func (h *RiskHandler) RecordCashFlow(c echo.Context) error {
+	if h.riskManager == nil || h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
+	}
+
+	var req CashFlowRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if req.Amount == 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Amount must be a non-zero number"})
+	}
+
+	previous := h.riskManager.GetHighWaterMark()
+	hwm := h.orchestrator.RecordCashFlow(req.Amount)
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   orchestrator.AuditCashFlow,
+		OldValue: orchestrator.AuditValue(previous),
+		NewValue: orchestrator.AuditValue(map[string]interface{}{"amount": req.Amount, "note": req.Note, "highWaterMark": hwm}),
+	}, nil)
+
+	log.Info().
+		Str("by", requestActor(c)).
+		Float64("amount", req.Amount).
+		Str("note", req.Note).
+		Msg("Cash flow recorded via API")
+	return c.JSON(http.StatusOK, hwm)
+}
+
 // RiskEventResponse represents a risk event
 type RiskEventResponse struct {
 	Type      string `json:"type"`
@@ -218,7 +401,45 @@ func (h *RiskHandler) GetEvents(c echo.Context) error {
 	}
 
 	events := h.riskManager.GetRecentEvents(20)
+	return c.JSON(http.StatusOK, toRiskEventResponses(events))
+}
+
+// GetReports returns the risk digest of the day so far and of the last
+// closed days: signals generated vs executed, rejection reasons, limit
+// utilization peaks and circuit-breaker events. format=csv or html renders
+// them as a table formatted in the caller's locale.
+// GET /api/v1/risk/reports?format=json|csv|html&locale=
+func (h *RiskHandler) GetReports(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
 
+	format, err := reportFormat(c, reportFormatJSON, reportFormatCSV, reportFormatHTML)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	reports, err := h.orchestrator.GetRiskReports()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load risk reports: " + err.Error()})
+	}
+	if format == reportFormatJSON {
+		return c.JSON(http.StatusOK, reports)
+	}
+
+	locale, err := requestLocale(c, h.orchestrator)
+	if err != nil {
+		return localeError(c, err)
+	}
+	rows := riskReportRows(reports, locale)
+	if format == reportFormatHTML {
+		return writeRiskReportHTML(c, locale, rows)
+	}
+	return writeCSV(c, "risk-reports-"+reports.Current.Day+".csv", locale, append([][]string{riskReportColumns}, rows...))
+}
+
+// toRiskEventResponses converts risk events for the API
+func toRiskEventResponses(events []risk.RiskEvent) []RiskEventResponse {
 	response := make([]RiskEventResponse, len(events))
 	for i, e := range events {
 		response[i] = RiskEventResponse{
@@ -229,8 +450,7 @@ func (h *RiskHandler) GetEvents(c echo.Context) error {
 			Handled:   e.Handled,
 		}
 	}
-
-	return c.JSON(http.StatusOK, response)
+	return response
 }
 
 // ResetCircuitBreaker resets the circuit breaker
@@ -239,10 +459,131 @@ func (h *RiskHandler) ResetCircuitBreaker(c echo.Context) error {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
 	}
 
+	previous := h.riskManager.GetAccountState()
 	h.riskManager.ResetCircuitBreaker()
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   orchestrator.AuditCircuitBreakerReset,
+		OldValue: haltAuditValue(previous),
+		NewValue: haltAuditValue(h.riskManager.GetAccountState()),
+	}, nil)
 	return c.JSON(http.StatusOK, map[string]string{"status": "reset"})
 }
 
+// HaltRequest represents a manual trading halt request
+type HaltRequest struct {
+	Reason          string `json:"reason"`
+	DurationMinutes int    `json:"durationMinutes,omitempty"` // 0 = until resumed
+}
+
+// Halt manually triggers the circuit breaker
+func (h *RiskHandler) Halt(c echo.Context) error {
+	if h.riskManager == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
+	}
+
+	var req HaltRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if req.Reason == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Reason is required"})
+	}
+	if req.DurationMinutes < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Duration must not be negative"})
+	}
+
+	actor := requestActor(c)
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	previous := h.riskManager.GetAccountState()
+	h.riskManager.Halt(req.Reason, duration, actor)
+
+	state := h.riskManager.GetAccountState()
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   orchestrator.AuditHalt,
+		OldValue: haltAuditValue(previous),
+		NewValue: haltAuditValue(state),
+	}, nil)
+	details := map[string]interface{}{
+		"reason": req.Reason,
+		"by":     actor,
+	}
+	if !state.HaltUntil.IsZero() {
+		details["haltUntil"] = state.HaltUntil
+	}
+	h.recordAudit("critical", "Trading halted manually: "+req.Reason, details)
+
+	response := map[string]interface{}{
+		"status": "halted",
+		"reason": req.Reason,
+	}
+	if !state.HaltUntil.IsZero() {
+		response["haltUntil"] = state.HaltUntil
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// Resume manually resets the circuit breaker and resumes trading
+func (h *RiskHandler) Resume(c echo.Context) error {
+	if h.riskManager == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Risk manager not available"})
+	}
+
+	previous := h.riskManager.GetAccountState()
+	actor := requestActor(c)
+	h.riskManager.Resume(actor)
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   orchestrator.AuditResume,
+		OldValue: haltAuditValue(previous),
+		NewValue: haltAuditValue(h.riskManager.GetAccountState()),
+	}, nil)
+
+	h.recordAudit("info", "Trading resumed manually", map[string]interface{}{
+		"wasHalted":      previous.IsHalted,
+		"previousReason": previous.HaltReason,
+		"by":             actor,
+	})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":    "resumed",
+		"wasHalted": previous.IsHalted,
+	})
+}
+
+// recordAudit stores a circuit breaker action in the alert log
+func (h *RiskHandler) recordAudit(severity, message string, details map[string]interface{}) {
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return
+	}
+
+	data, _ := json.Marshal(details)
+	if _, err := h.orchestrator.GetDataService().AddAlert(storage.Alert{
+		Type:     "circuit_breaker",
+		Severity: severity,
+		Message:  message,
+		Data:     string(data),
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to record circuit breaker audit entry")
+	}
+}
+
+// haltAuditValue is the halt state recorded in the audit log
+func haltAuditValue(state risk.AccountState) json.RawMessage {
+	value := map[string]interface{}{
+		"halted":            state.IsHalted,
+		"consecutiveLosses": state.ConsecutiveLosses,
+	}
+	if state.HaltReason != "" {
+		value["reason"] = state.HaltReason
+	}
+	if !state.HaltUntil.IsZero() {
+		value["haltUntil"] = state.HaltUntil
+	}
+	return orchestrator.AuditValue(value)
+}
+
 // Helper function to determine risk level string
 func determineRiskLevel(drawdown float64) string {
 	switch {
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// maxSandboxScriptBytes bounds the source of a sandboxed script
+	maxSandboxScriptBytes = 64 << 10
+	// maxSandboxBars bounds the primary bars a sandbox backtest covers
+	maxSandboxBars = 10000
+	// maxSandboxCallAlloc bounds the memory allocated by each call into a
+	// sandboxed script, which runs in the trading process
+	maxSandboxCallAlloc = 64 << 20
+)
+
+// SandboxHandler runs quick backtests of inline strategy scripts
+type SandboxHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewSandboxHandler creates a new sandbox handler
+func NewSandboxHandler(orch *orchestrator.Orchestrator) *SandboxHandler {
+	return &SandboxHandler{orchestrator: orch}
+}
+
+// SandboxRequest is an inline strategy script and the range to test it over
+type SandboxRequest struct {
+	Script         string  `json:"script"` // Lua source defining analyze(data), as in strategies/*.lua
+	Symbol         string  `json:"symbol"`
+	Timeframe      string  `json:"timeframe"`
+	StartDate      string  `json:"startDate"` // YYYY-MM-DD; default 30 days ago
+	EndDate        string  `json:"endDate"`   // YYYY-MM-DD; default today
+	InitialCapital float64 `json:"initialCapital"`
+	RiskPerTrade   float64 `json:"riskPerTrade"`
+}
+
+// SandboxResponse summarizes a sandbox backtest
+type SandboxResponse struct {
+	Strategy      string         `json:"strategy"`
+	Bars          int            `json:"bars"`
+	Metrics       SandboxMetrics `json:"metrics"`
+	ScriptError   string         `json:"scriptError,omitempty"` // Last runtime error raised by the script
+	Disabled      bool           `json:"disabled,omitempty"`    // The script ran out of time or memory and stopped trading
+	ExecutionTime string         `json:"executionTime"`
+}
+
+// SandboxMetrics are the headline results of a sandbox backtest
+type SandboxMetrics struct {
+	TotalReturn   float64 `json:"totalReturn"`
+	NetProfit     float64 `json:"netProfit"`
+	EndingCapital float64 `json:"endingCapital"`
+	MaxDrawdown   float64 `json:"maxDrawdown"`
+	SharpeRatio   float64 `json:"sharpeRatio"`
+	TotalTrades   int     `json:"totalTrades"`
+	WinRate       float64 `json:"winRate"`
+	ProfitFactor  float64 `json:"profitFactor"`
+	Expectancy    float64 `json:"expectancy"`
+}
+
+// Run backtests an inline strategy script over locally stored candles and
+// returns summary metrics. Nothing is queued or persisted; runs are limited
+// in script size, bars and time.
+// POST /api/v1/sandbox/run
+func (h *SandboxHandler) Run(c echo.Context) error {
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req SandboxRequest
+	if err := c.Bind(&req); err != nil || req.Script == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "A script is required"})
+	}
+	if len(req.Script) > maxSandboxScriptBytes {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": fmt.Sprintf("Script exceeds %d bytes", maxSandboxScriptBytes)})
+	}
+	if req.Symbol == "" {
+		req.Symbol = "ETHUSDT"
+	}
+	if req.Timeframe == "" {
+		req.Timeframe = "1h"
+	}
+	if req.InitialCapital <= 0 {
+		req.InitialCapital = 100000
+	}
+	if req.RiskPerTrade <= 0 {
+		req.RiskPerTrade = 0.02
+	}
+
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -30)
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "startDate must be YYYY-MM-DD"})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "endDate must be YYYY-MM-DD"})
+		}
+	}
+	if !endDate.After(startDate) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "endDate must be after startDate"})
+	}
+
+	script, err := strategy.NewScriptStrategy(&strategy.ScriptConfig{
+		Path:         "sandbox" + strategy.ScriptExtension,
+		Source:       req.Script,
+		MaxCallAlloc: maxSandboxCallAlloc,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer script.Close()
+	script.SetEnabled(true)
+
+	stored, err := h.orchestrator.GetDataService().GetHistoricalCandles(req.Symbol, req.Timeframe, startDate, endDate)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to fetch %s data: %v", req.Timeframe, err)})
+	}
+	if len(stored) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "No historical data available for the specified date range"})
+	}
+	if len(stored) > maxSandboxBars {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Range covers %d bars, the sandbox runs at most %d", len(stored), maxSandboxBars)})
+	}
+
+	candles := make([]backtest.Candle, len(stored))
+	for i, sc := range stored {
+		candles[i] = backtest.Candle{
+			Timestamp: sc.OpenTime,
+			Open:      sc.Open,
+			High:      sc.High,
+			Low:       sc.Low,
+			Close:     sc.Close,
+			Volume:    sc.Volume,
+		}
+	}
+
+	result, err := h.orchestrator.RunSandbox(&backtest.Config{
+		Symbol:         req.Symbol,
+		Timeframe:      req.Timeframe,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		InitialCapital: req.InitialCapital,
+		Fees:           h.orchestrator.GetFeeSchedule(),
+		RiskPerTrade:   req.RiskPerTrade,
+		Strategies:     []strategy.Strategy{script},
+	}, &backtest.HistoricalData{Symbol: req.Symbol, Timeframe: req.Timeframe, Candles: candles})
+	switch {
+	case errors.Is(err, orchestrator.ErrSandboxBusy):
+		return c.JSON(http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrSandboxTimeout):
+		return c.JSON(http.StatusRequestTimeout, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	m := result.Metrics
+	return c.JSON(http.StatusOK, SandboxResponse{
+		Strategy: script.Name(),
+		Bars:     len(candles),
+		Metrics: SandboxMetrics{
+			TotalReturn:   m.TotalReturn,
+			NetProfit:     m.NetProfit,
+			EndingCapital: m.EndingCapital,
+			MaxDrawdown:   m.MaxDrawdown,
+			SharpeRatio:   m.SharpeRatio,
+			TotalTrades:   m.TotalTrades,
+			WinRate:       m.WinRate,
+			ProfitFactor:  m.ProfitFactor,
+			Expectancy:    m.Expectancy,
+		},
+		ScriptError:   script.LastError(),
+		Disabled:      !script.IsEnabled(),
+		ExecutionTime: result.ExecutionTime.String(),
+	})
+}
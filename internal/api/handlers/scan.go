@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// ScanHandler serves the market scan ranking candidate symbols
+type ScanHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewScanHandler creates a new scan handler
+func NewScanHandler(orch *orchestrator.Orchestrator) *ScanHandler {
+	return &ScanHandler{orchestrator: orch}
+}
+
+// GetScan returns USDT pairs passing the liquidity and volatility filters,
+// ranked by strategy fit. Results are cached for a few minutes unless
+// refresh=true.
+// GET /api/v1/scan
+func (h *ScanHandler) GetScan(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	report, err := h.orchestrator.ScanMarket(c.Request().Context(), c.QueryParam("refresh") == "true")
+	if err != nil {
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// ScoreHandler reports the strategy scorer's live combined score
+type ScoreHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewScoreHandler creates a new score handler
+func NewScoreHandler(orch *orchestrator.Orchestrator) *ScoreHandler {
+	return &ScoreHandler{orchestrator: orch}
+}
+
+// GetScore returns the latest combined score with per-strategy sub-scores,
+// weights, regime adjustments and the entry thresholds. The same breakdown
+// is broadcast over the WebSocket as "score" messages.
+// GET /api/v1/score
+func (h *ScoreHandler) GetScore(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	score := h.orchestrator.GetScoreBreakdown()
+	if score == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "No analysis has run yet"})
+	}
+	return c.JSON(http.StatusOK, score)
+}
@@ -1,29 +1,45 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/eth-trading/internal/api/middleware"
+	"github.com/eth-trading/internal/config"
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/storage"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // SettingsHandler handles settings configuration endpoints
 type SettingsHandler struct {
 	orchestrator *orchestrator.Orchestrator
+	config       *config.Manager // Applies changes to the running bot; nil applies risk settings only
+
+	// mu serializes settings changes so history entries stay consistent
+	mu sync.Mutex
 }
 
 // NewSettingsHandler creates a new settings handler
-func NewSettingsHandler(orch *orchestrator.Orchestrator) *SettingsHandler {
-	return &SettingsHandler{orchestrator: orch}
+func NewSettingsHandler(orch *orchestrator.Orchestrator, cfgMgr *config.Manager) *SettingsHandler {
+	return &SettingsHandler{orchestrator: orch, config: cfgMgr}
 }
 
 // FullSettingsResponse represents all settings
 type FullSettingsResponse struct {
-	Trading    TradingSettings    `json:"trading"`
-	Binance    BinanceSettings    `json:"binance"`
-	Risk       RiskSettings       `json:"risk"`
-	Indicators IndicatorSettings  `json:"indicators"`
-	Strategies StrategySettings   `json:"strategies"`
+	Trading    TradingSettings                `json:"trading"`
+	Binance    BinanceSettings                `json:"binance"`
+	Risk       RiskSettings                   `json:"risk"`
+	Indicators IndicatorSettings              `json:"indicators"`
+	Strategies StrategySettings               `json:"strategies"`
+	Symbols    map[string]config.SymbolConfig `json:"symbols"` // Per-symbol overrides
 }
 
 // TradingSettings represents trading configuration
@@ -51,6 +67,7 @@ type RiskSettings struct {
 	MaxDailyLoss         float64 `json:"maxDailyLoss"`         // Max daily loss (0.05 = 5%)
 	MaxWeeklyLoss        float64 `json:"maxWeeklyLoss"`        // Max weekly loss (0.1 = 10%)
 	MaxDrawdown          float64 `json:"maxDrawdown"`          // Max total drawdown (0.2 = 20%)
+	HighWaterMarkMode    string  `json:"highWaterMarkMode"`    // Drawdown peak: trailing, monthly or deposit_adjusted
 	MaxOpenPositions     int     `json:"maxOpenPositions"`     // Max concurrent positions
 	MaxLeverage          float64 `json:"maxLeverage"`          // Max leverage (1.0 = no leverage)
 	MinRiskRewardRatio   float64 `json:"minRiskRewardRatio"`   // Minimum R/R ratio
@@ -61,19 +78,26 @@ type RiskSettings struct {
 
 // IndicatorSettings represents indicator configuration
 type IndicatorSettings struct {
-	RSIPeriod       int     `json:"rsiPeriod"`       // RSI period (default: 14)
-	RSIOversold     float64 `json:"rsiOversold"`     // RSI oversold level (default: 30)
-	RSIOverbought   float64 `json:"rsiOverbought"`   // RSI overbought level (default: 70)
-	MACDFast        int     `json:"macdFast"`        // MACD fast period (default: 12)
-	MACDSlow        int     `json:"macdSlow"`        // MACD slow period (default: 26)
-	MACDSignal      int     `json:"macdSignal"`      // MACD signal period (default: 9)
-	BBPeriod        int     `json:"bbPeriod"`        // Bollinger Band period (default: 20)
-	BBStdDev        float64 `json:"bbStdDev"`        // Bollinger Band std dev (default: 2.0)
-	ADXPeriod       int     `json:"adxPeriod"`       // ADX period (default: 14)
-	ADXThreshold    float64 `json:"adxThreshold"`    // ADX trend threshold (default: 25)
-	ATRPeriod       int     `json:"atrPeriod"`       // ATR period (default: 14)
-	ATRMultiplierSL float64 `json:"atrMultiplierSL"` // ATR multiplier for stop loss (default: 2.0)
-	ATRMultiplierTP float64 `json:"atrMultiplierTP"` // ATR multiplier for take profit (default: 3.0)
+	RSIPeriod            int     `json:"rsiPeriod"`            // RSI period (default: 14)
+	RSIOversold          float64 `json:"rsiOversold"`          // RSI oversold level (default: 30)
+	RSIOverbought        float64 `json:"rsiOverbought"`        // RSI overbought level (default: 70)
+	MACDFast             int     `json:"macdFast"`             // MACD fast period (default: 12)
+	MACDSlow             int     `json:"macdSlow"`             // MACD slow period (default: 26)
+	MACDSignal           int     `json:"macdSignal"`           // MACD signal period (default: 9)
+	BBPeriod             int     `json:"bbPeriod"`             // Bollinger Band period (default: 20)
+	BBStdDev             float64 `json:"bbStdDev"`             // Bollinger Band std dev (default: 2.0)
+	ADXPeriod            int     `json:"adxPeriod"`            // ADX period (default: 14)
+	ADXThreshold         float64 `json:"adxThreshold"`         // ADX trend threshold (default: 25)
+	ATRPeriod            int     `json:"atrPeriod"`            // ATR period (default: 14)
+	ATRMultiplierSL      float64 `json:"atrMultiplierSL"`      // ATR multiplier for stop loss (default: 2.0)
+	ATRMultiplierTP      float64 `json:"atrMultiplierTP"`      // ATR multiplier for take profit (default: 3.0)
+	KeltnerPeriod        int     `json:"keltnerPeriod"`        // Keltner Channel EMA and ATR period (default: 20)
+	KeltnerMultiplier    float64 `json:"keltnerMultiplier"`    // Keltner Channel ATR multiplier (default: 2.0)
+	SuperTrendPeriod     int     `json:"superTrendPeriod"`     // SuperTrend ATR period (default: 10)
+	SuperTrendMultiplier float64 `json:"superTrendMultiplier"` // SuperTrend ATR multiplier (default: 3.0)
+	IchimokuTenkan       int     `json:"ichimokuTenkan"`       // Ichimoku conversion line period (default: 9)
+	IchimokuKijun        int     `json:"ichimokuKijun"`        // Ichimoku base line period and cloud displacement (default: 26)
+	IchimokuSenkouB      int     `json:"ichimokuSenkouB"`      // Ichimoku leading span B period (default: 52)
 }
 
 // StrategySettings represents strategy configuration
@@ -83,20 +107,42 @@ type StrategySettings struct {
 
 // StrategyConfig represents individual strategy config
 type StrategyConfig struct {
-	Name    string                 `json:"name"`    // Strategy name
-	Enabled bool                   `json:"enabled"` // Is strategy enabled
-	Config  map[string]interface{} `json:"config"`  // Strategy-specific config
+	Name           string                 `json:"name"`                     // Strategy name
+	Enabled        bool                   `json:"enabled"`                  // Is strategy enabled
+	Config         map[string]interface{} `json:"config"`                   // Strategy-specific config
+	SignalCooldown *int                   `json:"signalCooldown,omitempty"` // Primary candles between entry signals (0 = unthrottled); omit to keep
+}
+
+// Settings sections recorded in the audit trail
+const (
+	settingsSectionTrading    = "trading"
+	settingsSectionRisk       = "risk"
+	settingsSectionIndicators = "indicators"
+	settingsSectionStrategies = "strategies"
+	settingsSectionSymbols    = "symbols"
+)
+
+// SettingsChangeResponse represents an entry of the settings audit trail
+type SettingsChangeResponse struct {
+	VersionID  int64           `json:"versionId"`
+	Section    string          `json:"section"`
+	Action     string          `json:"action"`
+	OldValue   json.RawMessage `json:"oldValue,omitempty"`
+	NewValue   json.RawMessage `json:"newValue"`
+	ChangedBy  string          `json:"changedBy"`
+	RollbackOf *int64          `json:"rollbackOf,omitempty"`
+	ChangedAt  time.Time       `json:"changedAt"`
 }
 
 // GetSettings returns all settings
 func (h *SettingsHandler) GetSettings(c echo.Context) error {
-	settings := getDefaultSettings()
+	settings := h.currentSettings()
 	return c.JSON(http.StatusOK, settings)
 }
 
 // GetTradingSettings returns trading settings
 func (h *SettingsHandler) GetTradingSettings(c echo.Context) error {
-	settings := getDefaultSettings()
+	settings := h.currentSettings()
 	return c.JSON(http.StatusOK, settings.Trading)
 }
 
@@ -118,11 +164,19 @@ func (h *SettingsHandler) UpdateTradingSettings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Initial balance must be positive"})
 	}
 
-	// In real implementation, save to config manager and apply changes
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	versionID, err := h.recordChange(c, settingsSectionTrading, "update", h.currentSettings().Trading, req, nil)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"status":  "updated",
-		"message": "Trading settings updated. Restart required for some changes.",
-		"trading": req,
+		"status":    "updated",
+		"message":   "Trading settings saved. They apply on restart.",
+		"versionId": versionID,
+		"trading":   req,
 	})
 }
 
@@ -143,6 +197,7 @@ func (h *SettingsHandler) UpdateBinanceSettings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
+	// Credentials are deliberately kept out of the settings history.
 	// In real implementation, validate API keys with a test call
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  "updated",
@@ -153,7 +208,7 @@ func (h *SettingsHandler) UpdateBinanceSettings(c echo.Context) error {
 
 // GetRiskSettings returns risk settings
 func (h *SettingsHandler) GetRiskSettings(c echo.Context) error {
-	settings := getDefaultSettings()
+	settings := h.currentSettings()
 	return c.JSON(http.StatusOK, settings.Risk)
 }
 
@@ -174,18 +229,40 @@ func (h *SettingsHandler) UpdateRiskSettings(c echo.Context) error {
 	if req.MaxDrawdown <= 0 || req.MaxDrawdown > 1 {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Max drawdown must be between 0 and 1"})
 	}
+	if req.HighWaterMarkMode == "" {
+		req.HighWaterMarkMode = string(risk.HighWaterMarkTrailing)
+	}
+	if !risk.ValidHighWaterMarkMode(risk.HighWaterMarkMode(req.HighWaterMarkMode)) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "High-water mark mode must be trailing, monthly or deposit_adjusted"})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
+	next := *current
+	next.Risk = req
+	revert, err := h.applySection(c, settingsSectionRisk, current, &next)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Risk settings rejected: " + err.Error()})
+	}
+	versionID, err := h.recordChange(c, settingsSectionRisk, "update", current.Risk, req, nil)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
 
-	// In real implementation, update risk manager
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"status":  "updated",
-		"message": "Risk settings updated and applied",
-		"risk":    req,
+		"status":    "updated",
+		"message":   "Risk settings updated and applied",
+		"versionId": versionID,
+		"risk":      req,
 	})
 }
 
 // GetIndicatorSettings returns indicator settings
 func (h *SettingsHandler) GetIndicatorSettings(c echo.Context) error {
-	settings := getDefaultSettings()
+	settings := h.currentSettings()
 	return c.JSON(http.StatusOK, settings.Indicators)
 }
 
@@ -204,17 +281,34 @@ func (h *SettingsHandler) UpdateIndicatorSettings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "MACD fast must be less than slow period"})
 	}
 
-	// In real implementation, update indicator manager
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
+	next := *current
+	next.Indicators = req
+	revert, err := h.applySection(c, settingsSectionIndicators, current, &next)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Indicator settings rejected: " + err.Error()})
+	}
+	versionID, err := h.recordChange(c, settingsSectionIndicators, "update", current.Indicators, req, nil)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+	h.applyParamVersion(settingsSectionIndicators, versionID)
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":     "updated",
-		"message":    "Indicator settings updated",
+		"message":    "Indicator settings updated and applied",
+		"versionId":  versionID,
 		"indicators": req,
 	})
 }
 
 // GetStrategySettings returns strategy settings
 func (h *SettingsHandler) GetStrategySettings(c echo.Context) error {
-	settings := getDefaultSettings()
+	settings := h.currentSettings()
 	return c.JSON(http.StatusOK, settings.Strategies)
 }
 
@@ -225,17 +319,167 @@ func (h *SettingsHandler) UpdateStrategySettings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
-	// In real implementation, update strategy manager
+	// Validate
+	for _, sc := range req.Enabled {
+		if sc.SignalCooldown != nil && *sc.SignalCooldown < 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Signal cooldown for %s must not be negative", sc.Name)})
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
+	next := *current
+	next.Strategies = req
+	revert, err := h.applySection(c, settingsSectionStrategies, current, &next)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Strategy settings rejected: " + err.Error()})
+	}
+	versionID, err := h.recordChange(c, settingsSectionStrategies, "update", current.Strategies, req, nil)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+	h.applyParamVersion(settingsSectionStrategies, versionID)
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":     "updated",
-		"message":    "Strategy settings updated",
+		"message":    "Strategy settings updated and applied",
+		"versionId":  versionID,
 		"strategies": req,
 	})
 }
 
+// GetSymbolSettings returns the per-symbol overrides
+func (h *SettingsHandler) GetSymbolSettings(c echo.Context) error {
+	settings := h.currentSettings()
+	return c.JSON(http.StatusOK, settings.Symbols)
+}
+
+// UpdateSymbolSettings sets the overrides for one symbol. Overrides for
+// the traded symbol apply immediately, except its enabled strategies.
+func (h *SettingsHandler) UpdateSymbolSettings(c echo.Context) error {
+	symbol := strings.ToUpper(c.Param("symbol"))
+
+	var req config.SymbolConfig
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
+	symbols := make(map[string]config.SymbolConfig, len(current.Symbols)+1)
+	for s, override := range current.Symbols {
+		symbols[s] = override
+	}
+	symbols[symbol] = req
+
+	next := *current
+	next.Symbols = symbols
+	revert, err := h.applySection(c, settingsSectionSymbols, current, &next)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Symbol overrides rejected: " + err.Error()})
+	}
+	versionID, err := h.recordChange(c, settingsSectionSymbols, "update", current.Symbols, symbols, nil)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+	h.applyParamVersion(settingsSectionSymbols, versionID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":    "updated",
+		"message":   fmt.Sprintf("Overrides for %s updated", symbol),
+		"versionId": versionID,
+		"symbol":    symbol,
+		"overrides": req,
+	})
+}
+
+// DeleteSymbolSettings removes the overrides for one symbol
+func (h *SettingsHandler) DeleteSymbolSettings(c echo.Context) error {
+	symbol := strings.ToUpper(c.Param("symbol"))
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
+	if _, ok := current.Symbols[symbol]; !ok {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "No overrides for symbol"})
+	}
+	symbols := make(map[string]config.SymbolConfig, len(current.Symbols))
+	for s, override := range current.Symbols {
+		if s != symbol {
+			symbols[s] = override
+		}
+	}
+
+	next := *current
+	next.Symbols = symbols
+	revert, err := h.applySection(c, settingsSectionSymbols, current, &next)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Symbol overrides rejected: " + err.Error()})
+	}
+	versionID, err := h.recordChange(c, settingsSectionSymbols, "delete", current.Symbols, symbols, nil)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+	h.applyParamVersion(settingsSectionSymbols, versionID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":    "deleted",
+		"message":   fmt.Sprintf("Overrides for %s removed", symbol),
+		"versionId": versionID,
+		"symbol":    symbol,
+	})
+}
+
 // ResetSettings resets all settings to defaults
 func (h *SettingsHandler) ResetSettings(c echo.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current := h.currentSettings()
 	settings := getDefaultSettings()
+	sections := []string{settingsSectionTrading, settingsSectionRisk, settingsSectionIndicators, settingsSectionStrategies, settingsSectionSymbols}
+
+	// Apply every section before saving any, undoing them all on failure
+	var reverts []func()
+	revertAll := func() {
+		for i := len(reverts) - 1; i >= 0; i-- {
+			reverts[i]()
+		}
+	}
+	for _, section := range sections {
+		revert, err := h.applySection(c, section, current, settings)
+		if err != nil {
+			revertAll()
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": fmt.Sprintf("Default %s settings rejected: %s", section, err)})
+		}
+		reverts = append(reverts, revert)
+	}
+
+	for _, section := range sections {
+		versionID, err := h.recordChange(c, section, "reset", current.section(section), settings.section(section), nil)
+		if err != nil {
+			revertAll()
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to reset settings"})
+		}
+		h.applyParamVersion(section, versionID)
+	}
+	if h.orchestrator != nil {
+		if err := h.orchestrator.ResetSignalCooldowns(); err != nil {
+			log.Error().Err(err).Msg("Failed to reset signal cooldowns")
+		}
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":   "reset",
 		"message":  "All settings reset to defaults",
@@ -243,6 +487,483 @@ func (h *SettingsHandler) ResetSettings(c echo.Context) error {
 	})
 }
 
+// GetReloadStatus returns the configuration changes applied to the running
+// bot and the changed keys that wait for a restart
+func (h *SettingsHandler) GetReloadStatus(c echo.Context) error {
+	if h.config == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Config manager not available"})
+	}
+	return c.JSON(http.StatusOK, h.config.Status())
+}
+
+// ReloadSettings reloads the config file now, as the file watcher does when
+// it changes. A file that fails validation leaves the running configuration.
+func (h *SettingsHandler) ReloadSettings(c echo.Context) error {
+	if h.config == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Config manager not available"})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	err := h.config.Reload()
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditConfig,
+		Action:   "settings_reload",
+		Resource: "config",
+	}, err)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Config file rejected: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, h.config.Status())
+}
+
+// GetSettingsHistory returns the settings audit trail
+func (h *SettingsHandler) GetSettingsHistory(c echo.Context) error {
+	ds := h.dataService()
+	if ds == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Data service not available"})
+	}
+
+	limit := 50
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	changes, err := ds.GetSettingsHistory(c.QueryParam("section"), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load settings history"})
+	}
+
+	response := make([]SettingsChangeResponse, len(changes))
+	for i, ch := range changes {
+		response[i] = toSettingsChangeResponse(ch)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RollbackSettings reverts the change recorded under versionId, restoring
+// the section to the value it had before that change
+func (h *SettingsHandler) RollbackSettings(c echo.Context) error {
+	ds := h.dataService()
+	if ds == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Data service not available"})
+	}
+
+	versionID, err := strconv.ParseInt(c.Param("versionId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid version ID"})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	change, err := ds.GetSettingsChange(versionID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load settings version"})
+	}
+	if change == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Settings version not found"})
+	}
+
+	// A change without an old value was made on top of the defaults
+	restored := getDefaultSettings()
+	if len(change.OldValue) > 0 {
+		if err := restored.setSection(change.Section, change.OldValue); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Stored settings version is invalid"})
+		}
+	}
+
+	current := h.currentSettings()
+	revert, err := h.applySection(c, change.Section, current, restored)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Restored settings rejected: " + err.Error()})
+	}
+	newVersionID, err := h.recordChange(c, change.Section, "rollback", current.section(change.Section), restored.section(change.Section), &versionID)
+	if err != nil {
+		revert()
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save settings"})
+	}
+	h.applyParamVersion(change.Section, newVersionID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":     "rolled_back",
+		"message":    fmt.Sprintf("Settings section '%s' rolled back", change.Section),
+		"versionId":  newVersionID,
+		"rollbackOf": versionID,
+		"section":    change.Section,
+		"settings":   restored.section(change.Section),
+	})
+}
+
+// dataService returns the data service backing settings persistence
+func (h *SettingsHandler) dataService() *storage.DataService {
+	if h.orchestrator == nil {
+		return nil
+	}
+	return h.orchestrator.GetDataService()
+}
+
+// currentSettings returns the defaults overlaid with persisted sections
+func (h *SettingsHandler) currentSettings() *FullSettingsResponse {
+	settings := getDefaultSettings()
+
+	ds := h.dataService()
+	if ds == nil {
+		return settings
+	}
+
+	for _, section := range []string{settingsSectionTrading, settingsSectionRisk, settingsSectionIndicators, settingsSectionStrategies, settingsSectionSymbols} {
+		value, err := ds.LoadSettings(section)
+		if err != nil {
+			log.Error().Err(err).Str("section", section).Msg("Failed to load persisted settings")
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if err := settings.setSection(section, json.RawMessage(value)); err != nil {
+			log.Error().Err(err).Str("section", section).Msg("Invalid persisted settings")
+		}
+	}
+
+	// Report the cooldowns in effect, which may come from the config file
+	if h.orchestrator != nil {
+		for i := range settings.Strategies.Enabled {
+			cooldown := h.orchestrator.GetSignalCooldown(settings.Strategies.Enabled[i].Name)
+			settings.Strategies.Enabled[i].SignalCooldown = &cooldown
+		}
+	}
+
+	return settings
+}
+
+// recordChange persists a settings section together with its audit trail entry.
+// Caller must hold h.mu.
+func (h *SettingsHandler) recordChange(c echo.Context, section, action string, oldValue, newValue interface{}, rollbackOf *int64) (int64, error) {
+	ds := h.dataService()
+	if ds == nil {
+		return 0, nil
+	}
+
+	oldJSON, err := json.Marshal(oldValue)
+	if err != nil {
+		return 0, err
+	}
+	newJSON, err := json.Marshal(newValue)
+	if err != nil {
+		return 0, err
+	}
+
+	changedBy := requestActor(c)
+	versionID, err := ds.SaveSettingsChange(storage.SettingsChange{
+		Section:    section,
+		Action:     action,
+		OldValue:   oldJSON,
+		NewValue:   newJSON,
+		ChangedBy:  changedBy,
+		RollbackOf: rollbackOf,
+	})
+	recordAPIAudit(c, h.orchestrator, storage.AuditEntry{
+		Category: storage.AuditConfig,
+		Action:   "settings_" + action,
+		Resource: section,
+		OldValue: oldJSON,
+		NewValue: newJSON,
+	}, err)
+	if err != nil {
+		log.Error().Err(err).Str("section", section).Msg("Failed to persist settings")
+		return 0, err
+	}
+
+	log.Info().
+		Int64("versionId", versionID).
+		Str("section", section).
+		Str("action", action).
+		Str("changedBy", changedBy).
+		Msg("Settings changed")
+
+	return versionID, nil
+}
+
+// applySection applies a settings section changed from current to next
+// to the running bot. Trading settings apply on restart. It returns a
+// function restoring the previous state, for when saving the change fails.
+func (h *SettingsHandler) applySection(c echo.Context, section string, current, next *FullSettingsResponse) (func(), error) {
+	switch section {
+	case settingsSectionRisk:
+		if h.config == nil {
+			h.applyRiskSettings(next.Risk, current.Symbols)
+			return func() { h.applyRiskSettings(current.Risk, current.Symbols) }, nil
+		}
+		return h.updateConfig(func(cfg *config.Config) {
+			next.Risk.applyTo(&cfg.Risk)
+		})
+
+	case settingsSectionIndicators:
+		return h.updateConfig(func(cfg *config.Config) {
+			cfg.Indicators = config.IndicatorConfig(next.Indicators)
+		})
+
+	case settingsSectionSymbols:
+		if h.config == nil {
+			h.applyRiskSettings(next.Risk, next.Symbols)
+			return func() { h.applyRiskSettings(current.Risk, current.Symbols) }, nil
+		}
+		return h.updateConfig(func(cfg *config.Config) {
+			cfg.Symbols = replaceSymbols(cfg.Symbols, current.Symbols, next.Symbols)
+		})
+
+	case settingsSectionStrategies:
+		return h.applyStrategySettings(c, current.Strategies, next.Strategies)
+	}
+	return func() {}, nil
+}
+
+// updateConfig applies a change to the running configuration through the
+// config manager, which validates it and rolls back the components it
+// reached if one rejects it. The returned function restores the previous
+// configuration.
+func (h *SettingsHandler) updateConfig(change func(cfg *config.Config)) (func(), error) {
+	if h.config == nil {
+		return func() {}, nil
+	}
+	var previous config.Config
+	err := h.config.Update(func(cfg *config.Config) error {
+		previous = *cfg
+		change(cfg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		err := h.config.Update(func(cfg *config.Config) error {
+			*cfg = previous
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to restore the running configuration")
+		}
+	}, nil
+}
+
+// replaceSymbols returns a copy of the running overrides with those saved
+// through the settings API changed from old to new. Overrides from the
+// config file stay unless the API replaced them.
+func replaceSymbols(running, old, new map[string]config.SymbolConfig) map[string]config.SymbolConfig {
+	symbols := make(map[string]config.SymbolConfig, len(running)+len(new))
+	for s, override := range running {
+		symbols[s] = override
+	}
+	for s := range old {
+		delete(symbols, strings.ToUpper(s))
+	}
+	for s, override := range new {
+		symbols[strings.ToUpper(s)] = override
+	}
+	return symbols
+}
+
+// applyTo copies the settings to the config file's risk section
+func (rs RiskSettings) applyTo(r *config.RiskConfig) {
+	r.MaxPositionSize = rs.MaxPositionSize
+	r.MaxRiskPerTrade = rs.MaxRiskPerTrade
+	r.MaxDailyLoss = rs.MaxDailyLoss
+	r.MaxWeeklyLoss = rs.MaxWeeklyLoss
+	r.MaxDrawdown = rs.MaxDrawdown
+	if risk.ValidHighWaterMarkMode(risk.HighWaterMarkMode(rs.HighWaterMarkMode)) {
+		r.HighWaterMarkMode = rs.HighWaterMarkMode
+	}
+	r.MaxOpenPositions = rs.MaxOpenPositions
+	r.MaxLeverage = rs.MaxLeverage
+	r.MinRiskRewardRatio = rs.MinRiskRewardRatio
+	r.EnableCircuitBreaker = rs.EnableCircuitBreaker
+	r.ConsecutiveLossLimit = rs.ConsecutiveLossLimit
+	r.HaltDurationHours = rs.HaltDurationHours
+}
+
+// applyRiskSettings pushes risk settings, with the traded symbol's
+// overrides, to the running risk manager. Used without a config manager.
+func (h *SettingsHandler) applyRiskSettings(rs RiskSettings, symbols map[string]config.SymbolConfig) {
+	if h.orchestrator == nil {
+		return
+	}
+	rm := h.orchestrator.GetRiskManager()
+	if rm == nil {
+		return
+	}
+
+	rc := *rm.GetConfig()
+	rc.MaxPositionSize = rs.MaxPositionSize
+	rc.MaxRiskPerTrade = rs.MaxRiskPerTrade
+	rc.MaxDailyLoss = rs.MaxDailyLoss
+	rc.MaxWeeklyLoss = rs.MaxWeeklyLoss
+	rc.MaxTotalDrawdown = rs.MaxDrawdown
+	if risk.ValidHighWaterMarkMode(risk.HighWaterMarkMode(rs.HighWaterMarkMode)) {
+		rc.HighWaterMarkMode = risk.HighWaterMarkMode(rs.HighWaterMarkMode)
+	}
+	rc.MaxOpenPositions = rs.MaxOpenPositions
+	rc.MaxLeverage = rs.MaxLeverage
+	rc.MinRiskRewardRatio = rs.MinRiskRewardRatio
+	rc.EnableCircuitBreaker = rs.EnableCircuitBreaker
+	rc.ConsecutiveLossLimit = rs.ConsecutiveLossLimit
+	rc.HaltDuration = time.Duration(rs.HaltDurationHours) * time.Hour
+
+	// The traded symbol's overrides take precedence
+	override := symbols[strings.ToUpper(h.orchestrator.GetSymbol())].Risk
+	if override.MaxPositionSize != nil {
+		rc.MaxPositionSize = *override.MaxPositionSize
+	}
+	if override.MaxRiskPerTrade != nil {
+		rc.MaxRiskPerTrade = *override.MaxRiskPerTrade
+	}
+	if override.MaxLeverage != nil {
+		rc.MaxLeverage = *override.MaxLeverage
+	}
+	if override.MinRiskRewardRatio != nil {
+		rc.MinRiskRewardRatio = *override.MinRiskRewardRatio
+	}
+	if override.StopLossPolicy != nil {
+		rc.StopLossPolicy = risk.StopLossPolicy(*override.StopLossPolicy)
+	}
+	if override.StopLossATRMultiplier != nil {
+		rc.StopLossATRMultiplier = *override.StopLossATRMultiplier
+	}
+
+	rm.UpdateConfig(&rc)
+}
+
+// applyStrategySettings pushes strategy parameters and signal cooldowns
+// to the running strategies. Parameters are validated for every strategy
+// before any changes; keys that are not parameters are ignored.
+func (h *SettingsHandler) applyStrategySettings(c echo.Context, current, next StrategySettings) (func(), error) {
+	if h.orchestrator == nil || h.orchestrator.GetStrategyManager() == nil {
+		return func() {}, nil
+	}
+
+	params := make(map[string]map[string]float64, len(next.Enabled))
+	for _, sc := range next.Enabled {
+		values := make(map[string]float64, len(sc.Config))
+		for key, v := range sc.Config {
+			switch v := v.(type) {
+			case float64:
+				values[key] = v
+			case bool:
+				values[key] = 0
+				if v {
+					values[key] = 1
+				}
+			}
+		}
+		params[sc.Name] = values
+	}
+	result, err := h.orchestrator.SetStrategyParams(params, requestActor(c))
+	if err != nil {
+		return nil, err
+	}
+	for name, keys := range result.Ignored {
+		log.Warn().Str("strategy", name).Strs("keys", keys).Msg("Settings keys are not strategy parameters, ignored")
+	}
+	h.applySignalCooldowns(next)
+
+	return func() {
+		if _, err := h.orchestrator.SetStrategyParams(result.Previous, requestActor(c)); err != nil {
+			log.Error().Err(err).Msg("Failed to restore strategy parameters")
+		}
+		h.applySignalCooldowns(current)
+	}, nil
+}
+
+// applySignalCooldowns pushes signal cooldowns to the running orchestrator
+func (h *SettingsHandler) applySignalCooldowns(ss StrategySettings) {
+	if h.orchestrator == nil {
+		return
+	}
+	for _, sc := range ss.Enabled {
+		if sc.SignalCooldown == nil {
+			continue
+		}
+		if err := h.orchestrator.SetSignalCooldown(sc.Name, *sc.SignalCooldown); err != nil {
+			log.Error().Err(err).Str("strategy", sc.Name).Msg("Failed to apply signal cooldown")
+		}
+	}
+}
+
+// applyParamVersion starts a new strategy parameter version when a
+// parameter section changed, so later trades are attributed to it
+func (h *SettingsHandler) applyParamVersion(section string, versionID int64) {
+	if h.orchestrator == nil || versionID == 0 || !orchestrator.IsParamSection(section) {
+		return
+	}
+	h.orchestrator.SetParamVersion(versionID)
+}
+
+// section returns the value of a named settings section
+func (s *FullSettingsResponse) section(name string) interface{} {
+	switch name {
+	case settingsSectionTrading:
+		return s.Trading
+	case settingsSectionRisk:
+		return s.Risk
+	case settingsSectionIndicators:
+		return s.Indicators
+	case settingsSectionStrategies:
+		return s.Strategies
+	case settingsSectionSymbols:
+		return s.Symbols
+	default:
+		return nil
+	}
+}
+
+// setSection replaces a named settings section from its JSON form
+func (s *FullSettingsResponse) setSection(name string, value json.RawMessage) error {
+	switch name {
+	case settingsSectionTrading:
+		return json.Unmarshal(value, &s.Trading)
+	case settingsSectionRisk:
+		return json.Unmarshal(value, &s.Risk)
+	case settingsSectionIndicators:
+		return json.Unmarshal(value, &s.Indicators)
+	case settingsSectionStrategies:
+		return json.Unmarshal(value, &s.Strategies)
+	case settingsSectionSymbols:
+		s.Symbols = make(map[string]config.SymbolConfig)
+		return json.Unmarshal(value, &s.Symbols)
+	default:
+		return fmt.Errorf("unknown settings section: %s", name)
+	}
+}
+
+// requestActor identifies the authenticated user making a request
+func requestActor(c echo.Context) string {
+	claims, err := middleware.GetUserClaims(c)
+	if err != nil {
+		return "unknown"
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.UserID.String()
+}
+
+func toSettingsChangeResponse(ch storage.SettingsChange) SettingsChangeResponse {
+	return SettingsChangeResponse{
+		VersionID:  ch.ID,
+		Section:    ch.Section,
+		Action:     ch.Action,
+		OldValue:   ch.OldValue,
+		NewValue:   ch.NewValue,
+		ChangedBy:  ch.ChangedBy,
+		RollbackOf: ch.RollbackOf,
+		ChangedAt:  ch.CreatedAt,
+	}
+}
+
 // getDefaultSettings returns default settings
 func getDefaultSettings() *FullSettingsResponse {
 	return &FullSettingsResponse{
@@ -266,6 +987,7 @@ func getDefaultSettings() *FullSettingsResponse {
 			MaxDailyLoss:         0.05,
 			MaxWeeklyLoss:        0.10,
 			MaxDrawdown:          0.20,
+			HighWaterMarkMode:    "trailing",
 			MaxOpenPositions:     5,
 			MaxLeverage:          1.0,
 			MinRiskRewardRatio:   1.5,
@@ -274,20 +996,28 @@ func getDefaultSettings() *FullSettingsResponse {
 			HaltDurationHours:    24,
 		},
 		Indicators: IndicatorSettings{
-			RSIPeriod:       14,
-			RSIOversold:     30,
-			RSIOverbought:   70,
-			MACDFast:        12,
-			MACDSlow:        26,
-			MACDSignal:      9,
-			BBPeriod:        20,
-			BBStdDev:        2.0,
-			ADXPeriod:       14,
-			ADXThreshold:    25,
-			ATRPeriod:       14,
-			ATRMultiplierSL: 2.0,
-			ATRMultiplierTP: 3.0,
+			RSIPeriod:            14,
+			RSIOversold:          30,
+			RSIOverbought:        70,
+			MACDFast:             12,
+			MACDSlow:             26,
+			MACDSignal:           9,
+			BBPeriod:             20,
+			BBStdDev:             2.0,
+			ADXPeriod:            14,
+			ADXThreshold:         25,
+			ATRPeriod:            14,
+			ATRMultiplierSL:      2.0,
+			ATRMultiplierTP:      3.0,
+			KeltnerPeriod:        20,
+			KeltnerMultiplier:    2.0,
+			SuperTrendPeriod:     10,
+			SuperTrendMultiplier: 3.0,
+			IchimokuTenkan:       9,
+			IchimokuKijun:        26,
+			IchimokuSenkouB:      52,
 		},
+		Symbols: map[string]config.SymbolConfig{},
 		Strategies: StrategySettings{
 			Enabled: []StrategyConfig{
 				{
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// maxShareBundleBytes bounds an uploaded share bundle
+const maxShareBundleBytes = 20 << 20
+
+// ShareHandler exports anonymized result bundles and imports ones shared
+// by other users
+type ShareHandler struct {
+	orchestrator *orchestrator.Orchestrator
+	settings     *SettingsHandler
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(orch *orchestrator.Orchestrator) *ShareHandler {
+	return &ShareHandler{
+		orchestrator: orch,
+		settings:     NewSettingsHandler(orch, nil),
+	}
+}
+
+// Export returns a bundle of the trades closed in a period, for sharing.
+// format=csv lists the trades instead, formatted in the caller's locale.
+// GET /api/v1/share/export?from=<ms>&to=<ms>&name=&candles=true&format=json|csv&locale=
+func (h *ShareHandler) Export(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	format, err := reportFormat(c, reportFormatJSON, reportFormatCSV)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	opts := orchestrator.ShareExportOptions{
+		Name:       c.QueryParam("name"),
+		ConfigHash: h.configHash(),
+	}
+	for param, t := range map[string]*time.Time{"from": &opts.From, "to": &opts.To} {
+		if v := c.QueryParam(param); v != "" {
+			ms, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid " + param + " timestamp"})
+			}
+			*t = time.UnixMilli(ms)
+		}
+	}
+	if !opts.To.IsZero() && opts.From.After(opts.To) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from must be before to"})
+	}
+	if v := c.QueryParam("candles"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "candles must be true or false"})
+		}
+		opts.IncludeCandles = include
+	}
+
+	bundle, err := h.orchestrator.ExportShareBundle(opts)
+	if errors.Is(err, orchestrator.ErrShareEmpty) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if format == reportFormatCSV {
+		locale, err := requestLocale(c, h.orchestrator)
+		if err != nil {
+			return localeError(c, err)
+		}
+		name := fmt.Sprintf("%s-%s.trades.csv", bundle.Symbol, bundle.CreatedAt.UTC().Format("20060102-150405"))
+		return writeCSV(c, name, locale, shareTradeRows(bundle, locale))
+	}
+
+	name := fmt.Sprintf("%s-%s.share.json", bundle.Symbol, bundle.CreatedAt.UTC().Format("20060102-150405"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	return c.JSON(http.StatusOK, bundle)
+}
+
+// Import stores a bundle shared by another user
+// POST /api/v1/share/import (body: the exported bundle)
+func (h *ShareHandler) Import(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxShareBundleBytes+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Failed to read bundle"})
+	}
+	if len(data) > maxShareBundleBytes {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "Bundle too large"})
+	}
+
+	record, err := h.orchestrator.ImportShareBundle(data, requestActor(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, record)
+}
+
+// GetBundles lists imported bundles
+// GET /api/v1/share/bundles
+func (h *ShareHandler) GetBundles(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	bundles, err := h.orchestrator.GetSharedBundles()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, bundles)
+}
+
+// GetBundle returns an imported bundle with its trades, equity curve and
+// candles
+// GET /api/v1/share/bundles/:id
+func (h *ShareHandler) GetBundle(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	bundle, err := h.orchestrator.GetSharedBundle(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if bundle == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Bundle not found"})
+	}
+	return c.JSON(http.StatusOK, bundle)
+}
+
+// DeleteBundle removes an imported bundle
+// DELETE /api/v1/share/bundles/:id
+func (h *ShareHandler) DeleteBundle(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	found, err := h.orchestrator.DeleteSharedBundle(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if !found {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Bundle not found"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// configHash fingerprints the settings that decide trades, so users can
+// tell whether two bundles came from the same configuration without
+// revealing it. Balances, mode and API credentials are left out.
+func (h *ShareHandler) configHash() string {
+	settings := h.settings.currentSettings()
+	fingerprint := struct {
+		Symbol           string      `json:"symbol"`
+		Timeframes       []string    `json:"timeframes"`
+		PrimaryTimeframe string      `json:"primaryTimeframe"`
+		Risk             interface{} `json:"risk"`
+		Indicators       interface{} `json:"indicators"`
+		Strategies       interface{} `json:"strategies"`
+		Symbols          interface{} `json:"symbols"`
+	}{
+		Symbol:           settings.Trading.Symbol,
+		Timeframes:       settings.Trading.Timeframes,
+		PrimaryTimeframe: settings.Trading.PrimaryTimeframe,
+		Risk:             settings.Risk,
+		Indicators:       settings.Indicators,
+		Strategies:       settings.Strategies,
+		Symbols:          settings.Symbols,
+	}
+	data, err := json.Marshal(fingerprint)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/labstack/echo/v4"
+)
+
+// StatusHandler serves the public status page: aggregates safe to share
+// or embed, with no balances, prices, orders or strategy details
+type StatusHandler struct {
+	orchestrator *orchestrator.Orchestrator
+}
+
+// NewStatusHandler creates a new status page handler
+func NewStatusHandler(orch *orchestrator.Orchestrator) *StatusHandler {
+	return &StatusHandler{orchestrator: orch}
+}
+
+// StatusPageResponse is the public status page
+type StatusPageResponse struct {
+	Status          string     `json:"status"` // running, paused, halted or stopped
+	Mode            string     `json:"mode"`   // PAPER, LIVE or OBSERVER
+	Uptime          string     `json:"uptime"`
+	UptimeSeconds   int64      `json:"uptimeSeconds"`
+	DailyPnLPercent float64    `json:"dailyPnlPercent"` // Today's PnL against the day's starting equity
+	OpenPositions   int        `json:"openPositions"`
+	LastSignalAt    *time.Time `json:"lastSignalAt,omitempty"`
+	Timestamp       time.Time  `json:"timestamp"`
+}
+
+// GetStatus returns the public status page
+// GET /status
+func (h *StatusHandler) GetStatus(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	state := h.orchestrator.GetState()
+	resp := StatusPageResponse{
+		Status:        "stopped",
+		Mode:          state.Mode.String(),
+		OpenPositions: state.OpenPositions,
+		Timestamp:     time.Now(),
+	}
+	switch {
+	case !state.IsRunning:
+	case state.IsHalted:
+		resp.Status = "halted"
+	case state.IsPaused:
+		resp.Status = "paused"
+	default:
+		resp.Status = "running"
+	}
+	if h.orchestrator.IsObserver() {
+		resp.Mode = "OBSERVER"
+	}
+	if !state.StartTime.IsZero() {
+		uptime := time.Since(state.StartTime).Round(time.Second)
+		resp.Uptime = uptime.String()
+		resp.UptimeSeconds = int64(uptime.Seconds())
+	}
+	if start := state.Equity - state.DailyPnL; start > 0 {
+		resp.DailyPnLPercent = math.Round(state.DailyPnL/start*10000) / 100
+	}
+	if signals := h.orchestrator.GetSignals(1); len(signals) > 0 {
+		at := signals[0].ReceivedAt
+		resp.LastSignalAt = &at
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
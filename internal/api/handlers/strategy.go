@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/strategy"
 	"github.com/labstack/echo/v4"
 )
 
@@ -19,11 +22,14 @@ func NewStrategyHandler(orch *orchestrator.Orchestrator) *StrategyHandler {
 
 // StrategyInfo represents strategy information
 type StrategyInfo struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Enabled     bool                   `json:"enabled"`
-	Config      map[string]interface{} `json:"config"`
-	Performance *StrategyPerformance   `json:"performance,omitempty"`
+	Name          string                                `json:"name"`
+	Description   string                                `json:"description"`
+	Enabled       bool                                  `json:"enabled"`
+	Config        map[string]interface{}                `json:"config"`
+	Performance   *StrategyPerformance                  `json:"performance,omitempty"`
+	Signals       *orchestrator.SignalThrottleStats     `json:"signals,omitempty"`
+	Spikes        *orchestrator.VolatilityThrottleStats `json:"spikes,omitempty"`        // Entries signaled on volatility spikes
+	Confirmations *orchestrator.EntryConfirmationStats  `json:"confirmations,omitempty"` // Entries held for a confirmation candle
 }
 
 // StrategyPerformance represents strategy performance metrics
@@ -93,6 +99,18 @@ func (h *StrategyHandler) GetStrategies(c echo.Context) error {
 		},
 	}
 
+	if h.orchestrator != nil {
+		throttle := h.orchestrator.GetSignalThrottleStats()
+		for i := range strategies {
+			stats := throttle[strategies[i].Name]
+			stats.Strategy = strategies[i].Name
+			stats.Cooldown = h.orchestrator.GetSignalCooldown(strategies[i].Name)
+			strategies[i].Signals = &stats
+			strategies[i].Spikes = h.spikeStats(strategies[i].Name)
+			strategies[i].Confirmations = h.confirmationStats(strategies[i].Name)
+		}
+	}
+
 	return c.JSON(http.StatusOK, strategies)
 }
 
@@ -106,10 +124,37 @@ func (h *StrategyHandler) GetStrategy(c echo.Context) error {
 		Enabled: true,
 		Config:  map[string]interface{}{},
 	}
+	if h.orchestrator != nil {
+		if stats, ok := h.orchestrator.GetSignalThrottleStats()[name]; ok {
+			strategy.Signals = &stats
+		}
+		strategy.Spikes = h.spikeStats(name)
+		strategy.Confirmations = h.confirmationStats(name)
+	}
 
 	return c.JSON(http.StatusOK, strategy)
 }
 
+// spikeStats returns a strategy's volatility spike counters, nil before
+// its first spike
+func (h *StrategyHandler) spikeStats(name string) *orchestrator.VolatilityThrottleStats {
+	stats, ok := h.orchestrator.GetVolatilityThrottleStats()[strategy.CanonicalName(name)]
+	if !ok {
+		return nil
+	}
+	return &stats
+}
+
+// confirmationStats returns a strategy's entry confirmation counters, nil
+// before its first held entry
+func (h *StrategyHandler) confirmationStats(name string) *orchestrator.EntryConfirmationStats {
+	stats, ok := h.orchestrator.GetEntryConfirmationStats()[strategy.CanonicalName(name)]
+	if !ok {
+		return nil
+	}
+	return &stats
+}
+
 // UpdateStrategyRequest represents strategy update request
 type UpdateStrategyRequest struct {
 	Config map[string]interface{} `json:"config"`
@@ -128,51 +173,39 @@ func (h *StrategyHandler) UpdateStrategy(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"status": "updated", "strategy": name})
 }
 
-// EnableStrategy enables a strategy
+// EnableStrategy enables a strategy from the next candle on and persists
+// the change
 func (h *StrategyHandler) EnableStrategy(c echo.Context) error {
-	name := c.Param("name")
-
-	strategyMgr := h.orchestrator.GetStrategyManager()
-	if strategyMgr == nil {
-		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
-	}
-
-	strategies := strategyMgr.GetStrategies()
-	strategy, ok := strategies[name]
-	if !ok {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "Strategy not found"})
-	}
-
-	strategy.SetEnabled(true)
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"status":   "enabled",
-		"strategy": name,
-		"enabled":  true,
-	})
+	return h.setStrategyEnabled(c, true)
 }
 
-// DisableStrategy disables a strategy
+// DisableStrategy disables a strategy from the next candle on and persists
+// the change
 func (h *StrategyHandler) DisableStrategy(c echo.Context) error {
-	name := c.Param("name")
+	return h.setStrategyEnabled(c, false)
+}
 
-	strategyMgr := h.orchestrator.GetStrategyManager()
-	if strategyMgr == nil {
+func (h *StrategyHandler) setStrategyEnabled(c echo.Context, enabled bool) error {
+	if h.orchestrator.GetStrategyManager() == nil {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
 	}
 
-	strategies := strategyMgr.GetStrategies()
-	strategy, ok := strategies[name]
-	if !ok {
+	name, err := h.orchestrator.SetStrategyEnabled(c.Param("name"), enabled, requestActor(c))
+	if errors.Is(err, orchestrator.ErrStrategyNotFound) {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "Strategy not found"})
 	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to persist strategy state: " + err.Error()})
+	}
 
-	strategy.SetEnabled(false)
-
+	status := "disabled"
+	if enabled {
+		status = "enabled"
+	}
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"status":   "disabled",
+		"status":   status,
 		"strategy": name,
-		"enabled":  false,
+		"enabled":  enabled,
 	})
 }
 
@@ -199,15 +232,22 @@ func (h *StrategyHandler) GetSignals(c echo.Context) error {
 
 // RegimeInfo represents market regime information
 type RegimeInfo struct {
-	Current     string  `json:"current"`
-	Confidence  float64 `json:"confidence"`
-	ADX         float64 `json:"adx"`
-	RSI         float64 `json:"rsi"`
-	Volatility  string  `json:"volatility"`
-	Description string  `json:"description"`
+	Current       string                         `json:"current"`
+	Confidence    float64                        `json:"confidence"`
+	Trend         string                         `json:"trend"`
+	TrendStrength string                         `json:"trendStrength"`
+	ADX           float64                        `json:"adx"`
+	RSI           float64                        `json:"rsi"`
+	Volatility    string                         `json:"volatility"`
+	Description   string                         `json:"description"`
+	Session       string                         `json:"session"`          // Current trading session
+	Routing       bool                           `json:"routing"`          // Strategies are routed by regime
+	Routes        map[string][]string            `json:"routes,omitempty"` // Strategies per regime; regimes not listed trade all
+	Strategies    []strategy.StrategyEligibility `json:"strategies"`       // Which strategies trade in the current regime
 }
 
-// GetRegime returns current market regime
+// GetRegime returns the current market regime as detected on the primary
+// timeframe, and the strategies it routes to
 func (h *StrategyHandler) GetRegime(c echo.Context) error {
 	if h.orchestrator == nil {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
@@ -217,11 +257,29 @@ func (h *StrategyHandler) GetRegime(c echo.Context) error {
 
 	regime := RegimeInfo{
 		Current:    state.CurrentRegime,
-		Confidence: 0.8,
+		Session:    string(strategy.SessionAt(time.Now())),
+		Strategies: []strategy.StrategyEligibility{},
+	}
+	if strategyMgr := h.orchestrator.GetStrategyManager(); strategyMgr != nil {
+		detected := strategyMgr.GetLastRegime()
+		regime.Current = detected.Regime.String()
+		regime.Confidence = detected.Confidence
+		regime.Trend = detected.TrendDir.String()
+		regime.TrendStrength = detected.TrendStrength.String()
+		regime.ADX = detected.Details.ADX
+		regime.RSI = detected.Details.RSI
+		regime.Volatility = detected.Volatility.String()
+
+		scorer := strategyMgr.GetScorer()
+		if routes := scorer.GetRegimeRoutes(); routes != nil {
+			regime.Routing = true
+			regime.Routes = strategy.RegimeRouteNames(routes)
+		}
+		regime.Strategies = scorer.Preview(detected.Regime)
 	}
 
 	// Add description based on regime
-	switch state.CurrentRegime {
+	switch regime.Current {
 	case "TRENDING":
 		regime.Description = "Market is in a strong trend"
 	case "MEAN_REVERTING":
@@ -236,3 +294,36 @@ func (h *StrategyHandler) GetRegime(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, regime)
 }
+
+// StrategyPreviewResponse shows which strategies take part in scoring
+type StrategyPreviewResponse struct {
+	Regime     string                         `json:"regime"`
+	Strategies []strategy.StrategyEligibility `json:"strategies"`
+}
+
+// GetPreview returns strategy eligibility for the current regime, or for the
+// regime given in the "regime" query parameter
+func (h *StrategyHandler) GetPreview(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	strategyMgr := h.orchestrator.GetStrategyManager()
+	if strategyMgr == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Strategy manager not available"})
+	}
+
+	regime := strategyMgr.GetLastRegime().Regime
+	if r := c.QueryParam("regime"); r != "" {
+		parsed, err := strategy.ParseMarketRegime(r)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		regime = parsed
+	}
+
+	return c.JSON(http.StatusOK, StrategyPreviewResponse{
+		Regime:     regime.String(),
+		Strategies: strategyMgr.GetScorer().Preview(regime),
+	})
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// SystemHandler handles database maintenance and stream subscription endpoints
+type SystemHandler struct {
+	orchestrator *orchestrator.Orchestrator
+	backupDir    string
+}
+
+// NewSystemHandler creates a new system handler. Backups requested without
+// download are saved under backupDir.
+func NewSystemHandler(orch *orchestrator.Orchestrator, backupDir string) *SystemHandler {
+	return &SystemHandler{orchestrator: orch, backupDir: backupDir}
+}
+
+// CreateBackup takes an online backup of the trading database. With
+// download=true the file is streamed to the caller, otherwise it is saved
+// to the backup directory.
+// POST /api/v1/system/backup?download=true
+func (h *SystemHandler) CreateBackup(c echo.Context) error {
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	ds := h.orchestrator.GetDataService()
+
+	download := c.QueryParam("download") == "true"
+	name := fmt.Sprintf("trading-%s.db", time.Now().UTC().Format("20060102-150405"))
+
+	record := storage.BackupRecord{
+		Destination: "file",
+		RequestedBy: requestActor(c),
+	}
+	var path string
+	if download {
+		record.Destination = "download"
+		dir, err := os.MkdirTemp("", "eth-bot-backup-")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create temporary file"})
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, name)
+	} else {
+		if h.backupDir == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "No backup directory configured, use download=true"})
+		}
+		if err := os.MkdirAll(h.backupDir, 0o755); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create backup directory"})
+		}
+		path = filepath.Join(h.backupDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return c.JSON(http.StatusConflict, map[string]string{"error": "A backup was just taken, retry in a second"})
+		}
+		record.Path = path
+	}
+
+	start := time.Now()
+	err := ds.BackupDatabase(c.Request().Context(), path)
+	record.DurationMs = time.Since(start).Milliseconds()
+	if err == nil {
+		if info, statErr := os.Stat(path); statErr == nil {
+			record.SizeBytes = info.Size()
+		}
+		record.Status = "ok"
+	} else {
+		os.Remove(path)
+		record.Status = "failed"
+		record.Error = err.Error()
+	}
+
+	if id, recErr := ds.RecordBackup(record); recErr != nil {
+		log.Warn().Err(recErr).Msg("Failed to record backup")
+	} else {
+		record.ID = id
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("destination", record.Destination).Msg("Database backup failed")
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	log.Info().
+		Str("destination", record.Destination).
+		Str("path", record.Path).
+		Int64("bytes", record.SizeBytes).
+		Int64("durationMs", record.DurationMs).
+		Msg("Database backup completed")
+
+	if download {
+		return c.Attachment(path, name)
+	}
+	record.CreatedAt = start
+	return c.JSON(http.StatusOK, record)
+}
+
+// GetBackups returns the backup history
+// GET /api/v1/system/backups?limit=20
+func (h *SystemHandler) GetBackups(c echo.Context) error {
+	if h.orchestrator == nil || h.orchestrator.GetDataService() == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	limit := 20
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 500 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit"})
+		}
+		limit = n
+	}
+
+	records, err := h.orchestrator.GetDataService().GetBackups(limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to load backup history"})
+	}
+	if records == nil {
+		records = []storage.BackupRecord{}
+	}
+	return c.JSON(http.StatusOK, records)
+}
+
+// SubscriptionRequest names a Binance stream to subscribe to
+type SubscriptionRequest struct {
+	Stream string `json:"stream"` // e.g. ethusdt@depth20@100ms
+}
+
+// GetSubscriptions lists the subscribed Binance streams
+// GET /api/v1/system/subscriptions
+func (h *SystemHandler) GetSubscriptions(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	return c.JSON(http.StatusOK, h.orchestrator.GetSubscriptions())
+}
+
+// AddSubscription subscribes to a Binance stream, restored after restarts
+// POST /api/v1/system/subscriptions
+func (h *SystemHandler) AddSubscription(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req SubscriptionRequest
+	if err := c.Bind(&req); err != nil || req.Stream == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "A stream is required"})
+	}
+	if _, err := binance.NormalizeStreamName(req.Stream); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	sub, err := h.orchestrator.AddSubscription(req.Stream, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrSubscriptionExists):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrTooManySubscriptions):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case err != nil && sub == nil:
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Subscribed but failed to persist: " + err.Error()})
+	}
+	return c.JSON(http.StatusCreated, sub)
+}
+
+// RemoveSubscription unsubscribes from a stream added through the API
+// DELETE /api/v1/system/subscriptions?stream=ethusdt@depth20@100ms
+func (h *SystemHandler) RemoveSubscription(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	stream := c.QueryParam("stream")
+	if stream == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "A stream is required"})
+	}
+	stream, err := binance.NormalizeStreamName(stream)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	err = h.orchestrator.RemoveSubscription(stream, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrSubscriptionNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrCoreSubscription):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to unsubscribe: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"status": "unsubscribed", "stream": stream})
+}
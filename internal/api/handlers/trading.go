@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/storage"
 	"github.com/labstack/echo/v4"
 )
 
@@ -32,6 +36,31 @@ func (h *TradingHandler) GetState(c echo.Context) error {
 	return c.JSON(http.StatusOK, TradingStateResponse{State: state})
 }
 
+// GetStateSnapshot returns the trading state with a version, or only the
+// fields changed since a version, for dashboards polling instead of
+// following the WebSocket
+// GET /api/v1/state?since=<version>
+func (h *TradingHandler) GetStateSnapshot(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var since uint64
+	if s := c.QueryParam("since"); s != "" {
+		parsed, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "since must be a state version"})
+		}
+		since = parsed
+	}
+
+	snapshot, err := h.orchestrator.GetStateSnapshot(since)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, snapshot)
+}
+
 // Start starts the trading bot
 func (h *TradingHandler) Start(c echo.Context) error {
 	if h.orchestrator == nil {
@@ -91,6 +120,9 @@ func (h *TradingHandler) GetMode(c echo.Context) error {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
 	}
 
+	if h.orchestrator.IsObserver() {
+		return c.JSON(http.StatusOK, ModeResponse{Mode: "OBSERVER"})
+	}
 	state := h.orchestrator.GetState()
 	return c.JSON(http.StatusOK, ModeResponse{Mode: state.Mode.String()})
 }
@@ -102,7 +134,207 @@ func (h *TradingHandler) SetMode(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
+	if h.orchestrator != nil && h.orchestrator.IsObserver() {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "Observer mode does not trade, restart in paper or live mode"})
+	}
+	if req.Mode == "live" && h.orchestrator != nil && h.orchestrator.ArmingRequired() {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "Live mode requires arming via POST /api/v1/trading/arm"})
+	}
+
 	// Mode switching would require recreating executor
 	// For now, just acknowledge the request
 	return c.JSON(http.StatusOK, ModeResponse{Mode: req.Mode})
 }
+
+// GetSchedule returns the trading mode schedule status and next transition
+func (h *TradingHandler) GetSchedule(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetScheduleStatus())
+}
+
+// GetReconciliation compares live fills with the shadow paper account
+func (h *TradingHandler) GetReconciliation(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	limit := 100
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	report := h.orchestrator.GetReconciliationReport(limit)
+	if report == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Shadow paper account not enabled"})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetDust returns residual balances too small to trade
+func (h *TradingHandler) GetDust(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	report, err := h.orchestrator.GetDustReport()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// ConvertDust converts dust balances to BNB
+func (h *TradingHandler) ConvertDust(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	conversion, err := h.orchestrator.ConvertDust()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, conversion)
+}
+
+// GetOrderIntents returns recent order intents and their execution state
+func (h *TradingHandler) GetOrderIntents(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	limit := 100
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	intents, err := h.orchestrator.GetOrderIntents(limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if intents == nil {
+		intents = []storage.OrderIntent{}
+	}
+
+	return c.JSON(http.StatusOK, intents)
+}
+
+// ChaosRequest requests an outage drill
+type ChaosRequest struct {
+	Fault string `json:"fault"` // ws_drop, rate_limit, delayed_fill or partial_fill
+	Count int    `json:"count"` // Orders the fault applies to (default 1)
+}
+
+// GetChaos returns the outage drill configuration and recent drills
+func (h *TradingHandler) GetChaos(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetChaosStatus())
+}
+
+// InjectChaos runs an outage drill against the paper account
+func (h *TradingHandler) InjectChaos(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req ChaosRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if !orchestrator.ValidChaosFault(req.Fault) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Fault must be ws_drop, rate_limit, delayed_fill or partial_fill"})
+	}
+	if req.Count < 0 || req.Count > 100 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Count must be between 1 and 100"})
+	}
+
+	event, err := h.orchestrator.InjectFault(req.Fault, req.Count, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrChaosDisabled), errors.Is(err, orchestrator.ErrChaosNotPaper):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, event)
+}
+
+// ClearChaos drops order faults that have not been applied yet
+func (h *TradingHandler) ClearChaos(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	if err := h.orchestrator.ClearChaos(); err != nil {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, h.orchestrator.GetChaosStatus())
+}
+
+// CapitalTransferRequest moves capital into or out of the bot's sub-balance
+type CapitalTransferRequest struct {
+	Amount float64 `json:"amount"` // Positive into the sub-balance, negative out of it
+	Note   string  `json:"note,omitempty"`
+}
+
+// GetCapital returns the bot's sub-balance against the whole account
+func (h *TradingHandler) GetCapital(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	status, err := h.orchestrator.GetSubBalance()
+	if err != nil {
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, status)
+}
+
+// GetEntryPolicy returns how signal orders are placed and how maker-first
+// entries were filled
+func (h *TradingHandler) GetEntryPolicy(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+	return c.JSON(http.StatusOK, h.orchestrator.GetEntryStatus())
+}
+
+// TransferCapital moves capital between the sub-balance and the rest of
+// the account
+func (h *TradingHandler) TransferCapital(c echo.Context) error {
+	if h.orchestrator == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "Orchestrator not available"})
+	}
+
+	var req CapitalTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if req.Amount == 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Amount must be a non-zero number"})
+	}
+
+	status, err := h.orchestrator.TransferCapital(req.Amount, req.Note, requestActor(c))
+	switch {
+	case errors.Is(err, orchestrator.ErrNoSubBalance):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, orchestrator.ErrTransferExceedsAccount), errors.Is(err, orchestrator.ErrTransferExceedsBalance):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/api/middleware"
+	"github.com/eth-trading/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// GetTwoFactor returns the caller's two-factor authentication status
+// GET /api/v1/auth/2fa
+func (h *AuthHandler) GetTwoFactor(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := h.authService.GetTwoFactorStatus(userID)
+	if err != nil {
+		return middleware.TwoFactorError(err)
+	}
+	return c.JSON(http.StatusOK, status)
+}
+
+// EnrollTwoFactor starts two-factor enrollment, returning the TOTP secret
+// for the caller's authenticator app. Until it is confirmed, codes are not
+// required.
+// POST /api/v1/auth/2fa/enroll
+func (h *AuthHandler) EnrollTwoFactor(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := h.authService.BeginTwoFactorEnrollment(userID)
+	if err != nil {
+		return middleware.TwoFactorError(err)
+	}
+	return c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactor enables two-factor authentication with a code from the
+// enrolled authenticator app
+// POST /api/v1/auth/2fa/confirm
+func (h *AuthHandler) ConfirmTwoFactor(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "code required")
+	}
+
+	if err := h.authService.ConfirmTwoFactorEnrollment(userID, req.Code); err != nil {
+		return middleware.TwoFactorError(err)
+	}
+
+	log.Info().Str("user_id", userID.String()).Msg("Two-factor authentication enabled")
+	return h.GetTwoFactor(c)
+}
+
+// DisableTwoFactor removes two-factor authentication after checking the
+// caller's password and a current code
+// POST /api/v1/auth/2fa/disable
+func (h *AuthHandler) DisableTwoFactor(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.TwoFactorDisableRequest
+	if err := c.Bind(&req); err != nil || req.Password == "" || req.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "password and code required")
+	}
+
+	if err := h.authService.DisableTwoFactor(userID, req.Password, req.Code); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Two-factor disable rejected")
+		return middleware.TwoFactorError(err)
+	}
+
+	log.Info().Str("user_id", userID.String()).Msg("Two-factor authentication disabled")
+	return h.GetTwoFactor(c)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/eth-trading/internal/models"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // AuthMiddleware provides authentication middleware
@@ -28,6 +30,10 @@ type contextKey string
 const (
 	// UserContextKey is the key for user claims in context
 	UserContextKey contextKey = "user"
+
+	// TwoFactorHeader carries the authenticator app code sensitive
+	// operations require
+	TwoFactorHeader = "X-OTP-Code"
 )
 
 // Authenticate is middleware that validates JWT tokens
@@ -119,6 +125,54 @@ func (m *AuthMiddleware) RequireOwnership(getUserID func(c echo.Context) (uuid.U
 	}
 }
 
+// RequireTwoFactor is middleware that verifies the caller's two-factor code
+// before a sensitive operation, such as arming live mode
+func (m *AuthMiddleware) RequireTwoFactor(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := VerifyTwoFactor(c, m.authService); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// VerifyTwoFactor checks the two-factor code in the TwoFactorHeader of a
+// request from an authenticated user, for handlers where only some requests
+// are sensitive
+func VerifyTwoFactor(c echo.Context, authService *auth.Service) error {
+	claims, err := GetUserClaims(c)
+	if err != nil {
+		return err
+	}
+	err = authService.VerifyTwoFactor(claims.UserID, c.Request().Header.Get(TwoFactorHeader))
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", claims.UserID.String()).Str("path", c.Path()).Msg("Two-factor verification failed")
+	}
+	return TwoFactorError(err)
+}
+
+// TwoFactorError converts a two-factor authentication error to the HTTP
+// error answered. Failed codes are 403, not 401, so that clients do not
+// take them for an expired access token.
+func TwoFactorError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, models.ErrTwoFactorUnavailable):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, models.ErrTwoFactorAlreadyEnabled), errors.Is(err, models.ErrTwoFactorNotEnrolled):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrTwoFactorRequired), errors.Is(err, models.ErrInvalidTwoFactorCode),
+		errors.Is(err, models.ErrInvalidCredentials):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, models.ErrTwoFactorLocked):
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, models.ErrTwoFactorCodeRequired):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error()+" in the "+TwoFactorHeader+" header")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "two-factor verification failed")
+}
+
 // GetUserClaims retrieves user claims from echo context
 func GetUserClaims(c echo.Context) (*models.JWTClaims, error) {
 	claims, ok := c.Get(string(UserContextKey)).(*models.JWTClaims)
@@ -0,0 +1,223 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxCacheEntries bounds the number of cached responses
+const maxCacheEntries = 1024
+
+// ResponseCache caches GET responses for a short TTL and answers conditional
+// requests (If-None-Match / If-Modified-Since) with 304 Not Modified
+type ResponseCache struct {
+	ttl     time.Duration
+	entries map[string]*cachedResponse
+	mu      sync.RWMutex
+}
+
+// cachedResponse is a captured response body and its validators
+type cachedResponse struct {
+	status      int
+	contentType string
+	body        []byte
+	etag        string
+	modified    time.Time // When the body last changed
+	expires     time.Time
+}
+
+// NewResponseCache creates a response cache; a ttl <= 0 disables caching
+// but conditional requests are still answered
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttl:     ttl,
+		entries: make(map[string]*cachedResponse),
+	}
+}
+
+// Cache is middleware that serves GET responses from the cache while fresh
+func (rc *ResponseCache) Cache(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if req.Method != http.MethodGet {
+			return next(c)
+		}
+
+		key := cacheKey(c)
+		previous, fresh := rc.lookup(key)
+		if fresh {
+			c.Response().Header().Set("X-Cache", "HIT")
+			return serveCached(c, previous)
+		}
+
+		// Buffer the response so validators can be computed before writing
+		res := c.Response()
+		recorder := &bodyRecorder{ResponseWriter: res.Writer, status: http.StatusOK}
+		res.Writer = recorder
+		err := next(c)
+		res.Writer = recorder.ResponseWriter
+		if err != nil {
+			if res.Committed {
+				recorder.flush()
+			}
+			return err
+		}
+
+		if recorder.status != http.StatusOK {
+			recorder.flush()
+			return nil
+		}
+
+		sum := sha256.Sum256(recorder.body.Bytes())
+		entry := &cachedResponse{
+			status:      recorder.status,
+			contentType: res.Header().Get(echo.HeaderContentType),
+			body:        recorder.body.Bytes(),
+			etag:        `"` + hex.EncodeToString(sum[:8]) + `"`,
+			modified:    time.Now().UTC().Truncate(time.Second),
+			expires:     time.Now().Add(rc.ttl),
+		}
+		// An unchanged body keeps its modification time across refreshes
+		if previous != nil && previous.etag == entry.etag {
+			entry.modified = previous.modified
+		}
+		rc.store(key, entry)
+
+		res.Header().Set("X-Cache", "MISS")
+		setValidators(res.Header(), entry)
+		if notModified(req, entry) {
+			res.Status = http.StatusNotModified
+			recorder.ResponseWriter.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+		recorder.flush()
+		return nil
+	}
+}
+
+// InvalidateOnWrite is middleware that expires the cache after a successful
+// state-changing request
+func (rc *ResponseCache) InvalidateOnWrite(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		switch c.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if err == nil && c.Response().Status < http.StatusBadRequest {
+				rc.Invalidate()
+			}
+		}
+		return err
+	}
+}
+
+// Invalidate expires every cached response. Validators are kept so clients
+// holding an unchanged ETag still get 304 after the next refresh.
+func (rc *ResponseCache) Invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, entry := range rc.entries {
+		entry.expires = time.Time{}
+	}
+}
+
+// lookup returns the entry for key and whether it is still fresh
+func (rc *ResponseCache) lookup(key string) (*cachedResponse, bool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return entry, rc.ttl > 0 && time.Now().Before(entry.expires)
+}
+
+// store saves an entry, pruning stale entries when the cache is full
+func (rc *ResponseCache) store(key string, entry *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, exists := rc.entries[key]; !exists && len(rc.entries) >= maxCacheEntries {
+		now := time.Now()
+		for k, e := range rc.entries {
+			if now.After(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+		if len(rc.entries) >= maxCacheEntries {
+			rc.entries = make(map[string]*cachedResponse)
+		}
+	}
+	rc.entries[key] = entry
+}
+
+// cacheKey identifies a response by user, path and query
+func cacheKey(c echo.Context) string {
+	user := ""
+	if claims, err := GetUserClaims(c); err == nil {
+		user = claims.UserID.String()
+	}
+	return user + "|" + c.Request().URL.Path + "?" + c.Request().URL.RawQuery
+}
+
+// serveCached writes a cached response, or 304 when the client's copy is current
+func serveCached(c echo.Context, entry *cachedResponse) error {
+	setValidators(c.Response().Header(), entry)
+	if notModified(c.Request(), entry) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(entry.status, entry.contentType, entry.body)
+}
+
+// setValidators sets the ETag and Last-Modified headers
+func setValidators(header http.Header, entry *cachedResponse) {
+	header.Set("ETag", entry.etag)
+	header.Set("Last-Modified", entry.modified.Format(http.TimeFormat))
+	header.Set("Cache-Control", "no-cache")
+}
+
+// notModified reports whether the request's validators match the entry.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(req *http.Request, entry *cachedResponse) bool {
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == entry.etag {
+				return true
+			}
+		}
+		return false
+	}
+	if since := req.Header.Get("If-Modified-Since"); since != "" {
+		t, err := http.ParseTime(since)
+		return err == nil && !entry.modified.After(t)
+	}
+	return false
+}
+
+// bodyRecorder buffers a response instead of writing it
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bodyRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// flush writes the buffered response through
+func (r *bodyRecorder) flush() {
+	r.ResponseWriter.WriteHeader(r.status)
+	r.ResponseWriter.Write(r.body.Bytes())
+}
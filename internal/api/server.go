@@ -9,10 +9,13 @@ import (
 	"github.com/eth-trading/internal/api/middleware"
 	"github.com/eth-trading/internal/api/websocket"
 	"github.com/eth-trading/internal/auth"
+	"github.com/eth-trading/internal/config"
+	"github.com/eth-trading/internal/logstream"
 	"github.com/eth-trading/internal/orchestrator"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
 )
 
 // ServerConfig holds server configuration
@@ -23,6 +26,13 @@ type ServerConfig struct {
 	ShutdownTimeout time.Duration
 	CORSOrigins     []string
 	EnableSwagger   bool
+	CacheTTL        time.Duration     // Hot endpoint response cache lifetime (<= 0 disables)
+	WSCompression   bool              // Offer permessage-deflate to dashboard WebSocket clients
+	StatusPage      bool              // Serve the public read-only status page at /status
+	StatusRateLimit int               // Status page requests per minute per client IP
+	LogStream       *logstream.Stream // Application log tail served at /logs (nil disables)
+	BackupDir       string            // Where database backups are saved
+	Config          *config.Manager   // Applies settings changes to the running bot (nil applies risk settings only)
 }
 
 // DefaultServerConfig returns default configuration
@@ -34,6 +44,9 @@ func DefaultServerConfig() *ServerConfig {
 		ShutdownTimeout: 10 * time.Second,
 		CORSOrigins:     []string{"*"},
 		EnableSwagger:   true,
+		CacheTTL:        2 * time.Second,
+		WSCompression:   true,
+		StatusRateLimit: 30,
 	}
 }
 
@@ -44,6 +57,7 @@ type Server struct {
 	orchestrator *orchestrator.Orchestrator
 	authService  *auth.Service
 	wsHub        *websocket.Hub
+	cache        *middleware.ResponseCache
 }
 
 // NewServer creates a new API server
@@ -62,8 +76,11 @@ func NewServer(config *ServerConfig, orch *orchestrator.Orchestrator, authServic
 		orchestrator: orch,
 		authService:  authService,
 		wsHub:        websocket.NewHub(),
+		cache:        middleware.NewResponseCache(config.CacheTTL),
 	}
 
+	server.wsHub.SetCompression(config.WSCompression)
+
 	server.setupMiddleware()
 	server.setupRoutes()
 
@@ -107,12 +124,45 @@ func (s *Server) setupRoutes() {
 	positionHandler := handlers.NewPositionHandler(s.orchestrator)
 	orderHandler := handlers.NewOrderHandler(s.orchestrator)
 	candleHandler := handlers.NewCandleHandler(s.orchestrator)
+	healthHandler := handlers.NewHealthHandler(s.orchestrator)
+	allocationHandler := handlers.NewAllocationHandler(s.orchestrator)
+	armingHandler := handlers.NewArmingHandler(s.orchestrator, s.authService)
+	inboxHandler := handlers.NewInboxHandler(s.orchestrator)
+	copyTradingHandler := handlers.NewCopyTradingHandler(s.orchestrator)
+	sandboxHandler := handlers.NewSandboxHandler(s.orchestrator)
+	indicatorSeriesHandler := handlers.NewIndicatorSeriesHandler(s.orchestrator)
+	bandwidthHandler := handlers.NewBandwidthHandler(s.orchestrator, s.wsHub)
+	rateLimitHandler := handlers.NewRateLimitHandler(s.orchestrator)
+	latencyHandler := handlers.NewLatencyHandler(s.orchestrator)
+	evaluationHandler := handlers.NewEvaluationHandler(s.orchestrator)
+	scoreHandler := handlers.NewScoreHandler(s.orchestrator)
+	scanHandler := handlers.NewScanHandler(s.orchestrator)
+	historyHandler := handlers.NewHistoryHandler(s.orchestrator)
+	auditHandler := handlers.NewAuditHandler(s.orchestrator)
+	shareHandler := handlers.NewShareHandler(s.orchestrator)
+	reportHandler := handlers.NewReportHandler(s.orchestrator)
+	accountHandler := handlers.NewAccountHandler(s.authService, s.orchestrator)
+	logHandler := handlers.NewLogHandler(s.config.LogStream)
+	systemHandler := handlers.NewSystemHandler(s.orchestrator, s.config.BackupDir)
+	analyticsHandler := handlers.NewAnalyticsHandler(s.orchestrator)
 
 	// Health check (public)
 	s.echo.GET("/health", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{"status": "healthy"})
 	})
 
+	// Internal loop health (public, used by container health checks)
+	s.echo.GET("/healthz", healthHandler.Healthz)
+
+	// Read-only status page (public, rate limited, embeddable from any origin)
+	if s.config.StatusPage {
+		statusHandler := handlers.NewStatusHandler(s.orchestrator)
+		s.echo.GET("/status", statusHandler.GetStatus, s.statusRateLimiter(), echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+			AllowOrigins: []string{"*"},
+			AllowMethods: []string{http.MethodGet},
+		}))
+	}
+
 	// API v1 group
 	v1 := s.echo.Group("/api/v1")
 
@@ -129,69 +179,198 @@ func (s *Server) setupRoutes() {
 	authProtected.POST("/logout", authHandler.Logout)
 	authProtected.GET("/me", authHandler.GetMe)
 	authProtected.POST("/change-password", authHandler.ChangePassword)
+	authProtected.GET("/2fa", authHandler.GetTwoFactor)
+	authProtected.POST("/2fa/enroll", authHandler.EnrollTwoFactor)
+	authProtected.POST("/2fa/confirm", authHandler.ConfirmTwoFactor)
+	authProtected.POST("/2fa/disable", authHandler.DisableTwoFactor)
+
+	// Protected routes (require authentication); writes expire cached reads
+	protected := v1.Group("", authMiddleware.Authenticate, s.cache.InvalidateOnWrite)
+
+	// Hot read endpoints polled by dashboards are served from a short-TTL cache
+	cached := s.cache.Cache
 
-	// Protected routes (require authentication)
-	protected := v1.Group("", authMiddleware.Authenticate)
+	// Sensitive operations need the caller's two-factor code once enrolled
+	twoFactor := authMiddleware.RequireTwoFactor
 
 	// Dashboard routes
-	protected.GET("/dashboard", dashboardHandler.GetDashboard)
-	protected.GET("/dashboard/summary", dashboardHandler.GetSummary)
-	protected.GET("/dashboard/equity-curve", dashboardHandler.GetEquityCurve)
-	protected.GET("/dashboard/performance", dashboardHandler.GetPerformance)
+	protected.GET("/dashboard", dashboardHandler.GetDashboard, cached)
+	protected.GET("/dashboard/summary", dashboardHandler.GetSummary, cached)
+	protected.GET("/dashboard/equity-curve", dashboardHandler.GetEquityCurve, cached)
+	protected.GET("/dashboard/performance", dashboardHandler.GetPerformance, cached)
+	protected.GET("/dashboard/performance/versions", dashboardHandler.GetVersionPerformance, cached)
+	protected.GET("/dashboard/performance/sessions", dashboardHandler.GetSessionPerformance, cached)
+
+	// Strategy performance by the market regime positions were entered in
+	protected.GET("/analytics/regime-matrix", analyticsHandler.GetRegimeMatrix, cached)
 
 	// Trading routes
-	protected.GET("/trading/state", tradingHandler.GetState)
+	protected.GET("/trading/state", tradingHandler.GetState, cached)
+	protected.GET("/state", tradingHandler.GetStateSnapshot)
 	protected.POST("/trading/start", tradingHandler.Start)
 	protected.POST("/trading/stop", tradingHandler.Stop)
 	protected.POST("/trading/pause", tradingHandler.Pause)
 	protected.POST("/trading/resume", tradingHandler.Resume)
 	protected.GET("/trading/mode", tradingHandler.GetMode)
 	protected.POST("/trading/mode", tradingHandler.SetMode)
+	protected.GET("/trading/arm", armingHandler.GetStatus)
+	protected.POST("/trading/arm", armingHandler.Arm, twoFactor)
+	protected.POST("/trading/disarm", armingHandler.Disarm)
+	protected.GET("/trading/schedule", tradingHandler.GetSchedule)
+	protected.GET("/trading/reconciliation", tradingHandler.GetReconciliation)
+	protected.GET("/trading/dust", tradingHandler.GetDust)
+	protected.POST("/trading/dust/convert", tradingHandler.ConvertDust)
+	protected.GET("/trading/intents", tradingHandler.GetOrderIntents)
+	protected.GET("/trading/chaos", tradingHandler.GetChaos)
+	protected.POST("/trading/chaos", tradingHandler.InjectChaos)
+	protected.DELETE("/trading/chaos", tradingHandler.ClearChaos)
+	protected.GET("/trading/capital", tradingHandler.GetCapital)
+	protected.POST("/trading/capital/transfers", tradingHandler.TransferCapital)
+	protected.GET("/trading/entry", tradingHandler.GetEntryPolicy)
+
+	// Trade idea inbox routes (semi-automatic mode)
+	protected.GET("/inbox", inboxHandler.GetInbox)
+	protected.POST("/inbox/:id/approve", inboxHandler.ApproveIdea)
+	protected.POST("/inbox/:id/reject", inboxHandler.RejectIdea)
+
+	// Copy trading: followers this bot pushes signals to, and signals pushed
+	// by leaders (public, authenticated by the request signature)
+	protected.GET("/copy", copyTradingHandler.GetStatus)
+	protected.POST("/copy/followers", copyTradingHandler.AddFollower)
+	protected.DELETE("/copy/followers/:name", copyTradingHandler.RemoveFollower)
+	v1.POST("/copy/signals", copyTradingHandler.ReceiveSignal)
 
 	// Strategy routes
 	protected.GET("/strategies", strategyHandler.GetStrategies)
+	protected.GET("/strategies/preview", strategyHandler.GetPreview)
+	protected.GET("/strategies/drift", strategyHandler.GetParamDrift)
+	protected.GET("/strategies/leaderboard", strategyHandler.GetLeaderboard, cached)
 	protected.GET("/strategies/:name", strategyHandler.GetStrategy)
 	protected.PUT("/strategies/:name", strategyHandler.UpdateStrategy)
 	protected.POST("/strategies/:name/enable", strategyHandler.EnableStrategy)
 	protected.POST("/strategies/:name/disable", strategyHandler.DisableStrategy)
+	protected.POST("/strategies/:name/apply-optimized", strategyHandler.ApplyOptimizedParams)
 	protected.GET("/strategies/:name/signals", strategyHandler.GetSignals)
 	protected.GET("/regime", strategyHandler.GetRegime)
+	protected.GET("/score", scoreHandler.GetScore)
+
+	// Capital allocation routes
+	protected.GET("/allocation", allocationHandler.GetAllocations, cached)
+	protected.GET("/allocation/history", allocationHandler.GetAllocationHistory)
+	protected.POST("/allocation/rebalance", allocationHandler.Rebalance)
 
 	// Risk routes
-	protected.GET("/risk", riskHandler.GetRiskStatus)
+	protected.GET("/risk", riskHandler.GetRiskStatus, cached)
 	protected.GET("/risk/config", riskHandler.GetConfig)
 	protected.PUT("/risk/config", riskHandler.UpdateConfig)
 	protected.GET("/risk/limits", riskHandler.GetLimits)
-	protected.GET("/risk/drawdown", riskHandler.GetDrawdown)
+	protected.GET("/risk/drawdown", riskHandler.GetDrawdown, cached)
+	protected.POST("/risk/high-water-mark/reset", riskHandler.ResetHighWaterMark)
+	protected.POST("/risk/cash-flow", riskHandler.RecordCashFlow)
 	protected.GET("/risk/events", riskHandler.GetEvents)
-	protected.POST("/risk/circuit-breaker/reset", riskHandler.ResetCircuitBreaker)
+	protected.GET("/risk/reports", riskHandler.GetReports)
+	protected.POST("/risk/circuit-breaker/reset", riskHandler.ResetCircuitBreaker, twoFactor)
+	protected.POST("/risk/halt", riskHandler.Halt)
+	protected.POST("/risk/resume", riskHandler.Resume, twoFactor)
 
 	// Position routes
-	protected.GET("/positions", positionHandler.GetPositions)
-	protected.GET("/positions/:id", positionHandler.GetPosition)
+	protected.GET("/positions", positionHandler.GetPositions, cached)
+	protected.GET("/positions/:id", positionHandler.GetPosition, cached)
 	protected.POST("/positions/:id/close", positionHandler.ClosePosition)
 	protected.PUT("/positions/:id/stop-loss", positionHandler.UpdateStopLoss)
 	protected.PUT("/positions/:id/take-profit", positionHandler.UpdateTakeProfit)
 
 	// Order routes
-	protected.GET("/orders", orderHandler.GetOrders)
-	protected.GET("/orders/open", orderHandler.GetOpenOrders)
+	protected.GET("/orders", orderHandler.GetOrders, cached)
+	protected.GET("/orders/open", orderHandler.GetOpenOrders, cached)
+	protected.GET("/orders/:id", orderHandler.GetOrder)
 	protected.POST("/orders", orderHandler.PlaceOrder)
 	protected.DELETE("/orders/:id", orderHandler.CancelOrder)
 
+	// Application events (errors, warnings, order lifecycle)
+	protected.GET("/logs", logHandler.GetLogs)
+	protected.GET("/logs/stream", logHandler.StreamLogs)
+
+	// Database maintenance
+	protected.POST("/system/backup", systemHandler.CreateBackup)
+	protected.GET("/system/backups", systemHandler.GetBackups)
+
+	// Binance stream subscriptions
+	protected.GET("/system/subscriptions", systemHandler.GetSubscriptions)
+	protected.POST("/system/subscriptions", systemHandler.AddSubscription)
+	protected.DELETE("/system/subscriptions", systemHandler.RemoveSubscription)
+
+	// Persisted trade and position history
+	protected.GET("/history/trades", historyHandler.GetTrades)
+	protected.GET("/history/positions", historyHandler.GetPositions)
+	protected.POST("/history/import", historyHandler.StartImport)
+	protected.GET("/history/import", historyHandler.GetImport)
+
+	// Audit trail of order, configuration, strategy and risk actions
+	protected.GET("/audit", auditHandler.GetAuditLog)
+
+	// Anonymized result bundles shared between users
+	protected.GET("/share/export", shareHandler.Export)
+	protected.POST("/share/import", shareHandler.Import)
+	protected.GET("/share/bundles", shareHandler.GetBundles)
+	protected.GET("/share/bundles/:id", shareHandler.GetBundle)
+	protected.DELETE("/share/bundles/:id", shareHandler.DeleteBundle)
+
+	// The caller's own trading accounts, each traded by an isolated executor
+	protected.GET("/accounts", accountHandler.ListAccounts)
+	protected.POST("/accounts", accountHandler.CreateAccount)
+	protected.GET("/accounts/:id", accountHandler.GetAccount)
+	protected.PUT("/accounts/:id", accountHandler.UpdateAccount)
+	protected.PUT("/accounts/:id/keys", accountHandler.LinkKeys, twoFactor)
+	protected.POST("/accounts/:id/start", accountHandler.StartAccount)
+	protected.POST("/accounts/:id/stop", accountHandler.StopAccount)
+
+	// Number, date and currency formatting of the caller's CSV/HTML reports
+	protected.GET("/reports/locale", reportHandler.GetLocale)
+	protected.PUT("/reports/locale", reportHandler.UpdateLocale)
+
 	// Candle/Market Data routes (public - no auth needed for market data)
 	v1.GET("/candles", candleHandler.GetCandles)
 	v1.GET("/candles/:symbol/:timeframe", candleHandler.GetCandlesBySymbol)
 	v1.GET("/ticker", candleHandler.GetTicker)
 	v1.GET("/indicators", candleHandler.GetIndicators)
+	v1.GET("/tape", candleHandler.GetTape)
+	v1.GET("/orderbook", candleHandler.GetOrderBook)
+	v1.GET("/price/sources", candleHandler.GetPriceSources)
+	v1.GET("/symbols/:symbol/info", candleHandler.GetSymbolInfo)
+
+	// WebSocket bandwidth and message rates
+	protected.GET("/metrics/bandwidth", bandwidthHandler.GetBandwidth)
+
+	// Binance REST request weight usage
+	protected.GET("/metrics/rate-limit", rateLimitHandler.GetRateLimit)
+
+	// Pipeline stage timings against their latency budgets
+	protected.GET("/metrics/latency", latencyHandler.GetLatency)
+
+	// Strategy evaluation workers and queueing delays
+	protected.GET("/metrics/evaluation", evaluationHandler.GetEvaluation)
+
+	// Candidate symbols ranked by liquidity, volatility and strategy fit
+	protected.GET("/scan", scanHandler.GetScan)
+
+	// Precomputed indicator series (research, charting)
+	v1.GET("/indicators/series", indicatorSeriesHandler.GetSeries)
+	v1.GET("/indicators/series/coverage", indicatorSeriesHandler.GetCoverage)
+	protected.POST("/indicators/precompute", indicatorSeriesHandler.Precompute)
 
 	// Backtest routes
 	protected.POST("/backtest", backtestHandler.RunBacktest)
+	protected.POST("/backtest/optimize", backtestHandler.Optimize)
 	protected.GET("/backtest/results", backtestHandler.GetResults)
 	protected.GET("/backtest/results/:id", backtestHandler.GetResult)
+	protected.GET("/backtest/:id", backtestHandler.GetResult)
+
+	// Quick synchronous backtests of inline strategy scripts
+	protected.POST("/sandbox/run", sandboxHandler.Run)
 
 	// Settings routes - for UI configuration
-	settingsHandler := handlers.NewSettingsHandler(s.orchestrator)
+	settingsHandler := handlers.NewSettingsHandler(s.orchestrator, s.config.Config)
 	protected.GET("/settings", settingsHandler.GetSettings)
 	protected.POST("/settings/reset", settingsHandler.ResetSettings)
 	protected.GET("/settings/trading", settingsHandler.GetTradingSettings)
@@ -204,14 +383,43 @@ func (s *Server) setupRoutes() {
 	protected.PUT("/settings/indicators", settingsHandler.UpdateIndicatorSettings)
 	protected.GET("/settings/strategies", settingsHandler.GetStrategySettings)
 	protected.PUT("/settings/strategies", settingsHandler.UpdateStrategySettings)
+	protected.GET("/settings/symbols", settingsHandler.GetSymbolSettings)
+	protected.PUT("/settings/symbols/:symbol", settingsHandler.UpdateSymbolSettings)
+	protected.DELETE("/settings/symbols/:symbol", settingsHandler.DeleteSymbolSettings)
+	protected.GET("/settings/history", settingsHandler.GetSettingsHistory)
+	protected.POST("/settings/rollback/:versionId", settingsHandler.RollbackSettings)
+	protected.GET("/settings/reload", settingsHandler.GetReloadStatus)
+	protected.POST("/settings/reload", settingsHandler.ReloadSettings)
 
 	// WebSocket
 	s.echo.GET("/ws", s.handleWebSocket)
 }
 
+// statusRateLimiter limits status page requests per client IP
+func (s *Server) statusRateLimiter() echo.MiddlewareFunc {
+	perMinute := s.config.StatusRateLimit
+	if perMinute <= 0 {
+		perMinute = 30
+	}
+	store := echoMiddleware.NewRateLimiterMemoryStoreWithConfig(echoMiddleware.RateLimiterMemoryStoreConfig{
+		Rate:      rate.Limit(float64(perMinute) / 60),
+		Burst:     perMinute,
+		ExpiresIn: 3 * time.Minute,
+	})
+	return echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		Store: store,
+		ErrorHandler: func(c echo.Context, err error) error {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": "Client not identified"})
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return c.JSON(http.StatusTooManyRequests, map[string]string{"error": "Too many requests"})
+		},
+	})
+}
+
 // handleWebSocket handles WebSocket connections
 func (s *Server) handleWebSocket(c echo.Context) error {
-	return websocket.HandleConnection(c, s.wsHub, s.orchestrator)
+	return websocket.HandleConnection(c, s.wsHub, s.orchestrator, s.authService)
 }
 
 // Start starts the server
@@ -239,10 +447,25 @@ func (s *Server) forwardBroadcasts() {
 	}
 
 	for msg := range ch {
+		if invalidatesCache(msg.Type) {
+			s.cache.Invalidate()
+		}
 		s.wsHub.Broadcast(msg)
 	}
 }
 
+// invalidatesCache reports whether a broadcast means cached reads are stale.
+// Periodic state, price and candle updates are left to the cache TTL.
+func invalidatesCache(messageType string) bool {
+	switch messageType {
+	case orchestrator.MessageTypeTrade, orchestrator.MessageTypePosition, orchestrator.MessageTypeSignal,
+		orchestrator.MessageTypeRisk, orchestrator.MessageTypeMode, orchestrator.MessageTypeArming,
+		orchestrator.MessageTypeTradeIdea:
+		return true
+	}
+	return false
+}
+
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
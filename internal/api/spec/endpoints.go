@@ -0,0 +1,226 @@
+package spec
+
+import (
+	"net/http"
+
+	"github.com/eth-trading/internal/api/handlers"
+	"github.com/eth-trading/internal/config"
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/logstream"
+	"github.com/eth-trading/internal/models"
+	"github.com/eth-trading/internal/orchestrator"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/scanner"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+)
+
+const (
+	get  = http.MethodGet
+	post = http.MethodPost
+	put  = http.MethodPut
+	del  = http.MethodDelete
+)
+
+// Endpoints are the REST routes under Prefix, grouped as in
+// internal/api/server.go. Health checks, the status page and the
+// WebSocket are served outside Prefix and are not listed.
+var Endpoints = []Endpoint{
+	// Auth
+	{Name: "Register", Method: post, Path: "/auth/register", Public: true, Request: typeOf[models.RegisterRequest](), Response: typeOf[models.LoginResponse](), Doc: "Creates a user and logs it in"},
+	{Name: "Login", Method: post, Path: "/auth/login", Public: true, Request: typeOf[models.LoginRequest](), Response: typeOf[models.LoginResponse](), Doc: "Exchanges credentials for access and refresh tokens"},
+	{Name: "RefreshToken", Method: post, Path: "/auth/refresh", Public: true, Request: typeOf[models.RefreshTokenRequest](), Response: typeOf[models.RefreshTokenResponse](), Doc: "Exchanges a refresh token for a new access token"},
+	{Name: "RequestPasswordReset", Method: post, Path: "/auth/password-reset", Public: true, Request: typeOf[models.PasswordResetRequest](), Response: typeOf[Status](), Doc: "Sends a password reset email"},
+	{Name: "ConfirmPasswordReset", Method: post, Path: "/auth/password-reset/confirm", Public: true, Request: typeOf[models.PasswordResetConfirm](), Response: typeOf[Status](), Doc: "Sets a new password with a reset token"},
+	{Name: "Logout", Method: post, Path: "/auth/logout", Response: typeOf[Status](), Doc: "Revokes the caller's sessions"},
+	{Name: "GetMe", Method: get, Path: "/auth/me", Response: typeOf[models.UserResponse](), Doc: "Returns the caller's user"},
+	{Name: "ChangePassword", Method: post, Path: "/auth/change-password", Request: typeOf[models.PasswordChangeRequest](), Response: typeOf[Status](), Doc: "Changes the caller's password"},
+	{Name: "GetTwoFactor", Method: get, Path: "/auth/2fa", Response: typeOf[models.TwoFactorStatus](), Doc: "Returns the caller's two-factor authentication status"},
+	{Name: "EnrollTwoFactor", Method: post, Path: "/auth/2fa/enroll", Response: typeOf[models.TwoFactorEnrollment](), Doc: "Generates a TOTP secret for the caller's authenticator app"},
+	{Name: "ConfirmTwoFactor", Method: post, Path: "/auth/2fa/confirm", Request: typeOf[models.TwoFactorCodeRequest](), Response: typeOf[models.TwoFactorStatus](), Doc: "Enables two-factor authentication with a code from the enrolled app"},
+	{Name: "DisableTwoFactor", Method: post, Path: "/auth/2fa/disable", Request: typeOf[models.TwoFactorDisableRequest](), Response: typeOf[models.TwoFactorStatus](), Doc: "Removes two-factor authentication"},
+
+	// Dashboard
+	{Name: "GetDashboard", Method: get, Path: "/dashboard", Response: typeOf[handlers.DashboardResponse](), Doc: "Returns the account, positions and recent trades"},
+	{Name: "GetDashboardSummary", Method: get, Path: "/dashboard/summary", Response: typeOf[orchestrator.AccountSummary](), Doc: "Returns the account summary"},
+	{Name: "GetEquityCurve", Method: get, Path: "/dashboard/equity-curve", Response: typeOf[[]handlers.EquityCurvePoint](), Doc: "Returns the equity curve"},
+	{Name: "GetPerformance", Method: get, Path: "/dashboard/performance", Response: typeOf[handlers.PerformanceData](), Doc: "Returns trading performance statistics"},
+	{Name: "GetVersionPerformance", Method: get, Path: "/dashboard/performance/versions", Query: []string{"strategy"}, Response: typeOf[orchestrator.ParamVersionReport](), Doc: "Returns performance by strategy parameter version"},
+	{Name: "GetSessionPerformance", Method: get, Path: "/dashboard/performance/sessions", Query: []string{"strategy", "days"}, Response: typeOf[orchestrator.SessionReport](), Doc: "Returns performance by trading session"},
+
+	// Analytics
+	{Name: "GetRegimeMatrix", Method: get, Path: "/analytics/regime-matrix", Query: []string{"strategy", "days"}, Response: typeOf[orchestrator.RegimeMatrix](), Doc: "Returns strategy performance by the market regime positions were entered in"},
+
+	// Trading
+	{Name: "GetTradingState", Method: get, Path: "/trading/state", Response: typeOf[handlers.TradingStateResponse](), Doc: "Returns the trading state"},
+	{Name: "GetStateSnapshot", Method: get, Path: "/state", Query: []string{"since"}, Response: typeOf[orchestrator.StateSnapshot](), Doc: "Returns the state snapshot WebSocket clients resync from"},
+	{Name: "StartTrading", Method: post, Path: "/trading/start", Response: typeOf[Status](), Doc: "Starts trading"},
+	{Name: "StopTrading", Method: post, Path: "/trading/stop", Response: typeOf[Status](), Doc: "Stops trading"},
+	{Name: "PauseTrading", Method: post, Path: "/trading/pause", Response: typeOf[Status](), Doc: "Pauses trading"},
+	{Name: "ResumeTrading", Method: post, Path: "/trading/resume", Response: typeOf[Status](), Doc: "Resumes trading"},
+	{Name: "GetTradingMode", Method: get, Path: "/trading/mode", Response: typeOf[handlers.ModeResponse](), Doc: "Returns the trading mode"},
+	{Name: "SetTradingMode", Method: post, Path: "/trading/mode", Request: typeOf[handlers.ModeRequest](), Response: typeOf[handlers.ModeResponse](), Doc: "Switches between paper and live trading"},
+	{Name: "GetArming", Method: get, Path: "/trading/arm", Response: typeOf[orchestrator.ArmingStatus](), Doc: "Returns the live-mode arming status"},
+	{Name: "Arm", Method: post, Path: "/trading/arm", TwoFactor: true, Request: typeOf[handlers.ArmRequest](), Response: typeOf[orchestrator.ArmingStatus](), Doc: "Starts arming live trading"},
+	{Name: "Disarm", Method: post, Path: "/trading/disarm", Request: typeOf[handlers.DisarmRequest](), Response: typeOf[orchestrator.ArmingStatus](), Doc: "Disarms live trading"},
+	{Name: "GetSchedule", Method: get, Path: "/trading/schedule", Response: typeOf[orchestrator.ScheduleStatus](), Doc: "Returns the trading schedule"},
+	{Name: "GetReconciliation", Method: get, Path: "/trading/reconciliation", Query: []string{"limit"}, Response: typeOf[orchestrator.ReconciliationReport](), Doc: "Returns exchange reconciliation results"},
+	{Name: "GetDust", Method: get, Path: "/trading/dust", Response: typeOf[execution.DustReport](), Doc: "Returns balances too small to trade"},
+	{Name: "ConvertDust", Method: post, Path: "/trading/dust/convert", Response: typeOf[execution.DustConversion](), Doc: "Converts dust balances to BNB"},
+	{Name: "GetOrderIntents", Method: get, Path: "/trading/intents", Query: []string{"limit"}, Response: typeOf[[]storage.OrderIntent](), Doc: "Returns recorded order intents"},
+	{Name: "GetChaos", Method: get, Path: "/trading/chaos", Response: typeOf[orchestrator.ChaosStatus](), Doc: "Returns injected faults"},
+	{Name: "InjectChaos", Method: post, Path: "/trading/chaos", Request: typeOf[handlers.ChaosRequest](), Response: typeOf[orchestrator.ChaosEvent](), Doc: "Injects a fault into order execution"},
+	{Name: "ClearChaos", Method: del, Path: "/trading/chaos", Response: typeOf[orchestrator.ChaosStatus](), Doc: "Clears injected faults"},
+	{Name: "GetCapital", Method: get, Path: "/trading/capital", Response: typeOf[orchestrator.SubBalanceStatus](), Doc: "Returns the capital the bot trades with"},
+	{Name: "TransferCapital", Method: post, Path: "/trading/capital/transfers", Request: typeOf[handlers.CapitalTransferRequest](), Response: typeOf[orchestrator.SubBalanceStatus](), Doc: "Moves capital into or out of the bot's sub-balance"},
+	{Name: "GetEntryPolicy", Method: get, Path: "/trading/entry", Response: typeOf[orchestrator.EntryStatus](), Doc: "Returns the entry policy and its state"},
+
+	// Trade idea inbox
+	{Name: "GetInbox", Method: get, Path: "/inbox", Query: []string{"status", "limit"}, Response: typeOf[handlers.InboxResponse](), Doc: "Returns trade ideas awaiting or past approval"},
+	{Name: "ApproveIdea", Method: post, Path: "/inbox/:id/approve", Request: typeOf[handlers.IdeaDecisionRequest](), Response: typeOf[orchestrator.TradeIdea](), Doc: "Approves a trade idea"},
+	{Name: "RejectIdea", Method: post, Path: "/inbox/:id/reject", Request: typeOf[handlers.IdeaDecisionRequest](), Response: typeOf[orchestrator.TradeIdea](), Doc: "Rejects a trade idea"},
+
+	// Copy trading
+	{Name: "GetCopyTrading", Method: get, Path: "/copy", Response: typeOf[orchestrator.CopyTradingStatus](), Doc: "Returns copy trading followers and leaders"},
+	{Name: "AddFollower", Method: post, Path: "/copy/followers", Request: typeOf[handlers.FollowerRequest](), Response: typeOf[orchestrator.CopyFollower](), Doc: "Adds a follower signals are pushed to"},
+	{Name: "RemoveFollower", Method: del, Path: "/copy/followers/:name", Response: typeOf[Status](), Doc: "Removes a follower"},
+	{Name: "ReceiveCopySignal", Method: post, Path: "/copy/signals", Public: true, Exclude: "signed leader-to-follower push"},
+
+	// Strategies
+	{Name: "GetStrategies", Method: get, Path: "/strategies", Response: typeOf[[]handlers.StrategyInfo](), Doc: "Returns the strategies and their performance"},
+	{Name: "GetStrategyPreview", Method: get, Path: "/strategies/preview", Query: []string{"regime"}, Response: typeOf[handlers.StrategyPreviewResponse](), Doc: "Returns the strategies that would trade in a regime"},
+	{Name: "GetParamDrift", Method: get, Path: "/strategies/drift", Response: typeOf[Object](), Doc: "Returns strategy parameter drift from optimized values"},
+	{Name: "GetStrategyLeaderboard", Method: get, Path: "/strategies/leaderboard", Query: []string{"window", "sort"}, Response: typeOf[orchestrator.Leaderboard](), Doc: "Ranks strategies by realized performance over a rolling window"},
+	{Name: "GetStrategy", Method: get, Path: "/strategies/:name", Response: typeOf[handlers.StrategyInfo](), Doc: "Returns a strategy"},
+	{Name: "UpdateStrategy", Method: put, Path: "/strategies/:name", Request: typeOf[handlers.UpdateStrategyRequest](), Response: typeOf[Status](), Doc: "Updates a strategy's parameters"},
+	{Name: "EnableStrategy", Method: post, Path: "/strategies/:name/enable", Response: typeOf[Object](), Doc: "Enables a strategy"},
+	{Name: "DisableStrategy", Method: post, Path: "/strategies/:name/disable", Response: typeOf[Object](), Doc: "Disables a strategy"},
+	{Name: "ApplyOptimizedParams", Method: post, Path: "/strategies/:name/apply-optimized", Query: []string{"source"}, Response: typeOf[Object](), Doc: "Reconfigures a strategy with its optimized parameters"},
+	{Name: "GetStrategySignals", Method: get, Path: "/strategies/:name/signals", Response: typeOf[[]handlers.SignalInfo](), Doc: "Returns a strategy's recent signals"},
+	{Name: "GetRegime", Method: get, Path: "/regime", Response: typeOf[handlers.RegimeInfo](), Doc: "Returns the detected market regime"},
+	{Name: "GetScore", Method: get, Path: "/score", Response: typeOf[orchestrator.ScoreUpdate](), Doc: "Returns the scorer breakdown of the latest analysis"},
+
+	// Capital allocation
+	{Name: "GetAllocations", Method: get, Path: "/allocation", Response: typeOf[handlers.AllocationResponse](), Doc: "Returns capital allocated to each strategy"},
+	{Name: "GetAllocationHistory", Method: get, Path: "/allocation/history", Query: []string{"limit"}, Response: typeOf[[]risk.AllocationSnapshot](), Doc: "Returns past allocations"},
+	{Name: "Rebalance", Method: post, Path: "/allocation/rebalance", Response: typeOf[risk.AllocationSnapshot](), Doc: "Reallocates capital between strategies"},
+
+	// Risk
+	{Name: "GetRiskStatus", Method: get, Path: "/risk", Response: typeOf[handlers.RiskStatusResponse](), Doc: "Returns the risk status"},
+	{Name: "GetRiskConfig", Method: get, Path: "/risk/config", Response: typeOf[handlers.RiskConfigResponse](), Doc: "Returns the risk limits"},
+	{Name: "UpdateRiskConfig", Method: put, Path: "/risk/config", Request: typeOf[handlers.UpdateConfigRequest](), Response: typeOf[Status](), Doc: "Changes risk limits"},
+	{Name: "GetRiskLimits", Method: get, Path: "/risk/limits", Query: []string{"events"}, Response: typeOf[handlers.RiskLimitsResponse](), Doc: "Returns how much of each risk limit is used"},
+	{Name: "GetDrawdown", Method: get, Path: "/risk/drawdown", Response: typeOf[handlers.DrawdownResponse](), Doc: "Returns drawdown from the high-water mark"},
+	{Name: "ResetHighWaterMark", Method: post, Path: "/risk/high-water-mark/reset", Response: typeOf[risk.HighWaterMark](), Doc: "Resets the high-water mark to current equity"},
+	{Name: "RecordCashFlow", Method: post, Path: "/risk/cash-flow", Request: typeOf[handlers.CashFlowRequest](), Response: typeOf[risk.HighWaterMark](), Doc: "Records a deposit or withdrawal"},
+	{Name: "GetRiskEvents", Method: get, Path: "/risk/events", Response: typeOf[[]handlers.RiskEventResponse](), Doc: "Returns recent risk events"},
+	{Name: "GetRiskReports", Method: get, Path: "/risk/reports", Response: typeOf[orchestrator.RiskReports](), Doc: "Returns daily risk digests"},
+	{Name: "ResetCircuitBreaker", Method: post, Path: "/risk/circuit-breaker/reset", TwoFactor: true, Response: typeOf[Status](), Doc: "Resets a tripped circuit breaker"},
+	{Name: "Halt", Method: post, Path: "/risk/halt", Request: typeOf[handlers.HaltRequest](), Response: typeOf[Object](), Doc: "Halts trading"},
+	{Name: "ResumeFromHalt", Method: post, Path: "/risk/resume", TwoFactor: true, Response: typeOf[Object](), Doc: "Lifts a halt"},
+
+	// Positions
+	{Name: "GetPositions", Method: get, Path: "/positions", Response: typeOf[[]handlers.PositionData](), Doc: "Returns open positions"},
+	{Name: "GetPosition", Method: get, Path: "/positions/:id", Response: typeOf[handlers.PositionData](), Doc: "Returns a position"},
+	{Name: "ClosePosition", Method: post, Path: "/positions/:id/close", Response: typeOf[Status](), Doc: "Closes a position at market"},
+	{Name: "UpdateStopLoss", Method: put, Path: "/positions/:id/stop-loss", Request: typeOf[handlers.UpdateStopLossRequest](), Response: typeOf[Status](), Doc: "Moves a position's stop loss"},
+	{Name: "UpdateTakeProfit", Method: put, Path: "/positions/:id/take-profit", Request: typeOf[handlers.UpdateTakeProfitRequest](), Response: typeOf[Status](), Doc: "Moves a position's take profit"},
+
+	// Orders
+	{Name: "GetOrders", Method: get, Path: "/orders", Query: []string{"symbol", "status", "strategy", "limit"}, Response: typeOf[[]handlers.OrderData](), Doc: "Returns order history"},
+	{Name: "GetOpenOrders", Method: get, Path: "/orders/open", Query: []string{"symbol"}, Response: typeOf[[]handlers.OrderData](), Doc: "Returns open orders"},
+	{Name: "GetOrder", Method: get, Path: "/orders/:id", Response: typeOf[handlers.OrderData](), Doc: "Returns an order and its state transitions"},
+	{Name: "PlaceOrder", Method: post, Path: "/orders", Request: typeOf[handlers.PlaceOrderRequest](), Response: typeOf[handlers.PlaceOrderResponse](), Doc: "Places a manual order"},
+	{Name: "CancelOrder", Method: del, Path: "/orders/:id", Response: typeOf[Status](), Doc: "Cancels an open order"},
+
+	// Application events
+	{Name: "GetLogs", Method: get, Path: "/logs", Query: []string{"limit", "level", "category"}, Response: typeOf[[]logstream.Event](), Doc: "Returns recent application events"},
+	{Name: "StreamLogs", Method: get, Path: "/logs/stream", Exclude: "server-sent events stream"},
+
+	// System
+	{Name: "CreateBackup", Method: post, Path: "/system/backup", Response: typeOf[storage.BackupRecord](), Doc: "Backs up the trading database"},
+	{Name: "GetBackups", Method: get, Path: "/system/backups", Query: []string{"limit"}, Response: typeOf[[]storage.BackupRecord](), Doc: "Returns past backups"},
+	{Name: "GetSubscriptions", Method: get, Path: "/system/subscriptions", Response: typeOf[[]orchestrator.StreamSubscription](), Doc: "Returns the subscribed Binance streams"},
+	{Name: "AddSubscription", Method: post, Path: "/system/subscriptions", Request: typeOf[handlers.SubscriptionRequest](), Response: typeOf[orchestrator.StreamSubscription](), Doc: "Subscribes to a Binance stream"},
+	{Name: "RemoveSubscription", Method: del, Path: "/system/subscriptions", Query: []string{"stream"}, Response: typeOf[Status](), Doc: "Unsubscribes from a Binance stream"},
+
+	// History
+	{Name: "GetTradeHistory", Method: get, Path: "/history/trades", Query: []string{"from", "to", "strategy", "limit"}, Response: typeOf[[]handlers.TradeHistoryData](), Doc: "Returns persisted trades"},
+	{Name: "GetPositionHistory", Method: get, Path: "/history/positions", Query: []string{"status", "limit"}, Response: typeOf[[]handlers.PositionHistoryData](), Doc: "Returns persisted positions"},
+	{Name: "StartHistoryImport", Method: post, Path: "/history/import", Request: typeOf[handlers.HistoryImportRequest](), Response: typeOf[orchestrator.HistoryImportReport](), Doc: "Imports trade history from the exchange"},
+	{Name: "GetHistoryImport", Method: get, Path: "/history/import", Response: typeOf[orchestrator.HistoryImportReport](), Doc: "Returns the progress of the history import"},
+	{Name: "GetAuditLog", Method: get, Path: "/audit", Query: []string{"category", "action", "actor", "resource", "accountId", "from", "to", "limit"}, Response: typeOf[[]storage.AuditEntry](), Doc: "Returns the audit trail of order, configuration, strategy and risk actions"},
+
+	// Shared result bundles
+	{Name: "ExportShareBundle", Method: get, Path: "/share/export", Query: []string{"name", "candles"}, Response: typeOf[orchestrator.ShareBundle](), Doc: "Exports anonymized results as a bundle"},
+	{Name: "ImportShareBundle", Method: post, Path: "/share/import", Request: typeOf[orchestrator.ShareBundle](), Response: typeOf[storage.SharedBundle](), Doc: "Imports a bundle shared by another user"},
+	{Name: "GetShareBundles", Method: get, Path: "/share/bundles", Response: typeOf[[]storage.SharedBundle](), Doc: "Returns imported bundles"},
+	{Name: "GetShareBundle", Method: get, Path: "/share/bundles/:id", Response: typeOf[storage.SharedBundle](), Doc: "Returns an imported bundle"},
+	{Name: "DeleteShareBundle", Method: del, Path: "/share/bundles/:id", Doc: "Deletes an imported bundle"},
+
+	// Trading accounts
+	{Name: "ListAccounts", Method: get, Path: "/accounts", Response: typeOf[Object](), Doc: "Returns the caller's trading accounts"},
+	{Name: "CreateAccount", Method: post, Path: "/accounts", TwoFactor: true, Request: typeOf[models.TradingAccountCreateRequest](), Response: typeOf[handlers.AccountResponse](), Doc: "Adds a trading account"},
+	{Name: "GetAccount", Method: get, Path: "/accounts/:id", Response: typeOf[handlers.AccountResponse](), Doc: "Returns a trading account"},
+	{Name: "UpdateAccount", Method: put, Path: "/accounts/:id", Request: typeOf[models.TradingAccountUpdateRequest](), Response: typeOf[handlers.AccountResponse](), Doc: "Changes a trading account's settings"},
+	{Name: "LinkAccountKeys", Method: put, Path: "/accounts/:id/keys", TwoFactor: true, Request: typeOf[models.BinanceKeysRequest](), Response: typeOf[handlers.AccountResponse](), Doc: "Links Binance API keys to a live account"},
+	{Name: "StartAccount", Method: post, Path: "/accounts/:id/start", TwoFactor: true, Response: typeOf[handlers.AccountResponse](), Doc: "Starts a trading account's executor"},
+	{Name: "StopAccount", Method: post, Path: "/accounts/:id/stop", Response: typeOf[handlers.AccountResponse](), Doc: "Stops a trading account's executor"},
+
+	// Report formatting
+	{Name: "GetReportLocale", Method: get, Path: "/reports/locale", Response: typeOf[handlers.ReportLocaleResponse](), Doc: "Returns the caller's report formatting"},
+	{Name: "UpdateReportLocale", Method: put, Path: "/reports/locale", Request: typeOf[orchestrator.ReportLocale](), Response: typeOf[handlers.ReportLocaleResponse](), Doc: "Changes the caller's report formatting"},
+
+	// Market data
+	{Name: "GetCandles", Method: get, Path: "/candles", Public: true, Query: []string{"symbol", "timeframe", "limit", "from", "to"}, Response: typeOf[[]handlers.CandleData](), Doc: "Returns candles"},
+	{Name: "GetSymbolCandles", Method: get, Path: "/candles/:symbol/:timeframe", Public: true, Query: []string{"limit", "from", "to"}, Response: typeOf[[]handlers.CandleData](), Doc: "Returns candles of a symbol and timeframe"},
+	{Name: "GetTicker", Method: get, Path: "/ticker", Public: true, Query: []string{"symbol"}, Response: typeOf[handlers.TickerData](), Doc: "Returns the 24h ticker"},
+	{Name: "GetIndicators", Method: get, Path: "/indicators", Public: true, Query: []string{"symbol", "timeframe"}, Response: typeOf[handlers.IndicatorData](), Doc: "Returns the latest indicator values"},
+	{Name: "GetTape", Method: get, Path: "/tape", Public: true, Query: []string{"bucket", "limit"}, Response: typeOf[orchestrator.Tape](), Doc: "Returns recent trades bucketed by time"},
+	{Name: "GetOrderBook", Method: get, Path: "/orderbook", Public: true, Query: []string{"levels", "quantity"}, Response: typeOf[orchestrator.OrderBookView](), Doc: "Returns the order book"},
+	{Name: "GetPriceSources", Method: get, Path: "/price/sources", Public: true, Response: typeOf[orchestrator.PriceArbiterStatus](), Doc: "Returns the price the bot acts on and its sources"},
+	{Name: "GetSymbolInfo", Method: get, Path: "/symbols/:symbol/info", Public: true, Query: []string{"refresh"}, Response: typeOf[orchestrator.SymbolRules](), Doc: "Returns a symbol's exchange trading rules"},
+
+	// Metrics
+	{Name: "GetBandwidth", Method: get, Path: "/metrics/bandwidth", Response: typeOf[handlers.BandwidthResponse](), Doc: "Returns WebSocket bandwidth and message rates"},
+	{Name: "GetRateLimit", Method: get, Path: "/metrics/rate-limit", Response: typeOf[orchestrator.RequestWeight](), Doc: "Returns Binance request weight usage"},
+	{Name: "GetLatency", Method: get, Path: "/metrics/latency", Response: typeOf[orchestrator.LatencyReport](), Doc: "Returns pipeline stage timings"},
+	{Name: "GetEvaluation", Method: get, Path: "/metrics/evaluation", Response: typeOf[strategy.EvalPoolStats](), Doc: "Returns strategy evaluation worker statistics"},
+
+	// Market scan
+	{Name: "GetScan", Method: get, Path: "/scan", Query: []string{"refresh"}, Response: typeOf[scanner.Report](), Doc: "Returns candidate symbols ranked by fit"},
+
+	// Indicator series
+	{Name: "GetIndicatorSeries", Method: get, Path: "/indicators/series", Public: true, Query: []string{"timeframe", "columns", "from", "to"}, Response: typeOf[handlers.IndicatorSeriesResponse](), Doc: "Returns precomputed indicator series"},
+	{Name: "GetIndicatorCoverage", Method: get, Path: "/indicators/series/coverage", Public: true, Query: []string{"timeframe"}, Response: typeOf[handlers.IndicatorCoverageResponse](), Doc: "Returns the time ranges indicator series cover"},
+	{Name: "PrecomputeIndicators", Method: post, Path: "/indicators/precompute", Request: typeOf[handlers.PrecomputeRequest](), Response: typeOf[orchestrator.IndicatorPrecomputeResult](), Doc: "Precomputes indicator series"},
+
+	// Backtests
+	{Name: "RunBacktest", Method: post, Path: "/backtest", Request: typeOf[handlers.BacktestRequest](), Response: typeOf[handlers.BacktestJobResponse](), Doc: "Queues a backtest"},
+	{Name: "Optimize", Method: post, Path: "/backtest/optimize", Request: typeOf[handlers.OptimizeRequest](), Response: typeOf[handlers.OptimizeResponse](), Doc: "Runs a parameter search"},
+	{Name: "GetBacktestResults", Method: get, Path: "/backtest/results", Query: []string{"limit"}, Response: typeOf[[]handlers.BacktestResultSummary](), Doc: "Returns completed backtests"},
+	{Name: "GetBacktestResult", Method: get, Path: "/backtest/results/:id", Response: typeOf[handlers.BacktestJobResponse](), Doc: "Returns a completed backtest"},
+	{Name: "GetBacktest", Method: get, Path: "/backtest/:id", Response: typeOf[handlers.BacktestJobResponse](), Doc: "Returns a backtest job and its result once done"},
+	{Name: "RunSandbox", Method: post, Path: "/sandbox/run", Request: typeOf[handlers.SandboxRequest](), Response: typeOf[handlers.SandboxResponse](), Doc: "Backtests an inline strategy script"},
+
+	// Settings
+	{Name: "GetSettings", Method: get, Path: "/settings", Response: typeOf[handlers.FullSettingsResponse](), Doc: "Returns all settings"},
+	{Name: "ResetSettings", Method: post, Path: "/settings/reset", Response: typeOf[Object](), Doc: "Resets all settings to defaults"},
+	{Name: "GetTradingSettings", Method: get, Path: "/settings/trading", Response: typeOf[handlers.TradingSettings](), Doc: "Returns trading settings"},
+	{Name: "UpdateTradingSettings", Method: put, Path: "/settings/trading", Request: typeOf[handlers.TradingSettings](), Response: typeOf[Object](), Doc: "Changes trading settings"},
+	{Name: "GetBinanceSettings", Method: get, Path: "/settings/binance", Response: typeOf[handlers.BinanceSettings](), Doc: "Returns Binance settings"},
+	{Name: "UpdateBinanceSettings", Method: put, Path: "/settings/binance", Request: typeOf[handlers.BinanceSettings](), Response: typeOf[Object](), Doc: "Changes Binance settings"},
+	{Name: "GetRiskSettings", Method: get, Path: "/settings/risk", Response: typeOf[handlers.RiskSettings](), Doc: "Returns risk settings"},
+	{Name: "UpdateRiskSettings", Method: put, Path: "/settings/risk", Request: typeOf[handlers.RiskSettings](), Response: typeOf[Object](), Doc: "Changes risk settings"},
+	{Name: "GetIndicatorSettings", Method: get, Path: "/settings/indicators", Response: typeOf[handlers.IndicatorSettings](), Doc: "Returns indicator settings"},
+	{Name: "UpdateIndicatorSettings", Method: put, Path: "/settings/indicators", Request: typeOf[handlers.IndicatorSettings](), Response: typeOf[Object](), Doc: "Changes indicator settings"},
+	{Name: "GetStrategySettings", Method: get, Path: "/settings/strategies", Response: typeOf[handlers.StrategySettings](), Doc: "Returns strategy settings"},
+	{Name: "UpdateStrategySettings", Method: put, Path: "/settings/strategies", Request: typeOf[handlers.StrategySettings](), Response: typeOf[Object](), Doc: "Changes strategy settings"},
+	{Name: "GetSymbolSettings", Method: get, Path: "/settings/symbols", Response: typeOf[map[string]config.SymbolConfig](), Doc: "Returns per-symbol overrides"},
+	{Name: "UpdateSymbolSettings", Method: put, Path: "/settings/symbols/:symbol", Request: typeOf[config.SymbolConfig](), Response: typeOf[Object](), Doc: "Sets a symbol's overrides"},
+	{Name: "DeleteSymbolSettings", Method: del, Path: "/settings/symbols/:symbol", Response: typeOf[Object](), Doc: "Removes a symbol's overrides"},
+	{Name: "GetSettingsHistory", Method: get, Path: "/settings/history", Query: []string{"section", "limit"}, Response: typeOf[[]handlers.SettingsChangeResponse](), Doc: "Returns the settings audit trail"},
+	{Name: "RollbackSettings", Method: post, Path: "/settings/rollback/:versionId", Response: typeOf[Object](), Doc: "Restores a settings section to a past version"},
+	{Name: "GetReloadStatus", Method: get, Path: "/settings/reload", Response: typeOf[config.ReloadStatus](), Doc: "Returns the configuration changes applied at runtime and those waiting for a restart"},
+	{Name: "ReloadSettings", Method: post, Path: "/settings/reload", Response: typeOf[config.ReloadStatus](), Doc: "Reloads config.yaml into the running bot"},
+}
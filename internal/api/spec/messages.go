@@ -0,0 +1,46 @@
+package spec
+
+import (
+	"github.com/eth-trading/internal/api/websocket"
+	"github.com/eth-trading/internal/orchestrator"
+)
+
+// WebSocketPath is where clients connect, with the access token as a Bearer
+// Authorization header or, from browsers, a token query parameter
+const WebSocketPath = "/ws"
+
+// ServerMessages are broadcast to WebSocket clients in an
+// orchestrator.BroadcastMessage envelope, or sent in reply to one client
+var ServerMessages = []Message{
+	{Type: orchestrator.MessageTypeState, Data: typeOf[orchestrator.StateUpdate](), Doc: "Periodic trading state"},
+	{Type: orchestrator.MessageTypeCandle, Data: typeOf[orchestrator.CandleUpdate](), Doc: "Candle updates and closes"},
+	{Type: orchestrator.MessageTypeSignal, Data: typeOf[orchestrator.SignalUpdate](), Doc: "Strategy signals and their risk verdict"},
+	{Type: orchestrator.MessageTypeTrade, Data: typeOf[orchestrator.TradeUpdate](), Doc: "Fills"},
+	{Type: orchestrator.MessageTypePosition, Data: typeOf[orchestrator.PositionUpdate](), Doc: "Positions opened, changed or closed"},
+	{Type: orchestrator.MessageTypeRisk, Data: typeOf[orchestrator.RiskUpdate](), Doc: "Risk state and risk events"},
+	{Type: orchestrator.MessageTypeError, Data: typeOf[orchestrator.ErrorUpdate](), Doc: "Errors worth showing to users"},
+	{Type: orchestrator.MessageTypeIndicators, Data: typeOf[orchestrator.IndicatorsUpdate](), Doc: "Indicator values of the primary timeframe"},
+	{Type: orchestrator.MessageTypePrice, Data: typeOf[orchestrator.PriceUpdate](), Doc: "Real-time prices"},
+	{Type: orchestrator.MessageTypeMode, Data: typeOf[orchestrator.ModeUpdate](), Doc: "Scheduled trading mode transitions"},
+	{Type: orchestrator.MessageTypeArming, Data: typeOf[orchestrator.ArmingStatus](), Doc: "Live-mode arming transitions"},
+	{Type: orchestrator.MessageTypeTradeIdea, Data: typeOf[orchestrator.TradeIdea](), Doc: "Signals queued for or decided in the approval inbox"},
+	{Type: orchestrator.MessageTypeScore, Data: typeOf[orchestrator.ScoreUpdate](), Doc: "Scorer breakdown of the latest analysis"},
+	{Type: orchestrator.MessageTypeBacktest, Data: typeOf[orchestrator.BacktestJob](), Doc: "Backtest job progress and completion"},
+	{Type: orchestrator.MessageTypeStrategy, Data: typeOf[orchestrator.StrategyStateUpdate](), Doc: "Strategies enabled or disabled at runtime"},
+	{Type: orchestrator.MessageTypeRiskReport, Data: typeOf[orchestrator.RiskReport](), Doc: "Daily risk digest"},
+	{Type: orchestrator.MessageTypeParamDrift, Data: typeOf[orchestrator.ParamDriftReport](), Doc: "Strategy parameters drifted from optimized values"},
+	{Type: "subscriptions", Data: typeOf[websocket.Subscriptions](), Reply: true, Doc: "The client's subscriptions, in reply to subscribe and unsubscribe"},
+	{Type: "pong", Reply: true, Doc: "Reply to ping"},
+}
+
+// ClientMessages are sent by WebSocket clients
+var ClientMessages = []Message{
+	{Type: "subscribe", Data: typeOf[SubscriptionChange](), Doc: "Receive more message types; none = all"},
+	{Type: "unsubscribe", Data: typeOf[SubscriptionChange](), Doc: "Stop receiving message types; none = all"},
+	{Type: "ping", Doc: "Keepalive, answered with pong"},
+}
+
+// SubscriptionChange is the data of subscribe and unsubscribe messages
+type SubscriptionChange struct {
+	Types []string `json:"types"` // Message types, e.g. "trade"
+}
@@ -0,0 +1,69 @@
+// Package spec defines the REST endpoints and WebSocket messages of the API
+// server, with the Go types of their bodies. The client SDKs (pkg/client and
+// web/src) are generated from it by cmd/sdkgen, which also fails when a
+// route registered in internal/api is missing here, so a route added to the
+// server must be added to Endpoints too.
+package spec
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
+)
+
+// Prefix is the path all endpoints are relative to
+const Prefix = "/api/v1"
+
+// TwoFactorHeader carries the two-factor code of TwoFactor endpoints
+const TwoFactorHeader = "X-OTP-Code"
+
+// Endpoint is a REST route
+type Endpoint struct {
+	Name      string       // Client method name
+	Method    string       // HTTP method
+	Path      string       // Relative to Prefix, with :params
+	Public    bool         // Served without an access token
+	TwoFactor bool         // Takes the caller's two-factor code in TwoFactorHeader
+	Query     []string     // Optional query parameters
+	Request   reflect.Type // JSON body; nil = none
+	Response  reflect.Type // JSON body; nil = none (204)
+	Doc       string       // One line, starting with a verb
+	Exclude   string       // Why the SDKs leave the route out; empty = included
+}
+
+// Message is a WebSocket message and the type of its data
+type Message struct {
+	Type  string       // The envelope's type field
+	Data  reflect.Type // nil = the message has no data
+	Reply bool         // Sent to one client in reply, without a timestamp
+	Doc   string
+}
+
+// Scalar is how a type the SDKs do not describe field by field is encoded
+type Scalar string
+
+const (
+	ScalarString Scalar = "string" // A JSON string
+	ScalarTime   Scalar = "time"   // An RFC 3339 timestamp
+)
+
+// Scalars are types encoded as a single JSON value rather than by their
+// fields, usually through a MarshalJSON or MarshalText method
+var Scalars = map[reflect.Type]Scalar{
+	typeOf[time.Time]():          ScalarTime,
+	typeOf[uuid.UUID]():          ScalarString,
+	typeOf[strategy.Direction](): ScalarString,
+}
+
+// typeOf returns the reflect type of T
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Object is the body of endpoints answering a free-form JSON object
+type Object = map[string]interface{}
+
+// Status is the body of endpoints answering a status string and details
+type Status = map[string]string
@@ -3,9 +3,16 @@ package websocket
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/eth-trading/internal/auth"
+	"github.com/eth-trading/internal/models"
 	"github.com/eth-trading/internal/orchestrator"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
@@ -25,24 +32,85 @@ type Client struct {
 	Conn   *websocket.Conn
 	Send   chan []byte
 	Hub    *Hub
+
+	// Authenticated user and the trading accounts it owns
+	UserID   uuid.UUID
+	Email    string
+	admin    bool
+	accounts map[string]bool
+
+	// Message types subscribed to, nil = all, less those unsubscribed
+	// from (guarded by mu)
+	mu       sync.Mutex
+	types    map[string]bool
+	excluded map[string]bool
+}
+
+// outbound is a broadcast encoded once for all clients
+type outbound struct {
+	msgType   string
+	accountID string
+	data      []byte
 }
 
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
 	clients    map[*Client]bool
-	broadcast  chan []byte
+	broadcast  chan outbound
 	register   chan *Client
 	unregister chan *Client
 	mu         sync.RWMutex
+
+	// permessage-deflate for clients that offer it
+	compression bool
+
+	// Traffic counters
+	since       time.Time
+	messagesIn  atomic.Int64
+	bytesIn     atomic.Int64
+	messagesOut atomic.Int64
+	bytesOut    atomic.Int64
+}
+
+// HubStats describes the traffic served to dashboard clients
+type HubStats struct {
+	Clients     int       `json:"clients"`
+	Users       int       `json:"users"`       // Distinct users connected
+	Compression bool      `json:"compression"` // Offered to clients; used when they accept
+	Since       time.Time `json:"since"`
+	MessagesIn  int64     `json:"messagesIn"`
+	BytesIn     int64     `json:"bytesIn"`
+	MessagesOut int64     `json:"messagesOut"`
+	BytesOut    int64     `json:"bytesOut"` // Payload bytes before compression
 }
 
 // NewHub creates a new hub
 func NewHub() *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
-		broadcast:  make(chan []byte, 256),
+		broadcast:  make(chan outbound, 256),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		since:      time.Now(),
+	}
+}
+
+// SetCompression offers permessage-deflate to connecting clients
+func (h *Hub) SetCompression(enabled bool) {
+	h.compression = enabled
+}
+
+// Stats returns the hub's traffic counters
+func (h *Hub) Stats() HubStats {
+	return HubStats{
+		Clients:     h.GetClientCount(),
+		Users:       h.GetUserCount(),
+		Compression: h.compression,
+		Since:       h.since,
+		MessagesIn:  h.messagesIn.Load(),
+		BytesIn:     h.bytesIn.Load(),
+		MessagesOut: h.messagesOut.Load(),
+		BytesOut:    h.bytesOut.Load(),
 	}
 }
 
@@ -54,7 +122,7 @@ func (h *Hub) Run() {
 			h.mu.Lock()
 			h.clients[client] = true
 			h.mu.Unlock()
-			log.Debug().Str("clientID", client.ID).Msg("WebSocket client connected")
+			log.Debug().Str("clientID", client.ID).Str("user", client.Email).Msg("WebSocket client connected")
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -68,8 +136,11 @@ func (h *Hub) Run() {
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for client := range h.clients {
+				if !client.accepts(message.msgType, message.accountID) {
+					continue
+				}
 				select {
-				case client.Send <- message:
+				case client.Send <- message.data:
 				default:
 					// Client buffer full, close connection
 					close(client.Send)
@@ -81,7 +152,8 @@ func (h *Hub) Run() {
 	}
 }
 
-// Broadcast sends a message to all clients
+// Broadcast sends a message to the clients subscribed to its type and,
+// for account-scoped messages, owning its trading account
 func (h *Hub) Broadcast(msg orchestrator.BroadcastMessage) {
 	data, err := json.Marshal(msg)
 	if err != nil {
@@ -90,7 +162,7 @@ func (h *Hub) Broadcast(msg orchestrator.BroadcastMessage) {
 	}
 
 	select {
-	case h.broadcast <- data:
+	case h.broadcast <- outbound{msgType: msg.Type, accountID: msg.AccountID, data: data}:
 	default:
 		log.Warn().Msg("Broadcast channel full, message dropped")
 	}
@@ -103,6 +175,18 @@ func (h *Hub) GetClientCount() int {
 	return len(h.clients)
 }
 
+// GetUserCount returns the number of distinct users connected
+func (h *Hub) GetUserCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	users := make(map[uuid.UUID]bool)
+	for client := range h.clients {
+		users[client.UserID] = true
+	}
+	return len(users)
+}
+
 // Close closes all client connections
 func (h *Hub) Close() {
 	h.mu.Lock()
@@ -115,25 +199,67 @@ func (h *Hub) Close() {
 	}
 }
 
-// HandleConnection handles a new WebSocket connection
-func HandleConnection(c echo.Context, hub *Hub, orch *orchestrator.Orchestrator) error {
-	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+// authenticate validates the access token of a connection request, sent
+// as a Bearer Authorization header or, for browsers, a token query parameter
+func authenticate(c echo.Context, authService *auth.Service) (*models.JWTClaims, error) {
+	token := c.QueryParam("token")
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); header != "" {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if token == "" {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "missing access token")
+	}
+	claims, err := authService.ValidateAccessToken(token)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired token")
+	}
+	return claims, nil
+}
+
+// HandleConnection authenticates a WebSocket connection request with the
+// JWT issued by authService and upgrades it. The client then receives the
+// broadcasts of the trading accounts its user owns, all of them for admins.
+func HandleConnection(c echo.Context, hub *Hub, orch *orchestrator.Orchestrator, authService *auth.Service) error {
+	if authService == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "authentication not available")
+	}
+	claims, err := authenticate(c, authService)
+	if err != nil {
+		return err
+	}
+
+	accounts := make(map[string]bool)
+	owned, err := authService.GetTradingAccounts(claims.UserID)
+	if err != nil {
+		log.Warn().Err(err).Str("user", claims.Email).Msg("Failed to load trading accounts of WebSocket client")
+	}
+	for _, a := range owned {
+		accounts[a.ID.String()] = true
+	}
+
+	up := upgrader
+	up.EnableCompression = hub.compression
+	conn, err := up.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to upgrade WebSocket connection")
 		return err
 	}
 
 	client := &Client{
-		ID:   c.Request().RemoteAddr,
-		Conn: conn,
-		Send: make(chan []byte, 256),
-		Hub:  hub,
+		ID:       c.Request().RemoteAddr,
+		Conn:     conn,
+		Send:     make(chan []byte, 256),
+		Hub:      hub,
+		UserID:   claims.UserID,
+		Email:    claims.Email,
+		admin:    claims.Role == models.RoleAdmin,
+		accounts: accounts,
 	}
 
 	hub.register <- client
 
 	// Send initial state in the same format as broadcast
-	if orch != nil {
+	if orch != nil && client.accepts(orchestrator.MessageTypeState, orch.GetTradingAccount()) {
 		state := orch.GetState()
 		msg := orchestrator.BroadcastMessage{
 			Type: orchestrator.MessageTypeState,
@@ -168,6 +294,8 @@ func (c *Client) readPump() {
 			}
 			break
 		}
+		c.Hub.messagesIn.Add(1)
+		c.Hub.bytesIn.Add(int64(len(message)))
 
 		// Handle incoming messages (e.g., subscription requests)
 		c.handleMessage(message)
@@ -186,6 +314,8 @@ func (c *Client) writePump() {
 			log.Error().Err(err).Msg("WebSocket write error")
 			return
 		}
+		c.Hub.messagesOut.Add(1)
+		c.Hub.bytesOut.Add(int64(len(message)))
 	}
 
 	// Hub closed the channel
@@ -205,9 +335,24 @@ func (c *Client) handleMessage(message []byte) {
 	}
 
 	switch msg.Type {
-	case "subscribe":
-		// Handle subscription request
-		log.Debug().Str("clientID", c.ID).Msg("Client subscribed")
+	case "subscribe", "unsubscribe":
+		var req struct {
+			Types []string `json:"types"` // Message types, e.g. "trade"; empty = all
+		}
+		if len(msg.Data) > 0 {
+			if err := json.Unmarshal(msg.Data, &req); err != nil {
+				log.Debug().Err(err).Str("clientID", c.ID).Msg("Invalid subscription request")
+				return
+			}
+		}
+		subs := c.subscribe(msg.Type == "subscribe", req.Types)
+		log.Debug().Str("clientID", c.ID).Str("user", c.Email).Strs("types", subs.Types).Bool("all", subs.All).Msg("Client subscriptions updated")
+
+		reply, _ := json.Marshal(map[string]interface{}{"type": "subscriptions", "data": subs})
+		select {
+		case c.Send <- reply:
+		default:
+		}
 	case "ping":
 		// Respond with pong - use select to avoid panic on closed channel
 		pong, _ := json.Marshal(map[string]string{"type": "pong"})
@@ -220,3 +365,69 @@ func (c *Client) handleMessage(message []byte) {
 		log.Debug().Str("type", msg.Type).Msg("Unknown message type")
 	}
 }
+
+// accepts reports whether the client receives a broadcast: it subscribed to
+// the type and, for account-scoped messages, owns the account or is an admin
+func (c *Client) accepts(msgType, accountID string) bool {
+	if accountID != "" && !c.admin && !c.accounts[accountID] {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return (c.types == nil || c.types[msgType]) && !c.excluded[msgType]
+}
+
+// Subscriptions are the message types a client receives
+type Subscriptions struct {
+	Types    []string `json:"types"` // Subscribed to; empty when all is set
+	All      bool     `json:"all"`
+	Excluded []string `json:"excluded"` // Unsubscribed from while receiving all
+}
+
+// subscribe adds message types to the client's subscriptions, or removes
+// them. Subscribing to no types restores every type; unsubscribing from
+// none stops all.
+func (c *Client) subscribe(add bool, types []string) Subscriptions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case len(types) == 0 && add:
+		c.types, c.excluded = nil, nil
+	case len(types) == 0:
+		c.types, c.excluded = make(map[string]bool), nil
+	case add:
+		if c.types != nil {
+			for _, t := range types {
+				c.types[t] = true
+			}
+		}
+		for _, t := range types {
+			delete(c.excluded, t)
+		}
+	case c.types != nil:
+		for _, t := range types {
+			delete(c.types, t)
+		}
+	default:
+		if c.excluded == nil {
+			c.excluded = make(map[string]bool)
+		}
+		for _, t := range types {
+			c.excluded[t] = true
+		}
+	}
+
+	return Subscriptions{Types: sortedKeys(c.types), All: c.types == nil, Excluded: sortedKeys(c.excluded)}
+}
+
+// sortedKeys returns the keys of a set, sorted
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eth-trading/internal/models"
+	"github.com/google/uuid"
+)
+
+// GetTradingAccount returns one of a user's trading accounts
+func (s *Service) GetTradingAccount(userID, accountID uuid.UUID) (*models.TradingAccount, error) {
+	account, err := s.tradingAccountRepo.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, models.ErrUnauthorizedAccount
+	}
+	return account, nil
+}
+
+// CreateTradingAccount adds a trading account for a user. Live accounts
+// need a key cipher to store their Binance secret.
+func (s *Service) CreateTradingAccount(userID uuid.UUID, req *models.TradingAccountCreateRequest) (*models.TradingAccount, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.tradingAccountRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get trading accounts: %w", err)
+	}
+	for _, a := range existing {
+		if a.AccountName == req.AccountName {
+			return nil, models.ErrAccountAlreadyExists
+		}
+	}
+
+	now := time.Now()
+	account := &models.TradingAccount{
+		ID:                uuid.New(),
+		UserID:            userID,
+		AccountType:       req.AccountType,
+		AccountName:       req.AccountName,
+		TradingSymbol:     req.TradingSymbol,
+		TradingMode:       models.TradingModePaper, // Switch to live explicitly
+		EnabledStrategies: req.EnabledStrategies,
+		IsActive:          true,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	switch req.AccountType {
+	case models.AccountTypeDemo:
+		account.DemoInitialCapital = req.DemoInitialCapital
+		account.DemoCurrentBalance = req.DemoInitialCapital
+	case models.AccountTypeLive:
+		if err := s.setBinanceKeys(account, *req.BinanceAPIKey, *req.BinanceSecretKey, req.BinanceTestnet); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.tradingAccountRepo.Create(account); err != nil {
+		return nil, fmt.Errorf("create trading account: %w", err)
+	}
+	return account, nil
+}
+
+// UpdateTradingAccount applies the fields set in req to a user's account.
+// Only live accounts with linked keys can switch to live trading.
+func (s *Service) UpdateTradingAccount(userID, accountID uuid.UUID, req *models.TradingAccountUpdateRequest) (*models.TradingAccount, error) {
+	account, err := s.GetTradingAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AccountName != nil {
+		account.AccountName = *req.AccountName
+	}
+	if req.TradingSymbol != nil {
+		account.TradingSymbol = *req.TradingSymbol
+	}
+	if req.TradingMode != nil {
+		switch *req.TradingMode {
+		case models.TradingModePaper:
+		case models.TradingModeLive:
+			if account.AccountType != models.AccountTypeLive {
+				return nil, fmt.Errorf("%w: demo accounts can only paper trade", models.ErrInvalidInput)
+			}
+			if !account.ToResponse().KeysLinked {
+				return nil, models.ErrBinanceKeysNotLinked
+			}
+		default:
+			return nil, fmt.Errorf("%w: trading_mode must be paper or live", models.ErrInvalidInput)
+		}
+		account.TradingMode = *req.TradingMode
+	}
+	if req.EnabledStrategies != nil {
+		account.EnabledStrategies = req.EnabledStrategies
+	}
+	if req.RiskConfig != nil {
+		if err := req.RiskConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidInput, err)
+		}
+		account.RiskConfig = *req.RiskConfig
+	}
+	if req.IsActive != nil {
+		account.IsActive = *req.IsActive
+		if !account.IsActive {
+			account.ExecutorEnabled = false
+		}
+	}
+
+	if err := s.tradingAccountRepo.Update(account); err != nil {
+		return nil, fmt.Errorf("update trading account: %w", err)
+	}
+	return account, nil
+}
+
+// LinkBinanceKeys stores Binance API keys on a user's live account, the
+// secret encrypted
+func (s *Service) LinkBinanceKeys(userID, accountID uuid.UUID, req *models.BinanceKeysRequest) (*models.TradingAccount, error) {
+	if req.APIKey == "" {
+		return nil, models.ErrBinanceAPIKeyRequired
+	}
+	if req.SecretKey == "" {
+		return nil, models.ErrBinanceSecretRequired
+	}
+
+	account, err := s.GetTradingAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType != models.AccountTypeLive {
+		return nil, fmt.Errorf("%w: binance keys can only be linked to live accounts", models.ErrInvalidInput)
+	}
+	if err := s.setBinanceKeys(account, req.APIKey, req.SecretKey, req.Testnet); err != nil {
+		return nil, err
+	}
+
+	if err := s.tradingAccountRepo.Update(account); err != nil {
+		return nil, fmt.Errorf("update trading account: %w", err)
+	}
+	return account, nil
+}
+
+// setBinanceKeys encrypts the secret and sets the keys on account
+func (s *Service) setBinanceKeys(account *models.TradingAccount, apiKey, secretKey string, testnet bool) error {
+	if s.keyCipher == nil {
+		return models.ErrKeyEncryptionDisabled
+	}
+	encrypted, err := s.keyCipher.Encrypt(secretKey)
+	if err != nil {
+		return fmt.Errorf("encrypt binance secret: %w", err)
+	}
+	account.BinanceAPIKey = &apiKey
+	account.BinanceSecretEncrypted = &encrypted
+	account.BinanceTestnet = testnet
+	return nil
+}
+
+// BinanceSecret decrypts the Binance secret linked to an account
+func (s *Service) BinanceSecret(account *models.TradingAccount) (string, error) {
+	if account.BinanceSecretEncrypted == nil || *account.BinanceSecretEncrypted == "" {
+		return "", models.ErrBinanceKeysNotLinked
+	}
+	if s.keyCipher == nil {
+		return "", models.ErrKeyEncryptionDisabled
+	}
+	return s.keyCipher.Decrypt(*account.BinanceSecretEncrypted)
+}
+
+// SetExecutorEnabled records whether a user's account runs its own
+// executor with the bot
+func (s *Service) SetExecutorEnabled(userID, accountID uuid.UUID, enabled bool) (*models.TradingAccount, error) {
+	account, err := s.GetTradingAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled && !account.IsActive {
+		return nil, models.ErrAccountInactive
+	}
+	if account.ExecutorEnabled == enabled {
+		return account, nil
+	}
+
+	account.ExecutorEnabled = enabled
+	if err := s.tradingAccountRepo.Update(account); err != nil {
+		return nil, fmt.Errorf("update trading account: %w", err)
+	}
+	return account, nil
+}
+
+// GetExecutorAccounts returns the accounts, of all users, whose executor
+// runs with the bot
+func (s *Service) GetExecutorAccounts() ([]*models.TradingAccount, error) {
+	accounts, err := s.tradingAccountRepo.GetExecutorEnabled()
+	if err != nil {
+		return nil, fmt.Errorf("get executor accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// UpdateDemoBalance persists a demo account's balance after its executor
+// stops
+func (s *Service) UpdateDemoBalance(accountID uuid.UUID, balance float64) error {
+	return s.tradingAccountRepo.UpdateBalance(accountID, balance)
+}
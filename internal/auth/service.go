@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/eth-trading/internal/models"
+	"github.com/eth-trading/internal/secrets"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -29,6 +30,8 @@ type Service struct {
 	tradingAccountRepo TradingAccountRepository
 	tokenExpiry        time.Duration
 	refreshTokenExpiry time.Duration
+	keyCipher          *secrets.Cipher // Encrypts Binance and TOTP secrets; nil = storing them is disabled
+	requireTwoFactor   bool            // Sensitive operations refuse users without two-factor authentication
 }
 
 // UserRepository defines methods for user data access
@@ -39,6 +42,8 @@ type UserRepository interface {
 	Update(user *models.User) error
 	UpdateLastLogin(userID uuid.UUID) error
 	EmailExists(email string) (bool, error)
+	UseTOTPStep(userID uuid.UUID, step int64) (bool, error)
+	RecordTOTPFailure(userID uuid.UUID, maxFailures int, lockedUntil time.Time) (*time.Time, error)
 }
 
 // SessionRepository defines methods for session data access
@@ -56,6 +61,8 @@ type TradingAccountRepository interface {
 	GetByID(id uuid.UUID) (*models.TradingAccount, error)
 	GetByUserID(userID uuid.UUID) ([]*models.TradingAccount, error)
 	Update(account *models.TradingAccount) error
+	GetExecutorEnabled() ([]*models.TradingAccount, error)
+	UpdateBalance(accountID uuid.UUID, newBalance float64) error
 }
 
 // Config holds authentication service configuration
@@ -63,6 +70,7 @@ type Config struct {
 	JWTSecret          string
 	TokenExpiry        time.Duration
 	RefreshTokenExpiry time.Duration
+	RequireTwoFactor   bool
 }
 
 // NewService creates a new authentication service
@@ -84,9 +92,16 @@ func NewService(cfg *Config, userRepo UserRepository, sessionRepo SessionReposit
 		tradingAccountRepo: tradingAccountRepo,
 		tokenExpiry:        tokenExpiry,
 		refreshTokenExpiry: refreshTokenExpiry,
+		requireTwoFactor:   cfg.RequireTwoFactor,
 	}
 }
 
+// SetKeyCipher enables storing users' Binance and TOTP secrets, encrypted
+// with c
+func (s *Service) SetKeyCipher(c *secrets.Cipher) {
+	s.keyCipher = c
+}
+
 // Register registers a new user with initial trading account
 func (s *Service) Register(req *models.RegisterRequest) (*models.User, *models.TradingAccount, error) {
 	// Validate request
@@ -146,8 +161,14 @@ func (s *Service) Register(req *models.RegisterRequest) (*models.User, *models.T
 	} else if req.AccountType == models.AccountTypeLive {
 		account.BinanceAPIKey = req.BinanceAPIKey
 		account.BinanceTestnet = req.BinanceTestnet
-		// TODO: Encrypt Binance secret key before storing
-		// For now, we'll leave it nil and handle encryption separately
+		// Without a key cipher the secret is dropped; link it later with LinkBinanceKeys
+		if s.keyCipher != nil {
+			encrypted, err := s.keyCipher.Encrypt(*req.BinanceSecretKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encrypt binance secret: %w", err)
+			}
+			account.BinanceSecretEncrypted = &encrypted
+		}
 	}
 
 	if err := s.tradingAccountRepo.Create(account); err != nil {
@@ -368,6 +389,15 @@ func (s *Service) ValidateAccessToken(tokenString string) (*models.JWTClaims, er
 	}, nil
 }
 
+// GetTradingAccounts returns the trading accounts a user owns
+func (s *Service) GetTradingAccounts(userID uuid.UUID) ([]*models.TradingAccount, error) {
+	accounts, err := s.tradingAccountRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get trading accounts: %w", err)
+	}
+	return accounts, nil
+}
+
 // GenerateRefreshToken generates a random refresh token
 func (s *Service) GenerateRefreshToken() (string, error) {
 	b := make([]byte, 32)
@@ -391,6 +421,21 @@ func (s *Service) VerifyPassword(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
 
+// VerifyUserPassword re-authenticates a user by password before a sensitive
+// operation
+func (s *Service) VerifyUserPassword(userID uuid.UUID, password string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if err := s.VerifyPassword(user.PasswordHash, password); err != nil {
+		return models.ErrInvalidCredentials
+	}
+
+	return nil
+}
+
 // ChangePassword changes a user's password
 func (s *Service) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
 	// Get user
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/models"
+	"github.com/google/uuid"
+)
+
+// TOTP parameters (RFC 6238), the defaults every authenticator app supports
+const (
+	TOTPIssuer = "ETH Trading Bot"
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	totpSkew   = 1  // Steps accepted either side of now, for clock drift
+	totpSecret = 20 // Bytes, the size of an HMAC-SHA1 key
+
+	totpMaxFailures = 5                // Wrong codes in a row before a lockout
+	totpLockout     = 15 * time.Minute // How long codes are refused after it
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// BeginTwoFactorEnrollment generates a new TOTP secret for a user. It is
+// stored encrypted but not enforced until ConfirmTwoFactorEnrollment
+// proves the user's authenticator app produces its codes.
+func (s *Service) BeginTwoFactorEnrollment(userID uuid.UUID) (*models.TwoFactorEnrollment, error) {
+	if s.keyCipher == nil {
+		return nil, models.ErrTwoFactorUnavailable
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return nil, models.ErrTwoFactorAlreadyEnabled
+	}
+
+	key := make([]byte, totpSecret)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate secret: %w", err)
+	}
+	secret := totpEncoding.EncodeToString(key)
+	sealed, err := s.keyCipher.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt secret: %w", err)
+	}
+	user.TOTPSecret = &sealed
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totpURI(user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactorEnrollment enables two-factor authentication once code
+// matches the secret from BeginTwoFactorEnrollment
+func (s *Service) ConfirmTwoFactorEnrollment(userID uuid.UUID, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return models.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == nil {
+		return models.ErrTwoFactorNotEnrolled
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = true
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	return nil
+}
+
+// DisableTwoFactor removes a user's TOTP secret after re-authenticating
+// them with both their password and a current code
+func (s *Service) DisableTwoFactor(userID uuid.UUID, password, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if !user.TOTPEnabled {
+		return models.ErrTwoFactorNotEnrolled
+	}
+	if err := s.VerifyPassword(user.PasswordHash, password); err != nil {
+		return models.ErrInvalidCredentials
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = nil
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	return nil
+}
+
+// VerifyTwoFactor checks a code before a sensitive operation. Users without
+// two-factor authentication pass unless it is required, and each code is
+// accepted only once.
+func (s *Service) VerifyTwoFactor(userID uuid.UUID, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if !user.TOTPEnabled {
+		if s.requireTwoFactor {
+			return models.ErrTwoFactorRequired
+		}
+		return nil
+	}
+	if code == "" {
+		return models.ErrTwoFactorCodeRequired
+	}
+	return s.checkTOTP(user, code)
+}
+
+// GetTwoFactorStatus reports whether a user has enabled two-factor
+// authentication and whether sensitive operations require it
+func (s *Service) GetTwoFactorStatus(userID uuid.UUID) (*models.TwoFactorStatus, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return &models.TwoFactorStatus{
+		Enabled:   user.TOTPEnabled,
+		Pending:   !user.TOTPEnabled && user.TOTPSecret != nil,
+		Required:  s.requireTwoFactor,
+		Available: s.keyCipher != nil,
+	}, nil
+}
+
+// checkTOTP validates code against the user's secret and records its time
+// step, refusing steps already used. Wrong codes count toward a lockout.
+func (s *Service) checkTOTP(user *models.User, code string) error {
+	if s.keyCipher == nil {
+		return models.ErrTwoFactorUnavailable
+	}
+	if user.TOTPLockedUntil != nil && time.Now().Before(*user.TOTPLockedUntil) {
+		return models.ErrTwoFactorLocked
+	}
+	secret, err := s.keyCipher.Decrypt(*user.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("decrypt secret: %w", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("decode secret: %w", err)
+	}
+
+	code = strings.ReplaceAll(code, " ", "")
+	now := time.Now().Unix() / int64(totpPeriod/time.Second)
+	for step := now - totpSkew; step <= now+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) != 1 {
+			continue
+		}
+		// Conditional on the stored step, so concurrent requests cannot
+		// both spend the same code
+		used, err := s.userRepo.UseTOTPStep(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return models.ErrInvalidTwoFactorCode
+		}
+		user.TOTPLastStep = step
+		user.TOTPFailures = 0
+		user.TOTPLockedUntil = nil
+		return nil
+	}
+
+	failedAt := time.Now()
+	locked, err := s.userRepo.RecordTOTPFailure(user.ID, totpMaxFailures, failedAt.Add(totpLockout))
+	if err != nil {
+		return err
+	}
+	if locked != nil && failedAt.Before(*locked) {
+		return models.ErrTwoFactorLocked
+	}
+	return models.ErrInvalidTwoFactorCode
+}
+
+// totpCode returns the code of a time step (RFC 4226 HOTP)
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// totpURI is the otpauth:// URI authenticator apps import, usually from a
+// QR code
+func totpURI(account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", TOTPIssuer)
+	q.Set("period", fmt.Sprint(int(totpPeriod/time.Second)))
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("algorithm", "SHA1")
+	return "otpauth://totp/" + url.PathEscape(TOTPIssuer+":"+account) + "?" + q.Encode()
+}
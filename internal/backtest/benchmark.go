@@ -0,0 +1,256 @@
+package backtest
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+const (
+	// MaxBenchmarkRuns caps the random-entry control runs of one backtest
+	MaxBenchmarkRuns = 1000
+	// benchmarkResamples is the number of bootstrap resamples behind the edge interval
+	benchmarkResamples = 2000
+	// benchmarkAlpha is the significance level of the benchmark test
+	benchmarkAlpha = 0.05
+)
+
+// BenchmarkReport compares a strategy with random-entry control runs.
+// Control runs enter at random on the bars the strategy could have entered
+// on, as often as it did, copying the strategy, direction and stop and
+// target distances of one of its own entries. Exits, sizing, fees and
+// slippage are the strategy's, so only the timing of entries differs.
+type BenchmarkReport struct {
+	Runs      int
+	Seed      int64
+	EntryRate float64 // Chance of a control entry on each bar an entry was allowed
+
+	StrategyReturn     float64
+	RandomMeanReturn   float64
+	RandomMedianReturn float64
+	RandomStdDev       float64
+	RandomMeanTrades   float64
+
+	// Percentile is the share of control runs the strategy returned more than
+	Percentile float64
+	// PValue is the chance of a control run returning at least as much as
+	// the strategy (one-sided, add-one corrected)
+	PValue float64
+	// Edge is the strategy return minus the mean control return, with its
+	// 95% bootstrap confidence interval
+	Edge     float64
+	EdgeLow  float64
+	EdgeHigh float64
+
+	// Significant reports an edge distinguishable from random entries at
+	// the 5% level: PValue below 0.05 and an interval above zero
+	Significant bool
+	// Reason explains a report that could not be computed
+	Reason string
+}
+
+// entryTemplate is a strategy entry copied by control runs, with its
+// levels as fractions of the entry price
+type entryTemplate struct {
+	strategy  string
+	direction strategy.Direction
+	stopDist  float64
+	takeDist  float64 // 0 = no target
+}
+
+// benchmarkRecording is what the strategy pass leaves for the control runs
+type benchmarkRecording struct {
+	analyses []indicators.AnalysisResult // By bar index
+	eligible int                         // Bars an entry was allowed on
+	entries  []entryTemplate
+
+	*candleColumns
+}
+
+// newBenchmarkRecording prepares a recording of a pass over data
+func newBenchmarkRecording(data *HistoricalData) *benchmarkRecording {
+	return &benchmarkRecording{
+		analyses:      make([]indicators.AnalysisResult, len(data.Candles)),
+		candleColumns: newCandleColumns(data.Candles),
+	}
+}
+
+// recordBar stores the indicators computed for bar i
+func (r *benchmarkRecording) recordBar(i int, analysis indicators.AnalysisResult) {
+	if r == nil {
+		return
+	}
+	r.analyses[i] = analysis
+}
+
+// recordEligible counts a bar an entry was allowed on
+func (r *benchmarkRecording) recordEligible() {
+	if r == nil {
+		return
+	}
+	r.eligible++
+}
+
+// recordEntry stores a position the strategy opened
+func (r *benchmarkRecording) recordEntry(pos *Position) {
+	if r == nil || pos == nil || pos.EntryPrice <= 0 {
+		return
+	}
+	t := entryTemplate{
+		strategy:  pos.Strategy,
+		direction: pos.Direction,
+		stopDist:  math.Abs(pos.EntryPrice-pos.StopLoss) / pos.EntryPrice,
+	}
+	if pos.TakeProfit > 0 {
+		t.takeDist = math.Abs(pos.TakeProfit-pos.EntryPrice) / pos.EntryPrice
+	}
+	r.entries = append(r.entries, t)
+}
+
+// marketData rebuilds the market data of bar i from the recording, sharing
+// its price series instead of copying them
+func (r *benchmarkRecording) marketData(config *Config, data *HistoricalData, i int) *strategy.MarketData {
+	opens, highs, lows, closes, volumes := r.window(0, i+1)
+	return &strategy.MarketData{
+		Symbol:       config.Symbol,
+		Timeframe:    config.Timeframe,
+		Timestamp:    data.Candles[i].Timestamp,
+		Session:      strategy.SessionAt(data.Candles[i].Timestamp),
+		Opens:        opens,
+		Highs:        highs,
+		Lows:         lows,
+		Closes:       closes,
+		Volumes:      volumes,
+		Analysis:     r.analyses[i],
+		CurrentPrice: data.Candles[i].Close,
+		Bid:          data.Candles[i].Close,
+		Ask:          data.Candles[i].Close,
+	}
+}
+
+// randomEntries decides the entries of a control run
+type randomEntries struct {
+	rec  *benchmarkRecording
+	rng  *rand.Rand
+	rate float64
+}
+
+// next returns the entry to make on an eligible bar, if any
+func (r *randomEntries) next() (entryTemplate, bool) {
+	if r.rng.Float64() >= r.rate {
+		return entryTemplate{}, false
+	}
+	return r.rec.entries[r.rng.Intn(len(r.rec.entries))], true
+}
+
+// enterRandom opens a control position copying template at the current price
+func (e *Engine) enterRandom(portfolio *Portfolio, data *strategy.MarketData, t entryTemplate) {
+	entryPrice := e.applySlippage(data.CurrentPrice, t.direction)
+	sign := 1.0
+	if t.direction == strategy.DirectionShort {
+		sign = -1.0
+	}
+	stopLoss := entryPrice * (1 - sign*t.stopDist)
+	takeProfit := 0.0
+	if t.takeDist > 0 {
+		takeProfit = entryPrice * (1 + sign*t.takeDist)
+	}
+	e.openPosition(portfolio, data, t.strategy, t.direction, entryPrice, stopLoss, takeProfit, 0)
+}
+
+// runBenchmark runs the control runs against the strategy result
+func (e *Engine) runBenchmark(data *HistoricalData, rec *benchmarkRecording, result *Result) (*BenchmarkReport, error) {
+	runs := e.config.BenchmarkRuns
+	if runs > MaxBenchmarkRuns {
+		runs = MaxBenchmarkRuns
+	}
+	seed := e.config.BenchmarkSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	report := &BenchmarkReport{
+		Runs:           runs,
+		Seed:           seed,
+		StrategyReturn: result.Metrics.TotalReturn,
+	}
+	if len(rec.entries) == 0 || rec.eligible == 0 {
+		report.Runs = 0
+		report.Reason = "strategy made no entries to compare against"
+		return report, nil
+	}
+	report.EntryRate = float64(len(rec.entries)) / float64(rec.eligible)
+
+	rng := rand.New(rand.NewSource(seed))
+	returns := make([]float64, runs)
+	trades := 0
+	for i := 0; i < runs; i++ {
+		control, err := e.run(data, 0, nil, nil, &randomEntries{rec: rec, rng: rng, rate: report.EntryRate})
+		if err != nil {
+			return nil, err
+		}
+		returns[i] = control.Metrics.TotalReturn
+		trades += control.Metrics.TotalTrades
+		e.reportProgress(i+1, runs)
+	}
+	report.RandomMeanTrades = float64(trades) / float64(runs)
+
+	summarizeBenchmark(report, returns, rng)
+	return report, nil
+}
+
+// summarizeBenchmark fills in the statistics of the control returns
+func summarizeBenchmark(report *BenchmarkReport, returns []float64, rng *rand.Rand) {
+	n := len(returns)
+	sorted := append([]float64(nil), returns...)
+	sort.Float64s(sorted)
+
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(n)
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	if n > 1 {
+		variance /= float64(n - 1)
+	}
+	report.RandomMeanReturn = mean
+	report.RandomStdDev = math.Sqrt(variance)
+	report.RandomMedianReturn = sorted[n/2]
+	if n%2 == 0 {
+		report.RandomMedianReturn = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	beaten, atLeast := 0, 0
+	for _, r := range returns {
+		if report.StrategyReturn > r {
+			beaten++
+		} else {
+			atLeast++
+		}
+	}
+	report.Percentile = float64(beaten) / float64(n)
+	report.PValue = float64(atLeast+1) / float64(n+1)
+
+	// Bootstrap the mean control return
+	report.Edge = report.StrategyReturn - mean
+	edges := make([]float64, benchmarkResamples)
+	for b := range edges {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += returns[rng.Intn(n)]
+		}
+		edges[b] = report.StrategyReturn - sum/float64(n)
+	}
+	sort.Float64s(edges)
+	report.EdgeLow = edges[int(float64(benchmarkResamples)*benchmarkAlpha/2)]
+	report.EdgeHigh = edges[int(float64(benchmarkResamples)*(1-benchmarkAlpha/2))-1]
+
+	report.Significant = report.PValue < benchmarkAlpha && report.EdgeLow > 0
+}
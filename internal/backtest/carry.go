@@ -0,0 +1,110 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+// defaultFundingInterval is how often perpetual futures settle funding
+const defaultFundingInterval = 8 * time.Hour
+
+// hoursPerYear converts annual borrow rates to hourly ones
+const hoursPerYear = 365 * 24
+
+// FundingRate is the funding rate settled from a time onward
+type FundingRate struct {
+	Time time.Time `json:"time"`
+	Rate float64   `json:"rate"`
+}
+
+// CarryCosts prices holding margin or futures positions over time. Funding
+// settles on every interval a position is open across; borrow interest
+// accrues continuously on the asset borrowed to hold shorts.
+type CarryCosts struct {
+	FundingRate          float64       `json:"fundingRate"`                    // Paid by longs to shorts per settlement (0.0001 = 0.01% of notional); negative pays shorts
+	FundingRates         []FundingRate `json:"fundingRates,omitempty"`         // Historical rates, oldest first; each applies until the next
+	FundingIntervalHours int           `json:"fundingIntervalHours,omitempty"` // Between settlements, aligned to midnight UTC; 0 = 8
+	BorrowRate           float64       `json:"borrowRate"`                     // Annual interest on borrowed short notional (0.05 = 5%)
+}
+
+// Validate checks the carry costs can be applied
+func (c *CarryCosts) Validate() error {
+	if c.FundingIntervalHours < 0 || c.FundingIntervalHours > 24 {
+		return fmt.Errorf("funding interval must be between 0 and 24 hours")
+	}
+	if c.BorrowRate < 0 {
+		return fmt.Errorf("borrow rate cannot be negative")
+	}
+	if !sort.SliceIsSorted(c.FundingRates, func(i, j int) bool {
+		return c.FundingRates[i].Time.Before(c.FundingRates[j].Time)
+	}) {
+		return fmt.Errorf("funding rates must be ordered oldest first")
+	}
+	return nil
+}
+
+// interval returns the time between funding settlements
+func (c *CarryCosts) interval() time.Duration {
+	if c.FundingIntervalHours <= 0 {
+		return defaultFundingInterval
+	}
+	return time.Duration(c.FundingIntervalHours) * time.Hour
+}
+
+// rateAt returns the funding rate settled at t: the latest historical rate
+// at or before t, else the flat rate
+func (c *CarryCosts) rateAt(t time.Time) float64 {
+	i := sort.Search(len(c.FundingRates), func(i int) bool {
+		return c.FundingRates[i].Time.After(t)
+	})
+	if i == 0 {
+		return c.FundingRate
+	}
+	return c.FundingRates[i-1].Rate
+}
+
+// accrue charges pos the funding settled and interest accrued since it was
+// last charged, marking its notional at price, and returns the cost. A
+// nil CarryCosts charges nothing.
+func (c *CarryCosts) accrue(pos *Position, now time.Time, price float64) float64 {
+	from := pos.carriedTo
+	if from.IsZero() {
+		from = pos.EntryTime
+	}
+	if c == nil || !now.After(from) {
+		return 0
+	}
+	pos.carriedTo = now
+	notional := pos.Quantity * price
+
+	// Settlements in (from, now]
+	var funding float64
+	interval := c.interval()
+	for t := from.Truncate(interval).Add(interval); !t.After(now); t = t.Add(interval) {
+		if pos.Direction == strategy.DirectionShort {
+			funding -= notional * c.rateAt(t)
+		} else {
+			funding += notional * c.rateAt(t)
+		}
+	}
+
+	var borrow float64
+	if pos.Direction == strategy.DirectionShort {
+		borrow = notional * c.BorrowRate * now.Sub(from).Hours() / hoursPerYear
+	}
+
+	pos.Funding += funding
+	pos.Borrow += borrow
+	return funding + borrow
+}
+
+// chargeCarry debits the carry costs open positions accrued up to now from
+// the portfolio's cash
+func (e *Engine) chargeCarry(portfolio *Portfolio, now time.Time, price float64) {
+	for _, pos := range portfolio.Positions {
+		portfolio.Cash -= e.config.Carry.accrue(pos, now, price)
+	}
+}
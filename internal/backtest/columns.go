@@ -0,0 +1,35 @@
+package backtest
+
+// candleColumns holds the price series of a candle set, split once per run
+// so market data for each bar shares a window of them instead of copying
+// every bar so far
+type candleColumns struct {
+	opens, highs, lows, closes, volumes []float64
+}
+
+// newCandleColumns splits candles into price series
+func newCandleColumns(candles []Candle) *candleColumns {
+	n := len(candles)
+	cols := &candleColumns{
+		opens:   make([]float64, n),
+		highs:   make([]float64, n),
+		lows:    make([]float64, n),
+		closes:  make([]float64, n),
+		volumes: make([]float64, n),
+	}
+	for i, c := range candles {
+		cols.opens[i] = c.Open
+		cols.highs[i] = c.High
+		cols.lows[i] = c.Low
+		cols.closes[i] = c.Close
+		cols.volumes[i] = c.Volume
+	}
+	return cols
+}
+
+// window returns the series of bars [from, end). Their capacity ends at
+// end, so appending to them never overwrites later bars.
+func (c *candleColumns) window(from, end int) (opens, highs, lows, closes, volumes []float64) {
+	return c.opens[from:end:end], c.highs[from:end:end], c.lows[from:end:end],
+		c.closes[from:end:end], c.volumes[from:end:end]
+}
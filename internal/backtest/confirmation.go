@@ -0,0 +1,107 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+// heldEntry is an entry held until the next candle of the confirmation
+// interval closes
+type heldEntry struct {
+	score      strategy.CombinedScore
+	signaledAt time.Time // Close of the bar the entry was signaled on
+}
+
+// confirmationInterval returns the duration of the candles entries wait
+// for when shorter than the bars, 0 when entries are confirmed on the next
+// bar or not at all. Intervals longer than the bars, or shorter ones
+// without candles, are errors.
+func (e *Engine) confirmationInterval(data *HistoricalData, bar time.Duration, barErr error) (time.Duration, error) {
+	policy := e.config.EntryConfirmation
+	if !policy.Enabled() {
+		return 0, nil
+	}
+	if barErr != nil {
+		return 0, barErr
+	}
+	if err := policy.Validate(); err != nil {
+		return 0, err
+	}
+	interval, err := TimeframeDuration(policy.Interval)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case interval > bar:
+		return 0, fmt.Errorf("confirmation interval %s is longer than the bars", policy.Interval)
+	case interval < bar && len(data.ConfirmationCandles) == 0:
+		return 0, fmt.Errorf("no %s candles for entry confirmation", policy.Interval)
+	case interval == bar:
+		return 0, nil
+	}
+	return interval, nil
+}
+
+// holdForConfirmation holds an entry signaled on the bar closing at
+// signaledAt when its strategy's entries are confirmed, as live trading
+// does. It returns nil when score is entered at once.
+func (e *Engine) holdForConfirmation(score strategy.CombinedScore, signaledAt time.Time, metrics *Metrics) *heldEntry {
+	if score.BestSignal == nil || !e.config.EntryConfirmation.Applies(score.BestSignal.Strategy) {
+		return nil
+	}
+	metrics.HeldEntries++
+	return &heldEntry{score: score, signaledAt: signaledAt}
+}
+
+// confirmHeld settles an entry held from the previous bar against the
+// latest bar or, with confirmation candles shorter than the bars
+// (subBar > 0, their series in cols), the one opening when the entry was
+// signaled. It returns the market data to enter at, priced at that
+// candle's close, or nil when the entry is dropped. Positions entered
+// within a bar are checked for exits from the next one.
+func (e *Engine) confirmHeld(entry *heldEntry, data *HistoricalData, cols *candleColumns, marketData *strategy.MarketData, subBar time.Duration, metrics *Metrics) *strategy.MarketData {
+	policy := e.config.EntryConfirmation
+	signal := *entry.score.BestSignal
+
+	if subBar == 0 {
+		_, _, close := lastBar(marketData)
+		if policy.Confirm(signal, close, marketData.Analysis) != "" {
+			metrics.UnconfirmedEntries++
+			return nil
+		}
+		metrics.ConfirmedEntries++
+		return marketData
+	}
+
+	candles := data.ConfirmationCandles
+	k := sort.Search(len(candles), func(j int) bool { return !candles[j].Timestamp.Before(entry.signaledAt) })
+	if k == len(candles) || !candles[k].Timestamp.Equal(entry.signaledAt) {
+		metrics.UnconfirmedEntries++
+		return nil
+	}
+
+	from := k - strategy.ConfirmationBars + 1
+	if from < 0 {
+		from = 0
+	}
+	opens, highs, lows, closes, volumes := cols.window(from, k+1)
+	analysis := e.indicatorMgr.AnalyzeSession(opens, highs, lows, closes, volumes, indicators.SessionBars(candles[k].Timestamp, subBar))
+
+	candle := candles[k]
+	if policy.Confirm(signal, candle.Close, analysis) != "" {
+		metrics.UnconfirmedEntries++
+		return nil
+	}
+	metrics.ConfirmedEntries++
+
+	entryData := *marketData
+	entryData.Timestamp = candle.Timestamp
+	entryData.CurrentPrice = candle.Close
+	entryData.Bid = candle.Close
+	entryData.Ask = candle.Close
+	return &entryData
+}
@@ -1,11 +1,13 @@
 package backtest
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/risk"
 	"github.com/eth-trading/internal/strategy"
 )
 
@@ -16,10 +18,103 @@ type Config struct {
 	StartDate      time.Time
 	EndDate        time.Time
 	InitialCapital float64
-	Commission     float64
+	Fees           *FeeSchedule // Commission per fill; nil charges none
+	Carry          *CarryCosts  // Funding and borrow costs of held positions; nil charges none
 	Slippage       float64
 	RiskPerTrade   float64
 	Strategies     []strategy.Strategy
+
+	// LookaheadAudit replays the backtest with inputs lagged by one bar and
+	// reports decisions or results that depend on data not yet available
+	LookaheadAudit bool
+
+	// DisallowedRegimes skips a strategy while the detected regime is
+	// listed, matching live scoring
+	DisallowedRegimes map[string][]strategy.MarketRegime
+
+	// RegimeRoutes limits the strategies scored in a regime, matching live
+	// scoring; nil scores all
+	RegimeRoutes map[strategy.MarketRegime][]string
+
+	// VolatilityThrottle delays or downsizes entries signaled on bars
+	// with an outsized range, matching live trading
+	VolatilityThrottle strategy.VolatilityThrottles
+
+	// EntryConfirmation holds entries for a candle of its interval and
+	// enters only if its conditions still hold, matching live trading.
+	// Intervals shorter than the bars need HistoricalData.ConfirmationCandles.
+	EntryConfirmation strategy.EntryConfirmation
+
+	// Compounding decides which capital entries risk RiskPerTrade of as
+	// equity grows, matching live sizing; empty compounds fully. Fixed and
+	// stepped sizing start from InitialCapital.
+	Compounding     risk.CompoundingMode
+	CompoundingStep float64 // Stepped: equity gain per re-base
+
+	// Indicators overrides the indicator parameters; nil uses the defaults
+	Indicators *indicators.IndicatorConfig
+
+	// MaxPositions is how many positions may be open at once; 0 holds one
+	MaxPositions int
+
+	// PositionAllocation caps each position's notional as a fraction of
+	// capital; 0 splits 95% of capital evenly over MaxPositions
+	PositionAllocation float64
+
+	// MaxCorrelatedExposure caps the combined notional of positions in the
+	// same symbol and direction as a fraction of capital; 0 = no cap
+	MaxCorrelatedExposure float64
+
+	// ExecutionModel decides how stops and targets fill within a bar;
+	// empty uses ExecutionWorstCase
+	ExecutionModel ExecutionModel
+
+	// Maintenance lists known exchange downtime; nothing fills during it
+	// and levels crossed meanwhile fill at the reopen price
+	Maintenance []MaintenanceWindow
+
+	// EvalPool, when set, evaluates strategies on workers shared with live
+	// trading, behind its primary evaluations
+	EvalPool *strategy.EvalPool
+
+	// BenchmarkRuns is the number of random-entry control runs the result
+	// is compared with; 0 disables the benchmark
+	BenchmarkRuns int
+
+	// BenchmarkSeed seeds the control runs; 0 picks one
+	BenchmarkSeed int64
+
+	// Deadline, when set, aborts the run with ErrDeadlineExceeded once
+	// passed
+	Deadline time.Time
+}
+
+// ErrDeadlineExceeded is returned by runs still going at Config.Deadline
+var ErrDeadlineExceeded = errors.New("backtest deadline exceeded")
+
+// maxPositions returns how many positions may be open at once
+func (c *Config) maxPositions() int {
+	if c.MaxPositions < 1 {
+		return 1
+	}
+	return c.MaxPositions
+}
+
+// positionAllocation returns the largest fraction of capital one position
+// may take
+func (c *Config) positionAllocation() float64 {
+	if c.PositionAllocation > 0 {
+		return c.PositionAllocation
+	}
+	return 0.95 / float64(c.maxPositions())
+}
+
+// executionModel returns the model stops and targets fill with
+func (c *Config) executionModel() ExecutionModel {
+	if c.ExecutionModel == "" {
+		return ExecutionWorstCase
+	}
+	return c.ExecutionModel
 }
 
 // Engine runs backtests
@@ -28,18 +123,28 @@ type Engine struct {
 	indicatorMgr    *indicators.Manager
 	regimeDetector  *strategy.RegimeDetector
 	scorer          *strategy.Scorer
+
+	// Progress reporting across the passes of a run
+	progress        func(fraction float64)
+	pass, passes    int
 }
 
 // NewEngine creates a new backtest engine
 func NewEngine(config *Config) *Engine {
-	indicatorMgr := indicators.NewManager(indicators.DefaultConfig())
+	indicatorMgr := indicators.NewManager(config.Indicators)
 	regimeDetector := strategy.NewRegimeDetector(strategy.DefaultRegimeConfig(), indicatorMgr)
-	scorer := strategy.NewScorer(strategy.DefaultScorerConfig())
+	scorerConfig := strategy.DefaultScorerConfig()
+	scorerConfig.DisallowedRegimes = config.DisallowedRegimes
+	scorerConfig.RegimeRoutes = config.RegimeRoutes
+	scorer := strategy.NewScorer(scorerConfig)
 
 	// Add strategies to scorer
 	for _, strat := range config.Strategies {
 		scorer.AddStrategy(strat)
 	}
+	if config.EvalPool != nil {
+		scorer.SetEvalPool(config.EvalPool, strategy.PriorityBackground)
+	}
 
 	return &Engine{
 		config:         config,
@@ -55,6 +160,80 @@ func (e *Engine) Run(data *HistoricalData) (*Result, error) {
 		return nil, fmt.Errorf("no historical data provided")
 	}
 
+	e.pass, e.passes = 0, 1
+	if e.config.LookaheadAudit {
+		e.passes++
+	}
+	var rec *benchmarkRecording
+	if e.config.BenchmarkRuns > 0 {
+		e.passes++
+		rec = newBenchmarkRecording(data)
+	}
+
+	if !e.config.LookaheadAudit && rec == nil {
+		return e.run(data, 0, nil, nil, nil)
+	}
+
+	var base *lookaheadAudit
+	if e.config.LookaheadAudit {
+		base = newLookaheadAudit()
+	}
+	result, err := e.run(data, 0, base, rec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec != nil {
+		e.pass++
+		report, err := e.runBenchmark(data, rec, result)
+		if err != nil {
+			return nil, fmt.Errorf("benchmark failed: %w", err)
+		}
+		result.Benchmark = report
+	}
+
+	if e.config.LookaheadAudit {
+		e.pass++
+		lagged := newLookaheadAudit()
+		shifted, err := e.run(data, 1, lagged, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("lookahead audit failed: %w", err)
+		}
+		result.Lookahead = compareLookahead(result, base, shifted, lagged)
+	}
+
+	result.EndTime = time.Now()
+	result.ExecutionTime = result.EndTime.Sub(result.StartTime)
+
+	return result, nil
+}
+
+// SetProgress sets a callback receiving the fraction of the run completed,
+// called about once per percent
+func (e *Engine) SetProgress(fn func(fraction float64)) {
+	e.progress = fn
+}
+
+// reportProgress reports done of total bars of the current pass
+func (e *Engine) reportProgress(done, total int) {
+	if e.progress == nil || total <= 0 {
+		return
+	}
+	step := total / 100
+	if step < 1 {
+		step = 1
+	}
+	if done%step != 0 && done != total {
+		return
+	}
+	e.progress((float64(e.pass) + float64(done)/float64(total)) / float64(e.passes))
+}
+
+// run executes a single backtest pass. With shift > 0, strategies only see
+// bars up to i-shift when deciding at bar i and orders fill at bar i's open.
+// rec records the pass for benchmark control runs; with random set, the
+// pass is a control run replaying a recording.
+func (e *Engine) run(data *HistoricalData, shift int, audit *lookaheadAudit, rec *benchmarkRecording, random *randomEntries) (*Result, error) {
 	result := &Result{
 		Config:         e.config,
 		Metrics:        &Metrics{},
@@ -75,51 +254,189 @@ func (e *Engine) Run(data *HistoricalData) (*Result, error) {
 		}
 	}
 
+	timeframe := data.Timeframe
+	if timeframe == "" {
+		timeframe = e.config.Timeframe
+	}
+	barDuration, durationErr := TimeframeDuration(timeframe)
+
+	// Align secondary timeframes with the primary one
+	var aligner *timeframeAligner
+	if len(data.HigherTimeframes) > 0 {
+		if durationErr != nil {
+			return nil, durationErr
+		}
+		var err error
+		aligner, err = newTimeframeAligner(barDuration, data.HigherTimeframes, e.indicatorMgr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Entries wait for a confirmation candle, as in live trading
+	confirmInterval, err := e.confirmationInterval(data, barDuration, durationErr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Price series split once for the whole pass; a recording already has
+	// them
+	var cols, confirmCols *candleColumns
+	if rec != nil {
+		cols = rec.candleColumns
+	} else if random == nil {
+		cols = newCandleColumns(data.Candles)
+	}
+	if confirmInterval > 0 {
+		confirmCols = newCandleColumns(data.ConfirmationCandles)
+	}
+
+	// Indicators follow the bars instead of being recomputed from the
+	// start on each one
+	stream := e.indicatorMgr.NewStream()
+
+	// Entry held back from a volatility spike to the next bar, or held
+	// for confirmation
+	var delayed *delayedEntry
+	var held *heldEntry
+
 	// Run through historical data
 	for i := minDataPoints; i < len(data.Candles); i++ {
+		if !e.config.Deadline.IsZero() && time.Now().After(e.config.Deadline) {
+			return nil, ErrDeadlineExceeded
+		}
 		candle := data.Candles[i]
+		last := i - shift
 
 		// Build market data for this point in time
-		marketData := e.buildMarketData(data, i)
+		var marketData *strategy.MarketData
+		if random != nil {
+			marketData = random.rec.marketData(e.config, data, last)
+		} else {
+			marketData = e.buildMarketData(data, cols, stream, last, barDuration)
+			rec.recordBar(last, marketData.Analysis)
+		}
+		marketData.HigherTimeframes = aligner.At(data.Candles[last].Timestamp)
+
+		decisionTime := candle.Timestamp.Add(barDuration)
+		if shift > 0 {
+			// Act at the open of the current bar using only earlier bars
+			decisionTime = candle.Timestamp
+			marketData.Timestamp = candle.Timestamp
+			marketData.CurrentPrice = candle.Open
+			marketData.Bid = candle.Open
+			marketData.Ask = candle.Open
+		}
+		if durationErr == nil {
+			audit.assertClosed(data, last, barDuration, decisionTime, marketData)
+		}
 
 		// Update portfolio with current price
 		portfolio.UpdatePrice(candle.Close)
 
-		// Check exit conditions for open positions
-		e.checkExits(portfolio, marketData, &result.Trades)
+		// Funding settles and interest accrues whether or not the
+		// exchange is up
+		e.chargeCarry(portfolio, marketData.Timestamp, marketData.CurrentPrice)
 
-		// Get regime
-		regime := e.regimeDetector.Detect(
-			marketData.Opens,
-			marketData.Highs,
-			marketData.Lows,
-			marketData.Closes,
-			marketData.Volumes,
-		)
-		marketData.Regime = regime
+		// Check exit conditions for open positions against the bar traded
+		// since the last check, unless the exchange is down
+		down := e.config.exchangeDown(decisionTime)
+		if down {
+			result.Metrics.DowntimeBars++
+		} else {
+			bar := data.Candles[last]
+			reopened := e.config.maintenanceOverlaps(bar.Timestamp, bar.Timestamp.Add(barDuration))
+			if reopened {
+				bar = reopenBar(bar)
+			}
+			closed := len(result.Trades)
+			e.checkExits(portfolio, marketData, bar, &result.Trades)
+			if reopened {
+				result.Metrics.ReopenExits += len(result.Trades) - closed
+			}
+		}
 
-		// Get combined score from all strategies
-		score := e.scorer.Score(marketData, regime)
+		canEnter := !down && len(portfolio.Positions) < e.config.maxPositions()
+		if random != nil {
+			// Control runs enter at random instead of scoring
+			if canEnter {
+				if t, ok := random.next(); ok {
+					e.enterRandom(portfolio, marketData, t)
+				}
+			}
+		} else {
+			// Get regime
+			regime := e.regimeDetector.DetectAnalyzed(marketData.Analysis, marketData.Closes)
+			marketData.Regime = regime
+
+			// Get combined score from all strategies
+			score := e.scorer.Score(marketData, regime)
+			audit.record(last, data.Candles[last].Timestamp, score)
+
+			// An entry held back from the last bar's volatility spike goes
+			// ahead or is dropped on this one, in place of its strategy's
+			// signal
+			var released string
+			if delayed != nil {
+				if !canEnter {
+					result.Metrics.DroppedEntries++
+				} else if e.releaseDelayed(delayed, marketData, result.Metrics) {
+					released = delayed.score.BestSignal.Strategy
+					rec.recordEntry(e.enterPosition(portfolio, marketData, delayed.score))
+					canEnter = len(portfolio.Positions) < e.config.maxPositions()
+				}
+				delayed = nil
+			}
+			if held != nil {
+				if !canEnter {
+					result.Metrics.UnconfirmedEntries++
+				} else if entryData := e.confirmHeld(held, data, confirmCols, marketData, confirmInterval, result.Metrics); entryData != nil {
+					released = held.score.BestSignal.Strategy
+					rec.recordEntry(e.enterPosition(portfolio, entryData, held.score))
+					canEnter = len(portfolio.Positions) < e.config.maxPositions()
+				}
+				held = nil
+			}
 
-		// Enter new position if signal is strong enough
-		if score.ShouldTrade && len(portfolio.Positions) == 0 {
-			e.enterPosition(portfolio, marketData, score, &result.Trades)
+			// Enter new position if signal is strong enough
+			if canEnter {
+				rec.recordEligible()
+				if score.ShouldTrade && (score.BestSignal == nil || score.BestSignal.Strategy != released) {
+					if delayed = e.throttleVolatility(&score, marketData, result.Metrics); delayed == nil {
+						signaledAt := data.Candles[last].Timestamp.Add(barDuration)
+						if held = e.holdForConfirmation(score, signaledAt, result.Metrics); held == nil {
+							rec.recordEntry(e.enterPosition(portfolio, marketData, score))
+						}
+					}
+				}
+			}
 		}
 
 		// Record equity
+		exposure := 0.0
+		if capital := portfolio.Capital(); capital > 0 {
+			exposure = portfolio.Allocated() / capital
+		}
 		result.EquityCurve = append(result.EquityCurve, EquityPoint{
 			Timestamp: candle.Timestamp,
 			Equity:    portfolio.GetEquity(),
 			Cash:      portfolio.Cash,
 			Drawdown:  portfolio.GetDrawdown(),
+			InMarket:  len(portfolio.Positions) > 0,
+			Positions: len(portfolio.Positions),
+			Exposure:  exposure,
 		})
+
+		if random == nil {
+			e.reportProgress(i-minDataPoints+1, len(data.Candles)-minDataPoints)
+		}
 	}
 
 	// Close any remaining positions
 	if len(portfolio.Positions) > 0 {
 		lastCandle := data.Candles[len(data.Candles)-1]
 		for _, pos := range portfolio.Positions {
-			trade := e.closePosition(portfolio, pos, lastCandle.Close, "backtest_end")
+			trade := e.closePosition(portfolio, pos, lastCandle.Close, lastCandle.Timestamp, "backtest_end")
 			result.Trades = append(result.Trades, trade)
 		}
 	}
@@ -133,30 +450,21 @@ func (e *Engine) Run(data *HistoricalData) (*Result, error) {
 	return result, nil
 }
 
-// buildMarketData creates MarketData from historical data up to index i
-func (e *Engine) buildMarketData(data *HistoricalData, i int) *strategy.MarketData {
-	// Extract data up to current point
-	opens := make([]float64, i+1)
-	highs := make([]float64, i+1)
-	lows := make([]float64, i+1)
-	closes := make([]float64, i+1)
-	volumes := make([]float64, i+1)
-
-	for j := 0; j <= i; j++ {
-		opens[j] = data.Candles[j].Open
-		highs[j] = data.Candles[j].High
-		lows[j] = data.Candles[j].Low
-		closes[j] = data.Candles[j].Close
-		volumes[j] = data.Candles[j].Volume
-	}
+// buildMarketData creates MarketData from historical data up to index i,
+// sharing the run's price series in cols. Indicators are updated with the
+// bars since the last call on the run's stream, with VWAP anchored at the
+// UTC day's first bar of length bar.
+func (e *Engine) buildMarketData(data *HistoricalData, cols *candleColumns, stream *indicators.Stream, i int, bar time.Duration) *strategy.MarketData {
+	opens, highs, lows, closes, volumes := cols.window(0, i+1)
 
 	// Calculate indicators
-	analysis := e.indicatorMgr.Analyze(opens, highs, lows, closes, volumes)
+	analysis := stream.AnalyzeSession(opens, highs, lows, closes, volumes, indicators.SessionBars(data.Candles[i].Timestamp, bar))
 
 	marketData := &strategy.MarketData{
 		Symbol:       e.config.Symbol,
 		Timeframe:    e.config.Timeframe,
 		Timestamp:    data.Candles[i].Timestamp,
+		Session:      strategy.SessionAt(data.Candles[i].Timestamp),
 		Opens:        opens,
 		Highs:        highs,
 		Lows:         lows,
@@ -171,10 +479,11 @@ func (e *Engine) buildMarketData(data *HistoricalData, i int) *strategy.MarketDa
 	return marketData
 }
 
-// enterPosition enters a new position based on signal
-func (e *Engine) enterPosition(portfolio *Portfolio, data *strategy.MarketData, score strategy.CombinedScore, trades *[]Trade) {
+// enterPosition enters a new position based on signal, returning it or nil
+// when none was opened
+func (e *Engine) enterPosition(portfolio *Portfolio, data *strategy.MarketData, score strategy.CombinedScore) *Position {
 	if score.BestSignal == nil {
-		return
+		return nil
 	}
 
 	// Calculate position size based on risk
@@ -190,76 +499,77 @@ func (e *Engine) enterPosition(portfolio *Portfolio, data *strategy.MarketData,
 		}
 	}
 
+	return e.openPosition(portfolio, data, score.BestSignal.Strategy, score.Direction, entryPrice, stopLoss, score.BestSignal.TakeProfit, score.BestSignal.SizeFactor)
+}
+
+// openPosition sizes and opens a position at entryPrice, scaled by
+// sizeFactor unless it is 0, returning it or nil when sizing, exposure
+// limits or cash leave no room
+func (e *Engine) openPosition(portfolio *Portfolio, data *strategy.MarketData, strategyName string, direction strategy.Direction, entryPrice, stopLoss, takeProfit, sizeFactor float64) *Position {
 	// Calculate position size based on risk per trade
 	riskPerShare := math.Abs(entryPrice - stopLoss)
 	if riskPerShare == 0 {
-		return
+		return nil
 	}
 
-	riskAmount := portfolio.GetEquity() * e.config.RiskPerTrade
-	quantity := riskAmount / riskPerShare
+	capital := portfolio.Capital()
+	riskAmount := risk.SizingCapital(e.config.Compounding, e.config.CompoundingStep, e.config.InitialCapital, capital) * e.config.RiskPerTrade
+	quantity := strategy.ScaleSize(riskAmount/riskPerShare, sizeFactor)
 
-	// Limit position size to available capital
-	maxQuantity := (portfolio.Cash * 0.95) / entryPrice
+	// Limit position size to its allocation and the available cash
+	maxQuantity := math.Min(capital*e.config.positionAllocation(), portfolio.Cash*0.95) / entryPrice
 	if quantity > maxQuantity {
 		quantity = maxQuantity
 	}
 
+	// Positions in the same symbol and direction are one bet, so they
+	// share a single exposure limit
+	if e.config.MaxCorrelatedExposure > 0 {
+		room := capital*e.config.MaxCorrelatedExposure - portfolio.CorrelatedExposure(data.Symbol, direction)
+		if quantity > room/entryPrice {
+			quantity = room / entryPrice
+		}
+	}
+
 	if quantity <= 0 {
-		return
+		return nil
 	}
 
-	// Calculate cost including commission
+	// Calculate cost including commission; entries are market orders
 	cost := quantity * entryPrice
-	commission := cost * e.config.Commission
+	commission := e.config.Fees.Commission(cost, LiquidityTaker)
 
 	if cost+commission > portfolio.Cash {
-		return
+		return nil
 	}
 
 	// Open position
 	pos := &Position{
-		ID:         int64(len(*trades) + 1),
+		ID:         portfolio.NextPositionID(),
 		Symbol:     data.Symbol,
-		Strategy:   score.BestSignal.Strategy,
-		Direction:  score.Direction,
+		Strategy:   strategyName,
+		Direction:  direction,
 		EntryPrice: entryPrice,
 		EntryTime:  data.Timestamp,
 		Quantity:   quantity,
 		StopLoss:   stopLoss,
-		TakeProfit: score.BestSignal.TakeProfit,
+		TakeProfit: takeProfit,
 		Commission: commission,
 	}
 
 	portfolio.OpenPosition(pos, cost+commission)
+	return pos
 }
 
-// checkExits checks if any positions should be exited
-func (e *Engine) checkExits(portfolio *Portfolio, data *strategy.MarketData, trades *[]Trade) {
+// checkExits checks if any positions should be exited. Stops and targets
+// are evaluated against bar with the configured execution model, strategy
+// exits at the current price.
+func (e *Engine) checkExits(portfolio *Portfolio, data *strategy.MarketData, bar Candle, trades *[]Trade) {
 	var toClose []*Position
 
 	for _, pos := range portfolio.Positions {
-		shouldExit := false
-		exitReason := ""
-
-		// Check stop loss
-		if pos.Direction == strategy.DirectionLong {
-			if data.CurrentPrice <= pos.StopLoss {
-				shouldExit = true
-				exitReason = "stop_loss"
-			} else if pos.TakeProfit > 0 && data.CurrentPrice >= pos.TakeProfit {
-				shouldExit = true
-				exitReason = "take_profit"
-			}
-		} else {
-			if data.CurrentPrice >= pos.StopLoss {
-				shouldExit = true
-				exitReason = "stop_loss"
-			} else if pos.TakeProfit > 0 && data.CurrentPrice <= pos.TakeProfit {
-				shouldExit = true
-				exitReason = "take_profit"
-			}
-		}
+		// Check stop loss and take profit
+		shouldExit, exitReason, exitPrice := levelExit(e.config.executionModel(), pos, bar)
 
 		// Check strategy exit signal
 		if !shouldExit {
@@ -281,6 +591,7 @@ func (e *Engine) checkExits(portfolio *Portfolio, data *strategy.MarketData, tra
 					if exit {
 						shouldExit = true
 						exitReason = reason
+						exitPrice = data.CurrentPrice
 					}
 					break
 				}
@@ -289,7 +600,7 @@ func (e *Engine) checkExits(portfolio *Portfolio, data *strategy.MarketData, tra
 
 		if shouldExit {
 			toClose = append(toClose, pos)
-			trade := e.closePosition(portfolio, pos, data.CurrentPrice, exitReason)
+			trade := e.closePosition(portfolio, pos, exitPrice, data.Timestamp, exitReason)
 			*trades = append(*trades, trade)
 		}
 	}
@@ -301,7 +612,7 @@ func (e *Engine) checkExits(portfolio *Portfolio, data *strategy.MarketData, tra
 }
 
 // closePosition closes a position and returns the trade record
-func (e *Engine) closePosition(portfolio *Portfolio, pos *Position, exitPrice float64, exitReason string) Trade {
+func (e *Engine) closePosition(portfolio *Portfolio, pos *Position, exitPrice float64, exitTime time.Time, exitReason string) Trade {
 	exitPrice = e.applySlippage(exitPrice, -pos.Direction)
 
 	// Calculate P&L
@@ -312,35 +623,52 @@ func (e *Engine) closePosition(portfolio *Portfolio, pos *Position, exitPrice fl
 		pnl = (pos.EntryPrice - exitPrice) * pos.Quantity
 	}
 
-	// Subtract commissions
-	exitCommission := exitPrice * pos.Quantity * e.config.Commission
-	netPnl := pnl - pos.Commission - exitCommission
+	// Carry costs up to the exit were already paid from cash
+	portfolio.Cash -= e.config.Carry.accrue(pos, exitTime, exitPrice)
+
+	// Subtract commissions and carry costs
+	liquidity := exitLiquidity(exitReason)
+	exitCommission := e.config.Fees.Commission(exitPrice*pos.Quantity, liquidity)
+	netPnl := pnl - pos.Commission - exitCommission - pos.Funding - pos.Borrow
 
-	// Return cash to portfolio
-	proceeds := pos.Quantity * exitPrice
+	// Return the entry notional and the P&L to cash
+	proceeds := pos.value(exitPrice)
 	portfolio.Cash += proceeds - exitCommission
 
 	returnPercent := netPnl / (pos.EntryPrice * pos.Quantity) * 100
 
 	trade := Trade{
-		ID:            pos.ID,
-		Symbol:        pos.Symbol,
-		Strategy:      pos.Strategy,
-		Direction:     pos.Direction.String(),
-		EntryTime:     pos.EntryTime,
-		ExitTime:      time.Now(),
-		EntryPrice:    pos.EntryPrice,
-		ExitPrice:     exitPrice,
-		Quantity:      pos.Quantity,
-		NetProfit:     netPnl,
-		ReturnPercent: returnPercent,
-		ExitReason:    exitReason,
-		Commission:    pos.Commission + exitCommission,
+		ID:             pos.ID,
+		Symbol:         pos.Symbol,
+		Strategy:       pos.Strategy,
+		Direction:      pos.Direction.String(),
+		EntryTime:      pos.EntryTime,
+		ExitTime:       exitTime,
+		EntryPrice:     pos.EntryPrice,
+		ExitPrice:      exitPrice,
+		Quantity:       pos.Quantity,
+		NetProfit:      netPnl,
+		ReturnPercent:  returnPercent,
+		ExitReason:     exitReason,
+		Commission:     pos.Commission + exitCommission,
+		Funding:        pos.Funding,
+		Borrow:         pos.Borrow,
+		EntryLiquidity: LiquidityTaker,
+		ExitLiquidity:  liquidity,
 	}
 
 	return trade
 }
 
+// exitLiquidity returns how an exit fills: take profits rest on the book
+// as limit orders, everything else leaves at market
+func exitLiquidity(exitReason string) Liquidity {
+	if exitReason == "take_profit" {
+		return LiquidityMaker
+	}
+	return LiquidityTaker
+}
+
 // applySlippage applies slippage to price
 func (e *Engine) applySlippage(price float64, direction strategy.Direction) float64 {
 	if e.config.Slippage == 0 {
@@ -391,6 +719,17 @@ func (e *Engine) calculateMetrics(result *Result, portfolio *Portfolio) {
 
 		holdingTime := trade.ExitTime.Sub(trade.EntryTime)
 		holdingTimes = append(holdingTimes, holdingTime)
+
+		metrics.TotalCommission += trade.Commission
+		metrics.TotalFunding += trade.Funding
+		metrics.TotalBorrow += trade.Borrow
+		for _, l := range []Liquidity{trade.EntryLiquidity, trade.ExitLiquidity} {
+			if l == LiquidityMaker {
+				metrics.MakerFills++
+			} else {
+				metrics.TakerFills++
+			}
+		}
 	}
 
 	metrics.WinRate = float64(metrics.WinningTrades) / float64(metrics.TotalTrades)
@@ -425,11 +764,62 @@ func (e *Engine) calculateMetrics(result *Result, portfolio *Portfolio) {
 		metrics.AvgHoldingTime = avgDuration.String()
 	}
 
+	// Turnover, frequency and exposure
+	e.calculateActivity(result)
+
 	// Strategy-specific stats
 	e.calculateStrategyStats(result)
 }
 
-// calculateDrawdown calculates maximum drawdown from equity curve
+// calculateActivity calculates turnover, trade frequency and market exposure
+func (e *Engine) calculateActivity(result *Result) {
+	metrics := result.Metrics
+
+	var exposure time.Duration
+	for _, trade := range result.Trades {
+		metrics.TradedNotional += trade.Quantity * (trade.EntryPrice + trade.ExitPrice)
+		exposure += trade.ExitTime.Sub(trade.EntryTime)
+	}
+	if len(result.Trades) > 0 {
+		metrics.AvgExposureTime = (exposure / time.Duration(len(result.Trades))).String()
+	}
+
+	if len(result.EquityCurve) == 0 {
+		return
+	}
+
+	var equitySum float64
+	inMarket := 0
+	for _, point := range result.EquityCurve {
+		equitySum += point.Equity
+		if point.InMarket {
+			inMarket++
+		}
+	}
+
+	avgEquity := equitySum / float64(len(result.EquityCurve))
+	if avgEquity > 0 {
+		metrics.Turnover = metrics.TradedNotional / avgEquity
+	}
+	metrics.TimeInMarket = float64(inMarket) / float64(len(result.EquityCurve))
+
+	for _, point := range result.EquityCurve {
+		if point.Positions > metrics.MaxConcurrentPositions {
+			metrics.MaxConcurrentPositions = point.Positions
+		}
+		metrics.MaxExposure = math.Max(metrics.MaxExposure, point.Exposure)
+	}
+
+	first := result.EquityCurve[0].Timestamp
+	last := result.EquityCurve[len(result.EquityCurve)-1].Timestamp
+	months := last.Sub(first).Hours() / 24 / 30.44
+	if months > 0 {
+		metrics.TradesPerMonth = float64(metrics.TotalTrades) / months
+	}
+}
+
+// calculateDrawdown calculates maximum drawdown, time underwater and the
+// ulcer index from equity curve
 func (e *Engine) calculateDrawdown(result *Result) {
 	if len(result.EquityCurve) == 0 {
 		return
@@ -437,6 +827,7 @@ func (e *Engine) calculateDrawdown(result *Result) {
 
 	peak := result.EquityCurve[0].Equity
 	maxDD := 0.0
+	var underwater Underwater
 
 	for _, point := range result.EquityCurve {
 		if point.Equity > peak {
@@ -447,9 +838,13 @@ func (e *Engine) calculateDrawdown(result *Result) {
 		if dd > maxDD {
 			maxDD = dd
 		}
+		underwater.Add(point.Timestamp, point.Equity)
 	}
 
 	result.Metrics.MaxDrawdown = maxDD
+	result.Metrics.MaxDrawdownDuration = underwater.Longest().String()
+	result.Metrics.LongestFlatPeriod = underwater.LongestFlat().String()
+	result.Metrics.UlcerIndex = underwater.UlcerIndex()
 
 	if maxDD > 0 {
 		result.Metrics.RecoveryFactor = result.Metrics.NetProfit / (maxDD * e.config.InitialCapital)
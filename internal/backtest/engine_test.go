@@ -0,0 +1,59 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+func TestHeldProfitablePositionHasNoDrawdown(t *testing.T) {
+	for _, direction := range []strategy.Direction{strategy.DirectionLong, strategy.DirectionShort} {
+		t.Run(direction.String(), func(t *testing.T) {
+			portfolio := NewPortfolio(10000)
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			result := &Result{Metrics: &Metrics{}}
+			result.EquityCurve = append(result.EquityCurve, EquityPoint{Timestamp: start, Equity: portfolio.GetEquity()})
+
+			portfolio.OpenPosition(&Position{
+				ID:         portfolio.NextPositionID(),
+				Symbol:     "ETHUSDT",
+				Direction:  direction,
+				EntryPrice: 100,
+				EntryTime:  start,
+				Quantity:   50,
+			}, 5000)
+
+			// The price moves in the position's favour every bar
+			for i := 1; i <= 48; i++ {
+				price := 100 + float64(i)
+				if direction == strategy.DirectionShort {
+					price = 100 - float64(i)
+				}
+				portfolio.UpdatePrice(price)
+				result.EquityCurve = append(result.EquityCurve, EquityPoint{
+					Timestamp: start.Add(time.Duration(i) * time.Hour),
+					Equity:    portfolio.GetEquity(),
+					Drawdown:  portfolio.GetDrawdown(),
+					InMarket:  true,
+				})
+			}
+
+			e := &Engine{config: &Config{InitialCapital: 10000}}
+			e.calculateDrawdown(result)
+
+			if result.Metrics.MaxDrawdown != 0 {
+				t.Errorf("max drawdown = %v, want 0", result.Metrics.MaxDrawdown)
+			}
+			if result.Metrics.MaxDrawdownDuration != "0s" {
+				t.Errorf("time underwater = %s, want 0s", result.Metrics.MaxDrawdownDuration)
+			}
+			if result.Metrics.UlcerIndex != 0 {
+				t.Errorf("ulcer index = %v, want 0", result.Metrics.UlcerIndex)
+			}
+			if last := result.EquityCurve[len(result.EquityCurve)-1]; last.Equity <= 10000 || last.Drawdown != 0 {
+				t.Errorf("final equity %.2f with drawdown %v, want a gain without drawdown", last.Equity, last.Drawdown)
+			}
+		})
+	}
+}
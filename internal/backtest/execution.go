@@ -0,0 +1,105 @@
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+// ExecutionModel decides when stops and targets fill within a bar
+type ExecutionModel string
+
+const (
+	// ExecutionClose only compares stops and targets with the bar close and
+	// fills them there, missing levels touched and recovered within the bar
+	ExecutionClose ExecutionModel = "close"
+
+	// ExecutionWorstCase fills levels touched by the bar's high or low.
+	// When a bar spans both the stop and the target the stop is assumed
+	// to fill first.
+	ExecutionWorstCase ExecutionModel = "worst_case"
+
+	// ExecutionOHLCPath walks the bar open, high, low and close in the
+	// order a bar of its colour most likely traded: up bars are assumed to
+	// dip to the low before the high, down bars to reach the high first
+	ExecutionOHLCPath ExecutionModel = "ohlc_path"
+)
+
+// ParseExecutionModel validates an execution model name; empty selects
+// ExecutionWorstCase
+func ParseExecutionModel(s string) (ExecutionModel, error) {
+	switch m := ExecutionModel(s); m {
+	case "":
+		return ExecutionWorstCase, nil
+	case ExecutionClose, ExecutionWorstCase, ExecutionOHLCPath:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown execution model %q (use close, worst_case or ohlc_path)", s)
+}
+
+// levelExit returns whether a position's stop loss or take profit filled
+// during bar, the exit reason and the fill price before slippage. A bar
+// opening beyond a level fills at the open.
+func levelExit(model ExecutionModel, pos *Position, bar Candle) (bool, string, float64) {
+	long := pos.Direction == strategy.DirectionLong
+
+	stopHit := func(price float64) bool {
+		if long {
+			return price <= pos.StopLoss
+		}
+		return price >= pos.StopLoss
+	}
+	targetHit := func(price float64) bool {
+		if pos.TakeProfit <= 0 {
+			return false
+		}
+		if long {
+			return price >= pos.TakeProfit
+		}
+		return price <= pos.TakeProfit
+	}
+
+	if model == ExecutionClose {
+		switch {
+		case stopHit(bar.Close):
+			return true, "stop_loss", bar.Close
+		case targetHit(bar.Close):
+			return true, "take_profit", bar.Close
+		}
+		return false, "", 0
+	}
+
+	// Gaps through a level fill at the open, not at the level
+	switch {
+	case stopHit(bar.Open):
+		return true, "stop_loss", bar.Open
+	case targetHit(bar.Open):
+		return true, "take_profit", bar.Open
+	}
+
+	// Extremes against and in favour of the position
+	adverse, favourable := bar.Low, bar.High
+	if !long {
+		adverse, favourable = bar.High, bar.Low
+	}
+
+	if model == ExecutionOHLCPath {
+		// Up bars are assumed to trade open, low, high, close and down bars
+		// open, high, low, close
+		adverseFirst := bar.Close >= bar.Open
+		if !long {
+			adverseFirst = !adverseFirst
+		}
+		if !adverseFirst && targetHit(favourable) {
+			return true, "take_profit", pos.TakeProfit
+		}
+	}
+
+	switch {
+	case stopHit(adverse):
+		return true, "stop_loss", pos.StopLoss
+	case targetHit(favourable):
+		return true, "take_profit", pos.TakeProfit
+	}
+	return false, "", 0
+}
@@ -0,0 +1,114 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// bnbFeeDiscount is the fee reduction for paying commission in BNB
+const bnbFeeDiscount = 0.25
+
+// Liquidity is whether a fill added liquidity (maker) or took it (taker)
+type Liquidity string
+
+const (
+	LiquidityMaker Liquidity = "maker"
+	LiquidityTaker Liquidity = "taker"
+)
+
+// FeeTier is the maker/taker rate from a 30-day traded volume upward
+type FeeTier struct {
+	Name      string  `json:"name,omitempty" yaml:"name"`
+	MinVolume float64 `json:"minVolume" yaml:"minVolume"` // 30-day volume (USDT) at which the tier starts
+	MakerBps  float64 `json:"makerBps" yaml:"makerBps"`
+	TakerBps  float64 `json:"takerBps" yaml:"takerBps"`
+}
+
+// FeeSchedule prices fills the way the exchange charges the account
+type FeeSchedule struct {
+	Tiers       []FeeTier `json:"tiers"`
+	Volume30d   float64   `json:"volume30d"`   // Account 30-day volume selecting the tier
+	BNBDiscount bool      `json:"bnbDiscount"` // Commission paid in BNB at a discount
+}
+
+// DefaultFeeTiers returns the Binance spot VIP 0-4 tiers. Rates change over
+// time; configure trading.fees to match the account.
+func DefaultFeeTiers() []FeeTier {
+	return []FeeTier{
+		{Name: "VIP 0", MinVolume: 0, MakerBps: 10, TakerBps: 10},
+		{Name: "VIP 1", MinVolume: 1_000_000, MakerBps: 9, TakerBps: 10},
+		{Name: "VIP 2", MinVolume: 5_000_000, MakerBps: 8, TakerBps: 10},
+		{Name: "VIP 3", MinVolume: 20_000_000, MakerBps: 4.2, TakerBps: 6},
+		{Name: "VIP 4", MinVolume: 75_000_000, MakerBps: 4.2, TakerBps: 5.4},
+	}
+}
+
+// DefaultFeeSchedule returns the entry-level spot fee schedule
+func DefaultFeeSchedule() *FeeSchedule {
+	return &FeeSchedule{Tiers: DefaultFeeTiers()}
+}
+
+// FlatFeeSchedule charges rate (0.001 = 0.1%) on every fill
+func FlatFeeSchedule(rate float64) *FeeSchedule {
+	bps := rate * 10000
+	return &FeeSchedule{Tiers: []FeeTier{{Name: "flat", MakerBps: bps, TakerBps: bps}}}
+}
+
+// Validate checks the schedule can price fills
+func (fs *FeeSchedule) Validate() error {
+	if len(fs.Tiers) == 0 {
+		return fmt.Errorf("fee schedule needs at least one tier")
+	}
+	for _, t := range fs.Tiers {
+		if t.MinVolume < 0 || t.MakerBps < 0 || t.TakerBps < 0 {
+			return fmt.Errorf("fee tier %q has negative values", t.Name)
+		}
+	}
+	if fs.Volume30d < 0 {
+		return fmt.Errorf("30-day volume cannot be negative")
+	}
+	return nil
+}
+
+// Tier returns the tier selected by the 30-day volume, or the lowest tier
+// when the volume is below every tier's minimum
+func (fs *FeeSchedule) Tier() FeeTier {
+	if len(fs.Tiers) == 0 {
+		return FeeTier{}
+	}
+	tiers := make([]FeeTier, len(fs.Tiers))
+	copy(tiers, fs.Tiers)
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinVolume < tiers[j].MinVolume
+	})
+
+	tier := tiers[0]
+	for _, t := range tiers[1:] {
+		if fs.Volume30d >= t.MinVolume {
+			tier = t
+		}
+	}
+	return tier
+}
+
+// Rate returns the commission rate (0.001 = 0.1%) for a fill
+func (fs *FeeSchedule) Rate(liquidity Liquidity) float64 {
+	if fs == nil {
+		return 0
+	}
+	tier := fs.Tier()
+	bps := tier.TakerBps
+	if liquidity == LiquidityMaker {
+		bps = tier.MakerBps
+	}
+	rate := bps / 10000
+	if fs.BNBDiscount {
+		rate *= 1 - bnbFeeDiscount
+	}
+	return rate
+}
+
+// Commission returns the fee charged on a fill of the given notional
+func (fs *FeeSchedule) Commission(notional float64, liquidity Liquidity) float64 {
+	return notional * fs.Rate(liquidity)
+}
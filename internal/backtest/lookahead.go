@@ -0,0 +1,181 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+// Lookahead audit thresholds
+const (
+	// maxSignalMismatchRate is the share of bars allowed to disagree between passes
+	maxSignalMismatchRate = 0.05
+	// maxReturnDecay is the share of a positive baseline return allowed to vanish when shifted
+	maxReturnDecay = 0.5
+	// maxRecordedViolations caps the violations kept in a report
+	maxRecordedViolations = 50
+)
+
+// LookaheadViolation is a single failed lookahead check
+type LookaheadViolation struct {
+	Timestamp time.Time
+	Check     string
+	Detail    string
+}
+
+// LookaheadReport summarizes a lookahead-bias audit.
+// The audit replays the backtest with every indicator input lagged by one bar
+// (decisions at bar i only see bars up to i-1 and fill at bar i's open). A
+// strategy without lookahead makes the same decision one bar later, so any
+// disagreement, or a collapse in returns, points at inflated results.
+type LookaheadReport struct {
+	BarsCompared     int
+	SignalMismatches int
+	MismatchRate     float64
+	BaselineReturn   float64
+	ShiftedReturn    float64
+	ReturnDecay      float64 // Share of the baseline return lost in the shifted pass
+	Violations       []LookaheadViolation
+	TotalViolations  int
+	Suspicious       bool
+	Reasons          []string
+}
+
+// barDecision records the scorer decision for a given last input bar
+type barDecision struct {
+	timestamp   time.Time
+	shouldTrade bool
+	direction   strategy.Direction
+}
+
+// lookaheadAudit collects decisions and assertion failures for one pass
+type lookaheadAudit struct {
+	decisions  map[int]barDecision // Keyed by index of the last bar fed to strategies
+	violations []LookaheadViolation
+	total      int
+}
+
+// newLookaheadAudit creates an empty audit collector
+func newLookaheadAudit() *lookaheadAudit {
+	return &lookaheadAudit{decisions: make(map[int]barDecision)}
+}
+
+// record stores the decision made from inputs ending at bar index
+func (a *lookaheadAudit) record(index int, ts time.Time, score strategy.CombinedScore) {
+	if a == nil {
+		return
+	}
+	a.decisions[index] = barDecision{
+		timestamp:   ts,
+		shouldTrade: score.ShouldTrade,
+		direction:   score.Direction,
+	}
+}
+
+// violate records a failed runtime assertion
+func (a *lookaheadAudit) violate(ts time.Time, check, detail string) {
+	if a == nil {
+		return
+	}
+	a.total++
+	if len(a.violations) < maxRecordedViolations {
+		a.violations = append(a.violations, LookaheadViolation{Timestamp: ts, Check: check, Detail: detail})
+	}
+}
+
+// assertClosed checks that no bar handed to strategies closes after the
+// decision time, including higher-timeframe context
+func (a *lookaheadAudit) assertClosed(data *HistoricalData, lastIndex int, barDuration time.Duration, decisionTime time.Time, md *strategy.MarketData) {
+	if a == nil {
+		return
+	}
+
+	if barDuration > 0 {
+		lastClose := data.Candles[lastIndex].Timestamp.Add(barDuration)
+		if lastClose.After(decisionTime) {
+			a.violate(decisionTime, "unclosed_bar",
+				fmt.Sprintf("Closes[len-1] belongs to a bar closing at %s", lastClose.Format(time.RFC3339)))
+		}
+	}
+
+	for tf, htf := range md.HigherTimeframes {
+		if htf.LastClose.After(decisionTime) {
+			a.violate(decisionTime, "unclosed_higher_timeframe_bar",
+				fmt.Sprintf("%s context includes a bar closing at %s", tf, htf.LastClose.Format(time.RFC3339)))
+		}
+	}
+
+	if math.IsNaN(md.CurrentPrice) || math.IsInf(md.CurrentPrice, 0) {
+		a.violate(decisionTime, "invalid_price", "current price is not a finite number")
+	}
+}
+
+// compareLookahead builds the audit report from a baseline and a shifted pass
+func compareLookahead(baseline *Result, base *lookaheadAudit, shifted *Result, lagged *lookaheadAudit) *LookaheadReport {
+	report := &LookaheadReport{
+		BaselineReturn: baseline.Metrics.TotalReturn,
+		ShiftedReturn:  shifted.Metrics.TotalReturn,
+	}
+
+	indexes := make([]int, 0, len(base.decisions))
+	for index := range base.decisions {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		want := base.decisions[index]
+		got, ok := lagged.decisions[index]
+		if !ok {
+			continue
+		}
+		report.BarsCompared++
+		if got.shouldTrade != want.shouldTrade || (want.shouldTrade && got.direction != want.direction) {
+			report.SignalMismatches++
+			base.violate(want.timestamp, "signal_mismatch",
+				fmt.Sprintf("decision changed when inputs were lagged by one bar (%s -> %s)",
+					decisionString(want), decisionString(got)))
+		}
+	}
+
+	if report.BarsCompared > 0 {
+		report.MismatchRate = float64(report.SignalMismatches) / float64(report.BarsCompared)
+	}
+	if report.BaselineReturn > 0 {
+		report.ReturnDecay = (report.BaselineReturn - report.ShiftedReturn) / report.BaselineReturn
+	}
+
+	report.Violations = append(report.Violations, base.violations...)
+	report.Violations = append(report.Violations, lagged.violations...)
+	if len(report.Violations) > maxRecordedViolations {
+		report.Violations = report.Violations[:maxRecordedViolations]
+	}
+	report.TotalViolations = base.total + lagged.total
+
+	unclosed := report.TotalViolations - report.SignalMismatches
+	if unclosed > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%d runtime assertion(s) failed", unclosed))
+	}
+	if report.MismatchRate > maxSignalMismatchRate {
+		report.Reasons = append(report.Reasons,
+			fmt.Sprintf("%.1f%% of decisions changed when inputs were lagged one bar", report.MismatchRate*100))
+	}
+	if report.BaselineReturn > 0 && report.ReturnDecay > maxReturnDecay {
+		report.Reasons = append(report.Reasons,
+			fmt.Sprintf("%.0f%% of the return disappears when filling on the next bar", report.ReturnDecay*100))
+	}
+	report.Suspicious = len(report.Reasons) > 0
+
+	return report
+}
+
+// decisionString renders a bar decision for violation details
+func decisionString(d barDecision) string {
+	if !d.shouldTrade {
+		return "NO_TRADE"
+	}
+	return d.direction.String()
+}
@@ -0,0 +1,61 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaintenanceWindow is a period the exchange is down. Nothing fills during
+// it; stops and targets crossed while it lasts fill at the price the
+// market reopens at.
+type MaintenanceWindow struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// Validate checks the window is not empty
+func (w MaintenanceWindow) Validate() error {
+	if w.Start.IsZero() || w.End.IsZero() {
+		return fmt.Errorf("maintenance window needs a start and an end")
+	}
+	if !w.End.After(w.Start) {
+		return fmt.Errorf("maintenance window ending %s does not end after its start", w.End.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// exchangeDown reports whether t falls within a maintenance window
+func (c *Config) exchangeDown(t time.Time) bool {
+	for _, w := range c.Maintenance {
+		if !t.Before(w.Start) && t.Before(w.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenanceOverlaps reports whether a maintenance window overlaps the
+// period from start to end
+func (c *Config) maintenanceOverlaps(start, end time.Time) bool {
+	for _, w := range c.Maintenance {
+		if w.Start.Before(end) && start.Before(w.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// reopenBar collapses a bar during which the exchange reopened to its
+// close. The range traded before the reopen could not be filled against,
+// so levels crossed by then gap to the close.
+func reopenBar(bar Candle) Candle {
+	return Candle{
+		Timestamp: bar.Timestamp,
+		Open:      bar.Close,
+		High:      bar.Close,
+		Low:       bar.Close,
+		Close:     bar.Close,
+		Volume:    bar.Volume,
+	}
+}
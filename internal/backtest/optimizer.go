@@ -0,0 +1,502 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+// Objective ranks optimizer runs; higher scores rank first
+type Objective string
+
+const (
+	ObjectiveSharpe       Objective = "sharpe"
+	ObjectiveSortino      Objective = "sortino"
+	ObjectiveCalmar       Objective = "calmar"
+	ObjectiveNetProfit    Objective = "net_profit"
+	ObjectiveProfitFactor Objective = "profit_factor"
+)
+
+// Search modes
+const (
+	SearchGrid   = "grid"
+	SearchRandom = "random"
+)
+
+// indicatorParamPrefix selects indicator parameters instead of a strategy's
+const indicatorParamPrefix = "indicators"
+
+const (
+	defaultOptimizerMaxRuns = 500
+	defaultRandomSamples    = 50
+)
+
+// ParseObjective validates an objective name; empty selects Sharpe
+func ParseObjective(s string) (Objective, error) {
+	switch Objective(s) {
+	case "":
+		return ObjectiveSharpe, nil
+	case ObjectiveSharpe, ObjectiveSortino, ObjectiveCalmar, ObjectiveNetProfit, ObjectiveProfitFactor:
+		return Objective(s), nil
+	}
+	return "", fmt.Errorf("unknown objective %q (sharpe, sortino, calmar, net_profit, profit_factor)", s)
+}
+
+// Score reads the objective from backtest metrics
+func (o Objective) Score(m *Metrics) float64 {
+	switch o {
+	case ObjectiveSortino:
+		return m.SortinoRatio
+	case ObjectiveCalmar:
+		return m.CalmarRatio
+	case ObjectiveNetProfit:
+		return m.NetProfit
+	case ObjectiveProfitFactor:
+		return m.ProfitFactor
+	default:
+		return m.SharpeRatio
+	}
+}
+
+// ParamRange lists the values a parameter takes, either explicitly or from
+// Min to Max in increments of Step
+type ParamRange struct {
+	Values []float64 `json:"values,omitempty"`
+	Min    float64   `json:"min,omitempty"`
+	Max    float64   `json:"max,omitempty"`
+	Step   float64   `json:"step,omitempty"`
+}
+
+// Expand returns every value in the range
+func (r ParamRange) Expand() ([]float64, error) {
+	if len(r.Values) > 0 {
+		return r.Values, nil
+	}
+	if r.Step <= 0 || r.Max < r.Min {
+		return nil, fmt.Errorf("range needs values or min <= max with a positive step")
+	}
+
+	var values []float64
+	for i := 0; ; i++ {
+		v := r.Min + float64(i)*r.Step
+		// Tolerate float drift so the max itself is included
+		if v > r.Max+r.Step*1e-9 {
+			break
+		}
+		values = append(values, math.Round(v*1e9)/1e9)
+	}
+	return values, nil
+}
+
+// OptimizerConfig describes a parameter search
+type OptimizerConfig struct {
+	// Base is the backtest every run starts from. Its strategies supply the
+	// parameters that are not searched; runs never modify them.
+	Base *Config
+
+	// Params maps "<strategy>.<Field>" (e.g. "trend_following.FastMAPeriod")
+	// or "indicators.<Field>" (e.g. "indicators.RSIPeriod") to its range
+	Params map[string]ParamRange
+
+	Mode      string // SearchGrid (default) or SearchRandom
+	Samples   int    // Random combinations to draw
+	Seed      int64  // Random mode seed; 0 uses the current time
+	Workers   int    // Parallel backtests; 0 uses every CPU
+	MaxRuns   int    // Refuse searches larger than this
+	Objective Objective
+	MinTrades int // Runs with fewer trades rank below every qualifying run
+	Top       int // Runs returned; 0 returns all
+}
+
+// OptimizationRun is one backtest of the search
+type OptimizationRun struct {
+	Params    map[string]float64
+	Score     float64
+	Qualified bool // Completed with at least MinTrades trades
+	Metrics   *Metrics
+	Error     string
+}
+
+// OptimizationResult holds the ranked runs of a search
+type OptimizationResult struct {
+	Objective     Objective
+	Mode          string
+	Seed          int64 // Random mode seed, to repeat the search
+	Combinations  int   // Size of the full grid
+	Runs          int
+	Failed        int
+	Best          *OptimizationRun
+	Results       []OptimizationRun
+	StartTime     time.Time
+	ExecutionTime time.Duration
+}
+
+// Optimize backtests parameter combinations in parallel and ranks them by
+// the objective. Cancelling ctx stops runs that have not started.
+func Optimize(ctx context.Context, config *OptimizerConfig, data *HistoricalData) (*OptimizationResult, error) {
+	if config.Base == nil || len(config.Base.Strategies) == 0 {
+		return nil, fmt.Errorf("optimizer needs a base config with strategies")
+	}
+	if data == nil || len(data.Candles) == 0 {
+		return nil, fmt.Errorf("no historical data provided")
+	}
+	if len(config.Params) == 0 {
+		return nil, fmt.Errorf("no parameters to optimize")
+	}
+
+	objective, err := ParseObjective(string(config.Objective))
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand ranges in a fixed order so results are reproducible
+	names := make([]string, 0, len(config.Params))
+	for name := range config.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ranges := make([][]float64, len(names))
+	combinations := 1
+	for i, name := range names {
+		values, err := config.Params[name].Expand()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if _, _, err := buildRun(config.Base, map[string]float64{name: values[0]}); err != nil {
+			return nil, err
+		}
+		ranges[i] = values
+		if combinations <= math.MaxInt32 {
+			combinations *= len(values)
+		}
+	}
+
+	maxRuns := config.MaxRuns
+	if maxRuns <= 0 {
+		maxRuns = defaultOptimizerMaxRuns
+	}
+
+	result := &OptimizationResult{
+		Objective:    objective,
+		Mode:         config.Mode,
+		Combinations: combinations,
+		StartTime:    time.Now(),
+	}
+	if result.Mode == "" {
+		result.Mode = SearchGrid
+	}
+
+	var combos []map[string]float64
+	switch result.Mode {
+	case SearchGrid:
+		if combinations > maxRuns {
+			return nil, fmt.Errorf("grid has %d combinations, limit is %d; narrow the ranges or use random mode", combinations, maxRuns)
+		}
+		combos = gridCombinations(names, ranges)
+	case SearchRandom:
+		samples := config.Samples
+		if samples <= 0 {
+			samples = defaultRandomSamples
+		}
+		if samples > maxRuns {
+			return nil, fmt.Errorf("%d samples requested, limit is %d", samples, maxRuns)
+		}
+		seed := config.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		result.Seed = seed
+		combos = randomCombinations(names, ranges, samples, combinations, rand.New(rand.NewSource(seed)))
+	default:
+		return nil, fmt.Errorf("unknown search mode %q", config.Mode)
+	}
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(combos) {
+		workers = len(combos)
+	}
+
+	runs := make([]OptimizationRun, len(combos))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i] = runCombination(config.Base, combos[i], data, objective, config.MinTrades)
+			}
+		}()
+	}
+
+feed:
+	for i := range combos {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("optimization cancelled: %w", err)
+	}
+
+	result.Runs = len(runs)
+	for _, run := range runs {
+		if run.Error != "" {
+			result.Failed++
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, b := runs[i], runs[j]
+		if a.Qualified != b.Qualified {
+			return a.Qualified
+		}
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		return a.Score > b.Score
+	})
+
+	if len(runs) > 0 && runs[0].Qualified {
+		best := runs[0]
+		result.Best = &best
+	}
+	if config.Top > 0 && len(runs) > config.Top {
+		runs = runs[:config.Top]
+	}
+	result.Results = runs
+	result.ExecutionTime = time.Since(result.StartTime)
+
+	return result, nil
+}
+
+// runCombination backtests one parameter combination
+func runCombination(base *Config, params map[string]float64, data *HistoricalData, objective Objective, minTrades int) OptimizationRun {
+	run := OptimizationRun{Params: params}
+
+	strategies, indicatorConfig, err := buildRun(base, params)
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	config := *base
+	config.Strategies = strategies
+	config.Indicators = indicatorConfig
+	config.LookaheadAudit = false
+	config.BenchmarkRuns = 0
+
+	result, err := NewEngine(&config).Run(data)
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	run.Metrics = result.Metrics
+	run.Score = objective.Score(result.Metrics)
+	if math.IsNaN(run.Score) || math.IsInf(run.Score, 0) {
+		run.Score = 0
+		return run
+	}
+	run.Qualified = result.Metrics.TotalTrades >= minTrades
+	return run
+}
+
+// buildRun creates fresh strategies and indicator settings with params
+// applied on top of the base configuration
+func buildRun(base *Config, params map[string]float64) ([]strategy.Strategy, *indicators.IndicatorConfig, error) {
+	indicatorConfig := indicators.DefaultConfig()
+	if base.Indicators != nil {
+		copied := *base.Indicators
+		indicatorConfig = &copied
+	}
+
+	// Copy each strategy's configuration so runs don't share state
+	configs := make(map[string]reflect.Value, len(base.Strategies))
+	order := make([]string, 0, len(base.Strategies))
+	for _, strat := range base.Strategies {
+		original := reflect.ValueOf(strat.GetConfig())
+		if original.Kind() != reflect.Ptr || original.Elem().Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("strategy %s has no configurable parameters", strat.Name())
+		}
+		copied := reflect.New(original.Elem().Type())
+		copied.Elem().Set(original.Elem())
+
+		name := strategy.CanonicalName(strat.Name())
+		configs[name] = copied
+		order = append(order, name)
+	}
+
+	for key, value := range params {
+		target, field, ok := strings.Cut(key, ".")
+		if !ok {
+			return nil, nil, fmt.Errorf("parameter %q must be <strategy>.<Field> or indicators.<Field>", key)
+		}
+
+		var config reflect.Value
+		if target == indicatorParamPrefix {
+			config = reflect.ValueOf(indicatorConfig)
+		} else {
+			config, ok = configs[strategy.CanonicalName(target)]
+			if !ok {
+				return nil, nil, fmt.Errorf("parameter %q: strategy %s is not part of the backtest", key, target)
+			}
+		}
+		if err := setParam(config.Elem(), field, value); err != nil {
+			return nil, nil, fmt.Errorf("parameter %q: %w", key, err)
+		}
+	}
+
+	strategies := make([]strategy.Strategy, 0, len(order))
+	for i, name := range order {
+		strat, err := strategy.NewFromConfig(configs[name].Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+		strat.SetEnabled(base.Strategies[i].IsEnabled())
+		strategies = append(strategies, strat)
+	}
+
+	return strategies, indicatorConfig, nil
+}
+
+// setParam sets a numeric or boolean field of a config struct by name
+func setParam(config reflect.Value, name string, value float64) error {
+	field := config.FieldByNameFunc(func(f string) bool {
+		return strings.EqualFold(f, name)
+	})
+	if !field.IsValid() || !field.CanSet() {
+		return fmt.Errorf("unknown field %s", name)
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(int64(math.Round(value)))
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(value)
+	case reflect.Bool:
+		field.SetBool(value != 0)
+	default:
+		return fmt.Errorf("field %s is not numeric", name)
+	}
+	return nil
+}
+
+// ReadParam reads a numeric or boolean field of a strategy or indicator
+// config by name, as the optimizer sets it
+func ReadParam(config interface{}, name string) (float64, error) {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return 0, fmt.Errorf("config %T has no fields", config)
+	}
+	field := value.Elem().FieldByNameFunc(func(f string) bool {
+		return strings.EqualFold(f, name)
+	})
+	if !field.IsValid() {
+		return 0, fmt.Errorf("unknown field %s", name)
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), nil
+	case reflect.Bool:
+		if field.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("field %s is not numeric", name)
+	}
+}
+
+// ApplyParams returns a new strategy configured as strat with params,
+// keyed by config field name, applied. strat is left unchanged.
+func ApplyParams(strat strategy.Strategy, params map[string]float64) (strategy.Strategy, error) {
+	original := reflect.ValueOf(strat.GetConfig())
+	if original.Kind() != reflect.Ptr || original.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("strategy %s has no configurable parameters", strat.Name())
+	}
+	copied := reflect.New(original.Elem().Type())
+	copied.Elem().Set(original.Elem())
+
+	for name, value := range params {
+		if err := setParam(copied.Elem(), name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	applied, err := strategy.NewFromConfig(copied.Interface())
+	if err != nil {
+		return nil, err
+	}
+	applied.SetEnabled(strat.IsEnabled())
+	return applied, nil
+}
+
+// gridCombinations enumerates every combination of the ranges
+func gridCombinations(names []string, ranges [][]float64) []map[string]float64 {
+	combos := []map[string]float64{{}}
+	for i, name := range names {
+		next := make([]map[string]float64, 0, len(combos)*len(ranges[i]))
+		for _, combo := range combos {
+			for _, v := range ranges[i] {
+				c := make(map[string]float64, len(combo)+1)
+				for k, existing := range combo {
+					c[k] = existing
+				}
+				c[name] = v
+				next = append(next, c)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// randomCombinations draws up to samples distinct combinations
+func randomCombinations(names []string, ranges [][]float64, samples, combinations int, rng *rand.Rand) []map[string]float64 {
+	if samples > combinations {
+		samples = combinations
+	}
+
+	seen := make(map[string]bool, samples)
+	combos := make([]map[string]float64, 0, samples)
+	for attempts := 0; len(combos) < samples && attempts < samples*20; attempts++ {
+		combo := make(map[string]float64, len(names))
+		var key strings.Builder
+		for i, name := range names {
+			v := ranges[i][rng.Intn(len(ranges[i]))]
+			combo[name] = v
+			key.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+			key.WriteByte('|')
+		}
+		if seen[key.String()] {
+			continue
+		}
+		seen[key.String()] = true
+		combos = append(combos, combo)
+	}
+	return combos
+}
@@ -0,0 +1,150 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+// maxHigherTimeframeBars caps the history passed to strategies per secondary timeframe
+const maxHigherTimeframeBars = 500
+
+// TimeframeDuration returns the bar duration of a timeframe such as "15m", "4h" or "1d"
+func TimeframeDuration(timeframe string) (time.Duration, error) {
+	if len(timeframe) < 2 {
+		return 0, fmt.Errorf("invalid timeframe %q", timeframe)
+	}
+
+	n, err := strconv.Atoi(timeframe[:len(timeframe)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid timeframe %q", timeframe)
+	}
+
+	var unit time.Duration
+	switch timeframe[len(timeframe)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid timeframe %q", timeframe)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// higherTimeframe replays a secondary timeframe in step with the primary one
+type higherTimeframe struct {
+	timeframe string
+	duration  time.Duration
+	candles   []Candle
+	cols      *candleColumns
+	closed    int // Number of bars closed so far
+	cached    *strategy.TimeframeData
+}
+
+// timeframeAligner exposes only bars that have closed by the time a primary
+// bar closes, so higher-timeframe context never leaks future data
+type timeframeAligner struct {
+	primary      time.Duration
+	frames       []*higherTimeframe
+	indicatorMgr *indicators.Manager
+}
+
+// newTimeframeAligner validates and prepares secondary timeframes
+func newTimeframeAligner(primary time.Duration, higher map[string][]Candle, indicatorMgr *indicators.Manager) (*timeframeAligner, error) {
+	aligner := &timeframeAligner{primary: primary, indicatorMgr: indicatorMgr}
+
+	names := make([]string, 0, len(higher))
+	for tf := range higher {
+		names = append(names, tf)
+	}
+	sort.Strings(names)
+
+	for _, tf := range names {
+		duration, err := TimeframeDuration(tf)
+		if err != nil {
+			return nil, err
+		}
+		if duration < primary {
+			return nil, fmt.Errorf("timeframe %s is shorter than the primary timeframe", tf)
+		}
+
+		candles := make([]Candle, len(higher[tf]))
+		copy(candles, higher[tf])
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].Timestamp.Before(candles[j].Timestamp)
+		})
+
+		aligner.frames = append(aligner.frames, &higherTimeframe{
+			timeframe: tf,
+			duration:  duration,
+			candles:   candles,
+			cols:      newCandleColumns(candles),
+		})
+	}
+
+	return aligner, nil
+}
+
+// At returns the higher-timeframe context visible when the primary bar
+// opened at barOpen closes
+func (a *timeframeAligner) At(barOpen time.Time) map[string]*strategy.TimeframeData {
+	if a == nil || len(a.frames) == 0 {
+		return nil
+	}
+
+	closeTime := barOpen.Add(a.primary)
+
+	result := make(map[string]*strategy.TimeframeData, len(a.frames))
+	for _, f := range a.frames {
+		// Advance past every bar whose close is at or before the primary close
+		advanced := false
+		for f.closed < len(f.candles) && !f.candles[f.closed].Timestamp.Add(f.duration).After(closeTime) {
+			f.closed++
+			advanced = true
+		}
+
+		if f.closed == 0 {
+			continue
+		}
+		if advanced || f.cached == nil {
+			f.cached = a.build(f)
+		}
+		result[f.timeframe] = f.cached
+	}
+
+	return result
+}
+
+// build creates timeframe data from the closed bars of a secondary
+// timeframe, sharing its price series
+func (a *timeframeAligner) build(f *higherTimeframe) *strategy.TimeframeData {
+	start := 0
+	if f.closed > maxHigherTimeframeBars {
+		start = f.closed - maxHigherTimeframeBars
+	}
+	opens, highs, lows, closes, volumes := f.cols.window(start, f.closed)
+
+	data := &strategy.TimeframeData{
+		Timeframe: f.timeframe,
+		LastClose: f.candles[f.closed-1].Timestamp.Add(f.duration),
+		Opens:     opens,
+		Highs:     highs,
+		Lows:      lows,
+		Closes:    closes,
+		Volumes:   volumes,
+	}
+
+	sessionBars := indicators.SessionBars(f.candles[f.closed-1].Timestamp, f.duration)
+	data.Analysis = a.indicatorMgr.AnalyzeSession(data.Opens, data.Highs, data.Lows, data.Closes, data.Volumes, sessionBars)
+	return data
+}
@@ -1,6 +1,7 @@
 package backtest
 
 import (
+	"math"
 	"time"
 
 	"github.com/eth-trading/internal/strategy"
@@ -21,6 +22,14 @@ type HistoricalData struct {
 	Symbol    string
 	Timeframe string
 	Candles   []Candle
+
+	// Secondary timeframe candles keyed by timeframe (e.g. "4h").
+	// Candle timestamps are bar open times, as for the primary candles.
+	HigherTimeframes map[string][]Candle
+
+	// Candles of the entry confirmation interval when it is shorter than
+	// Timeframe, from strategy.ConfirmationBars before the first bar
+	ConfirmationCandles []Candle
 }
 
 // Position represents an open position in backtest
@@ -35,23 +44,41 @@ type Position struct {
 	StopLoss   float64
 	TakeProfit float64
 	Commission float64
+	Funding    float64 // Funding paid so far; negative when received
+	Borrow     float64 // Borrow interest paid so far
+
+	carriedTo time.Time // When carry costs were last charged
+}
+
+// value returns the entry notional plus the unrealized P&L at price, what
+// closing the position would return to cash before fees
+func (pos *Position) value(price float64) float64 {
+	pnl := (price - pos.EntryPrice) * pos.Quantity
+	if pos.Direction != strategy.DirectionLong {
+		pnl = -pnl
+	}
+	return pos.EntryPrice*pos.Quantity + pnl
 }
 
 // Trade represents a completed trade
 type Trade struct {
-	ID            int64
-	Symbol        string
-	Strategy      string
-	Direction     string
-	EntryTime     time.Time
-	ExitTime      time.Time
-	EntryPrice    float64
-	ExitPrice     float64
-	Quantity      float64
-	NetProfit     float64
-	ReturnPercent float64
-	ExitReason    string
-	Commission    float64
+	ID             int64
+	Symbol         string
+	Strategy       string
+	Direction      string
+	EntryTime      time.Time
+	ExitTime       time.Time
+	EntryPrice     float64
+	ExitPrice      float64
+	Quantity       float64
+	NetProfit      float64
+	ReturnPercent  float64
+	ExitReason     string
+	Commission     float64
+	Funding        float64 // Funding paid; negative when received
+	Borrow         float64 // Borrow interest paid
+	EntryLiquidity Liquidity
+	ExitLiquidity  Liquidity
 }
 
 // EquityPoint represents a point on the equity curve
@@ -60,6 +87,9 @@ type EquityPoint struct {
 	Equity    float64
 	Cash      float64
 	Drawdown  float64
+	InMarket  bool
+	Positions int     // Open positions
+	Exposure  float64 // Gross open notional / capital
 }
 
 // Metrics holds backtest performance metrics
@@ -85,6 +115,44 @@ type Metrics struct {
 	StartingCapital  float64
 	EndingCapital    float64
 	NetProfit        float64
+
+	// Time underwater
+	MaxDrawdownDuration string  // Longest time below the high-water mark
+	LongestFlatPeriod   string  // Longest stretch within 0.5% of its starting equity
+	UlcerIndex          float64 // RMS of the percent drawdowns from the high-water mark
+
+	// Activity
+	TradedNotional   float64
+	Turnover         float64 // Traded notional / average equity
+	TradesPerMonth   float64
+	AvgExposureTime  string
+	TimeInMarket     float64 // Fraction of bars with an open position
+
+	// Trading costs
+	TotalCommission  float64
+	TotalFunding     float64 // Net funding paid; negative when received
+	TotalBorrow      float64
+	MakerFills       int
+	TakerFills       int
+
+	// Concurrency
+	MaxConcurrentPositions int
+	MaxExposure            float64 // Peak gross open notional / capital
+
+	// Exchange maintenance
+	DowntimeBars int // Bars decided while the exchange was down
+	ReopenExits  int // Exits filled at the price the exchange reopened at
+
+	// Volatility spikes
+	SpikeEntries     int // Entries signaled on a volatility spike
+	DelayedEntries   int // Spike entries held back to the next bar
+	DroppedEntries   int // Delayed entries the next bar did not bear out
+	DownsizedEntries int // Spike entries opened at a fraction of the usual size
+
+	// Entry confirmation
+	HeldEntries        int // Entries held for a confirmation candle
+	ConfirmedEntries   int // Held entries whose conditions still held
+	UnconfirmedEntries int // Held entries dropped
 }
 
 // StrategyStats holds per-strategy statistics
@@ -105,6 +173,8 @@ type Result struct {
 	Trades         []Trade
 	MonthlyReturns map[string]float64
 	StrategyStats  map[string]StrategyStats
+	Lookahead      *LookaheadReport // Set when Config.LookaheadAudit is enabled
+	Benchmark      *BenchmarkReport // Set when Config.BenchmarkRuns is positive
 	StartTime      time.Time
 	EndTime        time.Time
 	ExecutionTime  time.Duration
@@ -116,6 +186,9 @@ type Portfolio struct {
 	Positions     []*Position
 	PeakEquity    float64
 	InitialEquity float64
+
+	nextID int64
+	price  float64 // Last price open positions are marked to
 }
 
 // NewPortfolio creates a new portfolio
@@ -128,12 +201,15 @@ func NewPortfolio(initialCash float64) *Portfolio {
 	}
 }
 
-// GetEquity returns total portfolio equity
+// GetEquity returns cash plus open positions marked to the last price
 func (p *Portfolio) GetEquity() float64 {
 	equity := p.Cash
-	for range p.Positions {
-		// For simplicity, not calculating unrealized P&L here
-		// In a full implementation, would mark positions to market
+	for _, pos := range p.Positions {
+		price := p.price
+		if price <= 0 {
+			price = pos.EntryPrice
+		}
+		equity += pos.value(price)
 	}
 	return equity
 }
@@ -152,10 +228,49 @@ func (p *Portfolio) GetDrawdown() float64 {
 	return (p.PeakEquity - equity) / p.PeakEquity
 }
 
-// UpdatePrice updates portfolio with current market price
+// UpdatePrice marks open positions to the current market price
 func (p *Portfolio) UpdatePrice(price float64) {
-	// Update unrealized P&L for open positions
-	// In a full implementation would update position values
+	p.price = price
+}
+
+// NextPositionID returns the ID for the next position opened
+func (p *Portfolio) NextPositionID() int64 {
+	p.nextID++
+	return p.nextID
+}
+
+// Allocated returns the capital committed to open positions at entry
+func (p *Portfolio) Allocated() float64 {
+	allocated := 0.0
+	for _, pos := range p.Positions {
+		allocated += pos.EntryPrice * pos.Quantity
+	}
+	return allocated
+}
+
+// Capital returns cash plus the capital committed to open positions,
+// the base positions are sized and allocated against
+func (p *Portfolio) Capital() float64 {
+	return p.Cash + p.Allocated()
+}
+
+// CorrelatedExposure returns the entry notional of open positions that move
+// together with a new position in symbol and direction. Positions in the
+// same symbol and direction add up; opposite ones offset them.
+func (p *Portfolio) CorrelatedExposure(symbol string, direction strategy.Direction) float64 {
+	exposure := 0.0
+	for _, pos := range p.Positions {
+		if pos.Symbol != symbol {
+			continue
+		}
+		notional := pos.EntryPrice * pos.Quantity
+		if pos.Direction == direction {
+			exposure += notional
+		} else {
+			exposure -= notional
+		}
+	}
+	return math.Max(exposure, 0)
 }
 
 // OpenPosition opens a new position
@@ -0,0 +1,92 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// flatBand is how far equity may stray, as a fraction of its level at the
+// start of a stretch, for the stretch to count as flat
+const flatBand = 0.005
+
+// Underwater measures how long and how deep an equity curve stays below
+// its high-water mark, and how long it goes nowhere. Samples are added in
+// time order; each one's drawdown holds until the next.
+type Underwater struct {
+	peak        float64
+	last        time.Time
+	lastDD      float64 // Drawdown of the last sample, in percent
+	peakAt      time.Time
+	below       bool // Equity is below the peak
+	longest     time.Duration
+	flatStart   time.Time
+	flatLevel   float64
+	longestFlat time.Duration
+	ddSqSecs    float64 // Time-weighted sum of squared percent drawdowns
+	total       time.Duration
+}
+
+// Add records the equity at t
+func (u *Underwater) Add(t time.Time, equity float64) {
+	if equity <= 0 {
+		return
+	}
+	if !u.last.IsZero() {
+		if elapsed := t.Sub(u.last); elapsed > 0 {
+			u.ddSqSecs += u.lastDD * u.lastDD * elapsed.Seconds()
+			u.total += elapsed
+		}
+	}
+	u.last = t
+
+	u.below = equity < u.peak
+	if !u.below {
+		u.peak = equity
+		u.peakAt = t
+	}
+	u.lastDD = (u.peak - equity) / u.peak * 100
+	u.longest = maxDuration(u.longest, u.Current())
+
+	if u.flatStart.IsZero() || math.Abs(equity-u.flatLevel) > u.flatLevel*flatBand {
+		u.flatStart = t
+		u.flatLevel = equity
+	}
+	u.longestFlat = maxDuration(u.longestFlat, t.Sub(u.flatStart))
+}
+
+// Current returns how long equity has been below its high-water mark,
+// since the peak
+func (u *Underwater) Current() time.Duration {
+	if !u.below {
+		return 0
+	}
+	return u.last.Sub(u.peakAt)
+}
+
+// Longest returns the longest time equity spent below its high-water mark,
+// counting the current stretch
+func (u *Underwater) Longest() time.Duration {
+	return u.longest
+}
+
+// LongestFlat returns the longest stretch over which equity stayed within
+// flatBand of its level at the start
+func (u *Underwater) LongestFlat() time.Duration {
+	return u.longestFlat
+}
+
+// UlcerIndex returns the root mean square of the percent drawdowns from
+// the high-water mark, weighted by time
+func (u *Underwater) UlcerIndex() float64 {
+	if u.total <= 0 {
+		return 0
+	}
+	return math.Sqrt(u.ddSqSecs / u.total.Seconds())
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package backtest
+
+import "github.com/eth-trading/internal/strategy"
+
+// delayedEntry is an entry held back from a volatility spike until the
+// next bar closes
+type delayedEntry struct {
+	score      strategy.CombinedScore
+	spikeClose float64
+}
+
+// lastBar returns the high, low and close of the latest bar in data
+func lastBar(data *strategy.MarketData) (high, low, close float64) {
+	n := len(data.Closes) - 1
+	return data.Highs[n], data.Lows[n], data.Closes[n]
+}
+
+// throttleVolatility applies the configured volatility spike rule to an
+// entry signaled on the latest bar, as live trading does. It returns the
+// entry when held back to the next bar; otherwise score is entered, its
+// signal downsized if the rule says so.
+func (e *Engine) throttleVolatility(score *strategy.CombinedScore, data *strategy.MarketData, metrics *Metrics) *delayedEntry {
+	if score.BestSignal == nil {
+		return nil
+	}
+	rule := e.config.VolatilityThrottle.For(score.BestSignal.Strategy)
+	high, low, close := lastBar(data)
+	if !rule.Spike(high, low, data.Analysis.ATR.ATR) {
+		return nil
+	}
+	metrics.SpikeEntries++
+
+	if rule.Action == strategy.VolatilityDownsize {
+		metrics.DownsizedEntries++
+		signal := *score.BestSignal
+		signal.SizeFactor = rule.SizeFactor
+		score.BestSignal = &signal
+		return nil
+	}
+	metrics.DelayedEntries++
+	return &delayedEntry{score: *score, spikeClose: close}
+}
+
+// releaseDelayed reports whether an entry held back from the previous
+// bar's spike goes ahead on the latest bar
+func (e *Engine) releaseDelayed(entry *delayedEntry, data *strategy.MarketData, metrics *Metrics) bool {
+	rule := e.config.VolatilityThrottle.For(entry.score.BestSignal.Strategy)
+	high, low, close := lastBar(data)
+	if !rule.Release(*entry.score.BestSignal, entry.spikeClose, close, rule.Spike(high, low, data.Analysis.ATR.ATR)) {
+		metrics.DroppedEntries++
+		return false
+	}
+	return true
+}
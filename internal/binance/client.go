@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -23,6 +24,11 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	testnet    bool
+	limiter    *rateLimiter
+	retry      RetryPolicy
+
+	timeEndpoint string       // Server time endpoint used to resync the clock
+	timeOffset   atomic.Int64 // Server time minus local time (ms)
 }
 
 // ClientOption configures the client
@@ -52,12 +58,22 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithWeightLimit sets the request weight allowed per minute
+func WithWeightLimit(limit int) ClientOption {
+	return func(c *Client) {
+		if limit > 0 {
+			c.limiter = newRateLimiter(limit)
+		}
+	}
+}
+
 // Config holds client configuration
 type Config struct {
 	APIKey    string
 	SecretKey string
 	Testnet   bool
 	Timeout   time.Duration
+	Retry     RetryPolicy // Zero value uses the default policy
 }
 
 // NewClient creates a new Binance client
@@ -66,6 +82,7 @@ func NewClient(cfg *Config, opts ...ClientOption) *Client {
 	secretKey := ""
 	baseURL := BaseURLSpot
 	timeout := 30 * time.Second
+	var retry RetryPolicy
 
 	if cfg != nil {
 		apiKey = cfg.APIKey
@@ -76,6 +93,7 @@ func NewClient(cfg *Config, opts ...ClientOption) *Client {
 		if cfg.Timeout > 0 {
 			timeout = cfg.Timeout
 		}
+		retry = cfg.Retry
 	}
 
 	c := &Client{
@@ -85,6 +103,9 @@ func NewClient(cfg *Config, opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
+		limiter:      newRateLimiter(SpotWeightLimit),
+		retry:        retry.withDefaults(),
+		timeEndpoint: EndpointTime,
 	}
 
 	for _, opt := range opts {
@@ -101,16 +122,102 @@ func (c *Client) sign(queryString string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// doRequest performs HTTP request
+// doRequest performs HTTP request. Requests wait for request weight to be
+// available and failures are retried per the client's retry policy:
+// rate limits after their Retry-After pause, timestamp errors after a
+// clock resync, and transient failures of idempotent requests with
+// exponential backoff. IP bans fail immediately.
 func (c *Client) doRequest(method, endpoint string, params url.Values, signed bool) ([]byte, error) {
+	if signed && params == nil {
+		params = url.Values{}
+	}
+	weight := requestWeight(method, endpoint, params)
+	resynced := false
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(weight); err != nil {
+			return nil, err
+		}
+
+		body, err := c.attempt(method, endpoint, params, signed)
+		if err == nil {
+			return body, nil
+		}
+		if attempt >= c.retry.MaxRetries {
+			return nil, err
+		}
+
+		class := ClassifyError(err)
+		switch class {
+		case ErrorClassTimestamp:
+			if !signed || resynced {
+				return nil, err
+			}
+			resynced = true
+			if serr := c.SyncTime(); serr != nil {
+				log.Warn().Err(serr).Msg("Failed to resync clock with Binance")
+				return nil, err
+			}
+		case ErrorClassRateLimited:
+			// The limiter holds the next attempt until the pause is over
+		case ErrorClassTransient:
+			if !idempotent(method) {
+				return nil, err
+			}
+			time.Sleep(c.retry.backoff(attempt))
+		default:
+			return nil, err
+		}
+
+		log.Warn().
+			Err(err).
+			Str("endpoint", endpoint).
+			Str("class", class.String()).
+			Int("retry", attempt+1).
+			Msg("Retrying Binance request")
+	}
+}
+
+// attempt sends a request once and converts error responses to *APIError
+func (c *Client) attempt(method, endpoint string, params url.Values, signed bool) ([]byte, error) {
+	resp, body, err := c.send(method, endpoint, params, signed)
+	if err != nil {
+		return nil, err
+	}
+	pause := c.limiter.observe(resp)
+
+	if resp.StatusCode < 400 {
+		return body, nil
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr.Message = fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	if ClassifyError(apiErr) == ErrorClassBanned {
+		if until, ok := banExpiry(apiErr); ok {
+			c.limiter.pauseUntil(until)
+			pause = time.Until(until)
+		}
+		log.Error().
+			Str("endpoint", endpoint).
+			Dur("retryAfter", pause).
+			Msg("Binance IP ban for exceeding rate limits")
+	}
+
+	return nil, apiErr
+}
+
+// send signs and sends a single request, returning the response and its body
+func (c *Client) send(method, endpoint string, params url.Values, signed bool) (*http.Response, []byte, error) {
 	var reqBody io.Reader
 	fullURL := c.baseURL + endpoint
 
 	if signed {
-		if params == nil {
-			params = url.Values{}
-		}
-		params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		// Sign afresh on each attempt so the timestamp stays current
+		params.Del("signature")
+		params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli()+c.timeOffset.Load(), 10))
 		params.Set("signature", c.sign(params.Encode()))
 	}
 
@@ -122,7 +229,7 @@ func (c *Client) doRequest(method, endpoint string, params url.Values, signed bo
 
 	req, err := http.NewRequest(method, fullURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("X-MBX-APIKEY", c.apiKey)
@@ -132,24 +239,45 @@ func (c *Client) doRequest(method, endpoint string, params url.Values, signed bo
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		if err := json.Unmarshal(body, &apiErr); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-		}
-		return nil, &apiErr
+	return resp, body, nil
+}
+
+// SyncTime measures the offset between the local clock and Binance server
+// time and applies it to the timestamps of signed requests
+func (c *Client) SyncTime() error {
+	sent := time.Now()
+	data, err := c.doRequest(http.MethodGet, c.timeEndpoint, nil, false)
+	if err != nil {
+		return err
+	}
+	received := time.Now()
+
+	var result ServerTime
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return body, nil
+	// Assume the server read its clock halfway through the round trip
+	local := sent.Add(received.Sub(sent) / 2)
+	offset := result.ServerTime - local.UnixMilli()
+	c.timeOffset.Store(offset)
+
+	log.Info().Int64("offsetMs", offset).Msg("Clock synced with Binance server time")
+	return nil
+}
+
+// WeightStats returns the client's request weight usage
+func (c *Client) WeightStats() WeightStats {
+	return c.limiter.stats()
 }
 
 // Ping tests connectivity
@@ -221,7 +349,7 @@ func (c *Client) GetSymbolInfo(symbol string) (*SymbolInfo, error) {
 			return &s, nil
 		}
 	}
-	return nil, fmt.Errorf("symbol %s not found", symbol)
+	return nil, fmt.Errorf("%w: %s", ErrSymbolNotFound, symbol)
 }
 
 // countDecimals counts decimal places in a string number
@@ -309,6 +437,20 @@ func (c *Client) GetTicker24hr(symbol string) (*Ticker24hr, error) {
 	return &result, nil
 }
 
+// GetAllTickers24hr returns 24hr price change statistics for every symbol
+func (c *Client) GetAllTickers24hr() ([]Ticker24hr, error) {
+	data, err := c.doRequest(http.MethodGet, EndpointTicker24hr, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Ticker24hr
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result, nil
+}
+
 // GetTickerPrice returns current price
 func (c *Client) GetTickerPrice(symbol string) (*TickerPrice, error) {
 	params := url.Values{}
@@ -326,6 +468,23 @@ func (c *Client) GetTickerPrice(symbol string) (*TickerPrice, error) {
 	return &result, nil
 }
 
+// GetBookTicker returns the best bid and ask
+func (c *Client) GetBookTicker(symbol string) (*BookTicker, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	data, err := c.doRequest(http.MethodGet, EndpointBookTicker, params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BookTicker
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
+
 // GetDepth returns order book depth
 func (c *Client) GetDepth(symbol string, limit int) (*Depth, error) {
 	params := url.Values{}
@@ -382,6 +541,32 @@ func (c *Client) GetBalance(asset string) (*Balance, error) {
 	return nil, fmt.Errorf("asset %s not found", asset)
 }
 
+// DustTransfer converts small balances of the given assets to BNB (requires signature)
+func (c *Client) DustTransfer(assets []string) (*DustTransferResponse, error) {
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("no assets to convert")
+	}
+
+	params := url.Values{}
+	for _, asset := range assets {
+		params.Add("asset", asset)
+	}
+
+	data, err := c.doRequest(http.MethodPost, EndpointDustTransfer, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DustTransferResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	result.TotalServiceCharge, _ = strconv.ParseFloat(result.TotalServiceChargeStr, 64)
+	result.TotalTransfered, _ = strconv.ParseFloat(result.TotalTransferedStr, 64)
+
+	return &result, nil
+}
+
 // GetMyTrades returns account trades
 func (c *Client) GetMyTrades(symbol string, limit int) ([]Trade, error) {
 	params := url.Values{}
@@ -402,6 +587,28 @@ func (c *Client) GetMyTrades(symbol string, limit int) ([]Trade, error) {
 	return result, nil
 }
 
+// GetMyTradesFrom returns account trades with IDs from fromID upwards,
+// oldest first, for paging through the full history
+func (c *Client) GetMyTradesFrom(symbol string, fromID int64, limit int) ([]Trade, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("fromId", strconv.FormatInt(fromID, 10))
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	data, err := c.doRequest(http.MethodGet, EndpointMyTrades, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Trade
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result, nil
+}
+
 // CreateOrder creates a new order
 func (c *Client) CreateOrder(req OrderRequest) (*Order, error) {
 	params := url.Values{}
@@ -490,6 +697,24 @@ func (c *Client) GetOrder(symbol string, orderID int64) (*Order, error) {
 	return &result, nil
 }
 
+// GetOrderByClientID gets order status by the client order ID it was placed with
+func (c *Client) GetOrderByClientID(symbol, clientOrderID string) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+
+	data, err := c.doRequest(http.MethodGet, EndpointOrder, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Order
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
+
 // CancelOrder cancels an order
 func (c *Client) CancelOrder(symbol string, orderID int64) (*Order, error) {
 	params := url.Values{}
@@ -553,6 +778,28 @@ func (c *Client) GetAllOrders(symbol string, limit int) ([]Order, error) {
 	return result, nil
 }
 
+// GetAllOrdersFrom returns orders with IDs from fromOrderID upwards, oldest
+// first, for paging through the full history
+func (c *Client) GetAllOrdersFrom(symbol string, fromOrderID int64, limit int) ([]Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", strconv.FormatInt(fromOrderID, 10))
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	data, err := c.doRequest(http.MethodGet, EndpointAllOrders, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Order
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result, nil
+}
+
 // CancelAllOpenOrders cancels all open orders for a symbol
 func (c *Client) CancelAllOpenOrders(symbol string) ([]Order, error) {
 	orders, err := c.GetOpenOrders(symbol)
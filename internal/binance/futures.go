@@ -0,0 +1,431 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// USD-M futures endpoints
+const (
+	BaseURLFuturesTestnet = "https://testnet.binancefuture.com"
+
+	EndpointFuturesPing         = "/fapi/v1/ping"
+	EndpointFuturesTime         = "/fapi/v1/time"
+	EndpointFuturesExchangeInfo = "/fapi/v1/exchangeInfo"
+	EndpointFuturesTickerPrice  = "/fapi/v1/ticker/price"
+	EndpointFuturesPremiumIndex = "/fapi/v1/premiumIndex"
+	EndpointFuturesOrder        = "/fapi/v1/order"
+	EndpointFuturesOpenOrders   = "/fapi/v1/openOrders"
+	EndpointFuturesLeverage     = "/fapi/v1/leverage"
+	EndpointFuturesMarginType   = "/fapi/v1/marginType"
+	EndpointFuturesPositionMode = "/fapi/v1/positionSide/dual"
+	EndpointFuturesAccount      = "/fapi/v2/account"
+	EndpointFuturesPositionRisk = "/fapi/v2/positionRisk"
+)
+
+// Futures order types
+const (
+	OrderTypeStopMarket       OrderType = "STOP_MARKET"
+	OrderTypeTakeProfitMarket OrderType = "TAKE_PROFIT_MARKET"
+)
+
+// MarginType is how margin is allocated to a futures position
+type MarginType string
+
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCrossed  MarginType = "CROSSED"
+)
+
+// PositionSide is the futures position an order applies to. One-way mode
+// uses BOTH; hedge mode keeps separate LONG and SHORT positions.
+type PositionSide string
+
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+// Futures error codes for settings that are already in effect
+const (
+	ErrCodeNoNeedToChangeMarginType   = -4046
+	ErrCodeNoNeedToChangePositionMode = -4059
+)
+
+// isAPIErrorCode reports whether err is a Binance rejection with code
+func isAPIErrorCode(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// FuturesOrderRequest represents a USD-M futures order creation request
+type FuturesOrderRequest struct {
+	Symbol           string
+	Side             OrderSide
+	PositionSide     PositionSide
+	Type             OrderType
+	TimeInForce      TimeInForce
+	Quantity         float64
+	Price            float64
+	StopPrice        float64
+	ReduceOnly       bool
+	NewClientOrderID string
+}
+
+// FuturesOrder represents a USD-M futures order
+type FuturesOrder struct {
+	Symbol        string       `json:"symbol"`
+	OrderID       int64        `json:"orderId"`
+	ClientOrderID string       `json:"clientOrderId"`
+	Price         float64      `json:"price,string"`
+	AvgPrice      float64      `json:"avgPrice,string"`
+	OrigQty       float64      `json:"origQty,string"`
+	ExecutedQty   float64      `json:"executedQty,string"`
+	CumQuote      float64      `json:"cumQuote,string"`
+	StopPrice     float64      `json:"stopPrice,string"`
+	Status        OrderStatus  `json:"status"`
+	TimeInForce   TimeInForce  `json:"timeInForce"`
+	Type          OrderType    `json:"type"`
+	Side          OrderSide    `json:"side"`
+	PositionSide  PositionSide `json:"positionSide"`
+	ReduceOnly    bool         `json:"reduceOnly"`
+	Time          int64        `json:"time"`
+	UpdateTime    int64        `json:"updateTime"`
+}
+
+// FuturesAccount represents the USD-M futures account
+type FuturesAccount struct {
+	TotalWalletBalance    float64               `json:"totalWalletBalance,string"`
+	TotalUnrealizedProfit float64               `json:"totalUnrealizedProfit,string"`
+	TotalMarginBalance    float64               `json:"totalMarginBalance,string"`
+	TotalInitialMargin    float64               `json:"totalInitialMargin,string"`
+	TotalMaintMargin      float64               `json:"totalMaintMargin,string"`
+	AvailableBalance      float64               `json:"availableBalance,string"`
+	CanTrade              bool                  `json:"canTrade"`
+	UpdateTime            int64                 `json:"updateTime"`
+	Assets                []FuturesAssetBalance `json:"assets"`
+}
+
+// FuturesAssetBalance represents one margin asset of the futures account
+type FuturesAssetBalance struct {
+	Asset            string  `json:"asset"`
+	WalletBalance    float64 `json:"walletBalance,string"`
+	UnrealizedProfit float64 `json:"unrealizedProfit,string"`
+	MarginBalance    float64 `json:"marginBalance,string"`
+	AvailableBalance float64 `json:"availableBalance,string"`
+}
+
+// FuturesPositionRisk represents an open futures position as reported by
+// the exchange. PositionAmt is negative for shorts in one-way mode.
+type FuturesPositionRisk struct {
+	Symbol           string       `json:"symbol"`
+	PositionAmt      float64      `json:"positionAmt,string"`
+	EntryPrice       float64      `json:"entryPrice,string"`
+	MarkPrice        float64      `json:"markPrice,string"`
+	UnrealizedProfit float64      `json:"unRealizedProfit,string"`
+	LiquidationPrice float64      `json:"liquidationPrice,string"`
+	Leverage         int          `json:"leverage,string"`
+	MarginType       string       `json:"marginType"` // "isolated" or "cross"
+	IsolatedMargin   float64      `json:"isolatedMargin,string"`
+	PositionSide     PositionSide `json:"positionSide"`
+	UpdateTime       int64        `json:"updateTime"`
+}
+
+// PremiumIndex represents the mark price and funding rate of a perpetual
+type PremiumIndex struct {
+	Symbol          string  `json:"symbol"`
+	MarkPrice       float64 `json:"markPrice,string"`
+	IndexPrice      float64 `json:"indexPrice,string"`
+	LastFundingRate float64 `json:"lastFundingRate,string"`
+	NextFundingTime int64   `json:"nextFundingTime"`
+	Time            int64   `json:"time"`
+}
+
+// NextFunding returns when the next funding payment is exchanged
+func (p *PremiumIndex) NextFunding() time.Time {
+	return time.UnixMilli(p.NextFundingTime)
+}
+
+// FuturesClient is the Binance USD-M futures REST API client
+type FuturesClient struct {
+	client *Client
+}
+
+// NewFuturesClient creates a USD-M futures client against fapi.binance.com,
+// or the futures testnet
+func NewFuturesClient(cfg *Config, opts ...ClientOption) *FuturesClient {
+	baseURL := BaseURLFutures
+	if cfg != nil && cfg.Testnet {
+		baseURL = BaseURLFuturesTestnet
+	}
+	opts = append([]ClientOption{
+		WithBaseURL(baseURL),
+		WithWeightLimit(FuturesWeightLimit),
+		func(c *Client) { c.timeEndpoint = EndpointFuturesTime },
+	}, opts...)
+	return &FuturesClient{client: NewClient(cfg, opts...)}
+}
+
+// WeightStats returns the client's request weight usage
+func (f *FuturesClient) WeightStats() WeightStats {
+	return f.client.WeightStats()
+}
+
+// call performs a request and decodes the JSON response into result
+func (f *FuturesClient) call(method, endpoint string, params url.Values, signed bool, result interface{}) error {
+	data, err := f.client.doRequest(method, endpoint, params, signed)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// Ping tests connectivity
+func (f *FuturesClient) Ping() error {
+	return f.call(http.MethodGet, EndpointFuturesPing, nil, false, nil)
+}
+
+// GetSymbolInfo returns trading rules for a futures symbol with parsed filters
+func (f *FuturesClient) GetSymbolInfo(symbol string) (*SymbolInfo, error) {
+	var info ExchangeInfo
+	if err := f.call(http.MethodGet, EndpointFuturesExchangeInfo, nil, false, &info); err != nil {
+		return nil, err
+	}
+
+	for _, s := range info.Symbols {
+		if s.Symbol == symbol {
+			for _, flt := range s.Filters {
+				switch flt.FilterType {
+				case "PRICE_FILTER":
+					s.MinPrice, _ = strconv.ParseFloat(flt.MinPrice, 64)
+					s.MaxPrice, _ = strconv.ParseFloat(flt.MaxPrice, 64)
+					s.TickSize, _ = strconv.ParseFloat(flt.TickSize, 64)
+					if s.TickSize > 0 {
+						s.PricePrecision = countDecimals(flt.TickSize)
+					}
+				case "LOT_SIZE":
+					s.MinQty, _ = strconv.ParseFloat(flt.MinQty, 64)
+					s.MaxQty, _ = strconv.ParseFloat(flt.MaxQty, 64)
+					s.StepSize, _ = strconv.ParseFloat(flt.StepSize, 64)
+					if s.StepSize > 0 {
+						s.QuantityPrecision = countDecimals(flt.StepSize)
+					}
+				case "MIN_NOTIONAL":
+					// Futures report the minimum order value as "notional"
+					s.MinNotional, _ = strconv.ParseFloat(flt.Notional, 64)
+				}
+			}
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("futures %w: %s", ErrSymbolNotFound, symbol)
+}
+
+// GetTickerPrice returns the last traded price
+func (f *FuturesClient) GetTickerPrice(symbol string) (float64, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var result TickerPrice
+	if err := f.call(http.MethodGet, EndpointFuturesTickerPrice, params, false, &result); err != nil {
+		return 0, err
+	}
+	price, err := strconv.ParseFloat(result.Price, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", result.Price, err)
+	}
+	return price, nil
+}
+
+// GetPremiumIndex returns the mark price and current funding rate
+func (f *FuturesClient) GetPremiumIndex(symbol string) (*PremiumIndex, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var result PremiumIndex
+	if err := f.call(http.MethodGet, EndpointFuturesPremiumIndex, params, false, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAccount returns futures account balances and margin (requires signature)
+func (f *FuturesClient) GetAccount() (*FuturesAccount, error) {
+	var result FuturesAccount
+	if err := f.call(http.MethodGet, EndpointFuturesAccount, nil, true, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPositionRisk returns futures positions, optionally for one symbol
+func (f *FuturesClient) GetPositionRisk(symbol string) ([]FuturesPositionRisk, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+
+	var result []FuturesPositionRisk
+	if err := f.call(http.MethodGet, EndpointFuturesPositionRisk, params, true, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ChangeLeverage sets the initial leverage for a symbol
+func (f *FuturesClient) ChangeLeverage(symbol string, leverage int) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("leverage", strconv.Itoa(leverage))
+
+	if err := f.call(http.MethodPost, EndpointFuturesLeverage, params, true, nil); err != nil {
+		return err
+	}
+
+	log.Info().Str("symbol", symbol).Int("leverage", leverage).Msg("Futures leverage changed")
+	return nil
+}
+
+// ChangeMarginType switches a symbol between isolated and cross margin. It
+// succeeds when the symbol already uses the requested type.
+func (f *FuturesClient) ChangeMarginType(symbol string, marginType MarginType) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("marginType", string(marginType))
+
+	err := f.call(http.MethodPost, EndpointFuturesMarginType, params, true, nil)
+	if isAPIErrorCode(err, ErrCodeNoNeedToChangeMarginType) {
+		return nil
+	}
+	return err
+}
+
+// GetPositionMode reports whether the account uses hedge mode (separate long
+// and short positions per symbol)
+func (f *FuturesClient) GetPositionMode() (bool, error) {
+	var result struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}
+	if err := f.call(http.MethodGet, EndpointFuturesPositionMode, nil, true, &result); err != nil {
+		return false, err
+	}
+	return result.DualSidePosition, nil
+}
+
+// ChangePositionMode switches the account between one-way and hedge mode.
+// It succeeds when the account already uses the requested mode.
+func (f *FuturesClient) ChangePositionMode(hedge bool) error {
+	params := url.Values{}
+	params.Set("dualSidePosition", strconv.FormatBool(hedge))
+
+	err := f.call(http.MethodPost, EndpointFuturesPositionMode, params, true, nil)
+	if isAPIErrorCode(err, ErrCodeNoNeedToChangePositionMode) {
+		return nil
+	}
+	return err
+}
+
+// PlaceOrder creates a futures order. The response carries the average fill
+// price of orders that fill immediately.
+func (f *FuturesClient) PlaceOrder(req *FuturesOrderRequest) (*FuturesOrder, error) {
+	params := url.Values{}
+	params.Set("symbol", req.Symbol)
+	params.Set("side", string(req.Side))
+	params.Set("type", string(req.Type))
+	params.Set("newOrderRespType", "RESULT")
+
+	if req.PositionSide != "" {
+		params.Set("positionSide", string(req.PositionSide))
+	}
+	if req.Quantity > 0 {
+		params.Set("quantity", strconv.FormatFloat(req.Quantity, 'f', -1, 64))
+	}
+	if req.Price > 0 {
+		params.Set("price", strconv.FormatFloat(req.Price, 'f', -1, 64))
+	}
+	if req.StopPrice > 0 {
+		params.Set("stopPrice", strconv.FormatFloat(req.StopPrice, 'f', -1, 64))
+	}
+	if req.TimeInForce != "" {
+		params.Set("timeInForce", string(req.TimeInForce))
+	}
+	// Hedge mode rejects reduceOnly; the position side already says which
+	// position the order reduces
+	if req.ReduceOnly && (req.PositionSide == "" || req.PositionSide == PositionSideBoth) {
+		params.Set("reduceOnly", "true")
+	}
+	if req.NewClientOrderID != "" {
+		params.Set("newClientOrderId", req.NewClientOrderID)
+	}
+
+	var result FuturesOrder
+	if err := f.call(http.MethodPost, EndpointFuturesOrder, params, true, &result); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("symbol", req.Symbol).
+		Str("side", string(req.Side)).
+		Str("positionSide", string(req.PositionSide)).
+		Str("type", string(req.Type)).
+		Float64("quantity", req.Quantity).
+		Int64("orderID", result.OrderID).
+		Msg("Futures order created")
+
+	return &result, nil
+}
+
+// CancelOrder cancels a futures order
+func (f *FuturesClient) CancelOrder(symbol string, orderID int64) (*FuturesOrder, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", strconv.FormatInt(orderID, 10))
+
+	var result FuturesOrder
+	if err := f.call(http.MethodDelete, EndpointFuturesOrder, params, true, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetOrderByClientID gets a futures order by the client order ID it was
+// placed with
+func (f *FuturesClient) GetOrderByClientID(symbol, clientOrderID string) (*FuturesOrder, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+
+	var result FuturesOrder
+	if err := f.call(http.MethodGet, EndpointFuturesOrder, params, true, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetOpenOrders returns open futures orders for a symbol
+func (f *FuturesClient) GetOpenOrders(symbol string) ([]FuturesOrder, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+
+	var result []FuturesOrder
+	if err := f.call(http.MethodGet, EndpointFuturesOpenOrders, params, true, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
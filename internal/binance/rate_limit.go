@@ -0,0 +1,255 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Request weight allowed per minute and IP
+const (
+	SpotWeightLimit    = 6000
+	FuturesWeightLimit = 2400
+)
+
+// maxRateLimitWait bounds how long a request queues for weight or sits out
+// a Retry-After pause; longer waits fail with a rate limit error instead
+const maxRateLimitWait = 2 * time.Minute
+
+// WeightStats reports a client's request weight usage
+type WeightStats struct {
+	Limit       int           `json:"limit"`     // Weight allowed per minute
+	Used        int           `json:"used"`      // Weight used this minute, as last reported by Binance
+	Available   float64       `json:"available"` // Weight the limiter would spend right now
+	Waits       int64         `json:"waits"`     // Requests delayed for weight or a pause
+	WaitTime    time.Duration `json:"waitTime"`
+	Throttled   int64         `json:"throttled"` // 429 and 418 responses received
+	PausedUntil time.Time     `json:"pausedUntil,omitempty"`
+}
+
+// rateLimiter is a token bucket of request weight refilled continuously at
+// the per-minute limit. Binance's reported usage caps the bucket, so weight
+// spent by other clients on the same IP is accounted for too.
+type rateLimiter struct {
+	mu          sync.Mutex
+	limit       float64
+	tokens      float64 // May go negative while requests are queued
+	last        time.Time
+	used        int
+	pausedUntil time.Time
+
+	waits     int64
+	waitTime  time.Duration
+	throttled int64
+}
+
+// newRateLimiter creates a limiter for limit weight per minute
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{
+		limit:  float64(limit),
+		tokens: float64(limit),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the weight regained since the last call (l.mu must be held)
+func (l *rateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Minutes() * l.limit
+	if l.tokens > l.limit {
+		l.tokens = l.limit
+	}
+	l.last = now
+}
+
+// reserve takes weight from the bucket and returns how long the caller must
+// wait before sending. Nothing is taken when the wait would be too long.
+func (l *rateLimiter) reserve(weight int) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	var wait time.Duration
+	if l.pausedUntil.After(now) {
+		wait = l.pausedUntil.Sub(now)
+	}
+	if short := float64(weight) - l.tokens; short > 0 {
+		if d := time.Duration(short / l.limit * float64(time.Minute)); d > wait {
+			wait = d
+		}
+	}
+	if wait > maxRateLimitWait {
+		return 0, &APIError{
+			Code:    ErrCodeTooManyRequests,
+			Message: fmt.Sprintf("request weight exhausted or paused by Binance, retry in %s", wait.Round(time.Second)),
+		}
+	}
+
+	l.tokens -= float64(weight)
+	if wait > 0 {
+		l.waits++
+		l.waitTime += wait
+	}
+	return wait, nil
+}
+
+// wait blocks until weight can be spent
+func (l *rateLimiter) wait(weight int) error {
+	d, err := l.reserve(weight)
+	if err != nil {
+		return err
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+	return nil
+}
+
+// observe syncs the bucket with the usage Binance reports and pauses all
+// requests after a 429 or 418. It returns the pause, if any.
+func (l *rateLimiter) observe(resp *http.Response) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	used := resp.Header.Get("X-MBX-USED-WEIGHT-1M")
+	if used == "" {
+		used = resp.Header.Get("X-MBX-USED-WEIGHT")
+	}
+	if n, err := strconv.Atoi(used); err == nil {
+		l.used = n
+		if remaining := l.limit - float64(n); l.tokens > remaining {
+			l.tokens = remaining
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusTeapot {
+		return 0
+	}
+
+	l.throttled++
+	l.tokens = 0
+
+	// Without Retry-After, wait for the next minute window
+	pause := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		pause = time.Duration(secs) * time.Second
+	}
+	if until := now.Add(pause); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	return l.pausedUntil.Sub(now)
+}
+
+// pauseUntil holds all requests until t
+func (l *rateLimiter) pauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// stats returns the limiter's usage
+func (l *rateLimiter) stats() WeightStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	s := WeightStats{
+		Limit:     int(l.limit),
+		Used:      l.used,
+		Available: l.tokens,
+		Waits:     l.waits,
+		WaitTime:  l.waitTime,
+		Throttled: l.throttled,
+	}
+	if s.Available < 0 {
+		s.Available = 0
+	}
+	if l.pausedUntil.After(now) {
+		s.PausedUntil = l.pausedUntil
+	}
+	return s
+}
+
+// requestWeight returns the weight Binance charges for a request
+func requestWeight(method, endpoint string, params url.Values) int {
+	hasSymbol := params.Get("symbol") != ""
+
+	switch endpoint {
+	case EndpointExchangeInfo, EndpointAccount, EndpointMyTrades, EndpointAllOrders:
+		return 20
+	case EndpointTrades:
+		return 25
+	case EndpointKlines:
+		return 2
+	case EndpointDepth:
+		limit, _ := strconv.Atoi(params.Get("limit"))
+		switch {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		default:
+			return 250
+		}
+	case EndpointTicker24hr:
+		if hasSymbol {
+			return 2
+		}
+		return 80
+	case EndpointTickerPrice, EndpointBookTicker:
+		if hasSymbol {
+			return 2
+		}
+		return 4
+	case EndpointUserDataStream:
+		return 2
+	case EndpointOrder:
+		if method == http.MethodGet {
+			return 4
+		}
+		return 1
+	case EndpointOpenOrders:
+		if hasSymbol {
+			return 6
+		}
+		return 80
+
+	case EndpointFuturesAccount, EndpointFuturesPositionRisk:
+		return 5
+	case EndpointFuturesTickerPrice:
+		if hasSymbol {
+			return 1
+		}
+		return 2
+	case EndpointFuturesPremiumIndex:
+		if hasSymbol {
+			return 1
+		}
+		return 10
+	case EndpointFuturesOpenOrders:
+		if hasSymbol {
+			return 1
+		}
+		return 40
+	case EndpointFuturesPositionMode:
+		if method == http.MethodGet {
+			return 30
+		}
+		return 1
+	}
+	return 1
+}
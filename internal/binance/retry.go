@@ -0,0 +1,166 @@
+package binance
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Binance error codes that are safe to retry
+const (
+	ErrCodeDisconnected     = -1001 // Internal error; unable to process the request
+	ErrCodeTimeout          = -1007 // Backend timeout; execution status unknown
+	ErrCodeTooManyOrders    = -1015 // Order rate limit exceeded
+	ErrCodeInvalidTimestamp = -1021 // Timestamp outside recvWindow
+)
+
+// Retry policy defaults
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 10 * time.Second
+)
+
+// RetryPolicy controls how failed REST requests are retried. Zero fields
+// use the defaults.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; negative disables
+	BaseDelay  time.Duration // Backoff before the first retry, doubled after each
+	MaxDelay   time.Duration // Backoff cap
+}
+
+// withDefaults fills unset fields with the defaults
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries == 0 {
+		p.MaxRetries = DefaultMaxRetries
+	}
+	if p.MaxRetries < 0 {
+		p.MaxRetries = 0
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryMaxDelay
+	}
+	return p
+}
+
+// backoff returns the delay before retry n (0-based): exponential with
+// jitter over its upper half, so clients failing together spread out
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseDelay << uint(n)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// ErrorClass groups request errors by how a caller should react
+type ErrorClass int
+
+const (
+	ErrorClassNone        ErrorClass = iota // No error
+	ErrorClassFatal                         // Rejected; the same request fails again
+	ErrorClassTransient                     // Network failure or server error; may not have executed
+	ErrorClassTimestamp                     // Clock out of sync with the server
+	ErrorClassRateLimited                   // Request weight or order rate exceeded
+	ErrorClassBanned                        // IP banned for exceeding rate limits
+)
+
+// String returns the class name
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassTransient:
+		return "transient"
+	case ErrorClassTimestamp:
+		return "timestamp"
+	case ErrorClassRateLimited:
+		return "rate_limited"
+	case ErrorClassBanned:
+		return "banned"
+	default:
+		return "fatal"
+	}
+}
+
+// ClassifyError reports how an error returned by the client should be
+// handled
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTeapot:
+			return ErrorClassBanned
+		case apiErr.Code == ErrCodeTooManyRequests && banUntilPattern.MatchString(apiErr.Message):
+			return ErrorClassBanned
+		case apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.Code == ErrCodeTooManyRequests,
+			apiErr.Code == ErrCodeTooManyOrders:
+			return ErrorClassRateLimited
+		case apiErr.Code == ErrCodeInvalidTimestamp:
+			return ErrorClassTimestamp
+		case apiErr.StatusCode >= 500,
+			apiErr.Code == ErrCodeDisconnected,
+			apiErr.Code == ErrCodeTimeout:
+			return ErrorClassTransient
+		}
+		return ErrorClassFatal
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return ErrorClassTransient
+	}
+	return ErrorClassFatal
+}
+
+// IsRetryable reports whether a request that failed with err may succeed
+// if sent again
+func IsRetryable(err error) bool {
+	switch ClassifyError(err) {
+	case ErrorClassTransient, ErrorClassTimestamp, ErrorClassRateLimited:
+		return true
+	}
+	return false
+}
+
+// IsBanned reports whether err is a Binance IP ban
+func IsBanned(err error) bool {
+	return ClassifyError(err) == ErrorClassBanned
+}
+
+// banUntilPattern matches the ban expiry Binance puts in -1003 messages,
+// e.g. "Way too many requests; IP banned until 1700000000000."
+var banUntilPattern = regexp.MustCompile(`banned until (\d+)`)
+
+// banExpiry returns when the ban described by err ends, if it says
+func banExpiry(err *APIError) (time.Time, bool) {
+	m := banUntilPattern.FindStringSubmatch(err.Message)
+	if m == nil {
+		return time.Time{}, false
+	}
+	ms, perr := strconv.ParseInt(m[1], 10, 64)
+	if perr != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// idempotent reports whether a request can be sent twice without side
+// effects, so a response lost to a transient failure can be retried
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut
+}
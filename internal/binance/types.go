@@ -1,6 +1,10 @@
 package binance
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -26,10 +30,12 @@ const (
 	EndpointKlines       = "/api/v3/klines"
 	EndpointTicker24hr   = "/api/v3/ticker/24hr"
 	EndpointTickerPrice  = "/api/v3/ticker/price"
+	EndpointBookTicker   = "/api/v3/ticker/bookTicker"
 
 	// Account
 	EndpointAccount      = "/api/v3/account"
 	EndpointMyTrades     = "/api/v3/myTrades"
+	EndpointDustTransfer = "/sapi/v1/asset/dust"
 
 	// Orders
 	EndpointOrder        = "/api/v3/order"
@@ -81,6 +87,7 @@ const (
 	TimeInForceGTC TimeInForce = "GTC" // Good Till Cancel
 	TimeInForceIOC TimeInForce = "IOC" // Immediate or Cancel
 	TimeInForceFOK TimeInForce = "FOK" // Fill or Kill
+	TimeInForceGTX TimeInForce = "GTX" // Good Till Crossing (futures post-only)
 )
 
 // Kline intervals
@@ -147,6 +154,7 @@ type FilterInfo struct {
 	MaxQty      string `json:"maxQty,omitempty"`
 	StepSize    string `json:"stepSize,omitempty"`
 	MinNotional string `json:"minNotional,omitempty"`
+	Notional    string `json:"notional,omitempty"` // Futures MIN_NOTIONAL
 }
 
 // Kline represents candlestick data
@@ -223,6 +231,15 @@ type TickerPrice struct {
 	Price  string `json:"price"`
 }
 
+// BookTicker represents the best bid and ask on the order book
+type BookTicker struct {
+	Symbol   string `json:"symbol"`
+	BidPrice string `json:"bidPrice"`
+	BidQty   string `json:"bidQty"`
+	AskPrice string `json:"askPrice"`
+	AskQty   string `json:"askQty"`
+}
+
 // Depth represents order book depth
 type Depth struct {
 	LastUpdateID int64      `json:"lastUpdateId"`
@@ -252,6 +269,27 @@ type Balance struct {
 	LockedStr string `json:"locked"`
 }
 
+// DustTransferResponse represents the result of converting small balances to BNB
+type DustTransferResponse struct {
+	TotalServiceChargeStr string               `json:"totalServiceCharge"`
+	TotalTransferedStr    string               `json:"totalTransfered"`
+	TransferResult        []DustTransferResult `json:"transferResult"`
+
+	// Parsed values (populated by DustTransfer)
+	TotalServiceCharge float64 `json:"-"`
+	TotalTransfered    float64 `json:"-"`
+}
+
+// DustTransferResult represents the conversion of one asset
+type DustTransferResult struct {
+	FromAsset           string `json:"fromAsset"`
+	Amount              string `json:"amount"`
+	TransferedAmount    string `json:"transferedAmount"`
+	ServiceChargeAmount string `json:"serviceChargeAmount"`
+	OperateTime         int64  `json:"operateTime"`
+	TranID              int64  `json:"tranId"`
+}
+
 // SimpleTicker represents simple ticker with last price
 type SimpleTicker struct {
 	Symbol    string  `json:"symbol"`
@@ -451,18 +489,59 @@ type OrderUpdateEvent struct {
 	CumQuoteQty        string      `json:"Z"`
 	LastQuoteQty       string      `json:"Y"`
 	QuoteOrderQty      string      `json:"Q"`
+	WorkingTime        int64       `json:"W"`
+
+	// Keys Binance marks as ignored; decoded so encoding/json's case-insensitive
+	// matching doesn't assign them to "i" and "m"
+	IgnoredI           int64       `json:"I"`
+	IgnoredM           bool        `json:"M"`
 }
 
 // APIError represents Binance API error
 type APIError struct {
-	Code    int    `json:"code"`
-	Message string `json:"msg"`
+	Code       int    `json:"code"`
+	Message    string `json:"msg"`
+	StatusCode int    `json:"-"` // HTTP status of the response
 }
 
 func (e *APIError) Error() string {
 	return e.Message
 }
 
+// ErrCodeTooManyRequests is returned when the request weight limit is exceeded
+const ErrCodeTooManyRequests = -1003
+
+// IsRateLimited reports whether err is a Binance rate limit rejection,
+// including an IP ban
+func IsRateLimited(err error) bool {
+	class := ClassifyError(err)
+	return class == ErrorClassRateLimited || class == ErrorClassBanned
+}
+
+// ErrCodeNoSuchOrder is returned when a queried order does not exist
+const ErrCodeNoSuchOrder = -2013
+
+// IsOrderNotFound reports whether err is a Binance unknown order rejection
+func IsOrderNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeNoSuchOrder
+}
+
+// ErrCodeOrderRejected is returned for new orders the matching engine
+// rejects, including post-only orders that would take liquidity
+const ErrCodeOrderRejected = -2010
+
+// IsPostOnlyRejection reports whether err is a Binance rejection of a
+// LIMIT_MAKER order that would immediately match
+func IsPostOnlyRejection(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeOrderRejected &&
+		strings.Contains(apiErr.Message, "immediately match")
+}
+
+// ErrSymbolNotFound is returned for symbols the exchange doesn't list
+var ErrSymbolNotFound = errors.New("symbol not found")
+
 // RateLimitInfo represents rate limit information
 type RateLimitInfo struct {
 	Type        string
@@ -506,6 +585,9 @@ func IntervalToMilliseconds(interval string) int64 {
 	case Interval1M:
 		return 30 * 24 * 60 * 60 * 1000
 	default:
+		if d, ok := parseCustomInterval(interval); ok {
+			return d.Milliseconds()
+		}
 		return 60 * 1000
 	}
 }
@@ -514,3 +596,68 @@ func IntervalToMilliseconds(interval string) int64 {
 func IntervalToDuration(interval string) time.Duration {
 	return time.Duration(IntervalToMilliseconds(interval)) * time.Millisecond
 }
+
+// nativeIntervals are the kline intervals Binance serves, shortest first
+var nativeIntervals = []string{
+	Interval1m, Interval3m, Interval5m, Interval15m, Interval30m,
+	Interval1h, Interval2h, Interval4h, Interval6h, Interval8h, Interval12h,
+	Interval1d, Interval3d, Interval1w, Interval1M,
+}
+
+// IsNativeInterval reports whether Binance serves klines of interval
+func IsNativeInterval(interval string) bool {
+	for _, i := range nativeIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseInterval parses a native or custom kline interval. Custom intervals
+// are a whole number of minutes, hours or days, e.g. "45m" or "10h".
+func ParseInterval(interval string) (time.Duration, error) {
+	if IsNativeInterval(interval) {
+		return IntervalToDuration(interval), nil
+	}
+	if d, ok := parseCustomInterval(interval); ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid interval %q", interval)
+}
+
+// parseCustomInterval parses "<n>m", "<n>h" or "<n>d"
+func parseCustomInterval(interval string) (time.Duration, bool) {
+	if len(interval) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	switch interval[len(interval)-1] {
+	case 'm':
+		return time.Duration(n) * time.Minute, true
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// BaseInterval returns the longest native interval of at most a day that
+// evenly divides d, to build candles of a custom interval from
+func BaseInterval(d time.Duration) string {
+	base := Interval1m
+	for _, i := range nativeIntervals {
+		id := IntervalToDuration(i)
+		if id > d || id > 24*time.Hour {
+			break
+		}
+		if d%id == 0 {
+			base = i
+		}
+	}
+	return base
+}
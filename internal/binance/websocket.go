@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -35,6 +37,13 @@ func (h *DefaultWSHandler) OnError(err error)                  {}
 func (h *DefaultWSHandler) OnDisconnect()                      {}
 func (h *DefaultWSHandler) OnReconnect()                       {}
 
+// UserDataWSHandler is implemented by handlers that also consume user data
+// stream events (order executions and account balance changes)
+type UserDataWSHandler interface {
+	OnOrderUpdate(event OrderUpdateEvent)
+	OnAccountUpdate(event AccountUpdateEvent)
+}
+
 // WSClient is the Binance WebSocket client
 type WSClient struct {
 	baseURL       string
@@ -56,6 +65,10 @@ type WSClient struct {
 	reconnectWait time.Duration
 	maxReconnects int
 	testnet       bool
+	compression   bool
+
+	// Traffic counters, kept across reconnects
+	stats         *wsStats
 }
 
 // WSClientOption configures the WebSocket client
@@ -92,6 +105,13 @@ func WithMaxReconnects(n int) WSClientOption {
 	}
 }
 
+// WithCompression requests permessage-deflate, which the server may decline
+func WithCompression(enabled bool) WSClientOption {
+	return func(c *WSClient) {
+		c.compression = enabled
+	}
+}
+
 // NewWSClient creates a new WebSocket client
 func NewWSClient(handler WSHandler, opts ...WSClientOption) *WSClient {
 	if handler == nil {
@@ -107,6 +127,7 @@ func NewWSClient(handler WSHandler, opts ...WSClientOption) *WSClient {
 		pongTimeout:   10 * time.Second,
 		reconnectWait: 5 * time.Second,
 		maxReconnects: 10,
+		stats:         newWSStats(),
 	}
 
 	for _, opt := range opts {
@@ -125,6 +146,10 @@ func (c *WSClient) Connect(ctx context.Context) error {
 		return nil
 	}
 
+	// Stop the ping loop of a connection that failed to reconnect
+	if c.cancel != nil {
+		c.cancel()
+	}
 	c.ctx, c.cancel = context.WithCancel(ctx)
 
 	if err := c.connect(); err != nil {
@@ -156,7 +181,15 @@ func (c *WSClient) connect() error {
 	log.Debug().Str("url", url).Msg("Connecting to Binance WebSocket")
 
 	dialer := websocket.Dialer{
-		HandshakeTimeout: 15 * time.Second,
+		HandshakeTimeout:  15 * time.Second,
+		EnableCompression: c.compression,
+		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &countingConn{Conn: conn, stats: c.stats}, nil
+		},
 	}
 
 	conn, resp, err := dialer.DialContext(c.ctx, url, nil)
@@ -167,6 +200,12 @@ func (c *WSClient) connect() error {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
 
+	negotiated := strings.Contains(resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+	if c.compression && !negotiated {
+		log.Debug().Msg("WebSocket server declined permessage-deflate")
+	}
+	c.stats.setCompression(negotiated)
+
 	c.conn = conn
 	return nil
 }
@@ -191,6 +230,37 @@ func (c *WSClient) Disconnect() {
 	log.Info().Msg("WebSocket disconnected")
 }
 
+// DropConnection closes the underlying connection without shutting the
+// client down, so it reconnects as it would after a network drop
+func (c *WSClient) DropConnection() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected.Load() || c.conn == nil {
+		return fmt.Errorf("websocket not connected")
+	}
+	log.Warn().Msg("Dropping WebSocket connection")
+	return c.conn.Close()
+}
+
+// streamNamePattern matches stream names such as ethusdt@kline_1h,
+// ethusdt@depth20@100ms and !miniTicker@arr
+var streamNamePattern = regexp.MustCompile(`^!?[A-Za-z0-9]+(@[A-Za-z0-9_]+)+$`)
+
+// NormalizeStreamName validates a stream name and lowercases its symbol,
+// which Binance requires
+func NormalizeStreamName(stream string) (string, error) {
+	stream = strings.TrimSpace(stream)
+	if !streamNamePattern.MatchString(stream) {
+		return "", fmt.Errorf("invalid stream name %q", stream)
+	}
+	if strings.HasPrefix(stream, "!") {
+		return stream, nil
+	}
+	symbol, rest, _ := strings.Cut(stream, "@")
+	return strings.ToLower(symbol) + "@" + rest, nil
+}
+
 // Subscribe adds subscriptions
 func (c *WSClient) Subscribe(streams ...string) error {
 	c.mu.Lock()
@@ -282,7 +352,15 @@ func (c *WSClient) sendJSON(v interface{}) error {
 		return fmt.Errorf("not connected")
 	}
 
-	return c.conn.WriteJSON(v)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return err
+	}
+	c.stats.sent(len(data))
+	return nil
 }
 
 // readLoop reads messages from WebSocket
@@ -312,6 +390,7 @@ func (c *WSClient) readLoop() {
 			return
 		}
 
+		c.stats.received(message)
 		c.handleMessage(message)
 	}
 }
@@ -389,6 +468,30 @@ func (c *WSClient) handleMessage(data []byte) {
 		}
 		c.handler.OnMiniTicker(event)
 
+	case "executionReport":
+		userData, ok := c.handler.(UserDataWSHandler)
+		if !ok {
+			return
+		}
+		var event OrderUpdateEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			c.handler.OnError(fmt.Errorf("failed to parse execution report: %w", err))
+			return
+		}
+		userData.OnOrderUpdate(event)
+
+	case "outboundAccountPosition":
+		userData, ok := c.handler.(UserDataWSHandler)
+		if !ok {
+			return
+		}
+		var event AccountUpdateEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			c.handler.OnError(fmt.Errorf("failed to parse account update: %w", err))
+			return
+		}
+		userData.OnAccountUpdate(event)
+
 	default:
 		log.Debug().Str("event", eventType).Msg("Unknown event type")
 	}
@@ -510,6 +613,18 @@ func (c *WSClient) IsConnected() bool {
 	return c.connected.Load()
 }
 
+// IsReconnecting reports whether a reconnection after a drop is in progress
+func (c *WSClient) IsReconnecting() bool {
+	return c.reconnecting.Load()
+}
+
+// Stats returns bandwidth and message rates since the client was created
+func (c *WSClient) Stats() WSStats {
+	stats := c.stats.snapshot()
+	stats.Connected = c.connected.Load()
+	return stats
+}
+
 // GetSubscriptions returns current subscriptions
 func (c *WSClient) GetSubscriptions() []string {
 	c.mu.RLock()
@@ -0,0 +1,213 @@
+package binance
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// wsRateWindow is the period message and byte rates are averaged over
+const wsRateWindow = time.Minute
+
+// StreamStats holds traffic counters for one stream
+type StreamStats struct {
+	Stream         string    `json:"stream"`
+	Messages       int64     `json:"messages"`
+	Bytes          int64     `json:"bytes"` // Payload bytes after decompression
+	MessagesPerSec float64   `json:"messagesPerSec"`
+	BytesPerSec    float64   `json:"bytesPerSec"`
+	LastMessage    time.Time `json:"lastMessage"`
+}
+
+// WSStats describes the bandwidth used by a WebSocket client
+type WSStats struct {
+	Connected   bool      `json:"connected"`
+	Compression bool      `json:"compression"` // permessage-deflate negotiated with the server
+	Since       time.Time `json:"since"`
+
+	// Bytes on the socket, including TLS and frame overhead
+	WireBytesIn  int64 `json:"wireBytesIn"`
+	WireBytesOut int64 `json:"wireBytesOut"`
+
+	// Message payloads; compared with wire bytes this shows the saving
+	PayloadBytesIn  int64   `json:"payloadBytesIn"`
+	PayloadBytesOut int64   `json:"payloadBytesOut"`
+	MessagesIn      int64   `json:"messagesIn"`
+	MessagesOut     int64   `json:"messagesOut"`
+	WireRatio       float64 `json:"wireRatio,omitempty"` // Wire bytes in / payload bytes in
+
+	Streams []StreamStats `json:"streams"`
+}
+
+// streamCounter accumulates one stream's traffic
+type streamCounter struct {
+	messages int64
+	bytes    int64
+	last     time.Time
+
+	windowStart    time.Time
+	windowMessages int64
+	windowBytes    int64
+	messageRate    float64 // Rates over the last complete window
+	byteRate       float64
+	rated          bool // A window has completed
+}
+
+// wsStats counts WebSocket traffic across reconnects
+type wsStats struct {
+	since   time.Time
+	wireIn  atomic.Int64
+	wireOut atomic.Int64
+
+	mu          sync.Mutex
+	compression bool
+	messagesOut int64
+	bytesOut    int64
+	streams     map[string]*streamCounter
+}
+
+func newWSStats() *wsStats {
+	return &wsStats{
+		since:   time.Now(),
+		streams: make(map[string]*streamCounter),
+	}
+}
+
+// received records an inbound message
+func (s *wsStats) received(message []byte) {
+	now := time.Now()
+	name := streamLabel(message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc, ok := s.streams[name]
+	if !ok {
+		sc = &streamCounter{windowStart: now}
+		s.streams[name] = sc
+	}
+	sc.roll(now)
+	sc.messages++
+	sc.bytes += int64(len(message))
+	sc.windowMessages++
+	sc.windowBytes += int64(len(message))
+	sc.last = now
+}
+
+// sent records an outbound message
+func (s *wsStats) sent(size int) {
+	s.mu.Lock()
+	s.messagesOut++
+	s.bytesOut += int64(size)
+	s.mu.Unlock()
+}
+
+// setCompression records whether the server accepted permessage-deflate
+func (s *wsStats) setCompression(negotiated bool) {
+	s.mu.Lock()
+	s.compression = negotiated
+	s.mu.Unlock()
+}
+
+// roll closes the rate window once it has run its length
+func (sc *streamCounter) roll(now time.Time) {
+	elapsed := now.Sub(sc.windowStart)
+	if elapsed < wsRateWindow {
+		return
+	}
+	if elapsed > 2*wsRateWindow {
+		// Idle for more than a window; nothing arrived in the last one
+		sc.messageRate, sc.byteRate = 0, 0
+	} else {
+		sc.messageRate = float64(sc.windowMessages) / elapsed.Seconds()
+		sc.byteRate = float64(sc.windowBytes) / elapsed.Seconds()
+	}
+	sc.rated = true
+	sc.windowStart = now
+	sc.windowMessages = 0
+	sc.windowBytes = 0
+}
+
+// snapshot returns the counters, streams sorted by name
+func (s *wsStats) snapshot() WSStats {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := WSStats{
+		Compression:     s.compression,
+		Since:           s.since,
+		WireBytesIn:     s.wireIn.Load(),
+		WireBytesOut:    s.wireOut.Load(),
+		PayloadBytesOut: s.bytesOut,
+		MessagesOut:     s.messagesOut,
+		Streams:         make([]StreamStats, 0, len(s.streams)),
+	}
+	for name, sc := range s.streams {
+		sc.roll(now)
+		stream := StreamStats{
+			Stream:         name,
+			Messages:       sc.messages,
+			Bytes:          sc.bytes,
+			MessagesPerSec: sc.messageRate,
+			BytesPerSec:    sc.byteRate,
+			LastMessage:    sc.last,
+		}
+		if elapsed := now.Sub(sc.windowStart).Seconds(); !sc.rated && elapsed > 0 {
+			// First window still open; rate what has arrived so far
+			stream.MessagesPerSec = float64(sc.windowMessages) / elapsed
+			stream.BytesPerSec = float64(sc.windowBytes) / elapsed
+		}
+		stats.PayloadBytesIn += sc.bytes
+		stats.MessagesIn += sc.messages
+		stats.Streams = append(stats.Streams, stream)
+	}
+	sort.Slice(stats.Streams, func(i, j int) bool {
+		return stats.Streams[i].Stream < stats.Streams[j].Stream
+	})
+	if stats.PayloadBytesIn > 0 {
+		stats.WireRatio = float64(stats.WireBytesIn) / float64(stats.PayloadBytesIn)
+	}
+	return stats
+}
+
+// streamLabel names the stream a message belongs to without decoding it.
+// Combined stream messages start with {"stream":"<name>" and raw stream
+// events with {"e":"<type>"; anything else is a control message.
+func streamLabel(message []byte) string {
+	for _, prefix := range [][]byte{[]byte(`{"stream":"`), []byte(`{"e":"`)} {
+		if !bytes.HasPrefix(message, prefix) {
+			continue
+		}
+		rest := message[len(prefix):]
+		if end := bytes.IndexByte(rest, '"'); end > 0 {
+			return string(rest[:end])
+		}
+	}
+	if len(message) > 0 && message[0] == '[' {
+		return "array"
+	}
+	return "control"
+}
+
+// countingConn counts the bytes crossing a network connection
+type countingConn struct {
+	net.Conn
+	stats *wsStats
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.stats.wireIn.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.stats.wireOut.Add(int64(n))
+	return n, err
+}
@@ -1,35 +1,175 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/eth-trading/internal/secrets"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Trading     TradingConfig     `yaml:"trading"`
-	Binance     BinanceConfig     `yaml:"binance"`
-	Risk        RiskConfig        `yaml:"risk"`
-	Indicators  IndicatorConfig   `yaml:"indicators"`
-	Strategies  StrategiesConfig  `yaml:"strategies"`
-	Database    DatabaseConfig    `yaml:"database"`
-	Postgres    PostgresConfig    `yaml:"postgres"`
-	Auth        AuthConfig        `yaml:"auth"`
-	DataService DataServiceConfig `yaml:"dataService"`
-	API         APIConfig         `yaml:"api"`
+	Trading        TradingConfig           `yaml:"trading"`
+	Binance        BinanceConfig           `yaml:"binance"`
+	Risk           RiskConfig              `yaml:"risk"`
+	Indicators     IndicatorConfig         `yaml:"indicators"`
+	Strategies     StrategiesConfig        `yaml:"strategies"`
+	Allocation     AllocationConfig        `yaml:"allocation"`
+	Schedule       ScheduleConfig          `yaml:"schedule"`
+	Database       DatabaseConfig          `yaml:"database"`
+	Postgres       PostgresConfig          `yaml:"postgres"`
+	Auth           AuthConfig              `yaml:"auth"`
+	DataService    DataServiceConfig       `yaml:"dataService"`
+	API            APIConfig               `yaml:"api"`
+	Heartbeat      HeartbeatConfig         `yaml:"heartbeat"`
+	CopyTrading    CopyTradingConfig       `yaml:"copyTrading"`
+	IndicatorStore IndicatorStoreConfig    `yaml:"indicatorStore"`
+	Scan           ScanConfig              `yaml:"scan"`
+	Backtest       BacktestConfig          `yaml:"backtest"`
+	Secrets        SecretsConfig           `yaml:"secrets"`
+	Symbols        map[string]SymbolConfig `yaml:"symbols"` // Per-symbol overrides, keyed by symbol
 }
 
 // TradingConfig represents trading configuration
 type TradingConfig struct {
-	Mode             string   `yaml:"mode"`             // "paper" or "live"
-	Symbol           string   `yaml:"symbol"`           // e.g., "ETHUSDT"
-	Timeframes       []string `yaml:"timeframes"`       // e.g., ["1m", "5m", "15m", "1h", "4h", "1d"]
-	PrimaryTimeframe string   `yaml:"primaryTimeframe"` // e.g., "1h"
-	InitialBalance   float64  `yaml:"initialBalance"`   // Paper trading initial balance
-	Commission       float64  `yaml:"commission"`       // Commission rate (0.001 = 0.1%)
-	Slippage         float64  `yaml:"slippage"`         // Slippage rate
+	Mode              string          `yaml:"mode"`              // "paper", "live" or "observer" (market data only, nothing trades)
+	Symbol            string          `yaml:"symbol"`            // e.g., "ETHUSDT"
+	Timeframes        []string        `yaml:"timeframes"`        // e.g., ["1m", "5m", "15m", "1h", "4h", "1d"]
+	PrimaryTimeframe  string          `yaml:"primaryTimeframe"`  // e.g., "1h"
+	CandleAggregation bool            `yaml:"candleAggregation"` // Build timeframes up to 1d from the 1m stream instead of subscribing to each
+	AccountID         string          `yaml:"accountId"`         // Auth trading account traded; its owner alone receives its WebSocket updates
+	InitialBalance    float64         `yaml:"initialBalance"`    // Paper trading initial balance
+	AllocatedCapital  float64         `yaml:"allocatedCapital"`  // Live share of the exchange account the bot trades with; 0 = whole account
+	Commission        float64         `yaml:"commission"`        // Commission rate (0.001 = 0.1%)
+	Slippage          float64         `yaml:"slippage"`          // Slippage rate
+	MakerCommission   float64         `yaml:"makerCommission"`   // Paper commission rate of resting limit fills; 0 = commission
+	ShadowPaper       bool            `yaml:"shadowPaper"`       // Mirror live orders on a paper account for reconciliation
+	PersistPaper      bool            `yaml:"persistPaper"`      // Save the paper account to SQLite and restore it on startup
+	PaperClock        string          `yaml:"paperClock"`        // "system" (wall clock) or "event" (market data time, sequential IDs)
+	Dust              DustConfig      `yaml:"dust"`
+	Arming            ArmingConfig    `yaml:"arming"`
+	Tape              TapeConfig      `yaml:"tape"`
+	PriceFeed         PriceFeedConfig `yaml:"priceFeed"`
+	OrderBook         OrderBookConfig `yaml:"orderBook"`
+	Entry             EntryConfig     `yaml:"entry"`
+	Chaos             ChaosConfig     `yaml:"chaos"`
+	Fees              FeeConfig       `yaml:"fees"`
+	Futures           FuturesConfig   `yaml:"futures"`
+	Inbox             InboxConfig     `yaml:"inbox"`
+	StopGuard         StopGuardConfig `yaml:"stopGuard"`
+	Latency           LatencyConfig   `yaml:"latency"`
+	Liquidity         LiquidityConfig `yaml:"liquidity"`
+}
+
+// LiquidityConfig represents the minimum market liquidity for new entries;
+// signals arriving in thinner markets are rejected
+type LiquidityConfig struct {
+	MinQuoteVolume24h   float64 `yaml:"minQuoteVolume24h"`   // Rolling 24h volume in the quote asset; 0 = unchecked
+	MinVolumePercentile float64 `yaml:"minVolumePercentile"` // Rank (0-100) of the last candle's volume among recent ones; 0 = unchecked
+	Lookback            int     `yaml:"lookback"`            // Primary candles the volume rank is taken over
+}
+
+// LatencyConfig represents the time budgets of the stages between a closed
+// candle and the order it leads to; a stage over budget logs a warning and
+// raises an alert
+type LatencyConfig struct {
+	CandleToAnalysis time.Duration `yaml:"candleToAnalysis"` // Closed candle received to analysis done; 0 = not enforced
+	AnalysisToRisk   time.Duration `yaml:"analysisToRisk"`   // Analysis done to risk assessment done; 0 = not enforced
+	RiskToOrder      time.Duration `yaml:"riskToOrder"`      // Risk assessment done to order acknowledged; 0 = not enforced
+	AlertCooldown    time.Duration `yaml:"alertCooldown"`    // Minimum time between alerts for one stage
+}
+
+// StopGuardConfig represents the check that every live position's stop loss
+// order is resting on the exchange
+type StopGuardConfig struct {
+	Interval   time.Duration `yaml:"interval"`   // Time between checks; 0 = disabled
+	AlertAfter int           `yaml:"alertAfter"` // Consecutive failed re-placements before alerting
+}
+
+// InboxConfig represents semi-automatic trading, where approved signals wait
+// for manual confirmation
+type InboxConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Expiry  time.Duration `yaml:"expiry"` // Unconfirmed ideas are discarded after this long
+}
+
+// FuturesConfig represents live trading on Binance USD-M perpetual futures
+// instead of the spot account
+type FuturesConfig struct {
+	Enabled        bool    `yaml:"enabled"`
+	Leverage       int     `yaml:"leverage"`       // Capped by risk.maxLeverage
+	MarginType     string  `yaml:"marginType"`     // "ISOLATED" or "CROSSED"
+	HedgeMode      bool    `yaml:"hedgeMode"`      // Separate long and short positions per symbol
+	MaxFundingRate float64 `yaml:"maxFundingRate"` // Skip entries paying more than this rate per funding; 0 = no limit
+}
+
+// FeeConfig represents the account's exchange fee schedule, used to price
+// backtest fills
+type FeeConfig struct {
+	Volume30d   float64         `yaml:"volume30d"`   // Account 30-day volume (USDT) selecting the tier
+	BNBDiscount bool            `yaml:"bnbDiscount"` // Commission paid in BNB at a discount
+	Tiers       []FeeTierConfig `yaml:"tiers"`       // Empty = flat trading.commission
+}
+
+// FeeTierConfig represents the maker/taker rates from a 30-day volume upward
+type FeeTierConfig struct {
+	Name      string  `yaml:"name"`
+	MinVolume float64 `yaml:"minVolume"`
+	MakerBps  float64 `yaml:"makerBps"`
+	TakerBps  float64 `yaml:"takerBps"`
+}
+
+// ChaosConfig represents simulated exchange outage drills in paper mode
+type ChaosConfig struct {
+	Enabled          bool          `yaml:"enabled"`          // Allow drills (paper mode only)
+	Interval         time.Duration `yaml:"interval"`         // Time between scheduled drills; 0 = on demand only
+	Faults           []string      `yaml:"faults"`           // Faults drawn by scheduled drills
+	FillDelay        time.Duration `yaml:"fillDelay"`        // Delay applied by delayed_fill
+	PartialFillRatio float64       `yaml:"partialFillRatio"` // Fraction filled by partial_fill
+}
+
+// PriceFeedConfig represents how the trade and kline streams are arbitrated
+// into the price the bot acts on
+type PriceFeedConfig struct {
+	TradeStaleAfter time.Duration `yaml:"tradeStaleAfter"` // Quiet trade stream after which kline closes set the price; 0 = 2s
+}
+
+// TapeConfig represents the order-flow tape built from the trade stream
+type TapeConfig struct {
+	Window          time.Duration `yaml:"window"`          // How long trades are kept
+	LargeTradeValue float64       `yaml:"largeTradeValue"` // Trades worth at least this (USDT) are highlighted
+}
+
+// OrderBookConfig represents the local order book kept from the depth
+// stream, which paper market orders fill against
+type OrderBookConfig struct {
+	Enabled bool          `yaml:"enabled"` // Subscribe to the diff depth stream; off = flat slippage
+	Depth   int           `yaml:"depth"`   // Levels per side requested in snapshots (max 5000)
+	MaxAge  time.Duration `yaml:"maxAge"`  // A book without updates this long falls back to flat slippage
+}
+
+// EntryConfig represents how signal orders are placed
+type EntryConfig struct {
+	MakerFirst bool          `yaml:"makerFirst"` // Rest a post-only limit at the best bid/ask before going at market
+	OffsetBps  float64       `yaml:"offsetBps"`  // Distance behind the best bid/ask; 0 joins it
+	Timeout    time.Duration `yaml:"timeout"`    // How long the limit rests before the rest is sent at market
+}
+
+// ArmingConfig represents the confirmation flow required to enter live mode
+type ArmingConfig struct {
+	Enabled   bool          `yaml:"enabled"`   // Live mode must be armed via the API (starts disarmed in paper)
+	Countdown time.Duration `yaml:"countdown"` // Cancel window between arming and going live
+}
+
+// DustConfig represents residual balance handling in live mode
+type DustConfig struct {
+	MinValue          float64       `yaml:"minValue"`          // Balances worth less (USDT) are dust; 0 = exchange min notional
+	ExcludeFromEquity bool          `yaml:"excludeFromEquity"` // Leave dust out of equity
+	AutoConvert       bool          `yaml:"autoConvert"`       // Periodically convert dust to BNB
+	ConvertInterval   time.Duration `yaml:"convertInterval"`   // Time between conversions
 }
 
 // BinanceConfig represents Binance API configuration
@@ -37,48 +177,190 @@ type BinanceConfig struct {
 	APIKey    string `yaml:"apiKey"`
 	SecretKey string `yaml:"secretKey"`
 	Testnet   bool   `yaml:"testnet"`
+
+	// Request permessage-deflate on market data streams to save bandwidth
+	WSCompression bool `yaml:"wsCompression"`
+
+	Retry BinanceRetryConfig `yaml:"retry"`
+}
+
+// BinanceRetryConfig represents how failed REST requests are retried
+type BinanceRetryConfig struct {
+	MaxRetries int           `yaml:"maxRetries"` // Retries after the first attempt; negative disables
+	BaseDelay  time.Duration `yaml:"baseDelay"`  // Backoff before the first retry, doubled after each
+	MaxDelay   time.Duration `yaml:"maxDelay"`   // Backoff cap
 }
 
 // RiskConfig represents risk management configuration
 type RiskConfig struct {
-	MaxPositionSize      float64 `yaml:"maxPositionSize"`      // Max position as % of equity (0.1 = 10%)
-	MaxRiskPerTrade      float64 `yaml:"maxRiskPerTrade"`      // Max risk per trade (0.02 = 2%)
-	MaxDailyLoss         float64 `yaml:"maxDailyLoss"`         // Max daily loss (0.05 = 5%)
-	MaxWeeklyLoss        float64 `yaml:"maxWeeklyLoss"`        // Max weekly loss (0.1 = 10%)
-	MaxDrawdown          float64 `yaml:"maxDrawdown"`          // Max total drawdown (0.2 = 20%)
-	MaxOpenPositions     int     `yaml:"maxOpenPositions"`     // Max concurrent positions
-	MaxLeverage          float64 `yaml:"maxLeverage"`          // Max leverage (1.0 = no leverage)
-	MinRiskRewardRatio   float64 `yaml:"minRiskRewardRatio"`   // Minimum R/R ratio
-	EnableCircuitBreaker bool    `yaml:"enableCircuitBreaker"` // Enable circuit breaker
-	ConsecutiveLossLimit int     `yaml:"consecutiveLossLimit"` // Halt after N losses
-	HaltDurationHours    int     `yaml:"haltDurationHours"`    // Circuit breaker halt duration
+	MaxPositionSize      float64        `yaml:"maxPositionSize"`      // Max position as % of equity (0.1 = 10%)
+	MaxRiskPerTrade      float64        `yaml:"maxRiskPerTrade"`      // Max risk per trade (0.02 = 2%)
+	MaxDailyLoss         float64        `yaml:"maxDailyLoss"`         // Max daily loss (0.05 = 5%)
+	MaxWeeklyLoss        float64        `yaml:"maxWeeklyLoss"`        // Max weekly loss (0.1 = 10%)
+	MaxDrawdown          float64        `yaml:"maxDrawdown"`          // Max total drawdown (0.2 = 20%)
+	HighWaterMarkMode    string         `yaml:"highWaterMarkMode"`    // Drawdown peak: "trailing", "monthly" or "deposit_adjusted"
+	PnLTimezone          string         `yaml:"pnlTimezone"`          // IANA zone daily and weekly P&L roll over in, e.g. "America/New_York"
+	WeekStart            string         `yaml:"weekStart"`            // Day weekly P&L rolls over on, e.g. "monday"
+	MaxOpenPositions     int            `yaml:"maxOpenPositions"`     // Max concurrent positions
+	MaxLeverage          float64        `yaml:"maxLeverage"`          // Max leverage (1.0 = no leverage)
+	MinRiskRewardRatio   float64        `yaml:"minRiskRewardRatio"`   // Minimum R/R ratio
+	EnableCircuitBreaker bool           `yaml:"enableCircuitBreaker"` // Enable circuit breaker
+	ConsecutiveLossLimit int            `yaml:"consecutiveLossLimit"` // Halt after N losses
+	HaltDurationHours    int            `yaml:"haltDurationHours"`    // Circuit breaker halt duration
+	RequireStopLoss      bool           `yaml:"requireStopLoss"`      // Reject entries without a stop loss
+	StopLoss             StopLossConfig `yaml:"stopLoss"`
+	Sizing               SizingConfig   `yaml:"sizing"`
+}
+
+// StopLossConfig represents how entry signals without a stop loss are handled
+type StopLossConfig struct {
+	Policy        string            `yaml:"policy"`        // "reject", "atr" (derive from ATR) or "skip"; empty follows requireStopLoss
+	ATRMultiplier float64           `yaml:"atrMultiplier"` // Derived stop distance in ATRs
+	Strategies    map[string]string `yaml:"strategies"`    // Per-strategy policy, e.g. breakout: atr
+}
+
+// SizingConfig represents how the size of an entry is computed
+type SizingConfig struct {
+	Model            string            `yaml:"model"`            // "fixed_fractional", "kelly", "volatility_target" or "fixed_notional"
+	KellyFraction    float64           `yaml:"kellyFraction"`    // Share of the full Kelly fraction (0.5 = half Kelly)
+	KellyWindow      int               `yaml:"kellyWindow"`      // Recent closed trades per strategy Kelly reads
+	KellyMinTrades   int               `yaml:"kellyMinTrades"`   // Trades needed before Kelly applies; fewer size fixed fractional
+	TargetVolatility float64           `yaml:"targetVolatility"` // Equity share a one-ATR move may cost (0.01 = 1%)
+	FixedNotional    float64           `yaml:"fixedNotional"`    // Position value in quote currency
+	Strategies       map[string]string `yaml:"strategies"`       // Per-strategy model, e.g. breakout: volatility_target
+	Compounding      string            `yaml:"compounding"`      // Capital sizing follows: "full" (equity), "fixed" (baseCapital) or "stepped"
+	CompoundingStep  float64           `yaml:"compoundingStep"`  // Stepped: equity gain that re-bases sizing (0.1 = every +10%)
+	BaseCapital      float64           `yaml:"baseCapital"`      // Fixed and stepped: capital sizing starts from; 0 = trading.initialBalance
 }
 
 // IndicatorConfig represents indicator configuration
 type IndicatorConfig struct {
-	RSIPeriod       int     `yaml:"rsiPeriod"`
-	RSIOversold     float64 `yaml:"rsiOversold"`
-	RSIOverbought   float64 `yaml:"rsiOverbought"`
-	MACDFast        int     `yaml:"macdFast"`
-	MACDSlow        int     `yaml:"macdSlow"`
-	MACDSignal      int     `yaml:"macdSignal"`
-	BBPeriod        int     `yaml:"bbPeriod"`
-	BBStdDev        float64 `yaml:"bbStdDev"`
-	ADXPeriod       int     `yaml:"adxPeriod"`
-	ADXThreshold    float64 `yaml:"adxThreshold"`
-	ATRPeriod       int     `yaml:"atrPeriod"`
-	ATRMultiplierSL float64 `yaml:"atrMultiplierSL"`
-	ATRMultiplierTP float64 `yaml:"atrMultiplierTP"`
+	RSIPeriod            int     `yaml:"rsiPeriod"`
+	RSIOversold          float64 `yaml:"rsiOversold"`
+	RSIOverbought        float64 `yaml:"rsiOverbought"`
+	MACDFast             int     `yaml:"macdFast"`
+	MACDSlow             int     `yaml:"macdSlow"`
+	MACDSignal           int     `yaml:"macdSignal"`
+	BBPeriod             int     `yaml:"bbPeriod"`
+	BBStdDev             float64 `yaml:"bbStdDev"`
+	ADXPeriod            int     `yaml:"adxPeriod"`
+	ADXThreshold         float64 `yaml:"adxThreshold"`
+	ATRPeriod            int     `yaml:"atrPeriod"`
+	ATRMultiplierSL      float64 `yaml:"atrMultiplierSL"`
+	ATRMultiplierTP      float64 `yaml:"atrMultiplierTP"`
+	KeltnerPeriod        int     `yaml:"keltnerPeriod"`
+	KeltnerMultiplier    float64 `yaml:"keltnerMultiplier"` // ATRs from the EMA to the channel's bands
+	SuperTrendPeriod     int     `yaml:"superTrendPeriod"`
+	SuperTrendMultiplier float64 `yaml:"superTrendMultiplier"` // ATRs from the bar's midpoint to the trailing band
+	IchimokuTenkan       int     `yaml:"ichimokuTenkan"`
+	IchimokuKijun        int     `yaml:"ichimokuKijun"` // Also the cloud's displacement
+	IchimokuSenkouB      int     `yaml:"ichimokuSenkouB"`
 }
 
 // StrategiesConfig represents strategies configuration
 type StrategiesConfig struct {
-	Enabled []string `yaml:"enabled"` // List of enabled strategy names
+	Enabled            []string                 `yaml:"enabled"`           // List of enabled strategy names
+	DisallowedRegimes  map[string][]string      `yaml:"disallowedRegimes"` // Regimes a strategy sits out, e.g. stat_arb: [TRENDING]
+	SignalCooldown     int                      `yaml:"signalCooldown"`    // Primary candles between entry signals of a strategy; 0 = unthrottled
+	SignalCooldowns    map[string]int           `yaml:"signalCooldowns"`   // Per-strategy exceptions, e.g. MeanReversion: 3
+	Scripts            StrategyScriptsConfig    `yaml:"scripts"`
+	Confluence         ConfluenceConfig         `yaml:"confluence"`
+	RegimeRouting      RegimeRoutingConfig      `yaml:"regimeRouting"`
+	VolatilityThrottle VolatilityThrottleConfig `yaml:"volatilityThrottle"`
+	EntryConfirmation  EntryConfirmationConfig  `yaml:"entryConfirmation"`
+	ParamDrift         ParamDriftConfig         `yaml:"paramDrift"`
+	MaxConcurrent      int                      `yaml:"maxConcurrent"` // Strategy evaluations running at once, live and backtests combined; 0 = number of CPUs
+}
+
+// StrategyScriptsConfig represents scripted strategy loading configuration
+type StrategyScriptsConfig struct {
+	Dir            string        `yaml:"dir"`            // Directory of *.lua strategies; empty = scripts off
+	ReloadInterval time.Duration `yaml:"reloadInterval"` // How often the directory is checked for changes
+}
+
+// ConfluenceConfig represents how the trend of higher monitored timeframes
+// acts on primary-timeframe entries
+type ConfluenceConfig struct {
+	Mode          string            `yaml:"mode"`          // "off", "filter" or "weight", for strategies without their own
+	Modes         map[string]string `yaml:"modes"`         // Per-strategy modes, e.g. MeanReversion: off
+	Timeframes    []string          `yaml:"timeframes"`    // Higher timeframes consulted; empty = all monitored ones
+	MinStrength   string            `yaml:"minStrength"`   // Weakest trend that counts: WEAK, MODERATE, STRONG or VERY_STRONG
+	Weight        float64           `yaml:"weight"`        // Weight mode: confidence change per unit of net agreement
+	MinConfidence float64           `yaml:"minConfidence"` // Weight mode: weighted confidence an entry needs
+}
+
+// RegimeRoutingConfig represents which strategies trade in each detected
+// market regime
+type RegimeRoutingConfig struct {
+	Enabled bool                `yaml:"enabled"`
+	Routes  map[string][]string `yaml:"routes"` // Strategies per regime, e.g. TRENDING: [TrendFollowing, Breakout]; empty = built-in routes
+}
+
+// VolatilityThrottleConfig represents how entries signaled during candles
+// with an outsized range are delayed or downsized
+type VolatilityThrottleConfig struct {
+	VolatilityThrottleRule `yaml:",inline"`
+	Strategies             map[string]VolatilityThrottleRule `yaml:"strategies"` // Per-strategy rules replacing the default, e.g. Breakout: {rangeATR: 0}
+}
+
+// VolatilityThrottleRule represents one strategy's volatility throttle
+type VolatilityThrottleRule struct {
+	RangeATR   float64 `yaml:"rangeATR"`   // Candle range in ATRs that counts as a spike; 0 = off
+	Action     string  `yaml:"action"`     // "delay" until the next candle or "downsize"
+	SizeFactor float64 `yaml:"sizeFactor"` // Downsize: fraction of the usual size traded
+	Confirm    bool    `yaml:"confirm"`    // Delay: enter only if the next candle closes beyond the spike in the signal's direction
+}
+
+// EntryConfirmationConfig represents how entry signals wait for a candle
+// of a shorter or the primary timeframe before executing
+type EntryConfirmationConfig struct {
+	Interval   string   `yaml:"interval"`   // Timeframe of the candle waited, e.g. "1m"; empty = off
+	Conditions []string `yaml:"conditions"` // All must still hold: "price", "rsi", "macd", "trend"; empty = price
+	Strategies []string `yaml:"strategies"` // Strategies whose entries wait; empty = all
+}
+
+// ParamDriftConfig represents how configured strategy parameters are
+// compared with the optimizer's best
+type ParamDriftConfig struct {
+	Tolerance          float64       `yaml:"tolerance"`          // Relative difference that counts as drift, e.g. 0.1 = 10%
+	ReoptimizeInterval time.Duration `yaml:"reoptimizeInterval"` // Re-run recorded searches on recent data; 0 = never
+	Lookback           time.Duration `yaml:"lookback"`           // Recent data re-optimization runs on
+}
+
+// AllocationConfig represents per-strategy capital allocation configuration
+type AllocationConfig struct {
+	Mode              string             `yaml:"mode"`              // "fixed", "performance" or "off"
+	Weights           map[string]float64 `yaml:"weights"`           // Strategy weights (equal split if empty)
+	MinWeight         float64            `yaml:"minWeight"`         // Floor per strategy in performance mode
+	MaxWeight         float64            `yaml:"maxWeight"`         // Cap per strategy in performance mode
+	RebalanceInterval time.Duration      `yaml:"rebalanceInterval"` // Periodic reallocation (e.g. 24h)
+}
+
+// ScheduleConfig represents scheduled trading mode transitions
+type ScheduleConfig struct {
+	Enabled      bool                   `yaml:"enabled"`
+	Timezone     string                 `yaml:"timezone"`     // IANA zone, e.g. "Europe/London"
+	DefaultMode  string                 `yaml:"defaultMode"`  // Mode outside all windows: "live", "paper" or "halted"
+	CarryPolicy  string                 `yaml:"carryPolicy"`  // "flatten" or "carry" open positions at a switch
+	NotifyBefore time.Duration          `yaml:"notifyBefore"` // Lead time for pre-switch notifications
+	Windows      []ScheduleWindowConfig `yaml:"windows"`
+}
+
+// ScheduleWindowConfig represents a recurring mode window
+type ScheduleWindowConfig struct {
+	Name        string   `yaml:"name"`
+	Days        []string `yaml:"days"`        // e.g. ["mon", "tue"]; empty = every day
+	Start       string   `yaml:"start"`       // "HH:MM" local time
+	End         string   `yaml:"end"`         // "HH:MM"; earlier than start wraps past midnight
+	Mode        string   `yaml:"mode"`        // "live", "paper" or "halted"
+	CarryPolicy string   `yaml:"carryPolicy"` // Optional override of the schedule policy
 }
 
 // DatabaseConfig represents database configuration (SQLite - deprecated, use Postgres)
 type DatabaseConfig struct {
-	Path string `yaml:"path"`
+	Path         string `yaml:"path"`
+	BackupDir    string `yaml:"backupDir"`    // Where POST /system/backup saves copies
+	TradingStore string `yaml:"tradingStore"` // Where candles, trades and positions are kept: "sqlite" or "postgres"
 }
 
 // PostgresConfig represents PostgreSQL configuration
@@ -94,11 +376,40 @@ type PostgresConfig struct {
 	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
 }
 
+// SecretsConfig represents how secret values in this file are resolved.
+// Any of them may be written as enc:<ciphertext> (sealed with the master
+// key, see `bot secret encrypt`), vault:<path>#<field> or
+// awssm:<secret-id>[#<key>] instead of in plaintext.
+type SecretsConfig struct {
+	MasterKeyEnv string             `yaml:"masterKeyEnv"` // Environment variable holding the base64 AES-256 master key
+	Timeout      time.Duration      `yaml:"timeout"`      // Bounds each Vault or AWS lookup
+	Vault        VaultSecretsConfig `yaml:"vault"`
+	AWS          AWSSecretsConfig   `yaml:"aws"`
+}
+
+// VaultSecretsConfig represents a HashiCorp Vault KV v2 store. The token is
+// read from the environment only.
+type VaultSecretsConfig struct {
+	Address   string `yaml:"address"`   // Empty = $VAULT_ADDR
+	Mount     string `yaml:"mount"`     // KV v2 mount
+	Namespace string `yaml:"namespace"` // Enterprise namespace; empty = $VAULT_NAMESPACE
+	TokenEnv  string `yaml:"tokenEnv"`  // Environment variable holding the token
+}
+
+// AWSSecretsConfig represents AWS Secrets Manager. Credentials come from the
+// standard AWS_* environment variables.
+type AWSSecretsConfig struct {
+	Region   string `yaml:"region"`   // Empty = $AWS_REGION
+	Endpoint string `yaml:"endpoint"` // Empty = the regional endpoint
+}
+
 // AuthConfig represents authentication configuration
 type AuthConfig struct {
 	JWTSecret          string        `yaml:"jwtSecret"`
 	TokenExpiry        time.Duration `yaml:"tokenExpiry"`
 	RefreshTokenExpiry time.Duration `yaml:"refreshTokenExpiry"`
+	KeyEncryptionKey   string        `yaml:"keyEncryptionKey"` // Base64 AES-256 key encrypting users' Binance and TOTP secrets; empty = not stored
+	RequireTwoFactor   bool          `yaml:"requireTwoFactor"` // Refuse sensitive operations to users without two-factor authentication
 }
 
 // DataServiceConfig represents data service configuration
@@ -109,19 +420,116 @@ type DataServiceConfig struct {
 
 // APIConfig represents API server configuration
 type APIConfig struct {
-	Port        string   `yaml:"port"`
-	CORSOrigins []string `yaml:"corsOrigins"`
+	Port        string        `yaml:"port"`
+	CORSOrigins []string      `yaml:"corsOrigins"`
+	CacheTTL    time.Duration `yaml:"cacheTTL"` // Hot endpoint response cache lifetime (negative disables)
+
+	// Offer permessage-deflate to dashboard WebSocket clients
+	WSCompression bool `yaml:"wsCompression"`
+
+	StatusPage StatusPageConfig `yaml:"statusPage"`
 }
 
-// Load loads configuration from a YAML file
-func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
+// StatusPageConfig represents the public read-only status page at /status,
+// served without authentication
+type StatusPageConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	RateLimit int  `yaml:"rateLimit"` // Requests per minute per client IP
+}
+
+// HeartbeatConfig represents pings to an external monitoring service
+// (healthchecks.io style), sent only while the pipeline is healthy
+type HeartbeatConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	URL        string        `yaml:"url"`        // Ping URL; the service alerts when pings stop
+	Interval   time.Duration `yaml:"interval"`   // Time between pings
+	MaxSyncAge time.Duration `yaml:"maxSyncAge"` // Withhold pings once the executor has not answered for this long
+}
+
+// CopyTradingConfig represents leader/follower copy trading: a leader pushes
+// its approved signals to follower bots, which trade them through their own
+// risk pipeline
+type CopyTradingConfig struct {
+	Publisher CopyPublisherConfig `yaml:"publisher"`
+	Follower  CopyFollowerConfig  `yaml:"follower"`
+}
+
+// CopyPublisherConfig represents pushing approved signals to followers
+type CopyPublisherConfig struct {
+	Enabled   bool                 `yaml:"enabled"`
+	LeaderID  string               `yaml:"leaderId"`  // Identifies this bot to followers
+	Secret    string               `yaml:"secret"`    // HMAC key signing each push; shared with followers
+	Timeout   time.Duration        `yaml:"timeout"`   // Bounds a single push
+	Followers []CopyEndpointConfig `yaml:"followers"` // More can be registered through the API
+}
+
+// CopyEndpointConfig represents a follower signals are pushed to
+type CopyEndpointConfig struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"` // The follower's POST /api/v1/copy/signals
+}
+
+// CopyFollowerConfig represents trading signals pushed by leaders
+type CopyFollowerConfig struct {
+	Enabled  bool              `yaml:"enabled"`
+	Leaders  map[string]string `yaml:"leaders"`  // Leader ID -> shared secret of the leaders trusted
+	MaxSkew  time.Duration     `yaml:"maxSkew"`  // Pushes signed further from our clock are refused
+	MaxDrift float64           `yaml:"maxDrift"` // Percent the leader's price may be from ours; 0 = unchecked
+}
+
+// BacktestConfig represents the worker pool running backtests submitted
+// through the API
+type BacktestConfig struct {
+	Workers   int `yaml:"workers"`   // Backtests run concurrently
+	QueueSize int `yaml:"queueSize"` // Backtests waiting beyond the running ones; more are refused
+}
+
+// ScanConfig represents the market scan ranking candidate symbols
+// (`bot scan` and GET /api/v1/scan)
+type ScanConfig struct {
+	QuoteAsset     string  `yaml:"quoteAsset"`     // Only pairs quoted in this asset
+	MinQuoteVolume float64 `yaml:"minQuoteVolume"` // Minimum 24h volume in the quote asset
+	MinATRPercent  float64 `yaml:"minATRPercent"`  // Volatility band on the scan timeframe
+	MaxATRPercent  float64 `yaml:"maxATRPercent"`  // 0 = no upper bound
+	Timeframe      string  `yaml:"timeframe"`      // Candles used for regime detection
+	Candles        int     `yaml:"candles"`        // Candles fetched per symbol
+	MaxCandidates  int     `yaml:"maxCandidates"`  // Most liquid pairs scored
+	Top            int     `yaml:"top"`            // Results returned; 0 = all
+}
+
+// IndicatorStoreConfig represents batch precomputation of indicator series
+// over stored candles for research and charting
+type IndicatorStoreConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	Interval   time.Duration `yaml:"interval"`   // Time between incremental runs
+	Timeframes []string      `yaml:"timeframes"` // Empty = trading.primaryTimeframe
+	Indicators []string      `yaml:"indicators"` // Series columns; empty = all
+}
+
+// Load loads configuration in layers: defaults, the YAML file at path
+// (none when empty), ETH_BOT_* environment variables (see EnvName) and
+// overrides, key=value assignments typically from -set flags. Errors in
+// the last two wrap ErrInvalidOverride.
+func Load(path string, overrides ...string) (*Config, error) {
+	var cfg Config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	if err := cfg.applyFlags(overrides); err != nil {
 		return nil, err
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := resolveSecrets(&cfg); err != nil {
 		return nil, err
 	}
 
@@ -131,6 +539,63 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// NewSecretResolver creates the resolver of secret references configured
+// in cfg
+func NewSecretResolver(cfg *Config) *secrets.Resolver {
+	return secrets.NewResolver(secrets.Options{
+		MasterKeyEnv: cfg.Secrets.MasterKeyEnv,
+		Timeout:      cfg.Secrets.Timeout,
+		Vault: secrets.VaultConfig{
+			Address:   cfg.Secrets.Vault.Address,
+			Mount:     cfg.Secrets.Vault.Mount,
+			Namespace: cfg.Secrets.Vault.Namespace,
+			TokenEnv:  cfg.Secrets.Vault.TokenEnv,
+		},
+		AWS: secrets.AWSConfig{
+			Region:   cfg.Secrets.AWS.Region,
+			Endpoint: cfg.Secrets.AWS.Endpoint,
+		},
+	})
+}
+
+// resolveSecrets replaces secret references in cfg with their values.
+// Errors wrap secrets.ErrResolve.
+func resolveSecrets(cfg *Config) error {
+	resolver := NewSecretResolver(cfg)
+	resolve := func(name string, value *string) error {
+		resolved, err := resolver.Resolve(*value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		*value = resolved
+		return nil
+	}
+
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"binance.apiKey", &cfg.Binance.APIKey},
+		{"binance.secretKey", &cfg.Binance.SecretKey},
+		{"postgres.password", &cfg.Postgres.Password},
+		{"auth.jwtSecret", &cfg.Auth.JWTSecret},
+		{"auth.keyEncryptionKey", &cfg.Auth.KeyEncryptionKey},
+		{"copyTrading.publisher.secret", &cfg.CopyTrading.Publisher.Secret},
+	}
+	for _, f := range fields {
+		if err := resolve(f.name, f.value); err != nil {
+			return err
+		}
+	}
+	for leader, secret := range cfg.CopyTrading.Follower.Leaders {
+		if err := resolve("copyTrading.follower.leaders."+leader, &secret); err != nil {
+			return err
+		}
+		cfg.CopyTrading.Follower.Leaders[leader] = secret
+	}
+	return nil
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	cfg := &Config{}
@@ -162,9 +627,69 @@ func applyDefaults(cfg *Config) {
 	if cfg.Trading.Slippage == 0 {
 		cfg.Trading.Slippage = 0.0005
 	}
+	if cfg.Trading.PaperClock == "" {
+		cfg.Trading.PaperClock = "system"
+	}
+	if cfg.Trading.Dust.ConvertInterval == 0 {
+		cfg.Trading.Dust.ConvertInterval = 24 * time.Hour
+	}
+	if cfg.Trading.Arming.Countdown == 0 {
+		cfg.Trading.Arming.Countdown = 30 * time.Second
+	}
+	if cfg.Trading.Tape.Window == 0 {
+		cfg.Trading.Tape.Window = 15 * time.Minute
+	}
+	if cfg.Trading.Tape.LargeTradeValue == 0 {
+		cfg.Trading.Tape.LargeTradeValue = 50000
+	}
+	if cfg.Trading.OrderBook.Depth == 0 {
+		cfg.Trading.OrderBook.Depth = 1000
+	}
+	if cfg.Trading.OrderBook.MaxAge == 0 {
+		cfg.Trading.OrderBook.MaxAge = 5 * time.Second
+	}
+	if cfg.Trading.Entry.Timeout == 0 {
+		cfg.Trading.Entry.Timeout = 30 * time.Second
+	}
+	if len(cfg.Trading.Chaos.Faults) == 0 {
+		cfg.Trading.Chaos.Faults = []string{"ws_drop", "rate_limit", "delayed_fill", "partial_fill"}
+	}
+	if cfg.Trading.Chaos.FillDelay == 0 {
+		cfg.Trading.Chaos.FillDelay = 5 * time.Second
+	}
+	if cfg.Trading.Chaos.PartialFillRatio == 0 {
+		cfg.Trading.Chaos.PartialFillRatio = 0.5
+	}
+	if cfg.Trading.Futures.Leverage == 0 {
+		cfg.Trading.Futures.Leverage = 1
+	}
+	if cfg.Trading.Futures.MarginType == "" {
+		cfg.Trading.Futures.MarginType = "ISOLATED"
+	}
+	if cfg.Trading.Inbox.Expiry == 0 {
+		cfg.Trading.Inbox.Expiry = 15 * time.Minute
+	}
+	if cfg.Trading.StopGuard.AlertAfter <= 0 {
+		cfg.Trading.StopGuard.AlertAfter = 3
+	}
+	if cfg.Trading.Liquidity.Lookback <= 0 {
+		cfg.Trading.Liquidity.Lookback = 168
+	}
+	if cfg.Trading.Latency.AlertCooldown == 0 {
+		cfg.Trading.Latency.AlertCooldown = 15 * time.Minute
+	}
 
 	// Binance defaults - use production for real live data
 	// Testnet is explicitly set only via config file
+	if cfg.Binance.Retry.MaxRetries == 0 {
+		cfg.Binance.Retry.MaxRetries = 3
+	}
+	if cfg.Binance.Retry.BaseDelay == 0 {
+		cfg.Binance.Retry.BaseDelay = 500 * time.Millisecond
+	}
+	if cfg.Binance.Retry.MaxDelay == 0 {
+		cfg.Binance.Retry.MaxDelay = 10 * time.Second
+	}
 
 	// Risk defaults
 	if cfg.Risk.MaxPositionSize == 0 {
@@ -182,12 +707,45 @@ func applyDefaults(cfg *Config) {
 	if cfg.Risk.MaxDrawdown == 0 {
 		cfg.Risk.MaxDrawdown = 0.20
 	}
+	if cfg.Risk.HighWaterMarkMode == "" {
+		cfg.Risk.HighWaterMarkMode = "trailing"
+	}
+	if cfg.Risk.PnLTimezone == "" {
+		cfg.Risk.PnLTimezone = "UTC"
+	}
+	if cfg.Risk.WeekStart == "" {
+		cfg.Risk.WeekStart = "monday"
+	}
 	if cfg.Risk.MaxOpenPositions == 0 {
 		cfg.Risk.MaxOpenPositions = 5
 	}
 	if cfg.Risk.MaxLeverage == 0 {
 		cfg.Risk.MaxLeverage = 1.0
 	}
+	if cfg.Risk.StopLoss.ATRMultiplier == 0 {
+		cfg.Risk.StopLoss.ATRMultiplier = 2.0
+	}
+	if cfg.Risk.Sizing.Model == "" {
+		cfg.Risk.Sizing.Model = "fixed_fractional"
+	}
+	if cfg.Risk.Sizing.KellyFraction == 0 {
+		cfg.Risk.Sizing.KellyFraction = 0.5
+	}
+	if cfg.Risk.Sizing.KellyWindow == 0 {
+		cfg.Risk.Sizing.KellyWindow = 50
+	}
+	if cfg.Risk.Sizing.KellyMinTrades == 0 {
+		cfg.Risk.Sizing.KellyMinTrades = 20
+	}
+	if cfg.Risk.Sizing.Compounding == "" {
+		cfg.Risk.Sizing.Compounding = "full"
+	}
+	if cfg.Risk.Sizing.CompoundingStep == 0 {
+		cfg.Risk.Sizing.CompoundingStep = 0.1
+	}
+	if cfg.Risk.Sizing.TargetVolatility == 0 {
+		cfg.Risk.Sizing.TargetVolatility = 0.01
+	}
 	if cfg.Risk.MinRiskRewardRatio == 0 {
 		cfg.Risk.MinRiskRewardRatio = 1.5
 	}
@@ -238,6 +796,27 @@ func applyDefaults(cfg *Config) {
 	if cfg.Indicators.ATRMultiplierTP == 0 {
 		cfg.Indicators.ATRMultiplierTP = 3.0
 	}
+	if cfg.Indicators.KeltnerPeriod == 0 {
+		cfg.Indicators.KeltnerPeriod = 20
+	}
+	if cfg.Indicators.KeltnerMultiplier == 0 {
+		cfg.Indicators.KeltnerMultiplier = 2.0
+	}
+	if cfg.Indicators.SuperTrendPeriod == 0 {
+		cfg.Indicators.SuperTrendPeriod = 10
+	}
+	if cfg.Indicators.SuperTrendMultiplier == 0 {
+		cfg.Indicators.SuperTrendMultiplier = 3.0
+	}
+	if cfg.Indicators.IchimokuTenkan == 0 {
+		cfg.Indicators.IchimokuTenkan = 9
+	}
+	if cfg.Indicators.IchimokuKijun == 0 {
+		cfg.Indicators.IchimokuKijun = 26
+	}
+	if cfg.Indicators.IchimokuSenkouB == 0 {
+		cfg.Indicators.IchimokuSenkouB = 52
+	}
 
 	// Strategies defaults
 	if len(cfg.Strategies.Enabled) == 0 {
@@ -249,11 +828,48 @@ func applyDefaults(cfg *Config) {
 			"StatArb",
 		}
 	}
+	if cfg.Strategies.Scripts.ReloadInterval == 0 {
+		cfg.Strategies.Scripts.ReloadInterval = 5 * time.Second
+	}
+
+	// Allocation defaults
+	if cfg.Allocation.Mode == "" {
+		cfg.Allocation.Mode = "fixed"
+	}
+	if cfg.Allocation.MinWeight == 0 {
+		cfg.Allocation.MinWeight = 0.05
+	}
+	if cfg.Allocation.MaxWeight == 0 {
+		cfg.Allocation.MaxWeight = 0.5
+	}
+	if cfg.Allocation.RebalanceInterval == 0 {
+		cfg.Allocation.RebalanceInterval = 24 * time.Hour
+	}
+
+	// Schedule defaults
+	if cfg.Schedule.Timezone == "" {
+		cfg.Schedule.Timezone = "UTC"
+	}
+	if cfg.Schedule.DefaultMode == "" {
+		cfg.Schedule.DefaultMode = cfg.Trading.Mode
+	}
+	if cfg.Schedule.CarryPolicy == "" {
+		cfg.Schedule.CarryPolicy = "flatten"
+	}
+	if cfg.Schedule.NotifyBefore == 0 {
+		cfg.Schedule.NotifyBefore = 15 * time.Minute
+	}
 
 	// Database defaults (SQLite - deprecated)
 	if cfg.Database.Path == "" {
 		cfg.Database.Path = "data/trading.db"
 	}
+	if cfg.Database.BackupDir == "" {
+		cfg.Database.BackupDir = "data/backups"
+	}
+	if cfg.Database.TradingStore == "" {
+		cfg.Database.TradingStore = "sqlite"
+	}
 
 	// PostgreSQL defaults
 	if cfg.Postgres.Host == "" {
@@ -310,6 +926,70 @@ func applyDefaults(cfg *Config) {
 	if len(cfg.API.CORSOrigins) == 0 {
 		cfg.API.CORSOrigins = []string{"*"}
 	}
+	if cfg.API.CacheTTL == 0 {
+		cfg.API.CacheTTL = 2 * time.Second
+	}
+	if cfg.API.StatusPage.RateLimit <= 0 {
+		cfg.API.StatusPage.RateLimit = 30
+	}
+
+	// Indicator store defaults
+	if cfg.IndicatorStore.Interval == 0 {
+		cfg.IndicatorStore.Interval = time.Hour
+	}
+	if len(cfg.IndicatorStore.Timeframes) == 0 {
+		cfg.IndicatorStore.Timeframes = []string{cfg.Trading.PrimaryTimeframe}
+	}
+
+	// Heartbeat defaults
+	if cfg.Heartbeat.Interval == 0 {
+		cfg.Heartbeat.Interval = time.Minute
+	}
+	if cfg.Heartbeat.MaxSyncAge == 0 {
+		cfg.Heartbeat.MaxSyncAge = 2 * time.Minute
+	}
+
+	// Copy trading defaults
+	if cfg.CopyTrading.Publisher.Timeout == 0 {
+		cfg.CopyTrading.Publisher.Timeout = 10 * time.Second
+	}
+	if cfg.CopyTrading.Follower.MaxSkew == 0 {
+		cfg.CopyTrading.Follower.MaxSkew = 5 * time.Minute
+	}
+
+	// Backtest worker defaults
+	if cfg.Backtest.Workers <= 0 {
+		cfg.Backtest.Workers = 2
+	}
+	if cfg.Backtest.QueueSize <= 0 {
+		cfg.Backtest.QueueSize = 16
+	}
+
+	// Symbol overrides are looked up by upper-case symbol
+	if len(cfg.Symbols) > 0 {
+		symbols := make(map[string]SymbolConfig, len(cfg.Symbols))
+		for symbol, override := range cfg.Symbols {
+			symbols[strings.ToUpper(symbol)] = override
+		}
+		cfg.Symbols = symbols
+	}
+
+	// Market scan defaults
+	if cfg.Scan.QuoteAsset == "" {
+		cfg.Scan.QuoteAsset = "USDT"
+	}
+	if cfg.Scan.MinQuoteVolume == 0 {
+		cfg.Scan.MinQuoteVolume = 20000000
+	}
+	if cfg.Scan.Timeframe == "" {
+		cfg.Scan.Timeframe = "1h"
+	}
+	if cfg.Scan.Candles == 0 {
+		cfg.Scan.Candles = 200
+	}
+	if cfg.Scan.MaxCandidates == 0 {
+		cfg.Scan.MaxCandidates = 40
+	}
 }
 
 // Save saves configuration to a YAML file
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// Sources of configuration changes
+const (
+	SourceFile = "file"
+	SourceAPI  = "api"
+)
+
+// reloadDebounce is how long the file must stay unchanged before it is
+// reloaded; editors often write a file in several steps
+const reloadDebounce = 500 * time.Millisecond
+
+// reloadedSections are applied to the running bot, except for the keys in
+// restartKeys. Changes to any other key take effect on restart.
+var reloadedSections = map[string]bool{
+	"risk":       true,
+	"indicators": true,
+	"strategies": true,
+	"symbols":    true,
+}
+
+var restartKeys = map[string]bool{
+	"risk.pnlTimezone":         true,
+	"risk.weekStart":           true,
+	"strategies.enabled":       true,
+	"strategies.scripts":       true,
+	"strategies.paramDrift":    true,
+	"strategies.maxConcurrent": true,
+}
+
+// ReloadStatus describes the configuration changes applied since start
+type ReloadStatus struct {
+	Path            string    `json:"path"`
+	Watching        bool      `json:"watching"`
+	Version         int       `json:"version"`              // Changes applied since start
+	LastSource      string    `json:"lastSource,omitempty"` // "file" or "api"
+	LastAppliedAt   time.Time `json:"lastAppliedAt,omitempty"`
+	LastError       string    `json:"lastError,omitempty"` // Of the last change rejected, which was rolled back
+	LastErrorAt     time.Time `json:"lastErrorAt,omitempty"`
+	RestartRequired []string  `json:"restartRequired"` // Keys changed since start that apply only on restart, e.g. "trading.symbol"
+}
+
+// applier pushes a configuration to a running component
+type applier struct {
+	name  string
+	apply func(cfg *Config) error
+}
+
+// Manager holds the running configuration. It reloads the file when it
+// changes and takes changes made through the API, validates them and
+// pushes them to the components registered with it. When a component
+// rejects a change, those already updated get the previous configuration
+// back and the change is dropped.
+type Manager struct {
+	path      string
+	overrides []string
+	prepare   func(cfg *Config)
+
+	mu       sync.Mutex
+	started  *Config // Effective configuration at start
+	base     *Config // Global settings, before the traded symbol's overrides
+	current  *Config // base with the traded symbol's overrides merged
+	appliers []applier
+	status   ReloadStatus
+}
+
+// NewManager creates a manager of cfg, loaded from path with overrides
+// (see Load), which reloads keep applying over the file. prepare, when
+// set, completes each configuration loaded from the file, cfg included,
+// e.g. with overrides stored elsewhere.
+func NewManager(path string, overrides []string, cfg *Config, prepare func(cfg *Config)) *Manager {
+	if prepare != nil {
+		prepare(cfg)
+	}
+	current := cfg.ForSymbol(cfg.Trading.Symbol)
+	return &Manager{
+		path:      path,
+		overrides: overrides,
+		prepare:   prepare,
+		started:   current,
+		base:      cfg,
+		current:   current,
+		status:    ReloadStatus{Path: path, RestartRequired: []string{}},
+	}
+}
+
+// Current returns the running configuration, with the traded symbol's
+// overrides merged. It must not be modified.
+func (m *Manager) Current() *Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// Register adds a component that takes configuration changes. apply is
+// handed each new configuration, and the previous one again when a
+// component registered later rejects the change; it must not keep cfg.
+func (m *Manager) Register(name string, apply func(cfg *Config) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.appliers = append(m.appliers, applier{name: name, apply: apply})
+}
+
+// Status returns the changes applied since start
+func (m *Manager) Status() ReloadStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := m.status
+	status.RestartRequired = append([]string{}, m.status.RestartRequired...)
+	return status
+}
+
+// Update applies a change made through the API. change edits a copy of
+// the global settings; it must replace rather than modify the maps and
+// slices it changes, which are shared with the running configuration.
+func (m *Manager) Update(change func(cfg *Config) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next := *m.base
+	if err := change(&next); err != nil {
+		return err
+	}
+	return m.applyLocked(&next, SourceAPI)
+}
+
+// Reload loads the file, with the environment and flag overrides, and
+// applies it. Changes made through the API to the same settings are
+// replaced by the file's.
+func (m *Manager) Reload() error {
+	cfg, err := Load(m.path, m.overrides...)
+	if err != nil {
+		m.mu.Lock()
+		m.failLocked(err)
+		m.mu.Unlock()
+		return err
+	}
+	if m.prepare != nil {
+		m.prepare(cfg)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.applyLocked(cfg, SourceFile)
+}
+
+// Watch reloads the file whenever it changes until ctx is done. The
+// directory is watched so that files replaced by editors are picked up.
+func (m *Manager) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	path, err := filepath.Abs(m.path)
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.status.Watching = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.status.Watching = false
+		m.mu.Unlock()
+	}()
+	log.Info().Str("path", m.path).Msg("Watching configuration file for changes")
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			debounce.Reset(reloadDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warn().Err(err).Str("path", m.path).Msg("Configuration file watcher error")
+
+		case <-debounce.C:
+			if err := m.Reload(); err != nil {
+				log.Error().Err(err).Str("path", m.path).Msg("Configuration reload rejected, keeping the running configuration")
+			}
+		}
+	}
+}
+
+// applyLocked validates base and pushes it to the registered components,
+// rolling back on the first that rejects it (mu must be held)
+func (m *Manager) applyLocked(base *Config, source string) error {
+	next := base.ForSymbol(base.Trading.Symbol)
+	if err := next.validateReloaded(); err != nil {
+		m.failLocked(err)
+		return err
+	}
+
+	for i, a := range m.appliers {
+		if err := a.apply(next); err != nil {
+			err = fmt.Errorf("%s: %w", a.name, err)
+			for _, done := range m.appliers[:i+1] {
+				if rollbackErr := done.apply(m.current); rollbackErr != nil {
+					log.Error().Err(rollbackErr).Str("component", done.name).Msg("Failed to roll back configuration")
+				}
+			}
+			m.failLocked(err)
+			return err
+		}
+	}
+
+	m.base = base
+	m.current = next
+	m.status.Version++
+	m.status.LastSource = source
+	m.status.LastAppliedAt = time.Now()
+	m.status.RestartRequired = changedKeys("", reflect.ValueOf(*m.started), reflect.ValueOf(*next))
+
+	event := log.Info().Str("source", source).Int("version", m.status.Version)
+	if len(m.status.RestartRequired) > 0 {
+		event = event.Strs("restartRequired", m.status.RestartRequired)
+	}
+	event.Msg("Configuration applied")
+	return nil
+}
+
+// failLocked records a rejected change (mu must be held)
+func (m *Manager) failLocked(err error) {
+	m.status.LastError = err.Error()
+	m.status.LastErrorAt = time.Now()
+}
+
+// validateReloaded checks the settings applied to the running bot are in
+// range
+func (c *Config) validateReloaded() error {
+	r := c.Risk
+	if r.MaxPositionSize <= 0 || r.MaxPositionSize > 1 {
+		return fmt.Errorf("risk.maxPositionSize must be between 0 and 1")
+	}
+	if r.MaxRiskPerTrade <= 0 || r.MaxRiskPerTrade > 0.1 {
+		return fmt.Errorf("risk.maxRiskPerTrade must be between 0 and 0.1")
+	}
+	if r.MaxDrawdown <= 0 || r.MaxDrawdown > 1 {
+		return fmt.Errorf("risk.maxDrawdown must be between 0 and 1")
+	}
+	if r.MaxOpenPositions < 0 || r.ConsecutiveLossLimit < 0 || r.HaltDurationHours < 0 {
+		return fmt.Errorf("risk limits must not be negative")
+	}
+
+	ind := c.Indicators
+	if ind.RSIPeriod < 2 || ind.RSIPeriod > 100 {
+		return fmt.Errorf("indicators.rsiPeriod must be between 2 and 100")
+	}
+	if ind.MACDFast >= ind.MACDSlow {
+		return fmt.Errorf("indicators.macdFast must be less than macdSlow")
+	}
+	if ind.MACDSignal < 1 || ind.BBPeriod < 2 || ind.ADXPeriod < 1 || ind.ATRPeriod < 1 {
+		return fmt.Errorf("indicator periods must be positive")
+	}
+
+	for symbol, override := range c.Symbols {
+		if err := override.Validate(); err != nil {
+			return fmt.Errorf("symbols.%s: %w", symbol, err)
+		}
+	}
+	return nil
+}
+
+// changedKeys lists the keys that differ between a and b and apply only on
+// restart, by their YAML paths
+func changedKeys(prefix string, a, b reflect.Value) []string {
+	keys := []string{}
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if tag[0] == "" && len(tag) > 1 && tag[1] == "inline" {
+			keys = append(keys, changedKeys(prefix, a.Field(i), b.Field(i))...)
+			continue
+		}
+		key := prefix + tag[0]
+		if reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == t.PkgPath() {
+			keys = append(keys, changedKeys(key+".", a.Field(i), b.Field(i))...)
+			continue
+		}
+		if restartRequired(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// restartRequired reports whether a change to key applies only on restart
+func restartRequired(key string) bool {
+	section, _, _ := strings.Cut(key, ".")
+	if !reloadedSections[section] {
+		return true
+	}
+	// Keys within a restart-only setting, e.g. strategies.scripts.dir
+	for k := key; ; {
+		if restartKeys[k] {
+			return true
+		}
+		i := strings.LastIndex(k, ".")
+		if i < 0 {
+			return false
+		}
+		k = k[:i]
+	}
+}
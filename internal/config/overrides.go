@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix starts the names of environment variables overriding settings
+const EnvPrefix = "ETH_BOT_"
+
+// ErrInvalidOverride is wrapped by errors in environment variable and flag
+// overrides
+var ErrInvalidOverride = errors.New("invalid configuration override")
+
+// EnvName returns the environment variable overriding key, a YAML path:
+// "binance.apiKey" is ETH_BOT_BINANCE_API_KEY
+func EnvName(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	for i, segment := range strings.Split(key, ".") {
+		if i > 0 {
+			b.WriteByte('_')
+		}
+		runes := []rune(segment)
+		for j, r := range runes {
+			// Word boundaries: "apiKey", "cacheTTL", "maxATRPercent"
+			if j > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[j-1]) ||
+				(j+1 < len(runes) && unicode.IsLower(runes[j+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// EnvKeys returns the keys that environment variables can override, by
+// variable name. Maps and lists are overridden whole.
+func EnvKeys() map[string]string {
+	keys := make(map[string]string)
+	collectKeys(reflect.TypeOf(Config{}), "", keys)
+	return keys
+}
+
+// collectKeys adds the leaf keys of t under prefix to keys
+func collectKeys(t reflect.Type, prefix string, keys map[string]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		if tag[0] == "" && len(tag) > 1 && tag[1] == "inline" {
+			collectKeys(field.Type, prefix, keys)
+			continue
+		}
+		key := prefix + tag[0]
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == t.PkgPath() {
+			collectKeys(field.Type, key+".", keys)
+			continue
+		}
+		keys[EnvName(key)] = key
+	}
+}
+
+// Set overrides the setting at key, a YAML path such as "trading.mode" or
+// "symbols.BTCUSDT.risk.maxLeverage". Strings are taken as is, lists of
+// strings may be comma-separated and other values are parsed as YAML.
+func (c *Config) Set(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	return setPath(reflect.ValueOf(c).Elem(), strings.Split(key, "."), value)
+}
+
+// setPath sets the value at path below v, which must be addressable
+func setPath(v reflect.Value, path []string, value string) error {
+	if len(path) == 0 {
+		return setValue(v, value)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, ok := fieldByYAML(v, path[0])
+		if !ok {
+			return fmt.Errorf("unknown key %q", path[0])
+		}
+		return setPath(field, path[1:], value)
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key at %q", path[0])
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		k := reflect.ValueOf(path[0]).Convert(v.Type().Key())
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(k); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := setPath(elem, path[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(k, elem)
+		return nil
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), path, value)
+	}
+	return fmt.Errorf("%q is not a section", path[0])
+}
+
+// fieldByYAML returns the field of struct v named name in YAML, looking
+// into inlined structs
+func fieldByYAML(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")
+		if tag[0] == "" && len(tag) > 1 && tag[1] == "inline" {
+			if field, ok := fieldByYAML(v.Field(i), name); ok {
+				return field, true
+			}
+			continue
+		}
+		if tag[0] == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// setValue parses value into v
+func setValue(v reflect.Value, value string) error {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+		return nil
+	case v.Kind() == reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "["):
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = reflect.Append(list, reflect.ValueOf(item).Convert(v.Type().Elem()))
+			}
+		}
+		v.Set(list)
+		return nil
+	}
+
+	// Replace rather than merge into maps
+	parsed := reflect.New(v.Type())
+	if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return err
+	}
+	v.Set(parsed.Elem())
+	return nil
+}
+
+// applyEnv overrides settings with the environment variables named for
+// them. Other variables with the prefix, such as the master key, are left
+// alone.
+func (c *Config) applyEnv(environ []string) error {
+	keys := EnvKeys()
+	names := make([]string, 0)
+	values := make(map[string]string)
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		if _, ok := keys[name]; ok {
+			names = append(names, name)
+			values[name] = value
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.Set(keys[name], values[name]); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidOverride, name, err)
+		}
+		log.Debug().Str("env", name).Str("key", keys[name]).Msg("Setting overridden by environment variable")
+	}
+	return nil
+}
+
+// applyFlags overrides settings with key=value assignments from the
+// command line
+func (c *Config) applyFlags(overrides []string) error {
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("%w: %q: expected key=value", ErrInvalidOverride, override)
+		}
+		if err := c.Set(strings.TrimSpace(key), value); err != nil {
+			return fmt.Errorf("%w: -set %s: %v", ErrInvalidOverride, key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SymbolConfig represents overrides for one symbol, merged over the global
+// settings. Unset fields keep the global value.
+type SymbolConfig struct {
+	Risk       SymbolRiskConfig     `yaml:"risk" json:"risk"`
+	Strategies SymbolStrategyConfig `yaml:"strategies" json:"strategies"`
+}
+
+// SymbolRiskConfig overrides risk and position sizing limits
+type SymbolRiskConfig struct {
+	MaxPositionSize       *float64 `yaml:"maxPositionSize,omitempty" json:"maxPositionSize,omitempty"`
+	MaxRiskPerTrade       *float64 `yaml:"maxRiskPerTrade,omitempty" json:"maxRiskPerTrade,omitempty"`
+	MaxLeverage           *float64 `yaml:"maxLeverage,omitempty" json:"maxLeverage,omitempty"`
+	MinRiskRewardRatio    *float64 `yaml:"minRiskRewardRatio,omitempty" json:"minRiskRewardRatio,omitempty"`
+	StopLossPolicy        *string  `yaml:"stopLossPolicy,omitempty" json:"stopLossPolicy,omitempty"`               // "reject", "atr" or "skip"
+	StopLossATRMultiplier *float64 `yaml:"stopLossATRMultiplier,omitempty" json:"stopLossATRMultiplier,omitempty"` // Derived stop distance in ATRs
+}
+
+// SymbolStrategyConfig overrides strategy selection and parameters
+type SymbolStrategyConfig struct {
+	Enabled           []string            `yaml:"enabled,omitempty" json:"enabled,omitempty"`                     // Replaces the global list
+	DisallowedRegimes map[string][]string `yaml:"disallowedRegimes,omitempty" json:"disallowedRegimes,omitempty"` // Replaces the entries of the strategies listed
+	SignalCooldown    *int                `yaml:"signalCooldown,omitempty" json:"signalCooldown,omitempty"`
+	SignalCooldowns   map[string]int      `yaml:"signalCooldowns,omitempty" json:"signalCooldowns,omitempty"` // Replaces the entries of the strategies listed
+}
+
+// Validate checks override values are in range
+func (s SymbolConfig) Validate() error {
+	r := s.Risk
+	if r.MaxPositionSize != nil && (*r.MaxPositionSize <= 0 || *r.MaxPositionSize > 1) {
+		return fmt.Errorf("maxPositionSize must be between 0 and 1")
+	}
+	if r.MaxRiskPerTrade != nil && (*r.MaxRiskPerTrade <= 0 || *r.MaxRiskPerTrade > 0.1) {
+		return fmt.Errorf("maxRiskPerTrade must be between 0 and 0.1")
+	}
+	if r.MaxLeverage != nil && *r.MaxLeverage < 1 {
+		return fmt.Errorf("maxLeverage must be at least 1")
+	}
+	if r.MinRiskRewardRatio != nil && *r.MinRiskRewardRatio < 0 {
+		return fmt.Errorf("minRiskRewardRatio must not be negative")
+	}
+	if r.StopLossPolicy != nil {
+		switch *r.StopLossPolicy {
+		case "reject", "atr", "skip":
+		default:
+			return fmt.Errorf("stopLossPolicy must be reject, atr or skip")
+		}
+	}
+	if r.StopLossATRMultiplier != nil && *r.StopLossATRMultiplier <= 0 {
+		return fmt.Errorf("stopLossATRMultiplier must be positive")
+	}
+
+	st := s.Strategies
+	if st.SignalCooldown != nil && *st.SignalCooldown < 0 {
+		return fmt.Errorf("signalCooldown must not be negative")
+	}
+	for name, candles := range st.SignalCooldowns {
+		if candles < 0 {
+			return fmt.Errorf("signalCooldowns.%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// ForSymbol returns a copy of the config with the overrides for symbol
+// merged over the global settings. The copy shares unmodified maps and
+// slices with c.
+func (c *Config) ForSymbol(symbol string) *Config {
+	merged := *c
+	override, ok := c.Symbols[strings.ToUpper(symbol)]
+	if !ok {
+		return &merged
+	}
+
+	r := override.Risk
+	if r.MaxPositionSize != nil {
+		merged.Risk.MaxPositionSize = *r.MaxPositionSize
+	}
+	if r.MaxRiskPerTrade != nil {
+		merged.Risk.MaxRiskPerTrade = *r.MaxRiskPerTrade
+	}
+	if r.MaxLeverage != nil {
+		merged.Risk.MaxLeverage = *r.MaxLeverage
+	}
+	if r.MinRiskRewardRatio != nil {
+		merged.Risk.MinRiskRewardRatio = *r.MinRiskRewardRatio
+	}
+	if r.StopLossPolicy != nil {
+		merged.Risk.StopLoss.Policy = *r.StopLossPolicy
+	}
+	if r.StopLossATRMultiplier != nil {
+		merged.Risk.StopLoss.ATRMultiplier = *r.StopLossATRMultiplier
+	}
+
+	st := override.Strategies
+	if len(st.Enabled) > 0 {
+		merged.Strategies.Enabled = append([]string(nil), st.Enabled...)
+	}
+	if len(st.DisallowedRegimes) > 0 {
+		regimes := make(map[string][]string, len(c.Strategies.DisallowedRegimes)+len(st.DisallowedRegimes))
+		for name, list := range c.Strategies.DisallowedRegimes {
+			regimes[name] = list
+		}
+		for name, list := range st.DisallowedRegimes {
+			regimes[name] = list
+		}
+		merged.Strategies.DisallowedRegimes = regimes
+	}
+	if st.SignalCooldown != nil {
+		merged.Strategies.SignalCooldown = *st.SignalCooldown
+	}
+	if len(st.SignalCooldowns) > 0 {
+		cooldowns := make(map[string]int, len(c.Strategies.SignalCooldowns)+len(st.SignalCooldowns))
+		for name, candles := range c.Strategies.SignalCooldowns {
+			cooldowns[name] = candles
+		}
+		for name, candles := range st.SignalCooldowns {
+			cooldowns[name] = candles
+		}
+		merged.Strategies.SignalCooldowns = cooldowns
+	}
+
+	return &merged
+}
@@ -0,0 +1,113 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+)
+
+// OrderFault is a simulated exchange failure applied to a paper order
+type OrderFault string
+
+const (
+	FaultRateLimit   OrderFault = "rate_limit"   // Order rejected with HTTP 429 / -1003
+	FaultDelayedFill OrderFault = "delayed_fill" // Fill arrives after the configured delay
+	FaultPartialFill OrderFault = "partial_fill" // Only part of the order fills, the rest expires
+)
+
+// ValidOrderFault reports whether f is a known order fault
+func ValidOrderFault(f OrderFault) bool {
+	switch f {
+	case FaultRateLimit, FaultDelayedFill, FaultPartialFill:
+		return true
+	}
+	return false
+}
+
+// ChaosInjector queues simulated failures for upcoming paper orders. Each
+// queued fault is applied to one order, in the order they were injected.
+type ChaosInjector struct {
+	fillDelay    time.Duration
+	partialRatio float64 // Fraction of the quantity filled on a partial fill
+	queue        []OrderFault
+	mu           sync.Mutex
+}
+
+// NewChaosInjector creates a fault injector for paper orders
+func NewChaosInjector(fillDelay time.Duration, partialRatio float64) *ChaosInjector {
+	if fillDelay <= 0 {
+		fillDelay = 5 * time.Second
+	}
+	if partialRatio <= 0 || partialRatio >= 1 {
+		partialRatio = 0.5
+	}
+	return &ChaosInjector{
+		fillDelay:    fillDelay,
+		partialRatio: partialRatio,
+	}
+}
+
+// Inject queues a fault for the next count orders
+func (ci *ChaosInjector) Inject(fault OrderFault, count int) error {
+	if !ValidOrderFault(fault) {
+		return fmt.Errorf("unknown order fault %q", fault)
+	}
+	if count <= 0 {
+		count = 1
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	for i := 0; i < count; i++ {
+		ci.queue = append(ci.queue, fault)
+	}
+	return nil
+}
+
+// Pending returns the number of queued faults by type
+func (ci *ChaosInjector) Pending() map[OrderFault]int {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	pending := make(map[OrderFault]int)
+	for _, f := range ci.queue {
+		pending[f]++
+	}
+	return pending
+}
+
+// Clear drops all queued faults
+func (ci *ChaosInjector) Clear() {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.queue = nil
+}
+
+// next pops the fault for the next order, if any
+func (ci *ChaosInjector) next() (OrderFault, bool) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if len(ci.queue) == 0 {
+		return "", false
+	}
+	fault := ci.queue[0]
+	ci.queue = ci.queue[1:]
+	return fault, true
+}
+
+// partialQuantity returns the quantity filled on a partial fill
+func (ci *ChaosInjector) partialQuantity(quantity float64) float64 {
+	// Keep the precision of exchange lot sizes
+	return math.Floor(quantity*ci.partialRatio*1e8) / 1e8
+}
+
+// rateLimitError mimics the Binance request weight rejection
+func rateLimitError() error {
+	return &binance.APIError{
+		Code:    binance.ErrCodeTooManyRequests,
+		Message: "Too much request weight used; current limit is 6000 request weight per 1 MINUTE (simulated outage)",
+	}
+}
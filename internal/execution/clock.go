@@ -0,0 +1,59 @@
+package execution
+
+import (
+	"sync"
+	"time"
+)
+
+// Paper clock modes
+const (
+	ClockSystem = "system" // Wall clock, random order and trade IDs
+	ClockEvent  = "event"  // Market data time, sequential IDs
+)
+
+// Clock is the time source of the paper executor
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock reads the wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the wall clock
+var SystemClock Clock = systemClock{}
+
+// EventClock is a clock that only moves when market data arrives, so a
+// session replayed from the same data sees the same times
+type EventClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewEventClock creates an event clock reading start until advanced
+func NewEventClock(start time.Time) *EventClock {
+	return &EventClock{now: start}
+}
+
+// Now returns the time of the latest event
+func (c *EventClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock to t. Events arriving out of order never move
+// it back.
+func (c *EventClock) Advance(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// ValidClockMode reports whether mode is a known paper clock mode
+func ValidClockMode(mode string) bool {
+	return mode == ClockSystem || mode == ClockEvent
+}
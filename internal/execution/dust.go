@@ -0,0 +1,166 @@
+package execution
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DustBalance is a residual asset balance too small to trade
+type DustBalance struct {
+	Asset     string  `json:"asset"`
+	Free      float64 `json:"free"`
+	Locked    float64 `json:"locked"`
+	Price     float64 `json:"price"` // USDT price used for valuation
+	ValueUSDT float64 `json:"valueUsdt"`
+}
+
+// DustReport lists dust balances and the threshold used to detect them
+type DustReport struct {
+	Threshold  float64       `json:"threshold"` // USDT value below which a balance is dust
+	TotalValue float64       `json:"totalValue"`
+	Balances   []DustBalance `json:"balances"`
+}
+
+// DustConversion is the result of converting dust balances to BNB
+type DustConversion struct {
+	Assets      []string  `json:"assets"`
+	ReceivedBNB float64   `json:"receivedBnb"`
+	Fee         float64   `json:"fee"` // Service charge in BNB
+	ConvertedAt time.Time `json:"convertedAt"`
+}
+
+// dustExempt are assets never reported as dust: quote currencies and the
+// BNB that dust converts into
+var dustExempt = map[string]bool{
+	"USDT": true,
+	"BUSD": true,
+	"USD":  true,
+	"BNB":  true,
+}
+
+// dustThresholdLocked returns the USDT value below which a balance is dust
+// (e.mu must be held)
+func (e *LiveExecutor) dustThresholdLocked() float64 {
+	if e.config.DustMinValue > 0 {
+		return e.config.DustMinValue
+	}
+	if e.config.Symbol == "" {
+		return 0
+	}
+	info, err := e.getSymbolInfo(e.config.Symbol)
+	if err != nil {
+		return 0
+	}
+	return info.MinNotional
+}
+
+// dustThreshold returns the USDT value below which a balance is dust
+func (e *LiveExecutor) dustThreshold() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.dustThresholdLocked()
+}
+
+// isDustLocked reports whether a quantity of symbol is too small to trade,
+// either below the exchange minimum quantity or worth less than the dust
+// threshold (e.mu must be held)
+func (e *LiveExecutor) isDustLocked(symbol string, quantity, price float64) bool {
+	if quantity <= 0 {
+		return true
+	}
+	if info, err := e.getSymbolInfo(symbol); err == nil && quantity < info.MinQty {
+		return true
+	}
+	return price > 0 && quantity*price < e.dustThresholdLocked()
+}
+
+// GetDustBalances returns account balances worth less than the dust threshold
+func (e *LiveExecutor) GetDustBalances() (*DustReport, error) {
+	account, err := e.client.GetAccount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	report := &DustReport{
+		Threshold: e.dustThreshold(),
+		Balances:  []DustBalance{},
+	}
+
+	for _, bal := range account.Balances {
+		total := bal.Free + bal.Locked
+		if total == 0 || dustExempt[bal.Asset] {
+			continue
+		}
+
+		// Assets without a USDT market cannot be valued and are skipped
+		ticker, err := e.client.GetTicker(bal.Asset + "USDT")
+		if err != nil {
+			continue
+		}
+
+		value := total * ticker.LastPrice
+		if value >= report.Threshold {
+			continue
+		}
+
+		report.Balances = append(report.Balances, DustBalance{
+			Asset:     bal.Asset,
+			Free:      bal.Free,
+			Locked:    bal.Locked,
+			Price:     ticker.LastPrice,
+			ValueUSDT: value,
+		})
+		report.TotalValue += value
+	}
+
+	return report, nil
+}
+
+// ConvertDust converts free dust balances to BNB through the exchange dust
+// transfer. Assets backing an open position are left alone.
+func (e *LiveExecutor) ConvertDust() (*DustConversion, error) {
+	report, err := e.GetDustBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.RLock()
+	held := make(map[string]bool, len(e.positions))
+	for symbol := range e.positions {
+		held[symbol] = true
+	}
+	e.mu.RUnlock()
+
+	assets := make([]string, 0, len(report.Balances))
+	for _, bal := range report.Balances {
+		if bal.Free <= 0 || held[bal.Asset+"USDT"] {
+			continue
+		}
+		assets = append(assets, bal.Asset)
+	}
+
+	conversion := &DustConversion{
+		Assets:      assets,
+		ConvertedAt: time.Now(),
+	}
+	if len(assets) == 0 {
+		return conversion, nil
+	}
+
+	result, err := e.client.DustTransfer(assets)
+	if err != nil {
+		return nil, fmt.Errorf("dust transfer failed: %w", err)
+	}
+	conversion.ReceivedBNB = result.TotalTransfered
+	conversion.Fee = result.TotalServiceCharge
+
+	log.Info().
+		Strs("assets", assets).
+		Float64("receivedBnb", conversion.ReceivedBNB).
+		Float64("fee", conversion.Fee).
+		Msg("Dust converted to BNB")
+
+	return conversion, nil
+}
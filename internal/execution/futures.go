@@ -0,0 +1,1035 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// maxFuturesLeverage is the highest leverage Binance allows on USD-M perpetuals
+const maxFuturesLeverage = 125
+
+// ErrAdverseFunding is returned when an entry would pay more funding than
+// the configured maximum rate
+var ErrAdverseFunding = errors.New("funding rate exceeds the configured maximum")
+
+// FundingInfo is the funding state of a perpetual futures symbol
+type FundingInfo struct {
+	Symbol      string
+	Rate        float64 // Rate exchanged at the next funding time; longs pay shorts when positive
+	MarkPrice   float64
+	NextFunding time.Time
+	UpdatedAt   time.Time
+}
+
+// Cost returns the funding a position of the given side and notional pays
+// at the next funding time; negative when it receives funding
+func (fi *FundingInfo) Cost(side PositionSide, notional float64) float64 {
+	cost := fi.Rate * notional
+	if side == PositionSideShort {
+		cost = -cost
+	}
+	return cost
+}
+
+// FuturesExecutor executes orders on Binance USD-M perpetual futures. The
+// bot still holds one position per symbol; in hedge mode orders carry the
+// position side they open or reduce.
+type FuturesExecutor struct {
+	config     *ExecutorConfig
+	client     *binance.FuturesClient
+	leverage   int
+	marginType binance.MarginType
+
+	// State
+	orders      map[string]*Order
+	positions   map[string]*Position
+	account     *binance.FuturesAccount
+	funding     map[string]*FundingInfo
+	liquidation map[string]float64 // Liquidation price by symbol
+
+	// Position ID counter
+	nextPositionID int64
+
+	// Symbol info cache
+	symbolInfo map[string]*binance.SymbolInfo
+
+	// Parameter set version stamped on new positions
+	paramVersion int64
+
+	// Callbacks
+	onFill     func(FillEvent)
+	onPosition func(PositionEvent)
+	orderLifecycle
+
+	// Sync
+	mu         sync.RWMutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	syncTicker *time.Ticker
+}
+
+// NewFuturesExecutor creates a USD-M futures executor and applies the
+// configured position mode, margin type and leverage to the account
+func NewFuturesExecutor(config *ExecutorConfig) (*FuturesExecutor, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	leverage := config.Leverage
+	if leverage == 0 {
+		leverage = 1
+	}
+	if leverage < 1 || leverage > maxFuturesLeverage {
+		return nil, fmt.Errorf("leverage must be between 1 and %d, got %d", maxFuturesLeverage, leverage)
+	}
+
+	marginType := binance.MarginType(strings.ToUpper(config.MarginType))
+	if marginType == "" {
+		marginType = binance.MarginTypeIsolated
+	}
+	if marginType != binance.MarginTypeIsolated && marginType != binance.MarginTypeCrossed {
+		return nil, fmt.Errorf("unknown margin type %q", config.MarginType)
+	}
+
+	client := binance.NewFuturesClient(&binance.Config{
+		APIKey:    config.APIKey,
+		SecretKey: config.SecretKey,
+		Testnet:   config.Testnet,
+		Timeout:   30 * time.Second,
+		Retry:     config.retryPolicy(),
+	})
+
+	if err := client.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Binance Futures: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	executor := &FuturesExecutor{
+		config:         config,
+		client:         client,
+		leverage:       leverage,
+		marginType:     marginType,
+		orders:         make(map[string]*Order),
+		positions:      make(map[string]*Position),
+		funding:        make(map[string]*FundingInfo),
+		liquidation:    make(map[string]float64),
+		symbolInfo:     make(map[string]*binance.SymbolInfo),
+		nextPositionID: 1,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+
+	if err := executor.configureAccount(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := executor.Sync(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("initial sync failed: %w", err)
+	}
+
+	executor.syncTicker = time.NewTicker(30 * time.Second)
+	go executor.periodicSync()
+
+	log.Info().
+		Bool("testnet", config.Testnet).
+		Str("symbol", config.Symbol).
+		Int("leverage", leverage).
+		Str("marginType", string(marginType)).
+		Bool("hedgeMode", config.HedgeMode).
+		Msg("Futures executor initialized")
+
+	return executor, nil
+}
+
+// configureAccount applies the position mode, and the margin type and
+// leverage of the configured symbol
+func (e *FuturesExecutor) configureAccount() error {
+	if err := e.client.ChangePositionMode(e.config.HedgeMode); err != nil {
+		return fmt.Errorf("failed to set position mode (hedge=%t): %w", e.config.HedgeMode, err)
+	}
+	if e.config.Symbol == "" {
+		return nil
+	}
+	if err := e.client.ChangeMarginType(e.config.Symbol, e.marginType); err != nil {
+		return fmt.Errorf("failed to set %s margin: %w", e.marginType, err)
+	}
+	if err := e.client.ChangeLeverage(e.config.Symbol, e.leverage); err != nil {
+		return fmt.Errorf("failed to set leverage %dx: %w", e.leverage, err)
+	}
+	return nil
+}
+
+// GetMode returns execution mode
+func (e *FuturesExecutor) GetMode() ExecutionMode {
+	return ModeLive
+}
+
+// SetOnFill sets fill event callback
+func (e *FuturesExecutor) SetOnFill(fn func(FillEvent)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onFill = fn
+}
+
+// SetOnPosition sets position event callback
+func (e *FuturesExecutor) SetOnPosition(fn func(PositionEvent)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onPosition = fn
+}
+
+// SetParamVersion sets the parameter set version stamped on positions
+// opened from now on
+func (e *FuturesExecutor) SetParamVersion(version int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.paramVersion = version
+}
+
+// Leverage returns the leverage applied to the configured symbol
+func (e *FuturesExecutor) Leverage() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leverage
+}
+
+// SetLeverage changes the leverage of the configured symbol on the exchange
+func (e *FuturesExecutor) SetLeverage(leverage int) error {
+	if leverage < 1 || leverage > maxFuturesLeverage {
+		return fmt.Errorf("leverage must be between 1 and %d, got %d", maxFuturesLeverage, leverage)
+	}
+	if err := e.client.ChangeLeverage(e.config.Symbol, leverage); err != nil {
+		return fmt.Errorf("failed to set leverage %dx: %w", leverage, err)
+	}
+
+	e.mu.Lock()
+	e.leverage = leverage
+	e.mu.Unlock()
+	return nil
+}
+
+// GetFunding returns the latest funding rate of a symbol
+func (e *FuturesExecutor) GetFunding(symbol string) (*FundingInfo, error) {
+	e.mu.RLock()
+	fi, ok := e.funding[symbol]
+	e.mu.RUnlock()
+	if ok {
+		return fi, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.refreshFunding(symbol)
+}
+
+// LiquidationPrice returns the exchange's liquidation price of the position
+// in symbol, or 0 when there is none
+func (e *FuturesExecutor) LiquidationPrice(symbol string) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.liquidation[symbol]
+}
+
+// PlaceOrder places a new order on Binance Futures. An order against the
+// side of the open position reduces it; any other order opens or adds to
+// a position and is checked against the funding limit.
+func (e *FuturesExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	startTime := time.Now()
+
+	if order.ClientID == "" {
+		order.ClientID = uuid.New().String()
+	}
+	e.transition(order, OrderStatusPending, startTime, "submitted")
+
+	info, err := e.getSymbolInfo(order.Symbol)
+	if err != nil {
+		e.transition(order, OrderStatusRejected, time.Now(), "symbol info unavailable")
+		return &ExecutionResult{
+			Success: false,
+			Error:   err,
+			Message: "Failed to get symbol info",
+			Latency: time.Since(startTime),
+		}, err
+	}
+
+	quantity := roundToStepSize(order.Quantity, info.StepSize, info.QuantityPrecision)
+
+	position, exists := e.positions[order.Symbol]
+	reducing := exists && ((position.Side == PositionSideLong && order.Side == OrderSideSell) ||
+		(position.Side == PositionSideShort && order.Side == OrderSideBuy))
+
+	// Reduce-only orders are exempt from the minimum notional and funding checks
+	if !reducing {
+		if err := e.checkEntry(order, quantity, info); err != nil {
+			e.transition(order, OrderStatusRejected, time.Now(), err.Error())
+			return &ExecutionResult{
+				Success: false,
+				Error:   err,
+				Message: err.Error(),
+				Latency: time.Since(startTime),
+			}, err
+		}
+	}
+
+	req := &binance.FuturesOrderRequest{
+		Symbol:           order.Symbol,
+		Side:             toBinanceSide(order.Side),
+		PositionSide:     e.positionSide(order.Side, reducing, position),
+		Type:             toFuturesOrderType(order.Type),
+		Quantity:         quantity,
+		ReduceOnly:       reducing,
+		NewClientOrderID: order.ClientID,
+	}
+
+	switch order.Type {
+	case OrderTypeLimit:
+		req.Price = roundToTickSize(order.Price, info.TickSize, info.PricePrecision)
+		req.TimeInForce = binance.TimeInForceGTC
+	case OrderTypeLimitMaker:
+		// Futures post-only orders that would match expire instead of resting
+		req.Price = roundToTickSize(order.Price, info.TickSize, info.PricePrecision)
+		req.TimeInForce = binance.TimeInForceGTX
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		// Market stops fill at the trigger instead of resting as a limit
+		req.StopPrice = roundToTickSize(order.StopPrice, info.TickSize, info.PricePrecision)
+	}
+
+	fo, err := e.client.PlaceOrder(req)
+	if err != nil {
+		// After a transient failure the order may still have reached the
+		// exchange, so it stays pending for recovery
+		if binance.ClassifyError(err) != binance.ErrorClassTransient {
+			e.transition(order, OrderStatusRejected, time.Now(), err.Error())
+		}
+		return &ExecutionResult{
+			Success: false,
+			Error:   err,
+			Message: fmt.Sprintf("Failed to place order: %v", err),
+			Latency: time.Since(startTime),
+		}, err
+	}
+
+	order.ID = fmt.Sprintf("%d", fo.OrderID)
+	order.FilledQuantity = fo.ExecutedQty
+	order.AvgFillPrice = fo.AvgPrice
+	order.CreatedAt = time.UnixMilli(fo.UpdateTime)
+	if fo.ExecutedQty > 0 {
+		// The order response carries no fills; estimate the fee from the
+		// configured rate, the account sync reflects what was charged
+		order.Commission = fo.CumQuote * e.config.Commission
+		order.CommissionAsset = "USDT"
+	}
+
+	e.orders[order.ID] = order
+	e.transition(order, mapOrderStatus(string(fo.Status)), time.Now(), "placed")
+
+	result := &ExecutionResult{
+		Success: true,
+		Order:   order,
+		Message: fmt.Sprintf("Order %s placed successfully", order.ID),
+		Latency: time.Since(startTime),
+	}
+
+	if order.Status == OrderStatusFilled {
+		result.Trade, result.Position = e.handleFill(order)
+	}
+
+	log.Info().
+		Str("orderID", order.ID).
+		Str("symbol", order.Symbol).
+		Str("side", string(order.Side)).
+		Str("positionSide", string(req.PositionSide)).
+		Str("type", string(order.Type)).
+		Float64("quantity", quantity).
+		Bool("reduceOnly", reducing).
+		Str("status", string(order.Status)).
+		Dur("latency", result.Latency).
+		Msg("Order placed on Binance Futures")
+
+	return result, nil
+}
+
+// checkEntry verifies an order that opens or adds to a position against the
+// minimum notional and the funding limit
+func (e *FuturesExecutor) checkEntry(order *Order, quantity float64, info *binance.SymbolInfo) error {
+	price := order.Price
+	fi, err := e.refreshFunding(order.Symbol)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", order.Symbol).Msg("Failed to refresh funding rate")
+	} else if order.Type == OrderTypeMarket || price == 0 {
+		price = fi.MarkPrice
+	}
+
+	notional := quantity * price
+	if notional < info.MinNotional {
+		return fmt.Errorf("order value %.2f below minimum %.2f", notional, info.MinNotional)
+	}
+
+	if e.config.MaxFundingRate > 0 && fi != nil {
+		side := PositionSideLong
+		if order.Side == OrderSideSell {
+			side = PositionSideShort
+		}
+		if rate := fi.Cost(side, 1); rate > e.config.MaxFundingRate {
+			return fmt.Errorf("%w: %s %s would pay %.4f%% at %s (max %.4f%%)",
+				ErrAdverseFunding, order.Symbol, side, rate*100,
+				fi.NextFunding.UTC().Format(time.RFC3339), e.config.MaxFundingRate*100)
+		}
+	}
+	return nil
+}
+
+// positionSide returns the futures position an order applies to
+func (e *FuturesExecutor) positionSide(side OrderSide, reducing bool, position *Position) binance.PositionSide {
+	if !e.config.HedgeMode {
+		return binance.PositionSideBoth
+	}
+	if reducing {
+		return binance.PositionSide(position.Side)
+	}
+	if side == OrderSideSell {
+		return binance.PositionSideShort
+	}
+	return binance.PositionSideLong
+}
+
+// handleFill processes a filled order
+func (e *FuturesExecutor) handleFill(order *Order) (*Trade, *Position) {
+	trade := &Trade{
+		ID:              uuid.New().String(),
+		OrderID:         order.ID,
+		Symbol:          order.Symbol,
+		Side:            order.Side,
+		Quantity:        order.FilledQuantity,
+		Price:           order.AvgFillPrice,
+		Commission:      order.Commission,
+		CommissionAsset: order.CommissionAsset,
+		Strategy:        order.Strategy,
+		ParamVersion:    e.paramVersion,
+		ExecutedAt:      time.Now(),
+	}
+
+	position, exists := e.positions[order.Symbol]
+
+	if !exists {
+		side := PositionSideLong
+		if order.Side == OrderSideSell {
+			side = PositionSideShort
+		}
+
+		position = &Position{
+			ID:           e.nextPositionID,
+			Symbol:       order.Symbol,
+			Side:         side,
+			Quantity:     order.FilledQuantity,
+			EntryPrice:   order.AvgFillPrice,
+			CurrentPrice: order.AvgFillPrice,
+			Commission:   order.Commission,
+			Strategy:     order.Strategy,
+			ParamVersion: trade.ParamVersion,
+			OpenTime:     time.Now(),
+			UpdatedAt:    time.Now(),
+			Orders:       []string{order.ID},
+		}
+		e.nextPositionID++
+		e.positions[order.Symbol] = position
+		trade.PositionID = position.ID
+
+		e.emitPositionEvent(PositionEventOpened, position, trade)
+	} else {
+		trade.PositionID = position.ID
+
+		isClosing := (position.Side == PositionSideLong && order.Side == OrderSideSell) ||
+			(position.Side == PositionSideShort && order.Side == OrderSideBuy)
+
+		if isClosing {
+			var pnl float64
+			if position.Side == PositionSideLong {
+				pnl = (order.AvgFillPrice - position.EntryPrice) * order.FilledQuantity
+			} else {
+				pnl = (position.EntryPrice - order.AvgFillPrice) * order.FilledQuantity
+			}
+			pnl -= order.Commission
+			trade.RealizedPnL = pnl
+			trade.ParamVersion = position.ParamVersion
+			position.RealizedPnL += pnl
+
+			if position.Quantity-order.FilledQuantity <= 0 {
+				delete(e.positions, order.Symbol)
+				delete(e.liquidation, order.Symbol)
+				e.emitPositionEvent(PositionEventClosed, position, trade)
+			} else {
+				position.Quantity -= order.FilledQuantity
+				position.Commission += order.Commission
+				position.UpdatedAt = time.Now()
+				position.Orders = append(position.Orders, order.ID)
+				e.emitPositionEvent(PositionEventUpdated, position, trade)
+			}
+		} else {
+			totalQty := position.Quantity + order.FilledQuantity
+			position.EntryPrice = (position.EntryPrice*position.Quantity + order.AvgFillPrice*order.FilledQuantity) / totalQty
+			position.Quantity = totalQty
+			position.Commission += order.Commission
+			position.UpdatedAt = time.Now()
+			position.Orders = append(position.Orders, order.ID)
+			e.emitPositionEvent(PositionEventUpdated, position, trade)
+		}
+	}
+
+	if e.onFill != nil {
+		e.onFill(FillEvent{
+			OrderID:    order.ID,
+			TradeID:    trade.ID,
+			Symbol:     order.Symbol,
+			Side:       order.Side,
+			Quantity:   order.FilledQuantity,
+			Price:      order.AvgFillPrice,
+			Commission: order.Commission,
+			Timestamp:  time.Now(),
+		})
+	}
+
+	return trade, position
+}
+
+// CancelOrder cancels an existing order
+func (e *FuturesExecutor) CancelOrder(orderID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order, exists := e.orders[orderID]
+	if !exists {
+		return fmt.Errorf("order not found: %s", orderID)
+	}
+
+	futuresOrderID, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %s", orderID)
+	}
+
+	if _, err := e.client.CancelOrder(order.Symbol, futuresOrderID); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	if err := e.transition(order, OrderStatusCanceled, time.Now(), "canceled by request"); err != nil {
+		log.Warn().Err(err).Str("orderID", orderID).Msg("Canceled order was already closed locally")
+	}
+
+	log.Info().
+		Str("orderID", orderID).
+		Str("symbol", order.Symbol).
+		Msg("Futures order canceled")
+
+	return nil
+}
+
+// GetOrder returns a copy of the order with the given ID
+func (e *FuturesExecutor) GetOrder(orderID string) (*Order, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	order, exists := e.orders[orderID]
+	if !exists {
+		return nil, fmt.Errorf("order not found: %s", orderID)
+	}
+	copied := *order
+	return &copied, nil
+}
+
+// GetOpenOrders returns all open orders
+func (e *FuturesExecutor) GetOpenOrders(symbol string) ([]*Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	futuresOrders, err := e.client.GetOpenOrders(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+	}
+
+	orders := make([]*Order, 0, len(futuresOrders))
+	for i := range futuresOrders {
+		order, _ := e.mergeFuturesOrder(&futuresOrders[i], "")
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// mergeFuturesOrder merges an order as reported by the exchange into local
+// state, keeping local details such as the strategy. Reports whether the
+// order was already known (e.mu must be held).
+func (e *FuturesExecutor) mergeFuturesOrder(fo *binance.FuturesOrder, strategy string) (*Order, bool) {
+	reported := fromFuturesOrder(fo)
+	order, known := e.orders[reported.ID]
+	if known {
+		order.FilledQuantity = reported.FilledQuantity
+		order.AvgFillPrice = reported.AvgFillPrice
+	} else {
+		order = reported
+		order.Strategy = strategy
+		e.orders[order.ID] = order
+	}
+
+	status := mapOrderStatus(string(fo.Status))
+	if err := e.transition(order, status, time.UnixMilli(fo.UpdateTime), "reconciled"); err != nil {
+		log.Debug().Err(err).Str("orderID", order.ID).Msg("Ignoring stale order status")
+	}
+	return order, known
+}
+
+// RecoverOrder looks up an order by the client ID it was placed with and,
+// if it filled but is unknown locally (e.g. placed before a restart), applies
+// the fill to positions. Returns the binance error when the exchange has no
+// such order.
+func (e *FuturesExecutor) RecoverOrder(symbol, clientID, strategy string) (*ExecutionResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	startTime := time.Now()
+
+	fo, err := e.client.GetOrderByClientID(symbol, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, known := e.mergeFuturesOrder(fo, strategy)
+
+	result := &ExecutionResult{
+		Success: order.Status == OrderStatusFilled,
+		Order:   order,
+		Message: fmt.Sprintf("Order %s is %s", order.ID, order.Status),
+	}
+
+	if order.Status == OrderStatusFilled {
+		if !known {
+			result.Trade, result.Position = e.handleFill(order)
+		} else {
+			result.Position = e.positions[order.Symbol]
+		}
+	}
+	result.Latency = time.Since(startTime)
+
+	log.Info().
+		Str("orderID", order.ID).
+		Str("clientID", clientID).
+		Str("symbol", order.Symbol).
+		Str("status", string(order.Status)).
+		Float64("filledQty", order.FilledQuantity).
+		Msg("Order recovered from Binance Futures")
+
+	return result, nil
+}
+
+// GetPosition returns position by symbol
+func (e *FuturesExecutor) GetPosition(symbol string) (*Position, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	position, exists := e.positions[symbol]
+	if !exists {
+		return nil, nil
+	}
+	return position, nil
+}
+
+// GetPositions returns all open positions
+func (e *FuturesExecutor) GetPositions() ([]*Position, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	positions := make([]*Position, 0, len(e.positions))
+	for _, pos := range e.positions {
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
+
+// ClosePosition closes a position with a reduce-only market order
+func (e *FuturesExecutor) ClosePosition(positionID int64) (*ExecutionResult, error) {
+	e.mu.RLock()
+	position := e.findPosition(positionID)
+	e.mu.RUnlock()
+
+	if position == nil {
+		return nil, fmt.Errorf("position not found: %d", positionID)
+	}
+
+	side := OrderSideSell
+	if position.Side == PositionSideShort {
+		side = OrderSideBuy
+	}
+
+	return e.PlaceOrder(&Order{
+		Symbol:   position.Symbol,
+		Side:     side,
+		Type:     OrderTypeMarket,
+		Quantity: position.Quantity,
+		Strategy: position.Strategy,
+	})
+}
+
+// UpdateStopLoss replaces the position's stop-market order
+func (e *FuturesExecutor) UpdateStopLoss(positionID int64, stopLoss float64) error {
+	return e.replaceProtectiveOrder(positionID, OrderTypeStopLoss, stopLoss)
+}
+
+// UpdateTakeProfit replaces the position's take-profit-market order
+func (e *FuturesExecutor) UpdateTakeProfit(positionID int64, takeProfit float64) error {
+	return e.replaceProtectiveOrder(positionID, OrderTypeTakeProfit, takeProfit)
+}
+
+// replaceProtectiveOrder cancels the position's open order of orderType and
+// places a reduce-only trigger order at price; 0 removes it
+func (e *FuturesExecutor) replaceProtectiveOrder(positionID int64, orderType OrderType, price float64) error {
+	e.mu.Lock()
+
+	position := e.findPosition(positionID)
+	if position == nil {
+		e.mu.Unlock()
+		return fmt.Errorf("position not found: %d", positionID)
+	}
+
+	for _, orderID := range position.Orders {
+		order, exists := e.orders[orderID]
+		if exists && order.Type == orderType && order.Status == OrderStatusOpen {
+			futuresOrderID, _ := strconv.ParseInt(orderID, 10, 64)
+			if _, err := e.client.CancelOrder(position.Symbol, futuresOrderID); err != nil {
+				log.Warn().Err(err).Str("orderID", orderID).Msg("Failed to cancel protective order")
+				continue
+			}
+			e.transition(order, OrderStatusCanceled, time.Now(), "replaced")
+		}
+	}
+
+	if orderType == OrderTypeStopLoss {
+		position.StopLoss = price
+	} else {
+		position.TakeProfit = price
+	}
+	position.UpdatedAt = time.Now()
+
+	side := OrderSideSell
+	if position.Side == PositionSideShort {
+		side = OrderSideBuy
+	}
+	order := &Order{
+		Symbol:    position.Symbol,
+		Side:      side,
+		Type:      orderType,
+		Quantity:  position.Quantity,
+		StopPrice: price,
+		Strategy:  position.Strategy,
+	}
+	e.mu.Unlock()
+
+	if price <= 0 {
+		return nil
+	}
+
+	result, err := e.PlaceOrder(order)
+	if err != nil {
+		return fmt.Errorf("failed to place %s order: %w", strings.ToLower(string(orderType)), err)
+	}
+
+	e.mu.Lock()
+	position.Orders = append(position.Orders, result.Order.ID)
+	e.mu.Unlock()
+	return nil
+}
+
+// GetBalance returns the available and margin-locked balance of a margin asset
+func (e *FuturesExecutor) GetBalance(asset string) (free, locked float64, err error) {
+	e.mu.RLock()
+	account := e.account
+	e.mu.RUnlock()
+
+	if account == nil {
+		account, err = e.client.GetAccount()
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to fetch futures account: %w", err)
+		}
+		e.mu.Lock()
+		e.account = account
+		e.mu.Unlock()
+	}
+
+	for _, bal := range account.Assets {
+		if bal.Asset == asset {
+			locked = math.Max(bal.WalletBalance-bal.AvailableBalance, 0)
+			return bal.AvailableBalance, locked, nil
+		}
+	}
+	return 0, 0, nil
+}
+
+// GetEquity returns the futures margin balance: wallet balance plus
+// unrealized P&L
+func (e *FuturesExecutor) GetEquity() (float64, error) {
+	account, err := e.client.GetAccount()
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch futures account: %w", err)
+	}
+
+	e.mu.Lock()
+	e.account = account
+	e.mu.Unlock()
+
+	return account.TotalMarginBalance, nil
+}
+
+// Sync synchronizes account, positions and funding with Binance Futures.
+// Positions the exchange no longer reports (liquidated, or closed outside
+// the bot) are dropped.
+func (e *FuturesExecutor) Sync() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	account, err := e.client.GetAccount()
+	if err != nil {
+		return fmt.Errorf("failed to sync futures account: %w", err)
+	}
+	e.account = account
+
+	risks, err := e.client.GetPositionRisk(e.config.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to sync futures positions: %w", err)
+	}
+
+	open := make(map[string]bool)
+	for _, r := range risks {
+		if r.PositionAmt == 0 {
+			continue
+		}
+		open[r.Symbol] = true
+		e.liquidation[r.Symbol] = r.LiquidationPrice
+
+		side := PositionSideLong
+		if r.PositionAmt < 0 || r.PositionSide == binance.PositionSideShort {
+			side = PositionSideShort
+		}
+
+		pos, exists := e.positions[r.Symbol]
+		if !exists {
+			pos = &Position{
+				ID:       e.nextPositionID,
+				Symbol:   r.Symbol,
+				Side:     side,
+				OpenTime: time.UnixMilli(r.UpdateTime),
+			}
+			e.nextPositionID++
+			e.positions[r.Symbol] = pos
+			log.Warn().
+				Str("symbol", r.Symbol).
+				Str("side", string(side)).
+				Float64("quantity", math.Abs(r.PositionAmt)).
+				Msg("Adopted futures position opened outside the bot")
+		}
+		pos.Quantity = math.Abs(r.PositionAmt)
+		pos.EntryPrice = r.EntryPrice
+		e.updatePositionPrice(pos, r.MarkPrice, r.UnrealizedProfit)
+	}
+
+	for symbol, pos := range e.positions {
+		if (e.config.Symbol == "" || symbol == e.config.Symbol) && !open[symbol] {
+			log.Warn().
+				Str("symbol", symbol).
+				Int64("positionID", pos.ID).
+				Msg("Futures position no longer open on the exchange")
+			delete(e.positions, symbol)
+			delete(e.liquidation, symbol)
+			e.emitPositionEvent(PositionEventClosed, pos, nil)
+		}
+	}
+
+	if e.config.Symbol != "" {
+		if _, err := e.refreshFunding(e.config.Symbol); err != nil {
+			log.Warn().Err(err).Msg("Failed to sync funding rate")
+		}
+	}
+
+	log.Debug().Msg("State synchronized with Binance Futures")
+	return nil
+}
+
+// refreshFunding fetches the funding rate and mark price of a symbol. The
+// caller must hold the lock.
+func (e *FuturesExecutor) refreshFunding(symbol string) (*FundingInfo, error) {
+	index, err := e.client.GetPremiumIndex(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	fi := &FundingInfo{
+		Symbol:      symbol,
+		Rate:        index.LastFundingRate,
+		MarkPrice:   index.MarkPrice,
+		NextFunding: index.NextFunding(),
+		UpdatedAt:   time.Now(),
+	}
+	e.funding[symbol] = fi
+	return fi, nil
+}
+
+// updatePositionPrice updates a position with the mark price and the
+// exchange's unrealized P&L
+func (e *FuturesExecutor) updatePositionPrice(pos *Position, markPrice, unrealizedPnL float64) {
+	pos.CurrentPrice = markPrice
+	pos.UnrealizedPnL = unrealizedPnL
+	pos.UpdatedAt = time.Now()
+
+	if pos.EntryPrice > 0 && pos.Quantity > 0 {
+		pos.UnrealizedPnLPct = pos.UnrealizedPnL / (pos.EntryPrice * pos.Quantity)
+	}
+}
+
+// findPosition returns the position with the given ID. The caller must hold
+// the lock.
+func (e *FuturesExecutor) findPosition(positionID int64) *Position {
+	for _, p := range e.positions {
+		if p.ID == positionID {
+			return p
+		}
+	}
+	return nil
+}
+
+// getSymbolInfo gets futures symbol trading rules
+func (e *FuturesExecutor) getSymbolInfo(symbol string) (*binance.SymbolInfo, error) {
+	if info, exists := e.symbolInfo[symbol]; exists {
+		return info, nil
+	}
+
+	info, err := e.client.GetSymbolInfo(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	e.symbolInfo[symbol] = info
+	return info, nil
+}
+
+// periodicSync runs periodic synchronization
+func (e *FuturesExecutor) periodicSync() {
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-e.syncTicker.C:
+			if err := e.Sync(); err != nil {
+				log.Error().Err(err).Msg("Periodic futures sync failed")
+			}
+		}
+	}
+}
+
+// emitPositionEvent emits a position event
+func (e *FuturesExecutor) emitPositionEvent(eventType PositionEventType, position *Position, trade *Trade) {
+	if e.onPosition == nil {
+		return
+	}
+
+	e.onPosition(PositionEvent{
+		Type:      eventType,
+		Position:  position,
+		Trade:     trade,
+		Timestamp: time.Now(),
+	})
+}
+
+// Stop stops the futures executor
+func (e *FuturesExecutor) Stop() {
+	e.cancel()
+	if e.syncTicker != nil {
+		e.syncTicker.Stop()
+	}
+	log.Info().Msg("Futures executor stopped")
+}
+
+// RequestWeightStats returns the REST request weight used by the executor
+func (e *FuturesExecutor) RequestWeightStats() binance.WeightStats {
+	return e.client.WeightStats()
+}
+
+// GetAccountSummary returns account summary
+func (e *FuturesExecutor) GetAccountSummary() (*AccountSummary, error) {
+	account, err := e.client.GetAccount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch futures account: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.account = account
+
+	return &AccountSummary{
+		Mode:             ModeLive,
+		Equity:           account.TotalMarginBalance,
+		AvailableBalance: account.AvailableBalance,
+		UsedMargin:       account.TotalInitialMargin,
+		UnrealizedPnL:    account.TotalUnrealizedProfit,
+		OpenPositions:    len(e.positions),
+	}, nil
+}
+
+// fromFuturesOrder converts a Binance futures order to an internal order.
+// The status is left for the caller to apply as a transition.
+func fromFuturesOrder(fo *binance.FuturesOrder) *Order {
+	return &Order{
+		ID:             fmt.Sprintf("%d", fo.OrderID),
+		ClientID:       fo.ClientOrderID,
+		Symbol:         fo.Symbol,
+		Side:           fromBinanceSide(fo.Side),
+		Type:           fromFuturesOrderType(fo.Type),
+		Quantity:       fo.OrigQty,
+		Price:          fo.Price,
+		StopPrice:      fo.StopPrice,
+		FilledQuantity: fo.ExecutedQty,
+		AvgFillPrice:   fo.AvgPrice,
+		CreatedAt:      time.UnixMilli(fo.Time),
+		UpdatedAt:      time.UnixMilli(fo.UpdateTime),
+	}
+}
+
+// toFuturesOrderType converts internal OrderType to a futures order type.
+// Stops and take profits are trigger orders that fill at market.
+func toFuturesOrderType(t OrderType) binance.OrderType {
+	switch t {
+	case OrderTypeLimit, OrderTypeLimitMaker:
+		return binance.OrderTypeLimit
+	case OrderTypeStopLoss:
+		return binance.OrderTypeStopMarket
+	case OrderTypeTakeProfit:
+		return binance.OrderTypeTakeProfitMarket
+	default:
+		return binance.OrderTypeMarket
+	}
+}
+
+// fromFuturesOrderType converts a futures order type to internal OrderType
+func fromFuturesOrderType(t binance.OrderType) OrderType {
+	switch t {
+	case binance.OrderTypeLimit:
+		return OrderTypeLimit
+	case binance.OrderTypeStopMarket, "STOP":
+		return OrderTypeStopLoss
+	case binance.OrderTypeTakeProfitMarket, binance.OrderTypeTakeProfit:
+		return OrderTypeTakeProfit
+	default:
+		return OrderTypeMarket
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,15 +27,24 @@ type LiveExecutor struct {
 		Locked float64
 	}
 
+	// Filled quantity of each order already applied to positions, so a
+	// fill seen in both the order response and the user data stream
+	// counts once
+	fillsApplied map[string]float64
+
 	// Position ID counter
 	nextPositionID int64
 
 	// Symbol info cache
 	symbolInfo map[string]*binance.SymbolInfo
 
+	// Parameter set version stamped on new positions
+	paramVersion int64
+
 	// Callbacks
 	onFill     func(FillEvent)
 	onPosition func(PositionEvent)
+	orderLifecycle
 
 	// Sync
 	mu        sync.RWMutex
@@ -55,6 +65,7 @@ func NewLiveExecutor(config *ExecutorConfig) (*LiveExecutor, error) {
 		SecretKey: config.SecretKey,
 		Testnet:   config.Testnet,
 		Timeout:   30 * time.Second,
+		Retry:     config.retryPolicy(),
 	})
 
 	// Test connection
@@ -70,6 +81,7 @@ func NewLiveExecutor(config *ExecutorConfig) (*LiveExecutor, error) {
 		orders:         make(map[string]*Order),
 		positions:      make(map[string]*Position),
 		balances:       make(map[string]struct{ Free, Locked float64 }),
+		fillsApplied:   make(map[string]float64),
 		symbolInfo:     make(map[string]*binance.SymbolInfo),
 		nextPositionID: 1,
 		ctx:            ctx,
@@ -86,6 +98,11 @@ func NewLiveExecutor(config *ExecutorConfig) (*LiveExecutor, error) {
 	executor.syncTicker = time.NewTicker(30 * time.Second)
 	go executor.periodicSync()
 
+	// Real-time fills and balances; periodic sync remains the fallback
+	if err := executor.StartUserDataStream(); err != nil {
+		log.Warn().Err(err).Msg("User data stream unavailable, relying on periodic sync")
+	}
+
 	log.Info().
 		Bool("testnet", config.Testnet).
 		Str("symbol", config.Symbol).
@@ -113,6 +130,14 @@ func (e *LiveExecutor) SetOnPosition(fn func(PositionEvent)) {
 	e.onPosition = fn
 }
 
+// SetParamVersion sets the parameter set version stamped on positions
+// opened from now on
+func (e *LiveExecutor) SetParamVersion(version int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.paramVersion = version
+}
+
 // PlaceOrder places a new order on Binance
 func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 	e.mu.Lock()
@@ -124,10 +149,12 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 	if order.ClientID == "" {
 		order.ClientID = uuid.New().String()
 	}
+	e.transition(order, OrderStatusPending, startTime, "submitted")
 
 	// Get symbol info for precision
 	info, err := e.getSymbolInfo(order.Symbol)
 	if err != nil {
+		e.transition(order, OrderStatusRejected, time.Now(), "symbol info unavailable")
 		return &ExecutionResult{
 			Success: false,
 			Error:   err,
@@ -150,6 +177,7 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 	}
 	if notional < info.MinNotional {
 		err := fmt.Errorf("order value %.2f below minimum %.2f", notional, info.MinNotional)
+		e.transition(order, OrderStatusRejected, time.Now(), err.Error())
 		return &ExecutionResult{
 			Success: false,
 			Error:   err,
@@ -167,10 +195,12 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 		NewClientOrderID: order.ClientID,
 	}
 
-	// Set price for limit orders
+	// Set price for limit orders; post-only orders take no time in force
 	if order.Type == OrderTypeLimit {
 		req.Price = roundToTickSize(order.Price, info.TickSize, info.PricePrecision)
 		req.TimeInForce = binance.TimeInForceGTC
+	} else if order.Type == OrderTypeLimitMaker {
+		req.Price = roundToTickSize(order.Price, info.TickSize, info.PricePrecision)
 	}
 
 	// Set stop price for stop orders
@@ -184,6 +214,14 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 	// Place order on Binance
 	binanceOrder, err := e.client.PlaceOrder(req)
 	if err != nil {
+		// After a transient failure the order may still have reached the
+		// exchange, so it stays pending for recovery
+		if binance.ClassifyError(err) != binance.ErrorClassTransient {
+			e.transition(order, OrderStatusRejected, time.Now(), err.Error())
+		}
+		if binance.IsPostOnlyRejection(err) {
+			err = fmt.Errorf("%w: %v", ErrWouldTakeLiquidity, err)
+		}
 		return &ExecutionResult{
 			Success: false,
 			Error:   err,
@@ -194,10 +232,8 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 
 	// Update order with exchange response
 	order.ID = fmt.Sprintf("%d", binanceOrder.OrderID)
-	order.Status = mapOrderStatus(binanceOrder.Status)
 	order.FilledQuantity = binanceOrder.ExecutedQty
 	order.CreatedAt = time.UnixMilli(binanceOrder.TransactTime)
-	order.UpdatedAt = time.Now()
 
 	// Calculate average fill price from fills
 	if len(binanceOrder.Fills) > 0 {
@@ -218,6 +254,7 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 
 	// Store order
 	e.orders[order.ID] = order
+	e.transition(order, mapOrderStatus(binanceOrder.Status), time.Now(), "placed")
 
 	result := &ExecutionResult{
 		Success: true,
@@ -226,10 +263,10 @@ func (e *LiveExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 		Latency: time.Since(startTime),
 	}
 
-	// Handle filled orders
-	if order.Status == OrderStatusFilled {
-		order.FilledAt = time.Now()
-		result.Trade, result.Position = e.handleFill(order)
+	// Apply whatever filled immediately; the rest of a partially filled
+	// order arrives through the user data stream or periodic sync
+	if order.FilledQuantity > 0 {
+		result.Trade, result.Position = e.applyFill(order, order.FilledQuantity, order.AvgFillPrice, order.Commission)
 	}
 
 	log.Info().
@@ -258,6 +295,7 @@ func (e *LiveExecutor) handleFill(order *Order) (*Trade, *Position) {
 		Commission:      order.Commission,
 		CommissionAsset: order.CommissionAsset,
 		Strategy:        order.Strategy,
+		ParamVersion:    e.paramVersion,
 		ExecutedAt:      time.Now(),
 	}
 
@@ -280,6 +318,7 @@ func (e *LiveExecutor) handleFill(order *Order) (*Trade, *Position) {
 			CurrentPrice: order.AvgFillPrice,
 			Commission:   order.Commission,
 			Strategy:     order.Strategy,
+			ParamVersion: trade.ParamVersion,
 			OpenTime:     time.Now(),
 			UpdatedAt:    time.Now(),
 			Orders:       []string{order.ID},
@@ -306,9 +345,20 @@ func (e *LiveExecutor) handleFill(order *Order) (*Trade, *Position) {
 			}
 			pnl -= order.Commission
 			trade.RealizedPnL = pnl
+			trade.ParamVersion = position.ParamVersion
 			position.RealizedPnL += pnl
 
-			if order.FilledQuantity >= position.Quantity {
+			// A remainder too small to trade is dust, not a position
+			remaining := position.Quantity - order.FilledQuantity
+			if remaining > 0 && e.isDustLocked(order.Symbol, remaining, order.AvgFillPrice) {
+				log.Info().
+					Str("symbol", order.Symbol).
+					Float64("residual", remaining).
+					Msg("Residual position quantity is dust, closing position")
+				remaining = 0
+			}
+
+			if remaining <= 0 {
 				// Fully closed
 				delete(e.positions, order.Symbol)
 				e.emitPositionEvent(PositionEventClosed, position, trade)
@@ -370,8 +420,9 @@ func (e *LiveExecutor) CancelOrder(orderID string) error {
 		return fmt.Errorf("failed to cancel order: %w", err)
 	}
 
-	order.Status = OrderStatusCanceled
-	order.UpdatedAt = time.Now()
+	if err := e.transition(order, OrderStatusCanceled, time.Now(), "canceled by request"); err != nil {
+		log.Warn().Err(err).Str("orderID", orderID).Msg("Canceled order was already closed locally")
+	}
 
 	log.Info().
 		Str("orderID", orderID).
@@ -381,7 +432,7 @@ func (e *LiveExecutor) CancelOrder(orderID string) error {
 	return nil
 }
 
-// GetOrder returns order by ID
+// GetOrder returns a copy of the order with the given ID
 func (e *LiveExecutor) GetOrder(orderID string) (*Order, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
@@ -391,13 +442,14 @@ func (e *LiveExecutor) GetOrder(orderID string) (*Order, error) {
 		return nil, fmt.Errorf("order not found: %s", orderID)
 	}
 
-	return order, nil
+	copied := *order
+	return &copied, nil
 }
 
 // GetOpenOrders returns all open orders
 func (e *LiveExecutor) GetOpenOrders(symbol string) ([]*Order, error) {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.mu.Lock()
+	defer e.mu.Unlock()
 
 	// Fetch from Binance for accurate state
 	binanceOrders, err := e.client.GetOpenOrders(symbol)
@@ -406,35 +458,54 @@ func (e *LiveExecutor) GetOpenOrders(symbol string) ([]*Order, error) {
 	}
 
 	orders := make([]*Order, 0, len(binanceOrders))
-	for _, bo := range binanceOrders {
-		origQty, _ := strconv.ParseFloat(bo.OrigQty, 64)
-		price, _ := strconv.ParseFloat(bo.Price, 64)
-		stopPrice, _ := strconv.ParseFloat(bo.StopPrice, 64)
-		executedQty, _ := strconv.ParseFloat(bo.ExecutedQty, 64)
-
-		order := &Order{
-			ID:             fmt.Sprintf("%d", bo.OrderID),
-			ClientID:       bo.ClientOrderID,
-			Symbol:         bo.Symbol,
-			Side:           fromBinanceSide(bo.Side),
-			Type:           fromBinanceOrderType(bo.Type),
-			Quantity:       origQty,
-			Price:          price,
-			StopPrice:      stopPrice,
-			Status:         mapOrderStatus(string(bo.Status)),
-			FilledQuantity: executedQty,
-			CreatedAt:      time.UnixMilli(bo.Time),
-			UpdatedAt:      time.UnixMilli(bo.UpdateTime),
-		}
+	for i := range binanceOrders {
+		order, _, _ := e.applyExchangeOrder(&binanceOrders[i], "")
 		orders = append(orders, order)
-
-		// Update local cache
-		e.orders[order.ID] = order
 	}
 
 	return orders, nil
 }
 
+// RecoverOrder looks up an order by the client ID it was placed with and
+// applies any fills not yet applied to positions, e.g. for an order placed
+// before a restart. Returns the binance error when the exchange has no
+// such order.
+func (e *LiveExecutor) RecoverOrder(symbol, clientID, strategy string) (*ExecutionResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	startTime := time.Now()
+
+	bo, err := e.client.GetOrderByClientID(symbol, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, trade, position := e.applyExchangeOrder(bo, strategy)
+
+	result := &ExecutionResult{
+		Success:  order.Status == OrderStatusFilled,
+		Order:    order,
+		Trade:    trade,
+		Position: position,
+		Message:  fmt.Sprintf("Order %s is %s", order.ID, order.Status),
+	}
+	if result.Position == nil && order.FilledQuantity > 0 {
+		result.Position = e.positions[order.Symbol]
+	}
+	result.Latency = time.Since(startTime)
+
+	log.Info().
+		Str("orderID", order.ID).
+		Str("clientID", clientID).
+		Str("symbol", order.Symbol).
+		Str("status", string(order.Status)).
+		Float64("filledQty", order.FilledQuantity).
+		Msg("Order recovered from Binance")
+
+	return result, nil
+}
+
 // GetPosition returns position by symbol
 func (e *LiveExecutor) GetPosition(symbol string) (*Position, error) {
 	e.mu.RLock()
@@ -526,9 +597,13 @@ func (e *LiveExecutor) UpdateStopLoss(positionID int64, stopLoss float64) error
 	// Cancel existing stop loss orders
 	for _, orderID := range position.Orders {
 		order, exists := e.orders[orderID]
-		if exists && order.Type == OrderTypeStopLoss && order.Status == OrderStatusOpen {
+		if exists && order.Type == OrderTypeStopLoss && order.Status.IsWorking() {
 			binanceOrderID, _ := strconv.ParseInt(orderID, 10, 64)
-			e.client.CancelOrder(position.Symbol, binanceOrderID)
+			if _, err := e.client.CancelOrder(position.Symbol, binanceOrderID); err != nil {
+				log.Warn().Err(err).Str("orderID", orderID).Msg("Failed to cancel stop loss order")
+				continue
+			}
+			e.transition(order, OrderStatusCanceled, time.Now(), "replaced")
 		}
 	}
 
@@ -549,7 +624,7 @@ func (e *LiveExecutor) UpdateStopLoss(positionID int64, stopLoss float64) error
 		}
 
 		e.mu.Unlock()
-		_, err := e.PlaceOrder(&Order{
+		result, err := e.PlaceOrder(&Order{
 			Symbol:    position.Symbol,
 			Side:      side,
 			Type:      OrderTypeStopLoss,
@@ -562,7 +637,10 @@ func (e *LiveExecutor) UpdateStopLoss(positionID int64, stopLoss float64) error
 
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to place stop loss order")
+			return fmt.Errorf("failed to place stop loss order: %w", err)
 		}
+		// Tracked so the next update or stop check finds it
+		position.Orders = append(position.Orders, result.Order.ID)
 	}
 
 	return nil
@@ -657,6 +735,11 @@ func (e *LiveExecutor) GetEquity() (float64, error) {
 		return 0, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
+	var dustThreshold float64
+	if e.config.DustExcludeEquity {
+		dustThreshold = e.dustThreshold()
+	}
+
 	// Sum up USDT and USDT-equivalent values
 	var equity float64
 	for _, bal := range account.Balances {
@@ -667,15 +750,14 @@ func (e *LiveExecutor) GetEquity() (float64, error) {
 
 		if bal.Asset == "USDT" || bal.Asset == "BUSD" || bal.Asset == "USD" {
 			equity += total
-		} else if bal.Asset == "ETH" {
-			ticker, err := e.client.GetTicker("ETHUSDT")
-			if err == nil {
-				equity += total * ticker.LastPrice
-			}
-		} else if bal.Asset == "BTC" {
-			ticker, err := e.client.GetTicker("BTCUSDT")
+		} else if bal.Asset == "ETH" || bal.Asset == "BTC" {
+			ticker, err := e.client.GetTicker(bal.Asset + "USDT")
 			if err == nil {
-				equity += total * ticker.LastPrice
+				value := total * ticker.LastPrice
+				if value < dustThreshold {
+					continue
+				}
+				equity += value
 			}
 		}
 	}
@@ -706,34 +788,18 @@ func (e *LiveExecutor) Sync() error {
 		}
 	}
 
-	// Sync open orders
+	// Sync open orders, then settle tracked orders that have since closed
 	if e.config.Symbol != "" {
 		orders, err := e.client.GetOpenOrders(e.config.Symbol)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to sync open orders")
 		} else {
-			for _, bo := range orders {
-				origQty, _ := strconv.ParseFloat(bo.OrigQty, 64)
-				price, _ := strconv.ParseFloat(bo.Price, 64)
-				stopPrice, _ := strconv.ParseFloat(bo.StopPrice, 64)
-				executedQty, _ := strconv.ParseFloat(bo.ExecutedQty, 64)
-
-				order := &Order{
-					ID:             fmt.Sprintf("%d", bo.OrderID),
-					ClientID:       bo.ClientOrderID,
-					Symbol:         bo.Symbol,
-					Side:           fromBinanceSide(bo.Side),
-					Type:           fromBinanceOrderType(bo.Type),
-					Quantity:       origQty,
-					Price:          price,
-					StopPrice:      stopPrice,
-					Status:         mapOrderStatus(string(bo.Status)),
-					FilledQuantity: executedQty,
-					CreatedAt:      time.UnixMilli(bo.Time),
-					UpdatedAt:      time.UnixMilli(bo.UpdateTime),
-				}
-				e.orders[order.ID] = order
+			open := make(map[string]bool, len(orders))
+			for i := range orders {
+				order, _, _ := e.applyExchangeOrder(&orders[i], "")
+				open[order.ID] = true
 			}
+			e.reconcileClosedOrders(e.config.Symbol, open)
 		}
 	}
 
@@ -826,6 +892,18 @@ func (h *userDataHandler) OnDisconnect() {
 }
 func (h *userDataHandler) OnReconnect() {
 	log.Info().Msg("User data stream reconnected")
+	// Events sent while disconnected are not replayed
+	go func() {
+		if err := h.executor.Sync(); err != nil {
+			log.Error().Err(err).Msg("Sync after user data reconnect failed")
+		}
+	}()
+}
+func (h *userDataHandler) OnOrderUpdate(event binance.OrderUpdateEvent) {
+	h.executor.handleOrderUpdate(event)
+}
+func (h *userDataHandler) OnAccountUpdate(event binance.AccountUpdateEvent) {
+	h.executor.handleAccountUpdate(event)
 }
 
 // StartUserDataStream starts the user data stream for real-time updates
@@ -862,12 +940,142 @@ func (e *LiveExecutor) StartUserDataStream() error {
 	return nil
 }
 
-// handleUserDataEvent handles user data stream events
-func (e *LiveExecutor) handleUserDataEvent(msg []byte) {
-	// Parse event type and handle accordingly
-	// This would parse order updates, balance updates, etc.
-	// Implementation depends on Binance user data stream format
-	log.Debug().Str("event", string(msg)).Msg("User data event received")
+// UserDataStreamStats returns traffic counters for the user data stream
+func (e *LiveExecutor) UserDataStreamStats() (binance.WSStats, bool) {
+	if e.wsClient == nil {
+		return binance.WSStats{}, false
+	}
+	return e.wsClient.Stats(), true
+}
+
+// RequestWeightStats returns the REST request weight used by the executor
+func (e *LiveExecutor) RequestWeightStats() binance.WeightStats {
+	return e.client.WeightStats()
+}
+
+// handleOrderUpdate applies an executionReport to local order and position
+// state. Each report carries the cumulative filled quantity, so only the part
+// not yet applied becomes a fill; partial fills open or adjust the position
+// as they happen.
+func (e *LiveExecutor) handleOrderUpdate(event binance.OrderUpdateEvent) {
+	if e.config.Symbol != "" && event.Symbol != e.config.Symbol {
+		return
+	}
+
+	cumQty, _ := strconv.ParseFloat(event.CumFilledQty, 64)
+	cumQuote, _ := strconv.ParseFloat(event.CumQuoteQty, 64)
+	lastQty, _ := strconv.ParseFloat(event.LastExecutedQty, 64)
+	lastPrice, _ := strconv.ParseFloat(event.LastExecutedPrice, 64)
+	commission, _ := strconv.ParseFloat(event.Commission, 64)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	orderID := fmt.Sprintf("%d", event.OrderID)
+	order, exists := e.orders[orderID]
+	if !exists {
+		origQty, _ := strconv.ParseFloat(event.OrderQuantity, 64)
+		price, _ := strconv.ParseFloat(event.OrderPrice, 64)
+		stopPrice, _ := strconv.ParseFloat(event.StopPrice, 64)
+
+		clientID := event.ClientOrderID
+		if event.OrigClientOrderID != "" {
+			// Cancel reports carry the cancel request's ID in "c"
+			clientID = event.OrigClientOrderID
+		}
+
+		order = &Order{
+			ID:        orderID,
+			ClientID:  clientID,
+			Symbol:    event.Symbol,
+			Side:      fromBinanceSide(event.Side),
+			Type:      fromBinanceOrderType(event.OrderType),
+			Quantity:  origQty,
+			Price:     price,
+			StopPrice: stopPrice,
+			CreatedAt: time.UnixMilli(event.OrderCreationTime),
+		}
+		e.orders[orderID] = order
+	}
+
+	delta := cumQty - e.fillsApplied[orderID]
+	if delta > 0 {
+		order.FilledQuantity = cumQty
+		order.AvgFillPrice = cumQuote / cumQty
+		order.Commission += commission
+		if event.CommissionAsset != "" {
+			order.CommissionAsset = event.CommissionAsset
+		}
+	}
+
+	reason := strings.ToLower(event.ExecutionType)
+	if event.RejectReason != "" && event.RejectReason != "NONE" {
+		reason += ": " + event.RejectReason
+	}
+	at := time.UnixMilli(event.TransactionTime)
+	if err := e.transition(order, mapOrderStatus(string(event.OrderStatus)), at, reason); err != nil {
+		// Reports can arrive out of order; a stale one must not reopen a
+		// finished order
+		log.Debug().Err(err).Str("orderID", orderID).Msg("Ignoring stale order status")
+	}
+
+	if delta > 0 {
+		// Price the unapplied quantity at the last execution, or at the
+		// order average when earlier reports were missed
+		price := lastPrice
+		if delta > lastQty || price <= 0 {
+			price = order.AvgFillPrice
+		}
+
+		e.applyFill(order, cumQty, price, commission)
+
+		log.Info().
+			Str("orderID", orderID).
+			Str("symbol", order.Symbol).
+			Str("side", string(order.Side)).
+			Float64("quantity", delta).
+			Float64("price", price).
+			Float64("filled", cumQty).
+			Float64("orderQty", order.Quantity).
+			Str("status", string(order.Status)).
+			Msg("Order fill received from user data stream")
+		return
+	}
+
+	switch order.Status {
+	case OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired:
+		log.Info().
+			Str("orderID", orderID).
+			Str("symbol", order.Symbol).
+			Str("status", string(order.Status)).
+			Str("reason", event.RejectReason).
+			Float64("filled", order.FilledQuantity).
+			Float64("remaining", order.RemainingQuantity()).
+			Msg("Order closed on Binance")
+	default:
+		log.Debug().
+			Str("orderID", orderID).
+			Str("execution", event.ExecutionType).
+			Str("status", string(order.Status)).
+			Msg("Order update received")
+	}
+}
+
+// handleAccountUpdate applies an outboundAccountPosition balance snapshot
+func (e *LiveExecutor) handleAccountUpdate(event binance.AccountUpdateEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, bal := range event.Balances {
+		free, _ := strconv.ParseFloat(bal.Free, 64)
+		locked, _ := strconv.ParseFloat(bal.Locked, 64)
+		e.balances[bal.Asset] = struct{ Free, Locked float64 }{
+			Free:   free,
+			Locked: locked,
+		}
+	}
+
+	log.Debug().Int("assets", len(event.Balances)).Msg("Balances updated from user data stream")
 }
 
 // keepAliveListenKey keeps the listen key alive
@@ -1012,6 +1220,8 @@ func toBinanceOrderType(t OrderType) binance.OrderType {
 		return binance.OrderTypeMarket
 	case OrderTypeLimit:
 		return binance.OrderTypeLimit
+	case OrderTypeLimitMaker:
+		return binance.OrderTypeLimitMaker
 	case OrderTypeStopLoss:
 		return binance.OrderTypeStopLoss
 	case OrderTypeTakeProfit:
@@ -1028,6 +1238,8 @@ func fromBinanceOrderType(t binance.OrderType) OrderType {
 		return OrderTypeMarket
 	case binance.OrderTypeLimit:
 		return OrderTypeLimit
+	case binance.OrderTypeLimitMaker:
+		return OrderTypeLimitMaker
 	case binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit:
 		return OrderTypeStopLoss
 	case binance.OrderTypeTakeProfit, binance.OrderTypeTakeProfitLimit:
@@ -0,0 +1,134 @@
+package execution
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/rs/zerolog/log"
+)
+
+// applyFill applies the part of an order's cumulative filled quantity not
+// yet applied to positions, priced at price (e.mu must be held)
+func (e *LiveExecutor) applyFill(order *Order, cumQty, price, commission float64) (*Trade, *Position) {
+	delta := cumQty - e.fillsApplied[order.ID]
+	if delta <= 0 {
+		return nil, nil
+	}
+
+	fill := *order
+	fill.FilledQuantity = delta
+	fill.AvgFillPrice = price
+	fill.Commission = commission
+	e.fillsApplied[order.ID] = cumQty
+	return e.handleFill(&fill)
+}
+
+// applyExchangeOrder merges an order as reported by the exchange into local
+// state and applies fills the executor has not seen, e.g. while the user
+// data stream was down. Local details such as the strategy and signal are
+// kept (e.mu must be held).
+func (e *LiveExecutor) applyExchangeOrder(bo *binance.Order, strategy string) (*Order, *Trade, *Position) {
+	origQty, _ := strconv.ParseFloat(bo.OrigQty, 64)
+	price, _ := strconv.ParseFloat(bo.Price, 64)
+	stopPrice, _ := strconv.ParseFloat(bo.StopPrice, 64)
+	executedQty, _ := strconv.ParseFloat(bo.ExecutedQty, 64)
+	quoteQty, _ := strconv.ParseFloat(bo.CummulativeQuoteQty, 64)
+
+	orderID := fmt.Sprintf("%d", bo.OrderID)
+	order, known := e.orders[orderID]
+	if !known {
+		order = &Order{
+			ID:        orderID,
+			ClientID:  bo.ClientOrderID,
+			Symbol:    bo.Symbol,
+			Side:      fromBinanceSide(bo.Side),
+			Type:      fromBinanceOrderType(bo.Type),
+			Quantity:  origQty,
+			Price:     price,
+			StopPrice: stopPrice,
+			Strategy:  strategy,
+			CreatedAt: time.UnixMilli(bo.Time),
+		}
+		e.orders[orderID] = order
+	}
+	at := time.Now()
+	if bo.UpdateTime > 0 {
+		at = time.UnixMilli(bo.UpdateTime)
+	}
+
+	delta := executedQty - e.fillsApplied[orderID]
+	var fillPrice float64
+	if delta > 0 {
+		// Price the unapplied quantity from the quote not yet accounted
+		// for, so the order average stays right across partial fills
+		appliedQuote := order.FilledQuantity * order.AvgFillPrice
+		avgPrice := quoteQty / executedQty
+		fillPrice = (quoteQty - appliedQuote) / delta
+		if fillPrice <= 0 || order.FilledQuantity > e.fillsApplied[orderID] {
+			fillPrice = avgPrice
+		}
+
+		order.FilledQuantity = executedQty
+		order.AvgFillPrice = avgPrice
+	}
+
+	if err := e.transition(order, mapOrderStatus(string(bo.Status)), at, "reconciled"); err != nil {
+		log.Debug().Err(err).Str("orderID", orderID).Msg("Ignoring stale order status")
+	}
+	if delta <= 0 {
+		return order, nil, nil
+	}
+
+	// Order queries carry no commission; it is only reported with fills
+	trade, position := e.applyFill(order, executedQty, fillPrice, 0)
+
+	log.Info().
+		Str("orderID", orderID).
+		Str("symbol", order.Symbol).
+		Str("side", string(order.Side)).
+		Float64("quantity", delta).
+		Float64("price", fillPrice).
+		Float64("filled", executedQty).
+		Float64("orderQty", order.Quantity).
+		Str("status", string(order.Status)).
+		Msg("Order fill reconciled from Binance")
+
+	return order, trade, position
+}
+
+// reconcileClosedOrders settles working orders that are no longer open on
+// the exchange, applying fills whose reports were missed and recording the
+// final status and unfilled remainder (e.mu must be held)
+func (e *LiveExecutor) reconcileClosedOrders(symbol string, open map[string]bool) {
+	for orderID, order := range e.orders {
+		if order.Symbol != symbol || open[orderID] {
+			continue
+		}
+		if !order.Status.IsWorking() {
+			continue
+		}
+
+		id, err := strconv.ParseInt(orderID, 10, 64)
+		if err != nil {
+			continue
+		}
+		bo, err := e.client.GetOrder(symbol, id)
+		if err != nil {
+			log.Warn().Err(err).Str("orderID", orderID).Msg("Failed to reconcile order")
+			continue
+		}
+		e.applyExchangeOrder(bo, "")
+
+		if order.Status != OrderStatusFilled {
+			log.Info().
+				Str("orderID", orderID).
+				Str("symbol", symbol).
+				Str("status", string(order.Status)).
+				Float64("filled", order.FilledQuantity).
+				Float64("remaining", order.RemainingQuantity()).
+				Msg("Order closed on Binance with unfilled quantity")
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTransition is returned for an order status change the order
+// lifecycle does not allow, e.g. out of a terminal state
+var ErrInvalidTransition = errors.New("invalid order transition")
+
+// orderTransitions lists the statuses each status may move to. An order
+// starts with no status; statuses without an entry are terminal. Orders
+// first seen on the exchange may start in any status.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	"": {
+		OrderStatusPending, OrderStatusOpen, OrderStatusPartial, OrderStatusFilled,
+		OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired,
+	},
+	OrderStatusPending: {
+		OrderStatusOpen, OrderStatusPartial, OrderStatusFilled,
+		OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired,
+	},
+	OrderStatusOpen:    {OrderStatusPartial, OrderStatusFilled, OrderStatusCanceled, OrderStatusExpired},
+	OrderStatusPartial: {OrderStatusFilled, OrderStatusCanceled, OrderStatusExpired},
+}
+
+// IsTerminal reports whether an order in this status can no longer change
+func (s OrderStatus) IsTerminal() bool {
+	_, ok := orderTransitions[s]
+	return !ok
+}
+
+// IsWorking reports whether an order in this status may still fill
+func (s OrderStatus) IsWorking() bool {
+	return s == OrderStatusPending || s == OrderStatusOpen || s == OrderStatusPartial
+}
+
+// CanTransition reports whether an order may move from s to status to
+func (s OrderStatus) CanTransition(to OrderStatus) bool {
+	for _, next := range orderTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderTransition records one status change of an order
+type OrderTransition struct {
+	From   OrderStatus
+	To     OrderStatus
+	At     time.Time
+	Reason string
+}
+
+// Transition moves the order to status to, recording when and why. Moving
+// to the current status is a no-op; invalid moves leave the order unchanged
+// and return ErrInvalidTransition.
+func (o *Order) Transition(to OrderStatus, at time.Time, reason string) error {
+	if to == o.Status {
+		return nil
+	}
+	if !o.Status.CanTransition(to) {
+		from := o.Status
+		if from == "" {
+			from = "NEW"
+		}
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+
+	o.Transitions = append(o.Transitions, OrderTransition{
+		From:   o.Status,
+		To:     to,
+		At:     at,
+		Reason: reason,
+	})
+	o.Status = to
+	o.UpdatedAt = at
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = at
+	}
+	if to == OrderStatusFilled {
+		o.FilledAt = at
+	}
+	return nil
+}
+
+// OrderUpdate reports an order status transition. Order is a copy taken
+// right after the transition.
+type OrderUpdate struct {
+	Order      Order
+	Transition OrderTransition
+}
+
+// orderLifecycle applies order transitions for an executor and reports each
+// one to the registered callback, in order
+type orderLifecycle struct {
+	mu      sync.RWMutex
+	onOrder func(OrderUpdate)
+}
+
+// SetOnOrder sets the order transition callback. It is called synchronously,
+// often with the executor locked, so it must not block or call back into
+// the executor.
+func (l *orderLifecycle) SetOnOrder(fn func(OrderUpdate)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onOrder = fn
+}
+
+// transition moves order to status to and reports the change
+func (l *orderLifecycle) transition(order *Order, to OrderStatus, at time.Time, reason string) error {
+	applied := len(order.Transitions)
+	if err := order.Transition(to, at, reason); err != nil {
+		return err
+	}
+	if len(order.Transitions) == applied {
+		return nil
+	}
+
+	l.mu.RLock()
+	fn := l.onOrder
+	l.mu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+
+	update := OrderUpdate{
+		Order:      *order,
+		Transition: order.Transitions[len(order.Transitions)-1],
+	}
+	update.Order.Transitions = append([]OrderTransition(nil), order.Transitions...)
+	fn(update)
+	return nil
+}
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -27,9 +28,24 @@ type PaperExecutor struct {
 	// Current prices (updated externally)
 	prices      map[string]float64
 
+	// Per-strategy capital budgets (nil = unrestricted)
+	strategyBudgets map[string]float64
+
+	// Parameter set version stamped on new positions
+	paramVersion int64
+
+	// Simulated exchange failures (nil = none)
+	chaos       *ChaosInjector
+
+	// Time stamped on orders, trades and positions
+	clock       Clock
+	// Last sequential ID issued (config.SequentialIDs)
+	lastID      int64
+
 	// Callbacks
 	onFill      func(FillEvent)
 	onPosition  func(PositionEvent)
+	orderLifecycle
 
 	mu sync.RWMutex
 	nextPosID int64
@@ -50,8 +66,12 @@ func NewPaperExecutor(config *ExecutorConfig) *PaperExecutor {
 		trades:    make([]*Trade, 0),
 		prices:    make(map[string]float64),
 		stats:     &TradeStats{},
+		clock:     config.Clock,
 		nextPosID: 1,
 	}
+	if pe.clock == nil {
+		pe.clock = SystemClock
+	}
 
 	// Initialize balance
 	pe.balance["USDT"] = config.InitialBalance
@@ -83,15 +103,42 @@ func (pe *PaperExecutor) SetOnPosition(fn func(PositionEvent)) {
 	pe.onPosition = fn
 }
 
+// Clock returns the executor's time source
+func (pe *PaperExecutor) Clock() Clock {
+	return pe.clock
+}
+
+// newID returns an ID for a new order or trade: random, or numbered per
+// executor when IDs must repeat across replays. The caller must hold pe.mu.
+func (pe *PaperExecutor) newID(kind string) string {
+	if !pe.config.SequentialIDs {
+		return uuid.New().String()
+	}
+	pe.lastID++
+	return fmt.Sprintf("paper-%s-%06d", kind, pe.lastID)
+}
+
+// UpdatePriceAt updates the price of a symbol as of the time t it was
+// traded at, advancing an event clock to t first
+func (pe *PaperExecutor) UpdatePriceAt(symbol string, price float64, t time.Time) {
+	if c, ok := pe.clock.(*EventClock); ok {
+		c.Advance(t)
+	}
+	pe.UpdatePrice(symbol, price)
+}
+
 // UpdatePrice updates current price for a symbol
 func (pe *PaperExecutor) UpdatePrice(symbol string, price float64) {
 	pe.mu.Lock()
 	pe.prices[symbol] = price
 
+	// Fill resting limit orders the price traded through
+	pe.fillRestingOrders(symbol, price)
+
 	// Update position P&L
 	if pos, exists := pe.positions[symbol]; exists {
 		pos.CurrentPrice = price
-		pos.UpdatedAt = time.Now()
+		pos.UpdatedAt = pe.clock.Now()
 
 		if pos.Side == PositionSideLong {
 			pos.UnrealizedPnL = (price - pos.EntryPrice) * pos.Quantity
@@ -147,26 +194,50 @@ func (pe *PaperExecutor) checkStopTakeProfit(pos *Position, price float64) {
 
 // PlaceOrder places a new order
 func (pe *PaperExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
+	start := time.Now()
+
+	// Simulated failures are applied before locking so a delayed fill does
+	// not stall price updates
+	fault, faulted := pe.nextFault()
+	if faulted {
+		switch fault {
+		case FaultRateLimit:
+			err := rateLimitError()
+			pe.transition(order, OrderStatusRejected, pe.clock.Now(), "rate limited (simulated outage)")
+			return &ExecutionResult{
+				Success: false,
+				Order:   order,
+				Error:   err,
+				Message: "Rate limited (simulated outage)",
+				Latency: time.Since(start),
+			}, err
+		case FaultDelayedFill:
+			time.Sleep(pe.chaos.fillDelay)
+		}
+	}
+
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
 
-	start := time.Now()
-
 	// Generate order ID
 	if order.ID == "" {
-		order.ID = uuid.New().String()
+		order.ID = pe.newID("order")
 	}
 	if order.ClientID == "" {
-		order.ClientID = fmt.Sprintf("paper_%d", time.Now().UnixNano())
+		if pe.config.SequentialIDs {
+			order.ClientID = "paper_" + order.ID
+		} else {
+			order.ClientID = fmt.Sprintf("paper_%d", time.Now().UnixNano())
+		}
 	}
 
-	order.CreatedAt = time.Now()
-	order.Status = OrderStatusPending
+	order.CreatedAt = pe.clock.Now()
+	pe.transition(order, OrderStatusPending, order.CreatedAt, "submitted")
 
 	// Get current price
 	price, ok := pe.prices[order.Symbol]
 	if !ok {
-		order.Status = OrderStatusRejected
+		pe.transition(order, OrderStatusRejected, pe.clock.Now(), "no price available")
 		return &ExecutionResult{
 			Success: false,
 			Order:   order,
@@ -176,13 +247,39 @@ func (pe *PaperExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 		}, fmt.Errorf("no price for symbol")
 	}
 
+	// A partial fill executes part of the quantity and expires the rest
+	partial := faulted && fault == FaultPartialFill && order.Type == OrderTypeMarket
+	requested := order.Quantity
+	if partial {
+		order.Quantity = pe.chaos.partialQuantity(requested)
+		defer func() { order.Quantity = requested }()
+	}
+
+	// Post-only orders that would trade at once are rejected, as on the
+	// exchange; other marketable limit orders fill now as takers
+	marketable := order.Type.IsLimit() && crosses(order, price)
+	if marketable && order.Type == OrderTypeLimitMaker {
+		pe.transition(order, OrderStatusRejected, pe.clock.Now(), "post-only order would take liquidity")
+		return &ExecutionResult{
+			Success: false,
+			Order:   order,
+			Error:   ErrWouldTakeLiquidity,
+			Message: "Post-only order would immediately match",
+			Latency: time.Since(start),
+		}, ErrWouldTakeLiquidity
+	}
+
 	// Determine execution price
 	execPrice := price
-	if order.Type == OrderTypeLimit {
+	rate := pe.config.Commission
+	if order.Type.IsLimit() && !marketable {
 		execPrice = order.Price
+		rate = pe.makerCommission()
 	} else if order.Type == OrderTypeMarket {
-		// Apply slippage
-		if order.Side == OrderSideBuy {
+		// Walk the order book while it is fresh, else apply flat slippage
+		if fill, ok := pe.bookFillPrice(order); ok {
+			execPrice = fill
+		} else if order.Side == OrderSideBuy {
 			execPrice = price * (1 + pe.config.Slippage)
 		} else {
 			execPrice = price * (1 - pe.config.Slippage)
@@ -191,14 +288,14 @@ func (pe *PaperExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 
 	// Calculate order value
 	orderValue := order.Quantity * execPrice
-	commission := orderValue * pe.config.Commission
+	commission := orderValue * rate
 
 	// Check balance
 	if order.Side == OrderSideBuy {
 		available := pe.balance["USDT"]
 		required := orderValue + commission
 		if available < required {
-			order.Status = OrderStatusRejected
+			pe.transition(order, OrderStatusRejected, pe.clock.Now(), "insufficient balance")
 			return &ExecutionResult{
 				Success: false,
 				Order:   order,
@@ -209,14 +306,35 @@ func (pe *PaperExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 		}
 	}
 
-	// Execute order immediately (market orders)
-	if order.Type == OrderTypeMarket {
-		return pe.executeOrder(order, execPrice, commission, start)
+	// Check the strategy's own buying power for orders that add exposure
+	if _, budgeted := pe.strategyBudgets[order.Strategy]; budgeted && pe.increasesExposure(order) {
+		buyingPower := pe.strategyBuyingPowerLocked(order.Strategy)
+		if orderValue > buyingPower {
+			pe.transition(order, OrderStatusRejected, pe.clock.Now(), "insufficient strategy buying power")
+			return &ExecutionResult{
+				Success: false,
+				Order:   order,
+				Error:   fmt.Errorf("insufficient buying power for %s: have %.2f, need %.2f", order.Strategy, buyingPower, orderValue),
+				Message: "Insufficient strategy buying power",
+				Latency: time.Since(start),
+			}, nil
+		}
+	}
+
+	// Execute order immediately (market and marketable limit orders)
+	if order.Type == OrderTypeMarket || marketable {
+		result, err := pe.executeOrder(order, execPrice, commission, partial, start)
+		if partial && result != nil && result.Success {
+			order.Quantity = requested
+			pe.transition(order, OrderStatusExpired, pe.clock.Now(), "unfilled quantity expired (simulated outage)")
+			result.Message = "Order partially filled (simulated outage)"
+		}
+		return result, err
 	}
 
 	// Store limit order
 	pe.orders[order.ID] = order
-	order.Status = OrderStatusOpen
+	pe.transition(order, OrderStatusOpen, pe.clock.Now(), "resting")
 
 	return &ExecutionResult{
 		Success: true,
@@ -226,15 +344,68 @@ func (pe *PaperExecutor) PlaceOrder(order *Order) (*ExecutionResult, error) {
 	}, nil
 }
 
-// executeOrder executes an order
-func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float64, start time.Time) (*ExecutionResult, error) {
+// crosses reports whether a limit order is priced through the last trade,
+// so it would match on arrival
+func crosses(order *Order, price float64) bool {
+	if order.Side == OrderSideBuy {
+		return order.Price > price
+	}
+	return order.Price < price
+}
+
+// makerCommission returns the commission rate of resting limit fills
+func (pe *PaperExecutor) makerCommission() float64 {
+	if pe.config.MakerCommission > 0 {
+		return pe.config.MakerCommission
+	}
+	return pe.config.Commission
+}
+
+// fillRestingOrders fills the open limit orders on symbol that price traded
+// through, at their limit price as makers. The queue ahead of an order is
+// unknown, so trading at the limit price alone does not fill it. Callers
+// hold pe.mu.
+func (pe *PaperExecutor) fillRestingOrders(symbol string, price float64) {
+	var filled []*Order
+	for _, order := range pe.orders {
+		if order.Symbol != symbol || order.Status != OrderStatusOpen || !order.Type.IsLimit() {
+			continue
+		}
+		if (order.Side == OrderSideBuy && price < order.Price) || (order.Side == OrderSideSell && price > order.Price) {
+			filled = append(filled, order)
+		}
+	}
+	// Oldest first, so replays number trades identically
+	sort.Slice(filled, func(i, j int) bool {
+		if !filled[i].CreatedAt.Equal(filled[j].CreatedAt) {
+			return filled[i].CreatedAt.Before(filled[j].CreatedAt)
+		}
+		return filled[i].ID < filled[j].ID
+	})
+
+	for _, order := range filled {
+		orderValue := order.Quantity * order.Price
+		commission := orderValue * pe.makerCommission()
+		if order.Side == OrderSideBuy && pe.balance["USDT"] < orderValue+commission {
+			pe.transition(order, OrderStatusExpired, pe.clock.Now(), "insufficient balance at fill")
+			continue
+		}
+		pe.executeOrder(order, order.Price, commission, false, time.Now())
+	}
+}
+
+// executeOrder executes an order. A partial order fills its quantity but
+// stays partially filled, for the caller to expire the remainder.
+func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float64, partial bool, start time.Time) (*ExecutionResult, error) {
 	order.FilledQuantity = order.Quantity
 	order.AvgFillPrice = execPrice
 	order.Commission = commission
 	order.CommissionAsset = "USDT"
-	order.Status = OrderStatusFilled
-	order.FilledAt = time.Now()
-	order.UpdatedAt = time.Now()
+	if partial {
+		pe.transition(order, OrderStatusPartial, pe.clock.Now(), "partially filled")
+	} else {
+		pe.transition(order, OrderStatusFilled, pe.clock.Now(), "filled")
+	}
 
 	// Update balance
 	orderValue := order.Quantity * execPrice
@@ -249,7 +420,7 @@ func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float6
 
 	// Create trade record
 	trade := &Trade{
-		ID:              uuid.New().String(),
+		ID:              pe.newID("trade"),
 		OrderID:         order.ID,
 		Symbol:          order.Symbol,
 		Side:            order.Side,
@@ -258,7 +429,8 @@ func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float6
 		Commission:      commission,
 		CommissionAsset: "USDT",
 		Strategy:        order.Strategy,
-		ExecutedAt:      time.Now(),
+		ParamVersion:    pe.paramVersion,
+		ExecutedAt:      pe.clock.Now(),
 	}
 
 	// Handle position
@@ -292,7 +464,7 @@ func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float6
 			Quantity:   order.Quantity,
 			Price:      execPrice,
 			Commission: commission,
-			Timestamp:  time.Now(),
+			Timestamp:  pe.clock.Now(),
 		})
 	}
 
@@ -301,7 +473,7 @@ func (pe *PaperExecutor) executeOrder(order *Order, execPrice, commission float6
 			Type:      posEvent,
 			Position:  position,
 			Trade:     trade,
-			Timestamp: time.Now(),
+			Timestamp: pe.clock.Now(),
 		})
 	}
 
@@ -339,7 +511,9 @@ func (pe *PaperExecutor) handleExistingPosition(pos *Position, order *Order, tra
 			pnl = (pos.EntryPrice - execPrice) * order.Quantity
 		}
 
+		// P&L belongs to the parameters the position was opened under
 		trade.RealizedPnL = pnl
+		trade.ParamVersion = pos.ParamVersion
 		pos.RealizedPnL += pnl
 		pe.totalPnL += pnl
 
@@ -353,7 +527,7 @@ func (pe *PaperExecutor) handleExistingPosition(pos *Position, order *Order, tra
 		} else {
 			// Partial close
 			pos.Quantity -= order.Quantity
-			pos.UpdatedAt = time.Now()
+			pos.UpdatedAt = pe.clock.Now()
 			return pos, PositionEventUpdated
 		}
 	} else {
@@ -361,7 +535,7 @@ func (pe *PaperExecutor) handleExistingPosition(pos *Position, order *Order, tra
 		totalQty := pos.Quantity + order.Quantity
 		pos.EntryPrice = (pos.EntryPrice*pos.Quantity + execPrice*order.Quantity) / totalQty
 		pos.Quantity = totalQty
-		pos.UpdatedAt = time.Now()
+		pos.UpdatedAt = pe.clock.Now()
 		pos.Orders = append(pos.Orders, order.ID)
 		return pos, PositionEventUpdated
 	}
@@ -384,8 +558,9 @@ func (pe *PaperExecutor) openNewPosition(order *Order, trade *Trade, execPrice f
 		EntryPrice:   execPrice,
 		CurrentPrice: execPrice,
 		Strategy:     order.Strategy,
-		OpenTime:     time.Now(),
-		UpdatedAt:    time.Now(),
+		ParamVersion: trade.ParamVersion,
+		OpenTime:     pe.clock.Now(),
+		UpdatedAt:    pe.clock.Now(),
 		Orders:       []string{order.ID},
 	}
 
@@ -467,13 +642,13 @@ func (pe *PaperExecutor) closePositionInternal(positionID int64, price float64,
 	}
 
 	order := &Order{
-		ID:        uuid.New().String(),
+		ID:        pe.newID("order"),
 		Symbol:    symbol,
 		Side:      side,
 		Type:      OrderTypeMarket,
 		Quantity:  targetPos.Quantity,
 		Strategy:  targetPos.Strategy,
-		CreatedAt: time.Now(),
+		CreatedAt: pe.clock.Now(),
 	}
 
 	// Calculate P&L
@@ -488,17 +663,18 @@ func (pe *PaperExecutor) closePositionInternal(positionID int64, price float64,
 
 	// Create trade
 	trade := &Trade{
-		ID:          uuid.New().String(),
-		OrderID:     order.ID,
-		PositionID:  positionID,
-		Symbol:      symbol,
-		Side:        side,
-		Quantity:    targetPos.Quantity,
-		Price:       price,
-		Commission:  commission,
-		RealizedPnL: pnl,
-		Strategy:    targetPos.Strategy,
-		ExecutedAt:  time.Now(),
+		ID:           pe.newID("trade"),
+		OrderID:      order.ID,
+		PositionID:   positionID,
+		Symbol:       symbol,
+		Side:         side,
+		Quantity:     targetPos.Quantity,
+		Price:        price,
+		Commission:   commission,
+		RealizedPnL:  pnl,
+		Strategy:     targetPos.Strategy,
+		ParamVersion: targetPos.ParamVersion,
+		ExecutedAt:   pe.clock.Now(),
 	}
 
 	// Update balance
@@ -519,11 +695,10 @@ func (pe *PaperExecutor) closePositionInternal(positionID int64, price float64,
 	delete(pe.positions, symbol)
 
 	// Store records
-	order.Status = OrderStatusFilled
 	order.FilledQuantity = order.Quantity
 	order.AvgFillPrice = price
 	order.Commission = commission
-	order.FilledAt = time.Now()
+	pe.transition(order, OrderStatusFilled, pe.clock.Now(), eventType.String())
 
 	pe.orders[order.ID] = order
 	pe.trades = append(pe.trades, trade)
@@ -534,7 +709,7 @@ func (pe *PaperExecutor) closePositionInternal(positionID int64, price float64,
 			Type:      eventType,
 			Position:  targetPos,
 			Trade:     trade,
-			Timestamp: time.Now(),
+			Timestamp: pe.clock.Now(),
 		})
 	}
 
@@ -556,17 +731,15 @@ func (pe *PaperExecutor) CancelOrder(orderID string) error {
 		return fmt.Errorf("order not found: %s", orderID)
 	}
 
-	if order.Status != OrderStatusOpen && order.Status != OrderStatusPending {
+	if !order.Status.CanTransition(OrderStatusCanceled) {
 		return fmt.Errorf("order cannot be canceled: %s", order.Status)
 	}
-
-	order.Status = OrderStatusCanceled
-	order.UpdatedAt = time.Now()
+	pe.transition(order, OrderStatusCanceled, pe.clock.Now(), "canceled by request")
 
 	return nil
 }
 
-// GetOrder returns order by ID
+// GetOrder returns a copy of the order with the given ID
 func (pe *PaperExecutor) GetOrder(orderID string) (*Order, error) {
 	pe.mu.RLock()
 	defer pe.mu.RUnlock()
@@ -576,7 +749,8 @@ func (pe *PaperExecutor) GetOrder(orderID string) (*Order, error) {
 		return nil, fmt.Errorf("order not found: %s", orderID)
 	}
 
-	return order, nil
+	copied := *order
+	return &copied, nil
 }
 
 // GetOpenOrders returns all open orders
@@ -658,7 +832,7 @@ func (pe *PaperExecutor) UpdateStopLoss(positionID int64, stopLoss float64) erro
 	for _, pos := range pe.positions {
 		if pos.ID == positionID {
 			pos.StopLoss = stopLoss
-			pos.UpdatedAt = time.Now()
+			pos.UpdatedAt = pe.clock.Now()
 			return nil
 		}
 	}
@@ -674,7 +848,7 @@ func (pe *PaperExecutor) UpdateTakeProfit(positionID int64, takeProfit float64)
 	for _, pos := range pe.positions {
 		if pos.ID == positionID {
 			pos.TakeProfit = takeProfit
-			pos.UpdatedAt = time.Now()
+			pos.UpdatedAt = pe.clock.Now()
 			return nil
 		}
 	}
@@ -691,16 +865,21 @@ func (pe *PaperExecutor) GetBalance(asset string) (free, locked float64, err err
 	return free, 0, nil
 }
 
-// GetEquity returns total equity
+// GetEquity returns total equity: cash plus open positions marked to market
 func (pe *PaperExecutor) GetEquity() (float64, error) {
 	pe.mu.RLock()
 	defer pe.mu.RUnlock()
 
 	equity := pe.balance["USDT"]
 
-	// Add unrealized P&L
+	// Entries moved their notional in or out of cash; add it back with the
+	// unrealized P&L
 	for _, pos := range pe.positions {
-		equity += pos.UnrealizedPnL
+		notional := pos.EntryPrice * pos.Quantity
+		if pos.Side == PositionSideShort {
+			notional = -notional
+		}
+		equity += notional + pos.UnrealizedPnL
 	}
 
 	return equity, nil
@@ -744,6 +923,107 @@ func (pe *PaperExecutor) GetAccountSummary() AccountSummary {
 	}
 }
 
+// SetStrategyBudgets sets the capital each strategy may deploy. Strategies
+// without a budget are not limited. Passing nil removes all limits.
+func (pe *PaperExecutor) SetStrategyBudgets(budgets map[string]float64) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	if budgets == nil {
+		pe.strategyBudgets = nil
+		return
+	}
+	pe.strategyBudgets = make(map[string]float64, len(budgets))
+	for k, v := range budgets {
+		pe.strategyBudgets[k] = v
+	}
+}
+
+// bookFillPrice prices a market order against the configured order book
+func (pe *PaperExecutor) bookFillPrice(order *Order) (float64, bool) {
+	if pe.config.Book == nil {
+		return 0, false
+	}
+	return pe.config.Book.EstimateFill(order.Symbol, order.Side == OrderSideBuy, order.Quantity)
+}
+
+// SetChaos enables simulated exchange failures for outage drills
+func (pe *PaperExecutor) SetChaos(chaos *ChaosInjector) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.chaos = chaos
+}
+
+// nextFault pops the simulated failure for the next order, if any
+func (pe *PaperExecutor) nextFault() (OrderFault, bool) {
+	pe.mu.RLock()
+	chaos := pe.chaos
+	pe.mu.RUnlock()
+	if chaos == nil {
+		return "", false
+	}
+	return chaos.next()
+}
+
+// SetParamVersion sets the parameter set version stamped on positions
+// opened from now on
+func (pe *PaperExecutor) SetParamVersion(version int64) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.paramVersion = version
+}
+
+// GetStrategyBuyingPower returns the capital a strategy can still deploy
+// and whether a budget is set for it
+func (pe *PaperExecutor) GetStrategyBuyingPower(strategy string) (float64, bool) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	if _, ok := pe.strategyBudgets[strategy]; !ok {
+		return 0, false
+	}
+	return pe.strategyBuyingPowerLocked(strategy), true
+}
+
+// GetStrategyExposure returns the entry notional of open positions per strategy
+func (pe *PaperExecutor) GetStrategyExposure() map[string]float64 {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	exposure := make(map[string]float64)
+	for _, pos := range pe.positions {
+		exposure[pos.Strategy] += pos.EntryPrice * pos.Quantity
+	}
+	return exposure
+}
+
+// strategyBuyingPowerLocked returns remaining budget for a strategy (pe.mu must be held).
+// Strategies without a budget get no buying power once budgets are set.
+func (pe *PaperExecutor) strategyBuyingPowerLocked(strategy string) float64 {
+	budget := pe.strategyBudgets[strategy]
+	for _, pos := range pe.positions {
+		if pos.Strategy == strategy {
+			budget -= pos.EntryPrice * pos.Quantity
+		}
+	}
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// increasesExposure reports whether an order opens or adds to a position (pe.mu must be held)
+func (pe *PaperExecutor) increasesExposure(order *Order) bool {
+	pos, exists := pe.positions[order.Symbol]
+	if !exists {
+		return true
+	}
+	if pos.Side == PositionSideLong {
+		return order.Side == OrderSideBuy
+	}
+	return order.Side == OrderSideSell
+}
+
 // GetTrades returns all trades
 func (pe *PaperExecutor) GetTrades() []*Trade {
 	pe.mu.RLock()
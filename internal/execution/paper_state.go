@@ -0,0 +1,136 @@
+package execution
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// paperStateMaxTrades bounds the trade history kept in a snapshot
+const paperStateMaxTrades = 1000
+
+// PaperState is a serializable snapshot of a paper account, so long-running
+// paper tests survive restarts
+type PaperState struct {
+	Balance         map[string]float64 `json:"balance"`
+	Positions       []Position         `json:"positions"`
+	OpenOrders      []Order            `json:"openOrders"`
+	Trades          []Trade            `json:"trades"` // Most recent paperStateMaxTrades
+	Stats           TradeStats         `json:"stats"`
+	TotalPnL        float64            `json:"totalPnl"`
+	TotalCommission float64            `json:"totalCommission"`
+	Prices          map[string]float64 `json:"prices"`
+	NextPositionID  int64              `json:"nextPositionId"`
+	LastID          int64              `json:"lastId,omitempty"` // Last sequential order/trade ID issued
+	InitialBalance  float64            `json:"initialBalance"`   // Configured balance the account started from
+	SavedAt         time.Time          `json:"savedAt"`
+}
+
+// Snapshot returns a copy of the paper account state
+func (pe *PaperExecutor) Snapshot() PaperState {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	state := PaperState{
+		Balance:         make(map[string]float64, len(pe.balance)),
+		Positions:       make([]Position, 0, len(pe.positions)),
+		Stats:           *pe.stats,
+		TotalPnL:        pe.totalPnL,
+		TotalCommission: pe.totalCommission,
+		Prices:          make(map[string]float64, len(pe.prices)),
+		NextPositionID:  pe.nextPosID,
+		LastID:          pe.lastID,
+		InitialBalance:  pe.config.InitialBalance,
+		SavedAt:         pe.clock.Now(),
+	}
+	for asset, amount := range pe.balance {
+		state.Balance[asset] = amount
+	}
+	for symbol, price := range pe.prices {
+		state.Prices[symbol] = price
+	}
+	for _, pos := range pe.positions {
+		p := *pos
+		p.Orders = append([]string(nil), pos.Orders...)
+		state.Positions = append(state.Positions, p)
+	}
+	for _, order := range pe.orders {
+		if order.Status == OrderStatusOpen || order.Status == OrderStatusPending {
+			state.OpenOrders = append(state.OpenOrders, *order)
+		}
+	}
+
+	trades := pe.trades
+	if len(trades) > paperStateMaxTrades {
+		trades = trades[len(trades)-paperStateMaxTrades:]
+	}
+	state.Trades = make([]Trade, len(trades))
+	for i, t := range trades {
+		state.Trades[i] = *t
+	}
+
+	return state
+}
+
+// Restore replaces the paper account state with a snapshot
+func (pe *PaperExecutor) Restore(state PaperState) error {
+	if len(state.Balance) == 0 {
+		return fmt.Errorf("paper state has no balances")
+	}
+
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	pe.balance = make(map[string]float64, len(state.Balance))
+	for asset, amount := range state.Balance {
+		pe.balance[asset] = amount
+	}
+
+	nextPosID := state.NextPositionID
+	pe.positions = make(map[string]*Position, len(state.Positions))
+	for i := range state.Positions {
+		pos := state.Positions[i]
+		pe.positions[pos.Symbol] = &pos
+		if pos.ID >= nextPosID {
+			nextPosID = pos.ID + 1
+		}
+	}
+	if nextPosID < 1 {
+		nextPosID = 1
+	}
+	pe.nextPosID = nextPosID
+	pe.lastID = state.LastID
+
+	pe.orders = make(map[string]*Order, len(state.OpenOrders))
+	for i := range state.OpenOrders {
+		order := state.OpenOrders[i]
+		pe.orders[order.ID] = &order
+	}
+
+	pe.trades = make([]*Trade, len(state.Trades))
+	for i := range state.Trades {
+		trade := state.Trades[i]
+		pe.trades[i] = &trade
+	}
+
+	stats := state.Stats
+	pe.stats = &stats
+	pe.totalPnL = state.TotalPnL
+	pe.totalCommission = state.TotalCommission
+
+	for symbol, price := range state.Prices {
+		if _, known := pe.prices[symbol]; !known {
+			pe.prices[symbol] = price
+		}
+	}
+
+	log.Info().
+		Float64("balance", pe.balance["USDT"]).
+		Int("positions", len(pe.positions)).
+		Int("trades", len(pe.trades)).
+		Time("savedAt", state.SavedAt).
+		Msg("Paper account restored")
+
+	return nil
+}
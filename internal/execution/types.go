@@ -1,8 +1,10 @@
 package execution
 
 import (
+	"errors"
 	"time"
 
+	"github.com/eth-trading/internal/binance"
 	"github.com/eth-trading/internal/strategy"
 )
 
@@ -31,10 +33,16 @@ type OrderType string
 const (
 	OrderTypeMarket     OrderType = "MARKET"
 	OrderTypeLimit      OrderType = "LIMIT"
+	OrderTypeLimitMaker OrderType = "LIMIT_MAKER" // Post-only limit, rejected rather than taking liquidity
 	OrderTypeStopLoss   OrderType = "STOP_LOSS"
 	OrderTypeTakeProfit OrderType = "TAKE_PROFIT"
 )
 
+// IsLimit reports whether orders of this type rest on the book at a price
+func (t OrderType) IsLimit() bool {
+	return t == OrderTypeLimit || t == OrderTypeLimitMaker
+}
+
 // OrderSide represents order side
 type OrderSide string
 
@@ -76,6 +84,15 @@ type Order struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	FilledAt        time.Time
+	Transitions     []OrderTransition // Status history, oldest first
+}
+
+// RemainingQuantity returns the quantity not yet filled
+func (o *Order) RemainingQuantity() float64 {
+	if remaining := o.Quantity - o.FilledQuantity; remaining > 0 {
+		return remaining
+	}
+	return 0
 }
 
 // Position represents an open position
@@ -93,6 +110,7 @@ type Position struct {
 	RealizedPnL      float64
 	Commission       float64
 	Strategy         string
+	ParamVersion     int64 // Parameter set version the position was opened under
 	OpenTime         time.Time
 	UpdatedAt        time.Time
 	Orders           []string // Order IDs associated with position
@@ -119,6 +137,7 @@ type Trade struct {
 	CommissionAsset string
 	RealizedPnL     float64
 	Strategy        string
+	ParamVersion    int64 // Parameter set version of the position's entry
 	ExecutedAt      time.Time
 }
 
@@ -144,7 +163,7 @@ type Executor interface {
 	// CancelOrder cancels an existing order
 	CancelOrder(orderID string) error
 
-	// GetOrder returns order by ID
+	// GetOrder returns a copy of the order with the given ID
 	GetOrder(orderID string) (*Order, error)
 
 	// GetOpenOrders returns all open orders
@@ -175,6 +194,17 @@ type Executor interface {
 	Sync() error
 }
 
+// ErrWouldTakeLiquidity is returned when a post-only order would match
+// immediately instead of resting on the book
+var ErrWouldTakeLiquidity = errors.New("post-only order would immediately match")
+
+// FillEstimator prices simulated market orders against an order book
+type FillEstimator interface {
+	// EstimateFill returns the average price a market order for quantity
+	// would fill at, and false when the book cannot price it
+	EstimateFill(symbol string, buy bool, quantity float64) (float64, bool)
+}
+
 // ExecutorConfig holds executor configuration
 type ExecutorConfig struct {
 	Mode              ExecutionMode
@@ -182,17 +212,39 @@ type ExecutorConfig struct {
 
 	// Paper trading
 	InitialBalance    float64
-	Commission        float64 // Commission rate (e.g., 0.001 = 0.1%)
-	Slippage          float64 // Slippage rate
+	Commission        float64       // Commission rate (e.g., 0.001 = 0.1%)
+	MakerCommission   float64       // Rate for resting limit fills; 0 = Commission
+	Slippage          float64       // Slippage rate
+	Clock             Clock         // Time stamped on orders and trades; nil = wall clock
+	Book              FillEstimator // Order book market orders fill against when fresh; nil = flat Slippage
+	SequentialIDs     bool          // Number orders and trades instead of random UUIDs, for reproducible logs
 
 	// Live trading
 	APIKey            string
 	SecretKey         string
 	Testnet           bool
+	DustMinValue      float64 // Balances worth less (USDT) are dust; 0 = exchange min notional
+	DustExcludeEquity bool    // Leave dust balances out of equity
+
+	// Futures (USD-M)
+	Leverage          int     // Leverage set on the symbol
+	MarginType        string  // "ISOLATED" or "CROSSED"
+	HedgeMode         bool    // Separate long and short positions per symbol
+	MaxFundingRate    float64 // Skip entries paying more than this rate per funding; 0 = no limit
 
 	// General
-	MaxRetries        int
-	RetryDelay        time.Duration
+	MaxRetries        int           // REST retries after the first attempt; negative disables
+	RetryDelay        time.Duration // Backoff before the first retry
+	RetryMaxDelay     time.Duration // Backoff cap
+}
+
+// retryPolicy returns the Binance REST retry policy for the executor
+func (c *ExecutorConfig) retryPolicy() binance.RetryPolicy {
+	return binance.RetryPolicy{
+		MaxRetries: c.MaxRetries,
+		BaseDelay:  c.RetryDelay,
+		MaxDelay:   c.RetryMaxDelay,
+	}
 }
 
 // DefaultExecutorConfig returns default configuration
@@ -298,6 +298,28 @@ func (kc *KeltnerChannel) Calculate(highs, lows, closes []float64) KeltnerData {
 	return result
 }
 
+// Latest calculates Keltner Channels for the latest bar
+func (kc *KeltnerChannel) Latest(highs, lows, closes []float64) KeltnerResult {
+	data := kc.Calculate(highs, lows, closes)
+	if len(data.Middle) == 0 {
+		return KeltnerResult{}
+	}
+
+	idx := len(data.Middle) - 1
+	return keltnerResult(data.Upper[idx], data.Middle[idx], data.Lower[idx], closes[len(closes)-1])
+}
+
+// keltnerResult derives the Keltner result from the bands and the close
+func keltnerResult(upper, middle, lower, close float64) KeltnerResult {
+	result := KeltnerResult{Upper: upper, Middle: middle, Lower: lower}
+	if close > upper {
+		result.Breakout = BreakoutUpper
+	} else if close < lower {
+		result.Breakout = BreakoutLower
+	}
+	return result
+}
+
 // TTMSqueeze detects TTM Squeeze (BB inside KC)
 func TTMSqueeze(highs, lows, closes []float64, bbPeriod int, bbStdDev float64, kcPeriod int, kcMultiplier float64) (squeeze bool, momentum float64) {
 	if len(closes) < bbPeriod || len(closes) < kcPeriod {
@@ -0,0 +1,79 @@
+package indicators
+
+// Ichimoku calculates the Ichimoku cloud
+type Ichimoku struct {
+	tenkanPeriod  int
+	kijunPeriod   int // Also the cloud's displacement
+	senkouBPeriod int
+}
+
+// NewIchimoku creates a new Ichimoku calculator
+func NewIchimoku(tenkanPeriod, kijunPeriod, senkouBPeriod int) *Ichimoku {
+	if tenkanPeriod <= 0 {
+		tenkanPeriod = 9
+	}
+	if kijunPeriod <= 0 {
+		kijunPeriod = 26
+	}
+	if senkouBPeriod <= 0 {
+		senkouBPeriod = 52
+	}
+	return &Ichimoku{
+		tenkanPeriod:  tenkanPeriod,
+		kijunPeriod:   kijunPeriod,
+		senkouBPeriod: senkouBPeriod,
+	}
+}
+
+// Calculate calculates the Ichimoku lines for the latest bar, with the
+// cloud projected onto it from kijunPeriod bars back. Only the last
+// senkouBPeriod+kijunPeriod bars are read.
+func (ic *Ichimoku) Calculate(highs, lows, closes []float64) IchimokuResult {
+	n := len(closes)
+	if n < ic.senkouBPeriod+ic.kijunPeriod || len(highs) != n || len(lows) != n {
+		return IchimokuResult{}
+	}
+
+	last := n - 1
+	tenkan := midpoint(highs, lows, last, ic.tenkanPeriod)
+	kijun := midpoint(highs, lows, last, ic.kijunPeriod)
+
+	// Spans computed displacement bars ago lie under the latest bar
+	projected := last - ic.kijunPeriod
+	senkouA := (midpoint(highs, lows, projected, ic.tenkanPeriod) + midpoint(highs, lows, projected, ic.kijunPeriod)) / 2
+	senkouB := midpoint(highs, lows, projected, ic.senkouBPeriod)
+
+	result := IchimokuResult{
+		Tenkan:  tenkan,
+		Kijun:   kijun,
+		SenkouA: senkouA,
+		SenkouB: senkouB,
+	}
+
+	close := closes[last]
+	switch {
+	case close > MaxF(senkouA, senkouB):
+		result.Position = TrendUp
+	case close < MinF(senkouA, senkouB):
+		result.Position = TrendDown
+	default:
+		result.Position = TrendNeutral
+	}
+
+	// Tenkan/Kijun cross
+	prevTenkan := midpoint(highs, lows, last-1, ic.tenkanPeriod)
+	prevKijun := midpoint(highs, lows, last-1, ic.kijunPeriod)
+	if prevTenkan <= prevKijun && tenkan > kijun {
+		result.Crossover = CrossoverBullish
+	} else if prevTenkan >= prevKijun && tenkan < kijun {
+		result.Crossover = CrossoverBearish
+	}
+
+	return result
+}
+
+// midpoint returns the middle of the range of the period bars ending at end
+func midpoint(highs, lows []float64, end, period int) float64 {
+	start := end - period + 1
+	return (Max(highs[start:end+1]) + Min(lows[start:end+1])) / 2
+}
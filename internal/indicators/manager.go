@@ -9,14 +9,17 @@ type Manager struct {
 	config *IndicatorConfig
 
 	// Indicator instances
-	rsi      *RSI
-	macd     *MACD
-	bb       *BollingerBands
-	adx      *ADX
-	atr      *ATR
-	ma       *MovingAverage
-	volume   *VolumeAnalyzer
-	stoch    *Stochastic
+	rsi        *RSI
+	macd       *MACD
+	bb         *BollingerBands
+	adx        *ADX
+	atr        *ATR
+	ma         *MovingAverage
+	volume     *VolumeAnalyzer
+	stoch      *Stochastic
+	keltner    *KeltnerChannel
+	superTrend *SuperTrend
+	ichimoku   *Ichimoku
 
 	mu sync.RWMutex
 }
@@ -28,15 +31,18 @@ func NewManager(config *IndicatorConfig) *Manager {
 	}
 
 	return &Manager{
-		config:  config,
-		rsi:     NewRSI(config.RSIPeriod, config.RSIOverbought, config.RSIOversold),
-		macd:    NewMACD(config.MACDFast, config.MACDSlow, config.MACDSignal),
-		bb:      NewBollingerBands(config.BBPeriod, config.BBStdDev, config.BBSqueezeThreshold),
-		adx:     NewADX(config.ADXPeriod, config.ADXTrendingThreshold),
-		atr:     NewATR(config.ATRPeriod, config.ATRHighVolThreshold),
-		ma:      NewMovingAverage(config.MAShortPeriod, config.MAMediumPeriod, config.MALongPeriod, MATypeEMA),
-		volume:  NewVolumeAnalyzer(config.VolumePeriod, config.VolumeHighThreshold, config.VolumeLowThreshold),
-		stoch:   NewStochastic(config.StochKPeriod, config.StochDPeriod, config.StochSlowing, config.StochOverbought, config.StochOversold),
+		config:     config,
+		rsi:        NewRSI(config.RSIPeriod, config.RSIOverbought, config.RSIOversold),
+		macd:       NewMACD(config.MACDFast, config.MACDSlow, config.MACDSignal),
+		bb:         NewBollingerBands(config.BBPeriod, config.BBStdDev, config.BBSqueezeThreshold),
+		adx:        NewADX(config.ADXPeriod, config.ADXTrendingThreshold),
+		atr:        NewATR(config.ATRPeriod, config.ATRHighVolThreshold),
+		ma:         NewMovingAverage(config.MAShortPeriod, config.MAMediumPeriod, config.MALongPeriod, MATypeEMA),
+		volume:     NewVolumeAnalyzer(config.VolumePeriod, config.VolumeHighThreshold, config.VolumeLowThreshold),
+		stoch:      NewStochastic(config.StochKPeriod, config.StochDPeriod, config.StochSlowing, config.StochOverbought, config.StochOversold),
+		keltner:    NewKeltnerChannel(config.KeltnerPeriod, config.KeltnerMultiplier),
+		superTrend: NewSuperTrend(config.SuperTrendPeriod, config.SuperTrendMultiplier),
+		ichimoku:   NewIchimoku(config.IchimokuTenkan, config.IchimokuKijun, config.IchimokuSenkouB),
 	}
 }
 
@@ -50,6 +56,10 @@ type AnalysisResult struct {
 	MA         MAResult
 	Volume     VolumeResult
 	Stochastic StochResult
+	VWAP       VWAPResult
+	SuperTrend SuperTrendResult
+	Ichimoku   IchimokuResult
+	Keltner    KeltnerResult
 
 	// Derived signals
 	TrendStrength TrendStrength
@@ -79,8 +89,15 @@ func (v VolatilityLevel) String() string {
 	}
 }
 
-// Analyze performs complete technical analysis
+// Analyze performs complete technical analysis, with VWAP anchored at the
+// first bar passed
 func (m *Manager) Analyze(opens, highs, lows, closes, volumes []float64) AnalysisResult {
+	return m.AnalyzeSession(opens, highs, lows, closes, volumes, 0)
+}
+
+// AnalyzeSession performs complete technical analysis, with VWAP anchored
+// at the session's first bar, sessionBars from the end (see SessionBars)
+func (m *Manager) AnalyzeSession(opens, highs, lows, closes, volumes []float64, sessionBars int) AnalysisResult {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -119,6 +136,11 @@ func (m *Manager) Analyze(opens, highs, lows, closes, volumes []float64) Analysi
 		result.Stochastic = m.stoch.Calculate(highs, lows, closes)
 	}
 
+	result.VWAP = SessionVWAP(highs, lows, closes, volumes, sessionBars)
+	result.SuperTrend = m.superTrend.Calculate(highs, lows, closes)
+	result.Ichimoku = m.ichimoku.Calculate(highs, lows, closes)
+	result.Keltner = m.keltner.Latest(highs, lows, closes)
+
 	// Derive composite signals
 	result.TrendStrength = m.deriveTrendStrength(result)
 	result.TrendDir = m.deriveTrendDirection(result)
@@ -293,6 +315,9 @@ func (m *Manager) UpdateConfig(config *IndicatorConfig) {
 	m.ma = NewMovingAverage(config.MAShortPeriod, config.MAMediumPeriod, config.MALongPeriod, MATypeEMA)
 	m.volume = NewVolumeAnalyzer(config.VolumePeriod, config.VolumeHighThreshold, config.VolumeLowThreshold)
 	m.stoch = NewStochastic(config.StochKPeriod, config.StochDPeriod, config.StochSlowing, config.StochOverbought, config.StochOversold)
+	m.keltner = NewKeltnerChannel(config.KeltnerPeriod, config.KeltnerMultiplier)
+	m.superTrend = NewSuperTrend(config.SuperTrendPeriod, config.SuperTrendMultiplier)
+	m.ichimoku = NewIchimoku(config.IchimokuTenkan, config.IchimokuKijun, config.IchimokuSenkouB)
 }
 
 // QuickAnalysis performs a lightweight analysis for high-frequency updates
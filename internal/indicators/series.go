@@ -0,0 +1,120 @@
+package indicators
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Series column names for batch precomputation
+const (
+	SeriesRSI           = "rsi"
+	SeriesMACD          = "macd"
+	SeriesMACDSignal    = "macd_signal"
+	SeriesMACDHistogram = "macd_histogram"
+	SeriesBBUpper       = "bb_upper"
+	SeriesBBMiddle      = "bb_middle"
+	SeriesBBLower       = "bb_lower"
+	SeriesBBWidth       = "bb_width"
+	SeriesATR           = "atr"
+	SeriesADX           = "adx"
+	SeriesPlusDI        = "plus_di"
+	SeriesMinusDI       = "minus_di"
+	SeriesEMAShort      = "ema_short"
+	SeriesEMAMedium     = "ema_medium"
+	SeriesEMALong       = "ema_long"
+	SeriesOBV           = "obv"
+)
+
+// seriesFamily groups columns computed together, so selecting several
+// columns of one indicator runs it once
+var seriesFamily = map[string]string{
+	SeriesRSI:           "rsi",
+	SeriesMACD:          "macd",
+	SeriesMACDSignal:    "macd",
+	SeriesMACDHistogram: "macd",
+	SeriesBBUpper:       "bollinger",
+	SeriesBBMiddle:      "bollinger",
+	SeriesBBLower:       "bollinger",
+	SeriesBBWidth:       "bollinger",
+	SeriesATR:           "atr",
+	SeriesADX:           "adx",
+	SeriesPlusDI:        "adx",
+	SeriesMinusDI:       "adx",
+	SeriesEMAShort:      "ema_short",
+	SeriesEMAMedium:     "ema_medium",
+	SeriesEMALong:       "ema_long",
+	SeriesOBV:           "obv",
+}
+
+// ValidSeriesColumn reports whether ComputeSeries can produce the column
+func ValidSeriesColumn(name string) bool {
+	_, ok := seriesFamily[name]
+	return ok
+}
+
+// SeriesColumns returns every column ComputeSeries can produce, sorted
+func SeriesColumns() []string {
+	columns := make([]string, 0, len(seriesFamily))
+	for name := range seriesFamily {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// ComputeSeries computes the named indicator series over full OHLCV
+// history. As with the other series functions, each result is aligned to
+// the end of the input and omits leading bars without a value.
+func ComputeSeries(config *IndicatorConfig, columns []string, highs, lows, closes, volumes []float64) (map[string][]float64, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	families := make(map[string]bool)
+	for _, name := range columns {
+		if !ValidSeriesColumn(name) {
+			return nil, fmt.Errorf("unknown indicator series %q", name)
+		}
+		families[seriesFamily[name]] = true
+	}
+
+	all := make(map[string][]float64)
+	for family := range families {
+		switch family {
+		case "rsi":
+			all[SeriesRSI] = CalculateRSI(closes, config.RSIPeriod)
+		case "macd":
+			macd := CalculateMACD(closes, config.MACDFast, config.MACDSlow, config.MACDSignal)
+			all[SeriesMACD] = macd.MACD
+			all[SeriesMACDSignal] = macd.Signal
+			all[SeriesMACDHistogram] = macd.Histogram
+		case "bollinger":
+			bb := CalculateBollingerBands(closes, config.BBPeriod, config.BBStdDev)
+			all[SeriesBBUpper] = bb.Upper
+			all[SeriesBBMiddle] = bb.Middle
+			all[SeriesBBLower] = bb.Lower
+			all[SeriesBBWidth] = bb.Width
+		case "atr":
+			all[SeriesATR] = ATRSeries(highs, lows, closes, config.ATRPeriod)
+		case "adx":
+			adx := CalculateADX(highs, lows, closes, config.ADXPeriod)
+			all[SeriesADX] = adx.ADX
+			all[SeriesPlusDI] = adx.PlusDI
+			all[SeriesMinusDI] = adx.MinusDI
+		case "ema_short":
+			all[SeriesEMAShort] = EMA(closes, config.MAShortPeriod)
+		case "ema_medium":
+			all[SeriesEMAMedium] = EMA(closes, config.MAMediumPeriod)
+		case "ema_long":
+			all[SeriesEMALong] = EMA(closes, config.MALongPeriod)
+		case "obv":
+			all[SeriesOBV] = OBV(closes, volumes)
+		}
+	}
+
+	result := make(map[string][]float64, len(columns))
+	for _, name := range columns {
+		result[name] = all[name]
+	}
+	return result, nil
+}
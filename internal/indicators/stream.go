@@ -0,0 +1,536 @@
+package indicators
+
+// Stream analyzes a growing price series bar by bar. RSI, MACD, ADX, ATR,
+// the moving averages, the stochastic smoothing, SuperTrend, Keltner
+// Channels and the session VWAP carry their state from bar to bar instead
+// of being recomputed from the whole history; Bollinger Bands, volume,
+// Ichimoku and raw %K only read their window. Each result equals
+// Manager.AnalyzeSession over every bar passed so far.
+//
+// A Stream is not safe for concurrent use.
+type Stream struct {
+	manager *Manager
+	config  *IndicatorConfig
+	bars    int // Bars ingested
+
+	rsi   rsiStream
+	macd  macdStream
+	adx   adxStream
+	atr   atrStream
+	short emaStream // Moving averages
+	long  emaStream
+	stoch stochStream
+
+	keltnerMid emaStream
+	keltnerATR atrStream
+	superTrend superTrendStream
+	vwap       vwapStream
+}
+
+// NewStream creates a stream analyzing with the manager's indicators
+func (m *Manager) NewStream() *Stream {
+	s := &Stream{manager: m}
+	m.mu.RLock()
+	s.reset(m.config)
+	m.mu.RUnlock()
+	return s
+}
+
+// reset clears the stream's state for config, taking periods from the
+// manager's indicators as Analyze does
+func (s *Stream) reset(config *IndicatorConfig) {
+	m := s.manager
+	s.config = config
+	s.bars = 0
+	s.rsi = rsiStream{period: m.rsi.period}
+	s.macd = macdStream{
+		fast:   emaStream{period: m.macd.fastPeriod},
+		slow:   emaStream{period: m.macd.slowPeriod},
+		signal: emaStream{period: m.macd.signalPeriod},
+	}
+	s.adx = adxStream{
+		plusDM:  wilderStream{period: m.adx.period},
+		minusDM: wilderStream{period: m.adx.period},
+		tr:      wilderStream{period: m.adx.period},
+		adx:     wilderStream{period: m.adx.period},
+	}
+	s.atr = atrStream{period: m.atr.period}
+	s.short = emaStream{period: m.ma.shortPeriod}
+	s.long = emaStream{period: m.ma.longPeriod}
+	s.stoch = stochStream{
+		slowK: smaStream{period: m.stoch.slowing},
+		slowD: smaStream{period: m.stoch.dPeriod},
+	}
+	s.keltnerMid = emaStream{period: m.keltner.period}
+	s.keltnerATR = atrStream{period: m.keltner.period}
+	s.superTrend = superTrendStream{
+		atr:        atrStream{period: m.superTrend.period},
+		multiplier: m.superTrend.multiplier,
+	}
+	s.vwap = vwapStream{}
+}
+
+// Analyze ingests the bars added to the series since the last call and
+// analyzes it, with VWAP anchored at the first bar. Each call must pass
+// the series passed before extended by any number of bars; a shorter
+// series, or a change of the manager's configuration, starts the stream
+// over.
+func (s *Stream) Analyze(opens, highs, lows, closes, volumes []float64) AnalysisResult {
+	return s.AnalyzeSession(opens, highs, lows, closes, volumes, 0)
+}
+
+// AnalyzeSession is Analyze with VWAP anchored at the session's first bar,
+// sessionBars from the end, as for Manager.AnalyzeSession
+func (s *Stream) AnalyzeSession(opens, highs, lows, closes, volumes []float64, sessionBars int) AnalysisResult {
+	m := s.manager
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := len(closes)
+	if m.config != s.config || n < s.bars {
+		s.reset(m.config)
+	}
+	for ; s.bars < n; s.bars++ {
+		s.ingest(highs, lows, closes, s.bars)
+	}
+
+	result := AnalysisResult{}
+	if n == 0 {
+		return result
+	}
+	close := closes[n-1]
+
+	if n >= s.config.RSIPeriod+1 {
+		result.RSI = RSIResult{Value: 50}
+		if s.rsi.values > 0 {
+			result.RSI = RSIResult{
+				Value:        s.rsi.value,
+				IsOverbought: s.rsi.value >= m.rsi.overbought,
+				IsOversold:   s.rsi.value <= m.rsi.oversold,
+			}
+		}
+		if s.rsi.values > 1 {
+			result.RSI.Signal = m.rsi.getSignal(s.rsi.value, []float64{s.rsi.prev, s.rsi.value})
+		}
+	}
+
+	if n >= s.config.MACDSlow+s.config.MACDSignal && n >= m.macd.slowPeriod+m.macd.signalPeriod && s.macd.signal.ready() {
+		macd := s.macd
+		result.MACD = MACDResult{
+			MACD:      macd.line,
+			Signal:    macd.signal.value,
+			Histogram: macd.line - macd.signal.value,
+		}
+		if macd.signal.count > macd.signal.period {
+			result.MACD.Crossover = m.macd.detectCrossover(macd.line, macd.signal.value, macd.prevLine, macd.signal.prev)
+		}
+	}
+
+	if n >= s.config.BBPeriod && n >= m.bb.period {
+		result.Bollinger = m.bb.Calculate(closes[n-m.bb.period:])
+	}
+
+	if len(highs) >= s.config.ADXPeriod*2 && s.adx.adx.ready() {
+		adx := s.adx.adx.value
+		result.ADX = ADXResult{
+			ADX:       adx,
+			PlusDI:    s.adx.plusDI,
+			MinusDI:   s.adx.minusDI,
+			Trending:  adx >= m.adx.trendingThreshold,
+			Strength:  m.adx.getStrength(adx),
+			Direction: m.adx.getDirection(s.adx.plusDI, s.adx.minusDI),
+		}
+	}
+
+	if len(highs) >= s.config.ATRPeriod+1 && s.atr.count >= s.atr.period {
+		atrPercent := 0.0
+		if close > 0 {
+			atrPercent = (s.atr.value / close) * 100
+		}
+		result.ATR = ATRResult{
+			ATR:            s.atr.value,
+			ATRPercent:     atrPercent,
+			HighVolatility: atrPercent > m.atr.highVolThreshold,
+		}
+	}
+
+	if n >= s.config.MALongPeriod && n >= m.ma.longPeriod {
+		var shortMA, longMA float64
+		if s.short.ready() && s.long.ready() {
+			shortMA, longMA = s.short.value, s.long.value
+		}
+		result.MA = MAResult{
+			Value: shortMA,
+			Trend: m.ma.getTrend(close, shortMA, longMA),
+		}
+		if n >= m.ma.longPeriod+1 && s.short.count > s.short.period && s.long.count > s.long.period {
+			if s.short.prev <= s.long.prev && shortMA > longMA {
+				result.MA.Crossover = CrossoverBullish
+			} else if s.short.prev >= s.long.prev && shortMA < longMA {
+				result.MA.Crossover = CrossoverBearish
+			}
+		}
+	}
+
+	if len(volumes) >= s.config.VolumePeriod {
+		result.Volume = m.volume.Analyze(volumes)
+	}
+
+	if len(highs) >= s.config.StochKPeriod+s.config.StochDPeriod+s.config.StochSlowing-2 && s.stoch.slowD.ready() {
+		k, d := s.stoch.slowK.value, s.stoch.slowD.value
+		result.Stochastic = StochResult{
+			K:          k,
+			D:          d,
+			Overbought: k >= m.stoch.overbought,
+			Oversold:   k <= m.stoch.oversold,
+		}
+		if s.stoch.slowD.count > s.stoch.slowD.period {
+			result.Stochastic.Crossover = m.stoch.detectCrossover(
+				[]float64{s.stoch.slowK.prev, k},
+				[]float64{s.stoch.slowD.prev, d},
+			)
+		}
+	}
+
+	result.VWAP = s.vwap.analyze(highs, lows, closes, volumes, sessionBars)
+
+	if st := s.superTrend; st.band.bars > 0 {
+		result.SuperTrend = SuperTrendResult{
+			Value:     st.band.value(),
+			Direction: st.band.direction,
+		}
+		if st.band.bars > 1 {
+			result.SuperTrend.Crossover = superTrendCrossover(st.prevDirection, st.band.direction)
+		}
+	}
+
+	result.Ichimoku = m.ichimoku.Calculate(highs, lows, closes)
+
+	if s.keltnerMid.ready() && s.keltnerATR.count >= s.keltnerATR.period {
+		middle, atr := s.keltnerMid.value, s.keltnerATR.value
+		result.Keltner = keltnerResult(middle+m.keltner.multiplier*atr, middle, middle-m.keltner.multiplier*atr, close)
+	}
+
+	result.TrendStrength = m.deriveTrendStrength(result)
+	result.TrendDir = m.deriveTrendDirection(result)
+	result.Momentum = m.deriveMomentum(result)
+	result.Volatility = m.deriveVolatility(result)
+	result.OverallSignal = m.deriveOverallSignal(result)
+
+	return result
+}
+
+// ingest updates the recursive indicators with bar i
+func (s *Stream) ingest(highs, lows, closes []float64, i int) {
+	close := closes[i]
+
+	if i > 0 {
+		tr := TrueRange(highs[i], lows[i], closes[i-1])
+		s.rsi.add(close - closes[i-1])
+		s.adx.add(highs[i], lows[i], highs[i-1], lows[i-1], closes[i-1])
+		s.atr.add(tr)
+		s.keltnerATR.add(tr)
+		s.superTrend.add(highs[i], lows[i], close, closes[i-1], tr)
+	}
+	s.macd.add(close)
+	s.short.add(close)
+	s.long.add(close)
+	s.keltnerMid.add(close)
+
+	if k := s.manager.stoch.kPeriod; i >= k-1 {
+		high := Max(highs[i-k+1 : i+1])
+		low := Min(lows[i-k+1 : i+1])
+		rawK := 50.0
+		if high != low {
+			rawK = 100 * (close - low) / (high - low)
+		}
+		s.stoch.add(rawK)
+	}
+}
+
+// emaStream is EMA's recurrence, seeded with the mean of the first period
+// values
+type emaStream struct {
+	period int
+	count  int
+	seed   []float64
+	value  float64
+	prev   float64
+}
+
+// ready reports whether the average has a value
+func (e *emaStream) ready() bool {
+	return e.period > 0 && e.count >= e.period
+}
+
+func (e *emaStream) add(v float64) {
+	if e.period <= 0 {
+		return
+	}
+	e.count++
+	e.prev = e.value
+	if e.count <= e.period {
+		e.seed = append(e.seed, v)
+		if e.count == e.period {
+			e.value = Mean(e.seed)
+			e.seed = nil
+		}
+		return
+	}
+	multiplier := 2.0 / float64(e.period+1)
+	e.value = (v-e.value)*multiplier + e.value
+}
+
+// smaStream is SMA's running sum over a ring of the last period values
+type smaStream struct {
+	period int
+	count  int
+	ring   []float64
+	sum    float64
+	value  float64
+	prev   float64
+}
+
+// ready reports whether the average has a value
+func (a *smaStream) ready() bool {
+	return a.period > 0 && a.count >= a.period
+}
+
+func (a *smaStream) add(v float64) {
+	if a.period <= 0 {
+		return
+	}
+	if a.ring == nil {
+		a.ring = make([]float64, a.period)
+	}
+	slot := a.count % a.period
+	a.count++
+	a.prev = a.value
+	if a.count <= a.period {
+		a.ring[slot] = v
+		if a.count == a.period {
+			a.sum = Sum(a.ring)
+			a.value = a.sum / float64(a.period)
+		}
+		return
+	}
+	a.sum = a.sum - a.ring[slot] + v
+	a.ring[slot] = v
+	a.value = a.sum / float64(a.period)
+}
+
+// wilderStream is wilder's recurrence, seeded with the sum of the first
+// period values
+type wilderStream struct {
+	period int
+	count  int
+	seed   []float64
+	value  float64
+}
+
+// ready reports whether the smoothing has a value
+func (w *wilderStream) ready() bool {
+	return w.period > 0 && w.count >= w.period
+}
+
+func (w *wilderStream) add(v float64) {
+	if w.period <= 0 {
+		return
+	}
+	w.count++
+	if w.count <= w.period {
+		w.seed = append(w.seed, v)
+		if w.count == w.period {
+			w.value = Sum(w.seed)
+			w.seed = nil
+		}
+		return
+	}
+	w.value = w.value - (w.value / float64(w.period)) + v
+}
+
+// rsiStream follows CalculateRSI's averages of gains and losses
+type rsiStream struct {
+	period           int
+	changes          int
+	gains, losses    []float64 // Until the averages are seeded
+	avgGain, avgLoss float64
+	values           int // RSI values so far
+	value, prev      float64
+}
+
+func (r *rsiStream) add(change float64) {
+	if r.period <= 0 {
+		return
+	}
+	var gain, loss float64
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+
+	r.changes++
+	switch {
+	case r.changes < r.period:
+		r.gains = append(r.gains, gain)
+		r.losses = append(r.losses, loss)
+		return
+	case r.changes == r.period:
+		r.gains = append(r.gains, gain)
+		r.losses = append(r.losses, loss)
+		r.avgGain = Mean(r.gains)
+		r.avgLoss = Mean(r.losses)
+		r.gains, r.losses = nil, nil
+	default:
+		r.avgGain = (r.avgGain*float64(r.period-1) + gain) / float64(r.period)
+		r.avgLoss = (r.avgLoss*float64(r.period-1) + loss) / float64(r.period)
+	}
+
+	r.prev = r.value
+	r.values++
+	if r.avgLoss == 0 {
+		r.value = 100
+	} else {
+		rs := r.avgGain / r.avgLoss
+		r.value = 100 - (100 / (1 + rs))
+	}
+}
+
+// macdStream follows CalculateMACD's line and signal
+type macdStream struct {
+	fast, slow, signal emaStream
+	line, prevLine     float64
+}
+
+func (m *macdStream) add(close float64) {
+	m.fast.add(close)
+	m.slow.add(close)
+	if !m.fast.ready() || !m.slow.ready() {
+		return
+	}
+	m.prevLine = m.line
+	m.line = m.fast.value - m.slow.value
+	m.signal.add(m.line)
+}
+
+// atrStream follows ATRSeries' smoothing of true ranges
+type atrStream struct {
+	period int
+	count  int
+	seed   []float64
+	value  float64
+}
+
+func (a *atrStream) add(tr float64) {
+	if a.period <= 0 {
+		return
+	}
+	a.count++
+	if a.count <= a.period {
+		a.seed = append(a.seed, tr)
+		if a.count == a.period {
+			a.value = Mean(a.seed)
+			a.seed = nil
+		}
+		return
+	}
+	a.value = (a.value*float64(a.period-1) + tr) / float64(a.period)
+}
+
+// adxStream follows CalculateADX's directional movement and its smoothing
+type adxStream struct {
+	plusDM, minusDM, tr wilderStream
+	adx                 wilderStream // Of DX
+	plusDI, minusDI     float64
+}
+
+func (a *adxStream) add(high, low, prevHigh, prevLow, prevClose float64) {
+	upMove := high - prevHigh
+	downMove := prevLow - low
+
+	var plusDM, minusDM float64
+	if upMove > downMove && upMove > 0 {
+		plusDM = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minusDM = downMove
+	}
+
+	a.plusDM.add(plusDM)
+	a.minusDM.add(minusDM)
+	a.tr.add(TrueRange(high, low, prevClose))
+	if !a.tr.ready() {
+		return
+	}
+
+	a.plusDI, a.minusDI = 0, 0
+	if a.tr.value > 0 {
+		a.plusDI = 100 * a.plusDM.value / a.tr.value
+		a.minusDI = 100 * a.minusDM.value / a.tr.value
+	}
+	var dx float64
+	if diSum := a.plusDI + a.minusDI; diSum > 0 {
+		dx = 100 * Abs(a.plusDI-a.minusDI) / diSum
+	}
+	a.adx.add(dx)
+}
+
+// stochStream follows CalculateStochastic's slowing of raw %K and its %D
+type stochStream struct {
+	slowK, slowD smaStream
+}
+
+func (s *stochStream) add(rawK float64) {
+	s.slowK.add(rawK)
+	if s.slowK.ready() {
+		s.slowD.add(s.slowK.value)
+	}
+}
+
+// superTrendStream follows CalculateSuperTrend's bands over its own ATR
+type superTrendStream struct {
+	atr           atrStream
+	multiplier    float64
+	band          superTrendBand
+	prevDirection TrendDirection
+}
+
+func (st *superTrendStream) add(high, low, close, prevClose, tr float64) {
+	st.atr.add(tr)
+	if st.atr.count < st.atr.period {
+		return
+	}
+	st.prevDirection = st.band.direction
+	st.band.add(high, low, close, prevClose, st.atr.value, st.multiplier)
+}
+
+// vwapStream accumulates SessionVWAP's sums, starting over with each session
+type vwapStream struct {
+	start, end    int // Bars summed
+	cumPV, cumVol float64
+}
+
+// analyze extends the sums to the series' last bar, as SessionVWAP sums
+// the session's bars
+func (v *vwapStream) analyze(highs, lows, closes, volumes []float64, bars int) VWAPResult {
+	n := len(closes)
+	if n == 0 || len(highs) != n || len(lows) != n || len(volumes) != n {
+		return VWAPResult{}
+	}
+	if bars <= 0 || bars > n {
+		bars = n
+	}
+
+	start := n - bars
+	if v.end == 0 || start != v.start || v.end > n {
+		*v = vwapStream{start: start, end: start}
+	}
+	for ; v.end < n; v.end++ {
+		typicalPrice := (highs[v.end] + lows[v.end] + closes[v.end]) / 3
+		v.cumPV += typicalPrice * volumes[v.end]
+		v.cumVol += volumes[v.end]
+	}
+
+	return vwapResult(v.cumPV, v.cumVol, closes[n-1], bars)
+}
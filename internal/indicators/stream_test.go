@@ -0,0 +1,166 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// streamTolerance is how far a streamed value may drift from the batch one
+const streamTolerance = 1e-9
+
+// syntheticBars returns a random walk of n OHLCV bars
+func syntheticBars(n int, seed int64) (opens, highs, lows, closes, volumes []float64) {
+	r := rand.New(rand.NewSource(seed))
+	opens, highs, lows, closes, volumes = make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	price := 100.0
+	for i := 0; i < n; i++ {
+		price += r.NormFloat64()
+		opens[i] = price
+		closes[i] = price + r.NormFloat64()*0.5
+		highs[i] = math.Max(opens[i], closes[i]) + r.Float64()
+		lows[i] = math.Min(opens[i], closes[i]) - r.Float64()
+		volumes[i] = r.Float64() * 1000
+	}
+	return opens, highs, lows, closes, volumes
+}
+
+func TestStreamMatchesBatch(t *testing.T) {
+	configs := []struct {
+		name   string
+		config *IndicatorConfig
+	}{
+		{"default", DefaultConfig()},
+		{"partial", &IndicatorConfig{RSIPeriod: 14, MACDFast: 12, MACDSlow: 26, MACDSignal: 9, BBPeriod: 20, BBStdDev: 2, ADXPeriod: 14, ATRPeriod: 14}},
+		{"empty", &IndicatorConfig{}},
+		{"short", &IndicatorConfig{
+			RSIPeriod: 3, MACDFast: 2, MACDSlow: 4, MACDSignal: 2, BBPeriod: 3, BBStdDev: 1.5,
+			ADXPeriod: 3, ATRPeriod: 2, MAShortPeriod: 2, MAMediumPeriod: 3, MALongPeriod: 5,
+			StochKPeriod: 3, StochDPeriod: 2, StochSlowing: 2, VolumePeriod: 3,
+			KeltnerPeriod: 3, KeltnerMultiplier: 1, SuperTrendPeriod: 2, SuperTrendMultiplier: 1,
+			IchimokuTenkan: 2, IchimokuKijun: 3, IchimokuSenkouB: 4,
+		}},
+	}
+	// Lengths shorter than, around and well past each indicator's period
+	lengths := []int{1, 2, 5, 14, 27, 35, 60, 120, 300}
+
+	for _, c := range configs {
+		for _, n := range lengths {
+			for _, sessionBars := range []int{0, 1, 24} {
+				t.Run(fmt.Sprintf("%s/%d bars/session %d", c.name, n, sessionBars), func(t *testing.T) {
+					opens, highs, lows, closes, volumes := syntheticBars(n, int64(n))
+					m := NewManager(c.config)
+					s := m.NewStream()
+					for i := 1; i <= n; i++ {
+						// Sessions restart every sessionBars bars
+						bars := sessionBars
+						if bars > 0 {
+							bars = (i-1)%sessionBars + 1
+						}
+						want := m.AnalyzeSession(opens[:i], highs[:i], lows[:i], closes[:i], volumes[:i], bars)
+						got := s.AnalyzeSession(opens[:i], highs[:i], lows[:i], closes[:i], volumes[:i], bars)
+						compareAnalysis(t, fmt.Sprintf("bar %d", i-1), want, got)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestStreamRestartsOnShorterSeries(t *testing.T) {
+	opens, highs, lows, closes, volumes := syntheticBars(200, 1)
+	m := NewManager(DefaultConfig())
+	s := m.NewStream()
+	s.Analyze(opens, highs, lows, closes, volumes)
+
+	n := 120
+	want := m.Analyze(opens[:n], highs[:n], lows[:n], closes[:n], volumes[:n])
+	got := s.Analyze(opens[:n], highs[:n], lows[:n], closes[:n], volumes[:n])
+	compareAnalysis(t, "shorter series", want, got)
+}
+
+func TestStreamRestartsOnConfigChange(t *testing.T) {
+	opens, highs, lows, closes, volumes := syntheticBars(200, 2)
+	m := NewManager(DefaultConfig())
+	s := m.NewStream()
+	s.Analyze(opens[:100], highs[:100], lows[:100], closes[:100], volumes[:100])
+
+	config := DefaultConfig()
+	config.RSIPeriod = 7
+	config.SuperTrendPeriod = 5
+	config.KeltnerPeriod = 10
+	m.UpdateConfig(config)
+
+	want := m.Analyze(opens, highs, lows, closes, volumes)
+	got := s.Analyze(opens, highs, lows, closes, volumes)
+	compareAnalysis(t, "new config", want, got)
+}
+
+// compareAnalysis fails t when a streamed result differs from the batch one
+func compareAnalysis(t *testing.T, label string, want, got AnalysisResult) {
+	t.Helper()
+
+	values := []struct {
+		name      string
+		want, got float64
+	}{
+		{"RSI", want.RSI.Value, got.RSI.Value},
+		{"MACD", want.MACD.MACD, got.MACD.MACD},
+		{"MACD signal", want.MACD.Signal, got.MACD.Signal},
+		{"MACD histogram", want.MACD.Histogram, got.MACD.Histogram},
+		{"BB upper", want.Bollinger.Upper, got.Bollinger.Upper},
+		{"BB middle", want.Bollinger.Middle, got.Bollinger.Middle},
+		{"BB lower", want.Bollinger.Lower, got.Bollinger.Lower},
+		{"BB width", want.Bollinger.Width, got.Bollinger.Width},
+		{"ADX", want.ADX.ADX, got.ADX.ADX},
+		{"+DI", want.ADX.PlusDI, got.ADX.PlusDI},
+		{"-DI", want.ADX.MinusDI, got.ADX.MinusDI},
+		{"ATR", want.ATR.ATR, got.ATR.ATR},
+		{"ATR percent", want.ATR.ATRPercent, got.ATR.ATRPercent},
+		{"MA", want.MA.Value, got.MA.Value},
+		{"stochastic K", want.Stochastic.K, got.Stochastic.K},
+		{"stochastic D", want.Stochastic.D, got.Stochastic.D},
+		{"volume ratio", want.Volume.Ratio, got.Volume.Ratio},
+		{"VWAP", want.VWAP.VWAP, got.VWAP.VWAP},
+		{"VWAP distance", want.VWAP.Distance, got.VWAP.Distance},
+		{"SuperTrend", want.SuperTrend.Value, got.SuperTrend.Value},
+		{"Tenkan", want.Ichimoku.Tenkan, got.Ichimoku.Tenkan},
+		{"Kijun", want.Ichimoku.Kijun, got.Ichimoku.Kijun},
+		{"Senkou A", want.Ichimoku.SenkouA, got.Ichimoku.SenkouA},
+		{"Senkou B", want.Ichimoku.SenkouB, got.Ichimoku.SenkouB},
+		{"Keltner upper", want.Keltner.Upper, got.Keltner.Upper},
+		{"Keltner middle", want.Keltner.Middle, got.Keltner.Middle},
+		{"Keltner lower", want.Keltner.Lower, got.Keltner.Lower},
+	}
+	for _, v := range values {
+		if math.Abs(v.want-v.got) > streamTolerance*math.Max(1, math.Abs(v.want)) {
+			t.Errorf("%s: %s = %v, batch %v", label, v.name, v.got, v.want)
+		}
+	}
+
+	states := []struct {
+		name      string
+		want, got interface{}
+	}{
+		{"RSI signal", want.RSI.Signal, got.RSI.Signal},
+		{"MACD crossover", want.MACD.Crossover, got.MACD.Crossover},
+		{"ADX direction", want.ADX.Direction, got.ADX.Direction},
+		{"MA trend", want.MA.Trend, got.MA.Trend},
+		{"MA crossover", want.MA.Crossover, got.MA.Crossover},
+		{"stochastic crossover", want.Stochastic.Crossover, got.Stochastic.Crossover},
+		{"VWAP session bars", want.VWAP.SessionBars, got.VWAP.SessionBars},
+		{"SuperTrend direction", want.SuperTrend.Direction, got.SuperTrend.Direction},
+		{"SuperTrend crossover", want.SuperTrend.Crossover, got.SuperTrend.Crossover},
+		{"Ichimoku position", want.Ichimoku.Position, got.Ichimoku.Position},
+		{"Ichimoku crossover", want.Ichimoku.Crossover, got.Ichimoku.Crossover},
+		{"Keltner breakout", want.Keltner.Breakout, got.Keltner.Breakout},
+		{"trend direction", want.TrendDir, got.TrendDir},
+		{"overall signal", want.OverallSignal, got.OverallSignal},
+	}
+	for _, v := range states {
+		if v.want != v.got {
+			t.Errorf("%s: %s = %v, batch %v", label, v.name, v.got, v.want)
+		}
+	}
+}
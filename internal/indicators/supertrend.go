@@ -0,0 +1,128 @@
+package indicators
+
+// SuperTrend calculates the SuperTrend: an ATR band below price in an
+// uptrend and above it in a downtrend, flipping when price closes through it
+type SuperTrend struct {
+	period     int
+	multiplier float64
+}
+
+// NewSuperTrend creates a new SuperTrend calculator
+func NewSuperTrend(period int, multiplier float64) *SuperTrend {
+	if period <= 0 {
+		period = 10
+	}
+	if multiplier <= 0 {
+		multiplier = 3.0
+	}
+	return &SuperTrend{
+		period:     period,
+		multiplier: multiplier,
+	}
+}
+
+// Calculate calculates the SuperTrend for the latest bar
+func (st *SuperTrend) Calculate(highs, lows, closes []float64) SuperTrendResult {
+	data := CalculateSuperTrend(highs, lows, closes, st.period, st.multiplier)
+	if len(data.Value) == 0 {
+		return SuperTrendResult{}
+	}
+
+	idx := len(data.Value) - 1
+	result := SuperTrendResult{
+		Value:     data.Value[idx],
+		Direction: data.Direction[idx],
+	}
+	if idx > 0 {
+		result.Crossover = superTrendCrossover(data.Direction[idx-1], data.Direction[idx])
+	}
+	return result
+}
+
+// superTrendCrossover reports the flip from one bar's trend to the next
+func superTrendCrossover(prev, current TrendDirection) CrossoverType {
+	if prev == TrendDown && current == TrendUp {
+		return CrossoverBullish
+	}
+	if prev == TrendUp && current == TrendDown {
+		return CrossoverBearish
+	}
+	return CrossoverNone
+}
+
+// SuperTrendData holds complete SuperTrend data
+type SuperTrendData struct {
+	Value     []float64
+	Direction []TrendDirection
+}
+
+// CalculateSuperTrend calculates the SuperTrend for a series, from the
+// first bar with an ATR
+func CalculateSuperTrend(highs, lows, closes []float64, period int, multiplier float64) SuperTrendData {
+	atr := ATRSeries(highs, lows, closes, period)
+	if len(atr) == 0 {
+		return SuperTrendData{}
+	}
+
+	offset := len(closes) - len(atr)
+	result := SuperTrendData{
+		Value:     make([]float64, len(atr)),
+		Direction: make([]TrendDirection, len(atr)),
+	}
+
+	var band superTrendBand
+	for j, a := range atr {
+		i := j + offset
+		band.add(highs[i], lows[i], closes[i], closes[i-1], a, multiplier)
+		result.Value[j] = band.value()
+		result.Direction[j] = band.direction
+	}
+
+	return result
+}
+
+// superTrendBand carries the final bands and trend from bar to bar
+type superTrendBand struct {
+	bars         int
+	upper, lower float64
+	direction    TrendDirection
+}
+
+// add moves the bands to a bar, given the previous close and the bar's ATR
+func (b *superTrendBand) add(high, low, close, prevClose, atr, multiplier float64) {
+	mid := (high + low) / 2
+	basicUpper := mid + multiplier*atr
+	basicLower := mid - multiplier*atr
+
+	b.bars++
+	if b.bars == 1 {
+		b.upper, b.lower = basicUpper, basicLower
+		b.direction = TrendDown
+		if close >= mid {
+			b.direction = TrendUp
+		}
+		return
+	}
+
+	// Bands only tighten, unless price closed through them
+	if basicUpper < b.upper || prevClose > b.upper {
+		b.upper = basicUpper
+	}
+	if basicLower > b.lower || prevClose < b.lower {
+		b.lower = basicLower
+	}
+
+	if b.direction == TrendUp && close < b.lower {
+		b.direction = TrendDown
+	} else if b.direction == TrendDown && close > b.upper {
+		b.direction = TrendUp
+	}
+}
+
+// value returns the band trailing price
+func (b *superTrendBand) value() float64 {
+	if b.direction == TrendUp {
+		return b.lower
+	}
+	return b.upper
+}
@@ -187,6 +187,38 @@ type StochResult struct {
 	Crossover  CrossoverType
 }
 
+// VWAPResult holds session-anchored VWAP result
+type VWAPResult struct {
+	VWAP        float64
+	Distance    float64 // Percent the close is above (negative: below) VWAP
+	SessionBars int     // Bars VWAP is anchored over
+}
+
+// SuperTrendResult holds SuperTrend calculation result
+type SuperTrendResult struct {
+	Value     float64 // Band trailing price: below it in an uptrend, above it in a downtrend
+	Direction TrendDirection
+	Crossover CrossoverType // Bullish when the trend flips up on the latest bar
+}
+
+// IchimokuResult holds Ichimoku cloud calculation result
+type IchimokuResult struct {
+	Tenkan    float64        // Conversion line
+	Kijun     float64        // Base line
+	SenkouA   float64        // Leading span A under the latest bar
+	SenkouB   float64        // Leading span B under the latest bar
+	Position  TrendDirection // Up above the cloud, down below it, neutral inside
+	Crossover CrossoverType  // Tenkan/Kijun cross
+}
+
+// KeltnerResult holds Keltner Channel calculation result
+type KeltnerResult struct {
+	Upper    float64
+	Middle   float64
+	Lower    float64
+	Breakout BreakoutType
+}
+
 // SupportResistance holds support and resistance levels
 type SupportResistance struct {
 	Supports    []float64
@@ -245,6 +277,19 @@ type IndicatorConfig struct {
 	StochSlowing    int
 	StochOverbought float64
 	StochOversold   float64
+
+	// Keltner Channels
+	KeltnerPeriod     int
+	KeltnerMultiplier float64
+
+	// SuperTrend
+	SuperTrendPeriod     int
+	SuperTrendMultiplier float64
+
+	// Ichimoku
+	IchimokuTenkan  int
+	IchimokuKijun   int
+	IchimokuSenkouB int
 }
 
 // DefaultConfig returns default indicator configuration
@@ -274,5 +319,12 @@ func DefaultConfig() *IndicatorConfig {
 		StochSlowing:         3,
 		StochOverbought:      80,
 		StochOversold:        20,
+		KeltnerPeriod:        20,
+		KeltnerMultiplier:    2.0,
+		SuperTrendPeriod:     10,
+		SuperTrendMultiplier: 3.0,
+		IchimokuTenkan:       9,
+		IchimokuKijun:        26,
+		IchimokuSenkouB:      52,
 	}
 }
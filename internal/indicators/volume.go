@@ -1,5 +1,7 @@
 package indicators
 
+import "time"
+
 // VolumeAnalyzer provides volume-based analysis
 type VolumeAnalyzer struct {
 	period          int
@@ -159,6 +161,52 @@ func VWAP(highs, lows, closes, volumes []float64) []float64 {
 	return vwap
 }
 
+// SessionBars returns how many bars of length bar the UTC day's session
+// has had by the one opened at lastOpen, counting it, or 0 for an unknown
+// bar length
+func SessionBars(lastOpen time.Time, bar time.Duration) int {
+	if bar <= 0 {
+		return 0
+	}
+	open := lastOpen.UTC()
+	sessionOpen := time.Date(open.Year(), open.Month(), open.Day(), 0, 0, 0, 0, time.UTC)
+	return int(open.Sub(sessionOpen)/bar) + 1
+}
+
+// SessionVWAP calculates VWAP over the session's bars, the last bars of
+// the series; bars <= 0, or more than the series holds, anchor it at the
+// first bar
+func SessionVWAP(highs, lows, closes, volumes []float64, bars int) VWAPResult {
+	n := len(closes)
+	if n == 0 || len(highs) != n || len(lows) != n || len(volumes) != n {
+		return VWAPResult{}
+	}
+	if bars <= 0 || bars > n {
+		bars = n
+	}
+
+	var cumPV, cumVol float64
+	for i := n - bars; i < n; i++ {
+		typicalPrice := (highs[i] + lows[i] + closes[i]) / 3
+		cumPV += typicalPrice * volumes[i]
+		cumVol += volumes[i]
+	}
+
+	return vwapResult(cumPV, cumVol, closes[n-1], bars)
+}
+
+// vwapResult derives the VWAP result from cumulative price-volume and volume
+func vwapResult(cumPV, cumVol, close float64, bars int) VWAPResult {
+	result := VWAPResult{SessionBars: bars}
+	if cumVol > 0 {
+		result.VWAP = cumPV / cumVol
+	}
+	if result.VWAP > 0 {
+		result.Distance = (close - result.VWAP) / result.VWAP * 100
+	}
+	return result
+}
+
 // VWAPWithBands calculates VWAP with standard deviation bands
 type VWAPData struct {
 	VWAP      []float64
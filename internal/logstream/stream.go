@@ -0,0 +1,230 @@
+// Package logstream keeps a tail of structured application log events so
+// they can be followed over the API
+package logstream
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultCapacity is the number of events kept for late subscribers
+const DefaultCapacity = 1000
+
+// subscriberBuffer is the number of events queued per subscriber before
+// events are dropped for it
+const subscriberBuffer = 256
+
+// Event categories
+const (
+	CategoryApp   = "app"
+	CategoryOrder = "order"
+	CategoryHTTP  = "http"
+)
+
+// Event is one structured log event
+type Event struct {
+	Seq      int64                  `json:"seq"`
+	Time     time.Time              `json:"time"`
+	Level    string                 `json:"level"`
+	Category string                 `json:"category"`
+	Message  string                 `json:"message"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+
+	level zerolog.Level
+}
+
+// Filter selects events for a reader
+type Filter struct {
+	MinLevel zerolog.Level
+	Category string // Empty = all categories
+}
+
+// Match reports whether an event passes the filter
+func (f Filter) Match(e Event) bool {
+	if e.level < f.MinLevel {
+		return false
+	}
+	return f.Category == "" || f.Category == e.Category
+}
+
+// Stream is a zerolog writer that retains recent events and fans them out
+// to subscribers. Slow subscribers lose events rather than block logging.
+type Stream struct {
+	minLevel zerolog.Level
+
+	mu      sync.Mutex
+	events  []Event // Ring buffer
+	next    int
+	full    bool
+	seq     int64
+	subs    map[int]*Subscription
+	nextSub int
+}
+
+// Subscription receives events as they are logged
+type Subscription struct {
+	C       <-chan Event
+	ch      chan Event
+	filter  Filter
+	dropped int64 // Guarded by the stream lock
+}
+
+// New creates a stream keeping capacity events at or above minLevel
+func New(capacity int, minLevel zerolog.Level) *Stream {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Stream{
+		minLevel: minLevel,
+		events:   make([]Event, capacity),
+		subs:     make(map[int]*Subscription),
+	}
+}
+
+// Write implements io.Writer for events logged without a level
+func (s *Stream) Write(p []byte) (int, error) {
+	return s.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter
+func (s *Stream) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < s.minLevel && level != zerolog.NoLevel {
+		return len(p), nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		// Not a zerolog event; never fail the other writers over it
+		return len(p), nil
+	}
+
+	event := Event{
+		Time:   time.Now(),
+		Level:  level.String(),
+		Fields: fields,
+		level:  level,
+	}
+	if v, ok := fields[zerolog.LevelFieldName].(string); ok {
+		if parsed, err := zerolog.ParseLevel(v); err == nil {
+			event.level = parsed
+			event.Level = v
+		}
+		delete(fields, zerolog.LevelFieldName)
+	}
+	if v, ok := fields[zerolog.MessageFieldName].(string); ok {
+		event.Message = v
+		delete(fields, zerolog.MessageFieldName)
+	}
+	delete(fields, zerolog.TimestampFieldName)
+	if len(fields) == 0 {
+		event.Fields = nil
+	}
+	event.Category = categorize(event.Message, fields)
+
+	s.publish(event)
+	return len(p), nil
+}
+
+// publish stores an event and hands it to subscribers
+func (s *Stream) publish(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	event.Seq = s.seq
+	s.events[s.next] = event
+	s.next = (s.next + 1) % len(s.events)
+	if s.next == 0 {
+		s.full = true
+	}
+
+	for _, sub := range s.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			sub.dropped++
+		}
+	}
+}
+
+// Recent returns up to limit retained events matching the filter, oldest
+// first, with a sequence number above afterSeq
+func (s *Stream) Recent(filter Filter, afterSeq int64, limit int) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ordered []Event
+	if s.full {
+		ordered = append(ordered, s.events[s.next:]...)
+	}
+	ordered = append(ordered, s.events[:s.next]...)
+
+	var result []Event
+	for i := len(ordered) - 1; i >= 0 && len(result) < limit; i-- {
+		e := ordered[i]
+		if e.Seq <= afterSeq {
+			break
+		}
+		if filter.Match(e) {
+			result = append(result, e)
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// Subscribe registers a reader for new events matching the filter. The
+// returned function unsubscribes and must be called.
+func (s *Stream) Subscribe(filter Filter) (*Subscription, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter}
+
+	s.mu.Lock()
+	id := s.nextSub
+	s.nextSub++
+	s.subs[id] = sub
+	s.mu.Unlock()
+
+	return sub, func() {
+		s.mu.Lock()
+		delete(s.subs, id)
+		s.mu.Unlock()
+	}
+}
+
+// Dropped returns the number of events a subscription lost because its
+// reader fell behind
+func (s *Stream) Dropped(sub *Subscription) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sub.dropped
+}
+
+// categorize assigns an event to order lifecycle, HTTP access or general
+// application events
+func categorize(message string, fields map[string]interface{}) string {
+	if _, ok := fields["method"]; ok {
+		if _, ok := fields["path"]; ok {
+			return CategoryHTTP
+		}
+	}
+	for key := range fields {
+		switch strings.ToLower(key) {
+		case "orderid", "clientorderid", "intentid":
+			return CategoryOrder
+		}
+	}
+	if strings.Contains(strings.ToLower(message), "order") {
+		return CategoryOrder
+	}
+	return CategoryApp
+}
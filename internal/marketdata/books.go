@@ -0,0 +1,178 @@
+package marketdata
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultSnapshotDepth is how many levels a snapshot requests per side
+	defaultSnapshotDepth = 1000
+	// defaultMaxAge is how long a book goes without updates before its
+	// prices are considered stale
+	defaultMaxAge = 5 * time.Second
+	// snapshotRetryDelay spaces snapshot requests for a book that fails to sync
+	snapshotRetryDelay = 5 * time.Second
+)
+
+// SnapshotSource fetches order book snapshots
+type SnapshotSource interface {
+	GetDepth(symbol string, limit int) (*binance.Depth, error)
+}
+
+// Books keeps local order books synced from the diff depth stream, fetching
+// a snapshot whenever a book starts or falls out of sync
+type Books struct {
+	source SnapshotSource
+	depth  int
+	maxAge time.Duration
+	books  map[string]*OrderBook // symbol -> book
+
+	mu sync.RWMutex
+}
+
+// NewBooks creates order books synced from source. depth is the snapshot
+// size (0 = 1000 levels) and maxAge how long a book can go without updates
+// and still price fills (0 = 5s).
+func NewBooks(source SnapshotSource, depth int, maxAge time.Duration) *Books {
+	if depth <= 0 {
+		depth = defaultSnapshotDepth
+	}
+	if maxAge <= 0 {
+		maxAge = defaultMaxAge
+	}
+	return &Books{
+		source: source,
+		depth:  depth,
+		maxAge: maxAge,
+		books:  make(map[string]*OrderBook),
+	}
+}
+
+// OnDepth applies a diff depth event, requesting a snapshot when the book
+// is not synced
+func (bs *Books) OnDepth(event binance.DepthEvent) {
+	book := bs.bookFor(event.Symbol)
+	err := book.ApplyDiff(&event)
+	if err == nil {
+		return
+	}
+	if errors.Is(err, ErrOutOfSync) {
+		log.Warn().Str("symbol", event.Symbol).Int64("firstUpdateId", event.FirstUpdateID).Msg("Order book missed updates, resyncing")
+	}
+	if book.beginSync() {
+		go bs.sync(book)
+	}
+}
+
+// bookFor returns the book of symbol, creating it on first use
+func (bs *Books) bookFor(symbol string) *OrderBook {
+	symbol = strings.ToUpper(symbol)
+
+	bs.mu.RLock()
+	book, ok := bs.books[symbol]
+	bs.mu.RUnlock()
+	if ok {
+		return book
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if book, ok = bs.books[symbol]; !ok {
+		book = NewOrderBook(symbol)
+		bs.books[symbol] = book
+	}
+	return book
+}
+
+// beginSync claims the book's snapshot request, unless one is in flight or
+// the last failed too recently
+func (b *OrderBook) beginSync() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.syncing || time.Since(b.lastAttempt) < snapshotRetryDelay {
+		return false
+	}
+	b.syncing = true
+	b.lastAttempt = time.Now()
+	return true
+}
+
+// sync fetches a snapshot and replays the buffered diffs onto it
+func (bs *Books) sync(book *OrderBook) {
+	defer func() {
+		book.mu.Lock()
+		book.syncing = false
+		book.mu.Unlock()
+	}()
+
+	depth, err := bs.source.GetDepth(book.symbol, bs.depth)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", book.symbol).Msg("Failed to fetch order book snapshot")
+		return
+	}
+	if err := book.ApplySnapshot(depth); err != nil {
+		log.Debug().Err(err).Str("symbol", book.symbol).Msg("Order book snapshot behind the stream, retrying")
+		return
+	}
+
+	// A book that synced without trouble can resync right away next time
+	book.mu.Lock()
+	book.lastAttempt = time.Time{}
+	book.mu.Unlock()
+	log.Info().Str("symbol", book.symbol).Int64("lastUpdateId", depth.LastUpdateID).Msg("Order book synced")
+}
+
+// Book returns the order book of symbol, or nil before its first update
+func (bs *Books) Book(symbol string) *OrderBook {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	return bs.books[strings.ToUpper(symbol)]
+}
+
+// MaxAge returns how long a book can go without updates and still price fills
+func (bs *Books) MaxAge() time.Duration {
+	return bs.maxAge
+}
+
+// fresh returns the book of symbol when it is synced and recently updated
+func (bs *Books) fresh(symbol string) (*OrderBook, bool) {
+	book := bs.Book(symbol)
+	if book == nil || !book.Synced() || book.Age() > bs.maxAge {
+		return nil, false
+	}
+	return book, true
+}
+
+// Quote returns the top of symbol's book. ok is false while the book is
+// unsynced, stale or one-sided.
+func (bs *Books) Quote(symbol string) (Quote, bool) {
+	book, ok := bs.fresh(symbol)
+	if !ok {
+		return Quote{}, false
+	}
+	return book.Quote()
+}
+
+// Estimate prices a market order for quantity against symbol's book. ok is
+// false while the book is unsynced, stale or one-sided.
+func (bs *Books) Estimate(symbol string, buy bool, quantity float64) (Fill, bool) {
+	book, ok := bs.fresh(symbol)
+	if !ok {
+		return Fill{}, false
+	}
+	return book.EstimateFill(buy, quantity)
+}
+
+// EstimateFill returns the average price a market order for quantity would
+// fill at, for executors simulating fills
+func (bs *Books) EstimateFill(symbol string, buy bool, quantity float64) (float64, bool) {
+	fill, ok := bs.Estimate(symbol, buy, quantity)
+	return fill.AvgPrice, ok
+}
@@ -0,0 +1,312 @@
+package marketdata
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+)
+
+// maxBufferedEvents bounds the diffs kept while a book waits for its snapshot
+const maxBufferedEvents = 1000
+
+var (
+	// ErrNotSynced is returned for diffs buffered until a snapshot arrives
+	ErrNotSynced = errors.New("order book not synced")
+	// ErrOutOfSync is returned when a diff does not follow the last one
+	// applied, so the book needs a new snapshot
+	ErrOutOfSync = errors.New("order book out of sync")
+)
+
+// Level is one price level of an order book
+type Level struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// Quote is the top of an order book
+type Quote struct {
+	Symbol       string    `json:"symbol"`
+	Bid          float64   `json:"bid"`
+	BidQty       float64   `json:"bidQty"`
+	Ask          float64   `json:"ask"`
+	AskQty       float64   `json:"askQty"`
+	Mid          float64   `json:"mid"`
+	Spread       float64   `json:"spread"`
+	SpreadBps    float64   `json:"spreadBps"` // Spread relative to the mid
+	LastUpdateID int64     `json:"lastUpdateId"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Synced       bool      `json:"synced"`
+}
+
+// Fill is the estimated execution of a market order walking the book
+type Fill struct {
+	Quantity    float64 `json:"quantity"`
+	AvgPrice    float64 `json:"avgPrice"`
+	WorstPrice  float64 `json:"worstPrice"`
+	Levels      int     `json:"levels"`      // Price levels consumed
+	SlippageBps float64 `json:"slippageBps"` // Average price against the mid, always adverse
+	Exhausted   bool    `json:"exhausted"`   // The book was too thin; the rest is priced at the worst level
+}
+
+// OrderBook is a local copy of one symbol's order book, synced from a REST
+// snapshot and kept current by the diff depth stream
+type OrderBook struct {
+	symbol       string
+	bids         map[float64]float64 // price -> quantity
+	asks         map[float64]float64
+	lastUpdateID int64
+	updatedAt    time.Time
+	synced       bool
+	buffered     []binance.DepthEvent // Diffs received before the snapshot, oldest first
+
+	// Snapshot requests
+	syncing     bool
+	lastAttempt time.Time
+
+	mu sync.RWMutex
+}
+
+// NewOrderBook creates an empty, unsynced order book
+func NewOrderBook(symbol string) *OrderBook {
+	return &OrderBook{
+		symbol: symbol,
+		bids:   make(map[float64]float64),
+		asks:   make(map[float64]float64),
+	}
+}
+
+// ApplySnapshot replaces the book with a REST snapshot and replays the diffs
+// buffered since. It returns ErrOutOfSync when the snapshot is older than
+// the buffered diffs reach back, so another one is needed.
+func (b *OrderBook) ApplySnapshot(depth *binance.Depth) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = make(map[float64]float64, len(depth.Bids))
+	b.asks = make(map[float64]float64, len(depth.Asks))
+	applyLevels(b.bids, depth.Bids)
+	applyLevels(b.asks, depth.Asks)
+	b.lastUpdateID = depth.LastUpdateID
+	b.updatedAt = time.Now()
+	b.synced = true
+
+	buffered := b.buffered
+	b.buffered = nil
+	for i := range buffered {
+		event := &buffered[i]
+		if event.FinalUpdateID <= b.lastUpdateID {
+			continue
+		}
+		if event.FirstUpdateID > b.lastUpdateID+1 {
+			b.synced = false
+			b.buffered = append([]binance.DepthEvent(nil), buffered[i:]...)
+			return ErrOutOfSync
+		}
+		b.applyLocked(event)
+	}
+	return nil
+}
+
+// ApplyDiff applies a diff depth event. Diffs arriving before the snapshot
+// are buffered and return ErrNotSynced; a diff that skips updates returns
+// ErrOutOfSync and unsyncs the book.
+func (b *OrderBook) ApplyDiff(event *binance.DepthEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.synced {
+		b.buffer(event)
+		return ErrNotSynced
+	}
+	if event.FinalUpdateID <= b.lastUpdateID {
+		return nil
+	}
+	if event.FirstUpdateID > b.lastUpdateID+1 {
+		b.synced = false
+		b.buffered = nil
+		b.buffer(event)
+		return ErrOutOfSync
+	}
+	b.applyLocked(event)
+	return nil
+}
+
+// buffer keeps a diff for replay after the next snapshot, dropping the
+// oldest once full. Callers hold b.mu.
+func (b *OrderBook) buffer(event *binance.DepthEvent) {
+	if len(b.buffered) >= maxBufferedEvents {
+		b.buffered = b.buffered[1:]
+	}
+	b.buffered = append(b.buffered, *event)
+}
+
+// applyLocked applies a diff that follows the last update. Callers hold b.mu.
+func (b *OrderBook) applyLocked(event *binance.DepthEvent) {
+	applyLevels(b.bids, event.Bids)
+	applyLevels(b.asks, event.Asks)
+	b.lastUpdateID = event.FinalUpdateID
+	b.updatedAt = time.Now()
+}
+
+// applyLevels sets the quantity of each [price, quantity] level, removing
+// levels with none left
+func applyLevels(side map[float64]float64, levels [][]string) {
+	for _, l := range levels {
+		if len(l) < 2 {
+			continue
+		}
+		price, err := strconv.ParseFloat(l[0], 64)
+		if err != nil {
+			continue
+		}
+		qty, err := strconv.ParseFloat(l[1], 64)
+		if err != nil {
+			continue
+		}
+		if qty == 0 {
+			delete(side, price)
+		} else {
+			side[price] = qty
+		}
+	}
+}
+
+// Synced reports whether the book follows the stream
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Age returns how long ago the book last changed
+func (b *OrderBook) Age() time.Duration {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.updatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(b.updatedAt)
+}
+
+// Quote returns the best bid and ask. ok is false while either side is empty.
+func (b *OrderBook) Quote() (quote Quote, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	quote = Quote{
+		Symbol:       b.symbol,
+		LastUpdateID: b.lastUpdateID,
+		UpdatedAt:    b.updatedAt,
+		Synced:       b.synced,
+	}
+	bid, bidOK := best(b.bids, true)
+	ask, askOK := best(b.asks, false)
+	if !bidOK || !askOK {
+		return quote, false
+	}
+	quote.Bid, quote.BidQty = bid.Price, bid.Quantity
+	quote.Ask, quote.AskQty = ask.Price, ask.Quantity
+	quote.Mid = (bid.Price + ask.Price) / 2
+	quote.Spread = ask.Price - bid.Price
+	quote.SpreadBps = quote.Spread / quote.Mid * 10000
+	return quote, true
+}
+
+// Top returns up to n levels of each side, best first
+func (b *OrderBook) Top(n int) (bids, asks []Level) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bids = sortedLevels(b.bids, true)
+	asks = sortedLevels(b.asks, false)
+	if n > 0 && len(bids) > n {
+		bids = bids[:n]
+	}
+	if n > 0 && len(asks) > n {
+		asks = asks[:n]
+	}
+	return bids, asks
+}
+
+// EstimateFill walks the asks (buy) or bids (sell) for quantity and returns
+// the resulting average price. ok is false when the book cannot price it.
+func (b *OrderBook) EstimateFill(buy bool, quantity float64) (fill Fill, ok bool) {
+	if quantity <= 0 {
+		return Fill{}, false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bid, bidOK := best(b.bids, true)
+	ask, askOK := best(b.asks, false)
+	if !bidOK || !askOK {
+		return Fill{}, false
+	}
+	levels := sortedLevels(b.bids, true)
+	if buy {
+		levels = sortedLevels(b.asks, false)
+	}
+
+	fill.Quantity = quantity
+	remaining := quantity
+	var cost float64
+	for _, l := range levels {
+		take := l.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		cost += take * l.Price
+		remaining -= take
+		fill.WorstPrice = l.Price
+		fill.Levels++
+		if remaining <= 0 {
+			break
+		}
+	}
+	if remaining > 0 {
+		fill.Exhausted = true
+		cost += remaining * fill.WorstPrice
+	}
+	fill.AvgPrice = cost / quantity
+
+	mid := (bid.Price + ask.Price) / 2
+	if buy {
+		fill.SlippageBps = (fill.AvgPrice - mid) / mid * 10000
+	} else {
+		fill.SlippageBps = (mid - fill.AvgPrice) / mid * 10000
+	}
+	return fill, true
+}
+
+// best returns the highest (bids) or lowest (asks) level of a side
+func best(side map[float64]float64, highest bool) (Level, bool) {
+	var top Level
+	found := false
+	for price, qty := range side {
+		if !found || (highest && price > top.Price) || (!highest && price < top.Price) {
+			top = Level{Price: price, Quantity: qty}
+			found = true
+		}
+	}
+	return top, found
+}
+
+// sortedLevels returns a side's levels, best first
+func sortedLevels(side map[float64]float64, descending bool) []Level {
+	levels := make([]Level, 0, len(side))
+	for price, qty := range side {
+		levels = append(levels, Level{Price: price, Quantity: qty})
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if descending {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	return levels
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -43,11 +46,74 @@ type TradingAccount struct {
 	TradingMode        TradingMode `json:"trading_mode" db:"trading_mode"`
 	EnabledStrategies  []string    `json:"enabled_strategies" db:"enabled_strategies"`
 
+	// Isolated executor
+	RiskConfig      AccountRiskConfig `json:"risk_config" db:"risk_config"`
+	ExecutorEnabled bool              `json:"executor_enabled" db:"executor_enabled"` // Started with the bot
+
 	IsActive  bool      `json:"is_active" db:"is_active"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// AccountRiskConfig overrides the bot's risk limits for a trading account's
+// own executor. Unset fields keep the bot's values.
+type AccountRiskConfig struct {
+	MaxPositionSize    *float64 `json:"max_position_size,omitempty"`  // Fraction of equity
+	MaxRiskPerTrade    *float64 `json:"max_risk_per_trade,omitempty"` // Fraction of equity
+	MaxDailyLoss       *float64 `json:"max_daily_loss,omitempty"`     // Fraction of equity
+	MaxWeeklyLoss      *float64 `json:"max_weekly_loss,omitempty"`    // Fraction of equity
+	MaxDrawdown        *float64 `json:"max_drawdown,omitempty"`       // Fraction of peak equity
+	MaxOpenPositions   *int     `json:"max_open_positions,omitempty"`
+	MinRiskRewardRatio *float64 `json:"min_risk_reward_ratio,omitempty"`
+}
+
+// Validate checks the overridden limits are in range
+func (r AccountRiskConfig) Validate() error {
+	fractions := map[string]*float64{
+		"max_position_size":  r.MaxPositionSize,
+		"max_risk_per_trade": r.MaxRiskPerTrade,
+		"max_daily_loss":     r.MaxDailyLoss,
+		"max_weekly_loss":    r.MaxWeeklyLoss,
+		"max_drawdown":       r.MaxDrawdown,
+	}
+	for name, v := range fractions {
+		if v != nil && (*v <= 0 || *v > 1) {
+			return fmt.Errorf("%s must be above 0 and at most 1", name)
+		}
+	}
+	if r.MaxOpenPositions != nil && *r.MaxOpenPositions < 1 {
+		return fmt.Errorf("max_open_positions must be at least 1")
+	}
+	if r.MinRiskRewardRatio != nil && *r.MinRiskRewardRatio < 0 {
+		return fmt.Errorf("min_risk_reward_ratio must not be negative")
+	}
+	return nil
+}
+
+// Value stores the overrides as JSON
+func (r AccountRiskConfig) Value() (driver.Value, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads overrides stored as JSON; NULL is no overrides
+func (r *AccountRiskConfig) Scan(src interface{}) error {
+	*r = AccountRiskConfig{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("cannot scan %T into account risk config", src)
+	}
+}
+
 // TradingAccountCreateRequest represents a request to create a new trading account
 type TradingAccountCreateRequest struct {
 	AccountType AccountType `json:"account_type" validate:"required,oneof=demo live"`
@@ -68,10 +134,19 @@ type TradingAccountCreateRequest struct {
 
 // TradingAccountUpdateRequest represents an account update request
 type TradingAccountUpdateRequest struct {
-	AccountName       *string  `json:"account_name,omitempty" validate:"omitempty,min=3,max=100"`
-	TradingSymbol     *string  `json:"trading_symbol,omitempty"`
-	EnabledStrategies []string `json:"enabled_strategies,omitempty"`
-	IsActive          *bool    `json:"is_active,omitempty"`
+	AccountName       *string            `json:"account_name,omitempty" validate:"omitempty,min=3,max=100"`
+	TradingSymbol     *string            `json:"trading_symbol,omitempty"`
+	TradingMode       *TradingMode       `json:"trading_mode,omitempty"`
+	EnabledStrategies []string           `json:"enabled_strategies,omitempty"`
+	RiskConfig        *AccountRiskConfig `json:"risk_config,omitempty"`
+	IsActive          *bool              `json:"is_active,omitempty"`
+}
+
+// BinanceKeysRequest links Binance API keys to a trading account
+type BinanceKeysRequest struct {
+	APIKey    string `json:"binance_api_key"`
+	SecretKey string `json:"binance_secret_key"`
+	Testnet   bool   `json:"binance_testnet"`
 }
 
 // TradingAccountResponse is the public response for a trading account
@@ -94,6 +169,11 @@ type TradingAccountResponse struct {
 	TradingMode       TradingMode `json:"trading_mode"`
 	EnabledStrategies []string    `json:"enabled_strategies"`
 
+	// Isolated executor
+	RiskConfig      AccountRiskConfig `json:"risk_config"`
+	ExecutorEnabled bool              `json:"executor_enabled"`
+	KeysLinked      bool              `json:"keys_linked"` // A Binance secret is stored
+
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -109,6 +189,9 @@ func (a *TradingAccount) ToResponse() *TradingAccountResponse {
 		TradingSymbol:     a.TradingSymbol,
 		TradingMode:       a.TradingMode,
 		EnabledStrategies: a.EnabledStrategies,
+		RiskConfig:        a.RiskConfig,
+		ExecutorEnabled:   a.ExecutorEnabled,
+		KeysLinked:        a.BinanceSecretEncrypted != nil && *a.BinanceSecretEncrypted != "",
 		IsActive:          a.IsActive,
 		BinanceTestnet:    a.BinanceTestnet,
 		CreatedAt:         a.CreatedAt,
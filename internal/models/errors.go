@@ -13,6 +13,15 @@ var (
 	ErrWeakPassword         = errors.New("password does not meet requirements")
 	ErrPasswordMismatch     = errors.New("current password is incorrect")
 
+	// Two-factor authentication errors
+	ErrTwoFactorUnavailable    = errors.New("two-factor authentication is disabled, set auth.keyEncryptionKey or the master key")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired       = errors.New("two-factor authentication must be enabled for this operation")
+	ErrTwoFactorCodeRequired   = errors.New("two-factor code required")
+	ErrInvalidTwoFactorCode    = errors.New("invalid or already used two-factor code")
+	ErrTwoFactorLocked         = errors.New("too many invalid two-factor codes, try again later")
+
 	// Account errors
 	ErrAccountNotFound          = errors.New("trading account not found")
 	ErrAccountAlreadyExists     = errors.New("account with this name already exists")
@@ -22,6 +31,8 @@ var (
 	ErrBinanceAPIKeyRequired    = errors.New("binance API key is required for live accounts")
 	ErrBinanceSecretRequired    = errors.New("binance secret key is required for live accounts")
 	ErrAccountInactive          = errors.New("account is inactive")
+	ErrBinanceKeysNotLinked     = errors.New("binance API keys are not linked to this account")
+	ErrKeyEncryptionDisabled    = errors.New("API key storage is disabled, set auth.keyEncryptionKey or the master key")
 
 	// Session errors
 	ErrSessionNotFound = errors.New("session not found")
@@ -28,6 +28,11 @@ type User struct {
 	PasswordResetToken     *string    `json:"-" db:"password_reset_token"`
 	PasswordResetExpires   *time.Time `json:"-" db:"password_reset_expires"`
 	LastLoginAt            *time.Time `json:"last_login_at" db:"last_login_at"`
+	TOTPSecret             *string    `json:"-" db:"totp_secret"`       // Encrypted; set from enrollment until disabled
+	TOTPEnabled            bool       `json:"-" db:"totp_enabled"`      // Enrollment confirmed, codes are required
+	TOTPLastStep           int64      `json:"-" db:"totp_last_step"`    // Time step of the last accepted code, not accepted again
+	TOTPFailures           int        `json:"-" db:"totp_failures"`     // Wrong codes since the last accepted one or lockout
+	TOTPLockedUntil        *time.Time `json:"-" db:"totp_locked_until"` // Codes are refused until then
 	CreatedAt              time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt              time.Time  `json:"updated_at" db:"updated_at"`
 }
@@ -62,6 +67,32 @@ type PasswordResetConfirm struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+// TwoFactorEnrollment is a new TOTP secret for the user's authenticator app
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"` // Base32, for manual entry
+	URI    string `json:"uri"`    // otpauth:// URI, usually shown as a QR code
+}
+
+// TwoFactorStatus describes a user's two-factor authentication
+type TwoFactorStatus struct {
+	Enabled   bool `json:"enabled"`
+	Pending   bool `json:"pending"`   // Enrollment started but not confirmed
+	Required  bool `json:"required"`  // Sensitive operations refuse users without it
+	Available bool `json:"available"` // Secrets can be stored encrypted
+}
+
+// TwoFactorCodeRequest carries a code from the user's authenticator app
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TwoFactorDisableRequest re-authenticates the user before removing
+// two-factor authentication
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
 // UserResponse is the public user response (no sensitive data)
 type UserResponse struct {
 	ID              uuid.UUID  `json:"id"`
@@ -70,6 +101,7 @@ type UserResponse struct {
 	Role            UserRole   `json:"role"`
 	IsActive        bool       `json:"is_active"`
 	IsEmailVerified bool       `json:"is_email_verified"`
+	TwoFactor       bool       `json:"two_factor_enabled"`
 	LastLoginAt     *time.Time `json:"last_login_at"`
 	CreatedAt       time.Time  `json:"created_at"`
 }
@@ -83,6 +115,7 @@ func (u *User) ToResponse() *UserResponse {
 		Role:            u.Role,
 		IsActive:        u.IsActive,
 		IsEmailVerified: u.IsEmailVerified,
+		TwoFactor:       u.TOTPEnabled,
 		LastLoginAt:     u.LastLoginAt,
 		CreatedAt:       u.CreatedAt,
 	}
@@ -0,0 +1,558 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/models"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Errors returned when starting and stopping users' account executors
+var (
+	ErrAccountExecutorsDisabled = errors.New("account executors are not configured")
+	ErrAccountRunning           = errors.New("account executor already running")
+	ErrAccountNotRunning        = errors.New("account executor not running")
+	ErrAccountIsBotAccount      = errors.New("account is the one the bot trades")
+	ErrAccountSymbol            = errors.New("account symbol differs from the bot's")
+)
+
+// AccountExecutorFactory builds the executor of a user's trading account:
+// a paper account, or a live one on the user's own Binance keys when the
+// account trades live
+type AccountExecutorFactory func(account *models.TradingAccount) (execution.Executor, error)
+
+// userAccounts runs users' trading accounts alongside the bot's: every
+// signal that passes the market filters is offered to each account, which
+// sizes and vets it with its own risk manager and trades it on its own
+// executor
+type userAccounts struct {
+	mu       sync.RWMutex
+	factory  AccountExecutorFactory
+	accounts map[string]*userAccount // By trading account ID
+}
+
+// userAccount is a running account executor
+type userAccount struct {
+	mu         sync.Mutex
+	account    models.TradingAccount
+	executor   execution.Executor
+	risk       *risk.Manager
+	strategies map[string]bool // Canonical names traded; empty = all
+	startedAt  time.Time
+	day        time.Time // Start of the day the risk manager's daily stats cover
+	week       time.Time // Start of the week its weekly stats cover
+	trades     int
+	rejected   int
+	lastReject string
+
+	// Realized P&L of positions closed this week. Position events can fire
+	// while mu is held, so they are guarded separately.
+	closedMu sync.Mutex
+	closed   []accountClose
+}
+
+// accountClose is the realized P&L of a closed account position
+type accountClose struct {
+	at  time.Time
+	pnl float64
+}
+
+// AccountStatus is the state of a user's running account executor
+type AccountStatus struct {
+	AccountID     string             `json:"accountId"`
+	UserID        string             `json:"userId"`
+	Name          string             `json:"name"`
+	Mode          models.TradingMode `json:"mode"`
+	StartedAt     time.Time          `json:"startedAt"`
+	Equity        float64            `json:"equity"`
+	DailyPnL      float64            `json:"dailyPnl"`
+	WeeklyPnL     float64            `json:"weeklyPnl"`
+	OpenPositions int                `json:"openPositions"`
+	Trades        int                `json:"trades"`   // Orders placed since start
+	Rejected      int                `json:"rejected"` // Signals the account's risk manager rejected
+	LastReject    string             `json:"lastReject,omitempty"`
+	Halted        bool               `json:"halted"`
+}
+
+// SetAccountExecutorFactory enables users' account executors, built with
+// factory. It must be called before StartAccount.
+func (o *Orchestrator) SetAccountExecutorFactory(factory AccountExecutorFactory) {
+	o.accounts.mu.Lock()
+	defer o.accounts.mu.Unlock()
+	o.accounts.factory = factory
+}
+
+// StartAccount starts the executor of a user's trading account
+func (o *Orchestrator) StartAccount(account *models.TradingAccount) error {
+	id := account.ID.String()
+	if id == o.tradingAccount {
+		return ErrAccountIsBotAccount
+	}
+	if account.TradingSymbol != o.config.Symbol {
+		return fmt.Errorf("%w: %s, the bot trades %s", ErrAccountSymbol, account.TradingSymbol, o.config.Symbol)
+	}
+	if err := account.RiskConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid risk config: %w", err)
+	}
+
+	o.accounts.mu.Lock()
+	defer o.accounts.mu.Unlock()
+	if o.accounts.factory == nil {
+		return ErrAccountExecutorsDisabled
+	}
+	if _, ok := o.accounts.accounts[id]; ok {
+		return ErrAccountRunning
+	}
+
+	exec, err := o.accounts.factory(account)
+	if err != nil {
+		return fmt.Errorf("create executor: %w", err)
+	}
+
+	ua := &userAccount{
+		account:    *account,
+		executor:   exec,
+		risk:       risk.NewManager(o.accountRiskConfig(account.RiskConfig)),
+		strategies: make(map[string]bool),
+		startedAt:  time.Now(),
+	}
+	for _, name := range account.EnabledStrategies {
+		ua.strategies[strategy.CanonicalName(name)] = true
+	}
+
+	if paper, ok := exec.(*execution.PaperExecutor); ok {
+		o.stateMu.RLock()
+		price := o.state.CurrentPrice
+		o.stateMu.RUnlock()
+		if price > 0 {
+			paper.UpdatePrice(o.config.Symbol, price)
+		}
+	}
+	if events, ok := exec.(interface {
+		SetOnPosition(func(execution.PositionEvent))
+	}); ok {
+		events.SetOnPosition(func(event execution.PositionEvent) {
+			defer o.recoverPanic("account.onPosition")
+			o.onAccountPosition(ua, event)
+		})
+	}
+
+	if o.accounts.accounts == nil {
+		o.accounts.accounts = make(map[string]*userAccount)
+	}
+	o.accounts.accounts[id] = ua
+
+	log.Info().
+		Str("account", id).
+		Str("user", account.UserID.String()).
+		Str("mode", string(account.TradingMode)).
+		Msg("Account executor started")
+	return nil
+}
+
+// accountRiskConfig returns the bot's risk config with an account's
+// overrides applied. The high-water mark seeds from the account's first
+// equity reading.
+func (o *Orchestrator) accountRiskConfig(overrides models.AccountRiskConfig) *risk.RiskConfig {
+	var cfg risk.RiskConfig
+	if o.riskManager != nil {
+		cfg = *o.riskManager.GetConfig()
+	}
+	cfg.InitialCapital = 0
+
+	if overrides.MaxPositionSize != nil {
+		cfg.MaxPositionSize = *overrides.MaxPositionSize
+	}
+	if overrides.MaxRiskPerTrade != nil {
+		cfg.MaxRiskPerTrade = *overrides.MaxRiskPerTrade
+	}
+	if overrides.MaxDailyLoss != nil {
+		cfg.MaxDailyLoss = *overrides.MaxDailyLoss
+	}
+	if overrides.MaxWeeklyLoss != nil {
+		cfg.MaxWeeklyLoss = *overrides.MaxWeeklyLoss
+	}
+	if overrides.MaxDrawdown != nil {
+		cfg.MaxTotalDrawdown = *overrides.MaxDrawdown
+	}
+	if overrides.MaxOpenPositions != nil {
+		cfg.MaxOpenPositions = *overrides.MaxOpenPositions
+	}
+	if overrides.MinRiskRewardRatio != nil {
+		cfg.MinRiskRewardRatio = *overrides.MinRiskRewardRatio
+	}
+	return &cfg
+}
+
+// StopAccount stops a user's account executor and returns its final
+// equity. Paper positions are closed at the last price; live positions
+// stay open on the exchange.
+func (o *Orchestrator) StopAccount(accountID uuid.UUID) (float64, error) {
+	id := accountID.String()
+	o.accounts.mu.Lock()
+	ua, ok := o.accounts.accounts[id]
+	delete(o.accounts.accounts, id)
+	o.accounts.mu.Unlock()
+	if !ok {
+		return 0, ErrAccountNotRunning
+	}
+	return o.stopUserAccount(ua), nil
+}
+
+// StopAccounts stops every running account executor and returns their
+// final equity by account ID
+func (o *Orchestrator) StopAccounts() map[uuid.UUID]float64 {
+	o.accounts.mu.Lock()
+	running := o.accounts.accounts
+	o.accounts.accounts = nil
+	o.accounts.mu.Unlock()
+
+	equity := make(map[uuid.UUID]float64, len(running))
+	for _, ua := range running {
+		equity[ua.account.ID] = o.stopUserAccount(ua)
+	}
+	return equity
+}
+
+// stopUserAccount flattens a paper account and releases a live one
+func (o *Orchestrator) stopUserAccount(ua *userAccount) float64 {
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	if _, ok := ua.executor.(*execution.PaperExecutor); ok {
+		positions, _ := ua.executor.GetPositions()
+		for _, pos := range positions {
+			_, err := ua.executor.ClosePosition(pos.ID)
+			o.auditPositionClose(ua.account.ID.String(), pos, "account stopped", err)
+			if err != nil {
+				log.Warn().Err(err).Str("account", ua.account.ID.String()).Int64("position", pos.ID).Msg("Failed to close account position")
+			}
+		}
+	}
+	equity, err := ua.executor.GetEquity()
+	if err != nil {
+		log.Warn().Err(err).Str("account", ua.account.ID.String()).Msg("Failed to read account equity")
+	}
+	if stopper, ok := ua.executor.(interface{ Stop() }); ok {
+		stopper.Stop()
+	}
+
+	log.Info().
+		Str("account", ua.account.ID.String()).
+		Float64("equity", equity).
+		Int("trades", ua.trades).
+		Msg("Account executor stopped")
+	return equity
+}
+
+// GetAccountStatus returns the state of a running account executor
+func (o *Orchestrator) GetAccountStatus(accountID uuid.UUID) (AccountStatus, error) {
+	o.accounts.mu.RLock()
+	ua, ok := o.accounts.accounts[accountID.String()]
+	o.accounts.mu.RUnlock()
+	if !ok {
+		return AccountStatus{}, ErrAccountNotRunning
+	}
+	return o.accountStatus(ua), nil
+}
+
+// GetAccountStatuses returns the state of every running account executor,
+// oldest first
+func (o *Orchestrator) GetAccountStatuses() []AccountStatus {
+	o.accounts.mu.RLock()
+	running := make([]*userAccount, 0, len(o.accounts.accounts))
+	for _, ua := range o.accounts.accounts {
+		running = append(running, ua)
+	}
+	o.accounts.mu.RUnlock()
+
+	statuses := make([]AccountStatus, 0, len(running))
+	for _, ua := range running {
+		statuses = append(statuses, o.accountStatus(ua))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].StartedAt.Before(statuses[j].StartedAt) })
+	return statuses
+}
+
+// accountStatus refreshes and reports an account's state
+func (o *Orchestrator) accountStatus(ua *userAccount) AccountStatus {
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	equity, daily, weekly, open := o.refreshAccountRisk(ua, time.Now())
+	return AccountStatus{
+		AccountID:     ua.account.ID.String(),
+		UserID:        ua.account.UserID.String(),
+		Name:          ua.account.AccountName,
+		Mode:          ua.account.TradingMode,
+		StartedAt:     ua.startedAt,
+		Equity:        equity,
+		DailyPnL:      daily,
+		WeeklyPnL:     weekly,
+		OpenPositions: open,
+		Trades:        ua.trades,
+		Rejected:      ua.rejected,
+		LastReject:    ua.lastReject,
+		Halted:        ua.risk.IsHalted(),
+	}
+}
+
+// refreshAccountRisk feeds an account's equity, positions and the P&L
+// realized this calendar day and week to its risk manager. The caller holds
+// ua.mu.
+func (o *Orchestrator) refreshAccountRisk(ua *userAccount, now time.Time) (equity, daily, weekly float64, open int) {
+	equity, err := ua.executor.GetEquity()
+	if err != nil {
+		log.Warn().Err(err).Str("account", ua.account.ID.String()).Msg("Failed to read account equity")
+		return 0, 0, 0, 0
+	}
+	positions, _ := ua.executor.GetPositions()
+	var unrealizedPnL float64
+	for _, pos := range positions {
+		unrealizedPnL += pos.UnrealizedPnL
+	}
+
+	day, week := o.pnlPeriodStarts(now)
+	if !ua.day.Equal(day) {
+		ua.day = day
+		ua.risk.ResetDailyStats()
+	}
+	if !ua.week.Equal(week) {
+		ua.week = week
+		ua.risk.ResetWeeklyStats()
+	}
+	daily, weekly = ua.periodPnL(day, week)
+
+	ua.risk.UpdateAccountState(equity, equity-unrealizedPnL, unrealizedPnL, daily, weekly, len(positions))
+	ua.risk.CheckCircuitBreaker()
+	return equity, daily, weekly, len(positions)
+}
+
+// tradeAccounts offers a signal that passed the market filters to every
+// running account executor
+func (o *Orchestrator) tradeAccounts(signal strategy.Signal) {
+	o.accounts.mu.RLock()
+	running := make([]*userAccount, 0, len(o.accounts.accounts))
+	for _, ua := range o.accounts.accounts {
+		running = append(running, ua)
+	}
+	o.accounts.mu.RUnlock()
+
+	for _, ua := range running {
+		o.tradeAccount(ua, signal)
+	}
+}
+
+// tradeAccount sizes a signal with an account's risk manager and places it
+// on the account's executor
+func (o *Orchestrator) tradeAccount(ua *userAccount, signal strategy.Signal) {
+	defer o.recoverPanic("account.trade")
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	if len(ua.strategies) > 0 && !ua.strategies[strategy.CanonicalName(signal.Strategy)] {
+		return
+	}
+	id := ua.account.ID.String()
+
+	equity, _, _, _ := o.refreshAccountRisk(ua, time.Now())
+	if equity <= 0 {
+		return
+	}
+	assessment := ua.risk.AssessTrade(risk.TradeParams{
+		Symbol:     signal.Symbol,
+		Direction:  signal.Direction.String(),
+		EntryPrice: signal.Price,
+		StopLoss:   signal.StopLoss,
+		TakeProfit: signal.TakeProfit,
+		ATR:        signal.Indicators.ATR,
+		Strategy:   signal.Strategy,
+	})
+	if !assessment.Approved || assessment.AdjustedSize <= 0 {
+		ua.rejected++
+		if len(assessment.Reasons) > 0 {
+			ua.lastReject = assessment.Reasons[0]
+		}
+		log.Debug().Str("account", id).Str("strategy", signal.Strategy).Str("reason", ua.lastReject).Msg("Signal rejected by account risk manager")
+		return
+	}
+
+	side := execution.OrderSideBuy
+	if signal.Direction == strategy.DirectionShort {
+		side = execution.OrderSideSell
+	}
+	order := &execution.Order{
+		ClientID: uuid.New().String(),
+		Symbol:   signal.Symbol,
+		Side:     side,
+		Type:     execution.OrderTypeMarket,
+		Quantity: strategy.ScaleSize(assessment.AdjustedSize, signal.SizeFactor),
+		Strategy: signal.Strategy,
+		Signal:   &signal,
+	}
+	result, err := ua.executor.PlaceOrder(order)
+	o.auditPlacement(id, order, result, err)
+	if err == nil && !result.Success {
+		err = errors.New(result.Message)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("account", id).Str("strategy", signal.Strategy).Msg("Failed to execute account order")
+		o.broadcast(BroadcastMessage{
+			Type:      MessageTypeError,
+			Timestamp: time.Now(),
+			AccountID: id,
+			Data:      ErrorUpdate{Code: "ORDER_FAILED", Message: "Failed to execute order", Details: err.Error(), Time: time.Now()},
+		})
+		return
+	}
+	ua.trades++
+
+	if pos := result.Position; pos != nil && (pos.Side == execution.PositionSideLong) == (side == execution.OrderSideBuy) {
+		if signal.StopLoss > 0 {
+			if err := ua.executor.UpdateStopLoss(pos.ID, signal.StopLoss); err != nil {
+				log.Warn().Err(err).Str("account", id).Msg("Failed to set account stop loss")
+			}
+		}
+		if signal.TakeProfit > 0 {
+			if err := ua.executor.UpdateTakeProfit(pos.ID, signal.TakeProfit); err != nil {
+				log.Warn().Err(err).Str("account", id).Msg("Failed to set account take profit")
+			}
+		}
+	}
+
+	update := TradeUpdate{
+		OrderID:  result.Order.ID,
+		Symbol:   signal.Symbol,
+		Side:     side,
+		Type:     string(execution.OrderTypeMarket),
+		Quantity: result.Order.Quantity,
+		Price:    result.Order.Price,
+		Strategy: signal.Strategy,
+	}
+	if trade := result.Trade; trade != nil {
+		update.TradeID = trade.ID
+		update.Quantity = trade.Quantity
+		update.Price = trade.Price
+		update.Commission = trade.Commission
+		update.RealizedPnL = trade.RealizedPnL
+		update.Timestamp = trade.ExecutedAt
+	}
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeTrade,
+		Timestamp: time.Now(),
+		AccountID: id,
+		Data:      update,
+	})
+
+	log.Info().
+		Str("account", id).
+		Str("strategy", signal.Strategy).
+		Float64("quantity", update.Quantity).
+		Msg("Account order executed")
+}
+
+// onAccountPosition broadcasts an account's position events to its owner
+// and records closed trades with the account's risk manager
+func (o *Orchestrator) onAccountPosition(ua *userAccount, event execution.PositionEvent) {
+	pos := event.Position
+	if pos == nil {
+		return
+	}
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypePosition,
+		Timestamp: time.Now(),
+		AccountID: ua.account.ID.String(),
+		Data: PositionUpdate{
+			PositionID:    pos.ID,
+			Symbol:        pos.Symbol,
+			Side:          pos.Side,
+			Quantity:      pos.Quantity,
+			EntryPrice:    pos.EntryPrice,
+			CurrentPrice:  pos.CurrentPrice,
+			StopLoss:      pos.StopLoss,
+			TakeProfit:    pos.TakeProfit,
+			UnrealizedPnL: pos.UnrealizedPnL,
+			RealizedPnL:   pos.RealizedPnL,
+			Strategy:      pos.Strategy,
+			OpenTime:      pos.OpenTime,
+			EventType:     event.Type.String(),
+		},
+	})
+
+	switch event.Type {
+	case execution.PositionEventClosed, execution.PositionEventStopLossHit, execution.PositionEventTakeProfitHit:
+	default:
+		return
+	}
+	exit, pnl := pos.CurrentPrice, pos.RealizedPnL
+	if event.Trade != nil {
+		exit, pnl = event.Trade.Price, event.Trade.RealizedPnL
+	}
+	ua.recordClose(event.Timestamp, pnl)
+	var pnlPercent float64
+	if notional := pos.EntryPrice * pos.Quantity; notional > 0 {
+		pnlPercent = pnl / notional * 100
+	}
+	ua.risk.RecordTrade(risk.TradeMetrics{
+		EntryPrice: pos.EntryPrice,
+		ExitPrice:  exit,
+		Quantity:   pos.Quantity,
+		Direction:  string(pos.Side),
+		PnL:        pnl,
+		PnLPercent: pnlPercent,
+		Duration:   event.Timestamp.Sub(pos.OpenTime),
+		IsWin:      pnl > 0,
+	})
+}
+
+// recordClose adds a closed position's realized P&L, dropping closes older
+// than a week
+func (ua *userAccount) recordClose(at time.Time, pnl float64) {
+	ua.closedMu.Lock()
+	defer ua.closedMu.Unlock()
+
+	cutoff := at.AddDate(0, 0, -7)
+	kept := ua.closed[:0]
+	for _, c := range ua.closed {
+		if c.at.After(cutoff) {
+			kept = append(kept, c)
+		}
+	}
+	ua.closed = append(kept, accountClose{at: at, pnl: pnl})
+}
+
+// periodPnL returns the P&L realized since the start of the day and week
+func (ua *userAccount) periodPnL(dayStart, weekStart time.Time) (daily, weekly float64) {
+	ua.closedMu.Lock()
+	defer ua.closedMu.Unlock()
+
+	for _, c := range ua.closed {
+		if c.at.Before(weekStart) {
+			continue
+		}
+		weekly += c.pnl
+		if !c.at.Before(dayStart) {
+			daily += c.pnl
+		}
+	}
+	return daily, weekly
+}
+
+// updateAccountPrices marks running paper accounts to a trade price
+func (o *Orchestrator) updateAccountPrices(symbol string, price float64, t time.Time) {
+	o.accounts.mu.RLock()
+	defer o.accounts.mu.RUnlock()
+	for _, ua := range o.accounts.accounts {
+		if paper, ok := ua.executor.(*execution.PaperExecutor); ok {
+			paper.UpdatePriceAt(symbol, price, t)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/eth-trading/internal/execution"
+)
+
+// daysPerMonth is the average month length used for trade frequency
+const daysPerMonth = 30.44
+
+// ActivityMetrics describes trading frequency and capital turnover
+type ActivityMetrics struct {
+	TradedNotional  float64   `json:"tradedNotional"`
+	AverageEquity   float64   `json:"averageEquity"`
+	Turnover        float64   `json:"turnover"`    // Traded notional / average equity
+	TotalTrades     int       `json:"totalTrades"` // Completed round trips
+	TradesPerMonth  float64   `json:"tradesPerMonth"`
+	AvgExposureTime string    `json:"avgExposureTime"` // Average time a position stays open
+	TimeInMarket    float64   `json:"timeInMarket"`    // Fraction of time with an open position
+	Since           time.Time `json:"since"`
+
+	// Time underwater
+	DrawdownDuration    string  `json:"drawdownDuration"`    // Time below the equity high-water mark so far
+	MaxDrawdownDuration string  `json:"maxDrawdownDuration"` // Longest time below it, counting the current stretch
+	LongestFlatPeriod   string  `json:"longestFlatPeriod"`   // Longest stretch with equity within 0.5% of its start
+	UlcerIndex          float64 `json:"ulcerIndex"`          // RMS of the percent drawdowns from the high-water mark
+}
+
+// activityTracker integrates equity and market exposure over time
+type activityTracker struct {
+	mu           sync.Mutex
+	lastSample   time.Time
+	lastEquity   float64
+	lastInMarket bool
+	equitySecs   float64 // Time-weighted sum of equity
+	total        time.Duration
+	inMarket     time.Duration
+	underwater   backtest.Underwater
+}
+
+// sample records the current equity and exposure
+func (t *activityTracker) sample(now time.Time, equity float64, inMarket bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.lastSample.IsZero() {
+		elapsed := now.Sub(t.lastSample)
+		if elapsed > 0 {
+			t.equitySecs += t.lastEquity * elapsed.Seconds()
+			t.total += elapsed
+			if t.lastInMarket {
+				t.inMarket += elapsed
+			}
+		}
+	}
+
+	t.lastSample = now
+	t.lastEquity = equity
+	t.lastInMarket = inMarket
+	t.underwater.Add(now, equity)
+}
+
+// averages returns the time-weighted average equity and share of time in market
+func (t *activityTracker) averages() (avgEquity, timeInMarket float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.total <= 0 {
+		return t.lastEquity, 0
+	}
+	return t.equitySecs / t.total.Seconds(), float64(t.inMarket) / float64(t.total)
+}
+
+// recordUnderwater copies the time spent below the equity high-water mark
+// into metrics
+func (t *activityTracker) recordUnderwater(metrics *ActivityMetrics) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	metrics.DrawdownDuration = t.underwater.Current().Round(time.Second).String()
+	metrics.MaxDrawdownDuration = t.underwater.Longest().Round(time.Second).String()
+	metrics.LongestFlatPeriod = t.underwater.LongestFlat().Round(time.Second).String()
+	metrics.UlcerIndex = t.underwater.UlcerIndex()
+}
+
+// GetActivityMetrics returns live turnover, trade frequency, exposure and
+// drawdown duration metrics
+func (o *Orchestrator) GetActivityMetrics() *ActivityMetrics {
+	avgEquity, timeInMarket := o.activity.averages()
+
+	metrics := &ActivityMetrics{
+		AverageEquity: avgEquity,
+		TimeInMarket:  timeInMarket,
+		Since:         o.startTime,
+	}
+	o.activity.recordUnderwater(metrics)
+
+	exec := o.activeExecutor()
+	history, ok := exec.(interface{ GetTrades() []*execution.Trade })
+	if !ok {
+		return metrics
+	}
+
+	openIDs := make(map[int64]bool)
+	if positions, err := exec.GetPositions(); err == nil {
+		for _, pos := range positions {
+			openIDs[pos.ID] = true
+		}
+	}
+
+	// Group fills by position to measure how long each round trip was exposed
+	type span struct{ first, last time.Time }
+	spans := make(map[int64]*span)
+	for _, trade := range history.GetTrades() {
+		metrics.TradedNotional += trade.Quantity * trade.Price
+
+		s, exists := spans[trade.PositionID]
+		if !exists {
+			spans[trade.PositionID] = &span{first: trade.ExecutedAt, last: trade.ExecutedAt}
+			continue
+		}
+		if trade.ExecutedAt.Before(s.first) {
+			s.first = trade.ExecutedAt
+		}
+		if trade.ExecutedAt.After(s.last) {
+			s.last = trade.ExecutedAt
+		}
+	}
+
+	// Trades restored from before a restart count from when they were made
+	var exposure time.Duration
+	tradesSince := o.startTime
+	for id, s := range spans {
+		if openIDs[id] {
+			continue
+		}
+		metrics.TotalTrades++
+		exposure += s.last.Sub(s.first)
+		if tradesSince.IsZero() || s.first.Before(tradesSince) {
+			tradesSince = s.first
+		}
+	}
+
+	if metrics.TotalTrades > 0 {
+		metrics.AvgExposureTime = (exposure / time.Duration(metrics.TotalTrades)).Round(time.Second).String()
+	}
+	if avgEquity > 0 {
+		metrics.Turnover = metrics.TradedNotional / avgEquity
+	}
+	if !tradesSince.IsZero() {
+		months := time.Since(tradesSince).Hours() / 24 / daysPerMonth
+		if months > 0 {
+			metrics.TradesPerMonth = float64(metrics.TotalTrades) / months
+		}
+	}
+
+	return metrics
+}
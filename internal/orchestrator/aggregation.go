@@ -0,0 +1,272 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// maxAggregatedTimeframe is the longest timeframe built from 1m candles;
+// longer ones are streamed from Binance
+const maxAggregatedTimeframe = 24 * time.Hour
+
+// candleAggregation builds timeframes locally from the 1m kline stream
+type candleAggregation struct {
+	enabled bool
+	mu      sync.Mutex
+	forming map[string]*storage.Candle // Closed minutes of each timeframe's open bar
+}
+
+// SetCandleAggregation validates the monitored timeframes and, when enabled,
+// builds those up to a day from the 1m kline stream instead of subscribing
+// to each. Custom timeframes such as 45m or 2h need aggregation. It must be
+// called before Start.
+func (o *Orchestrator) SetCandleAggregation(enabled bool) error {
+	for _, tf := range append([]string{o.config.PrimaryTimeframe}, o.config.Timeframes...) {
+		d, err := binance.ParseInterval(tf)
+		if err != nil {
+			return err
+		}
+		if binance.IsNativeInterval(tf) {
+			continue
+		}
+		if !enabled {
+			return fmt.Errorf("timeframe %s is not a Binance interval, enable candle aggregation to build it from 1m candles", tf)
+		}
+		if d > maxAggregatedTimeframe {
+			return fmt.Errorf("timeframe %s is longer than the %s candles can be aggregated to", tf, maxAggregatedTimeframe)
+		}
+	}
+
+	o.aggregation.mu.Lock()
+	defer o.aggregation.mu.Unlock()
+	o.aggregation.enabled = enabled
+	o.aggregation.forming = make(map[string]*storage.Candle)
+	return nil
+}
+
+// aggregating reports whether tf is built from 1m candles
+func (o *Orchestrator) aggregating(tf string) bool {
+	if !o.aggregation.enabled || tf == binance.Interval1m {
+		return false
+	}
+	return binance.IntervalToDuration(tf) <= maxAggregatedTimeframe
+}
+
+// candleTimeframes returns the timeframes kept in the data service: the
+// monitored ones, led by 1m when the others are aggregated from it
+func (o *Orchestrator) candleTimeframes() []string {
+	if !o.aggregation.enabled {
+		return o.config.Timeframes
+	}
+	timeframes := []string{binance.Interval1m}
+	for _, tf := range o.config.Timeframes {
+		if tf != binance.Interval1m {
+			timeframes = append(timeframes, tf)
+		}
+	}
+	return timeframes
+}
+
+// streamedTimeframes returns the timeframes subscribed to on the kline stream
+func (o *Orchestrator) streamedTimeframes() []string {
+	var streamed []string
+	for _, tf := range o.candleTimeframes() {
+		if !o.aggregating(tf) {
+			streamed = append(streamed, tf)
+		}
+	}
+	return streamed
+}
+
+// bucketStart returns the open time of the bar of length d containing t.
+// Bars are aligned to the Unix epoch, as Binance aligns intervals up to a day.
+func bucketStart(t time.Time, d time.Duration) time.Time {
+	ms := t.UnixMilli()
+	return time.UnixMilli(ms - ms%d.Milliseconds())
+}
+
+// foldCandle folds a shorter candle into a bar, opening it when empty
+func foldCandle(bar *storage.Candle, c storage.Candle) {
+	if bar.Open == 0 {
+		bar.Open = c.Open
+		bar.High = c.High
+		bar.Low = c.Low
+	}
+	if c.High > bar.High {
+		bar.High = c.High
+	}
+	if c.Low < bar.Low {
+		bar.Low = c.Low
+	}
+	bar.Close = c.Close
+	bar.Volume += c.Volume
+	bar.Trades += c.Trades
+}
+
+// newBar returns an empty bar of timeframe tf opening at start
+func (o *Orchestrator) newBar(tf string, start time.Time, d time.Duration) storage.Candle {
+	return storage.Candle{
+		Symbol:    o.config.Symbol,
+		Timeframe: tf,
+		OpenTime:  start,
+		CloseTime: start.Add(d - time.Millisecond),
+	}
+}
+
+// aggregateCandles folds candles of length base, oldest first, into the
+// complete bars of timeframe tf. Bars missing a candle are dropped.
+func (o *Orchestrator) aggregateCandles(candles []storage.Candle, tf string, d, base time.Duration) []storage.Candle {
+	per := int(d / base)
+	var bars []storage.Candle
+	var bar storage.Candle
+	count := 0
+	for _, c := range candles {
+		start := bucketStart(c.OpenTime, d)
+		if count > 0 && !bar.OpenTime.Equal(start) {
+			if count == per {
+				bars = append(bars, bar)
+			}
+			count = 0
+		}
+		if count == 0 {
+			bar = o.newBar(tf, start, d)
+		}
+		foldCandle(&bar, c)
+		count++
+	}
+	if count == per {
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
+// aggregateRange returns the complete bars of tf opening within [from, to),
+// built from the longest Binance interval that divides tf. Candles missing
+// locally are fetched and persisted.
+func (o *Orchestrator) aggregateRange(tf string, from, to time.Time) ([]storage.Candle, error) {
+	d := binance.IntervalToDuration(tf)
+	base := binance.BaseInterval(d)
+	from = bucketStart(from, d)
+	if !from.Before(to) {
+		return nil, nil
+	}
+
+	rng, err := o.candleRange(o.config.Symbol, base, from, to.Add(-time.Millisecond), 0, maxBacktestFetchBars)
+	if err != nil {
+		return nil, err
+	}
+	bars := o.aggregateCandles(rng.Candles, tf, d, binance.IntervalToDuration(base))
+	if rng.Partial {
+		return bars, fmt.Errorf("%s candles missing, %d %s bars built", base, len(bars), tf)
+	}
+	return bars, nil
+}
+
+// minuteBar folds the stored 1m candles opening within [start, before) into
+// a bar of tf, fetching those missing
+func (o *Orchestrator) minuteBar(tf string, start, before time.Time, d time.Duration) storage.Candle {
+	bar := o.newBar(tf, start, d)
+	if !start.Before(before) {
+		return bar
+	}
+	rng, err := o.candleRange(o.config.Symbol, binance.Interval1m, start, before.Add(-time.Millisecond), 0, maxHistoryFetchBars)
+	if err != nil {
+		log.Warn().Err(err).Str("timeframe", tf).Msg("Failed to read 1m candles to aggregate")
+		return bar
+	}
+	for _, c := range rng.Candles {
+		foldCandle(&bar, c)
+	}
+	return bar
+}
+
+// aggregateMinute folds a 1m kline update into the open bar of every
+// aggregated timeframe. Bars the minute completes are rebuilt from stored
+// 1m candles, so minutes the stream dropped are fetched, and processed
+// longest timeframe first so the primary timeframe's trading logic sees
+// the higher bars closing with it.
+func (o *Orchestrator) aggregateMinute(minute storage.Candle, closed bool, receivedAt time.Time) {
+	var timeframes []string
+	for _, tf := range o.config.Timeframes {
+		if o.aggregating(tf) {
+			timeframes = append(timeframes, tf)
+		}
+	}
+	sort.Slice(timeframes, func(i, j int) bool {
+		return binance.IntervalToDuration(timeframes[i]) > binance.IntervalToDuration(timeframes[j])
+	})
+
+	for _, tf := range timeframes {
+		d := binance.IntervalToDuration(tf)
+		start := bucketStart(minute.OpenTime, d)
+		completes := minute.OpenTime.Add(time.Minute).Equal(start.Add(d))
+
+		if closed && completes {
+			bar := o.minuteBar(tf, start, minute.OpenTime, d)
+			foldCandle(&bar, minute)
+			bar.IsClosed = true
+
+			o.aggregation.mu.Lock()
+			delete(o.aggregation.forming, tf)
+			o.aggregation.mu.Unlock()
+
+			o.processCandleUpdate(&bar, true, receivedAt)
+			continue
+		}
+
+		o.aggregation.mu.Lock()
+		forming := o.aggregation.forming[tf]
+		o.aggregation.mu.Unlock()
+		if forming == nil || !forming.OpenTime.Equal(start) {
+			// First update of the bar, or of the bot mid-bar
+			seeded := o.minuteBar(tf, start, minute.OpenTime, d)
+			forming = &seeded
+		}
+
+		bar := *forming
+		foldCandle(&bar, minute)
+		if closed {
+			forming = &bar
+		}
+		o.aggregation.mu.Lock()
+		o.aggregation.forming[tf] = forming
+		o.aggregation.mu.Unlock()
+
+		o.processCandleUpdate(&bar, false, receivedAt)
+	}
+}
+
+// loadAggregatedHistory loads the last 500 bars of a custom timeframe,
+// built from the longest Binance interval dividing it
+func (o *Orchestrator) loadAggregatedHistory(tf string) {
+	d := binance.IntervalToDuration(tf)
+	now := time.Now()
+	bars, err := o.aggregateRange(tf, bucketStart(now, d).Add(-500*d), now)
+	if err != nil {
+		log.Warn().Str("timeframe", tf).Err(err).Msg("Failed to load candles to aggregate")
+	}
+
+	for _, bar := range bars {
+		if bar.CloseTime.After(now) {
+			continue
+		}
+		bar.IsClosed = true
+		o.dataService.AddCandle(bar)
+
+		o.stateMu.Lock()
+		o.recordCandleCloseLocked(tf, bar.CloseTime)
+		o.stateMu.Unlock()
+	}
+
+	log.Debug().
+		Str("timeframe", tf).
+		Str("base", binance.BaseInterval(d)).
+		Int("count", len(bars)).
+		Msg("Loaded aggregated history")
+}
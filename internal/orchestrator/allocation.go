@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/risk"
+	"github.com/rs/zerolog/log"
+)
+
+// SetCapitalAllocator sets the per-strategy capital allocator
+func (o *Orchestrator) SetCapitalAllocator(a *risk.CapitalAllocator) {
+	o.allocator = a
+}
+
+// GetCapitalAllocator returns the capital allocator
+func (o *Orchestrator) GetCapitalAllocator() *risk.CapitalAllocator {
+	return o.allocator
+}
+
+// GetStrategyAllocations returns each strategy's capital, usage and buying power
+func (o *Orchestrator) GetStrategyAllocations() []risk.StrategyAllocation {
+	if o.allocator == nil || o.activeExecutor() == nil {
+		return nil
+	}
+	equity, _ := o.equity()
+	return o.allocator.Allocations(equity, o.strategyExposure())
+}
+
+// RebalanceCapital recomputes strategy weights and pushes budgets to the executor
+func (o *Orchestrator) RebalanceCapital(reason string) (*risk.AllocationSnapshot, error) {
+	if o.allocator == nil || o.activeExecutor() == nil {
+		return nil, nil
+	}
+
+	equity, err := o.equity()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := o.allocator.Rebalance(equity, o.strategyPerformance(), reason)
+	o.applyStrategyBudgets(equity)
+	return &snapshot, nil
+}
+
+// applyStrategyBudgets hands the current budgets to executors that enforce them
+func (o *Orchestrator) applyStrategyBudgets(equity float64) {
+	if paperExec, ok := o.activeExecutor().(*execution.PaperExecutor); ok {
+		paperExec.SetStrategyBudgets(o.allocator.Budgets(equity))
+	}
+}
+
+// handleStrategyChange gives strategies added or enabled at runtime a share
+// of capital and withdraws it from those removed or disabled
+func (o *Orchestrator) handleStrategyChange(name string, active bool) {
+	if o.allocator == nil {
+		return
+	}
+
+	changed, reason := false, ""
+	if active {
+		changed, reason = o.allocator.AddStrategy(name), "strategy "+name+" activated"
+	} else {
+		changed, reason = o.allocator.RemoveStrategy(name), "strategy "+name+" deactivated"
+	}
+	if !changed {
+		return
+	}
+	if _, err := o.RebalanceCapital(reason); err != nil {
+		log.Warn().Err(err).Str("strategy", name).Msg("Capital reallocation after strategy change failed")
+	}
+}
+
+// syncAllocatorStrategies allocates capital to exactly the enabled strategies
+func (o *Orchestrator) syncAllocatorStrategies() {
+	if o.strategyMgr == nil {
+		return
+	}
+	for name, s := range o.strategyMgr.GetStrategies() {
+		if s.IsEnabled() {
+			o.allocator.AddStrategy(name)
+		} else {
+			o.allocator.RemoveStrategy(name)
+		}
+	}
+}
+
+// strategyBuyingPower returns the capital a strategy may still deploy
+func (o *Orchestrator) strategyBuyingPower(strategyName string, equity float64) float64 {
+	return o.allocator.BuyingPower(strategyName, equity, o.strategyExposure()[strategyName])
+}
+
+// strategyExposure returns the entry notional of open positions per strategy
+func (o *Orchestrator) strategyExposure() map[string]float64 {
+	exposure := make(map[string]float64)
+	positions, err := o.activeExecutor().GetPositions()
+	if err != nil {
+		return exposure
+	}
+	for _, pos := range positions {
+		exposure[pos.Strategy] += pos.EntryPrice * pos.Quantity
+	}
+	return exposure
+}
+
+// strategyPerformance summarizes realized P&L per strategy from executed trades
+func (o *Orchestrator) strategyPerformance() map[string]risk.StrategyPerformance {
+	perf := make(map[string]risk.StrategyPerformance)
+	history, ok := o.activeExecutor().(interface{ GetTrades() []*execution.Trade })
+	if !ok {
+		return perf
+	}
+
+	for _, trade := range history.GetTrades() {
+		if trade.Strategy == "" || trade.RealizedPnL == 0 {
+			continue
+		}
+		p := perf[trade.Strategy]
+		p.RealizedPnL += trade.RealizedPnL
+		p.Trades++
+		perf[trade.Strategy] = p
+	}
+	return perf
+}
+
+// allocationLoop runs periodic capital reallocation
+func (o *Orchestrator) allocationLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if o.allocator.DueForRebalance(now) {
+				if _, err := o.RebalanceCapital("scheduled"); err != nil {
+					log.Warn().Err(err).Msg("Scheduled capital reallocation failed")
+				}
+			} else if equity, err := o.equity(); err == nil {
+				// Keep budgets in step with equity between reallocations
+				o.applyStrategyBudgets(equity)
+			}
+			beat()
+		}
+	}
+}
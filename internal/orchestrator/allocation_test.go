@@ -0,0 +1,66 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/strategy"
+)
+
+func TestHotAddedStrategyCanTrade(t *testing.T) {
+	managerConfig := strategy.DefaultManagerConfig()
+	managerConfig.EnableStatArb = false
+	manager := strategy.NewManager(managerConfig, nil)
+
+	var names []string
+	for name := range manager.GetStrategies() {
+		names = append(names, name)
+	}
+
+	paper := execution.NewPaperExecutor(nil)
+	paper.UpdatePrice("ETHUSDT", 2000)
+
+	o := NewOrchestrator(nil)
+	o.SetExecutor(paper)
+	o.SetStrategyManager(manager)
+	o.SetCapitalAllocator(risk.NewCapitalAllocator(nil, names))
+	if _, err := o.RebalanceCapital("initial"); err != nil {
+		t.Fatalf("initial allocation: %v", err)
+	}
+
+	added := strategy.NewStatArbStrategy(nil)
+	manager.AddStrategy(added)
+
+	equity, err := o.equity()
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	buyingPower := o.strategyBuyingPower(added.Name(), equity)
+	if buyingPower <= 0 {
+		t.Fatalf("hot-added strategy has no buying power")
+	}
+
+	result, err := paper.PlaceOrder(&execution.Order{
+		ClientID: "hot-added",
+		Symbol:   "ETHUSDT",
+		Side:     execution.OrderSideBuy,
+		Type:     execution.OrderTypeMarket,
+		Quantity: buyingPower * 0.5 / 2000,
+		Strategy: added.Name(),
+	})
+	if err != nil || !result.Success {
+		t.Fatalf("order for hot-added strategy not filled: %v %s", err, result.Message)
+	}
+
+	if o.strategyBuyingPower("copy:leader", equity) <= 0 {
+		t.Errorf("copied signals have no buying power")
+	}
+
+	manager.DisableStrategy(added.Name())
+	for _, a := range o.GetStrategyAllocations() {
+		if a.Strategy == added.Name() {
+			t.Errorf("disabled strategy still has an allocation")
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// ArmingState is the phase of the live-mode arming sequence
+type ArmingState string
+
+const (
+	ArmingDisarmed  ArmingState = "disarmed"
+	ArmingCountdown ArmingState = "arming" // Waiting out the cancel window
+	ArmingArmed     ArmingState = "armed"
+)
+
+// ErrArmingChecksFailed is returned when live trading requirements are not met
+var ErrArmingChecksFailed = errors.New("live trading requirements not met")
+
+// ArmingCheck is one minimum configuration requirement for live trading
+type ArmingCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ArmingStatus describes the live-mode arming sequence
+type ArmingStatus struct {
+	Required  bool          `json:"required"` // Live mode can only be entered by arming
+	State     ArmingState   `json:"state"`
+	Mode      string        `json:"mode"`
+	Actor     string        `json:"actor,omitempty"` // User behind the last transition
+	Reason    string        `json:"reason,omitempty"`
+	ArmsAt    *time.Time    `json:"armsAt,omitempty"` // End of the cancel window
+	ArmedAt   *time.Time    `json:"armedAt,omitempty"`
+	Countdown float64       `json:"countdownSeconds"`
+	Checks    []ArmingCheck `json:"checks,omitempty"`
+}
+
+// armingControl tracks the arming sequence
+type armingControl struct {
+	required   bool
+	countdown  time.Duration
+	state      ArmingState
+	actor      string
+	reason     string
+	armsAt     time.Time
+	armedAt    time.Time
+	timer      *time.Timer
+	generation int // Invalidates countdowns that were canceled
+	mu         sync.Mutex
+}
+
+// SetArmingPolicy requires live mode to be entered through the arming
+// sequence, with countdown as the cancel window
+func (o *Orchestrator) SetArmingPolicy(countdown time.Duration) {
+	o.arming.mu.Lock()
+	defer o.arming.mu.Unlock()
+	o.arming.required = true
+	o.arming.countdown = countdown
+	o.arming.state = ArmingDisarmed
+}
+
+// ArmingRequired reports whether live mode must be armed
+func (o *Orchestrator) ArmingRequired() bool {
+	o.arming.mu.Lock()
+	defer o.arming.mu.Unlock()
+	return o.arming.required
+}
+
+// liveArmed reports whether the executor may be switched to live mode
+func (o *Orchestrator) liveArmed() bool {
+	o.arming.mu.Lock()
+	defer o.arming.mu.Unlock()
+	return !o.arming.required || o.arming.state == ArmingArmed
+}
+
+// armingPhase returns the current arming state, empty when arming is not required
+func (o *Orchestrator) armingPhase() ArmingState {
+	o.arming.mu.Lock()
+	defer o.arming.mu.Unlock()
+	if !o.arming.required {
+		return ""
+	}
+	return o.arming.state
+}
+
+// GetArmingStatus returns the arming state and the live trading requirements
+func (o *Orchestrator) GetArmingStatus() *ArmingStatus {
+	status := o.armingStatus()
+	status.Checks = o.checkArmingRequirements()
+	return status
+}
+
+// armingStatus snapshots the arming state
+func (o *Orchestrator) armingStatus() *ArmingStatus {
+	o.stateMu.RLock()
+	mode := o.state.Mode
+	o.stateMu.RUnlock()
+
+	o.arming.mu.Lock()
+	defer o.arming.mu.Unlock()
+
+	status := &ArmingStatus{
+		Required:  o.arming.required,
+		State:     o.arming.state,
+		Mode:      mode.String(),
+		Actor:     o.arming.actor,
+		Reason:    o.arming.reason,
+		Countdown: o.arming.countdown.Seconds(),
+	}
+	if status.State == "" {
+		status.State = ArmingDisarmed
+	}
+	if o.arming.state == ArmingCountdown {
+		armsAt := o.arming.armsAt
+		status.ArmsAt = &armsAt
+	}
+	if o.arming.state == ArmingArmed {
+		armedAt := o.arming.armedAt
+		status.ArmedAt = &armedAt
+	}
+	return status
+}
+
+// checkArmingRequirements verifies the minimum configuration for live trading
+func (o *Orchestrator) checkArmingRequirements() []ArmingCheck {
+	o.stateMu.RLock()
+	_, liveRegistered := o.executors[TradingModeLive]
+	o.stateMu.RUnlock()
+	if exec := o.activeExecutor(); exec != nil && exec.GetMode() == execution.ModeLive {
+		liveRegistered = true
+	}
+
+	checks := []ArmingCheck{
+		{Name: "liveExecutor", Passed: liveRegistered, Detail: "Binance live account configured"},
+	}
+
+	if o.riskManager == nil {
+		return append(checks, ArmingCheck{Name: "riskManager", Detail: "risk manager not configured"})
+	}
+
+	cfg := o.riskManager.GetConfig()
+	checks = append(checks,
+		ArmingCheck{
+			Name:   "stopLossEnforced",
+			Passed: o.riskManager.EnforcesStopLoss(),
+			Detail: "entries without a stop loss are rejected or given one (risk.requireStopLoss, risk.stopLoss)",
+		},
+		ArmingCheck{
+			Name:   "maxPositionSize",
+			Passed: cfg.MaxPositionSize > 0 && cfg.MaxPositionValue > 0,
+			Detail: fmt.Sprintf("max position %.1f%% of equity, %.2f value", cfg.MaxPositionSize*100, cfg.MaxPositionValue),
+		},
+		ArmingCheck{
+			Name:   "lossLimits",
+			Passed: cfg.MaxDailyLoss > 0 && cfg.MaxTotalDrawdown > 0,
+			Detail: fmt.Sprintf("daily loss %.1f%%, drawdown %.1f%%", cfg.MaxDailyLoss*100, cfg.MaxTotalDrawdown*100),
+		},
+		ArmingCheck{
+			Name:   "notHalted",
+			Passed: !o.riskManager.IsHalted(),
+			Detail: "risk manager is not halted",
+		},
+	)
+	return checks
+}
+
+// armingChecksPassed reports whether every requirement passed
+func armingChecksPassed(checks []ArmingCheck) bool {
+	for _, c := range checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Arm starts the arming countdown; live mode is entered when it ends
+// unless canceled with Disarm
+func (o *Orchestrator) Arm(actor string) (*ArmingStatus, error) {
+	checks := o.checkArmingRequirements()
+
+	o.arming.mu.Lock()
+	if !o.arming.required {
+		o.arming.mu.Unlock()
+		return nil, fmt.Errorf("live mode arming is not enabled")
+	}
+	if o.arming.state != ArmingDisarmed {
+		state := o.arming.state
+		o.arming.mu.Unlock()
+		return nil, fmt.Errorf("live mode is already %s", state)
+	}
+	if !armingChecksPassed(checks) {
+		o.arming.mu.Unlock()
+		status := o.armingStatus()
+		status.Checks = checks
+		return status, ErrArmingChecksFailed
+	}
+
+	o.arming.generation++
+	generation := o.arming.generation
+	countdown := o.arming.countdown
+	o.arming.state = ArmingCountdown
+	o.arming.actor = actor
+	o.arming.reason = ""
+	o.arming.armsAt = time.Now().Add(countdown)
+	o.arming.timer = time.AfterFunc(countdown, func() {
+		o.completeArming(generation)
+	})
+	o.arming.mu.Unlock()
+
+	log.Warn().
+		Str("actor", actor).
+		Dur("countdown", countdown).
+		Msg("Live trading arming started")
+	o.RecordAudit(storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   AuditArm,
+		Actor:    actor,
+		OldValue: AuditValue(map[string]string{"state": string(ArmingDisarmed)}),
+		NewValue: AuditValue(map[string]interface{}{"state": string(ArmingCountdown), "countdown": countdown.String()}),
+	}, nil)
+
+	status := o.armingStatus()
+	status.Checks = checks
+	o.notifyArming(status)
+	return status, nil
+}
+
+// completeArming switches to live mode once the cancel window has passed
+func (o *Orchestrator) completeArming(generation int) {
+	defer o.recoverPanic("arming")
+
+	o.arming.mu.Lock()
+	if generation != o.arming.generation || o.arming.state != ArmingCountdown {
+		o.arming.mu.Unlock()
+		return
+	}
+	actor := o.arming.actor
+	o.arming.mu.Unlock()
+
+	// Requirements may have changed during the countdown
+	failure := ""
+	if !armingChecksPassed(o.checkArmingRequirements()) {
+		failure = ErrArmingChecksFailed.Error()
+	} else {
+		o.arming.mu.Lock()
+		if generation != o.arming.generation {
+			// Disarmed while the checks ran
+			o.arming.mu.Unlock()
+			return
+		}
+		o.arming.state = ArmingArmed
+		o.arming.armedAt = time.Now()
+		o.arming.mu.Unlock()
+
+		if err := o.SwitchMode(TradingModeLive, CarryPolicyCarry, "armed by "+actor); err != nil {
+			failure = err.Error()
+		}
+	}
+
+	if failure != "" {
+		o.arming.mu.Lock()
+		o.arming.state = ArmingDisarmed
+		o.arming.reason = failure
+		o.arming.mu.Unlock()
+
+		log.Error().Str("reason", failure).Msg("Live trading arming failed")
+		o.broadcastError("ARMING_FAILED", "Live trading arming failed", failure)
+	} else {
+		log.Warn().Str("actor", actor).Msg("Live trading armed")
+	}
+
+	o.notifyArming(o.armingStatus())
+}
+
+// Disarm cancels a pending countdown or leaves live mode for paper trading.
+// Open live positions are carried and keep their exchange stops.
+func (o *Orchestrator) Disarm(actor, reason string) (*ArmingStatus, error) {
+	o.arming.mu.Lock()
+	previous := o.arming.state
+	if previous == ArmingDisarmed || previous == "" {
+		o.arming.mu.Unlock()
+		return nil, fmt.Errorf("live mode is not armed")
+	}
+	o.arming.generation++
+	if o.arming.timer != nil {
+		o.arming.timer.Stop()
+		o.arming.timer = nil
+	}
+	o.arming.state = ArmingDisarmed
+	o.arming.actor = actor
+	o.arming.reason = reason
+	o.arming.mu.Unlock()
+
+	var switchErr error
+	if previous == ArmingArmed {
+		if err := o.SwitchMode(TradingModePaper, CarryPolicyCarry, "disarmed by "+actor); err != nil {
+			// Never keep trading live once disarmed
+			o.Pause()
+			switchErr = fmt.Errorf("disarmed but failed to leave live mode, trading paused: %w", err)
+			o.broadcastError("DISARM_FAILED", "Failed to leave live mode", err.Error())
+		}
+	}
+
+	log.Warn().
+		Str("actor", actor).
+		Str("previous", string(previous)).
+		Str("reason", reason).
+		Msg("Live trading disarmed")
+	o.RecordAudit(storage.AuditEntry{
+		Category: storage.AuditRisk,
+		Action:   AuditDisarm,
+		Actor:    actor,
+		OldValue: AuditValue(map[string]string{"state": string(previous)}),
+		NewValue: AuditValue(map[string]string{"state": string(ArmingDisarmed), "reason": reason}),
+	}, switchErr)
+
+	status := o.armingStatus()
+	o.notifyArming(status)
+	return status, switchErr
+}
+
+// notifyArming broadcasts an arming transition and records it as an alert
+func (o *Orchestrator) notifyArming(status *ArmingStatus) {
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeArming,
+		Timestamp: time.Now(),
+		Data:      status,
+	})
+
+	if o.dataService == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Live trading %s", status.State)
+	switch {
+	case status.State == ArmingCountdown && status.ArmsAt != nil:
+		message = fmt.Sprintf("Live trading arming by %s, live at %s", status.Actor, status.ArmsAt.Format(time.RFC3339))
+	case status.Actor != "":
+		message = fmt.Sprintf("Live trading %s by %s", status.State, status.Actor)
+	}
+	if status.Reason != "" {
+		message += ": " + status.Reason
+	}
+
+	data, _ := json.Marshal(status)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "live_arming",
+		Severity: "warning",
+		Message:  message,
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record arming alert")
+	}
+}
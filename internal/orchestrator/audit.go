@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Audit actions, by category
+const (
+	// storage.AuditOrder
+	AuditOrderPlace    = "order_place"
+	AuditOrderCancel   = "order_cancel"
+	AuditPositionClose = "position_close"
+
+	// storage.AuditConfig, and "settings_" + the action of a
+	// storage.SettingsChange
+	AuditRiskConfig     = "risk_config_update"
+	AuditStrategyParams = "strategy_params_update"
+	AuditModeSwitch     = "mode_switch"
+
+	// storage.AuditStrategy
+	AuditStrategyEnable  = "strategy_enable"
+	AuditStrategyDisable = "strategy_disable"
+
+	// storage.AuditRisk
+	AuditHalt                = "halt"
+	AuditResume              = "resume"
+	AuditCircuitBreakerReset = "circuit_breaker_reset"
+	AuditArm                 = "arm"
+	AuditDisarm              = "disarm"
+	AuditHighWaterMarkReset  = "high_water_mark_reset"
+	AuditCashFlow            = "cash_flow"
+)
+
+// auditedOrder is how an order is recorded in the audit log
+type auditedOrder struct {
+	ClientID string  `json:"clientId,omitempty"`
+	Symbol   string  `json:"symbol"`
+	Side     string  `json:"side"`
+	Type     string  `json:"type"`
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price,omitempty"`
+	Strategy string  `json:"strategy,omitempty"`
+}
+
+// RecordAudit adds an entry to the audit log, failed with err unless it is
+// nil. An empty actor is AuditActorSystem. Write failures are logged rather
+// than returned: the action has already happened.
+func (o *Orchestrator) RecordAudit(entry storage.AuditEntry, err error) {
+	if o.dataService == nil {
+		return
+	}
+	if entry.Actor == "" {
+		entry.Actor = storage.AuditActorSystem
+	}
+	entry.Success = err == nil
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if _, err := o.dataService.RecordAudit(entry); err != nil {
+		log.Error().Err(err).Str("action", entry.Action).Str("actor", entry.Actor).Msg("Failed to record audit entry")
+	}
+}
+
+// GetAuditLog returns the audit entries matching filter, newest first
+func (o *Orchestrator) GetAuditLog(filter storage.AuditFilter) ([]storage.AuditEntry, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+	return o.dataService.GetAuditLog(filter)
+}
+
+// AuditValue encodes the previous or new value of an audit entry; nil
+// stays empty
+func AuditValue(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to encode audit value")
+		return nil
+	}
+	return data
+}
+
+// auditOrder records an order the bot placed or canceled on its own.
+// accountID is empty for the bot's executor.
+func (o *Orchestrator) auditOrder(action, accountID string, order *execution.Order, err error) {
+	resource := order.ID
+	if resource == "" {
+		resource = order.ClientID
+	}
+	o.RecordAudit(storage.AuditEntry{
+		Category:  storage.AuditOrder,
+		Action:    action,
+		Resource:  resource,
+		AccountID: accountID,
+		NewValue: AuditValue(auditedOrder{
+			ClientID: order.ClientID,
+			Symbol:   order.Symbol,
+			Side:     string(order.Side),
+			Type:     string(order.Type),
+			Quantity: order.Quantity,
+			Price:    order.Price,
+			Strategy: order.Strategy,
+		}),
+	}, err)
+}
+
+// auditPlacement records the outcome of placing order
+func (o *Orchestrator) auditPlacement(accountID string, order *execution.Order, result *execution.ExecutionResult, err error) {
+	if err == nil && result != nil && !result.Success {
+		err = errors.New(result.Message)
+	}
+	if result != nil && result.Order != nil {
+		order = result.Order
+	}
+	o.auditOrder(AuditOrderPlace, accountID, order, err)
+}
+
+// auditPositionClose records a position the bot closed on its own
+func (o *Orchestrator) auditPositionClose(accountID string, position *execution.Position, reason string, err error) {
+	o.RecordAudit(storage.AuditEntry{
+		Category:  storage.AuditOrder,
+		Action:    AuditPositionClose,
+		Resource:  fmt.Sprint(position.ID),
+		AccountID: accountID,
+		OldValue: AuditValue(map[string]interface{}{
+			"symbol":   position.Symbol,
+			"side":     position.Side,
+			"quantity": position.Quantity,
+		}),
+		NewValue: AuditValue(map[string]string{"reason": reason}),
+	}, err)
+}
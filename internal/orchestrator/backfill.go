@@ -0,0 +1,139 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// maxBackfillCandles caps the candles fetched to fill one gap; after a
+// longer outage only the most recent ones are restored
+const maxBackfillCandles = 1000
+
+// streamRetryInterval is how often the stream is reconnected once the
+// WebSocket client has given up, or never connected
+const streamRetryInterval = 15 * time.Second
+
+// streamMonitorLoop watches the WebSocket connection. The client reconnects
+// on its own after a drop; once it gives up, or if the first connection
+// failed, the stream is reconnected here and the candles missed are
+// backfilled.
+func (o *Orchestrator) streamMonitorLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	var lastAttempt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if !o.wsClient.IsConnected() && !o.wsClient.IsReconnecting() && time.Since(lastAttempt) >= streamRetryInterval {
+				lastAttempt = time.Now()
+				if err := o.wsClient.Connect(o.ctx); err != nil {
+					log.Warn().Err(err).Msg("Binance WebSocket connection failed, will retry")
+				} else {
+					log.Info().Msg("Binance WebSocket connected - real-time data active")
+					o.resyncStream()
+				}
+			}
+			beat()
+		}
+	}
+}
+
+// resyncStream backfills the closed candles of every timeframe missed while
+// the stream was down
+func (o *Orchestrator) resyncStream() {
+	now := time.Now()
+	for _, tf := range o.candleTimeframes() {
+		o.backfillMu.Lock()
+		if _, err := o.backfillTimeframeLocked(tf, now); err != nil {
+			log.Warn().Err(err).Str("timeframe", tf).Msg("Failed to backfill missing candles")
+		}
+		o.backfillMu.Unlock()
+	}
+}
+
+// backfillTimeframeLocked adds the closed candles of a timeframe missing
+// between the last one stored and until, returning how many were added
+// (backfillMu must be held)
+func (o *Orchestrator) backfillTimeframeLocked(tf string, until time.Time) (int, error) {
+	if o.binanceClient == nil || o.dataService == nil {
+		return 0, nil
+	}
+	duration := binance.IntervalToDuration(tf)
+	last, ok := o.dataService.GetLatestCandle(o.config.Symbol, tf)
+	if !ok || duration <= 0 {
+		return 0, nil
+	}
+
+	// The next candle opens a millisecond after the last one closes
+	start := last.CloseTime.Add(time.Millisecond)
+	if until.Sub(start) < duration {
+		return 0, nil
+	}
+	missing := int(until.Sub(start) / duration)
+	if missing > maxBackfillCandles {
+		start = until.Add(-time.Duration(maxBackfillCandles) * duration)
+		missing = maxBackfillCandles
+	}
+
+	var candles []storage.Candle
+	if o.aggregating(tf) {
+		// Rebuilt from the shorter candles, which are fetched when missing too
+		bars, err := o.aggregateRange(tf, start, until)
+		if err != nil && len(bars) == 0 {
+			return 0, fmt.Errorf("failed to aggregate candles: %w", err)
+		}
+		candles = bars
+	} else {
+		klines, err := o.binanceClient.GetKlines(o.config.Symbol, tf, missing, start.UnixMilli(), until.UnixMilli()-1)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch klines: %w", err)
+		}
+		for _, k := range klines {
+			candles = append(candles, *convertKlineToCandle(k, o.config.Symbol, tf))
+		}
+	}
+
+	now := time.Now()
+	added := 0
+	var lastClose time.Time
+	for i := range candles {
+		candle := &candles[i]
+		if candle.OpenTime.Before(start) || !candle.OpenTime.Before(until) || candle.CloseTime.After(now) {
+			continue
+		}
+		candle.IsClosed = true
+		o.dataService.AddCandle(*candle)
+		added++
+		lastClose = candle.CloseTime
+	}
+
+	if added > 0 {
+		o.stateMu.Lock()
+		o.state.CandleCount += added
+		if lastClose.After(o.state.LastCandleTime) {
+			o.state.LastCandleTime = lastClose
+		}
+		o.recordCandleCloseLocked(tf, lastClose)
+		o.stateMu.Unlock()
+	}
+
+	log.Info().
+		Str("timeframe", tf).
+		Time("from", start).
+		Int("missing", missing).
+		Int("added", added).
+		Msg("Backfilled missing candles")
+
+	if added < missing {
+		return added, fmt.Errorf("exchange returned %d of %d missing candles", added, missing)
+	}
+	return added, nil
+}
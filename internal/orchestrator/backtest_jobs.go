@@ -0,0 +1,347 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Backtest job states
+const (
+	BacktestQueued    = "queued"
+	BacktestRunning   = "running"
+	BacktestCompleted = "completed"
+	BacktestFailed    = "failed"
+)
+
+const (
+	defaultBacktestWorkers   = 2
+	defaultBacktestQueueSize = 16
+
+	// backtestProgressInterval is the minimum time between progress
+	// broadcasts of one job
+	backtestProgressInterval = time.Second
+
+	// backtestStallTimeout is how long a worker may go without progress
+	// before the supervisor restarts it
+	backtestStallTimeout = 10 * time.Minute
+
+	// maxFinishedBacktestJobs is the number of finished jobs kept in memory;
+	// older ones are only available from storage
+	maxFinishedBacktestJobs = 100
+)
+
+// ErrBacktestQueueFull is returned when the backtest queue cannot take
+// another job
+var ErrBacktestQueueFull = errors.New("backtest queue is full")
+
+// BacktestJob is a backtest queued or run by the worker pool
+type BacktestJob struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Progress    float64    `json:"progress"` // Percent complete
+	Error       string     `json:"error,omitempty"`
+	SubmittedBy string     `json:"submittedBy,omitempty"`
+	QueuedAt    time.Time  `json:"queuedAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+// backtestTask is a queued job with what it runs over
+type backtestTask struct {
+	job    *BacktestJob
+	config *backtest.Config
+	data   *backtest.HistoricalData
+	render func(id int64, result *backtest.Result) interface{}
+
+	broadcastAt time.Time // Last progress broadcast
+}
+
+// backtestQueue runs submitted backtests on a pool of workers
+type backtestQueue struct {
+	workers int
+	tasks   chan *backtestTask
+	jobs    map[int64]*BacktestJob
+	mu      sync.Mutex
+}
+
+// SetBacktestWorkers sets the number of backtests run concurrently and how
+// many more may wait. It must be called before Start.
+func (o *Orchestrator) SetBacktestWorkers(workers, queueSize int) {
+	if workers <= 0 {
+		workers = defaultBacktestWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = defaultBacktestQueueSize
+	}
+
+	o.backtests.mu.Lock()
+	defer o.backtests.mu.Unlock()
+	o.backtests.workers = workers
+	o.backtests.tasks = make(chan *backtestTask, queueSize)
+}
+
+// startBacktestWorkers fails runs a restart interrupted and starts the workers
+func (o *Orchestrator) startBacktestWorkers() {
+	if n, err := o.dataService.FailUnfinishedBacktestRuns("interrupted by restart"); err != nil {
+		log.Warn().Err(err).Msg("Failed to mark interrupted backtests")
+	} else if n > 0 {
+		log.Warn().Int64("runs", n).Msg("Marked backtests interrupted by restart as failed")
+	}
+
+	for i := 1; i <= o.backtests.workers; i++ {
+		o.supervisor.Go(fmt.Sprintf("backtestWorker%d", i), backtestStallTimeout, o.backtestWorkerLoop)
+	}
+}
+
+// SubmitBacktest stores run as queued and queues the backtest for the
+// workers. render converts the result to the form stored for clients.
+func (o *Orchestrator) SubmitBacktest(run storage.BacktestRun, config *backtest.Config, data *backtest.HistoricalData, submittedBy string, render func(id int64, result *backtest.Result) interface{}) (*BacktestJob, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+
+	// Refuse before storing a run that would never start
+	if len(o.backtests.tasks) == cap(o.backtests.tasks) {
+		return nil, ErrBacktestQueueFull
+	}
+
+	run.Status = BacktestQueued
+	id, err := o.dataService.CreateBacktestRun(run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store backtest run: %w", err)
+	}
+
+	job := &BacktestJob{
+		ID:          id,
+		Name:        run.Name,
+		Status:      BacktestQueued,
+		SubmittedBy: submittedBy,
+		QueuedAt:    time.Now(),
+	}
+	task := &backtestTask{job: job, config: config, data: data, render: render}
+
+	o.backtests.mu.Lock()
+	if o.backtests.jobs == nil {
+		o.backtests.jobs = make(map[int64]*BacktestJob)
+	}
+	o.backtests.jobs[id] = job
+	snapshot := *job
+	o.backtests.mu.Unlock()
+
+	select {
+	case o.backtests.tasks <- task:
+	default:
+		// Filled up since the check above
+		o.finishBacktest(task, nil, ErrBacktestQueueFull)
+		return nil, ErrBacktestQueueFull
+	}
+
+	log.Info().Int64("id", id).Str("name", run.Name).Str("by", submittedBy).Msg("Backtest queued")
+	o.broadcastBacktest(snapshot)
+	return &snapshot, nil
+}
+
+// GetBacktestJob returns a job still held in memory
+func (o *Orchestrator) GetBacktestJob(id int64) (BacktestJob, bool) {
+	o.backtests.mu.Lock()
+	defer o.backtests.mu.Unlock()
+
+	job, ok := o.backtests.jobs[id]
+	if !ok {
+		return BacktestJob{}, false
+	}
+	return *job, true
+}
+
+// backtestWorkerLoop runs queued backtests one at a time
+func (o *Orchestrator) backtestWorkerLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case task := <-o.backtests.tasks:
+			o.runBacktest(task, beat)
+			beat()
+		case <-ticker.C:
+			beat()
+		}
+	}
+}
+
+// runBacktest runs a queued job, beating on every progress report
+func (o *Orchestrator) runBacktest(task *backtestTask, beat func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			o.reportPanic("backtest", "", fmt.Sprint(r), string(debug.Stack()))
+			o.finishBacktest(task, nil, fmt.Errorf("backtest panicked: %v", r))
+		}
+	}()
+
+	now := time.Now()
+	o.backtests.mu.Lock()
+	task.job.Status = BacktestRunning
+	task.job.StartedAt = &now
+	snapshot := *task.job
+	o.backtests.mu.Unlock()
+
+	if err := o.dataService.StartBacktestRun(task.job.ID); err != nil {
+		log.Warn().Err(err).Int64("id", task.job.ID).Msg("Failed to mark backtest running")
+	}
+	o.broadcastBacktest(snapshot)
+
+	engine := backtest.NewEngine(task.config)
+	engine.SetProgress(func(fraction float64) {
+		beat()
+		o.backtestProgress(task, fraction)
+	})
+	result, err := engine.Run(task.data)
+	o.finishBacktest(task, result, err)
+}
+
+// backtestProgress records the progress of a running job, broadcasting it
+// at most once per backtestProgressInterval
+func (o *Orchestrator) backtestProgress(task *backtestTask, fraction float64) {
+	now := time.Now()
+	o.backtests.mu.Lock()
+	task.job.Progress = fraction * 100
+	if now.Sub(task.broadcastAt) < backtestProgressInterval {
+		o.backtests.mu.Unlock()
+		return
+	}
+	task.broadcastAt = now
+	snapshot := *task.job
+	o.backtests.mu.Unlock()
+
+	o.broadcastBacktest(snapshot)
+}
+
+// finishBacktest stores the outcome of a job and broadcasts it
+func (o *Orchestrator) finishBacktest(task *backtestTask, result *backtest.Result, runErr error) {
+	id := task.job.ID
+	now := time.Now()
+
+	var stored json.RawMessage
+	if runErr == nil {
+		data, err := json.Marshal(task.render(id, result))
+		if err != nil {
+			runErr = fmt.Errorf("failed to encode result: %w", err)
+		} else {
+			stored = data
+		}
+	}
+
+	run := storage.BacktestRun{ID: id, Status: BacktestFailed, CompletedAt: &now}
+	errMsg := ""
+	if runErr != nil {
+		errMsg = runErr.Error()
+		log.Warn().Err(runErr).Int64("id", id).Msg("Backtest failed")
+	} else {
+		run = backtestRunRecord(id, result, now)
+		log.Info().Int64("id", id).Dur("took", result.ExecutionTime).Msg("Backtest completed")
+	}
+
+	if err := o.dataService.SaveBacktestResult(id, stored, errMsg); err != nil {
+		log.Error().Err(err).Int64("id", id).Msg("Failed to store backtest result")
+	}
+	if err := o.dataService.UpdateBacktestRun(run); err != nil {
+		log.Error().Err(err).Int64("id", id).Msg("Failed to update backtest run")
+	}
+
+	o.backtests.mu.Lock()
+	task.job.Status = run.Status
+	task.job.Error = errMsg
+	task.job.FinishedAt = &now
+	if runErr == nil {
+		task.job.Progress = 100
+	}
+	snapshot := *task.job
+	o.pruneBacktestJobs()
+	o.backtests.mu.Unlock()
+
+	o.broadcastBacktest(snapshot)
+}
+
+// pruneBacktestJobs drops the oldest finished jobs beyond
+// maxFinishedBacktestJobs. The caller must hold backtests.mu.
+func (o *Orchestrator) pruneBacktestJobs() {
+	var finished []*BacktestJob
+	for _, job := range o.backtests.jobs {
+		if job.FinishedAt != nil {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= maxFinishedBacktestJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(*finished[j].FinishedAt)
+	})
+	for _, job := range finished[:len(finished)-maxFinishedBacktestJobs] {
+		delete(o.backtests.jobs, job.ID)
+	}
+}
+
+// broadcastBacktest sends a job's state to connected clients
+func (o *Orchestrator) broadcastBacktest(job BacktestJob) {
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeBacktest,
+		Timestamp: time.Now(),
+		Data:      job,
+	})
+}
+
+// backtestRunRecord maps a completed backtest to its backtest_runs row
+func backtestRunRecord(id int64, result *backtest.Result, completedAt time.Time) storage.BacktestRun {
+	m := result.Metrics
+	run := storage.BacktestRun{
+		ID:             id,
+		FinalBalance:   m.EndingCapital,
+		TotalTrades:    m.TotalTrades,
+		WinningTrades:  m.WinningTrades,
+		LosingTrades:   m.LosingTrades,
+		NetProfit:      m.NetProfit,
+		MaxDrawdownPct: m.MaxDrawdown * 100,
+		WinRate:        m.WinRate,
+		ProfitFactor:   m.ProfitFactor,
+		SharpeRatio:    m.SharpeRatio,
+		SortinoRatio:   m.SortinoRatio,
+		CalmarRatio:    m.CalmarRatio,
+		Status:         BacktestCompleted,
+		CompletedAt:    &completedAt,
+	}
+
+	for _, t := range result.Trades {
+		if t.NetProfit > 0 {
+			run.GrossProfit += t.NetProfit
+		} else {
+			run.GrossLoss -= t.NetProfit
+		}
+	}
+
+	// Largest peak-to-trough fall in account currency
+	peak := 0.0
+	for _, p := range result.EquityCurve {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak-p.Equity > run.MaxDrawdown {
+			run.MaxDrawdown = peak - p.Equity
+		}
+	}
+	return run
+}
@@ -0,0 +1,26 @@
+package orchestrator
+
+import "github.com/eth-trading/internal/binance"
+
+// StreamBandwidth reports traffic on the exchange WebSocket connections
+type StreamBandwidth struct {
+	Market   *binance.WSStats `json:"market,omitempty"`
+	UserData *binance.WSStats `json:"userData,omitempty"` // Live trading only
+}
+
+// GetStreamBandwidth returns bytes and message rates per exchange stream
+func (o *Orchestrator) GetStreamBandwidth() StreamBandwidth {
+	var bw StreamBandwidth
+	if o.wsClient != nil {
+		stats := o.wsClient.Stats()
+		bw.Market = &stats
+	}
+	if live, ok := o.activeExecutor().(interface {
+		UserDataStreamStats() (binance.WSStats, bool)
+	}); ok {
+		if stats, ok := live.UserDataStreamStats(); ok {
+			bw.UserData = &stats
+		}
+	}
+	return bw
+}
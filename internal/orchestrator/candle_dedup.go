@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// processedCandles records the close time of the last candle processed per
+// timeframe, so a closed candle triggers the trading logic once only, even
+// when the stream replays it or the bot restarts after processing it
+type processedCandles struct {
+	mu     sync.Mutex
+	closes map[string]time.Time // Loaded from storage on first use
+}
+
+// claimCandle reports whether the candle of a timeframe closing at
+// closeTime is yet to be processed, recording it as processed if so. The
+// claim is persisted before the candle is processed: a crash while
+// processing it skips the candle on restart rather than risking a second
+// trade on it.
+func (o *Orchestrator) claimCandle(timeframe string, closeTime time.Time) bool {
+	p := &o.processed
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closes == nil {
+		p.closes = make(map[string]time.Time)
+	}
+	last, ok := p.closes[timeframe]
+	if !ok {
+		last = o.loadProcessedCandle(timeframe)
+	}
+	if !closeTime.After(last) {
+		log.Info().
+			Str("timeframe", timeframe).
+			Time("closeTime", closeTime).
+			Time("lastProcessed", last).
+			Msg("Skipping candle already processed")
+		p.closes[timeframe] = last
+		return false
+	}
+	p.closes[timeframe] = closeTime
+
+	if o.dataService != nil {
+		value := strconv.FormatInt(closeTime.UnixMilli(), 10)
+		if err := o.dataService.SaveProcessedCandle(o.config.Symbol, timeframe, value); err != nil {
+			log.Warn().Err(err).Str("timeframe", timeframe).Msg("Failed to persist processed candle")
+		}
+	}
+	return true
+}
+
+// loadProcessedCandle returns the persisted close time of the last candle
+// of a timeframe processed, zero if none was
+func (o *Orchestrator) loadProcessedCandle(timeframe string) time.Time {
+	if o.dataService == nil {
+		return time.Time{}
+	}
+	value, err := o.dataService.LoadProcessedCandle(o.config.Symbol, timeframe)
+	if err != nil {
+		log.Warn().Err(err).Str("timeframe", timeframe).Msg("Failed to load processed candle")
+		return time.Time{}
+	}
+	if value == "" {
+		return time.Time{}
+	}
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Warn().Err(err).Str("timeframe", timeframe).Msg("Invalid persisted processed candle")
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
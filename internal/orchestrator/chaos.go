@@ -0,0 +1,219 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// FaultWSDrop drops the market data WebSocket so it has to reconnect
+const FaultWSDrop = "ws_drop"
+
+// maxChaosEvents is the number of drill events kept for the status endpoint
+const maxChaosEvents = 100
+
+var (
+	// ErrChaosDisabled is returned when outage drills are not configured
+	ErrChaosDisabled = errors.New("chaos mode is not enabled")
+	// ErrChaosNotPaper is returned when a drill is requested outside paper mode
+	ErrChaosNotPaper = errors.New("outage drills only run in paper mode")
+)
+
+// ChaosEvent is a simulated failure that was injected
+type ChaosEvent struct {
+	Fault      string    `json:"fault"`
+	Count      int       `json:"count"`
+	Trigger    string    `json:"trigger"` // "schedule" or the user who requested it
+	Error      string    `json:"error,omitempty"`
+	InjectedAt time.Time `json:"injectedAt"`
+}
+
+// ChaosStatus describes the outage drill configuration and history
+type ChaosStatus struct {
+	Enabled  bool           `json:"enabled"`
+	Interval string         `json:"interval,omitempty"` // Time between scheduled drills
+	Faults   []string       `json:"faults"`             // Faults drawn by scheduled drills
+	Pending  map[string]int `json:"pending"`            // Order faults waiting for an order
+	Events   []ChaosEvent   `json:"events"`             // Most recent first
+}
+
+// chaosControl runs outage drills against the paper executor
+type chaosControl struct {
+	injector *execution.ChaosInjector
+	interval time.Duration
+	faults   []string
+	events   []ChaosEvent
+	mu       sync.Mutex
+}
+
+// ValidChaosFault reports whether fault can be injected
+func ValidChaosFault(fault string) bool {
+	return fault == FaultWSDrop || execution.ValidOrderFault(execution.OrderFault(fault))
+}
+
+// SetChaosMode enables outage drills. Order faults are queued on injector,
+// which must be attached to the paper executor. A positive interval runs a
+// drill drawn from faults on that schedule; otherwise drills are on demand.
+func (o *Orchestrator) SetChaosMode(injector *execution.ChaosInjector, interval time.Duration, faults []string) error {
+	for _, f := range faults {
+		if !ValidChaosFault(f) {
+			return fmt.Errorf("unknown chaos fault %q", f)
+		}
+	}
+
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	o.chaos.injector = injector
+	o.chaos.interval = interval
+	o.chaos.faults = faults
+	return nil
+}
+
+// chaosEnabled reports whether outage drills are configured
+func (o *Orchestrator) chaosEnabled() bool {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	return o.chaos.injector != nil
+}
+
+// InjectFault runs an outage drill: fault is applied to the next count
+// paper orders, or drops the market data WebSocket
+func (o *Orchestrator) InjectFault(fault string, count int, trigger string) (*ChaosEvent, error) {
+	o.chaos.mu.Lock()
+	injector := o.chaos.injector
+	o.chaos.mu.Unlock()
+	if injector == nil {
+		return nil, ErrChaosDisabled
+	}
+	if !ValidChaosFault(fault) {
+		return nil, fmt.Errorf("unknown chaos fault %q", fault)
+	}
+	if _, ok := o.activeExecutor().(*execution.PaperExecutor); !ok {
+		return nil, ErrChaosNotPaper
+	}
+	if count <= 0 {
+		count = 1
+	}
+
+	event := ChaosEvent{
+		Fault:      fault,
+		Count:      count,
+		Trigger:    trigger,
+		InjectedAt: time.Now(),
+	}
+
+	var err error
+	if fault == FaultWSDrop {
+		event.Count = 1
+		if o.wsClient == nil {
+			err = fmt.Errorf("websocket client not set")
+		} else {
+			err = o.wsClient.DropConnection()
+		}
+	} else {
+		err = injector.Inject(execution.OrderFault(fault), count)
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	o.recordChaosEvent(event)
+	return &event, err
+}
+
+// recordChaosEvent keeps a drill in the history and records it as an alert
+func (o *Orchestrator) recordChaosEvent(event ChaosEvent) {
+	o.chaos.mu.Lock()
+	o.chaos.events = append([]ChaosEvent{event}, o.chaos.events...)
+	if len(o.chaos.events) > maxChaosEvents {
+		o.chaos.events = o.chaos.events[:maxChaosEvents]
+	}
+	o.chaos.mu.Unlock()
+
+	log.Warn().
+		Str("fault", event.Fault).
+		Int("count", event.Count).
+		Str("trigger", event.Trigger).
+		Str("error", event.Error).
+		Msg("Outage drill injected")
+
+	if o.dataService == nil {
+		return
+	}
+	data, _ := json.Marshal(event)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "chaos_drill",
+		Severity: "info",
+		Message:  fmt.Sprintf("Outage drill: %s x%d (%s)", event.Fault, event.Count, event.Trigger),
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record outage drill alert")
+	}
+}
+
+// GetChaosStatus returns the outage drill configuration and recent drills
+func (o *Orchestrator) GetChaosStatus() *ChaosStatus {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+
+	status := &ChaosStatus{
+		Enabled: o.chaos.injector != nil,
+		Faults:  append([]string{}, o.chaos.faults...),
+		Pending: map[string]int{},
+		Events:  append([]ChaosEvent{}, o.chaos.events...),
+	}
+	if o.chaos.interval > 0 {
+		status.Interval = o.chaos.interval.String()
+	}
+	if o.chaos.injector != nil {
+		for fault, n := range o.chaos.injector.Pending() {
+			status.Pending[string(fault)] = n
+		}
+	}
+	return status
+}
+
+// ClearChaos drops order faults that have not been applied yet
+func (o *Orchestrator) ClearChaos() error {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	if o.chaos.injector == nil {
+		return ErrChaosDisabled
+	}
+	o.chaos.injector.Clear()
+	return nil
+}
+
+// chaosLoop runs a drill drawn from the configured faults on each interval
+func (o *Orchestrator) chaosLoop(ctx context.Context, beat func()) error {
+	o.chaos.mu.Lock()
+	interval := o.chaos.interval
+	faults := o.chaos.faults
+	o.chaos.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if len(faults) > 0 {
+				fault := faults[rand.Intn(len(faults))]
+				if _, err := o.InjectFault(fault, 1, "schedule"); err != nil && !errors.Is(err, ErrChaosNotPaper) {
+					log.Warn().Err(err).Str("fault", fault).Msg("Scheduled outage drill failed")
+				}
+			}
+			beat()
+		}
+	}
+}
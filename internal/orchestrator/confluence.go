@@ -0,0 +1,214 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+const (
+	defaultConfluenceWeight        = 0.25
+	defaultConfluenceMinConfidence = 0.4
+)
+
+// Confluence modes decide how higher-timeframe trends act on a strategy's
+// primary-timeframe entries
+const (
+	ConfluenceOff    = "off"    // Higher timeframes are ignored
+	ConfluenceFilter = "filter" // Entries against a trending higher timeframe are rejected
+	ConfluenceWeight = "weight" // Confidence is scaled by agreement; entries below the minimum are rejected
+)
+
+// Stances of a higher timeframe towards an entry
+const (
+	StanceAgree   = "agree"
+	StanceOppose  = "oppose"
+	StanceNeutral = "neutral" // Ranging, too weak a trend, or not enough bars
+)
+
+// ConfluencePolicy sets how higher-timeframe trend and regime weigh on
+// entries, by default and per strategy
+type ConfluencePolicy struct {
+	Mode          string            // Mode of strategies without their own
+	Modes         map[string]string // Per-strategy modes, e.g. MeanReversion: off
+	Timeframes    []string          // Higher timeframes consulted; empty = all monitored ones
+	MinStrength   string            // Weakest trend (WEAK, MODERATE, STRONG, VERY_STRONG) that counts; weaker is ranging
+	Weight        float64           // Confidence change per unit of net agreement in weight mode
+	MinConfidence float64           // Weighted confidence an entry needs in weight mode
+}
+
+// TimeframeStance is one higher timeframe's trend against an entry
+type TimeframeStance struct {
+	Timeframe string `json:"timeframe"`
+	Trend     string `json:"trend"`    // UP, DOWN or NEUTRAL
+	Strength  string `json:"strength"` // WEAK to VERY_STRONG
+	Stance    string `json:"stance"`
+}
+
+// ConfluenceCheck is the outcome of the confluence layer for one signal
+type ConfluenceCheck struct {
+	Passed     bool              `json:"passed"`
+	Mode       string            `json:"mode"`
+	Timeframes []TimeframeStance `json:"timeframes"`
+	Agree      int               `json:"agree"`
+	Oppose     int               `json:"oppose"`
+	Confidence float64           `json:"confidence"` // After weighting; unchanged in filter mode
+	Reason     string            `json:"reason,omitempty"`
+}
+
+// confluenceLayer holds the confluence policy
+type confluenceLayer struct {
+	mu          sync.Mutex
+	policy      ConfluencePolicy
+	minStrength indicators.TrendStrength
+}
+
+// SetConfluencePolicy sets how higher timeframes act on entries
+func (o *Orchestrator) SetConfluencePolicy(policy ConfluencePolicy) error {
+	if policy.Mode == "" {
+		policy.Mode = ConfluenceOff
+	}
+	if err := validConfluenceMode(policy.Mode); err != nil {
+		return err
+	}
+	for name, mode := range policy.Modes {
+		if err := validConfluenceMode(mode); err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+	}
+	if policy.MinStrength == "" {
+		policy.MinStrength = indicators.TrendModerate.String()
+	}
+	minStrength, ok := parseTrendStrength(policy.MinStrength)
+	if !ok {
+		return fmt.Errorf("unknown trend strength %q", policy.MinStrength)
+	}
+	if policy.Weight <= 0 {
+		policy.Weight = defaultConfluenceWeight
+	}
+	if policy.MinConfidence <= 0 {
+		policy.MinConfidence = defaultConfluenceMinConfidence
+	}
+
+	o.confluence.mu.Lock()
+	defer o.confluence.mu.Unlock()
+	o.confluence.policy = policy
+	o.confluence.minStrength = minStrength
+	return nil
+}
+
+// validConfluenceMode checks a confluence mode name
+func validConfluenceMode(mode string) error {
+	switch mode {
+	case ConfluenceOff, ConfluenceFilter, ConfluenceWeight:
+		return nil
+	}
+	return fmt.Errorf("unknown confluence mode %q", mode)
+}
+
+// parseTrendStrength maps a trend strength name to its level
+func parseTrendStrength(name string) (indicators.TrendStrength, bool) {
+	for s := indicators.TrendWeak; s <= indicators.TrendVeryStrong; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// checkConfluence weighs an entry against the trend of the higher
+// timeframes. It returns nil when the signal's strategy has confluence off
+// or no higher timeframe is available; in weight mode it updates the
+// signal's confidence.
+func (o *Orchestrator) checkConfluence(signal *strategy.Signal, marketData *strategy.MarketData) *ConfluenceCheck {
+	o.confluence.mu.Lock()
+	policy := o.confluence.policy
+	minStrength := o.confluence.minStrength
+	o.confluence.mu.Unlock()
+
+	mode := policy.Mode
+	if m, ok := policy.Modes[signal.Strategy]; ok {
+		mode = m
+	}
+	if mode == "" || mode == ConfluenceOff || marketData == nil || len(marketData.HigherTimeframes) == 0 {
+		return nil
+	}
+
+	timeframes := policy.Timeframes
+	if len(timeframes) == 0 {
+		for tf := range marketData.HigherTimeframes {
+			timeframes = append(timeframes, tf)
+		}
+		sort.Slice(timeframes, func(i, j int) bool {
+			return binance.IntervalToDuration(timeframes[i]) < binance.IntervalToDuration(timeframes[j])
+		})
+	}
+
+	check := &ConfluenceCheck{Passed: true, Mode: mode, Confidence: signal.Confidence}
+	for _, tf := range timeframes {
+		data := marketData.HigherTimeframe(tf)
+		if data == nil {
+			continue
+		}
+		trend := data.Analysis.TrendDir
+		strength := data.Analysis.TrendStrength
+		stance := StanceNeutral
+		if trend != indicators.TrendNeutral && strength >= minStrength {
+			rising := trend == indicators.TrendUp
+			if rising == (signal.Direction == strategy.DirectionLong) {
+				stance = StanceAgree
+				check.Agree++
+			} else {
+				stance = StanceOppose
+				check.Oppose++
+			}
+		}
+		check.Timeframes = append(check.Timeframes, TimeframeStance{
+			Timeframe: tf,
+			Trend:     trend.String(),
+			Strength:  strength.String(),
+			Stance:    stance,
+		})
+	}
+	if len(check.Timeframes) == 0 {
+		return nil
+	}
+
+	switch mode {
+	case ConfluenceFilter:
+		if check.Oppose > 0 {
+			check.Passed = false
+			check.Reason = fmt.Sprintf("%s entry against the trend of %s", signal.Direction, opposing(check.Timeframes))
+		}
+	case ConfluenceWeight:
+		net := float64(check.Agree-check.Oppose) / float64(len(check.Timeframes))
+		check.Confidence = math.Max(0, math.Min(1, signal.Confidence*(1+policy.Weight*net)))
+		signal.Confidence = check.Confidence
+		if check.Confidence < policy.MinConfidence {
+			check.Passed = false
+			check.Reason = fmt.Sprintf("confidence %.2f after higher-timeframe weighting below minimum %.2f",
+				check.Confidence, policy.MinConfidence)
+		}
+	}
+	return check
+}
+
+// opposing lists the timeframes opposing an entry, e.g. "4h, 1d"
+func opposing(stances []TimeframeStance) string {
+	var names string
+	for _, s := range stances {
+		if s.Stance != StanceOppose {
+			continue
+		}
+		if names != "" {
+			names += ", "
+		}
+		names += s.Timeframe
+	}
+	return names
+}
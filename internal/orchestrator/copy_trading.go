@@ -0,0 +1,734 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Headers of a pushed signal. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" under the secret the leader shares with followers.
+const (
+	CopyLeaderHeader    = "X-Signal-Leader"
+	CopyTimestampHeader = "X-Signal-Timestamp"
+	CopySignatureHeader = "X-Signal-Signature"
+)
+
+const (
+	// copyStrategyPrefix names the strategy of copied signals, followed by
+	// the leader's ID
+	copyStrategyPrefix = "copy:"
+	// defaultCopyTimeout bounds a single push to a follower
+	defaultCopyTimeout = 10 * time.Second
+	// copyAttempts is how many times a push is tried before it counts as failed
+	copyAttempts = 3
+	// copyQueueSize bounds the signals waiting to be pushed
+	copyQueueSize = 64
+	// defaultCopyMaxSkew is how far a signal's timestamp may be from the
+	// follower's clock
+	defaultCopyMaxSkew = 5 * time.Minute
+	// maxCopyFollowers bounds the followers registered through the API
+	maxCopyFollowers = 50
+)
+
+var (
+	// ErrCopyPublishingDisabled is returned for follower registrations
+	// while publishing is off
+	ErrCopyPublishingDisabled = errors.New("signal publishing is disabled")
+	// ErrCopyFollowingDisabled is returned for pushed signals while
+	// following is off
+	ErrCopyFollowingDisabled = errors.New("signal following is disabled")
+	// ErrFollowerExists is returned when registering a follower name in use
+	ErrFollowerExists = errors.New("follower already registered")
+	// ErrFollowerNotFound is returned when removing an unknown follower
+	ErrFollowerNotFound = errors.New("follower not registered")
+	// ErrCoreFollower is returned when removing a configured follower
+	ErrCoreFollower = errors.New("follower is configured and cannot be removed")
+	// ErrTooManyFollowers is returned when the registered followers are at their limit
+	ErrTooManyFollowers = fmt.Errorf("at most %d followers can be registered", maxCopyFollowers)
+	// ErrUnknownLeader is returned for signals from a leader not trusted
+	ErrUnknownLeader = errors.New("unknown leader")
+	// ErrBadSignature is returned for signals whose signature does not match
+	ErrBadSignature = errors.New("invalid signal signature")
+	// ErrStaleSignal is returned for signals signed too long ago, or ahead
+	ErrStaleSignal = errors.New("signal timestamp outside the allowed skew")
+	// ErrDuplicateSignal is returned for signals already received
+	ErrDuplicateSignal = errors.New("signal already received")
+	// ErrInvalidSignal is returned for malformed signals or ones for a
+	// symbol this bot does not trade
+	ErrInvalidSignal = errors.New("invalid signal")
+)
+
+// CopySignal is an approved signal as pushed to follower bots. It carries
+// the trade idea only, never account data such as balances or sizes.
+type CopySignal struct {
+	ID         string    `json:"id"`
+	Leader     string    `json:"leader"`
+	Symbol     string    `json:"symbol"`
+	Direction  string    `json:"direction"` // "LONG" or "SHORT"
+	Price      float64   `json:"price"`
+	StopLoss   float64   `json:"stopLoss"`
+	TakeProfit float64   `json:"takeProfit"`
+	Confidence float64   `json:"confidence"`
+	Strategy   string    `json:"strategy"`
+	Timeframe  string    `json:"timeframe"`
+	Reason     string    `json:"reason,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// CopyFollower is an endpoint approved signals are pushed to
+type CopyFollower struct {
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`
+	Core         bool       `json:"core"` // From the configuration; cannot be removed
+	AddedBy      string     `json:"addedBy,omitempty"`
+	AddedAt      *time.Time `json:"addedAt,omitempty"`
+	Delivered    int64      `json:"delivered"`
+	Failed       int64      `json:"failed"`
+	LastDelivery *time.Time `json:"lastDelivery,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+}
+
+// CopyResult is what a follower did with a pushed signal
+type CopyResult struct {
+	ID       string `json:"id"`
+	Strategy string `json:"strategy"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// CopyTradingStatus describes both sides of copy trading
+type CopyTradingStatus struct {
+	Publishing bool           `json:"publishing"`
+	Leader     string         `json:"leader,omitempty"`
+	Followers  []CopyFollower `json:"followers"`
+	Published  int64          `json:"published"`
+	Dropped    int64          `json:"dropped"` // Not pushed because the queue was full
+
+	Following    bool       `json:"following"`
+	Leaders      []string   `json:"leaders,omitempty"`
+	Received     int64      `json:"received"`
+	Approved     int64      `json:"approved"`
+	Rejected     int64      `json:"rejected"` // Refused by this bot's checks or risk pipeline
+	LastReceived *time.Time `json:"lastReceived,omitempty"`
+}
+
+// addedFollower is a follower registered through the API, as persisted
+type addedFollower struct {
+	Name    string    `json:"name"`
+	URL     string    `json:"url"`
+	AddedBy string    `json:"addedBy,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// copyPublisher pushes approved signals to follower bots
+type copyPublisher struct {
+	leader string
+	secret []byte // Nil while publishing is off
+	client *http.Client
+	queue  chan CopySignal
+
+	mu        sync.Mutex
+	followers map[string]*CopyFollower // name -> follower
+	published int64
+	dropped   int64
+}
+
+// copyReceiver accepts signals pushed by trusted leaders
+type copyReceiver struct {
+	leaders  map[string][]byte // leader ID -> shared secret; nil while following is off
+	maxSkew  time.Duration
+	maxDrift float64 // Percent the leader's price may be from ours; 0 = unchecked
+
+	mu           sync.Mutex
+	seen         map[string]time.Time // leader/signal ID -> when received
+	received     int64
+	approved     int64
+	rejected     int64
+	lastReceived time.Time
+}
+
+// SetCopyPublisher pushes approved signals, signed with secret, to the
+// configured followers and those registered through the API
+func (o *Orchestrator) SetCopyPublisher(leader, secret string, followers []CopyFollower, timeout time.Duration) error {
+	if leader == "" || secret == "" {
+		return fmt.Errorf("publishing requires a leader ID and a secret")
+	}
+	if timeout <= 0 {
+		timeout = defaultCopyTimeout
+	}
+
+	configured := make(map[string]*CopyFollower, len(followers))
+	for _, f := range followers {
+		if err := validateFollower(f.Name, f.URL); err != nil {
+			return err
+		}
+		if _, ok := configured[f.Name]; ok {
+			return fmt.Errorf("follower %q configured twice", f.Name)
+		}
+		configured[f.Name] = &CopyFollower{Name: f.Name, URL: f.URL, Core: true}
+	}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.leader = leader
+	p.secret = []byte(secret)
+	p.client = &http.Client{Timeout: timeout}
+	p.queue = make(chan CopySignal, copyQueueSize)
+	p.followers = configured
+	return nil
+}
+
+// SetCopyReceiver accepts signals from the given leaders (ID -> shared
+// secret) and trades them through the risk pipeline. Signals whose
+// timestamp is more than maxSkew from now, or whose price is more than
+// maxDrift percent from ours, are refused. Every leader needs an ID and a
+// secret: an empty key would let anyone sign signals.
+func (o *Orchestrator) SetCopyReceiver(leaders map[string]string, maxSkew time.Duration, maxDrift float64) error {
+	for id, secret := range leaders {
+		if id == "" || secret == "" {
+			return fmt.Errorf("leader %q requires an ID and a secret", id)
+		}
+	}
+	if maxSkew <= 0 {
+		maxSkew = defaultCopyMaxSkew
+	}
+
+	r := &o.copyReceiver
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leaders = make(map[string][]byte, len(leaders))
+	for id, secret := range leaders {
+		r.leaders[id] = []byte(secret)
+	}
+	r.maxSkew = maxSkew
+	r.maxDrift = maxDrift
+	r.seen = make(map[string]time.Time)
+	return nil
+}
+
+// validateFollower checks a follower's name and push URL
+func validateFollower(name, rawURL string) error {
+	if name == "" {
+		return fmt.Errorf("follower name is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("follower %q needs an http(s) URL", name)
+	}
+	return nil
+}
+
+// GetCopyTradingStatus returns the followers signals are pushed to and the
+// signals received from leaders
+func (o *Orchestrator) GetCopyTradingStatus() CopyTradingStatus {
+	status := CopyTradingStatus{Followers: []CopyFollower{}}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	if p.secret != nil {
+		status.Publishing = true
+		status.Leader = p.leader
+		for _, f := range p.followers {
+			status.Followers = append(status.Followers, *f)
+		}
+		status.Published = p.published
+		status.Dropped = p.dropped
+	}
+	p.mu.Unlock()
+	sort.Slice(status.Followers, func(i, j int) bool {
+		if status.Followers[i].Core != status.Followers[j].Core {
+			return status.Followers[i].Core
+		}
+		return status.Followers[i].Name < status.Followers[j].Name
+	})
+
+	r := &o.copyReceiver
+	r.mu.Lock()
+	if r.leaders != nil {
+		status.Following = true
+		for id := range r.leaders {
+			status.Leaders = append(status.Leaders, id)
+		}
+		sort.Strings(status.Leaders)
+		status.Received = r.received
+		status.Approved = r.approved
+		status.Rejected = r.rejected
+		if !r.lastReceived.IsZero() {
+			lastReceived := r.lastReceived
+			status.LastReceived = &lastReceived
+		}
+	}
+	r.mu.Unlock()
+
+	return status
+}
+
+// AddFollower registers an endpoint to push signals to and persists it
+func (o *Orchestrator) AddFollower(name, rawURL, addedBy string) (*CopyFollower, error) {
+	if err := validateFollower(name, rawURL); err != nil {
+		return nil, err
+	}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	if p.secret == nil {
+		p.mu.Unlock()
+		return nil, ErrCopyPublishingDisabled
+	}
+	if _, ok := p.followers[name]; ok {
+		p.mu.Unlock()
+		return nil, ErrFollowerExists
+	}
+	added := 0
+	for _, f := range p.followers {
+		if !f.Core {
+			added++
+		}
+	}
+	if added >= maxCopyFollowers {
+		p.mu.Unlock()
+		return nil, ErrTooManyFollowers
+	}
+	now := time.Now()
+	follower := &CopyFollower{Name: name, URL: rawURL, AddedBy: addedBy, AddedAt: &now}
+	p.followers[name] = follower
+	registered := *follower
+	p.mu.Unlock()
+
+	log.Info().Str("follower", name).Str("by", addedBy).Msg("Copy-trading follower registered")
+	return &registered, o.persistFollowers()
+}
+
+// RemoveFollower stops pushing signals to a follower registered through the API
+func (o *Orchestrator) RemoveFollower(name, removedBy string) error {
+	p := &o.copyPublisher
+	p.mu.Lock()
+	if p.secret == nil {
+		p.mu.Unlock()
+		return ErrCopyPublishingDisabled
+	}
+	follower, ok := p.followers[name]
+	if !ok {
+		p.mu.Unlock()
+		return ErrFollowerNotFound
+	}
+	if follower.Core {
+		p.mu.Unlock()
+		return ErrCoreFollower
+	}
+	delete(p.followers, name)
+	p.mu.Unlock()
+
+	log.Info().Str("follower", name).Str("by", removedBy).Msg("Copy-trading follower removed")
+	return o.persistFollowers()
+}
+
+// restoreFollowers registers again the followers added through the API
+// before the last shutdown
+func (o *Orchestrator) restoreFollowers() {
+	if o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadCopyFollowers()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load copy-trading followers")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var added []addedFollower
+	if err := json.Unmarshal([]byte(value), &added); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted copy-trading followers")
+		return
+	}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	restored := 0
+	for _, a := range added {
+		// A follower since added to the configuration keeps its settings
+		if _, ok := p.followers[a.Name]; ok {
+			continue
+		}
+		addedAt := a.AddedAt
+		p.followers[a.Name] = &CopyFollower{Name: a.Name, URL: a.URL, AddedBy: a.AddedBy, AddedAt: &addedAt}
+		restored++
+	}
+	p.mu.Unlock()
+
+	if restored > 0 {
+		log.Info().Int("followers", restored).Msg("Restored copy-trading followers")
+	}
+}
+
+// persistFollowers saves the followers registered through the API
+func (o *Orchestrator) persistFollowers() error {
+	if o.dataService == nil {
+		return nil
+	}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	added := make([]addedFollower, 0, len(p.followers))
+	for _, f := range p.followers {
+		if f.Core {
+			continue
+		}
+		added = append(added, addedFollower{Name: f.Name, URL: f.URL, AddedBy: f.AddedBy, AddedAt: *f.AddedAt})
+	}
+	p.mu.Unlock()
+	sort.Slice(added, func(i, j int) bool { return added[i].AddedAt.Before(added[j].AddedAt) })
+
+	data, err := json.Marshal(added)
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveCopyFollowers(string(data))
+}
+
+// publishSignal queues an approved entry signal for the followers. Copied
+// signals are not relayed, so bots following each other cannot loop.
+func (o *Orchestrator) publishSignal(signal strategy.Signal) {
+	p := &o.copyPublisher
+	p.mu.Lock()
+	enabled := p.secret != nil
+	leader := p.leader
+	p.mu.Unlock()
+	if !enabled || strings.HasPrefix(signal.Strategy, copyStrategyPrefix) {
+		return
+	}
+
+	at := signal.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	copied := CopySignal{
+		ID:         uuid.New().String(),
+		Leader:     leader,
+		Symbol:     signal.Symbol,
+		Direction:  signal.Direction.String(),
+		Price:      signal.Price,
+		StopLoss:   signal.StopLoss,
+		TakeProfit: signal.TakeProfit,
+		Confidence: signal.Confidence,
+		Strategy:   signal.Strategy,
+		Timeframe:  signal.Timeframe,
+		Reason:     signal.Reason,
+		Time:       at,
+	}
+
+	select {
+	case p.queue <- copied:
+	default:
+		p.mu.Lock()
+		p.dropped++
+		p.mu.Unlock()
+		log.Warn().Str("signal", copied.ID).Msg("Copy-trading queue full, signal not pushed")
+	}
+}
+
+// copyPublisherLoop pushes queued signals to every follower
+func (o *Orchestrator) copyPublisherLoop(ctx context.Context, beat func()) error {
+	// Beats while idle, since signals can be hours apart
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			beat()
+		case signal := <-o.copyPublisher.queue:
+			o.pushSignal(ctx, signal)
+			beat()
+		}
+	}
+}
+
+// pushSignal delivers a signal to the followers concurrently
+func (o *Orchestrator) pushSignal(ctx context.Context, signal CopySignal) {
+	body, err := json.Marshal(signal)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode copy-trading signal")
+		return
+	}
+
+	p := &o.copyPublisher
+	p.mu.Lock()
+	p.published++
+	targets := make([]CopyFollower, 0, len(p.followers))
+	for _, f := range p.followers {
+		targets = append(targets, *f)
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, f := range targets {
+		wg.Add(1)
+		go func(f CopyFollower) {
+			defer wg.Done()
+			err := p.deliver(ctx, f.URL, body)
+			p.recordDelivery(f.Name, err)
+			if err != nil {
+				log.Warn().Err(err).Str("follower", f.Name).Str("signal", signal.ID).Msg("Failed to push signal to follower")
+			}
+		}(f)
+	}
+	wg.Wait()
+
+	log.Info().
+		Str("signal", signal.ID).
+		Str("direction", signal.Direction).
+		Str("strategy", signal.Strategy).
+		Int("followers", len(targets)).
+		Msg("Signal pushed to followers")
+}
+
+// deliver pushes a signal body to a follower, retrying failed attempts
+func (p *copyPublisher) deliver(ctx context.Context, target string, body []byte) error {
+	var err error
+	for attempt := 0; attempt < copyAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		if err = p.post(ctx, target, body); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// post sends one signed push request
+func (p *copyPublisher) post(ctx context.Context, target string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid follower URL: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CopyLeaderHeader, p.leader)
+	req.Header.Set(CopyTimestampHeader, timestamp)
+	req.Header.Set(CopySignatureHeader, signCopySignal(p.secret, timestamp, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// Follower URLs may embed credentials; keep them out of status and logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	resp.Body.Close()
+
+	// A follower's risk pipeline refusing the trade is still a delivery
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("follower returned %s", resp.Status)
+	}
+	return nil
+}
+
+// recordDelivery updates a follower's delivery counters
+func (p *copyPublisher) recordDelivery(name string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Removed while the push was in flight
+	f, ok := p.followers[name]
+	if !ok {
+		return
+	}
+	if err != nil {
+		f.Failed++
+		f.LastError = err.Error()
+		return
+	}
+	now := time.Now()
+	f.Delivered++
+	f.LastDelivery = &now
+	f.LastError = ""
+}
+
+// signCopySignal returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func signCopySignal(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ReceiveCopySignal verifies a signal pushed by a leader and trades it as an
+// external strategy through the same stop-loss, liquidity and risk checks
+// as this bot's own signals. Signals this bot refuses return a result that
+// is not approved; malformed, unsigned or replayed pushes return an error.
+func (o *Orchestrator) ReceiveCopySignal(leader, timestamp, signature string, body []byte) (*CopyResult, error) {
+	copied, err := o.verifyCopySignal(leader, timestamp, signature, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CopyResult{ID: copied.ID, Strategy: copyStrategyPrefix + copied.Leader}
+	result.Approved, result.Reason = o.tradeCopySignal(copied, result.Strategy)
+
+	r := &o.copyReceiver
+	r.mu.Lock()
+	if result.Approved {
+		r.approved++
+	} else {
+		r.rejected++
+	}
+	r.mu.Unlock()
+
+	log.Info().
+		Str("leader", copied.Leader).
+		Str("signal", copied.ID).
+		Str("direction", copied.Direction).
+		Bool("approved", result.Approved).
+		Str("reason", result.Reason).
+		Msg("Copied signal received")
+	return result, nil
+}
+
+// verifyCopySignal authenticates a pushed signal and decodes it
+func (o *Orchestrator) verifyCopySignal(leader, timestamp, signature string, body []byte) (*CopySignal, error) {
+	r := &o.copyReceiver
+	r.mu.Lock()
+	following := r.leaders != nil
+	secret, trusted := r.leaders[leader]
+	maxSkew := r.maxSkew
+	r.mu.Unlock()
+
+	if !following {
+		return nil, ErrCopyFollowingDisabled
+	}
+	if !trusted {
+		return nil, ErrUnknownLeader
+	}
+	expected := signCopySignal(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return nil, ErrBadSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrStaleSignal
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(unix, 0)); skew > maxSkew || skew < -maxSkew {
+		return nil, ErrStaleSignal
+	}
+
+	var copied CopySignal
+	if err := json.Unmarshal(body, &copied); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignal, err)
+	}
+	if copied.ID == "" || copied.Leader != leader {
+		return nil, fmt.Errorf("%w: missing ID or mismatched leader", ErrInvalidSignal)
+	}
+	if copied.Price <= 0 {
+		return nil, fmt.Errorf("%w: price is required", ErrInvalidSignal)
+	}
+	if copied.Direction != strategy.DirectionLong.String() && copied.Direction != strategy.DirectionShort.String() {
+		return nil, fmt.Errorf("%w: direction must be LONG or SHORT", ErrInvalidSignal)
+	}
+	if !strings.EqualFold(copied.Symbol, o.config.Symbol) {
+		return nil, fmt.Errorf("%w: %s is not traded here", ErrInvalidSignal, copied.Symbol)
+	}
+
+	// Retries re-sign the same signal, so its ID identifies it. IDs are
+	// forgotten once their timestamps would be refused anyway.
+	key := leader + "/" + copied.ID
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, at := range r.seen {
+		if now.Sub(at) > 2*maxSkew {
+			delete(r.seen, k)
+		}
+	}
+	if _, ok := r.seen[key]; ok {
+		return nil, ErrDuplicateSignal
+	}
+	r.seen[key] = now
+	r.received++
+	r.lastReceived = now
+	return &copied, nil
+}
+
+// tradeCopySignal runs a copied signal through the risk pipeline at this
+// bot's current price, returning whether it was approved and why not
+func (o *Orchestrator) tradeCopySignal(copied *CopySignal, strategyName string) (bool, string) {
+	if o.riskManager != nil && o.riskManager.IsHalted() {
+		return false, "trading is halted"
+	}
+	o.stateMu.RLock()
+	paused := o.state.IsPaused
+	price := o.state.CurrentPrice
+	o.stateMu.RUnlock()
+	if paused {
+		return false, "trading is paused"
+	}
+
+	marketData, release := o.buildMarketData()
+	defer release()
+	if marketData == nil {
+		return false, "market data not ready"
+	}
+	if price <= 0 {
+		price = copied.Price
+	}
+
+	o.copyReceiver.mu.Lock()
+	maxDrift := o.copyReceiver.maxDrift
+	o.copyReceiver.mu.Unlock()
+	if drift := math.Abs(price-copied.Price) / copied.Price * 100; maxDrift > 0 && drift > maxDrift {
+		return false, fmt.Sprintf("price moved %.2f%% from the leader's %.2f", drift, copied.Price)
+	}
+
+	direction := strategy.DirectionLong
+	if copied.Direction == strategy.DirectionShort.String() {
+		direction = strategy.DirectionShort
+	}
+	signal := strategy.Signal{
+		Type:       strategy.SignalTypeEntry,
+		Direction:  direction,
+		Price:      price,
+		StopLoss:   copied.StopLoss,
+		TakeProfit: copied.TakeProfit,
+		Confidence: copied.Confidence,
+		Reason:     fmt.Sprintf("Copied from %s (%s): %s", copied.Leader, copied.Strategy, copied.Reason),
+		Strategy:   strategyName,
+		Timestamp:  time.Now(),
+		Symbol:     o.config.Symbol,
+		Timeframe:  copied.Timeframe,
+	}
+	cols, releaseCols := o.lastColumns(o.config.PrimaryTimeframe, 0)
+	defer releaseCols()
+	return o.assessSignal(signal, marketData, cols.Volumes, nil, nil)
+}
@@ -0,0 +1,87 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/eth-trading/internal/risk"
+	"github.com/rs/zerolog/log"
+)
+
+// hwmPersistInterval throttles high-water mark writes while equity climbs
+const hwmPersistInterval = time.Minute
+
+// restoreHighWaterMark loads the persisted high-water mark into the risk manager
+func (o *Orchestrator) restoreHighWaterMark() {
+	if o.riskManager == nil || o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadHighWaterMark()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load high-water mark")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var hwm risk.HighWaterMark
+	if err := json.Unmarshal([]byte(value), &hwm); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted high-water mark")
+		return
+	}
+	o.riskManager.RestoreHighWaterMark(hwm)
+
+	o.hwmMu.Lock()
+	o.hwmSavedAt = hwm.UpdatedAt
+	o.hwmMu.Unlock()
+}
+
+// persistHighWaterMark saves the high-water mark when it has changed.
+// Unless force is set, writes are throttled to hwmPersistInterval.
+func (o *Orchestrator) persistHighWaterMark(force bool) {
+	if o.riskManager == nil || o.dataService == nil {
+		return
+	}
+
+	o.hwmMu.Lock()
+	defer o.hwmMu.Unlock()
+
+	hwm := o.riskManager.GetHighWaterMark()
+	if !hwm.UpdatedAt.After(o.hwmSavedAt) {
+		return
+	}
+	if !force && time.Since(o.hwmLastWrite) < hwmPersistInterval {
+		return
+	}
+
+	data, err := json.Marshal(hwm)
+	if err != nil {
+		return
+	}
+	if err := o.dataService.SaveHighWaterMark(string(data)); err != nil {
+		log.Warn().Err(err).Msg("Failed to persist high-water mark")
+		return
+	}
+	o.hwmSavedAt = hwm.UpdatedAt
+	o.hwmLastWrite = time.Now()
+}
+
+// ResetHighWaterMark sets the drawdown peak to current equity and persists it
+func (o *Orchestrator) ResetHighWaterMark() (risk.HighWaterMark, error) {
+	equity, err := o.equity()
+	if err != nil {
+		return risk.HighWaterMark{}, err
+	}
+	hwm := o.riskManager.ResetHighWaterMark(equity)
+	o.persistHighWaterMark(true)
+	return hwm, nil
+}
+
+// RecordCashFlow records a deposit or withdrawal against the high-water mark
+func (o *Orchestrator) RecordCashFlow(amount float64) risk.HighWaterMark {
+	hwm := o.riskManager.RecordCashFlow(amount)
+	o.persistHighWaterMark(true)
+	return hwm
+}
@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/risk"
+)
+
+func TestDrawdownLimitTrailsEquityGrowth(t *testing.T) {
+	paper := execution.NewPaperExecutor(nil)
+	paper.UpdatePrice("ETHUSDT", 2000)
+
+	o := NewOrchestrator(nil)
+	o.SetExecutor(paper)
+	o.SetRiskManager(risk.NewManager(nil))
+
+	o.updateRiskMetrics()
+	before := o.GetState().MaxDrawdown
+	if before <= 0 {
+		t.Fatalf("no drawdown limit reported")
+	}
+
+	// Double the price over a round trip
+	for i, side := range []execution.OrderSide{execution.OrderSideBuy, execution.OrderSideSell} {
+		result, err := paper.PlaceOrder(&execution.Order{
+			ClientID: fmt.Sprintf("growth-%d", i),
+			Symbol:   "ETHUSDT",
+			Side:     side,
+			Type:     execution.OrderTypeMarket,
+			Quantity: 10,
+		})
+		if err != nil || !result.Success {
+			t.Fatalf("%s order not filled: %v", side, err)
+		}
+		paper.UpdatePrice("ETHUSDT", 4000)
+	}
+
+	o.updateRiskMetrics()
+	state := o.GetState()
+	if state.MaxDrawdown <= before {
+		t.Errorf("drawdown limit %.2f did not grow with equity from %.2f", state.MaxDrawdown, before)
+	}
+	if want := risk.DefaultRiskConfig().MaxTotalDrawdown * state.PeakEquity; state.MaxDrawdown != want {
+		t.Errorf("drawdown limit = %.2f, want %.2f on the high-water mark", state.MaxDrawdown, want)
+	}
+}
@@ -0,0 +1,86 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// SetDustConversion enables periodic conversion of dust balances to BNB
+func (o *Orchestrator) SetDustConversion(interval time.Duration) {
+	o.dustInterval = interval
+}
+
+// liveExecutor returns the active executor when trading live
+func (o *Orchestrator) liveExecutor() (*execution.LiveExecutor, error) {
+	liveExec, ok := o.activeExecutor().(*execution.LiveExecutor)
+	if !ok {
+		return nil, fmt.Errorf("dust management requires live trading mode")
+	}
+	return liveExec, nil
+}
+
+// GetDustReport returns residual balances too small to trade
+func (o *Orchestrator) GetDustReport() (*execution.DustReport, error) {
+	liveExec, err := o.liveExecutor()
+	if err != nil {
+		return nil, err
+	}
+	return liveExec.GetDustBalances()
+}
+
+// ConvertDust converts dust balances to BNB and records an alert
+func (o *Orchestrator) ConvertDust() (*execution.DustConversion, error) {
+	liveExec, err := o.liveExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	conversion, err := liveExec.ConvertDust()
+	if err != nil {
+		return nil, err
+	}
+	if len(conversion.Assets) == 0 || o.dataService == nil {
+		return conversion, nil
+	}
+
+	data, _ := json.Marshal(conversion)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "dust_conversion",
+		Severity: "info",
+		Message: fmt.Sprintf("Converted dust (%s) to %.8f BNB",
+			strings.Join(conversion.Assets, ", "), conversion.ReceivedBNB),
+		Data: string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record dust conversion alert")
+	}
+
+	return conversion, nil
+}
+
+// dustLoop converts dust balances on the configured interval
+func (o *Orchestrator) dustLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(o.dustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// Mode switches may leave a paper executor active
+			if _, ok := o.activeExecutor().(*execution.LiveExecutor); ok {
+				if _, err := o.ConvertDust(); err != nil {
+					log.Warn().Err(err).Msg("Periodic dust conversion failed")
+				}
+			}
+			beat()
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// EntryConfirmationStats counts a strategy's entries held for
+// confirmation and what became of them
+type EntryConfirmationStats struct {
+	Strategy     string    `json:"strategy"`
+	Held         int64     `json:"held"`
+	Confirmed    int64     `json:"confirmed"`
+	Rejected     int64     `json:"rejected"`               // Conditions no longer held, or the wait was missed
+	LastRejected string    `json:"lastRejected,omitempty"` // Condition that failed last, e.g. "macd"
+	LastHeldAt   time.Time `json:"lastHeldAt,omitempty"`
+}
+
+// heldEntry is an entry signal waiting for the next confirmation candle
+type heldEntry struct {
+	signal      strategy.Signal
+	candleClose time.Time // Of the candle the signal fired on
+}
+
+// entryConfirmer holds entry signals for one candle of the confirmation
+// timeframe, per strategy
+type entryConfirmer struct {
+	mu     sync.Mutex
+	policy strategy.EntryConfirmation
+	held   map[string]heldEntry // By canonical strategy name
+	stats  map[string]*EntryConfirmationStats
+}
+
+// SetEntryConfirmation sets how entry signals are confirmed before they
+// execute. The interval must be a monitored timeframe no longer than the
+// primary one. Backtests built from live settings confirm alike.
+func (o *Orchestrator) SetEntryConfirmation(policy strategy.EntryConfirmation) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if policy.Enabled() {
+		monitored := policy.Interval == o.config.PrimaryTimeframe
+		for _, tf := range o.config.Timeframes {
+			monitored = monitored || tf == policy.Interval
+		}
+		if !monitored {
+			return fmt.Errorf("confirmation interval %s is not a monitored timeframe", policy.Interval)
+		}
+		if binance.IntervalToDuration(policy.Interval) > binance.IntervalToDuration(o.config.PrimaryTimeframe) {
+			return fmt.Errorf("confirmation interval %s is longer than the primary timeframe %s", policy.Interval, o.config.PrimaryTimeframe)
+		}
+	}
+
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+	policy.Conditions = append([]strategy.ConfirmationCondition(nil), policy.Conditions...)
+	policy.Strategies = append([]string(nil), policy.Strategies...)
+	o.confirm.policy = policy
+	if !policy.Enabled() {
+		o.confirm.held = nil
+	}
+	return nil
+}
+
+// GetEntryConfirmation returns a copy of the entry confirmation policy
+func (o *Orchestrator) GetEntryConfirmation() strategy.EntryConfirmation {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+
+	policy := o.confirm.policy
+	policy.Conditions = append([]strategy.ConfirmationCondition(nil), policy.Conditions...)
+	policy.Strategies = append([]string(nil), policy.Strategies...)
+	return policy
+}
+
+// GetEntryConfirmationStats returns entry confirmation counters by
+// canonical strategy name
+func (o *Orchestrator) GetEntryConfirmationStats() map[string]EntryConfirmationStats {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+
+	result := make(map[string]EntryConfirmationStats, len(o.confirm.stats))
+	for name, stats := range o.confirm.stats {
+		result[name] = *stats
+	}
+	return result
+}
+
+// statsLocked returns the counters of a strategy (mu must be held)
+func (c *entryConfirmer) statsLocked(strategyName string) *EntryConfirmationStats {
+	if c.stats == nil {
+		c.stats = make(map[string]*EntryConfirmationStats)
+	}
+	key := strategy.CanonicalName(strategyName)
+	stats, ok := c.stats[key]
+	if !ok {
+		stats = &EntryConfirmationStats{Strategy: strategyName}
+		c.stats[key] = stats
+	}
+	return stats
+}
+
+// holdForConfirmation holds an entry signaled on the candle closing at
+// candleClose until the next confirmation candle closes, reporting
+// whether it was held. A newer signal of the strategy replaces a held one.
+func (o *Orchestrator) holdForConfirmation(signal strategy.Signal, candleClose time.Time) bool {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+
+	if !o.confirm.policy.Applies(signal.Strategy) {
+		return false
+	}
+	if o.confirm.held == nil {
+		o.confirm.held = make(map[string]heldEntry)
+	}
+	stats := o.confirm.statsLocked(signal.Strategy)
+	stats.Held++
+	stats.LastHeldAt = candleClose
+	o.confirm.held[strategy.CanonicalName(signal.Strategy)] = heldEntry{signal: signal, candleClose: candleClose}
+
+	log.Info().
+		Str("strategy", signal.Strategy).
+		Str("interval", o.confirm.policy.Interval).
+		Msg("Entry held for confirmation")
+	return true
+}
+
+// confirmationInterval returns the timeframe entries wait on, "" when off
+func (o *Orchestrator) confirmationInterval() string {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+	return o.confirm.policy.Interval
+}
+
+// hasHeldEntries reports whether entries wait for confirmation
+func (o *Orchestrator) hasHeldEntries() bool {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+	return len(o.confirm.held) > 0
+}
+
+// confirmEntries settles the held entries against the confirmation candle
+// closing at candleClose at close, with the indicator snapshot analysis of
+// its timeframe. It returns those that go ahead, priced at close; the
+// others, and entries whose confirmation candle was missed, are dropped.
+func (o *Orchestrator) confirmEntries(close float64, analysis indicators.AnalysisResult, candleClose time.Time) []strategy.Signal {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+
+	if len(o.confirm.held) == 0 {
+		return nil
+	}
+	interval := binance.IntervalToDuration(o.confirm.policy.Interval)
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	var confirmed []strategy.Signal
+	for name, entry := range o.confirm.held {
+		if !candleClose.After(entry.candleClose) {
+			continue
+		}
+		delete(o.confirm.held, name)
+
+		stats := o.confirm.statsLocked(entry.signal.Strategy)
+		failed := "missed"
+		if candleClose.Sub(entry.candleClose) <= interval {
+			failed = o.confirm.policy.Confirm(entry.signal, close, analysis)
+		}
+		if failed != "" {
+			stats.Rejected++
+			stats.LastRejected = failed
+			log.Info().
+				Str("strategy", entry.signal.Strategy).
+				Str("failed", failed).
+				Float64("signalPrice", entry.signal.Price).
+				Float64("close", close).
+				Msg("Held entry not confirmed")
+			continue
+		}
+
+		stats.Confirmed++
+		signal := entry.signal
+		signal.Price = close
+		signal.Reason += " (confirmed after " + o.confirm.policy.Interval + ")"
+		confirmed = append(confirmed, signal)
+	}
+	return confirmed
+}
+
+// dropHeldEntries drops the entries waiting for confirmation, e.g. when
+// trading halts or pauses during the wait
+func (o *Orchestrator) dropHeldEntries(reason string) {
+	o.confirm.mu.Lock()
+	defer o.confirm.mu.Unlock()
+
+	for name, entry := range o.confirm.held {
+		stats := o.confirm.statsLocked(entry.signal.Strategy)
+		stats.Rejected++
+		stats.LastRejected = reason
+		delete(o.confirm.held, name)
+	}
+}
+
+// processConfirmation settles held entries when a candle of a
+// confirmation timeframe shorter than the primary one closes at
+// candleClose. Entries on the primary timeframe are settled by
+// processTradingLogic.
+func (o *Orchestrator) processConfirmation(candleClose time.Time) {
+	defer o.recoverPanic("entryConfirmation")
+
+	if !o.hasHeldEntries() {
+		return
+	}
+	if o.riskManager != nil && o.riskManager.IsHalted() {
+		o.dropHeldEntries("halted")
+		return
+	}
+	o.stateMu.RLock()
+	paused := o.state.IsPaused
+	o.stateMu.RUnlock()
+	if paused {
+		o.dropHeldEntries("paused")
+		return
+	}
+
+	cols, release := o.lastColumns(o.confirmationInterval(), strategy.ConfirmationBars)
+	defer release()
+	if cols.Len() == 0 || o.indicatorMgr == nil {
+		return
+	}
+	sessionBars := indicators.SessionBars(cols.Latest.OpenTime, binance.IntervalToDuration(o.confirmationInterval()))
+	analysis, ok := o.analyzeIndicators(cols.Opens, cols.Highs, cols.Lows, cols.Closes, cols.Volumes, sessionBars)
+	if !ok {
+		return
+	}
+
+	confirmed := o.confirmEntries(cols.Latest.Close, analysis, candleClose)
+	if len(confirmed) == 0 {
+		return
+	}
+
+	// Assessed against the primary timeframe like any other entry
+	marketData, releaseData := o.buildMarketData()
+	defer releaseData()
+	if marketData == nil {
+		return
+	}
+	primary, releasePrimary := o.lastColumns(o.config.PrimaryTimeframe, 0)
+	defer releasePrimary()
+	for _, signal := range confirmed {
+		o.assessSignal(signal, marketData, primary.Volumes, nil, nil)
+	}
+}
@@ -0,0 +1,24 @@
+package orchestrator
+
+import "github.com/eth-trading/internal/strategy"
+
+// SetEvalPool sets the worker pool bounding strategy evaluations. The
+// strategy manager evaluates on it at primary priority; backtests started
+// through the API share it at background priority.
+func (o *Orchestrator) SetEvalPool(pool *strategy.EvalPool) {
+	o.evalPool = pool
+}
+
+// EvalPool returns the strategy evaluation pool, nil when evaluations are
+// unbounded
+func (o *Orchestrator) EvalPool() *strategy.EvalPool {
+	return o.evalPool
+}
+
+// GetEvalPoolStats returns the evaluation workers and queueing delays
+func (o *Orchestrator) GetEvalPoolStats() (strategy.EvalPoolStats, bool) {
+	if o.evalPool == nil {
+		return strategy.EvalPoolStats{}, false
+	}
+	return o.evalPool.Stats(), true
+}
@@ -0,0 +1,57 @@
+package orchestrator
+
+import (
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+)
+
+// candleCloseGrace is how long after a bar's close its candle may take to arrive
+const candleCloseGrace = 15 * time.Second
+
+// TimeframeHealth describes how fresh the candle feed is for one timeframe
+type TimeframeHealth struct {
+	Timeframe         string    `json:"timeframe"`
+	LastCandleTime    time.Time `json:"lastCandleTime"`    // Close time of the last closed candle
+	ExpectedNextClose time.Time `json:"expectedNextClose"` // When the next candle should close
+	BehindBy          int       `json:"behindBy"`          // Closed bars that have not arrived yet
+	Stale             bool      `json:"stale"`
+}
+
+// recordCandleCloseLocked records a closed candle for a timeframe (stateMu must be held)
+func (o *Orchestrator) recordCandleCloseLocked(timeframe string, closeTime time.Time) {
+	if o.candleCloses == nil {
+		o.candleCloses = make(map[string]time.Time)
+	}
+	if closeTime.After(o.candleCloses[timeframe]) {
+		o.candleCloses[timeframe] = closeTime
+	}
+}
+
+// timeframeHealthLocked computes feed freshness for every monitored
+// timeframe (stateMu must be held)
+func (o *Orchestrator) timeframeHealthLocked(now time.Time) []TimeframeHealth {
+	health := make([]TimeframeHealth, 0, len(o.config.Timeframes))
+	for _, tf := range o.config.Timeframes {
+		h := TimeframeHealth{Timeframe: tf}
+		duration := binance.IntervalToDuration(tf)
+
+		last, ok := o.candleCloses[tf]
+		switch {
+		case duration <= 0:
+		case !ok:
+			// Nothing received yet
+			h.Stale = true
+		default:
+			h.LastCandleTime = last
+			h.ExpectedNextClose = last.Add(duration)
+			if overdue := now.Sub(last) - candleCloseGrace; overdue >= duration {
+				h.BehindBy = int(overdue / duration)
+				h.Stale = true
+			}
+		}
+
+		health = append(health, h)
+	}
+	return health
+}
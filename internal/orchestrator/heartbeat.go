@@ -0,0 +1,196 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// heartbeatTimeout bounds a single ping to the monitoring service
+const heartbeatTimeout = 10 * time.Second
+
+// HeartbeatStatus describes the outbound heartbeat to external monitoring
+type HeartbeatStatus struct {
+	Enabled   bool      `json:"enabled"`
+	Interval  string    `json:"interval,omitempty"`
+	LastPing  time.Time `json:"lastPing"`
+	LastError string    `json:"lastError,omitempty"`
+	Withheld  string    `json:"withheld,omitempty"` // Why the last beat was not sent
+}
+
+// heartbeatPinger pings a monitoring URL (healthchecks.io style) while the
+// pipeline is healthy, so a missed ping tells the operator the bot died
+type heartbeatPinger struct {
+	url        string
+	interval   time.Duration
+	maxSyncAge time.Duration // Longest the executor may go without answering
+	client     *http.Client
+
+	mu        sync.Mutex
+	syncedAt  time.Time // Last successful equity fetch from the executor
+	lastPing  time.Time
+	lastError string
+	withheld  string
+}
+
+// SetHeartbeat enables pinging pingURL every interval while healthy. The
+// executor counts as out of sync when it has not answered for maxSyncAge.
+func (o *Orchestrator) SetHeartbeat(pingURL string, interval, maxSyncAge time.Duration) {
+	o.heartbeat.url = pingURL
+	o.heartbeat.interval = interval
+	o.heartbeat.maxSyncAge = maxSyncAge
+	o.heartbeat.client = &http.Client{Timeout: heartbeatTimeout}
+}
+
+// GetHeartbeatStatus returns the state of the outbound heartbeat
+func (o *Orchestrator) GetHeartbeatStatus() HeartbeatStatus {
+	hb := &o.heartbeat
+	if hb.url == "" {
+		return HeartbeatStatus{}
+	}
+
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+	return HeartbeatStatus{
+		Enabled:   true,
+		Interval:  hb.interval.String(),
+		LastPing:  hb.lastPing,
+		LastError: hb.lastError,
+		Withheld:  hb.withheld,
+	}
+}
+
+// markExecutorSynced records that the executor answered an account query
+func (o *Orchestrator) markExecutorSynced(at time.Time) {
+	o.heartbeat.mu.Lock()
+	o.heartbeat.syncedAt = at
+	o.heartbeat.mu.Unlock()
+}
+
+// pipelineProblem returns why the pipeline is unhealthy, or "" when loops
+// are running, candles are fresh and the executor is in sync
+func (o *Orchestrator) pipelineProblem(now time.Time) string {
+	o.stateMu.RLock()
+	running := o.state.IsRunning
+	var stale []string
+	for _, tf := range o.timeframeHealthLocked(now) {
+		if tf.Stale {
+			stale = append(stale, tf.Timeframe)
+		}
+	}
+	o.stateMu.RUnlock()
+
+	if !running {
+		return "orchestrator not running"
+	}
+
+	var unhealthy []string
+	for _, loop := range o.supervisor.Health() {
+		if !loop.Healthy {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s %s", loop.Name, loop.Status))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return "loops unhealthy: " + strings.Join(unhealthy, ", ")
+	}
+
+	if len(stale) > 0 {
+		return "stale candles: " + strings.Join(stale, ", ")
+	}
+
+	o.heartbeat.mu.Lock()
+	syncedAt := o.heartbeat.syncedAt
+	o.heartbeat.mu.Unlock()
+	if age := now.Sub(syncedAt); age > o.heartbeat.maxSyncAge {
+		if syncedAt.IsZero() {
+			return "executor never synced"
+		}
+		return fmt.Sprintf("executor not synced for %s", age.Round(time.Second))
+	}
+
+	return ""
+}
+
+// sendHeartbeat pings the monitoring URL if the pipeline is healthy
+func (o *Orchestrator) sendHeartbeat(ctx context.Context) {
+	hb := &o.heartbeat
+	now := time.Now()
+
+	if problem := o.pipelineProblem(now); problem != "" {
+		hb.mu.Lock()
+		changed := hb.withheld != problem
+		hb.withheld = problem
+		hb.mu.Unlock()
+
+		if changed {
+			log.Warn().Str("reason", problem).Msg("Withholding heartbeat, pipeline unhealthy")
+		}
+		return
+	}
+
+	err := hb.ping(ctx)
+
+	hb.mu.Lock()
+	recovered := hb.withheld != ""
+	hb.withheld = ""
+	if err != nil {
+		hb.lastError = err.Error()
+	} else {
+		hb.lastPing = now
+		hb.lastError = ""
+	}
+	hb.mu.Unlock()
+
+	if err != nil {
+		log.Warn().Err(err).Msg("Heartbeat ping failed")
+	} else if recovered {
+		log.Info().Msg("Pipeline healthy, heartbeat resumed")
+	}
+}
+
+// ping requests the monitoring URL once
+func (hb *heartbeatPinger) ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hb.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid heartbeat URL: %w", err)
+	}
+
+	resp, err := hb.client.Do(req)
+	if err != nil {
+		// The URL embeds the check's secret; keep it out of status and logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("heartbeat URL returned %s", resp.Status)
+	}
+	return nil
+}
+
+// heartbeatLoop pings external monitoring on the configured interval
+func (o *Orchestrator) heartbeatLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(o.heartbeat.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			o.sendHeartbeat(ctx)
+			beat()
+		}
+	}
+}
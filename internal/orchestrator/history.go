@@ -0,0 +1,259 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxHistoryFetchBars caps the bars fetched from Binance for one request
+	maxHistoryFetchBars = 5000
+	// maxBacktestFetchBars caps the bars fetched from Binance for one backtest
+	maxBacktestFetchBars = 250000
+	// historyFetchCooldown spaces out on-demand fetches
+	historyFetchCooldown = time.Second
+	// historyRateLimitBackoff pauses fetching after a rate limit rejection
+	historyRateLimitBackoff = time.Minute
+)
+
+// CandleRange is the result of a historical candle range query
+type CandleRange struct {
+	Candles []storage.Candle
+	Fetched int  // Candles backfilled from Binance for this request
+	Partial bool // Missing candles could not be fetched (rate limited or failed)
+}
+
+// candleHistory serializes on-demand history fetches
+type candleHistory struct {
+	lastFetch    time.Time
+	backoffUntil time.Time
+	listedFrom   map[string]time.Time // Earliest candle Binance has, per symbol/timeframe
+	mu           sync.Mutex
+}
+
+// GetCandleRange returns candles opening within [from, to], oldest first and
+// capped to the limit most recent. Ranges not in local storage are fetched
+// from Binance and persisted.
+func (o *Orchestrator) GetCandleRange(symbol, timeframe string, from, to time.Time, limit int) (*CandleRange, error) {
+	return o.candleRange(symbol, timeframe, from, to, limit, maxHistoryFetchBars)
+}
+
+// GetBacktestCandles returns every candle opening within [from, to], oldest
+// first. Unlike GetCandleRange the whole missing range is fetched from
+// Binance and persisted, up to maxBacktestFetchBars.
+func (o *Orchestrator) GetBacktestCandles(symbol, timeframe string, from, to time.Time) (*CandleRange, error) {
+	interval := binance.IntervalToDuration(timeframe)
+	if interval > 0 && to.Sub(from)/interval > maxBacktestFetchBars {
+		return nil, fmt.Errorf("range spans more than %d %s candles", maxBacktestFetchBars, timeframe)
+	}
+	return o.candleRange(symbol, timeframe, from, to, 0, maxBacktestFetchBars)
+}
+
+// candleRange returns candles opening within [from, to], fetching at most
+// maxFetch missing bars nearest the end of the range
+func (o *Orchestrator) candleRange(symbol, timeframe string, from, to time.Time, limit, maxFetch int) (*CandleRange, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+	interval := binance.IntervalToDuration(timeframe)
+	if interval <= 0 {
+		return nil, fmt.Errorf("unsupported timeframe %q", timeframe)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("range start must be before end")
+	}
+
+	candles, err := o.localCandleRange(symbol, timeframe, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CandleRange{}
+	if start, end, missing := o.missingCandleSpan(candles, symbol, timeframe, from, to, interval); missing {
+		// Only a missing head can mean the market did not trade yet; interior
+		// gaps may be exchange outages
+		head := len(candles) == 0 || start.Before(candles[0].OpenTime)
+		fetched, err := o.fetchCandleHistory(symbol, timeframe, start, end, interval, head, maxFetch)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Str("timeframe", timeframe).
+				Time("from", start).
+				Time("to", end).
+				Msg("On-demand candle fetch failed, serving local history")
+			result.Partial = true
+		} else if len(fetched) > 0 {
+			result.Fetched = len(fetched)
+			candles = mergeCandles(candles, fetched, from, to)
+		}
+	}
+
+	if limit > 0 && len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+	result.Candles = candles
+	return result, nil
+}
+
+// localCandleRange merges persisted candles with in-memory candles that have
+// not been flushed yet
+func (o *Orchestrator) localCandleRange(symbol, timeframe string, from, to time.Time) ([]storage.Candle, error) {
+	stored, err := o.dataService.GetHistoricalCandles(symbol, timeframe, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load candles: %w", err)
+	}
+	return mergeCandles(stored, o.dataService.GetCandles(symbol, timeframe), from, to), nil
+}
+
+// missingCandleSpan finds the outermost span of closed bars absent from
+// candles (sorted oldest first)
+func (o *Orchestrator) missingCandleSpan(candles []storage.Candle, symbol, timeframe string, from, to time.Time, interval time.Duration) (start, end time.Time, missing bool) {
+	// Only closed bars can be missing
+	until := to
+	if lastClosed := time.Now().Add(-interval); until.After(lastClosed) {
+		until = lastClosed
+	}
+
+	o.history.mu.Lock()
+	listed := o.history.listedFrom[symbol+"|"+timeframe]
+	o.history.mu.Unlock()
+	if from.Before(listed) {
+		from = listed
+	}
+	if !from.Before(until) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if len(candles) == 0 {
+		return from, until, true
+	}
+
+	mark := func(s, e time.Time) {
+		if !missing || s.Before(start) {
+			start = s
+		}
+		if !missing || e.After(end) {
+			end = e
+		}
+		missing = true
+	}
+
+	if first := candles[0].OpenTime; first.Sub(from) >= interval {
+		mark(from, first)
+	}
+	// Calendar intervals (1M) vary in length, so only gaps of two or more
+	// bars are treated as missing data
+	for i := 1; i < len(candles); i++ {
+		if candles[i].OpenTime.Sub(candles[i-1].OpenTime) >= 2*interval {
+			mark(candles[i-1].OpenTime.Add(interval), candles[i].OpenTime)
+		}
+	}
+	if last := candles[len(candles)-1].OpenTime; until.Sub(last) >= 2*interval {
+		mark(last.Add(interval), until)
+	}
+
+	return start, end, missing
+}
+
+// fetchCandleHistory fetches [start, end] from Binance, at most maxBars
+// nearest the end, and persists it, respecting the fetch cooldown and any
+// rate limit backoff. head marks a span at the start of the requested range.
+func (o *Orchestrator) fetchCandleHistory(symbol, timeframe string, start, end time.Time, interval time.Duration, head bool, maxBars int) ([]storage.Candle, error) {
+	if o.binanceClient == nil {
+		return nil, fmt.Errorf("binance client not set")
+	}
+
+	o.history.mu.Lock()
+	defer o.history.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(o.history.backoffUntil) {
+		return nil, fmt.Errorf("rate limited until %s", o.history.backoffUntil.Format(time.RFC3339))
+	}
+	if wait := historyFetchCooldown - now.Sub(o.history.lastFetch); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	// Keep the bars nearest the end of the range; charts scroll back from there
+	if earliest := end.Add(-time.Duration(maxBars) * interval); start.Before(earliest) {
+		start = earliest
+	}
+
+	klines, err := o.binanceClient.GetHistoricalKlines(symbol, timeframe, start, end)
+	o.history.lastFetch = time.Now()
+	if err != nil {
+		if binance.IsRateLimited(err) {
+			o.history.backoffUntil = time.Now().Add(historyRateLimitBackoff)
+		}
+		return nil, err
+	}
+
+	candles := make([]storage.Candle, 0, len(klines))
+	for _, k := range klines {
+		candle := convertKlineToCandle(k, symbol, timeframe)
+		if candle.CloseTime.After(now) {
+			continue // Still forming; the live feed owns it
+		}
+		candles = append(candles, *candle)
+	}
+
+	// Remember where the market's history begins so ranges before the
+	// listing are not fetched again
+	if head && (len(candles) == 0 || candles[0].OpenTime.Sub(start) >= interval) {
+		listed := end
+		if len(candles) > 0 {
+			listed = candles[0].OpenTime
+		}
+		if o.history.listedFrom == nil {
+			o.history.listedFrom = make(map[string]time.Time)
+		}
+		key := symbol + "|" + timeframe
+		if listed.After(o.history.listedFrom[key]) {
+			o.history.listedFrom[key] = listed
+		}
+	}
+
+	if err := o.dataService.SaveHistoricalCandles(candles); err != nil {
+		log.Warn().Err(err).Int("count", len(candles)).Msg("Failed to persist fetched candles")
+	}
+
+	log.Info().
+		Str("symbol", symbol).
+		Str("timeframe", timeframe).
+		Time("from", start).
+		Time("to", end).
+		Int("count", len(candles)).
+		Msg("Backfilled candle history on demand")
+
+	return candles, nil
+}
+
+// mergeCandles combines candle sets within [from, to], later sets winning on
+// equal open times, sorted oldest first
+func mergeCandles(base, extra []storage.Candle, from, to time.Time) []storage.Candle {
+	byOpen := make(map[int64]storage.Candle, len(base)+len(extra))
+	for _, set := range [][]storage.Candle{base, extra} {
+		for _, c := range set {
+			if c.OpenTime.Before(from) || c.OpenTime.After(to) {
+				continue
+			}
+			byOpen[c.OpenTime.UnixMilli()] = c
+		}
+	}
+
+	merged := make([]storage.Candle, 0, len(byOpen))
+	for _, c := range byOpen {
+		merged = append(merged, c)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].OpenTime.Before(merged[j].OpenTime)
+	})
+	return merged
+}
@@ -0,0 +1,439 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// ImportedStrategy tags trades and positions imported from the exchange's
+// order history rather than placed by the bot
+const ImportedStrategy = "imported"
+
+// importPageSize is the number of trades or orders fetched per request
+const importPageSize = 1000
+
+// importDustFraction is the share of a position's bought quantity that may
+// remain, e.g. after commission paid in the base asset, for it to count as
+// closed
+const importDustFraction = 0.001
+
+// HistoryImportReport describes an exchange order history import
+type HistoryImportReport struct {
+	Status     string    `json:"status"` // running, completed or failed
+	Symbols    []string  `json:"symbols"`
+	Until      time.Time `json:"until"` // Fills from this time on are left to the bot's own records
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+
+	Fills     int `json:"fills"`     // Exchange fills read
+	Orders    int `json:"orders"`    // Orders the fills belong to
+	Imported  int `json:"imported"`  // Orders stored as trades
+	Skipped   int `json:"skipped"`   // Orders already stored
+	Positions int `json:"positions"` // Closed positions rebuilt from the fills
+
+	OpenQuantity       map[string]float64 `json:"openQuantity,omitempty"`       // Holdings still open at the end, by symbol
+	UnmatchedSells     map[string]float64 `json:"unmatchedSells,omitempty"`     // Quantity sold without a recorded buy, by symbol
+	UnpricedCommission map[string]float64 `json:"unpricedCommission,omitempty"` // Commission in assets other than the pair's, by asset
+
+	Error string `json:"error,omitempty"`
+}
+
+// historyImport tracks the latest import
+type historyImport struct {
+	mu     sync.Mutex
+	report *HistoryImportReport
+}
+
+// importedOrder aggregates the fills of one exchange order
+type importedOrder struct {
+	id         int64
+	side       binance.OrderSide
+	orderType  string
+	quantity   float64
+	quote      float64
+	commission float64 // In commissionAsset
+	asset      string
+	feeQuote   float64 // Commission valued in the quote asset
+	executedAt time.Time
+}
+
+// importCycle is a position rebuilt from fills, from the first buy until
+// the holding is sold off
+type importCycle struct {
+	openedAt        time.Time
+	holding         float64
+	avgEntry        float64
+	bought          float64
+	boughtQuote     float64
+	sold            float64
+	soldQuote       float64
+	grossPnL        float64
+	entryCommission float64
+	exitCommission  float64
+	hasNew          bool // Includes an order imported by this run
+}
+
+// StartHistoryImport imports the account's fills for symbols executed
+// before until from the exchange, storing them as trades and rebuilding
+// closed positions, all tagged ImportedStrategy. A zero until imports up to
+// the bot's first recorded trade. Orders already stored are skipped, so an
+// import can be re-run. It runs in the background; see GetHistoryImport.
+func (o *Orchestrator) StartHistoryImport(symbols []string, until time.Time) (*HistoryImportReport, error) {
+	if o.binanceClient == nil || o.dataService == nil {
+		return nil, fmt.Errorf("exchange history import requires a Binance client and database")
+	}
+	if len(symbols) == 0 {
+		symbols = []string{o.config.Symbol}
+	}
+	for i, s := range symbols {
+		symbols[i] = strings.ToUpper(s)
+	}
+
+	if until.IsZero() {
+		first, err := o.dataService.GetFirstTradeTime(ImportedStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trade history: %w", err)
+		}
+		until = first
+		if until.IsZero() {
+			until = time.Now()
+		}
+	}
+
+	o.historyImport.mu.Lock()
+	defer o.historyImport.mu.Unlock()
+	if r := o.historyImport.report; r != nil && r.Status == "running" {
+		return nil, fmt.Errorf("an import is already running")
+	}
+
+	report := &HistoryImportReport{
+		Status:             "running",
+		Symbols:            symbols,
+		Until:              until,
+		StartedAt:          time.Now(),
+		OpenQuantity:       make(map[string]float64),
+		UnmatchedSells:     make(map[string]float64),
+		UnpricedCommission: make(map[string]float64),
+	}
+	o.historyImport.report = report
+	snapshot := *report
+	snapshot.OpenQuantity = nil
+	snapshot.UnmatchedSells = nil
+	snapshot.UnpricedCommission = nil
+
+	go o.runHistoryImport(o.ctx, report)
+	return &snapshot, nil
+}
+
+// GetHistoryImport returns the latest import's report, nil if none ran
+func (o *Orchestrator) GetHistoryImport() *HistoryImportReport {
+	o.historyImport.mu.Lock()
+	defer o.historyImport.mu.Unlock()
+	if o.historyImport.report == nil {
+		return nil
+	}
+	snapshot := *o.historyImport.report
+	snapshot.OpenQuantity = copyQuantities(snapshot.OpenQuantity)
+	snapshot.UnmatchedSells = copyQuantities(snapshot.UnmatchedSells)
+	snapshot.UnpricedCommission = copyQuantities(snapshot.UnpricedCommission)
+	return &snapshot
+}
+
+// runHistoryImport imports each symbol in turn
+func (o *Orchestrator) runHistoryImport(ctx context.Context, report *HistoryImportReport) {
+	defer o.recoverPanic("historyImport")
+
+	var err error
+	for _, symbol := range report.Symbols {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if err = o.importSymbolHistory(symbol, report); err != nil {
+			err = fmt.Errorf("%s: %w", symbol, err)
+			break
+		}
+	}
+
+	o.historyImport.mu.Lock()
+	report.FinishedAt = time.Now()
+	report.Status = "completed"
+	if err != nil {
+		report.Status = "failed"
+		report.Error = err.Error()
+	}
+	o.historyImport.mu.Unlock()
+
+	event := log.Info()
+	if err != nil {
+		event = log.Error().Err(err)
+	}
+	event.
+		Strs("symbols", report.Symbols).
+		Int("imported", report.Imported).
+		Int("skipped", report.Skipped).
+		Int("positions", report.Positions).
+		Msg("Exchange history import finished")
+}
+
+// importSymbolHistory imports one symbol's fills and rebuilds its positions
+func (o *Orchestrator) importSymbolHistory(symbol string, report *HistoryImportReport) error {
+	info, err := o.binanceClient.GetSymbolInfo(symbol)
+	if err != nil {
+		return fmt.Errorf("failed to fetch symbol info: %w", err)
+	}
+
+	orderTypes, err := o.fetchImportOrderTypes(symbol, report.Until)
+	if err != nil {
+		return err
+	}
+	orders, fills, err := o.fetchImportOrders(symbol, info, report.Until)
+	if err != nil {
+		return err
+	}
+
+	o.historyImport.mu.Lock()
+	report.Fills += fills
+	report.Orders += len(orders)
+	o.historyImport.mu.Unlock()
+
+	var cycle *importCycle
+	for _, order := range orders {
+		order.orderType = orderTypes[order.id]
+		if order.orderType == "" {
+			order.orderType = string(binance.OrderTypeMarket)
+		}
+
+		added, err := o.dataService.ImportTrade(storage.Trade{
+			OrderID:         strconv.FormatInt(order.id, 10),
+			Symbol:          symbol,
+			Side:            string(order.side),
+			Type:            order.orderType,
+			Quantity:        order.quantity,
+			Price:           order.quote / order.quantity,
+			Commission:      order.commission,
+			CommissionAsset: order.asset,
+			ExecutedAt:      order.executedAt,
+			Strategy:        ImportedStrategy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store order %d: %w", order.id, err)
+		}
+
+		o.historyImport.mu.Lock()
+		if added {
+			report.Imported++
+		} else {
+			report.Skipped++
+		}
+		if order.feeQuote == 0 && order.commission > 0 && order.asset != info.QuoteAsset && order.asset != info.BaseAsset {
+			report.UnpricedCommission[order.asset] += order.commission
+		}
+		o.historyImport.mu.Unlock()
+
+		cycle, err = o.applyImportedOrder(symbol, info, cycle, order, added, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cycle != nil && cycle.holding > 0 {
+		o.historyImport.mu.Lock()
+		report.OpenQuantity[symbol] = cycle.holding
+		o.historyImport.mu.Unlock()
+	}
+	return nil
+}
+
+// applyImportedOrder replays an order against the position being rebuilt,
+// storing the position once it is sold off. Spot holdings are long only.
+func (o *Orchestrator) applyImportedOrder(symbol string, info *binance.SymbolInfo, cycle *importCycle, order *importedOrder, added bool, report *HistoryImportReport) (*importCycle, error) {
+	price := order.quote / order.quantity
+
+	if order.side == binance.SideBuy {
+		if cycle == nil {
+			cycle = &importCycle{openedAt: order.executedAt}
+		}
+		received := order.quantity
+		if order.asset == info.BaseAsset {
+			received -= order.commission
+		}
+		cycle.avgEntry = (cycle.avgEntry*cycle.holding + order.quote) / (cycle.holding + received)
+		cycle.holding += received
+		cycle.bought += order.quantity
+		cycle.boughtQuote += order.quote
+		cycle.entryCommission += order.feeQuote
+		cycle.hasNew = cycle.hasNew || added
+		return cycle, nil
+	}
+
+	sold := order.quantity
+	if cycle == nil || cycle.holding <= 0 {
+		o.historyImport.mu.Lock()
+		report.UnmatchedSells[symbol] += sold
+		o.historyImport.mu.Unlock()
+		return cycle, nil
+	}
+	if sold > cycle.holding {
+		o.historyImport.mu.Lock()
+		report.UnmatchedSells[symbol] += sold - cycle.holding
+		o.historyImport.mu.Unlock()
+		sold = cycle.holding
+	}
+
+	cycle.grossPnL += (price - cycle.avgEntry) * sold
+	cycle.holding -= sold
+	cycle.sold += sold
+	cycle.soldQuote += price * sold
+	cycle.exitCommission += order.feeQuote * sold / order.quantity
+	cycle.hasNew = cycle.hasNew || added
+
+	if cycle.holding > cycle.bought*importDustFraction {
+		return cycle, nil
+	}
+
+	// Sold off; only positions with newly imported orders are stored so
+	// re-running an import doesn't duplicate them
+	if cycle.hasNew {
+		if err := o.storeImportedPosition(symbol, cycle, order.executedAt); err != nil {
+			return nil, err
+		}
+		o.historyImport.mu.Lock()
+		report.Positions++
+		o.historyImport.mu.Unlock()
+	}
+	return nil, nil
+}
+
+// storeImportedPosition stores a rebuilt position as closed, with its P&L
+// breakdown
+func (o *Orchestrator) storeImportedPosition(symbol string, cycle *importCycle, closedAt time.Time) error {
+	netPnL := cycle.grossPnL - cycle.entryCommission - cycle.exitCommission
+	row := storage.Position{
+		Symbol:       symbol,
+		Side:         "long",
+		EntryPrice:   cycle.boughtQuote / cycle.bought,
+		Quantity:     cycle.bought,
+		CurrentPrice: cycle.soldQuote / cycle.sold,
+		RealizedPnL:  netPnL,
+		Strategy:     ImportedStrategy,
+		Status:       "closed",
+		OpenedAt:     cycle.openedAt,
+		ClosedAt:     &closedAt,
+	}
+
+	id, err := o.dataService.AddPosition(row)
+	if err != nil {
+		return fmt.Errorf("failed to store position: %w", err)
+	}
+	// Insert leaves out the closing fields
+	row.ID = id
+	if err := o.dataService.UpdatePosition(row); err != nil {
+		return fmt.Errorf("failed to store position: %w", err)
+	}
+
+	return o.dataService.SavePositionPnL(storage.PositionPnL{
+		PositionID:      id,
+		GrossPnL:        cycle.grossPnL,
+		EntryCommission: cycle.entryCommission,
+		ExitCommission:  cycle.exitCommission,
+		NetPnL:          netPnL,
+	})
+}
+
+// fetchImportOrders pages through the account's fills before until and
+// aggregates them by order, oldest first, also returning the fill count
+func (o *Orchestrator) fetchImportOrders(symbol string, info *binance.SymbolInfo, until time.Time) ([]*importedOrder, int, error) {
+	var orders []*importedOrder
+	byID := make(map[int64]*importedOrder)
+	fills := 0
+
+	var fromID int64
+	for {
+		page, err := o.binanceClient.GetMyTradesFrom(symbol, fromID, importPageSize)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch trades: %w", err)
+		}
+
+		for _, t := range page {
+			executedAt := time.UnixMilli(t.Time)
+			if !executedAt.Before(until) {
+				return orders, fills, nil
+			}
+			fills++
+
+			qty, _ := strconv.ParseFloat(t.Qty, 64)
+			quote, _ := strconv.ParseFloat(t.QuoteQty, 64)
+			price, _ := strconv.ParseFloat(t.Price, 64)
+			commission, _ := strconv.ParseFloat(t.Commission, 64)
+
+			order, ok := byID[t.OrderID]
+			if !ok {
+				side := binance.SideSell
+				if t.IsBuyer {
+					side = binance.SideBuy
+				}
+				order = &importedOrder{id: t.OrderID, side: side, asset: t.CommissionAsset}
+				byID[t.OrderID] = order
+				orders = append(orders, order)
+			}
+			order.quantity += qty
+			order.quote += quote
+			order.commission += commission
+			order.executedAt = executedAt
+			switch t.CommissionAsset {
+			case info.QuoteAsset:
+				order.feeQuote += commission
+			case info.BaseAsset:
+				order.feeQuote += commission * price
+			}
+		}
+
+		if len(page) < importPageSize {
+			return orders, fills, nil
+		}
+		fromID = page[len(page)-1].ID + 1
+	}
+}
+
+// fetchImportOrderTypes pages through the account's orders placed before
+// until and returns their types by order ID
+func (o *Orchestrator) fetchImportOrderTypes(symbol string, until time.Time) (map[int64]string, error) {
+	types := make(map[int64]string)
+
+	var fromID int64
+	for {
+		page, err := o.binanceClient.GetAllOrdersFrom(symbol, fromID, importPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch orders: %w", err)
+		}
+
+		for _, order := range page {
+			if !time.UnixMilli(order.Time).Before(until) {
+				return types, nil
+			}
+			types[order.OrderID] = string(order.Type)
+		}
+
+		if len(page) < importPageSize {
+			return types, nil
+		}
+		fromID = page[len(page)-1].OrderID + 1
+	}
+}
+
+// copyQuantities copies a report's per-key quantities
+func copyQuantities(m map[string]float64) map[string]float64 {
+	c := make(map[string]float64, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
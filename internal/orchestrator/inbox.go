@@ -0,0 +1,439 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/risk"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Trade idea states
+const (
+	IdeaPending  = "pending"
+	IdeaApproved = "approved" // Confirmed and sent to the executor
+	IdeaRejected = "rejected"
+	IdeaExpired  = "expired"
+	IdeaFailed   = "failed" // Confirmed but the order could not be placed
+
+	// ideaDeciding marks an idea claimed by an approval in progress
+	ideaDeciding = "deciding"
+)
+
+// maxInboxIdeas is the number of decided ideas kept for the inbox history
+const maxInboxIdeas = 200
+
+// inboxCheckInterval is how often pending ideas are checked for expiry
+const inboxCheckInterval = 10 * time.Second
+
+var (
+	// ErrIdeaNotFound is returned for an unknown trade idea ID
+	ErrIdeaNotFound = errors.New("trade idea not found")
+	// ErrIdeaNotPending is returned when an idea was already decided or expired
+	ErrIdeaNotPending = errors.New("trade idea is no longer pending")
+)
+
+// IdeaStrategyScore is one strategy's contribution to a trade idea
+type IdeaStrategyScore struct {
+	Strategy   string             `json:"strategy"`
+	Direction  string             `json:"direction"`
+	Score      float64            `json:"score"`
+	Confidence float64            `json:"confidence"`
+	Factors    map[string]float64 `json:"factors,omitempty"`
+}
+
+// IdeaReasoning is why the bot proposed a trade
+type IdeaReasoning struct {
+	Regime           string              `json:"regime"`
+	RegimeConfidence float64             `json:"regimeConfidence"`
+	Score            float64             `json:"score"`
+	Confidence       float64             `json:"confidence"`
+	LongSignals      int                 `json:"longSignals"`
+	ShortSignals     int                 `json:"shortSignals"`
+	HasConflict      bool                `json:"hasConflict"`
+	Strategies       []IdeaStrategyScore `json:"strategies"`
+	RiskLevel        string              `json:"riskLevel,omitempty"`
+	RiskRewardRatio  float64             `json:"riskRewardRatio,omitempty"`
+	RiskWarnings     []string            `json:"riskWarnings,omitempty"`
+	SizingModel      string              `json:"sizingModel,omitempty"`
+	SizingDetail     string              `json:"sizingDetail,omitempty"`
+}
+
+// TradeIdea is an approved signal waiting for human confirmation
+type TradeIdea struct {
+	ID         string          `json:"id"`
+	Status     string          `json:"status"`
+	Signal     strategy.Signal `json:"signal"`
+	Reasoning  IdeaReasoning   `json:"reasoning"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	ExpiresAt  time.Time       `json:"expiresAt"`
+	DecidedAt  *time.Time      `json:"decidedAt,omitempty"`
+	DecidedBy  string          `json:"decidedBy,omitempty"`
+	Note       string          `json:"note,omitempty"`
+	OrderID    string          `json:"orderId,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	EntryPrice float64         `json:"entryPrice,omitempty"` // Price the order was sized at on approval
+}
+
+// tradeInbox queues approved signals for manual confirmation
+type tradeInbox struct {
+	enabled bool
+	expiry  time.Duration
+	ideas   []*TradeIdea // Most recent first
+	mu      sync.Mutex
+}
+
+// SetInboxMode enables semi-automatic trading: signals approved by the risk
+// manager are queued for confirmation instead of executing, and discarded
+// when not confirmed within expiry
+func (o *Orchestrator) SetInboxMode(enabled bool, expiry time.Duration) {
+	if expiry <= 0 {
+		expiry = 15 * time.Minute
+	}
+
+	o.inbox.mu.Lock()
+	defer o.inbox.mu.Unlock()
+	o.inbox.enabled = enabled
+	o.inbox.expiry = expiry
+}
+
+// InboxEnabled reports whether signals wait for manual approval
+func (o *Orchestrator) InboxEnabled() bool {
+	o.inbox.mu.Lock()
+	defer o.inbox.mu.Unlock()
+	return o.inbox.enabled
+}
+
+// queueIdea adds an approved signal to the inbox. A pending idea from the
+// same strategy in the same direction is kept instead of queueing a repeat.
+func (o *Orchestrator) queueIdea(signal strategy.Signal, analysis *strategy.AnalysisOutput, assessment risk.RiskAssessment) {
+	now := time.Now()
+
+	o.inbox.mu.Lock()
+	for _, idea := range o.inbox.ideas {
+		if idea.Status == IdeaPending && now.Before(idea.ExpiresAt) &&
+			idea.Signal.Strategy == signal.Strategy && idea.Signal.Direction == signal.Direction {
+			o.inbox.mu.Unlock()
+			log.Debug().
+				Str("idea", idea.ID).
+				Str("strategy", signal.Strategy).
+				Msg("Signal already waiting in the inbox")
+			return
+		}
+	}
+
+	if signal.Timestamp.IsZero() {
+		signal.Timestamp = now
+	}
+	idea := &TradeIdea{
+		ID:        uuid.New().String(),
+		Status:    IdeaPending,
+		Signal:    signal,
+		Reasoning: ideaReasoning(analysis, assessment),
+		CreatedAt: now,
+		ExpiresAt: now.Add(o.inbox.expiry),
+	}
+	o.inbox.ideas = append([]*TradeIdea{idea}, o.inbox.ideas...)
+	o.trimInbox()
+	queued := *idea
+	o.inbox.mu.Unlock()
+
+	log.Info().
+		Str("idea", queued.ID).
+		Str("strategy", signal.Strategy).
+		Str("direction", signal.Direction.String()).
+		Time("expiresAt", queued.ExpiresAt).
+		Msg("Trade idea queued for approval")
+
+	o.auditIdea(&queued, "info", fmt.Sprintf("Trade idea queued: %s %s (%s)", signal.Direction, signal.Symbol, signal.Strategy))
+	o.broadcastIdea(&queued)
+}
+
+// ideaReasoning summarizes the analysis and risk assessment behind a signal
+func ideaReasoning(analysis *strategy.AnalysisOutput, assessment risk.RiskAssessment) IdeaReasoning {
+	r := IdeaReasoning{
+		RiskLevel:       assessment.RiskLevel.String(),
+		RiskRewardRatio: assessment.RiskRewardRatio,
+		RiskWarnings:    assessment.Warnings,
+		SizingModel:     string(assessment.SizingModel),
+		SizingDetail:    assessment.SizingDetail,
+	}
+	if analysis == nil {
+		return r
+	}
+
+	r.Regime = analysis.Regime.Regime.String()
+	r.RegimeConfidence = analysis.Regime.Confidence
+	r.Score = analysis.Score.Score
+	r.Confidence = analysis.Score.Confidence
+	r.LongSignals = analysis.Score.LongSignals
+	r.ShortSignals = analysis.Score.ShortSignals
+	r.HasConflict = analysis.Score.HasConflict
+	for _, s := range analysis.Score.Scores {
+		r.Strategies = append(r.Strategies, IdeaStrategyScore{
+			Strategy:   s.Strategy,
+			Direction:  s.Direction.String(),
+			Score:      s.Score,
+			Confidence: s.Confidence,
+			Factors:    s.Factors,
+		})
+	}
+	sort.Slice(r.Strategies, func(i, j int) bool {
+		return r.Strategies[i].Score > r.Strategies[j].Score
+	})
+	return r
+}
+
+// GetInbox returns trade ideas, most recent first. An empty status returns
+// pending ideas; "all" includes decided ones.
+func (o *Orchestrator) GetInbox(status string, limit int) []TradeIdea {
+	o.expireIdeas()
+
+	if status == "" {
+		status = IdeaPending
+	}
+
+	o.inbox.mu.Lock()
+	defer o.inbox.mu.Unlock()
+
+	ideas := make([]TradeIdea, 0)
+	for _, idea := range o.inbox.ideas {
+		if status != "all" && idea.Status != status {
+			continue
+		}
+		ideas = append(ideas, *idea)
+		if limit > 0 && len(ideas) >= limit {
+			break
+		}
+	}
+	return ideas
+}
+
+// ApproveIdea confirms a pending idea and executes it. The trade is assessed
+// again at the current price, since the market may have moved while it
+// waited.
+func (o *Orchestrator) ApproveIdea(id, user, note string) (*TradeIdea, error) {
+	idea, err := o.decideIdea(id)
+	if err != nil {
+		return nil, err
+	}
+
+	signal := idea.Signal
+	o.stateMu.RLock()
+	if price := o.state.CurrentPrice; price > 0 {
+		signal.Price = price
+	}
+	o.stateMu.RUnlock()
+
+	var execErr error
+	if o.riskManager != nil && o.riskManager.IsHalted() {
+		execErr = fmt.Errorf("trading is halted")
+	} else if o.riskManager != nil {
+		assessment := o.riskManager.AssessTrade(risk.TradeParams{
+			Symbol:     signal.Symbol,
+			Direction:  signal.Direction.String(),
+			EntryPrice: signal.Price,
+			StopLoss:   signal.StopLoss,
+			TakeProfit: signal.TakeProfit,
+			ATR:        signal.Indicators.ATR,
+			Strategy:   signal.Strategy,
+			Stats:      o.sizingStats(signal.Strategy),
+		})
+		if !assessment.Approved && len(assessment.Reasons) > 0 {
+			execErr = fmt.Errorf("rejected by risk manager: %s", assessment.Reasons[0])
+		} else if !assessment.Approved {
+			execErr = fmt.Errorf("rejected by risk manager")
+		}
+	}
+
+	var orderID string
+	if execErr == nil {
+		o.publishSignal(signal)
+		var result *execution.ExecutionResult
+		result, execErr = o.executeSignal(signal, nil)
+		if result != nil && result.Order != nil {
+			orderID = result.Order.ID
+		}
+		if execErr == nil {
+			o.riskTally.recordExecuted()
+		}
+	}
+
+	status, severity := IdeaApproved, "info"
+	message := fmt.Sprintf("Trade idea approved by %s: %s %s (%s)", user, signal.Direction, signal.Symbol, signal.Strategy)
+	if execErr != nil {
+		status, severity = IdeaFailed, "warning"
+		message = fmt.Sprintf("Trade idea approved by %s but not executed: %v", user, execErr)
+	}
+
+	decided := o.finishIdea(id, status, user, note, func(i *TradeIdea) {
+		i.EntryPrice = signal.Price
+		i.OrderID = orderID
+		if execErr != nil {
+			i.Error = execErr.Error()
+		}
+	})
+
+	log.Info().
+		Str("idea", id).
+		Str("user", user).
+		Str("status", status).
+		Str("orderID", orderID).
+		Msg("Trade idea approved")
+
+	o.auditIdea(decided, severity, message)
+	o.broadcastIdea(decided)
+
+	return decided, execErr
+}
+
+// RejectIdea discards a pending idea
+func (o *Orchestrator) RejectIdea(id, user, note string) (*TradeIdea, error) {
+	if _, err := o.decideIdea(id); err != nil {
+		return nil, err
+	}
+
+	decided := o.finishIdea(id, IdeaRejected, user, note, nil)
+
+	log.Info().Str("idea", id).Str("user", user).Msg("Trade idea rejected")
+
+	o.auditIdea(decided, "info", fmt.Sprintf("Trade idea rejected by %s: %s %s (%s)",
+		user, decided.Signal.Direction, decided.Signal.Symbol, decided.Signal.Strategy))
+	o.broadcastIdea(decided)
+	return decided, nil
+}
+
+// decideIdea claims a pending idea for a decision so concurrent approvals
+// cannot execute it twice
+func (o *Orchestrator) decideIdea(id string) (*TradeIdea, error) {
+	o.expireIdeas()
+
+	o.inbox.mu.Lock()
+	defer o.inbox.mu.Unlock()
+
+	for _, idea := range o.inbox.ideas {
+		if idea.ID != id {
+			continue
+		}
+		if idea.Status != IdeaPending {
+			return nil, ErrIdeaNotPending
+		}
+		idea.Status = ideaDeciding
+		claimed := *idea
+		return &claimed, nil
+	}
+	return nil, ErrIdeaNotFound
+}
+
+// finishIdea records the decision on an idea and returns a copy
+func (o *Orchestrator) finishIdea(id, status, user, note string, update func(*TradeIdea)) *TradeIdea {
+	o.inbox.mu.Lock()
+	defer o.inbox.mu.Unlock()
+
+	now := time.Now()
+	for _, idea := range o.inbox.ideas {
+		if idea.ID != id {
+			continue
+		}
+		idea.Status = status
+		idea.DecidedAt = &now
+		idea.DecidedBy = user
+		idea.Note = note
+		if update != nil {
+			update(idea)
+		}
+		decided := *idea
+		return &decided
+	}
+	return &TradeIdea{ID: id, Status: status}
+}
+
+// expireIdeas marks pending ideas past their expiry
+func (o *Orchestrator) expireIdeas() {
+	now := time.Now()
+
+	o.inbox.mu.Lock()
+	var expired []TradeIdea
+	for _, idea := range o.inbox.ideas {
+		if idea.Status == IdeaPending && !now.Before(idea.ExpiresAt) {
+			idea.Status = IdeaExpired
+			decidedAt := now
+			idea.DecidedAt = &decidedAt
+			expired = append(expired, *idea)
+		}
+	}
+	o.inbox.mu.Unlock()
+
+	for i := range expired {
+		idea := &expired[i]
+		log.Info().Str("idea", idea.ID).Str("strategy", idea.Signal.Strategy).Msg("Trade idea expired")
+		o.auditIdea(idea, "info", fmt.Sprintf("Trade idea expired unconfirmed: %s %s (%s)",
+			idea.Signal.Direction, idea.Signal.Symbol, idea.Signal.Strategy))
+		o.broadcastIdea(idea)
+	}
+}
+
+// trimInbox drops the oldest decided ideas beyond the history limit. The
+// caller must hold the inbox lock.
+func (o *Orchestrator) trimInbox() {
+	if len(o.inbox.ideas) <= maxInboxIdeas {
+		return
+	}
+	kept := o.inbox.ideas[:0]
+	for i, idea := range o.inbox.ideas {
+		if i < maxInboxIdeas || idea.Status == IdeaPending {
+			kept = append(kept, idea)
+		}
+	}
+	o.inbox.ideas = kept
+}
+
+// inboxLoop expires unconfirmed ideas
+func (o *Orchestrator) inboxLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(inboxCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			o.expireIdeas()
+			beat()
+		}
+	}
+}
+
+// auditIdea records a trade idea transition in the alert log
+func (o *Orchestrator) auditIdea(idea *TradeIdea, severity, message string) {
+	if o.dataService == nil {
+		return
+	}
+	data, _ := json.Marshal(idea)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "trade_idea",
+		Severity: severity,
+		Message:  message,
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record trade idea audit entry")
+	}
+}
+
+// broadcastIdea notifies clients of a new or decided trade idea
+func (o *Orchestrator) broadcastIdea(idea *TradeIdea) {
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeTradeIdea,
+		Timestamp: time.Now(),
+		Data:      idea,
+	})
+}
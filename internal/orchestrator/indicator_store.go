@@ -0,0 +1,236 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrPrecomputeRunning is returned when an indicator precompute job is
+// already in progress
+var ErrPrecomputeRunning = errors.New("indicator precompute already running")
+
+// IndicatorPrecomputeResult summarizes one precompute run
+type IndicatorPrecomputeResult struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Columns   []string  `json:"columns"`
+	Candles   int       `json:"candles"` // Stored candles the series were computed over
+	Written   int       `json:"written"` // Values upserted
+	Full      bool      `json:"full"`    // Rewrote every value rather than only new bars
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+}
+
+// indicatorStore precomputes indicator series for stored candles
+type indicatorStore struct {
+	interval   time.Duration // Periodic run interval (0 = on demand only)
+	timeframes []string
+	columns    []string
+
+	running sync.Mutex
+	mu      sync.Mutex
+	last    map[string]IndicatorPrecomputeResult // Last run per timeframe
+}
+
+// SetIndicatorPrecompute configures the columns precomputed by default and,
+// with a positive interval, runs the job periodically for timeframes
+func (o *Orchestrator) SetIndicatorPrecompute(interval time.Duration, timeframes, columns []string) error {
+	for _, name := range columns {
+		if !indicators.ValidSeriesColumn(name) {
+			return fmt.Errorf("unknown indicator series %q", name)
+		}
+	}
+	o.indicatorStore.interval = interval
+	o.indicatorStore.timeframes = timeframes
+	o.indicatorStore.columns = columns
+	return nil
+}
+
+// IndicatorSeriesColumns returns the columns precomputed when none are
+// requested explicitly
+func (o *Orchestrator) IndicatorSeriesColumns() []string {
+	if len(o.indicatorStore.columns) > 0 {
+		return o.indicatorStore.columns
+	}
+	return indicators.SeriesColumns()
+}
+
+// PrecomputeIndicators computes indicator series over every stored candle
+// of a timeframe (default primary) and writes them to the indicator side table. Unless full
+// is set only bars newer than each series' last stored value are written.
+func (o *Orchestrator) PrecomputeIndicators(timeframe string, columns []string, full bool) (*IndicatorPrecomputeResult, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+	if timeframe == "" {
+		timeframe = o.config.PrimaryTimeframe
+	}
+	if len(columns) == 0 {
+		columns = o.IndicatorSeriesColumns()
+	}
+
+	if !o.indicatorStore.running.TryLock() {
+		return nil, ErrPrecomputeRunning
+	}
+	defer o.indicatorStore.running.Unlock()
+
+	result := &IndicatorPrecomputeResult{
+		Symbol:    o.config.Symbol,
+		Timeframe: timeframe,
+		Columns:   columns,
+		Full:      full,
+		StartedAt: time.Now(),
+	}
+
+	candles, err := o.dataService.GetHistoricalCandles(o.config.Symbol, timeframe, time.Time{}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load candles: %w", err)
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no stored %s candles", timeframe)
+	}
+	result.Candles = len(candles)
+
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+		closes[i] = c.Close
+		volumes[i] = c.Volume
+	}
+
+	var config *indicators.IndicatorConfig
+	if o.indicatorMgr != nil {
+		config = o.indicatorMgr.GetConfig()
+	}
+	series, err := indicators.ComputeSeries(config, columns, highs, lows, closes, volumes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range columns {
+		values := series[name]
+		offset := len(candles) - len(values)
+
+		var after time.Time
+		if !full {
+			if after, err = o.dataService.GetIndicatorLatestTime(o.config.Symbol, timeframe, name); err != nil {
+				return nil, fmt.Errorf("failed to read %s coverage: %w", name, err)
+			}
+		}
+
+		column := storage.IndicatorColumn{Name: name}
+		for i, v := range values {
+			openTime := candles[offset+i].OpenTime
+			if !openTime.After(after) {
+				continue
+			}
+			column.Times = append(column.Times, openTime)
+			column.Values = append(column.Values, v)
+		}
+
+		if err := o.dataService.SaveIndicatorColumn(o.config.Symbol, timeframe, column); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %w", name, err)
+		}
+		result.Written += len(column.Values)
+	}
+
+	result.Duration = time.Since(result.StartedAt).Round(time.Millisecond).String()
+
+	o.indicatorStore.mu.Lock()
+	if o.indicatorStore.last == nil {
+		o.indicatorStore.last = make(map[string]IndicatorPrecomputeResult)
+	}
+	o.indicatorStore.last[timeframe] = *result
+	o.indicatorStore.mu.Unlock()
+
+	log.Info().
+		Str("timeframe", timeframe).
+		Int("candles", result.Candles).
+		Int("columns", len(columns)).
+		Int("written", result.Written).
+		Str("duration", result.Duration).
+		Msg("Indicator series precomputed")
+
+	return result, nil
+}
+
+// GetLastPrecompute returns the most recent precompute run per timeframe
+func (o *Orchestrator) GetLastPrecompute() []IndicatorPrecomputeResult {
+	o.indicatorStore.mu.Lock()
+	defer o.indicatorStore.mu.Unlock()
+
+	runs := make([]IndicatorPrecomputeResult, 0, len(o.indicatorStore.last))
+	for _, run := range o.indicatorStore.last {
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Timeframe < runs[j].Timeframe
+	})
+	return runs
+}
+
+// GetIndicatorSeries reads precomputed indicator series for a date range
+func (o *Orchestrator) GetIndicatorSeries(timeframe string, columns []string, from, to time.Time) ([]*storage.IndicatorColumn, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+	if timeframe == "" {
+		timeframe = o.config.PrimaryTimeframe
+	}
+	if len(columns) == 0 {
+		columns = o.IndicatorSeriesColumns()
+	}
+
+	result := make([]*storage.IndicatorColumn, 0, len(columns))
+	for _, name := range columns {
+		column, err := o.dataService.GetIndicatorColumn(o.config.Symbol, timeframe, name, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		result = append(result, column)
+	}
+	return result, nil
+}
+
+// GetIndicatorCoverage lists the precomputed series stored for a timeframe
+func (o *Orchestrator) GetIndicatorCoverage(timeframe string) ([]storage.IndicatorCoverage, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+	if timeframe == "" {
+		timeframe = o.config.PrimaryTimeframe
+	}
+	return o.dataService.GetIndicatorCoverage(o.config.Symbol, timeframe)
+}
+
+// indicatorStoreLoop precomputes the configured timeframes on the interval
+func (o *Orchestrator) indicatorStoreLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(o.indicatorStore.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, tf := range o.indicatorStore.timeframes {
+				if _, err := o.PrecomputeIndicators(tf, nil, false); err != nil {
+					log.Warn().Err(err).Str("timeframe", tf).Msg("Indicator precompute failed")
+				}
+				beat()
+			}
+		}
+	}
+}
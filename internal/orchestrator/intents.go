@@ -0,0 +1,273 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// recordOrderIntent durably records a risk-approved order before it is sent
+// to exec, so a restart mid-execution can complete or reconcile it
+func (o *Orchestrator) recordOrderIntent(exec execution.Executor, order *execution.Order, signal strategy.Signal) (*storage.OrderIntent, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+	intent := &storage.OrderIntent{
+		ClientOrderID: order.ClientID,
+		Symbol:        order.Symbol,
+		Side:          string(order.Side),
+		Quantity:      order.Quantity,
+		Strategy:      order.Strategy,
+		SignalPrice:   signal.Price,
+		StopLoss:      signal.StopLoss,
+		TakeProfit:    signal.TakeProfit,
+		Mode:          executorMode(exec).String(),
+		State:         storage.IntentApproved,
+	}
+
+	id, err := o.dataService.AddOrderIntent(*intent)
+	if err != nil {
+		return nil, err
+	}
+	intent.ID = id
+	return intent, nil
+}
+
+// executorMode returns the trading mode exec places orders in
+func executorMode(exec execution.Executor) TradingMode {
+	if _, ok := exec.(*execution.PaperExecutor); ok {
+		return TradingModePaper
+	}
+	return TradingModeLive
+}
+
+// advanceIntent moves an intent to a new state and persists it
+func (o *Orchestrator) advanceIntent(intent *storage.OrderIntent, state, reason string) {
+	intent.State = state
+	intent.Error = reason
+	if err := o.dataService.UpdateOrderIntent(*intent); err != nil {
+		log.Warn().
+			Err(err).
+			Str("clientOrderId", intent.ClientOrderID).
+			Str("state", state).
+			Msg("Failed to persist order intent")
+	}
+}
+
+// recordIntentFill stores the fill details of an executed intent
+func (o *Orchestrator) recordIntentFill(intent *storage.OrderIntent, result *execution.ExecutionResult) {
+	if result.Order != nil {
+		intent.OrderID = result.Order.ID
+	}
+	if result.Trade != nil {
+		intent.FillPrice = result.Trade.Price
+		intent.FilledQuantity = result.Trade.Quantity
+	}
+	if result.Position != nil {
+		intent.PositionID = result.Position.ID
+	}
+
+	// Orders the exchange accepted but has not filled stay submitted until
+	// reconciled
+	state := storage.IntentFilled
+	if result.Trade == nil {
+		state = storage.IntentSubmitted
+	}
+	o.advanceIntent(intent, state, "")
+}
+
+// protectIntent places the intent's stop loss and take profit on its
+// position, leaving the intent filled if either cannot be placed
+func (o *Orchestrator) protectIntent(exec execution.Executor, intent *storage.OrderIntent) {
+	if intent.State != storage.IntentFilled {
+		return
+	}
+
+	var errs []string
+	if intent.StopLoss > 0 {
+		if err := exec.UpdateStopLoss(intent.PositionID, intent.StopLoss); err != nil {
+			errs = append(errs, fmt.Sprintf("stop loss: %v", err))
+		}
+	}
+	if intent.TakeProfit > 0 {
+		if err := exec.UpdateTakeProfit(intent.PositionID, intent.TakeProfit); err != nil {
+			errs = append(errs, fmt.Sprintf("take profit: %v", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		reason := strings.Join(errs, "; ")
+		o.advanceIntent(intent, storage.IntentFilled, reason)
+		log.Error().
+			Str("clientOrderId", intent.ClientOrderID).
+			Int64("positionID", intent.PositionID).
+			Str("reason", reason).
+			Msg("Position left unprotected")
+		o.broadcastError("POSITION_UNPROTECTED", "Failed to place protective orders", reason)
+		return
+	}
+
+	o.advanceIntent(intent, storage.IntentProtected, "")
+}
+
+// replayOrderIntents completes or reconciles intents interrupted by a
+// restart. Approved intents were never sent and are dropped as stale; live
+// intents are resolved against the exchange by client order ID; paper
+// intents are resolved against the restored paper account, or dropped
+// when it was reset. Intents recorded without a mode go by the executors
+// present.
+func (o *Orchestrator) replayOrderIntents() {
+	if o.dataService == nil {
+		return
+	}
+	intents, err := o.dataService.GetPendingOrderIntents()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load pending order intents")
+		return
+	}
+	if len(intents) == 0 {
+		return
+	}
+
+	log.Info().Int("count", len(intents)).Msg("Replaying interrupted order intents")
+
+	// Live intents can be reconciled even when starting disarmed on paper
+	live, _ := o.activeExecutor().(orderRecoverer)
+	if live == nil {
+		o.stateMu.RLock()
+		live, _ = o.executors[TradingModeLive].(orderRecoverer)
+		o.stateMu.RUnlock()
+	}
+	var paper *execution.PaperExecutor
+	if o.paperState.restored {
+		paper = o.paperAccount()
+	}
+	for i := range intents {
+		intent := &intents[i]
+
+		switch {
+		case intent.State == storage.IntentApproved:
+			o.advanceIntent(intent, storage.IntentCanceled, "signal expired before submission")
+		case intent.Mode == TradingModePaper.String() && paper != nil:
+			o.reconcilePaperIntent(paper, intent)
+		case intent.Mode == TradingModePaper.String():
+			o.advanceIntent(intent, storage.IntentCanceled, "paper account reset on restart")
+		case intent.Mode == TradingModeLive.String() && live == nil:
+			// Left pending until a live executor is configured again
+			log.Warn().Str("clientOrderId", intent.ClientOrderID).Msg("No live executor to reconcile order intent")
+			continue
+		case intent.Mode == TradingModeLive.String():
+			o.reconcileLiveIntent(live, intent)
+		case live == nil && paper != nil:
+			o.reconcilePaperIntent(paper, intent)
+		case live == nil:
+			o.advanceIntent(intent, storage.IntentCanceled, "paper account reset on restart")
+		default:
+			o.reconcileLiveIntent(live, intent)
+		}
+
+		log.Info().
+			Str("clientOrderId", intent.ClientOrderID).
+			Str("state", intent.State).
+			Str("reason", intent.Error).
+			Msg("Order intent replayed")
+	}
+}
+
+// orderRecoverer is implemented by exchange executors that can look up an
+// order by its client order ID
+type orderRecoverer interface {
+	execution.Executor
+	RecoverOrder(symbol, clientID, strategy string) (*execution.ExecutionResult, error)
+}
+
+// reconcileLiveIntent resolves a submitted or filled live intent from the
+// exchange's record of its order
+func (o *Orchestrator) reconcileLiveIntent(live orderRecoverer, intent *storage.OrderIntent) {
+	result, err := live.RecoverOrder(intent.Symbol, intent.ClientOrderID, intent.Strategy)
+	if err != nil {
+		if binance.IsOrderNotFound(err) {
+			o.advanceIntent(intent, storage.IntentCanceled, "order never reached the exchange")
+			return
+		}
+		// Leave the intent pending for the next restart
+		log.Warn().Err(err).Str("clientOrderId", intent.ClientOrderID).Msg("Failed to reconcile order intent")
+		return
+	}
+
+	switch result.Order.Status {
+	case execution.OrderStatusFilled:
+		o.recordIntentFill(intent, result)
+		o.protectIntent(live, intent)
+		data, _ := json.Marshal(intent)
+		if _, err := o.dataService.AddAlert(storage.Alert{
+			Type:     "order_intent_recovered",
+			Severity: "warning",
+			Message:  fmt.Sprintf("Recovered %s order %s interrupted by restart", intent.Side, result.Order.ID),
+			Data:     string(data),
+		}); err != nil {
+			log.Warn().Err(err).Msg("Failed to record order intent alert")
+		}
+	case execution.OrderStatusCanceled, execution.OrderStatusRejected, execution.OrderStatusExpired:
+		intent.OrderID = result.Order.ID
+		o.advanceIntent(intent, storage.IntentFailed, fmt.Sprintf("order %s", strings.ToLower(string(result.Order.Status))))
+	default:
+		// Still working on the exchange
+		intent.OrderID = result.Order.ID
+		o.advanceIntent(intent, storage.IntentSubmitted, "")
+	}
+}
+
+// reconcilePaperIntent resolves a submitted or filled paper intent from the
+// restored paper account. The account is saved periodically, so intents
+// newer than its snapshot are not in it and are dropped.
+func (o *Orchestrator) reconcilePaperIntent(paper *execution.PaperExecutor, intent *storage.OrderIntent) {
+	if intent.State == storage.IntentSubmitted {
+		// Still resting on the paper book
+		orders, _ := paper.GetOpenOrders(intent.Symbol)
+		for _, order := range orders {
+			if order.ClientID == intent.ClientOrderID {
+				intent.OrderID = order.ID
+				o.advanceIntent(intent, storage.IntentSubmitted, "")
+				return
+			}
+		}
+
+		var fill *execution.Trade
+		for _, trade := range paper.GetTrades() {
+			if intent.OrderID != "" && trade.OrderID == intent.OrderID {
+				fill = trade
+				break
+			}
+		}
+		if fill == nil {
+			o.advanceIntent(intent, storage.IntentCanceled, "order not in the restored paper account")
+			return
+		}
+		intent.PositionID = fill.PositionID
+		o.recordIntentFill(intent, &execution.ExecutionResult{Trade: fill})
+	}
+
+	positions, _ := paper.GetPositions()
+	for _, pos := range positions {
+		if pos.ID == intent.PositionID {
+			o.protectIntent(paper, intent)
+			return
+		}
+	}
+	o.advanceIntent(intent, storage.IntentCanceled, "position not open in the restored paper account")
+}
+
+// GetOrderIntents returns the most recent order intents
+func (o *Orchestrator) GetOrderIntents(limit int) ([]storage.OrderIntent, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+	return o.dataService.GetOrderIntents(limit)
+}
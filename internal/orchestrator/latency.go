@@ -0,0 +1,245 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Pipeline stages timed from a closed candle to the order it leads to
+const (
+	StageCandleToAnalysis = "candle_to_analysis"
+	StageAnalysisToRisk   = "analysis_to_risk"
+	StageRiskToOrder      = "risk_to_order"
+)
+
+// pipelineStages lists the stages in pipeline order
+var pipelineStages = []string{StageCandleToAnalysis, StageAnalysisToRisk, StageRiskToOrder}
+
+// maxLatencyBreaches is the number of budget breaches kept for the status endpoint
+const maxLatencyBreaches = 50
+
+// LatencyBudgets is the time each pipeline stage may take before a slow-path
+// warning; 0 leaves a stage unchecked
+type LatencyBudgets struct {
+	CandleToAnalysis time.Duration // Closed candle received to strategy analysis done
+	AnalysisToRisk   time.Duration // Analysis done to risk assessment done
+	RiskToOrder      time.Duration // Risk assessment done to order acknowledged
+	AlertCooldown    time.Duration // Minimum time between alerts for one stage
+}
+
+// budget returns the budget of a stage
+func (b LatencyBudgets) budget(stage string) time.Duration {
+	switch stage {
+	case StageCandleToAnalysis:
+		return b.CandleToAnalysis
+	case StageAnalysisToRisk:
+		return b.AnalysisToRisk
+	case StageRiskToOrder:
+		return b.RiskToOrder
+	}
+	return 0
+}
+
+// StageLatency summarizes the timings of one pipeline stage
+type StageLatency struct {
+	Stage    string  `json:"stage"`
+	BudgetMs float64 `json:"budgetMs"` // 0 = not enforced
+	Count    int64   `json:"count"`
+	Breaches int64   `json:"breaches"`
+	LastMs   float64 `json:"lastMs"`
+	AvgMs    float64 `json:"avgMs"`
+	MaxMs    float64 `json:"maxMs"`
+}
+
+// LatencyBreach is a pipeline run that overran a stage budget
+type LatencyBreach struct {
+	CorrelationID string             `json:"correlationId"`
+	Stage         string             `json:"stage"`
+	ElapsedMs     float64            `json:"elapsedMs"`
+	BudgetMs      float64            `json:"budgetMs"`
+	Stages        map[string]float64 `json:"stages"` // Timings of the run so far, in ms
+	Timeframe     string             `json:"timeframe"`
+	CandleClose   time.Time          `json:"candleClose"`
+	At            time.Time          `json:"at"`
+}
+
+// LatencyReport describes pipeline stage timings against their budgets
+type LatencyReport struct {
+	Stages   []StageLatency  `json:"stages"`
+	Breaches []LatencyBreach `json:"breaches"` // Most recent first
+}
+
+// pipelineTrace follows one closed candle through the pipeline. Its ID
+// correlates the log lines, alerts and order of the run.
+type pipelineTrace struct {
+	ID          string
+	Timeframe   string
+	CandleClose time.Time
+	last        time.Time // End of the previous stage
+	stages      map[string]time.Duration
+}
+
+// stageStats accumulates the timings of one stage
+type stageStats struct {
+	count    int64
+	breaches int64
+	last     time.Duration
+	total    time.Duration
+	max      time.Duration
+}
+
+// latencyMonitor enforces the stage budgets
+type latencyMonitor struct {
+	mu        sync.Mutex
+	budgets   LatencyBudgets
+	stats     map[string]*stageStats
+	breaches  []LatencyBreach
+	lastAlert map[string]time.Time
+}
+
+// SetLatencyBudgets sets the time each pipeline stage may take
+func (o *Orchestrator) SetLatencyBudgets(budgets LatencyBudgets) {
+	o.latency.mu.Lock()
+	defer o.latency.mu.Unlock()
+	o.latency.budgets = budgets
+}
+
+// newPipelineTrace starts timing a closed candle received at receivedAt
+func newPipelineTrace(timeframe string, candleClose, receivedAt time.Time) *pipelineTrace {
+	return &pipelineTrace{
+		ID:          uuid.New().String(),
+		Timeframe:   timeframe,
+		CandleClose: candleClose,
+		last:        receivedAt,
+		stages:      make(map[string]time.Duration, len(pipelineStages)),
+	}
+}
+
+// markStage ends a stage of the traced run, warning and alerting when it
+// overran its budget. A nil trace is ignored.
+func (o *Orchestrator) markStage(trace *pipelineTrace, stage string) {
+	if trace == nil {
+		return
+	}
+	now := time.Now()
+	elapsed := now.Sub(trace.last)
+	trace.last = now
+	trace.stages[stage] = elapsed
+
+	m := &o.latency
+	m.mu.Lock()
+	if m.stats == nil {
+		m.stats = make(map[string]*stageStats)
+	}
+	s := m.stats[stage]
+	if s == nil {
+		s = &stageStats{}
+		m.stats[stage] = s
+	}
+	s.count++
+	s.last = elapsed
+	s.total += elapsed
+	if elapsed > s.max {
+		s.max = elapsed
+	}
+
+	budget := m.budgets.budget(stage)
+	if budget <= 0 || elapsed <= budget {
+		m.mu.Unlock()
+		return
+	}
+
+	s.breaches++
+	breach := LatencyBreach{
+		CorrelationID: trace.ID,
+		Stage:         stage,
+		ElapsedMs:     durationMs(elapsed),
+		BudgetMs:      durationMs(budget),
+		Stages:        make(map[string]float64, len(trace.stages)),
+		Timeframe:     trace.Timeframe,
+		CandleClose:   trace.CandleClose,
+		At:            now,
+	}
+	for name, d := range trace.stages {
+		breach.Stages[name] = durationMs(d)
+	}
+	m.breaches = append([]LatencyBreach{breach}, m.breaches...)
+	if len(m.breaches) > maxLatencyBreaches {
+		m.breaches = m.breaches[:maxLatencyBreaches]
+	}
+
+	alert := now.Sub(m.lastAlert[stage]) >= m.budgets.AlertCooldown
+	if alert {
+		if m.lastAlert == nil {
+			m.lastAlert = make(map[string]time.Time)
+		}
+		m.lastAlert[stage] = now
+	}
+	m.mu.Unlock()
+
+	event := log.Warn().
+		Str("correlationId", trace.ID).
+		Str("stage", stage).
+		Dur("elapsed", elapsed).
+		Dur("budget", budget)
+	for name, d := range trace.stages {
+		event = event.Dur(name, d)
+	}
+	event.Msg("Pipeline stage over latency budget")
+
+	// The alert write stays off the trading path
+	if alert && o.dataService != nil {
+		go o.recordLatencyAlert(breach)
+	}
+}
+
+// recordLatencyAlert stores a budget breach as an alert
+func (o *Orchestrator) recordLatencyAlert(breach LatencyBreach) {
+	defer o.recoverPanic("latencyAlert")
+
+	data, _ := json.Marshal(breach)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "latency_budget",
+		Severity: "warning",
+		Message: fmt.Sprintf("Pipeline stage %s took %.0fms, budget %.0fms",
+			breach.Stage, breach.ElapsedMs, breach.BudgetMs),
+		Data: string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record latency alert")
+	}
+}
+
+// GetLatency returns pipeline stage timings and recent budget breaches
+func (o *Orchestrator) GetLatency() LatencyReport {
+	m := &o.latency
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := LatencyReport{
+		Stages:   make([]StageLatency, 0, len(pipelineStages)),
+		Breaches: append([]LatencyBreach{}, m.breaches...),
+	}
+	for _, stage := range pipelineStages {
+		sl := StageLatency{Stage: stage, BudgetMs: durationMs(m.budgets.budget(stage))}
+		if s := m.stats[stage]; s != nil {
+			sl.Count = s.count
+			sl.Breaches = s.breaches
+			sl.LastMs = durationMs(s.last)
+			sl.MaxMs = durationMs(s.max)
+			sl.AvgMs = durationMs(s.total / time.Duration(s.count))
+		}
+		report.Stages = append(report.Stages, sl)
+	}
+	return report
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,266 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxLeaderboardPositions bounds the closed positions read for the leaderboard
+const maxLeaderboardPositions = 5000
+
+// LeaderboardSort is the metric strategies are ranked by
+type LeaderboardSort string
+
+const (
+	SortBySharpe       LeaderboardSort = "sharpe"
+	SortByWinRate      LeaderboardSort = "win_rate"
+	SortByExpectancy   LeaderboardSort = "expectancy"
+	SortByMaxDrawdown  LeaderboardSort = "max_drawdown" // Smallest first
+	SortByTrades       LeaderboardSort = "trades"
+	SortByNetPnL       LeaderboardSort = "net_pnl"
+	SortByProfitFactor LeaderboardSort = "profit_factor"
+)
+
+// ParseLeaderboardSort validates a sort metric; empty selects Sharpe
+func ParseLeaderboardSort(s string) (LeaderboardSort, error) {
+	switch LeaderboardSort(s) {
+	case "":
+		return SortBySharpe, nil
+	case SortBySharpe, SortByWinRate, SortByExpectancy, SortByMaxDrawdown, SortByTrades, SortByNetPnL, SortByProfitFactor:
+		return LeaderboardSort(s), nil
+	}
+	return "", fmt.Errorf("unknown sort %q (sharpe, win_rate, expectancy, max_drawdown, trades, net_pnl, profit_factor)", s)
+}
+
+// StrategyStanding is one strategy's realized performance over the
+// leaderboard window
+type StrategyStanding struct {
+	Rank         int        `json:"rank"`
+	Strategy     string     `json:"strategy"`
+	Enabled      bool       `json:"enabled"` // Running now; false for strategies that only have history
+	Trades       int        `json:"trades"`
+	Wins         int        `json:"wins"`
+	Losses       int        `json:"losses"`
+	WinRate      float64    `json:"winRate"`
+	NetPnL       float64    `json:"netPnl"`
+	Expectancy   float64    `json:"expectancy"` // Average P&L per trade
+	AvgWin       float64    `json:"avgWin"`
+	AvgLoss      float64    `json:"avgLoss"` // Positive
+	ProfitFactor float64    `json:"profitFactor"`
+	Sharpe       float64    `json:"sharpe"`      // Annualized, from daily realized P&L
+	MaxDrawdown  float64    `json:"maxDrawdown"` // Largest fall of cumulative realized P&L from its peak
+	LastTrade    *time.Time `json:"lastTrade,omitempty"`
+
+	grossProfit, grossLoss float64
+	daily                  map[string]float64 // Realized P&L by close date
+	closes                 []leaderboardClose
+}
+
+type leaderboardClose struct {
+	at  time.Time
+	pnl float64
+}
+
+// Leaderboard ranks strategies by realized performance
+type Leaderboard struct {
+	Window     string             `json:"window"` // e.g. "30d"; "all" = every persisted position
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Sort       LeaderboardSort    `json:"sort"`
+	Strategies []StrategyStanding `json:"strategies"`
+}
+
+// GetStrategyLeaderboard ranks strategies by the positions they closed in
+// the last window, or in all persisted history when window is 0. Running
+// strategies without trades are listed after those with trades.
+func (o *Orchestrator) GetStrategyLeaderboard(window time.Duration, sortBy LeaderboardSort) (*Leaderboard, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+
+	to := time.Now()
+	var from time.Time
+	if window > 0 {
+		from = to.Add(-window)
+	}
+
+	byName := make(map[string]*StrategyStanding)
+	standing := func(name string) *StrategyStanding {
+		s, ok := byName[name]
+		if !ok {
+			s = &StrategyStanding{Strategy: name, daily: make(map[string]float64)}
+			byName[name] = s
+		}
+		return s
+	}
+	if o.strategyMgr != nil {
+		for _, st := range o.strategyMgr.GetStrategyStatuses() {
+			standing(st.Name).Enabled = st.Enabled
+		}
+	}
+
+	positions, err := o.dataService.GetClosedPositions(maxLeaderboardPositions)
+	if err != nil {
+		return nil, err
+	}
+	first := to
+	for _, pos := range positions {
+		if pos.ClosedAt == nil || pos.ClosedAt.Before(from) || pos.RealizedPnL == 0 || pos.Strategy == "" {
+			continue
+		}
+		closedAt := *pos.ClosedAt
+		if closedAt.Before(first) {
+			first = closedAt
+		}
+
+		s := standing(pos.Strategy)
+		s.Trades++
+		s.NetPnL += pos.RealizedPnL
+		if pos.RealizedPnL > 0 {
+			s.Wins++
+			s.grossProfit += pos.RealizedPnL
+		} else {
+			s.Losses++
+			s.grossLoss -= pos.RealizedPnL
+		}
+		s.daily[closedAt.UTC().Format("2006-01-02")] += pos.RealizedPnL
+		s.closes = append(s.closes, leaderboardClose{at: closedAt, pnl: pos.RealizedPnL})
+		if s.LastTrade == nil || closedAt.After(*s.LastTrade) {
+			s.LastTrade = &closedAt
+		}
+	}
+	if window == 0 {
+		from = first
+	}
+
+	board := &Leaderboard{
+		Window:     formatLeaderboardWindow(window),
+		From:       from,
+		To:         to,
+		Sort:       sortBy,
+		Strategies: make([]StrategyStanding, 0, len(byName)),
+	}
+	days := leaderboardDays(from, to)
+	for _, s := range byName {
+		if s.Trades > 0 {
+			s.WinRate = float64(s.Wins) / float64(s.Trades)
+			s.Expectancy = s.NetPnL / float64(s.Trades)
+		}
+		if s.Wins > 0 {
+			s.AvgWin = s.grossProfit / float64(s.Wins)
+		}
+		if s.Losses > 0 {
+			s.AvgLoss = s.grossLoss / float64(s.Losses)
+		}
+		if s.grossLoss > 0 {
+			s.ProfitFactor = s.grossProfit / s.grossLoss
+		}
+		s.Sharpe = dailySharpe(s.daily, days)
+		s.MaxDrawdown = realizedDrawdown(s.closes)
+		board.Strategies = append(board.Strategies, *s)
+	}
+
+	sort.Slice(board.Strategies, func(i, j int) bool {
+		a, b := board.Strategies[i], board.Strategies[j]
+		if (a.Trades > 0) != (b.Trades > 0) {
+			return a.Trades > 0
+		}
+		if va, vb := sortBy.value(a), sortBy.value(b); va != vb {
+			if sortBy == SortByMaxDrawdown {
+				return va < vb
+			}
+			return va > vb
+		}
+		if a.NetPnL != b.NetPnL {
+			return a.NetPnL > b.NetPnL
+		}
+		return a.Strategy < b.Strategy
+	})
+	for i := range board.Strategies {
+		board.Strategies[i].Rank = i + 1
+	}
+	return board, nil
+}
+
+// value reads the sort metric from a standing
+func (s LeaderboardSort) value(st StrategyStanding) float64 {
+	switch s {
+	case SortByWinRate:
+		return st.WinRate
+	case SortByExpectancy:
+		return st.Expectancy
+	case SortByMaxDrawdown:
+		return st.MaxDrawdown
+	case SortByTrades:
+		return float64(st.Trades)
+	case SortByNetPnL:
+		return st.NetPnL
+	case SortByProfitFactor:
+		return st.ProfitFactor
+	default:
+		return st.Sharpe
+	}
+}
+
+// leaderboardDays lists the UTC dates from from to to
+func leaderboardDays(from, to time.Time) []string {
+	var days []string
+	end := to.UTC().Format("2006-01-02")
+	for d := from.UTC(); ; d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		days = append(days, day)
+		if day >= end {
+			return days
+		}
+	}
+}
+
+// dailySharpe annualizes the mean over the standard deviation of daily
+// realized P&L, counting days without closes as zero. Crypto trades every
+// day of the year.
+func dailySharpe(daily map[string]float64, days []string) float64 {
+	if len(daily) == 0 || len(days) < 2 {
+		return 0
+	}
+	returns := make([]float64, len(days))
+	var sum float64
+	for i, d := range days {
+		returns[i] = daily[d]
+		sum += returns[i]
+	}
+	sd := stdDev(returns)
+	if sd == 0 {
+		return 0
+	}
+	return sum / float64(len(returns)) / sd * math.Sqrt(365)
+}
+
+// realizedDrawdown returns the largest fall of cumulative realized P&L from
+// a previous peak, starting from zero
+func realizedDrawdown(closes []leaderboardClose) float64 {
+	sort.Slice(closes, func(i, j int) bool { return closes[i].at.Before(closes[j].at) })
+	var cum, peak, maxDD float64
+	for _, c := range closes {
+		cum += c.pnl
+		if cum > peak {
+			peak = cum
+		}
+		if dd := peak - cum; dd > maxDD {
+			maxDD = dd
+		}
+	}
+	return maxDD
+}
+
+// formatLeaderboardWindow renders a window as the handler accepts it
+func formatLeaderboardWindow(window time.Duration) string {
+	switch {
+	case window == 0:
+		return "all"
+	case window%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", window/(24*time.Hour))
+	}
+	return window.String()
+}
@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLiquidityLookback = 168
+	// liquidityTickerMaxAge is how long a 24h ticker is reused
+	liquidityTickerMaxAge = time.Minute
+)
+
+// LiquidityFilter skips entries while the market is abnormally thin, when
+// slippage and stop hunting cost the most. Zero thresholds are unchecked.
+type LiquidityFilter struct {
+	MinQuoteVolume24h   float64 // Minimum rolling 24h volume in the quote asset
+	MinVolumePercentile float64 // Minimum rank (0-100) of the last candle's volume among recent ones
+	Lookback            int     // Primary candles the volume rank is taken over
+}
+
+// LiquidityCheck is the outcome of the liquidity filter for one signal
+type LiquidityCheck struct {
+	Passed              bool    `json:"passed"`
+	QuoteVolume24h      float64 `json:"quoteVolume24h"` // 0 when the ticker was unavailable
+	MinQuoteVolume24h   float64 `json:"minQuoteVolume24h"`
+	CandleVolume        float64 `json:"candleVolume"`
+	VolumePercentile    float64 `json:"volumePercentile"` // Share of recent candles with less volume, 0-100
+	MinVolumePercentile float64 `json:"minVolumePercentile"`
+	Reason              string  `json:"reason,omitempty"`
+}
+
+// liquidityGate holds the filter and the cached 24h ticker
+type liquidityGate struct {
+	mu          sync.Mutex
+	filter      LiquidityFilter
+	quoteVolume float64
+	fetchedAt   time.Time
+}
+
+// SetLiquidityFilter sets the minimum liquidity an entry needs
+func (o *Orchestrator) SetLiquidityFilter(filter LiquidityFilter) {
+	if filter.Lookback <= 0 {
+		filter.Lookback = defaultLiquidityLookback
+	}
+
+	o.liquidity.mu.Lock()
+	defer o.liquidity.mu.Unlock()
+	o.liquidity.filter = filter
+}
+
+// checkLiquidity runs the liquidity filter against the primary candle
+// volumes, oldest first. It returns nil when the filter is off. Data that
+// cannot be fetched does not block the entry.
+func (o *Orchestrator) checkLiquidity(volumes []float64) *LiquidityCheck {
+	o.liquidity.mu.Lock()
+	filter := o.liquidity.filter
+	o.liquidity.mu.Unlock()
+	if filter.MinQuoteVolume24h <= 0 && filter.MinVolumePercentile <= 0 {
+		return nil
+	}
+
+	check := &LiquidityCheck{
+		Passed:              true,
+		MinQuoteVolume24h:   filter.MinQuoteVolume24h,
+		MinVolumePercentile: filter.MinVolumePercentile,
+	}
+
+	if len(volumes) > 0 {
+		check.CandleVolume = volumes[len(volumes)-1]
+		check.VolumePercentile = volumePercentile(volumes, filter.Lookback)
+	}
+	if filter.MinQuoteVolume24h > 0 {
+		check.QuoteVolume24h = o.quoteVolume24h()
+	}
+
+	switch {
+	case check.QuoteVolume24h > 0 && check.QuoteVolume24h < filter.MinQuoteVolume24h:
+		check.Passed = false
+		check.Reason = fmt.Sprintf("24h quote volume %.0f below minimum %.0f",
+			check.QuoteVolume24h, filter.MinQuoteVolume24h)
+	case filter.MinVolumePercentile > 0 && len(volumes) > 1 && check.VolumePercentile < filter.MinVolumePercentile:
+		check.Passed = false
+		check.Reason = fmt.Sprintf("candle volume at the %.0fth percentile of the last %d candles, minimum %.0fth",
+			check.VolumePercentile, min(filter.Lookback, len(volumes)-1), filter.MinVolumePercentile)
+	}
+	return check
+}
+
+// quoteVolume24h returns the rolling 24h quote volume of the traded symbol,
+// fetching the ticker when the cached one is stale, or 0 when unavailable
+func (o *Orchestrator) quoteVolume24h() float64 {
+	o.liquidity.mu.Lock()
+	defer o.liquidity.mu.Unlock()
+
+	if time.Since(o.liquidity.fetchedAt) < liquidityTickerMaxAge {
+		return o.liquidity.quoteVolume
+	}
+	if o.binanceClient == nil {
+		return 0
+	}
+
+	ticker, err := o.binanceClient.GetTicker24hr(o.config.Symbol)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch 24h ticker for the liquidity filter")
+		return 0
+	}
+	volume, err := strconv.ParseFloat(ticker.QuoteVolume, 64)
+	if err != nil {
+		log.Warn().Err(err).Str("quoteVolume", ticker.QuoteVolume).Msg("Invalid 24h quote volume")
+		return 0
+	}
+	o.liquidity.quoteVolume = volume
+	o.liquidity.fetchedAt = time.Now()
+	return volume
+}
+
+// volumePercentile ranks the last volume against up to lookback volumes
+// before it, as the percentage of them that were lower
+func volumePercentile(volumes []float64, lookback int) float64 {
+	last := len(volumes) - 1
+	start := last - lookback
+	if start < 0 {
+		start = 0
+	}
+	if start >= last {
+		return 100
+	}
+
+	lower := 0
+	for _, v := range volumes[start:last] {
+		if v < volumes[last] {
+			lower++
+		}
+	}
+	return 100 * float64(lower) / float64(last-start)
+}
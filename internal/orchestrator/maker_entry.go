@@ -0,0 +1,313 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// makerPollInterval is how often a resting maker entry is checked for fills
+	makerPollInterval = 250 * time.Millisecond
+	// defaultMakerTimeout is how long a maker entry rests by default
+	defaultMakerTimeout = 30 * time.Second
+)
+
+// EntryPolicy decides how signal orders are placed. Maker-first entries
+// rest a post-only limit at the best bid (buys) or ask (sells) to pay the
+// maker fee, sending whatever has not filled at the timeout at market.
+type EntryPolicy struct {
+	MakerFirst bool          // Rest a post-only limit before crossing the spread
+	OffsetBps  float64       // Distance behind the best bid/ask; 0 joins it
+	Timeout    time.Duration // How long the limit rests before the market fallback
+}
+
+// EntryStats counts how maker-first entries were filled
+type EntryStats struct {
+	MakerFilled    int64 `json:"makerFilled"`    // Filled in full as maker
+	MakerPartial   int64 `json:"makerPartial"`   // Partly filled as maker, the rest at market
+	Rejected       int64 `json:"rejected"`       // Priced through the book when placed, sent at market
+	MarketFallback int64 `json:"marketFallback"` // Market orders sent for unfilled quantity
+}
+
+// EntryStatus describes the entry policy and its outcomes
+type EntryStatus struct {
+	MakerFirst bool       `json:"makerFirst"`
+	OffsetBps  float64    `json:"offsetBps"`
+	Timeout    string     `json:"timeout"`
+	Stats      EntryStats `json:"stats"`
+}
+
+// entryPolicy holds the entry policy and its outcome counters
+type entryPolicy struct {
+	mu     sync.Mutex
+	policy EntryPolicy
+	stats  EntryStats
+}
+
+// SetEntryPolicy sets how signal orders are placed
+func (o *Orchestrator) SetEntryPolicy(policy EntryPolicy) {
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaultMakerTimeout
+	}
+
+	o.entry.mu.Lock()
+	defer o.entry.mu.Unlock()
+	o.entry.policy = policy
+}
+
+// GetEntryStatus returns the entry policy and how its entries were filled
+func (o *Orchestrator) GetEntryStatus() EntryStatus {
+	o.entry.mu.Lock()
+	defer o.entry.mu.Unlock()
+
+	return EntryStatus{
+		MakerFirst: o.entry.policy.MakerFirst,
+		OffsetBps:  o.entry.policy.OffsetBps,
+		Timeout:    o.entry.policy.Timeout.String(),
+		Stats:      o.entry.stats,
+	}
+}
+
+// makerEntryPrice returns the post-only price of an entry, and false when
+// it goes at market: maker-first is off or the book cannot be read
+func (o *Orchestrator) makerEntryPrice(symbol string, side execution.OrderSide) (float64, bool) {
+	o.entry.mu.Lock()
+	policy := o.entry.policy
+	o.entry.mu.Unlock()
+	if !policy.MakerFirst {
+		return 0, false
+	}
+
+	bid, ask, err := o.bestBidAsk(symbol)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("No bid/ask for maker entry, sending at market")
+		return 0, false
+	}
+	offset := policy.OffsetBps / 10000
+	if side == execution.OrderSideBuy {
+		return bid * (1 - offset), true
+	}
+	return ask * (1 + offset), true
+}
+
+// bestBidAsk returns the top of the book from the local order book while it
+// is fresh, else from the REST book ticker
+func (o *Orchestrator) bestBidAsk(symbol string) (bid, ask float64, err error) {
+	if o.orderBooks != nil {
+		if quote, ok := o.orderBooks.Quote(symbol); ok {
+			return quote.Bid, quote.Ask, nil
+		}
+	}
+	if o.binanceClient == nil {
+		return 0, 0, fmt.Errorf("no order book or exchange client")
+	}
+
+	ticker, err := o.binanceClient.GetBookTicker(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+	bid, _ = strconv.ParseFloat(ticker.BidPrice, 64)
+	ask, _ = strconv.ParseFloat(ticker.AskPrice, 64)
+	if bid <= 0 || ask <= 0 {
+		return 0, 0, fmt.Errorf("empty book ticker for %s", symbol)
+	}
+	return bid, ask, nil
+}
+
+// executeMakerEntry places a post-only entry and leaves it resting until it
+// fills or times out. One that would take liquidity goes at market at once.
+func (o *Orchestrator) executeMakerEntry(exec execution.Executor, order *execution.Order, signal strategy.Signal, trace *pipelineTrace) (*execution.ExecutionResult, error) {
+	result, intent, err := o.submitOrder(exec, order, signal, trace)
+	if errors.Is(err, execution.ErrWouldTakeLiquidity) {
+		o.countEntry(func(s *EntryStats) { s.Rejected++ })
+		log.Info().
+			Str("strategy", signal.Strategy).
+			Float64("price", order.Price).
+			Msg("Maker entry would take liquidity, sending at market")
+		return o.marketRemainder(exec, order, order.Quantity, signal, trace)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	o.entry.mu.Lock()
+	timeout := o.entry.policy.Timeout
+	o.entry.mu.Unlock()
+
+	// Paper orders fill on price updates delivered by the same goroutine as
+	// closed candles, so the order is watched on its own
+	go o.watchMakerEntry(exec, order, intent, signal, timeout)
+	return result, nil
+}
+
+// watchMakerEntry waits for a maker entry to fill, canceling it at the
+// timeout or as soon as trading halts or pauses, then completes the entry
+func (o *Orchestrator) watchMakerEntry(exec execution.Executor, order *execution.Order, intent *storage.OrderIntent, signal strategy.Signal, timeout time.Duration) {
+	defer o.recoverPanic("makerEntry")
+
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ticker := time.NewTicker(makerPollInterval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for !orderDone(exec, order.ID) {
+		cancel := false
+		select {
+		case <-ctx.Done():
+			// Left resting; the intent is reconciled on restart
+			return
+		case <-ticker.C:
+			if reason := o.makerEntryBlocked(exec); reason != "" {
+				log.Info().Str("orderID", order.ID).Str("reason", reason).Msg("Canceling maker entry")
+				cancel = true
+			}
+		case <-deadline.C:
+			cancel = true
+		}
+		if cancel {
+			err := exec.CancelOrder(order.ID)
+			o.auditOrder(AuditOrderCancel, "", order, err)
+			if err != nil && !orderDone(exec, order.ID) {
+				log.Error().Err(err).Str("orderID", order.ID).Msg("Failed to cancel maker entry, remainder not sent")
+				o.broadcastError("ORDER_FAILED", "Failed to cancel maker entry", err.Error())
+				return
+			}
+		}
+	}
+
+	// Read the final fill under the executor's lock
+	final, err := exec.GetOrder(order.ID)
+	if err != nil {
+		log.Error().Err(err).Str("orderID", order.ID).Msg("Finished maker entry not found, remainder not sent")
+		return
+	}
+	o.completeMakerEntry(exec, final, intent, signal)
+}
+
+// makerEntryBlocked returns why the remainder of a maker entry placed on
+// exec must not be sent, or "" when it may
+func (o *Orchestrator) makerEntryBlocked(exec execution.Executor) string {
+	if o.riskManager != nil && o.riskManager.IsHalted() {
+		return "halted"
+	}
+	o.stateMu.RLock()
+	paused := o.state.IsPaused
+	o.stateMu.RUnlock()
+	if paused {
+		return "paused"
+	}
+	if exec != o.activeExecutor() {
+		return "mode switched"
+	}
+	return ""
+}
+
+// orderDone reports whether an order can no longer fill
+func orderDone(exec execution.Executor, orderID string) bool {
+	order, err := exec.GetOrder(orderID)
+	return err == nil && order.Status.IsTerminal()
+}
+
+// completeMakerEntry records what a finished maker entry filled, sends the
+// rest at market unless trading stopped meanwhile and protects the position.
+// order is a snapshot taken once the maker order could no longer fill.
+func (o *Orchestrator) completeMakerEntry(exec execution.Executor, order *execution.Order, intent *storage.OrderIntent, signal strategy.Signal) {
+	filled := order.FilledQuantity
+	remaining := order.RemainingQuantity()
+
+	var position *execution.Position
+	if filled > 0 {
+		position, _ = exec.GetPosition(order.Symbol)
+		intent.OrderID = order.ID
+		intent.FillPrice = order.AvgFillPrice
+		intent.FilledQuantity = filled
+		if position != nil {
+			intent.PositionID = position.ID
+		}
+		o.advanceIntent(intent, storage.IntentFilled, "")
+
+		// Mirror the maker fill as the market entry it replaced
+		mirrored := *order
+		mirrored.Type = execution.OrderTypeMarket
+		mirrored.Quantity = filled
+		o.mirrorOrder(&mirrored, signal, &execution.ExecutionResult{
+			Success:  true,
+			Order:    order,
+			Trade:    &execution.Trade{Price: order.AvgFillPrice, Quantity: filled, Commission: order.Commission},
+			Position: position,
+		})
+	} else {
+		o.advanceIntent(intent, storage.IntentCanceled, "maker entry unfilled at timeout")
+	}
+
+	log.Info().
+		Str("orderID", order.ID).
+		Str("strategy", signal.Strategy).
+		Float64("filled", filled).
+		Float64("remaining", remaining).
+		Msg("Maker entry finished")
+
+	if remaining <= 0 {
+		o.countEntry(func(s *EntryStats) { s.MakerFilled++ })
+	} else {
+		if filled > 0 {
+			o.countEntry(func(s *EntryStats) { s.MakerPartial++ })
+		}
+		if reason := o.makerEntryBlocked(exec); reason != "" {
+			log.Warn().
+				Str("orderID", order.ID).
+				Float64("remaining", remaining).
+				Str("reason", reason).
+				Msg("Maker entry remainder not sent")
+		} else {
+			result, err := o.marketRemainder(exec, order, remaining, signal, nil)
+			if err == nil && result.Position != nil && intent.State == storage.IntentFilled {
+				// The remainder's protective orders cover the whole position
+				o.advanceIntent(intent, storage.IntentProtected, "protected with the market remainder")
+				return
+			}
+		}
+	}
+
+	if intent.State == storage.IntentFilled && position != nil {
+		o.protectOpened(exec, intent, order.Side, position)
+	}
+}
+
+// marketRemainder sends quantity of a maker entry at market
+func (o *Orchestrator) marketRemainder(exec execution.Executor, maker *execution.Order, quantity float64, signal strategy.Signal, trace *pipelineTrace) (*execution.ExecutionResult, error) {
+	o.countEntry(func(s *EntryStats) { s.MarketFallback++ })
+
+	order := &execution.Order{
+		ClientID: uuid.New().String(),
+		Symbol:   maker.Symbol,
+		Side:     maker.Side,
+		Type:     execution.OrderTypeMarket,
+		Quantity: quantity,
+		Strategy: maker.Strategy,
+		Signal:   maker.Signal,
+	}
+	result, _, err := o.submitOrder(exec, order, signal, trace)
+	return result, err
+}
+
+// countEntry updates the entry outcome counters
+func (o *Orchestrator) countEntry(update func(*EntryStats)) {
+	o.entry.mu.Lock()
+	defer o.entry.mu.Unlock()
+	update(&o.entry.stats)
+}
@@ -0,0 +1,22 @@
+package orchestrator
+
+import (
+	"sync"
+
+	"github.com/eth-trading/internal/storage"
+)
+
+// columnPool recycles the price columns market data is built from on every
+// candle close, so the hot path reuses arrays instead of allocating them
+var columnPool = sync.Pool{
+	New: func() any { return new(storage.CandleColumns) },
+}
+
+// lastColumns fills pooled columns with the last n candles of a timeframe,
+// all of them when n <= 0. release returns the columns to the pool; neither
+// they nor slices of them may be used after it is called.
+func (o *Orchestrator) lastColumns(timeframe string, n int) (cols *storage.CandleColumns, release func()) {
+	cols = columnPool.Get().(*storage.CandleColumns)
+	o.dataService.FillLastColumns(o.config.Symbol, timeframe, n, cols)
+	return cols, func() { columnPool.Put(cols) }
+}
@@ -2,18 +2,22 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/eth-trading/internal/backtest"
 	"github.com/eth-trading/internal/binance"
 	"github.com/eth-trading/internal/execution"
 	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/marketdata"
 	"github.com/eth-trading/internal/risk"
 	"github.com/eth-trading/internal/storage"
 	"github.com/eth-trading/internal/strategy"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,19 +29,175 @@ type Orchestrator struct {
 	binanceClient *binance.Client
 	wsClient      *binance.WSClient
 	dataService   *storage.DataService
-	executor      execution.Executor
+	executor      execution.Executor // Swapped by SwitchMode; read through activeExecutor
+	executorMu    sync.RWMutex
 	riskManager   *risk.Manager
 	strategyMgr   *strategy.Manager
 	indicatorMgr  *indicators.Manager
+	allocator     *risk.CapitalAllocator
+	executors     map[TradingMode]execution.Executor // Executors available for mode switches
+
+	// Account fee schedule used to price backtests
+	feeSchedule   *backtest.FeeSchedule
+
+	// Shadow paper account mirroring live orders
+	shadow        *shadowReconciler
+
+	// Periodic dust conversion (live mode; 0 = disabled)
+	dustInterval  time.Duration
+
+	// Scheduled mode transitions
+	schedule      *ModeSchedule
+	scheduleState scheduleState
+
+	// Live-mode arming sequence
+	arming        armingControl
+
+	// Simulated exchange outage drills (paper mode)
+	chaos         chaosControl
+
+	// Signals waiting for manual approval
+	inbox         tradeInbox
+
+	// Outbound heartbeat to external monitoring
+	heartbeat     heartbeatPinger
+
+	// Precomputed indicator series for research and charting
+	indicatorStore indicatorStore
+
+	// Live fills and position lifecycle written to SQLite
+	journal       tradeJournal
+
+	// Order status transitions written to SQLite
+	orders        orderLog
+
+	// Exchange-side stop loss verification (live mode)
+	stopGuard     stopGuard
+
+	// Entry signal cooldowns per strategy
+	throttle      signalThrottle
+
+	// Entries signaled on volatility spikes, delayed or downsized
+	spikes        volatilityThrottle
+
+	// Entries held for a confirmation candle
+	confirm       entryConfirmer
+
+	// Candidate symbol ranking
+	scan          marketScan
+
+	// Exchange trading rules per symbol, for order forms
+	symbolRules   symbolRulesCache
+
+	// Exchange order history import
+	historyImport historyImport
+
+	// Pipeline stage timings against their latency budgets
+	latency       latencyMonitor
+
+	// Throttled scorer breakdown broadcasts
+	scoreBroadcast scoreBroadcaster
+
+	// Asynchronous backtest jobs
+	backtests     backtestQueue
+
+	// Synchronous sandbox backtests for strategy authors
+	sandbox       sandboxLimiter
+
+	// Minimum market liquidity for entries
+	liquidity     liquidityGate
+
+	// Hot-reloaded scripted strategies
+	scripts       strategyScripts
+
+	// Strategies enabled or disabled through the API
+	strategyStates strategyStates
+
+	// Bounds concurrent strategy evaluations (nil = unbounded)
+	evalPool *strategy.EvalPool
+
+	// Calendar days and weeks the daily and weekly loss limits apply to
+	pnlPeriods pnlPeriods
+
+	// Risk digest of the day in progress, reported at the day boundary
+	riskTally riskTally
+
+	// Binance streams subscribed by the bot and added through the API
+	subscriptions streamSubscriptions
+
+	// Part of the exchange account designated as the bot's capital
+	subBalance subBalance
 
 	// State
 	state         *TradingState
 	stateMu       sync.RWMutex
 
+	// Versions of the state for delta polling
+	stateVersions stateVersions
+
+	// Close time of the last candle per timeframe (guarded by stateMu)
+	candleCloses  map[string]time.Time
+
+	// Serializes candle gap backfills with closed candle inserts
+	backfillMu    sync.Mutex
+
+	// Close times of the last candles the trading logic ran on, kept
+	// across restarts
+	processed     processedCandles
+
+	// Timeframes built from the 1m stream
+	aggregation   candleAggregation
+
+	// Auth trading account the bot trades, stamped on account-scoped broadcasts
+	tradingAccount string
+
+	// Users' own trading accounts, each on an isolated executor
+	accounts userAccounts
+
+	// Optimized strategy parameters and drift from them
+	paramDrift paramDriftMonitor
+
+	// Strategy parameter set version stamped on trades (guarded by stateMu)
+	paramVersion  int64
+
 	// Signal history (recent signals for UI)
 	signals       []SignalRecord
 	signalsMu     sync.RWMutex
 
+	// Turnover and exposure tracking
+	activity      activityTracker
+
+	// On-demand candle history fetching
+	history       candleHistory
+
+	// Trade and kline prices arbitrated into the one the bot acts on
+	prices priceArbiter
+
+	// Rolling trade window for the order-flow tape
+	tape          tradeTape
+
+	// Local order books from the depth stream (nil = not subscribed)
+	orderBooks    *marketdata.Books
+
+	// Higher-timeframe confluence on entries
+	confluence    confluenceLayer
+
+	// Maker-first entry placement
+	entry         entryPolicy
+
+	// Copy trading: approved signals pushed to followers, and signals
+	// received from leaders
+	copyPublisher copyPublisher
+	copyReceiver  copyReceiver
+
+	// Paper account persistence across restarts
+	paperState    paperStatePersister
+
+	// High-water mark persistence
+	hwmSavedAt    time.Time
+	hwmLastWrite  time.Time
+	hwmMu         sync.Mutex
+
 	// Broadcasting
 	broadcaster   *Broadcaster
 	subscribers   map[string]chan BroadcastMessage
@@ -46,6 +206,7 @@ type Orchestrator struct {
 	ctx           context.Context
 	cancel        context.CancelFunc
 	wg            sync.WaitGroup
+	supervisor    *Supervisor
 	startTime     time.Time
 }
 
@@ -63,9 +224,20 @@ func NewOrchestrator(config *OrchestratorConfig) *Orchestrator {
 		subscribers: make(map[string]chan BroadcastMessage),
 		ctx:         ctx,
 		cancel:      cancel,
+		journal:     tradeJournal{events: make(chan journalEntry, tradeJournalBuffer)},
+		orders:      orderLog{events: make(chan execution.OrderUpdate, orderLogBuffer)},
+		backtests: backtestQueue{
+			workers: defaultBacktestWorkers,
+			tasks:   make(chan *backtestTask, defaultBacktestQueueSize),
+		},
+		pnlPeriods: pnlPeriods{loc: time.UTC, weekStart: time.Monday},
 	}
 
 	o.broadcaster = NewBroadcaster(o)
+	o.supervisor = NewSupervisor(ctx, supervisorCheckInterval)
+	o.supervisor.SetOnRestart(func(name, reason string) {
+		o.broadcastError("LOOP_RESTARTED", fmt.Sprintf("Internal loop %s was restarted", name), reason)
+	})
 
 	return o
 }
@@ -92,7 +264,16 @@ func (o *Orchestrator) GetDataService() *storage.DataService {
 
 // SetExecutor sets the executor
 func (o *Orchestrator) SetExecutor(exec execution.Executor) {
+	o.executorMu.Lock()
 	o.executor = exec
+	o.executorMu.Unlock()
+}
+
+// activeExecutor returns the executor orders currently go to
+func (o *Orchestrator) activeExecutor() execution.Executor {
+	o.executorMu.RLock()
+	defer o.executorMu.RUnlock()
+	return o.executor
 }
 
 // SetRiskManager sets the risk manager
@@ -101,6 +282,7 @@ func (o *Orchestrator) SetRiskManager(rm *risk.Manager) {
 
 	// Set up risk event callback
 	rm.SetOnRiskEvent(func(event risk.RiskEvent) {
+		o.riskTally.recordEvent(event)
 		o.broadcastRiskEvent(event)
 	})
 }
@@ -118,6 +300,10 @@ func (o *Orchestrator) GetStrategyManager() *strategy.Manager {
 // SetStrategyManager sets the strategy manager
 func (o *Orchestrator) SetStrategyManager(sm *strategy.Manager) {
 	o.strategyMgr = sm
+	if sm != nil {
+		sm.SetOnStrategyPanic(o.handleStrategyPanic)
+		sm.SetOnStrategyChange(o.handleStrategyChange)
+	}
 }
 
 // SetIndicatorManager sets the indicator manager
@@ -125,6 +311,31 @@ func (o *Orchestrator) SetIndicatorManager(im *indicators.Manager) {
 	o.indicatorMgr = im
 }
 
+// GetSymbol returns the traded symbol
+func (o *Orchestrator) GetSymbol() string {
+	return o.config.Symbol
+}
+
+// IsObserver reports whether the bot only records and broadcasts market
+// data, with strategies, risk checks and orders off
+func (o *Orchestrator) IsObserver() bool {
+	return o.config.Observer
+}
+
+// SetFeeSchedule sets the account's fee schedule
+func (o *Orchestrator) SetFeeSchedule(fs *backtest.FeeSchedule) {
+	o.feeSchedule = fs
+}
+
+// GetFeeSchedule returns the account's fee schedule, or the entry-level
+// schedule when none is configured
+func (o *Orchestrator) GetFeeSchedule() *backtest.FeeSchedule {
+	if o.feeSchedule == nil {
+		return backtest.DefaultFeeSchedule()
+	}
+	return o.feeSchedule
+}
+
 // Start starts the orchestrator
 func (o *Orchestrator) Start() error {
 	log.Info().
@@ -139,7 +350,7 @@ func (o *Orchestrator) Start() error {
 	if o.dataService == nil {
 		return fmt.Errorf("data service not set")
 	}
-	if o.executor == nil {
+	if o.activeExecutor() == nil {
 		return fmt.Errorf("executor not set")
 	}
 	if o.strategyMgr == nil {
@@ -170,22 +381,130 @@ func (o *Orchestrator) Start() error {
 
 	// Start broadcast loop
 	if o.config.EnableWebSocket {
-		o.wg.Add(1)
-		go o.broadcastLoop()
+		o.supervisor.Go("broadcast", maxDuration(10*o.config.BroadcastInterval, 30*time.Second), o.broadcastLoop)
+	}
+
+	// Keep precomputed indicator series current
+	if o.indicatorStore.interval > 0 && len(o.indicatorStore.timeframes) > 0 {
+		o.supervisor.Go("indicatorStore", o.indicatorStore.interval+30*time.Minute, o.indicatorStoreLoop)
+	}
+
+	// Tell external monitoring the pipeline is alive
+	if o.heartbeat.url != "" {
+		o.supervisor.Go("heartbeat", maxDuration(3*o.heartbeat.interval, time.Minute), o.heartbeatLoop)
 	}
 
-	// Initialize risk metrics before starting monitor loop
+	// Run queued backtests
+	o.startBacktestWorkers()
+
+	// Start candle persistence
+	o.supervisor.Go("persistence", maxDuration(6*o.dataService.PersistInterval(), time.Minute), o.persistenceLoop)
+
+	// Start supervising internal loops
+	o.wg.Add(1)
+	go o.superviseLoops()
+
+	// Observer mode records, analyzes and broadcasts market data only
+	if o.config.Observer {
+		log.Info().Msg("Orchestrator started in observer mode, trading is off")
+		return nil
+	}
+	o.startTrading()
+
+	log.Info().Msg("Orchestrator started")
+	return nil
+}
+
+// startTrading restores trading state and starts the loops that trade,
+// monitor risk and record executions
+func (o *Orchestrator) startTrading() {
+	// Restore the paper account and drawdown peak, then initialize risk
+	// metrics before starting monitor loop
+	o.restorePaperState()
+	o.restoreSubBalance()
+	o.restoreHighWaterMark()
+	o.restoreSignalCooldowns()
+	o.restoreStrategyStates()
+	o.restoreParamDrift()
 	o.updateRiskMetrics()
 
 	// Start risk monitoring
-	o.wg.Add(1)
-	go o.riskMonitorLoop()
+	o.supervisor.Go("riskMonitor", 30*time.Second, o.riskMonitorLoop)
+
+	// Allocate capital between strategies
+	if o.allocator != nil {
+		o.syncAllocatorStrategies()
+		if _, err := o.RebalanceCapital("initial"); err != nil {
+			log.Warn().Err(err).Msg("Initial capital allocation failed")
+		}
+		o.supervisor.Go("allocation", 5*time.Minute, o.allocationLoop)
+	}
+
+	// Apply the mode schedule now, then follow it
+	if o.schedule != nil {
+		o.applySchedule(time.Now())
+		o.supervisor.Go("schedule", 4*scheduleCheckInterval, o.scheduleLoop)
+	}
+
+	// Convert residual balances periodically
+	if o.dustInterval > 0 {
+		o.supervisor.Go("dust", o.dustInterval+5*time.Minute, o.dustLoop)
+	}
+
+	// Run scheduled outage drills
+	if o.chaosEnabled() && o.chaos.interval > 0 {
+		o.supervisor.Go("chaos", o.chaos.interval+time.Minute, o.chaosLoop)
+	}
+
+	// Expire trade ideas nobody confirmed
+	if o.InboxEnabled() {
+		o.supervisor.Go("inbox", 4*inboxCheckInterval, o.inboxLoop)
+	}
+
+	// Re-run recorded parameter searches on recent data
+	if o.reoptimizing() {
+		o.supervisor.Go("paramDrift", o.paramDrift.config.ReoptimizeInterval+time.Hour, o.paramDriftLoop)
+	}
+
+	// Pick up edited strategy scripts
+	if o.scripts.loader != nil {
+		o.supervisor.Go("strategyScripts", 4*o.scripts.interval+time.Minute, o.strategyScriptsLoop)
+	}
+
+	// Persist live trades and positions; runs in paper mode too since a
+	// scheduled switch can bring the live executor in later
+	o.supervisor.Go("tradeJournal", 2*time.Minute, o.tradeJournalLoop)
+
+	// Roll daily and weekly P&L over at calendar boundaries
+	o.supervisor.Go("pnlRollover", 4*pnlRolloverInterval, o.pnlRolloverLoop)
+
+	// Persist order lifecycle transitions
+	o.supervisor.Go("orderLog", 2*time.Minute, o.orderLogLoop)
+
+	// Re-place protective stops missing on the exchange
+	if o.stopGuard.interval > 0 {
+		o.supervisor.Go("stopGuard", o.stopGuard.interval+2*time.Minute, o.stopGuardLoop)
+	}
+
+	// Snapshot the paper account
+	if o.paperState.enabled {
+		o.supervisor.Go("paperState", 3*paperStateInterval, o.paperStateLoop)
+	}
+
+	// Push approved signals to copy-trading followers
+	if o.copyPublisher.queue != nil {
+		o.restoreFollowers()
+		o.supervisor.Go("copyPublisher", 3*time.Minute, o.copyPublisherLoop)
+	}
+
+	// Stamp trades with the parameter version from the settings history
+	o.loadParamVersion()
 
 	// Set up executor callbacks
 	o.setupExecutorCallbacks()
 
-	log.Info().Msg("Orchestrator started")
-	return nil
+	// Complete or reconcile executions interrupted by the last shutdown
+	o.replayOrderIntents()
 }
 
 // Stop stops the orchestrator
@@ -197,8 +516,15 @@ func (o *Orchestrator) Stop() {
 	o.stateMu.Unlock()
 
 	o.cancel()
+	o.supervisor.Wait(10 * time.Second)
 	o.wg.Wait()
 
+	// Observer mode never restored the trading state, so must not save it
+	if !o.config.Observer {
+		o.persistHighWaterMark(true)
+		o.persistPaperState()
+	}
+
 	if o.wsClient != nil {
 		o.wsClient.Disconnect()
 	}
@@ -208,7 +534,12 @@ func (o *Orchestrator) Stop() {
 
 // loadHistoricalData loads historical klines
 func (o *Orchestrator) loadHistoricalData() error {
-	for _, tf := range o.config.Timeframes {
+	for _, tf := range o.candleTimeframes() {
+		if !binance.IsNativeInterval(tf) {
+			o.loadAggregatedHistory(tf)
+			continue
+		}
+
 		// Fetch last 500 candles for each timeframe
 		klines, err := o.binanceClient.GetKlines(o.config.Symbol, tf, 500, 0, 0)
 		if err != nil {
@@ -216,10 +547,19 @@ func (o *Orchestrator) loadHistoricalData() error {
 			continue
 		}
 
-		// Store in data service
+		// Store in data service, skipping the still-forming bar so
+		// strategies never read Closes[len-1] of an unclosed candle
+		now := time.Now()
 		for _, k := range klines {
 			candle := convertKlineToCandle(k, o.config.Symbol, tf)
+			if candle.CloseTime.After(now) {
+				continue
+			}
 			o.dataService.AddCandle(*candle)
+
+			o.stateMu.Lock()
+			o.recordCandleCloseLocked(tf, candle.CloseTime)
+			o.stateMu.Unlock()
 		}
 
 		log.Debug().
@@ -236,49 +576,25 @@ func (o *Orchestrator) startWebSocketSubscription() {
 	// Subscribe to kline streams for each timeframe (must use lowercase symbol)
 	symbol := strings.ToLower(o.config.Symbol)
 	var streams []string
-	for _, tf := range o.config.Timeframes {
+	for _, tf := range o.streamedTimeframes() {
 		stream := fmt.Sprintf("%s@kline_%s", symbol, tf)
 		streams = append(streams, stream)
 	}
 	// Add trade stream for real-time price updates (millisecond latency)
 	streams = append(streams, fmt.Sprintf("%s@trade", symbol))
-	o.wsClient.Subscribe(streams...)
+	// Diff depth stream for the local order book
+	if o.orderBooks != nil {
+		streams = append(streams, fmt.Sprintf("%s@depth@100ms", symbol))
+	}
+	o.subscribeCoreStreams(streams)
 
-	// Connect the WebSocket
+	// Connect the WebSocket; the monitor retries if this fails
 	if err := o.wsClient.Connect(o.ctx); err != nil {
-		log.Warn().Err(err).Msg("Binance WebSocket connection failed, using REST API polling")
-		// Start polling fallback only if WebSocket fails
-		o.wg.Add(1)
-		go o.pollPriceFallback()
+		log.Warn().Err(err).Msg("Binance WebSocket connection failed, will retry")
 	} else {
 		log.Info().Msg("Binance WebSocket connected - real-time data active")
-		// Start WebSocket message handler
-		o.wg.Add(1)
-		go o.handleBinanceWebSocket()
-	}
-}
-
-// handleBinanceWebSocket handles real-time WebSocket messages from Binance
-func (o *Orchestrator) handleBinanceWebSocket() {
-	defer o.wg.Done()
-
-	log.Info().Msg("Started Binance WebSocket handler - real-time data active")
-
-	// The wsClient is already connected, handler receives events
-	// Just monitor connection status here
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
-		case <-ticker.C:
-			if !o.wsClient.IsConnected() {
-				log.Warn().Msg("Binance WebSocket disconnected, will auto-reconnect")
-			}
-		}
 	}
+	o.supervisor.Go("marketData", time.Minute, o.streamMonitorLoop)
 }
 
 // OnKline handles kline events from Binance WebSocket
@@ -286,6 +602,7 @@ func (h *BinanceWSHandler) OnKline(event binance.KlineEvent) {
 	if h.orchestrator == nil {
 		return
 	}
+	defer h.orchestrator.recoverPanic("ws.kline")
 	h.orchestrator.processKlineUpdate(&event)
 }
 
@@ -294,22 +611,21 @@ func (h *BinanceWSHandler) OnTrade(event binance.TradeEvent) {
 	if h.orchestrator == nil {
 		return
 	}
+	defer h.orchestrator.recoverPanic("ws.trade")
 
 	price, err := strconv.ParseFloat(event.Price, 64)
 	if err != nil {
 		return
 	}
 
-	now := time.Now()
-	h.orchestrator.stateMu.Lock()
-	h.orchestrator.state.CurrentPrice = price
-	h.orchestrator.state.LastUpdate = now
-	h.orchestrator.stateMu.Unlock()
+	h.orchestrator.tape.record(event, price)
 
-	// Update executor price cache (for paper trading)
-	if paperExec, ok := h.orchestrator.executor.(*execution.PaperExecutor); ok {
-		paperExec.UpdatePrice(event.Symbol, price)
+	// Trades set the price, and the paper accounts' prices, unless out of
+	// order. Stamped with the exchange trade time so event-clock sessions replay.
+	if !h.orchestrator.offerPrice(PriceSourceTrade, event.Symbol, price, time.UnixMilli(event.TradeTime), event.TradeID) {
+		return
 	}
+	now := time.Now()
 
 	// Broadcast price immediately for real-time updates
 	h.orchestrator.broadcast(BroadcastMessage{
@@ -323,8 +639,14 @@ func (h *BinanceWSHandler) OnTrade(event binance.TradeEvent) {
 	})
 }
 
-// OnDepth handles depth events (not used for now)
-func (h *BinanceWSHandler) OnDepth(event binance.DepthEvent) {}
+// OnDepth handles diff depth events, keeping the local order book current
+func (h *BinanceWSHandler) OnDepth(event binance.DepthEvent) {
+	if h.orchestrator == nil || h.orchestrator.orderBooks == nil {
+		return
+	}
+	defer h.orchestrator.recoverPanic("ws.depth")
+	h.orchestrator.orderBooks.OnDepth(event)
+}
 
 // OnMiniTicker handles mini ticker events (not used for now)
 func (h *BinanceWSHandler) OnMiniTicker(event binance.MiniTickerEvent) {}
@@ -342,6 +664,14 @@ func (h *BinanceWSHandler) OnDisconnect() {
 // OnReconnect handles WebSocket reconnection
 func (h *BinanceWSHandler) OnReconnect() {
 	log.Info().Msg("Binance WebSocket reconnected")
+	if h.orchestrator == nil {
+		return
+	}
+	defer h.orchestrator.recoverPanic("ws.reconnect")
+
+	// Runs before the stream is read again, so trading resumes on a
+	// complete series
+	h.orchestrator.resyncStream()
 }
 
 // CreateWSHandler creates a WebSocket handler for this orchestrator
@@ -349,142 +679,6 @@ func (o *Orchestrator) CreateWSHandler() *BinanceWSHandler {
 	return NewBinanceWSHandler(o)
 }
 
-// pollPriceFallback polls price using REST API as a fallback
-func (o *Orchestrator) pollPriceFallback() {
-	defer o.wg.Done()
-
-	log.Info().Msg("Started REST API price polling (fallback mode)")
-
-	priceTicker := time.NewTicker(2 * time.Second) // Poll price every 2s
-	klineTicker := time.NewTicker(15 * time.Second) // Poll klines every 15s and run trading logic
-	defer priceTicker.Stop()
-	defer klineTicker.Stop()
-
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
-		case <-priceTicker.C:
-			if o.binanceClient != nil {
-				tickerPrice, err := o.binanceClient.GetTickerPrice(o.config.Symbol)
-				if err != nil {
-					log.Debug().Err(err).Msg("Failed to fetch price")
-					continue
-				}
-
-				price, err := strconv.ParseFloat(tickerPrice.Price, 64)
-				if err != nil {
-					log.Debug().Err(err).Msg("Failed to parse price")
-					continue
-				}
-
-				o.stateMu.Lock()
-				o.state.CurrentPrice = price
-				o.state.LastUpdate = time.Now()
-				o.stateMu.Unlock()
-
-				// Broadcast price update
-				o.broadcast(BroadcastMessage{
-					Type:      MessageTypePrice,
-					Timestamp: time.Now(),
-					Data: PriceUpdate{
-						Symbol:    o.config.Symbol,
-						Price:     price,
-						Timestamp: time.Now(),
-					},
-				})
-			}
-		case <-klineTicker.C:
-			// Fetch latest klines and run trading logic
-			o.pollKlinesAndTrade()
-		}
-	}
-}
-
-// pollKlinesAndTrade fetches latest klines and runs trading logic
-func (o *Orchestrator) pollKlinesAndTrade() {
-	if o.binanceClient == nil || o.dataService == nil {
-		return
-	}
-
-	// Fetch last 10 klines for primary timeframe
-	klines, err := o.binanceClient.GetKlines(o.config.Symbol, o.config.PrimaryTimeframe, 10, 0, 0)
-	if err != nil {
-		log.Debug().Err(err).Msg("Failed to fetch klines for trading")
-		return
-	}
-
-	if len(klines) == 0 {
-		return
-	}
-
-	// Get the most recent closed candle
-	var latestClosed *binance.Kline
-	now := time.Now().UnixMilli()
-	for i := len(klines) - 1; i >= 0; i-- {
-		if klines[i].CloseTime < now {
-			latestClosed = &klines[i]
-			break
-		}
-	}
-
-	if latestClosed == nil {
-		return
-	}
-
-	// Convert and store
-	candle := convertKlineToCandle(*latestClosed, o.config.Symbol, o.config.PrimaryTimeframe)
-	candle.IsClosed = true
-
-	// Check if this candle is new (not already processed)
-	existingCandles := o.dataService.GetLastCandles(o.config.Symbol, o.config.PrimaryTimeframe, 1)
-	if len(existingCandles) > 0 {
-		lastTime := existingCandles[0].CloseTime
-		if !candle.CloseTime.After(lastTime) {
-			// Already processed, but still run trading logic periodically
-			o.processTradingLogic()
-			return
-		}
-	}
-
-	// Add new candle
-	o.dataService.AddCandle(*candle)
-
-	// Update state
-	o.stateMu.Lock()
-	o.state.CandleCount++
-	o.state.LastCandleTime = candle.CloseTime
-	closePrice := candle.Close
-	o.state.CurrentPrice = closePrice
-	o.stateMu.Unlock()
-
-	// Broadcast candle
-	o.broadcast(BroadcastMessage{
-		Type:      MessageTypeCandle,
-		Timestamp: time.Now(),
-		Data: CandleUpdate{
-			Symbol:    candle.Symbol,
-			Timeframe: candle.Timeframe,
-			Timestamp: candle.OpenTime,
-			Open:      candle.Open,
-			High:      candle.High,
-			Low:       candle.Low,
-			Close:     candle.Close,
-			Volume:    candle.Volume,
-			IsClosed:  true,
-		},
-	})
-
-	log.Debug().
-		Str("timeframe", o.config.PrimaryTimeframe).
-		Float64("close", closePrice).
-		Time("time", candle.CloseTime).
-		Msg("Processed new kline via REST polling")
-
-	// Run trading logic
-	o.processTradingLogic()
-}
-
 // handleWebSocketMessage handles incoming WebSocket messages
 func (o *Orchestrator) handleWebSocketMessage(data []byte) {
 	// Parse kline event
@@ -498,6 +692,7 @@ func (o *Orchestrator) handleWebSocketMessage(data []byte) {
 
 // processKlineUpdate processes a kline update
 func (o *Orchestrator) processKlineUpdate(event *binance.KlineEvent) {
+	receivedAt := time.Now()
 	kd := event.Kline
 
 	// Convert to storage candle
@@ -519,9 +714,26 @@ func (o *Orchestrator) processKlineUpdate(event *binance.KlineEvent) {
 		Volume:    volume,
 	}
 
-	// Update current price
+	// Build the aggregated timeframes from the 1m stream, before the minute
+	// itself so the bars it closes are stored when a 1m primary trades
+	if kd.Interval == binance.Interval1m && o.aggregation.enabled {
+		o.aggregateMinute(*candle, kd.IsClosed, receivedAt)
+	}
+
+	// The close stands in for the price only while trades are quiet
+	eventTime := receivedAt
+	if event.EventTime > 0 {
+		eventTime = time.UnixMilli(event.EventTime)
+	}
+	o.offerPrice(PriceSourceKline, kd.Symbol, closePrice, eventTime, 0)
+
+	o.processCandleUpdate(candle, kd.IsClosed, receivedAt)
+}
+
+// processCandleUpdate broadcasts a streamed or aggregated candle and, once
+// it closes, stores it and runs the trading logic on the primary timeframe
+func (o *Orchestrator) processCandleUpdate(candle *storage.Candle, isClosed bool, receivedAt time.Time) {
 	o.stateMu.Lock()
-	o.state.CurrentPrice = closePrice
 	o.state.LastUpdate = time.Now()
 	o.stateMu.Unlock()
 
@@ -538,68 +750,145 @@ func (o *Orchestrator) processKlineUpdate(event *binance.KlineEvent) {
 			Low:       candle.Low,
 			Close:     candle.Close,
 			Volume:    candle.Volume,
-			IsClosed:  kd.IsClosed,
+			IsClosed:  isClosed,
+			Session:   string(strategy.SessionAt(candle.OpenTime)),
 		},
 	})
 
 	// If candle is closed
-	if kd.IsClosed {
+	if isClosed {
 		candle.IsClosed = true
-		// Add to data service
+
+		// Fill any candles missed since the last one, e.g. dropped
+		// messages, before adding this one
+		o.backfillMu.Lock()
+		_, gapErr := o.backfillTimeframeLocked(candle.Timeframe, candle.OpenTime)
 		o.dataService.AddCandle(*candle)
+		o.backfillMu.Unlock()
 
 		// Update state
 		o.stateMu.Lock()
 		o.state.CandleCount++
 		o.state.LastCandleTime = candle.CloseTime
+		o.recordCandleCloseLocked(candle.Timeframe, candle.CloseTime)
 		o.stateMu.Unlock()
 
-		// Process trading logic on primary timeframe
-		if kd.Interval == o.config.PrimaryTimeframe {
-			o.processTradingLogic()
+		// Settle entries held for a candle of a shorter confirmation
+		// timeframe
+		if candle.Timeframe != o.config.PrimaryTimeframe && candle.Timeframe == o.confirmationInterval() && gapErr == nil &&
+			o.claimCandle(candle.Timeframe, candle.CloseTime) {
+			o.processConfirmation(candle.CloseTime)
+		}
+
+		// Process trading logic on primary timeframe, unless the series
+		// has a hole indicators would be computed across
+		if candle.Timeframe == o.config.PrimaryTimeframe {
+			if gapErr != nil {
+				log.Warn().Err(gapErr).Str("timeframe", candle.Timeframe).Msg("Skipping trading logic, candle gap not backfilled")
+				return
+			}
+			if !o.claimCandle(candle.Timeframe, candle.CloseTime) {
+				return
+			}
+			o.processTradingLogic(newPipelineTrace(candle.Timeframe, candle.CloseTime, receivedAt))
 		}
 	}
 }
 
-// processTradingLogic runs the main trading logic
-func (o *Orchestrator) processTradingLogic() {
+// processTradingLogic runs the main trading logic for a closed candle,
+// timing each stage on trace
+func (o *Orchestrator) processTradingLogic(trace *pipelineTrace) {
+	defer o.recoverPanic("tradingLogic")
+
 	// Get market data
-	marketData := o.buildMarketData()
+	marketData, release := o.buildMarketData()
+	defer release()
 	if marketData == nil {
 		return
 	}
 
+	// Observer mode stops at the indicators
+	if o.config.Observer {
+		return
+	}
+
 	// Check if trading is halted
 	if o.riskManager != nil && o.riskManager.IsHalted() {
 		return
 	}
 
+	// Skip new entries while paused (manually or by the mode schedule)
+	o.stateMu.RLock()
+	paused := o.state.IsPaused
+	o.stateMu.RUnlock()
+	if paused {
+		return
+	}
+
 	// Run analysis through strategy manager
 	if o.strategyMgr == nil {
 		return
 	}
 
-	opens, highs, lows, closes, volumes := o.dataService.GetOHLCV(o.config.Symbol, o.config.PrimaryTimeframe)
+	cols, releaseCols := o.lastColumns(o.config.PrimaryTimeframe, 0)
+	defer releaseCols()
+	opens, highs, lows, closes, volumes := cols.Opens, cols.Highs, cols.Lows, cols.Closes, cols.Volumes
 	if len(closes) < 50 {
 		return
 	}
 
+	// Lookahead guard: the last bar handed to strategies must be closed
+	if last, ok := o.dataService.GetLatestCandle(o.config.Symbol, o.config.PrimaryTimeframe); ok && last.CloseTime.After(time.Now()) {
+		log.Error().
+			Time("closeTime", last.CloseTime).
+			Msg("Lookahead guard: latest candle is not closed, skipping analysis")
+		o.broadcastError("LOOKAHEAD_GUARD", "Latest candle is not closed, analysis skipped", last.CloseTime.Format(time.RFC3339))
+		return
+	}
+
 	currentPrice := closes[len(closes)-1]
-	analysis := o.strategyMgr.Analyze(o.config.Symbol, o.config.PrimaryTimeframe, opens, highs, lows, closes, volumes, currentPrice)
+	sessionBars := indicators.SessionBars(cols.Latest.OpenTime, binance.IntervalToDuration(o.config.PrimaryTimeframe))
+	analysis := o.strategyMgr.AnalyzeWithContext(o.config.Symbol, o.config.PrimaryTimeframe, opens, highs, lows, closes, volumes, currentPrice, marketData.HigherTimeframes, sessionBars)
 	if analysis == nil {
 		return
 	}
+	o.markStage(trace, StageCandleToAnalysis)
+	o.broadcastScore(analysis)
 
 	// Update regime in state
 	o.stateMu.Lock()
 	o.state.CurrentRegime = analysis.Regime.Regime.String()
 	o.stateMu.Unlock()
 
+	// Entries held back from the last candle's volatility spike go ahead
+	// or are dropped on this one
+	last := len(closes) - 1
+	atr := marketData.Analysis.ATR.ATR
+	released := o.releaseDelayedEntries(highs[last], lows[last], closes[last], atr, marketData.Timestamp)
+	for _, signal := range released {
+		o.assessSignal(signal, marketData, volumes, analysis, nil)
+	}
+
+	// So do entries held for confirmation on the primary timeframe
+	if o.confirmationInterval() == o.config.PrimaryTimeframe {
+		confirmed := o.confirmEntries(closes[last], marketData.Analysis, marketData.Timestamp)
+		for _, signal := range confirmed {
+			o.assessSignal(signal, marketData, volumes, analysis, nil)
+		}
+		released = append(released, confirmed...)
+	}
+
 	// Check if we have a trade recommendation
 	rec := analysis.Recommendation
 	if rec.Action == strategy.ActionNone {
 		return
 	}
+	// A released entry stands in for its strategy's signal on this candle
+	for _, signal := range released {
+		if signal.Strategy == rec.Strategy {
+			return
+		}
+	}
 
 	// Create signal from recommendation
 	bestSignal := strategy.Signal{
@@ -615,104 +904,194 @@ func (o *Orchestrator) processTradingLogic() {
 		Timeframe:  o.config.PrimaryTimeframe,
 	}
 
+	// Drop repeat entries from a strategy within its cooldown
+	if o.throttleSignal(rec.Strategy, marketData.Timestamp) {
+		return
+	}
+
+	// Hold back or downsize entries signaled on a volatility spike
+	if o.throttleVolatility(&bestSignal, highs[last], lows[last], closes[last], atr, marketData.Timestamp) {
+		return
+	}
+
+	// Wait a candle of the confirmation timeframe before entering
+	if o.holdForConfirmation(bestSignal, marketData.Timestamp) {
+		return
+	}
+
 	log.Info().
+		Str("correlationId", trace.ID).
 		Str("direction", rec.Direction.String()).
 		Str("strategy", rec.Strategy).
 		Float64("price", rec.Price).
 		Float64("confidence", rec.Confidence).
 		Msg("Signal generated")
 
+	o.assessSignal(bestSignal, marketData, volumes, analysis, trace)
+}
+
+// assessSignal runs an entry signal through the stop-loss policy, liquidity
+// filter and risk manager, records it, and executes it when approved (or
+// queues it in semi-automatic mode). analysis is nil for signals from
+// outside the strategy manager. It returns whether the signal was approved
+// and why not.
+func (o *Orchestrator) assessSignal(signal strategy.Signal, marketData *strategy.MarketData, volumes []float64, analysis *strategy.AnalysisOutput, trace *pipelineTrace) (bool, string) {
+	// Stop-loss policy: reject the entry or derive a stop when the signal has none
+	var stopLoss *risk.StopLossEnforcement
+	if o.riskManager != nil {
+		enforcement := o.riskManager.EnforceStopLoss(signal.Strategy, signal.Direction.String(),
+			signal.Price, signal.StopLoss, marketData.Analysis.ATR.ATR)
+		if enforcement.Action != "none" {
+			stopLoss = &enforcement
+			signal.StopLoss = enforcement.StopLoss
+			log.Info().
+				Str("strategy", signal.Strategy).
+				Str("policy", string(enforcement.Policy)).
+				Str("action", enforcement.Action).
+				Float64("stopLoss", enforcement.StopLoss).
+				Msg("Stop-loss policy applied")
+		}
+	}
+
+	// Sizing models read the ATR at entry
+	if signal.Indicators.ATR == 0 {
+		signal.Indicators.ATR = marketData.Analysis.ATR.ATR
+	}
+
+	// Skip entries into an abnormally thin market
+	liquidity := o.checkLiquidity(volumes)
+
+	// Weigh the entry against the higher-timeframe trend
+	confluence := o.checkConfluence(&signal, marketData)
+
 	// Risk assessment
 	var approved bool
 	var rejectReason string
-	if o.riskManager != nil {
-		assessment := o.riskManager.AssessTrade(risk.TradeParams{
-			Symbol:     bestSignal.Symbol,
-			Direction:  bestSignal.Direction.String(),
-			EntryPrice: bestSignal.Price,
-			StopLoss:   bestSignal.StopLoss,
-			TakeProfit: bestSignal.TakeProfit,
+	rejectedBy := "RiskManager"
+	var assessment risk.RiskAssessment
+	if liquidity != nil && !liquidity.Passed {
+		rejectReason = liquidity.Reason
+		rejectedBy = "LiquidityFilter"
+		log.Warn().
+			Str("strategy", signal.Strategy).
+			Str("reason", rejectReason).
+			Msg("Signal rejected by liquidity filter")
+	} else if confluence != nil && !confluence.Passed {
+		rejectReason = confluence.Reason
+		rejectedBy = "Confluence"
+		log.Warn().
+			Str("strategy", signal.Strategy).
+			Str("reason", rejectReason).
+			Msg("Signal rejected by higher-timeframe confluence")
+	} else if stopLoss != nil && stopLoss.Rejected() {
+		rejectReason = stopLoss.Detail
+		log.Warn().
+			Str("strategy", signal.Strategy).
+			Str("reason", rejectReason).
+			Msg("Signal rejected by stop-loss policy")
+	} else if o.riskManager != nil {
+		assessment = o.riskManager.AssessTrade(risk.TradeParams{
+			Symbol:     signal.Symbol,
+			Direction:  signal.Direction.String(),
+			EntryPrice: signal.Price,
+			StopLoss:   signal.StopLoss,
+			TakeProfit: signal.TakeProfit,
+			ATR:        signal.Indicators.ATR,
+			Strategy:   signal.Strategy,
+			Stats:      o.sizingStats(signal.Strategy),
 		})
 		approved = assessment.Approved
 		if !approved && len(assessment.Reasons) > 0 {
 			rejectReason = assessment.Reasons[0]
 			log.Warn().
-				Str("strategy", rec.Strategy).
+				Str("strategy", signal.Strategy).
 				Str("reason", rejectReason).
 				Msg("Signal rejected by risk manager")
 		} else {
 			log.Debug().
-				Str("strategy", rec.Strategy).
+				Str("strategy", signal.Strategy).
 				Bool("approved", approved).
+				Str("sizingModel", string(assessment.SizingModel)).
+				Str("sizing", assessment.SizingDetail).
 				Msg("Signal approved by risk manager")
 		}
 	} else {
 		approved = true
 	}
+	o.markStage(trace, StageAnalysisToRisk)
+	o.riskTally.recordSignal(approved, rejectedBy, rejectReason, assessment.Warnings)
 
 	// Broadcast signal
 	o.broadcast(BroadcastMessage{
 		Type:      MessageTypeSignal,
 		Timestamp: time.Now(),
 		Data: SignalUpdate{
-			Signal:     &bestSignal,
+			Signal:     &signal,
 			Approved:   approved,
-			RejectedBy: "RiskManager",
+			RejectedBy: rejectedBy,
 			Reason:     rejectReason,
+			Liquidity:  liquidity,
+			Confluence: confluence,
 		},
 	})
 
 	o.stateMu.Lock()
-	o.state.LastSignal = &bestSignal
+	o.state.LastSignal = &signal
 	o.stateMu.Unlock()
 
 	// Store signal in history
-	o.addSignal(&bestSignal, approved, rejectReason)
+	o.addSignal(&signal, approved, rejectReason, stopLoss, liquidity, confluence)
 
-	// Execute if approved
-	if approved {
-		o.executeSignal(bestSignal)
+	// Users' accounts take entries the market filters passed, vetted by
+	// their own risk managers rather than the bot's
+	if rejectedBy == "RiskManager" && (stopLoss == nil || !stopLoss.Rejected()) {
+		o.tradeAccounts(signal)
 	}
-}
 
-// buildMarketData builds market data for strategies
-func (o *Orchestrator) buildMarketData() *strategy.MarketData {
-	// Get recent candles from data service
-	candles := o.dataService.GetLastCandles(o.config.Symbol, o.config.PrimaryTimeframe, 200)
-	if len(candles) < 50 {
-		return nil
+	// Execute if approved, or queue for confirmation in semi-automatic mode
+	if approved && o.InboxEnabled() {
+		o.queueIdea(signal, analysis, assessment)
+	} else if approved {
+		o.publishSignal(signal)
+		if _, err := o.executeSignal(signal, trace); err == nil {
+			o.riskTally.recordExecuted()
+		}
 	}
+	return approved, rejectReason
+}
 
-	// Build price arrays
-	opens := make([]float64, len(candles))
-	highs := make([]float64, len(candles))
-	lows := make([]float64, len(candles))
-	closes := make([]float64, len(candles))
-	volumes := make([]float64, len(candles))
-
-	for i, c := range candles {
-		opens[i] = c.Open
-		highs[i] = c.High
-		lows[i] = c.Low
-		closes[i] = c.Close
-		volumes[i] = c.Volume
+// buildMarketData builds market data for strategies on pooled price
+// columns. Callers must call release once done with the data, also when
+// none is returned.
+func (o *Orchestrator) buildMarketData() (data *strategy.MarketData, release func()) {
+	// Get recent candles from data service
+	cols, release := o.lastColumns(o.config.PrimaryTimeframe, 200)
+	if cols.Len() < 50 {
+		return nil, release
 	}
 
-	lastCandle := candles[len(candles)-1]
+	opens, highs, lows, closes, volumes := cols.Opens, cols.Highs, cols.Lows, cols.Closes, cols.Volumes
+	lastCandle := cols.Latest
 
 	// Calculate indicators
 	var analysisResult indicators.AnalysisResult
 	if o.indicatorMgr != nil {
-		analysisResult = o.indicatorMgr.Analyze(opens, highs, lows, closes, volumes)
+		var ok bool
+		sessionBars := indicators.SessionBars(lastCandle.OpenTime, binance.IntervalToDuration(o.config.PrimaryTimeframe))
+		analysisResult, ok = o.analyzeIndicators(opens, highs, lows, closes, volumes, sessionBars)
+		if !ok {
+			return nil, release
+		}
 
 		// Broadcast indicators
 		o.broadcastIndicators(&analysisResult, lastCandle.CloseTime)
 	}
 
-	return &strategy.MarketData{
+	data = &strategy.MarketData{
 		Symbol:       o.config.Symbol,
 		Timeframe:    o.config.PrimaryTimeframe,
 		Timestamp:    lastCandle.CloseTime,
+		Session:      strategy.SessionAt(lastCandle.CloseTime),
 		Opens:        opens,
 		Highs:        highs,
 		Lows:         lows,
@@ -720,11 +1099,67 @@ func (o *Orchestrator) buildMarketData() *strategy.MarketData {
 		Volumes:      volumes,
 		CurrentPrice: lastCandle.Close,
 		Analysis:     analysisResult,
+		HigherTimeframes: o.buildHigherTimeframes(lastCandle.CloseTime),
+	}
+	if o.orderBooks != nil {
+		if quote, ok := o.orderBooks.Quote(o.config.Symbol); ok {
+			data.Bid = quote.Bid
+			data.Ask = quote.Ask
+		}
+	}
+	return data, release
+}
+
+// buildHigherTimeframes builds context for monitored timeframes longer than
+// the primary one, using only bars closed by asOf (matching the backtest engine)
+func (o *Orchestrator) buildHigherTimeframes(asOf time.Time) map[string]*strategy.TimeframeData {
+	if o.indicatorMgr == nil {
+		return nil
+	}
+
+	primary := binance.IntervalToDuration(o.config.PrimaryTimeframe)
+	result := make(map[string]*strategy.TimeframeData)
+
+	for _, tf := range o.config.Timeframes {
+		if tf == o.config.PrimaryTimeframe || binance.IntervalToDuration(tf) <= primary {
+			continue
+		}
+
+		candles := o.dataService.GetLastCandles(o.config.Symbol, tf, 500)
+		data := &strategy.TimeframeData{Timeframe: tf}
+		var lastOpen time.Time
+		for _, c := range candles {
+			if c.CloseTime.After(asOf) {
+				continue
+			}
+			lastOpen = c.OpenTime
+			data.Opens = append(data.Opens, c.Open)
+			data.Highs = append(data.Highs, c.High)
+			data.Lows = append(data.Lows, c.Low)
+			data.Closes = append(data.Closes, c.Close)
+			data.Volumes = append(data.Volumes, c.Volume)
+			data.LastClose = c.CloseTime
+		}
+		if len(data.Closes) == 0 {
+			continue
+		}
+
+		analysis, ok := o.analyzeIndicators(data.Opens, data.Highs, data.Lows, data.Closes, data.Volumes, indicators.SessionBars(lastOpen, binance.IntervalToDuration(tf)))
+		if !ok {
+			continue
+		}
+		data.Analysis = analysis
+		result[tf] = data
 	}
+
+	return result
 }
 
-// executeSignal executes a trading signal
-func (o *Orchestrator) executeSignal(signal strategy.Signal) {
+// executeSignal executes a trading signal. Failures are logged and
+// broadcast; the error is returned for callers acting on a user's behalf.
+// Signals from the candle pipeline pass its trace, whose correlation ID
+// becomes the order's client ID; others pass nil.
+func (o *Orchestrator) executeSignal(signal strategy.Signal, trace *pipelineTrace) (*execution.ExecutionResult, error) {
 	// Determine order side
 	side := execution.OrderSideBuy
 	if signal.Direction == strategy.DirectionShort {
@@ -735,18 +1170,23 @@ func (o *Orchestrator) executeSignal(signal strategy.Signal) {
 	var quantity float64
 	if o.riskManager != nil {
 		sizer := o.riskManager.GetPositionSizer()
-		equity, _ := o.executor.GetEquity()
+		equity, _ := o.equity()
 		result := sizer.CalculateSize(risk.PositionSizeParams{
 			Equity:     equity,
 			EntryPrice: signal.Price,
 			StopLoss:   signal.StopLoss,
 			TakeProfit: signal.TakeProfit,
 			Direction:  signal.Direction.String(),
+			ATR:        signal.Indicators.ATR,
+			Strategy:   signal.Strategy,
+			Stats:      o.sizingStats(signal.Strategy),
 		})
 		quantity = result.Size
 
 		log.Debug().
 			Float64("equity", equity).
+			Str("sizingModel", string(result.Model)).
+			Str("sizing", result.ModelDetail).
 			Float64("entryPrice", signal.Price).
 			Float64("stopLoss", signal.StopLoss).
 			Float64("quantity", quantity).
@@ -754,9 +1194,25 @@ func (o *Orchestrator) executeSignal(signal strategy.Signal) {
 			Msg("Position size calculated")
 	} else {
 		// Default sizing
-		equity, _ := o.executor.GetEquity()
+		equity, _ := o.equity()
 		quantity = (equity * 0.1) / signal.Price
 	}
+	quantity = strategy.ScaleSize(quantity, signal.SizeFactor)
+
+	// Cap the order at the strategy's remaining share of capital
+	if o.allocator != nil && signal.Price > 0 {
+		equity, _ := o.equity()
+		buyingPower := o.strategyBuyingPower(signal.Strategy, equity)
+		// Leave headroom for slippage so the executor doesn't reject the fill
+		if maxQuantity := buyingPower * 0.99 / signal.Price; quantity > maxQuantity {
+			log.Info().
+				Str("strategy", signal.Strategy).
+				Float64("quantity", quantity).
+				Float64("buyingPower", buyingPower).
+				Msg("Position size capped by strategy allocation")
+			quantity = maxQuantity
+		}
+	}
 
 	if quantity <= 0 {
 		log.Warn().
@@ -764,11 +1220,16 @@ func (o *Orchestrator) executeSignal(signal strategy.Signal) {
 			Float64("quantity", quantity).
 			Float64("stopLoss", signal.StopLoss).
 			Msg("Order skipped: Invalid position size")
-		return
+		return nil, fmt.Errorf("invalid position size %.8f", quantity)
 	}
 
 	// Create order
+	clientID := uuid.New().String()
+	if trace != nil {
+		clientID = trace.ID
+	}
 	order := &execution.Order{
+		ClientID: clientID,
 		Symbol:   signal.Symbol,
 		Side:     side,
 		Type:     execution.OrderTypeMarket,
@@ -777,38 +1238,94 @@ func (o *Orchestrator) executeSignal(signal strategy.Signal) {
 		Signal:   &signal,
 	}
 
+	// Rest a post-only limit at the touch first when the entry policy asks
+	exec := o.activeExecutor()
+	if price, ok := o.makerEntryPrice(signal.Symbol, side); ok {
+		order.Type = execution.OrderTypeLimitMaker
+		order.Price = price
+		return o.executeMakerEntry(exec, order, signal, trace)
+	}
+
+	result, _, err := o.submitOrder(exec, order, signal, trace)
+	return result, err
+}
+
+// submitOrder records an intent for order, places it on exec and protects
+// the position it opens. Post-only orders are not mirrored or protected;
+// the caller does so once they fill.
+func (o *Orchestrator) submitOrder(exec execution.Executor, order *execution.Order, signal strategy.Signal, trace *pipelineTrace) (*execution.ExecutionResult, *storage.OrderIntent, error) {
+	// Record the intent before sending so a restart mid-execution can be reconciled
+	intent, err := o.recordOrderIntent(exec, order, signal)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to record order intent, order skipped")
+		o.broadcastError("ORDER_FAILED", "Failed to record order intent", err.Error())
+		return nil, nil, fmt.Errorf("failed to record order intent: %w", err)
+	}
+
 	// Execute
-	result, err := o.executor.PlaceOrder(order)
+	o.advanceIntent(intent, storage.IntentSubmitted, "")
+	result, err := exec.PlaceOrder(order)
+	o.markStage(trace, StageRiskToOrder)
+	o.auditPlacement("", order, result, err)
+	if errors.Is(err, execution.ErrWouldTakeLiquidity) {
+		// Expected when the market moved through the maker price
+		o.advanceIntent(intent, storage.IntentCanceled, err.Error())
+		return result, intent, err
+	}
 	if err != nil {
+		o.advanceIntent(intent, storage.IntentFailed, err.Error())
 		log.Error().Err(err).Msg("Failed to execute order")
 		o.broadcastError("ORDER_FAILED", "Failed to execute order", err.Error())
-		return
+		return result, intent, err
 	}
 
-	if result.Success {
-		log.Info().
-			Str("orderID", result.Order.ID).
-			Str("strategy", signal.Strategy).
-			Float64("quantity", quantity).
-			Msg("Order executed")
+	if !result.Success {
+		o.advanceIntent(intent, storage.IntentFailed, result.Message)
+		return result, intent, fmt.Errorf("order not placed: %s", result.Message)
+	}
 
-		// Set stop loss and take profit
-		if result.Position != nil {
-			if signal.StopLoss > 0 {
-				o.executor.UpdateStopLoss(result.Position.ID, signal.StopLoss)
-			}
-			if signal.TakeProfit > 0 {
-				o.executor.UpdateTakeProfit(result.Position.ID, signal.TakeProfit)
-			}
-		}
+	log.Info().
+		Str("orderID", result.Order.ID).
+		Str("strategy", signal.Strategy).
+		Str("type", string(order.Type)).
+		Float64("quantity", order.Quantity).
+		Msg("Order executed")
+	o.recordIntentFill(intent, result)
+	if order.Type == execution.OrderTypeLimitMaker {
+		return result, intent, nil
+	}
+
+	// Mirror onto the shadow paper account for reconciliation
+	o.mirrorOrder(order, signal, result)
+
+	// Set stop loss and take profit on the position the order opened or added to
+	if result.Position != nil {
+		o.protectOpened(exec, intent, order.Side, result.Position)
+	}
+
+	return result, intent, nil
+}
+
+// protectOpened protects the position an order opened or added to, or
+// completes the intent of an order that reduced one
+func (o *Orchestrator) protectOpened(exec execution.Executor, intent *storage.OrderIntent, side execution.OrderSide, position *execution.Position) {
+	opened := (position.Side == execution.PositionSideLong) == (side == execution.OrderSideBuy)
+	if opened {
+		o.protectIntent(exec, intent)
+	} else if intent.State == storage.IntentFilled {
+		o.advanceIntent(intent, storage.IntentProtected, "order reduced an existing position")
 	}
 }
 
 // setupExecutorCallbacks sets up callbacks for executor events
 func (o *Orchestrator) setupExecutorCallbacks() {
+	exec := o.activeExecutor()
+
 	// Set fill callback for paper executor
-	if paperExec, ok := o.executor.(*execution.PaperExecutor); ok {
+	if paperExec, ok := exec.(*execution.PaperExecutor); ok {
 		paperExec.SetOnFill(func(event execution.FillEvent) {
+			defer o.recoverPanic("executor.onFill")
+
 			o.broadcast(BroadcastMessage{
 				Type:      MessageTypeTrade,
 				Timestamp: time.Now(),
@@ -829,6 +1346,8 @@ func (o *Orchestrator) setupExecutorCallbacks() {
 		})
 
 		paperExec.SetOnPosition(func(event execution.PositionEvent) {
+			defer o.recoverPanic("executor.onPosition")
+
 			o.broadcast(BroadcastMessage{
 				Type:      MessageTypePosition,
 				Timestamp: time.Now(),
@@ -850,11 +1369,35 @@ func (o *Orchestrator) setupExecutorCallbacks() {
 			})
 		})
 	}
+
+	// Journal live position events and feed closes to the shadow reconciler
+	liveExec, ok := exec.(interface {
+		SetOnPosition(func(execution.PositionEvent))
+	})
+	if ok && exec.GetMode() == execution.ModeLive {
+		liveExec.SetOnPosition(func(event execution.PositionEvent) {
+			defer o.recoverPanic("executor.onPosition")
+			o.journalPositionEvent(event)
+			if o.shadow != nil {
+				o.shadow.recordExit(event, true)
+			}
+		})
+	}
+
+	// Persist order transitions of whichever executor is active
+	if orderExec, ok := exec.(interface {
+		SetOnOrder(func(execution.OrderUpdate))
+	}); ok {
+		orderExec.SetOnOrder(func(update execution.OrderUpdate) {
+			defer o.recoverPanic("executor.onOrder")
+			o.recordOrderUpdate(update)
+		})
+	}
 }
 
 // updateTradeStats updates trading statistics in state
 func (o *Orchestrator) updateTradeStats() {
-	if paperExec, ok := o.executor.(*execution.PaperExecutor); ok {
+	if paperExec, ok := o.activeExecutor().(*execution.PaperExecutor); ok {
 		stats := paperExec.GetStats()
 
 		o.stateMu.Lock()
@@ -865,58 +1408,84 @@ func (o *Orchestrator) updateTradeStats() {
 }
 
 // broadcastLoop sends periodic state updates
-func (o *Orchestrator) broadcastLoop() {
-	defer o.wg.Done()
-
+func (o *Orchestrator) broadcastLoop(ctx context.Context, beat func()) error {
 	ticker := time.NewTicker(o.config.BroadcastInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-o.ctx.Done():
-			return
+		case <-ctx.Done():
+			return nil
 		case <-ticker.C:
 			o.broadcastState()
+			beat()
 		}
 	}
 }
 
 // riskMonitorLoop monitors risk metrics
-func (o *Orchestrator) riskMonitorLoop() {
-	defer o.wg.Done()
-
+func (o *Orchestrator) riskMonitorLoop(ctx context.Context, beat func()) error {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-o.ctx.Done():
-			return
+		case <-ctx.Done():
+			return nil
 		case <-ticker.C:
 			o.updateRiskMetrics()
+			beat()
 		}
 	}
 }
 
+// persistenceLoop flushes closed candles to storage
+func (o *Orchestrator) persistenceLoop(ctx context.Context, beat func()) error {
+	o.dataService.RunPersistence(ctx, beat)
+	return nil
+}
+
+// superviseLoops restarts crashed or stalled loops until shutdown
+func (o *Orchestrator) superviseLoops() {
+	defer o.wg.Done()
+	o.supervisor.Run()
+}
+
+// GetLoopHealth returns the health of all supervised internal loops
+func (o *Orchestrator) GetLoopHealth() []LoopHealth {
+	return o.supervisor.Health()
+}
+
+// IsHealthy reports whether the orchestrator is running and all loops are healthy
+func (o *Orchestrator) IsHealthy() bool {
+	o.stateMu.RLock()
+	running := o.state.IsRunning
+	o.stateMu.RUnlock()
+
+	return running && o.supervisor.IsHealthy()
+}
+
 // updateRiskMetrics updates risk metrics
 func (o *Orchestrator) updateRiskMetrics() {
-	if o.riskManager == nil || o.executor == nil {
+	exec := o.activeExecutor()
+	if o.riskManager == nil || exec == nil {
 		return
 	}
 
 	// Get current equity
-	equity, err := o.executor.GetEquity()
+	equity, err := o.equity()
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get equity")
 		return
 	}
+	o.markExecutorSynced(time.Now())
 
 	log.Debug().
 		Float64("equity", equity).
 		Msg("Updating risk metrics")
 
 	// Get positions
-	positions, _ := o.executor.GetPositions()
+	positions, _ := exec.GetPositions()
 	openPositions := len(positions)
 
 	// Calculate unrealized P&L
@@ -925,13 +1494,11 @@ func (o *Orchestrator) updateRiskMetrics() {
 		unrealizedPnL += pos.UnrealizedPnL
 	}
 
-	// Get account state from paper executor
-	dailyPnL := 0.0
-	weeklyPnL := 0.0
-	if paperExec, ok := o.executor.(*execution.PaperExecutor); ok {
-		summary := paperExec.GetAccountSummary()
-		dailyPnL = summary.RealizedPnL // Simplified
-	}
+	// Realized P&L of the current calendar day and week
+	dailyPnL, weeklyPnL := o.periodPnL(time.Now())
+
+	// Track equity and exposure for turnover metrics
+	o.activity.sample(time.Now(), equity, openPositions > 0)
 
 	// Update risk manager
 	o.riskManager.UpdateAccountState(equity, equity, unrealizedPnL, dailyPnL, weeklyPnL, openPositions)
@@ -939,16 +1506,23 @@ func (o *Orchestrator) updateRiskMetrics() {
 	// Check circuit breaker
 	o.riskManager.CheckCircuitBreaker()
 
+	// Persist the drawdown peak so it survives restarts
+	o.persistHighWaterMark(false)
+
 	// Update state
 	state := o.riskManager.GetAccountState()
 	limits := o.riskManager.GetRiskLimits()
+	o.riskTally.observeLimits(limits)
+	// The limit trails the high-water mark, so it grows with equity
+	maxDrawdown := o.riskManager.GetConfig().MaxTotalDrawdown * state.PeakEquity
 
 	o.stateMu.Lock()
 	o.state.Equity = equity
 	o.state.AvailableBalance = equity - unrealizedPnL
 	o.state.UnrealizedPnL = unrealizedPnL
 	o.state.CurrentDrawdown = state.CurrentDrawdown
-	o.state.MaxDrawdown = o.config.InitialCapital * 0.2 // From config
+	o.state.MaxDrawdown = maxDrawdown
+	o.state.PeakEquity = state.PeakEquity
 	o.state.OpenPositions = openPositions
 	o.state.IsHalted = state.IsHalted
 	o.state.HaltReason = state.HaltReason
@@ -961,7 +1535,7 @@ func (o *Orchestrator) updateRiskMetrics() {
 		Data: RiskUpdate{
 			Level:           o.determineRiskLevel(state.CurrentDrawdown),
 			Drawdown:        state.CurrentDrawdown,
-			MaxDrawdown:     o.config.InitialCapital * 0.2,
+			MaxDrawdown:     maxDrawdown,
 			DailyLossUsed:   limits.DailyLossUsed,
 			DailyLossLimit:  limits.DailyLossLimit,
 			WeeklyLossUsed:  limits.WeeklyLossUsed,
@@ -990,6 +1564,8 @@ func (o *Orchestrator) determineRiskLevel(drawdown float64) risk.RiskLevel {
 func (o *Orchestrator) broadcastState() {
 	o.stateMu.RLock()
 	state := *o.state
+	state.Timeframes = o.timeframeHealthLocked(time.Now())
+	state.Arming = o.armingPhase()
 	o.stateMu.RUnlock()
 
 	summary := o.getAccountSummary()
@@ -1008,21 +1584,22 @@ func (o *Orchestrator) broadcastState() {
 func (o *Orchestrator) getAccountSummary() *AccountSummary {
 	summary := &AccountSummary{}
 
-	if o.executor == nil {
+	exec := o.activeExecutor()
+	if exec == nil {
 		return summary
 	}
 
-	equity, _ := o.executor.GetEquity()
+	equity, _ := o.equity()
 	summary.Equity = equity
 
-	positions, _ := o.executor.GetPositions()
+	positions, _ := exec.GetPositions()
 	summary.OpenPositions = len(positions)
 
 	for _, pos := range positions {
 		summary.UnrealizedPnL += pos.UnrealizedPnL
 	}
 
-	if paperExec, ok := o.executor.(*execution.PaperExecutor); ok {
+	if paperExec, ok := exec.(*execution.PaperExecutor); ok {
 		stats := paperExec.GetStats()
 		summary.TotalTrades = stats.TotalTrades
 		summary.WinningTrades = stats.WinningTrades
@@ -1070,6 +1647,26 @@ func (o *Orchestrator) broadcastIndicators(result *indicators.AnalysisResult, ti
 			MinusDI: result.ADX.MinusDI,
 		},
 		ATR: result.ATR.ATR,
+		VWAP: &VWAPValue{
+			VWAP:     result.VWAP.VWAP,
+			Distance: result.VWAP.Distance,
+		},
+		SuperTrend: &SuperTrendValue{
+			Value:     result.SuperTrend.Value,
+			Direction: result.SuperTrend.Direction.String(),
+		},
+		Ichimoku: &IchimokuValue{
+			Tenkan:   result.Ichimoku.Tenkan,
+			Kijun:    result.Ichimoku.Kijun,
+			SenkouA:  result.Ichimoku.SenkouA,
+			SenkouB:  result.Ichimoku.SenkouB,
+			Position: result.Ichimoku.Position.String(),
+		},
+		Keltner: &KeltnerValue{
+			Upper:  result.Keltner.Upper,
+			Middle: result.Keltner.Middle,
+			Lower:  result.Keltner.Lower,
+		},
 	}
 
 	o.broadcast(BroadcastMessage{
@@ -1109,6 +1706,9 @@ func (o *Orchestrator) broadcastError(code, message, details string) {
 
 // broadcast sends a message to all subscribers
 func (o *Orchestrator) broadcast(msg BroadcastMessage) {
+	if msg.AccountID == "" && AccountScoped(msg.Type) {
+		msg.AccountID = o.tradingAccount
+	}
 	if o.broadcaster != nil {
 		o.broadcaster.Broadcast(msg)
 	}
@@ -1134,6 +1734,8 @@ func (o *Orchestrator) GetState() *TradingState {
 	o.stateMu.RLock()
 	defer o.stateMu.RUnlock()
 	state := *o.state
+	state.Timeframes = o.timeframeHealthLocked(time.Now())
+	state.Arming = o.armingPhase()
 	return &state
 }
 
@@ -1155,7 +1757,7 @@ func (o *Orchestrator) GetSignals(limit int) []SignalRecord {
 }
 
 // addSignal adds a signal to history (keeps last 50)
-func (o *Orchestrator) addSignal(signal *strategy.Signal, approved bool, reason string) {
+func (o *Orchestrator) addSignal(signal *strategy.Signal, approved bool, reason string, stopLoss *risk.StopLossEnforcement, liquidity *LiquidityCheck, confluence *ConfluenceCheck) {
 	o.signalsMu.Lock()
 	defer o.signalsMu.Unlock()
 
@@ -1163,6 +1765,9 @@ func (o *Orchestrator) addSignal(signal *strategy.Signal, approved bool, reason
 		Signal:     signal,
 		Approved:   approved,
 		Reason:     reason,
+		StopLoss:   stopLoss,
+		Liquidity:  liquidity,
+		Confluence: confluence,
 		ReceivedAt: time.Now(),
 	}
 
@@ -0,0 +1,149 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// orderLogBuffer is the number of order transitions queued for SQLite
+const orderLogBuffer = 1024
+
+// orderLog persists the order lifecycle of the active executor. Writes
+// happen on one goroutine, in the order the transitions happened.
+type orderLog struct {
+	events chan execution.OrderUpdate
+}
+
+// recordOrderUpdate queues an order transition for persistence. It is called
+// from executors with their state locked and never blocks.
+func (o *Orchestrator) recordOrderUpdate(update execution.OrderUpdate) {
+	// Orders rejected before reaching the exchange have no ID to key on
+	if update.Order.ID == "" {
+		return
+	}
+
+	select {
+	case o.orders.events <- update:
+	default:
+		log.Error().
+			Str("orderID", update.Order.ID).
+			Str("status", string(update.Transition.To)).
+			Msg("Order log full, transition not persisted")
+	}
+}
+
+// orderLogLoop writes queued order transitions to SQLite
+func (o *Orchestrator) orderLogLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-o.orders.events:
+			o.persistOrderUpdate(update)
+			beat()
+		case <-ticker.C:
+			beat()
+		}
+	}
+}
+
+// persistOrderUpdate stores the order and its status history
+func (o *Orchestrator) persistOrderUpdate(update execution.OrderUpdate) {
+	if o.dataService == nil {
+		return
+	}
+
+	order := update.Order
+	row := storage.Order{
+		OrderID:        order.ID,
+		ClientOrderID:  order.ClientID,
+		Symbol:         order.Symbol,
+		Side:           string(order.Side),
+		Type:           string(order.Type),
+		Quantity:       order.Quantity,
+		Price:          order.Price,
+		StopPrice:      order.StopPrice,
+		Status:         string(order.Status),
+		FilledQuantity: order.FilledQuantity,
+		AvgFillPrice:   order.AvgFillPrice,
+		Strategy:       order.Strategy,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+
+	transitions := make([]storage.OrderTransition, len(order.Transitions))
+	for i, t := range order.Transitions {
+		transitions[i] = storage.OrderTransition{
+			OrderID:    order.ID,
+			Seq:        i,
+			FromStatus: string(t.From),
+			ToStatus:   string(t.To),
+			Reason:     t.Reason,
+			At:         t.At,
+		}
+	}
+
+	if err := o.dataService.SaveOrder(row, transitions); err != nil {
+		log.Error().Err(err).Str("orderID", order.ID).Msg("Failed to persist order")
+	}
+}
+
+// GetOrderHistory returns persisted orders, most recently updated first,
+// with their status histories
+func (o *Orchestrator) GetOrderHistory(symbol, status, strategy string, limit int) ([]storage.Order, map[string][]storage.OrderTransition, error) {
+	if o.dataService == nil {
+		return nil, map[string][]storage.OrderTransition{}, nil
+	}
+
+	orders, err := o.dataService.GetRecentOrders(symbol, status, strategy, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.OrderID
+	}
+	transitions, err := o.dataService.GetOrderTransitions(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return orders, transitions, nil
+}
+
+// GetStoredOrder returns a persisted order and its status history, nil if
+// the order is not stored
+func (o *Orchestrator) GetStoredOrder(orderID string) (*storage.Order, []storage.OrderTransition, error) {
+	if o.dataService == nil {
+		return nil, nil, nil
+	}
+
+	order, err := o.dataService.GetOrder(orderID)
+	if err != nil || order == nil {
+		return nil, nil, err
+	}
+	transitions, err := o.dataService.GetOrderTransitions([]string{orderID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return order, transitions[orderID], nil
+}
+
+// GetOpenOrders returns the active executor's working orders for symbol,
+// the trading symbol when empty
+func (o *Orchestrator) GetOpenOrders(symbol string) ([]*execution.Order, error) {
+	exec := o.activeExecutor()
+	if exec == nil {
+		return nil, nil
+	}
+	if symbol == "" {
+		symbol = o.config.Symbol
+	}
+	return exec.GetOpenOrders(symbol)
+}
@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"errors"
+
+	"github.com/eth-trading/internal/marketdata"
+)
+
+var (
+	// ErrOrderBookDisabled is returned for order book requests while the
+	// depth stream is not subscribed
+	ErrOrderBookDisabled = errors.New("order book is disabled")
+	// ErrOrderBookUnavailable is returned while the order book is unsynced
+	// or stale
+	ErrOrderBookUnavailable = errors.New("order book not synced yet")
+)
+
+// OrderBookView is the top of the local order book with optional fill
+// estimates for a quantity
+type OrderBookView struct {
+	marketdata.Quote
+	Bids []marketdata.Level `json:"bids"`
+	Asks []marketdata.Level `json:"asks"`
+	Buy  *marketdata.Fill   `json:"buy,omitempty"`  // Estimated market buy of the quantity asked for
+	Sell *marketdata.Fill   `json:"sell,omitempty"` // Estimated market sell of the quantity asked for
+}
+
+// SetOrderBooks keeps local order books from the diff depth stream. It must
+// be called before Start for the stream to be subscribed.
+func (o *Orchestrator) SetOrderBooks(books *marketdata.Books) {
+	o.orderBooks = books
+}
+
+// GetOrderBook returns levels of each side of the traded symbol's book and,
+// for a positive quantity, what market orders for it would fill at
+func (o *Orchestrator) GetOrderBook(levels int, quantity float64) (*OrderBookView, error) {
+	if o.orderBooks == nil {
+		return nil, ErrOrderBookDisabled
+	}
+	quote, ok := o.orderBooks.Quote(o.config.Symbol)
+	if !ok {
+		return nil, ErrOrderBookUnavailable
+	}
+
+	view := &OrderBookView{Quote: quote}
+	view.Bids, view.Asks = o.orderBooks.Book(o.config.Symbol).Top(levels)
+	if quantity > 0 {
+		if fill, ok := o.orderBooks.Estimate(o.config.Symbol, true, quantity); ok {
+			view.Buy = &fill
+		}
+		if fill, ok := o.orderBooks.Estimate(o.config.Symbol, false, quantity); ok {
+			view.Sell = &fill
+		}
+	}
+	return view, nil
+}
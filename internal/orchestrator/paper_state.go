@@ -0,0 +1,136 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/rs/zerolog/log"
+)
+
+// paperStateInterval is the time between paper account snapshots
+const paperStateInterval = time.Minute
+
+// paperStatePersister saves the paper account so restarts don't reset it
+type paperStatePersister struct {
+	enabled  bool
+	restored bool // The account was restored on startup
+
+	mu        sync.Mutex
+	lastSaved string // Last snapshot written, to skip unchanged writes
+}
+
+// SetPaperStatePersistence enables saving the paper account to SQLite and
+// restoring it on startup
+func (o *Orchestrator) SetPaperStatePersistence(enabled bool) {
+	o.paperState.enabled = enabled
+}
+
+// paperAccount returns the paper executor whose state is persisted: the
+// active one, or the one registered for scheduled switches
+func (o *Orchestrator) paperAccount() *execution.PaperExecutor {
+	if paperExec, ok := o.activeExecutor().(*execution.PaperExecutor); ok {
+		return paperExec
+	}
+	if paperExec, ok := o.executors[TradingModePaper].(*execution.PaperExecutor); ok {
+		return paperExec
+	}
+	return nil
+}
+
+// restorePaperState loads the persisted paper account into the executor
+func (o *Orchestrator) restorePaperState() {
+	if !o.paperState.enabled || o.dataService == nil {
+		return
+	}
+	paperExec := o.paperAccount()
+	if paperExec == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadPaperState()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load paper account")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var state execution.PaperState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted paper account")
+		return
+	}
+
+	// A new initial balance in the config means a fresh paper test
+	if current := paperExec.Snapshot().InitialBalance; state.InitialBalance != current {
+		log.Warn().
+			Float64("saved", state.InitialBalance).
+			Float64("configured", current).
+			Msg("Paper initial balance changed, starting a fresh paper account")
+		return
+	}
+
+	if err := paperExec.Restore(state); err != nil {
+		log.Warn().Err(err).Msg("Failed to restore paper account")
+		return
+	}
+	o.paperState.restored = true
+}
+
+// persistPaperState saves the paper account when it has changed
+func (o *Orchestrator) persistPaperState() {
+	if !o.paperState.enabled || o.dataService == nil {
+		return
+	}
+	paperExec := o.paperAccount()
+	if paperExec == nil {
+		return
+	}
+
+	// Compare snapshots without their timestamp
+	state := paperExec.Snapshot()
+	state.SavedAt = time.Time{}
+	content, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+
+	o.paperState.mu.Lock()
+	defer o.paperState.mu.Unlock()
+
+	if string(content) == o.paperState.lastSaved {
+		return
+	}
+
+	state.SavedAt = time.Now()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := o.dataService.SavePaperState(string(data)); err != nil {
+		log.Warn().Err(err).Msg("Failed to persist paper account")
+		return
+	}
+	o.paperState.lastSaved = string(content)
+}
+
+// paperStateLoop snapshots the paper account so a crash loses at most one
+// interval of paper trading
+func (o *Orchestrator) paperStateLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(paperStateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			o.persistPaperState()
+			beat()
+		}
+	}
+}
@@ -0,0 +1,706 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultParamDriftTolerance is the relative difference from the
+	// reference value at which a parameter counts as drifted
+	defaultParamDriftTolerance = 0.1
+	// defaultParamDriftLookback is the recent data re-optimization runs on
+	defaultParamDriftLookback = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrNoOptimizedParams is returned for a strategy without a recorded optimization
+	ErrNoOptimizedParams = errors.New("no optimized parameters recorded")
+	// ErrNoSuggestedParams is returned when re-optimization suggested nothing to apply
+	ErrNoSuggestedParams = errors.New("no re-optimized parameters suggested")
+)
+
+// ParamDriftConfig configures how strategy parameters are compared with
+// the optimizer's
+type ParamDriftConfig struct {
+	Tolerance          float64       // Relative difference that counts as drift, e.g. 0.1 = 10%
+	ReoptimizeInterval time.Duration // How often recorded searches re-run on recent data; 0 = never
+	Lookback           time.Duration // Recent data re-optimization runs on
+}
+
+// OptimizedParams are the best parameters the optimizer found for a
+// strategy, with the search that found them
+type OptimizedParams struct {
+	Strategy       string                         `json:"strategy"`
+	Params         map[string]float64             `json:"params"` // By config field, e.g. FastMAPeriod
+	Ranges         map[string]backtest.ParamRange `json:"ranges"` // Searched ranges, re-run on recent data
+	Objective      string                         `json:"objective"`
+	Mode           string                         `json:"mode"`
+	Samples        int                            `json:"samples,omitempty"`
+	MinTrades      int                            `json:"minTrades,omitempty"`
+	Score          float64                        `json:"score"`
+	Symbol         string                         `json:"symbol"`
+	Timeframe      string                         `json:"timeframe"`
+	From           time.Time                      `json:"from"`
+	To             time.Time                      `json:"to"`
+	InitialCapital float64                        `json:"initialCapital"`
+	RiskPerTrade   float64                        `json:"riskPerTrade"`
+	RecordedAt     time.Time                      `json:"recordedAt"`
+}
+
+// ParamDeviation compares a configured parameter with a reference value
+type ParamDeviation struct {
+	Field      string  `json:"field"`
+	Configured float64 `json:"configured"`
+	Reference  float64 `json:"reference"`
+	Deviation  float64 `json:"deviation"` // Relative to the reference; absolute when it is 0
+	Drifted    bool    `json:"drifted"`
+}
+
+// Reoptimization is the outcome of re-running a strategy's recorded search
+// on recent data
+type Reoptimization struct {
+	Params          map[string]float64 `json:"params"`
+	Score           float64            `json:"score"`           // Objective of the suggested parameters
+	ConfiguredScore float64            `json:"configuredScore"` // Objective of the configured ones on the same data
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	RanAt           time.Time          `json:"ranAt"`
+	Deviations      []ParamDeviation   `json:"deviations"` // Configured values against the suggested ones
+	Drifted         bool               `json:"drifted"`    // Suggested optima differ materially and score better
+}
+
+// ParamDriftReport compares a strategy's configured parameters with the
+// optimized and, when re-optimization ran, suggested ones
+type ParamDriftReport struct {
+	Strategy    string           `json:"strategy"`
+	Optimized   *OptimizedParams `json:"optimized"`
+	Deviations  []ParamDeviation `json:"deviations"` // Configured values against the optimized ones
+	Drifted     bool             `json:"drifted"`    // Configured values diverge from the optimized ones
+	Reoptimized *Reoptimization  `json:"reoptimized,omitempty"`
+	Error       string           `json:"error,omitempty"` // Why the configured values could not be read
+}
+
+// paramDriftState is the persisted optimization record
+type paramDriftState struct {
+	Optimized   map[string]*OptimizedParams `json:"optimized"`
+	Reoptimized map[string]*Reoptimization  `json:"reoptimized,omitempty"`
+}
+
+// paramDriftMonitor tracks optimized strategy parameters and alerts when
+// the configured ones drift from them
+type paramDriftMonitor struct {
+	mu          sync.Mutex
+	config      ParamDriftConfig
+	optimized   map[string]*OptimizedParams
+	reoptimized map[string]*Reoptimization
+	alerted     map[string]string // Drifted fields last alerted, by strategy and kind
+}
+
+// SetParamDrift configures drift detection and re-optimization. It must be
+// called before Start.
+func (o *Orchestrator) SetParamDrift(config ParamDriftConfig) error {
+	if config.Tolerance < 0 {
+		return fmt.Errorf("parameter drift tolerance must not be negative")
+	}
+	if config.ReoptimizeInterval < 0 || config.Lookback < 0 {
+		return fmt.Errorf("re-optimization interval and lookback must not be negative")
+	}
+	if config.Tolerance == 0 {
+		config.Tolerance = defaultParamDriftTolerance
+	}
+	if config.Lookback == 0 {
+		config.Lookback = defaultParamDriftLookback
+	}
+
+	o.paramDrift.mu.Lock()
+	defer o.paramDrift.mu.Unlock()
+	o.paramDrift.config = config
+	return nil
+}
+
+// paramDriftTolerance returns the configured drift tolerance or the default
+func (o *Orchestrator) paramDriftTolerance() float64 {
+	o.paramDrift.mu.Lock()
+	defer o.paramDrift.mu.Unlock()
+	if o.paramDrift.config.Tolerance == 0 {
+		return defaultParamDriftTolerance
+	}
+	return o.paramDrift.config.Tolerance
+}
+
+// RecordOptimizedParams records the best run of a parameter search as the
+// reference values of every strategy searched. Indicator parameters are
+// shared by all strategies and not tracked. It returns the strategies
+// recorded.
+func (o *Orchestrator) RecordOptimizedParams(config *backtest.OptimizerConfig, result *backtest.OptimizationResult) []string {
+	if result == nil || result.Best == nil || config.Base == nil {
+		return nil
+	}
+
+	records := make(map[string]*OptimizedParams)
+	for key, value := range result.Best.Params {
+		target, field, ok := strings.Cut(key, ".")
+		if !ok || target == "indicators" {
+			continue
+		}
+		name := strategy.CanonicalName(target)
+		rec, ok := records[name]
+		if !ok {
+			rec = &OptimizedParams{
+				Strategy:       name,
+				Params:         make(map[string]float64),
+				Ranges:         make(map[string]backtest.ParamRange),
+				Objective:      string(result.Objective),
+				Mode:           result.Mode,
+				Samples:        config.Samples,
+				MinTrades:      config.MinTrades,
+				Score:          result.Best.Score,
+				Symbol:         config.Base.Symbol,
+				Timeframe:      config.Base.Timeframe,
+				From:           config.Base.StartDate,
+				To:             config.Base.EndDate,
+				InitialCapital: config.Base.InitialCapital,
+				RiskPerTrade:   config.Base.RiskPerTrade,
+				RecordedAt:     time.Now(),
+			}
+			records[name] = rec
+		}
+		rec.Params[field] = value
+		rec.Ranges[field] = config.Params[key]
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(records))
+	o.paramDrift.mu.Lock()
+	if o.paramDrift.optimized == nil {
+		o.paramDrift.optimized = make(map[string]*OptimizedParams)
+	}
+	for name, rec := range records {
+		o.paramDrift.optimized[name] = rec
+		delete(o.paramDrift.reoptimized, name)
+		names = append(names, name)
+	}
+	o.paramDrift.mu.Unlock()
+	sort.Strings(names)
+
+	log.Info().Strs("strategies", names).Float64("score", result.Best.Score).Msg("Recorded optimized strategy parameters")
+	o.persistParamDrift()
+	o.checkParamDrift()
+	return names
+}
+
+// GetParamDrift compares every strategy with recorded optimized parameters
+// against its configuration, sorted by strategy
+func (o *Orchestrator) GetParamDrift() []ParamDriftReport {
+	tolerance := o.paramDriftTolerance()
+
+	o.paramDrift.mu.Lock()
+	names := make([]string, 0, len(o.paramDrift.optimized))
+	for name := range o.paramDrift.optimized {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reports := make([]ParamDriftReport, 0, len(names))
+	for _, name := range names {
+		report := ParamDriftReport{Strategy: name, Optimized: o.paramDrift.optimized[name]}
+		if reopt := o.paramDrift.reoptimized[name]; reopt != nil {
+			copied := *reopt
+			report.Reoptimized = &copied
+		}
+		reports = append(reports, report)
+	}
+	o.paramDrift.mu.Unlock()
+
+	var strategies map[string]strategy.Strategy
+	if o.strategyMgr != nil {
+		strategies = o.strategyMgr.GetStrategies()
+	}
+	for i := range reports {
+		r := &reports[i]
+		strat, ok := strategies[r.Strategy]
+		if !ok {
+			r.Error = ErrStrategyNotFound.Error()
+			continue
+		}
+		deviations, drifted, err := compareParams(strat, r.Optimized.Params, tolerance)
+		if err != nil {
+			r.Error = err.Error()
+			continue
+		}
+		r.Deviations, r.Drifted = deviations, drifted
+
+		if r.Reoptimized != nil {
+			// Compare with the configuration now, which may have changed since the run
+			deviations, drifted, err := compareParams(strat, r.Reoptimized.Params, tolerance)
+			if err == nil {
+				r.Reoptimized.Deviations = deviations
+				r.Reoptimized.Drifted = drifted && r.Reoptimized.Score > r.Reoptimized.ConfiguredScore
+			}
+		}
+	}
+	return reports
+}
+
+// compareParams compares a strategy's configured parameters with reference
+// values, sorted by field
+func compareParams(strat strategy.Strategy, reference map[string]float64, tolerance float64) ([]ParamDeviation, bool, error) {
+	fields := make([]string, 0, len(reference))
+	for field := range reference {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	deviations := make([]ParamDeviation, 0, len(fields))
+	drifted := false
+	for _, field := range fields {
+		configured, err := backtest.ReadParam(strat.GetConfig(), field)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", strat.Name(), err)
+		}
+		d := ParamDeviation{
+			Field:      field,
+			Configured: configured,
+			Reference:  reference[field],
+			Deviation:  paramDeviation(configured, reference[field]),
+		}
+		// Rounded so float noise never counts as drift
+		d.Drifted = math.Round(d.Deviation*1e9)/1e9 > tolerance
+		drifted = drifted || d.Drifted
+		deviations = append(deviations, d)
+	}
+	return deviations, drifted, nil
+}
+
+// paramDeviation returns how far configured is from reference, relative to
+// the reference or absolute when it is 0
+func paramDeviation(configured, reference float64) float64 {
+	if reference == 0 {
+		return math.Abs(configured)
+	}
+	return math.Abs(configured-reference) / math.Abs(reference)
+}
+
+// driftedFields lists the drifted parameters, e.g. "FastMAPeriod 12 (optimized 8)"
+func driftedFields(deviations []ParamDeviation, reference string) []string {
+	var fields []string
+	for _, d := range deviations {
+		if d.Drifted {
+			fields = append(fields, fmt.Sprintf("%s %g (%s %g)", d.Field, d.Configured, reference, d.Reference))
+		}
+	}
+	return fields
+}
+
+// checkParamDrift alerts on strategies whose configured parameters newly
+// drifted from the optimized ones, or for which re-optimization newly
+// suggests materially different ones. Drift already alerted is not
+// repeated until it changes.
+func (o *Orchestrator) checkParamDrift() {
+	for _, report := range o.GetParamDrift() {
+		if report.Error != "" {
+			continue
+		}
+
+		var drifted []string
+		if report.Drifted {
+			drifted = driftedFields(report.Deviations, "optimized")
+		}
+		if o.markParamDrift(report.Strategy+".optimized", drifted) {
+			o.alertParamDrift(report, fmt.Sprintf("%s parameters drifted from optimized values: %s",
+				report.Strategy, strings.Join(drifted, ", ")))
+		}
+
+		var suggested []string
+		if r := report.Reoptimized; r != nil && r.Drifted {
+			suggested = driftedFields(r.Deviations, "suggested")
+		}
+		if o.markParamDrift(report.Strategy+".reoptimized", suggested) {
+			r := report.Reoptimized
+			o.alertParamDrift(report, fmt.Sprintf("Re-optimizing %s on %s to %s suggests different parameters (%s %.2f vs %.2f configured): %s",
+				report.Strategy, r.From.Format("2006-01-02"), r.To.Format("2006-01-02"),
+				report.Optimized.Objective, r.Score, r.ConfiguredScore, strings.Join(suggested, ", ")))
+		}
+	}
+}
+
+// markParamDrift records the drifted fields of a check and reports whether
+// they are new and worth an alert
+func (o *Orchestrator) markParamDrift(key string, fields []string) bool {
+	fingerprint := strings.Join(fields, "; ")
+
+	o.paramDrift.mu.Lock()
+	defer o.paramDrift.mu.Unlock()
+	if o.paramDrift.alerted == nil {
+		o.paramDrift.alerted = make(map[string]string)
+	}
+	if o.paramDrift.alerted[key] == fingerprint {
+		return false
+	}
+	o.paramDrift.alerted[key] = fingerprint
+	return fingerprint != ""
+}
+
+// alertParamDrift records a drift alert and broadcasts the report
+func (o *Orchestrator) alertParamDrift(report ParamDriftReport, message string) {
+	log.Warn().Str("strategy", report.Strategy).Msg(message)
+
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeParamDrift,
+		Timestamp: time.Now(),
+		Data:      report,
+	})
+
+	if o.dataService == nil {
+		return
+	}
+	data, _ := json.Marshal(report)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "param_drift",
+		Severity: "warning",
+		Message:  message,
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record parameter drift alert")
+	}
+}
+
+// ApplyOptimizedParams reconfigures a running strategy with its optimized
+// parameters, or with those re-optimization suggested. Applying suggested
+// parameters makes them the new optimized reference. The change lasts
+// until restart; the configuration file is left as it is.
+func (o *Orchestrator) ApplyOptimizedParams(name string, suggested bool, appliedBy string) (*ParamDriftReport, error) {
+	if o.strategyMgr == nil {
+		return nil, fmt.Errorf("strategy manager not set")
+	}
+	name = strategy.CanonicalName(name)
+
+	o.paramDrift.mu.Lock()
+	rec := o.paramDrift.optimized[name]
+	reopt := o.paramDrift.reoptimized[name]
+	o.paramDrift.mu.Unlock()
+	if rec == nil {
+		return nil, ErrNoOptimizedParams
+	}
+	params := rec.Params
+	if suggested {
+		if reopt == nil || len(reopt.Params) == 0 {
+			return nil, ErrNoSuggestedParams
+		}
+		params = reopt.Params
+	}
+
+	current, ok := o.strategyMgr.GetStrategies()[name]
+	if !ok {
+		return nil, ErrStrategyNotFound
+	}
+	previous := make(map[string]float64, len(params))
+	for field := range params {
+		if v, err := backtest.ReadParam(current.GetConfig(), field); err == nil {
+			previous[field] = v
+		}
+	}
+	applied, err := backtest.ApplyParams(current, params)
+	if err != nil {
+		return nil, err
+	}
+	o.strategyMgr.AddStrategy(applied)
+	o.RecordAudit(storage.AuditEntry{
+		Category: storage.AuditConfig,
+		Action:   AuditStrategyParams,
+		Actor:    appliedBy,
+		Resource: name,
+		OldValue: AuditValue(previous),
+		NewValue: AuditValue(params),
+	}, nil)
+
+	if suggested {
+		updated := *rec
+		updated.Params = reopt.Params
+		updated.Score = reopt.Score
+		updated.From, updated.To = reopt.From, reopt.To
+		updated.RecordedAt = time.Now()
+
+		o.paramDrift.mu.Lock()
+		o.paramDrift.optimized[name] = &updated
+		delete(o.paramDrift.reoptimized, name)
+		o.paramDrift.mu.Unlock()
+		o.persistParamDrift()
+	}
+
+	log.Info().
+		Str("strategy", name).
+		Bool("suggested", suggested).
+		Str("by", appliedBy).
+		Interface("params", params).
+		Msg("Applied optimized strategy parameters")
+	o.checkParamDrift()
+
+	for _, report := range o.GetParamDrift() {
+		if report.Strategy == name {
+			return &report, nil
+		}
+	}
+	return nil, ErrNoOptimizedParams
+}
+
+// reoptimize re-runs a strategy's recorded search on the lookback window
+// ending now and compares the best run with the configured parameters
+func (o *Orchestrator) reoptimize(ctx context.Context, rec *OptimizedParams, lookback time.Duration) (*Reoptimization, error) {
+	strat, ok := o.strategyMgr.GetStrategies()[rec.Strategy]
+	if !ok {
+		return nil, ErrStrategyNotFound
+	}
+
+	to := time.Now()
+	from := to.Add(-lookback)
+	rng, err := o.GetBacktestCandles(rec.Symbol, rec.Timeframe, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if rng.Partial || len(rng.Candles) == 0 {
+		return nil, fmt.Errorf("%s %s candles missing for %s to %s", rec.Symbol, rec.Timeframe, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	candles := make([]backtest.Candle, len(rng.Candles))
+	for i, c := range rng.Candles {
+		candles[i] = backtest.Candle{
+			Timestamp: c.OpenTime,
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			Close:     c.Close,
+			Volume:    c.Volume,
+		}
+	}
+	data := &backtest.HistoricalData{Symbol: rec.Symbol, Timeframe: rec.Timeframe, Candles: candles}
+
+	// Entries are confirmed as they are live
+	confirmation := o.GetEntryConfirmation()
+	if confirmation.Enabled() && confirmation.Interval != rec.Timeframe {
+		interval, err := backtest.TimeframeDuration(confirmation.Interval)
+		if err != nil {
+			return nil, err
+		}
+		if bar, err := backtest.TimeframeDuration(rec.Timeframe); err != nil || interval > bar {
+			return nil, fmt.Errorf("entry confirmation interval %s is longer than the %s bars", confirmation.Interval, rec.Timeframe)
+		}
+		confirmRng, err := o.GetBacktestCandles(rec.Symbol, confirmation.Interval, from.Add(-strategy.ConfirmationBars*interval), to)
+		if err != nil {
+			return nil, err
+		}
+		if confirmRng.Partial || len(confirmRng.Candles) == 0 {
+			return nil, fmt.Errorf("%s %s candles missing for entry confirmation", rec.Symbol, confirmation.Interval)
+		}
+		data.ConfirmationCandles = make([]backtest.Candle, len(confirmRng.Candles))
+		for i, c := range confirmRng.Candles {
+			data.ConfirmationCandles[i] = backtest.Candle{
+				Timestamp: c.OpenTime,
+				Open:      c.Open,
+				High:      c.High,
+				Low:       c.Low,
+				Close:     c.Close,
+				Volume:    c.Volume,
+			}
+		}
+	}
+
+	compounding, compoundingStep := o.GetCompounding()
+	base := &backtest.Config{
+		Symbol:             rec.Symbol,
+		Timeframe:          rec.Timeframe,
+		StartDate:          from,
+		EndDate:            to,
+		InitialCapital:     rec.InitialCapital,
+		Fees:               o.GetFeeSchedule(),
+		RiskPerTrade:       rec.RiskPerTrade,
+		Strategies:         []strategy.Strategy{strat},
+		DisallowedRegimes:  o.strategyMgr.GetScorer().GetDisallowedRegimes(),
+		RegimeRoutes:       o.strategyMgr.GetScorer().GetRegimeRoutes(),
+		VolatilityThrottle: o.GetVolatilityThrottles(),
+		EntryConfirmation:  confirmation,
+		Compounding:        compounding,
+		CompoundingStep:    compoundingStep,
+		EvalPool:           o.EvalPool(),
+	}
+	workers := runtime.NumCPU() / 2
+	if workers < 1 {
+		workers = 1
+	}
+
+	search := make(map[string]backtest.ParamRange, len(rec.Ranges))
+	configured := make(map[string]backtest.ParamRange, len(rec.Ranges))
+	for field, r := range rec.Ranges {
+		key := rec.Strategy + "." + field
+		search[key] = r
+		value, err := backtest.ReadParam(strat.GetConfig(), field)
+		if err != nil {
+			return nil, err
+		}
+		configured[key] = backtest.ParamRange{Values: []float64{value}}
+	}
+
+	result, err := backtest.Optimize(ctx, &backtest.OptimizerConfig{
+		Base:      base,
+		Params:    search,
+		Mode:      rec.Mode,
+		Samples:   rec.Samples,
+		Workers:   workers,
+		Objective: backtest.Objective(rec.Objective),
+		MinTrades: rec.MinTrades,
+		Top:       1,
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+	if result.Best == nil {
+		return nil, fmt.Errorf("no run reached %d trades", rec.MinTrades)
+	}
+	baseline, err := backtest.Optimize(ctx, &backtest.OptimizerConfig{
+		Base:      base,
+		Params:    configured,
+		Workers:   1,
+		Objective: backtest.Objective(rec.Objective),
+		Top:       1,
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(baseline.Results) == 0 || baseline.Results[0].Error != "" {
+		return nil, fmt.Errorf("configured parameters failed to backtest")
+	}
+
+	params := make(map[string]float64, len(result.Best.Params))
+	for key, value := range result.Best.Params {
+		_, field, _ := strings.Cut(key, ".")
+		params[field] = value
+	}
+	return &Reoptimization{
+		Params:          params,
+		Score:           result.Best.Score,
+		ConfiguredScore: baseline.Results[0].Score,
+		From:            from,
+		To:              to,
+		RanAt:           time.Now(),
+	}, nil
+}
+
+// paramDriftLoop re-runs the recorded searches on recent data on the
+// configured interval and alerts on materially different optima
+func (o *Orchestrator) paramDriftLoop(ctx context.Context, beat func()) error {
+	o.paramDrift.mu.Lock()
+	interval, lookback := o.paramDrift.config.ReoptimizeInterval, o.paramDrift.config.Lookback
+	o.paramDrift.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			o.paramDrift.mu.Lock()
+			records := make([]*OptimizedParams, 0, len(o.paramDrift.optimized))
+			for _, rec := range o.paramDrift.optimized {
+				records = append(records, rec)
+			}
+			o.paramDrift.mu.Unlock()
+
+			for _, rec := range records {
+				reopt, err := o.reoptimize(ctx, rec, lookback)
+				if ctx.Err() != nil {
+					return nil
+				}
+				beat()
+				if err != nil {
+					log.Warn().Err(err).Str("strategy", rec.Strategy).Msg("Strategy re-optimization failed")
+					continue
+				}
+
+				o.paramDrift.mu.Lock()
+				if o.paramDrift.optimized[rec.Strategy] == rec {
+					if o.paramDrift.reoptimized == nil {
+						o.paramDrift.reoptimized = make(map[string]*Reoptimization)
+					}
+					o.paramDrift.reoptimized[rec.Strategy] = reopt
+				}
+				o.paramDrift.mu.Unlock()
+			}
+
+			o.persistParamDrift()
+			o.checkParamDrift()
+			beat()
+		}
+	}
+}
+
+// reoptimizing reports whether recorded searches re-run periodically
+func (o *Orchestrator) reoptimizing() bool {
+	o.paramDrift.mu.Lock()
+	defer o.paramDrift.mu.Unlock()
+	return o.paramDrift.config.ReoptimizeInterval > 0
+}
+
+// restoreParamDrift loads the recorded optimizations and checks the
+// configured parameters against them, e.g. after manual edits
+func (o *Orchestrator) restoreParamDrift() {
+	if o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadOptimizedParams()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load optimized strategy parameters")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var state paramDriftState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted optimized strategy parameters")
+		return
+	}
+
+	o.paramDrift.mu.Lock()
+	o.paramDrift.optimized = state.Optimized
+	o.paramDrift.reoptimized = state.Reoptimized
+	o.paramDrift.mu.Unlock()
+	o.checkParamDrift()
+}
+
+// persistParamDrift saves the recorded optimizations
+func (o *Orchestrator) persistParamDrift() {
+	if o.dataService == nil {
+		return
+	}
+
+	o.paramDrift.mu.Lock()
+	data, err := json.Marshal(paramDriftState{
+		Optimized:   o.paramDrift.optimized,
+		Reoptimized: o.paramDrift.reoptimized,
+	})
+	o.paramDrift.mu.Unlock()
+	if err != nil {
+		return
+	}
+	if err := o.dataService.SaveOptimizedParams(string(data)); err != nil {
+		log.Warn().Err(err).Msg("Failed to persist optimized strategy parameters")
+	}
+}
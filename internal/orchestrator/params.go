@@ -0,0 +1,236 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/rs/zerolog/log"
+)
+
+// paramSections are the settings sections that make up the strategy
+// parameter set; a change to any of them starts a new parameter version
+var paramSections = []string{"strategies", "indicators", "symbols"}
+
+// IsParamSection reports whether a settings section belongs to the strategy
+// parameter set
+func IsParamSection(section string) bool {
+	for _, s := range paramSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamVersionPerformance is the realized performance of trades entered
+// under one parameter set version. Version 0 is the configuration the bot
+// started with before any recorded settings change.
+type ParamVersionPerformance struct {
+	Version      int64      `json:"version"`
+	Section      string     `json:"section,omitempty"` // Settings section whose change started the version
+	ChangedBy    string     `json:"changedBy,omitempty"`
+	ChangedAt    *time.Time `json:"changedAt,omitempty"`
+	Current      bool       `json:"current"`
+	Trades       int        `json:"trades"` // Closing trades with realized P&L
+	Wins         int        `json:"wins"`
+	Losses       int        `json:"losses"`
+	WinRate      float64    `json:"winRate"`
+	NetPnL       float64    `json:"netPnl"`
+	GrossProfit  float64    `json:"grossProfit"`
+	GrossLoss    float64    `json:"grossLoss"`
+	ProfitFactor float64    `json:"profitFactor"`
+	AvgPnL       float64    `json:"avgPnl"`
+	Commission   float64    `json:"commission"`
+	FirstTrade   *time.Time `json:"firstTrade,omitempty"`
+	LastTrade    *time.Time `json:"lastTrade,omitempty"`
+}
+
+// ParamTuningResult compares the latest parameter version with trades
+// against the one before it
+type ParamTuningResult struct {
+	From               int64   `json:"from"`
+	To                 int64   `json:"to"`
+	AvgPnLChange       float64 `json:"avgPnlChange"`
+	WinRateChange      float64 `json:"winRateChange"`
+	ProfitFactorChange float64 `json:"profitFactorChange"`
+	Improved           bool    `json:"improved"` // Average P&L per trade went up
+}
+
+// ParamVersionReport breaks realized performance down by parameter version
+type ParamVersionReport struct {
+	CurrentVersion int64                     `json:"currentVersion"`
+	Strategy       string                    `json:"strategy,omitempty"`
+	Versions       []ParamVersionPerformance `json:"versions"` // Oldest first
+	LastTuning     *ParamTuningResult        `json:"lastTuning,omitempty"`
+}
+
+// paramVersioned is implemented by executors that stamp trades with the
+// parameter set version
+type paramVersioned interface {
+	SetParamVersion(version int64)
+}
+
+// SetParamVersion records a new parameter set version and stamps it on
+// positions opened from now on
+func (o *Orchestrator) SetParamVersion(version int64) {
+	o.stateMu.Lock()
+	o.paramVersion = version
+	o.stateMu.Unlock()
+
+	o.syncParamVersion()
+
+	log.Info().Int64("version", version).Msg("Strategy parameter version changed")
+}
+
+// ParamVersion returns the parameter set version in effect
+func (o *Orchestrator) ParamVersion() int64 {
+	o.stateMu.RLock()
+	defer o.stateMu.RUnlock()
+	return o.paramVersion
+}
+
+// syncParamVersion pushes the parameter version to every executor trades
+// can be made on
+func (o *Orchestrator) syncParamVersion() {
+	o.stateMu.RLock()
+	version := o.paramVersion
+	executors := []execution.Executor{o.activeExecutor()}
+	for _, exec := range o.executors {
+		executors = append(executors, exec)
+	}
+	o.stateMu.RUnlock()
+	if o.shadow != nil {
+		executors = append(executors, o.shadow.paper)
+	}
+
+	for _, exec := range executors {
+		if v, ok := exec.(paramVersioned); ok {
+			v.SetParamVersion(version)
+		}
+	}
+}
+
+// loadParamVersion restores the parameter version from the settings history
+func (o *Orchestrator) loadParamVersion() {
+	var version int64
+	for _, section := range paramSections {
+		changes, err := o.dataService.GetSettingsHistory(section, 1)
+		if err != nil {
+			log.Warn().Err(err).Str("section", section).Msg("Failed to load parameter version")
+			continue
+		}
+		if len(changes) > 0 && changes[0].ID > version {
+			version = changes[0].ID
+		}
+	}
+
+	o.stateMu.Lock()
+	o.paramVersion = version
+	o.stateMu.Unlock()
+	o.syncParamVersion()
+}
+
+// GetParamVersionPerformance breaks realized trade performance down by the
+// parameter version each position was entered under, optionally for one
+// strategy
+func (o *Orchestrator) GetParamVersionPerformance(strategyName string) (*ParamVersionReport, error) {
+	history, ok := o.activeExecutor().(interface{ GetTrades() []*execution.Trade })
+	if !ok {
+		return nil, fmt.Errorf("executor does not keep trade history")
+	}
+
+	current := o.ParamVersion()
+	byVersion := map[int64]*ParamVersionPerformance{
+		current: {Version: current},
+	}
+	for _, trade := range history.GetTrades() {
+		if trade.RealizedPnL == 0 {
+			continue
+		}
+		if strategyName != "" && trade.Strategy != strategyName {
+			continue
+		}
+
+		p, ok := byVersion[trade.ParamVersion]
+		if !ok {
+			p = &ParamVersionPerformance{Version: trade.ParamVersion}
+			byVersion[trade.ParamVersion] = p
+		}
+		p.Trades++
+		p.NetPnL += trade.RealizedPnL
+		p.Commission += trade.Commission
+		if trade.RealizedPnL > 0 {
+			p.Wins++
+			p.GrossProfit += trade.RealizedPnL
+		} else {
+			p.Losses++
+			p.GrossLoss -= trade.RealizedPnL
+		}
+		executedAt := trade.ExecutedAt
+		if p.FirstTrade == nil || executedAt.Before(*p.FirstTrade) {
+			p.FirstTrade = &executedAt
+		}
+		if p.LastTrade == nil || executedAt.After(*p.LastTrade) {
+			p.LastTrade = &executedAt
+		}
+	}
+
+	report := &ParamVersionReport{
+		CurrentVersion: current,
+		Strategy:       strategyName,
+		Versions:       make([]ParamVersionPerformance, 0, len(byVersion)),
+	}
+	for _, p := range byVersion {
+		if p.Trades > 0 {
+			p.WinRate = float64(p.Wins) / float64(p.Trades)
+			p.AvgPnL = p.NetPnL / float64(p.Trades)
+		}
+		if p.GrossLoss > 0 {
+			p.ProfitFactor = p.GrossProfit / p.GrossLoss
+		}
+		p.Current = p.Version == current
+		o.describeParamVersion(p)
+		report.Versions = append(report.Versions, *p)
+	}
+	sort.Slice(report.Versions, func(i, j int) bool {
+		return report.Versions[i].Version < report.Versions[j].Version
+	})
+
+	// Compare the two most recent versions that have trades
+	var traded []ParamVersionPerformance
+	for _, p := range report.Versions {
+		if p.Trades > 0 {
+			traded = append(traded, p)
+		}
+	}
+	if n := len(traded); n >= 2 {
+		prev, last := traded[n-2], traded[n-1]
+		report.LastTuning = &ParamTuningResult{
+			From:               prev.Version,
+			To:                 last.Version,
+			AvgPnLChange:       last.AvgPnL - prev.AvgPnL,
+			WinRateChange:      last.WinRate - prev.WinRate,
+			ProfitFactorChange: last.ProfitFactor - prev.ProfitFactor,
+			Improved:           last.AvgPnL > prev.AvgPnL,
+		}
+	}
+
+	return report, nil
+}
+
+// describeParamVersion fills in the settings change that started a version
+func (o *Orchestrator) describeParamVersion(p *ParamVersionPerformance) {
+	if p.Version == 0 || o.dataService == nil {
+		return
+	}
+	change, err := o.dataService.GetSettingsChange(p.Version)
+	if err != nil || change == nil {
+		return
+	}
+	changedAt := change.CreatedAt
+	p.Section = change.Section
+	p.ChangedBy = change.ChangedBy
+	p.ChangedAt = &changedAt
+}
@@ -0,0 +1,117 @@
+package orchestrator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// fundingInterval is the time between perpetual futures funding settlements
+const fundingInterval = 8 * time.Hour
+
+// attributePnL folds one journaled fill into the position's P&L breakdown.
+// The breakdown is read back from SQLite each time so it survives restarts
+// while the position is open.
+func (o *Orchestrator) attributePnL(rowID int64, entry journalEntry, order *execution.Order, closed bool) {
+	t := entry.trade
+	if t == nil && !closed {
+		return
+	}
+
+	pnl, err := o.dataService.GetPositionPnL(rowID)
+	if err != nil {
+		log.Error().Err(err).Int64("positionID", rowID).Msg("Failed to read position P&L breakdown")
+		return
+	}
+	if pnl == nil {
+		pnl = &storage.PositionPnL{PositionID: rowID}
+	}
+
+	pos := entry.position
+	if t != nil {
+		commission := commissionInQuote(t)
+		slippage := fillSlippage(t, order)
+		isEntry := (pos.Side == execution.PositionSideLong && t.Side == execution.OrderSideBuy) ||
+			(pos.Side == execution.PositionSideShort && t.Side == execution.OrderSideSell)
+		if isEntry {
+			pnl.EntryCommission += commission
+			pnl.EntrySlippage += slippage
+		} else {
+			pnl.ExitCommission += commission
+			pnl.ExitSlippage += slippage
+			// Executors report realized P&L net of the exit commission
+			pnl.GrossPnL += t.RealizedPnL + t.Commission
+		}
+	} else {
+		// Closed without a fill (liquidated or closed on the exchange);
+		// the last marked P&L is the best estimate of the price move
+		pnl.GrossPnL += pos.UnrealizedPnL
+	}
+
+	if closed {
+		pnl.FundingCost = o.estimateFunding(pos, entry.at)
+	}
+	pnl.NetPnL = pnl.GrossPnL - pnl.EntryCommission - pnl.ExitCommission - pnl.FundingCost
+
+	if err := o.dataService.SavePositionPnL(*pnl); err != nil {
+		log.Error().Err(err).Int64("positionID", rowID).Msg("Failed to save position P&L breakdown")
+	}
+}
+
+// commissionInQuote converts a fill's commission to the quote asset.
+// Commission charged in the base asset (spot buys) is valued at the fill
+// price; other assets such as BNB are taken as quoted.
+func commissionInQuote(t *execution.Trade) float64 {
+	asset := t.CommissionAsset
+	if asset != "" && asset != t.Symbol && strings.HasPrefix(t.Symbol, asset) {
+		return t.Commission * t.Price
+	}
+	return t.Commission
+}
+
+// fillSlippage returns what a fill gave away against its signal price,
+// positive when the fill was worse. Fills without a signal have none.
+func fillSlippage(t *execution.Trade, order *execution.Order) float64 {
+	if order == nil || order.Signal == nil || order.Signal.Price <= 0 {
+		return 0
+	}
+	if t.Side == execution.OrderSideBuy {
+		return (t.Price - order.Signal.Price) * t.Quantity
+	}
+	return (order.Signal.Price - t.Price) * t.Quantity
+}
+
+// estimateFunding estimates the funding a futures position paid over its
+// life from the current rate and the settlements it was open across. Spot
+// positions pay none.
+func (o *Orchestrator) estimateFunding(pos execution.Position, closedAt time.Time) float64 {
+	futures, ok := o.activeExecutor().(interface {
+		GetFunding(symbol string) (*execution.FundingInfo, error)
+	})
+	if !ok || pos.OpenTime.IsZero() {
+		return 0
+	}
+	fi, err := futures.GetFunding(pos.Symbol)
+	if err != nil || fi == nil {
+		return 0
+	}
+
+	// Settlements fall on multiples of the interval since the epoch (UTC)
+	first := pos.OpenTime.Truncate(fundingInterval).Add(fundingInterval)
+	settlements := 0
+	for t := first; !t.After(closedAt); t = t.Add(fundingInterval) {
+		settlements++
+	}
+	return fi.Cost(pos.Side, pos.EntryPrice*pos.Quantity) * float64(settlements)
+}
+
+// GetPositionPnL returns P&L breakdowns for persisted positions by ID
+func (o *Orchestrator) GetPositionPnL(positionIDs []int64) (map[int64]storage.PositionPnL, error) {
+	if o.dataService == nil {
+		return map[int64]storage.PositionPnL{}, nil
+	}
+	return o.dataService.GetPositionPnLs(positionIDs)
+}
@@ -0,0 +1,184 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/rs/zerolog/log"
+)
+
+// pnlRolloverInterval is how often the P&L period boundaries are checked
+const pnlRolloverInterval = time.Minute
+
+// maxPeriodPositions bounds the closed positions read for period P&L when
+// the executor keeps no trade history
+const maxPeriodPositions = 1000
+
+// pnlPeriods tracks the calendar day and week the risk limits apply to
+type pnlPeriods struct {
+	mu        sync.Mutex
+	loc       *time.Location
+	weekStart time.Weekday
+	day       time.Time // Start of the day last rolled over to
+	week      time.Time // Start of the week last rolled over to
+}
+
+// PnLPeriodStatus is the realized P&L of the current calendar day and week
+type PnLPeriodStatus struct {
+	Timezone   string    `json:"timezone"`
+	WeekStarts string    `json:"weekStarts"`
+	DayStart   time.Time `json:"dayStart"`
+	WeekStart  time.Time `json:"weekStart"`
+	NextDay    time.Time `json:"nextDay"`
+	NextWeek   time.Time `json:"nextWeek"`
+	DailyPnL   float64   `json:"dailyPnl"`
+	WeeklyPnL  float64   `json:"weeklyPnl"`
+}
+
+// SetPnLPeriods sets the timezone calendar days are counted in and the day
+// weeks start on. It must be called before Start; the default is UTC days
+// and Monday weeks.
+func (o *Orchestrator) SetPnLPeriods(loc *time.Location, weekStart time.Weekday) {
+	o.pnlPeriods.mu.Lock()
+	defer o.pnlPeriods.mu.Unlock()
+
+	o.pnlPeriods.loc = loc
+	o.pnlPeriods.weekStart = weekStart
+}
+
+// periodStarts returns the first instants of the calendar day and week now
+// falls in
+func (p *pnlPeriods) periodStarts(now time.Time) (day, week time.Time) {
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := now.In(loc)
+	day = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	offset := (int(t.Weekday()) - int(p.weekStart) + 7) % 7
+	week = day.AddDate(0, 0, -offset)
+	return day, week
+}
+
+// pnlPeriodStarts returns the current day and week starts
+func (o *Orchestrator) pnlPeriodStarts(now time.Time) (day, week time.Time) {
+	o.pnlPeriods.mu.Lock()
+	defer o.pnlPeriods.mu.Unlock()
+	return o.pnlPeriods.periodStarts(now)
+}
+
+// periodPnL returns the P&L realized since the start of the calendar day and
+// week. It reads the executor's trade history, or the trade journal for
+// executors without one.
+func (o *Orchestrator) periodPnL(now time.Time) (daily, weekly float64) {
+	dayStart, weekStart := o.pnlPeriodStarts(now)
+
+	if history, ok := o.activeExecutor().(interface{ GetTrades() []*execution.Trade }); ok {
+		for _, trade := range history.GetTrades() {
+			if trade.RealizedPnL == 0 || trade.ExecutedAt.Before(weekStart) {
+				continue
+			}
+			weekly += trade.RealizedPnL
+			if !trade.ExecutedAt.Before(dayStart) {
+				daily += trade.RealizedPnL
+			}
+		}
+		return daily, weekly
+	}
+
+	if o.dataService == nil {
+		return 0, 0
+	}
+	positions, err := o.dataService.GetClosedPositions(maxPeriodPositions)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to read closed positions for period P&L")
+		return 0, 0
+	}
+	for _, pos := range positions {
+		if pos.ClosedAt == nil || pos.ClosedAt.Before(weekStart) {
+			continue
+		}
+		weekly += pos.RealizedPnL
+		if !pos.ClosedAt.Before(dayStart) {
+			daily += pos.RealizedPnL
+		}
+	}
+	return daily, weekly
+}
+
+// GetPnLPeriods returns the current calendar day and week and their
+// realized P&L
+func (o *Orchestrator) GetPnLPeriods() PnLPeriodStatus {
+	now := time.Now()
+	o.pnlPeriods.mu.Lock()
+	day, week := o.pnlPeriods.periodStarts(now)
+	status := PnLPeriodStatus{
+		Timezone:   day.Location().String(),
+		WeekStarts: o.pnlPeriods.weekStart.String(),
+		DayStart:   day,
+		WeekStart:  week,
+		NextDay:    day.AddDate(0, 0, 1),
+		NextWeek:   week.AddDate(0, 0, 7),
+	}
+	o.pnlPeriods.mu.Unlock()
+
+	if o.activeExecutor() != nil {
+		status.DailyPnL, status.WeeklyPnL = o.periodPnL(now)
+	}
+	return status
+}
+
+// rollPnLPeriods resets the risk manager's daily and weekly stats when a
+// new calendar day or week has started since the last check
+func (o *Orchestrator) rollPnLPeriods(now time.Time) {
+	o.pnlPeriods.mu.Lock()
+	day, week := o.pnlPeriods.periodStarts(now)
+	first := o.pnlPeriods.day.IsZero()
+	newDay := !first && !day.Equal(o.pnlPeriods.day)
+	newWeek := !first && !week.Equal(o.pnlPeriods.week)
+	o.pnlPeriods.day = day
+	o.pnlPeriods.week = week
+	o.pnlPeriods.mu.Unlock()
+
+	if first {
+		o.startRiskTally(day, now)
+	} else if newDay {
+		o.closeRiskDay(day)
+	}
+
+	if o.riskManager == nil {
+		return
+	}
+	if newDay {
+		o.riskManager.ResetDailyStats()
+		log.Info().Time("dayStart", day).Msg("Daily P&L period rolled over")
+	}
+	if newWeek {
+		o.riskManager.ResetWeeklyStats()
+		log.Info().Time("weekStart", week).Msg("Weekly P&L period rolled over")
+	}
+	if newDay || newWeek {
+		// Recompute the new period's P&L right away
+		o.updateRiskMetrics()
+	}
+}
+
+// pnlRolloverLoop rolls the daily and weekly P&L over at the calendar
+// boundaries of the configured timezone
+func (o *Orchestrator) pnlRolloverLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(pnlRolloverInterval)
+	defer ticker.Stop()
+
+	o.rollPnLPeriods(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			o.rollPnLPeriods(now)
+			beat()
+		}
+	}
+}
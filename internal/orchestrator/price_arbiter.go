@@ -0,0 +1,170 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+)
+
+// defaultTradeStaleAfter is how long the trade stream may go quiet before
+// kline closes set the price
+const defaultTradeStaleAfter = 2 * time.Second
+
+// PriceSource is a market data stream the bot takes prices from
+type PriceSource string
+
+const (
+	PriceSourceTrade PriceSource = "trade" // Individual trades; takes precedence
+	PriceSourceKline PriceSource = "kline" // Candle closes; stand in while trades are quiet
+)
+
+// priceArbiter decides which stream's price the bot acts on. Around a
+// candle close the kline stream reports a close that can lag trades already
+// received, so trades take precedence and a kline close is only taken once
+// the trade stream has been quiet for staleAfter. Prices are accepted in
+// exchange time order and applied under the lock, so the state, paper fills
+// and stop-loss/take-profit checks all see one monotonic series.
+type priceArbiter struct {
+	mu          sync.Mutex
+	staleAfter  time.Duration
+	price       float64
+	at          time.Time // Exchange time of the accepted price
+	source      PriceSource
+	tradeAt     time.Time // Exchange time of the last accepted trade
+	lastTradeID int64
+	sources     map[PriceSource]*PriceSourceStatus
+}
+
+// PriceSourceStatus is the last price a stream reported and how many of its
+// prices were taken
+type PriceSourceStatus struct {
+	Price      float64   `json:"price"`
+	At         time.Time `json:"at"`         // Exchange time
+	ReceivedAt time.Time `json:"receivedAt"` // Local time
+	Accepted   int64     `json:"accepted"`
+	Rejected   int64     `json:"rejected"` // Out of order, or superseded by trades
+}
+
+// PriceArbiterStatus is the price the bot acts on and where it came from
+type PriceArbiterStatus struct {
+	Symbol          string                            `json:"symbol"`
+	Price           float64                           `json:"price"`
+	At              time.Time                         `json:"at"`
+	Source          PriceSource                       `json:"source"`
+	TradeStaleAfter string                            `json:"tradeStaleAfter"`
+	Sources         map[PriceSource]PriceSourceStatus `json:"sources"`
+}
+
+// SetPriceArbiter sets how long the trade stream may go quiet before kline
+// closes set the price; zero keeps the default. It must be called before
+// Start.
+func (o *Orchestrator) SetPriceArbiter(tradeStaleAfter time.Duration) error {
+	if tradeStaleAfter < 0 {
+		return fmt.Errorf("trade stale after must not be negative")
+	}
+	o.prices.mu.Lock()
+	defer o.prices.mu.Unlock()
+	o.prices.staleAfter = tradeStaleAfter
+	return nil
+}
+
+// offerPrice submits a price from a stream, stamped with its exchange time,
+// and applies it when the arbiter takes it. tradeID is the exchange trade
+// ID of trade prices, zero otherwise. Only the bot's symbol is arbitrated.
+func (o *Orchestrator) offerPrice(source PriceSource, symbol string, price float64, at time.Time, tradeID int64) bool {
+	if symbol != o.config.Symbol || price <= 0 {
+		return false
+	}
+
+	a := &o.prices
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.sources == nil {
+		a.sources = make(map[PriceSource]*PriceSourceStatus)
+	}
+	status := a.sources[source]
+	if status == nil {
+		status = &PriceSourceStatus{}
+		a.sources[source] = status
+	}
+	status.Price, status.At, status.ReceivedAt = price, at, time.Now()
+
+	if !a.accepts(source, at, tradeID) {
+		status.Rejected++
+		return false
+	}
+	status.Accepted++
+	a.price, a.at, a.source = price, at, source
+	if source == PriceSourceTrade {
+		a.tradeAt, a.lastTradeID = at, tradeID
+	}
+
+	o.applyPrice(symbol, price, at)
+	return true
+}
+
+// accepts reports whether a price at the exchange time at may replace the
+// current one (a.mu must be held)
+func (a *priceArbiter) accepts(source PriceSource, at time.Time, tradeID int64) bool {
+	if at.Before(a.at) {
+		return false
+	}
+	switch source {
+	case PriceSourceTrade:
+		return tradeID == 0 || tradeID > a.lastTradeID
+	case PriceSourceKline:
+		staleAfter := a.staleAfter
+		if staleAfter <= 0 {
+			staleAfter = defaultTradeStaleAfter
+		}
+		// A close at the same instant as the current price adds nothing
+		return at.After(a.at) && (a.tradeAt.IsZero() || at.Sub(a.tradeAt) >= staleAfter)
+	}
+	return false
+}
+
+// applyPrice makes an accepted price current and marks the paper accounts
+// to it, which checks their stops and take profits. Paper clocks advance to
+// the exchange time at.
+func (o *Orchestrator) applyPrice(symbol string, price float64, at time.Time) {
+	o.stateMu.Lock()
+	o.state.CurrentPrice = price
+	o.state.LastUpdate = time.Now()
+	o.stateMu.Unlock()
+
+	if paperExec, ok := o.activeExecutor().(*execution.PaperExecutor); ok {
+		paperExec.UpdatePriceAt(symbol, price, at)
+	}
+	if o.shadow != nil {
+		o.shadow.paper.UpdatePriceAt(symbol, price, at)
+	}
+	o.updateAccountPrices(symbol, price, at)
+}
+
+// GetPriceArbiter returns the price the bot acts on and what each stream
+// last reported
+func (o *Orchestrator) GetPriceArbiter() PriceArbiterStatus {
+	a := &o.prices
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	staleAfter := a.staleAfter
+	if staleAfter <= 0 {
+		staleAfter = defaultTradeStaleAfter
+	}
+	status := PriceArbiterStatus{
+		Symbol:          o.config.Symbol,
+		Price:           a.price,
+		At:              a.at,
+		Source:          a.source,
+		TradeStaleAfter: staleAfter.String(),
+		Sources:         make(map[PriceSource]PriceSourceStatus, len(a.sources)),
+	}
+	for source, s := range a.sources {
+		status.Sources[source] = *s
+	}
+	return status
+}
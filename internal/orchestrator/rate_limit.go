@@ -0,0 +1,26 @@
+package orchestrator
+
+import "github.com/eth-trading/internal/binance"
+
+// RequestWeight reports REST request weight usage per Binance client
+type RequestWeight struct {
+	Market  *binance.WeightStats `json:"market,omitempty"`  // Candles, tickers and backtest data
+	Trading *binance.WeightStats `json:"trading,omitempty"` // Live or futures executor
+}
+
+// GetRequestWeight returns how much of the Binance request weight limit
+// each client is using
+func (o *Orchestrator) GetRequestWeight() RequestWeight {
+	var rw RequestWeight
+	if o.binanceClient != nil {
+		stats := o.binanceClient.WeightStats()
+		rw.Market = &stats
+	}
+	if exec, ok := o.activeExecutor().(interface {
+		RequestWeightStats() binance.WeightStats
+	}); ok {
+		stats := exec.RequestWeightStats()
+		rw.Trading = &stats
+	}
+	return rw
+}
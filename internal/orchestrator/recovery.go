@@ -0,0 +1,84 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// maxStateErrors caps the number of errors kept in TradingState
+const maxStateErrors = 50
+
+// recoverPanic recovers a panic raised in a pipeline stage and reports it
+// as a critical alert. Must be deferred directly.
+func (o *Orchestrator) recoverPanic(stage string) {
+	if r := recover(); r != nil {
+		o.reportPanic(stage, "", fmt.Sprint(r), string(debug.Stack()))
+	}
+}
+
+// handleStrategyPanic reports a strategy panic recovered by the strategy manager
+func (o *Orchestrator) handleStrategyPanic(event strategy.PanicEvent) {
+	o.reportPanic("strategy."+event.Stage, event.Strategy, event.Value, event.Stack)
+}
+
+// reportPanic logs a recovered panic, stores a critical alert with the stack
+// trace and broadcasts it to connected clients
+func (o *Orchestrator) reportPanic(stage, strategyName, value, stack string) {
+	message := fmt.Sprintf("Recovered panic in %s: %s", stage, value)
+	if strategyName != "" {
+		message = fmt.Sprintf("Strategy %s panicked in %s and was disabled: %s", strategyName, stage, value)
+	}
+
+	log.Error().
+		Str("stage", stage).
+		Str("strategy", strategyName).
+		Str("panic", value).
+		Str("stack", stack).
+		Msg("Recovered panic")
+
+	o.stateMu.Lock()
+	o.state.Errors = append(o.state.Errors, fmt.Sprintf("%s %s", time.Now().Format(time.RFC3339), message))
+	if len(o.state.Errors) > maxStateErrors {
+		o.state.Errors = o.state.Errors[len(o.state.Errors)-maxStateErrors:]
+	}
+	o.stateMu.Unlock()
+
+	if o.dataService != nil {
+		data, _ := json.Marshal(map[string]interface{}{
+			"stage":    stage,
+			"strategy": strategyName,
+			"panic":    value,
+			"stack":    stack,
+		})
+		if _, err := o.dataService.AddAlert(storage.Alert{
+			Type:     "panic",
+			Severity: "critical",
+			Message:  message,
+			Data:     string(data),
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to record panic alert")
+		}
+	}
+
+	o.broadcastError("PANIC_RECOVERED", message, stack)
+}
+
+// analyzeIndicators computes indicators, with VWAP anchored sessionBars
+// from the end, reporting a panic instead of propagating it. ok is false
+// if the computation panicked.
+func (o *Orchestrator) analyzeIndicators(opens, highs, lows, closes, volumes []float64, sessionBars int) (result indicators.AnalysisResult, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			o.reportPanic("indicators", "", fmt.Sprint(r), string(debug.Stack()))
+			ok = false
+		}
+	}()
+	return o.indicatorMgr.AnalyzeSession(opens, highs, lows, closes, volumes, sessionBars), true
+}
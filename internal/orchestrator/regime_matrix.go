@@ -0,0 +1,238 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+)
+
+const (
+	// maxRegimeMatrixPositions bounds the closed positions read for the
+	// regime matrix
+	maxRegimeMatrixPositions = 5000
+	// regimeMatrixBars is how many primary candles the regime at an entry
+	// is detected over, as for live market data
+	regimeMatrixBars = 200
+	// regimeMinBars is the fewest candles a regime is detected over, as
+	// for live market data
+	regimeMinBars = 50
+	// regimeWarmupBars are detected before an entry's bar so regime
+	// persistence applies as it did live
+	regimeWarmupBars = 3
+	// minRegimeMatrixTrades is how many trades a strategy needs in a regime
+	// to be named the regime's best
+	minRegimeMatrixTrades = 5
+)
+
+// RegimePerformance is the performance of positions entered in one regime,
+// by one strategy or, with Strategy empty, by all of them
+type RegimePerformance struct {
+	Strategy     string  `json:"strategy,omitempty"`
+	Regime       string  `json:"regime"`
+	Trades       int     `json:"trades"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"winRate"`
+	NetPnL       float64 `json:"netPnl"`
+	AvgPnL       float64 `json:"avgPnl"`
+	ProfitFactor float64 `json:"profitFactor"`
+
+	grossProfit, grossLoss float64
+}
+
+// RegimeMatrix cross-tabulates closed positions by strategy and the market
+// regime detected when they were entered
+type RegimeMatrix struct {
+	Timeframe    string              `json:"timeframe"`
+	From         time.Time           `json:"from"`
+	To           time.Time           `json:"to"`
+	Current      string              `json:"current"`      // Regime detected on the last candle
+	Regimes      []string            `json:"regimes"`      // Columns
+	Strategies   []string            `json:"strategies"`   // Rows
+	Cells        []RegimePerformance `json:"cells"`        // Strategy and regime pairs with trades
+	Totals       []RegimePerformance `json:"totals"`       // By regime, across strategies
+	Best         map[string]string   `json:"best"`         // By regime, the strategy with the highest net PnL over enough trades
+	Unclassified int                 `json:"unclassified"` // Positions entered without enough candles stored before them
+}
+
+// add counts a closed position
+func (p *RegimePerformance) add(pnl float64) {
+	p.Trades++
+	p.NetPnL += pnl
+	if pnl > 0 {
+		p.Wins++
+		p.grossProfit += pnl
+	} else {
+		p.Losses++
+		p.grossLoss -= pnl
+	}
+}
+
+// finish derives the ratios once every position is counted
+func (p *RegimePerformance) finish() {
+	if p.Trades > 0 {
+		p.WinRate = float64(p.Wins) / float64(p.Trades)
+		p.AvgPnL = p.NetPnL / float64(p.Trades)
+	}
+	if p.grossLoss > 0 {
+		p.ProfitFactor = p.grossProfit / p.grossLoss
+	}
+}
+
+// GetRegimeMatrix breaks the positions closed in the last days down by
+// strategy and the regime detected on the last primary candle closed when
+// they were entered, replayed from stored candles with the live detector's
+// settings. An empty strategy includes all strategies.
+func (o *Orchestrator) GetRegimeMatrix(strategyName string, days int) (*RegimeMatrix, error) {
+	if o.dataService == nil || o.strategyMgr == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	matrix := &RegimeMatrix{
+		Timeframe: o.config.PrimaryTimeframe,
+		From:      from,
+		To:        to,
+		Current:   o.strategyMgr.GetLastRegime().Regime.String(),
+		Best:      make(map[string]string),
+	}
+	for _, r := range strategy.MarketRegimes {
+		matrix.Regimes = append(matrix.Regimes, r.String())
+	}
+
+	all, err := o.dataService.GetClosedPositions(maxRegimeMatrixPositions)
+	if err != nil {
+		return nil, err
+	}
+	var positions []storage.Position
+	firstEntry := to
+	for _, pos := range all {
+		if pos.ClosedAt == nil || pos.ClosedAt.Before(from) || pos.RealizedPnL == 0 {
+			continue
+		}
+		if strategyName != "" && pos.Strategy != strategyName {
+			continue
+		}
+		if pos.Symbol != "" && pos.Symbol != o.config.Symbol {
+			continue
+		}
+		positions = append(positions, pos)
+		if pos.OpenedAt.Before(firstEntry) {
+			firstEntry = pos.OpenedAt
+		}
+	}
+
+	bar := binance.IntervalToDuration(o.config.PrimaryTimeframe)
+	if bar <= 0 {
+		bar = time.Minute
+	}
+	candles, err := o.dataService.GetHistoricalCandles(o.config.Symbol, o.config.PrimaryTimeframe,
+		firstEntry.Add(-time.Duration(regimeMatrixBars+regimeWarmupBars+1)*bar), to)
+	if err != nil {
+		return nil, err
+	}
+	regimes := o.entryRegimes(candles)
+
+	cells := make(map[string]map[string]*RegimePerformance)
+	totals := make(map[string]*RegimePerformance)
+	for _, pos := range positions {
+		// Last candle closed by the entry
+		i := sort.Search(len(candles), func(j int) bool { return candles[j].CloseTime.After(pos.OpenedAt) }) - 1
+		regime, ok := regimes(i)
+		if !ok {
+			matrix.Unclassified++
+			continue
+		}
+
+		if cells[pos.Strategy] == nil {
+			cells[pos.Strategy] = make(map[string]*RegimePerformance)
+		}
+		cell := cells[pos.Strategy][regime]
+		if cell == nil {
+			cell = &RegimePerformance{Strategy: pos.Strategy, Regime: regime}
+			cells[pos.Strategy][regime] = cell
+		}
+		cell.add(pos.RealizedPnL)
+
+		if totals[regime] == nil {
+			totals[regime] = &RegimePerformance{Regime: regime}
+		}
+		totals[regime].add(pos.RealizedPnL)
+	}
+
+	for name := range cells {
+		matrix.Strategies = append(matrix.Strategies, name)
+	}
+	sort.Strings(matrix.Strategies)
+	for _, regime := range matrix.Regimes {
+		var best *RegimePerformance
+		for _, name := range matrix.Strategies {
+			cell := cells[name][regime]
+			if cell == nil {
+				continue
+			}
+			cell.finish()
+			matrix.Cells = append(matrix.Cells, *cell)
+			if cell.Trades >= minRegimeMatrixTrades && cell.NetPnL > 0 && (best == nil || cell.NetPnL > best.NetPnL) {
+				best = cell
+			}
+		}
+		if best != nil {
+			matrix.Best[regime] = best.Strategy
+		}
+		if total := totals[regime]; total != nil {
+			total.finish()
+			matrix.Totals = append(matrix.Totals, *total)
+		}
+	}
+
+	return matrix, nil
+}
+
+// entryRegimes returns a lookup of the regime detected on candle i, each
+// detected over the candles before it after warming a fresh detector on
+// the bars leading up to it. Lookups are cached; candles too early to
+// classify report false.
+func (o *Orchestrator) entryRegimes(candles []storage.Candle) func(i int) (string, bool) {
+	opens := make([]float64, len(candles))
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, c := range candles {
+		opens[i] = c.Open
+		highs[i] = c.High
+		lows[i] = c.Low
+		closes[i] = c.Close
+		volumes[i] = c.Volume
+	}
+
+	base := o.strategyMgr.GetRegimeDetector()
+	cache := make(map[int]string)
+	return func(i int) (string, bool) {
+		if i < regimeWarmupBars+regimeMinBars-1 || i >= len(candles) {
+			return "", false
+		}
+		if regime, ok := cache[i]; ok {
+			return regime, true
+		}
+
+		detector := base.Clone()
+		var result strategy.RegimeResult
+		for j := i - regimeWarmupBars; j <= i; j++ {
+			start := j + 1 - regimeMatrixBars
+			if start < 0 {
+				start = 0
+			}
+			end := j + 1
+			result = detector.Detect(opens[start:end], highs[start:end], lows[start:end], closes[start:end], volumes[start:end])
+		}
+		cache[i] = result.Regime.String()
+		return cache[i], true
+	}
+}
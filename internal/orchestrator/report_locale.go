@@ -0,0 +1,215 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReportLocale is the locale of users who never set one
+const DefaultReportLocale = "en-US"
+
+// ErrUnknownReportLocale is returned for locales without a preset
+var ErrUnknownReportLocale = errors.New("unknown report locale")
+
+// ReportLocale is how generated reports and exports (CSV, HTML) format
+// numbers, times and amounts for a user. API JSON is never localized.
+type ReportLocale struct {
+	Locale           string `json:"locale"`           // Preset the fields default to, e.g. "de-DE"
+	DecimalSeparator string `json:"decimalSeparator"` // e.g. "," in de-DE
+	GroupSeparator   string `json:"groupSeparator"`   // Thousands separator; empty = none
+	DateFormat       string `json:"dateFormat"`       // Go time layout, e.g. "02.01.2006 15:04"
+	Timezone         string `json:"timezone"`         // IANA name; empty = UTC
+	CurrencySymbol   string `json:"currencySymbol"`   // Prefixed or suffixed to quote currency amounts
+	CurrencySuffix   bool   `json:"currencySuffix"`   // Symbol after the amount, e.g. "1.234,50 $"
+}
+
+// reportLocalePresets are the locales reports can be generated in
+var reportLocalePresets = map[string]ReportLocale{
+	"en-US": {DecimalSeparator: ".", GroupSeparator: ",", DateFormat: "01/02/2006 3:04 PM", CurrencySymbol: "$"},
+	"en-GB": {DecimalSeparator: ".", GroupSeparator: ",", DateFormat: "02/01/2006 15:04", CurrencySymbol: "$"},
+	"de-DE": {DecimalSeparator: ",", GroupSeparator: ".", DateFormat: "02.01.2006 15:04", CurrencySymbol: "$", CurrencySuffix: true},
+	"fr-FR": {DecimalSeparator: ",", GroupSeparator: " ", DateFormat: "02/01/2006 15:04", CurrencySymbol: "$", CurrencySuffix: true},
+	"es-ES": {DecimalSeparator: ",", GroupSeparator: ".", DateFormat: "02/01/2006 15:04", CurrencySymbol: "$", CurrencySuffix: true},
+	"it-IT": {DecimalSeparator: ",", GroupSeparator: ".", DateFormat: "02/01/2006 15:04", CurrencySymbol: "$", CurrencySuffix: true},
+	"pt-BR": {DecimalSeparator: ",", GroupSeparator: ".", DateFormat: "02/01/2006 15:04", CurrencySymbol: "US$"},
+	"ru-RU": {DecimalSeparator: ",", GroupSeparator: " ", DateFormat: "02.01.2006 15:04", CurrencySymbol: "$", CurrencySuffix: true},
+	"ja-JP": {DecimalSeparator: ".", GroupSeparator: ",", DateFormat: "2006/01/02 15:04", CurrencySymbol: "$"},
+	"zh-CN": {DecimalSeparator: ".", GroupSeparator: ",", DateFormat: "2006-01-02 15:04", CurrencySymbol: "US$"},
+}
+
+// ReportLocales lists the locale presets, sorted
+func ReportLocales() []string {
+	names := make([]string, 0, len(reportLocalePresets))
+	for name := range reportLocalePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupReportLocale returns the preset of a locale such as "de-DE"
+func LookupReportLocale(name string) (ReportLocale, error) {
+	preset, ok := reportLocalePresets[name]
+	if !ok {
+		return ReportLocale{}, fmt.Errorf("%w %q, use one of %s", ErrUnknownReportLocale, name, strings.Join(ReportLocales(), ", "))
+	}
+	preset.Locale = name
+	return preset, nil
+}
+
+// withDefaults fills the fields left empty from the locale's preset
+func (l ReportLocale) withDefaults() (ReportLocale, error) {
+	if l.Locale == "" {
+		l.Locale = DefaultReportLocale
+	}
+	preset, err := LookupReportLocale(l.Locale)
+	if err != nil {
+		return ReportLocale{}, err
+	}
+	if l.DecimalSeparator == "" {
+		l.DecimalSeparator = preset.DecimalSeparator
+		l.GroupSeparator = preset.GroupSeparator
+	}
+	if l.DateFormat == "" {
+		l.DateFormat = preset.DateFormat
+	}
+	if l.CurrencySymbol == "" {
+		l.CurrencySymbol = preset.CurrencySymbol
+		l.CurrencySuffix = preset.CurrencySuffix
+	}
+	return l, nil
+}
+
+// validate checks the separators are distinct and the timezone is known
+func (l ReportLocale) validate() error {
+	switch {
+	case len([]rune(l.DecimalSeparator)) != 1:
+		return fmt.Errorf("decimal separator must be one character")
+	case len([]rune(l.GroupSeparator)) > 1:
+		return fmt.Errorf("group separator must be at most one character")
+	case l.GroupSeparator == l.DecimalSeparator:
+		return fmt.Errorf("group and decimal separators must differ")
+	case strings.ContainsAny(l.DecimalSeparator+l.GroupSeparator, "0123456789-"):
+		return fmt.Errorf("separators cannot be digits or '-'")
+	}
+	if _, err := time.LoadLocation(l.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", l.Timezone, err)
+	}
+	return nil
+}
+
+// Number formats v with the given decimals, e.g. "1.234,50" in de-DE
+func (l ReportLocale) Number(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteRune(d)
+	}
+	if frac != "" {
+		b.WriteString(l.DecimalSeparator)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// Money formats a quote currency amount with two decimals and the
+// currency symbol
+func (l ReportLocale) Money(v float64) string {
+	if l.CurrencySuffix {
+		return l.Number(v, 2) + " " + l.CurrencySymbol
+	}
+	if v < 0 {
+		return "-" + l.CurrencySymbol + l.Number(-v, 2)
+	}
+	return l.CurrencySymbol + l.Number(v, 2)
+}
+
+// Percent formats a percentage, e.g. 12.5 as "12,50 %" in de-DE
+func (l ReportLocale) Percent(v float64) string {
+	if l.DecimalSeparator == "," {
+		return l.Number(v, 2) + " %"
+	}
+	return l.Number(v, 2) + "%"
+}
+
+// Time formats t in the locale's timezone and date format
+func (l ReportLocale) Time(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	loc, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(l.DateFormat)
+}
+
+// CSVDelimiter returns the field separator spreadsheets expect in the
+// locale: ';' where the decimal separator is a comma
+func (l ReportLocale) CSVDelimiter() rune {
+	if l.DecimalSeparator == "," {
+		return ';'
+	}
+	return ','
+}
+
+// GetReportLocale returns the report locale set by a user, or the default
+func (o *Orchestrator) GetReportLocale(user string) (ReportLocale, error) {
+	def, _ := ReportLocale{}.withDefaults()
+	if o.dataService == nil {
+		return def, nil
+	}
+	value, err := o.dataService.LoadReportLocale(user)
+	if err != nil || value == "" {
+		return def, err
+	}
+	var locale ReportLocale
+	if err := json.Unmarshal([]byte(value), &locale); err != nil {
+		return def, fmt.Errorf("invalid persisted report locale: %w", err)
+	}
+	if locale, err = locale.withDefaults(); err != nil {
+		return def, err
+	}
+	return locale, nil
+}
+
+// SetReportLocale validates and persists a user's report locale. Fields
+// left empty take the value of the locale's preset.
+func (o *Orchestrator) SetReportLocale(user string, locale ReportLocale) (ReportLocale, error) {
+	locale, err := locale.withDefaults()
+	if err != nil {
+		return ReportLocale{}, err
+	}
+	if err := locale.validate(); err != nil {
+		return ReportLocale{}, err
+	}
+	if o.dataService == nil {
+		return ReportLocale{}, fmt.Errorf("data service not set")
+	}
+
+	data, err := json.Marshal(locale)
+	if err != nil {
+		return ReportLocale{}, err
+	}
+	if err := o.dataService.SaveReportLocale(user, string(data)); err != nil {
+		return ReportLocale{}, err
+	}
+	return locale, nil
+}
@@ -0,0 +1,297 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/risk"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxRiskReports bounds the daily risk reports kept in storage
+	maxRiskReports = 30
+	// maxReportBreakerEvents bounds the circuit-breaker events listed in one report
+	maxReportBreakerEvents = 50
+)
+
+// RiskReport is the risk digest of one calendar day: what the bot wanted
+// to trade, what it blocked and why, and how close it ran to its limits
+type RiskReport struct {
+	Day              string                `json:"day"` // YYYY-MM-DD in the P&L timezone
+	Timezone         string                `json:"timezone"`
+	From             time.Time             `json:"from"` // Start of the tally; after the day start when the bot restarted mid-day
+	To               time.Time             `json:"to"`
+	SignalsGenerated int                   `json:"signalsGenerated"`
+	SignalsApproved  int                   `json:"signalsApproved"`
+	SignalsExecuted  int                   `json:"signalsExecuted"`
+	SignalsRejected  int                   `json:"signalsRejected"`
+	Rejections       map[string]int        `json:"rejections"` // Rejected signals by stage and reason
+	NearMisses       map[string]int        `json:"nearMisses"` // Approved signals by risk warning, e.g. "Approaching daily loss limit"
+	LimitPeaks       LimitPeaks            `json:"limitPeaks"`
+	RiskEvents       map[string]int        `json:"riskEvents"` // Risk events by type
+	CircuitBreakers  []CircuitBreakerEvent `json:"circuitBreakers"`
+}
+
+// LimitPeaks is the highest utilization of each risk limit over a day, as
+// a fraction of the limit (1 = at the limit)
+type LimitPeaks struct {
+	DailyLoss  float64 `json:"dailyLoss"`
+	WeeklyLoss float64 `json:"weeklyLoss"`
+	Drawdown   float64 `json:"drawdown"`
+	Positions  float64 `json:"positions"`
+}
+
+// CircuitBreakerEvent is a trip or reset of the circuit breaker
+type CircuitBreakerEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"` // CIRCUIT_BREAKER or CIRCUIT_BREAKER_RESET
+	Message string    `json:"message"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// RiskReports is the digest of the day in progress and the last closed days
+type RiskReports struct {
+	Current RiskReport   `json:"current"`
+	Reports []RiskReport `json:"reports"` // Most recent first
+}
+
+// riskTally accumulates the current day's risk digest. Tallies live in
+// memory, so a restart starts the day's tally over.
+type riskTally struct {
+	mu     sync.Mutex
+	report RiskReport
+}
+
+// reset starts a tally for the day starting at day
+func (t *riskTally) reset(day, from time.Time) {
+	t.report = RiskReport{
+		Day:             day.Format("2006-01-02"),
+		Timezone:        day.Location().String(),
+		From:            from,
+		Rejections:      make(map[string]int),
+		NearMisses:      make(map[string]int),
+		RiskEvents:      make(map[string]int),
+		CircuitBreakers: []CircuitBreakerEvent{},
+	}
+}
+
+// started reports whether the tally has been reset for a day
+func (t *riskTally) started() bool {
+	return t.report.Day != ""
+}
+
+// recordSignal counts an assessed entry signal. warnings are the risk
+// manager's warnings on an approved entry.
+func (t *riskTally) recordSignal(approved bool, rejectedBy, reason string, warnings []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.started() {
+		return
+	}
+
+	t.report.SignalsGenerated++
+	if !approved {
+		t.report.SignalsRejected++
+		t.report.Rejections[rejectionKey(rejectedBy, reason)]++
+		return
+	}
+	t.report.SignalsApproved++
+	for _, w := range warnings {
+		t.report.NearMisses[w]++
+	}
+}
+
+// recordExecuted counts an entry signal that placed an order
+func (t *riskTally) recordExecuted() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.started() {
+		t.report.SignalsExecuted++
+	}
+}
+
+// observeLimits raises the limit peaks to the current utilization
+func (t *riskTally) observeLimits(limits risk.RiskLimits) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.started() {
+		return
+	}
+
+	peaks := &t.report.LimitPeaks
+	peaks.DailyLoss = math.Max(peaks.DailyLoss, limits.DailyLossPercent)
+	peaks.WeeklyLoss = math.Max(peaks.WeeklyLoss, limits.WeeklyLossPercent)
+	peaks.Drawdown = math.Max(peaks.Drawdown, limits.DrawdownPercent)
+	peaks.Positions = math.Max(peaks.Positions, limits.PositionsPercent)
+}
+
+// recordEvent counts a risk event and lists circuit-breaker trips and resets
+func (t *riskTally) recordEvent(event risk.RiskEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.started() {
+		return
+	}
+
+	t.report.RiskEvents[event.Type.String()]++
+	if event.Type != risk.RiskEventCircuitBreaker && event.Type != risk.RiskEventCircuitBreakerReset {
+		return
+	}
+	if len(t.report.CircuitBreakers) >= maxReportBreakerEvents {
+		return
+	}
+	breaker := CircuitBreakerEvent{
+		Time:    event.Timestamp,
+		Type:    event.Type.String(),
+		Message: event.Message,
+	}
+	if reason, ok := event.Details["reason"].(string); ok {
+		breaker.Reason = reason
+	}
+	t.report.CircuitBreakers = append(t.report.CircuitBreakers, breaker)
+}
+
+// snapshot returns a copy of the day's tally so far
+func (t *riskTally) snapshot(now time.Time) RiskReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := t.report
+	report.To = now
+	report.Rejections = copyCounts(t.report.Rejections)
+	report.NearMisses = copyCounts(t.report.NearMisses)
+	report.RiskEvents = copyCounts(t.report.RiskEvents)
+	report.CircuitBreakers = append([]CircuitBreakerEvent{}, t.report.CircuitBreakers...)
+	return report
+}
+
+// roll closes the day's tally at end and starts the next day's
+func (t *riskTally) roll(day, end time.Time) (RiskReport, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report, ok := t.report, t.started()
+	report.To = end
+	t.reset(day, day)
+	return report, ok
+}
+
+// copyCounts copies a histogram
+func copyCounts(counts map[string]int) map[string]int {
+	c := make(map[string]int, len(counts))
+	for k, v := range counts {
+		c[k] = v
+	}
+	return c
+}
+
+// rejectionKey buckets a rejection for the reasons histogram, e.g.
+// "RiskManager: Daily loss limit exceeded"
+func rejectionKey(rejectedBy, reason string) string {
+	switch rejectedBy {
+	case "LiquidityFilter", "Confluence":
+		// Their reasons quote measured values, so bucket by stage alone
+		return rejectedBy
+	}
+	// Drop the halt reason or sizing detail after the colon
+	if i := strings.Index(reason, ":"); i > 0 {
+		reason = reason[:i]
+	}
+	if reason == "" {
+		return rejectedBy
+	}
+	return rejectedBy + ": " + reason
+}
+
+// startRiskTally starts the first tally at startup, covering the rest of
+// the day
+func (o *Orchestrator) startRiskTally(day, now time.Time) {
+	o.riskTally.mu.Lock()
+	defer o.riskTally.mu.Unlock()
+	if !o.riskTally.started() {
+		o.riskTally.reset(day, now)
+	}
+}
+
+// closeRiskDay compiles the digest of the day that ended at day, persists
+// it and broadcasts it
+func (o *Orchestrator) closeRiskDay(day time.Time) {
+	report, ok := o.riskTally.roll(day, day)
+	if !ok {
+		return
+	}
+
+	log.Info().
+		Str("day", report.Day).
+		Int("generated", report.SignalsGenerated).
+		Int("executed", report.SignalsExecuted).
+		Int("rejected", report.SignalsRejected).
+		Int("circuitBreakers", len(report.CircuitBreakers)).
+		Msg("Daily risk report compiled")
+
+	if err := o.persistRiskReport(report); err != nil {
+		log.Warn().Err(err).Str("day", report.Day).Msg("Failed to persist daily risk report")
+	}
+
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeRiskReport,
+		Timestamp: time.Now(),
+		Data:      report,
+	})
+}
+
+// loadRiskReports reads the persisted daily risk reports, most recent first
+func (o *Orchestrator) loadRiskReports() ([]RiskReport, error) {
+	if o.dataService == nil {
+		return nil, nil
+	}
+	value, err := o.dataService.LoadRiskReports()
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var reports []RiskReport
+	if err := json.Unmarshal([]byte(value), &reports); err != nil {
+		return nil, fmt.Errorf("invalid persisted risk reports: %w", err)
+	}
+	return reports, nil
+}
+
+// persistRiskReport adds a daily report to the persisted ones, keeping the
+// last maxRiskReports
+func (o *Orchestrator) persistRiskReport(report RiskReport) error {
+	if o.dataService == nil {
+		return nil
+	}
+	reports, err := o.loadRiskReports()
+	if err != nil {
+		log.Warn().Err(err).Msg("Discarding unreadable risk reports")
+	}
+	reports = append([]RiskReport{report}, reports...)
+	if len(reports) > maxRiskReports {
+		reports = reports[:maxRiskReports]
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveRiskReports(string(data))
+}
+
+// GetRiskReports returns the digest of the day so far and of the last
+// closed days
+func (o *Orchestrator) GetRiskReports() (RiskReports, error) {
+	reports, err := o.loadRiskReports()
+	if reports == nil {
+		reports = []RiskReport{}
+	}
+	return RiskReports{
+		Current: o.riskTally.snapshot(time.Now()),
+		Reports: reports,
+	}, err
+}
@@ -0,0 +1,67 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxSandboxRuns bounds the sandbox backtests running at once
+	maxSandboxRuns = 2
+	// sandboxTimeout bounds the wall-clock time of one sandbox backtest
+	sandboxTimeout = 20 * time.Second
+)
+
+// ErrSandboxBusy is returned when the sandbox is running its limit of backtests
+var ErrSandboxBusy = fmt.Errorf("sandbox is running %d backtests, retry later", maxSandboxRuns)
+
+// ErrSandboxTimeout is returned for sandbox backtests cut off at the timeout
+var ErrSandboxTimeout = fmt.Errorf("sandbox backtest exceeded %s, shorten the date range", sandboxTimeout)
+
+// sandboxLimiter caps the sandbox backtests running at once. They run on
+// the request goroutine, outside the backtest queue, so they must stay short.
+type sandboxLimiter struct {
+	mu      sync.Mutex
+	running int
+}
+
+// RunSandbox runs a quick backtest synchronously for strategy authors,
+// isolated from the backtest queue and storage. Runs are cut off at
+// sandboxTimeout and at most maxSandboxRuns run at once. The run shares
+// the trading process, so scripted strategies in config should bound
+// their memory with ScriptConfig.MaxCallAlloc, as ScriptLoader does.
+func (o *Orchestrator) RunSandbox(config *backtest.Config, data *backtest.HistoricalData) (result *backtest.Result, err error) {
+	o.sandbox.mu.Lock()
+	if o.sandbox.running >= maxSandboxRuns {
+		o.sandbox.mu.Unlock()
+		return nil, ErrSandboxBusy
+	}
+	o.sandbox.running++
+	o.sandbox.mu.Unlock()
+
+	defer func() {
+		o.sandbox.mu.Lock()
+		o.sandbox.running--
+		o.sandbox.mu.Unlock()
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			o.reportPanic("sandbox", "", fmt.Sprint(r), string(debug.Stack()))
+			result, err = nil, fmt.Errorf("sandbox backtest panicked: %v", r)
+		}
+	}()
+
+	config.Deadline = time.Now().Add(sandboxTimeout)
+	result, err = backtest.NewEngine(config).Run(data)
+	if errors.Is(err, backtest.ErrDeadlineExceeded) {
+		log.Warn().Int("bars", len(data.Candles)).Msg("Sandbox backtest timed out")
+		return nil, ErrSandboxTimeout
+	}
+	return result, err
+}
@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/scanner"
+)
+
+// scanCacheTTL is how long a market scan is served before a new one runs
+const scanCacheTTL = 5 * time.Minute
+
+// marketScan ranks candidate symbols for multi-symbol trading
+type marketScan struct {
+	scanner *scanner.Scanner
+	config  scanner.Config
+
+	mu   sync.Mutex // Also serializes scans, which are heavy on request weight
+	last *scanner.Report
+}
+
+// SetMarketScanner sets the scanner and filters used by ScanMarket
+func (o *Orchestrator) SetMarketScanner(s *scanner.Scanner, cfg scanner.Config) {
+	o.scan.scanner = s
+	o.scan.config = cfg
+}
+
+// ScanMarket returns the latest market scan, running a new one when the
+// last is older than scanCacheTTL or refresh is set
+func (o *Orchestrator) ScanMarket(ctx context.Context, refresh bool) (*scanner.Report, error) {
+	if o.scan.scanner == nil {
+		return nil, fmt.Errorf("market scanner not configured")
+	}
+
+	o.scan.mu.Lock()
+	defer o.scan.mu.Unlock()
+
+	if !refresh && o.scan.last != nil && time.Since(o.scan.last.ScannedAt) < scanCacheTTL {
+		return o.scan.last, nil
+	}
+
+	report, err := o.scan.scanner.Scan(ctx, o.scan.config)
+	if err != nil {
+		return nil, err
+	}
+	o.scan.last = report
+	return report, nil
+}
@@ -0,0 +1,482 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// scheduleCheckInterval is how often the mode schedule is evaluated
+const scheduleCheckInterval = 30 * time.Second
+
+// ScheduledMode is the trading mode requested by a schedule
+type ScheduledMode string
+
+const (
+	ScheduledModeLive   ScheduledMode = "live"
+	ScheduledModePaper  ScheduledMode = "paper"
+	ScheduledModeHalted ScheduledMode = "halted" // No new entries
+)
+
+// CarryPolicy decides what happens to open positions at a mode switch
+type CarryPolicy string
+
+const (
+	// CarryPolicyFlatten closes open positions before the switch
+	CarryPolicyFlatten CarryPolicy = "flatten"
+	// CarryPolicyCarry leaves positions open; positions on the previous
+	// executor are managed again when its mode comes back
+	CarryPolicyCarry CarryPolicy = "carry"
+)
+
+// ModeWindow is a recurring period during which a given mode applies
+type ModeWindow struct {
+	Name   string
+	Days   []time.Weekday // Empty means every day
+	Start  time.Duration  // Offset from local midnight
+	End    time.Duration  // End <= Start wraps past midnight
+	Mode   ScheduledMode
+	Policy CarryPolicy // Overrides the schedule policy when set
+}
+
+// contains reports whether the window covers the given local time
+func (w ModeWindow) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+
+	if w.Start < w.End {
+		return w.onDay(t.Weekday()) && offset >= w.Start && offset < w.End
+	}
+
+	// Overnight window: the tail belongs to the previous day's session
+	if w.onDay(t.Weekday()) && offset >= w.Start {
+		return true
+	}
+	return w.onDay((t.Weekday()+6)%7) && offset < w.End
+}
+
+// onDay reports whether the window runs on the given weekday
+func (w ModeWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ModeSchedule maps wall-clock time to trading modes
+type ModeSchedule struct {
+	Location     *time.Location
+	DefaultMode  ScheduledMode // Mode outside every window
+	Policy       CarryPolicy
+	NotifyBefore time.Duration // Lead time for pre-switch notifications
+	Windows      []ModeWindow  // First matching window wins
+}
+
+// ScheduledTransition describes an upcoming mode switch
+type ScheduledTransition struct {
+	At     time.Time     `json:"at"`
+	From   ScheduledMode `json:"from"`
+	To     ScheduledMode `json:"to"`
+	Window string        `json:"window,omitempty"`
+	Policy CarryPolicy   `json:"policy"`
+}
+
+// ModeAt returns the scheduled mode, and the window providing it, at t
+func (s *ModeSchedule) ModeAt(t time.Time) (ScheduledMode, *ModeWindow) {
+	local := t.In(s.location())
+	for i := range s.Windows {
+		if s.Windows[i].contains(local) {
+			return s.Windows[i].Mode, &s.Windows[i]
+		}
+	}
+	return s.DefaultMode, nil
+}
+
+// NextTransition returns the next time the scheduled mode changes after t,
+// or nil when the mode never changes within the coming week
+func (s *ModeSchedule) NextTransition(t time.Time) *ScheduledTransition {
+	loc := s.location()
+	local := t.In(loc)
+	current, _ := s.ModeAt(t)
+
+	// Modes can only change on a window boundary
+	var boundaries []time.Time
+	for day := -1; day <= 8; day++ {
+		midnight := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, loc)
+		for _, w := range s.Windows {
+			for _, offset := range []time.Duration{w.Start, w.End} {
+				if at := midnight.Add(offset); at.After(t) {
+					boundaries = append(boundaries, at)
+				}
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	for _, at := range boundaries {
+		mode, window := s.ModeAt(at)
+		if mode == current {
+			continue
+		}
+		transition := &ScheduledTransition{
+			At:     at,
+			From:   current,
+			To:     mode,
+			Policy: s.policyFor(window),
+		}
+		if window != nil {
+			transition.Window = window.Name
+		}
+		return transition
+	}
+	return nil
+}
+
+// policyFor returns the carry policy for switching into a window
+func (s *ModeSchedule) policyFor(window *ModeWindow) CarryPolicy {
+	if window != nil && window.Policy != "" {
+		return window.Policy
+	}
+	if s.Policy == "" {
+		return CarryPolicyFlatten
+	}
+	return s.Policy
+}
+
+// location returns the schedule time zone
+func (s *ModeSchedule) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+// ParseTimeOfDay parses "HH:MM" into an offset from midnight ("24:00" is allowed)
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 || hours < 0 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// ParseWeekday parses a weekday name such as "mon" or "Monday"
+func ParseWeekday(value string) (time.Weekday, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if v == name || v == name[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+// scheduleState tracks what the schedule has applied so far
+type scheduleState struct {
+	mu       sync.Mutex
+	applied  ScheduledMode
+	window   string
+	notified time.Time // Transition time already announced
+	paused   bool      // Trading paused by the schedule rather than a user
+}
+
+// ScheduleStatus is the current state of the mode schedule
+type ScheduleStatus struct {
+	Enabled  bool                 `json:"enabled"`
+	Timezone string               `json:"timezone,omitempty"`
+	Mode     string               `json:"mode"`
+	Current  ScheduledMode        `json:"current,omitempty"`
+	Window   string               `json:"window,omitempty"`
+	Next     *ScheduledTransition `json:"next,omitempty"`
+}
+
+// SetModeSchedule sets the schedule that drives automatic mode transitions
+func (o *Orchestrator) SetModeSchedule(schedule *ModeSchedule) {
+	o.schedule = schedule
+}
+
+// RegisterExecutor makes an executor available for switching into mode
+func (o *Orchestrator) RegisterExecutor(mode TradingMode, exec execution.Executor) {
+	o.stateMu.Lock()
+	defer o.stateMu.Unlock()
+	if o.executors == nil {
+		o.executors = make(map[TradingMode]execution.Executor)
+	}
+	o.executors[mode] = exec
+}
+
+// GetScheduleStatus returns the active scheduled mode and the next transition
+func (o *Orchestrator) GetScheduleStatus() *ScheduleStatus {
+	state := o.GetState()
+	status := &ScheduleStatus{Mode: state.Mode.String()}
+	if o.schedule == nil {
+		return status
+	}
+
+	o.scheduleState.mu.Lock()
+	status.Current = o.scheduleState.applied
+	status.Window = o.scheduleState.window
+	o.scheduleState.mu.Unlock()
+
+	status.Enabled = true
+	status.Timezone = o.schedule.location().String()
+	status.Next = o.schedule.NextTransition(time.Now())
+	return status
+}
+
+// SwitchMode swaps the active executor to the one registered for mode,
+// flattening positions on the current executor first when policy requires it
+func (o *Orchestrator) SwitchMode(mode TradingMode, policy CarryPolicy, reason string) error {
+	o.stateMu.RLock()
+	current := o.state.Mode
+	target := o.executors[mode]
+	o.stateMu.RUnlock()
+
+	if mode == current && (target == nil || target == o.activeExecutor()) {
+		return nil
+	}
+	if target == nil {
+		return fmt.Errorf("no executor registered for %s mode", mode)
+	}
+	if mode == TradingModeLive && !o.liveArmed() {
+		return fmt.Errorf("live mode is not armed")
+	}
+
+	if policy == CarryPolicyFlatten {
+		if err := o.flattenPositions(reason); err != nil {
+			return err
+		}
+	}
+
+	o.SetExecutor(target)
+	o.setupExecutorCallbacks()
+
+	o.stateMu.Lock()
+	o.state.Mode = mode
+	price := o.state.CurrentPrice
+	o.stateMu.Unlock()
+	o.config.Mode = mode
+
+	// Prime the paper price cache so the first order doesn't fill at zero
+	if paperExec, ok := target.(*execution.PaperExecutor); ok && price > 0 {
+		paperExec.UpdatePrice(o.config.Symbol, price)
+	}
+	if o.allocator != nil {
+		if equity, err := o.equityOf(target); err == nil {
+			o.applyStrategyBudgets(equity)
+		}
+	}
+
+	log.Info().
+		Str("from", current.String()).
+		Str("to", mode.String()).
+		Str("policy", string(policy)).
+		Str("reason", reason).
+		Msg("Trading mode switched")
+	o.RecordAudit(storage.AuditEntry{
+		Category: storage.AuditConfig,
+		Action:   AuditModeSwitch,
+		OldValue: AuditValue(map[string]string{"mode": current.String()}),
+		NewValue: AuditValue(map[string]string{"mode": mode.String(), "policy": string(policy), "reason": reason}),
+	}, nil)
+	return nil
+}
+
+// flattenPositions closes every open position on the active executor
+func (o *Orchestrator) flattenPositions(reason string) error {
+	exec := o.activeExecutor()
+	positions, err := exec.GetPositions()
+	if err != nil {
+		return fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	var failed []string
+	for _, pos := range positions {
+		_, err := exec.ClosePosition(pos.ID)
+		o.auditPositionClose("", pos, reason, err)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%d: %v", pos.ID, err))
+			continue
+		}
+		log.Info().Int64("positionId", pos.ID).Str("reason", reason).Msg("Position flattened before mode switch")
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to flatten positions: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// applySchedule moves the orchestrator into the mode scheduled for now and
+// announces upcoming transitions
+func (o *Orchestrator) applySchedule(now time.Time) {
+	defer o.recoverPanic("schedule")
+
+	mode, window := o.schedule.ModeAt(now)
+	windowName := ""
+	if window != nil {
+		windowName = window.Name
+	}
+
+	o.scheduleState.mu.Lock()
+	previous := o.scheduleState.applied
+	o.scheduleState.mu.Unlock()
+
+	if mode != previous {
+		policy := o.schedule.policyFor(window)
+		reason := "schedule"
+		if windowName != "" {
+			reason = "schedule window " + windowName
+		}
+		if err := o.transitionTo(mode, policy, reason); err != nil {
+			log.Error().Err(err).Str("mode", string(mode)).Msg("Scheduled mode switch failed")
+			o.broadcastError("MODE_SWITCH_FAILED", fmt.Sprintf("Scheduled switch to %s failed", mode), err.Error())
+			// Leave applied unchanged so the switch is retried on the next check
+		} else {
+			o.scheduleState.mu.Lock()
+			o.scheduleState.applied = mode
+			o.scheduleState.window = windowName
+			o.scheduleState.mu.Unlock()
+
+			o.notifyModeChange("switched", previous, mode, windowName, policy, reason, now)
+		}
+	}
+
+	next := o.schedule.NextTransition(now)
+	if next == nil || o.schedule.NotifyBefore <= 0 || next.At.Sub(now) > o.schedule.NotifyBefore {
+		return
+	}
+
+	o.scheduleState.mu.Lock()
+	announced := o.scheduleState.notified.Equal(next.At)
+	o.scheduleState.notified = next.At
+	o.scheduleState.mu.Unlock()
+
+	if !announced {
+		o.notifyModeChange("pending", next.From, next.To, next.Window, next.Policy,
+			fmt.Sprintf("switching in %s", next.At.Sub(now).Round(time.Minute)), next.At)
+	}
+}
+
+// transitionTo performs the actions needed to enter a scheduled mode
+func (o *Orchestrator) transitionTo(mode ScheduledMode, policy CarryPolicy, reason string) error {
+	switch mode {
+	case ScheduledModeHalted:
+		if policy == CarryPolicyFlatten {
+			if err := o.flattenPositions(reason); err != nil {
+				return err
+			}
+		}
+		o.stateMu.RLock()
+		alreadyPaused := o.state.IsPaused
+		o.stateMu.RUnlock()
+		if !alreadyPaused {
+			o.Pause()
+			o.scheduleState.mu.Lock()
+			o.scheduleState.paused = true
+			o.scheduleState.mu.Unlock()
+		}
+		return nil
+
+	case ScheduledModeLive, ScheduledModePaper:
+		target := TradingModePaper
+		if mode == ScheduledModeLive {
+			target = TradingModeLive
+		}
+		if err := o.SwitchMode(target, policy, reason); err != nil {
+			return err
+		}
+
+		// Only lift a pause the schedule itself put in place
+		o.scheduleState.mu.Lock()
+		resume := o.scheduleState.paused
+		o.scheduleState.paused = false
+		o.scheduleState.mu.Unlock()
+		if resume {
+			o.Resume()
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown scheduled mode %q", mode)
+	}
+}
+
+// notifyModeChange broadcasts a mode transition and records it as an alert
+func (o *Orchestrator) notifyModeChange(event string, from, to ScheduledMode, window string, policy CarryPolicy, reason string, at time.Time) {
+	update := ModeUpdate{
+		Event:       event,
+		From:        string(from),
+		To:          string(to),
+		Window:      window,
+		Policy:      string(policy),
+		Reason:      reason,
+		EffectiveAt: at,
+	}
+
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeMode,
+		Timestamp: time.Now(),
+		Data:      update,
+	})
+
+	if o.dataService == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Trading mode switched to %s", to)
+	if event == "pending" {
+		message = fmt.Sprintf("Trading mode switches from %s to %s at %s (%s)",
+			from, to, at.Format(time.RFC3339), policy)
+	}
+	data, _ := json.Marshal(update)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "mode_schedule",
+		Severity: "info",
+		Message:  message,
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record mode schedule alert")
+	}
+}
+
+// scheduleLoop evaluates the mode schedule periodically
+func (o *Orchestrator) scheduleLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			o.applySchedule(now)
+			beat()
+		}
+	}
+}
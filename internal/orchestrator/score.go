@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+// scoreBroadcastInterval is the minimum time between score broadcasts
+const scoreBroadcastInterval = 5 * time.Second
+
+// ScoreUpdate is the scorer's breakdown of the latest analysis
+type ScoreUpdate struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Timestamp time.Time `json:"timestamp"` // When the analysis ran
+	Action    string    `json:"action"`    // Recommendation after regime filters
+	Reason    string    `json:"reason,omitempty"`
+	strategy.ScoreBreakdown
+}
+
+// scoreBroadcaster throttles score broadcasts
+type scoreBroadcaster struct {
+	mu       sync.Mutex
+	lastSent time.Time
+}
+
+// GetScoreBreakdown returns the breakdown of the latest analysis, or nil
+// before the first one
+func (o *Orchestrator) GetScoreBreakdown() *ScoreUpdate {
+	if o.strategyMgr == nil {
+		return nil
+	}
+	return o.scoreUpdate(o.strategyMgr.GetLastResult())
+}
+
+// scoreUpdate builds the breakdown of an analysis
+func (o *Orchestrator) scoreUpdate(analysis *strategy.AnalysisOutput) *ScoreUpdate {
+	if analysis == nil || o.strategyMgr == nil {
+		return nil
+	}
+	return &ScoreUpdate{
+		Symbol:         analysis.Symbol,
+		Timeframe:      analysis.Timeframe,
+		Timestamp:      analysis.Timestamp,
+		Action:         analysis.Recommendation.Action.String(),
+		Reason:         analysis.Recommendation.Reason,
+		ScoreBreakdown: o.strategyMgr.GetScorer().Breakdown(analysis.Score, analysis.Regime),
+	}
+}
+
+// broadcastScore broadcasts the breakdown of an analysis, at most once per
+// scoreBroadcastInterval
+func (o *Orchestrator) broadcastScore(analysis *strategy.AnalysisOutput) {
+	now := time.Now()
+	o.scoreBroadcast.mu.Lock()
+	if now.Sub(o.scoreBroadcast.lastSent) < scoreBroadcastInterval {
+		o.scoreBroadcast.mu.Unlock()
+		return
+	}
+	o.scoreBroadcast.lastSent = now
+	o.scoreBroadcast.mu.Unlock()
+
+	update := o.scoreUpdate(analysis)
+	if update == nil {
+		return
+	}
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeScore,
+		Timestamp: now,
+		Data:      update,
+	})
+}
@@ -0,0 +1,151 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+)
+
+// maxSessionPositions bounds the closed positions read for session stats
+const maxSessionPositions = 5000
+
+// SessionPerformance is the trading and market activity of one session
+type SessionPerformance struct {
+	Session strategy.TradingSession `json:"session"`
+
+	// Closed positions, attributed to the session they were entered in
+	Trades       int     `json:"trades"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"winRate"`
+	NetPnL       float64 `json:"netPnl"`
+	AvgPnL       float64 `json:"avgPnl"`
+	ProfitFactor float64 `json:"profitFactor"`
+
+	// Primary timeframe candles opened in the session
+	Candles     int     `json:"candles"`
+	Volatility  float64 `json:"volatility"`  // Standard deviation of close-to-close returns
+	AvgRange    float64 `json:"avgRange"`    // Average high-low range as a fraction of the open
+	AvgVolume   float64 `json:"avgVolume"`   // Average candle volume
+	VolumeShare float64 `json:"volumeShare"` // Share of the period's volume
+
+	grossProfit, grossLoss float64
+	returns                []float64
+	volume                 float64
+}
+
+// SessionReport breaks performance down by trading session
+type SessionReport struct {
+	Strategy  string               `json:"strategy,omitempty"`
+	Timeframe string               `json:"timeframe"`
+	From      time.Time            `json:"from"`
+	To        time.Time            `json:"to"`
+	Current   string               `json:"current"`
+	Sessions  []SessionPerformance `json:"sessions"`
+}
+
+// GetSessionPerformance breaks the win rate of positions closed in the
+// last days, and the volatility and volume of the primary timeframe, down
+// by trading session. An empty strategy includes all strategies.
+func (o *Orchestrator) GetSessionPerformance(strategyName string, days int) (*SessionReport, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not available")
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	report := &SessionReport{
+		Strategy:  strategyName,
+		Timeframe: o.config.PrimaryTimeframe,
+		From:      from,
+		To:        to,
+		Current:   string(strategy.SessionAt(to)),
+	}
+
+	bySession := make(map[strategy.TradingSession]*SessionPerformance, len(strategy.TradingSessions))
+	for _, s := range strategy.TradingSessions {
+		bySession[s] = &SessionPerformance{Session: s}
+	}
+
+	positions, err := o.dataService.GetClosedPositions(maxSessionPositions)
+	if err != nil {
+		return nil, err
+	}
+	for _, pos := range positions {
+		if pos.ClosedAt == nil || pos.ClosedAt.Before(from) || pos.RealizedPnL == 0 {
+			continue
+		}
+		if strategyName != "" && pos.Strategy != strategyName {
+			continue
+		}
+		p := bySession[strategy.SessionAt(pos.OpenedAt)]
+		p.Trades++
+		p.NetPnL += pos.RealizedPnL
+		if pos.RealizedPnL > 0 {
+			p.Wins++
+			p.grossProfit += pos.RealizedPnL
+		} else {
+			p.Losses++
+			p.grossLoss -= pos.RealizedPnL
+		}
+	}
+
+	candles, err := o.dataService.GetHistoricalCandles(o.config.Symbol, o.config.PrimaryTimeframe, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var totalVolume float64
+	for i, c := range candles {
+		p := bySession[strategy.SessionAt(c.OpenTime)]
+		p.Candles++
+		p.volume += c.Volume
+		totalVolume += c.Volume
+		if c.Open > 0 {
+			p.AvgRange += (c.High - c.Low) / c.Open
+		}
+		if i > 0 && candles[i-1].Close > 0 {
+			p.returns = append(p.returns, c.Close/candles[i-1].Close-1)
+		}
+	}
+
+	for _, s := range strategy.TradingSessions {
+		p := bySession[s]
+		if p.Trades > 0 {
+			p.WinRate = float64(p.Wins) / float64(p.Trades)
+			p.AvgPnL = p.NetPnL / float64(p.Trades)
+		}
+		if p.grossLoss > 0 {
+			p.ProfitFactor = p.grossProfit / p.grossLoss
+		}
+		if p.Candles > 0 {
+			p.AvgRange /= float64(p.Candles)
+			p.AvgVolume = p.volume / float64(p.Candles)
+		}
+		if totalVolume > 0 {
+			p.VolumeShare = p.volume / totalVolume
+		}
+		p.Volatility = stdDev(p.returns)
+		report.Sessions = append(report.Sessions, *p)
+	}
+
+	return report, nil
+}
+
+// stdDev returns the population standard deviation of values
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
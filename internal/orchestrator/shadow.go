@@ -0,0 +1,296 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/strategy"
+)
+
+// maxReconciliationEntries caps the trades kept for the reconciliation report
+const maxReconciliationEntries = 500
+
+// ExecutionLeg is one side (live or shadow paper) of a reconciled trade
+type ExecutionLeg struct {
+	OrderID          string    `json:"orderId,omitempty"`
+	PositionID       int64     `json:"positionId,omitempty"`
+	Quantity         float64   `json:"quantity"`
+	EntryPrice       float64   `json:"entryPrice"`
+	EntrySlippageBps float64   `json:"entrySlippageBps"` // Adverse slippage vs signal price
+	ExitPrice        float64   `json:"exitPrice,omitempty"`
+	ExitReason       string    `json:"exitReason,omitempty"`
+	ExitTime         time.Time `json:"exitTime,omitempty"`
+	Commission       float64   `json:"commission"` // Entry plus exit commission
+	NetPnL           float64   `json:"netPnl"`
+	Closed           bool      `json:"closed"`
+	Error            string    `json:"error,omitempty"`
+}
+
+// ReconciliationEntry pairs the live and shadow paper execution of one signal
+type ReconciliationEntry struct {
+	ID              int64               `json:"id"`
+	Timestamp       time.Time           `json:"timestamp"`
+	Strategy        string              `json:"strategy"`
+	Side            execution.OrderSide `json:"side"`
+	SignalPrice     float64             `json:"signalPrice"`
+	Live            ExecutionLeg        `json:"live"`
+	Paper           ExecutionLeg        `json:"paper"`
+	SlippageDiffBps float64             `json:"slippageDiffBps"` // Live minus paper entry slippage
+	PnLDivergence   float64             `json:"pnlDivergence"`   // Live minus paper net P&L
+	Reconciled      bool                `json:"reconciled"`      // Both legs closed
+}
+
+// ReconciliationSummary aggregates divergence across reconciled trades
+type ReconciliationSummary struct {
+	Trades              int     `json:"trades"`
+	Reconciled          int     `json:"reconciled"`
+	AvgLiveSlippageBps  float64 `json:"avgLiveSlippageBps"`
+	AvgPaperSlippageBps float64 `json:"avgPaperSlippageBps"`
+	AvgSlippageDiffBps  float64 `json:"avgSlippageDiffBps"`
+	LiveCommission      float64 `json:"liveCommission"`
+	PaperCommission     float64 `json:"paperCommission"`
+	LiveNetPnL          float64 `json:"liveNetPnl"`
+	PaperNetPnL         float64 `json:"paperNetPnl"`
+	TotalPnLDivergence  float64 `json:"totalPnlDivergence"`
+	AvgPnLDivergence    float64 `json:"avgPnlDivergence"`
+}
+
+// ReconciliationReport compares live fills with the shadow paper account
+type ReconciliationReport struct {
+	Summary ReconciliationSummary  `json:"summary"`
+	Entries []*ReconciliationEntry `json:"entries"` // Newest first
+}
+
+// shadowReconciler mirrors live orders onto a paper account and pairs the results
+type shadowReconciler struct {
+	paper   *execution.PaperExecutor
+	entries []*ReconciliationEntry
+	byLive  map[int64]*ReconciliationEntry // Keyed by live position ID
+	byPaper map[int64]*ReconciliationEntry // Keyed by paper position ID
+	nextID  int64
+	mu      sync.Mutex
+}
+
+// SetShadowExecutor attaches a paper account that mirrors every live order
+// so live execution can be reconciled against simulation
+func (o *Orchestrator) SetShadowExecutor(paper *execution.PaperExecutor) {
+	if paper == nil {
+		o.shadow = nil
+		return
+	}
+
+	s := &shadowReconciler{
+		paper:   paper,
+		byLive:  make(map[int64]*ReconciliationEntry),
+		byPaper: make(map[int64]*ReconciliationEntry),
+	}
+	paper.SetOnPosition(func(event execution.PositionEvent) {
+		defer o.recoverPanic("shadow.onPosition")
+		s.recordExit(event, false)
+	})
+	o.shadow = s
+}
+
+// GetReconciliationReport returns live vs shadow paper divergence, newest first
+func (o *Orchestrator) GetReconciliationReport(limit int) *ReconciliationReport {
+	if o.shadow == nil {
+		return nil
+	}
+	return o.shadow.report(limit)
+}
+
+// mirrorOrder places the live order on the shadow account and records the pair
+func (o *Orchestrator) mirrorOrder(order *execution.Order, signal strategy.Signal, live *execution.ExecutionResult) {
+	if o.shadow == nil || o.config.Mode != TradingModeLive {
+		return
+	}
+	defer o.recoverPanic("shadow.mirror")
+
+	shadowOrder := &execution.Order{
+		Symbol:   order.Symbol,
+		Side:     order.Side,
+		Type:     order.Type,
+		Quantity: order.Quantity,
+		Price:    order.Price,
+		Strategy: order.Strategy,
+		Signal:   order.Signal,
+	}
+	paper, err := o.shadow.paper.PlaceOrder(shadowOrder)
+	if err == nil && paper.Success && paper.Position != nil {
+		if signal.StopLoss > 0 {
+			o.shadow.paper.UpdateStopLoss(paper.Position.ID, signal.StopLoss)
+		}
+		if signal.TakeProfit > 0 {
+			o.shadow.paper.UpdateTakeProfit(paper.Position.ID, signal.TakeProfit)
+		}
+	}
+
+	o.shadow.recordEntry(order, signal, live, paper, err)
+}
+
+// recordEntry pairs the live and shadow results of a new position
+func (s *shadowReconciler) recordEntry(order *execution.Order, signal strategy.Signal, live, paper *execution.ExecutionResult, paperErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Orders that add to or close a tracked position are not new trades
+	if live.Position != nil {
+		if _, tracked := s.byLive[live.Position.ID]; tracked {
+			return
+		}
+	}
+
+	s.nextID++
+	entry := &ReconciliationEntry{
+		ID:          s.nextID,
+		Timestamp:   time.Now(),
+		Strategy:    signal.Strategy,
+		Side:        order.Side,
+		SignalPrice: signal.Price,
+		Live:        entryLeg(order.Side, signal.Price, live, nil),
+		Paper:       entryLeg(order.Side, signal.Price, paper, paperErr),
+	}
+	entry.SlippageDiffBps = entry.Live.EntrySlippageBps - entry.Paper.EntrySlippageBps
+
+	if entry.Live.PositionID != 0 {
+		s.byLive[entry.Live.PositionID] = entry
+	}
+	if entry.Paper.PositionID != 0 {
+		s.byPaper[entry.Paper.PositionID] = entry
+	}
+
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > maxReconciliationEntries {
+		dropped := s.entries[0]
+		s.entries = s.entries[1:]
+		delete(s.byLive, dropped.Live.PositionID)
+		delete(s.byPaper, dropped.Paper.PositionID)
+	}
+}
+
+// recordExit completes a leg when its position closes
+func (s *shadowReconciler) recordExit(event execution.PositionEvent, live bool) {
+	switch event.Type {
+	case execution.PositionEventClosed, execution.PositionEventStopLossHit, execution.PositionEventTakeProfitHit:
+	default:
+		return
+	}
+	if event.Position == nil || event.Trade == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, leg := s.byPaper, func(e *ReconciliationEntry) *ExecutionLeg { return &e.Paper }
+	if live {
+		index, leg = s.byLive, func(e *ReconciliationEntry) *ExecutionLeg { return &e.Live }
+	}
+
+	entry, ok := index[event.Position.ID]
+	if !ok {
+		return
+	}
+	delete(index, event.Position.ID)
+
+	l := leg(entry)
+	l.ExitPrice = event.Trade.Price
+	l.ExitReason = event.Type.String()
+	l.ExitTime = event.Trade.ExecutedAt
+	l.Commission += event.Trade.Commission
+	l.NetPnL = legGrossPnL(entry.Side, l) - l.Commission
+	l.Closed = true
+
+	if entry.Live.Closed && entry.Paper.Closed {
+		entry.PnLDivergence = entry.Live.NetPnL - entry.Paper.NetPnL
+		entry.Reconciled = true
+	}
+}
+
+// report builds the reconciliation report
+func (s *shadowReconciler) report(limit int) *ReconciliationReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := &ReconciliationReport{}
+	summary := &report.Summary
+
+	for _, e := range s.entries {
+		summary.Trades++
+		if !e.Reconciled {
+			continue
+		}
+		summary.Reconciled++
+		summary.AvgLiveSlippageBps += e.Live.EntrySlippageBps
+		summary.AvgPaperSlippageBps += e.Paper.EntrySlippageBps
+		summary.AvgSlippageDiffBps += e.SlippageDiffBps
+		summary.LiveCommission += e.Live.Commission
+		summary.PaperCommission += e.Paper.Commission
+		summary.LiveNetPnL += e.Live.NetPnL
+		summary.PaperNetPnL += e.Paper.NetPnL
+		summary.TotalPnLDivergence += e.PnLDivergence
+	}
+	if n := float64(summary.Reconciled); n > 0 {
+		summary.AvgLiveSlippageBps /= n
+		summary.AvgPaperSlippageBps /= n
+		summary.AvgSlippageDiffBps /= n
+		summary.AvgPnLDivergence = summary.TotalPnLDivergence / n
+	}
+
+	if limit <= 0 || limit > len(s.entries) {
+		limit = len(s.entries)
+	}
+	report.Entries = make([]*ReconciliationEntry, 0, limit)
+	for i := len(s.entries) - 1; i >= 0 && len(report.Entries) < limit; i-- {
+		e := *s.entries[i]
+		report.Entries = append(report.Entries, &e)
+	}
+
+	return report
+}
+
+// entryLeg builds a leg from an order result
+func entryLeg(side execution.OrderSide, signalPrice float64, result *execution.ExecutionResult, err error) ExecutionLeg {
+	var leg ExecutionLeg
+	switch {
+	case err != nil:
+		leg.Error = err.Error()
+		return leg
+	case result == nil:
+		leg.Error = "no execution result"
+		return leg
+	case !result.Success:
+		leg.Error = result.Message
+		return leg
+	}
+
+	if result.Order != nil {
+		leg.OrderID = result.Order.ID
+	}
+	if result.Position != nil {
+		leg.PositionID = result.Position.ID
+	}
+	if result.Trade != nil {
+		leg.Quantity = result.Trade.Quantity
+		leg.EntryPrice = result.Trade.Price
+		leg.Commission = result.Trade.Commission
+	}
+
+	if signalPrice > 0 && leg.EntryPrice > 0 {
+		slippage := (leg.EntryPrice - signalPrice) / signalPrice
+		if side == execution.OrderSideSell {
+			slippage = -slippage
+		}
+		leg.EntrySlippageBps = slippage * 10000
+	}
+	return leg
+}
+
+// legGrossPnL returns a closed leg's P&L before commission
+func legGrossPnL(side execution.OrderSide, leg *ExecutionLeg) float64 {
+	if side == execution.OrderSideSell {
+		return (leg.EntryPrice - leg.ExitPrice) * leg.Quantity
+	}
+	return (leg.ExitPrice - leg.EntryPrice) * leg.Quantity
+}
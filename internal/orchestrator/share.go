@@ -0,0 +1,357 @@
+package orchestrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ShareBundleFormat identifies a shared result bundle
+	ShareBundleFormat = "eth-bot-share"
+	// ShareBundleVersion is the bundle layout written by this build
+	ShareBundleVersion = 1
+
+	// shareNominalEquity is the starting equity amounts are rescaled to,
+	// so a bundle does not reveal the size of the account
+	shareNominalEquity = 10000.0
+
+	maxShareTrades  = 5000
+	maxShareCandles = 5000
+	// shareClosedPositionScan bounds the closed positions read for an export
+	shareClosedPositionScan = 100000
+)
+
+// ErrShareEmpty is returned when there are no closed trades to share
+var ErrShareEmpty = errors.New("no closed trades in the selected period")
+
+// ShareBundle is a shareable record of trading results. It holds no order,
+// position or account identifiers, and amounts are rescaled to a nominal
+// starting equity; prices and times are kept so results can be charted.
+type ShareBundle struct {
+	Format         string              `json:"format"`
+	Version        int                 `json:"version"`
+	Name           string              `json:"name,omitempty"`
+	Symbol         string              `json:"symbol"`
+	Timeframe      string              `json:"timeframe"`
+	ConfigHash     string              `json:"configHash,omitempty"` // Fingerprint of the strategy settings
+	From           time.Time           `json:"from"`
+	To             time.Time           `json:"to"`
+	StartingEquity float64             `json:"startingEquity"`
+	Summary        ShareSummary        `json:"summary"`
+	Trades         []SharedTrade       `json:"trades"`
+	EquityCurve    []SharedEquityPoint `json:"equityCurve"`
+	Candles        []SharedCandle      `json:"candles,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt"`
+}
+
+// ShareSummary is the headline performance of a bundle
+type ShareSummary struct {
+	Trades         int     `json:"trades"`
+	Wins           int     `json:"wins"`
+	Losses         int     `json:"losses"`
+	WinRate        float64 `json:"winRate"`
+	NetPnL         float64 `json:"netPnl"`
+	ReturnPct      float64 `json:"returnPct"`
+	MaxDrawdownPct float64 `json:"maxDrawdownPct"`
+	ProfitFactor   float64 `json:"profitFactor"`
+}
+
+// SharedTrade is a closed position in a bundle
+type SharedTrade struct {
+	Strategy   string    `json:"strategy"`
+	Side       string    `json:"side"`
+	EntryPrice float64   `json:"entryPrice"`
+	ExitPrice  float64   `json:"exitPrice"`
+	Quantity   float64   `json:"quantity"` // Rescaled
+	PnL        float64   `json:"pnl"`      // Rescaled
+	ReturnPct  float64   `json:"returnPct"`
+	OpenedAt   time.Time `json:"openedAt"`
+	ClosedAt   time.Time `json:"closedAt"`
+}
+
+// SharedEquityPoint is a point of a bundle's equity curve
+type SharedEquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"` // Rescaled
+}
+
+// SharedCandle is a primary timeframe candle of a bundle
+type SharedCandle struct {
+	Time   time.Time `json:"time"` // Open time
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// ShareExportOptions selects what goes into a bundle
+type ShareExportOptions struct {
+	Name           string
+	ConfigHash     string
+	From           time.Time // Zero = from the first trade
+	To             time.Time // Zero = now
+	IncludeCandles bool
+}
+
+// ExportShareBundle builds a shareable bundle of the trades closed in the
+// selected period
+func (o *Orchestrator) ExportShareBundle(opts ShareExportOptions) (*ShareBundle, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+
+	positions, err := o.dataService.GetClosedPositions(shareClosedPositionScan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load closed positions: %w", err)
+	}
+	var closed []storage.Position
+	for _, p := range positions {
+		if p.ClosedAt == nil || p.Symbol != o.config.Symbol {
+			continue
+		}
+		if p.ClosedAt.Before(opts.From) || p.ClosedAt.After(opts.To) {
+			continue
+		}
+		closed = append(closed, p)
+	}
+	if len(closed) == 0 {
+		return nil, ErrShareEmpty
+	}
+	if len(closed) > maxShareTrades {
+		return nil, fmt.Errorf("%d trades in the selected period, at most %d can be shared", len(closed), maxShareTrades)
+	}
+	sort.Slice(closed, func(i, j int) bool { return closed[i].ClosedAt.Before(*closed[j].ClosedAt) })
+
+	if opts.From.IsZero() {
+		opts.From = closed[0].OpenedAt
+	}
+
+	// Equity at the start of the period, less the P&L closed since
+	startEquity := o.GetState().Equity
+	for _, p := range positions {
+		if p.ClosedAt != nil && p.Symbol == o.config.Symbol && !p.ClosedAt.Before(opts.From) {
+			startEquity -= p.RealizedPnL
+		}
+	}
+	if startEquity <= 0 {
+		startEquity = o.config.InitialCapital
+	}
+	scale := shareNominalEquity / startEquity
+
+	bundle := &ShareBundle{
+		Format:         ShareBundleFormat,
+		Version:        ShareBundleVersion,
+		Name:           opts.Name,
+		Symbol:         o.config.Symbol,
+		Timeframe:      o.config.PrimaryTimeframe,
+		ConfigHash:     opts.ConfigHash,
+		From:           opts.From,
+		To:             opts.To,
+		StartingEquity: shareNominalEquity,
+		Trades:         make([]SharedTrade, len(closed)),
+		EquityCurve:    []SharedEquityPoint{{Time: opts.From, Equity: shareNominalEquity}},
+		CreatedAt:      time.Now(),
+	}
+
+	equity := shareNominalEquity
+	for i, p := range closed {
+		pnl := p.RealizedPnL * scale
+		t := SharedTrade{
+			Strategy:   p.Strategy,
+			Side:       p.Side,
+			EntryPrice: p.EntryPrice,
+			ExitPrice:  p.CurrentPrice,
+			Quantity:   p.Quantity * scale,
+			PnL:        pnl,
+			OpenedAt:   p.OpenedAt,
+			ClosedAt:   *p.ClosedAt,
+		}
+		if notional := p.EntryPrice * p.Quantity; notional > 0 {
+			t.ReturnPct = p.RealizedPnL / notional * 100
+		}
+		bundle.Trades[i] = t
+
+		equity += pnl
+		bundle.EquityCurve = append(bundle.EquityCurve, SharedEquityPoint{Time: t.ClosedAt, Equity: equity})
+	}
+	bundle.Summary = summarizeShare(bundle)
+
+	if opts.IncludeCandles {
+		candles, err := o.dataService.GetHistoricalCandles(bundle.Symbol, bundle.Timeframe, opts.From, opts.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load candles: %w", err)
+		}
+		if len(candles) > maxShareCandles {
+			return nil, fmt.Errorf("%d candles in the selected period, at most %d can be shared; narrow the period or leave candles out",
+				len(candles), maxShareCandles)
+		}
+		bundle.Candles = make([]SharedCandle, len(candles))
+		for i, c := range candles {
+			bundle.Candles[i] = SharedCandle{
+				Time:   c.OpenTime,
+				Open:   c.Open,
+				High:   c.High,
+				Low:    c.Low,
+				Close:  c.Close,
+				Volume: c.Volume,
+			}
+		}
+	}
+
+	log.Info().Int("trades", len(bundle.Trades)).Int("candles", len(bundle.Candles)).Msg("Share bundle exported")
+	return bundle, nil
+}
+
+// ImportShareBundle validates a bundle shared by another user and stores
+// it for viewing. Importing the same bundle again replaces it.
+func (o *Orchestrator) ImportShareBundle(data []byte, importedBy string) (*storage.SharedBundle, error) {
+	if o.dataService == nil {
+		return nil, fmt.Errorf("data service not set")
+	}
+
+	var bundle ShareBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	if err := validateShareBundle(&bundle); err != nil {
+		return nil, err
+	}
+	// Recompute rather than trust the sender's summary
+	bundle.Summary = summarizeShare(&bundle)
+
+	// Stored re-encoded, so only known fields are kept
+	encoded, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(encoded)
+
+	record := storage.SharedBundle{
+		ID:          hex.EncodeToString(sum[:8]),
+		Name:        bundle.Name,
+		Symbol:      bundle.Symbol,
+		Timeframe:   bundle.Timeframe,
+		ConfigHash:  bundle.ConfigHash,
+		Trades:      bundle.Summary.Trades,
+		ReturnPct:   bundle.Summary.ReturnPct,
+		PeriodStart: bundle.From,
+		PeriodEnd:   bundle.To,
+		Bundle:      encoded,
+		ImportedBy:  importedBy,
+		ImportedAt:  time.Now(),
+	}
+	if err := o.dataService.SaveSharedBundle(record); err != nil {
+		return nil, fmt.Errorf("failed to store bundle: %w", err)
+	}
+
+	log.Info().Str("id", record.ID).Str("name", record.Name).Str("by", importedBy).Msg("Share bundle imported")
+	record.Bundle = nil
+	return &record, nil
+}
+
+// GetSharedBundles lists imported bundles without their contents
+func (o *Orchestrator) GetSharedBundles() ([]storage.SharedBundle, error) {
+	if o.dataService == nil {
+		return nil, nil
+	}
+	return o.dataService.GetSharedBundles()
+}
+
+// GetSharedBundle returns an imported bundle, or nil if unknown
+func (o *Orchestrator) GetSharedBundle(id string) (*storage.SharedBundle, error) {
+	if o.dataService == nil {
+		return nil, nil
+	}
+	return o.dataService.GetSharedBundle(id)
+}
+
+// DeleteSharedBundle removes an imported bundle, reporting whether it existed
+func (o *Orchestrator) DeleteSharedBundle(id string) (bool, error) {
+	if o.dataService == nil {
+		return false, nil
+	}
+	return o.dataService.DeleteSharedBundle(id)
+}
+
+// validateShareBundle checks a bundle is one this build can show
+func validateShareBundle(b *ShareBundle) error {
+	switch {
+	case b.Format != ShareBundleFormat:
+		return fmt.Errorf("not a share bundle")
+	case b.Version < 1 || b.Version > ShareBundleVersion:
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	case b.Symbol == "":
+		return fmt.Errorf("bundle has no symbol")
+	case len(b.Trades) == 0:
+		return fmt.Errorf("bundle has no trades")
+	case len(b.Trades) > maxShareTrades:
+		return fmt.Errorf("bundle has %d trades, at most %d are accepted", len(b.Trades), maxShareTrades)
+	case len(b.Candles) > maxShareCandles:
+		return fmt.Errorf("bundle has %d candles, at most %d are accepted", len(b.Candles), maxShareCandles)
+	case len(b.EquityCurve) > maxShareTrades+1:
+		return fmt.Errorf("bundle has %d equity points, at most %d are accepted", len(b.EquityCurve), maxShareTrades+1)
+	case b.StartingEquity <= 0 || math.IsInf(b.StartingEquity, 0):
+		return fmt.Errorf("bundle has no starting equity")
+	case b.To.Before(b.From):
+		return fmt.Errorf("bundle period ends before it starts")
+	}
+	for i, t := range b.Trades {
+		if t.ClosedAt.Before(t.OpenedAt) {
+			return fmt.Errorf("trade %d closes before it opens", i+1)
+		}
+		if t.EntryPrice <= 0 || t.ExitPrice <= 0 || math.IsNaN(t.PnL) || math.IsInf(t.PnL, 0) {
+			return fmt.Errorf("trade %d has invalid prices or P&L", i+1)
+		}
+	}
+	return nil
+}
+
+// summarizeShare computes the headline performance of a bundle's trades
+// and equity curve
+func summarizeShare(b *ShareBundle) ShareSummary {
+	s := ShareSummary{Trades: len(b.Trades)}
+	grossProfit, grossLoss := 0.0, 0.0
+	for _, t := range b.Trades {
+		s.NetPnL += t.PnL
+		if t.PnL > 0 {
+			s.Wins++
+			grossProfit += t.PnL
+		} else {
+			s.Losses++
+			grossLoss -= t.PnL
+		}
+	}
+	if s.Trades > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.Trades)
+	}
+	if grossLoss > 0 {
+		s.ProfitFactor = grossProfit / grossLoss
+	}
+	if b.StartingEquity > 0 {
+		s.ReturnPct = s.NetPnL / b.StartingEquity * 100
+	}
+
+	peak := 0.0
+	for _, p := range b.EquityCurve {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak > 0 {
+			s.MaxDrawdownPct = math.Max(s.MaxDrawdownPct, (peak-p.Equity)/peak*100)
+		}
+	}
+	return s
+}
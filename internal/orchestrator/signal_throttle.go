@@ -0,0 +1,200 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/rs/zerolog/log"
+)
+
+// SignalThrottleStats counts a strategy's entry signals let through and
+// dropped by its cooldown
+type SignalThrottleStats struct {
+	Strategy        string    `json:"strategy"`
+	Cooldown        int       `json:"cooldown"` // Candles between entry signals; 0 = unthrottled
+	Passed          int64     `json:"passed"`
+	Throttled       int64     `json:"throttled"`
+	LastSignalAt    time.Time `json:"lastSignalAt,omitempty"` // Close time of the candle of the last signal let through
+	LastThrottledAt time.Time `json:"lastThrottledAt,omitempty"`
+}
+
+// signalThrottle limits each strategy to one entry signal per cooldown of
+// primary candles, so price oscillating around a threshold doesn't produce
+// a burst of entries
+type signalThrottle struct {
+	mu              sync.Mutex
+	defaultCooldown int
+	configured      map[string]int // Cooldowns by strategy from the config file
+	overrides       map[string]int // Cooldowns by strategy set through the API
+	stats           map[string]*SignalThrottleStats
+}
+
+// SetSignalThrottle sets the configured cooldown for all strategies and
+// per-strategy exceptions
+func (o *Orchestrator) SetSignalThrottle(defaultCooldown int, cooldowns map[string]int) {
+	o.throttle.mu.Lock()
+	defer o.throttle.mu.Unlock()
+
+	o.throttle.defaultCooldown = defaultCooldown
+	o.throttle.configured = make(map[string]int, len(cooldowns))
+	for name, candles := range cooldowns {
+		o.throttle.configured[name] = candles
+	}
+}
+
+// SetSignalCooldown sets the number of primary candles a strategy waits
+// after an entry signal before the next one; 1 allows one per candle and 0
+// disables throttling. It takes precedence over the config and is persisted.
+func (o *Orchestrator) SetSignalCooldown(strategyName string, candles int) error {
+	if candles < 0 {
+		return fmt.Errorf("signal cooldown must not be negative")
+	}
+
+	o.throttle.mu.Lock()
+	if o.throttle.overrides == nil {
+		o.throttle.overrides = make(map[string]int)
+	}
+	o.throttle.overrides[strategyName] = candles
+	o.throttle.mu.Unlock()
+
+	log.Info().Str("strategy", strategyName).Int("candles", candles).Msg("Signal cooldown updated")
+	return o.persistSignalCooldowns()
+}
+
+// ResetSignalCooldowns drops the cooldowns set through the API, returning
+// to the configured ones
+func (o *Orchestrator) ResetSignalCooldowns() error {
+	o.throttle.mu.Lock()
+	o.throttle.overrides = make(map[string]int)
+	o.throttle.mu.Unlock()
+
+	return o.persistSignalCooldowns()
+}
+
+// GetSignalCooldown returns the cooldown applied to a strategy
+func (o *Orchestrator) GetSignalCooldown(strategyName string) int {
+	o.throttle.mu.Lock()
+	defer o.throttle.mu.Unlock()
+	return o.throttle.cooldownLocked(strategyName)
+}
+
+// GetSignalThrottleStats returns throttling counters by strategy, including
+// strategies that have not signaled yet
+func (o *Orchestrator) GetSignalThrottleStats() map[string]SignalThrottleStats {
+	var names []string
+	if o.strategyMgr != nil {
+		for name := range o.strategyMgr.GetStrategies() {
+			names = append(names, name)
+		}
+	}
+
+	o.throttle.mu.Lock()
+	defer o.throttle.mu.Unlock()
+
+	result := make(map[string]SignalThrottleStats, len(names))
+	for _, name := range names {
+		result[name] = SignalThrottleStats{Strategy: name}
+	}
+	for name, stats := range o.throttle.stats {
+		result[name] = *stats
+	}
+	for name, stats := range result {
+		stats.Cooldown = o.throttle.cooldownLocked(name)
+		result[name] = stats
+	}
+	return result
+}
+
+// cooldownLocked returns the cooldown for a strategy (mu must be held)
+func (t *signalThrottle) cooldownLocked(strategyName string) int {
+	if candles, ok := t.overrides[strategyName]; ok {
+		return candles
+	}
+	if candles, ok := t.configured[strategyName]; ok {
+		return candles
+	}
+	return t.defaultCooldown
+}
+
+// throttleSignal reports whether an entry signal from a strategy on the
+// candle closing at candleClose falls within the strategy's cooldown and
+// must be dropped. Signals let through start a new cooldown.
+func (o *Orchestrator) throttleSignal(strategyName string, candleClose time.Time) bool {
+	o.throttle.mu.Lock()
+	defer o.throttle.mu.Unlock()
+
+	if o.throttle.stats == nil {
+		o.throttle.stats = make(map[string]*SignalThrottleStats)
+	}
+	stats, ok := o.throttle.stats[strategyName]
+	if !ok {
+		stats = &SignalThrottleStats{Strategy: strategyName}
+		o.throttle.stats[strategyName] = stats
+	}
+
+	cooldown := o.throttle.cooldownLocked(strategyName)
+	if cooldown > 0 && !stats.LastSignalAt.IsZero() {
+		candle := binance.IntervalToDuration(o.config.PrimaryTimeframe)
+		if candle <= 0 {
+			candle = time.Minute
+		}
+		if elapsed := int(candleClose.Sub(stats.LastSignalAt) / candle); elapsed < cooldown {
+			stats.Throttled++
+			stats.LastThrottledAt = candleClose
+			log.Info().
+				Str("strategy", strategyName).
+				Int("candlesSinceLast", elapsed).
+				Int("cooldown", cooldown).
+				Msg("Signal throttled")
+			return true
+		}
+	}
+
+	stats.Passed++
+	stats.LastSignalAt = candleClose
+	return false
+}
+
+// restoreSignalCooldowns loads the cooldowns set through the API
+func (o *Orchestrator) restoreSignalCooldowns() {
+	if o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadSignalCooldowns()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load signal cooldowns")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var cooldowns map[string]int
+	if err := json.Unmarshal([]byte(value), &cooldowns); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted signal cooldowns")
+		return
+	}
+
+	o.throttle.mu.Lock()
+	o.throttle.overrides = cooldowns
+	o.throttle.mu.Unlock()
+}
+
+// persistSignalCooldowns saves the cooldowns set through the API
+func (o *Orchestrator) persistSignalCooldowns() error {
+	if o.dataService == nil {
+		return nil
+	}
+
+	o.throttle.mu.Lock()
+	data, err := json.Marshal(o.throttle.overrides)
+	o.throttle.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveSignalCooldowns(string(data))
+}
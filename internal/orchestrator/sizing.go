@@ -0,0 +1,37 @@
+package orchestrator
+
+import (
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/risk"
+)
+
+// sizingStats summarizes a strategy's most recent closed trades for Kelly
+// sizing. Executors without a trade history yield no trades, which sizes
+// with the fixed fractional model.
+func (o *Orchestrator) sizingStats(strategyName string) risk.SizingStats {
+	history, ok := o.activeExecutor().(interface{ GetTrades() []*execution.Trade })
+	if !ok || o.riskManager == nil {
+		return risk.SizingStats{}
+	}
+
+	window := o.riskManager.GetConfig().KellyWindow
+	trades := history.GetTrades()
+	pnls := make([]float64, 0, window)
+	for i := len(trades) - 1; i >= 0 && (window <= 0 || len(pnls) < window); i-- {
+		if trades[i].Strategy != strategyName || trades[i].RealizedPnL == 0 {
+			continue
+		}
+		pnls = append(pnls, trades[i].RealizedPnL)
+	}
+	return risk.NewSizingStats(pnls)
+}
+
+// GetCompounding returns the live compounding mode and step, full
+// compounding without a risk manager, for backtests to size alike
+func (o *Orchestrator) GetCompounding() (risk.CompoundingMode, float64) {
+	if o.riskManager == nil {
+		return risk.CompoundingFull, 0
+	}
+	cfg := o.riskManager.GetConfig()
+	return cfg.Compounding, cfg.CompoundingStep
+}
@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// StateSnapshot is the trading state at a version. Full snapshots carry
+// every field; deltas only the fields changed after the requested version.
+type StateSnapshot struct {
+	Version uint64                     `json:"version"`
+	Full    bool                       `json:"full"`
+	State   map[string]json.RawMessage `json:"state"` // TradingState fields by name
+}
+
+// stateVersions numbers changes to the trading state for delta polling.
+// Changes are observed when the state is read, so versions count the
+// snapshots that differed rather than every update.
+type stateVersions struct {
+	mu      sync.Mutex
+	version uint64
+	fields  map[string]json.RawMessage // Last observed value of each field
+	changed map[string]uint64          // Version each field last changed at
+}
+
+// GetStateSnapshot returns the trading state with its version. since > 0
+// returns only the fields changed after that version; a version this
+// process never issued (e.g. from before a restart) gets a full snapshot.
+func (o *Orchestrator) GetStateSnapshot(since uint64) (StateSnapshot, error) {
+	data, err := json.Marshal(o.GetState())
+	if err != nil {
+		return StateSnapshot{}, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return StateSnapshot{}, fmt.Errorf("failed to split state fields: %w", err)
+	}
+
+	sv := &o.stateVersions
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	if sv.fields == nil {
+		sv.fields = make(map[string]json.RawMessage, len(fields))
+		sv.changed = make(map[string]uint64, len(fields))
+	}
+	bumped := false
+	for name, value := range fields {
+		if prev, ok := sv.fields[name]; ok && bytes.Equal(prev, value) {
+			continue
+		}
+		if !bumped {
+			sv.version++
+			bumped = true
+		}
+		sv.fields[name] = value
+		sv.changed[name] = sv.version
+	}
+
+	snapshot := StateSnapshot{Version: sv.version}
+	if since == 0 || since > sv.version {
+		snapshot.Full = true
+		snapshot.State = fields
+		return snapshot, nil
+	}
+
+	snapshot.State = make(map[string]json.RawMessage)
+	for name, version := range sv.changed {
+		if version > since {
+			snapshot.State[name] = sv.fields[name]
+		}
+	}
+	return snapshot, nil
+}
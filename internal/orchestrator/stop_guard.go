@@ -0,0 +1,225 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Relative tolerances when matching a resting stop order to its position,
+// covering tick and lot size rounding by the exchange
+const (
+	stopPriceTolerance    = 0.001
+	stopQuantityTolerance = 0.01
+)
+
+// stopGuard verifies that every live position's stop loss order is resting
+// on the exchange and re-places missing ones, e.g. canceled externally
+type stopGuard struct {
+	interval   time.Duration
+	alertAfter int
+
+	mu       sync.Mutex
+	failures map[int64]int // Consecutive failed re-placements by position ID
+}
+
+// SetStopGuard enables periodic verification of exchange-side stop loss
+// orders in live mode. A critical alert is raised once a position's stop
+// could not be re-placed alertAfter times in a row.
+func (o *Orchestrator) SetStopGuard(interval time.Duration, alertAfter int) {
+	o.stopGuard.interval = interval
+	o.stopGuard.alertAfter = alertAfter
+	o.stopGuard.failures = make(map[int64]int)
+}
+
+// stopGuardLoop runs the stop verification on the configured interval
+func (o *Orchestrator) stopGuardLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(o.stopGuard.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// Mode switches may leave a paper executor active
+			if exec := o.activeExecutor(); exec.GetMode() == execution.ModeLive {
+				o.verifyProtectiveStops(exec)
+			}
+			beat()
+		}
+	}
+}
+
+// verifyProtectiveStops checks each open position with a stop loss for a
+// matching working stop order and re-places the stop when there is none
+func (o *Orchestrator) verifyProtectiveStops(exec execution.Executor) {
+	positions, err := exec.GetPositions()
+	if err != nil {
+		log.Warn().Err(err).Msg("Stop verification failed to load positions")
+		return
+	}
+
+	open := make(map[int64]bool, len(positions))
+	ordersBySymbol := make(map[string][]*execution.Order)
+	for _, position := range positions {
+		if position.StopLoss <= 0 || position.Quantity <= 0 {
+			continue
+		}
+		open[position.ID] = true
+
+		orders, fetched := ordersBySymbol[position.Symbol]
+		if !fetched {
+			orders, err = exec.GetOpenOrders(position.Symbol)
+			if err != nil {
+				o.stopGuardFailed(position, fmt.Errorf("failed to fetch open orders: %w", err))
+				continue
+			}
+			ordersBySymbol[position.Symbol] = orders
+		}
+
+		stop, stale := matchProtectiveStop(position, orders)
+		if stop != nil {
+			o.stopGuardOK(position.ID)
+			continue
+		}
+
+		// A stop at the wrong price or size would close the position
+		// wrongly, so it goes before the replacement is placed
+		for _, order := range stale {
+			err := exec.CancelOrder(order.ID)
+			o.auditOrder(AuditOrderCancel, "", order, err)
+			if err != nil {
+				log.Warn().Err(err).Str("orderID", order.ID).Msg("Failed to cancel mismatched stop loss order")
+			}
+		}
+
+		if err := exec.UpdateStopLoss(position.ID, position.StopLoss); err != nil {
+			o.stopGuardFailed(position, err)
+			continue
+		}
+		o.stopGuardOK(position.ID)
+		o.recordStopReplaced(position, len(stale))
+	}
+
+	// Forget positions that have closed
+	o.stopGuard.mu.Lock()
+	for id := range o.stopGuard.failures {
+		if !open[id] {
+			delete(o.stopGuard.failures, id)
+		}
+	}
+	o.stopGuard.mu.Unlock()
+}
+
+// matchProtectiveStop returns the working stop loss order protecting
+// position, if any, along with the ones on the closing side that don't
+// match its stop price or quantity
+func matchProtectiveStop(position *execution.Position, orders []*execution.Order) (*execution.Order, []*execution.Order) {
+	side := execution.OrderSideSell
+	if position.Side == execution.PositionSideShort {
+		side = execution.OrderSideBuy
+	}
+
+	var stale []*execution.Order
+	for _, order := range orders {
+		if order.Type != execution.OrderTypeStopLoss || order.Side != side || !order.Status.IsWorking() {
+			continue
+		}
+		if withinTolerance(order.StopPrice, position.StopLoss, stopPriceTolerance) &&
+			withinTolerance(order.RemainingQuantity(), position.Quantity, stopQuantityTolerance) {
+			return order, nil
+		}
+		stale = append(stale, order)
+	}
+	return nil, stale
+}
+
+// withinTolerance reports whether got is within a relative tolerance of want
+func withinTolerance(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= want*tolerance
+}
+
+// stopGuardOK resets a position's failure count
+func (o *Orchestrator) stopGuardOK(positionID int64) {
+	o.stopGuard.mu.Lock()
+	delete(o.stopGuard.failures, positionID)
+	o.stopGuard.mu.Unlock()
+}
+
+// stopGuardFailed counts a failed verification and alerts when the count
+// reaches the threshold
+func (o *Orchestrator) stopGuardFailed(position *execution.Position, err error) {
+	o.stopGuard.mu.Lock()
+	o.stopGuard.failures[position.ID]++
+	failures := o.stopGuard.failures[position.ID]
+	o.stopGuard.mu.Unlock()
+
+	log.Error().
+		Err(err).
+		Int64("positionID", position.ID).
+		Str("symbol", position.Symbol).
+		Float64("stopLoss", position.StopLoss).
+		Int("failures", failures).
+		Msg("Failed to restore protective stop")
+
+	if failures != o.stopGuard.alertAfter {
+		return
+	}
+
+	details := fmt.Sprintf("%s position %d has no stop loss order at %.2f after %d attempts: %v",
+		position.Symbol, position.ID, position.StopLoss, failures, err)
+	o.broadcastError("POSITION_UNPROTECTED", "Protective stop could not be restored", details)
+
+	if o.dataService == nil {
+		return
+	}
+	data, _ := json.Marshal(position)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "stop_verification_failed",
+		Severity: "critical",
+		Message:  details,
+		Data:     string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record stop verification alert")
+	}
+}
+
+// recordStopReplaced logs and records a stop loss order re-placed by the
+// verification
+func (o *Orchestrator) recordStopReplaced(position *execution.Position, canceled int) {
+	reason := "missing"
+	if canceled > 0 {
+		reason = "mismatched"
+	}
+
+	log.Warn().
+		Int64("positionID", position.ID).
+		Str("symbol", position.Symbol).
+		Float64("stopLoss", position.StopLoss).
+		Float64("quantity", position.Quantity).
+		Str("reason", reason).
+		Msg("Protective stop re-placed")
+
+	if o.dataService == nil {
+		return
+	}
+	data, _ := json.Marshal(position)
+	if _, err := o.dataService.AddAlert(storage.Alert{
+		Type:     "stop_replaced",
+		Severity: "warning",
+		Message: fmt.Sprintf("Re-placed %s stop loss for %s %s position at %.2f",
+			reason, position.Symbol, strings.ToLower(string(position.Side)), position.StopLoss),
+		Data: string(data),
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to record stop replacement alert")
+	}
+}
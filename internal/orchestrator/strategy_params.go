@@ -0,0 +1,93 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eth-trading/internal/backtest"
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// StrategyParamsResult reports which strategy parameters changed through
+// the settings API were applied to the running strategies
+type StrategyParamsResult struct {
+	Applied    map[string]map[string]float64 `json:"applied"`              // By canonical strategy name
+	Previous   map[string]map[string]float64 `json:"previous"`             // Values the applied ones replaced
+	Ignored    map[string][]string           `json:"ignored,omitempty"`    // Keys that are not numeric parameters of the strategy
+	NotRunning []string                      `json:"notRunning,omitempty"` // Strategies the manager does not run, left unchanged
+}
+
+// SetStrategyParams applies parameters, keyed by config field name, to the
+// running strategies. Every strategy is validated before any changes, so
+// an error leaves them all as they were.
+func (o *Orchestrator) SetStrategyParams(params map[string]map[string]float64, appliedBy string) (*StrategyParamsResult, error) {
+	if o.strategyMgr == nil {
+		return nil, fmt.Errorf("strategy manager not set")
+	}
+
+	result := &StrategyParamsResult{
+		Applied:  make(map[string]map[string]float64),
+		Previous: make(map[string]map[string]float64),
+		Ignored:  make(map[string][]string),
+	}
+	running := o.strategyMgr.GetStrategies()
+	updated := make(map[string]strategy.Strategy, len(params))
+	for name, values := range params {
+		name = strategy.CanonicalName(name)
+		current, ok := running[name]
+		if !ok {
+			result.NotRunning = append(result.NotRunning, name)
+			continue
+		}
+
+		known := make(map[string]float64, len(values))
+		old := make(map[string]float64, len(values))
+		for field, value := range values {
+			v, err := backtest.ReadParam(current.GetConfig(), field)
+			if err != nil {
+				result.Ignored[name] = append(result.Ignored[name], field)
+				continue
+			}
+			if v != value {
+				known[field] = value
+				old[field] = v
+			}
+		}
+		sort.Strings(result.Ignored[name])
+		if len(known) == 0 {
+			continue
+		}
+
+		applied, err := backtest.ApplyParams(current, known)
+		if err != nil {
+			return nil, fmt.Errorf("strategy %s: %w", name, err)
+		}
+		updated[name] = applied
+		result.Applied[name] = known
+		result.Previous[name] = old
+	}
+	sort.Strings(result.NotRunning)
+
+	for name, applied := range updated {
+		o.strategyMgr.AddStrategy(applied)
+		o.RecordAudit(storage.AuditEntry{
+			Category: storage.AuditConfig,
+			Action:   AuditStrategyParams,
+			Actor:    appliedBy,
+			Resource: name,
+			OldValue: AuditValue(result.Previous[name]),
+			NewValue: AuditValue(result.Applied[name]),
+		}, nil)
+		log.Info().
+			Str("strategy", name).
+			Str("by", appliedBy).
+			Interface("params", result.Applied[name]).
+			Msg("Applied strategy parameters from settings")
+	}
+	if len(updated) > 0 {
+		o.checkParamDrift()
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultScriptReloadInterval is how often the strategies directory is
+// checked for changed scripts by default
+const defaultScriptReloadInterval = 5 * time.Second
+
+// strategyScripts hot-reloads scripted strategies
+type strategyScripts struct {
+	loader   *strategy.ScriptLoader
+	interval time.Duration
+}
+
+// SetStrategyScripts sets the loader of scripted strategies and how often
+// it checks for changes. It must be called before Start.
+func (o *Orchestrator) SetStrategyScripts(loader *strategy.ScriptLoader, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultScriptReloadInterval
+	}
+	o.scripts.loader = loader
+	o.scripts.interval = interval
+}
+
+// ReloadStrategyScripts loads new and changed scripts and drops deleted
+// ones. Reloaded scripts keep states set through the API.
+func (o *Orchestrator) ReloadStrategyScripts() error {
+	if o.scripts.loader == nil {
+		return nil
+	}
+	err := o.scripts.loader.Load()
+	o.applyStrategyStates()
+	return err
+}
+
+// strategyScriptsLoop reloads scripts as they change
+func (o *Orchestrator) strategyScriptsLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(o.scripts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// Load logs each failing script itself
+			if err := o.ReloadStrategyScripts(); err != nil {
+				log.Debug().Err(err).Msg("Strategy scripts reloaded with errors")
+			}
+			beat()
+		}
+	}
+}
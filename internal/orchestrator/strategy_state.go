@@ -0,0 +1,156 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/storage"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrStrategyNotFound is returned for a strategy the manager does not run
+var ErrStrategyNotFound = errors.New("strategy not found")
+
+// StrategyStateUpdate is broadcast when a strategy is enabled or disabled
+type StrategyStateUpdate struct {
+	Strategy  string `json:"strategy"`
+	Enabled   bool   `json:"enabled"`
+	ChangedBy string `json:"changedBy,omitempty"`
+}
+
+// strategyStates holds the strategies enabled or disabled through the API,
+// which win over the startup configuration
+type strategyStates struct {
+	mu        sync.Mutex
+	overrides map[string]bool
+}
+
+// SetStrategyEnabled enables or disables a strategy from the next analysis
+// on and persists the change across restarts. It returns the strategy's
+// canonical name.
+func (o *Orchestrator) SetStrategyEnabled(name string, enabled bool, changedBy string) (string, error) {
+	if o.strategyMgr == nil {
+		return "", fmt.Errorf("strategy manager not set")
+	}
+
+	name = strategy.CanonicalName(name)
+	wasEnabled := false
+	for _, st := range o.strategyMgr.GetStrategyStatuses() {
+		if st.Name == name {
+			wasEnabled = st.Enabled
+		}
+	}
+	var found bool
+	if enabled {
+		found = o.strategyMgr.EnableStrategy(name)
+	} else {
+		found = o.strategyMgr.DisableStrategy(name)
+	}
+	if !found {
+		return "", ErrStrategyNotFound
+	}
+
+	o.strategyStates.mu.Lock()
+	if o.strategyStates.overrides == nil {
+		o.strategyStates.overrides = make(map[string]bool)
+	}
+	o.strategyStates.overrides[name] = enabled
+	o.strategyStates.mu.Unlock()
+
+	log.Info().Str("strategy", name).Bool("enabled", enabled).Str("by", changedBy).Msg("Strategy state changed")
+	o.broadcast(BroadcastMessage{
+		Type:      MessageTypeStrategy,
+		Timestamp: time.Now(),
+		Data:      StrategyStateUpdate{Strategy: name, Enabled: enabled, ChangedBy: changedBy},
+	})
+
+	err := o.persistStrategyStates()
+	action := AuditStrategyDisable
+	if enabled {
+		action = AuditStrategyEnable
+	}
+	o.RecordAudit(storage.AuditEntry{
+		Category: storage.AuditStrategy,
+		Action:   action,
+		Actor:    changedBy,
+		Resource: name,
+		OldValue: AuditValue(map[string]bool{"enabled": wasEnabled}),
+		NewValue: AuditValue(map[string]bool{"enabled": enabled}),
+	}, err)
+	return name, err
+}
+
+// restoreStrategyStates loads the strategy states set through the API and
+// applies them
+func (o *Orchestrator) restoreStrategyStates() {
+	if o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadStrategyStates()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load strategy states")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var states map[string]bool
+	if err := json.Unmarshal([]byte(value), &states); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted strategy states")
+		return
+	}
+
+	o.strategyStates.mu.Lock()
+	o.strategyStates.overrides = states
+	o.strategyStates.mu.Unlock()
+	o.applyStrategyStates()
+}
+
+// applyStrategyStates applies the strategy states set through the API to
+// the strategies that exist now, e.g. after scripts were reloaded
+func (o *Orchestrator) applyStrategyStates() {
+	if o.strategyMgr == nil {
+		return
+	}
+
+	o.strategyStates.mu.Lock()
+	states := make(map[string]bool, len(o.strategyStates.overrides))
+	for name, enabled := range o.strategyStates.overrides {
+		states[name] = enabled
+	}
+	o.strategyStates.mu.Unlock()
+
+	strategies := o.strategyMgr.GetStrategies()
+	for name, enabled := range states {
+		s, ok := strategies[name]
+		if !ok || s.IsEnabled() == enabled {
+			continue
+		}
+		if enabled {
+			o.strategyMgr.EnableStrategy(name)
+		} else {
+			o.strategyMgr.DisableStrategy(name)
+		}
+	}
+}
+
+// persistStrategyStates saves the strategy states set through the API
+func (o *Orchestrator) persistStrategyStates() error {
+	if o.dataService == nil {
+		return nil
+	}
+
+	o.strategyStates.mu.Lock()
+	data, err := json.Marshal(o.strategyStates.overrides)
+	o.strategyStates.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveStrategyStates(string(data))
+}
@@ -0,0 +1,239 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrNoSubBalance is returned for transfers while the bot trades the
+	// whole account
+	ErrNoSubBalance = errors.New("no sub-balance configured, the bot trades the whole account")
+	// ErrTransferExceedsAccount is returned when a transfer would allocate
+	// more than the account holds
+	ErrTransferExceedsAccount = errors.New("transfer exceeds the unallocated account equity")
+	// ErrTransferExceedsBalance is returned when a withdrawal would leave
+	// the sub-balance empty
+	ErrTransferExceedsBalance = errors.New("transfer exceeds the sub-balance")
+)
+
+// CapitalTransfer moves capital between the bot's sub-balance and the rest
+// of the exchange account
+type CapitalTransfer struct {
+	Amount float64   `json:"amount"` // Positive into the sub-balance, negative out of it
+	Note   string    `json:"note,omitempty"`
+	By     string    `json:"by,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// SubBalance is the part of the exchange account designated as the bot's
+// trading capital
+type SubBalance struct {
+	Allocated float64           `json:"allocated"` // Initial allocation plus transfers
+	Since     time.Time         `json:"since"`     // Realized P&L counts from here
+	Transfers []CapitalTransfer `json:"transfers"`
+}
+
+// SubBalanceStatus describes the bot's capital against the whole account
+type SubBalanceStatus struct {
+	Enabled       bool              `json:"enabled"`
+	Allocated     float64           `json:"allocated,omitempty"`
+	RealizedPnL   float64           `json:"realizedPnl,omitempty"`
+	UnrealizedPnL float64           `json:"unrealizedPnl,omitempty"`
+	Equity        float64           `json:"equity"` // What equity, drawdown and sizing use
+	AccountEquity float64           `json:"accountEquity"`
+	Unallocated   float64           `json:"unallocated,omitempty"`
+	Since         *time.Time        `json:"since,omitempty"`
+	Transfers     []CapitalTransfer `json:"transfers,omitempty"`
+}
+
+// subBalance limits live trading to part of the exchange account. Paper
+// accounts are virtual already and are never limited.
+type subBalance struct {
+	mu         sync.Mutex
+	configured float64     // Initial allocation; 0 trades the whole account
+	state      *SubBalance // Nil until restored or created
+}
+
+// SetSubBalance designates allocated of the exchange account as the bot's
+// trading capital. It must be called before Start; a persisted sub-balance,
+// which includes later transfers, takes precedence.
+func (o *Orchestrator) SetSubBalance(allocated float64) {
+	o.subBalance.mu.Lock()
+	defer o.subBalance.mu.Unlock()
+	o.subBalance.configured = allocated
+}
+
+// restoreSubBalance loads the persisted sub-balance, or starts one from the
+// configured allocation
+func (o *Orchestrator) restoreSubBalance() {
+	o.subBalance.mu.Lock()
+	configured := o.subBalance.configured
+	o.subBalance.mu.Unlock()
+	if configured <= 0 {
+		return
+	}
+
+	var restored *SubBalance
+	if o.dataService != nil {
+		value, err := o.dataService.LoadSubBalance()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to load sub-balance")
+		} else if value != "" {
+			var sb SubBalance
+			if err := json.Unmarshal([]byte(value), &sb); err != nil {
+				log.Warn().Err(err).Msg("Invalid persisted sub-balance")
+			} else {
+				restored = &sb
+			}
+		}
+	}
+	if restored == nil {
+		restored = &SubBalance{Allocated: configured, Since: time.Now(), Transfers: []CapitalTransfer{}}
+	}
+
+	o.subBalance.mu.Lock()
+	o.subBalance.state = restored
+	o.subBalance.mu.Unlock()
+
+	log.Info().
+		Float64("allocated", restored.Allocated).
+		Time("since", restored.Since).
+		Msg("Trading against a sub-balance of the account")
+	if err := o.persistSubBalance(); err != nil {
+		log.Warn().Err(err).Msg("Failed to persist sub-balance")
+	}
+}
+
+// equity returns the equity the bot trades with
+func (o *Orchestrator) equity() (float64, error) {
+	return o.equityOf(o.activeExecutor())
+}
+
+// equityOf returns exec's equity, limited to the sub-balance for live
+// executors when one is configured
+func (o *Orchestrator) equityOf(exec execution.Executor) (float64, error) {
+	status, err := o.subBalanceStatus(exec)
+	if err != nil {
+		return 0, err
+	}
+	return status.Equity, nil
+}
+
+// subBalanceStatus computes the sub-balance's equity against exec's account
+func (o *Orchestrator) subBalanceStatus(exec execution.Executor) (SubBalanceStatus, error) {
+	account, err := exec.GetEquity()
+	if err != nil {
+		return SubBalanceStatus{}, err
+	}
+	status := SubBalanceStatus{Equity: account, AccountEquity: account}
+
+	o.subBalance.mu.Lock()
+	state := o.subBalance.state
+	var sb SubBalance
+	if state != nil {
+		sb = *state
+		sb.Transfers = append([]CapitalTransfer(nil), state.Transfers...)
+	}
+	o.subBalance.mu.Unlock()
+
+	if _, paper := exec.(*execution.PaperExecutor); paper || state == nil {
+		return status, nil
+	}
+
+	status.Enabled = true
+	status.Allocated = sb.Allocated
+	since := sb.Since
+	status.Since = &since
+	status.Transfers = sb.Transfers
+	if o.dataService != nil {
+		if status.RealizedPnL, err = o.dataService.GetRealizedPnLSince(sb.Since); err != nil {
+			return SubBalanceStatus{}, fmt.Errorf("failed to sum realized P&L: %w", err)
+		}
+	}
+	if positions, err := exec.GetPositions(); err == nil {
+		for _, pos := range positions {
+			status.UnrealizedPnL += pos.UnrealizedPnL
+		}
+	}
+
+	// The bot can never have more than the account holds
+	status.Equity = math.Min(sb.Allocated+status.RealizedPnL+status.UnrealizedPnL, account)
+	status.Unallocated = account - status.Equity
+	return status, nil
+}
+
+// GetSubBalance returns the bot's capital against the whole account
+func (o *Orchestrator) GetSubBalance() (SubBalanceStatus, error) {
+	exec := o.activeExecutor()
+	if exec == nil {
+		return SubBalanceStatus{}, fmt.Errorf("executor not set")
+	}
+	return o.subBalanceStatus(exec)
+}
+
+// TransferCapital moves amount into (positive) or out of (negative) the
+// sub-balance. The transfer is persisted and recorded as a cash flow so
+// drawdown does not count it as a gain or loss.
+func (o *Orchestrator) TransferCapital(amount float64, note, by string) (SubBalanceStatus, error) {
+	exec := o.activeExecutor()
+	if exec == nil {
+		return SubBalanceStatus{}, fmt.Errorf("executor not set")
+	}
+	status, err := o.subBalanceStatus(exec)
+	if err != nil {
+		return SubBalanceStatus{}, err
+	}
+	if !status.Enabled {
+		return SubBalanceStatus{}, ErrNoSubBalance
+	}
+	if amount > status.Unallocated {
+		return SubBalanceStatus{}, ErrTransferExceedsAccount
+	}
+	if -amount >= status.Equity {
+		return SubBalanceStatus{}, ErrTransferExceedsBalance
+	}
+
+	transfer := CapitalTransfer{Amount: amount, Note: note, By: by, At: time.Now()}
+	o.subBalance.mu.Lock()
+	o.subBalance.state.Allocated += amount
+	o.subBalance.state.Transfers = append(o.subBalance.state.Transfers, transfer)
+	o.subBalance.mu.Unlock()
+
+	log.Info().
+		Float64("amount", amount).
+		Str("note", note).
+		Str("by", by).
+		Msg("Sub-balance transfer recorded")
+
+	if o.riskManager != nil {
+		o.RecordCashFlow(amount)
+	}
+	if err := o.persistSubBalance(); err != nil {
+		return SubBalanceStatus{}, fmt.Errorf("transfer applied but not persisted: %w", err)
+	}
+	o.updateRiskMetrics()
+	return o.subBalanceStatus(exec)
+}
+
+// persistSubBalance saves the sub-balance and its transfers
+func (o *Orchestrator) persistSubBalance() error {
+	if o.dataService == nil {
+		return nil
+	}
+
+	o.subBalance.mu.Lock()
+	data, err := json.Marshal(o.subBalance.state)
+	o.subBalance.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveSubBalance(string(data))
+}
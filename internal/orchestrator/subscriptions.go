@@ -0,0 +1,238 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/rs/zerolog/log"
+)
+
+// maxExtraSubscriptions bounds the streams added through the API, well
+// under Binance's limit of 1024 streams per connection
+const maxExtraSubscriptions = 100
+
+var (
+	// ErrSubscriptionExists is returned when adding a stream already subscribed
+	ErrSubscriptionExists = errors.New("stream already subscribed")
+	// ErrSubscriptionNotFound is returned when removing a stream not subscribed
+	ErrSubscriptionNotFound = errors.New("stream not subscribed")
+	// ErrCoreSubscription is returned when removing a stream trading depends on
+	ErrCoreSubscription = errors.New("stream is required by the bot and cannot be removed")
+	// ErrTooManySubscriptions is returned when the added streams are at their limit
+	ErrTooManySubscriptions = fmt.Errorf("at most %d streams can be added", maxExtraSubscriptions)
+)
+
+// StreamSubscription is a Binance stream the WebSocket client subscribes to
+type StreamSubscription struct {
+	Stream      string     `json:"stream"`
+	Core        bool       `json:"core"` // Subscribed by the bot itself; cannot be removed
+	AddedBy     string     `json:"addedBy,omitempty"`
+	AddedAt     *time.Time `json:"addedAt,omitempty"`
+	Messages    int64      `json:"messages"`
+	LastMessage *time.Time `json:"lastMessage,omitempty"`
+}
+
+// addedSubscription is a stream added through the API, as persisted
+type addedSubscription struct {
+	Stream  string    `json:"stream"`
+	AddedBy string    `json:"addedBy,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// streamSubscriptions tracks the streams the bot subscribes to itself and
+// those operators added at runtime
+type streamSubscriptions struct {
+	mu    sync.Mutex
+	core  map[string]bool
+	added map[string]addedSubscription
+}
+
+// subscribeCoreStreams subscribes to the streams trading depends on and
+// restores those added through the API, before the client connects
+func (o *Orchestrator) subscribeCoreStreams(streams []string) {
+	o.subscriptions.mu.Lock()
+	o.subscriptions.core = make(map[string]bool, len(streams))
+	for _, s := range streams {
+		o.subscriptions.core[s] = true
+	}
+	o.subscriptions.mu.Unlock()
+
+	o.wsClient.Subscribe(streams...)
+	o.restoreSubscriptions()
+}
+
+// GetSubscriptions lists the subscribed streams with their traffic
+func (o *Orchestrator) GetSubscriptions() []StreamSubscription {
+	if o.wsClient == nil {
+		return []StreamSubscription{}
+	}
+
+	traffic := make(map[string]binance.StreamStats)
+	for _, s := range o.wsClient.Stats().Streams {
+		traffic[s.Stream] = s
+	}
+
+	o.subscriptions.mu.Lock()
+	defer o.subscriptions.mu.Unlock()
+
+	subs := make([]StreamSubscription, 0)
+	for _, stream := range o.wsClient.GetSubscriptions() {
+		sub := StreamSubscription{Stream: stream, Core: o.subscriptions.core[stream]}
+		if added, ok := o.subscriptions.added[stream]; ok {
+			addedAt := added.AddedAt
+			sub.AddedBy = added.AddedBy
+			sub.AddedAt = &addedAt
+		}
+		if t, ok := traffic[stream]; ok {
+			sub.Messages = t.Messages
+			if !t.LastMessage.IsZero() {
+				lastMessage := t.LastMessage
+				sub.LastMessage = &lastMessage
+			}
+		}
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		if subs[i].Core != subs[j].Core {
+			return subs[i].Core
+		}
+		return subs[i].Stream < subs[j].Stream
+	})
+	return subs
+}
+
+// AddSubscription subscribes to a stream and persists it, so it is
+// subscribed again after a restart
+func (o *Orchestrator) AddSubscription(stream, addedBy string) (*StreamSubscription, error) {
+	if o.wsClient == nil {
+		return nil, fmt.Errorf("websocket client not set")
+	}
+	stream, err := binance.NormalizeStreamName(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	o.subscriptions.mu.Lock()
+	for _, s := range o.wsClient.GetSubscriptions() {
+		if s == stream {
+			o.subscriptions.mu.Unlock()
+			return nil, ErrSubscriptionExists
+		}
+	}
+	if len(o.subscriptions.added) >= maxExtraSubscriptions {
+		o.subscriptions.mu.Unlock()
+		return nil, ErrTooManySubscriptions
+	}
+	if o.subscriptions.added == nil {
+		o.subscriptions.added = make(map[string]addedSubscription)
+	}
+	added := addedSubscription{Stream: stream, AddedBy: addedBy, AddedAt: time.Now()}
+	o.subscriptions.added[stream] = added
+	o.subscriptions.mu.Unlock()
+
+	// Kept for the next connection even if sending fails now
+	if err := o.wsClient.Subscribe(stream); err != nil {
+		log.Warn().Err(err).Str("stream", stream).Msg("Stream subscription not sent, it applies on reconnect")
+	}
+	log.Info().Str("stream", stream).Str("by", addedBy).Msg("Stream subscribed")
+
+	sub := &StreamSubscription{Stream: stream, AddedBy: addedBy, AddedAt: &added.AddedAt}
+	return sub, o.persistSubscriptions()
+}
+
+// RemoveSubscription unsubscribes from a stream added through the API
+func (o *Orchestrator) RemoveSubscription(stream, removedBy string) error {
+	if o.wsClient == nil {
+		return fmt.Errorf("websocket client not set")
+	}
+	stream, err := binance.NormalizeStreamName(stream)
+	if err != nil {
+		return err
+	}
+
+	o.subscriptions.mu.Lock()
+	if o.subscriptions.core[stream] {
+		o.subscriptions.mu.Unlock()
+		return ErrCoreSubscription
+	}
+	if _, ok := o.subscriptions.added[stream]; !ok {
+		o.subscriptions.mu.Unlock()
+		return ErrSubscriptionNotFound
+	}
+	delete(o.subscriptions.added, stream)
+	o.subscriptions.mu.Unlock()
+
+	if err := o.wsClient.Unsubscribe(stream); err != nil {
+		log.Warn().Err(err).Str("stream", stream).Msg("Stream unsubscription not sent, it applies on reconnect")
+	}
+	log.Info().Str("stream", stream).Str("by", removedBy).Msg("Stream unsubscribed")
+
+	return o.persistSubscriptions()
+}
+
+// restoreSubscriptions subscribes again to the streams added through the
+// API before the last shutdown
+func (o *Orchestrator) restoreSubscriptions() {
+	if o.dataService == nil {
+		return
+	}
+
+	value, err := o.dataService.LoadStreamSubscriptions()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load stream subscriptions")
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	var added []addedSubscription
+	if err := json.Unmarshal([]byte(value), &added); err != nil {
+		log.Warn().Err(err).Msg("Invalid persisted stream subscriptions")
+		return
+	}
+
+	o.subscriptions.mu.Lock()
+	o.subscriptions.added = make(map[string]addedSubscription, len(added))
+	var streams []string
+	for _, a := range added {
+		// Core streams may have changed with the configuration
+		if o.subscriptions.core[a.Stream] {
+			continue
+		}
+		o.subscriptions.added[a.Stream] = a
+		streams = append(streams, a.Stream)
+	}
+	o.subscriptions.mu.Unlock()
+
+	if len(streams) > 0 {
+		o.wsClient.Subscribe(streams...)
+		log.Info().Strs("streams", streams).Msg("Restored stream subscriptions")
+	}
+}
+
+// persistSubscriptions saves the streams added through the API
+func (o *Orchestrator) persistSubscriptions() error {
+	if o.dataService == nil {
+		return nil
+	}
+
+	o.subscriptions.mu.Lock()
+	added := make([]addedSubscription, 0, len(o.subscriptions.added))
+	for _, a := range o.subscriptions.added {
+		added = append(added, a)
+	}
+	o.subscriptions.mu.Unlock()
+	sort.Slice(added, func(i, j int) bool { return added[i].AddedAt.Before(added[j].AddedAt) })
+
+	data, err := json.Marshal(added)
+	if err != nil {
+		return err
+	}
+	return o.dataService.SaveStreamSubscriptions(string(data))
+}
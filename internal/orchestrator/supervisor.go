@@ -0,0 +1,312 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// supervisorCheckInterval is how often the supervisor checks loop heartbeats
+const supervisorCheckInterval = 5 * time.Second
+
+// LoopFunc is a supervised loop body. It must call beat on every iteration
+// and return when ctx is cancelled. Returning early (with or without an
+// error) or panicking is treated as a crash and the loop is restarted.
+type LoopFunc func(ctx context.Context, beat func()) error
+
+// LoopStatus represents the health status of a supervised loop
+type LoopStatus string
+
+const (
+	LoopStatusRunning    LoopStatus = "running"
+	LoopStatusStalled    LoopStatus = "stalled"
+	LoopStatusRestarting LoopStatus = "restarting"
+	LoopStatusStopped    LoopStatus = "stopped"
+)
+
+// LoopHealth is a point-in-time health report for a supervised loop
+type LoopHealth struct {
+	Name          string     `json:"name"`
+	Status        LoopStatus `json:"status"`
+	Healthy       bool       `json:"healthy"`
+	StartedAt     time.Time  `json:"startedAt"`
+	LastHeartbeat time.Time  `json:"lastHeartbeat"`
+	StallTimeout  string     `json:"stallTimeout"`
+	Restarts      int        `json:"restarts"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastRestartAt *time.Time `json:"lastRestartAt,omitempty"`
+}
+
+// supervisedLoop tracks a single loop managed by the supervisor
+type supervisedLoop struct {
+	name         string
+	fn           LoopFunc
+	stallTimeout time.Duration
+
+	generation    int
+	cancel        context.CancelFunc
+	done          chan struct{}
+	startedAt     time.Time
+	lastBeat      time.Time
+	restarts      int
+	lastError     string
+	lastRestartAt time.Time
+	exited        bool
+}
+
+// Supervisor runs internal loops, tracks their heartbeats and restarts
+// loops that crash, exit unexpectedly or stop sending heartbeats
+type Supervisor struct {
+	ctx           context.Context
+	checkInterval time.Duration
+	loops         map[string]*supervisedLoop
+	mu            sync.Mutex
+
+	onRestart func(name, reason string)
+}
+
+// NewSupervisor creates a supervisor bound to the given context
+func NewSupervisor(ctx context.Context, checkInterval time.Duration) *Supervisor {
+	if checkInterval <= 0 {
+		checkInterval = 5 * time.Second
+	}
+	return &Supervisor{
+		ctx:           ctx,
+		checkInterval: checkInterval,
+		loops:         make(map[string]*supervisedLoop),
+	}
+}
+
+// SetOnRestart sets a callback invoked whenever a loop is restarted
+func (s *Supervisor) SetOnRestart(fn func(name, reason string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRestart = fn
+}
+
+// Go registers and starts a supervised loop. A loop that has not sent a
+// heartbeat within stallTimeout is considered dead and is restarted.
+func (s *Supervisor) Go(name string, stallTimeout time.Duration, fn LoopFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.loops[name]; exists {
+		log.Warn().Str("loop", name).Msg("Loop already supervised, ignoring duplicate registration")
+		return
+	}
+
+	loop := &supervisedLoop{
+		name:         name,
+		fn:           fn,
+		stallTimeout: stallTimeout,
+	}
+	s.loops[name] = loop
+	s.startLocked(loop)
+
+	log.Debug().Str("loop", name).Dur("stallTimeout", stallTimeout).Msg("Supervised loop started")
+}
+
+// startLocked launches a new generation of the loop (s.mu must be held)
+func (s *Supervisor) startLocked(loop *supervisedLoop) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+
+	loop.generation++
+	loop.cancel = cancel
+	loop.done = done
+	loop.startedAt = time.Now()
+	loop.lastBeat = loop.startedAt
+	loop.exited = false
+
+	generation := loop.generation
+	beat := func() {
+		s.mu.Lock()
+		if loop.generation == generation {
+			loop.lastBeat = time.Now()
+		}
+		s.mu.Unlock()
+	}
+
+	go func() {
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+				log.Error().
+					Str("loop", loop.name).
+					Interface("panic", r).
+					Str("stack", string(debug.Stack())).
+					Msg("Supervised loop panicked")
+			}
+			s.markExited(loop, generation, err)
+			close(done)
+		}()
+		err = loop.fn(ctx, beat)
+	}()
+}
+
+// markExited records that a loop generation has returned
+func (s *Supervisor) markExited(loop *supervisedLoop, generation int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if loop.generation != generation {
+		return
+	}
+	loop.exited = true
+	if err != nil {
+		loop.lastError = err.Error()
+	} else if s.ctx.Err() == nil {
+		loop.lastError = "loop exited unexpectedly"
+	}
+}
+
+// Run checks loop health until the supervisor context is cancelled
+func (s *Supervisor) Run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.check()
+		}
+	}
+}
+
+// check restarts loops that have exited or stalled
+func (s *Supervisor) check() {
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	type restart struct {
+		name   string
+		reason string
+	}
+	var restarted []restart
+
+	s.mu.Lock()
+	now := time.Now()
+	for _, loop := range s.loops {
+		reason := ""
+		switch {
+		case loop.exited:
+			reason = loop.lastError
+		case loop.stallTimeout > 0 && now.Sub(loop.lastBeat) > loop.stallTimeout:
+			reason = fmt.Sprintf("no heartbeat for %s", now.Sub(loop.lastBeat).Round(time.Second))
+			loop.lastError = reason
+		default:
+			continue
+		}
+
+		// Cancel the previous generation; a stalled goroutine that ignores
+		// its context is abandoned and its heartbeats are ignored
+		loop.cancel()
+		loop.restarts++
+		loop.lastRestartAt = now
+		s.startLocked(loop)
+		restarted = append(restarted, restart{name: loop.name, reason: reason})
+	}
+	onRestart := s.onRestart
+	s.mu.Unlock()
+
+	for _, r := range restarted {
+		log.Warn().Str("loop", r.name).Str("reason", r.reason).Msg("Restarted supervised loop")
+		if onRestart != nil {
+			onRestart(r.name, r.reason)
+		}
+	}
+}
+
+// Wait waits for the current generation of every loop to exit, up to timeout
+func (s *Supervisor) Wait(timeout time.Duration) {
+	s.mu.Lock()
+	pending := make(map[string]chan struct{}, len(s.loops))
+	for name, loop := range s.loops {
+		pending[name] = loop.done
+	}
+	s.mu.Unlock()
+
+	deadline := time.After(timeout)
+	for name, done := range pending {
+		select {
+		case <-done:
+		case <-deadline:
+			log.Warn().Str("loop", name).Msg("Supervised loop did not stop in time")
+			return
+		}
+	}
+}
+
+// Health returns the health of all supervised loops sorted by name
+func (s *Supervisor) Health() []LoopHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	stopping := s.ctx.Err() != nil
+
+	health := make([]LoopHealth, 0, len(s.loops))
+	for _, loop := range s.loops {
+		h := LoopHealth{
+			Name:          loop.name,
+			Status:        LoopStatusRunning,
+			Healthy:       true,
+			StartedAt:     loop.startedAt,
+			LastHeartbeat: loop.lastBeat,
+			StallTimeout:  loop.stallTimeout.String(),
+			Restarts:      loop.restarts,
+			LastError:     loop.lastError,
+		}
+		if !loop.lastRestartAt.IsZero() {
+			t := loop.lastRestartAt
+			h.LastRestartAt = &t
+		}
+
+		switch {
+		case stopping:
+			h.Status = LoopStatusStopped
+			h.Healthy = false
+		case loop.exited:
+			h.Status = LoopStatusRestarting
+			h.Healthy = false
+		case loop.stallTimeout > 0 && now.Sub(loop.lastBeat) > loop.stallTimeout:
+			h.Status = LoopStatusStalled
+			h.Healthy = false
+		}
+
+		health = append(health, h)
+	}
+
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].Name < health[j].Name
+	})
+
+	return health
+}
+
+// IsHealthy reports whether every supervised loop is running and beating
+func (s *Supervisor) IsHealthy() bool {
+	for _, h := range s.Health() {
+		if !h.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// maxDuration returns the larger of two durations
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+)
+
+// symbolRulesTTL is how long a symbol's trading rules are served before
+// they are fetched again. Exchange info is heavy on request weight and
+// its filters rarely change.
+const symbolRulesTTL = time.Hour
+
+// SymbolRulesSource fetches a symbol's trading rules from the exchange
+type SymbolRulesSource interface {
+	GetSymbolInfo(symbol string) (*binance.SymbolInfo, error)
+}
+
+// SymbolRules are the exchange's trading rules for a symbol, which an
+// order's price and quantity must meet
+type SymbolRules struct {
+	Symbol              string    `json:"symbol"`
+	Market              string    `json:"market"` // "spot" or "futures"
+	Status              string    `json:"status"`
+	BaseAsset           string    `json:"baseAsset"`
+	QuoteAsset          string    `json:"quoteAsset"`
+	BaseAssetPrecision  int       `json:"baseAssetPrecision"`
+	QuoteAssetPrecision int       `json:"quoteAssetPrecision"`
+	OrderTypes          []string  `json:"orderTypes"`
+	TickSize            float64   `json:"tickSize"` // Prices are multiples of it
+	MinPrice            float64   `json:"minPrice"`
+	MaxPrice            float64   `json:"maxPrice"` // 0 = no limit
+	StepSize            float64   `json:"stepSize"` // Quantities are multiples of it
+	MinQty              float64   `json:"minQty"`
+	MaxQty              float64   `json:"maxQty"`
+	MinNotional         float64   `json:"minNotional"`       // Minimum price × quantity
+	PricePrecision      int       `json:"pricePrecision"`    // Decimals of the tick size
+	QuantityPrecision   int       `json:"quantityPrecision"` // Decimals of the step size
+	FetchedAt           time.Time `json:"fetchedAt"`
+}
+
+// symbolRulesCache holds the trading rules fetched per symbol
+type symbolRulesCache struct {
+	mu     sync.Mutex // Also serializes fetches
+	source SymbolRulesSource
+	market string
+	rules  map[string]*SymbolRules
+}
+
+// SetSymbolRulesSource sets where GetSymbolRules fetches trading rules,
+// the client of the market traded. Without one, the spot client is used.
+func (o *Orchestrator) SetSymbolRulesSource(source SymbolRulesSource, market string) {
+	o.symbolRules.mu.Lock()
+	defer o.symbolRules.mu.Unlock()
+	o.symbolRules.source = source
+	o.symbolRules.market = market
+	o.symbolRules.rules = nil
+}
+
+// GetSymbolRules returns a symbol's trading rules, fetching them when the
+// cached ones are older than symbolRulesTTL or refresh is set. Symbols the
+// exchange doesn't list return binance.ErrSymbolNotFound.
+func (o *Orchestrator) GetSymbolRules(symbol string, refresh bool) (*SymbolRules, error) {
+	symbol = strings.ToUpper(symbol)
+
+	o.symbolRules.mu.Lock()
+	defer o.symbolRules.mu.Unlock()
+
+	if cached, ok := o.symbolRules.rules[symbol]; ok && !refresh && time.Since(cached.FetchedAt) < symbolRulesTTL {
+		return cached, nil
+	}
+
+	source, market := o.symbolRules.source, o.symbolRules.market
+	if source == nil {
+		if o.binanceClient == nil {
+			return nil, fmt.Errorf("binance client not set")
+		}
+		source, market = o.binanceClient, "spot"
+	}
+
+	info, err := source.GetSymbolInfo(symbol)
+	if err != nil {
+		return nil, err
+	}
+	rules := &SymbolRules{
+		Symbol:              info.Symbol,
+		Market:              market,
+		Status:              info.Status,
+		BaseAsset:           info.BaseAsset,
+		QuoteAsset:          info.QuoteAsset,
+		BaseAssetPrecision:  info.BaseAssetPrecision,
+		QuoteAssetPrecision: info.QuoteAssetPrecision,
+		OrderTypes:          info.OrderTypes,
+		TickSize:            info.TickSize,
+		MinPrice:            info.MinPrice,
+		MaxPrice:            info.MaxPrice,
+		StepSize:            info.StepSize,
+		MinQty:              info.MinQty,
+		MaxQty:              info.MaxQty,
+		MinNotional:         info.MinNotional,
+		PricePrecision:      info.PricePrecision,
+		QuantityPrecision:   info.QuantityPrecision,
+		FetchedAt:           time.Now(),
+	}
+
+	if o.symbolRules.rules == nil {
+		o.symbolRules.rules = make(map[string]*SymbolRules)
+	}
+	o.symbolRules.rules[symbol] = rules
+	return rules, nil
+}
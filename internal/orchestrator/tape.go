@@ -0,0 +1,209 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+)
+
+const (
+	// defaultTapeWindow is how long trades are kept for the tape
+	defaultTapeWindow = 15 * time.Minute
+	// defaultLargeTradeValue is the quote value (USDT) of a highlighted trade
+	defaultLargeTradeValue = 50000
+	// maxTapeTrades bounds the rolling window during bursts
+	maxTapeTrades = 200000
+)
+
+// TapeTrade is a single trade on the tape
+type TapeTrade struct {
+	ID       int64     `json:"id"`
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Quantity float64   `json:"quantity"`
+	Value    float64   `json:"value"` // Quote value (USDT)
+	Side     string    `json:"side"`  // Aggressor side: "buy" or "sell"
+}
+
+// TapeBucket aggregates the trades within one bucket of the tape
+type TapeBucket struct {
+	Start       time.Time   `json:"start"`
+	Trades      int         `json:"trades"`
+	BuyVolume   float64     `json:"buyVolume"`  // Base quantity bought by takers
+	SellVolume  float64     `json:"sellVolume"` // Base quantity sold by takers
+	BuyCount    int         `json:"buyCount"`
+	SellCount   int         `json:"sellCount"`
+	Delta       float64     `json:"delta"` // BuyVolume - SellVolume
+	VWAP        float64     `json:"vwap"`
+	High        float64     `json:"high"`
+	Low         float64     `json:"low"`
+	LargeTrades []TapeTrade `json:"largeTrades,omitempty"`
+}
+
+// Tape is the aggregated order-flow tape
+type Tape struct {
+	Symbol          string       `json:"symbol"`
+	Bucket          string       `json:"bucket"`
+	Window          string       `json:"window"`
+	LargeTradeValue float64      `json:"largeTradeValue"`
+	From            time.Time    `json:"from"`
+	To              time.Time    `json:"to"`
+	BuyVolume       float64      `json:"buyVolume"`
+	SellVolume      float64      `json:"sellVolume"`
+	Delta           float64      `json:"delta"`
+	Buckets         []TapeBucket `json:"buckets"` // Oldest first; buckets without trades are omitted
+}
+
+// tradeTape keeps a rolling window of trades from the trade stream
+type tradeTape struct {
+	window     time.Duration
+	largeValue float64
+	trades     []TapeTrade // Oldest first
+	mu         sync.RWMutex
+}
+
+// SetTapePolicy sets how long the order-flow tape keeps trades and the quote
+// value above which trades are highlighted; zero values keep the defaults
+func (o *Orchestrator) SetTapePolicy(window time.Duration, largeTradeValue float64) {
+	o.tape.mu.Lock()
+	defer o.tape.mu.Unlock()
+	o.tape.window = window
+	o.tape.largeValue = largeTradeValue
+}
+
+// settings returns the window and large trade threshold (t.mu must be held)
+func (t *tradeTape) settings() (time.Duration, float64) {
+	window, large := t.window, t.largeValue
+	if window <= 0 {
+		window = defaultTapeWindow
+	}
+	if large <= 0 {
+		large = defaultLargeTradeValue
+	}
+	return window, large
+}
+
+// record appends a trade event and drops trades that left the window
+func (t *tradeTape) record(event binance.TradeEvent, price float64) {
+	qty, err := strconv.ParseFloat(event.Quantity, 64)
+	if err != nil || qty <= 0 {
+		return
+	}
+
+	side := "buy"
+	if event.IsBuyerMaker {
+		side = "sell" // The seller took the bid
+	}
+	trade := TapeTrade{
+		ID:       event.TradeID,
+		Time:     time.UnixMilli(event.TradeTime),
+		Price:    price,
+		Quantity: qty,
+		Value:    price * qty,
+		Side:     side,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	window, _ := t.settings()
+	t.trades = append(t.trades, trade)
+
+	cutoff := trade.Time.Add(-window)
+	drop := 0
+	for drop < len(t.trades) && t.trades[drop].Time.Before(cutoff) {
+		drop++
+	}
+	if excess := len(t.trades) - maxTapeTrades; excess > drop {
+		drop = excess
+	}
+	if drop > 0 {
+		// Copy down rather than reslice so the backing array does not grow
+		n := copy(t.trades, t.trades[drop:])
+		t.trades = t.trades[:n]
+	}
+}
+
+// GetTape aggregates the rolling trade window into buckets of the given
+// size, returning at most limit of the most recent non-empty buckets
+func (o *Orchestrator) GetTape(bucket time.Duration, limit int) (*Tape, error) {
+	if bucket <= 0 {
+		return nil, fmt.Errorf("bucket must be positive")
+	}
+
+	o.tape.mu.RLock()
+	defer o.tape.mu.RUnlock()
+
+	window, large := o.tape.settings()
+	if bucket > window {
+		return nil, fmt.Errorf("bucket %s exceeds the %s tape window", bucket, window)
+	}
+
+	tape := &Tape{
+		Symbol:          o.config.Symbol,
+		Bucket:          bucket.String(),
+		Window:          window.String(),
+		LargeTradeValue: large,
+		Buckets:         []TapeBucket{},
+	}
+
+	var current *TapeBucket
+	var notional float64
+	closeBucket := func() {
+		if current == nil {
+			return
+		}
+		if volume := current.BuyVolume + current.SellVolume; volume > 0 {
+			current.VWAP = notional / volume
+		}
+		current.Delta = current.BuyVolume - current.SellVolume
+		tape.Buckets = append(tape.Buckets, *current)
+	}
+
+	for _, trade := range o.tape.trades {
+		start := trade.Time.Truncate(bucket)
+		if current == nil || !start.Equal(current.Start) {
+			closeBucket()
+			current = &TapeBucket{Start: start, High: trade.Price, Low: trade.Price}
+			notional = 0
+		}
+
+		current.Trades++
+		notional += trade.Value
+		if trade.Side == "buy" {
+			current.BuyVolume += trade.Quantity
+			current.BuyCount++
+		} else {
+			current.SellVolume += trade.Quantity
+			current.SellCount++
+		}
+		if trade.Price > current.High {
+			current.High = trade.Price
+		}
+		if trade.Price < current.Low {
+			current.Low = trade.Price
+		}
+		if trade.Value >= large {
+			current.LargeTrades = append(current.LargeTrades, trade)
+		}
+	}
+	closeBucket()
+
+	if limit > 0 && len(tape.Buckets) > limit {
+		tape.Buckets = tape.Buckets[len(tape.Buckets)-limit:]
+	}
+	for _, b := range tape.Buckets {
+		tape.BuyVolume += b.BuyVolume
+		tape.SellVolume += b.SellVolume
+	}
+	tape.Delta = tape.BuyVolume - tape.SellVolume
+	if n := len(tape.Buckets); n > 0 {
+		tape.From = tape.Buckets[0].Start
+		tape.To = tape.Buckets[n-1].Start.Add(bucket)
+	}
+
+	return tape, nil
+}
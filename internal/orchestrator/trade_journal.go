@@ -0,0 +1,247 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/eth-trading/internal/execution"
+	"github.com/eth-trading/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// tradeJournalBuffer is the number of position events queued for SQLite
+const tradeJournalBuffer = 1024
+
+// journalEntry is a position event copied at the time it happened
+type journalEntry struct {
+	eventType execution.PositionEventType
+	position  execution.Position
+	trade     *execution.Trade
+	at        time.Time
+}
+
+// tradeJournal persists live fills and position lifecycle events so trade
+// history survives restarts. Writes happen on one goroutine, in order.
+type tradeJournal struct {
+	events chan journalEntry
+
+	// Executor position ID -> positions row ID, owned by the journal loop
+	rows map[int64]int64
+}
+
+// journalPositionEvent queues a live position event for persistence. It is
+// called from executor callbacks and never blocks.
+func (o *Orchestrator) journalPositionEvent(event execution.PositionEvent) {
+	if event.Position == nil {
+		return
+	}
+
+	entry := journalEntry{
+		eventType: event.Type,
+		position:  *event.Position,
+		at:        event.Timestamp,
+	}
+	if event.Trade != nil {
+		trade := *event.Trade
+		entry.trade = &trade
+	}
+
+	select {
+	case o.journal.events <- entry:
+	default:
+		log.Error().
+			Int64("positionID", event.Position.ID).
+			Str("event", event.Type.String()).
+			Msg("Trade journal full, event not persisted")
+	}
+}
+
+// tradeJournalLoop writes queued position events to SQLite
+func (o *Orchestrator) tradeJournalLoop(ctx context.Context, beat func()) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry := <-o.journal.events:
+			o.persistJournalEntry(entry)
+			beat()
+		case <-ticker.C:
+			beat()
+		}
+	}
+}
+
+// persistJournalEntry records the fill and the position change of one event
+func (o *Orchestrator) persistJournalEntry(entry journalEntry) {
+	if o.journal.rows == nil {
+		o.journal.rows = make(map[int64]int64)
+	}
+
+	var order *execution.Order
+	if t := entry.trade; t != nil {
+		order = o.journalOrder(t.OrderID)
+		orderType := string(execution.OrderTypeMarket)
+		if order != nil && order.Type != "" {
+			orderType = string(order.Type)
+		}
+		trade := storage.Trade{
+			OrderID:         t.OrderID,
+			Symbol:          t.Symbol,
+			Side:            string(t.Side),
+			Type:            orderType,
+			Quantity:        t.Quantity,
+			Price:           t.Price,
+			Commission:      t.Commission,
+			CommissionAsset: t.CommissionAsset,
+			ExecutedAt:      t.ExecutedAt,
+			Strategy:        t.Strategy,
+		}
+		if err := o.dataService.MergeTrade(trade); err != nil {
+			log.Error().Err(err).Str("orderID", t.OrderID).Msg("Failed to persist trade")
+		}
+	}
+
+	pos := entry.position
+	row := storage.Position{
+		Symbol:        pos.Symbol,
+		Side:          strings.ToLower(string(pos.Side)),
+		EntryPrice:    pos.EntryPrice,
+		Quantity:      pos.Quantity,
+		CurrentPrice:  pos.CurrentPrice,
+		UnrealizedPnL: pos.UnrealizedPnL,
+		RealizedPnL:   pos.RealizedPnL,
+		StopLoss:      pos.StopLoss,
+		TakeProfit:    pos.TakeProfit,
+		Strategy:      pos.Strategy,
+		Status:        "open",
+		OpenedAt:      pos.OpenTime,
+	}
+
+	closed := false
+	switch entry.eventType {
+	case execution.PositionEventClosed, execution.PositionEventStopLossHit, execution.PositionEventTakeProfitHit:
+		closed = true
+		closedAt := entry.at
+		row.Status = "closed"
+		row.ClosedAt = &closedAt
+		row.UnrealizedPnL = 0
+		if entry.trade != nil {
+			row.CurrentPrice = entry.trade.Price
+		}
+	}
+
+	id, known := o.journal.rows[pos.ID]
+	if !known {
+		id = o.findOpenPositionRow(row)
+	}
+
+	if id == 0 {
+		// First sight of the position, or opened before a restart
+		newID, err := o.dataService.AddPosition(row)
+		if err != nil {
+			log.Error().Err(err).Int64("positionID", pos.ID).Msg("Failed to persist position")
+			return
+		}
+		id = newID
+	}
+
+	row.ID = id
+	if err := o.dataService.UpdatePosition(row); err != nil {
+		log.Error().Err(err).Int64("positionID", pos.ID).Msg("Failed to update persisted position")
+		return
+	}
+	if closed {
+		delete(o.journal.rows, pos.ID)
+	} else {
+		o.journal.rows[pos.ID] = id
+	}
+
+	o.attributePnL(id, entry, order, closed)
+}
+
+// findOpenPositionRow finds the stored open position a restarted executor's
+// position continues, matching on symbol and side
+func (o *Orchestrator) findOpenPositionRow(row storage.Position) int64 {
+	open, err := o.dataService.GetOpenPositions()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to read open positions")
+		return 0
+	}
+	for _, p := range open {
+		if p.Symbol != row.Symbol || p.Side != row.Side {
+			continue
+		}
+		claimed := false
+		for _, id := range o.journal.rows {
+			if id == p.ID {
+				claimed = true
+				break
+			}
+		}
+		if !claimed {
+			return p.ID
+		}
+	}
+	return 0
+}
+
+// journalOrder looks up the order behind a fill, nil if the executor no
+// longer knows it
+func (o *Orchestrator) journalOrder(orderID string) *execution.Order {
+	exec := o.activeExecutor()
+	if exec == nil {
+		return nil
+	}
+	order, err := exec.GetOrder(orderID)
+	if err != nil {
+		return nil
+	}
+	return order
+}
+
+// GetTradeHistory returns persisted trades, newest first. A strategy
+// filters by strategy; a non-zero from/to selects a date range instead.
+func (o *Orchestrator) GetTradeHistory(strategy string, from, to time.Time, limit int) ([]storage.Trade, error) {
+	if o.dataService == nil {
+		return nil, nil
+	}
+
+	switch {
+	case !from.IsZero() || !to.IsZero():
+		if to.IsZero() {
+			to = time.Now()
+		}
+		trades, err := o.dataService.GetTradesByDateRange(from, to)
+		if err != nil {
+			return nil, err
+		}
+		// Stored ascending; return newest first like the other queries
+		var result []storage.Trade
+		for i := len(trades) - 1; i >= 0 && len(result) < limit; i-- {
+			if strategy == "" || trades[i].Strategy == strategy {
+				result = append(result, trades[i])
+			}
+		}
+		return result, nil
+	case strategy != "":
+		return o.dataService.GetTradesByStrategy(strategy, limit)
+	default:
+		return o.dataService.GetRecentTrades(limit)
+	}
+}
+
+// GetPositionHistory returns persisted positions: "open", or closed ones
+// newest first
+func (o *Orchestrator) GetPositionHistory(status string, limit int) ([]storage.Position, error) {
+	if o.dataService == nil {
+		return nil, nil
+	}
+	if status == "open" {
+		return o.dataService.GetOpenPositions()
+	}
+	return o.dataService.GetClosedPositions(limit)
+}
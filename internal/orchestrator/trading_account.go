@@ -0,0 +1,39 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SetTradingAccount sets the trading account, by its ID in the auth
+// database, that the bot trades. Account-scoped broadcasts carry it so that
+// only its owner's WebSocket clients receive them; empty sends them to every
+// user. It must be called before Start.
+func (o *Orchestrator) SetTradingAccount(id string) error {
+	if id != "" {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("invalid trading account ID %q: %w", id, err)
+		}
+	}
+	o.tradingAccount = id
+	return nil
+}
+
+// GetTradingAccount returns the ID of the trading account the bot trades,
+// empty when unset
+func (o *Orchestrator) GetTradingAccount() string {
+	return o.tradingAccount
+}
+
+// AccountScoped reports whether broadcasts of a message type concern the
+// traded account, rather than the market or the bot as a whole
+func AccountScoped(messageType string) bool {
+	switch messageType {
+	case MessageTypeState, MessageTypeSignal, MessageTypeTrade, MessageTypePosition,
+		MessageTypeRisk, MessageTypeMode, MessageTypeArming, MessageTypeTradeIdea,
+		MessageTypeRiskReport:
+		return true
+	}
+	return false
+}
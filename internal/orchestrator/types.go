@@ -18,6 +18,7 @@ type OrchestratorConfig struct {
 	// Mode
 	Mode            TradingMode
 	InitialCapital  float64
+	Observer        bool // Market data only: no strategies, risk checks or orders
 
 	// Strategy
 	EnabledStrategies []string
@@ -72,6 +73,7 @@ type TradingState struct {
 	Mode           TradingMode
 	IsRunning      bool
 	IsPaused       bool
+	Arming         ArmingState // Live-mode arming phase (empty when not required)
 	StartTime      time.Time
 	LastUpdate     time.Time
 
@@ -94,7 +96,8 @@ type TradingState struct {
 
 	// Risk
 	CurrentDrawdown float64
-	MaxDrawdown    float64
+	MaxDrawdown    float64 // Drawdown limit in quote currency, on PeakEquity
+	PeakEquity     float64 // High-water mark drawdown is measured from
 	RiskLevel      risk.RiskLevel
 	IsHalted       bool
 	HaltReason     string
@@ -107,6 +110,7 @@ type TradingState struct {
 	// System
 	CandleCount    int
 	LastCandleTime time.Time
+	Timeframes     []TimeframeHealth // Candle feed freshness per timeframe
 	Errors         []string
 }
 
@@ -115,6 +119,7 @@ type BroadcastMessage struct {
 	Type      string      `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data"`
+	AccountID string      `json:"accountId,omitempty"` // Trading account the message concerns; empty = every user
 }
 
 // MessageType constants
@@ -127,7 +132,15 @@ const (
 	MessageTypeRisk       = "risk"
 	MessageTypeError      = "error"
 	MessageTypeIndicators = "indicators"
-	MessageTypePrice      = "price" // Real-time price updates
+	MessageTypePrice      = "price"       // Real-time price updates
+	MessageTypeMode       = "mode"        // Scheduled trading mode transitions
+	MessageTypeArming     = "arming"      // Live-mode arming transitions
+	MessageTypeTradeIdea  = "trade_idea"  // Signals queued for or decided in the approval inbox
+	MessageTypeScore      = "score"       // Scorer breakdown of the latest analysis (throttled)
+	MessageTypeBacktest   = "backtest"    // Backtest job progress and completion
+	MessageTypeStrategy   = "strategy"    // Strategies enabled or disabled at runtime
+	MessageTypeRiskReport = "risk_report" // Daily risk digest, at the day boundary
+	MessageTypeParamDrift = "param_drift" // Strategy parameters drifted from optimized values
 )
 
 // StateUpdate represents a state update message
@@ -165,6 +178,7 @@ type CandleUpdate struct {
 	Close     float64   `json:"close"`
 	Volume    float64   `json:"volume"`
 	IsClosed  bool      `json:"isClosed"`
+	Session   string    `json:"session"`
 }
 
 // SignalUpdate represents a signal update message
@@ -173,14 +187,30 @@ type SignalUpdate struct {
 	Approved    bool             `json:"approved"`
 	RejectedBy  string           `json:"rejectedBy,omitempty"`
 	Reason      string           `json:"reason,omitempty"`
+	Liquidity   *LiquidityCheck  `json:"liquidity,omitempty"` // Set when the liquidity filter is on
+	Confluence  *ConfluenceCheck `json:"confluence,omitempty"` // Set when confluence applies to the strategy
 }
 
 // SignalRecord stores a signal with its approval status for history
 type SignalRecord struct {
-	Signal     *strategy.Signal `json:"signal"`
-	Approved   bool             `json:"approved"`
-	Reason     string           `json:"reason,omitempty"`
-	ReceivedAt time.Time        `json:"receivedAt"`
+	Signal     *strategy.Signal          `json:"signal"`
+	Approved   bool                      `json:"approved"`
+	Reason     string                    `json:"reason,omitempty"`
+	StopLoss   *risk.StopLossEnforcement `json:"stopLossEnforcement,omitempty"` // Set when the signal had no stop loss
+	Liquidity  *LiquidityCheck           `json:"liquidity,omitempty"`           // Set when the liquidity filter is on
+	Confluence *ConfluenceCheck          `json:"confluence,omitempty"`          // Set when confluence applies to the strategy
+	ReceivedAt time.Time                 `json:"receivedAt"`
+}
+
+// ModeUpdate represents a trading mode transition message
+type ModeUpdate struct {
+	Event       string    `json:"event"` // "pending" or "switched"
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Window      string    `json:"window,omitempty"`
+	Policy      string    `json:"policy"`
+	Reason      string    `json:"reason,omitempty"`
+	EffectiveAt time.Time `json:"effectiveAt"`
 }
 
 // TradeUpdate represents a trade update message
@@ -231,15 +261,19 @@ type RiskUpdate struct {
 
 // IndicatorsUpdate represents indicators update message
 type IndicatorsUpdate struct {
-	Symbol    string             `json:"symbol"`
-	Timeframe string             `json:"timeframe"`
-	Timestamp time.Time          `json:"timestamp"`
-	RSI       float64            `json:"rsi"`
-	MACD      *MACDValue         `json:"macd"`
-	BB        *BollingerValue    `json:"bb"`
-	ADX       *ADXValue          `json:"adx"`
-	ATR       float64            `json:"atr"`
-	Regime    string             `json:"regime"`
+	Symbol     string           `json:"symbol"`
+	Timeframe  string           `json:"timeframe"`
+	Timestamp  time.Time        `json:"timestamp"`
+	RSI        float64          `json:"rsi"`
+	MACD       *MACDValue       `json:"macd"`
+	BB         *BollingerValue  `json:"bb"`
+	ADX        *ADXValue        `json:"adx"`
+	ATR        float64          `json:"atr"`
+	VWAP       *VWAPValue       `json:"vwap"`
+	SuperTrend *SuperTrendValue `json:"supertrend"`
+	Ichimoku   *IchimokuValue   `json:"ichimoku"`
+	Keltner    *KeltnerValue    `json:"keltner"`
+	Regime     string           `json:"regime"`
 }
 
 // MACDValue represents MACD values
@@ -264,6 +298,34 @@ type ADXValue struct {
 	MinusDI float64 `json:"minusDI"`
 }
 
+// VWAPValue represents session VWAP values
+type VWAPValue struct {
+	VWAP     float64 `json:"vwap"`
+	Distance float64 `json:"distance"` // Percent of the close from VWAP
+}
+
+// SuperTrendValue represents SuperTrend values
+type SuperTrendValue struct {
+	Value     float64 `json:"value"`
+	Direction string  `json:"direction"` // UP or DOWN
+}
+
+// IchimokuValue represents Ichimoku cloud values
+type IchimokuValue struct {
+	Tenkan   float64 `json:"tenkan"`
+	Kijun    float64 `json:"kijun"`
+	SenkouA  float64 `json:"senkouA"`
+	SenkouB  float64 `json:"senkouB"`
+	Position string  `json:"position"` // UP above the cloud, DOWN below, NEUTRAL inside
+}
+
+// KeltnerValue represents Keltner Channel values
+type KeltnerValue struct {
+	Upper  float64 `json:"upper"`
+	Middle float64 `json:"middle"`
+	Lower  float64 `json:"lower"`
+}
+
 // ErrorUpdate represents an error message
 type ErrorUpdate struct {
 	Code    string    `json:"code"`
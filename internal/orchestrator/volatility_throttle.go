@@ -0,0 +1,191 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/strategy"
+	"github.com/rs/zerolog/log"
+)
+
+// VolatilityThrottleStats counts a strategy's entries signaled on candles
+// whose range was a volatility spike, and what became of them
+type VolatilityThrottleStats struct {
+	Strategy    string    `json:"strategy"`
+	Spikes      int64     `json:"spikes"` // Entries signaled on a spike
+	Downsized   int64     `json:"downsized"`
+	Delayed     int64     `json:"delayed"`
+	Released    int64     `json:"released"` // Delayed entries let through on the next candle
+	Dropped     int64     `json:"dropped"`  // Delayed entries the next candle did not bear out
+	LastSpikeAt time.Time `json:"lastSpikeAt,omitempty"`
+}
+
+// delayedEntry is an entry held back from a volatility spike until the
+// next candle closes
+type delayedEntry struct {
+	signal      strategy.Signal
+	spikeClose  float64
+	candleClose time.Time // Of the spike
+}
+
+// volatilityThrottle delays or downsizes entries signaled during candles
+// with an outsized range, per strategy
+type volatilityThrottle struct {
+	mu      sync.Mutex
+	rules   strategy.VolatilityThrottles
+	pending map[string]delayedEntry // By canonical strategy name
+	stats   map[string]*VolatilityThrottleStats
+}
+
+// SetVolatilityThrottle sets the volatility spike rule for all strategies
+// and per-strategy exceptions. Backtests built from live settings apply
+// the same rules.
+func (o *Orchestrator) SetVolatilityThrottle(rules strategy.VolatilityThrottles) error {
+	if err := rules.Default.Validate(); err != nil {
+		return err
+	}
+	strategies := make(map[string]strategy.VolatilityThrottle, len(rules.Strategies))
+	for name, rule := range rules.Strategies {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+		strategies[strategy.CanonicalName(name)] = rule
+	}
+
+	o.spikes.mu.Lock()
+	defer o.spikes.mu.Unlock()
+	o.spikes.rules = strategy.VolatilityThrottles{Default: rules.Default, Strategies: strategies}
+	return nil
+}
+
+// GetVolatilityThrottles returns a copy of the volatility spike rules
+func (o *Orchestrator) GetVolatilityThrottles() strategy.VolatilityThrottles {
+	o.spikes.mu.Lock()
+	defer o.spikes.mu.Unlock()
+
+	result := strategy.VolatilityThrottles{
+		Default:    o.spikes.rules.Default,
+		Strategies: make(map[string]strategy.VolatilityThrottle, len(o.spikes.rules.Strategies)),
+	}
+	for name, rule := range o.spikes.rules.Strategies {
+		result.Strategies[name] = rule
+	}
+	return result
+}
+
+// GetVolatilityThrottleStats returns volatility spike counters by
+// canonical strategy name
+func (o *Orchestrator) GetVolatilityThrottleStats() map[string]VolatilityThrottleStats {
+	o.spikes.mu.Lock()
+	defer o.spikes.mu.Unlock()
+
+	result := make(map[string]VolatilityThrottleStats, len(o.spikes.stats))
+	for name, stats := range o.spikes.stats {
+		result[name] = *stats
+	}
+	return result
+}
+
+// statsLocked returns the counters of a strategy (mu must be held)
+func (t *volatilityThrottle) statsLocked(strategyName string) *VolatilityThrottleStats {
+	if t.stats == nil {
+		t.stats = make(map[string]*VolatilityThrottleStats)
+	}
+	key := strategy.CanonicalName(strategyName)
+	stats, ok := t.stats[key]
+	if !ok {
+		stats = &VolatilityThrottleStats{Strategy: strategyName}
+		t.stats[key] = stats
+	}
+	return stats
+}
+
+// throttleVolatility applies the strategy's volatility spike rule to an
+// entry signaled on a candle with the given high, low and close. It
+// reports whether the entry was held back until the next candle; entries
+// let through may have been downsized.
+func (o *Orchestrator) throttleVolatility(signal *strategy.Signal, high, low, close, atr float64, candleClose time.Time) bool {
+	o.spikes.mu.Lock()
+	defer o.spikes.mu.Unlock()
+
+	rule := o.spikes.rules.For(signal.Strategy)
+	if !rule.Spike(high, low, atr) {
+		return false
+	}
+	stats := o.spikes.statsLocked(signal.Strategy)
+	stats.Spikes++
+	stats.LastSpikeAt = candleClose
+
+	if rule.Action == strategy.VolatilityDownsize {
+		stats.Downsized++
+		signal.SizeFactor = rule.SizeFactor
+		log.Info().
+			Str("strategy", signal.Strategy).
+			Float64("rangeATR", (high-low)/atr).
+			Float64("sizeFactor", rule.SizeFactor).
+			Msg("Entry downsized on volatility spike")
+		return false
+	}
+
+	if o.spikes.pending == nil {
+		o.spikes.pending = make(map[string]delayedEntry)
+	}
+	stats.Delayed++
+	o.spikes.pending[strategy.CanonicalName(signal.Strategy)] = delayedEntry{
+		signal:      *signal,
+		spikeClose:  close,
+		candleClose: candleClose,
+	}
+	log.Info().
+		Str("strategy", signal.Strategy).
+		Float64("rangeATR", (high-low)/atr).
+		Bool("confirm", rule.Confirm).
+		Msg("Entry delayed past volatility spike")
+	return true
+}
+
+// releaseDelayedEntries settles the entries held back from the previous
+// candle's spike against the candle closing at candleClose, returning
+// those that go ahead at its close. The others, and entries left over from
+// older candles, are dropped.
+func (o *Orchestrator) releaseDelayedEntries(high, low, close, atr float64, candleClose time.Time) []strategy.Signal {
+	o.spikes.mu.Lock()
+	defer o.spikes.mu.Unlock()
+
+	if len(o.spikes.pending) == 0 {
+		return nil
+	}
+	candle := binance.IntervalToDuration(o.config.PrimaryTimeframe)
+	if candle <= 0 {
+		candle = time.Minute
+	}
+
+	var released []strategy.Signal
+	for name, entry := range o.spikes.pending {
+		if !candleClose.After(entry.candleClose) {
+			continue
+		}
+		delete(o.spikes.pending, name)
+
+		rule := o.spikes.rules.For(name)
+		stats := o.spikes.statsLocked(entry.signal.Strategy)
+		if candleClose.Sub(entry.candleClose) > candle || !rule.Release(entry.signal, entry.spikeClose, close, rule.Spike(high, low, atr)) {
+			stats.Dropped++
+			log.Info().
+				Str("strategy", entry.signal.Strategy).
+				Float64("spikeClose", entry.spikeClose).
+				Float64("close", close).
+				Msg("Delayed entry dropped")
+			continue
+		}
+
+		stats.Released++
+		signal := entry.signal
+		signal.Price = close
+		signal.Reason += " (delayed past volatility spike)"
+		released = append(released, signal)
+	}
+	return released
+}
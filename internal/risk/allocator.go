@@ -0,0 +1,351 @@
+package risk
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AllocationMode determines how strategy weights are assigned
+type AllocationMode string
+
+const (
+	// AllocationFixed uses configured weights (equal split when none given)
+	AllocationFixed AllocationMode = "fixed"
+	// AllocationPerformance reweights strategies by recent realized returns
+	AllocationPerformance AllocationMode = "performance"
+)
+
+// AllocatorConfig holds capital allocator configuration
+type AllocatorConfig struct {
+	Mode              AllocationMode
+	Weights           map[string]float64 // Fixed weights (normalized); also the base for performance mode
+	MinWeight         float64            // Floor per strategy in performance mode
+	MaxWeight         float64            // Cap per strategy in performance mode
+	RebalanceInterval time.Duration      // Periodic reallocation interval (0 = manual only)
+	HistorySize       int
+}
+
+// DefaultAllocatorConfig returns default allocator configuration
+func DefaultAllocatorConfig() *AllocatorConfig {
+	return &AllocatorConfig{
+		Mode:              AllocationFixed,
+		MinWeight:         0.05,
+		MaxWeight:         0.5,
+		RebalanceInterval: 24 * time.Hour,
+		HistorySize:       200,
+	}
+}
+
+// StrategyPerformance summarizes realized results used for reweighting
+type StrategyPerformance struct {
+	RealizedPnL float64
+	Trades      int
+}
+
+// StrategyAllocation is the capital assigned to a single strategy
+type StrategyAllocation struct {
+	Strategy    string  `json:"strategy"`
+	Weight      float64 `json:"weight"`
+	Capital     float64 `json:"capital"`
+	Used        float64 `json:"used"`
+	BuyingPower float64 `json:"buyingPower"`
+}
+
+// AllocationSnapshot records a reallocation
+type AllocationSnapshot struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Mode      AllocationMode     `json:"mode"`
+	Reason    string             `json:"reason"`
+	Equity    float64            `json:"equity"`
+	Weights   map[string]float64 `json:"weights"`
+}
+
+// CapitalAllocator splits equity between strategies so that one strategy
+// cannot consume the buying power of the others
+type CapitalAllocator struct {
+	config     *AllocatorConfig
+	strategies []string
+	weights    map[string]float64
+	lastRun    time.Time
+	history    []AllocationSnapshot
+	mu         sync.RWMutex
+}
+
+// NewCapitalAllocator creates a new capital allocator for the given strategies
+func NewCapitalAllocator(config *AllocatorConfig, strategies []string) *CapitalAllocator {
+	if config == nil {
+		config = DefaultAllocatorConfig()
+	}
+	if config.HistorySize <= 0 {
+		config.HistorySize = 200
+	}
+
+	a := &CapitalAllocator{
+		config:     config,
+		strategies: append([]string(nil), strategies...),
+	}
+	a.weights = a.baseWeights()
+	return a
+}
+
+// GetConfig returns the allocator configuration
+func (a *CapitalAllocator) GetConfig() AllocatorConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return *a.config
+}
+
+// AddStrategy gives a strategy a share of capital, reporting whether it
+// was new. Weights return to their base split until the next rebalance.
+func (a *CapitalAllocator) AddStrategy(name string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, existing := range a.strategies {
+		if existing == name {
+			return false
+		}
+	}
+	a.strategies = append(a.strategies, name)
+	a.weights = a.baseWeights()
+	return true
+}
+
+// RemoveStrategy withdraws a strategy's share of capital, reporting whether
+// it had one. Weights return to their base split until the next rebalance.
+func (a *CapitalAllocator) RemoveStrategy(name string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, existing := range a.strategies {
+		if existing == name {
+			a.strategies = append(a.strategies[:i], a.strategies[i+1:]...)
+			a.weights = a.baseWeights()
+			return true
+		}
+	}
+	return false
+}
+
+// baseWeights returns the configured weights, normalized, or an equal split
+func (a *CapitalAllocator) baseWeights() map[string]float64 {
+	weights := make(map[string]float64, len(a.strategies))
+	total := 0.0
+	for _, name := range a.strategies {
+		if w, ok := a.config.Weights[name]; ok && w > 0 {
+			weights[name] = w
+			total += w
+		}
+	}
+
+	if total == 0 {
+		for _, name := range a.strategies {
+			weights[name] = 1
+		}
+		total = float64(len(a.strategies))
+	}
+
+	for name := range weights {
+		weights[name] /= total
+	}
+	return weights
+}
+
+// Rebalance recomputes strategy weights and records the change
+func (a *CapitalAllocator) Rebalance(equity float64, perf map[string]StrategyPerformance, reason string) AllocationSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	weights := a.baseWeights()
+	if a.config.Mode == AllocationPerformance {
+		weights = a.performanceWeights(weights, equity, perf)
+	}
+
+	a.weights = weights
+	a.lastRun = time.Now()
+
+	snapshot := AllocationSnapshot{
+		Timestamp: a.lastRun,
+		Mode:      a.config.Mode,
+		Reason:    reason,
+		Equity:    equity,
+		Weights:   copyWeights(weights),
+	}
+	a.history = append(a.history, snapshot)
+	if len(a.history) > a.config.HistorySize {
+		a.history = a.history[len(a.history)-a.config.HistorySize:]
+	}
+
+	log.Info().
+		Str("mode", string(a.config.Mode)).
+		Str("reason", reason).
+		Interface("weights", snapshot.Weights).
+		Msg("Capital reallocated")
+
+	return snapshot
+}
+
+// performanceWeights tilts base weights by each strategy's return on its capital
+func (a *CapitalAllocator) performanceWeights(base map[string]float64, equity float64, perf map[string]StrategyPerformance) map[string]float64 {
+	scores := make(map[string]float64, len(base))
+	total := 0.0
+	for name, w := range base {
+		score := w
+		if p, ok := perf[name]; ok && p.Trades > 0 && equity > 0 {
+			capital := equity * w
+			ret := p.RealizedPnL / capital
+			// Bound the tilt so one good or bad streak can't zero a strategy out
+			score = w * (1 + math.Max(-0.5, math.Min(1.0, ret)))
+		}
+		scores[name] = score
+		total += score
+	}
+	if total <= 0 {
+		return base
+	}
+
+	for name := range scores {
+		scores[name] /= total
+	}
+	return clampWeights(scores, a.config.MinWeight, a.config.MaxWeight)
+}
+
+// clampWeights bounds weights to [min, max] and renormalizes the remainder
+func clampWeights(weights map[string]float64, min, max float64) map[string]float64 {
+	n := float64(len(weights))
+	if n == 0 {
+		return weights
+	}
+	if min <= 0 || min*n > 1 {
+		min = 0
+	}
+	if max <= 0 || max*n < 1 {
+		max = 1
+	}
+
+	result := copyWeights(weights)
+	for i := 0; i < len(weights); i++ {
+		lockedSum, freeSum := 0.0, 0.0
+		for name, w := range result {
+			switch {
+			case w <= min:
+				result[name] = min
+				lockedSum += min
+			case w >= max:
+				result[name] = max
+				lockedSum += max
+			default:
+				freeSum += w
+			}
+		}
+		if freeSum == 0 {
+			break
+		}
+
+		// Spread the remaining weight over unclamped strategies
+		scale := (1 - lockedSum) / freeSum
+		outOfBounds := false
+		for name, w := range result {
+			if w > min && w < max {
+				result[name] = w * scale
+				if result[name] <= min || result[name] >= max {
+					outOfBounds = true
+				}
+			}
+		}
+		if !outOfBounds {
+			break
+		}
+	}
+	return result
+}
+
+// Allocations returns each strategy's capital and remaining buying power.
+// used maps strategy name to the notional currently held by that strategy.
+func (a *CapitalAllocator) Allocations(equity float64, used map[string]float64) []StrategyAllocation {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	allocations := make([]StrategyAllocation, 0, len(a.weights))
+	for name, w := range a.weights {
+		capital := equity * w
+		allocations = append(allocations, StrategyAllocation{
+			Strategy:    name,
+			Weight:      w,
+			Capital:     capital,
+			Used:        used[name],
+			BuyingPower: math.Max(0, capital-used[name]),
+		})
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].Strategy < allocations[j].Strategy
+	})
+	return allocations
+}
+
+// Budgets returns the capital assigned to each strategy at the given equity
+func (a *CapitalAllocator) Budgets(equity float64) map[string]float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	budgets := make(map[string]float64, len(a.weights))
+	for name, w := range a.weights {
+		budgets[name] = equity * w
+	}
+	return budgets
+}
+
+// BuyingPower returns the capital a strategy can still deploy.
+// Strategies without an allocation, such as copied signals, are only
+// bounded by equity.
+func (a *CapitalAllocator) BuyingPower(strategy string, equity, used float64) float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	w, ok := a.weights[strategy]
+	if !ok {
+		w = 1
+	}
+	return math.Max(0, equity*w-used)
+}
+
+// DueForRebalance reports whether a periodic reallocation should run
+func (a *CapitalAllocator) DueForRebalance(now time.Time) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.config.RebalanceInterval <= 0 {
+		return false
+	}
+	return a.lastRun.IsZero() || now.Sub(a.lastRun) >= a.config.RebalanceInterval
+}
+
+// GetHistory returns recent reallocations, newest first
+func (a *CapitalAllocator) GetHistory(limit int) []AllocationSnapshot {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if limit <= 0 || limit > len(a.history) {
+		limit = len(a.history)
+	}
+
+	result := make([]AllocationSnapshot, 0, limit)
+	for i := len(a.history) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, a.history[i])
+	}
+	return result
+}
+
+// copyWeights returns a copy of a weight map
+func copyWeights(weights map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(weights))
+	for k, v := range weights {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+)
+
+// CompoundingMode determines which capital entries are sized from as
+// equity grows
+type CompoundingMode string
+
+const (
+	// CompoundingFull sizes from current equity
+	CompoundingFull CompoundingMode = "full"
+	// CompoundingFixed sizes from the base capital, leaving gains unused
+	CompoundingFixed CompoundingMode = "fixed"
+	// CompoundingStepped re-bases sizing on the base capital grown by
+	// each whole step of equity gain, e.g. every +10%
+	CompoundingStepped CompoundingMode = "stepped"
+)
+
+// defaultCompoundingStep is the stepped milestone when none is configured
+const defaultCompoundingStep = 0.1
+
+// ValidCompoundingMode reports whether mode is a known compounding mode
+func ValidCompoundingMode(mode CompoundingMode) bool {
+	switch mode {
+	case CompoundingFull, CompoundingFixed, CompoundingStepped:
+		return true
+	}
+	return false
+}
+
+// SizingCapital returns the capital entries are sized from under mode,
+// given the base capital and current equity. Fixed and stepped sizing
+// fall back to equity without a base, and never size from more than
+// equity, so losses always shrink entries.
+func SizingCapital(mode CompoundingMode, step, base, equity float64) float64 {
+	if base <= 0 || equity <= 0 {
+		return equity
+	}
+
+	capital := equity
+	switch mode {
+	case CompoundingFixed:
+		capital = base
+	case CompoundingStepped:
+		if step <= 0 {
+			step = defaultCompoundingStep
+		}
+		capital = base
+		if equity > base {
+			// Last milestone reached: base × (1 + step)^n; the epsilon keeps
+			// equity exactly on a milestone from rounding below it
+			n := math.Floor(math.Log(equity/base)/math.Log1p(step) + 1e-9)
+			capital = base * math.Pow(1+step, n)
+		}
+	}
+	return math.Min(capital, equity)
+}
+
+// compoundingDetail describes the sizing capital when it isn't equity
+func compoundingDetail(mode CompoundingMode, capital, equity float64) string {
+	if capital == equity {
+		return ""
+	}
+	return fmt.Sprintf("; equity taken as %.2f of %.2f (%s compounding)", capital, equity, mode)
+}
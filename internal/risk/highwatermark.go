@@ -0,0 +1,169 @@
+package risk
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HighWaterMarkMode determines how the equity peak used for drawdown evolves
+type HighWaterMarkMode string
+
+const (
+	// HighWaterMarkTrailing keeps the highest equity ever reached
+	HighWaterMarkTrailing HighWaterMarkMode = "trailing"
+	// HighWaterMarkMonthly restarts the peak at the start of each calendar month
+	HighWaterMarkMonthly HighWaterMarkMode = "monthly"
+	// HighWaterMarkDepositAdjusted trails the peak and shifts it by deposits
+	// and withdrawals so cash flows are not counted as gains or drawdown
+	HighWaterMarkDepositAdjusted HighWaterMarkMode = "deposit_adjusted"
+)
+
+// ValidHighWaterMarkMode reports whether mode is a known high-water mark mode
+func ValidHighWaterMarkMode(mode HighWaterMarkMode) bool {
+	switch mode {
+	case HighWaterMarkTrailing, HighWaterMarkMonthly, HighWaterMarkDepositAdjusted:
+		return true
+	}
+	return false
+}
+
+// HighWaterMark is the persisted equity peak drawdown is measured from
+type HighWaterMark struct {
+	Mode        HighWaterMarkMode `json:"mode"`
+	Peak        float64           `json:"peak"`
+	PeakAt      time.Time         `json:"peakAt"`
+	PeriodStart time.Time         `json:"periodStart"` // Start of the current period (monthly mode)
+	NetDeposits float64           `json:"netDeposits"` // Cash flows recorded so far
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// monthStart returns the first instant of t's calendar month
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// hwmMode returns the configured mode, defaulting to trailing
+func (m *Manager) hwmMode() HighWaterMarkMode {
+	if ValidHighWaterMarkMode(m.config.HighWaterMarkMode) {
+		return m.config.HighWaterMarkMode
+	}
+	return HighWaterMarkTrailing
+}
+
+// updateHighWaterMarkLocked advances the high-water mark for the current
+// equity (m.mu must be held)
+func (m *Manager) updateHighWaterMarkLocked(equity float64, now time.Time) {
+	mode := m.hwmMode()
+	m.hwm.Mode = mode
+
+	if mode == HighWaterMarkMonthly {
+		period := monthStart(now)
+		if !m.hwm.PeriodStart.Equal(period) {
+			if !m.hwm.PeriodStart.IsZero() {
+				log.Info().
+					Float64("previousPeak", m.hwm.Peak).
+					Float64("equity", equity).
+					Msg("Monthly high-water mark reset")
+			}
+			m.hwm.PeriodStart = period
+			m.hwm.Peak = equity
+			m.hwm.PeakAt = now
+			m.hwm.UpdatedAt = now
+		}
+	}
+
+	// Seed from initial capital so losses before the first peak still count
+	if m.hwm.Peak == 0 {
+		m.hwm.Peak = m.config.InitialCapital
+		m.hwm.PeakAt = now
+		m.hwm.UpdatedAt = now
+	}
+
+	if equity > m.hwm.Peak {
+		m.hwm.Peak = equity
+		m.hwm.PeakAt = now
+		m.hwm.UpdatedAt = now
+	}
+
+	m.state.PeakEquity = m.hwm.Peak
+}
+
+// GetHighWaterMark returns the current high-water mark
+func (m *Manager) GetHighWaterMark() HighWaterMark {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.hwm
+}
+
+// RestoreHighWaterMark restores a persisted high-water mark. A restored
+// monthly period from an earlier month is reset on the next update.
+func (m *Manager) RestoreHighWaterMark(hwm HighWaterMark) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.hwmMode() != HighWaterMarkMonthly {
+		hwm.PeriodStart = time.Time{}
+	}
+	hwm.Mode = m.hwmMode()
+	m.hwm = hwm
+	m.state.PeakEquity = hwm.Peak
+
+	log.Info().
+		Str("mode", string(hwm.Mode)).
+		Float64("peak", hwm.Peak).
+		Time("peakAt", hwm.PeakAt).
+		Msg("High-water mark restored")
+}
+
+// ResetHighWaterMark sets the peak to the given equity
+func (m *Manager) ResetHighWaterMark(equity float64) HighWaterMark {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.hwm.Mode = m.hwmMode()
+	m.hwm.Peak = equity
+	m.hwm.PeakAt = now
+	m.hwm.UpdatedAt = now
+	if m.hwm.Mode == HighWaterMarkMonthly {
+		m.hwm.PeriodStart = monthStart(now)
+	}
+
+	m.state.PeakEquity = equity
+	if equity > 0 && m.state.Equity > 0 {
+		m.state.CurrentDrawdown = (equity - m.state.Equity) / equity
+		if m.state.CurrentDrawdown < 0 {
+			m.state.CurrentDrawdown = 0
+		}
+	}
+
+	log.Info().Float64("peak", equity).Msg("High-water mark reset")
+	return m.hwm
+}
+
+// RecordCashFlow records a deposit (positive) or withdrawal (negative). In
+// deposit-adjusted mode the peak moves by the same amount.
+func (m *Manager) RecordCashFlow(amount float64) HighWaterMark {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.hwm.NetDeposits += amount
+	m.hwm.UpdatedAt = now
+
+	if m.hwmMode() == HighWaterMarkDepositAdjusted && m.hwm.Peak > 0 {
+		m.hwm.Peak += amount
+		if m.hwm.Peak < 0 {
+			m.hwm.Peak = 0
+		}
+		m.state.PeakEquity = m.hwm.Peak
+	}
+
+	log.Info().
+		Float64("amount", amount).
+		Float64("peak", m.hwm.Peak).
+		Str("mode", string(m.hwmMode())).
+		Msg("Cash flow recorded")
+	return m.hwm
+}
@@ -12,6 +12,7 @@ type Manager struct {
 	config        *RiskConfig
 	positionSizer *PositionSizer
 	state         *AccountState
+	hwm           HighWaterMark
 	events        []RiskEvent
 	mu            sync.RWMutex
 
@@ -55,9 +56,7 @@ func (m *Manager) UpdateAccountState(equity, availableBalance, unrealizedPnL, da
 	m.state.OpenPositions = openPositions
 
 	// Update peak equity
-	if equity > m.state.PeakEquity {
-		m.state.PeakEquity = equity
-	}
+	m.updateHighWaterMarkLocked(equity, time.Now())
 
 	// Calculate drawdown
 	if m.state.PeakEquity > 0 {
@@ -168,6 +167,14 @@ func (m *Manager) AssessTrade(params TradeParams) RiskAssessment {
 		return assessment
 	}
 
+	// Check stop loss
+	if m.config.RequireStopLoss && params.StopLoss <= 0 {
+		assessment.Approved = false
+		assessment.RiskLevel = RiskHigh
+		assessment.Reasons = append(assessment.Reasons, "Stop loss required")
+		return assessment
+	}
+
 	// Check position limits
 	if m.state.OpenPositions >= m.config.MaxOpenPositions {
 		assessment.Approved = false
@@ -225,9 +232,19 @@ func (m *Manager) AssessTrade(params TradeParams) RiskAssessment {
 		ATR:              params.ATR,
 		IsHighVolatility: params.IsHighVolatility,
 		SignalStrength:   params.SignalStrength,
+		Strategy:         params.Strategy,
+		Stats:            params.Stats,
 	})
 
 	assessment.AdjustedSize = sizeResult.Size
+	assessment.SizingModel = sizeResult.Model
+	assessment.SizingDetail = sizeResult.ModelDetail
+	if sizeResult.Size <= 0 && sizeResult.Model == SizingKelly {
+		assessment.Approved = false
+		assessment.RiskLevel = RiskMedium
+		assessment.Reasons = append(assessment.Reasons, "Position sizing: "+sizeResult.ModelDetail)
+		return assessment
+	}
 	assessment.StopLoss = params.StopLoss
 	assessment.TakeProfit = params.TakeProfit
 	assessment.RiskAmount = sizeResult.RiskAmount
@@ -296,6 +313,8 @@ type TradeParams struct {
 	ATR              float64
 	IsHighVolatility bool
 	SignalStrength   float64
+	Strategy         string      // Canonical name, selects the sizing model
+	Stats            SizingStats // Strategy's recent trades, for Kelly sizing
 }
 
 // RecordTrade records a completed trade for risk tracking
@@ -353,12 +372,70 @@ func (m *Manager) ResetCircuitBreaker() {
 	log.Info().Msg("Circuit breaker reset")
 }
 
+// Halt manually triggers the circuit breaker. A non-positive duration halts
+// trading until Resume is called.
+func (m *Manager) Halt(reason string, duration time.Duration, by string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.IsHalted = true
+	m.state.HaltReason = reason
+	m.state.HaltUntil = time.Time{}
+	if duration > 0 {
+		m.state.HaltUntil = time.Now().Add(duration)
+	}
+
+	details := map[string]interface{}{
+		"reason": reason,
+		"manual": true,
+		"by":     by,
+	}
+	if !m.state.HaltUntil.IsZero() {
+		details["haltUntil"] = m.state.HaltUntil
+	}
+
+	m.emitEvent(RiskEvent{
+		Type:      RiskEventCircuitBreaker,
+		Level:     RiskCritical,
+		Message:   "Trading halted manually: " + reason,
+		Timestamp: time.Now(),
+		Details:   details,
+	})
+}
+
+// Resume manually resets the circuit breaker and resumes trading
+func (m *Manager) Resume(by string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previousReason := m.state.HaltReason
+	wasHalted := m.state.IsHalted
+
+	m.state.IsHalted = false
+	m.state.HaltReason = ""
+	m.state.HaltUntil = time.Time{}
+	m.state.ConsecutiveLosses = 0
+
+	m.emitEvent(RiskEvent{
+		Type:      RiskEventCircuitBreakerReset,
+		Level:     RiskLow,
+		Message:   "Trading resumed manually",
+		Timestamp: time.Now(),
+		Details: map[string]interface{}{
+			"wasHalted":      wasHalted,
+			"previousReason": previousReason,
+			"by":             by,
+		},
+	})
+}
+
 // CheckCircuitBreaker checks and updates circuit breaker status
 func (m *Manager) CheckCircuitBreaker() bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.state.IsHalted && time.Now().After(m.state.HaltUntil) {
+	// A zero HaltUntil means a manual halt that lasts until Resume is called
+	if m.state.IsHalted && !m.state.HaltUntil.IsZero() && time.Now().After(m.state.HaltUntil) {
 		m.state.IsHalted = false
 		m.state.HaltReason = ""
 		log.Info().Msg("Circuit breaker expired, trading resumed")
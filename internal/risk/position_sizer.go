@@ -29,20 +29,13 @@ func (ps *PositionSizer) CalculateSize(params PositionSizeParams) PositionSizeRe
 		return result
 	}
 
-	// Calculate risk amount (% of equity)
-	riskPercent := ps.config.MaxRiskPerTrade
-	result.RiskAmount = params.Equity * riskPercent
-
-	// Calculate position size based on risk
-	if params.Direction == "LONG" {
-		result.Size = result.RiskAmount / result.StopDistance
-	} else {
-		result.Size = result.RiskAmount / result.StopDistance
-	}
+	// Calculate position size with the strategy's sizing model
+	result.Size, result.Model, result.ModelDetail = ps.modelSize(params, result.StopDistance)
+	result.RiskAmount = result.Size * result.StopDistance
 
 	log.Debug().
 		Float64("paramsEquity", params.Equity).
-		Float64("riskPercent", riskPercent).
+		Str("model", string(result.Model)).
 		Float64("stopDistance", result.StopDistance).
 		Float64("riskAmount", result.RiskAmount).
 		Float64("size", result.Size).
@@ -93,7 +86,9 @@ type PositionSizeParams struct {
 	Direction        string // "LONG" or "SHORT"
 	ATR              float64
 	IsHighVolatility bool
-	SignalStrength   float64 // 0-1, can scale position
+	SignalStrength   float64     // 0-1, can scale position
+	Strategy         string      // Canonical name, selects the sizing model
+	Stats            SizingStats // Strategy's recent trades, for Kelly sizing
 }
 
 // calculateStopDistance calculates distance to stop loss
@@ -0,0 +1,162 @@
+package risk
+
+import "fmt"
+
+// SizingModel determines how the size of an entry is computed
+type SizingModel string
+
+const (
+	// SizingFixedFractional risks MaxRiskPerTrade of equity between entry
+	// and stop loss
+	SizingFixedFractional SizingModel = "fixed_fractional"
+	// SizingKelly sizes by a fraction of the Kelly criterion, from the
+	// strategy's recent win rate and expectancy
+	SizingKelly SizingModel = "kelly"
+	// SizingVolatilityTarget sizes so that a one-ATR move costs a fixed
+	// share of equity
+	SizingVolatilityTarget SizingModel = "volatility_target"
+	// SizingFixedNotional enters with a fixed position value
+	SizingFixedNotional SizingModel = "fixed_notional"
+)
+
+// Sizing model defaults, used when the configuration leaves them unset
+const (
+	defaultKellyFraction    = 0.5
+	defaultKellyMinTrades   = 20
+	defaultTargetVolatility = 0.01
+)
+
+// ValidSizingModel reports whether model is a known sizing model
+func ValidSizingModel(model SizingModel) bool {
+	switch model {
+	case SizingFixedFractional, SizingKelly, SizingVolatilityTarget, SizingFixedNotional:
+		return true
+	}
+	return false
+}
+
+// SizingStats summarizes a strategy's recent closed trades for Kelly sizing
+type SizingStats struct {
+	Trades  int
+	WinRate float64
+	AvgWin  float64
+	AvgLoss float64 // Positive
+}
+
+// NewSizingStats summarizes the realized P&L of closed trades
+func NewSizingStats(pnls []float64) SizingStats {
+	var stats SizingStats
+	var wins, grossWin, grossLoss float64
+	for _, pnl := range pnls {
+		if pnl == 0 {
+			continue
+		}
+		stats.Trades++
+		if pnl > 0 {
+			wins++
+			grossWin += pnl
+		} else {
+			grossLoss -= pnl
+		}
+	}
+	if stats.Trades == 0 {
+		return stats
+	}
+
+	stats.WinRate = wins / float64(stats.Trades)
+	if wins > 0 {
+		stats.AvgWin = grossWin / wins
+	}
+	if losses := float64(stats.Trades) - wins; losses > 0 {
+		stats.AvgLoss = grossLoss / losses
+	}
+	return stats
+}
+
+// Expectancy returns the average P&L per trade
+func (s SizingStats) Expectancy() float64 {
+	return s.WinRate*s.AvgWin - (1-s.WinRate)*s.AvgLoss
+}
+
+// SizingModelFor returns the sizing model for a strategy: its override,
+// else the default, else fixed fractional
+func (m *Manager) SizingModelFor(strategy string) SizingModel {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.sizingModelFor(strategy)
+}
+
+func (c *RiskConfig) sizingModelFor(strategy string) SizingModel {
+	if model, ok := c.SizingModels[strategy]; ok && ValidSizingModel(model) {
+		return model
+	}
+	if ValidSizingModel(c.SizingModel) {
+		return c.SizingModel
+	}
+	return SizingFixedFractional
+}
+
+// modelSize returns the unlimited size of an entry under the strategy's
+// sizing model, with the model actually applied and how it got there.
+// Equity-based models size from the capital the compounding mode leaves.
+func (ps *PositionSizer) modelSize(params PositionSizeParams, stopDistance float64) (float64, SizingModel, string) {
+	capital := SizingCapital(ps.config.Compounding, ps.config.CompoundingStep, ps.config.CompoundingBase, params.Equity)
+	size, model, detail := ps.sizeFrom(capital, params, stopDistance)
+	if model != SizingFixedNotional {
+		detail += compoundingDetail(ps.config.Compounding, capital, params.Equity)
+	}
+	return size, model, detail
+}
+
+// sizeFrom sizes an entry from capital under the strategy's sizing model.
+// Models lacking their inputs fall back to fixed fractional.
+func (ps *PositionSizer) sizeFrom(capital float64, params PositionSizeParams, stopDistance float64) (float64, SizingModel, string) {
+	model := ps.config.sizingModelFor(params.Strategy)
+	riskSize := capital * ps.config.MaxRiskPerTrade / stopDistance
+
+	switch model {
+	case SizingKelly:
+		minTrades := ps.config.KellyMinTrades
+		if minTrades <= 0 {
+			minTrades = defaultKellyMinTrades
+		}
+		stats := params.Stats
+		if stats.Trades < minTrades || stats.AvgWin <= 0 {
+			return riskSize, SizingFixedFractional,
+				fmt.Sprintf("kelly needs %d trades, has %d; fixed fractional", minTrades, stats.Trades)
+		}
+		fraction := ps.config.KellyFraction
+		if fraction <= 0 {
+			fraction = defaultKellyFraction
+		}
+		// f* = expectancy / average win, the Kelly criterion (p*b - q) / b
+		kelly := stats.Expectancy() / stats.AvgWin * fraction
+		if kelly <= 0 {
+			return 0, model, fmt.Sprintf("kelly: no edge in the last %d trades (expectancy %.2f)", stats.Trades, stats.Expectancy())
+		}
+		return capital * kelly / params.EntryPrice, model,
+			fmt.Sprintf("kelly %.1f%% of equity (win rate %.0f%%, expectancy %.2f over %d trades)",
+				kelly*100, stats.WinRate*100, stats.Expectancy(), stats.Trades)
+
+	case SizingVolatilityTarget:
+		if params.ATR <= 0 {
+			return riskSize, SizingFixedFractional, "volatility target needs an ATR; fixed fractional"
+		}
+		target := ps.config.TargetVolatility
+		if target <= 0 {
+			target = defaultTargetVolatility
+		}
+		return capital * target / params.ATR, model,
+			fmt.Sprintf("one ATR (%.2f) costs %.1f%% of equity", params.ATR, target*100)
+
+	case SizingFixedNotional:
+		if ps.config.FixedNotional <= 0 {
+			return riskSize, SizingFixedFractional, "no fixed notional configured; fixed fractional"
+		}
+		return ps.config.FixedNotional / params.EntryPrice, model,
+			fmt.Sprintf("fixed notional %.2f", ps.config.FixedNotional)
+	}
+
+	return riskSize, SizingFixedFractional,
+		fmt.Sprintf("risk %.1f%% of equity to the stop", ps.config.MaxRiskPerTrade*100)
+}
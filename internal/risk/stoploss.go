@@ -0,0 +1,125 @@
+package risk
+
+import "fmt"
+
+// StopLossPolicy determines what happens to an entry signal without a stop loss
+type StopLossPolicy string
+
+const (
+	// StopLossSkip enters without a stop loss, leaving the position unprotected
+	StopLossSkip StopLossPolicy = "skip"
+	// StopLossReject refuses the entry
+	StopLossReject StopLossPolicy = "reject"
+	// StopLossATR derives a stop loss from the ATR at entry
+	StopLossATR StopLossPolicy = "atr"
+)
+
+// defaultStopLossATRMultiplier places derived stops two ATRs from entry
+const defaultStopLossATRMultiplier = 2.0
+
+// ValidStopLossPolicy reports whether policy is a known stop-loss policy
+func ValidStopLossPolicy(policy StopLossPolicy) bool {
+	switch policy {
+	case StopLossSkip, StopLossReject, StopLossATR:
+		return true
+	}
+	return false
+}
+
+// StopLossEnforcement records how the stop-loss policy treated a signal
+type StopLossEnforcement struct {
+	Policy   StopLossPolicy `json:"policy"`
+	Action   string         `json:"action"` // "none", "skipped", "rejected" or "derived"
+	StopLoss float64        `json:"stopLoss,omitempty"`
+	Detail   string         `json:"detail,omitempty"`
+}
+
+// Rejected reports whether the policy refused the entry
+func (e StopLossEnforcement) Rejected() bool {
+	return e.Action == "rejected"
+}
+
+// StopLossPolicyFor returns the policy for a strategy: its override, else
+// the default, else reject when stop losses are required and skip otherwise
+func (m *Manager) StopLossPolicyFor(strategy string) StopLossPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.stopLossPolicyLocked(strategy)
+}
+
+func (m *Manager) stopLossPolicyLocked(strategy string) StopLossPolicy {
+	if policy, ok := m.config.StopLossPolicies[strategy]; ok && ValidStopLossPolicy(policy) {
+		return policy
+	}
+	if ValidStopLossPolicy(m.config.StopLossPolicy) {
+		return m.config.StopLossPolicy
+	}
+	if m.config.RequireStopLoss {
+		return StopLossReject
+	}
+	return StopLossSkip
+}
+
+// EnforcesStopLoss reports whether no strategy can enter without a stop loss
+func (m *Manager) EnforcesStopLoss() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.config.RequireStopLoss {
+		return true
+	}
+	if m.stopLossPolicyLocked("") == StopLossSkip {
+		return false
+	}
+	for strategy := range m.config.StopLossPolicies {
+		if m.stopLossPolicyLocked(strategy) == StopLossSkip {
+			return false
+		}
+	}
+	return true
+}
+
+// EnforceStopLoss applies the strategy's stop-loss policy to an entry. A
+// signal that has a stop loss passes unchanged; otherwise the result carries
+// the derived stop, or reports that the entry was rejected or left unprotected.
+func (m *Manager) EnforceStopLoss(strategy, direction string, entryPrice, stopLoss, atr float64) StopLossEnforcement {
+	m.mu.RLock()
+	policy := m.stopLossPolicyLocked(strategy)
+	multiplier := m.config.StopLossATRMultiplier
+	m.mu.RUnlock()
+
+	enforcement := StopLossEnforcement{Policy: policy, Action: "none", StopLoss: stopLoss}
+	if stopLoss > 0 {
+		return enforcement
+	}
+
+	switch policy {
+	case StopLossSkip:
+		enforcement.Action = "skipped"
+		enforcement.Detail = "no stop loss, entering unprotected"
+
+	case StopLossATR:
+		if multiplier <= 0 {
+			multiplier = defaultStopLossATRMultiplier
+		}
+		distance := atr * multiplier
+		derived := entryPrice + distance
+		if direction == "LONG" {
+			derived = entryPrice - distance
+		}
+		if atr <= 0 || entryPrice <= 0 || derived <= 0 {
+			enforcement.Action = "rejected"
+			enforcement.Detail = "Stop loss required, no ATR to derive one"
+			break
+		}
+		enforcement.Action = "derived"
+		enforcement.StopLoss = derived
+		enforcement.Detail = fmt.Sprintf("stop loss %.2f derived from ATR %.2f x %.1f", derived, atr, multiplier)
+
+	default:
+		enforcement.Action = "rejected"
+		enforcement.Detail = "Stop loss required"
+	}
+
+	return enforcement
+}
@@ -39,11 +39,27 @@ type RiskConfig struct {
 	// Per-trade risk
 	MaxRiskPerTrade        float64 // Max risk per trade as % of equity
 	MinRiskRewardRatio     float64 // Minimum risk/reward ratio
+	RequireStopLoss        bool    // Reject entries without a stop loss
+	StopLossPolicy         StopLossPolicy // Entries without a stop loss (empty follows RequireStopLoss)
+	StopLossPolicies       map[string]StopLossPolicy // Per-strategy policy by canonical name
+	StopLossATRMultiplier  float64 // ATR multiple for derived stops
+	SizingModel            SizingModel // Default sizing model (empty = fixed fractional)
+	SizingModels           map[string]SizingModel // Per-strategy model by canonical name
+	KellyFraction          float64 // Share of the full Kelly fraction
+	KellyWindow            int     // Recent closed trades Kelly sizing reads
+	KellyMinTrades         int     // Trades needed before Kelly applies
+	TargetVolatility       float64 // Equity share a one-ATR move may cost
+	FixedNotional          float64 // Position value for fixed notional sizing
+	Compounding            CompoundingMode // Capital sizing grows with (empty = full)
+	CompoundingStep        float64 // Stepped: equity gain per re-base, e.g. 0.1
+	CompoundingBase        float64 // Fixed and stepped: capital sizing starts from
 
 	// Account limits
 	MaxDailyLoss           float64 // Max daily loss as % of equity
 	MaxWeeklyLoss          float64 // Max weekly loss as % of equity
 	MaxTotalDrawdown       float64 // Max total drawdown as % of peak equity
+	HighWaterMarkMode      HighWaterMarkMode // How the drawdown peak evolves
+	InitialCapital         float64 // Seeds the high-water mark (0 = first equity)
 
 	// Position limits
 	MaxOpenPositions       int     // Maximum concurrent positions
@@ -79,9 +95,18 @@ func DefaultRiskConfig() *RiskConfig {
 		DefaultPositionSize:     0.05,   // 5% of equity
 		MaxRiskPerTrade:         0.02,   // 2% risk per trade
 		MinRiskRewardRatio:      1.5,    // 1.5:1 min R/R
+		StopLossATRMultiplier:   2.0,    // Derived stops 2 ATR from entry
+		SizingModel:             SizingFixedFractional,
+		KellyFraction:           0.5,    // Half Kelly
+		KellyWindow:             50,
+		KellyMinTrades:          20,
+		TargetVolatility:        0.01,   // One ATR costs 1% of equity
+		Compounding:             CompoundingFull,
+		CompoundingStep:         0.1,    // Re-base every +10% when stepped
 		MaxDailyLoss:            0.05,   // 5% max daily loss
 		MaxWeeklyLoss:           0.10,   // 10% max weekly loss
 		MaxTotalDrawdown:        0.20,   // 20% max drawdown
+		HighWaterMarkMode:       HighWaterMarkTrailing,
 		MaxOpenPositions:        5,
 		MaxPositionsPerSymbol:   1,
 		MaxLeverage:             1.0,    // No leverage by default
@@ -110,6 +135,8 @@ type RiskAssessment struct {
 	RiskAmount     float64
 	RewardAmount   float64
 	RiskRewardRatio float64
+	SizingModel    SizingModel // Model that sized the trade
+	SizingDetail   string      // How the model arrived at the size
 }
 
 // PositionSizeResult holds position sizing calculation
@@ -120,6 +147,8 @@ type PositionSizeResult struct {
 	RiskPercent    float64 // Risk as % of equity
 	StopDistance   float64 // Distance to stop loss
 	Leverage       float64 // Effective leverage
+	Model          SizingModel // Sizing model applied
+	ModelDetail    string      // How the model arrived at the size
 }
 
 // AccountState holds current account state for risk calculations
@@ -189,6 +218,7 @@ const (
 	RiskEventPositionLimit
 	RiskEventVolatilitySpike
 	RiskEventLiquidityWarning
+	RiskEventCircuitBreakerReset
 )
 
 func (r RiskEventType) String() string {
@@ -207,6 +237,8 @@ func (r RiskEventType) String() string {
 		return "VOLATILITY_SPIKE"
 	case RiskEventLiquidityWarning:
 		return "LIQUIDITY_WARNING"
+	case RiskEventCircuitBreakerReset:
+		return "CIRCUIT_BREAKER_RESET"
 	default:
 		return "UNKNOWN"
 	}
@@ -0,0 +1,267 @@
+// Package scanner ranks exchange symbols by liquidity, volatility and how
+// well their current market regime suits the enabled strategies, to help
+// pick candidates before trading them
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eth-trading/internal/binance"
+	"github.com/eth-trading/internal/indicators"
+	"github.com/eth-trading/internal/strategy"
+)
+
+// minScanCandles is the fewest candles a symbol needs to be scored
+const minScanCandles = 60
+
+// Config holds the scan filters
+type Config struct {
+	QuoteAsset     string  // Only pairs quoted in this asset, e.g. "USDT"
+	MinQuoteVolume float64 // Minimum 24h volume in the quote asset
+	MinATRPercent  float64 // Volatility band on the scan timeframe (ATR as % of price)
+	MaxATRPercent  float64 // 0 = no upper bound
+	Timeframe      string  // Candles used for regime detection, e.g. "1h"
+	Candles        int     // Candles fetched per symbol
+	MaxCandidates  int     // Most liquid pairs passing the volume filter that are scored
+	Top            int     // Results returned; 0 = all
+}
+
+// DefaultConfig returns the default scan filters
+func DefaultConfig() Config {
+	return Config{
+		QuoteAsset:     "USDT",
+		MinQuoteVolume: 20000000,
+		MinATRPercent:  0.3,
+		MaxATRPercent:  5,
+		Timeframe:      "1h",
+		Candles:        200,
+		MaxCandidates:  40,
+		Top:            20,
+	}
+}
+
+// withDefaults fills unset fields with the defaults
+func (c Config) withDefaults() Config {
+	d := DefaultConfig()
+	if c.QuoteAsset == "" {
+		c.QuoteAsset = d.QuoteAsset
+	}
+	if c.Timeframe == "" {
+		c.Timeframe = d.Timeframe
+	}
+	if c.Candles < minScanCandles {
+		c.Candles = d.Candles
+	}
+	if c.MaxCandidates <= 0 {
+		c.MaxCandidates = d.MaxCandidates
+	}
+	return c
+}
+
+// StrategyFit scores how well a strategy suits a symbol's current regime
+type StrategyFit struct {
+	Strategy string  `json:"strategy"`
+	Weight   float64 `json:"weight"` // Regime-adjusted strategy weight
+	Fit      float64 `json:"fit"`    // Weight scaled by regime confidence
+}
+
+// Result is one ranked symbol
+type Result struct {
+	Rank             int           `json:"rank"`
+	Symbol           string        `json:"symbol"`
+	Price            float64       `json:"price"`
+	QuoteVolume      float64       `json:"quoteVolume"` // 24h, in the quote asset
+	PriceChange      float64       `json:"priceChange"` // 24h, percent
+	ATRPercent       float64       `json:"atrPercent"`  // On the scan timeframe
+	Regime           string        `json:"regime"`
+	RegimeConfidence float64       `json:"regimeConfidence"`
+	ADX              float64       `json:"adx"`
+	BestStrategy     string        `json:"bestStrategy"`
+	Score            float64       `json:"score"` // Best strategy fit
+	Strategies       []StrategyFit `json:"strategies"`
+}
+
+// Rejection records why a candidate was left out of the ranking
+type Rejection struct {
+	Symbol string `json:"symbol"`
+	Reason string `json:"reason"`
+}
+
+// Report is the outcome of a scan
+type Report struct {
+	ScannedAt  time.Time   `json:"scannedAt"`
+	Timeframe  string      `json:"timeframe"`
+	Pairs      int         `json:"pairs"`      // Trading pairs in the quote asset
+	Candidates int         `json:"candidates"` // Pairs passing the volume filter that were scored
+	Results    []Result    `json:"results"`
+	Rejected   []Rejection `json:"rejected,omitempty"`
+}
+
+// Scanner evaluates exchange symbols against the scan filters
+type Scanner struct {
+	client          *binance.Client
+	scorer          *strategy.Scorer
+	indicatorConfig *indicators.IndicatorConfig
+	regimeConfig    *strategy.RegimeConfig
+}
+
+// New creates a scanner. Strategy weights, enabled flags and regime
+// blacklists are read from scorer.
+func New(client *binance.Client, scorer *strategy.Scorer, indicatorConfig *indicators.IndicatorConfig, regimeConfig *strategy.RegimeConfig) *Scanner {
+	return &Scanner{
+		client:          client,
+		scorer:          scorer,
+		indicatorConfig: indicatorConfig,
+		regimeConfig:    regimeConfig,
+	}
+}
+
+// Scan ranks the pairs passing the filters by strategy fit
+func (s *Scanner) Scan(ctx context.Context, cfg Config) (*Report, error) {
+	cfg = cfg.withDefaults()
+
+	info, err := s.client.GetExchangeInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange info: %w", err)
+	}
+	trading := make(map[string]bool)
+	for _, sym := range info.Symbols {
+		if sym.Status == "TRADING" && sym.QuoteAsset == cfg.QuoteAsset {
+			trading[sym.Symbol] = true
+		}
+	}
+
+	tickers, err := s.client.GetAllTickers24hr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch 24h tickers: %w", err)
+	}
+
+	report := &Report{
+		ScannedAt: time.Now(),
+		Timeframe: cfg.Timeframe,
+		Pairs:     len(trading),
+		Results:   []Result{},
+	}
+
+	var candidates []Result
+	for _, t := range tickers {
+		if !trading[t.Symbol] {
+			continue
+		}
+		quoteVolume, _ := strconv.ParseFloat(t.QuoteVolume, 64)
+		if quoteVolume < cfg.MinQuoteVolume {
+			continue
+		}
+		price, _ := strconv.ParseFloat(t.LastPrice, 64)
+		change, _ := strconv.ParseFloat(t.PriceChangePercent, 64)
+		candidates = append(candidates, Result{
+			Symbol:      t.Symbol,
+			Price:       price,
+			QuoteVolume: quoteVolume,
+			PriceChange: change,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].QuoteVolume > candidates[j].QuoteVolume
+	})
+	if len(candidates) > cfg.MaxCandidates {
+		candidates = candidates[:cfg.MaxCandidates]
+	}
+	report.Candidates = len(candidates)
+
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, reason := s.evaluate(candidate, cfg)
+		if reason != "" {
+			report.Rejected = append(report.Rejected, Rejection{Symbol: candidate.Symbol, Reason: reason})
+			continue
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.QuoteVolume > b.QuoteVolume
+	})
+	if cfg.Top > 0 && len(report.Results) > cfg.Top {
+		report.Results = report.Results[:cfg.Top]
+	}
+	for i := range report.Results {
+		report.Results[i].Rank = i + 1
+	}
+
+	return report, nil
+}
+
+// evaluate detects a candidate's regime and scores the strategies against
+// it. A non-empty reason means the candidate was rejected.
+func (s *Scanner) evaluate(result Result, cfg Config) (Result, string) {
+	klines, err := s.client.GetKlines(result.Symbol, cfg.Timeframe, cfg.Candles, 0, 0)
+	if err != nil {
+		return result, fmt.Sprintf("failed to fetch candles: %v", err)
+	}
+	if len(klines) < minScanCandles {
+		return result, fmt.Sprintf("only %d candles of history", len(klines))
+	}
+
+	opens := make([]float64, len(klines))
+	highs := make([]float64, len(klines))
+	lows := make([]float64, len(klines))
+	closes := make([]float64, len(klines))
+	volumes := make([]float64, len(klines))
+	for i, k := range klines {
+		opens[i], _ = strconv.ParseFloat(k.Open, 64)
+		highs[i], _ = strconv.ParseFloat(k.High, 64)
+		lows[i], _ = strconv.ParseFloat(k.Low, 64)
+		closes[i], _ = strconv.ParseFloat(k.Close, 64)
+		volumes[i], _ = strconv.ParseFloat(k.Volume, 64)
+	}
+
+	// Regime detection keeps state between calls, so each symbol gets its own
+	detector := strategy.NewRegimeDetector(s.regimeConfig, indicators.NewManager(s.indicatorConfig))
+	regime := detector.Detect(opens, highs, lows, closes, volumes)
+
+	result.ATRPercent = regime.Details.ATRPercent
+	result.Regime = regime.Regime.String()
+	result.RegimeConfidence = regime.Confidence
+	result.ADX = regime.Details.ADX
+
+	if result.ATRPercent < cfg.MinATRPercent {
+		return result, fmt.Sprintf("ATR %.2f%% below %.2f%%", result.ATRPercent, cfg.MinATRPercent)
+	}
+	if cfg.MaxATRPercent > 0 && result.ATRPercent > cfg.MaxATRPercent {
+		return result, fmt.Sprintf("ATR %.2f%% above %.2f%%", result.ATRPercent, cfg.MaxATRPercent)
+	}
+
+	result.Strategies = []StrategyFit{}
+	for _, e := range s.scorer.Preview(regime.Regime) {
+		if !e.Eligible {
+			continue
+		}
+		fit := StrategyFit{
+			Strategy: e.Strategy,
+			Weight:   e.Weight,
+			Fit:      e.Weight * regime.Confidence,
+		}
+		result.Strategies = append(result.Strategies, fit)
+		if fit.Fit > result.Score {
+			result.Score = fit.Fit
+			result.BestStrategy = fit.Strategy
+		}
+	}
+	sort.SliceStable(result.Strategies, func(i, j int) bool {
+		return result.Strategies[i].Fit > result.Strategies[j].Fit
+	})
+
+	return result, ""
+}
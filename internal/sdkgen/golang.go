@@ -0,0 +1,276 @@
+package sdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/eth-trading/internal/api/spec"
+)
+
+// GoTypes renders pkg/client/types_gen.go
+func (m *Model) GoTypes() ([]byte, error) {
+	var b bytes.Buffer
+	for _, n := range m.Types {
+		writeGoDoc(&b, "", n.Doc)
+		switch {
+		case IsRaw(n.Type):
+			fmt.Fprintf(&b, "type %s = json.RawMessage\n\n", n.Name)
+		case n.Type.Kind() == reflect.Struct:
+			fmt.Fprintf(&b, "type %s %s\n\n", n.Name, m.goStruct(n.Fields, ""))
+		default:
+			fmt.Fprintf(&b, "type %s %s\n\n", n.Name, m.goUnderlying(n.Type))
+		}
+	}
+	return goFile(b.Bytes())
+}
+
+// GoEndpoints renders pkg/client/endpoints_gen.go, a Client method per
+// endpoint
+func (m *Model) GoEndpoints() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// apiPrefix is the path endpoints are relative to\nconst apiPrefix = %q\n\n", spec.Prefix)
+	fmt.Fprintf(&b, "// twoFactorHeader carries the code set by WithTwoFactorCode\nconst twoFactorHeader = %q\n\n", spec.TwoFactorHeader)
+
+	for _, e := range m.Endpoints {
+		params := []string{"ctx context.Context"}
+		urlPath := fmt.Sprintf("%q", e.Path)
+		if pp := PathParams(e.Path); len(pp) > 0 {
+			var parts []string
+			rest := e.Path
+			for _, p := range pp {
+				before, after, _ := strings.Cut(rest, ":"+p)
+				parts = append(parts, fmt.Sprintf("%q", before), "url.PathEscape("+p+")")
+				rest = after
+				params = append(params, p+" string")
+			}
+			if rest != "" {
+				parts = append(parts, fmt.Sprintf("%q", rest))
+			}
+			urlPath = strings.Join(parts, " + ")
+		}
+		query := "nil"
+		if len(e.Query) > 0 {
+			params = append(params, "query url.Values")
+			query = "query"
+		}
+		body := "nil"
+		if e.Request != nil {
+			params = append(params, "req "+m.goValueType(e.Request))
+			body = "req"
+		}
+
+		doc := e.Name + " " + lowerFirst(e.Doc) + "."
+		if len(e.Query) > 0 {
+			doc += " Query parameters: " + strings.Join(e.Query, ", ") + "."
+		}
+		if e.TwoFactor {
+			doc += " Users with two-factor authentication must pass a code with WithTwoFactorCode."
+		}
+		writeGoDoc(&b, "", doc)
+		fmt.Fprintf(&b, "//\n// %s %s%s\n", e.Method, spec.Prefix, e.Path)
+
+		call := fmt.Sprintf("c.do(ctx, %q, %s, %s, %s, ", e.Method, urlPath, query, body)
+		if e.Response == nil {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) error {\n\treturn %snil)\n}\n\n", e.Name, strings.Join(params, ", "), call)
+			continue
+		}
+		respType := m.goExpr(e.Response)
+		if e.Response.Kind() == reflect.Struct {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) (*%s, error) {\n", e.Name, strings.Join(params, ", "), respType)
+			fmt.Fprintf(&b, "\tvar resp %s\n\tif err := %s&resp); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &resp, nil\n}\n\n", respType, call)
+		} else {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) (%s, error) {\n", e.Name, strings.Join(params, ", "), respType)
+			fmt.Fprintf(&b, "\tvar resp %s\n\tif err := %s&resp); err != nil {\n\t\treturn nil, err\n\t}\n\treturn resp, nil\n}\n\n", respType, call)
+		}
+	}
+	return goFile(b.Bytes())
+}
+
+// GoMessages renders pkg/client/messages_gen.go, the WebSocket message
+// types and the types of their data
+func (m *Model) GoMessages() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Message types sent by the server\nconst (\n")
+	for _, msg := range m.ServerMessages {
+		fmt.Fprintf(&b, "\t%s = %q // %s\n", messageConst(msg.Type), msg.Type, msg.Doc)
+	}
+	b.WriteString(")\n\n// Message types sent by clients\nconst (\n")
+	for _, msg := range m.ClientMessages {
+		fmt.Fprintf(&b, "\t%s = %q // %s\n", messageConst(msg.Type), msg.Type, msg.Doc)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// newMessageData returns a value to decode the data of a server message\n")
+	b.WriteString("// into, nil for messages without data and unknown types\n")
+	b.WriteString("func newMessageData(msgType string) interface{} {\n\tswitch msgType {\n")
+	for _, msg := range m.ServerMessages {
+		if msg.Data == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\tcase %s:\n\t\treturn new(%s)\n", messageConst(msg.Type), m.goExpr(msg.Data))
+	}
+	b.WriteString("\t}\n\treturn nil\n}\n")
+	return goFile(b.Bytes())
+}
+
+// goValueType is how a request body is passed: structs by pointer
+func (m *Model) goValueType(t reflect.Type) string {
+	if t.Kind() == reflect.Struct {
+		return "*" + m.goExpr(t)
+	}
+	return m.goExpr(t)
+}
+
+// goExpr returns the Go type expression of t in the client package
+func (m *Model) goExpr(t reflect.Type) string {
+	if s, ok := spec.Scalars[t]; ok {
+		if s == spec.ScalarTime {
+			return "time.Time"
+		}
+		return "string"
+	}
+	if n := m.named[t]; n != nil {
+		return n.Name
+	}
+	return m.goUnderlying(t)
+}
+
+// goUnderlying returns the Go type expression of t's structure
+func (m *Model) goUnderlying(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + m.goExpr(t.Elem())
+	case reflect.Slice:
+		return "[]" + m.goExpr(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), m.goExpr(t.Elem()))
+	case reflect.Map:
+		return "map[" + m.goExpr(t.Key()) + "]" + m.goExpr(t.Elem())
+	case reflect.Interface:
+		return "interface{}"
+	case reflect.Struct:
+		return m.goStruct(m.Fields(t), "")
+	}
+	return t.Kind().String()
+}
+
+// goStruct returns a struct type literal
+func (m *Model) goStruct(fields []Field, indent string) string {
+	if len(fields) == 0 {
+		return "struct{}"
+	}
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, f := range fields {
+		if f.Embedded {
+			fmt.Fprintf(&b, "%s\t%s", indent, m.goExpr(f.Type))
+		} else {
+			tag := f.JSONName
+			if f.OmitEmpty {
+				tag += ",omitempty"
+			}
+			if f.AsString {
+				tag += ",string"
+			}
+			fmt.Fprintf(&b, "%s\t%s %s `json:%q`", indent, f.GoName, m.goExpr(f.Type), tag)
+		}
+		if f.Doc != "" {
+			b.WriteString(" // " + f.Doc)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// goFile adds the header and the imports the declarations use, and
+// formats them
+func goFile(decls []byte) ([]byte, error) {
+	src := append([]byte("package client\n\n"), decls...)
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parse generated Go: %w", err)
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\npackage client\n\n", Header)
+	var imports []string
+	for _, pkg := range []string{"context", "encoding/json", "net/url", "time"} {
+		if used[path.Base(pkg)] {
+			imports = append(imports, fmt.Sprintf("%q", pkg))
+		}
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&b, "import (\n\t%s\n)\n\n", strings.Join(imports, "\n\t"))
+	}
+	b.Write(decls)
+
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated Go: %w", err)
+	}
+	return out, nil
+}
+
+// writeGoDoc writes a doc comment wrapped at 80 columns
+func writeGoDoc(b *bytes.Buffer, indent, doc string) {
+	for _, line := range wrap(doc, 77-len(indent)) {
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+// wrap splits text into lines of at most width runes, breaking at spaces
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// messageConst is the constant naming a message type, e.g.
+// MessageTypeTradeIdea for trade_idea
+func messageConst(msgType string) string {
+	var b strings.Builder
+	b.WriteString("MessageType")
+	for _, part := range strings.Split(msgType, "_") {
+		if part != "" {
+			b.WriteString(exportName(part))
+		}
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
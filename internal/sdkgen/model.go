@@ -0,0 +1,372 @@
+// Package sdkgen generates the Go and TypeScript API clients from the API
+// definition in internal/api/spec
+package sdkgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/eth-trading/internal/api/spec"
+)
+
+// Header marks generated files
+const Header = "Code generated by sdkgen from internal/api/spec. DO NOT EDIT."
+
+var jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Model is the API definition with every named type it reaches
+type Model struct {
+	Endpoints      []spec.Endpoint // Those the SDKs include
+	ServerMessages []spec.Message
+	ClientMessages []spec.Message
+	Types          []*Named // Sorted by name
+
+	named map[reflect.Type]*Named
+	docs  *docIndex
+}
+
+// Named is a named Go type the SDKs declare
+type Named struct {
+	Name   string // Unique across the model
+	Type   reflect.Type
+	Doc    string
+	Fields []Field // Struct types only
+}
+
+// Field is a JSON encoded struct field
+type Field struct {
+	GoName    string
+	JSONName  string
+	Type      reflect.Type
+	OmitEmpty bool
+	AsString  bool // ,string option
+	Embedded  bool // Fields promoted into the parent object
+	Doc       string
+}
+
+// NewModel resolves the API definition. root is the module root, used to
+// read type and field comments from the source.
+func NewModel(root string) (*Model, error) {
+	docs, err := newDocIndex(root)
+	if err != nil {
+		return nil, err
+	}
+	m := &Model{
+		ServerMessages: spec.ServerMessages,
+		ClientMessages: spec.ClientMessages,
+		named:          make(map[reflect.Type]*Named),
+		docs:           docs,
+	}
+
+	names := make(map[string]bool)
+	for _, e := range spec.Endpoints {
+		if e.Exclude != "" {
+			continue
+		}
+		if names[e.Name] {
+			return nil, fmt.Errorf("endpoint name %s used twice", e.Name)
+		}
+		names[e.Name] = true
+		m.Endpoints = append(m.Endpoints, e)
+		m.visit(e.Request)
+		m.visit(e.Response)
+	}
+	for _, msg := range append(append([]spec.Message{}, m.ServerMessages...), m.ClientMessages...) {
+		m.visit(msg.Data)
+	}
+
+	m.assignNames()
+	return m, nil
+}
+
+// Named returns the declaration of a named type, nil for other types
+func (m *Model) Named(t reflect.Type) *Named {
+	return m.named[t]
+}
+
+// IsRaw reports whether t is encoded by its own MarshalJSON, so the SDKs
+// cannot describe it
+func IsRaw(t reflect.Type) bool {
+	if _, ok := spec.Scalars[t]; ok {
+		return false
+	}
+	return t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler)
+}
+
+// visit registers t and the named types it reaches
+func (m *Model) visit(t reflect.Type) {
+	if t == nil {
+		return
+	}
+	if _, ok := spec.Scalars[t]; ok {
+		return
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		if _, seen := m.named[t]; seen {
+			return
+		}
+		n := &Named{Type: t}
+		m.named[t] = n
+		if IsRaw(t) {
+			return
+		}
+		n.Doc = m.docs.typeDoc(t)
+		if t.Kind() == reflect.Struct {
+			n.Fields = m.fields(t)
+			for _, f := range n.Fields {
+				m.visit(f.Type)
+			}
+			return
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		m.visit(t.Elem())
+	case reflect.Map:
+		m.visit(t.Key())
+		m.visit(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			for _, f := range m.fields(t) {
+				m.visit(f.Type)
+			}
+		}
+	}
+}
+
+// Fields returns the JSON encoded fields of a struct type
+func (m *Model) Fields(t reflect.Type) []Field {
+	if n := m.named[t]; n != nil {
+		return n.Fields
+	}
+	return m.fields(t)
+}
+
+func (m *Model) fields(t reflect.Type) []Field {
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := sf.Type
+		embedded := sf.Anonymous && name == ""
+		if embedded {
+			base := ft
+			if base.Kind() == reflect.Pointer {
+				base = base.Elem()
+			}
+			if base.Kind() != reflect.Struct || IsRaw(base) {
+				embedded = false
+			}
+		}
+		if !sf.IsExported() && !embedded {
+			continue
+		}
+		if k := ft.Kind(); k == reflect.Chan || k == reflect.Func || k == reflect.UnsafePointer {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, Field{
+			GoName:    sf.Name,
+			JSONName:  name,
+			Type:      ft,
+			OmitEmpty: hasOption(opts, "omitempty"),
+			AsString:  hasOption(opts, "string"),
+			Embedded:  embedded,
+			Doc:       m.docs.fieldDoc(t, sf.Name),
+		})
+	}
+	return fields
+}
+
+func hasOption(opts, option string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// assignNames names the declarations, qualifying names declared in more
+// than one package with the package name
+func (m *Model) assignNames() {
+	byName := make(map[string][]*Named)
+	for t, n := range m.named {
+		base := exportName(t.Name())
+		byName[base] = append(byName[base], n)
+	}
+	for base, ns := range byName {
+		for _, n := range ns {
+			n.Name = base
+			if len(ns) > 1 {
+				n.Name = exportName(filepath.Base(n.Type.PkgPath())) + base
+			}
+			if n.Doc != "" && n.Name != n.Type.Name() && strings.HasPrefix(n.Doc, n.Type.Name()+" ") {
+				n.Doc = n.Name + strings.TrimPrefix(n.Doc, n.Type.Name())
+			}
+			m.Types = append(m.Types, n)
+		}
+	}
+	sort.Slice(m.Types, func(i, j int) bool { return m.Types[i].Name < m.Types[j].Name })
+}
+
+// exportName capitalizes an identifier
+func exportName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// PathParams returns the :params of an endpoint path in order
+func PathParams(path string) []string {
+	var params []string
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, ":") {
+			params = append(params, seg[1:])
+		}
+	}
+	return params
+}
+
+// docIndex holds the comments of types declared in the module
+type docIndex struct {
+	root   string
+	module string
+	pkgs   map[string]map[string]*typeDocs // Package path -> type name
+}
+
+type typeDocs struct {
+	doc    string
+	fields map[string]string
+}
+
+func newDocIndex(root string) (*docIndex, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("read go.mod: %w", err)
+	}
+	idx := &docIndex{root: root, pkgs: make(map[string]map[string]*typeDocs)}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			idx.module = strings.TrimSpace(rest)
+			break
+		}
+	}
+	if idx.module == "" {
+		return nil, fmt.Errorf("no module path in go.mod")
+	}
+	return idx, nil
+}
+
+func (d *docIndex) typeDoc(t reflect.Type) string {
+	if td := d.lookup(t); td != nil {
+		return td.doc
+	}
+	return ""
+}
+
+func (d *docIndex) fieldDoc(t reflect.Type, field string) string {
+	if td := d.lookup(t); td != nil {
+		return td.fields[field]
+	}
+	return ""
+}
+
+// lookup parses the package declaring t on first use. Types from outside
+// the module have no docs.
+func (d *docIndex) lookup(t reflect.Type) *typeDocs {
+	rel, ok := strings.CutPrefix(t.PkgPath(), d.module+"/")
+	if !ok || t.Name() == "" {
+		return nil
+	}
+	types, ok := d.pkgs[t.PkgPath()]
+	if !ok {
+		types = parseDocs(filepath.Join(d.root, filepath.FromSlash(rel)))
+		d.pkgs[t.PkgPath()] = types
+	}
+	return types[t.Name()]
+}
+
+// parseDocs reads the type and field comments of a package directory
+func parseDocs(dir string) map[string]*typeDocs {
+	types := make(map[string]*typeDocs)
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return types
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range gen.Specs {
+					ts := s.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					td := &typeDocs{doc: commentText(doc), fields: make(map[string]string)}
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						for _, f := range st.Fields.List {
+							text := commentText(f.Comment)
+							if text == "" {
+								text = commentText(f.Doc)
+							}
+							for _, name := range f.Names {
+								td.fields[name.Name] = text
+							}
+							if len(f.Names) == 0 {
+								td.fields[embeddedName(f.Type)] = text
+							}
+						}
+					}
+					types[ts.Name.Name] = td
+				}
+			}
+		}
+	}
+	return types
+}
+
+// embeddedName is the field name of an embedded type expression
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// commentText returns a comment group as one line
+func commentText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(g.Text()), " ")
+}
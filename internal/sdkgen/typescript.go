@@ -0,0 +1,263 @@
+package sdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/eth-trading/internal/api/spec"
+)
+
+var tsIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TSTypes renders web/src/types/api.gen.ts
+func (m *Model) TSTypes() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\n", Header)
+
+	for _, n := range m.Types {
+		writeTSDoc(&b, "", n.Doc, "")
+		switch {
+		case IsRaw(n.Type):
+			fmt.Fprintf(&b, "export type %s = unknown;\n\n", n.Name)
+		case n.Type.Kind() == reflect.Struct:
+			var extends []string
+			var own []Field
+			for _, f := range n.Fields {
+				if f.Embedded {
+					extends = append(extends, m.tsExpr(derefType(f.Type)))
+				} else {
+					own = append(own, f)
+				}
+			}
+			fmt.Fprintf(&b, "export interface %s ", n.Name)
+			if len(extends) > 0 {
+				fmt.Fprintf(&b, "extends %s ", strings.Join(extends, ", "))
+			}
+			b.WriteString(m.tsObject(own, "", "") + "\n\n")
+		default:
+			fmt.Fprintf(&b, "export type %s = %s;\n\n", n.Name, m.tsStructure(n.Type, ""))
+		}
+	}
+
+	b.WriteString("// WebSocket message types\nexport const MessageType = {\n")
+	for _, msg := range append(append([]spec.Message{}, m.ServerMessages...), m.ClientMessages...) {
+		fmt.Fprintf(&b, "  %s: '%s', // %s\n", strings.TrimPrefix(messageConst(msg.Type), "MessageType"), msg.Type, msg.Doc)
+	}
+	b.WriteString("} as const;\n\n")
+	b.WriteString("export type MessageType = (typeof MessageType)[keyof typeof MessageType];\n\n")
+
+	b.WriteString("// Envelope of messages broadcast by the server\n")
+	b.WriteString("export interface BroadcastMessage<T extends MessageType, D> {\n")
+	b.WriteString("  type: T;\n  timestamp: string;\n  data: D;\n")
+	b.WriteString("  accountId?: string; // Trading account the message concerns; absent = every user\n}\n\n")
+
+	b.WriteString("// Messages sent by the server\nexport type ServerMessage =\n")
+	var server, client []string
+	for _, msg := range m.ServerMessages {
+		server = append(server, m.tsMessage(msg, !msg.Reply))
+	}
+	for _, msg := range m.ClientMessages {
+		client = append(client, m.tsMessage(msg, false))
+	}
+	b.WriteString("  | " + strings.Join(server, "\n  | ") + ";\n\n")
+	b.WriteString("// Messages sent by clients\nexport type ClientMessage =\n")
+	b.WriteString("  | " + strings.Join(client, "\n  | ") + ";\n")
+	return b.Bytes()
+}
+
+// TSClient renders web/src/services/client.gen.ts
+func (m *Model) TSClient() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\n", Header)
+	b.WriteString("import type { AxiosInstance } from 'axios';\n")
+	b.WriteString("import type * as T from '../types/api.gen';\n\n")
+	b.WriteString("type QueryValue = string | number | boolean | undefined;\n\n")
+	fmt.Fprintf(&b, "// createApiClient returns the API endpoints on an axios instance whose\n")
+	fmt.Fprintf(&b, "// baseURL ends in %s\n", spec.Prefix)
+	b.WriteString("export const createApiClient = (http: AxiosInstance) => ({\n")
+
+	for _, e := range m.Endpoints {
+		var params []string
+		urlPath := "'" + e.Path + "'"
+		pp := PathParams(e.Path)
+		if len(pp) > 0 {
+			p := e.Path
+			for _, name := range pp {
+				p = strings.Replace(p, ":"+name, "${encodeURIComponent("+name+")}", 1)
+				params = append(params, name+": string")
+			}
+			urlPath = "`" + p + "`"
+		}
+		if e.Request != nil {
+			params = append(params, "body: "+m.tsExprQualified(e.Request))
+		}
+		if len(e.Query) > 0 {
+			var keys []string
+			for _, q := range e.Query {
+				keys = append(keys, tsKey(q)+"?: QueryValue")
+			}
+			params = append(params, "query?: { "+strings.Join(keys, "; ")+" }")
+		}
+		if e.TwoFactor {
+			params = append(params, "otp?: string")
+		}
+
+		resp := "void"
+		if e.Response != nil {
+			resp = m.tsExprQualified(e.Response)
+		}
+		method := strings.ToLower(e.Method)
+		args := []string{urlPath}
+		switch {
+		case e.Request != nil:
+			args = append(args, "body")
+		case method == "post" || method == "put":
+			args = append(args, "undefined")
+		}
+		switch {
+		case len(e.Query) > 0:
+			args = append(args, "{ params: query }")
+		case e.TwoFactor:
+			args = append(args, fmt.Sprintf("otp ? { headers: { '%s': otp } } : undefined", spec.TwoFactorHeader))
+		}
+
+		writeTSDoc(&b, "  ", e.Doc, e.Method+" "+spec.Prefix+e.Path)
+		fmt.Fprintf(&b, "  %s: (%s): Promise<%s> =>\n", lowerFirst(e.Name), strings.Join(params, ", "), resp)
+		fmt.Fprintf(&b, "    http.%s<%s>(%s).then((r) => r.data),\n", method, resp, strings.Join(args, ", "))
+	}
+	b.WriteString("});\n\nexport type ApiClient = ReturnType<typeof createApiClient>;\n")
+	return b.Bytes()
+}
+
+// tsMessage returns the type of a WebSocket message. Broadcasts come in a
+// BroadcastMessage envelope, replies and client messages without one.
+func (m *Model) tsMessage(msg spec.Message, broadcast bool) string {
+	typ := "typeof MessageType." + strings.TrimPrefix(messageConst(msg.Type), "MessageType")
+	data := "undefined"
+	if msg.Data != nil {
+		data = m.tsExpr(msg.Data)
+	}
+	switch {
+	case broadcast:
+		return "BroadcastMessage<" + typ + ", " + data + ">"
+	case msg.Data == nil:
+		return "{ type: " + typ + " }"
+	}
+	return "{ type: " + typ + "; data: " + data + " }"
+}
+
+// tsExprQualified is tsExpr for code importing the types as T
+func (m *Model) tsExprQualified(t reflect.Type) string {
+	return m.tsType(t, "T.")
+}
+
+// tsExpr returns the TypeScript type expression of t
+func (m *Model) tsExpr(t reflect.Type) string {
+	return m.tsType(t, "")
+}
+
+func (m *Model) tsType(t reflect.Type, qual string) string {
+	if _, ok := spec.Scalars[t]; ok {
+		return "string"
+	}
+	if n := m.named[t]; n != nil {
+		return qual + n.Name
+	}
+	return m.tsStructure(t, qual)
+}
+
+// tsStructure returns the TypeScript type expression of t's structure
+func (m *Model) tsStructure(t reflect.Type, qual string) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return m.tsType(t.Elem(), qual) + " | null"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string" // base64
+		}
+		elem := m.tsType(t.Elem(), qual)
+		if strings.Contains(elem, " ") && !strings.HasPrefix(elem, "{") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return "Record<string, " + m.tsType(t.Elem(), qual) + ">"
+	case reflect.Interface:
+		return "unknown"
+	case reflect.Struct:
+		return m.tsObject(m.Fields(t), "", qual)
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return "unknown"
+}
+
+// tsObject returns an object type literal of fields
+func (m *Model) tsObject(fields []Field, indent, qual string) string {
+	if len(fields) == 0 {
+		return "{}"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, f := range fields {
+		typ := strings.ReplaceAll(m.tsType(f.Type, qual), "\n", "\n"+indent+"  ")
+		if f.AsString {
+			typ = "string"
+		}
+		opt := ""
+		if f.OmitEmpty {
+			opt = "?"
+			typ = strings.TrimSuffix(typ, " | null")
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;", indent, tsKey(f.JSONName), opt, typ)
+		if f.Doc != "" {
+			b.WriteString(" // " + f.Doc)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsKey quotes property names that are not identifiers
+func tsKey(name string) string {
+	if tsIdent.MatchString(name) {
+		return name
+	}
+	return "'" + name + "'"
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// writeTSDoc writes a JSDoc comment of doc and an unwrapped last line
+func writeTSDoc(b *bytes.Buffer, indent, doc, last string) {
+	lines := wrap(doc, 77-len(indent))
+	if last != "" {
+		lines = append(lines, last)
+	}
+	switch len(lines) {
+	case 0:
+	case 1:
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+	default:
+		fmt.Fprintf(b, "%s/**\n", indent)
+		for _, l := range lines {
+			fmt.Fprintf(b, "%s * %s\n", indent, l)
+		}
+		fmt.Fprintf(b, "%s */\n", indent)
+	}
+}
@@ -0,0 +1,140 @@
+// Package secrets resolves secret values written in configuration as
+// references, and encrypts secrets stored at rest. A value may be:
+//
+//	enc:<base64>               sealed with the master key (see Cipher)
+//	vault:<path>#<field>       a field of a HashiCorp Vault KV v2 secret
+//	awssm:<secret-id>[#<key>]  an AWS Secrets Manager secret, or one key of
+//	                           its JSON object
+//
+// Anything else is taken as plaintext.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	prefixEncrypted = "enc:"
+	prefixVault     = "vault:"
+	prefixAWS       = "awssm:"
+
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	// ErrResolve wraps every failure to resolve a secret reference
+	ErrResolve = errors.New("resolve secret")
+	// ErrNoMasterKey is returned for enc: values when no master key is set
+	ErrNoMasterKey = errors.New("master key not set")
+	// ErrSecretNotFound is returned when a backend has no such secret or field
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
+// Backend fetches secrets from an external store. ref is the part of the
+// reference after its prefix.
+type Backend interface {
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+// Options configures a Resolver
+type Options struct {
+	MasterKeyEnv string        // Environment variable holding the master key; empty = DefaultMasterKeyEnv
+	Timeout      time.Duration // Bounds each backend lookup; 0 = 10s
+	Vault        VaultConfig
+	AWS          AWSConfig
+}
+
+// Resolver turns secret references into their values
+type Resolver struct {
+	cipher    *Cipher
+	cipherErr error
+	vault     Backend
+	aws       Backend
+	timeout   time.Duration
+}
+
+// NewResolver creates a resolver. An invalid master key only fails the
+// enc: values that need it.
+func NewResolver(opts Options) *Resolver {
+	r := &Resolver{
+		vault:   newVaultBackend(opts.Vault),
+		aws:     newAWSBackend(opts.AWS),
+		timeout: opts.Timeout,
+	}
+	if r.timeout <= 0 {
+		r.timeout = defaultTimeout
+	}
+	r.cipher, r.cipherErr = MasterCipher(opts.MasterKeyEnv)
+	if r.cipher == nil && r.cipherErr == nil {
+		env := opts.MasterKeyEnv
+		if env == "" {
+			env = DefaultMasterKeyEnv
+		}
+		r.cipherErr = fmt.Errorf("%w: set %s", ErrNoMasterKey, env)
+	}
+	return r
+}
+
+// IsReference reports whether value names a secret rather than holding it
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, prefixEncrypted) ||
+		strings.HasPrefix(value, prefixVault) ||
+		strings.HasPrefix(value, prefixAWS)
+}
+
+// Encrypted returns the enc: reference of a value sealed by Cipher.Encrypt
+func Encrypted(ciphertext string) string {
+	return prefixEncrypted + ciphertext
+}
+
+// Resolve returns the value a reference names, or value itself when it is
+// plaintext. Errors wrap ErrResolve.
+func (r *Resolver) Resolve(value string) (string, error) {
+	var (
+		resolved string
+		err      error
+	)
+	switch {
+	case strings.HasPrefix(value, prefixEncrypted):
+		if r.cipherErr != nil {
+			err = r.cipherErr
+			break
+		}
+		resolved, err = r.cipher.Decrypt(strings.TrimPrefix(value, prefixEncrypted))
+	case strings.HasPrefix(value, prefixVault):
+		resolved, err = r.lookup(r.vault, strings.TrimPrefix(value, prefixVault))
+	case strings.HasPrefix(value, prefixAWS):
+		resolved, err = r.lookup(r.aws, strings.TrimPrefix(value, prefixAWS))
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %v", ErrResolve, describe(value), err)
+	}
+	return resolved, nil
+}
+
+// lookup fetches ref from a backend within the resolver's timeout
+func (r *Resolver) lookup(b Backend, ref string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	return b.Get(ctx, ref)
+}
+
+// describe names a reference in errors without leaking ciphertext
+func describe(value string) string {
+	if strings.HasPrefix(value, prefixEncrypted) {
+		return prefixEncrypted + "..."
+	}
+	return value
+}
+
+// splitRef splits a backend reference into its path and optional #field
+func splitRef(ref string) (string, string) {
+	path, field, _ := strings.Cut(ref, "#")
+	return path, field
+}
@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// VaultConfig locates a HashiCorp Vault server. The token is only ever read
+// from the environment.
+type VaultConfig struct {
+	Address   string // Empty = $VAULT_ADDR
+	Mount     string // KV v2 mount; empty = "secret"
+	Namespace string // Enterprise namespace; empty = $VAULT_NAMESPACE
+	TokenEnv  string // Environment variable holding the token; empty = VAULT_TOKEN
+}
+
+// vaultBackend reads fields of KV v2 secrets: vault:<path>#<field>
+type vaultBackend struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+func newVaultBackend(cfg VaultConfig) *vaultBackend {
+	if cfg.Address == "" {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if cfg.TokenEnv == "" {
+		cfg.TokenEnv = "VAULT_TOKEN"
+	}
+	return &vaultBackend{cfg: cfg, client: &http.Client{}}
+}
+
+// Get reads one field of a secret's latest version
+func (b *vaultBackend) Get(ctx context.Context, ref string) (string, error) {
+	path, field := splitRef(ref)
+	if path == "" || field == "" {
+		return "", fmt.Errorf("vault reference must be <path>#<field>")
+	}
+	if b.cfg.Address == "" {
+		return "", fmt.Errorf("vault address not set (secrets.vault.address or VAULT_ADDR)")
+	}
+	token := os.Getenv(b.cfg.TokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("vault token not set (%s)", b.cfg.TokenEnv)
+	}
+
+	endpoint, err := url.JoinPath(b.cfg.Address, "v1", b.cfg.Mount, "data", strings.Trim(path, "/"))
+	if err != nil {
+		return "", fmt.Errorf("vault address: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if b.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", b.cfg.Namespace)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("read vault response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", ErrSecretNotFound
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("decode vault response: %w", err)
+	}
+	value, ok := secret.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("%w: no field %q", ErrSecretNotFound, field)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
@@ -29,9 +29,10 @@ func (r *TradingAccountRepository) Create(account *models.TradingAccount) error
 			demo_initial_capital, demo_current_balance,
 			binance_api_key, binance_secret_key_encrypted, binance_testnet,
 			trading_symbol, trading_mode, enabled_strategies,
+			risk_config, executor_enabled,
 			is_active, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		)
 	`
 
@@ -49,6 +50,8 @@ func (r *TradingAccountRepository) Create(account *models.TradingAccount) error
 		account.TradingSymbol,
 		account.TradingMode,
 		pq.Array(account.EnabledStrategies),
+		account.RiskConfig,
+		account.ExecutorEnabled,
 		account.IsActive,
 		account.CreatedAt,
 		account.UpdatedAt,
@@ -68,6 +71,7 @@ func (r *TradingAccountRepository) GetByID(id uuid.UUID) (*models.TradingAccount
 		       demo_initial_capital, demo_current_balance,
 		       binance_api_key, binance_secret_key_encrypted, binance_testnet,
 		       trading_symbol, trading_mode, enabled_strategies,
+		       risk_config, executor_enabled,
 		       is_active, created_at, updated_at
 		FROM trading_accounts
 		WHERE id = $1
@@ -92,6 +96,7 @@ func (r *TradingAccountRepository) GetByUserID(userID uuid.UUID) ([]*models.Trad
 		       demo_initial_capital, demo_current_balance,
 		       binance_api_key, binance_secret_key_encrypted, binance_testnet,
 		       trading_symbol, trading_mode, enabled_strategies,
+		       risk_config, executor_enabled,
 		       is_active, created_at, updated_at
 		FROM trading_accounts
 		WHERE user_id = $1
@@ -107,6 +112,30 @@ func (r *TradingAccountRepository) GetByUserID(userID uuid.UUID) ([]*models.Trad
 	return accounts, nil
 }
 
+// GetExecutorEnabled retrieves the active trading accounts whose executor
+// runs with the bot
+func (r *TradingAccountRepository) GetExecutorEnabled() ([]*models.TradingAccount, error) {
+	query := `
+		SELECT id, user_id, account_type, account_name,
+		       demo_initial_capital, demo_current_balance,
+		       binance_api_key, binance_secret_key_encrypted, binance_testnet,
+		       trading_symbol, trading_mode, enabled_strategies,
+		       risk_config, executor_enabled,
+		       is_active, created_at, updated_at
+		FROM trading_accounts
+		WHERE executor_enabled AND is_active
+		ORDER BY created_at
+	`
+
+	var accounts []*models.TradingAccount
+	err := r.db.Select(&accounts, query)
+	if err != nil {
+		return nil, fmt.Errorf("get executor enabled trading accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
 // Update updates a trading account
 func (r *TradingAccountRepository) Update(account *models.TradingAccount) error {
 	query := `
@@ -119,8 +148,10 @@ func (r *TradingAccountRepository) Update(account *models.TradingAccount) error
 		    trading_symbol = $7,
 		    trading_mode = $8,
 		    enabled_strategies = $9,
-		    is_active = $10,
-		    updated_at = $11
+		    risk_config = $10,
+		    executor_enabled = $11,
+		    is_active = $12,
+		    updated_at = $13
 		WHERE id = $1
 	`
 
@@ -137,6 +168,8 @@ func (r *TradingAccountRepository) Update(account *models.TradingAccount) error
 		account.TradingSymbol,
 		account.TradingMode,
 		pq.Array(account.EnabledStrategies),
+		account.RiskConfig,
+		account.ExecutorEnabled,
 		account.IsActive,
 		account.UpdatedAt,
 	)
@@ -189,6 +189,21 @@ type Position struct {
 	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
 }
 
+// PositionPnL breaks down where a position's P&L came from. Costs are
+// positive when they reduce P&L; slippage is already inside GrossPnL and
+// is reported to show how much of it execution gave away.
+type PositionPnL struct {
+	PositionID      int64     `db:"position_id" json:"position_id"`
+	GrossPnL        float64   `db:"gross_pnl" json:"gross_pnl"` // Price move on the exited quantity
+	EntryCommission float64   `db:"entry_commission" json:"entry_commission"`
+	ExitCommission  float64   `db:"exit_commission" json:"exit_commission"`
+	FundingCost     float64   `db:"funding_cost" json:"funding_cost"`
+	EntrySlippage   float64   `db:"entry_slippage" json:"entry_slippage"` // Fill vs signal price
+	ExitSlippage    float64   `db:"exit_slippage" json:"exit_slippage"`
+	NetPnL          float64   `db:"net_pnl" json:"net_pnl"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
+
 // UpdatePrice updates the position's current price and unrealized P&L
 func (p *Position) UpdatePrice(price float64) {
 	p.CurrentPrice = price
@@ -255,6 +270,16 @@ type Order struct {
 	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
 }
 
+// OrderTransition represents one status change of a persisted order
+type OrderTransition struct {
+	OrderID    string    `db:"order_id" json:"order_id"`
+	Seq        int       `db:"seq" json:"seq"` // Position in the order's history, from 0
+	FromStatus string    `db:"from_status" json:"from_status"`
+	ToStatus   string    `db:"to_status" json:"to_status"`
+	Reason     string    `db:"reason" json:"reason,omitempty"`
+	At         time.Time `db:"at" json:"at"`
+}
+
 // AccountSnapshot represents a point-in-time account state
 type AccountSnapshot struct {
 	ID               int64     `db:"id" json:"id"`
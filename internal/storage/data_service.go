@@ -2,23 +2,33 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
-// DataService coordinates between in-memory queues and SQLite database
+// DataService coordinates between in-memory queues and the databases:
+// SQLite, and PostgreSQL for trading data when configured
 type DataService struct {
 	db              *SQLiteDB
 	queueManager    *QueueManager
-	candleRepo      *CandleRepository
-	tradeRepo       *TradeRepository
-	positionRepo    *PositionRepository
+	backend         string // Where candles, trades and positions are kept
+	candleRepo      CandleStore
+	tradeRepo       TradeStore
+	positionRepo    PositionStore
+	orderRepo       *OrderRepository
 	accountRepo     *AccountRepository
 	alertRepo       *AlertRepository
 	backtestRepo    *BacktestRepository
 	strategyPerfRepo *StrategyPerformanceRepository
+	settingsRepo     *SettingsHistoryRepository
+	intentRepo       *OrderIntentRepository
+	indicatorRepo    *IndicatorRepository
+	backupRepo       *BackupRepository
+	sharedRepo       *SharedBundleRepository
+	auditRepo        *AuditLogRepository
 
 	// Persistence settings
 	persistInterval time.Duration
@@ -41,21 +51,44 @@ func NewDataService(db *SQLiteDB, persistInterval time.Duration, capacities map[
 		capacities = DefaultCapacities
 	}
 
+	stores := NewSQLiteStores(db)
 	return &DataService{
 		db:               db,
 		queueManager:     NewQueueManager(defaultCapacity, capacities),
-		candleRepo:       NewCandleRepository(db),
-		tradeRepo:        NewTradeRepository(db),
-		positionRepo:     NewPositionRepository(db),
+		backend:          stores.Backend,
+		candleRepo:       stores.Candles,
+		tradeRepo:        stores.Trades,
+		positionRepo:     stores.Positions,
+		orderRepo:        NewOrderRepository(db),
 		accountRepo:      NewAccountRepository(db),
 		alertRepo:        NewAlertRepository(db),
 		backtestRepo:     NewBacktestRepository(db),
 		strategyPerfRepo: NewStrategyPerformanceRepository(db),
+		settingsRepo:     NewSettingsHistoryRepository(db),
+		intentRepo:       NewOrderIntentRepository(db),
+		indicatorRepo:    NewIndicatorRepository(db),
+		backupRepo:       NewBackupRepository(db),
+		sharedRepo:       NewSharedBundleRepository(db),
+		auditRepo:        NewAuditLogRepository(db),
 		persistInterval:  persistInterval,
 		pendingCandles:   make([]Candle, 0, 100),
 	}
 }
 
+// SetTradingStores moves candles, trades and positions to stores, e.g.
+// PostgreSQL. It must be called before the service is used.
+func (ds *DataService) SetTradingStores(stores TradingStores) {
+	ds.backend = stores.Backend
+	ds.candleRepo = stores.Candles
+	ds.tradeRepo = stores.Trades
+	ds.positionRepo = stores.Positions
+}
+
+// TradingBackend returns where candles, trades and positions are kept
+func (ds *DataService) TradingBackend() string {
+	return ds.backend
+}
+
 // Start starts the background persistence goroutine
 func (ds *DataService) Start(ctx context.Context) {
 	if ds.running {
@@ -65,7 +98,7 @@ func (ds *DataService) Start(ctx context.Context) {
 	ctx, ds.cancel = context.WithCancel(ctx)
 	ds.running = true
 
-	go ds.persistenceLoop(ctx)
+	go ds.RunPersistence(ctx, nil)
 	log.Info().Dur("interval", ds.persistInterval).Msg("Data service started")
 }
 
@@ -83,8 +116,9 @@ func (ds *DataService) Stop() {
 	log.Info().Msg("Data service stopped")
 }
 
-// persistenceLoop runs the background persistence
-func (ds *DataService) persistenceLoop(ctx context.Context) {
+// RunPersistence runs the persistence loop until ctx is cancelled.
+// beat, if set, is called after every flush so callers can supervise the loop.
+func (ds *DataService) RunPersistence(ctx context.Context, beat func()) {
 	ticker := time.NewTicker(ds.persistInterval)
 	defer ticker.Stop()
 
@@ -95,11 +129,19 @@ func (ds *DataService) persistenceLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			ds.flushPendingCandles()
+			if beat != nil {
+				beat()
+			}
 		}
 	}
 }
 
-// flushPendingCandles writes pending candles to SQLite
+// PersistInterval returns the interval between candle flushes
+func (ds *DataService) PersistInterval() time.Duration {
+	return ds.persistInterval
+}
+
+// flushPendingCandles writes pending candles to the candle store
 func (ds *DataService) flushPendingCandles() {
 	ds.pendingMu.Lock()
 	if len(ds.pendingCandles) == 0 {
@@ -117,7 +159,7 @@ func (ds *DataService) flushPendingCandles() {
 		ds.pendingCandles = append(candles, ds.pendingCandles...)
 		ds.pendingMu.Unlock()
 	} else {
-		log.Debug().Int("count", len(candles)).Msg("Persisted candles to SQLite")
+		log.Debug().Int("count", len(candles)).Msg("Persisted candles")
 	}
 }
 
@@ -134,7 +176,16 @@ func (ds *DataService) AddCandle(candle Candle) {
 		}
 	}
 
-	queue.Push(candle)
+	// A closed candle already queued, e.g. replayed by the stream or
+	// fetched again, replaces its copy instead of being added twice
+	if latest, ok := queue.GetLatest(); ok && candle.IsClosed && !candle.OpenTime.After(latest.OpenTime) {
+		if !latest.OpenTime.Equal(candle.OpenTime) {
+			return
+		}
+		queue.UpdateLatest(candle)
+	} else {
+		queue.Push(candle)
+	}
 
 	// Queue for async persistence (only closed candles)
 	if candle.IsClosed {
@@ -169,6 +220,13 @@ func (ds *DataService) GetOHLCV(symbol, timeframe string) (opens, highs, lows, c
 	return ds.queueManager.GetOHLCV(symbol, timeframe)
 }
 
+// FillLastColumns fills cols with the last n candles from the in-memory
+// queue, all of them when n <= 0, reusing their arrays. It returns the
+// number of candles filled.
+func (ds *DataService) FillLastColumns(symbol, timeframe string, n int, cols *CandleColumns) int {
+	return ds.queueManager.FillLastColumns(symbol, timeframe, n, cols)
+}
+
 // GetCloses returns close prices for indicator calculations
 func (ds *DataService) GetCloses(symbol, timeframe string) []float64 {
 	queue, exists := ds.queueManager.Get(symbol, timeframe)
@@ -210,11 +268,11 @@ func (ds *DataService) HasEnoughData(symbol, timeframe string, n int) bool {
 	return ds.queueManager.HasEnoughData(symbol, timeframe, n)
 }
 
-// LoadHistoricalCandles loads candles from SQLite into memory queues
+// LoadHistoricalCandles loads stored candles into memory queues
 func (ds *DataService) LoadHistoricalCandles(symbol, timeframe string) error {
 	capacity := ds.queueManager.GetCapacity(timeframe)
 
-	// Load from SQLite
+	// Load from the candle store
 	candles, err := ds.candleRepo.GetLast(symbol, timeframe, capacity)
 	if err != nil {
 		return err
@@ -250,11 +308,17 @@ func (ds *DataService) LoadAllHistoricalCandles(symbol string, timeframes []stri
 	return nil
 }
 
-// GetHistoricalCandles retrieves candles from SQLite for a date range
+// GetHistoricalCandles retrieves stored candles for a date range
 func (ds *DataService) GetHistoricalCandles(symbol, timeframe string, from, to time.Time) ([]Candle, error) {
 	return ds.candleRepo.GetRange(symbol, timeframe, from, to)
 }
 
+// SaveHistoricalCandles persists backfilled candles without
+// touching the in-memory queues
+func (ds *DataService) SaveHistoricalCandles(candles []Candle) error {
+	return ds.candleRepo.InsertBatch(candles)
+}
+
 // Trade methods
 
 // AddTrade persists a trade
@@ -262,6 +326,28 @@ func (ds *DataService) AddTrade(trade Trade) error {
 	return ds.tradeRepo.Insert(trade)
 }
 
+// MergeTrade persists a fill, accumulating partial fills of one order
+func (ds *DataService) MergeTrade(trade Trade) error {
+	return ds.tradeRepo.Merge(trade)
+}
+
+// ImportTrade stores a trade recorded outside the bot unless its order is
+// already stored, reporting whether it was added
+func (ds *DataService) ImportTrade(trade Trade) (bool, error) {
+	return ds.tradeRepo.InsertIfAbsent(trade)
+}
+
+// GetFirstTradeTime returns when the oldest trade not made by
+// excludeStrategy was executed, or the zero time when there is none
+func (ds *DataService) GetFirstTradeTime(excludeStrategy string) (time.Time, error) {
+	return ds.tradeRepo.GetFirstTime(excludeStrategy)
+}
+
+// GetRecentTrades retrieves the most recent trades across symbols
+func (ds *DataService) GetRecentTrades(limit int) ([]Trade, error) {
+	return ds.tradeRepo.GetRecent(limit)
+}
+
 // GetTrades retrieves trades for a symbol
 func (ds *DataService) GetTrades(symbol string, limit int) ([]Trade, error) {
 	return ds.tradeRepo.GetBySymbol(symbol, limit)
@@ -304,6 +390,51 @@ func (ds *DataService) GetClosedPositions(limit int) ([]Position, error) {
 	return ds.positionRepo.GetClosed(limit)
 }
 
+// GetRealizedPnLSince sums the realized P&L of positions closed since a time
+func (ds *DataService) GetRealizedPnLSince(since time.Time) (float64, error) {
+	return ds.positionRepo.RealizedPnLSince(since)
+}
+
+// GetPositionPnL retrieves a position's P&L breakdown
+func (ds *DataService) GetPositionPnL(positionID int64) (*PositionPnL, error) {
+	return ds.positionRepo.GetPnL(positionID)
+}
+
+// GetPositionPnLs retrieves the P&L breakdowns of several positions
+func (ds *DataService) GetPositionPnLs(positionIDs []int64) (map[int64]PositionPnL, error) {
+	return ds.positionRepo.GetPnLs(positionIDs)
+}
+
+// SavePositionPnL stores a position's P&L breakdown
+func (ds *DataService) SavePositionPnL(pnl PositionPnL) error {
+	return ds.positionRepo.UpsertPnL(pnl)
+}
+
+// Order methods
+
+// SaveOrder stores an order and the transitions in its history not yet stored
+func (ds *DataService) SaveOrder(order Order, transitions []OrderTransition) error {
+	if err := ds.orderRepo.Upsert(order); err != nil {
+		return err
+	}
+	return ds.orderRepo.AddTransitions(transitions)
+}
+
+// GetOrder retrieves an order by exchange order ID, nil if not stored
+func (ds *DataService) GetOrder(orderID string) (*Order, error) {
+	return ds.orderRepo.Get(orderID)
+}
+
+// GetRecentOrders retrieves the most recently updated orders
+func (ds *DataService) GetRecentOrders(symbol, status, strategy string, limit int) ([]Order, error) {
+	return ds.orderRepo.GetRecent(symbol, status, strategy, limit)
+}
+
+// GetOrderTransitions retrieves the status history of orders by order ID
+func (ds *DataService) GetOrderTransitions(orderIDs []string) (map[string][]OrderTransition, error) {
+	return ds.orderRepo.GetTransitions(orderIDs)
+}
+
 // Account methods
 
 // AddAccountSnapshot persists an account snapshot
@@ -338,6 +469,50 @@ func (ds *DataService) AcknowledgeAlert(id int64) error {
 	return ds.alertRepo.Acknowledge(id)
 }
 
+// Order intent methods
+
+// AddOrderIntent records a new order intent
+func (ds *DataService) AddOrderIntent(intent OrderIntent) (int64, error) {
+	return ds.intentRepo.Insert(intent)
+}
+
+// UpdateOrderIntent saves an order intent's state
+func (ds *DataService) UpdateOrderIntent(intent OrderIntent) error {
+	return ds.intentRepo.Update(intent)
+}
+
+// GetPendingOrderIntents retrieves intents interrupted before completion
+func (ds *DataService) GetPendingOrderIntents() ([]OrderIntent, error) {
+	return ds.intentRepo.GetPending()
+}
+
+// GetOrderIntents retrieves the most recent order intents
+func (ds *DataService) GetOrderIntents(limit int) ([]OrderIntent, error) {
+	return ds.intentRepo.GetRecent(limit)
+}
+
+// Indicator series methods
+
+// SaveIndicatorColumn persists a precomputed indicator series
+func (ds *DataService) SaveIndicatorColumn(symbol, timeframe string, column IndicatorColumn) error {
+	return ds.indicatorRepo.UpsertColumn(symbol, timeframe, column)
+}
+
+// GetIndicatorColumn retrieves a precomputed indicator series for a date range
+func (ds *DataService) GetIndicatorColumn(symbol, timeframe, name string, from, to time.Time) (*IndicatorColumn, error) {
+	return ds.indicatorRepo.GetColumn(symbol, timeframe, name, from, to)
+}
+
+// GetIndicatorLatestTime returns the newest stored bar of an indicator series
+func (ds *DataService) GetIndicatorLatestTime(symbol, timeframe, name string) (time.Time, error) {
+	return ds.indicatorRepo.GetLatestTime(symbol, timeframe, name)
+}
+
+// GetIndicatorCoverage lists the precomputed indicator series
+func (ds *DataService) GetIndicatorCoverage(symbol, timeframe string) ([]IndicatorCoverage, error) {
+	return ds.indicatorRepo.GetCoverage(symbol, timeframe)
+}
+
 // Strategy Performance methods
 
 // UpdateStrategyPerformance updates strategy performance metrics
@@ -362,6 +537,26 @@ func (ds *DataService) UpdateBacktestRun(run BacktestRun) error {
 	return ds.backtestRepo.UpdateRun(run)
 }
 
+// StartBacktestRun marks a queued backtest run as running
+func (ds *DataService) StartBacktestRun(id int64) error {
+	return ds.backtestRepo.StartRun(id)
+}
+
+// FailUnfinishedBacktestRuns marks queued or running backtest runs as failed
+func (ds *DataService) FailUnfinishedBacktestRuns(reason string) (int64, error) {
+	return ds.backtestRepo.FailUnfinishedRuns(reason)
+}
+
+// SaveBacktestResult stores the full result of a backtest run, or its error
+func (ds *DataService) SaveBacktestResult(id int64, result json.RawMessage, errMsg string) error {
+	return ds.backtestRepo.SaveResult(id, result, errMsg)
+}
+
+// GetBacktestResult retrieves the full result of a backtest run and its error
+func (ds *DataService) GetBacktestResult(id int64) (json.RawMessage, string, error) {
+	return ds.backtestRepo.GetResult(id)
+}
+
 // GetBacktestRun retrieves a backtest run by ID
 func (ds *DataService) GetBacktestRun(id int64) (*BacktestRun, error) {
 	return ds.backtestRepo.GetRun(id)
@@ -377,6 +572,192 @@ func (ds *DataService) DeleteBacktestRun(id int64) error {
 	return ds.backtestRepo.DeleteRun(id)
 }
 
+// Settings methods
+
+// settingsKeyPrefix prefixes persisted settings sections in the config table
+const settingsKeyPrefix = "settings."
+
+// LoadSettings retrieves a persisted settings section (empty if never saved)
+func (ds *DataService) LoadSettings(section string) (string, error) {
+	return ds.db.GetConfig(settingsKeyPrefix + section)
+}
+
+// highWaterMarkKey is the config table key holding the drawdown high-water mark
+const highWaterMarkKey = "risk.high_water_mark"
+
+// LoadHighWaterMark retrieves the persisted high-water mark (empty if never saved)
+func (ds *DataService) LoadHighWaterMark() (string, error) {
+	return ds.db.GetConfig(highWaterMarkKey)
+}
+
+// SaveHighWaterMark persists the high-water mark
+func (ds *DataService) SaveHighWaterMark(value string) error {
+	return ds.db.SetConfig(highWaterMarkKey, value)
+}
+
+// paperStateKey is the config table key holding the paper account snapshot
+const paperStateKey = "paper.state"
+
+// LoadPaperState retrieves the persisted paper account (empty if never saved)
+func (ds *DataService) LoadPaperState() (string, error) {
+	return ds.db.GetConfig(paperStateKey)
+}
+
+// SavePaperState persists the paper account
+func (ds *DataService) SavePaperState(value string) error {
+	return ds.db.SetConfig(paperStateKey, value)
+}
+
+// signalCooldownsKey is the config table key holding per-strategy signal
+// cooldowns set through the API
+const signalCooldownsKey = "strategy.signal_cooldowns"
+
+// LoadSignalCooldowns retrieves the persisted signal cooldowns (empty if never saved)
+func (ds *DataService) LoadSignalCooldowns() (string, error) {
+	return ds.db.GetConfig(signalCooldownsKey)
+}
+
+// SaveSignalCooldowns persists the signal cooldowns
+func (ds *DataService) SaveSignalCooldowns(value string) error {
+	return ds.db.SetConfig(signalCooldownsKey, value)
+}
+
+// strategyStatesKey is the config table key holding strategies enabled or
+// disabled through the API
+const strategyStatesKey = "strategy.enabled"
+
+// LoadStrategyStates retrieves the persisted strategy states (empty if never saved)
+func (ds *DataService) LoadStrategyStates() (string, error) {
+	return ds.db.GetConfig(strategyStatesKey)
+}
+
+// SaveStrategyStates persists the strategy states
+func (ds *DataService) SaveStrategyStates(value string) error {
+	return ds.db.SetConfig(strategyStatesKey, value)
+}
+
+// streamSubscriptionsKey is the config key of the Binance streams added
+// through the API
+const streamSubscriptionsKey = "ws.subscriptions"
+
+// LoadStreamSubscriptions retrieves the persisted stream subscriptions (empty if never saved)
+func (ds *DataService) LoadStreamSubscriptions() (string, error) {
+	return ds.db.GetConfig(streamSubscriptionsKey)
+}
+
+// SaveStreamSubscriptions persists the stream subscriptions
+func (ds *DataService) SaveStreamSubscriptions(value string) error {
+	return ds.db.SetConfig(streamSubscriptionsKey, value)
+}
+
+// subBalanceKey is the config key of the bot's share of the exchange account
+const subBalanceKey = "capital.subBalance"
+
+// LoadSubBalance retrieves the persisted sub-balance (empty if never saved)
+func (ds *DataService) LoadSubBalance() (string, error) {
+	return ds.db.GetConfig(subBalanceKey)
+}
+
+// SaveSubBalance persists the sub-balance
+func (ds *DataService) SaveSubBalance(value string) error {
+	return ds.db.SetConfig(subBalanceKey, value)
+}
+
+// copyFollowersKey is the config key of the copy-trading followers
+// registered through the API
+const copyFollowersKey = "copy.followers"
+
+// LoadCopyFollowers retrieves the persisted copy-trading followers (empty if never saved)
+func (ds *DataService) LoadCopyFollowers() (string, error) {
+	return ds.db.GetConfig(copyFollowersKey)
+}
+
+// SaveCopyFollowers persists the copy-trading followers
+func (ds *DataService) SaveCopyFollowers(value string) error {
+	return ds.db.SetConfig(copyFollowersKey, value)
+}
+
+// riskReportsKey is the config key of the recent daily risk reports
+const riskReportsKey = "risk.daily_reports"
+
+// LoadRiskReports retrieves the persisted daily risk reports (empty if never saved)
+func (ds *DataService) LoadRiskReports() (string, error) {
+	return ds.db.GetConfig(riskReportsKey)
+}
+
+// SaveRiskReports persists the daily risk reports
+func (ds *DataService) SaveRiskReports(value string) error {
+	return ds.db.SetConfig(riskReportsKey, value)
+}
+
+// optimizedParamsKey is the config key of the recorded optimized strategy parameters
+const optimizedParamsKey = "strategy.optimized_params"
+
+// LoadOptimizedParams retrieves the recorded optimized strategy parameters (empty if never saved)
+func (ds *DataService) LoadOptimizedParams() (string, error) {
+	return ds.db.GetConfig(optimizedParamsKey)
+}
+
+// SaveOptimizedParams persists the recorded optimized strategy parameters
+func (ds *DataService) SaveOptimizedParams(value string) error {
+	return ds.db.SetConfig(optimizedParamsKey, value)
+}
+
+// reportLocaleKeyPrefix prefixes the report locales users set, by user ID
+const reportLocaleKeyPrefix = "report.locale."
+
+// LoadReportLocale retrieves a user's report locale (empty if never saved)
+func (ds *DataService) LoadReportLocale(user string) (string, error) {
+	return ds.db.GetConfig(reportLocaleKeyPrefix + user)
+}
+
+// SaveReportLocale persists a user's report locale
+func (ds *DataService) SaveReportLocale(user, value string) error {
+	return ds.db.SetConfig(reportLocaleKeyPrefix+user, value)
+}
+
+// processedCandleKeyPrefix prefixes the close times of the last candles
+// processed, by symbol and timeframe
+const processedCandleKeyPrefix = "candle.processed."
+
+// LoadProcessedCandle retrieves the close time of the last candle processed for a symbol and timeframe (empty if never saved)
+func (ds *DataService) LoadProcessedCandle(symbol, timeframe string) (string, error) {
+	return ds.db.GetConfig(processedCandleKeyPrefix + symbol + "." + timeframe)
+}
+
+// SaveProcessedCandle persists the close time of the last candle processed for a symbol and timeframe
+func (ds *DataService) SaveProcessedCandle(symbol, timeframe, value string) error {
+	return ds.db.SetConfig(processedCandleKeyPrefix+symbol+"."+timeframe, value)
+}
+
+// SaveSettingsChange persists the new value of a settings section and adds
+// the change to the settings audit trail in one transaction
+func (ds *DataService) SaveSettingsChange(change SettingsChange) (int64, error) {
+	return ds.settingsRepo.InsertApplied(settingsKeyPrefix+change.Section, string(change.NewValue), change)
+}
+
+// GetSettingsChange retrieves a settings change by version ID
+func (ds *DataService) GetSettingsChange(id int64) (*SettingsChange, error) {
+	return ds.settingsRepo.GetByID(id)
+}
+
+// GetSettingsHistory retrieves recent settings changes
+func (ds *DataService) GetSettingsHistory(section string, limit int) ([]SettingsChange, error) {
+	return ds.settingsRepo.GetRecent(section, limit)
+}
+
+// Audit log methods
+
+// RecordAudit adds an entry to the audit log
+func (ds *DataService) RecordAudit(entry AuditEntry) (int64, error) {
+	return ds.auditRepo.Insert(entry)
+}
+
+// GetAuditLog retrieves audit entries matching filter, newest first
+func (ds *DataService) GetAuditLog(filter AuditFilter) ([]AuditEntry, error) {
+	return ds.auditRepo.Query(filter)
+}
+
 // Database methods
 
 // GetDB returns the underlying database
@@ -399,6 +780,43 @@ func (ds *DataService) GetDBStats() (*DBStats, error) {
 	return ds.db.GetStats()
 }
 
+// BackupDatabase writes a consistent copy of the database to destPath
+// while the bot keeps running
+func (ds *DataService) BackupDatabase(ctx context.Context, destPath string) error {
+	return ds.db.Backup(ctx, destPath)
+}
+
+// RecordBackup records a backup attempt
+func (ds *DataService) RecordBackup(record BackupRecord) (int64, error) {
+	return ds.backupRepo.Insert(record)
+}
+
+// GetBackups retrieves recent backup attempts
+func (ds *DataService) GetBackups(limit int) ([]BackupRecord, error) {
+	return ds.backupRepo.GetRecent(limit)
+}
+
+// SaveSharedBundle stores an imported result bundle
+func (ds *DataService) SaveSharedBundle(bundle SharedBundle) error {
+	return ds.sharedRepo.Save(bundle)
+}
+
+// GetSharedBundles lists imported result bundles without their contents
+func (ds *DataService) GetSharedBundles() ([]SharedBundle, error) {
+	return ds.sharedRepo.List()
+}
+
+// GetSharedBundle retrieves an imported result bundle, or nil if unknown
+func (ds *DataService) GetSharedBundle(id string) (*SharedBundle, error) {
+	return ds.sharedRepo.Get(id)
+}
+
+// DeleteSharedBundle removes an imported result bundle, reporting whether
+// it existed
+func (ds *DataService) DeleteSharedBundle(id string) (bool, error) {
+	return ds.sharedRepo.Delete(id)
+}
+
 // Cleanup removes old data
 func (ds *DataService) Cleanup(candleRetentionDays, snapshotRetentionDays int) error {
 	return ds.db.Cleanup(candleRetentionDays, snapshotRetentionDays)
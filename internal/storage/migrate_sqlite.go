@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultCopyBatchSize is how many rows are copied per transaction
+const defaultCopyBatchSize = 1000
+
+// TableCopy counts the rows of one table copied to PostgreSQL
+type TableCopy struct {
+	Table   string `json:"table"`
+	Copied  int64  `json:"copied"`
+	Skipped int64  `json:"skipped"` // Already in PostgreSQL
+}
+
+// copyTable describes how a SQLite table is copied
+type copyTable struct {
+	name    string
+	key     string   // Unique column the SQLite rows are read in order of
+	columns []string // Copied; the key is copied only when listed
+	// Columns that must match when a row with the same ID is already in
+	// PostgreSQL, so rows written there by the bot aren't taken for copies
+	match []string
+}
+
+// tradingDataTables are copied in order; positions come before the P&L
+// breakdowns that reference them. Candles and trades are matched on their
+// natural keys and get new IDs; positions keep theirs, which other tables
+// refer to.
+var tradingDataTables = []copyTable{
+	{
+		name:    "candles",
+		key:     "id",
+		columns: []string{"symbol", "timeframe", "open_time", "close_time", "open", "high", "low", "close", "volume", "trades", "created_at"},
+	},
+	{
+		name: "trades",
+		key:  "id",
+		columns: []string{"order_id", "symbol", "side", "type", "quantity", "price", "commission", "commission_asset",
+			"executed_at", "strategy", "signal_strength", "created_at"},
+	},
+	{
+		name: "positions",
+		key:  "id",
+		columns: []string{"id", "symbol", "side", "entry_price", "quantity", "current_price", "unrealized_pnl", "realized_pnl",
+			"stop_loss", "take_profit", "strategy", "status", "opened_at", "closed_at", "created_at", "updated_at"},
+		match: []string{"symbol", "side", "opened_at"},
+	},
+	{
+		name: "position_pnl",
+		key:  "position_id",
+		columns: []string{"position_id", "gross_pnl", "entry_commission", "exit_commission", "funding_cost",
+			"entry_slippage", "exit_slippage", "net_pnl", "updated_at"},
+	},
+}
+
+// CopyTradingData copies candles, trades, positions and their P&L
+// breakdowns from SQLite into PostgreSQL, creating the tables when
+// missing. Rows already in PostgreSQL are skipped, so an interrupted copy
+// can be run again. It must run before the bot writes positions to
+// PostgreSQL: a position there with the ID of a different SQLite position
+// stops the copy.
+func CopyTradingData(ctx context.Context, src *SQLiteDB, dst *sqlx.DB, batchSize int) ([]TableCopy, error) {
+	if batchSize <= 0 {
+		batchSize = defaultCopyBatchSize
+	}
+	if err := MigratePostgresTradingData(dst); err != nil {
+		return nil, fmt.Errorf("create trading data tables: %w", err)
+	}
+
+	report := make([]TableCopy, 0, len(tradingDataTables))
+	for _, table := range tradingDataTables {
+		copied, err := copyTableRows(ctx, src, dst, table, batchSize)
+		report = append(report, copied)
+		if err != nil {
+			return report, fmt.Errorf("copy %s: %w", table.name, err)
+		}
+		log.Info().
+			Str("table", table.name).
+			Int64("copied", copied.Copied).
+			Int64("skipped", copied.Skipped).
+			Msg("Copied table to PostgreSQL")
+	}
+
+	// New positions continue after the copied IDs
+	if _, err := dst.ExecContext(ctx,
+		`SELECT setval(pg_get_serial_sequence('positions', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM positions`,
+	); err != nil {
+		return report, fmt.Errorf("advance position IDs: %w", err)
+	}
+	return report, nil
+}
+
+// copyTableRows copies one table in batches of batchSize rows, each in its
+// own transaction
+func copyTableRows(ctx context.Context, src *SQLiteDB, dst *sqlx.DB, table copyTable, batchSize int) (TableCopy, error) {
+	result := TableCopy{Table: table.name}
+
+	selectQuery := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s > ? ORDER BY %s LIMIT ?",
+		table.key, strings.Join(table.columns, ", "), table.name, table.key, table.key)
+	placeholders := make([]string, len(table.columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO NOTHING",
+		table.name, strings.Join(table.columns, ", "), strings.Join(placeholders, ", "))
+
+	var after int64
+	for {
+		rows, err := readRows(ctx, src, selectQuery, after, batchSize, len(table.columns)+1)
+		if err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+
+		tx, err := dst.BeginTx(ctx, nil)
+		if err != nil {
+			return result, err
+		}
+		var copied, skipped int64
+		for _, row := range rows {
+			res, err := tx.ExecContext(ctx, insertQuery, row[1:]...)
+			if err != nil {
+				tx.Rollback()
+				return result, err
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				copied++
+				continue
+			}
+			if err := checkSameRow(ctx, tx, table, row); err != nil {
+				tx.Rollback()
+				return result, err
+			}
+			skipped++
+		}
+		if err := tx.Commit(); err != nil {
+			return result, err
+		}
+		result.Copied += copied
+		result.Skipped += skipped
+
+		key, ok := rows[len(rows)-1][0].(int64)
+		if !ok {
+			return result, fmt.Errorf("unexpected %s key %v", table.name, rows[len(rows)-1][0])
+		}
+		after = key
+	}
+}
+
+// readRows reads one batch of SQLite rows with width columns
+func readRows(ctx context.Context, src *SQLiteDB, query string, after int64, limit, width int) ([][]interface{}, error) {
+	rows, err := src.DB().QueryContext(ctx, query, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, width)
+		ptrs := make([]interface{}, width)
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		batch = append(batch, values)
+	}
+	return batch, rows.Err()
+}
+
+// checkSameRow verifies that the row already in PostgreSQL with the ID of
+// a skipped row is the same one, for tables with match columns
+func checkSameRow(ctx context.Context, tx *sql.Tx, table copyTable, row []interface{}) error {
+	if len(table.match) == 0 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(table.columns))
+	for i, column := range table.columns {
+		values[column] = row[i+1]
+	}
+	existing := make([]interface{}, len(table.match))
+	ptrs := make([]interface{}, len(table.match))
+	for i := range existing {
+		ptrs[i] = &existing[i]
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = $1", strings.Join(table.match, ", "), table.name, table.key)
+	if err := tx.QueryRowContext(ctx, query, row[0]).Scan(ptrs...); err != nil {
+		return err
+	}
+
+	for i, column := range table.match {
+		if !sameValue(existing[i], values[column]) {
+			return fmt.Errorf("%s %v in PostgreSQL is not the one in SQLite (%s differs); copy before the bot writes to PostgreSQL",
+				strings.TrimSuffix(table.name, "s"), row[0], column)
+		}
+	}
+	return nil
+}
+
+// sameValue compares a PostgreSQL value with the SQLite one copied.
+// PostgreSQL keeps times to the microsecond.
+func sameValue(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Sub(bt).Abs() < time.Microsecond
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
@@ -0,0 +1,525 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// postgresTradingSchema creates the trading data tables, the same as
+// migrations/004_trading_data.up.sql
+var postgresTradingSchema = []string{
+	`CREATE TABLE IF NOT EXISTS candles (
+		id BIGSERIAL PRIMARY KEY,
+		symbol TEXT NOT NULL,
+		timeframe TEXT NOT NULL,
+		open_time TIMESTAMPTZ NOT NULL,
+		close_time TIMESTAMPTZ NOT NULL,
+		open DOUBLE PRECISION NOT NULL,
+		high DOUBLE PRECISION NOT NULL,
+		low DOUBLE PRECISION NOT NULL,
+		close DOUBLE PRECISION NOT NULL,
+		volume DOUBLE PRECISION NOT NULL,
+		trades INTEGER DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT NOW(),
+		UNIQUE(symbol, timeframe, open_time)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_candles_symbol_timeframe_time
+	 ON candles(symbol, timeframe, open_time DESC)`,
+
+	`CREATE TABLE IF NOT EXISTS trades (
+		id BIGSERIAL PRIMARY KEY,
+		order_id TEXT UNIQUE NOT NULL,
+		symbol TEXT NOT NULL,
+		side TEXT NOT NULL,
+		type TEXT NOT NULL,
+		quantity DOUBLE PRECISION NOT NULL,
+		price DOUBLE PRECISION NOT NULL,
+		commission DOUBLE PRECISION DEFAULT 0,
+		commission_asset TEXT,
+		executed_at TIMESTAMPTZ NOT NULL,
+		strategy TEXT,
+		signal_strength DOUBLE PRECISION,
+		created_at TIMESTAMPTZ DEFAULT NOW()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_trades_symbol_time
+	 ON trades(symbol, executed_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_trades_strategy
+	 ON trades(strategy, executed_at DESC)`,
+
+	`CREATE TABLE IF NOT EXISTS positions (
+		id BIGSERIAL PRIMARY KEY,
+		symbol TEXT NOT NULL,
+		side TEXT NOT NULL,
+		entry_price DOUBLE PRECISION NOT NULL,
+		quantity DOUBLE PRECISION NOT NULL,
+		current_price DOUBLE PRECISION,
+		unrealized_pnl DOUBLE PRECISION DEFAULT 0,
+		realized_pnl DOUBLE PRECISION DEFAULT 0,
+		stop_loss DOUBLE PRECISION,
+		take_profit DOUBLE PRECISION,
+		strategy TEXT,
+		status TEXT DEFAULT 'open',
+		opened_at TIMESTAMPTZ NOT NULL,
+		closed_at TIMESTAMPTZ,
+		created_at TIMESTAMPTZ DEFAULT NOW(),
+		updated_at TIMESTAMPTZ DEFAULT NOW()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_positions_symbol_status
+	 ON positions(symbol, status)`,
+	`CREATE INDEX IF NOT EXISTS idx_positions_strategy
+	 ON positions(strategy, status)`,
+
+	`CREATE TABLE IF NOT EXISTS position_pnl (
+		position_id BIGINT PRIMARY KEY REFERENCES positions(id),
+		gross_pnl DOUBLE PRECISION DEFAULT 0,
+		entry_commission DOUBLE PRECISION DEFAULT 0,
+		exit_commission DOUBLE PRECISION DEFAULT 0,
+		funding_cost DOUBLE PRECISION DEFAULT 0,
+		entry_slippage DOUBLE PRECISION DEFAULT 0,
+		exit_slippage DOUBLE PRECISION DEFAULT 0,
+		net_pnl DOUBLE PRECISION DEFAULT 0,
+		updated_at TIMESTAMPTZ DEFAULT NOW()
+	)`,
+}
+
+// MigratePostgresTradingData creates the candle, trade and position tables
+// when missing
+func MigratePostgresTradingData(db *sqlx.DB) error {
+	for _, stmt := range postgresTradingSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostgresCandleRepository handles candle persistence in PostgreSQL
+type PostgresCandleRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCandleRepository creates a new PostgreSQL candle repository
+func NewPostgresCandleRepository(db *sqlx.DB) *PostgresCandleRepository {
+	return &PostgresCandleRepository{db: db}
+}
+
+const postgresUpsertCandle = `
+	INSERT INTO candles (symbol, timeframe, open_time, close_time, open, high, low, close, volume, trades)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT(symbol, timeframe, open_time) DO UPDATE SET
+		high = GREATEST(excluded.high, candles.high),
+		low = LEAST(excluded.low, candles.low),
+		close = excluded.close,
+		volume = excluded.volume,
+		trades = excluded.trades
+`
+
+// Insert adds a new candle (upsert)
+func (r *PostgresCandleRepository) Insert(candle Candle) error {
+	_, err := r.db.Exec(postgresUpsertCandle,
+		candle.Symbol, candle.Timeframe, candle.OpenTime, candle.CloseTime,
+		candle.Open, candle.High, candle.Low, candle.Close, candle.Volume, candle.Trades,
+	)
+	return err
+}
+
+// InsertBatch inserts multiple candles in one transaction
+func (r *PostgresCandleRepository) InsertBatch(candles []Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(postgresUpsertCandle)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, candle := range candles {
+		_, err := stmt.Exec(
+			candle.Symbol, candle.Timeframe, candle.OpenTime, candle.CloseTime,
+			candle.Open, candle.High, candle.Low, candle.Close, candle.Volume, candle.Trades,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetRange retrieves candles within a time range
+func (r *PostgresCandleRepository) GetRange(symbol, timeframe string, from, to time.Time) ([]Candle, error) {
+	query := `
+		SELECT id, symbol, timeframe, open_time, close_time, open, high, low, close, volume, trades
+		FROM candles
+		WHERE symbol = $1 AND timeframe = $2 AND open_time >= $3 AND open_time <= $4
+		ORDER BY open_time ASC
+	`
+	rows, err := r.db.Query(query, symbol, timeframe, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCandles(rows)
+}
+
+// GetLast retrieves the last N candles, oldest first
+func (r *PostgresCandleRepository) GetLast(symbol, timeframe string, limit int) ([]Candle, error) {
+	query := `
+		SELECT id, symbol, timeframe, open_time, close_time, open, high, low, close, volume, trades
+		FROM (
+			SELECT id, symbol, timeframe, open_time, close_time, open, high, low, close, volume, trades
+			FROM candles
+			WHERE symbol = $1 AND timeframe = $2
+			ORDER BY open_time DESC
+			LIMIT $3
+		) last
+		ORDER BY open_time ASC
+	`
+	rows, err := r.db.Query(query, symbol, timeframe, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCandles(rows)
+}
+
+// GetLatest retrieves the most recent candle
+func (r *PostgresCandleRepository) GetLatest(symbol, timeframe string) (*Candle, error) {
+	query := `
+		SELECT id, symbol, timeframe, open_time, close_time, open, high, low, close, volume, trades
+		FROM candles
+		WHERE symbol = $1 AND timeframe = $2
+		ORDER BY open_time DESC
+		LIMIT 1
+	`
+	var c Candle
+	err := r.db.QueryRow(query, symbol, timeframe).Scan(
+		&c.ID, &c.Symbol, &c.Timeframe, &c.OpenTime, &c.CloseTime,
+		&c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Trades,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Count returns the number of candles
+func (r *PostgresCandleRepository) Count(symbol, timeframe string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM candles WHERE symbol = $1 AND timeframe = $2",
+		symbol, timeframe,
+	).Scan(&count)
+	return count, err
+}
+
+// DeleteOlderThan removes candles older than the given date
+func (r *PostgresCandleRepository) DeleteOlderThan(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM candles WHERE open_time < $1", cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// PostgresTradeRepository handles trade persistence in PostgreSQL
+type PostgresTradeRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTradeRepository creates a new PostgreSQL trade repository
+func NewPostgresTradeRepository(db *sqlx.DB) *PostgresTradeRepository {
+	return &PostgresTradeRepository{db: db}
+}
+
+const postgresInsertTrade = `
+	INSERT INTO trades (order_id, symbol, side, type, quantity, price, commission, commission_asset, executed_at, strategy, signal_strength)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+// Insert adds a new trade
+func (r *PostgresTradeRepository) Insert(trade Trade) error {
+	_, err := r.db.Exec(postgresInsertTrade,
+		trade.OrderID, trade.Symbol, trade.Side, trade.Type,
+		trade.Quantity, trade.Price, trade.Commission, trade.CommissionAsset,
+		trade.ExecutedAt, trade.Strategy, trade.SignalStrength,
+	)
+	return err
+}
+
+// Merge adds a fill to its order's trade, creating the trade on the first
+// fill. Partial fills of one order accumulate into a single row with the
+// volume-weighted average price.
+func (r *PostgresTradeRepository) Merge(trade Trade) error {
+	query := postgresInsertTrade + `
+		ON CONFLICT(order_id) DO UPDATE SET
+			price = (trades.price * trades.quantity + excluded.price * excluded.quantity) / (trades.quantity + excluded.quantity),
+			quantity = trades.quantity + excluded.quantity,
+			commission = trades.commission + excluded.commission,
+			executed_at = excluded.executed_at
+	`
+	_, err := r.db.Exec(query,
+		trade.OrderID, trade.Symbol, trade.Side, trade.Type,
+		trade.Quantity, trade.Price, trade.Commission, trade.CommissionAsset,
+		trade.ExecutedAt, trade.Strategy, trade.SignalStrength,
+	)
+	return err
+}
+
+// InsertIfAbsent adds a trade unless one with its order ID is stored,
+// reporting whether it was added
+func (r *PostgresTradeRepository) InsertIfAbsent(trade Trade) (bool, error) {
+	result, err := r.db.Exec(postgresInsertTrade+` ON CONFLICT(order_id) DO NOTHING`,
+		trade.OrderID, trade.Symbol, trade.Side, trade.Type,
+		trade.Quantity, trade.Price, trade.Commission, trade.CommissionAsset,
+		trade.ExecutedAt, trade.Strategy, trade.SignalStrength,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// GetFirstTime returns the execution time of the oldest trade not made by
+// excludeStrategy, or the zero time when there is none
+func (r *PostgresTradeRepository) GetFirstTime(excludeStrategy string) (time.Time, error) {
+	var first time.Time
+	err := r.db.QueryRow(`
+		SELECT executed_at FROM trades
+		WHERE strategy IS NULL OR strategy != $1
+		ORDER BY executed_at ASC
+		LIMIT 1
+	`, excludeStrategy).Scan(&first)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return first, err
+}
+
+const postgresSelectTrades = `
+	SELECT id, order_id, symbol, side, type, quantity, price, commission, commission_asset, executed_at, strategy, signal_strength, created_at
+	FROM trades
+`
+
+// GetRecent retrieves the most recent trades across symbols
+func (r *PostgresTradeRepository) GetRecent(limit int) ([]Trade, error) {
+	return r.query(postgresSelectTrades+`ORDER BY executed_at DESC LIMIT $1`, limit)
+}
+
+// GetBySymbol retrieves trades for a symbol
+func (r *PostgresTradeRepository) GetBySymbol(symbol string, limit int) ([]Trade, error) {
+	return r.query(postgresSelectTrades+`WHERE symbol = $1 ORDER BY executed_at DESC LIMIT $2`, symbol, limit)
+}
+
+// GetByStrategy retrieves trades for a strategy
+func (r *PostgresTradeRepository) GetByStrategy(strategy string, limit int) ([]Trade, error) {
+	return r.query(postgresSelectTrades+`WHERE strategy = $1 ORDER BY executed_at DESC LIMIT $2`, strategy, limit)
+}
+
+// GetByDateRange retrieves trades within a date range
+func (r *PostgresTradeRepository) GetByDateRange(from, to time.Time) ([]Trade, error) {
+	return r.query(postgresSelectTrades+`WHERE executed_at >= $1 AND executed_at <= $2 ORDER BY executed_at ASC`, from, to)
+}
+
+func (r *PostgresTradeRepository) query(query string, args ...interface{}) ([]Trade, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTrades(rows)
+}
+
+// PostgresPositionRepository handles position persistence in PostgreSQL
+type PostgresPositionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPositionRepository creates a new PostgreSQL position repository
+func NewPostgresPositionRepository(db *sqlx.DB) *PostgresPositionRepository {
+	return &PostgresPositionRepository{db: db}
+}
+
+// Insert adds a new position
+func (r *PostgresPositionRepository) Insert(pos Position) (int64, error) {
+	query := `
+		INSERT INTO positions (symbol, side, entry_price, quantity, current_price, unrealized_pnl, stop_loss, take_profit, strategy, status, opened_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	var id int64
+	err := r.db.QueryRow(query,
+		pos.Symbol, pos.Side, pos.EntryPrice, pos.Quantity, pos.CurrentPrice,
+		pos.UnrealizedPnL, pos.StopLoss, pos.TakeProfit, pos.Strategy, pos.Status, pos.OpenedAt,
+	).Scan(&id)
+	return id, err
+}
+
+// Update updates a position
+func (r *PostgresPositionRepository) Update(pos Position) error {
+	query := `
+		UPDATE positions SET
+			entry_price = $1, quantity = $2,
+			current_price = $3, unrealized_pnl = $4, realized_pnl = $5,
+			stop_loss = $6, take_profit = $7, status = $8, closed_at = $9,
+			updated_at = NOW()
+		WHERE id = $10
+	`
+	_, err := r.db.Exec(query,
+		pos.EntryPrice, pos.Quantity,
+		pos.CurrentPrice, pos.UnrealizedPnL, pos.RealizedPnL,
+		pos.StopLoss, pos.TakeProfit, pos.Status, pos.ClosedAt, pos.ID,
+	)
+	return err
+}
+
+const postgresSelectPositions = `
+	SELECT id, symbol, side, entry_price, quantity, current_price, unrealized_pnl, realized_pnl,
+	       stop_loss, take_profit, strategy, status, opened_at, closed_at, created_at, updated_at
+	FROM positions
+`
+
+// GetOpen retrieves all open positions
+func (r *PostgresPositionRepository) GetOpen() ([]Position, error) {
+	rows, err := r.db.Query(postgresSelectPositions + `WHERE status = 'open' ORDER BY opened_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPositions(rows)
+}
+
+// GetByID retrieves a position by ID
+func (r *PostgresPositionRepository) GetByID(id int64) (*Position, error) {
+	var pos Position
+	var closedAt sql.NullTime
+	err := r.db.QueryRow(postgresSelectPositions+`WHERE id = $1`, id).Scan(
+		&pos.ID, &pos.Symbol, &pos.Side, &pos.EntryPrice, &pos.Quantity,
+		&pos.CurrentPrice, &pos.UnrealizedPnL, &pos.RealizedPnL,
+		&pos.StopLoss, &pos.TakeProfit, &pos.Strategy, &pos.Status,
+		&pos.OpenedAt, &closedAt, &pos.CreatedAt, &pos.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if closedAt.Valid {
+		pos.ClosedAt = &closedAt.Time
+	}
+	return &pos, nil
+}
+
+// GetClosed retrieves closed positions
+func (r *PostgresPositionRepository) GetClosed(limit int) ([]Position, error) {
+	rows, err := r.db.Query(postgresSelectPositions+`WHERE status = 'closed' ORDER BY closed_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPositions(rows)
+}
+
+// RealizedPnLSince sums the realized P&L of positions closed at or after since
+func (r *PostgresPositionRepository) RealizedPnLSince(since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(realized_pnl), 0)
+		FROM positions
+		WHERE status = 'closed' AND closed_at >= $1
+	`
+	var pnl float64
+	err := r.db.QueryRow(query, since).Scan(&pnl)
+	return pnl, err
+}
+
+const postgresSelectPnL = `
+	SELECT position_id, gross_pnl, entry_commission, exit_commission, funding_cost,
+	       entry_slippage, exit_slippage, net_pnl, updated_at
+	FROM position_pnl
+`
+
+// GetPnL retrieves a position's P&L breakdown, nil if none was recorded
+func (r *PostgresPositionRepository) GetPnL(positionID int64) (*PositionPnL, error) {
+	var p PositionPnL
+	err := r.db.QueryRow(postgresSelectPnL+`WHERE position_id = $1`, positionID).Scan(
+		&p.PositionID, &p.GrossPnL, &p.EntryCommission, &p.ExitCommission, &p.FundingCost,
+		&p.EntrySlippage, &p.ExitSlippage, &p.NetPnL, &p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetPnLs retrieves the P&L breakdowns of several positions by position ID
+func (r *PostgresPositionRepository) GetPnLs(positionIDs []int64) (map[int64]PositionPnL, error) {
+	result := make(map[int64]PositionPnL, len(positionIDs))
+	if len(positionIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(postgresSelectPnL+`WHERE position_id = ANY($1)`, pq.Array(positionIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p PositionPnL
+		if err := rows.Scan(
+			&p.PositionID, &p.GrossPnL, &p.EntryCommission, &p.ExitCommission, &p.FundingCost,
+			&p.EntrySlippage, &p.ExitSlippage, &p.NetPnL, &p.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result[p.PositionID] = p
+	}
+	return result, rows.Err()
+}
+
+// UpsertPnL stores a position's P&L breakdown, replacing any earlier one
+func (r *PostgresPositionRepository) UpsertPnL(p PositionPnL) error {
+	query := `
+		INSERT INTO position_pnl (position_id, gross_pnl, entry_commission, exit_commission, funding_cost,
+		                          entry_slippage, exit_slippage, net_pnl, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT(position_id) DO UPDATE SET
+			gross_pnl = excluded.gross_pnl,
+			entry_commission = excluded.entry_commission,
+			exit_commission = excluded.exit_commission,
+			funding_cost = excluded.funding_cost,
+			entry_slippage = excluded.entry_slippage,
+			exit_slippage = excluded.exit_slippage,
+			net_pnl = excluded.net_pnl,
+			updated_at = NOW()
+	`
+	_, err := r.db.Exec(query,
+		p.PositionID, p.GrossPnL, p.EntryCommission, p.ExitCommission, p.FundingCost,
+		p.EntrySlippage, p.ExitSlippage, p.NetPnL,
+	)
+	return err
+}
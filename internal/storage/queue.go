@@ -330,6 +330,68 @@ func (q *CandleQueue) GetOHLCV() (opens, highs, lows, closes, volumes []float64)
 	return
 }
 
+// CandleColumns holds OHLCV columns filled from a candle queue. Refilling
+// reuses the arrays, so columns kept past the next fill must be copied.
+type CandleColumns struct {
+	Opens   []float64
+	Highs   []float64
+	Lows    []float64
+	Closes  []float64
+	Volumes []float64
+	Latest  Candle // Newest candle filled
+}
+
+// Len returns the number of candles in the columns
+func (c *CandleColumns) Len() int {
+	return len(c.Closes)
+}
+
+// resize sets the columns to n candles, allocating only when the arrays
+// are too short
+func (c *CandleColumns) resize(n int) {
+	if cap(c.Closes) < n {
+		c.Opens = make([]float64, n)
+		c.Highs = make([]float64, n)
+		c.Lows = make([]float64, n)
+		c.Closes = make([]float64, n)
+		c.Volumes = make([]float64, n)
+	}
+	c.Opens = c.Opens[:n]
+	c.Highs = c.Highs[:n]
+	c.Lows = c.Lows[:n]
+	c.Closes = c.Closes[:n]
+	c.Volumes = c.Volumes[:n]
+	c.Latest = Candle{}
+}
+
+// FillLastColumns fills cols with the last n candles (oldest to newest),
+// all of them when n <= 0, without copying the candles themselves. It
+// returns the number of candles filled.
+func (q *CandleQueue) FillLastColumns(n int, cols *CandleColumns) int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if n <= 0 || n > q.size {
+		n = q.size
+	}
+	cols.resize(n)
+	if n == 0 {
+		return 0
+	}
+
+	startIdx := q.size - n
+	for i := 0; i < n; i++ {
+		c := &q.buffer[(q.head+startIdx+i)%q.capacity]
+		cols.Opens[i] = c.Open
+		cols.Highs[i] = c.High
+		cols.Lows[i] = c.Low
+		cols.Closes[i] = c.Close
+		cols.Volumes[i] = c.Volume
+	}
+	cols.Latest = q.buffer[(q.tail-1+q.capacity)%q.capacity]
+	return n
+}
+
 // GetLastNCloses returns the last N close prices (oldest to newest)
 func (q *CandleQueue) GetLastNCloses(n int) []float64 {
 	q.mu.RLock()
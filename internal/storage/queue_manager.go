@@ -200,6 +200,17 @@ func (qm *QueueManager) GetOHLCV(symbol, timeframe string) (opens, highs, lows,
 	return queue.GetOHLCV()
 }
 
+// FillLastColumns fills cols with the last n candles for a
+// symbol/timeframe, all of them when n <= 0
+func (qm *QueueManager) FillLastColumns(symbol, timeframe string, n int, cols *CandleColumns) int {
+	queue, exists := qm.Get(symbol, timeframe)
+	if !exists {
+		cols.resize(0)
+		return 0
+	}
+	return queue.FillLastColumns(n, cols)
+}
+
 // HasEnoughData checks if a queue has enough data for indicators
 func (qm *QueueManager) HasEnoughData(symbol, timeframe string, n int) bool {
 	queue, exists := qm.Get(symbol, timeframe)
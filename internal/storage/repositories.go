@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -203,6 +204,80 @@ func (r *TradeRepository) Insert(trade Trade) error {
 	return err
 }
 
+// Merge adds a fill to its order's trade, creating the trade on the first
+// fill. Partial fills of one order accumulate into a single row with the
+// volume-weighted average price.
+func (r *TradeRepository) Merge(trade Trade) error {
+	query := `
+		INSERT INTO trades (order_id, symbol, side, type, quantity, price, commission, commission_asset, executed_at, strategy, signal_strength)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(order_id) DO UPDATE SET
+			price = (trades.price * trades.quantity + excluded.price * excluded.quantity) / (trades.quantity + excluded.quantity),
+			quantity = trades.quantity + excluded.quantity,
+			commission = trades.commission + excluded.commission,
+			executed_at = excluded.executed_at
+	`
+	_, err := r.db.Exec(query,
+		trade.OrderID, trade.Symbol, trade.Side, trade.Type,
+		trade.Quantity, trade.Price, trade.Commission, trade.CommissionAsset,
+		trade.ExecutedAt, trade.Strategy, trade.SignalStrength,
+	)
+	return err
+}
+
+// InsertIfAbsent adds a trade unless one with its order ID is stored,
+// reporting whether it was added
+func (r *TradeRepository) InsertIfAbsent(trade Trade) (bool, error) {
+	query := `
+		INSERT INTO trades (order_id, symbol, side, type, quantity, price, commission, commission_asset, executed_at, strategy, signal_strength)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(order_id) DO NOTHING
+	`
+	result, err := r.db.Exec(query,
+		trade.OrderID, trade.Symbol, trade.Side, trade.Type,
+		trade.Quantity, trade.Price, trade.Commission, trade.CommissionAsset,
+		trade.ExecutedAt, trade.Strategy, trade.SignalStrength,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// GetFirstTime returns the execution time of the oldest trade not made by
+// excludeStrategy, or the zero time when there is none
+func (r *TradeRepository) GetFirstTime(excludeStrategy string) (time.Time, error) {
+	var first time.Time
+	err := r.db.QueryRow(`
+		SELECT executed_at FROM trades
+		WHERE strategy IS NULL OR strategy != ?
+		ORDER BY executed_at ASC
+		LIMIT 1
+	`, excludeStrategy).Scan(&first)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return first, err
+}
+
+// GetRecent retrieves the most recent trades across symbols
+func (r *TradeRepository) GetRecent(limit int) ([]Trade, error) {
+	query := `
+		SELECT id, order_id, symbol, side, type, quantity, price, commission, commission_asset, executed_at, strategy, signal_strength, created_at
+		FROM trades
+		ORDER BY executed_at DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTrades(rows)
+}
+
 // GetBySymbol retrieves trades for a symbol
 func (r *TradeRepository) GetBySymbol(symbol string, limit int) ([]Trade, error) {
 	query := `
@@ -307,12 +382,14 @@ func (r *PositionRepository) Insert(pos Position) (int64, error) {
 func (r *PositionRepository) Update(pos Position) error {
 	query := `
 		UPDATE positions SET
+			entry_price = ?, quantity = ?,
 			current_price = ?, unrealized_pnl = ?, realized_pnl = ?,
 			stop_loss = ?, take_profit = ?, status = ?, closed_at = ?,
 			updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`
 	_, err := r.db.Exec(query,
+		pos.EntryPrice, pos.Quantity,
 		pos.CurrentPrice, pos.UnrealizedPnL, pos.RealizedPnL,
 		pos.StopLoss, pos.TakeProfit, pos.Status, pos.ClosedAt, pos.ID,
 	)
@@ -384,6 +461,100 @@ func (r *PositionRepository) GetClosed(limit int) ([]Position, error) {
 	return scanPositions(rows)
 }
 
+// RealizedPnLSince sums the realized P&L of positions closed at or after since
+func (r *PositionRepository) RealizedPnLSince(since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(realized_pnl), 0)
+		FROM positions
+		WHERE status = 'closed' AND closed_at >= ?
+	`
+	var pnl float64
+	err := r.db.QueryRow(query, since).Scan(&pnl)
+	return pnl, err
+}
+
+// GetPnL retrieves a position's P&L breakdown, nil if none was recorded
+func (r *PositionRepository) GetPnL(positionID int64) (*PositionPnL, error) {
+	query := `
+		SELECT position_id, gross_pnl, entry_commission, exit_commission, funding_cost,
+		       entry_slippage, exit_slippage, net_pnl, updated_at
+		FROM position_pnl
+		WHERE position_id = ?
+	`
+	var p PositionPnL
+	err := r.db.QueryRow(query, positionID).Scan(
+		&p.PositionID, &p.GrossPnL, &p.EntryCommission, &p.ExitCommission, &p.FundingCost,
+		&p.EntrySlippage, &p.ExitSlippage, &p.NetPnL, &p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetPnLs retrieves the P&L breakdowns of several positions by position ID
+func (r *PositionRepository) GetPnLs(positionIDs []int64) (map[int64]PositionPnL, error) {
+	result := make(map[int64]PositionPnL, len(positionIDs))
+	if len(positionIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(positionIDs)), ",")
+	args := make([]interface{}, len(positionIDs))
+	for i, id := range positionIDs {
+		args[i] = id
+	}
+	query := `
+		SELECT position_id, gross_pnl, entry_commission, exit_commission, funding_cost,
+		       entry_slippage, exit_slippage, net_pnl, updated_at
+		FROM position_pnl
+		WHERE position_id IN (` + placeholders + `)
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p PositionPnL
+		if err := rows.Scan(
+			&p.PositionID, &p.GrossPnL, &p.EntryCommission, &p.ExitCommission, &p.FundingCost,
+			&p.EntrySlippage, &p.ExitSlippage, &p.NetPnL, &p.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result[p.PositionID] = p
+	}
+	return result, rows.Err()
+}
+
+// UpsertPnL stores a position's P&L breakdown, replacing any earlier one
+func (r *PositionRepository) UpsertPnL(p PositionPnL) error {
+	query := `
+		INSERT INTO position_pnl (position_id, gross_pnl, entry_commission, exit_commission, funding_cost,
+		                          entry_slippage, exit_slippage, net_pnl, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(position_id) DO UPDATE SET
+			gross_pnl = excluded.gross_pnl,
+			entry_commission = excluded.entry_commission,
+			exit_commission = excluded.exit_commission,
+			funding_cost = excluded.funding_cost,
+			entry_slippage = excluded.entry_slippage,
+			exit_slippage = excluded.exit_slippage,
+			net_pnl = excluded.net_pnl,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	_, err := r.db.Exec(query,
+		p.PositionID, p.GrossPnL, p.EntryCommission, p.ExitCommission, p.FundingCost,
+		p.EntrySlippage, p.ExitSlippage, p.NetPnL,
+	)
+	return err
+}
+
 func scanPositions(rows *sql.Rows) ([]Position, error) {
 	var positions []Position
 	for rows.Next() {
@@ -406,6 +577,155 @@ func scanPositions(rows *sql.Rows) ([]Position, error) {
 	return positions, rows.Err()
 }
 
+// OrderRepository handles order and order transition persistence
+type OrderRepository struct {
+	db *SQLiteDB
+}
+
+// NewOrderRepository creates a new order repository
+func NewOrderRepository(db *SQLiteDB) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// Upsert stores an order, updating its state if it is already stored
+func (r *OrderRepository) Upsert(order Order) error {
+	query := `
+		INSERT INTO orders (order_id, client_order_id, symbol, side, type, quantity, price, stop_price,
+		                    status, filled_quantity, avg_fill_price, strategy, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(order_id) DO UPDATE SET
+			quantity = excluded.quantity,
+			price = excluded.price,
+			stop_price = excluded.stop_price,
+			status = excluded.status,
+			filled_quantity = excluded.filled_quantity,
+			avg_fill_price = excluded.avg_fill_price,
+			strategy = COALESCE(NULLIF(excluded.strategy, ''), orders.strategy),
+			updated_at = excluded.updated_at
+	`
+	_, err := r.db.Exec(query,
+		order.OrderID, order.ClientOrderID, order.Symbol, order.Side, order.Type,
+		order.Quantity, order.Price, order.StopPrice, order.Status,
+		order.FilledQuantity, order.AvgFillPrice, order.Strategy, order.CreatedAt, order.UpdatedAt,
+	)
+	return err
+}
+
+// AddTransitions stores order transitions, skipping ones already stored
+func (r *OrderRepository) AddTransitions(transitions []OrderTransition) error {
+	query := `
+		INSERT OR IGNORE INTO order_transitions (order_id, seq, from_status, to_status, reason, at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	for _, t := range transitions {
+		if _, err := r.db.Exec(query, t.OrderID, t.Seq, t.FromStatus, t.ToStatus, t.Reason, t.At); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get retrieves an order by exchange order ID
+func (r *OrderRepository) Get(orderID string) (*Order, error) {
+	query := `
+		SELECT id, order_id, client_order_id, symbol, side, type, quantity, price, stop_price,
+		       status, filled_quantity, avg_fill_price, strategy, created_at, updated_at
+		FROM orders
+		WHERE order_id = ?
+	`
+	rows, err := r.db.Query(query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders, err := scanOrders(rows)
+	if err != nil || len(orders) == 0 {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// GetRecent retrieves the most recently updated orders, optionally
+// filtered by symbol, status and strategy
+func (r *OrderRepository) GetRecent(symbol, status, strategy string, limit int) ([]Order, error) {
+	query := `
+		SELECT id, order_id, client_order_id, symbol, side, type, quantity, price, stop_price,
+		       status, filled_quantity, avg_fill_price, strategy, created_at, updated_at
+		FROM orders
+		WHERE (? = '' OR symbol = ?) AND (? = '' OR status = ?) AND (? = '' OR strategy = ?)
+		ORDER BY updated_at DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, symbol, symbol, status, status, strategy, strategy, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOrders(rows)
+}
+
+// GetTransitions retrieves the transitions of several orders, oldest first
+func (r *OrderRepository) GetTransitions(orderIDs []string) (map[string][]OrderTransition, error) {
+	result := make(map[string][]OrderTransition, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(orderIDs)), ",")
+	args := make([]interface{}, len(orderIDs))
+	for i, id := range orderIDs {
+		args[i] = id
+	}
+	query := `
+		SELECT order_id, seq, from_status, to_status, reason, at
+		FROM order_transitions
+		WHERE order_id IN (` + placeholders + `)
+		ORDER BY order_id, seq
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t OrderTransition
+		var from, reason sql.NullString
+		if err := rows.Scan(&t.OrderID, &t.Seq, &from, &t.ToStatus, &reason, &t.At); err != nil {
+			return nil, err
+		}
+		t.FromStatus = from.String
+		t.Reason = reason.String
+		result[t.OrderID] = append(result[t.OrderID], t)
+	}
+	return result, rows.Err()
+}
+
+func scanOrders(rows *sql.Rows) ([]Order, error) {
+	var orders []Order
+	for rows.Next() {
+		var o Order
+		var clientID, strategy sql.NullString
+		var price, stopPrice, avgFillPrice sql.NullFloat64
+		err := rows.Scan(
+			&o.ID, &o.OrderID, &clientID, &o.Symbol, &o.Side, &o.Type, &o.Quantity, &price, &stopPrice,
+			&o.Status, &o.FilledQuantity, &avgFillPrice, &strategy, &o.CreatedAt, &o.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		o.ClientOrderID = clientID.String
+		o.Strategy = strategy.String
+		o.Price = price.Float64
+		o.StopPrice = stopPrice.Float64
+		o.AvgFillPrice = avgFillPrice.Float64
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
 // AccountRepository handles account snapshot persistence
 type AccountRepository struct {
 	db *SQLiteDB
@@ -650,13 +970,17 @@ type BacktestRun struct {
 	CreatedAt      time.Time       `json:"created_at"`
 }
 
-// InsertRun inserts a new backtest run
+// InsertRun inserts a new backtest run, "running" unless run.Status is set
 func (r *BacktestRepository) InsertRun(run BacktestRun) (int64, error) {
 	strategies, _ := json.Marshal(run.Strategies)
 	config := string(run.Config)
 	if config == "" {
 		config = "{}"
 	}
+	status := run.Status
+	if status == "" {
+		status = "running"
+	}
 
 	query := `
 		INSERT INTO backtest_runs (name, symbol, timeframe, start_date, end_date, initial_balance, strategies, config, status)
@@ -664,7 +988,7 @@ func (r *BacktestRepository) InsertRun(run BacktestRun) (int64, error) {
 	`
 	result, err := r.db.Exec(query,
 		run.Name, run.Symbol, run.Timeframe, run.StartDate, run.EndDate,
-		run.InitialBalance, string(strategies), config, "running",
+		run.InitialBalance, string(strategies), config, status,
 	)
 	if err != nil {
 		return 0, err
@@ -695,6 +1019,71 @@ func (r *BacktestRepository) UpdateRun(run BacktestRun) error {
 	return err
 }
 
+// StartRun marks a queued backtest run as running
+func (r *BacktestRepository) StartRun(id int64) error {
+	_, err := r.db.Exec(`UPDATE backtest_runs SET status = 'running', started_at = ? WHERE id = ?`, time.Now(), id)
+	return err
+}
+
+// FailUnfinishedRuns marks runs still queued or running as failed, e.g.
+// after a restart interrupted them, returning how many were marked
+func (r *BacktestRepository) FailUnfinishedRuns(reason string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT OR REPLACE INTO backtest_results (backtest_id, error)
+		SELECT id, ? FROM backtest_runs WHERE status IN ('queued', 'running')
+	`, reason); err != nil {
+		return 0, err
+	}
+	result, err := tx.Exec(`
+		UPDATE backtest_runs SET status = 'failed', completed_at = ?
+		WHERE status IN ('queued', 'running')
+	`, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SaveResult stores the full result of a run, or the error it failed with
+func (r *BacktestRepository) SaveResult(id int64, result json.RawMessage, errMsg string) error {
+	var stored sql.NullString
+	if len(result) > 0 {
+		stored = sql.NullString{String: string(result), Valid: true}
+	}
+	_, err := r.db.Exec(`
+		INSERT OR REPLACE INTO backtest_results (backtest_id, result, error)
+		VALUES (?, ?, ?)
+	`, id, stored, errMsg)
+	return err
+}
+
+// GetResult retrieves the full result of a run and the error it failed
+// with; both are empty while the run is unfinished
+func (r *BacktestRepository) GetResult(id int64) (json.RawMessage, string, error) {
+	var result, errMsg sql.NullString
+	err := r.db.QueryRow(`SELECT result, error FROM backtest_results WHERE backtest_id = ?`, id).Scan(&result, &errMsg)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	var raw json.RawMessage
+	if result.Valid {
+		raw = json.RawMessage(result.String)
+	}
+	return raw, errMsg.String, nil
+}
+
 // GetRun retrieves a backtest run by ID
 func (r *BacktestRepository) GetRun(id int64) (*BacktestRun, error) {
 	query := `
@@ -809,6 +1198,11 @@ func (r *BacktestRepository) DeleteRun(id int64) error {
 		return fmt.Errorf("failed to delete trades: %w", err)
 	}
 
+	// Delete result
+	if _, err := tx.Exec("DELETE FROM backtest_results WHERE backtest_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete result: %w", err)
+	}
+
 	// Delete run
 	if _, err := tx.Exec("DELETE FROM backtest_runs WHERE id = ?", id); err != nil {
 		return fmt.Errorf("failed to delete run: %w", err)
@@ -816,3 +1210,750 @@ func (r *BacktestRepository) DeleteRun(id int64) error {
 
 	return tx.Commit()
 }
+
+// SettingsHistoryRepository handles the settings change audit trail
+type SettingsHistoryRepository struct {
+	db *SQLiteDB
+}
+
+// NewSettingsHistoryRepository creates a new settings history repository
+func NewSettingsHistoryRepository(db *SQLiteDB) *SettingsHistoryRepository {
+	return &SettingsHistoryRepository{db: db}
+}
+
+// SettingsChange represents a single recorded settings change
+type SettingsChange struct {
+	ID         int64           `json:"id"`
+	Section    string          `json:"section"`
+	Action     string          `json:"action"` // update, reset, rollback
+	OldValue   json.RawMessage `json:"old_value,omitempty"`
+	NewValue   json.RawMessage `json:"new_value"`
+	ChangedBy  string          `json:"changed_by"`
+	RollbackOf *int64          `json:"rollback_of,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Insert records a settings change and returns its version ID
+func (r *SettingsHistoryRepository) Insert(change SettingsChange) (int64, error) {
+	query := `
+		INSERT INTO settings_history (section, action, old_value, new_value, changed_by, rollback_of)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	var oldValue interface{}
+	if len(change.OldValue) > 0 {
+		oldValue = string(change.OldValue)
+	}
+	result, err := r.db.Exec(query,
+		change.Section, change.Action, oldValue, string(change.NewValue),
+		change.ChangedBy, change.RollbackOf,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// InsertApplied stores value under the config key and records change in
+// one transaction, so a saved setting always has its history entry
+func (r *SettingsHistoryRepository) InsertApplied(key, value string, change SettingsChange) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO config (key, value, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP
+	`, key, value); err != nil {
+		return 0, err
+	}
+
+	var oldValue interface{}
+	if len(change.OldValue) > 0 {
+		oldValue = string(change.OldValue)
+	}
+	result, err := tx.Exec(`
+		INSERT INTO settings_history (section, action, old_value, new_value, changed_by, rollback_of)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, change.Section, change.Action, oldValue, string(change.NewValue),
+		change.ChangedBy, change.RollbackOf,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// GetByID retrieves a settings change by version ID
+func (r *SettingsHistoryRepository) GetByID(id int64) (*SettingsChange, error) {
+	query := `
+		SELECT id, section, action, old_value, new_value, changed_by, rollback_of, created_at
+		FROM settings_history
+		WHERE id = ?
+	`
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes, err := scanSettingsChanges(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return &changes[0], nil
+}
+
+// GetRecent retrieves recent settings changes, optionally filtered by section
+func (r *SettingsHistoryRepository) GetRecent(section string, limit int) ([]SettingsChange, error) {
+	query := `
+		SELECT id, section, action, old_value, new_value, changed_by, rollback_of, created_at
+		FROM settings_history
+		WHERE (? = '' OR section = ?)
+		ORDER BY id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, section, section, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSettingsChanges(rows)
+}
+
+func scanSettingsChanges(rows *sql.Rows) ([]SettingsChange, error) {
+	var changes []SettingsChange
+	for rows.Next() {
+		var ch SettingsChange
+		var oldValue, changedBy sql.NullString
+		var newValue string
+		var rollbackOf sql.NullInt64
+		err := rows.Scan(&ch.ID, &ch.Section, &ch.Action, &oldValue, &newValue, &changedBy, &rollbackOf, &ch.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if oldValue.Valid {
+			ch.OldValue = json.RawMessage(oldValue.String)
+		}
+		ch.NewValue = json.RawMessage(newValue)
+		if changedBy.Valid {
+			ch.ChangedBy = changedBy.String
+		}
+		if rollbackOf.Valid {
+			ch.RollbackOf = &rollbackOf.Int64
+		}
+		changes = append(changes, ch)
+	}
+	return changes, rows.Err()
+}
+
+// Order intent states
+const (
+	IntentApproved  = "approved"  // Risk approved, not yet sent to the executor
+	IntentSubmitted = "submitted" // Sent to the executor, outcome unknown
+	IntentFilled    = "filled"    // Entry filled, stops not yet placed
+	IntentProtected = "protected" // Entry filled and stops in place (terminal)
+	IntentFailed    = "failed"    // Rejected or failed (terminal)
+	IntentCanceled  = "canceled"  // Abandoned, e.g. stale after a restart (terminal)
+)
+
+// OrderIntent is a durable record of an entry order moving through execution
+type OrderIntent struct {
+	ID             int64     `json:"id"`
+	ClientOrderID  string    `json:"clientOrderId"`
+	Symbol         string    `json:"symbol"`
+	Side           string    `json:"side"`
+	Quantity       float64   `json:"quantity"`
+	Strategy       string    `json:"strategy"`
+	SignalPrice    float64   `json:"signalPrice"`
+	StopLoss       float64   `json:"stopLoss"`
+	TakeProfit     float64   `json:"takeProfit"`
+	Mode           string    `json:"mode,omitempty"` // Trading mode of the executor it was sent to
+	State          string    `json:"state"`
+	OrderID        string    `json:"orderId,omitempty"`
+	PositionID     int64     `json:"positionId,omitempty"`
+	FillPrice      float64   `json:"fillPrice,omitempty"`
+	FilledQuantity float64   `json:"filledQuantity,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// OrderIntentRepository handles order intent persistence
+type OrderIntentRepository struct {
+	db *SQLiteDB
+}
+
+// NewOrderIntentRepository creates a new order intent repository
+func NewOrderIntentRepository(db *SQLiteDB) *OrderIntentRepository {
+	return &OrderIntentRepository{db: db}
+}
+
+// Insert records a new intent and returns its ID
+func (r *OrderIntentRepository) Insert(intent OrderIntent) (int64, error) {
+	query := `
+		INSERT INTO order_intents (client_order_id, symbol, side, quantity, strategy,
+			signal_price, stop_loss, take_profit, mode, state)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.Exec(query,
+		intent.ClientOrderID, intent.Symbol, intent.Side, intent.Quantity, intent.Strategy,
+		intent.SignalPrice, intent.StopLoss, intent.TakeProfit, intent.Mode, intent.State,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Update saves an intent's state and execution details
+func (r *OrderIntentRepository) Update(intent OrderIntent) error {
+	query := `
+		UPDATE order_intents SET
+			state = ?, order_id = ?, position_id = ?, fill_price = ?,
+			filled_quantity = ?, error = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query,
+		intent.State, intent.OrderID, intent.PositionID, intent.FillPrice,
+		intent.FilledQuantity, intent.Error, intent.ID,
+	)
+	return err
+}
+
+// GetPending retrieves intents not yet in a terminal state, oldest first
+func (r *OrderIntentRepository) GetPending() ([]OrderIntent, error) {
+	query := `
+		SELECT id, client_order_id, symbol, side, quantity, strategy, signal_price,
+			stop_loss, take_profit, mode, state, order_id, position_id, fill_price,
+			filled_quantity, error, created_at, updated_at
+		FROM order_intents
+		WHERE state IN (?, ?, ?)
+		ORDER BY id ASC
+	`
+	rows, err := r.db.Query(query, IntentApproved, IntentSubmitted, IntentFilled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOrderIntents(rows)
+}
+
+// GetRecent retrieves the most recent intents
+func (r *OrderIntentRepository) GetRecent(limit int) ([]OrderIntent, error) {
+	query := `
+		SELECT id, client_order_id, symbol, side, quantity, strategy, signal_price,
+			stop_loss, take_profit, mode, state, order_id, position_id, fill_price,
+			filled_quantity, error, created_at, updated_at
+		FROM order_intents
+		ORDER BY id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOrderIntents(rows)
+}
+
+func scanOrderIntents(rows *sql.Rows) ([]OrderIntent, error) {
+	var intents []OrderIntent
+	for rows.Next() {
+		var in OrderIntent
+		var strategy, mode, orderID, errMsg sql.NullString
+		var positionID sql.NullInt64
+		err := rows.Scan(&in.ID, &in.ClientOrderID, &in.Symbol, &in.Side, &in.Quantity, &strategy,
+			&in.SignalPrice, &in.StopLoss, &in.TakeProfit, &mode, &in.State, &orderID, &positionID,
+			&in.FillPrice, &in.FilledQuantity, &errMsg, &in.CreatedAt, &in.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		in.Strategy = strategy.String
+		in.Mode = mode.String
+		in.OrderID = orderID.String
+		in.PositionID = positionID.Int64
+		in.Error = errMsg.String
+		intents = append(intents, in)
+	}
+	return intents, rows.Err()
+}
+
+// IndicatorColumn is one precomputed indicator series
+type IndicatorColumn struct {
+	Name   string      `json:"name"`
+	Times  []time.Time `json:"times"` // Candle open times
+	Values []float64   `json:"values"`
+}
+
+// IndicatorCoverage describes what is stored for one indicator series
+type IndicatorCoverage struct {
+	Name  string    `json:"name"`
+	Count int64     `json:"count"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+}
+
+// IndicatorRepository handles precomputed indicator series
+type IndicatorRepository struct {
+	db *SQLiteDB
+}
+
+// NewIndicatorRepository creates a new indicator repository
+func NewIndicatorRepository(db *SQLiteDB) *IndicatorRepository {
+	return &IndicatorRepository{db: db}
+}
+
+// UpsertColumn writes a series' values (upsert) in one transaction
+func (r *IndicatorRepository) UpsertColumn(symbol, timeframe string, column IndicatorColumn) error {
+	if len(column.Times) != len(column.Values) {
+		return fmt.Errorf("series %s has %d times for %d values", column.Name, len(column.Times), len(column.Values))
+	}
+	if len(column.Values) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO indicator_values (symbol, timeframe, name, open_time, value)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(symbol, timeframe, name, open_time) DO UPDATE SET
+			value = excluded.value
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, value := range column.Values {
+		if _, err := stmt.Exec(symbol, timeframe, column.Name, column.Times[i], value); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetColumn retrieves a series within a time range
+func (r *IndicatorRepository) GetColumn(symbol, timeframe, name string, from, to time.Time) (*IndicatorColumn, error) {
+	query := `
+		SELECT open_time, value
+		FROM indicator_values
+		WHERE symbol = ? AND timeframe = ? AND name = ? AND open_time >= ? AND open_time <= ?
+		ORDER BY open_time ASC
+	`
+	rows, err := r.db.Query(query, symbol, timeframe, name, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	column := &IndicatorColumn{Name: name, Times: []time.Time{}, Values: []float64{}}
+	for rows.Next() {
+		var t time.Time
+		var v float64
+		if err := rows.Scan(&t, &v); err != nil {
+			return nil, err
+		}
+		column.Times = append(column.Times, t)
+		column.Values = append(column.Values, v)
+	}
+	return column, rows.Err()
+}
+
+// GetLatestTime returns the open time of a series' newest value, or the
+// zero time when nothing is stored
+func (r *IndicatorRepository) GetLatestTime(symbol, timeframe, name string) (time.Time, error) {
+	return r.boundTime(symbol, timeframe, name, "DESC")
+}
+
+// boundTime returns a series' first or last open time. Selected directly
+// rather than via MIN/MAX, which lose the column's DATETIME type.
+func (r *IndicatorRepository) boundTime(symbol, timeframe, name, order string) (time.Time, error) {
+	var t time.Time
+	err := r.db.QueryRow(
+		"SELECT open_time FROM indicator_values WHERE symbol = ? AND timeframe = ? AND name = ? ORDER BY open_time "+order+" LIMIT 1",
+		symbol, timeframe, name,
+	).Scan(&t)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return t, err
+}
+
+// GetCoverage lists the stored series for a symbol and timeframe
+func (r *IndicatorRepository) GetCoverage(symbol, timeframe string) ([]IndicatorCoverage, error) {
+	query := `
+		SELECT name, COUNT(*)
+		FROM indicator_values
+		WHERE symbol = ? AND timeframe = ?
+		GROUP BY name
+		ORDER BY name ASC
+	`
+	rows, err := r.db.Query(query, symbol, timeframe)
+	if err != nil {
+		return nil, err
+	}
+
+	var coverage []IndicatorCoverage
+	for rows.Next() {
+		var c IndicatorCoverage
+		if err := rows.Scan(&c.Name, &c.Count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		coverage = append(coverage, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range coverage {
+		if coverage[i].From, err = r.boundTime(symbol, timeframe, coverage[i].Name, "ASC"); err != nil {
+			return nil, err
+		}
+		if coverage[i].To, err = r.boundTime(symbol, timeframe, coverage[i].Name, "DESC"); err != nil {
+			return nil, err
+		}
+	}
+	return coverage, nil
+}
+
+// BackupRecord is one database backup attempt
+type BackupRecord struct {
+	ID          int64     `json:"id"`
+	Destination string    `json:"destination"` // file or download
+	Path        string    `json:"path,omitempty"`
+	SizeBytes   int64     `json:"size_bytes"`
+	DurationMs  int64     `json:"duration_ms"`
+	Status      string    `json:"status"` // ok or failed
+	Error       string    `json:"error,omitempty"`
+	RequestedBy string    `json:"requested_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BackupRepository handles the database backup history
+type BackupRepository struct {
+	db *SQLiteDB
+}
+
+// NewBackupRepository creates a new backup repository
+func NewBackupRepository(db *SQLiteDB) *BackupRepository {
+	return &BackupRepository{db: db}
+}
+
+// Insert records a backup attempt
+func (r *BackupRepository) Insert(record BackupRecord) (int64, error) {
+	query := `
+		INSERT INTO backups (destination, path, size_bytes, duration_ms, status, error, requested_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.Exec(query,
+		record.Destination, record.Path, record.SizeBytes, record.DurationMs,
+		record.Status, record.Error, record.RequestedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetRecent retrieves the most recent backup attempts
+func (r *BackupRepository) GetRecent(limit int) ([]BackupRecord, error) {
+	query := `
+		SELECT id, destination, path, size_bytes, duration_ms, status, error, requested_by, created_at
+		FROM backups
+		ORDER BY id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []BackupRecord
+	for rows.Next() {
+		var b BackupRecord
+		var path, errMsg, requestedBy sql.NullString
+		err := rows.Scan(&b.ID, &b.Destination, &path, &b.SizeBytes, &b.DurationMs, &b.Status, &errMsg, &requestedBy, &b.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		b.Path = path.String
+		b.Error = errMsg.String
+		b.RequestedBy = requestedBy.String
+		records = append(records, b)
+	}
+	return records, rows.Err()
+}
+
+// SharedBundle is a result bundle shared by another user and imported here
+type SharedBundle struct {
+	ID          string          `json:"id"` // Content hash of the bundle
+	Name        string          `json:"name"`
+	Symbol      string          `json:"symbol"`
+	Timeframe   string          `json:"timeframe"`
+	ConfigHash  string          `json:"config_hash"`
+	Trades      int             `json:"trades"`
+	ReturnPct   float64         `json:"return_pct"`
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+	Bundle      json.RawMessage `json:"bundle,omitempty"` // Only loaded by Get
+	ImportedBy  string          `json:"imported_by"`
+	ImportedAt  time.Time       `json:"imported_at"`
+}
+
+// SharedBundleRepository handles imported result bundles
+type SharedBundleRepository struct {
+	db *SQLiteDB
+}
+
+// NewSharedBundleRepository creates a new shared bundle repository
+func NewSharedBundleRepository(db *SQLiteDB) *SharedBundleRepository {
+	return &SharedBundleRepository{db: db}
+}
+
+// Save stores a bundle, replacing an earlier import of the same one
+func (r *SharedBundleRepository) Save(b SharedBundle) error {
+	_, err := r.db.Exec(`
+		INSERT OR REPLACE INTO shared_bundles
+			(id, name, symbol, timeframe, config_hash, trades, return_pct, period_start, period_end, bundle, imported_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, b.ID, b.Name, b.Symbol, b.Timeframe, b.ConfigHash, b.Trades, b.ReturnPct,
+		b.PeriodStart, b.PeriodEnd, string(b.Bundle), b.ImportedBy)
+	return err
+}
+
+// List retrieves imported bundles without their contents, newest first
+func (r *SharedBundleRepository) List() ([]SharedBundle, error) {
+	rows, err := r.db.Query(`
+		SELECT id, name, symbol, timeframe, config_hash, trades, return_pct, period_start, period_end, imported_by, imported_at
+		FROM shared_bundles
+		ORDER BY imported_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bundles []SharedBundle
+	for rows.Next() {
+		var b SharedBundle
+		var name, timeframe, configHash, importedBy sql.NullString
+		err := rows.Scan(&b.ID, &name, &b.Symbol, &timeframe, &configHash, &b.Trades, &b.ReturnPct,
+			&b.PeriodStart, &b.PeriodEnd, &importedBy, &b.ImportedAt)
+		if err != nil {
+			return nil, err
+		}
+		b.Name = name.String
+		b.Timeframe = timeframe.String
+		b.ConfigHash = configHash.String
+		b.ImportedBy = importedBy.String
+		bundles = append(bundles, b)
+	}
+	return bundles, rows.Err()
+}
+
+// Get retrieves an imported bundle with its contents, or nil if unknown
+func (r *SharedBundleRepository) Get(id string) (*SharedBundle, error) {
+	var b SharedBundle
+	var name, timeframe, configHash, importedBy sql.NullString
+	var bundle string
+	err := r.db.QueryRow(`
+		SELECT id, name, symbol, timeframe, config_hash, trades, return_pct, period_start, period_end, bundle, imported_by, imported_at
+		FROM shared_bundles
+		WHERE id = ?
+	`, id).Scan(&b.ID, &name, &b.Symbol, &timeframe, &configHash, &b.Trades, &b.ReturnPct,
+		&b.PeriodStart, &b.PeriodEnd, &bundle, &importedBy, &b.ImportedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.Name = name.String
+	b.Timeframe = timeframe.String
+	b.ConfigHash = configHash.String
+	b.ImportedBy = importedBy.String
+	b.Bundle = json.RawMessage(bundle)
+	return &b, nil
+}
+
+// Delete removes an imported bundle, reporting whether it existed
+func (r *SharedBundleRepository) Delete(id string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM shared_bundles WHERE id = ?`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// Audit log categories
+const (
+	AuditOrder    = "order"    // Orders placed or canceled and positions closed
+	AuditConfig   = "config"   // Settings, risk limits, strategy parameters and trading mode
+	AuditStrategy = "strategy" // Strategies enabled or disabled
+	AuditRisk     = "risk"     // Halts, resumes, circuit breaker resets, arming and high-water mark changes
+)
+
+// AuditActorSystem is the actor of actions the bot takes on its own
+const AuditActorSystem = "system"
+
+// AuditLogRepository handles the audit trail of order, configuration,
+// strategy and risk actions
+type AuditLogRepository struct {
+	db *SQLiteDB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *SQLiteDB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// AuditEntry is one recorded action
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	Category  string          `json:"category"`
+	Action    string          `json:"action"`              // e.g. order_place, strategy_enable, circuit_breaker_reset
+	Actor     string          `json:"actor"`               // User email or ID; "system" for actions the bot takes on its own
+	Resource  string          `json:"resource,omitempty"`  // What was acted on, e.g. an order ID, strategy or settings section
+	AccountID string          `json:"accountId,omitempty"` // Trading account of per-account orders
+	OldValue  json.RawMessage `json:"oldValue,omitempty"`
+	NewValue  json.RawMessage `json:"newValue,omitempty"`
+	Success   bool            `json:"success"`
+	Error     string          `json:"error,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// AuditFilter selects audit entries. Empty fields match any value.
+type AuditFilter struct {
+	Category  string
+	Action    string
+	Actor     string
+	Resource  string
+	AccountID string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// Insert records an action and returns its ID
+func (r *AuditLogRepository) Insert(entry AuditEntry) (int64, error) {
+	query := `
+		INSERT INTO audit_log (category, action, actor, resource, account_id, old_value, new_value, success, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	result, err := r.db.Exec(query,
+		entry.Category, entry.Action, entry.Actor, nullString(entry.Resource), nullString(entry.AccountID),
+		nullJSON(entry.OldValue), nullJSON(entry.NewValue), entry.Success, nullString(entry.Error),
+		entry.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Query retrieves entries matching filter, newest first
+func (r *AuditLogRepository) Query(filter AuditFilter) ([]AuditEntry, error) {
+	var where []string
+	var args []interface{}
+	for _, f := range []struct {
+		column, value string
+	}{
+		{"category", filter.Category},
+		{"action", filter.Action},
+		{"actor", filter.Actor},
+		{"resource", filter.Resource},
+		{"account_id", filter.AccountID},
+	} {
+		if f.value != "" {
+			where = append(where, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "created_at <= ?")
+		args = append(args, filter.To.UTC())
+	}
+
+	query := `
+		SELECT id, category, action, actor, resource, account_id, old_value, new_value, success, error, created_at
+		FROM audit_log
+	`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		var resource, accountID, oldValue, newValue, errMsg sql.NullString
+		err := rows.Scan(&e.ID, &e.Category, &e.Action, &e.Actor, &resource, &accountID,
+			&oldValue, &newValue, &e.Success, &errMsg, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		e.Resource = resource.String
+		e.AccountID = accountID.String
+		if oldValue.Valid {
+			e.OldValue = json.RawMessage(oldValue.String)
+		}
+		if newValue.Valid {
+			e.NewValue = json.RawMessage(newValue.String)
+		}
+		e.Error = errMsg.String
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullJSON stores an empty JSON value as NULL
+func nullJSON(v json.RawMessage) interface{} {
+	if len(v) == 0 {
+		return nil
+	}
+	return string(v)
+}
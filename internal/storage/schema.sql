@@ -14,6 +14,11 @@ CREATE TABLE IF NOT EXISTS users (
     password_reset_token VARCHAR(255),
     password_reset_expires TIMESTAMP,
     last_login_at TIMESTAMP,
+    totp_secret TEXT, -- Encrypted with AES-256-GCM
+    totp_enabled BOOLEAN NOT NULL DEFAULT false,
+    totp_last_step BIGINT NOT NULL DEFAULT 0, -- Time step of the last accepted code
+    totp_failures INTEGER NOT NULL DEFAULT 0, -- Wrong codes since the last accepted one or lockout
+    totp_locked_until TIMESTAMP, -- Codes are refused until then after too many wrong ones
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
@@ -31,7 +36,7 @@ CREATE TABLE IF NOT EXISTS trading_accounts (
 
     -- Live account fields (Binance)
     binance_api_key VARCHAR(255),
-    binance_secret_key_encrypted TEXT, -- Encrypted with AES-256
+    binance_secret_key_encrypted TEXT, -- Encrypted with AES-256-GCM
     binance_testnet BOOLEAN DEFAULT false,
 
     -- Trading configuration
@@ -39,6 +44,10 @@ CREATE TABLE IF NOT EXISTS trading_accounts (
     trading_mode VARCHAR(20) NOT NULL DEFAULT 'paper', -- paper, live
     enabled_strategies TEXT[], -- Array of enabled strategy names
 
+    -- Isolated executor
+    risk_config JSONB NOT NULL DEFAULT '{}', -- Risk limits overriding the bot's
+    executor_enabled BOOLEAN NOT NULL DEFAULT false, -- Run the account's executor with the bot
+
     is_active BOOLEAN NOT NULL DEFAULT true,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
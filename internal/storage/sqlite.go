@@ -1,11 +1,12 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog/log"
 )
 
@@ -134,6 +135,19 @@ func (s *SQLiteDB) migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_positions_strategy
 		 ON positions(strategy, status)`,
 
+		// P&L breakdown per position (fees, funding, slippage)
+		`CREATE TABLE IF NOT EXISTS position_pnl (
+			position_id INTEGER PRIMARY KEY REFERENCES positions(id),
+			gross_pnl REAL DEFAULT 0,
+			entry_commission REAL DEFAULT 0,
+			exit_commission REAL DEFAULT 0,
+			funding_cost REAL DEFAULT 0,
+			entry_slippage REAL DEFAULT 0,
+			exit_slippage REAL DEFAULT 0,
+			net_pnl REAL DEFAULT 0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+
 		// Orders table
 		`CREATE TABLE IF NOT EXISTS orders (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -157,6 +171,17 @@ func (s *SQLiteDB) migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_orders_symbol_status
 		 ON orders(symbol, status)`,
 
+		// Order status history, one row per lifecycle transition
+		`CREATE TABLE IF NOT EXISTS order_transitions (
+			order_id TEXT NOT NULL,
+			seq INTEGER NOT NULL,
+			from_status TEXT,
+			to_status TEXT NOT NULL,
+			reason TEXT,
+			at DATETIME NOT NULL,
+			PRIMARY KEY (order_id, seq)
+		)`,
+
 		// Account snapshots for equity tracking
 		`CREATE TABLE IF NOT EXISTS account_snapshots (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -283,6 +308,116 @@ func (s *SQLiteDB) migrate() error {
 
 		`CREATE INDEX IF NOT EXISTS idx_backtest_equity_run
 		 ON backtest_equity(backtest_id, timestamp)`,
+
+		// Settings change audit trail
+		`CREATE TABLE IF NOT EXISTS settings_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			section TEXT NOT NULL,
+			action TEXT NOT NULL DEFAULT 'update',
+			old_value TEXT,
+			new_value TEXT NOT NULL,
+			changed_by TEXT,
+			rollback_of INTEGER,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_settings_history_section_time
+		 ON settings_history(section, created_at DESC)`,
+
+		// Durable order intents (approved -> submitted -> filled -> protected)
+		`CREATE TABLE IF NOT EXISTS order_intents (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			client_order_id TEXT NOT NULL UNIQUE,
+			symbol TEXT NOT NULL,
+			side TEXT NOT NULL,
+			quantity REAL NOT NULL,
+			strategy TEXT,
+			signal_price REAL DEFAULT 0,
+			stop_loss REAL DEFAULT 0,
+			take_profit REAL DEFAULT 0,
+			mode TEXT,
+			state TEXT NOT NULL,
+			order_id TEXT,
+			position_id INTEGER,
+			fill_price REAL DEFAULT 0,
+			filled_quantity REAL DEFAULT 0,
+			error TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_order_intents_state
+		 ON order_intents(state)`,
+
+		// Precomputed indicator series, clustered by column so reading one
+		// indicator over a range is a contiguous scan
+		`CREATE TABLE IF NOT EXISTS indicator_values (
+			symbol TEXT NOT NULL,
+			timeframe TEXT NOT NULL,
+			name TEXT NOT NULL,
+			open_time DATETIME NOT NULL,
+			value REAL NOT NULL,
+			PRIMARY KEY (symbol, timeframe, name, open_time)
+		) WITHOUT ROWID`,
+
+		// Database backup history
+		`CREATE TABLE IF NOT EXISTS backups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			destination TEXT NOT NULL,
+			path TEXT,
+			size_bytes INTEGER DEFAULT 0,
+			duration_ms INTEGER DEFAULT 0,
+			status TEXT NOT NULL,
+			error TEXT,
+			requested_by TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Full backtest results (report JSON) and failures, by run
+		`CREATE TABLE IF NOT EXISTS backtest_results (
+			backtest_id INTEGER PRIMARY KEY,
+			result TEXT,
+			error TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (backtest_id) REFERENCES backtest_runs(id)
+		)`,
+
+		// Results shared by other users, imported for viewing
+		`CREATE TABLE IF NOT EXISTS shared_bundles (
+			id TEXT PRIMARY KEY,
+			name TEXT,
+			symbol TEXT NOT NULL,
+			timeframe TEXT,
+			config_hash TEXT,
+			trades INTEGER DEFAULT 0,
+			return_pct REAL DEFAULT 0,
+			period_start DATETIME,
+			period_end DATETIME,
+			bundle TEXT NOT NULL,
+			imported_by TEXT,
+			imported_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Audit trail of order, configuration, strategy and risk actions
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			category TEXT NOT NULL,
+			action TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			resource TEXT,
+			account_id TEXT,
+			old_value TEXT,
+			new_value TEXT,
+			success BOOLEAN NOT NULL DEFAULT TRUE,
+			error TEXT,
+			created_at DATETIME NOT NULL
+		)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_time
+		 ON audit_log(created_at DESC)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_category_time
+		 ON audit_log(category, created_at DESC)`,
 	}
 
 	for _, migration := range migrations {
@@ -291,10 +426,31 @@ func (s *SQLiteDB) migrate() error {
 		}
 	}
 
+	// Columns added to tables that may predate them
+	columns := []struct{ table, column, definition string }{
+		{"order_intents", "mode", "TEXT"},
+	}
+	for _, c := range columns {
+		if err := s.addColumn(c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+
 	log.Debug().Msg("Database migrations completed")
 	return nil
 }
 
+// addColumn adds a column to a table unless it already has it
+func (s *SQLiteDB) addColumn(table, column, definition string) error {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
 // Exec executes a query without returning rows
 func (s *SQLiteDB) Exec(query string, args ...interface{}) (sql.Result, error) {
 	return s.db.Exec(query, args...)
@@ -327,6 +483,62 @@ func (s *SQLiteDB) Checkpoint() error {
 	return err
 }
 
+// Backup writes a consistent copy of the database to destPath with the
+// SQLite online backup API while it stays in use. Transactions committed to
+// the WAL but not yet checkpointed are included, and the copy is switched to
+// rollback journaling so it is a single self-contained file.
+func (s *SQLiteDB) Backup(ctx context.Context, destPath string) error {
+	dest, err := sql.Open("sqlite3", destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer dest.Close()
+
+	destConn, err := dest.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer destConn.Close()
+
+	srcConn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire database connection: %w", err)
+	}
+	defer srcConn.Close()
+
+	err = destConn.Raw(func(destDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			destSQLite, ok := destDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", destDriver)
+			}
+			srcSQLite, ok := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", srcDriver)
+			}
+
+			backup, err := destSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			// Copy every page in one step, so the copy is a single snapshot
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	if _, err := destConn.ExecContext(ctx, "PRAGMA journal_mode=DELETE"); err != nil {
+		return fmt.Errorf("failed to set backup journal mode: %w", err)
+	}
+	return nil
+}
+
 // GetConfig retrieves a config value
 func (s *SQLiteDB) GetConfig(key string) (string, error) {
 	var value string
@@ -354,6 +566,10 @@ func (s *SQLiteDB) Cleanup(candleRetentionDays, snapshotRetentionDays int) error
 		return fmt.Errorf("failed to cleanup candles: %w", err)
 	}
 
+	if _, err := s.db.Exec("DELETE FROM indicator_values WHERE open_time < ?", candleCutoff); err != nil {
+		return fmt.Errorf("failed to cleanup indicator values: %w", err)
+	}
+
 	// Clean old snapshots
 	snapshotCutoff := time.Now().AddDate(0, 0, -snapshotRetentionDays)
 	if _, err := s.db.Exec("DELETE FROM account_snapshots WHERE snapshot_time < ?", snapshotCutoff); err != nil {
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Trading data backends
+const (
+	StoreSQLite   = "sqlite"
+	StorePostgres = "postgres"
+)
+
+// CandleStore persists closed candles
+type CandleStore interface {
+	Insert(candle Candle) error
+	InsertBatch(candles []Candle) error
+	GetRange(symbol, timeframe string, from, to time.Time) ([]Candle, error)
+	GetLast(symbol, timeframe string, limit int) ([]Candle, error)
+	GetLatest(symbol, timeframe string) (*Candle, error)
+	Count(symbol, timeframe string) (int64, error)
+	DeleteOlderThan(cutoff time.Time) (int64, error)
+}
+
+// TradeStore persists executed trades, one per order
+type TradeStore interface {
+	Insert(trade Trade) error
+	Merge(trade Trade) error
+	InsertIfAbsent(trade Trade) (bool, error)
+	GetFirstTime(excludeStrategy string) (time.Time, error)
+	GetRecent(limit int) ([]Trade, error)
+	GetBySymbol(symbol string, limit int) ([]Trade, error)
+	GetByStrategy(strategy string, limit int) ([]Trade, error)
+	GetByDateRange(from, to time.Time) ([]Trade, error)
+}
+
+// PositionStore persists positions and their P&L breakdowns
+type PositionStore interface {
+	Insert(pos Position) (int64, error)
+	Update(pos Position) error
+	GetOpen() ([]Position, error)
+	GetByID(id int64) (*Position, error)
+	GetClosed(limit int) ([]Position, error)
+	RealizedPnLSince(since time.Time) (float64, error)
+	GetPnL(positionID int64) (*PositionPnL, error)
+	GetPnLs(positionIDs []int64) (map[int64]PositionPnL, error)
+	UpsertPnL(p PositionPnL) error
+}
+
+var (
+	_ CandleStore   = (*CandleRepository)(nil)
+	_ TradeStore    = (*TradeRepository)(nil)
+	_ PositionStore = (*PositionRepository)(nil)
+	_ CandleStore   = (*PostgresCandleRepository)(nil)
+	_ TradeStore    = (*PostgresTradeRepository)(nil)
+	_ PositionStore = (*PostgresPositionRepository)(nil)
+)
+
+// TradingStores are where the data service keeps candles, trades and
+// positions. Everything else stays in SQLite.
+type TradingStores struct {
+	Backend   string // StoreSQLite or StorePostgres
+	Candles   CandleStore
+	Trades    TradeStore
+	Positions PositionStore
+}
+
+// NewSQLiteStores keeps trading data in the SQLite database
+func NewSQLiteStores(db *SQLiteDB) TradingStores {
+	return TradingStores{
+		Backend:   StoreSQLite,
+		Candles:   NewCandleRepository(db),
+		Trades:    NewTradeRepository(db),
+		Positions: NewPositionRepository(db),
+	}
+}
+
+// NewPostgresStores keeps trading data in PostgreSQL, creating its tables
+// when missing
+func NewPostgresStores(db *sqlx.DB) (TradingStores, error) {
+	if err := MigratePostgresTradingData(db); err != nil {
+		return TradingStores{}, fmt.Errorf("create trading data tables: %w", err)
+	}
+	return TradingStores{
+		Backend:   StorePostgres,
+		Candles:   NewPostgresCandleRepository(db),
+		Trades:    NewPostgresTradeRepository(db),
+		Positions: NewPostgresPositionRepository(db),
+	}, nil
+}
@@ -57,6 +57,8 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 		SELECT id, email, password_hash, full_name, role,
 		       is_active, is_email_verified, email_verification_token,
 		       password_reset_token, password_reset_expires, last_login_at,
+		       totp_secret, totp_enabled, totp_last_step,
+		       totp_failures, totp_locked_until,
 		       created_at, updated_at
 		FROM users
 		WHERE id = $1
@@ -80,6 +82,8 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		SELECT id, email, password_hash, full_name, role,
 		       is_active, is_email_verified, email_verification_token,
 		       password_reset_token, password_reset_expires, last_login_at,
+		       totp_secret, totp_enabled, totp_last_step,
+		       totp_failures, totp_locked_until,
 		       created_at, updated_at
 		FROM users
 		WHERE email = $1
@@ -110,7 +114,10 @@ func (r *UserRepository) Update(user *models.User) error {
 		    email_verification_token = $8,
 		    password_reset_token = $9,
 		    password_reset_expires = $10,
-		    updated_at = $11
+		    totp_secret = $11,
+		    totp_enabled = $12,
+		    totp_last_step = $13,
+		    updated_at = $14
 		WHERE id = $1
 	`
 
@@ -128,6 +135,9 @@ func (r *UserRepository) Update(user *models.User) error {
 		user.EmailVerificationToken,
 		user.PasswordResetToken,
 		user.PasswordResetExpires,
+		user.TOTPSecret,
+		user.TOTPEnabled,
+		user.TOTPLastStep,
 		user.UpdatedAt,
 	)
 
@@ -163,6 +173,47 @@ func (r *UserRepository) UpdateLastLogin(userID uuid.UUID) error {
 	return nil
 }
 
+// UseTOTPStep records step as the user's last accepted two-factor code and
+// clears their failures, reporting false when a code of that step or a
+// later one was already accepted
+func (r *UserRepository) UseTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE users
+		SET totp_last_step = $2, totp_failures = 0, totp_locked_until = NULL, updated_at = $3
+		WHERE id = $1 AND totp_last_step < $2
+	`
+
+	result, err := r.db.Exec(query, userID, step, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("use totp step: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// RecordTOTPFailure counts a wrong two-factor code. The maxFailures-th
+// one locks codes out until lockedUntil and restarts the count. It
+// returns the lockout in force, nil if none.
+func (r *UserRepository) RecordTOTPFailure(userID uuid.UUID, maxFailures int, lockedUntil time.Time) (*time.Time, error) {
+	query := `
+		UPDATE users
+		SET totp_failures = CASE WHEN totp_failures + 1 >= $2 THEN 0 ELSE totp_failures + 1 END,
+		    totp_locked_until = CASE WHEN totp_failures + 1 >= $2 THEN $3 ELSE totp_locked_until END
+		WHERE id = $1
+		RETURNING totp_locked_until
+	`
+
+	var locked *time.Time
+	if err := r.db.Get(&locked, query, userID, maxFailures, lockedUntil); err != nil {
+		return nil, fmt.Errorf("record totp failure: %w", err)
+	}
+	return locked, nil
+}
+
 // EmailExists checks if an email is already registered
 func (r *UserRepository) EmailExists(email string) (bool, error) {
 	query := `
@@ -77,7 +77,7 @@ func NewBreakoutStrategy(config *BreakoutConfig) *BreakoutStrategy {
 
 // Analyze analyzes market data for breakout signals
 func (s *BreakoutStrategy) Analyze(data *MarketData) []Signal {
-	if !s.enabled || len(data.Closes) < s.minData {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
 		return nil
 	}
 
@@ -0,0 +1,105 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/eth-trading/internal/indicators"
+)
+
+// ConfirmationBars is how many candles of the confirmation timeframe the
+// indicator snapshot confirming an entry is computed over
+const ConfirmationBars = 200
+
+// ConfirmationCondition is checked on the indicator snapshot taken one
+// confirmation candle after an entry signal
+type ConfirmationCondition string
+
+const (
+	// ConfirmPrice requires the candle to close at or beyond the signal's
+	// price in its direction
+	ConfirmPrice ConfirmationCondition = "price"
+	// ConfirmRSI requires RSI on the signal's side of 50
+	ConfirmRSI ConfirmationCondition = "rsi"
+	// ConfirmMACD requires the MACD histogram on the signal's side of zero
+	ConfirmMACD ConfirmationCondition = "macd"
+	// ConfirmTrend requires the moving average trend not to oppose the
+	// signal
+	ConfirmTrend ConfirmationCondition = "trend"
+)
+
+// EntryConfirmation holds entry signals for one candle of Interval and
+// executes them only if the conditions still hold when it closes, to
+// avoid whipsaw entries
+type EntryConfirmation struct {
+	Interval   string                  `json:"interval"`   // Timeframe of the candle waited, e.g. "1m"; empty = off
+	Conditions []ConfirmationCondition `json:"conditions"` // All must hold; none = price
+	Strategies []string                `json:"strategies"` // Strategies confirmed; empty = all
+}
+
+// Enabled reports whether entries wait for confirmation
+func (c EntryConfirmation) Enabled() bool {
+	return c.Interval != ""
+}
+
+// Validate checks the conditions; the interval is checked against the
+// traded timeframes by the caller
+func (c EntryConfirmation) Validate() error {
+	for _, cond := range c.Conditions {
+		switch cond {
+		case ConfirmPrice, ConfirmRSI, ConfirmMACD, ConfirmTrend:
+		default:
+			return fmt.Errorf("unknown confirmation condition %q, want %q, %q, %q or %q",
+				cond, ConfirmPrice, ConfirmRSI, ConfirmMACD, ConfirmTrend)
+		}
+	}
+	return nil
+}
+
+// Applies reports whether a strategy's entries wait for confirmation
+func (c EntryConfirmation) Applies(strategyName string) bool {
+	if !c.Enabled() {
+		return false
+	}
+	if len(c.Strategies) == 0 {
+		return true
+	}
+	for _, name := range c.Strategies {
+		if CanonicalName(name) == CanonicalName(strategyName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Confirm checks a held entry against the confirmation candle's close and
+// the indicator snapshot taken when it closed. It returns "" when the
+// entry goes ahead, or else the condition that failed: "stop" when the
+// candle closed through the entry's stop.
+func (c EntryConfirmation) Confirm(signal Signal, close float64, analysis indicators.AnalysisResult) string {
+	long := signal.Direction == DirectionLong
+	if signal.StopLoss > 0 && ((long && close <= signal.StopLoss) || (!long && close >= signal.StopLoss)) {
+		return "stop"
+	}
+
+	conditions := c.Conditions
+	if len(conditions) == 0 {
+		conditions = []ConfirmationCondition{ConfirmPrice}
+	}
+	for _, cond := range conditions {
+		var held bool
+		switch cond {
+		case ConfirmPrice:
+			held = (long && close >= signal.Price) || (!long && close <= signal.Price)
+		case ConfirmRSI:
+			held = (long && analysis.RSI.Value > 50) || (!long && analysis.RSI.Value < 50)
+		case ConfirmMACD:
+			held = (long && analysis.MACD.Histogram > 0) || (!long && analysis.MACD.Histogram < 0)
+		case ConfirmTrend:
+			held = (long && analysis.MA.Trend != indicators.TrendDown) || (!long && analysis.MA.Trend != indicators.TrendUp)
+		}
+		if !held {
+			return string(cond)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package strategy
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// EvalPriority orders strategy evaluations waiting for a worker
+type EvalPriority int
+
+const (
+	// PriorityPrimary is the live primary symbol and timeframe, whose
+	// signals lead to orders
+	PriorityPrimary EvalPriority = iota
+	// PriorityBackground is everything else: backtests, optimizations and
+	// other symbols or timeframes
+	PriorityBackground
+)
+
+// evalPriorities lists the priorities from most to least urgent
+var evalPriorities = []EvalPriority{PriorityPrimary, PriorityBackground}
+
+func (p EvalPriority) String() string {
+	switch p {
+	case PriorityPrimary:
+		return "primary"
+	case PriorityBackground:
+		return "background"
+	default:
+		return "unknown"
+	}
+}
+
+// EvalPool bounds how many strategy evaluations run at once across every
+// scorer sharing it. A freed worker goes to the longest waiting primary
+// evaluation before any background one.
+type EvalPool struct {
+	workers int
+	busy    int
+	waiting [2][]chan struct{} // By priority, oldest first
+	stats   [2]evalQueueStats
+	mu      sync.Mutex
+}
+
+// evalQueueStats accumulates the queueing delay of one priority
+type evalQueueStats struct {
+	count int64
+	total time.Duration
+	max   time.Duration
+	last  time.Duration
+}
+
+// EvalQueueStats summarizes the queueing delay of one priority
+type EvalQueueStats struct {
+	Priority    string  `json:"priority"`
+	Waiting     int     `json:"waiting"`
+	Evaluations int64   `json:"evaluations"`
+	LastWaitMs  float64 `json:"lastWaitMs"`
+	AvgWaitMs   float64 `json:"avgWaitMs"`
+	MaxWaitMs   float64 `json:"maxWaitMs"`
+}
+
+// EvalPoolStats describes the pool's workers and queueing delays
+type EvalPoolStats struct {
+	Workers int              `json:"workers"`
+	Busy    int              `json:"busy"`
+	Queues  []EvalQueueStats `json:"queues"`
+}
+
+// NewEvalPool creates a pool running at most workers evaluations at once;
+// 0 uses the number of CPUs
+func NewEvalPool(workers int) *EvalPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &EvalPool{workers: workers}
+}
+
+// Run runs fn once a worker is free, recording how long it waited
+func (p *EvalPool) Run(priority EvalPriority, fn func()) {
+	if priority != PriorityPrimary {
+		priority = PriorityBackground
+	}
+
+	queued := time.Now()
+	p.acquire(priority)
+	defer p.release()
+	p.record(priority, time.Since(queued))
+
+	fn()
+}
+
+// acquire takes a worker, waiting behind evaluations of the same or a
+// higher priority
+func (p *EvalPool) acquire(priority EvalPriority) {
+	p.mu.Lock()
+	if p.busy < p.workers && !p.queuedAhead(priority) {
+		p.busy++
+		p.mu.Unlock()
+		return
+	}
+	ready := make(chan struct{})
+	p.waiting[priority] = append(p.waiting[priority], ready)
+	p.mu.Unlock()
+
+	// release hands its worker over without freeing it
+	<-ready
+}
+
+// queuedAhead reports whether an evaluation of the same or a higher
+// priority is waiting. Callers hold p.mu.
+func (p *EvalPool) queuedAhead(priority EvalPriority) bool {
+	for _, pr := range evalPriorities {
+		if len(p.waiting[pr]) > 0 {
+			return true
+		}
+		if pr == priority {
+			break
+		}
+	}
+	return false
+}
+
+// release hands the worker to the most urgent waiting evaluation, or frees it
+func (p *EvalPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pr := range evalPriorities {
+		if len(p.waiting[pr]) > 0 {
+			ready := p.waiting[pr][0]
+			p.waiting[pr] = p.waiting[pr][1:]
+			close(ready)
+			return
+		}
+	}
+	p.busy--
+}
+
+// record adds a queueing delay to the statistics of its priority
+func (p *EvalPool) record(priority EvalPriority, wait time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &p.stats[priority]
+	s.count++
+	s.total += wait
+	s.last = wait
+	if wait > s.max {
+		s.max = wait
+	}
+}
+
+// Stats returns the pool's workers and queueing delays by priority
+func (p *EvalPool) Stats() EvalPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := EvalPoolStats{Workers: p.workers, Busy: p.busy}
+	for _, pr := range evalPriorities {
+		s := p.stats[pr]
+		q := EvalQueueStats{
+			Priority:    pr.String(),
+			Waiting:     len(p.waiting[pr]),
+			Evaluations: s.count,
+			LastWaitMs:  durationMs(s.last),
+			MaxWaitMs:   durationMs(s.max),
+		}
+		if s.count > 0 {
+			q.AvgWaitMs = durationMs(s.total / time.Duration(s.count))
+		}
+		stats.Queues = append(stats.Queues, q)
+	}
+	return stats
+}
+
+// durationMs converts d to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
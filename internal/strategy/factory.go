@@ -0,0 +1,25 @@
+package strategy
+
+import "fmt"
+
+// NewFromConfig creates a strategy from its configuration, as returned by
+// GetConfig. Used to run independent copies of a strategy with changed
+// parameters, e.g. in the backtest optimizer.
+func NewFromConfig(config interface{}) (Strategy, error) {
+	switch c := config.(type) {
+	case *TrendFollowingConfig:
+		return NewTrendFollowingStrategy(c), nil
+	case *MeanReversionConfig:
+		return NewMeanReversionStrategy(c), nil
+	case *BreakoutConfig:
+		return NewBreakoutStrategy(c), nil
+	case *VolatilityConfig:
+		return NewVolatilityStrategy(c), nil
+	case *StatArbConfig:
+		return NewStatArbStrategy(c), nil
+	case *ScriptConfig:
+		return NewScriptStrategy(c)
+	default:
+		return nil, fmt.Errorf("unsupported strategy config %T", config)
+	}
+}
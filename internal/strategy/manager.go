@@ -66,6 +66,11 @@ type Manager struct {
 	lastRegime     RegimeResult
 	regimeHistory  *RegimeHistory
 
+	// Called when a strategy panics and is auto-disabled
+	onPanic PanicHandler
+	// Called when a strategy is added, removed, enabled or disabled
+	onChange ChangeHandler
+
 	mu sync.RWMutex
 }
 
@@ -188,6 +193,12 @@ func (a Action) String() string {
 
 // Analyze performs complete market analysis
 func (m *Manager) Analyze(symbol, timeframe string, opens, highs, lows, closes, volumes []float64, currentPrice float64) *AnalysisOutput {
+	return m.AnalyzeWithContext(symbol, timeframe, opens, highs, lows, closes, volumes, currentPrice, nil, 0)
+}
+
+// AnalyzeWithContext performs complete market analysis with higher timeframe
+// context, with VWAP anchored sessionBars from the end (0 for the first bar)
+func (m *Manager) AnalyzeWithContext(symbol, timeframe string, opens, highs, lows, closes, volumes []float64, currentPrice float64, higher map[string]*TimeframeData, sessionBars int) *AnalysisOutput {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -207,14 +218,16 @@ func (m *Manager) Analyze(symbol, timeframe string, opens, highs, lows, closes,
 		Closes:       closes,
 		Volumes:      volumes,
 		CurrentPrice: currentPrice,
+		HigherTimeframes: higher,
 	}
+	data.Session = SessionAt(data.Timestamp)
 
 	if data.CurrentPrice == 0 {
 		data.CurrentPrice = closes[len(closes)-1]
 	}
 
 	// Get indicator analysis
-	data.Analysis = m.indicators.Analyze(opens, highs, lows, closes, volumes)
+	data.Analysis = m.indicators.AnalyzeSession(opens, highs, lows, closes, volumes, sessionBars)
 
 	// Detect regime
 	regime := m.regimeDetector.Detect(opens, highs, lows, closes, volumes)
@@ -300,7 +313,7 @@ func (m *Manager) AnalyzePosition(position *Position, data *MarketData) (bool, s
 			continue
 		}
 
-		shouldExit, reason := strategy.ShouldExit(data, position)
+		shouldExit, reason := safeShouldExit(strategy, data, position, m.onPanic)
 		if shouldExit {
 			return true, reason
 		}
@@ -335,26 +348,99 @@ func (m *Manager) GetStrategies() map[string]Strategy {
 	return result
 }
 
-// EnableStrategy enables a strategy
-func (m *Manager) EnableStrategy(name string) {
+// EnableStrategy enables a strategy, reporting whether it exists. Waits
+// for a running analysis, so the change applies from the next one.
+func (m *Manager) EnableStrategy(name string) bool {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if s, ok := m.strategies[name]; ok {
+	s, ok := m.strategies[name]
+	if ok {
 		s.SetEnabled(true)
 		log.Info().Str("strategy", name).Msg("Strategy enabled")
 	}
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if ok && onChange != nil {
+		onChange(name, true)
+	}
+	return ok
 }
 
-// DisableStrategy disables a strategy
-func (m *Manager) DisableStrategy(name string) {
+// DisableStrategy disables a strategy, reporting whether it exists. Waits
+// for a running analysis, so the change applies from the next one.
+func (m *Manager) DisableStrategy(name string) bool {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if s, ok := m.strategies[name]; ok {
+	s, ok := m.strategies[name]
+	if ok {
 		s.SetEnabled(false)
 		log.Info().Str("strategy", name).Msg("Strategy disabled")
 	}
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if ok && onChange != nil {
+		onChange(name, false)
+	}
+	return ok
+}
+
+// SetEvalPool evaluates the strategies on pool's workers at the given
+// priority
+func (m *Manager) SetEvalPool(pool *EvalPool, priority EvalPriority) {
+	m.scorer.SetEvalPool(pool, priority)
+}
+
+// AddStrategy adds a strategy, replacing one with the same name. A
+// replaced strategy keeps its enabled state.
+func (m *Manager) AddStrategy(s Strategy) {
+	m.mu.Lock()
+	if old, ok := m.strategies[s.Name()]; ok {
+		s.SetEnabled(old.IsEnabled())
+	}
+	m.strategies[s.Name()] = s
+	m.scorer.AddStrategy(s)
+	log.Info().Str("strategy", s.Name()).Msg("Strategy added")
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if onChange != nil {
+		onChange(s.Name(), s.IsEnabled())
+	}
+}
+
+// RemoveStrategy removes a strategy
+func (m *Manager) RemoveStrategy(name string) {
+	m.mu.Lock()
+	if _, ok := m.strategies[name]; !ok {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.strategies, name)
+	m.scorer.RemoveStrategy(name)
+	log.Info().Str("strategy", name).Msg("Strategy removed")
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if onChange != nil {
+		onChange(name, false)
+	}
+}
+
+// SetOnStrategyPanic sets the handler called when a strategy panics.
+// The offending strategy is disabled before the handler runs.
+func (m *Manager) SetOnStrategyPanic(handler PanicHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPanic = handler
+	m.scorer.SetOnPanic(handler)
+}
+
+// SetOnStrategyChange sets the handler called after a strategy is added,
+// removed, enabled or disabled. It runs outside the manager's lock.
+func (m *Manager) SetOnStrategyChange(handler ChangeHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = handler
 }
 
 // GetIndicators returns indicator manager
@@ -377,6 +463,10 @@ func (m *Manager) GetRegimeHistory() *RegimeHistory {
 	return m.regimeHistory
 }
 
+// ChangeHandler is called after a strategy changes. active reports whether
+// the strategy now exists and is enabled.
+type ChangeHandler func(name string, active bool)
+
 // StrategyStatus holds strategy status information
 type StrategyStatus struct {
 	Name       string
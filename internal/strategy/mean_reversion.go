@@ -71,7 +71,7 @@ func NewMeanReversionStrategy(config *MeanReversionConfig) *MeanReversionStrateg
 
 // Analyze analyzes market data for mean reversion signals
 func (s *MeanReversionStrategy) Analyze(data *MarketData) []Signal {
-	if !s.enabled || len(data.Closes) < s.minData {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
 		return nil
 	}
 
@@ -0,0 +1,64 @@
+package strategy
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PanicEvent describes a panic recovered from a strategy
+type PanicEvent struct {
+	Strategy string
+	Stage    string // "analyze" or "shouldExit"
+	Value    string
+	Stack    string
+	Time     time.Time
+}
+
+// PanicHandler is called after a strategy panic has been recovered and the
+// strategy has been disabled
+type PanicHandler func(event PanicEvent)
+
+// recoverStrategyPanic recovers a panic raised by a strategy, disables the
+// strategy and reports it. Must be deferred directly.
+func recoverStrategyPanic(s Strategy, stage string, handler PanicHandler) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	event := PanicEvent{
+		Strategy: s.Name(),
+		Stage:    stage,
+		Value:    fmt.Sprint(r),
+		Stack:    string(debug.Stack()),
+		Time:     time.Now(),
+	}
+
+	s.SetEnabled(false)
+
+	log.Error().
+		Str("strategy", event.Strategy).
+		Str("stage", stage).
+		Str("panic", event.Value).
+		Str("stack", event.Stack).
+		Msg("Strategy panicked and was disabled")
+
+	if handler != nil {
+		handler(event)
+	}
+}
+
+// safeAnalyze runs strategy analysis, converting a panic into no signals
+func safeAnalyze(s Strategy, data *MarketData, handler PanicHandler) (signals []Signal) {
+	defer recoverStrategyPanic(s, "analyze", handler)
+	return s.Analyze(data)
+}
+
+// safeShouldExit runs a strategy exit check, converting a panic into no exit
+func safeShouldExit(s Strategy, data *MarketData, position *Position, handler PanicHandler) (exit bool, reason string) {
+	defer recoverStrategyPanic(s, "shouldExit", handler)
+	return s.ShouldExit(data, position)
+}
@@ -151,14 +151,26 @@ func NewRegimeDetector(config *RegimeConfig, indicatorManager *indicators.Manage
 	}
 }
 
+// Clone returns a detector with the same settings and no regime history,
+// e.g. to classify past bars without disturbing live persistence
+func (rd *RegimeDetector) Clone() *RegimeDetector {
+	return NewRegimeDetector(rd.config, rd.indicators)
+}
+
 // Detect detects the current market regime
 func (rd *RegimeDetector) Detect(opens, highs, lows, closes, volumes []float64) RegimeResult {
-	rd.mu.Lock()
-	defer rd.mu.Unlock()
-
 	// Get indicator analysis
 	analysis := rd.indicators.Analyze(opens, highs, lows, closes, volumes)
 
+	return rd.DetectAnalyzed(analysis, closes)
+}
+
+// DetectAnalyzed detects the current market regime from an analysis
+// already made of the candles closing at closes
+func (rd *RegimeDetector) DetectAnalyzed(analysis indicators.AnalysisResult, closes []float64) RegimeResult {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
 	// Build details
 	details := RegimeDetails{
 		ADX:         analysis.ADX.ADX,
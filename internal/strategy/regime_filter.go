@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MarketRegimes lists every regime, unknown last
+var MarketRegimes = []MarketRegime{
+	RegimeTrending,
+	RegimeMeanReverting,
+	RegimeBreakout,
+	RegimeHighVolatility,
+	RegimeConsolidating,
+	RegimeUnknown,
+}
+
+// ParseMarketRegime parses a regime name such as "TRENDING" or "high_volatility"
+func ParseMarketRegime(name string) (MarketRegime, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	for _, r := range MarketRegimes {
+		if r.String() == normalized {
+			return r, nil
+		}
+	}
+	return RegimeUnknown, fmt.Errorf("unknown market regime %q", name)
+}
+
+// StrategyEligibility describes whether a strategy takes part in scoring
+// under a given regime
+type StrategyEligibility struct {
+	Strategy          string   `json:"strategy"`
+	Enabled           bool     `json:"enabled"`
+	Weight            float64  `json:"weight"` // Regime-adjusted weight
+	DisallowedRegimes []string `json:"disallowedRegimes"`
+	Blocked           bool     `json:"blocked"`   // Regime is disallowed for this strategy
+	RoutedOut         bool     `json:"routedOut"` // Regime is routed to other strategies
+	Eligible          bool     `json:"eligible"`
+}
+
+// SetDisallowedRegimes sets the regimes in which each strategy is skipped
+func (s *Scorer) SetDisallowedRegimes(disallowed map[string][]MarketRegime) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.DisallowedRegimes = disallowed
+}
+
+// GetDisallowedRegimes returns a copy of the per-strategy regime blacklist
+func (s *Scorer) GetDisallowedRegimes() map[string][]MarketRegime {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string][]MarketRegime, len(s.config.DisallowedRegimes))
+	for name, regimes := range s.config.DisallowedRegimes {
+		result[name] = append([]MarketRegime(nil), regimes...)
+	}
+	return result
+}
+
+// isRegimeDisallowed reports whether a strategy is blacklisted in regime
+// (s.mu must be held)
+func (s *Scorer) isRegimeDisallowed(name string, regime MarketRegime) bool {
+	for _, r := range s.config.DisallowedRegimes[name] {
+		if r == regime {
+			return true
+		}
+	}
+	return false
+}
+
+// Preview reports which strategies would be scored under regime
+func (s *Scorer) Preview(regime MarketRegime) []StrategyEligibility {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	preview := make([]StrategyEligibility, 0, len(s.strategies))
+	for name, strategy := range s.strategies {
+		e := StrategyEligibility{
+			Strategy:          name,
+			Enabled:           strategy.IsEnabled(),
+			Weight:            s.getWeight(name, regime),
+			DisallowedRegimes: []string{},
+			Blocked:           s.isRegimeDisallowed(name, regime),
+			RoutedOut:         s.isRoutedOut(name, regime),
+		}
+		for _, r := range s.config.DisallowedRegimes[name] {
+			e.DisallowedRegimes = append(e.DisallowedRegimes, r.String())
+		}
+		e.Eligible = e.Enabled && !e.Blocked && !e.RoutedOut
+		preview = append(preview, e)
+	}
+
+	sort.Slice(preview, func(i, j int) bool {
+		return preview[i].Strategy < preview[j].Strategy
+	})
+	return preview
+}
@@ -0,0 +1,70 @@
+package strategy
+
+import "sort"
+
+// DefaultRegimeRoutes routes trending and breakout regimes to the
+// trend-following and breakout strategies, and ranging regimes to the
+// mean-reversion ones. Other regimes trade every strategy.
+func DefaultRegimeRoutes() map[MarketRegime][]string {
+	return map[MarketRegime][]string{
+		RegimeTrending:      {"trend_following", "breakout"},
+		RegimeBreakout:      {"breakout", "trend_following"},
+		RegimeMeanReverting: {"mean_reversion", "stat_arb"},
+		RegimeConsolidating: {"mean_reversion", "stat_arb"},
+	}
+}
+
+// SetRegimeRoutes sets the strategies that trade in each regime. Regimes
+// without a route trade every strategy; nil turns routing off.
+func (s *Scorer) SetRegimeRoutes(routes map[MarketRegime][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.RegimeRoutes = routes
+}
+
+// GetRegimeRoutes returns a copy of the regime routes, nil when routing is off
+func (s *Scorer) GetRegimeRoutes() map[MarketRegime][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.config.RegimeRoutes == nil {
+		return nil
+	}
+	result := make(map[MarketRegime][]string, len(s.config.RegimeRoutes))
+	for regime, names := range s.config.RegimeRoutes {
+		result[regime] = append([]string(nil), names...)
+	}
+	return result
+}
+
+// isRoutedOut reports whether regime is routed to other strategies than
+// name (s.mu must be held)
+func (s *Scorer) isRoutedOut(name string, regime MarketRegime) bool {
+	routed, ok := s.config.RegimeRoutes[regime]
+	if !ok {
+		return false
+	}
+	for _, n := range routed {
+		if n == name {
+			return false
+		}
+	}
+	return true
+}
+
+// skipsRegime reports whether a strategy sits out regime, blacklisted or
+// routed out (s.mu must be held)
+func (s *Scorer) skipsRegime(name string, regime MarketRegime) bool {
+	return s.isRegimeDisallowed(name, regime) || s.isRoutedOut(name, regime)
+}
+
+// RegimeRouteNames keys regime routes by regime name, strategies sorted
+func RegimeRouteNames(routes map[MarketRegime][]string) map[string][]string {
+	result := make(map[string][]string, len(routes))
+	for regime, names := range routes {
+		sorted := append([]string(nil), names...)
+		sort.Strings(sorted)
+		result[regime.String()] = sorted
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package strategy
+
+import "sort"
+
+// String returns the conflict mode name
+func (m ConflictMode) String() string {
+	switch m {
+	case ConflictModeHighestScore:
+		return "highest_score"
+	case ConflictModeConsensus:
+		return "consensus"
+	case ConflictModeNoTrade:
+		return "no_trade"
+	case ConflictModeAverage:
+		return "average"
+	default:
+		return "unknown"
+	}
+}
+
+// Strategy statuses in a score breakdown
+const (
+	ScoreStatusSignal   = "signal"    // Produced a signal counted in the score
+	ScoreStatusNoSignal = "no_signal" // Ran without signalling
+	ScoreStatusDisabled = "disabled"
+	ScoreStatusBlocked  = "blocked" // Skipped in the current regime, blacklisted or routed out
+)
+
+// StrategyScoreBreakdown describes one strategy's part in a combined score
+type StrategyScoreBreakdown struct {
+	Strategy         string  `json:"strategy"`
+	Status           string  `json:"status"`
+	BaseWeight       float64 `json:"baseWeight"`
+	RegimeMultiplier float64 `json:"regimeMultiplier"` // 1 when regime weights are off
+	Weight           float64 `json:"weight"`           // Base weight x regime multiplier
+	Direction        string  `json:"direction,omitempty"`
+	RawStrength      float64 `json:"rawStrength"` // Signal strength before weighting
+	Score            float64 `json:"score"`       // Weighted strength
+	Confidence       float64 `json:"confidence"`  // Weighted confidence
+	Signals          int     `json:"signals"`
+}
+
+// ScoreBreakdown explains a combined score against the entry thresholds
+type ScoreBreakdown struct {
+	Regime             string                   `json:"regime"`
+	RegimeConfidence   float64                  `json:"regimeConfidence"`
+	ConflictMode       string                   `json:"conflictMode"`
+	Direction          string                   `json:"direction"`
+	Score              float64                  `json:"score"`
+	Confidence         float64                  `json:"confidence"`
+	MinScore           float64                  `json:"minScore"`
+	MinConfidence      float64                  `json:"minConfidence"`
+	ScoreProgress      float64                  `json:"scoreProgress"`      // Score / minScore; 1 meets the threshold
+	ConfidenceProgress float64                  `json:"confidenceProgress"` // Confidence / minConfidence
+	ShouldTrade        bool                     `json:"shouldTrade"`
+	BestStrategy       string                   `json:"bestStrategy,omitempty"`
+	LongSignals        int                      `json:"longSignals"`
+	ShortSignals       int                      `json:"shortSignals"`
+	HasConflict        bool                     `json:"hasConflict"`
+	ConflictLevel      float64                  `json:"conflictLevel"`
+	Strategies         []StrategyScoreBreakdown `json:"strategies"`
+}
+
+// Breakdown explains a combined score produced under regime, using the
+// scorer's current weights and thresholds
+func (s *Scorer) Breakdown(score CombinedScore, regime RegimeResult) ScoreBreakdown {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b := ScoreBreakdown{
+		Regime:           regime.Regime.String(),
+		RegimeConfidence: regime.Confidence,
+		ConflictMode:     s.config.ConflictMode.String(),
+		Direction:        score.Direction.String(),
+		Score:            score.Score,
+		Confidence:       score.Confidence,
+		MinScore:         s.config.MinScoreForEntry,
+		MinConfidence:    s.config.MinConfidence,
+		ShouldTrade:      score.ShouldTrade,
+		LongSignals:      score.LongSignals,
+		ShortSignals:     score.ShortSignals,
+		HasConflict:      score.HasConflict,
+		ConflictLevel:    score.ConflictLevel,
+		Strategies:       make([]StrategyScoreBreakdown, 0, len(s.strategies)),
+	}
+	if b.MinScore > 0 {
+		b.ScoreProgress = b.Score / b.MinScore
+	}
+	if b.MinConfidence > 0 {
+		b.ConfidenceProgress = b.Confidence / b.MinConfidence
+	}
+	if score.BestSignal != nil {
+		b.BestStrategy = score.BestSignal.Strategy
+	}
+
+	for name, strategy := range s.strategies {
+		sb := StrategyScoreBreakdown{
+			Strategy:         name,
+			BaseWeight:       1.0,
+			RegimeMultiplier: 1.0,
+			Weight:           s.getWeight(name, regime.Regime),
+		}
+		if w, ok := s.config.Weights[name]; ok {
+			sb.BaseWeight = w
+		}
+		if sb.BaseWeight != 0 {
+			sb.RegimeMultiplier = sb.Weight / sb.BaseWeight
+		}
+
+		result, signalled := score.Scores[name]
+		switch {
+		case !strategy.IsEnabled():
+			sb.Status = ScoreStatusDisabled
+		case s.skipsRegime(name, regime.Regime):
+			sb.Status = ScoreStatusBlocked
+		case !signalled:
+			sb.Status = ScoreStatusNoSignal
+		default:
+			sb.Status = ScoreStatusSignal
+			sb.Direction = result.Direction.String()
+			sb.Score = result.Score
+			sb.Confidence = result.Confidence
+			sb.Signals = len(result.Signals)
+			if sb.Weight != 0 {
+				sb.RawStrength = result.Score / sb.Weight
+			}
+		}
+		b.Strategies = append(b.Strategies, sb)
+	}
+
+	sort.Slice(b.Strategies, func(i, j int) bool {
+		if b.Strategies[i].Score != b.Strategies[j].Score {
+			return b.Strategies[i].Score > b.Strategies[j].Score
+		}
+		return b.Strategies[i].Strategy < b.Strategies[j].Strategy
+	})
+	return b
+}
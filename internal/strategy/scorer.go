@@ -28,6 +28,12 @@ type ScorerConfig struct {
 	// Regime adjustments
 	UseRegimeWeights bool
 	RegimeWeights    map[MarketRegime]map[string]float64
+
+	// Regimes in which a strategy is skipped before scoring
+	DisallowedRegimes map[string][]MarketRegime
+
+	// Strategies scored in each regime; regimes without a route score all
+	RegimeRoutes map[MarketRegime][]string
 }
 
 // ConflictMode determines how conflicting signals are handled
@@ -98,6 +104,9 @@ func DefaultScorerConfig() *ScorerConfig {
 type Scorer struct {
 	config     *ScorerConfig
 	strategies map[string]Strategy
+	onPanic    PanicHandler
+	pool       *EvalPool    // Bounds concurrent evaluations; nil evaluates inline
+	priority   EvalPriority // Priority of this scorer's evaluations in the pool
 	mu         sync.RWMutex
 }
 
@@ -120,6 +129,22 @@ func (s *Scorer) AddStrategy(strategy Strategy) {
 	s.strategies[strategy.Name()] = strategy
 }
 
+// SetOnPanic sets the handler called when a strategy panics during scoring
+func (s *Scorer) SetOnPanic(handler PanicHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onPanic = handler
+}
+
+// SetEvalPool evaluates strategies concurrently on pool's workers at the
+// given priority; nil evaluates them one after another
+func (s *Scorer) SetEvalPool(pool *EvalPool, priority EvalPriority) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pool = pool
+	s.priority = priority
+}
+
 // RemoveStrategy removes a strategy
 func (s *Scorer) RemoveStrategy(name string) {
 	s.mu.Lock()
@@ -136,12 +161,7 @@ func (s *Scorer) Score(data *MarketData, regime RegimeResult) CombinedScore {
 	strategyScores := make(map[string]ScoreResult)
 
 	// Get signals from each strategy
-	for name, strategy := range s.strategies {
-		if !strategy.IsEnabled() {
-			continue
-		}
-
-		signals := strategy.Analyze(data)
+	for name, signals := range s.evaluate(data, regime) {
 		if len(signals) == 0 {
 			continue
 		}
@@ -173,6 +193,39 @@ func (s *Scorer) Score(data *MarketData, regime RegimeResult) CombinedScore {
 	return s.combineSignals(allSignals, strategyScores, regime)
 }
 
+// evaluate runs the strategies eligible in regime, on the pool's workers
+// when one is set, and returns their signals by strategy
+func (s *Scorer) evaluate(data *MarketData, regime RegimeResult) map[string][]Signal {
+	results := make(map[string][]Signal, len(s.strategies))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, strategy := range s.strategies {
+		if !strategy.IsEnabled() || s.skipsRegime(name, regime.Regime) {
+			continue
+		}
+
+		if s.pool == nil {
+			results[name] = safeAnalyze(strategy, data, s.onPanic)
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, strategy Strategy) {
+			defer wg.Done()
+			s.pool.Run(s.priority, func() {
+				signals := safeAnalyze(strategy, data, s.onPanic)
+				mu.Lock()
+				results[name] = signals
+				mu.Unlock()
+			})
+		}(name, strategy)
+	}
+
+	wg.Wait()
+	return results
+}
+
 // getWeight returns strategy weight adjusted for regime
 func (s *Scorer) getWeight(strategyName string, regime MarketRegime) float64 {
 	baseWeight := 1.0
@@ -0,0 +1,571 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ScriptExtension is the file extension of scripted strategies
+const ScriptExtension = ".lua"
+
+const (
+	defaultScriptMinData  = 50
+	defaultScriptLookback = 100
+
+	// scriptCallTimeout bounds each call into a script, so a runaway loop
+	// cannot stall the trading pipeline
+	scriptCallTimeout = 500 * time.Millisecond
+
+	// scriptCallStackSize bounds the nesting of calls within a script
+	scriptCallStackSize = 256
+	// scriptRegistryMaxSize bounds the values on a script's stack
+	scriptRegistryMaxSize = 256 * 1024
+	// scriptMaxRep bounds the length of strings built by string.rep
+	scriptMaxRep = 1 << 20
+	// scriptAllocPoll is how often a call's allocations are checked
+	// against ScriptConfig.MaxCallAlloc
+	scriptAllocPoll = time.Millisecond
+	// defaultScriptCallAlloc is the MaxCallAlloc of scripts loaded from
+	// the scripts directory, generous enough that allocations elsewhere in
+	// the process rarely count a well-behaved call over it
+	defaultScriptCallAlloc = 256 << 20
+)
+
+// ScriptConfig holds the source of a scripted strategy
+type ScriptConfig struct {
+	Path   string // File the script was loaded from
+	Source string
+
+	// MaxCallAlloc bounds the bytes allocated during each call into the
+	// script, 0 for no bound. The bound is approximate: allocations are
+	// counted process-wide and sampled every scriptAllocPoll, so it must
+	// leave room for everything else running during the call.
+	MaxCallAlloc uint64
+}
+
+// ScriptStrategy is a strategy written in Lua. The script defines
+// analyze(data), returning nil, a signal table or a list of them, and may
+// define should_exit(data, position), stop_loss(data, direction, price)
+// and take_profit(data, direction, price). Globals name, min_data,
+// lookback and enabled override the defaults.
+//
+// A signal table has direction ("long" or "short"), strength and
+// confidence (0-1), reason, stop_loss and take_profit; missing levels are
+// derived from the ATR.
+type ScriptStrategy struct {
+	BaseStrategy
+	config   *ScriptConfig
+	lookback int
+
+	mu      sync.Mutex // Lua states are not safe for concurrent use
+	state   *lua.LState
+	lastErr string
+}
+
+// ScriptName returns the strategy name a script file gets unless it sets
+// one, e.g. "strategies/RsiDip.lua" becomes "rsi_dip"
+func ScriptName(path string) string {
+	return CanonicalName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// NewScriptStrategy compiles and runs a script, returning the strategy it
+// defines
+func NewScriptStrategy(config *ScriptConfig) (*ScriptStrategy, error) {
+	L := lua.NewState(lua.Options{
+		SkipOpenLibs:    true,
+		CallStackSize:   scriptCallStackSize,
+		RegistrySize:    lua.RegistrySize,
+		RegistryMaxSize: scriptRegistryMaxSize,
+	})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	// Scripts only see the data they are handed
+	for _, name := range []string{"dofile", "loadfile", "load", "loadstring", "require", "module"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	// Nor build strings too large to hold
+	if strlib, ok := L.GetGlobal("string").(*lua.LTable); ok {
+		strlib.RawSetString("rep", L.NewFunction(scriptRep))
+	}
+	L.SetGlobal("LONG", lua.LString("long"))
+	L.SetGlobal("SHORT", lua.LString("short"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), scriptCallTimeout)
+	L.SetContext(ctx)
+	stop, exceeded := watchAllocs(config.MaxCallAlloc, cancel)
+	err := L.DoString(config.Source)
+	stop()
+	cancel()
+	L.RemoveContext()
+	if err != nil {
+		L.Close()
+		if exceeded() {
+			return nil, fmt.Errorf("%s: script allocated more than %d bytes", config.Path, config.MaxCallAlloc)
+		}
+		return nil, fmt.Errorf("%s: %w", config.Path, err)
+	}
+
+	if _, ok := L.GetGlobal("analyze").(*lua.LFunction); !ok {
+		L.Close()
+		return nil, fmt.Errorf("%s: script does not define analyze(data)", config.Path)
+	}
+
+	name := ScriptName(config.Path)
+	if v, ok := L.GetGlobal("name").(lua.LString); ok && strings.TrimSpace(string(v)) != "" {
+		name = CanonicalName(string(v))
+	}
+	minData := defaultScriptMinData
+	if v, ok := L.GetGlobal("min_data").(lua.LNumber); ok && v > 0 {
+		minData = int(v)
+	}
+	lookback := defaultScriptLookback
+	if v, ok := L.GetGlobal("lookback").(lua.LNumber); ok && v > 0 {
+		lookback = int(v)
+	}
+
+	s := &ScriptStrategy{
+		BaseStrategy: NewBaseStrategy(name, minData, 14),
+		config:       config,
+		lookback:     lookback,
+		state:        L,
+	}
+	if v, ok := L.GetGlobal("enabled").(lua.LBool); ok {
+		s.SetEnabled(bool(v))
+	}
+	return s, nil
+}
+
+// LastError returns the error of the last failed call into the script, or
+// "" once it succeeds again
+func (s *ScriptStrategy) LastError() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
+
+// Close releases the script's Lua state. The strategy must not be used
+// afterwards.
+func (s *ScriptStrategy) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Close()
+}
+
+// Path returns the file the script was loaded from
+func (s *ScriptStrategy) Path() string {
+	return s.config.Path
+}
+
+// Analyze runs the script's analyze function
+func (s *ScriptStrategy) Analyze(data *MarketData) []Signal {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret, ok := s.call("analyze", 1, s.marketTable(data))
+	if !ok {
+		return nil
+	}
+	result, ok := ret[0].(*lua.LTable)
+	if !ok {
+		return nil
+	}
+
+	// A single signal has a direction; otherwise it is a list of signals
+	var tables []*lua.LTable
+	if result.RawGetString("direction") != lua.LNil {
+		tables = append(tables, result)
+	} else {
+		result.ForEach(func(_, v lua.LValue) {
+			if t, ok := v.(*lua.LTable); ok {
+				tables = append(tables, t)
+			}
+		})
+	}
+
+	signals := make([]Signal, 0, len(tables))
+	for _, t := range tables {
+		if signal, ok := s.signalFromTable(data, t); ok {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
+
+// ShouldEnter returns the first signal of the script
+func (s *ScriptStrategy) ShouldEnter(data *MarketData) (bool, Direction, float64) {
+	signals := s.Analyze(data)
+	if len(signals) == 0 {
+		return false, DirectionNone, 0
+	}
+	return true, signals[0].Direction, signals[0].Strength
+}
+
+// ShouldExit runs the script's should_exit function, or checks the stop
+// and target when it has none
+func (s *ScriptStrategy) ShouldExit(data *MarketData, position *Position) (bool, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.GetGlobal("should_exit").(*lua.LFunction); ok {
+		ret, ok := s.call("should_exit", 2, s.marketTable(data), positionTable(s.state, position))
+		if !ok || !lua.LVAsBool(ret[0]) {
+			return false, ""
+		}
+		reason := "Script exit"
+		if r, ok := ret[1].(lua.LString); ok && r != "" {
+			reason = string(r)
+		}
+		return true, reason
+	}
+
+	price := data.CurrentPrice
+	switch position.Direction {
+	case DirectionLong:
+		if position.StopLoss > 0 && price <= position.StopLoss {
+			return true, "Stop loss triggered"
+		}
+		if position.TakeProfit > 0 && price >= position.TakeProfit {
+			return true, "Take profit reached"
+		}
+	case DirectionShort:
+		if position.StopLoss > 0 && price >= position.StopLoss {
+			return true, "Stop loss triggered"
+		}
+		if position.TakeProfit > 0 && price <= position.TakeProfit {
+			return true, "Take profit reached"
+		}
+	}
+	return false, ""
+}
+
+// CalculateStopLoss runs the script's stop_loss function, or places the
+// stop two ATRs away
+func (s *ScriptStrategy) CalculateStopLoss(data *MarketData, direction Direction, entryPrice float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.level("stop_loss", data, direction, entryPrice, func() float64 {
+		return s.CalculateATRStop(data, direction, entryPrice, 2.0)
+	})
+}
+
+// CalculateTakeProfit runs the script's take_profit function, or places
+// the target three ATRs away
+func (s *ScriptStrategy) CalculateTakeProfit(data *MarketData, direction Direction, entryPrice float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.level("take_profit", data, direction, entryPrice, func() float64 {
+		return s.CalculateATRTarget(data, direction, entryPrice, 3.0)
+	})
+}
+
+// GetConfig returns the script source
+func (s *ScriptStrategy) GetConfig() interface{} {
+	return s.config
+}
+
+// level returns a price level from an optional script function. The
+// caller must hold s.mu.
+func (s *ScriptStrategy) level(fn string, data *MarketData, direction Direction, entryPrice float64, fallback func() float64) float64 {
+	if _, ok := s.state.GetGlobal(fn).(*lua.LFunction); !ok {
+		return fallback()
+	}
+	ret, ok := s.call(fn, 1, s.marketTable(data), lua.LString(directionName(direction)), lua.LNumber(entryPrice))
+	if !ok {
+		return fallback()
+	}
+	if v, ok := ret[0].(lua.LNumber); ok && v > 0 {
+		return float64(v)
+	}
+	return fallback()
+}
+
+// call calls a global script function with a deadline, logging errors
+// once until the script recovers. A script that runs out of time or
+// allocates more than its bound is disabled. The caller must hold s.mu.
+func (s *ScriptStrategy) call(fn string, nret int, args ...lua.LValue) ([]lua.LValue, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), scriptCallTimeout)
+	defer cancel()
+	s.state.SetContext(ctx)
+	defer s.state.RemoveContext()
+
+	stop, exceeded := watchAllocs(s.config.MaxCallAlloc, cancel)
+	err := s.state.CallByParam(lua.P{
+		Fn:      s.state.GetGlobal(fn),
+		NRet:    nret,
+		Protect: true,
+	}, args...)
+	stop()
+	if err != nil {
+		if exceeded() {
+			// Left running, it could exhaust the process's memory
+			s.SetEnabled(false)
+			s.lastErr = fmt.Sprintf("%s allocated more than %d bytes", fn, s.config.MaxCallAlloc)
+			log.Error().Str("strategy", s.name).Str("function", fn).Uint64("limit", s.config.MaxCallAlloc).
+				Msg("Strategy script exceeded its allocation limit and was disabled")
+			return nil, false
+		}
+		if ctx.Err() != nil {
+			// A script this slow would stall every candle and backtest bar
+			s.SetEnabled(false)
+			log.Error().Str("strategy", s.name).Str("function", fn).Dur("limit", scriptCallTimeout).
+				Msg("Strategy script timed out and was disabled")
+			return nil, false
+		}
+		if msg := err.Error(); msg != s.lastErr {
+			s.lastErr = msg
+			log.Warn().Err(err).Str("strategy", s.name).Str("function", fn).Msg("Strategy script failed")
+		}
+		return nil, false
+	}
+	s.lastErr = ""
+
+	ret := make([]lua.LValue, nret)
+	for i := nret - 1; i >= 0; i-- {
+		ret[i] = s.state.Get(-1)
+		s.state.Pop(1)
+	}
+	return ret, true
+}
+
+// watchAllocs cancels a call once the process has allocated more than
+// limit bytes since it started, until stop is called; exceeded reports
+// whether it did. Go has no per-goroutine allocation count, so allocations
+// by other goroutines count too. A zero limit watches nothing.
+func watchAllocs(limit uint64, cancel context.CancelFunc) (stop func(), exceeded func() bool) {
+	var hit atomic.Bool
+	if limit == 0 {
+		return func() {}, hit.Load
+	}
+
+	sample := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	metrics.Read(sample)
+	start := sample[0].Value.Uint64()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(scriptAllocPoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				metrics.Read(sample)
+				if sample[0].Value.Uint64()-start > limit {
+					hit.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }, hit.Load
+}
+
+// scriptRep is string.rep bounded to scriptMaxRep bytes
+func scriptRep(L *lua.LState) int {
+	str := L.CheckString(1)
+	n := L.CheckInt(2)
+	if n <= 0 || str == "" {
+		L.Push(lua.LString(""))
+		return 1
+	}
+	if n > scriptMaxRep/len(str) {
+		L.RaiseError("string.rep result exceeds %d bytes", scriptMaxRep)
+		return 0
+	}
+	L.Push(lua.LString(strings.Repeat(str, n)))
+	return 1
+}
+
+// signalFromTable converts a signal table returned by the script. The
+// caller must hold s.mu.
+func (s *ScriptStrategy) signalFromTable(data *MarketData, t *lua.LTable) (Signal, bool) {
+	var direction Direction
+	switch strings.ToLower(lua.LVAsString(t.RawGetString("direction"))) {
+	case "long", "buy":
+		direction = DirectionLong
+	case "short", "sell":
+		direction = DirectionShort
+	default:
+		return Signal{}, false
+	}
+
+	strength := clampUnit(numberField(t, "strength", 0.5))
+	reason := lua.LVAsString(t.RawGetString("reason"))
+	if reason == "" {
+		reason = "Script signal"
+	}
+
+	signal := s.CreateSignal(data, SignalTypeEntry, direction, strength, reason)
+	signal.Confidence = clampUnit(numberField(t, "confidence", strength))
+	signal.StopLoss = numberField(t, "stop_loss", 0)
+	if signal.StopLoss <= 0 {
+		signal.StopLoss = s.level("stop_loss", data, direction, signal.Price, func() float64 {
+			return s.CalculateATRStop(data, direction, signal.Price, 2.0)
+		})
+	}
+	signal.TakeProfit = numberField(t, "take_profit", 0)
+	if signal.TakeProfit <= 0 {
+		signal.TakeProfit = s.level("take_profit", data, direction, signal.Price, func() float64 {
+			return s.CalculateATRTarget(data, direction, signal.Price, 3.0)
+		})
+	}
+	return signal, true
+}
+
+// marketTable exposes market data to the script. Price series hold the
+// last lookback bars, oldest first. The caller must hold s.mu.
+func (s *ScriptStrategy) marketTable(data *MarketData) *lua.LTable {
+	L := s.state
+	t := L.NewTable()
+	t.RawSetString("symbol", lua.LString(data.Symbol))
+	t.RawSetString("timeframe", lua.LString(data.Timeframe))
+	t.RawSetString("time", lua.LNumber(data.Timestamp.Unix()))
+	t.RawSetString("price", lua.LNumber(data.CurrentPrice))
+	t.RawSetString("bid", lua.LNumber(data.Bid))
+	t.RawSetString("ask", lua.LNumber(data.Ask))
+	t.RawSetString("regime", lua.LString(data.Regime.Regime.String()))
+	t.RawSetString("regime_confidence", lua.LNumber(data.Regime.Confidence))
+	t.RawSetString("session", lua.LString(data.Session))
+
+	t.RawSetString("open", seriesTable(L, data.Opens, s.lookback))
+	t.RawSetString("high", seriesTable(L, data.Highs, s.lookback))
+	t.RawSetString("low", seriesTable(L, data.Lows, s.lookback))
+	t.RawSetString("close", seriesTable(L, data.Closes, s.lookback))
+	t.RawSetString("volume", seriesTable(L, data.Volumes, s.lookback))
+	t.RawSetString("indicators", indicatorTable(L, data))
+
+	higher := L.NewTable()
+	for tf, htf := range data.HigherTimeframes {
+		h := L.NewTable()
+		h.RawSetString("close", seriesTable(L, htf.Closes, s.lookback))
+		h.RawSetString("indicators", indicatorTable(L, &MarketData{Analysis: htf.Analysis}))
+		higher.RawSetString(tf, h)
+	}
+	t.RawSetString("higher", higher)
+	return t
+}
+
+// indicatorTable exposes the precomputed indicators of data
+func indicatorTable(L *lua.LState, data *MarketData) *lua.LTable {
+	a := data.Analysis
+	t := L.NewTable()
+	for name, v := range map[string]float64{
+		"rsi":            a.RSI.Value,
+		"macd":           a.MACD.MACD,
+		"macd_signal":    a.MACD.Signal,
+		"macd_histogram": a.MACD.Histogram,
+		"bb_upper":       a.Bollinger.Upper,
+		"bb_middle":      a.Bollinger.Middle,
+		"bb_lower":       a.Bollinger.Lower,
+		"bb_width":       a.Bollinger.Width,
+		"bb_percent_b":   a.Bollinger.PercentB,
+		"atr":            a.ATR.ATR,
+		"atr_percent":    a.ATR.ATRPercent,
+		"adx":            a.ADX.ADX,
+		"plus_di":        a.ADX.PlusDI,
+		"minus_di":       a.ADX.MinusDI,
+		"stoch_k":        a.Stochastic.K,
+		"stoch_d":        a.Stochastic.D,
+		"ma":             a.MA.Value,
+		"volume_average": a.Volume.Average,
+		"volume_ratio":   a.Volume.Ratio,
+		"vwap":           a.VWAP.VWAP,
+		"vwap_distance":  a.VWAP.Distance,
+		"supertrend":     a.SuperTrend.Value,
+		"tenkan":         a.Ichimoku.Tenkan,
+		"kijun":          a.Ichimoku.Kijun,
+		"senkou_a":       a.Ichimoku.SenkouA,
+		"senkou_b":       a.Ichimoku.SenkouB,
+		"kc_upper":       a.Keltner.Upper,
+		"kc_middle":      a.Keltner.Middle,
+		"kc_lower":       a.Keltner.Lower,
+	} {
+		t.RawSetString(name, lua.LNumber(v))
+	}
+	t.RawSetString("supertrend_direction", lua.LString(a.SuperTrend.Direction.String()))
+	t.RawSetString("ichimoku_position", lua.LString(a.Ichimoku.Position.String()))
+	return t
+}
+
+// positionTable exposes an open position to the script
+func positionTable(L *lua.LState, p *Position) *lua.LTable {
+	t := L.NewTable()
+	t.RawSetString("direction", lua.LString(directionName(p.Direction)))
+	t.RawSetString("entry_price", lua.LNumber(p.EntryPrice))
+	t.RawSetString("quantity", lua.LNumber(p.Quantity))
+	t.RawSetString("price", lua.LNumber(p.CurrentPrice))
+	t.RawSetString("stop_loss", lua.LNumber(p.StopLoss))
+	t.RawSetString("take_profit", lua.LNumber(p.TakeProfit))
+	t.RawSetString("opened_at", lua.LNumber(p.OpenTime.Unix()))
+	t.RawSetString("pnl_percent", lua.LNumber(p.UnrealizedPnLPercent))
+	return t
+}
+
+// seriesTable converts the last n values of a series to a Lua array
+func seriesTable(L *lua.LState, values []float64, n int) *lua.LTable {
+	if len(values) > n {
+		values = values[len(values)-n:]
+	}
+	t := L.CreateTable(len(values), 0)
+	for _, v := range values {
+		t.Append(lua.LNumber(v))
+	}
+	return t
+}
+
+// numberField reads a numeric field of a table, or def when it is unset
+func numberField(t *lua.LTable, key string, def float64) float64 {
+	if v, ok := t.RawGetString(key).(lua.LNumber); ok {
+		return float64(v)
+	}
+	return def
+}
+
+// directionName returns the script name of a direction
+func directionName(d Direction) string {
+	switch d {
+	case DirectionLong:
+		return "long"
+	case DirectionShort:
+		return "short"
+	default:
+		return "none"
+	}
+}
+
+// clampUnit limits v to [0, 1]
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
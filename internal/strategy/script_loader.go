@@ -0,0 +1,130 @@
+package strategy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ScriptLoader loads scripted strategies from a directory into a manager,
+// picking up new, changed and deleted scripts on each Load
+type ScriptLoader struct {
+	dir     string
+	manager *Manager
+
+	mu    sync.Mutex
+	files map[string]scriptFile // By path
+}
+
+// scriptFile is a loaded script file
+type scriptFile struct {
+	modTime time.Time
+	size    int64
+	name    string // Strategy name, empty when the script failed to load
+}
+
+// NewScriptLoader creates a loader of the *.lua files in dir
+func NewScriptLoader(dir string, manager *Manager) *ScriptLoader {
+	return &ScriptLoader{
+		dir:     dir,
+		manager: manager,
+		files:   make(map[string]scriptFile),
+	}
+}
+
+// Dir returns the directory scripts are loaded from
+func (l *ScriptLoader) Dir() string {
+	return l.dir
+}
+
+// Load synchronizes the manager with the scripts in the directory. A script
+// that fails to load is reported and, if it loaded before, its previous
+// version stays in use.
+func (l *ScriptLoader) Load() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(l.dir, "*"+ScriptExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	infos := make(map[string]os.FileInfo, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			infos[path] = info
+		}
+	}
+
+	// Remove deleted scripts first so their names are free to reuse
+	for path, file := range l.files {
+		if _, ok := infos[path]; ok {
+			continue
+		}
+		delete(l.files, path)
+		if file.name != "" {
+			l.manager.RemoveStrategy(file.name)
+			log.Info().Str("strategy", file.name).Str("path", path).Msg("Strategy script removed")
+		}
+	}
+
+	var errs []string
+	for _, path := range paths {
+		info, ok := infos[path]
+		if !ok {
+			continue
+		}
+		prev, known := l.files[path]
+		if known && prev.modTime.Equal(info.ModTime()) && prev.size == info.Size() {
+			continue
+		}
+		file := scriptFile{modTime: info.ModTime(), size: info.Size(), name: prev.name}
+		if err := l.load(path, &file); err != nil {
+			errs = append(errs, err.Error())
+			log.Error().Err(err).Str("path", path).Msg("Failed to load strategy script")
+		}
+		l.files[path] = file
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d strategy script(s) failed to load: %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// load compiles the script at path and adds it to the manager, updating
+// file.name. The caller must hold l.mu.
+func (l *ScriptLoader) load(path string, file *scriptFile) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s, err := NewScriptStrategy(&ScriptConfig{Path: path, Source: string(source), MaxCallAlloc: defaultScriptCallAlloc})
+	if err != nil {
+		return err
+	}
+
+	name := s.Name()
+	if existing, ok := l.manager.GetStrategies()[name]; ok && name != file.name {
+		if other, scripted := existing.(*ScriptStrategy); scripted {
+			return fmt.Errorf("%s: strategy name %q is already taken by %s", path, name, other.Path())
+		}
+		return fmt.Errorf("%s: strategy name %q is already taken by a built-in strategy", path, name)
+	}
+	if file.name != "" && file.name != name {
+		// Renamed: the old name no longer exists
+		l.manager.RemoveStrategy(file.name)
+	}
+
+	l.manager.AddStrategy(s)
+	file.name = name
+	log.Info().Str("strategy", name).Str("path", path).Msg("Strategy script loaded")
+	return nil
+}
@@ -0,0 +1,28 @@
+package strategy
+
+import "time"
+
+// TradingSession is the regional session a time falls in. Sessions are
+// fixed UTC windows and do not overlap, so every time has exactly one.
+type TradingSession string
+
+const (
+	SessionAsia   TradingSession = "asia"   // 21:00-08:00 UTC (Sydney and Tokyo)
+	SessionEurope TradingSession = "europe" // 08:00-13:00 UTC (London until New York opens)
+	SessionUS     TradingSession = "us"     // 13:00-21:00 UTC (New York)
+)
+
+// TradingSessions lists the sessions in the order a UTC day starts them
+var TradingSessions = []TradingSession{SessionAsia, SessionEurope, SessionUS}
+
+// SessionAt returns the trading session t falls in
+func SessionAt(t time.Time) TradingSession {
+	switch hour := t.UTC().Hour(); {
+	case hour >= 8 && hour < 13:
+		return SessionEurope
+	case hour >= 13 && hour < 21:
+		return SessionUS
+	default:
+		return SessionAsia
+	}
+}
@@ -81,7 +81,7 @@ func NewStatArbStrategy(config *StatArbConfig) *StatArbStrategy {
 
 // Analyze analyzes market data for stat arb signals
 func (s *StatArbStrategy) Analyze(data *MarketData) []Signal {
-	if !s.enabled || len(data.Closes) < s.minData {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
 		return nil
 	}
 
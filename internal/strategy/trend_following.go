@@ -69,7 +69,7 @@ func NewTrendFollowingStrategy(config *TrendFollowingConfig) *TrendFollowingStra
 
 // Analyze analyzes market data for trend following signals
 func (s *TrendFollowingStrategy) Analyze(data *MarketData) []Signal {
-	if !s.enabled || len(data.Closes) < s.minData {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
 		return nil
 	}
 
@@ -1,7 +1,10 @@
 package strategy
 
 import (
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
 
 	"github.com/eth-trading/internal/indicators"
 )
@@ -21,6 +24,7 @@ type Signal struct {
 	Timeframe   string           `json:"timeframe"`
 	Symbol      string           `json:"symbol"`
 	Indicators  SignalIndicators `json:"indicators"`
+	SizeFactor  float64          `json:"sizeFactor,omitempty"` // Fraction of the usual size to trade; 0 = full size
 }
 
 // SignalType represents type of signal
@@ -137,6 +141,9 @@ type MarketData struct {
 	// Regime
 	Regime RegimeResult
 
+	// Trading session of Timestamp
+	Session TradingSession
+
 	// Current price
 	CurrentPrice float64
 	Bid          float64
@@ -146,6 +153,32 @@ type MarketData struct {
 	DailyHigh  float64
 	DailyLow   float64
 	DailyOpen  float64
+
+	// Higher timeframe context keyed by timeframe (e.g. "4h").
+	// Only fully closed bars are included, in live trading and backtests alike.
+	HigherTimeframes map[string]*TimeframeData
+}
+
+// TimeframeData holds closed bars and indicators for a secondary timeframe
+type TimeframeData struct {
+	Timeframe string
+	LastClose time.Time // Close time of the most recent bar
+
+	Opens   []float64
+	Highs   []float64
+	Lows    []float64
+	Closes  []float64
+	Volumes []float64
+
+	Analysis indicators.AnalysisResult
+}
+
+// HigherTimeframe returns context for the given timeframe, or nil if unavailable
+func (d *MarketData) HigherTimeframe(timeframe string) *TimeframeData {
+	if d == nil || d.HigherTimeframes == nil {
+		return nil
+	}
+	return d.HigherTimeframes[timeframe]
 }
 
 // Position represents an open position
@@ -167,16 +200,31 @@ type Position struct {
 // BaseStrategy provides common functionality
 type BaseStrategy struct {
 	name      string
-	enabled   bool
+	disabled  atomic.Bool // Set from the eval pool, e.g. when a script times out
 	minData   int
 	atrPeriod int
 }
 
+// CanonicalName converts a configured strategy name such as "TrendFollowing"
+// to the name strategies report, e.g. "trend_following"
+func CanonicalName(name string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(name) {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // NewBaseStrategy creates a new base strategy
 func NewBaseStrategy(name string, minData, atrPeriod int) BaseStrategy {
 	return BaseStrategy{
 		name:      name,
-		enabled:   true,
 		minData:   minData,
 		atrPeriod: atrPeriod,
 	}
@@ -194,12 +242,12 @@ func (bs *BaseStrategy) GetMinDataPoints() int {
 
 // IsEnabled returns if strategy is enabled
 func (bs *BaseStrategy) IsEnabled() bool {
-	return bs.enabled
+	return !bs.disabled.Load()
 }
 
 // SetEnabled enables/disables strategy
 func (bs *BaseStrategy) SetEnabled(enabled bool) {
-	bs.enabled = enabled
+	bs.disabled.Store(!enabled)
 }
 
 // CalculateATRStop calculates ATR-based stop loss
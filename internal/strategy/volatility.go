@@ -76,7 +76,7 @@ func NewVolatilityStrategy(config *VolatilityConfig) *VolatilityStrategy {
 
 // Analyze analyzes market data for volatility signals
 func (s *VolatilityStrategy) Analyze(data *MarketData) []Signal {
-	if !s.enabled || len(data.Closes) < s.minData {
+	if !s.IsEnabled() || len(data.Closes) < s.minData {
 		return nil
 	}
 
@@ -0,0 +1,90 @@
+package strategy
+
+import "fmt"
+
+// VolatilityAction is what happens to an entry signaled on a candle whose
+// range is a volatility spike
+type VolatilityAction string
+
+const (
+	// VolatilityDelay holds the entry until the next candle closes
+	VolatilityDelay VolatilityAction = "delay"
+	// VolatilityDownsize enters at once with a fraction of the usual size
+	VolatilityDownsize VolatilityAction = "downsize"
+)
+
+// VolatilityThrottle is the rule for entries signaled during candles whose
+// high-low range exceeds RangeATR times the ATR, typically news spikes
+type VolatilityThrottle struct {
+	RangeATR   float64          `json:"rangeATR"`   // Range in ATRs that makes a spike; 0 = off
+	Action     VolatilityAction `json:"action"`     // "delay" or "downsize"
+	SizeFactor float64          `json:"sizeFactor"` // Downsize: fraction of the usual size traded
+	Confirm    bool             `json:"confirm"`    // Delay: the next candle must close beyond the spike's close in the signal's direction
+}
+
+// Validate checks the rule's settings
+func (t VolatilityThrottle) Validate() error {
+	if t.RangeATR < 0 {
+		return fmt.Errorf("rangeATR must not be negative")
+	}
+	if t.RangeATR == 0 {
+		return nil
+	}
+	switch t.Action {
+	case VolatilityDelay:
+	case VolatilityDownsize:
+		if t.SizeFactor <= 0 || t.SizeFactor > 1 {
+			return fmt.Errorf("sizeFactor must be within (0, 1]")
+		}
+	default:
+		return fmt.Errorf("action must be %q or %q", VolatilityDelay, VolatilityDownsize)
+	}
+	return nil
+}
+
+// Spike reports whether a candle's range is a volatility spike against atr
+func (t VolatilityThrottle) Spike(high, low, atr float64) bool {
+	return t.RangeATR > 0 && atr > 0 && high-low > t.RangeATR*atr
+}
+
+// Release reports whether an entry delayed from a spike closing at
+// spikeClose may go ahead on the next candle, which closed at close.
+// Entries are dropped if that candle is a spike too, if it closed through
+// the entry's stop or, with Confirm, if it didn't carry on in the signal's
+// direction.
+func (t VolatilityThrottle) Release(signal Signal, spikeClose, close float64, spike bool) bool {
+	if spike {
+		return false
+	}
+	long := signal.Direction == DirectionLong
+	if signal.StopLoss > 0 && ((long && close <= signal.StopLoss) || (!long && close >= signal.StopLoss)) {
+		return false
+	}
+	if t.Confirm {
+		return (long && close > spikeClose) || (!long && close < spikeClose)
+	}
+	return true
+}
+
+// VolatilityThrottles holds the rule for all strategies and per-strategy
+// exceptions
+type VolatilityThrottles struct {
+	Default    VolatilityThrottle
+	Strategies map[string]VolatilityThrottle // By canonical strategy name
+}
+
+// For returns the rule applied to a strategy
+func (t VolatilityThrottles) For(strategyName string) VolatilityThrottle {
+	if rule, ok := t.Strategies[CanonicalName(strategyName)]; ok {
+		return rule
+	}
+	return t.Default
+}
+
+// ScaleSize applies a signal's size factor to quantity; 0 leaves it whole
+func ScaleSize(quantity, sizeFactor float64) float64 {
+	if sizeFactor > 0 && sizeFactor < 1 {
+		return quantity * sizeFactor
+	}
+	return quantity
+}
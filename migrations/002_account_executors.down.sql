@@ -0,0 +1,7 @@
+-- ETH Trading Bot - Rollback Account Executors Migration
+
+DROP INDEX IF EXISTS idx_trading_accounts_executor_enabled;
+
+ALTER TABLE trading_accounts
+    DROP COLUMN IF EXISTS executor_enabled,
+    DROP COLUMN IF EXISTS risk_config;
@@ -0,0 +1,13 @@
+-- ETH Trading Bot - Account Executors Migration
+-- Description: Per-account risk overrides and the flag that starts an account's own executor with the bot
+
+ALTER TABLE trading_accounts
+    ADD COLUMN IF NOT EXISTS risk_config JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS executor_enabled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_trading_accounts_executor_enabled
+    ON trading_accounts(executor_enabled) WHERE executor_enabled AND is_active;
+
+COMMENT ON COLUMN trading_accounts.risk_config IS 'Risk limits overriding the bot''s for the account''s executor';
+COMMENT ON COLUMN trading_accounts.executor_enabled IS 'Run the account''s isolated executor whenever the bot runs';
+COMMENT ON COLUMN trading_accounts.binance_secret_key_encrypted IS 'AES-256-GCM encrypted Binance secret, base64 nonce and ciphertext';
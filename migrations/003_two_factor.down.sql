@@ -0,0 +1,6 @@
+-- ETH Trading Bot - Rollback Two-Factor Authentication Migration
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS totp_last_step,
+    DROP COLUMN IF EXISTS totp_enabled,
+    DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,11 @@
+-- ETH Trading Bot - Two-Factor Authentication Migration
+-- Description: TOTP secrets that step up sensitive operations such as arming live mode
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret TEXT,
+    ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN users.totp_secret IS 'AES-256-GCM encrypted base32 TOTP secret, set from enrollment until disabled';
+COMMENT ON COLUMN users.totp_enabled IS 'Enrollment confirmed; sensitive operations require a code';
+COMMENT ON COLUMN users.totp_last_step IS 'Time step of the last accepted code, refused again to stop replays';
@@ -0,0 +1,6 @@
+-- ETH Trading Bot - Rollback Trading Data Migration
+
+DROP TABLE IF EXISTS position_pnl;
+DROP TABLE IF EXISTS positions;
+DROP TABLE IF EXISTS trades;
+DROP TABLE IF EXISTS candles;
@@ -0,0 +1,80 @@
+-- ETH Trading Bot - Trading Data Migration
+-- Description: Candles, trades and positions for database.tradingStore: postgres (the bot also creates them on start)
+
+CREATE TABLE IF NOT EXISTS candles (
+    id BIGSERIAL PRIMARY KEY,
+    symbol TEXT NOT NULL,
+    timeframe TEXT NOT NULL,
+    open_time TIMESTAMPTZ NOT NULL,
+    close_time TIMESTAMPTZ NOT NULL,
+    open DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL,
+    volume DOUBLE PRECISION NOT NULL,
+    trades INTEGER DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(symbol, timeframe, open_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_candles_symbol_timeframe_time
+    ON candles(symbol, timeframe, open_time DESC);
+
+CREATE TABLE IF NOT EXISTS trades (
+    id BIGSERIAL PRIMARY KEY,
+    order_id TEXT UNIQUE NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    type TEXT NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    commission DOUBLE PRECISION DEFAULT 0,
+    commission_asset TEXT,
+    executed_at TIMESTAMPTZ NOT NULL,
+    strategy TEXT,
+    signal_strength DOUBLE PRECISION,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_symbol_time
+    ON trades(symbol, executed_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_trades_strategy
+    ON trades(strategy, executed_at DESC);
+
+CREATE TABLE IF NOT EXISTS positions (
+    id BIGSERIAL PRIMARY KEY,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    entry_price DOUBLE PRECISION NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    current_price DOUBLE PRECISION,
+    unrealized_pnl DOUBLE PRECISION DEFAULT 0,
+    realized_pnl DOUBLE PRECISION DEFAULT 0,
+    stop_loss DOUBLE PRECISION,
+    take_profit DOUBLE PRECISION,
+    strategy TEXT,
+    status TEXT DEFAULT 'open',
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_positions_symbol_status
+    ON positions(symbol, status);
+
+CREATE INDEX IF NOT EXISTS idx_positions_strategy
+    ON positions(strategy, status);
+
+CREATE TABLE IF NOT EXISTS position_pnl (
+    position_id BIGINT PRIMARY KEY REFERENCES positions(id),
+    gross_pnl DOUBLE PRECISION DEFAULT 0,
+    entry_commission DOUBLE PRECISION DEFAULT 0,
+    exit_commission DOUBLE PRECISION DEFAULT 0,
+    funding_cost DOUBLE PRECISION DEFAULT 0,
+    entry_slippage DOUBLE PRECISION DEFAULT 0,
+    exit_slippage DOUBLE PRECISION DEFAULT 0,
+    net_pnl DOUBLE PRECISION DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
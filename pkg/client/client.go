@@ -0,0 +1,155 @@
+// Package client is a typed Go client for the trading bot API. The types,
+// endpoint methods and WebSocket message types are generated from
+// internal/api/spec by cmd/sdkgen; this file and websocket.go are written
+// by hand.
+package client
+
+//go:generate go run ../../cmd/sdkgen -root ../..
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client calls the API of one server
+type Client struct {
+	baseURL string // Server URL without apiPrefix
+	http    *http.Client
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithToken sets the access token sent with requests
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// New returns a client of the server at baseURL, e.g.
+// http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), apiPrefix),
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken replaces the access token, e.g. after Login or RefreshToken
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
+
+func (c *Client) accessToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+type twoFactorKey struct{}
+
+// WithTwoFactorCode returns a context that sends code as the two-factor
+// code of the requests made with it, for endpoints that guard sensitive
+// operations
+func WithTwoFactorCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, twoFactorKey{}, code)
+}
+
+// APIError is a response with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string // The error or message field of the body, if any
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("api: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// do sends a request to path below apiPrefix with body encoded as JSON,
+// and decodes the response into out unless it is nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	u := c.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.accessToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if code, _ := ctx.Value(twoFactorKey{}).(string); code != "" {
+		req.Header.Set(twoFactorHeader, code)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: data}
+		var msg struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &msg) == nil {
+			apiErr.Message = msg.Error
+			if apiErr.Message == "" {
+				apiErr.Message = msg.Message
+			}
+		}
+		return apiErr
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,1694 @@
+// Code generated by sdkgen from internal/api/spec. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+// apiPrefix is the path endpoints are relative to
+const apiPrefix = "/api/v1"
+
+// twoFactorHeader carries the code set by WithTwoFactorCode
+const twoFactorHeader = "X-OTP-Code"
+
+// Register creates a user and logs it in.
+//
+// POST /api/v1/auth/register
+func (c *Client) Register(ctx context.Context, req *RegisterRequest) (*LoginResponse, error) {
+	var resp LoginResponse
+	if err := c.do(ctx, "POST", "/auth/register", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Login exchanges credentials for access and refresh tokens.
+//
+// POST /api/v1/auth/login
+func (c *Client) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	var resp LoginResponse
+	if err := c.do(ctx, "POST", "/auth/login", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token.
+//
+// POST /api/v1/auth/refresh
+func (c *Client) RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	var resp RefreshTokenResponse
+	if err := c.do(ctx, "POST", "/auth/refresh", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RequestPasswordReset sends a password reset email.
+//
+// POST /api/v1/auth/password-reset
+func (c *Client) RequestPasswordReset(ctx context.Context, req *PasswordResetRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/auth/password-reset", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConfirmPasswordReset sets a new password with a reset token.
+//
+// POST /api/v1/auth/password-reset/confirm
+func (c *Client) ConfirmPasswordReset(ctx context.Context, req *PasswordResetConfirm) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/auth/password-reset/confirm", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Logout revokes the caller's sessions.
+//
+// POST /api/v1/auth/logout
+func (c *Client) Logout(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/auth/logout", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetMe returns the caller's user.
+//
+// GET /api/v1/auth/me
+func (c *Client) GetMe(ctx context.Context) (*UserResponse, error) {
+	var resp UserResponse
+	if err := c.do(ctx, "GET", "/auth/me", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ChangePassword changes the caller's password.
+//
+// POST /api/v1/auth/change-password
+func (c *Client) ChangePassword(ctx context.Context, req *PasswordChangeRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/auth/change-password", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTwoFactor returns the caller's two-factor authentication status.
+//
+// GET /api/v1/auth/2fa
+func (c *Client) GetTwoFactor(ctx context.Context) (*TwoFactorStatus, error) {
+	var resp TwoFactorStatus
+	if err := c.do(ctx, "GET", "/auth/2fa", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// EnrollTwoFactor generates a TOTP secret for the caller's authenticator app.
+//
+// POST /api/v1/auth/2fa/enroll
+func (c *Client) EnrollTwoFactor(ctx context.Context) (*TwoFactorEnrollment, error) {
+	var resp TwoFactorEnrollment
+	if err := c.do(ctx, "POST", "/auth/2fa/enroll", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication with a code from the
+// enrolled app.
+//
+// POST /api/v1/auth/2fa/confirm
+func (c *Client) ConfirmTwoFactor(ctx context.Context, req *TwoFactorCodeRequest) (*TwoFactorStatus, error) {
+	var resp TwoFactorStatus
+	if err := c.do(ctx, "POST", "/auth/2fa/confirm", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DisableTwoFactor removes two-factor authentication.
+//
+// POST /api/v1/auth/2fa/disable
+func (c *Client) DisableTwoFactor(ctx context.Context, req *TwoFactorDisableRequest) (*TwoFactorStatus, error) {
+	var resp TwoFactorStatus
+	if err := c.do(ctx, "POST", "/auth/2fa/disable", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDashboard returns the account, positions and recent trades.
+//
+// GET /api/v1/dashboard
+func (c *Client) GetDashboard(ctx context.Context) (*DashboardResponse, error) {
+	var resp DashboardResponse
+	if err := c.do(ctx, "GET", "/dashboard", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDashboardSummary returns the account summary.
+//
+// GET /api/v1/dashboard/summary
+func (c *Client) GetDashboardSummary(ctx context.Context) (*AccountSummary, error) {
+	var resp AccountSummary
+	if err := c.do(ctx, "GET", "/dashboard/summary", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetEquityCurve returns the equity curve.
+//
+// GET /api/v1/dashboard/equity-curve
+func (c *Client) GetEquityCurve(ctx context.Context) ([]EquityCurvePoint, error) {
+	var resp []EquityCurvePoint
+	if err := c.do(ctx, "GET", "/dashboard/equity-curve", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPerformance returns trading performance statistics.
+//
+// GET /api/v1/dashboard/performance
+func (c *Client) GetPerformance(ctx context.Context) (*PerformanceData, error) {
+	var resp PerformanceData
+	if err := c.do(ctx, "GET", "/dashboard/performance", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetVersionPerformance returns performance by strategy parameter version.
+// Query parameters: strategy.
+//
+// GET /api/v1/dashboard/performance/versions
+func (c *Client) GetVersionPerformance(ctx context.Context, query url.Values) (*ParamVersionReport, error) {
+	var resp ParamVersionReport
+	if err := c.do(ctx, "GET", "/dashboard/performance/versions", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSessionPerformance returns performance by trading session. Query
+// parameters: strategy, days.
+//
+// GET /api/v1/dashboard/performance/sessions
+func (c *Client) GetSessionPerformance(ctx context.Context, query url.Values) (*SessionReport, error) {
+	var resp SessionReport
+	if err := c.do(ctx, "GET", "/dashboard/performance/sessions", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRegimeMatrix returns strategy performance by the market regime positions
+// were entered in. Query parameters: strategy, days.
+//
+// GET /api/v1/analytics/regime-matrix
+func (c *Client) GetRegimeMatrix(ctx context.Context, query url.Values) (*RegimeMatrix, error) {
+	var resp RegimeMatrix
+	if err := c.do(ctx, "GET", "/analytics/regime-matrix", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTradingState returns the trading state.
+//
+// GET /api/v1/trading/state
+func (c *Client) GetTradingState(ctx context.Context) (*TradingStateResponse, error) {
+	var resp TradingStateResponse
+	if err := c.do(ctx, "GET", "/trading/state", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetStateSnapshot returns the state snapshot WebSocket clients resync from.
+// Query parameters: since.
+//
+// GET /api/v1/state
+func (c *Client) GetStateSnapshot(ctx context.Context, query url.Values) (*StateSnapshot, error) {
+	var resp StateSnapshot
+	if err := c.do(ctx, "GET", "/state", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StartTrading starts trading.
+//
+// POST /api/v1/trading/start
+func (c *Client) StartTrading(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/trading/start", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StopTrading stops trading.
+//
+// POST /api/v1/trading/stop
+func (c *Client) StopTrading(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/trading/stop", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PauseTrading pauses trading.
+//
+// POST /api/v1/trading/pause
+func (c *Client) PauseTrading(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/trading/pause", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ResumeTrading resumes trading.
+//
+// POST /api/v1/trading/resume
+func (c *Client) ResumeTrading(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/trading/resume", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTradingMode returns the trading mode.
+//
+// GET /api/v1/trading/mode
+func (c *Client) GetTradingMode(ctx context.Context) (*ModeResponse, error) {
+	var resp ModeResponse
+	if err := c.do(ctx, "GET", "/trading/mode", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetTradingMode switches between paper and live trading.
+//
+// POST /api/v1/trading/mode
+func (c *Client) SetTradingMode(ctx context.Context, req *ModeRequest) (*ModeResponse, error) {
+	var resp ModeResponse
+	if err := c.do(ctx, "POST", "/trading/mode", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetArming returns the live-mode arming status.
+//
+// GET /api/v1/trading/arm
+func (c *Client) GetArming(ctx context.Context) (*ArmingStatus, error) {
+	var resp ArmingStatus
+	if err := c.do(ctx, "GET", "/trading/arm", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Arm starts arming live trading. Users with two-factor authentication must
+// pass a code with WithTwoFactorCode.
+//
+// POST /api/v1/trading/arm
+func (c *Client) Arm(ctx context.Context, req *ArmRequest) (*ArmingStatus, error) {
+	var resp ArmingStatus
+	if err := c.do(ctx, "POST", "/trading/arm", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Disarm disarms live trading.
+//
+// POST /api/v1/trading/disarm
+func (c *Client) Disarm(ctx context.Context, req *DisarmRequest) (*ArmingStatus, error) {
+	var resp ArmingStatus
+	if err := c.do(ctx, "POST", "/trading/disarm", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSchedule returns the trading schedule.
+//
+// GET /api/v1/trading/schedule
+func (c *Client) GetSchedule(ctx context.Context) (*ScheduleStatus, error) {
+	var resp ScheduleStatus
+	if err := c.do(ctx, "GET", "/trading/schedule", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetReconciliation returns exchange reconciliation results. Query parameters:
+// limit.
+//
+// GET /api/v1/trading/reconciliation
+func (c *Client) GetReconciliation(ctx context.Context, query url.Values) (*ReconciliationReport, error) {
+	var resp ReconciliationReport
+	if err := c.do(ctx, "GET", "/trading/reconciliation", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDust returns balances too small to trade.
+//
+// GET /api/v1/trading/dust
+func (c *Client) GetDust(ctx context.Context) (*DustReport, error) {
+	var resp DustReport
+	if err := c.do(ctx, "GET", "/trading/dust", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ConvertDust converts dust balances to BNB.
+//
+// POST /api/v1/trading/dust/convert
+func (c *Client) ConvertDust(ctx context.Context) (*DustConversion, error) {
+	var resp DustConversion
+	if err := c.do(ctx, "POST", "/trading/dust/convert", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetOrderIntents returns recorded order intents. Query parameters: limit.
+//
+// GET /api/v1/trading/intents
+func (c *Client) GetOrderIntents(ctx context.Context, query url.Values) ([]OrderIntent, error) {
+	var resp []OrderIntent
+	if err := c.do(ctx, "GET", "/trading/intents", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetChaos returns injected faults.
+//
+// GET /api/v1/trading/chaos
+func (c *Client) GetChaos(ctx context.Context) (*ChaosStatus, error) {
+	var resp ChaosStatus
+	if err := c.do(ctx, "GET", "/trading/chaos", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// InjectChaos injects a fault into order execution.
+//
+// POST /api/v1/trading/chaos
+func (c *Client) InjectChaos(ctx context.Context, req *ChaosRequest) (*ChaosEvent, error) {
+	var resp ChaosEvent
+	if err := c.do(ctx, "POST", "/trading/chaos", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClearChaos clears injected faults.
+//
+// DELETE /api/v1/trading/chaos
+func (c *Client) ClearChaos(ctx context.Context) (*ChaosStatus, error) {
+	var resp ChaosStatus
+	if err := c.do(ctx, "DELETE", "/trading/chaos", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetCapital returns the capital the bot trades with.
+//
+// GET /api/v1/trading/capital
+func (c *Client) GetCapital(ctx context.Context) (*SubBalanceStatus, error) {
+	var resp SubBalanceStatus
+	if err := c.do(ctx, "GET", "/trading/capital", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TransferCapital moves capital into or out of the bot's sub-balance.
+//
+// POST /api/v1/trading/capital/transfers
+func (c *Client) TransferCapital(ctx context.Context, req *CapitalTransferRequest) (*SubBalanceStatus, error) {
+	var resp SubBalanceStatus
+	if err := c.do(ctx, "POST", "/trading/capital/transfers", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetEntryPolicy returns the entry policy and its state.
+//
+// GET /api/v1/trading/entry
+func (c *Client) GetEntryPolicy(ctx context.Context) (*EntryStatus, error) {
+	var resp EntryStatus
+	if err := c.do(ctx, "GET", "/trading/entry", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetInbox returns trade ideas awaiting or past approval. Query parameters:
+// status, limit.
+//
+// GET /api/v1/inbox
+func (c *Client) GetInbox(ctx context.Context, query url.Values) (*InboxResponse, error) {
+	var resp InboxResponse
+	if err := c.do(ctx, "GET", "/inbox", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ApproveIdea approves a trade idea.
+//
+// POST /api/v1/inbox/:id/approve
+func (c *Client) ApproveIdea(ctx context.Context, id string, req *IdeaDecisionRequest) (*TradeIdea, error) {
+	var resp TradeIdea
+	if err := c.do(ctx, "POST", "/inbox/"+url.PathEscape(id)+"/approve", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RejectIdea rejects a trade idea.
+//
+// POST /api/v1/inbox/:id/reject
+func (c *Client) RejectIdea(ctx context.Context, id string, req *IdeaDecisionRequest) (*TradeIdea, error) {
+	var resp TradeIdea
+	if err := c.do(ctx, "POST", "/inbox/"+url.PathEscape(id)+"/reject", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetCopyTrading returns copy trading followers and leaders.
+//
+// GET /api/v1/copy
+func (c *Client) GetCopyTrading(ctx context.Context) (*CopyTradingStatus, error) {
+	var resp CopyTradingStatus
+	if err := c.do(ctx, "GET", "/copy", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AddFollower adds a follower signals are pushed to.
+//
+// POST /api/v1/copy/followers
+func (c *Client) AddFollower(ctx context.Context, req *FollowerRequest) (*CopyFollower, error) {
+	var resp CopyFollower
+	if err := c.do(ctx, "POST", "/copy/followers", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveFollower removes a follower.
+//
+// DELETE /api/v1/copy/followers/:name
+func (c *Client) RemoveFollower(ctx context.Context, name string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "DELETE", "/copy/followers/"+url.PathEscape(name), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrategies returns the strategies and their performance.
+//
+// GET /api/v1/strategies
+func (c *Client) GetStrategies(ctx context.Context) ([]StrategyInfo, error) {
+	var resp []StrategyInfo
+	if err := c.do(ctx, "GET", "/strategies", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrategyPreview returns the strategies that would trade in a regime. Query
+// parameters: regime.
+//
+// GET /api/v1/strategies/preview
+func (c *Client) GetStrategyPreview(ctx context.Context, query url.Values) (*StrategyPreviewResponse, error) {
+	var resp StrategyPreviewResponse
+	if err := c.do(ctx, "GET", "/strategies/preview", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetParamDrift returns strategy parameter drift from optimized values.
+//
+// GET /api/v1/strategies/drift
+func (c *Client) GetParamDrift(ctx context.Context) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "GET", "/strategies/drift", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrategyLeaderboard ranks strategies by realized performance over a
+// rolling window. Query parameters: window, sort.
+//
+// GET /api/v1/strategies/leaderboard
+func (c *Client) GetStrategyLeaderboard(ctx context.Context, query url.Values) (*Leaderboard, error) {
+	var resp Leaderboard
+	if err := c.do(ctx, "GET", "/strategies/leaderboard", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetStrategy returns a strategy.
+//
+// GET /api/v1/strategies/:name
+func (c *Client) GetStrategy(ctx context.Context, name string) (*StrategyInfo, error) {
+	var resp StrategyInfo
+	if err := c.do(ctx, "GET", "/strategies/"+url.PathEscape(name), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateStrategy updates a strategy's parameters.
+//
+// PUT /api/v1/strategies/:name
+func (c *Client) UpdateStrategy(ctx context.Context, name string, req *UpdateStrategyRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "PUT", "/strategies/"+url.PathEscape(name), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EnableStrategy enables a strategy.
+//
+// POST /api/v1/strategies/:name/enable
+func (c *Client) EnableStrategy(ctx context.Context, name string) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/strategies/"+url.PathEscape(name)+"/enable", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DisableStrategy disables a strategy.
+//
+// POST /api/v1/strategies/:name/disable
+func (c *Client) DisableStrategy(ctx context.Context, name string) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/strategies/"+url.PathEscape(name)+"/disable", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ApplyOptimizedParams reconfigures a strategy with its optimized parameters.
+// Query parameters: source.
+//
+// POST /api/v1/strategies/:name/apply-optimized
+func (c *Client) ApplyOptimizedParams(ctx context.Context, name string, query url.Values) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/strategies/"+url.PathEscape(name)+"/apply-optimized", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrategySignals returns a strategy's recent signals.
+//
+// GET /api/v1/strategies/:name/signals
+func (c *Client) GetStrategySignals(ctx context.Context, name string) ([]SignalInfo, error) {
+	var resp []SignalInfo
+	if err := c.do(ctx, "GET", "/strategies/"+url.PathEscape(name)+"/signals", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRegime returns the detected market regime.
+//
+// GET /api/v1/regime
+func (c *Client) GetRegime(ctx context.Context) (*RegimeInfo, error) {
+	var resp RegimeInfo
+	if err := c.do(ctx, "GET", "/regime", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetScore returns the scorer breakdown of the latest analysis.
+//
+// GET /api/v1/score
+func (c *Client) GetScore(ctx context.Context) (*ScoreUpdate, error) {
+	var resp ScoreUpdate
+	if err := c.do(ctx, "GET", "/score", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAllocations returns capital allocated to each strategy.
+//
+// GET /api/v1/allocation
+func (c *Client) GetAllocations(ctx context.Context) (*AllocationResponse, error) {
+	var resp AllocationResponse
+	if err := c.do(ctx, "GET", "/allocation", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAllocationHistory returns past allocations. Query parameters: limit.
+//
+// GET /api/v1/allocation/history
+func (c *Client) GetAllocationHistory(ctx context.Context, query url.Values) ([]AllocationSnapshot, error) {
+	var resp []AllocationSnapshot
+	if err := c.do(ctx, "GET", "/allocation/history", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Rebalance reallocates capital between strategies.
+//
+// POST /api/v1/allocation/rebalance
+func (c *Client) Rebalance(ctx context.Context) (*AllocationSnapshot, error) {
+	var resp AllocationSnapshot
+	if err := c.do(ctx, "POST", "/allocation/rebalance", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRiskStatus returns the risk status.
+//
+// GET /api/v1/risk
+func (c *Client) GetRiskStatus(ctx context.Context) (*RiskStatusResponse, error) {
+	var resp RiskStatusResponse
+	if err := c.do(ctx, "GET", "/risk", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRiskConfig returns the risk limits.
+//
+// GET /api/v1/risk/config
+func (c *Client) GetRiskConfig(ctx context.Context) (*RiskConfigResponse, error) {
+	var resp RiskConfigResponse
+	if err := c.do(ctx, "GET", "/risk/config", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateRiskConfig changes risk limits.
+//
+// PUT /api/v1/risk/config
+func (c *Client) UpdateRiskConfig(ctx context.Context, req *UpdateConfigRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "PUT", "/risk/config", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRiskLimits returns how much of each risk limit is used. Query parameters:
+// events.
+//
+// GET /api/v1/risk/limits
+func (c *Client) GetRiskLimits(ctx context.Context, query url.Values) (*RiskLimitsResponse, error) {
+	var resp RiskLimitsResponse
+	if err := c.do(ctx, "GET", "/risk/limits", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDrawdown returns drawdown from the high-water mark.
+//
+// GET /api/v1/risk/drawdown
+func (c *Client) GetDrawdown(ctx context.Context) (*DrawdownResponse, error) {
+	var resp DrawdownResponse
+	if err := c.do(ctx, "GET", "/risk/drawdown", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResetHighWaterMark resets the high-water mark to current equity.
+//
+// POST /api/v1/risk/high-water-mark/reset
+func (c *Client) ResetHighWaterMark(ctx context.Context) (*HighWaterMark, error) {
+	var resp HighWaterMark
+	if err := c.do(ctx, "POST", "/risk/high-water-mark/reset", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RecordCashFlow records a deposit or withdrawal.
+//
+// POST /api/v1/risk/cash-flow
+func (c *Client) RecordCashFlow(ctx context.Context, req *CashFlowRequest) (*HighWaterMark, error) {
+	var resp HighWaterMark
+	if err := c.do(ctx, "POST", "/risk/cash-flow", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRiskEvents returns recent risk events.
+//
+// GET /api/v1/risk/events
+func (c *Client) GetRiskEvents(ctx context.Context) ([]RiskEventResponse, error) {
+	var resp []RiskEventResponse
+	if err := c.do(ctx, "GET", "/risk/events", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRiskReports returns daily risk digests.
+//
+// GET /api/v1/risk/reports
+func (c *Client) GetRiskReports(ctx context.Context) (*RiskReports, error) {
+	var resp RiskReports
+	if err := c.do(ctx, "GET", "/risk/reports", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResetCircuitBreaker resets a tripped circuit breaker. Users with two-factor
+// authentication must pass a code with WithTwoFactorCode.
+//
+// POST /api/v1/risk/circuit-breaker/reset
+func (c *Client) ResetCircuitBreaker(ctx context.Context) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/risk/circuit-breaker/reset", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Halt halts trading.
+//
+// POST /api/v1/risk/halt
+func (c *Client) Halt(ctx context.Context, req *HaltRequest) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/risk/halt", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ResumeFromHalt lifts a halt. Users with two-factor authentication must pass a
+// code with WithTwoFactorCode.
+//
+// POST /api/v1/risk/resume
+func (c *Client) ResumeFromHalt(ctx context.Context) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/risk/resume", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPositions returns open positions.
+//
+// GET /api/v1/positions
+func (c *Client) GetPositions(ctx context.Context) ([]PositionData, error) {
+	var resp []PositionData
+	if err := c.do(ctx, "GET", "/positions", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPosition returns a position.
+//
+// GET /api/v1/positions/:id
+func (c *Client) GetPosition(ctx context.Context, id string) (*PositionData, error) {
+	var resp PositionData
+	if err := c.do(ctx, "GET", "/positions/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClosePosition closes a position at market.
+//
+// POST /api/v1/positions/:id/close
+func (c *Client) ClosePosition(ctx context.Context, id string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "POST", "/positions/"+url.PathEscape(id)+"/close", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateStopLoss moves a position's stop loss.
+//
+// PUT /api/v1/positions/:id/stop-loss
+func (c *Client) UpdateStopLoss(ctx context.Context, id string, req *UpdateStopLossRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "PUT", "/positions/"+url.PathEscape(id)+"/stop-loss", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateTakeProfit moves a position's take profit.
+//
+// PUT /api/v1/positions/:id/take-profit
+func (c *Client) UpdateTakeProfit(ctx context.Context, id string, req *UpdateTakeProfitRequest) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "PUT", "/positions/"+url.PathEscape(id)+"/take-profit", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOrders returns order history. Query parameters: symbol, status, strategy,
+// limit.
+//
+// GET /api/v1/orders
+func (c *Client) GetOrders(ctx context.Context, query url.Values) ([]OrderData, error) {
+	var resp []OrderData
+	if err := c.do(ctx, "GET", "/orders", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOpenOrders returns open orders. Query parameters: symbol.
+//
+// GET /api/v1/orders/open
+func (c *Client) GetOpenOrders(ctx context.Context, query url.Values) ([]OrderData, error) {
+	var resp []OrderData
+	if err := c.do(ctx, "GET", "/orders/open", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOrder returns an order and its state transitions.
+//
+// GET /api/v1/orders/:id
+func (c *Client) GetOrder(ctx context.Context, id string) (*OrderData, error) {
+	var resp OrderData
+	if err := c.do(ctx, "GET", "/orders/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PlaceOrder places a manual order.
+//
+// POST /api/v1/orders
+func (c *Client) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	var resp PlaceOrderResponse
+	if err := c.do(ctx, "POST", "/orders", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelOrder cancels an open order.
+//
+// DELETE /api/v1/orders/:id
+func (c *Client) CancelOrder(ctx context.Context, id string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "DELETE", "/orders/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLogs returns recent application events. Query parameters: limit, level,
+// category.
+//
+// GET /api/v1/logs
+func (c *Client) GetLogs(ctx context.Context, query url.Values) ([]Event, error) {
+	var resp []Event
+	if err := c.do(ctx, "GET", "/logs", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateBackup backs up the trading database.
+//
+// POST /api/v1/system/backup
+func (c *Client) CreateBackup(ctx context.Context) (*BackupRecord, error) {
+	var resp BackupRecord
+	if err := c.do(ctx, "POST", "/system/backup", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBackups returns past backups. Query parameters: limit.
+//
+// GET /api/v1/system/backups
+func (c *Client) GetBackups(ctx context.Context, query url.Values) ([]BackupRecord, error) {
+	var resp []BackupRecord
+	if err := c.do(ctx, "GET", "/system/backups", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSubscriptions returns the subscribed Binance streams.
+//
+// GET /api/v1/system/subscriptions
+func (c *Client) GetSubscriptions(ctx context.Context) ([]StreamSubscription, error) {
+	var resp []StreamSubscription
+	if err := c.do(ctx, "GET", "/system/subscriptions", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddSubscription subscribes to a Binance stream.
+//
+// POST /api/v1/system/subscriptions
+func (c *Client) AddSubscription(ctx context.Context, req *SubscriptionRequest) (*StreamSubscription, error) {
+	var resp StreamSubscription
+	if err := c.do(ctx, "POST", "/system/subscriptions", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveSubscription unsubscribes from a Binance stream. Query parameters:
+// stream.
+//
+// DELETE /api/v1/system/subscriptions
+func (c *Client) RemoveSubscription(ctx context.Context, query url.Values) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.do(ctx, "DELETE", "/system/subscriptions", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTradeHistory returns persisted trades. Query parameters: from, to,
+// strategy, limit.
+//
+// GET /api/v1/history/trades
+func (c *Client) GetTradeHistory(ctx context.Context, query url.Values) ([]TradeHistoryData, error) {
+	var resp []TradeHistoryData
+	if err := c.do(ctx, "GET", "/history/trades", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPositionHistory returns persisted positions. Query parameters: status,
+// limit.
+//
+// GET /api/v1/history/positions
+func (c *Client) GetPositionHistory(ctx context.Context, query url.Values) ([]PositionHistoryData, error) {
+	var resp []PositionHistoryData
+	if err := c.do(ctx, "GET", "/history/positions", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StartHistoryImport imports trade history from the exchange.
+//
+// POST /api/v1/history/import
+func (c *Client) StartHistoryImport(ctx context.Context, req *HistoryImportRequest) (*HistoryImportReport, error) {
+	var resp HistoryImportReport
+	if err := c.do(ctx, "POST", "/history/import", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetHistoryImport returns the progress of the history import.
+//
+// GET /api/v1/history/import
+func (c *Client) GetHistoryImport(ctx context.Context) (*HistoryImportReport, error) {
+	var resp HistoryImportReport
+	if err := c.do(ctx, "GET", "/history/import", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAuditLog returns the audit trail of order, configuration, strategy and
+// risk actions. Query parameters: category, action, actor, resource, accountId,
+// from, to, limit.
+//
+// GET /api/v1/audit
+func (c *Client) GetAuditLog(ctx context.Context, query url.Values) ([]AuditEntry, error) {
+	var resp []AuditEntry
+	if err := c.do(ctx, "GET", "/audit", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ExportShareBundle exports anonymized results as a bundle. Query parameters:
+// name, candles.
+//
+// GET /api/v1/share/export
+func (c *Client) ExportShareBundle(ctx context.Context, query url.Values) (*ShareBundle, error) {
+	var resp ShareBundle
+	if err := c.do(ctx, "GET", "/share/export", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportShareBundle imports a bundle shared by another user.
+//
+// POST /api/v1/share/import
+func (c *Client) ImportShareBundle(ctx context.Context, req *ShareBundle) (*SharedBundle, error) {
+	var resp SharedBundle
+	if err := c.do(ctx, "POST", "/share/import", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetShareBundles returns imported bundles.
+//
+// GET /api/v1/share/bundles
+func (c *Client) GetShareBundles(ctx context.Context) ([]SharedBundle, error) {
+	var resp []SharedBundle
+	if err := c.do(ctx, "GET", "/share/bundles", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetShareBundle returns an imported bundle.
+//
+// GET /api/v1/share/bundles/:id
+func (c *Client) GetShareBundle(ctx context.Context, id string) (*SharedBundle, error) {
+	var resp SharedBundle
+	if err := c.do(ctx, "GET", "/share/bundles/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteShareBundle deletes an imported bundle.
+//
+// DELETE /api/v1/share/bundles/:id
+func (c *Client) DeleteShareBundle(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/share/bundles/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ListAccounts returns the caller's trading accounts.
+//
+// GET /api/v1/accounts
+func (c *Client) ListAccounts(ctx context.Context) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "GET", "/accounts", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateAccount adds a trading account. Users with two-factor authentication
+// must pass a code with WithTwoFactorCode.
+//
+// POST /api/v1/accounts
+func (c *Client) CreateAccount(ctx context.Context, req *TradingAccountCreateRequest) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "POST", "/accounts", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAccount returns a trading account.
+//
+// GET /api/v1/accounts/:id
+func (c *Client) GetAccount(ctx context.Context, id string) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "GET", "/accounts/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateAccount changes a trading account's settings.
+//
+// PUT /api/v1/accounts/:id
+func (c *Client) UpdateAccount(ctx context.Context, id string, req *TradingAccountUpdateRequest) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "PUT", "/accounts/"+url.PathEscape(id), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LinkAccountKeys links Binance API keys to a live account. Users with
+// two-factor authentication must pass a code with WithTwoFactorCode.
+//
+// PUT /api/v1/accounts/:id/keys
+func (c *Client) LinkAccountKeys(ctx context.Context, id string, req *BinanceKeysRequest) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "PUT", "/accounts/"+url.PathEscape(id)+"/keys", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StartAccount starts a trading account's executor. Users with two-factor
+// authentication must pass a code with WithTwoFactorCode.
+//
+// POST /api/v1/accounts/:id/start
+func (c *Client) StartAccount(ctx context.Context, id string) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "POST", "/accounts/"+url.PathEscape(id)+"/start", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StopAccount stops a trading account's executor.
+//
+// POST /api/v1/accounts/:id/stop
+func (c *Client) StopAccount(ctx context.Context, id string) (*AccountResponse, error) {
+	var resp AccountResponse
+	if err := c.do(ctx, "POST", "/accounts/"+url.PathEscape(id)+"/stop", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetReportLocale returns the caller's report formatting.
+//
+// GET /api/v1/reports/locale
+func (c *Client) GetReportLocale(ctx context.Context) (*ReportLocaleResponse, error) {
+	var resp ReportLocaleResponse
+	if err := c.do(ctx, "GET", "/reports/locale", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateReportLocale changes the caller's report formatting.
+//
+// PUT /api/v1/reports/locale
+func (c *Client) UpdateReportLocale(ctx context.Context, req *ReportLocale) (*ReportLocaleResponse, error) {
+	var resp ReportLocaleResponse
+	if err := c.do(ctx, "PUT", "/reports/locale", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetCandles returns candles. Query parameters: symbol, timeframe, limit, from,
+// to.
+//
+// GET /api/v1/candles
+func (c *Client) GetCandles(ctx context.Context, query url.Values) ([]CandleData, error) {
+	var resp []CandleData
+	if err := c.do(ctx, "GET", "/candles", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSymbolCandles returns candles of a symbol and timeframe. Query parameters:
+// limit, from, to.
+//
+// GET /api/v1/candles/:symbol/:timeframe
+func (c *Client) GetSymbolCandles(ctx context.Context, symbol string, timeframe string, query url.Values) ([]CandleData, error) {
+	var resp []CandleData
+	if err := c.do(ctx, "GET", "/candles/"+url.PathEscape(symbol)+"/"+url.PathEscape(timeframe), query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTicker returns the 24h ticker. Query parameters: symbol.
+//
+// GET /api/v1/ticker
+func (c *Client) GetTicker(ctx context.Context, query url.Values) (*TickerData, error) {
+	var resp TickerData
+	if err := c.do(ctx, "GET", "/ticker", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetIndicators returns the latest indicator values. Query parameters: symbol,
+// timeframe.
+//
+// GET /api/v1/indicators
+func (c *Client) GetIndicators(ctx context.Context, query url.Values) (*IndicatorData, error) {
+	var resp IndicatorData
+	if err := c.do(ctx, "GET", "/indicators", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTape returns recent trades bucketed by time. Query parameters: bucket,
+// limit.
+//
+// GET /api/v1/tape
+func (c *Client) GetTape(ctx context.Context, query url.Values) (*Tape, error) {
+	var resp Tape
+	if err := c.do(ctx, "GET", "/tape", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetOrderBook returns the order book. Query parameters: levels, quantity.
+//
+// GET /api/v1/orderbook
+func (c *Client) GetOrderBook(ctx context.Context, query url.Values) (*OrderBookView, error) {
+	var resp OrderBookView
+	if err := c.do(ctx, "GET", "/orderbook", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPriceSources returns the price the bot acts on and its sources.
+//
+// GET /api/v1/price/sources
+func (c *Client) GetPriceSources(ctx context.Context) (*PriceArbiterStatus, error) {
+	var resp PriceArbiterStatus
+	if err := c.do(ctx, "GET", "/price/sources", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSymbolInfo returns a symbol's exchange trading rules. Query parameters:
+// refresh.
+//
+// GET /api/v1/symbols/:symbol/info
+func (c *Client) GetSymbolInfo(ctx context.Context, symbol string, query url.Values) (*SymbolRules, error) {
+	var resp SymbolRules
+	if err := c.do(ctx, "GET", "/symbols/"+url.PathEscape(symbol)+"/info", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBandwidth returns WebSocket bandwidth and message rates.
+//
+// GET /api/v1/metrics/bandwidth
+func (c *Client) GetBandwidth(ctx context.Context) (*BandwidthResponse, error) {
+	var resp BandwidthResponse
+	if err := c.do(ctx, "GET", "/metrics/bandwidth", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRateLimit returns Binance request weight usage.
+//
+// GET /api/v1/metrics/rate-limit
+func (c *Client) GetRateLimit(ctx context.Context) (*RequestWeight, error) {
+	var resp RequestWeight
+	if err := c.do(ctx, "GET", "/metrics/rate-limit", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLatency returns pipeline stage timings.
+//
+// GET /api/v1/metrics/latency
+func (c *Client) GetLatency(ctx context.Context) (*LatencyReport, error) {
+	var resp LatencyReport
+	if err := c.do(ctx, "GET", "/metrics/latency", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetEvaluation returns strategy evaluation worker statistics.
+//
+// GET /api/v1/metrics/evaluation
+func (c *Client) GetEvaluation(ctx context.Context) (*EvalPoolStats, error) {
+	var resp EvalPoolStats
+	if err := c.do(ctx, "GET", "/metrics/evaluation", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetScan returns candidate symbols ranked by fit. Query parameters: refresh.
+//
+// GET /api/v1/scan
+func (c *Client) GetScan(ctx context.Context, query url.Values) (*Report, error) {
+	var resp Report
+	if err := c.do(ctx, "GET", "/scan", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetIndicatorSeries returns precomputed indicator series. Query parameters:
+// timeframe, columns, from, to.
+//
+// GET /api/v1/indicators/series
+func (c *Client) GetIndicatorSeries(ctx context.Context, query url.Values) (*IndicatorSeriesResponse, error) {
+	var resp IndicatorSeriesResponse
+	if err := c.do(ctx, "GET", "/indicators/series", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetIndicatorCoverage returns the time ranges indicator series cover. Query
+// parameters: timeframe.
+//
+// GET /api/v1/indicators/series/coverage
+func (c *Client) GetIndicatorCoverage(ctx context.Context, query url.Values) (*IndicatorCoverageResponse, error) {
+	var resp IndicatorCoverageResponse
+	if err := c.do(ctx, "GET", "/indicators/series/coverage", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PrecomputeIndicators precomputes indicator series.
+//
+// POST /api/v1/indicators/precompute
+func (c *Client) PrecomputeIndicators(ctx context.Context, req *PrecomputeRequest) (*IndicatorPrecomputeResult, error) {
+	var resp IndicatorPrecomputeResult
+	if err := c.do(ctx, "POST", "/indicators/precompute", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RunBacktest queues a backtest.
+//
+// POST /api/v1/backtest
+func (c *Client) RunBacktest(ctx context.Context, req *BacktestRequest) (*BacktestJobResponse, error) {
+	var resp BacktestJobResponse
+	if err := c.do(ctx, "POST", "/backtest", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Optimize runs a parameter search.
+//
+// POST /api/v1/backtest/optimize
+func (c *Client) Optimize(ctx context.Context, req *OptimizeRequest) (*OptimizeResponse, error) {
+	var resp OptimizeResponse
+	if err := c.do(ctx, "POST", "/backtest/optimize", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBacktestResults returns completed backtests. Query parameters: limit.
+//
+// GET /api/v1/backtest/results
+func (c *Client) GetBacktestResults(ctx context.Context, query url.Values) ([]BacktestResultSummary, error) {
+	var resp []BacktestResultSummary
+	if err := c.do(ctx, "GET", "/backtest/results", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBacktestResult returns a completed backtest.
+//
+// GET /api/v1/backtest/results/:id
+func (c *Client) GetBacktestResult(ctx context.Context, id string) (*BacktestJobResponse, error) {
+	var resp BacktestJobResponse
+	if err := c.do(ctx, "GET", "/backtest/results/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBacktest returns a backtest job and its result once done.
+//
+// GET /api/v1/backtest/:id
+func (c *Client) GetBacktest(ctx context.Context, id string) (*BacktestJobResponse, error) {
+	var resp BacktestJobResponse
+	if err := c.do(ctx, "GET", "/backtest/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RunSandbox backtests an inline strategy script.
+//
+// POST /api/v1/sandbox/run
+func (c *Client) RunSandbox(ctx context.Context, req *SandboxRequest) (*SandboxResponse, error) {
+	var resp SandboxResponse
+	if err := c.do(ctx, "POST", "/sandbox/run", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSettings returns all settings.
+//
+// GET /api/v1/settings
+func (c *Client) GetSettings(ctx context.Context) (*FullSettingsResponse, error) {
+	var resp FullSettingsResponse
+	if err := c.do(ctx, "GET", "/settings", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResetSettings resets all settings to defaults.
+//
+// POST /api/v1/settings/reset
+func (c *Client) ResetSettings(ctx context.Context) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/settings/reset", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTradingSettings returns trading settings.
+//
+// GET /api/v1/settings/trading
+func (c *Client) GetTradingSettings(ctx context.Context) (*TradingSettings, error) {
+	var resp TradingSettings
+	if err := c.do(ctx, "GET", "/settings/trading", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateTradingSettings changes trading settings.
+//
+// PUT /api/v1/settings/trading
+func (c *Client) UpdateTradingSettings(ctx context.Context, req *TradingSettings) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/trading", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBinanceSettings returns Binance settings.
+//
+// GET /api/v1/settings/binance
+func (c *Client) GetBinanceSettings(ctx context.Context) (*BinanceSettings, error) {
+	var resp BinanceSettings
+	if err := c.do(ctx, "GET", "/settings/binance", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateBinanceSettings changes Binance settings.
+//
+// PUT /api/v1/settings/binance
+func (c *Client) UpdateBinanceSettings(ctx context.Context, req *BinanceSettings) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/binance", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRiskSettings returns risk settings.
+//
+// GET /api/v1/settings/risk
+func (c *Client) GetRiskSettings(ctx context.Context) (*RiskSettings, error) {
+	var resp RiskSettings
+	if err := c.do(ctx, "GET", "/settings/risk", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateRiskSettings changes risk settings.
+//
+// PUT /api/v1/settings/risk
+func (c *Client) UpdateRiskSettings(ctx context.Context, req *RiskSettings) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/risk", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetIndicatorSettings returns indicator settings.
+//
+// GET /api/v1/settings/indicators
+func (c *Client) GetIndicatorSettings(ctx context.Context) (*IndicatorSettings, error) {
+	var resp IndicatorSettings
+	if err := c.do(ctx, "GET", "/settings/indicators", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateIndicatorSettings changes indicator settings.
+//
+// PUT /api/v1/settings/indicators
+func (c *Client) UpdateIndicatorSettings(ctx context.Context, req *IndicatorSettings) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/indicators", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrategySettings returns strategy settings.
+//
+// GET /api/v1/settings/strategies
+func (c *Client) GetStrategySettings(ctx context.Context) (*StrategySettings, error) {
+	var resp StrategySettings
+	if err := c.do(ctx, "GET", "/settings/strategies", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateStrategySettings changes strategy settings.
+//
+// PUT /api/v1/settings/strategies
+func (c *Client) UpdateStrategySettings(ctx context.Context, req *StrategySettings) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/strategies", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSymbolSettings returns per-symbol overrides.
+//
+// GET /api/v1/settings/symbols
+func (c *Client) GetSymbolSettings(ctx context.Context) (map[string]SymbolConfig, error) {
+	var resp map[string]SymbolConfig
+	if err := c.do(ctx, "GET", "/settings/symbols", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateSymbolSettings sets a symbol's overrides.
+//
+// PUT /api/v1/settings/symbols/:symbol
+func (c *Client) UpdateSymbolSettings(ctx context.Context, symbol string, req *SymbolConfig) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "PUT", "/settings/symbols/"+url.PathEscape(symbol), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteSymbolSettings removes a symbol's overrides.
+//
+// DELETE /api/v1/settings/symbols/:symbol
+func (c *Client) DeleteSymbolSettings(ctx context.Context, symbol string) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "DELETE", "/settings/symbols/"+url.PathEscape(symbol), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSettingsHistory returns the settings audit trail. Query parameters:
+// section, limit.
+//
+// GET /api/v1/settings/history
+func (c *Client) GetSettingsHistory(ctx context.Context, query url.Values) ([]SettingsChangeResponse, error) {
+	var resp []SettingsChangeResponse
+	if err := c.do(ctx, "GET", "/settings/history", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RollbackSettings restores a settings section to a past version.
+//
+// POST /api/v1/settings/rollback/:versionId
+func (c *Client) RollbackSettings(ctx context.Context, versionId string) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	if err := c.do(ctx, "POST", "/settings/rollback/"+url.PathEscape(versionId), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetReloadStatus returns the configuration changes applied at runtime and
+// those waiting for a restart.
+//
+// GET /api/v1/settings/reload
+func (c *Client) GetReloadStatus(ctx context.Context) (*ReloadStatus, error) {
+	var resp ReloadStatus
+	if err := c.do(ctx, "GET", "/settings/reload", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReloadSettings reloads config.yaml into the running bot.
+//
+// POST /api/v1/settings/reload
+func (c *Client) ReloadSettings(ctx context.Context) (*ReloadStatus, error) {
+	var resp ReloadStatus
+	if err := c.do(ctx, "POST", "/settings/reload", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,77 @@
+// Code generated by sdkgen from internal/api/spec. DO NOT EDIT.
+
+package client
+
+// Message types sent by the server
+const (
+	MessageTypeState         = "state"         // Periodic trading state
+	MessageTypeCandle        = "candle"        // Candle updates and closes
+	MessageTypeSignal        = "signal"        // Strategy signals and their risk verdict
+	MessageTypeTrade         = "trade"         // Fills
+	MessageTypePosition      = "position"      // Positions opened, changed or closed
+	MessageTypeRisk          = "risk"          // Risk state and risk events
+	MessageTypeError         = "error"         // Errors worth showing to users
+	MessageTypeIndicators    = "indicators"    // Indicator values of the primary timeframe
+	MessageTypePrice         = "price"         // Real-time prices
+	MessageTypeMode          = "mode"          // Scheduled trading mode transitions
+	MessageTypeArming        = "arming"        // Live-mode arming transitions
+	MessageTypeTradeIdea     = "trade_idea"    // Signals queued for or decided in the approval inbox
+	MessageTypeScore         = "score"         // Scorer breakdown of the latest analysis
+	MessageTypeBacktest      = "backtest"      // Backtest job progress and completion
+	MessageTypeStrategy      = "strategy"      // Strategies enabled or disabled at runtime
+	MessageTypeRiskReport    = "risk_report"   // Daily risk digest
+	MessageTypeParamDrift    = "param_drift"   // Strategy parameters drifted from optimized values
+	MessageTypeSubscriptions = "subscriptions" // The client's subscriptions, in reply to subscribe and unsubscribe
+	MessageTypePong          = "pong"          // Reply to ping
+)
+
+// Message types sent by clients
+const (
+	MessageTypeSubscribe   = "subscribe"   // Receive more message types; none = all
+	MessageTypeUnsubscribe = "unsubscribe" // Stop receiving message types; none = all
+	MessageTypePing        = "ping"        // Keepalive, answered with pong
+)
+
+// newMessageData returns a value to decode the data of a server message
+// into, nil for messages without data and unknown types
+func newMessageData(msgType string) interface{} {
+	switch msgType {
+	case MessageTypeState:
+		return new(StateUpdate)
+	case MessageTypeCandle:
+		return new(CandleUpdate)
+	case MessageTypeSignal:
+		return new(SignalUpdate)
+	case MessageTypeTrade:
+		return new(TradeUpdate)
+	case MessageTypePosition:
+		return new(PositionUpdate)
+	case MessageTypeRisk:
+		return new(RiskUpdate)
+	case MessageTypeError:
+		return new(ErrorUpdate)
+	case MessageTypeIndicators:
+		return new(IndicatorsUpdate)
+	case MessageTypePrice:
+		return new(PriceUpdate)
+	case MessageTypeMode:
+		return new(ModeUpdate)
+	case MessageTypeArming:
+		return new(ArmingStatus)
+	case MessageTypeTradeIdea:
+		return new(TradeIdea)
+	case MessageTypeScore:
+		return new(ScoreUpdate)
+	case MessageTypeBacktest:
+		return new(BacktestJob)
+	case MessageTypeStrategy:
+		return new(StrategyStateUpdate)
+	case MessageTypeRiskReport:
+		return new(RiskReport)
+	case MessageTypeParamDrift:
+		return new(ParamDriftReport)
+	case MessageTypeSubscriptions:
+		return new(Subscriptions)
+	}
+	return nil
+}
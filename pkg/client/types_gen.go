@@ -0,0 +1,2541 @@
+// Code generated by sdkgen from internal/api/spec. DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ADXData represents ADX indicator values
+type ADXData struct {
+	ADX     float64 `json:"adx"`
+	PlusDI  float64 `json:"plusDI"`
+	MinusDI float64 `json:"minusDI"`
+	Trend   string  `json:"trend"`
+}
+
+// ADXValue represents ADX values
+type ADXValue struct {
+	ADX     float64 `json:"adx"`
+	PlusDI  float64 `json:"plusDI"`
+	MinusDI float64 `json:"minusDI"`
+}
+
+// AccountResponse is a trading account and, while it runs, its executor
+type AccountResponse struct {
+	*TradingAccountResponse
+	Executor *AccountStatus `json:"executor,omitempty"`
+}
+
+// AccountRiskConfig overrides the bot's risk limits for a trading account's own
+// executor. Unset fields keep the bot's values.
+type AccountRiskConfig struct {
+	MaxPositionSize    *float64 `json:"max_position_size,omitempty"`  // Fraction of equity
+	MaxRiskPerTrade    *float64 `json:"max_risk_per_trade,omitempty"` // Fraction of equity
+	MaxDailyLoss       *float64 `json:"max_daily_loss,omitempty"`     // Fraction of equity
+	MaxWeeklyLoss      *float64 `json:"max_weekly_loss,omitempty"`    // Fraction of equity
+	MaxDrawdown        *float64 `json:"max_drawdown,omitempty"`       // Fraction of peak equity
+	MaxOpenPositions   *int     `json:"max_open_positions,omitempty"`
+	MinRiskRewardRatio *float64 `json:"min_risk_reward_ratio,omitempty"`
+}
+
+// AccountStatus is the state of a user's running account executor
+type AccountStatus struct {
+	AccountID     string            `json:"accountId"`
+	UserID        string            `json:"userId"`
+	Name          string            `json:"name"`
+	Mode          ModelsTradingMode `json:"mode"`
+	StartedAt     time.Time         `json:"startedAt"`
+	Equity        float64           `json:"equity"`
+	DailyPnL      float64           `json:"dailyPnl"`
+	WeeklyPnL     float64           `json:"weeklyPnl"`
+	OpenPositions int               `json:"openPositions"`
+	Trades        int               `json:"trades"`   // Orders placed since start
+	Rejected      int               `json:"rejected"` // Signals the account's risk manager rejected
+	LastReject    string            `json:"lastReject,omitempty"`
+	Halted        bool              `json:"halted"`
+}
+
+// AccountSummary represents account summary for API
+type AccountSummary struct {
+	Equity           float64 `json:"equity"`
+	AvailableBalance float64 `json:"availableBalance"`
+	UsedMargin       float64 `json:"usedMargin"`
+	UnrealizedPnL    float64 `json:"unrealizedPnL"`
+	RealizedPnL      float64 `json:"realizedPnL"`
+	DailyPnL         float64 `json:"dailyPnL"`
+	WeeklyPnL        float64 `json:"weeklyPnL"`
+	TotalReturn      float64 `json:"totalReturn"`
+	OpenPositions    int     `json:"openPositions"`
+	TotalTrades      int     `json:"totalTrades"`
+	WinningTrades    int     `json:"winningTrades"`
+	LosingTrades     int     `json:"losingTrades"`
+	WinRate          float64 `json:"winRate"`
+	ProfitFactor     float64 `json:"profitFactor"`
+}
+
+// AccountType represents the type of trading account
+type AccountType string
+
+// AllocationMode determines how strategy weights are assigned
+type AllocationMode string
+
+// AllocationResponse represents the current capital allocation
+type AllocationResponse struct {
+	Mode              string               `json:"mode"`
+	RebalanceInterval string               `json:"rebalanceInterval"`
+	Allocations       []StrategyAllocation `json:"allocations"`
+	Timestamp         time.Time            `json:"timestamp"`
+}
+
+// AllocationSnapshot records a reallocation
+type AllocationSnapshot struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Mode      AllocationMode     `json:"mode"`
+	Reason    string             `json:"reason"`
+	Equity    float64            `json:"equity"`
+	Weights   map[string]float64 `json:"weights"`
+}
+
+// ArmRequest re-authenticates the user before arming live mode
+type ArmRequest struct {
+	Password string `json:"password"`
+}
+
+// ArmingCheck is one minimum configuration requirement for live trading
+type ArmingCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ArmingState is the phase of the live-mode arming sequence
+type ArmingState string
+
+// ArmingStatus describes the live-mode arming sequence
+type ArmingStatus struct {
+	Required  bool          `json:"required"` // Live mode can only be entered by arming
+	State     ArmingState   `json:"state"`
+	Mode      string        `json:"mode"`
+	Actor     string        `json:"actor,omitempty"` // User behind the last transition
+	Reason    string        `json:"reason,omitempty"`
+	ArmsAt    *time.Time    `json:"armsAt,omitempty"` // End of the cancel window
+	ArmedAt   *time.Time    `json:"armedAt,omitempty"`
+	Countdown float64       `json:"countdownSeconds"`
+	Checks    []ArmingCheck `json:"checks,omitempty"`
+}
+
+// AuditEntry is one recorded action
+type AuditEntry struct {
+	ID        int64     `json:"id"`
+	Category  string    `json:"category"`
+	Action    string    `json:"action"`              // e.g. order_place, strategy_enable, circuit_breaker_reset
+	Actor     string    `json:"actor"`               // User email or ID; "system" for actions the bot takes on its own
+	Resource  string    `json:"resource,omitempty"`  // What was acted on, e.g. an order ID, strategy or settings section
+	AccountID string    `json:"accountId,omitempty"` // Trading account of per-account orders
+	OldValue  Value     `json:"oldValue,omitempty"`
+	NewValue  Value     `json:"newValue,omitempty"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BacktestJob is a backtest queued or run by the worker pool
+type BacktestJob struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Progress    float64    `json:"progress"` // Percent complete
+	Error       string     `json:"error,omitempty"`
+	SubmittedBy string     `json:"submittedBy,omitempty"`
+	QueuedAt    time.Time  `json:"queuedAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+// BacktestJobResponse represents the state of a queued backtest and, once
+// completed, its result
+type BacktestJobResponse struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Progress    float64    `json:"progress"` // Percent complete
+	Error       string     `json:"error,omitempty"`
+	SubmittedBy string     `json:"submittedBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	Result      Value      `json:"result,omitempty"` // BacktestResponse
+}
+
+// BacktestMetricsData represents backtest metrics for API
+type BacktestMetricsData struct {
+	TotalReturn            float64 `json:"totalReturn"`
+	AnnualizedReturn       float64 `json:"annualizedReturn"`
+	SharpeRatio            float64 `json:"sharpeRatio"`
+	SortinoRatio           float64 `json:"sortinoRatio"`
+	CalmarRatio            float64 `json:"calmarRatio"`
+	MaxDrawdown            float64 `json:"maxDrawdown"`
+	TotalTrades            int     `json:"totalTrades"`
+	WinningTrades          int     `json:"winningTrades"`
+	LosingTrades           int     `json:"losingTrades"`
+	WinRate                float64 `json:"winRate"`
+	ProfitFactor           float64 `json:"profitFactor"`
+	AvgWin                 float64 `json:"avgWin"`
+	AvgLoss                float64 `json:"avgLoss"`
+	LargestWin             float64 `json:"largestWin"`
+	LargestLoss            float64 `json:"largestLoss"`
+	AvgHoldingTime         string  `json:"avgHoldingTime"`
+	Expectancy             float64 `json:"expectancy"`
+	RecoveryFactor         float64 `json:"recoveryFactor"`
+	StartingCapital        float64 `json:"startingCapital"`
+	EndingCapital          float64 `json:"endingCapital"`
+	NetProfit              float64 `json:"netProfit"`
+	TradedNotional         float64 `json:"tradedNotional"`
+	Turnover               float64 `json:"turnover"`
+	TradesPerMonth         float64 `json:"tradesPerMonth"`
+	AvgExposureTime        string  `json:"avgExposureTime"`
+	TimeInMarket           float64 `json:"timeInMarket"`
+	TotalCommission        float64 `json:"totalCommission"`
+	TotalFunding           float64 `json:"totalFunding"`
+	TotalBorrow            float64 `json:"totalBorrow"`
+	MakerFills             int     `json:"makerFills"`
+	TakerFills             int     `json:"takerFills"`
+	MaxConcurrentPositions int     `json:"maxConcurrentPositions"`
+	MaxExposure            float64 `json:"maxExposure"`
+	MaxDrawdownDuration    string  `json:"maxDrawdownDuration"` // Longest time below the high-water mark
+	LongestFlatPeriod      string  `json:"longestFlatPeriod"`
+	UlcerIndex             float64 `json:"ulcerIndex"`
+	DowntimeBars           int     `json:"downtimeBars"`
+	ReopenExits            int     `json:"reopenExits"`
+	SpikeEntries           int     `json:"spikeEntries"` // Entries signaled on a volatility spike
+	DelayedEntries         int     `json:"delayedEntries"`
+	DroppedEntries         int     `json:"droppedEntries"` // Delayed entries the next bar did not bear out
+	DownsizedEntries       int     `json:"downsizedEntries"`
+	HeldEntries            int     `json:"heldEntries"` // Entries held for a confirmation candle
+	ConfirmedEntries       int     `json:"confirmedEntries"`
+	UnconfirmedEntries     int     `json:"unconfirmedEntries"` // Held entries dropped
+}
+
+// BacktestRequest represents a backtest request
+type BacktestRequest struct {
+	Symbol                string                     `json:"symbol"`
+	Timeframe             string                     `json:"timeframe"`
+	StartDate             string                     `json:"startDate"` // ISO 8601 format
+	EndDate               string                     `json:"endDate"`   // ISO 8601 format
+	InitialCapital        float64                    `json:"initialCapital"`
+	Commission            float64                    `json:"commission"` // Flat rate on every fill; ignored when fees is set
+	Slippage              float64                    `json:"slippage"`
+	Strategies            []string                   `json:"strategies"`
+	RiskPerTrade          float64                    `json:"riskPerTrade"`
+	Fees                  *FeeSchedule               `json:"fees,omitempty"`             // Maker/taker fee schedule; defaults to the account's configured schedule
+	Carry                 *CarryCosts                `json:"carry,omitempty"`            // Funding and borrow costs of holding margin or futures positions; spot backtests leave it unset and pay none
+	HigherTimeframes      []string                   `json:"higherTimeframes,omitempty"` // Secondary timeframes provided as context (e.g. ["4h"] while trading 1h)
+	FetchMissing          bool                       `json:"fetchMissing,omitempty"`     // Fetch candles missing from local storage from Binance and persist them before running, instead of only using what the bot has cached
+	LookaheadAudit        bool                       `json:"lookaheadAudit,omitempty"`   // Replay with inputs lagged one bar and report lookahead bias
+	BenchmarkRuns         int                        `json:"benchmarkRuns,omitempty"`    // Compare with this many random-entry control runs sharing the exits, sizing and costs (0 = no benchmark); a seed makes them repeatable
+	BenchmarkSeed         int64                      `json:"benchmarkSeed,omitempty"`
+	DisallowedRegimes     map[string][]string        `json:"disallowedRegimes,omitempty"` // Per-strategy regime blacklist (e.g. {"stat_arb": ["TRENDING"]}); defaults to the live configuration when omitted
+	MaxPositions          int                        `json:"maxPositions,omitempty"`      // Concurrent positions (default 1), the largest fraction of capital one may take (default 95% split over maxPositions) and the cap on same-direction exposure as a fraction of capital (0 = none)
+	PositionAllocation    float64                    `json:"positionAllocation,omitempty"`
+	MaxCorrelatedExposure float64                    `json:"maxCorrelatedExposure,omitempty"`
+	ExecutionModel        string                     `json:"executionModel,omitempty"`     // How stops and targets fill within a bar: "worst_case" (default) checks the high/low and assumes the stop fills first, "ohlc_path" follows the likely open-high-low-close path, "close" only the close
+	MaintenanceWindows    []MaintenanceWindowRequest `json:"maintenanceWindows,omitempty"` // Known exchange downtime during which nothing fills
+	Compounding           string                     `json:"compounding,omitempty"`        // Capital entries are sized from as equity grows: "full", "fixed" (initialCapital) or "stepped" every compoundingStep of gain; defaults to the live configuration when omitted
+	CompoundingStep       float64                    `json:"compoundingStep,omitempty"`
+}
+
+// BacktestResultSummary represents a backtest result summary
+type BacktestResultSummary struct {
+	ID        string    `json:"id"`
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	StartDate string    `json:"startDate"`
+	EndDate   string    `json:"endDate"`
+	Return    float64   `json:"return"`
+	Sharpe    float64   `json:"sharpe"`
+	MaxDD     float64   `json:"maxDD"`
+	Trades    int       `json:"trades"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BackupRecord is one database backup attempt
+type BackupRecord struct {
+	ID          int64     `json:"id"`
+	Destination string    `json:"destination"` // file or download
+	Path        string    `json:"path,omitempty"`
+	SizeBytes   int64     `json:"size_bytes"`
+	DurationMs  int64     `json:"duration_ms"`
+	Status      string    `json:"status"` // ok or failed
+	Error       string    `json:"error,omitempty"`
+	RequestedBy string    `json:"requested_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BandwidthResponse represents WebSocket traffic on both sides of the bot
+type BandwidthResponse struct {
+	Exchange StreamBandwidth `json:"exchange"`
+	API      HubStats        `json:"api"`
+}
+
+// BinanceKeysRequest links Binance API keys to a trading account
+type BinanceKeysRequest struct {
+	APIKey    string `json:"binance_api_key"`
+	SecretKey string `json:"binance_secret_key"`
+	Testnet   bool   `json:"binance_testnet"`
+}
+
+// BinanceSettings represents Binance API configuration
+type BinanceSettings struct {
+	APIKey    string `json:"apiKey"`    // Binance API key
+	SecretKey string `json:"secretKey"` // Binance Secret key (masked on read)
+	Testnet   bool   `json:"testnet"`   // Use testnet
+}
+
+// BollingerData represents Bollinger Band values
+type BollingerData struct {
+	Upper   float64 `json:"upper"`
+	Middle  float64 `json:"middle"`
+	Lower   float64 `json:"lower"`
+	Width   float64 `json:"width"`
+	Percent float64 `json:"percent"`
+}
+
+// BollingerValue represents Bollinger Band values
+type BollingerValue struct {
+	Upper  float64 `json:"upper"`
+	Middle float64 `json:"middle"`
+	Lower  float64 `json:"lower"`
+	Width  float64 `json:"width"`
+}
+
+// CandleData represents candle data for API
+type CandleData struct {
+	Time    int64   `json:"time"` // Unix timestamp in seconds
+	Open    float64 `json:"open"`
+	High    float64 `json:"high"`
+	Low     float64 `json:"low"`
+	Close   float64 `json:"close"`
+	Volume  float64 `json:"volume"`
+	Session string  `json:"session"` // Trading session the candle opened in
+}
+
+// CandleUpdate represents a candle update message
+type CandleUpdate struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	IsClosed  bool      `json:"isClosed"`
+	Session   string    `json:"session"`
+}
+
+// CapitalTransfer moves capital between the bot's sub-balance and the rest of
+// the exchange account
+type CapitalTransfer struct {
+	Amount float64   `json:"amount"` // Positive into the sub-balance, negative out of it
+	Note   string    `json:"note,omitempty"`
+	By     string    `json:"by,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// CapitalTransferRequest moves capital into or out of the bot's sub-balance
+type CapitalTransferRequest struct {
+	Amount float64 `json:"amount"` // Positive into the sub-balance, negative out of it
+	Note   string  `json:"note,omitempty"`
+}
+
+// CarryCosts prices holding margin or futures positions over time. Funding
+// settles on every interval a position is open across; borrow interest accrues
+// continuously on the asset borrowed to hold shorts.
+type CarryCosts struct {
+	FundingRate          float64       `json:"fundingRate"`                    // Paid by longs to shorts per settlement (0.0001 = 0.01% of notional); negative pays shorts
+	FundingRates         []FundingRate `json:"fundingRates,omitempty"`         // Historical rates, oldest first; each applies until the next
+	FundingIntervalHours int           `json:"fundingIntervalHours,omitempty"` // Between settlements, aligned to midnight UTC; 0 = 8
+	BorrowRate           float64       `json:"borrowRate"`                     // Annual interest on borrowed short notional (0.05 = 5%)
+}
+
+// CarryPolicy decides what happens to open positions at a mode switch
+type CarryPolicy string
+
+// CashFlowRequest represents a deposit or withdrawal
+type CashFlowRequest struct {
+	Amount float64 `json:"amount"` // Positive for deposits, negative for withdrawals
+	Note   string  `json:"note,omitempty"`
+}
+
+// ChaosEvent is a simulated failure that was injected
+type ChaosEvent struct {
+	Fault      string    `json:"fault"`
+	Count      int       `json:"count"`
+	Trigger    string    `json:"trigger"` // "schedule" or the user who requested it
+	Error      string    `json:"error,omitempty"`
+	InjectedAt time.Time `json:"injectedAt"`
+}
+
+// ChaosRequest requests an outage drill
+type ChaosRequest struct {
+	Fault string `json:"fault"` // ws_drop, rate_limit, delayed_fill or partial_fill
+	Count int    `json:"count"` // Orders the fault applies to (default 1)
+}
+
+// ChaosStatus describes the outage drill configuration and history
+type ChaosStatus struct {
+	Enabled  bool           `json:"enabled"`
+	Interval string         `json:"interval,omitempty"` // Time between scheduled drills
+	Faults   []string       `json:"faults"`             // Faults drawn by scheduled drills
+	Pending  map[string]int `json:"pending"`            // Order faults waiting for an order
+	Events   []ChaosEvent   `json:"events"`             // Most recent first
+}
+
+// CircuitBreakerEvent is a trip or reset of the circuit breaker
+type CircuitBreakerEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"` // CIRCUIT_BREAKER or CIRCUIT_BREAKER_RESET
+	Message string    `json:"message"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// ConfluenceCheck is the outcome of the confluence layer for one signal
+type ConfluenceCheck struct {
+	Passed     bool              `json:"passed"`
+	Mode       string            `json:"mode"`
+	Timeframes []TimeframeStance `json:"timeframes"`
+	Agree      int               `json:"agree"`
+	Oppose     int               `json:"oppose"`
+	Confidence float64           `json:"confidence"` // After weighting; unchanged in filter mode
+	Reason     string            `json:"reason,omitempty"`
+}
+
+// CopyFollower is an endpoint approved signals are pushed to
+type CopyFollower struct {
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`
+	Core         bool       `json:"core"` // From the configuration; cannot be removed
+	AddedBy      string     `json:"addedBy,omitempty"`
+	AddedAt      *time.Time `json:"addedAt,omitempty"`
+	Delivered    int64      `json:"delivered"`
+	Failed       int64      `json:"failed"`
+	LastDelivery *time.Time `json:"lastDelivery,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+}
+
+// CopyTradingStatus describes both sides of copy trading
+type CopyTradingStatus struct {
+	Publishing   bool           `json:"publishing"`
+	Leader       string         `json:"leader,omitempty"`
+	Followers    []CopyFollower `json:"followers"`
+	Published    int64          `json:"published"`
+	Dropped      int64          `json:"dropped"` // Not pushed because the queue was full
+	Following    bool           `json:"following"`
+	Leaders      []string       `json:"leaders,omitempty"`
+	Received     int64          `json:"received"`
+	Approved     int64          `json:"approved"`
+	Rejected     int64          `json:"rejected"` // Refused by this bot's checks or risk pipeline
+	LastReceived *time.Time     `json:"lastReceived,omitempty"`
+}
+
+// DashboardResponse represents dashboard data
+type DashboardResponse struct {
+	State        *TradingState    `json:"state"`
+	Summary      *AccountSummary  `json:"summary"`
+	Performance  *PerformanceData `json:"performance"`
+	RecentTrades []TradeData      `json:"recentTrades"`
+	Positions    []PositionData   `json:"positions"`
+	Signals      []SignalRecord   `json:"signals"`
+	Timestamp    time.Time        `json:"timestamp"`
+}
+
+// DisarmRequest optionally records why live mode was disarmed
+type DisarmRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DrawdownResponse represents drawdown information
+type DrawdownResponse struct {
+	Current          float64    `json:"current"`
+	Max              float64    `json:"max"`
+	RecoveryRequired float64    `json:"recoveryRequired"`
+	IsAtPeak         bool       `json:"isAtPeak"`
+	PeakEquity       float64    `json:"peakEquity,omitempty"`
+	PeakAt           *time.Time `json:"peakAt,omitempty"`
+	HighWaterMark    string     `json:"highWaterMarkMode,omitempty"`
+}
+
+type Duration int64
+
+// DustBalance is a residual asset balance too small to trade
+type DustBalance struct {
+	Asset     string  `json:"asset"`
+	Free      float64 `json:"free"`
+	Locked    float64 `json:"locked"`
+	Price     float64 `json:"price"` // USDT price used for valuation
+	ValueUSDT float64 `json:"valueUsdt"`
+}
+
+// DustConversion is the result of converting dust balances to BNB
+type DustConversion struct {
+	Assets      []string  `json:"assets"`
+	ReceivedBNB float64   `json:"receivedBnb"`
+	Fee         float64   `json:"fee"` // Service charge in BNB
+	ConvertedAt time.Time `json:"convertedAt"`
+}
+
+// DustReport lists dust balances and the threshold used to detect them
+type DustReport struct {
+	Threshold  float64       `json:"threshold"` // USDT value below which a balance is dust
+	TotalValue float64       `json:"totalValue"`
+	Balances   []DustBalance `json:"balances"`
+}
+
+// EntryConfirmationStats counts a strategy's entries held for confirmation and
+// what became of them
+type EntryConfirmationStats struct {
+	Strategy     string    `json:"strategy"`
+	Held         int64     `json:"held"`
+	Confirmed    int64     `json:"confirmed"`
+	Rejected     int64     `json:"rejected"`               // Conditions no longer held, or the wait was missed
+	LastRejected string    `json:"lastRejected,omitempty"` // Condition that failed last, e.g. "macd"
+	LastHeldAt   time.Time `json:"lastHeldAt,omitempty"`
+}
+
+// EntryStats counts how maker-first entries were filled
+type EntryStats struct {
+	MakerFilled    int64 `json:"makerFilled"`    // Filled in full as maker
+	MakerPartial   int64 `json:"makerPartial"`   // Partly filled as maker, the rest at market
+	Rejected       int64 `json:"rejected"`       // Priced through the book when placed, sent at market
+	MarketFallback int64 `json:"marketFallback"` // Market orders sent for unfilled quantity
+}
+
+// EntryStatus describes the entry policy and its outcomes
+type EntryStatus struct {
+	MakerFirst bool       `json:"makerFirst"`
+	OffsetBps  float64    `json:"offsetBps"`
+	Timeout    string     `json:"timeout"`
+	Stats      EntryStats `json:"stats"`
+}
+
+// EquityCurvePoint represents a point on the equity curve
+type EquityCurvePoint struct {
+	Time     time.Time `json:"time"`
+	Equity   float64   `json:"equity"`
+	Drawdown float64   `json:"drawdown"`
+	Return   float64   `json:"return"`
+}
+
+// ErrorUpdate represents an error message
+type ErrorUpdate struct {
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+	Details string    `json:"details,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// EvalPoolStats describes the pool's workers and queueing delays
+type EvalPoolStats struct {
+	Workers int              `json:"workers"`
+	Busy    int              `json:"busy"`
+	Queues  []EvalQueueStats `json:"queues"`
+}
+
+// EvalQueueStats summarizes the queueing delay of one priority
+type EvalQueueStats struct {
+	Priority    string  `json:"priority"`
+	Waiting     int     `json:"waiting"`
+	Evaluations int64   `json:"evaluations"`
+	LastWaitMs  float64 `json:"lastWaitMs"`
+	AvgWaitMs   float64 `json:"avgWaitMs"`
+	MaxWaitMs   float64 `json:"maxWaitMs"`
+}
+
+// Event is one structured log event
+type Event struct {
+	Seq      int64                  `json:"seq"`
+	Time     time.Time              `json:"time"`
+	Level    string                 `json:"level"`
+	Category string                 `json:"category"`
+	Message  string                 `json:"message"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+}
+
+// ExecutionLeg is one side (live or shadow paper) of a reconciled trade
+type ExecutionLeg struct {
+	OrderID          string    `json:"orderId,omitempty"`
+	PositionID       int64     `json:"positionId,omitempty"`
+	Quantity         float64   `json:"quantity"`
+	EntryPrice       float64   `json:"entryPrice"`
+	EntrySlippageBps float64   `json:"entrySlippageBps"` // Adverse slippage vs signal price
+	ExitPrice        float64   `json:"exitPrice,omitempty"`
+	ExitReason       string    `json:"exitReason,omitempty"`
+	ExitTime         time.Time `json:"exitTime,omitempty"`
+	Commission       float64   `json:"commission"` // Entry plus exit commission
+	NetPnL           float64   `json:"netPnl"`
+	Closed           bool      `json:"closed"`
+	Error            string    `json:"error,omitempty"`
+}
+
+// FeeSchedule prices fills the way the exchange charges the account
+type FeeSchedule struct {
+	Tiers       []FeeTier `json:"tiers"`
+	Volume30d   float64   `json:"volume30d"`   // Account 30-day volume selecting the tier
+	BNBDiscount bool      `json:"bnbDiscount"` // Commission paid in BNB at a discount
+}
+
+// FeeTier is the maker/taker rate from a 30-day traded volume upward
+type FeeTier struct {
+	Name      string  `json:"name,omitempty"`
+	MinVolume float64 `json:"minVolume"` // 30-day volume (USDT) at which the tier starts
+	MakerBps  float64 `json:"makerBps"`
+	TakerBps  float64 `json:"takerBps"`
+}
+
+// Fill is the estimated execution of a market order walking the book
+type Fill struct {
+	Quantity    float64 `json:"quantity"`
+	AvgPrice    float64 `json:"avgPrice"`
+	WorstPrice  float64 `json:"worstPrice"`
+	Levels      int     `json:"levels"`      // Price levels consumed
+	SlippageBps float64 `json:"slippageBps"` // Average price against the mid, always adverse
+	Exhausted   bool    `json:"exhausted"`   // The book was too thin; the rest is priced at the worst level
+}
+
+// FollowerRequest registers an endpoint to push signals to
+type FollowerRequest struct {
+	Name string `json:"name"`
+	URL  string `json:"url"` // Follower's POST /api/v1/copy/signals
+}
+
+// FullSettingsResponse represents all settings
+type FullSettingsResponse struct {
+	Trading    TradingSettings         `json:"trading"`
+	Binance    BinanceSettings         `json:"binance"`
+	Risk       RiskSettings            `json:"risk"`
+	Indicators IndicatorSettings       `json:"indicators"`
+	Strategies StrategySettings        `json:"strategies"`
+	Symbols    map[string]SymbolConfig `json:"symbols"` // Per-symbol overrides
+}
+
+// FundingRate is the funding rate settled from a time onward
+type FundingRate struct {
+	Time time.Time `json:"time"`
+	Rate float64   `json:"rate"`
+}
+
+// HaltRequest represents a manual trading halt request
+type HaltRequest struct {
+	Reason          string `json:"reason"`
+	DurationMinutes int    `json:"durationMinutes,omitempty"` // 0 = until resumed
+}
+
+// HighWaterMark is the persisted equity peak drawdown is measured from
+type HighWaterMark struct {
+	Mode        HighWaterMarkMode `json:"mode"`
+	Peak        float64           `json:"peak"`
+	PeakAt      time.Time         `json:"peakAt"`
+	PeriodStart time.Time         `json:"periodStart"` // Start of the current period (monthly mode)
+	NetDeposits float64           `json:"netDeposits"` // Cash flows recorded so far
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// HighWaterMarkMode determines how the equity peak used for drawdown evolves
+type HighWaterMarkMode string
+
+// HistoryImportReport describes an exchange order history import
+type HistoryImportReport struct {
+	Status             string             `json:"status"` // running, completed or failed
+	Symbols            []string           `json:"symbols"`
+	Until              time.Time          `json:"until"` // Fills from this time on are left to the bot's own records
+	StartedAt          time.Time          `json:"startedAt"`
+	FinishedAt         time.Time          `json:"finishedAt,omitempty"`
+	Fills              int                `json:"fills"`                        // Exchange fills read
+	Orders             int                `json:"orders"`                       // Orders the fills belong to
+	Imported           int                `json:"imported"`                     // Orders stored as trades
+	Skipped            int                `json:"skipped"`                      // Orders already stored
+	Positions          int                `json:"positions"`                    // Closed positions rebuilt from the fills
+	OpenQuantity       map[string]float64 `json:"openQuantity,omitempty"`       // Holdings still open at the end, by symbol
+	UnmatchedSells     map[string]float64 `json:"unmatchedSells,omitempty"`     // Quantity sold without a recorded buy, by symbol
+	UnpricedCommission map[string]float64 `json:"unpricedCommission,omitempty"` // Commission in assets other than the pair's, by asset
+	Error              string             `json:"error,omitempty"`
+}
+
+// HistoryImportRequest selects what to import from the exchange
+type HistoryImportRequest struct {
+	Symbols []string `json:"symbols"` // Defaults to the traded symbol
+	Until   int64    `json:"until"`   // Unix ms; defaults to the bot's first recorded trade
+}
+
+// HubStats describes the traffic served to dashboard clients
+type HubStats struct {
+	Clients     int       `json:"clients"`
+	Users       int       `json:"users"`       // Distinct users connected
+	Compression bool      `json:"compression"` // Offered to clients; used when they accept
+	Since       time.Time `json:"since"`
+	MessagesIn  int64     `json:"messagesIn"`
+	BytesIn     int64     `json:"bytesIn"`
+	MessagesOut int64     `json:"messagesOut"`
+	BytesOut    int64     `json:"bytesOut"` // Payload bytes before compression
+}
+
+// IchimokuValue represents Ichimoku cloud values
+type IchimokuValue struct {
+	Tenkan   float64 `json:"tenkan"`
+	Kijun    float64 `json:"kijun"`
+	SenkouA  float64 `json:"senkouA"`
+	SenkouB  float64 `json:"senkouB"`
+	Position string  `json:"position"` // UP above the cloud, DOWN below, NEUTRAL inside
+}
+
+// IdeaDecisionRequest optionally records why an idea was approved or rejected
+type IdeaDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// IdeaReasoning is why the bot proposed a trade
+type IdeaReasoning struct {
+	Regime           string              `json:"regime"`
+	RegimeConfidence float64             `json:"regimeConfidence"`
+	Score            float64             `json:"score"`
+	Confidence       float64             `json:"confidence"`
+	LongSignals      int                 `json:"longSignals"`
+	ShortSignals     int                 `json:"shortSignals"`
+	HasConflict      bool                `json:"hasConflict"`
+	Strategies       []IdeaStrategyScore `json:"strategies"`
+	RiskLevel        string              `json:"riskLevel,omitempty"`
+	RiskRewardRatio  float64             `json:"riskRewardRatio,omitempty"`
+	RiskWarnings     []string            `json:"riskWarnings,omitempty"`
+	SizingModel      string              `json:"sizingModel,omitempty"`
+	SizingDetail     string              `json:"sizingDetail,omitempty"`
+}
+
+// IdeaStrategyScore is one strategy's contribution to a trade idea
+type IdeaStrategyScore struct {
+	Strategy   string             `json:"strategy"`
+	Direction  string             `json:"direction"`
+	Score      float64            `json:"score"`
+	Confidence float64            `json:"confidence"`
+	Factors    map[string]float64 `json:"factors,omitempty"`
+}
+
+// InboxResponse represents the trade idea inbox
+type InboxResponse struct {
+	Enabled bool        `json:"enabled"`
+	Ideas   []TradeIdea `json:"ideas"`
+}
+
+// IndicatorColumnValue is one series with open times in Unix milliseconds
+type IndicatorColumnValue struct {
+	Name   string    `json:"name"`
+	Times  []int64   `json:"times"`
+	Values []float64 `json:"values"`
+}
+
+// IndicatorCoverage describes what is stored for one indicator series
+type IndicatorCoverage struct {
+	Name  string    `json:"name"`
+	Count int64     `json:"count"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+}
+
+// IndicatorCoverageResponse describes which series are stored
+type IndicatorCoverageResponse struct {
+	Timeframe string                      `json:"timeframe"`
+	Available []string                    `json:"available"`
+	Stored    []IndicatorCoverage         `json:"stored"`
+	LastRuns  []IndicatorPrecomputeResult `json:"lastRuns"`
+}
+
+// IndicatorData represents indicator values
+type IndicatorData struct {
+	RSI       *float64       `json:"rsi,omitempty"`
+	MACD      *MACDData      `json:"macd,omitempty"`
+	BB        *BollingerData `json:"bb,omitempty"`
+	ADX       *ADXData       `json:"adx,omitempty"`
+	ATR       *float64       `json:"atr,omitempty"`
+	Volume    *VolumeData    `json:"volume,omitempty"`
+	Regime    string         `json:"regime"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+// IndicatorPrecomputeResult summarizes one precompute run
+type IndicatorPrecomputeResult struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Columns   []string  `json:"columns"`
+	Candles   int       `json:"candles"` // Stored candles the series were computed over
+	Written   int       `json:"written"` // Values upserted
+	Full      bool      `json:"full"`    // Rewrote every value rather than only new bars
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+}
+
+// IndicatorSeriesResponse holds precomputed series in columnar form
+type IndicatorSeriesResponse struct {
+	Timeframe string                 `json:"timeframe"`
+	Columns   []IndicatorColumnValue `json:"columns"`
+}
+
+// IndicatorSettings represents indicator configuration
+type IndicatorSettings struct {
+	RSIPeriod            int     `json:"rsiPeriod"`            // RSI period (default: 14)
+	RSIOversold          float64 `json:"rsiOversold"`          // RSI oversold level (default: 30)
+	RSIOverbought        float64 `json:"rsiOverbought"`        // RSI overbought level (default: 70)
+	MACDFast             int     `json:"macdFast"`             // MACD fast period (default: 12)
+	MACDSlow             int     `json:"macdSlow"`             // MACD slow period (default: 26)
+	MACDSignal           int     `json:"macdSignal"`           // MACD signal period (default: 9)
+	BBPeriod             int     `json:"bbPeriod"`             // Bollinger Band period (default: 20)
+	BBStdDev             float64 `json:"bbStdDev"`             // Bollinger Band std dev (default: 2.0)
+	ADXPeriod            int     `json:"adxPeriod"`            // ADX period (default: 14)
+	ADXThreshold         float64 `json:"adxThreshold"`         // ADX trend threshold (default: 25)
+	ATRPeriod            int     `json:"atrPeriod"`            // ATR period (default: 14)
+	ATRMultiplierSL      float64 `json:"atrMultiplierSL"`      // ATR multiplier for stop loss (default: 2.0)
+	ATRMultiplierTP      float64 `json:"atrMultiplierTP"`      // ATR multiplier for take profit (default: 3.0)
+	KeltnerPeriod        int     `json:"keltnerPeriod"`        // Keltner Channel EMA and ATR period (default: 20)
+	KeltnerMultiplier    float64 `json:"keltnerMultiplier"`    // Keltner Channel ATR multiplier (default: 2.0)
+	SuperTrendPeriod     int     `json:"superTrendPeriod"`     // SuperTrend ATR period (default: 10)
+	SuperTrendMultiplier float64 `json:"superTrendMultiplier"` // SuperTrend ATR multiplier (default: 3.0)
+	IchimokuTenkan       int     `json:"ichimokuTenkan"`       // Ichimoku conversion line period (default: 9)
+	IchimokuKijun        int     `json:"ichimokuKijun"`        // Ichimoku base line period and cloud displacement (default: 26)
+	IchimokuSenkouB      int     `json:"ichimokuSenkouB"`      // Ichimoku leading span B period (default: 52)
+}
+
+// IndicatorsUpdate represents indicators update message
+type IndicatorsUpdate struct {
+	Symbol     string           `json:"symbol"`
+	Timeframe  string           `json:"timeframe"`
+	Timestamp  time.Time        `json:"timestamp"`
+	RSI        float64          `json:"rsi"`
+	MACD       *MACDValue       `json:"macd"`
+	BB         *BollingerValue  `json:"bb"`
+	ADX        *ADXValue        `json:"adx"`
+	ATR        float64          `json:"atr"`
+	VWAP       *VWAPValue       `json:"vwap"`
+	SuperTrend *SuperTrendValue `json:"supertrend"`
+	Ichimoku   *IchimokuValue   `json:"ichimoku"`
+	Keltner    *KeltnerValue    `json:"keltner"`
+	Regime     string           `json:"regime"`
+}
+
+// KeltnerValue represents Keltner Channel values
+type KeltnerValue struct {
+	Upper  float64 `json:"upper"`
+	Middle float64 `json:"middle"`
+	Lower  float64 `json:"lower"`
+}
+
+// LatencyBreach is a pipeline run that overran a stage budget
+type LatencyBreach struct {
+	CorrelationID string             `json:"correlationId"`
+	Stage         string             `json:"stage"`
+	ElapsedMs     float64            `json:"elapsedMs"`
+	BudgetMs      float64            `json:"budgetMs"`
+	Stages        map[string]float64 `json:"stages"` // Timings of the run so far, in ms
+	Timeframe     string             `json:"timeframe"`
+	CandleClose   time.Time          `json:"candleClose"`
+	At            time.Time          `json:"at"`
+}
+
+// LatencyReport describes pipeline stage timings against their budgets
+type LatencyReport struct {
+	Stages   []StageLatency  `json:"stages"`
+	Breaches []LatencyBreach `json:"breaches"` // Most recent first
+}
+
+// Leaderboard ranks strategies by realized performance
+type Leaderboard struct {
+	Window     string             `json:"window"` // e.g. "30d"; "all" = every persisted position
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Sort       LeaderboardSort    `json:"sort"`
+	Strategies []StrategyStanding `json:"strategies"`
+}
+
+// LeaderboardSort is the metric strategies are ranked by
+type LeaderboardSort string
+
+// Level is one price level of an order book
+type Level struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// LimitPeaks is the highest utilization of each risk limit over a day, as a
+// fraction of the limit (1 = at the limit)
+type LimitPeaks struct {
+	DailyLoss  float64 `json:"dailyLoss"`
+	WeeklyLoss float64 `json:"weeklyLoss"`
+	Drawdown   float64 `json:"drawdown"`
+	Positions  float64 `json:"positions"`
+}
+
+// LimitUtilization represents usage of a single risk limit
+type LimitUtilization struct {
+	Name        string  `json:"name"`
+	Used        float64 `json:"used"`
+	Limit       float64 `json:"limit"`
+	Utilization float64 `json:"utilization"` // Percent of limit used (100 = at limit)
+	Breached    bool    `json:"breached"`
+}
+
+// LiquidityCheck is the outcome of the liquidity filter for one signal
+type LiquidityCheck struct {
+	Passed              bool    `json:"passed"`
+	QuoteVolume24h      float64 `json:"quoteVolume24h"` // 0 when the ticker was unavailable
+	MinQuoteVolume24h   float64 `json:"minQuoteVolume24h"`
+	CandleVolume        float64 `json:"candleVolume"`
+	VolumePercentile    float64 `json:"volumePercentile"` // Share of recent candles with less volume, 0-100
+	MinVolumePercentile float64 `json:"minVolumePercentile"`
+	Reason              string  `json:"reason,omitempty"`
+}
+
+// LoginRequest represents a login request
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse represents a successful login response
+type LoginResponse struct {
+	User         *UserResponse             `json:"user"`
+	AccessToken  string                    `json:"access_token"`
+	RefreshToken string                    `json:"refresh_token"`
+	ExpiresIn    int64                     `json:"expires_in"` // seconds
+	Accounts     []*TradingAccountResponse `json:"accounts"`
+}
+
+// MACDData represents MACD indicator values
+type MACDData struct {
+	MACD      float64 `json:"macd"`
+	Signal    float64 `json:"signal"`
+	Histogram float64 `json:"histogram"`
+}
+
+// MACDValue represents MACD values
+type MACDValue struct {
+	MACD      float64 `json:"macd"`
+	Signal    float64 `json:"signal"`
+	Histogram float64 `json:"histogram"`
+}
+
+// MaintenanceWindowRequest represents exchange downtime in a backtest
+type MaintenanceWindowRequest struct {
+	Start  string `json:"start"` // RFC 3339
+	End    string `json:"end"`   // RFC 3339
+	Reason string `json:"reason,omitempty"`
+}
+
+// ModeRequest represents trading mode request
+type ModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+// ModeResponse represents trading mode response
+type ModeResponse struct {
+	Mode string `json:"mode"`
+}
+
+// ModeUpdate represents a trading mode transition message
+type ModeUpdate struct {
+	Event       string    `json:"event"` // "pending" or "switched"
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Window      string    `json:"window,omitempty"`
+	Policy      string    `json:"policy"`
+	Reason      string    `json:"reason,omitempty"`
+	EffectiveAt time.Time `json:"effectiveAt"`
+}
+
+// ModelsTradingMode represents the trading mode
+type ModelsTradingMode string
+
+// OptimizationRunData represents one backtest of a parameter search
+type OptimizationRunData struct {
+	Rank      int                  `json:"rank"`
+	Params    map[string]float64   `json:"params"`
+	Score     float64              `json:"score"`
+	Qualified bool                 `json:"qualified"`
+	Metrics   *BacktestMetricsData `json:"metrics,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// OptimizeRequest runs a parameter search over the backtest described by the
+// embedded request
+type OptimizeRequest struct {
+	BacktestRequest
+	Params    map[string]ParamRange `json:"params"`    // Parameter ranges keyed "<strategy>.<Field>" or "indicators.<Field>", e.g. {"trend_following.FastMAPeriod": {"min": 5, "max": 20, "step": 5}}
+	Mode      string                `json:"mode"`      // grid (default) or random
+	Samples   int                   `json:"samples"`   // Random mode combinations
+	Seed      int64                 `json:"seed"`      // Random mode seed, to repeat a search
+	Workers   int                   `json:"workers"`   // Parallel backtests, capped at the CPU count
+	Objective string                `json:"objective"` // sharpe (default), sortino, calmar, net_profit, profit_factor
+	MinTrades int                   `json:"minTrades"` // Runs with fewer trades rank last
+	Top       int                   `json:"top"`       // Ranked runs returned
+}
+
+// OptimizeResponse holds the ranked runs of a parameter search
+type OptimizeResponse struct {
+	Objective     string                `json:"objective"`
+	Mode          string                `json:"mode"`
+	Seed          int64                 `json:"seed,omitempty"`
+	Combinations  int                   `json:"combinations"`
+	Runs          int                   `json:"runs"`
+	Failed        int                   `json:"failed"`
+	Best          *OptimizationRunData  `json:"best,omitempty"`
+	Results       []OptimizationRunData `json:"results"`
+	ExecutionTime string                `json:"executionTime"`
+	Recorded      []string              `json:"recorded,omitempty"` // Strategies whose parameters of the best run were recorded as the optimized values drift is measured against
+}
+
+// OptimizedParams are the best parameters the optimizer found for a strategy,
+// with the search that found them
+type OptimizedParams struct {
+	Strategy       string                `json:"strategy"`
+	Params         map[string]float64    `json:"params"` // By config field, e.g. FastMAPeriod
+	Ranges         map[string]ParamRange `json:"ranges"` // Searched ranges, re-run on recent data
+	Objective      string                `json:"objective"`
+	Mode           string                `json:"mode"`
+	Samples        int                   `json:"samples,omitempty"`
+	MinTrades      int                   `json:"minTrades,omitempty"`
+	Score          float64               `json:"score"`
+	Symbol         string                `json:"symbol"`
+	Timeframe      string                `json:"timeframe"`
+	From           time.Time             `json:"from"`
+	To             time.Time             `json:"to"`
+	InitialCapital float64               `json:"initialCapital"`
+	RiskPerTrade   float64               `json:"riskPerTrade"`
+	RecordedAt     time.Time             `json:"recordedAt"`
+}
+
+// OrchestratorTradingMode represents the trading mode
+type OrchestratorTradingMode int
+
+// OrderBookView is the top of the local order book with optional fill estimates
+// for a quantity
+type OrderBookView struct {
+	Quote
+	Bids []Level `json:"bids"`
+	Asks []Level `json:"asks"`
+	Buy  *Fill   `json:"buy,omitempty"`  // Estimated market buy of the quantity asked for
+	Sell *Fill   `json:"sell,omitempty"` // Estimated market sell of the quantity asked for
+}
+
+// OrderData represents order data for API
+type OrderData struct {
+	ID             string                `json:"id"`
+	ClientID       string                `json:"clientId,omitempty"`
+	Symbol         string                `json:"symbol"`
+	Side           string                `json:"side"`
+	Type           string                `json:"type"`
+	Quantity       float64               `json:"quantity"`
+	Price          float64               `json:"price"`
+	StopPrice      float64               `json:"stopPrice,omitempty"`
+	Status         string                `json:"status"`
+	FilledQuantity float64               `json:"filledQuantity"`
+	AvgFillPrice   float64               `json:"avgFillPrice"`
+	Strategy       string                `json:"strategy,omitempty"`
+	CreatedAt      int64                 `json:"createdAt"`
+	UpdatedAt      int64                 `json:"updatedAt"`
+	Transitions    []OrderTransitionData `json:"transitions"`
+}
+
+// OrderIntent is a durable record of an entry order moving through execution
+type OrderIntent struct {
+	ID             int64     `json:"id"`
+	ClientOrderID  string    `json:"clientOrderId"`
+	Symbol         string    `json:"symbol"`
+	Side           string    `json:"side"`
+	Quantity       float64   `json:"quantity"`
+	Strategy       string    `json:"strategy"`
+	SignalPrice    float64   `json:"signalPrice"`
+	StopLoss       float64   `json:"stopLoss"`
+	TakeProfit     float64   `json:"takeProfit"`
+	Mode           string    `json:"mode,omitempty"` // Trading mode of the executor it was sent to
+	State          string    `json:"state"`
+	OrderID        string    `json:"orderId,omitempty"`
+	PositionID     int64     `json:"positionId,omitempty"`
+	FillPrice      float64   `json:"fillPrice,omitempty"`
+	FilledQuantity float64   `json:"filledQuantity,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// OrderSide represents order side
+type OrderSide string
+
+// OrderTransitionData represents one status change of an order
+type OrderTransitionData struct {
+	From   string `json:"from,omitempty"` // Empty for the first status
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+	At     int64  `json:"at"`
+}
+
+// ParamDeviation compares a configured parameter with a reference value
+type ParamDeviation struct {
+	Field      string  `json:"field"`
+	Configured float64 `json:"configured"`
+	Reference  float64 `json:"reference"`
+	Deviation  float64 `json:"deviation"` // Relative to the reference; absolute when it is 0
+	Drifted    bool    `json:"drifted"`
+}
+
+// ParamDriftReport compares a strategy's configured parameters with the
+// optimized and, when re-optimization ran, suggested ones
+type ParamDriftReport struct {
+	Strategy    string           `json:"strategy"`
+	Optimized   *OptimizedParams `json:"optimized"`
+	Deviations  []ParamDeviation `json:"deviations"` // Configured values against the optimized ones
+	Drifted     bool             `json:"drifted"`    // Configured values diverge from the optimized ones
+	Reoptimized *Reoptimization  `json:"reoptimized,omitempty"`
+	Error       string           `json:"error,omitempty"` // Why the configured values could not be read
+}
+
+// ParamRange lists the values a parameter takes, either explicitly or from Min
+// to Max in increments of Step
+type ParamRange struct {
+	Values []float64 `json:"values,omitempty"`
+	Min    float64   `json:"min,omitempty"`
+	Max    float64   `json:"max,omitempty"`
+	Step   float64   `json:"step,omitempty"`
+}
+
+// ParamTuningResult compares the latest parameter version with trades against
+// the one before it
+type ParamTuningResult struct {
+	From               int64   `json:"from"`
+	To                 int64   `json:"to"`
+	AvgPnLChange       float64 `json:"avgPnlChange"`
+	WinRateChange      float64 `json:"winRateChange"`
+	ProfitFactorChange float64 `json:"profitFactorChange"`
+	Improved           bool    `json:"improved"` // Average P&L per trade went up
+}
+
+// ParamVersionPerformance is the realized performance of trades entered under
+// one parameter set version. Version 0 is the configuration the bot started
+// with before any recorded settings change.
+type ParamVersionPerformance struct {
+	Version      int64      `json:"version"`
+	Section      string     `json:"section,omitempty"` // Settings section whose change started the version
+	ChangedBy    string     `json:"changedBy,omitempty"`
+	ChangedAt    *time.Time `json:"changedAt,omitempty"`
+	Current      bool       `json:"current"`
+	Trades       int        `json:"trades"` // Closing trades with realized P&L
+	Wins         int        `json:"wins"`
+	Losses       int        `json:"losses"`
+	WinRate      float64    `json:"winRate"`
+	NetPnL       float64    `json:"netPnl"`
+	GrossProfit  float64    `json:"grossProfit"`
+	GrossLoss    float64    `json:"grossLoss"`
+	ProfitFactor float64    `json:"profitFactor"`
+	AvgPnL       float64    `json:"avgPnl"`
+	Commission   float64    `json:"commission"`
+	FirstTrade   *time.Time `json:"firstTrade,omitempty"`
+	LastTrade    *time.Time `json:"lastTrade,omitempty"`
+}
+
+// ParamVersionReport breaks realized performance down by parameter version
+type ParamVersionReport struct {
+	CurrentVersion int64                     `json:"currentVersion"`
+	Strategy       string                    `json:"strategy,omitempty"`
+	Versions       []ParamVersionPerformance `json:"versions"` // Oldest first
+	LastTuning     *ParamTuningResult        `json:"lastTuning,omitempty"`
+}
+
+// PasswordChangeRequest represents a password change request
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// PasswordResetConfirm represents password reset confirmation
+type PasswordResetConfirm struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// PasswordResetRequest represents a password reset request
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// PerformanceData represents performance metrics
+type PerformanceData struct {
+	TotalReturn         float64 `json:"totalReturn"`
+	DailyReturn         float64 `json:"dailyReturn"`
+	WeeklyReturn        float64 `json:"weeklyReturn"`
+	MonthlyReturn       float64 `json:"monthlyReturn"`
+	SharpeRatio         float64 `json:"sharpeRatio"`
+	MaxDrawdown         float64 `json:"maxDrawdown"`
+	WinRate             float64 `json:"winRate"`
+	ProfitFactor        float64 `json:"profitFactor"`
+	TotalTrades         int     `json:"totalTrades"`
+	WinningTrades       int     `json:"winningTrades"`
+	LosingTrades        int     `json:"losingTrades"`
+	Turnover            float64 `json:"turnover"`
+	TradesPerMonth      float64 `json:"tradesPerMonth"`
+	AvgExposureTime     string  `json:"avgExposureTime"`
+	TimeInMarket        float64 `json:"timeInMarket"`
+	DrawdownDuration    string  `json:"drawdownDuration"` // Time below the equity high-water mark so far
+	MaxDrawdownDuration string  `json:"maxDrawdownDuration"`
+	LongestFlatPeriod   string  `json:"longestFlatPeriod"`
+	UlcerIndex          float64 `json:"ulcerIndex"`
+}
+
+// PlaceOrderRequest represents order placement request
+type PlaceOrderRequest struct {
+	Symbol    string  `json:"symbol"`
+	Side      string  `json:"side"`
+	Type      string  `json:"type"`
+	Quantity  float64 `json:"quantity"`
+	Price     float64 `json:"price,omitempty"`
+	StopPrice float64 `json:"stopPrice,omitempty"`
+}
+
+// PlaceOrderResponse represents order placement response
+type PlaceOrderResponse struct {
+	OrderID string `json:"orderId"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// PnLPeriodStatus is the realized P&L of the current calendar day and week
+type PnLPeriodStatus struct {
+	Timezone   string    `json:"timezone"`
+	WeekStarts string    `json:"weekStarts"`
+	DayStart   time.Time `json:"dayStart"`
+	WeekStart  time.Time `json:"weekStart"`
+	NextDay    time.Time `json:"nextDay"`
+	NextWeek   time.Time `json:"nextWeek"`
+	DailyPnL   float64   `json:"dailyPnl"`
+	WeeklyPnL  float64   `json:"weeklyPnl"`
+}
+
+// PositionData represents a position for API response
+type PositionData struct {
+	ID            int64     `json:"id"`
+	Symbol        string    `json:"symbol"`
+	Side          string    `json:"side"`
+	Quantity      float64   `json:"quantity"`
+	EntryPrice    float64   `json:"entryPrice"`
+	CurrentPrice  float64   `json:"currentPrice"`
+	StopLoss      float64   `json:"stopLoss"`
+	TakeProfit    float64   `json:"takeProfit"`
+	UnrealizedPnL float64   `json:"unrealizedPnL"`
+	UnrealizedPct float64   `json:"unrealizedPct"`
+	Strategy      string    `json:"strategy"`
+	OpenTime      time.Time `json:"openTime"`
+	Duration      string    `json:"duration"`
+}
+
+// PositionHistoryData represents a persisted position
+type PositionHistoryData struct {
+	ID            int64            `json:"id"`
+	Symbol        string           `json:"symbol"`
+	Side          string           `json:"side"`
+	EntryPrice    float64          `json:"entryPrice"`
+	Quantity      float64          `json:"quantity"`
+	CurrentPrice  float64          `json:"currentPrice"` // Exit price once closed
+	UnrealizedPnL float64          `json:"unrealizedPnl"`
+	RealizedPnL   float64          `json:"realizedPnl"`
+	StopLoss      float64          `json:"stopLoss"`
+	TakeProfit    float64          `json:"takeProfit"`
+	Strategy      string           `json:"strategy"`
+	Status        string           `json:"status"`
+	OpenedAt      int64            `json:"openedAt"`
+	ClosedAt      int64            `json:"closedAt,omitempty"`
+	Session       string           `json:"session"` // Trading session of the entry
+	PnL           *PositionPnLData `json:"pnl,omitempty"`
+}
+
+// PositionPnLData breaks a position's P&L into price move, fees, funding and
+// slippage. Costs are positive; slippage is included in grossPnl.
+type PositionPnLData struct {
+	GrossPnL        float64 `json:"grossPnl"`
+	EntryCommission float64 `json:"entryCommission"`
+	ExitCommission  float64 `json:"exitCommission"`
+	FundingCost     float64 `json:"fundingCost"`
+	EntrySlippage   float64 `json:"entrySlippage"`
+	ExitSlippage    float64 `json:"exitSlippage"`
+	NetPnL          float64 `json:"netPnl"`
+}
+
+// PositionSide represents position side
+type PositionSide string
+
+// PositionUpdate represents a position update message
+type PositionUpdate struct {
+	PositionID    int64        `json:"positionId"`
+	Symbol        string       `json:"symbol"`
+	Side          PositionSide `json:"side"`
+	Quantity      float64      `json:"quantity"`
+	EntryPrice    float64      `json:"entryPrice"`
+	CurrentPrice  float64      `json:"currentPrice"`
+	StopLoss      float64      `json:"stopLoss"`
+	TakeProfit    float64      `json:"takeProfit"`
+	UnrealizedPnL float64      `json:"unrealizedPnL"`
+	RealizedPnL   float64      `json:"realizedPnL"`
+	Strategy      string       `json:"strategy"`
+	OpenTime      time.Time    `json:"openTime"`
+	EventType     string       `json:"eventType"` // opened, closed, updated
+}
+
+// PrecomputeRequest selects what a precompute run computes
+type PrecomputeRequest struct {
+	Timeframe string   `json:"timeframe"`
+	Columns   []string `json:"columns"` // Empty = configured columns
+	Full      bool     `json:"full"`    // Rewrite all values, not only new bars
+}
+
+// PriceArbiterStatus is the price the bot acts on and where it came from
+type PriceArbiterStatus struct {
+	Symbol          string                            `json:"symbol"`
+	Price           float64                           `json:"price"`
+	At              time.Time                         `json:"at"`
+	Source          PriceSource                       `json:"source"`
+	TradeStaleAfter string                            `json:"tradeStaleAfter"`
+	Sources         map[PriceSource]PriceSourceStatus `json:"sources"`
+}
+
+// PriceSource is a market data stream the bot takes prices from
+type PriceSource string
+
+// PriceSourceStatus is the last price a stream reported and how many of its
+// prices were taken
+type PriceSourceStatus struct {
+	Price      float64   `json:"price"`
+	At         time.Time `json:"at"`         // Exchange time
+	ReceivedAt time.Time `json:"receivedAt"` // Local time
+	Accepted   int64     `json:"accepted"`
+	Rejected   int64     `json:"rejected"` // Out of order, or superseded by trades
+}
+
+// PriceUpdate represents a real-time price update (lightweight for high
+// frequency)
+type PriceUpdate struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Quote is the top of an order book
+type Quote struct {
+	Symbol       string    `json:"symbol"`
+	Bid          float64   `json:"bid"`
+	BidQty       float64   `json:"bidQty"`
+	Ask          float64   `json:"ask"`
+	AskQty       float64   `json:"askQty"`
+	Mid          float64   `json:"mid"`
+	Spread       float64   `json:"spread"`
+	SpreadBps    float64   `json:"spreadBps"` // Spread relative to the mid
+	LastUpdateID int64     `json:"lastUpdateId"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Synced       bool      `json:"synced"`
+}
+
+// ReconciliationEntry pairs the live and shadow paper execution of one signal
+type ReconciliationEntry struct {
+	ID              int64        `json:"id"`
+	Timestamp       time.Time    `json:"timestamp"`
+	Strategy        string       `json:"strategy"`
+	Side            OrderSide    `json:"side"`
+	SignalPrice     float64      `json:"signalPrice"`
+	Live            ExecutionLeg `json:"live"`
+	Paper           ExecutionLeg `json:"paper"`
+	SlippageDiffBps float64      `json:"slippageDiffBps"` // Live minus paper entry slippage
+	PnLDivergence   float64      `json:"pnlDivergence"`   // Live minus paper net P&L
+	Reconciled      bool         `json:"reconciled"`      // Both legs closed
+}
+
+// ReconciliationReport compares live fills with the shadow paper account
+type ReconciliationReport struct {
+	Summary ReconciliationSummary  `json:"summary"`
+	Entries []*ReconciliationEntry `json:"entries"` // Newest first
+}
+
+// ReconciliationSummary aggregates divergence across reconciled trades
+type ReconciliationSummary struct {
+	Trades              int     `json:"trades"`
+	Reconciled          int     `json:"reconciled"`
+	AvgLiveSlippageBps  float64 `json:"avgLiveSlippageBps"`
+	AvgPaperSlippageBps float64 `json:"avgPaperSlippageBps"`
+	AvgSlippageDiffBps  float64 `json:"avgSlippageDiffBps"`
+	LiveCommission      float64 `json:"liveCommission"`
+	PaperCommission     float64 `json:"paperCommission"`
+	LiveNetPnL          float64 `json:"liveNetPnl"`
+	PaperNetPnL         float64 `json:"paperNetPnl"`
+	TotalPnLDivergence  float64 `json:"totalPnlDivergence"`
+	AvgPnLDivergence    float64 `json:"avgPnlDivergence"`
+}
+
+// RefreshTokenRequest represents a token refresh request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshTokenResponse represents a token refresh response
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // seconds
+}
+
+// RegimeInfo represents market regime information
+type RegimeInfo struct {
+	Current       string                `json:"current"`
+	Confidence    float64               `json:"confidence"`
+	Trend         string                `json:"trend"`
+	TrendStrength string                `json:"trendStrength"`
+	ADX           float64               `json:"adx"`
+	RSI           float64               `json:"rsi"`
+	Volatility    string                `json:"volatility"`
+	Description   string                `json:"description"`
+	Session       string                `json:"session"`          // Current trading session
+	Routing       bool                  `json:"routing"`          // Strategies are routed by regime
+	Routes        map[string][]string   `json:"routes,omitempty"` // Strategies per regime; regimes not listed trade all
+	Strategies    []StrategyEligibility `json:"strategies"`       // Which strategies trade in the current regime
+}
+
+// RegimeMatrix cross-tabulates closed positions by strategy and the market
+// regime detected when they were entered
+type RegimeMatrix struct {
+	Timeframe    string              `json:"timeframe"`
+	From         time.Time           `json:"from"`
+	To           time.Time           `json:"to"`
+	Current      string              `json:"current"`      // Regime detected on the last candle
+	Regimes      []string            `json:"regimes"`      // Columns
+	Strategies   []string            `json:"strategies"`   // Rows
+	Cells        []RegimePerformance `json:"cells"`        // Strategy and regime pairs with trades
+	Totals       []RegimePerformance `json:"totals"`       // By regime, across strategies
+	Best         map[string]string   `json:"best"`         // By regime, the strategy with the highest net PnL over enough trades
+	Unclassified int                 `json:"unclassified"` // Positions entered without enough candles stored before them
+}
+
+// RegimePerformance is the performance of positions entered in one regime, by
+// one strategy or, with Strategy empty, by all of them
+type RegimePerformance struct {
+	Strategy     string  `json:"strategy,omitempty"`
+	Regime       string  `json:"regime"`
+	Trades       int     `json:"trades"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"winRate"`
+	NetPnL       float64 `json:"netPnl"`
+	AvgPnL       float64 `json:"avgPnl"`
+	ProfitFactor float64 `json:"profitFactor"`
+}
+
+// RegisterRequest represents a registration request
+type RegisterRequest struct {
+	Email              string      `json:"email"`
+	Password           string      `json:"password"`
+	FullName           string      `json:"full_name"`
+	AccountType        AccountType `json:"account_type"` // Account setup (demo or live)
+	AccountName        string      `json:"account_name"`
+	DemoInitialCapital *float64    `json:"demo_initial_capital,omitempty"` // Demo account fields (required if account_type = demo)
+	BinanceAPIKey      *string     `json:"binance_api_key,omitempty"`      // Live account fields (required if account_type = live)
+	BinanceSecretKey   *string     `json:"binance_secret_key,omitempty"`
+	BinanceTestnet     bool        `json:"binance_testnet"`
+}
+
+// Rejection records why a candidate was left out of the ranking
+type Rejection struct {
+	Symbol string `json:"symbol"`
+	Reason string `json:"reason"`
+}
+
+// ReloadStatus describes the configuration changes applied since start
+type ReloadStatus struct {
+	Path            string    `json:"path"`
+	Watching        bool      `json:"watching"`
+	Version         int       `json:"version"`              // Changes applied since start
+	LastSource      string    `json:"lastSource,omitempty"` // "file" or "api"
+	LastAppliedAt   time.Time `json:"lastAppliedAt,omitempty"`
+	LastError       string    `json:"lastError,omitempty"` // Of the last change rejected, which was rolled back
+	LastErrorAt     time.Time `json:"lastErrorAt,omitempty"`
+	RestartRequired []string  `json:"restartRequired"` // Keys changed since start that apply only on restart, e.g. "trading.symbol"
+}
+
+// Reoptimization is the outcome of re-running a strategy's recorded search on
+// recent data
+type Reoptimization struct {
+	Params          map[string]float64 `json:"params"`
+	Score           float64            `json:"score"`           // Objective of the suggested parameters
+	ConfiguredScore float64            `json:"configuredScore"` // Objective of the configured ones on the same data
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	RanAt           time.Time          `json:"ranAt"`
+	Deviations      []ParamDeviation   `json:"deviations"` // Configured values against the suggested ones
+	Drifted         bool               `json:"drifted"`    // Suggested optima differ materially and score better
+}
+
+// Report is the outcome of a scan
+type Report struct {
+	ScannedAt  time.Time   `json:"scannedAt"`
+	Timeframe  string      `json:"timeframe"`
+	Pairs      int         `json:"pairs"`      // Trading pairs in the quote asset
+	Candidates int         `json:"candidates"` // Pairs passing the volume filter that were scored
+	Results    []Result    `json:"results"`
+	Rejected   []Rejection `json:"rejected,omitempty"`
+}
+
+// ReportLocale is how generated reports and exports (CSV, HTML) format numbers,
+// times and amounts for a user. API JSON is never localized.
+type ReportLocale struct {
+	Locale           string `json:"locale"`           // Preset the fields default to, e.g. "de-DE"
+	DecimalSeparator string `json:"decimalSeparator"` // e.g. "," in de-DE
+	GroupSeparator   string `json:"groupSeparator"`   // Thousands separator; empty = none
+	DateFormat       string `json:"dateFormat"`       // Go time layout, e.g. "02.01.2006 15:04"
+	Timezone         string `json:"timezone"`         // IANA name; empty = UTC
+	CurrencySymbol   string `json:"currencySymbol"`   // Prefixed or suffixed to quote currency amounts
+	CurrencySuffix   bool   `json:"currencySuffix"`   // Symbol after the amount, e.g. "1.234,50 $"
+}
+
+// ReportLocaleResponse is a user's report locale and the presets available
+type ReportLocaleResponse struct {
+	ReportLocale
+	Locales []string `json:"locales"`
+}
+
+// RequestWeight reports REST request weight usage per Binance client
+type RequestWeight struct {
+	Market  *WeightStats `json:"market,omitempty"`  // Candles, tickers and backtest data
+	Trading *WeightStats `json:"trading,omitempty"` // Live or futures executor
+}
+
+// Result is one ranked symbol
+type Result struct {
+	Rank             int           `json:"rank"`
+	Symbol           string        `json:"symbol"`
+	Price            float64       `json:"price"`
+	QuoteVolume      float64       `json:"quoteVolume"` // 24h, in the quote asset
+	PriceChange      float64       `json:"priceChange"` // 24h, percent
+	ATRPercent       float64       `json:"atrPercent"`  // On the scan timeframe
+	Regime           string        `json:"regime"`
+	RegimeConfidence float64       `json:"regimeConfidence"`
+	ADX              float64       `json:"adx"`
+	BestStrategy     string        `json:"bestStrategy"`
+	Score            float64       `json:"score"` // Best strategy fit
+	Strategies       []StrategyFit `json:"strategies"`
+}
+
+// RiskConfigResponse represents risk configuration
+type RiskConfigResponse struct {
+	MaxPositionSize      float64 `json:"maxPositionSize"`
+	MaxPositionValue     float64 `json:"maxPositionValue"`
+	MaxRiskPerTrade      float64 `json:"maxRiskPerTrade"`
+	MinRiskRewardRatio   float64 `json:"minRiskRewardRatio"`
+	MaxDailyLoss         float64 `json:"maxDailyLoss"`
+	MaxWeeklyLoss        float64 `json:"maxWeeklyLoss"`
+	MaxTotalDrawdown     float64 `json:"maxTotalDrawdown"`
+	HighWaterMarkMode    string  `json:"highWaterMarkMode"`
+	MaxOpenPositions     int     `json:"maxOpenPositions"`
+	MaxLeverage          float64 `json:"maxLeverage"`
+	EnableCircuitBreaker bool    `json:"enableCircuitBreaker"`
+	ConsecutiveLossLimit int     `json:"consecutiveLossLimit"`
+	AdjustForVolatility  bool    `json:"adjustForVolatility"`
+	TradingHoursOnly     bool    `json:"tradingHoursOnly"`
+}
+
+// RiskEvent represents a risk-related event
+type RiskEvent struct {
+	Type      RiskEventType          `json:"Type"`
+	Level     RiskLevel              `json:"Level"`
+	Message   string                 `json:"Message"`
+	Details   map[string]interface{} `json:"Details"`
+	Timestamp time.Time              `json:"Timestamp"`
+	Handled   bool                   `json:"Handled"`
+}
+
+// RiskEventResponse represents a risk event
+type RiskEventResponse struct {
+	Type      string `json:"type"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+	Handled   bool   `json:"handled"`
+}
+
+// RiskEventType represents types of risk events
+type RiskEventType int
+
+// RiskLevel represents risk severity
+type RiskLevel int
+
+// RiskLimitsResponse represents the consolidated risk limits view
+type RiskLimitsResponse struct {
+	Level          string              `json:"level"`
+	IsHalted       bool                `json:"isHalted"`
+	HaltReason     string              `json:"haltReason,omitempty"`
+	HaltUntil      *time.Time          `json:"haltUntil,omitempty"`
+	IsWithinLimits bool                `json:"isWithinLimits"`
+	Breaches       []string            `json:"breaches"`
+	Limits         []LimitUtilization  `json:"limits"`
+	Drawdown       DrawdownResponse    `json:"drawdown"`
+	RecentEvents   []RiskEventResponse `json:"recentEvents"`
+	Timestamp      time.Time           `json:"timestamp"`
+}
+
+// RiskReport is the risk digest of one calendar day: what the bot wanted to
+// trade, what it blocked and why, and how close it ran to its limits
+type RiskReport struct {
+	Day              string                `json:"day"` // YYYY-MM-DD in the P&L timezone
+	Timezone         string                `json:"timezone"`
+	From             time.Time             `json:"from"` // Start of the tally; after the day start when the bot restarted mid-day
+	To               time.Time             `json:"to"`
+	SignalsGenerated int                   `json:"signalsGenerated"`
+	SignalsApproved  int                   `json:"signalsApproved"`
+	SignalsExecuted  int                   `json:"signalsExecuted"`
+	SignalsRejected  int                   `json:"signalsRejected"`
+	Rejections       map[string]int        `json:"rejections"` // Rejected signals by stage and reason
+	NearMisses       map[string]int        `json:"nearMisses"` // Approved signals by risk warning, e.g. "Approaching daily loss limit"
+	LimitPeaks       LimitPeaks            `json:"limitPeaks"`
+	RiskEvents       map[string]int        `json:"riskEvents"` // Risk events by type
+	CircuitBreakers  []CircuitBreakerEvent `json:"circuitBreakers"`
+}
+
+// RiskReports is the digest of the day in progress and the last closed days
+type RiskReports struct {
+	Current RiskReport   `json:"current"`
+	Reports []RiskReport `json:"reports"` // Most recent first
+}
+
+// RiskSettings represents risk management configuration
+type RiskSettings struct {
+	MaxPositionSize      float64 `json:"maxPositionSize"`      // Max position as % of equity (0.1 = 10%)
+	MaxRiskPerTrade      float64 `json:"maxRiskPerTrade"`      // Max risk per trade (0.02 = 2%)
+	MaxDailyLoss         float64 `json:"maxDailyLoss"`         // Max daily loss (0.05 = 5%)
+	MaxWeeklyLoss        float64 `json:"maxWeeklyLoss"`        // Max weekly loss (0.1 = 10%)
+	MaxDrawdown          float64 `json:"maxDrawdown"`          // Max total drawdown (0.2 = 20%)
+	HighWaterMarkMode    string  `json:"highWaterMarkMode"`    // Drawdown peak: trailing, monthly or deposit_adjusted
+	MaxOpenPositions     int     `json:"maxOpenPositions"`     // Max concurrent positions
+	MaxLeverage          float64 `json:"maxLeverage"`          // Max leverage (1.0 = no leverage)
+	MinRiskRewardRatio   float64 `json:"minRiskRewardRatio"`   // Minimum R/R ratio
+	EnableCircuitBreaker bool    `json:"enableCircuitBreaker"` // Enable circuit breaker
+	ConsecutiveLossLimit int     `json:"consecutiveLossLimit"` // Halt after N losses
+	HaltDurationHours    int     `json:"haltDurationHours"`    // Circuit breaker halt duration
+}
+
+// RiskStatusResponse represents risk status
+type RiskStatusResponse struct {
+	Level            string           `json:"level"`
+	CurrentDrawdown  float64          `json:"currentDrawdown"`
+	MaxDrawdown      float64          `json:"maxDrawdown"`
+	DailyLossUsed    float64          `json:"dailyLossUsed"`
+	DailyLossLimit   float64          `json:"dailyLossLimit"`
+	DailyLossPercent float64          `json:"dailyLossPercent"`
+	WeeklyLossUsed   float64          `json:"weeklyLossUsed"`
+	WeeklyLossLimit  float64          `json:"weeklyLossLimit"`
+	OpenPositions    int              `json:"openPositions"`
+	MaxPositions     int              `json:"maxPositions"`
+	IsHalted         bool             `json:"isHalted"`
+	HaltReason       string           `json:"haltReason,omitempty"`
+	IsWithinLimits   bool             `json:"isWithinLimits"`
+	Warnings         []string         `json:"warnings,omitempty"`
+	Periods          *PnLPeriodStatus `json:"periods,omitempty"` // Calendar day and week the loss limits cover
+}
+
+// RiskUpdate represents a risk update message
+type RiskUpdate struct {
+	Level           RiskLevel   `json:"level"`
+	Drawdown        float64     `json:"drawdown"`
+	MaxDrawdown     float64     `json:"maxDrawdown"`
+	DailyLossUsed   float64     `json:"dailyLossUsed"`
+	DailyLossLimit  float64     `json:"dailyLossLimit"`
+	WeeklyLossUsed  float64     `json:"weeklyLossUsed"`
+	WeeklyLossLimit float64     `json:"weeklyLossLimit"`
+	IsHalted        bool        `json:"isHalted"`
+	HaltReason      string      `json:"haltReason,omitempty"`
+	Events          []RiskEvent `json:"events,omitempty"`
+}
+
+// SandboxMetrics are the headline results of a sandbox backtest
+type SandboxMetrics struct {
+	TotalReturn   float64 `json:"totalReturn"`
+	NetProfit     float64 `json:"netProfit"`
+	EndingCapital float64 `json:"endingCapital"`
+	MaxDrawdown   float64 `json:"maxDrawdown"`
+	SharpeRatio   float64 `json:"sharpeRatio"`
+	TotalTrades   int     `json:"totalTrades"`
+	WinRate       float64 `json:"winRate"`
+	ProfitFactor  float64 `json:"profitFactor"`
+	Expectancy    float64 `json:"expectancy"`
+}
+
+// SandboxRequest is an inline strategy script and the range to test it over
+type SandboxRequest struct {
+	Script         string  `json:"script"` // Lua source defining analyze(data), as in strategies/*.lua
+	Symbol         string  `json:"symbol"`
+	Timeframe      string  `json:"timeframe"`
+	StartDate      string  `json:"startDate"` // YYYY-MM-DD; default 30 days ago
+	EndDate        string  `json:"endDate"`   // YYYY-MM-DD; default today
+	InitialCapital float64 `json:"initialCapital"`
+	RiskPerTrade   float64 `json:"riskPerTrade"`
+}
+
+// SandboxResponse summarizes a sandbox backtest
+type SandboxResponse struct {
+	Strategy      string         `json:"strategy"`
+	Bars          int            `json:"bars"`
+	Metrics       SandboxMetrics `json:"metrics"`
+	ScriptError   string         `json:"scriptError,omitempty"` // Last runtime error raised by the script
+	Disabled      bool           `json:"disabled,omitempty"`    // The script ran out of time or memory and stopped trading
+	ExecutionTime string         `json:"executionTime"`
+}
+
+// ScheduleStatus is the current state of the mode schedule
+type ScheduleStatus struct {
+	Enabled  bool                 `json:"enabled"`
+	Timezone string               `json:"timezone,omitempty"`
+	Mode     string               `json:"mode"`
+	Current  ScheduledMode        `json:"current,omitempty"`
+	Window   string               `json:"window,omitempty"`
+	Next     *ScheduledTransition `json:"next,omitempty"`
+}
+
+// ScheduledMode is the trading mode requested by a schedule
+type ScheduledMode string
+
+// ScheduledTransition describes an upcoming mode switch
+type ScheduledTransition struct {
+	At     time.Time     `json:"at"`
+	From   ScheduledMode `json:"from"`
+	To     ScheduledMode `json:"to"`
+	Window string        `json:"window,omitempty"`
+	Policy CarryPolicy   `json:"policy"`
+}
+
+// ScoreBreakdown explains a combined score against the entry thresholds
+type ScoreBreakdown struct {
+	Regime             string                   `json:"regime"`
+	RegimeConfidence   float64                  `json:"regimeConfidence"`
+	ConflictMode       string                   `json:"conflictMode"`
+	Direction          string                   `json:"direction"`
+	Score              float64                  `json:"score"`
+	Confidence         float64                  `json:"confidence"`
+	MinScore           float64                  `json:"minScore"`
+	MinConfidence      float64                  `json:"minConfidence"`
+	ScoreProgress      float64                  `json:"scoreProgress"`      // Score / minScore; 1 meets the threshold
+	ConfidenceProgress float64                  `json:"confidenceProgress"` // Confidence / minConfidence
+	ShouldTrade        bool                     `json:"shouldTrade"`
+	BestStrategy       string                   `json:"bestStrategy,omitempty"`
+	LongSignals        int                      `json:"longSignals"`
+	ShortSignals       int                      `json:"shortSignals"`
+	HasConflict        bool                     `json:"hasConflict"`
+	ConflictLevel      float64                  `json:"conflictLevel"`
+	Strategies         []StrategyScoreBreakdown `json:"strategies"`
+}
+
+// ScoreUpdate is the scorer's breakdown of the latest analysis
+type ScoreUpdate struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Timestamp time.Time `json:"timestamp"` // When the analysis ran
+	Action    string    `json:"action"`    // Recommendation after regime filters
+	Reason    string    `json:"reason,omitempty"`
+	ScoreBreakdown
+}
+
+// SessionPerformance is the trading and market activity of one session
+type SessionPerformance struct {
+	Session      TradingSession `json:"session"`
+	Trades       int            `json:"trades"` // Closed positions, attributed to the session they were entered in
+	Wins         int            `json:"wins"`
+	Losses       int            `json:"losses"`
+	WinRate      float64        `json:"winRate"`
+	NetPnL       float64        `json:"netPnl"`
+	AvgPnL       float64        `json:"avgPnl"`
+	ProfitFactor float64        `json:"profitFactor"`
+	Candles      int            `json:"candles"`     // Primary timeframe candles opened in the session
+	Volatility   float64        `json:"volatility"`  // Standard deviation of close-to-close returns
+	AvgRange     float64        `json:"avgRange"`    // Average high-low range as a fraction of the open
+	AvgVolume    float64        `json:"avgVolume"`   // Average candle volume
+	VolumeShare  float64        `json:"volumeShare"` // Share of the period's volume
+}
+
+// SessionReport breaks performance down by trading session
+type SessionReport struct {
+	Strategy  string               `json:"strategy,omitempty"`
+	Timeframe string               `json:"timeframe"`
+	From      time.Time            `json:"from"`
+	To        time.Time            `json:"to"`
+	Current   string               `json:"current"`
+	Sessions  []SessionPerformance `json:"sessions"`
+}
+
+// SettingsChangeResponse represents an entry of the settings audit trail
+type SettingsChangeResponse struct {
+	VersionID  int64     `json:"versionId"`
+	Section    string    `json:"section"`
+	Action     string    `json:"action"`
+	OldValue   Value     `json:"oldValue,omitempty"`
+	NewValue   Value     `json:"newValue"`
+	ChangedBy  string    `json:"changedBy"`
+	RollbackOf *int64    `json:"rollbackOf,omitempty"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+// ShareBundle is a shareable record of trading results. It holds no order,
+// position or account identifiers, and amounts are rescaled to a nominal
+// starting equity; prices and times are kept so results can be charted.
+type ShareBundle struct {
+	Format         string              `json:"format"`
+	Version        int                 `json:"version"`
+	Name           string              `json:"name,omitempty"`
+	Symbol         string              `json:"symbol"`
+	Timeframe      string              `json:"timeframe"`
+	ConfigHash     string              `json:"configHash,omitempty"` // Fingerprint of the strategy settings
+	From           time.Time           `json:"from"`
+	To             time.Time           `json:"to"`
+	StartingEquity float64             `json:"startingEquity"`
+	Summary        ShareSummary        `json:"summary"`
+	Trades         []SharedTrade       `json:"trades"`
+	EquityCurve    []SharedEquityPoint `json:"equityCurve"`
+	Candles        []SharedCandle      `json:"candles,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt"`
+}
+
+// ShareSummary is the headline performance of a bundle
+type ShareSummary struct {
+	Trades         int     `json:"trades"`
+	Wins           int     `json:"wins"`
+	Losses         int     `json:"losses"`
+	WinRate        float64 `json:"winRate"`
+	NetPnL         float64 `json:"netPnl"`
+	ReturnPct      float64 `json:"returnPct"`
+	MaxDrawdownPct float64 `json:"maxDrawdownPct"`
+	ProfitFactor   float64 `json:"profitFactor"`
+}
+
+// SharedBundle is a result bundle shared by another user and imported here
+type SharedBundle struct {
+	ID          string    `json:"id"` // Content hash of the bundle
+	Name        string    `json:"name"`
+	Symbol      string    `json:"symbol"`
+	Timeframe   string    `json:"timeframe"`
+	ConfigHash  string    `json:"config_hash"`
+	Trades      int       `json:"trades"`
+	ReturnPct   float64   `json:"return_pct"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Bundle      Value     `json:"bundle,omitempty"` // Only loaded by Get
+	ImportedBy  string    `json:"imported_by"`
+	ImportedAt  time.Time `json:"imported_at"`
+}
+
+// SharedCandle is a primary timeframe candle of a bundle
+type SharedCandle struct {
+	Time   time.Time `json:"time"` // Open time
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// SharedEquityPoint is a point of a bundle's equity curve
+type SharedEquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"` // Rescaled
+}
+
+// SharedTrade is a closed position in a bundle
+type SharedTrade struct {
+	Strategy   string    `json:"strategy"`
+	Side       string    `json:"side"`
+	EntryPrice float64   `json:"entryPrice"`
+	ExitPrice  float64   `json:"exitPrice"`
+	Quantity   float64   `json:"quantity"` // Rescaled
+	PnL        float64   `json:"pnl"`      // Rescaled
+	ReturnPct  float64   `json:"returnPct"`
+	OpenedAt   time.Time `json:"openedAt"`
+	ClosedAt   time.Time `json:"closedAt"`
+}
+
+// Signal represents a trading signal
+type Signal struct {
+	Type       SignalType       `json:"type"`
+	Direction  string           `json:"direction"`
+	Strength   float64          `json:"strength"`   // 0-1 signal strength
+	Price      float64          `json:"price"`      // Current price
+	StopLoss   float64          `json:"stopLoss"`   // Suggested stop loss
+	TakeProfit float64          `json:"takeProfit"` // Suggested take profit
+	Confidence float64          `json:"confidence"` // 0-1 confidence level
+	Reason     string           `json:"reason"`     // Signal reason
+	Strategy   string           `json:"strategy"`   // Strategy that generated signal
+	Timestamp  time.Time        `json:"timestamp"`
+	Timeframe  string           `json:"timeframe"`
+	Symbol     string           `json:"symbol"`
+	Indicators SignalIndicators `json:"indicators"`
+	SizeFactor float64          `json:"sizeFactor,omitempty"` // Fraction of the usual size to trade; 0 = full size
+}
+
+// SignalIndicators holds indicator values at signal time
+type SignalIndicators struct {
+	RSI        float64 `json:"RSI"`
+	MACD       float64 `json:"MACD"`
+	MACDSignal float64 `json:"MACDSignal"`
+	ADX        float64 `json:"ADX"`
+	ATR        float64 `json:"ATR"`
+	BBPercentB float64 `json:"BBPercentB"`
+	Volume     float64 `json:"Volume"`
+}
+
+// SignalInfo represents a trading signal
+type SignalInfo struct {
+	Timestamp  string  `json:"timestamp"`
+	Strategy   string  `json:"strategy"`
+	Symbol     string  `json:"symbol"`
+	Direction  string  `json:"direction"`
+	EntryPrice float64 `json:"entryPrice"`
+	StopLoss   float64 `json:"stopLoss"`
+	TakeProfit float64 `json:"takeProfit"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+	Approved   bool    `json:"approved"`
+}
+
+// SignalRecord stores a signal with its approval status for history
+type SignalRecord struct {
+	Signal     *Signal              `json:"signal"`
+	Approved   bool                 `json:"approved"`
+	Reason     string               `json:"reason,omitempty"`
+	StopLoss   *StopLossEnforcement `json:"stopLossEnforcement,omitempty"` // Set when the signal had no stop loss
+	Liquidity  *LiquidityCheck      `json:"liquidity,omitempty"`           // Set when the liquidity filter is on
+	Confluence *ConfluenceCheck     `json:"confluence,omitempty"`          // Set when confluence applies to the strategy
+	ReceivedAt time.Time            `json:"receivedAt"`
+}
+
+// SignalThrottleStats counts a strategy's entry signals let through and dropped
+// by its cooldown
+type SignalThrottleStats struct {
+	Strategy        string    `json:"strategy"`
+	Cooldown        int       `json:"cooldown"` // Candles between entry signals; 0 = unthrottled
+	Passed          int64     `json:"passed"`
+	Throttled       int64     `json:"throttled"`
+	LastSignalAt    time.Time `json:"lastSignalAt,omitempty"` // Close time of the candle of the last signal let through
+	LastThrottledAt time.Time `json:"lastThrottledAt,omitempty"`
+}
+
+// SignalType represents type of signal
+type SignalType int
+
+// SignalUpdate represents a signal update message
+type SignalUpdate struct {
+	Signal     *Signal          `json:"signal"`
+	Approved   bool             `json:"approved"`
+	RejectedBy string           `json:"rejectedBy,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	Liquidity  *LiquidityCheck  `json:"liquidity,omitempty"`  // Set when the liquidity filter is on
+	Confluence *ConfluenceCheck `json:"confluence,omitempty"` // Set when confluence applies to the strategy
+}
+
+// StageLatency summarizes the timings of one pipeline stage
+type StageLatency struct {
+	Stage    string  `json:"stage"`
+	BudgetMs float64 `json:"budgetMs"` // 0 = not enforced
+	Count    int64   `json:"count"`
+	Breaches int64   `json:"breaches"`
+	LastMs   float64 `json:"lastMs"`
+	AvgMs    float64 `json:"avgMs"`
+	MaxMs    float64 `json:"maxMs"`
+}
+
+// StateSnapshot is the trading state at a version. Full snapshots carry every
+// field; deltas only the fields changed after the requested version.
+type StateSnapshot struct {
+	Version uint64           `json:"version"`
+	Full    bool             `json:"full"`
+	State   map[string]Value `json:"state"` // TradingState fields by name
+}
+
+// StateUpdate represents a state update message
+type StateUpdate struct {
+	State   *TradingState   `json:"state"`
+	Summary *AccountSummary `json:"summary"`
+}
+
+// StopLossEnforcement records how the stop-loss policy treated a signal
+type StopLossEnforcement struct {
+	Policy   StopLossPolicy `json:"policy"`
+	Action   string         `json:"action"` // "none", "skipped", "rejected" or "derived"
+	StopLoss float64        `json:"stopLoss,omitempty"`
+	Detail   string         `json:"detail,omitempty"`
+}
+
+// StopLossPolicy determines what happens to an entry signal without a stop loss
+type StopLossPolicy string
+
+// StrategyAllocation is the capital assigned to a single strategy
+type StrategyAllocation struct {
+	Strategy    string  `json:"strategy"`
+	Weight      float64 `json:"weight"`
+	Capital     float64 `json:"capital"`
+	Used        float64 `json:"used"`
+	BuyingPower float64 `json:"buyingPower"`
+}
+
+// StrategyConfig represents individual strategy config
+type StrategyConfig struct {
+	Name           string                 `json:"name"`                     // Strategy name
+	Enabled        bool                   `json:"enabled"`                  // Is strategy enabled
+	Config         map[string]interface{} `json:"config"`                   // Strategy-specific config
+	SignalCooldown *int                   `json:"signalCooldown,omitempty"` // Primary candles between entry signals (0 = unthrottled); omit to keep
+}
+
+// StrategyEligibility describes whether a strategy takes part in scoring under
+// a given regime
+type StrategyEligibility struct {
+	Strategy          string   `json:"strategy"`
+	Enabled           bool     `json:"enabled"`
+	Weight            float64  `json:"weight"` // Regime-adjusted weight
+	DisallowedRegimes []string `json:"disallowedRegimes"`
+	Blocked           bool     `json:"blocked"`   // Regime is disallowed for this strategy
+	RoutedOut         bool     `json:"routedOut"` // Regime is routed to other strategies
+	Eligible          bool     `json:"eligible"`
+}
+
+// StrategyFit scores how well a strategy suits a symbol's current regime
+type StrategyFit struct {
+	Strategy string  `json:"strategy"`
+	Weight   float64 `json:"weight"` // Regime-adjusted strategy weight
+	Fit      float64 `json:"fit"`    // Weight scaled by regime confidence
+}
+
+// StrategyInfo represents strategy information
+type StrategyInfo struct {
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Enabled       bool                     `json:"enabled"`
+	Config        map[string]interface{}   `json:"config"`
+	Performance   *StrategyPerformance     `json:"performance,omitempty"`
+	Signals       *SignalThrottleStats     `json:"signals,omitempty"`
+	Spikes        *VolatilityThrottleStats `json:"spikes,omitempty"`        // Entries signaled on volatility spikes
+	Confirmations *EntryConfirmationStats  `json:"confirmations,omitempty"` // Entries held for a confirmation candle
+}
+
+// StrategyPerformance represents strategy performance metrics
+type StrategyPerformance struct {
+	TotalTrades  int     `json:"totalTrades"`
+	WinRate      float64 `json:"winRate"`
+	ProfitFactor float64 `json:"profitFactor"`
+	NetProfit    float64 `json:"netProfit"`
+	Contribution float64 `json:"contribution"`
+}
+
+// StrategyPreviewResponse shows which strategies take part in scoring
+type StrategyPreviewResponse struct {
+	Regime     string                `json:"regime"`
+	Strategies []StrategyEligibility `json:"strategies"`
+}
+
+// StrategyScoreBreakdown describes one strategy's part in a combined score
+type StrategyScoreBreakdown struct {
+	Strategy         string  `json:"strategy"`
+	Status           string  `json:"status"`
+	BaseWeight       float64 `json:"baseWeight"`
+	RegimeMultiplier float64 `json:"regimeMultiplier"` // 1 when regime weights are off
+	Weight           float64 `json:"weight"`           // Base weight x regime multiplier
+	Direction        string  `json:"direction,omitempty"`
+	RawStrength      float64 `json:"rawStrength"` // Signal strength before weighting
+	Score            float64 `json:"score"`       // Weighted strength
+	Confidence       float64 `json:"confidence"`  // Weighted confidence
+	Signals          int     `json:"signals"`
+}
+
+// StrategySettings represents strategy configuration
+type StrategySettings struct {
+	Enabled []StrategyConfig `json:"enabled"` // Enabled strategies with configs
+}
+
+// StrategyStanding is one strategy's realized performance over the leaderboard
+// window
+type StrategyStanding struct {
+	Rank         int        `json:"rank"`
+	Strategy     string     `json:"strategy"`
+	Enabled      bool       `json:"enabled"` // Running now; false for strategies that only have history
+	Trades       int        `json:"trades"`
+	Wins         int        `json:"wins"`
+	Losses       int        `json:"losses"`
+	WinRate      float64    `json:"winRate"`
+	NetPnL       float64    `json:"netPnl"`
+	Expectancy   float64    `json:"expectancy"` // Average P&L per trade
+	AvgWin       float64    `json:"avgWin"`
+	AvgLoss      float64    `json:"avgLoss"` // Positive
+	ProfitFactor float64    `json:"profitFactor"`
+	Sharpe       float64    `json:"sharpe"`      // Annualized, from daily realized P&L
+	MaxDrawdown  float64    `json:"maxDrawdown"` // Largest fall of cumulative realized P&L from its peak
+	LastTrade    *time.Time `json:"lastTrade,omitempty"`
+}
+
+// StrategyStateUpdate is broadcast when a strategy is enabled or disabled
+type StrategyStateUpdate struct {
+	Strategy  string `json:"strategy"`
+	Enabled   bool   `json:"enabled"`
+	ChangedBy string `json:"changedBy,omitempty"`
+}
+
+// StreamBandwidth reports traffic on the exchange WebSocket connections
+type StreamBandwidth struct {
+	Market   *WSStats `json:"market,omitempty"`
+	UserData *WSStats `json:"userData,omitempty"` // Live trading only
+}
+
+// StreamStats holds traffic counters for one stream
+type StreamStats struct {
+	Stream         string    `json:"stream"`
+	Messages       int64     `json:"messages"`
+	Bytes          int64     `json:"bytes"` // Payload bytes after decompression
+	MessagesPerSec float64   `json:"messagesPerSec"`
+	BytesPerSec    float64   `json:"bytesPerSec"`
+	LastMessage    time.Time `json:"lastMessage"`
+}
+
+// StreamSubscription is a Binance stream the WebSocket client subscribes to
+type StreamSubscription struct {
+	Stream      string     `json:"stream"`
+	Core        bool       `json:"core"` // Subscribed by the bot itself; cannot be removed
+	AddedBy     string     `json:"addedBy,omitempty"`
+	AddedAt     *time.Time `json:"addedAt,omitempty"`
+	Messages    int64      `json:"messages"`
+	LastMessage *time.Time `json:"lastMessage,omitempty"`
+}
+
+// SubBalanceStatus describes the bot's capital against the whole account
+type SubBalanceStatus struct {
+	Enabled       bool              `json:"enabled"`
+	Allocated     float64           `json:"allocated,omitempty"`
+	RealizedPnL   float64           `json:"realizedPnl,omitempty"`
+	UnrealizedPnL float64           `json:"unrealizedPnl,omitempty"`
+	Equity        float64           `json:"equity"` // What equity, drawdown and sizing use
+	AccountEquity float64           `json:"accountEquity"`
+	Unallocated   float64           `json:"unallocated,omitempty"`
+	Since         *time.Time        `json:"since,omitempty"`
+	Transfers     []CapitalTransfer `json:"transfers,omitempty"`
+}
+
+// SubscriptionChange is the data of subscribe and unsubscribe messages
+type SubscriptionChange struct {
+	Types []string `json:"types"` // Message types, e.g. "trade"
+}
+
+// SubscriptionRequest names a Binance stream to subscribe to
+type SubscriptionRequest struct {
+	Stream string `json:"stream"` // e.g. ethusdt@depth20@100ms
+}
+
+// Subscriptions are the message types a client receives
+type Subscriptions struct {
+	Types    []string `json:"types"` // Subscribed to; empty when all is set
+	All      bool     `json:"all"`
+	Excluded []string `json:"excluded"` // Unsubscribed from while receiving all
+}
+
+// SuperTrendValue represents SuperTrend values
+type SuperTrendValue struct {
+	Value     float64 `json:"value"`
+	Direction string  `json:"direction"` // UP or DOWN
+}
+
+// SymbolConfig represents overrides for one symbol, merged over the global
+// settings. Unset fields keep the global value.
+type SymbolConfig struct {
+	Risk       SymbolRiskConfig     `json:"risk"`
+	Strategies SymbolStrategyConfig `json:"strategies"`
+}
+
+// SymbolRiskConfig overrides risk and position sizing limits
+type SymbolRiskConfig struct {
+	MaxPositionSize       *float64 `json:"maxPositionSize,omitempty"`
+	MaxRiskPerTrade       *float64 `json:"maxRiskPerTrade,omitempty"`
+	MaxLeverage           *float64 `json:"maxLeverage,omitempty"`
+	MinRiskRewardRatio    *float64 `json:"minRiskRewardRatio,omitempty"`
+	StopLossPolicy        *string  `json:"stopLossPolicy,omitempty"`        // "reject", "atr" or "skip"
+	StopLossATRMultiplier *float64 `json:"stopLossATRMultiplier,omitempty"` // Derived stop distance in ATRs
+}
+
+// SymbolRules are the exchange's trading rules for a symbol, which an order's
+// price and quantity must meet
+type SymbolRules struct {
+	Symbol              string    `json:"symbol"`
+	Market              string    `json:"market"` // "spot" or "futures"
+	Status              string    `json:"status"`
+	BaseAsset           string    `json:"baseAsset"`
+	QuoteAsset          string    `json:"quoteAsset"`
+	BaseAssetPrecision  int       `json:"baseAssetPrecision"`
+	QuoteAssetPrecision int       `json:"quoteAssetPrecision"`
+	OrderTypes          []string  `json:"orderTypes"`
+	TickSize            float64   `json:"tickSize"` // Prices are multiples of it
+	MinPrice            float64   `json:"minPrice"`
+	MaxPrice            float64   `json:"maxPrice"` // 0 = no limit
+	StepSize            float64   `json:"stepSize"` // Quantities are multiples of it
+	MinQty              float64   `json:"minQty"`
+	MaxQty              float64   `json:"maxQty"`
+	MinNotional         float64   `json:"minNotional"`       // Minimum price × quantity
+	PricePrecision      int       `json:"pricePrecision"`    // Decimals of the tick size
+	QuantityPrecision   int       `json:"quantityPrecision"` // Decimals of the step size
+	FetchedAt           time.Time `json:"fetchedAt"`
+}
+
+// SymbolStrategyConfig overrides strategy selection and parameters
+type SymbolStrategyConfig struct {
+	Enabled           []string            `json:"enabled,omitempty"`           // Replaces the global list
+	DisallowedRegimes map[string][]string `json:"disallowedRegimes,omitempty"` // Replaces the entries of the strategies listed
+	SignalCooldown    *int                `json:"signalCooldown,omitempty"`
+	SignalCooldowns   map[string]int      `json:"signalCooldowns,omitempty"` // Replaces the entries of the strategies listed
+}
+
+// Tape is the aggregated order-flow tape
+type Tape struct {
+	Symbol          string       `json:"symbol"`
+	Bucket          string       `json:"bucket"`
+	Window          string       `json:"window"`
+	LargeTradeValue float64      `json:"largeTradeValue"`
+	From            time.Time    `json:"from"`
+	To              time.Time    `json:"to"`
+	BuyVolume       float64      `json:"buyVolume"`
+	SellVolume      float64      `json:"sellVolume"`
+	Delta           float64      `json:"delta"`
+	Buckets         []TapeBucket `json:"buckets"` // Oldest first; buckets without trades are omitted
+}
+
+// TapeBucket aggregates the trades within one bucket of the tape
+type TapeBucket struct {
+	Start       time.Time   `json:"start"`
+	Trades      int         `json:"trades"`
+	BuyVolume   float64     `json:"buyVolume"`  // Base quantity bought by takers
+	SellVolume  float64     `json:"sellVolume"` // Base quantity sold by takers
+	BuyCount    int         `json:"buyCount"`
+	SellCount   int         `json:"sellCount"`
+	Delta       float64     `json:"delta"` // BuyVolume - SellVolume
+	VWAP        float64     `json:"vwap"`
+	High        float64     `json:"high"`
+	Low         float64     `json:"low"`
+	LargeTrades []TapeTrade `json:"largeTrades,omitempty"`
+}
+
+// TapeTrade is a single trade on the tape
+type TapeTrade struct {
+	ID       int64     `json:"id"`
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Quantity float64   `json:"quantity"`
+	Value    float64   `json:"value"` // Quote value (USDT)
+	Side     string    `json:"side"`  // Aggressor side: "buy" or "sell"
+}
+
+// TickerData represents ticker data
+type TickerData struct {
+	Symbol        string  `json:"symbol"`
+	Price         float64 `json:"price"`
+	PriceChange   float64 `json:"priceChange"`
+	PercentChange float64 `json:"percentChange"`
+	High24h       float64 `json:"high24h"`
+	Low24h        float64 `json:"low24h"`
+	Volume24h     float64 `json:"volume24h"`
+	Timestamp     int64   `json:"timestamp"`
+}
+
+// TimeframeHealth describes how fresh the candle feed is for one timeframe
+type TimeframeHealth struct {
+	Timeframe         string    `json:"timeframe"`
+	LastCandleTime    time.Time `json:"lastCandleTime"`    // Close time of the last closed candle
+	ExpectedNextClose time.Time `json:"expectedNextClose"` // When the next candle should close
+	BehindBy          int       `json:"behindBy"`          // Closed bars that have not arrived yet
+	Stale             bool      `json:"stale"`
+}
+
+// TimeframeStance is one higher timeframe's trend against an entry
+type TimeframeStance struct {
+	Timeframe string `json:"timeframe"`
+	Trend     string `json:"trend"`    // UP, DOWN or NEUTRAL
+	Strength  string `json:"strength"` // WEAK to VERY_STRONG
+	Stance    string `json:"stance"`
+}
+
+// TradeData represents a trade for API response
+type TradeData struct {
+	ID          string    `json:"id"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	Type        string    `json:"type"`
+	Quantity    float64   `json:"quantity"`
+	Price       float64   `json:"price"`
+	RealizedPnL float64   `json:"realizedPnL"`
+	Commission  float64   `json:"commission"`
+	Strategy    string    `json:"strategy"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// TradeHistoryData represents a persisted fill. Partial fills of one order are
+// merged at their average price.
+type TradeHistoryData struct {
+	ID              int64   `json:"id"`
+	OrderID         string  `json:"orderId"`
+	Symbol          string  `json:"symbol"`
+	Side            string  `json:"side"`
+	Type            string  `json:"type"`
+	Quantity        float64 `json:"quantity"`
+	Price           float64 `json:"price"`
+	Commission      float64 `json:"commission"`
+	CommissionAsset string  `json:"commissionAsset"`
+	Strategy        string  `json:"strategy"`
+	ExecutedAt      int64   `json:"executedAt"`
+	Session         string  `json:"session"` // Trading session of the fill
+}
+
+// TradeIdea is an approved signal waiting for human confirmation
+type TradeIdea struct {
+	ID         string        `json:"id"`
+	Status     string        `json:"status"`
+	Signal     Signal        `json:"signal"`
+	Reasoning  IdeaReasoning `json:"reasoning"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  time.Time     `json:"expiresAt"`
+	DecidedAt  *time.Time    `json:"decidedAt,omitempty"`
+	DecidedBy  string        `json:"decidedBy,omitempty"`
+	Note       string        `json:"note,omitempty"`
+	OrderID    string        `json:"orderId,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	EntryPrice float64       `json:"entryPrice,omitempty"` // Price the order was sized at on approval
+}
+
+// TradeUpdate represents a trade update message
+type TradeUpdate struct {
+	TradeID     string    `json:"tradeId"`
+	OrderID     string    `json:"orderId"`
+	Symbol      string    `json:"symbol"`
+	Side        OrderSide `json:"side"`
+	Type        string    `json:"type"`
+	Quantity    float64   `json:"quantity"`
+	Price       float64   `json:"price"`
+	Commission  float64   `json:"commission"`
+	RealizedPnL float64   `json:"realizedPnL"`
+	Strategy    string    `json:"strategy"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// TradingAccountCreateRequest represents a request to create a new trading
+// account
+type TradingAccountCreateRequest struct {
+	AccountType        AccountType `json:"account_type"`
+	AccountName        string      `json:"account_name"`
+	DemoInitialCapital *float64    `json:"demo_initial_capital,omitempty"` // Demo account fields (required if account_type = demo)
+	BinanceAPIKey      *string     `json:"binance_api_key,omitempty"`      // Live account fields (required if account_type = live)
+	BinanceSecretKey   *string     `json:"binance_secret_key,omitempty"`
+	BinanceTestnet     bool        `json:"binance_testnet"`
+	TradingSymbol      string      `json:"trading_symbol"` // Trading configuration
+	EnabledStrategies  []string    `json:"enabled_strategies"`
+}
+
+// TradingAccountResponse is the public response for a trading account
+type TradingAccountResponse struct {
+	ID                  string            `json:"id"`
+	UserID              string            `json:"user_id"`
+	AccountType         AccountType       `json:"account_type"`
+	AccountName         string            `json:"account_name"`
+	DemoInitialCapital  *float64          `json:"demo_initial_capital,omitempty"` // Demo account fields
+	DemoCurrentBalance  *float64          `json:"demo_current_balance,omitempty"`
+	BinanceAPIKeyMasked *string           `json:"binance_api_key_masked,omitempty"` // Only show last 4 chars
+	BinanceTestnet      bool              `json:"binance_testnet"`
+	TradingSymbol       string            `json:"trading_symbol"` // Trading configuration
+	TradingMode         ModelsTradingMode `json:"trading_mode"`
+	EnabledStrategies   []string          `json:"enabled_strategies"`
+	RiskConfig          AccountRiskConfig `json:"risk_config"` // Isolated executor
+	ExecutorEnabled     bool              `json:"executor_enabled"`
+	KeysLinked          bool              `json:"keys_linked"` // A Binance secret is stored
+	IsActive            bool              `json:"is_active"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+// TradingAccountUpdateRequest represents an account update request
+type TradingAccountUpdateRequest struct {
+	AccountName       *string            `json:"account_name,omitempty"`
+	TradingSymbol     *string            `json:"trading_symbol,omitempty"`
+	TradingMode       *ModelsTradingMode `json:"trading_mode,omitempty"`
+	EnabledStrategies []string           `json:"enabled_strategies,omitempty"`
+	RiskConfig        *AccountRiskConfig `json:"risk_config,omitempty"`
+	IsActive          *bool              `json:"is_active,omitempty"`
+}
+
+// TradingSession is the regional session a time falls in. Sessions are fixed
+// UTC windows and do not overlap, so every time has exactly one.
+type TradingSession string
+
+// TradingSettings represents trading configuration
+type TradingSettings struct {
+	Mode             string   `json:"mode"`             // "paper" or "live"
+	Symbol           string   `json:"symbol"`           // e.g., "ETHUSDT"
+	Timeframes       []string `json:"timeframes"`       // e.g., ["1m", "5m", "15m", "1h", "4h"]
+	PrimaryTimeframe string   `json:"primaryTimeframe"` // e.g., "1h"
+	InitialBalance   float64  `json:"initialBalance"`   // Paper trading initial balance
+	Commission       float64  `json:"commission"`       // Commission rate (0.001 = 0.1%)
+	Slippage         float64  `json:"slippage"`         // Slippage rate
+}
+
+// TradingState represents the current trading state
+type TradingState struct {
+	Mode             OrchestratorTradingMode `json:"Mode"` // General
+	IsRunning        bool                    `json:"IsRunning"`
+	IsPaused         bool                    `json:"IsPaused"`
+	Arming           ArmingState             `json:"Arming"` // Live-mode arming phase (empty when not required)
+	StartTime        time.Time               `json:"StartTime"`
+	LastUpdate       time.Time               `json:"LastUpdate"`
+	CurrentPrice     float64                 `json:"CurrentPrice"` // Market
+	DailyChange      float64                 `json:"DailyChange"`
+	Volume24h        float64                 `json:"Volume24h"`
+	Equity           float64                 `json:"Equity"` // Account
+	AvailableBalance float64                 `json:"AvailableBalance"`
+	UnrealizedPnL    float64                 `json:"UnrealizedPnL"`
+	RealizedPnL      float64                 `json:"RealizedPnL"`
+	DailyPnL         float64                 `json:"DailyPnL"`
+	OpenPositions    int                     `json:"OpenPositions"` // Positions
+	TotalTrades      int                     `json:"TotalTrades"`
+	WinRate          float64                 `json:"WinRate"`
+	CurrentDrawdown  float64                 `json:"CurrentDrawdown"` // Risk
+	MaxDrawdown      float64                 `json:"MaxDrawdown"`     // Drawdown limit in quote currency, on PeakEquity
+	PeakEquity       float64                 `json:"PeakEquity"`      // High-water mark drawdown is measured from
+	RiskLevel        RiskLevel               `json:"RiskLevel"`
+	IsHalted         bool                    `json:"IsHalted"`
+	HaltReason       string                  `json:"HaltReason"`
+	ActiveStrategies []string                `json:"ActiveStrategies"` // Strategy
+	CurrentRegime    string                  `json:"CurrentRegime"`
+	LastSignal       *Signal                 `json:"LastSignal"`
+	CandleCount      int                     `json:"CandleCount"` // System
+	LastCandleTime   time.Time               `json:"LastCandleTime"`
+	Timeframes       []TimeframeHealth       `json:"Timeframes"` // Candle feed freshness per timeframe
+	Errors           []string                `json:"Errors"`
+}
+
+// TradingStateResponse represents trading state response
+type TradingStateResponse struct {
+	State *TradingState `json:"state"`
+}
+
+// TwoFactorCodeRequest carries a code from the user's authenticator app
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorDisableRequest re-authenticates the user before removing two-factor
+// authentication
+type TwoFactorDisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// TwoFactorEnrollment is a new TOTP secret for the user's authenticator app
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"` // Base32, for manual entry
+	URI    string `json:"uri"`    // otpauth:// URI, usually shown as a QR code
+}
+
+// TwoFactorStatus describes a user's two-factor authentication
+type TwoFactorStatus struct {
+	Enabled   bool `json:"enabled"`
+	Pending   bool `json:"pending"`   // Enrollment started but not confirmed
+	Required  bool `json:"required"`  // Sensitive operations refuse users without it
+	Available bool `json:"available"` // Secrets can be stored encrypted
+}
+
+// UpdateConfigRequest represents risk config update request
+type UpdateConfigRequest struct {
+	MaxRiskPerTrade      *float64 `json:"maxRiskPerTrade,omitempty"`
+	MaxDailyLoss         *float64 `json:"maxDailyLoss,omitempty"`
+	MaxTotalDrawdown     *float64 `json:"maxTotalDrawdown,omitempty"`
+	HighWaterMarkMode    *string  `json:"highWaterMarkMode,omitempty"`
+	MaxOpenPositions     *int     `json:"maxOpenPositions,omitempty"`
+	EnableCircuitBreaker *bool    `json:"enableCircuitBreaker,omitempty"`
+}
+
+// UpdateStopLossRequest represents stop loss update request
+type UpdateStopLossRequest struct {
+	StopLoss float64 `json:"stopLoss"`
+}
+
+// UpdateStrategyRequest represents strategy update request
+type UpdateStrategyRequest struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// UpdateTakeProfitRequest represents take profit update request
+type UpdateTakeProfitRequest struct {
+	TakeProfit float64 `json:"takeProfit"`
+}
+
+// UserResponse is the public user response (no sensitive data)
+type UserResponse struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	FullName        string     `json:"full_name"`
+	Role            UserRole   `json:"role"`
+	IsActive        bool       `json:"is_active"`
+	IsEmailVerified bool       `json:"is_email_verified"`
+	TwoFactor       bool       `json:"two_factor_enabled"`
+	LastLoginAt     *time.Time `json:"last_login_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// UserRole represents user role types
+type UserRole string
+
+// VWAPValue represents session VWAP values
+type VWAPValue struct {
+	VWAP     float64 `json:"vwap"`
+	Distance float64 `json:"distance"` // Percent of the close from VWAP
+}
+
+type Value = json.RawMessage
+
+// VolatilityThrottleStats counts a strategy's entries signaled on candles whose
+// range was a volatility spike, and what became of them
+type VolatilityThrottleStats struct {
+	Strategy    string    `json:"strategy"`
+	Spikes      int64     `json:"spikes"` // Entries signaled on a spike
+	Downsized   int64     `json:"downsized"`
+	Delayed     int64     `json:"delayed"`
+	Released    int64     `json:"released"` // Delayed entries let through on the next candle
+	Dropped     int64     `json:"dropped"`  // Delayed entries the next candle did not bear out
+	LastSpikeAt time.Time `json:"lastSpikeAt,omitempty"`
+}
+
+// VolumeData represents volume indicator values
+type VolumeData struct {
+	Volume    float64 `json:"volume"`
+	VolumeSMA float64 `json:"volumeSma"`
+	OBV       float64 `json:"obv"`
+	VWAP      float64 `json:"vwap"`
+}
+
+// WSStats describes the bandwidth used by a WebSocket client
+type WSStats struct {
+	Connected       bool          `json:"connected"`
+	Compression     bool          `json:"compression"` // permessage-deflate negotiated with the server
+	Since           time.Time     `json:"since"`
+	WireBytesIn     int64         `json:"wireBytesIn"` // Bytes on the socket, including TLS and frame overhead
+	WireBytesOut    int64         `json:"wireBytesOut"`
+	PayloadBytesIn  int64         `json:"payloadBytesIn"` // Message payloads; compared with wire bytes this shows the saving
+	PayloadBytesOut int64         `json:"payloadBytesOut"`
+	MessagesIn      int64         `json:"messagesIn"`
+	MessagesOut     int64         `json:"messagesOut"`
+	WireRatio       float64       `json:"wireRatio,omitempty"` // Wire bytes in / payload bytes in
+	Streams         []StreamStats `json:"streams"`
+}
+
+// WeightStats reports a client's request weight usage
+type WeightStats struct {
+	Limit       int       `json:"limit"`     // Weight allowed per minute
+	Used        int       `json:"used"`      // Weight used this minute, as last reported by Binance
+	Available   float64   `json:"available"` // Weight the limiter would spend right now
+	Waits       int64     `json:"waits"`     // Requests delayed for weight or a pause
+	WaitTime    Duration  `json:"waitTime"`
+	Throttled   int64     `json:"throttled"` // 429 and 418 responses received
+	PausedUntil time.Time `json:"pausedUntil,omitempty"`
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// webSocketPath is where the server accepts WebSocket connections
+const webSocketPath = "/ws"
+
+// Message is a message received from the server. Replies have no
+// timestamp.
+type Message struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+	AccountID string          `json:"accountId,omitempty"` // Trading account the message concerns; empty = every user
+}
+
+// Decode returns the data of a known message type as a pointer to its
+// type, e.g. *TradeUpdate for MessageTypeTrade, and nil for messages
+// without data or of unknown types
+func (m *Message) Decode() (interface{}, error) {
+	v := newMessageData(m.Type)
+	if v == nil || len(m.Data) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(m.Data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Stream is a WebSocket connection to the server. Next must be called from
+// one goroutine at a time; the other methods are safe for concurrent use.
+type Stream struct {
+	conn *websocket.Conn
+
+	mu sync.Mutex // Serializes writes
+}
+
+// Connect opens a WebSocket connection with the client's access token. The
+// server sends every message type until Subscribe narrows them down.
+func (c *Client) Connect(ctx context.Context) (*Stream, error) {
+	u := c.baseURL + webSocketPath
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	header := http.Header{}
+	if token := c.accessToken(); token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, u, header)
+	if err != nil {
+		if resp != nil {
+			return nil, &APIError{StatusCode: resp.StatusCode}
+		}
+		return nil, err
+	}
+	return &Stream{conn: conn}, nil
+}
+
+// Subscribe adds message types to receive; none = all
+func (s *Stream) Subscribe(types ...string) error {
+	return s.send(MessageTypeSubscribe, SubscriptionChange{Types: types})
+}
+
+// Unsubscribe stops receiving message types; none = all
+func (s *Stream) Unsubscribe(types ...string) error {
+	return s.send(MessageTypeUnsubscribe, SubscriptionChange{Types: types})
+}
+
+// Ping asks the server for a MessageTypePong reply
+func (s *Stream) Ping() error {
+	return s.send(MessageTypePing, nil)
+}
+
+func (s *Stream) send(msgType string, data interface{}) error {
+	msg := struct {
+		Type string      `json:"type"`
+		Data interface{} `json:"data,omitempty"`
+	}{msgType, data}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WriteJSON(msg)
+}
+
+// Next blocks until the next message arrives or the connection fails
+func (s *Stream) Next() (*Message, error) {
+	var msg Message
+	if err := s.conn.ReadJSON(&msg); err != nil {
+		var closeErr *websocket.CloseError
+		if errors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure {
+			return nil, ErrStreamClosed
+		}
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// ErrStreamClosed is returned by Next after the connection closed normally
+var ErrStreamClosed = errors.New("client: stream closed")
+
+// Close closes the connection
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return s.conn.Close()
+}
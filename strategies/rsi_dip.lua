@@ -0,0 +1,63 @@
+-- Buys oversold dips in an uptrend and sells overbought rallies in a
+-- downtrend, using the precomputed indicators.
+--
+-- data: symbol, timeframe, time, price, bid, ask, regime, regime_confidence,
+--   session ("asia", "europe" or "us"),
+--   open/high/low/close/volume (last `lookback` bars, oldest first),
+--   indicators (rsi, macd, macd_signal, macd_histogram, bb_upper, bb_middle,
+--   bb_lower, bb_width, bb_percent_b, atr, atr_percent, adx, plus_di,
+--   minus_di, stoch_k, stoch_d, ma, volume_average, volume_ratio, vwap,
+--   vwap_distance, supertrend, supertrend_direction ("UP" or "DOWN"),
+--   tenkan, kijun, senkou_a, senkou_b, ichimoku_position ("UP" above the
+--   cloud, "DOWN" below, "NEUTRAL" inside), kc_upper, kc_middle, kc_lower),
+--   higher[timeframe] with close and indicators
+-- analyze returns nil, a signal or a list of signals; stop_loss and
+-- take_profit default to 2 and 3 ATRs when left out.
+
+name = "rsi_dip"
+min_data = 50
+lookback = 50
+enabled = true
+
+local function sma(values, n)
+  local sum = 0
+  for i = #values - n + 1, #values do
+    sum = sum + values[i]
+  end
+  return sum / n
+end
+
+function analyze(data)
+  local ind = data.indicators
+  local trend = sma(data.close, 50)
+
+  if data.price > trend and ind.rsi < 30 then
+    return {
+      direction = LONG,
+      strength = (30 - ind.rsi) / 30,
+      confidence = 0.6,
+      reason = string.format("RSI %.1f dip above the 50-bar average", ind.rsi),
+    }
+  end
+  if data.price < trend and ind.rsi > 70 then
+    return {
+      direction = SHORT,
+      strength = (ind.rsi - 70) / 30,
+      confidence = 0.6,
+      reason = string.format("RSI %.1f rally below the 50-bar average", ind.rsi),
+    }
+  end
+  return nil
+end
+
+function should_exit(data, position)
+  local rsi = data.indicators.rsi
+  if position.direction == "long" then
+    if data.price <= position.stop_loss then return true, "Stop loss triggered" end
+    if rsi > 60 then return true, "RSI recovered" end
+  else
+    if data.price >= position.stop_loss then return true, "Stop loss triggered" end
+    if rsi < 40 then return true, "RSI recovered" end
+  end
+  return false
+end
@@ -8,6 +8,7 @@ import type {
   Strategy,
   RiskConfig,
 } from '../types';
+import type { AuditEntry, Leaderboard } from '../types/api.gen';
 import { useAuthStore } from '../stores/authStore';
 
 const api = axios.create({
@@ -73,6 +74,8 @@ export const getRegime = () => api.get('/regime');
 
 // Strategies
 export const getStrategies = () => api.get<Strategy[]>('/strategies');
+export const getStrategyLeaderboard = (window = '30d', sort = 'sharpe') =>
+  api.get<Leaderboard>('/strategies/leaderboard', { params: { window, sort } });
 export const getStrategy = (name: string) => api.get<Strategy>(`/strategies/${name}`);
 export const updateStrategy = (name: string, data: Partial<Strategy>) =>
   api.put(`/strategies/${name}`, data);
@@ -88,7 +91,22 @@ export const updateRiskConfig = (config: Partial<RiskConfig>) =>
 export const getRiskLimits = () => api.get('/risk/limits');
 export const getDrawdown = () => api.get('/risk/drawdown');
 export const getRiskEvents = () => api.get('/risk/events');
-export const resetCircuitBreaker = () => api.post('/risk/circuit-breaker/reset');
+export const resetCircuitBreaker = (otp?: string) =>
+  api.post('/risk/circuit-breaker/reset', undefined, otp ? { headers: { 'X-OTP-Code': otp } } : undefined);
+
+// Audit trail
+export interface AuditQuery {
+  category?: 'order' | 'config' | 'strategy' | 'risk';
+  action?: string;
+  actor?: string;
+  resource?: string;
+  accountId?: string;
+  from?: number; // Unix ms
+  to?: number;
+  limit?: number;
+}
+export const getAuditLog = (query: AuditQuery = {}) =>
+  api.get<AuditEntry[]>('/audit', { params: query });
 
 // Settings
 export const getSettings = () => api.get('/settings');
@@ -0,0 +1,914 @@
+// Code generated by sdkgen from internal/api/spec. DO NOT EDIT.
+
+import type { AxiosInstance } from 'axios';
+import type * as T from '../types/api.gen';
+
+type QueryValue = string | number | boolean | undefined;
+
+// createApiClient returns the API endpoints on an axios instance whose
+// baseURL ends in /api/v1
+export const createApiClient = (http: AxiosInstance) => ({
+  /**
+   * Creates a user and logs it in
+   * POST /api/v1/auth/register
+   */
+  register: (body: T.RegisterRequest): Promise<T.LoginResponse> =>
+    http.post<T.LoginResponse>('/auth/register', body).then((r) => r.data),
+  /**
+   * Exchanges credentials for access and refresh tokens
+   * POST /api/v1/auth/login
+   */
+  login: (body: T.LoginRequest): Promise<T.LoginResponse> =>
+    http.post<T.LoginResponse>('/auth/login', body).then((r) => r.data),
+  /**
+   * Exchanges a refresh token for a new access token
+   * POST /api/v1/auth/refresh
+   */
+  refreshToken: (body: T.RefreshTokenRequest): Promise<T.RefreshTokenResponse> =>
+    http.post<T.RefreshTokenResponse>('/auth/refresh', body).then((r) => r.data),
+  /**
+   * Sends a password reset email
+   * POST /api/v1/auth/password-reset
+   */
+  requestPasswordReset: (body: T.PasswordResetRequest): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/auth/password-reset', body).then((r) => r.data),
+  /**
+   * Sets a new password with a reset token
+   * POST /api/v1/auth/password-reset/confirm
+   */
+  confirmPasswordReset: (body: T.PasswordResetConfirm): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/auth/password-reset/confirm', body).then((r) => r.data),
+  /**
+   * Revokes the caller's sessions
+   * POST /api/v1/auth/logout
+   */
+  logout: (): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/auth/logout', undefined).then((r) => r.data),
+  /**
+   * Returns the caller's user
+   * GET /api/v1/auth/me
+   */
+  getMe: (): Promise<T.UserResponse> =>
+    http.get<T.UserResponse>('/auth/me').then((r) => r.data),
+  /**
+   * Changes the caller's password
+   * POST /api/v1/auth/change-password
+   */
+  changePassword: (body: T.PasswordChangeRequest): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/auth/change-password', body).then((r) => r.data),
+  /**
+   * Returns the caller's two-factor authentication status
+   * GET /api/v1/auth/2fa
+   */
+  getTwoFactor: (): Promise<T.TwoFactorStatus> =>
+    http.get<T.TwoFactorStatus>('/auth/2fa').then((r) => r.data),
+  /**
+   * Generates a TOTP secret for the caller's authenticator app
+   * POST /api/v1/auth/2fa/enroll
+   */
+  enrollTwoFactor: (): Promise<T.TwoFactorEnrollment> =>
+    http.post<T.TwoFactorEnrollment>('/auth/2fa/enroll', undefined).then((r) => r.data),
+  /**
+   * Enables two-factor authentication with a code from the enrolled app
+   * POST /api/v1/auth/2fa/confirm
+   */
+  confirmTwoFactor: (body: T.TwoFactorCodeRequest): Promise<T.TwoFactorStatus> =>
+    http.post<T.TwoFactorStatus>('/auth/2fa/confirm', body).then((r) => r.data),
+  /**
+   * Removes two-factor authentication
+   * POST /api/v1/auth/2fa/disable
+   */
+  disableTwoFactor: (body: T.TwoFactorDisableRequest): Promise<T.TwoFactorStatus> =>
+    http.post<T.TwoFactorStatus>('/auth/2fa/disable', body).then((r) => r.data),
+  /**
+   * Returns the account, positions and recent trades
+   * GET /api/v1/dashboard
+   */
+  getDashboard: (): Promise<T.DashboardResponse> =>
+    http.get<T.DashboardResponse>('/dashboard').then((r) => r.data),
+  /**
+   * Returns the account summary
+   * GET /api/v1/dashboard/summary
+   */
+  getDashboardSummary: (): Promise<T.AccountSummary> =>
+    http.get<T.AccountSummary>('/dashboard/summary').then((r) => r.data),
+  /**
+   * Returns the equity curve
+   * GET /api/v1/dashboard/equity-curve
+   */
+  getEquityCurve: (): Promise<T.EquityCurvePoint[]> =>
+    http.get<T.EquityCurvePoint[]>('/dashboard/equity-curve').then((r) => r.data),
+  /**
+   * Returns trading performance statistics
+   * GET /api/v1/dashboard/performance
+   */
+  getPerformance: (): Promise<T.PerformanceData> =>
+    http.get<T.PerformanceData>('/dashboard/performance').then((r) => r.data),
+  /**
+   * Returns performance by strategy parameter version
+   * GET /api/v1/dashboard/performance/versions
+   */
+  getVersionPerformance: (query?: { strategy?: QueryValue }): Promise<T.ParamVersionReport> =>
+    http.get<T.ParamVersionReport>('/dashboard/performance/versions', { params: query }).then((r) => r.data),
+  /**
+   * Returns performance by trading session
+   * GET /api/v1/dashboard/performance/sessions
+   */
+  getSessionPerformance: (query?: { strategy?: QueryValue; days?: QueryValue }): Promise<T.SessionReport> =>
+    http.get<T.SessionReport>('/dashboard/performance/sessions', { params: query }).then((r) => r.data),
+  /**
+   * Returns strategy performance by the market regime positions were entered in
+   * GET /api/v1/analytics/regime-matrix
+   */
+  getRegimeMatrix: (query?: { strategy?: QueryValue; days?: QueryValue }): Promise<T.RegimeMatrix> =>
+    http.get<T.RegimeMatrix>('/analytics/regime-matrix', { params: query }).then((r) => r.data),
+  /**
+   * Returns the trading state
+   * GET /api/v1/trading/state
+   */
+  getTradingState: (): Promise<T.TradingStateResponse> =>
+    http.get<T.TradingStateResponse>('/trading/state').then((r) => r.data),
+  /**
+   * Returns the state snapshot WebSocket clients resync from
+   * GET /api/v1/state
+   */
+  getStateSnapshot: (query?: { since?: QueryValue }): Promise<T.StateSnapshot> =>
+    http.get<T.StateSnapshot>('/state', { params: query }).then((r) => r.data),
+  /**
+   * Starts trading
+   * POST /api/v1/trading/start
+   */
+  startTrading: (): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/trading/start', undefined).then((r) => r.data),
+  /**
+   * Stops trading
+   * POST /api/v1/trading/stop
+   */
+  stopTrading: (): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/trading/stop', undefined).then((r) => r.data),
+  /**
+   * Pauses trading
+   * POST /api/v1/trading/pause
+   */
+  pauseTrading: (): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/trading/pause', undefined).then((r) => r.data),
+  /**
+   * Resumes trading
+   * POST /api/v1/trading/resume
+   */
+  resumeTrading: (): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/trading/resume', undefined).then((r) => r.data),
+  /**
+   * Returns the trading mode
+   * GET /api/v1/trading/mode
+   */
+  getTradingMode: (): Promise<T.ModeResponse> =>
+    http.get<T.ModeResponse>('/trading/mode').then((r) => r.data),
+  /**
+   * Switches between paper and live trading
+   * POST /api/v1/trading/mode
+   */
+  setTradingMode: (body: T.ModeRequest): Promise<T.ModeResponse> =>
+    http.post<T.ModeResponse>('/trading/mode', body).then((r) => r.data),
+  /**
+   * Returns the live-mode arming status
+   * GET /api/v1/trading/arm
+   */
+  getArming: (): Promise<T.ArmingStatus> =>
+    http.get<T.ArmingStatus>('/trading/arm').then((r) => r.data),
+  /**
+   * Starts arming live trading
+   * POST /api/v1/trading/arm
+   */
+  arm: (body: T.ArmRequest, otp?: string): Promise<T.ArmingStatus> =>
+    http.post<T.ArmingStatus>('/trading/arm', body, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Disarms live trading
+   * POST /api/v1/trading/disarm
+   */
+  disarm: (body: T.DisarmRequest): Promise<T.ArmingStatus> =>
+    http.post<T.ArmingStatus>('/trading/disarm', body).then((r) => r.data),
+  /**
+   * Returns the trading schedule
+   * GET /api/v1/trading/schedule
+   */
+  getSchedule: (): Promise<T.ScheduleStatus> =>
+    http.get<T.ScheduleStatus>('/trading/schedule').then((r) => r.data),
+  /**
+   * Returns exchange reconciliation results
+   * GET /api/v1/trading/reconciliation
+   */
+  getReconciliation: (query?: { limit?: QueryValue }): Promise<T.ReconciliationReport> =>
+    http.get<T.ReconciliationReport>('/trading/reconciliation', { params: query }).then((r) => r.data),
+  /**
+   * Returns balances too small to trade
+   * GET /api/v1/trading/dust
+   */
+  getDust: (): Promise<T.DustReport> =>
+    http.get<T.DustReport>('/trading/dust').then((r) => r.data),
+  /**
+   * Converts dust balances to BNB
+   * POST /api/v1/trading/dust/convert
+   */
+  convertDust: (): Promise<T.DustConversion> =>
+    http.post<T.DustConversion>('/trading/dust/convert', undefined).then((r) => r.data),
+  /**
+   * Returns recorded order intents
+   * GET /api/v1/trading/intents
+   */
+  getOrderIntents: (query?: { limit?: QueryValue }): Promise<T.OrderIntent[]> =>
+    http.get<T.OrderIntent[]>('/trading/intents', { params: query }).then((r) => r.data),
+  /**
+   * Returns injected faults
+   * GET /api/v1/trading/chaos
+   */
+  getChaos: (): Promise<T.ChaosStatus> =>
+    http.get<T.ChaosStatus>('/trading/chaos').then((r) => r.data),
+  /**
+   * Injects a fault into order execution
+   * POST /api/v1/trading/chaos
+   */
+  injectChaos: (body: T.ChaosRequest): Promise<T.ChaosEvent> =>
+    http.post<T.ChaosEvent>('/trading/chaos', body).then((r) => r.data),
+  /**
+   * Clears injected faults
+   * DELETE /api/v1/trading/chaos
+   */
+  clearChaos: (): Promise<T.ChaosStatus> =>
+    http.delete<T.ChaosStatus>('/trading/chaos').then((r) => r.data),
+  /**
+   * Returns the capital the bot trades with
+   * GET /api/v1/trading/capital
+   */
+  getCapital: (): Promise<T.SubBalanceStatus> =>
+    http.get<T.SubBalanceStatus>('/trading/capital').then((r) => r.data),
+  /**
+   * Moves capital into or out of the bot's sub-balance
+   * POST /api/v1/trading/capital/transfers
+   */
+  transferCapital: (body: T.CapitalTransferRequest): Promise<T.SubBalanceStatus> =>
+    http.post<T.SubBalanceStatus>('/trading/capital/transfers', body).then((r) => r.data),
+  /**
+   * Returns the entry policy and its state
+   * GET /api/v1/trading/entry
+   */
+  getEntryPolicy: (): Promise<T.EntryStatus> =>
+    http.get<T.EntryStatus>('/trading/entry').then((r) => r.data),
+  /**
+   * Returns trade ideas awaiting or past approval
+   * GET /api/v1/inbox
+   */
+  getInbox: (query?: { status?: QueryValue; limit?: QueryValue }): Promise<T.InboxResponse> =>
+    http.get<T.InboxResponse>('/inbox', { params: query }).then((r) => r.data),
+  /**
+   * Approves a trade idea
+   * POST /api/v1/inbox/:id/approve
+   */
+  approveIdea: (id: string, body: T.IdeaDecisionRequest): Promise<T.TradeIdea> =>
+    http.post<T.TradeIdea>(`/inbox/${encodeURIComponent(id)}/approve`, body).then((r) => r.data),
+  /**
+   * Rejects a trade idea
+   * POST /api/v1/inbox/:id/reject
+   */
+  rejectIdea: (id: string, body: T.IdeaDecisionRequest): Promise<T.TradeIdea> =>
+    http.post<T.TradeIdea>(`/inbox/${encodeURIComponent(id)}/reject`, body).then((r) => r.data),
+  /**
+   * Returns copy trading followers and leaders
+   * GET /api/v1/copy
+   */
+  getCopyTrading: (): Promise<T.CopyTradingStatus> =>
+    http.get<T.CopyTradingStatus>('/copy').then((r) => r.data),
+  /**
+   * Adds a follower signals are pushed to
+   * POST /api/v1/copy/followers
+   */
+  addFollower: (body: T.FollowerRequest): Promise<T.CopyFollower> =>
+    http.post<T.CopyFollower>('/copy/followers', body).then((r) => r.data),
+  /**
+   * Removes a follower
+   * DELETE /api/v1/copy/followers/:name
+   */
+  removeFollower: (name: string): Promise<Record<string, string>> =>
+    http.delete<Record<string, string>>(`/copy/followers/${encodeURIComponent(name)}`).then((r) => r.data),
+  /**
+   * Returns the strategies and their performance
+   * GET /api/v1/strategies
+   */
+  getStrategies: (): Promise<T.StrategyInfo[]> =>
+    http.get<T.StrategyInfo[]>('/strategies').then((r) => r.data),
+  /**
+   * Returns the strategies that would trade in a regime
+   * GET /api/v1/strategies/preview
+   */
+  getStrategyPreview: (query?: { regime?: QueryValue }): Promise<T.StrategyPreviewResponse> =>
+    http.get<T.StrategyPreviewResponse>('/strategies/preview', { params: query }).then((r) => r.data),
+  /**
+   * Returns strategy parameter drift from optimized values
+   * GET /api/v1/strategies/drift
+   */
+  getParamDrift: (): Promise<Record<string, unknown>> =>
+    http.get<Record<string, unknown>>('/strategies/drift').then((r) => r.data),
+  /**
+   * Ranks strategies by realized performance over a rolling window
+   * GET /api/v1/strategies/leaderboard
+   */
+  getStrategyLeaderboard: (query?: { window?: QueryValue; sort?: QueryValue }): Promise<T.Leaderboard> =>
+    http.get<T.Leaderboard>('/strategies/leaderboard', { params: query }).then((r) => r.data),
+  /**
+   * Returns a strategy
+   * GET /api/v1/strategies/:name
+   */
+  getStrategy: (name: string): Promise<T.StrategyInfo> =>
+    http.get<T.StrategyInfo>(`/strategies/${encodeURIComponent(name)}`).then((r) => r.data),
+  /**
+   * Updates a strategy's parameters
+   * PUT /api/v1/strategies/:name
+   */
+  updateStrategy: (name: string, body: T.UpdateStrategyRequest): Promise<Record<string, string>> =>
+    http.put<Record<string, string>>(`/strategies/${encodeURIComponent(name)}`, body).then((r) => r.data),
+  /**
+   * Enables a strategy
+   * POST /api/v1/strategies/:name/enable
+   */
+  enableStrategy: (name: string): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>(`/strategies/${encodeURIComponent(name)}/enable`, undefined).then((r) => r.data),
+  /**
+   * Disables a strategy
+   * POST /api/v1/strategies/:name/disable
+   */
+  disableStrategy: (name: string): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>(`/strategies/${encodeURIComponent(name)}/disable`, undefined).then((r) => r.data),
+  /**
+   * Reconfigures a strategy with its optimized parameters
+   * POST /api/v1/strategies/:name/apply-optimized
+   */
+  applyOptimizedParams: (name: string, query?: { source?: QueryValue }): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>(`/strategies/${encodeURIComponent(name)}/apply-optimized`, undefined, { params: query }).then((r) => r.data),
+  /**
+   * Returns a strategy's recent signals
+   * GET /api/v1/strategies/:name/signals
+   */
+  getStrategySignals: (name: string): Promise<T.SignalInfo[]> =>
+    http.get<T.SignalInfo[]>(`/strategies/${encodeURIComponent(name)}/signals`).then((r) => r.data),
+  /**
+   * Returns the detected market regime
+   * GET /api/v1/regime
+   */
+  getRegime: (): Promise<T.RegimeInfo> =>
+    http.get<T.RegimeInfo>('/regime').then((r) => r.data),
+  /**
+   * Returns the scorer breakdown of the latest analysis
+   * GET /api/v1/score
+   */
+  getScore: (): Promise<T.ScoreUpdate> =>
+    http.get<T.ScoreUpdate>('/score').then((r) => r.data),
+  /**
+   * Returns capital allocated to each strategy
+   * GET /api/v1/allocation
+   */
+  getAllocations: (): Promise<T.AllocationResponse> =>
+    http.get<T.AllocationResponse>('/allocation').then((r) => r.data),
+  /**
+   * Returns past allocations
+   * GET /api/v1/allocation/history
+   */
+  getAllocationHistory: (query?: { limit?: QueryValue }): Promise<T.AllocationSnapshot[]> =>
+    http.get<T.AllocationSnapshot[]>('/allocation/history', { params: query }).then((r) => r.data),
+  /**
+   * Reallocates capital between strategies
+   * POST /api/v1/allocation/rebalance
+   */
+  rebalance: (): Promise<T.AllocationSnapshot> =>
+    http.post<T.AllocationSnapshot>('/allocation/rebalance', undefined).then((r) => r.data),
+  /**
+   * Returns the risk status
+   * GET /api/v1/risk
+   */
+  getRiskStatus: (): Promise<T.RiskStatusResponse> =>
+    http.get<T.RiskStatusResponse>('/risk').then((r) => r.data),
+  /**
+   * Returns the risk limits
+   * GET /api/v1/risk/config
+   */
+  getRiskConfig: (): Promise<T.RiskConfigResponse> =>
+    http.get<T.RiskConfigResponse>('/risk/config').then((r) => r.data),
+  /**
+   * Changes risk limits
+   * PUT /api/v1/risk/config
+   */
+  updateRiskConfig: (body: T.UpdateConfigRequest): Promise<Record<string, string>> =>
+    http.put<Record<string, string>>('/risk/config', body).then((r) => r.data),
+  /**
+   * Returns how much of each risk limit is used
+   * GET /api/v1/risk/limits
+   */
+  getRiskLimits: (query?: { events?: QueryValue }): Promise<T.RiskLimitsResponse> =>
+    http.get<T.RiskLimitsResponse>('/risk/limits', { params: query }).then((r) => r.data),
+  /**
+   * Returns drawdown from the high-water mark
+   * GET /api/v1/risk/drawdown
+   */
+  getDrawdown: (): Promise<T.DrawdownResponse> =>
+    http.get<T.DrawdownResponse>('/risk/drawdown').then((r) => r.data),
+  /**
+   * Resets the high-water mark to current equity
+   * POST /api/v1/risk/high-water-mark/reset
+   */
+  resetHighWaterMark: (): Promise<T.HighWaterMark> =>
+    http.post<T.HighWaterMark>('/risk/high-water-mark/reset', undefined).then((r) => r.data),
+  /**
+   * Records a deposit or withdrawal
+   * POST /api/v1/risk/cash-flow
+   */
+  recordCashFlow: (body: T.CashFlowRequest): Promise<T.HighWaterMark> =>
+    http.post<T.HighWaterMark>('/risk/cash-flow', body).then((r) => r.data),
+  /**
+   * Returns recent risk events
+   * GET /api/v1/risk/events
+   */
+  getRiskEvents: (): Promise<T.RiskEventResponse[]> =>
+    http.get<T.RiskEventResponse[]>('/risk/events').then((r) => r.data),
+  /**
+   * Returns daily risk digests
+   * GET /api/v1/risk/reports
+   */
+  getRiskReports: (): Promise<T.RiskReports> =>
+    http.get<T.RiskReports>('/risk/reports').then((r) => r.data),
+  /**
+   * Resets a tripped circuit breaker
+   * POST /api/v1/risk/circuit-breaker/reset
+   */
+  resetCircuitBreaker: (otp?: string): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>('/risk/circuit-breaker/reset', undefined, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Halts trading
+   * POST /api/v1/risk/halt
+   */
+  halt: (body: T.HaltRequest): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>('/risk/halt', body).then((r) => r.data),
+  /**
+   * Lifts a halt
+   * POST /api/v1/risk/resume
+   */
+  resumeFromHalt: (otp?: string): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>('/risk/resume', undefined, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Returns open positions
+   * GET /api/v1/positions
+   */
+  getPositions: (): Promise<T.PositionData[]> =>
+    http.get<T.PositionData[]>('/positions').then((r) => r.data),
+  /**
+   * Returns a position
+   * GET /api/v1/positions/:id
+   */
+  getPosition: (id: string): Promise<T.PositionData> =>
+    http.get<T.PositionData>(`/positions/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Closes a position at market
+   * POST /api/v1/positions/:id/close
+   */
+  closePosition: (id: string): Promise<Record<string, string>> =>
+    http.post<Record<string, string>>(`/positions/${encodeURIComponent(id)}/close`, undefined).then((r) => r.data),
+  /**
+   * Moves a position's stop loss
+   * PUT /api/v1/positions/:id/stop-loss
+   */
+  updateStopLoss: (id: string, body: T.UpdateStopLossRequest): Promise<Record<string, string>> =>
+    http.put<Record<string, string>>(`/positions/${encodeURIComponent(id)}/stop-loss`, body).then((r) => r.data),
+  /**
+   * Moves a position's take profit
+   * PUT /api/v1/positions/:id/take-profit
+   */
+  updateTakeProfit: (id: string, body: T.UpdateTakeProfitRequest): Promise<Record<string, string>> =>
+    http.put<Record<string, string>>(`/positions/${encodeURIComponent(id)}/take-profit`, body).then((r) => r.data),
+  /**
+   * Returns order history
+   * GET /api/v1/orders
+   */
+  getOrders: (query?: { symbol?: QueryValue; status?: QueryValue; strategy?: QueryValue; limit?: QueryValue }): Promise<T.OrderData[]> =>
+    http.get<T.OrderData[]>('/orders', { params: query }).then((r) => r.data),
+  /**
+   * Returns open orders
+   * GET /api/v1/orders/open
+   */
+  getOpenOrders: (query?: { symbol?: QueryValue }): Promise<T.OrderData[]> =>
+    http.get<T.OrderData[]>('/orders/open', { params: query }).then((r) => r.data),
+  /**
+   * Returns an order and its state transitions
+   * GET /api/v1/orders/:id
+   */
+  getOrder: (id: string): Promise<T.OrderData> =>
+    http.get<T.OrderData>(`/orders/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Places a manual order
+   * POST /api/v1/orders
+   */
+  placeOrder: (body: T.PlaceOrderRequest): Promise<T.PlaceOrderResponse> =>
+    http.post<T.PlaceOrderResponse>('/orders', body).then((r) => r.data),
+  /**
+   * Cancels an open order
+   * DELETE /api/v1/orders/:id
+   */
+  cancelOrder: (id: string): Promise<Record<string, string>> =>
+    http.delete<Record<string, string>>(`/orders/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Returns recent application events
+   * GET /api/v1/logs
+   */
+  getLogs: (query?: { limit?: QueryValue; level?: QueryValue; category?: QueryValue }): Promise<T.Event[]> =>
+    http.get<T.Event[]>('/logs', { params: query }).then((r) => r.data),
+  /**
+   * Backs up the trading database
+   * POST /api/v1/system/backup
+   */
+  createBackup: (): Promise<T.BackupRecord> =>
+    http.post<T.BackupRecord>('/system/backup', undefined).then((r) => r.data),
+  /**
+   * Returns past backups
+   * GET /api/v1/system/backups
+   */
+  getBackups: (query?: { limit?: QueryValue }): Promise<T.BackupRecord[]> =>
+    http.get<T.BackupRecord[]>('/system/backups', { params: query }).then((r) => r.data),
+  /**
+   * Returns the subscribed Binance streams
+   * GET /api/v1/system/subscriptions
+   */
+  getSubscriptions: (): Promise<T.StreamSubscription[]> =>
+    http.get<T.StreamSubscription[]>('/system/subscriptions').then((r) => r.data),
+  /**
+   * Subscribes to a Binance stream
+   * POST /api/v1/system/subscriptions
+   */
+  addSubscription: (body: T.SubscriptionRequest): Promise<T.StreamSubscription> =>
+    http.post<T.StreamSubscription>('/system/subscriptions', body).then((r) => r.data),
+  /**
+   * Unsubscribes from a Binance stream
+   * DELETE /api/v1/system/subscriptions
+   */
+  removeSubscription: (query?: { stream?: QueryValue }): Promise<Record<string, string>> =>
+    http.delete<Record<string, string>>('/system/subscriptions', { params: query }).then((r) => r.data),
+  /**
+   * Returns persisted trades
+   * GET /api/v1/history/trades
+   */
+  getTradeHistory: (query?: { from?: QueryValue; to?: QueryValue; strategy?: QueryValue; limit?: QueryValue }): Promise<T.TradeHistoryData[]> =>
+    http.get<T.TradeHistoryData[]>('/history/trades', { params: query }).then((r) => r.data),
+  /**
+   * Returns persisted positions
+   * GET /api/v1/history/positions
+   */
+  getPositionHistory: (query?: { status?: QueryValue; limit?: QueryValue }): Promise<T.PositionHistoryData[]> =>
+    http.get<T.PositionHistoryData[]>('/history/positions', { params: query }).then((r) => r.data),
+  /**
+   * Imports trade history from the exchange
+   * POST /api/v1/history/import
+   */
+  startHistoryImport: (body: T.HistoryImportRequest): Promise<T.HistoryImportReport> =>
+    http.post<T.HistoryImportReport>('/history/import', body).then((r) => r.data),
+  /**
+   * Returns the progress of the history import
+   * GET /api/v1/history/import
+   */
+  getHistoryImport: (): Promise<T.HistoryImportReport> =>
+    http.get<T.HistoryImportReport>('/history/import').then((r) => r.data),
+  /**
+   * Returns the audit trail of order, configuration, strategy and risk actions
+   * GET /api/v1/audit
+   */
+  getAuditLog: (query?: { category?: QueryValue; action?: QueryValue; actor?: QueryValue; resource?: QueryValue; accountId?: QueryValue; from?: QueryValue; to?: QueryValue; limit?: QueryValue }): Promise<T.AuditEntry[]> =>
+    http.get<T.AuditEntry[]>('/audit', { params: query }).then((r) => r.data),
+  /**
+   * Exports anonymized results as a bundle
+   * GET /api/v1/share/export
+   */
+  exportShareBundle: (query?: { name?: QueryValue; candles?: QueryValue }): Promise<T.ShareBundle> =>
+    http.get<T.ShareBundle>('/share/export', { params: query }).then((r) => r.data),
+  /**
+   * Imports a bundle shared by another user
+   * POST /api/v1/share/import
+   */
+  importShareBundle: (body: T.ShareBundle): Promise<T.SharedBundle> =>
+    http.post<T.SharedBundle>('/share/import', body).then((r) => r.data),
+  /**
+   * Returns imported bundles
+   * GET /api/v1/share/bundles
+   */
+  getShareBundles: (): Promise<T.SharedBundle[]> =>
+    http.get<T.SharedBundle[]>('/share/bundles').then((r) => r.data),
+  /**
+   * Returns an imported bundle
+   * GET /api/v1/share/bundles/:id
+   */
+  getShareBundle: (id: string): Promise<T.SharedBundle> =>
+    http.get<T.SharedBundle>(`/share/bundles/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Deletes an imported bundle
+   * DELETE /api/v1/share/bundles/:id
+   */
+  deleteShareBundle: (id: string): Promise<void> =>
+    http.delete<void>(`/share/bundles/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Returns the caller's trading accounts
+   * GET /api/v1/accounts
+   */
+  listAccounts: (): Promise<Record<string, unknown>> =>
+    http.get<Record<string, unknown>>('/accounts').then((r) => r.data),
+  /**
+   * Adds a trading account
+   * POST /api/v1/accounts
+   */
+  createAccount: (body: T.TradingAccountCreateRequest, otp?: string): Promise<T.AccountResponse> =>
+    http.post<T.AccountResponse>('/accounts', body, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Returns a trading account
+   * GET /api/v1/accounts/:id
+   */
+  getAccount: (id: string): Promise<T.AccountResponse> =>
+    http.get<T.AccountResponse>(`/accounts/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Changes a trading account's settings
+   * PUT /api/v1/accounts/:id
+   */
+  updateAccount: (id: string, body: T.TradingAccountUpdateRequest): Promise<T.AccountResponse> =>
+    http.put<T.AccountResponse>(`/accounts/${encodeURIComponent(id)}`, body).then((r) => r.data),
+  /**
+   * Links Binance API keys to a live account
+   * PUT /api/v1/accounts/:id/keys
+   */
+  linkAccountKeys: (id: string, body: T.BinanceKeysRequest, otp?: string): Promise<T.AccountResponse> =>
+    http.put<T.AccountResponse>(`/accounts/${encodeURIComponent(id)}/keys`, body, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Starts a trading account's executor
+   * POST /api/v1/accounts/:id/start
+   */
+  startAccount: (id: string, otp?: string): Promise<T.AccountResponse> =>
+    http.post<T.AccountResponse>(`/accounts/${encodeURIComponent(id)}/start`, undefined, otp ? { headers: { 'X-OTP-Code': otp } } : undefined).then((r) => r.data),
+  /**
+   * Stops a trading account's executor
+   * POST /api/v1/accounts/:id/stop
+   */
+  stopAccount: (id: string): Promise<T.AccountResponse> =>
+    http.post<T.AccountResponse>(`/accounts/${encodeURIComponent(id)}/stop`, undefined).then((r) => r.data),
+  /**
+   * Returns the caller's report formatting
+   * GET /api/v1/reports/locale
+   */
+  getReportLocale: (): Promise<T.ReportLocaleResponse> =>
+    http.get<T.ReportLocaleResponse>('/reports/locale').then((r) => r.data),
+  /**
+   * Changes the caller's report formatting
+   * PUT /api/v1/reports/locale
+   */
+  updateReportLocale: (body: T.ReportLocale): Promise<T.ReportLocaleResponse> =>
+    http.put<T.ReportLocaleResponse>('/reports/locale', body).then((r) => r.data),
+  /**
+   * Returns candles
+   * GET /api/v1/candles
+   */
+  getCandles: (query?: { symbol?: QueryValue; timeframe?: QueryValue; limit?: QueryValue; from?: QueryValue; to?: QueryValue }): Promise<T.CandleData[]> =>
+    http.get<T.CandleData[]>('/candles', { params: query }).then((r) => r.data),
+  /**
+   * Returns candles of a symbol and timeframe
+   * GET /api/v1/candles/:symbol/:timeframe
+   */
+  getSymbolCandles: (symbol: string, timeframe: string, query?: { limit?: QueryValue; from?: QueryValue; to?: QueryValue }): Promise<T.CandleData[]> =>
+    http.get<T.CandleData[]>(`/candles/${encodeURIComponent(symbol)}/${encodeURIComponent(timeframe)}`, { params: query }).then((r) => r.data),
+  /**
+   * Returns the 24h ticker
+   * GET /api/v1/ticker
+   */
+  getTicker: (query?: { symbol?: QueryValue }): Promise<T.TickerData> =>
+    http.get<T.TickerData>('/ticker', { params: query }).then((r) => r.data),
+  /**
+   * Returns the latest indicator values
+   * GET /api/v1/indicators
+   */
+  getIndicators: (query?: { symbol?: QueryValue; timeframe?: QueryValue }): Promise<T.IndicatorData> =>
+    http.get<T.IndicatorData>('/indicators', { params: query }).then((r) => r.data),
+  /**
+   * Returns recent trades bucketed by time
+   * GET /api/v1/tape
+   */
+  getTape: (query?: { bucket?: QueryValue; limit?: QueryValue }): Promise<T.Tape> =>
+    http.get<T.Tape>('/tape', { params: query }).then((r) => r.data),
+  /**
+   * Returns the order book
+   * GET /api/v1/orderbook
+   */
+  getOrderBook: (query?: { levels?: QueryValue; quantity?: QueryValue }): Promise<T.OrderBookView> =>
+    http.get<T.OrderBookView>('/orderbook', { params: query }).then((r) => r.data),
+  /**
+   * Returns the price the bot acts on and its sources
+   * GET /api/v1/price/sources
+   */
+  getPriceSources: (): Promise<T.PriceArbiterStatus> =>
+    http.get<T.PriceArbiterStatus>('/price/sources').then((r) => r.data),
+  /**
+   * Returns a symbol's exchange trading rules
+   * GET /api/v1/symbols/:symbol/info
+   */
+  getSymbolInfo: (symbol: string, query?: { refresh?: QueryValue }): Promise<T.SymbolRules> =>
+    http.get<T.SymbolRules>(`/symbols/${encodeURIComponent(symbol)}/info`, { params: query }).then((r) => r.data),
+  /**
+   * Returns WebSocket bandwidth and message rates
+   * GET /api/v1/metrics/bandwidth
+   */
+  getBandwidth: (): Promise<T.BandwidthResponse> =>
+    http.get<T.BandwidthResponse>('/metrics/bandwidth').then((r) => r.data),
+  /**
+   * Returns Binance request weight usage
+   * GET /api/v1/metrics/rate-limit
+   */
+  getRateLimit: (): Promise<T.RequestWeight> =>
+    http.get<T.RequestWeight>('/metrics/rate-limit').then((r) => r.data),
+  /**
+   * Returns pipeline stage timings
+   * GET /api/v1/metrics/latency
+   */
+  getLatency: (): Promise<T.LatencyReport> =>
+    http.get<T.LatencyReport>('/metrics/latency').then((r) => r.data),
+  /**
+   * Returns strategy evaluation worker statistics
+   * GET /api/v1/metrics/evaluation
+   */
+  getEvaluation: (): Promise<T.EvalPoolStats> =>
+    http.get<T.EvalPoolStats>('/metrics/evaluation').then((r) => r.data),
+  /**
+   * Returns candidate symbols ranked by fit
+   * GET /api/v1/scan
+   */
+  getScan: (query?: { refresh?: QueryValue }): Promise<T.Report> =>
+    http.get<T.Report>('/scan', { params: query }).then((r) => r.data),
+  /**
+   * Returns precomputed indicator series
+   * GET /api/v1/indicators/series
+   */
+  getIndicatorSeries: (query?: { timeframe?: QueryValue; columns?: QueryValue; from?: QueryValue; to?: QueryValue }): Promise<T.IndicatorSeriesResponse> =>
+    http.get<T.IndicatorSeriesResponse>('/indicators/series', { params: query }).then((r) => r.data),
+  /**
+   * Returns the time ranges indicator series cover
+   * GET /api/v1/indicators/series/coverage
+   */
+  getIndicatorCoverage: (query?: { timeframe?: QueryValue }): Promise<T.IndicatorCoverageResponse> =>
+    http.get<T.IndicatorCoverageResponse>('/indicators/series/coverage', { params: query }).then((r) => r.data),
+  /**
+   * Precomputes indicator series
+   * POST /api/v1/indicators/precompute
+   */
+  precomputeIndicators: (body: T.PrecomputeRequest): Promise<T.IndicatorPrecomputeResult> =>
+    http.post<T.IndicatorPrecomputeResult>('/indicators/precompute', body).then((r) => r.data),
+  /**
+   * Queues a backtest
+   * POST /api/v1/backtest
+   */
+  runBacktest: (body: T.BacktestRequest): Promise<T.BacktestJobResponse> =>
+    http.post<T.BacktestJobResponse>('/backtest', body).then((r) => r.data),
+  /**
+   * Runs a parameter search
+   * POST /api/v1/backtest/optimize
+   */
+  optimize: (body: T.OptimizeRequest): Promise<T.OptimizeResponse> =>
+    http.post<T.OptimizeResponse>('/backtest/optimize', body).then((r) => r.data),
+  /**
+   * Returns completed backtests
+   * GET /api/v1/backtest/results
+   */
+  getBacktestResults: (query?: { limit?: QueryValue }): Promise<T.BacktestResultSummary[]> =>
+    http.get<T.BacktestResultSummary[]>('/backtest/results', { params: query }).then((r) => r.data),
+  /**
+   * Returns a completed backtest
+   * GET /api/v1/backtest/results/:id
+   */
+  getBacktestResult: (id: string): Promise<T.BacktestJobResponse> =>
+    http.get<T.BacktestJobResponse>(`/backtest/results/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Returns a backtest job and its result once done
+   * GET /api/v1/backtest/:id
+   */
+  getBacktest: (id: string): Promise<T.BacktestJobResponse> =>
+    http.get<T.BacktestJobResponse>(`/backtest/${encodeURIComponent(id)}`).then((r) => r.data),
+  /**
+   * Backtests an inline strategy script
+   * POST /api/v1/sandbox/run
+   */
+  runSandbox: (body: T.SandboxRequest): Promise<T.SandboxResponse> =>
+    http.post<T.SandboxResponse>('/sandbox/run', body).then((r) => r.data),
+  /**
+   * Returns all settings
+   * GET /api/v1/settings
+   */
+  getSettings: (): Promise<T.FullSettingsResponse> =>
+    http.get<T.FullSettingsResponse>('/settings').then((r) => r.data),
+  /**
+   * Resets all settings to defaults
+   * POST /api/v1/settings/reset
+   */
+  resetSettings: (): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>('/settings/reset', undefined).then((r) => r.data),
+  /**
+   * Returns trading settings
+   * GET /api/v1/settings/trading
+   */
+  getTradingSettings: (): Promise<T.TradingSettings> =>
+    http.get<T.TradingSettings>('/settings/trading').then((r) => r.data),
+  /**
+   * Changes trading settings
+   * PUT /api/v1/settings/trading
+   */
+  updateTradingSettings: (body: T.TradingSettings): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>('/settings/trading', body).then((r) => r.data),
+  /**
+   * Returns Binance settings
+   * GET /api/v1/settings/binance
+   */
+  getBinanceSettings: (): Promise<T.BinanceSettings> =>
+    http.get<T.BinanceSettings>('/settings/binance').then((r) => r.data),
+  /**
+   * Changes Binance settings
+   * PUT /api/v1/settings/binance
+   */
+  updateBinanceSettings: (body: T.BinanceSettings): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>('/settings/binance', body).then((r) => r.data),
+  /**
+   * Returns risk settings
+   * GET /api/v1/settings/risk
+   */
+  getRiskSettings: (): Promise<T.RiskSettings> =>
+    http.get<T.RiskSettings>('/settings/risk').then((r) => r.data),
+  /**
+   * Changes risk settings
+   * PUT /api/v1/settings/risk
+   */
+  updateRiskSettings: (body: T.RiskSettings): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>('/settings/risk', body).then((r) => r.data),
+  /**
+   * Returns indicator settings
+   * GET /api/v1/settings/indicators
+   */
+  getIndicatorSettings: (): Promise<T.IndicatorSettings> =>
+    http.get<T.IndicatorSettings>('/settings/indicators').then((r) => r.data),
+  /**
+   * Changes indicator settings
+   * PUT /api/v1/settings/indicators
+   */
+  updateIndicatorSettings: (body: T.IndicatorSettings): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>('/settings/indicators', body).then((r) => r.data),
+  /**
+   * Returns strategy settings
+   * GET /api/v1/settings/strategies
+   */
+  getStrategySettings: (): Promise<T.StrategySettings> =>
+    http.get<T.StrategySettings>('/settings/strategies').then((r) => r.data),
+  /**
+   * Changes strategy settings
+   * PUT /api/v1/settings/strategies
+   */
+  updateStrategySettings: (body: T.StrategySettings): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>('/settings/strategies', body).then((r) => r.data),
+  /**
+   * Returns per-symbol overrides
+   * GET /api/v1/settings/symbols
+   */
+  getSymbolSettings: (): Promise<Record<string, T.SymbolConfig>> =>
+    http.get<Record<string, T.SymbolConfig>>('/settings/symbols').then((r) => r.data),
+  /**
+   * Sets a symbol's overrides
+   * PUT /api/v1/settings/symbols/:symbol
+   */
+  updateSymbolSettings: (symbol: string, body: T.SymbolConfig): Promise<Record<string, unknown>> =>
+    http.put<Record<string, unknown>>(`/settings/symbols/${encodeURIComponent(symbol)}`, body).then((r) => r.data),
+  /**
+   * Removes a symbol's overrides
+   * DELETE /api/v1/settings/symbols/:symbol
+   */
+  deleteSymbolSettings: (symbol: string): Promise<Record<string, unknown>> =>
+    http.delete<Record<string, unknown>>(`/settings/symbols/${encodeURIComponent(symbol)}`).then((r) => r.data),
+  /**
+   * Returns the settings audit trail
+   * GET /api/v1/settings/history
+   */
+  getSettingsHistory: (query?: { section?: QueryValue; limit?: QueryValue }): Promise<T.SettingsChangeResponse[]> =>
+    http.get<T.SettingsChangeResponse[]>('/settings/history', { params: query }).then((r) => r.data),
+  /**
+   * Restores a settings section to a past version
+   * POST /api/v1/settings/rollback/:versionId
+   */
+  rollbackSettings: (versionId: string): Promise<Record<string, unknown>> =>
+    http.post<Record<string, unknown>>(`/settings/rollback/${encodeURIComponent(versionId)}`, undefined).then((r) => r.data),
+  /**
+   * Returns the configuration changes applied at runtime and those waiting for
+   * a restart
+   * GET /api/v1/settings/reload
+   */
+  getReloadStatus: (): Promise<T.ReloadStatus> =>
+    http.get<T.ReloadStatus>('/settings/reload').then((r) => r.data),
+  /**
+   * Reloads config.yaml into the running bot
+   * POST /api/v1/settings/reload
+   */
+  reloadSettings: (): Promise<T.ReloadStatus> =>
+    http.post<T.ReloadStatus>('/settings/reload', undefined).then((r) => r.data),
+});
+
+export type ApiClient = ReturnType<typeof createApiClient>;
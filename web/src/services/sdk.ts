@@ -0,0 +1,8 @@
+import api from '../lib/axios';
+import { createApiClient } from './client.gen';
+
+// Typed API client generated from the server's route spec (make sdk), on the
+// axios instance that adds the access token and refreshes it
+export const apiClient = createApiClient(api);
+
+export type { ApiClient } from './client.gen';
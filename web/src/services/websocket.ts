@@ -1,4 +1,5 @@
 import type { WSMessage } from '../types';
+import { useAuthStore } from '../stores/authStore';
 
 type MessageHandler = (message: WSMessage) => void;
 type ConnectionHandler = () => void;
@@ -31,7 +32,9 @@ class WebSocketService {
     this.intentionalClose = false;
 
     try {
-      this.ws = new WebSocket(this.url);
+      // Browsers cannot set headers on the upgrade, so the token goes in the query
+      const token = useAuthStore.getState().accessToken;
+      this.ws = new WebSocket(token ? `${this.url}?token=${encodeURIComponent(token)}` : this.url);
 
       this.ws.onopen = () => {
         console.log('WebSocket connected');
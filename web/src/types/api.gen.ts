@@ -0,0 +1,2659 @@
+// Code generated by sdkgen from internal/api/spec. DO NOT EDIT.
+
+/** ADXData represents ADX indicator values */
+export interface ADXData {
+  adx: number;
+  plusDI: number;
+  minusDI: number;
+  trend: string;
+}
+
+/** ADXValue represents ADX values */
+export interface ADXValue {
+  adx: number;
+  plusDI: number;
+  minusDI: number;
+}
+
+/** AccountResponse is a trading account and, while it runs, its executor */
+export interface AccountResponse extends TradingAccountResponse {
+  executor?: AccountStatus;
+}
+
+/**
+ * AccountRiskConfig overrides the bot's risk limits for a trading account's own
+ * executor. Unset fields keep the bot's values.
+ */
+export interface AccountRiskConfig {
+  max_position_size?: number; // Fraction of equity
+  max_risk_per_trade?: number; // Fraction of equity
+  max_daily_loss?: number; // Fraction of equity
+  max_weekly_loss?: number; // Fraction of equity
+  max_drawdown?: number; // Fraction of peak equity
+  max_open_positions?: number;
+  min_risk_reward_ratio?: number;
+}
+
+/** AccountStatus is the state of a user's running account executor */
+export interface AccountStatus {
+  accountId: string;
+  userId: string;
+  name: string;
+  mode: ModelsTradingMode;
+  startedAt: string;
+  equity: number;
+  dailyPnl: number;
+  weeklyPnl: number;
+  openPositions: number;
+  trades: number; // Orders placed since start
+  rejected: number; // Signals the account's risk manager rejected
+  lastReject?: string;
+  halted: boolean;
+}
+
+/** AccountSummary represents account summary for API */
+export interface AccountSummary {
+  equity: number;
+  availableBalance: number;
+  usedMargin: number;
+  unrealizedPnL: number;
+  realizedPnL: number;
+  dailyPnL: number;
+  weeklyPnL: number;
+  totalReturn: number;
+  openPositions: number;
+  totalTrades: number;
+  winningTrades: number;
+  losingTrades: number;
+  winRate: number;
+  profitFactor: number;
+}
+
+/** AccountType represents the type of trading account */
+export type AccountType = string;
+
+/** AllocationMode determines how strategy weights are assigned */
+export type AllocationMode = string;
+
+/** AllocationResponse represents the current capital allocation */
+export interface AllocationResponse {
+  mode: string;
+  rebalanceInterval: string;
+  allocations: StrategyAllocation[];
+  timestamp: string;
+}
+
+/** AllocationSnapshot records a reallocation */
+export interface AllocationSnapshot {
+  timestamp: string;
+  mode: AllocationMode;
+  reason: string;
+  equity: number;
+  weights: Record<string, number>;
+}
+
+/** ArmRequest re-authenticates the user before arming live mode */
+export interface ArmRequest {
+  password: string;
+}
+
+/** ArmingCheck is one minimum configuration requirement for live trading */
+export interface ArmingCheck {
+  name: string;
+  passed: boolean;
+  detail?: string;
+}
+
+/** ArmingState is the phase of the live-mode arming sequence */
+export type ArmingState = string;
+
+/** ArmingStatus describes the live-mode arming sequence */
+export interface ArmingStatus {
+  required: boolean; // Live mode can only be entered by arming
+  state: ArmingState;
+  mode: string;
+  actor?: string; // User behind the last transition
+  reason?: string;
+  armsAt?: string; // End of the cancel window
+  armedAt?: string;
+  countdownSeconds: number;
+  checks?: ArmingCheck[];
+}
+
+/** AuditEntry is one recorded action */
+export interface AuditEntry {
+  id: number;
+  category: string;
+  action: string; // e.g. order_place, strategy_enable, circuit_breaker_reset
+  actor: string; // User email or ID; "system" for actions the bot takes on its own
+  resource?: string; // What was acted on, e.g. an order ID, strategy or settings section
+  accountId?: string; // Trading account of per-account orders
+  oldValue?: Value;
+  newValue?: Value;
+  success: boolean;
+  error?: string;
+  createdAt: string;
+}
+
+/** BacktestJob is a backtest queued or run by the worker pool */
+export interface BacktestJob {
+  id: number;
+  name: string;
+  status: string;
+  progress: number; // Percent complete
+  error?: string;
+  submittedBy?: string;
+  queuedAt: string;
+  startedAt?: string;
+  finishedAt?: string;
+}
+
+/**
+ * BacktestJobResponse represents the state of a queued backtest and, once
+ * completed, its result
+ */
+export interface BacktestJobResponse {
+  id: number;
+  name: string;
+  status: string;
+  progress: number; // Percent complete
+  error?: string;
+  submittedBy?: string;
+  createdAt: string;
+  startedAt?: string;
+  completedAt?: string;
+  result?: Value; // BacktestResponse
+}
+
+/** BacktestMetricsData represents backtest metrics for API */
+export interface BacktestMetricsData {
+  totalReturn: number;
+  annualizedReturn: number;
+  sharpeRatio: number;
+  sortinoRatio: number;
+  calmarRatio: number;
+  maxDrawdown: number;
+  totalTrades: number;
+  winningTrades: number;
+  losingTrades: number;
+  winRate: number;
+  profitFactor: number;
+  avgWin: number;
+  avgLoss: number;
+  largestWin: number;
+  largestLoss: number;
+  avgHoldingTime: string;
+  expectancy: number;
+  recoveryFactor: number;
+  startingCapital: number;
+  endingCapital: number;
+  netProfit: number;
+  tradedNotional: number;
+  turnover: number;
+  tradesPerMonth: number;
+  avgExposureTime: string;
+  timeInMarket: number;
+  totalCommission: number;
+  totalFunding: number;
+  totalBorrow: number;
+  makerFills: number;
+  takerFills: number;
+  maxConcurrentPositions: number;
+  maxExposure: number;
+  maxDrawdownDuration: string; // Longest time below the high-water mark
+  longestFlatPeriod: string;
+  ulcerIndex: number;
+  downtimeBars: number;
+  reopenExits: number;
+  spikeEntries: number; // Entries signaled on a volatility spike
+  delayedEntries: number;
+  droppedEntries: number; // Delayed entries the next bar did not bear out
+  downsizedEntries: number;
+  heldEntries: number; // Entries held for a confirmation candle
+  confirmedEntries: number;
+  unconfirmedEntries: number; // Held entries dropped
+}
+
+/** BacktestRequest represents a backtest request */
+export interface BacktestRequest {
+  symbol: string;
+  timeframe: string;
+  startDate: string; // ISO 8601 format
+  endDate: string; // ISO 8601 format
+  initialCapital: number;
+  commission: number; // Flat rate on every fill; ignored when fees is set
+  slippage: number;
+  strategies: string[];
+  riskPerTrade: number;
+  fees?: FeeSchedule; // Maker/taker fee schedule; defaults to the account's configured schedule
+  carry?: CarryCosts; // Funding and borrow costs of holding margin or futures positions; spot backtests leave it unset and pay none
+  higherTimeframes?: string[]; // Secondary timeframes provided as context (e.g. ["4h"] while trading 1h)
+  fetchMissing?: boolean; // Fetch candles missing from local storage from Binance and persist them before running, instead of only using what the bot has cached
+  lookaheadAudit?: boolean; // Replay with inputs lagged one bar and report lookahead bias
+  benchmarkRuns?: number; // Compare with this many random-entry control runs sharing the exits, sizing and costs (0 = no benchmark); a seed makes them repeatable
+  benchmarkSeed?: number;
+  disallowedRegimes?: Record<string, string[]>; // Per-strategy regime blacklist (e.g. {"stat_arb": ["TRENDING"]}); defaults to the live configuration when omitted
+  maxPositions?: number; // Concurrent positions (default 1), the largest fraction of capital one may take (default 95% split over maxPositions) and the cap on same-direction exposure as a fraction of capital (0 = none)
+  positionAllocation?: number;
+  maxCorrelatedExposure?: number;
+  executionModel?: string; // How stops and targets fill within a bar: "worst_case" (default) checks the high/low and assumes the stop fills first, "ohlc_path" follows the likely open-high-low-close path, "close" only the close
+  maintenanceWindows?: MaintenanceWindowRequest[]; // Known exchange downtime during which nothing fills
+  compounding?: string; // Capital entries are sized from as equity grows: "full", "fixed" (initialCapital) or "stepped" every compoundingStep of gain; defaults to the live configuration when omitted
+  compoundingStep?: number;
+}
+
+/** BacktestResultSummary represents a backtest result summary */
+export interface BacktestResultSummary {
+  id: string;
+  symbol: string;
+  timeframe: string;
+  startDate: string;
+  endDate: string;
+  return: number;
+  sharpe: number;
+  maxDD: number;
+  trades: number;
+  status: string;
+  createdAt: string;
+}
+
+/** BackupRecord is one database backup attempt */
+export interface BackupRecord {
+  id: number;
+  destination: string; // file or download
+  path?: string;
+  size_bytes: number;
+  duration_ms: number;
+  status: string; // ok or failed
+  error?: string;
+  requested_by: string;
+  created_at: string;
+}
+
+/** BandwidthResponse represents WebSocket traffic on both sides of the bot */
+export interface BandwidthResponse {
+  exchange: StreamBandwidth;
+  api: HubStats;
+}
+
+/** BinanceKeysRequest links Binance API keys to a trading account */
+export interface BinanceKeysRequest {
+  binance_api_key: string;
+  binance_secret_key: string;
+  binance_testnet: boolean;
+}
+
+/** BinanceSettings represents Binance API configuration */
+export interface BinanceSettings {
+  apiKey: string; // Binance API key
+  secretKey: string; // Binance Secret key (masked on read)
+  testnet: boolean; // Use testnet
+}
+
+/** BollingerData represents Bollinger Band values */
+export interface BollingerData {
+  upper: number;
+  middle: number;
+  lower: number;
+  width: number;
+  percent: number;
+}
+
+/** BollingerValue represents Bollinger Band values */
+export interface BollingerValue {
+  upper: number;
+  middle: number;
+  lower: number;
+  width: number;
+}
+
+/** CandleData represents candle data for API */
+export interface CandleData {
+  time: number; // Unix timestamp in seconds
+  open: number;
+  high: number;
+  low: number;
+  close: number;
+  volume: number;
+  session: string; // Trading session the candle opened in
+}
+
+/** CandleUpdate represents a candle update message */
+export interface CandleUpdate {
+  symbol: string;
+  timeframe: string;
+  timestamp: string;
+  open: number;
+  high: number;
+  low: number;
+  close: number;
+  volume: number;
+  isClosed: boolean;
+  session: string;
+}
+
+/**
+ * CapitalTransfer moves capital between the bot's sub-balance and the rest of
+ * the exchange account
+ */
+export interface CapitalTransfer {
+  amount: number; // Positive into the sub-balance, negative out of it
+  note?: string;
+  by?: string;
+  at: string;
+}
+
+/** CapitalTransferRequest moves capital into or out of the bot's sub-balance */
+export interface CapitalTransferRequest {
+  amount: number; // Positive into the sub-balance, negative out of it
+  note?: string;
+}
+
+/**
+ * CarryCosts prices holding margin or futures positions over time. Funding
+ * settles on every interval a position is open across; borrow interest accrues
+ * continuously on the asset borrowed to hold shorts.
+ */
+export interface CarryCosts {
+  fundingRate: number; // Paid by longs to shorts per settlement (0.0001 = 0.01% of notional); negative pays shorts
+  fundingRates?: FundingRate[]; // Historical rates, oldest first; each applies until the next
+  fundingIntervalHours?: number; // Between settlements, aligned to midnight UTC; 0 = 8
+  borrowRate: number; // Annual interest on borrowed short notional (0.05 = 5%)
+}
+
+/** CarryPolicy decides what happens to open positions at a mode switch */
+export type CarryPolicy = string;
+
+/** CashFlowRequest represents a deposit or withdrawal */
+export interface CashFlowRequest {
+  amount: number; // Positive for deposits, negative for withdrawals
+  note?: string;
+}
+
+/** ChaosEvent is a simulated failure that was injected */
+export interface ChaosEvent {
+  fault: string;
+  count: number;
+  trigger: string; // "schedule" or the user who requested it
+  error?: string;
+  injectedAt: string;
+}
+
+/** ChaosRequest requests an outage drill */
+export interface ChaosRequest {
+  fault: string; // ws_drop, rate_limit, delayed_fill or partial_fill
+  count: number; // Orders the fault applies to (default 1)
+}
+
+/** ChaosStatus describes the outage drill configuration and history */
+export interface ChaosStatus {
+  enabled: boolean;
+  interval?: string; // Time between scheduled drills
+  faults: string[]; // Faults drawn by scheduled drills
+  pending: Record<string, number>; // Order faults waiting for an order
+  events: ChaosEvent[]; // Most recent first
+}
+
+/** CircuitBreakerEvent is a trip or reset of the circuit breaker */
+export interface CircuitBreakerEvent {
+  time: string;
+  type: string; // CIRCUIT_BREAKER or CIRCUIT_BREAKER_RESET
+  message: string;
+  reason?: string;
+}
+
+/** ConfluenceCheck is the outcome of the confluence layer for one signal */
+export interface ConfluenceCheck {
+  passed: boolean;
+  mode: string;
+  timeframes: TimeframeStance[];
+  agree: number;
+  oppose: number;
+  confidence: number; // After weighting; unchanged in filter mode
+  reason?: string;
+}
+
+/** CopyFollower is an endpoint approved signals are pushed to */
+export interface CopyFollower {
+  name: string;
+  url: string;
+  core: boolean; // From the configuration; cannot be removed
+  addedBy?: string;
+  addedAt?: string;
+  delivered: number;
+  failed: number;
+  lastDelivery?: string;
+  lastError?: string;
+}
+
+/** CopyTradingStatus describes both sides of copy trading */
+export interface CopyTradingStatus {
+  publishing: boolean;
+  leader?: string;
+  followers: CopyFollower[];
+  published: number;
+  dropped: number; // Not pushed because the queue was full
+  following: boolean;
+  leaders?: string[];
+  received: number;
+  approved: number;
+  rejected: number; // Refused by this bot's checks or risk pipeline
+  lastReceived?: string;
+}
+
+/** DashboardResponse represents dashboard data */
+export interface DashboardResponse {
+  state: TradingState | null;
+  summary: AccountSummary | null;
+  performance: PerformanceData | null;
+  recentTrades: TradeData[];
+  positions: PositionData[];
+  signals: SignalRecord[];
+  timestamp: string;
+}
+
+/** DisarmRequest optionally records why live mode was disarmed */
+export interface DisarmRequest {
+  reason: string;
+}
+
+/** DrawdownResponse represents drawdown information */
+export interface DrawdownResponse {
+  current: number;
+  max: number;
+  recoveryRequired: number;
+  isAtPeak: boolean;
+  peakEquity?: number;
+  peakAt?: string;
+  highWaterMarkMode?: string;
+}
+
+export type Duration = number;
+
+/** DustBalance is a residual asset balance too small to trade */
+export interface DustBalance {
+  asset: string;
+  free: number;
+  locked: number;
+  price: number; // USDT price used for valuation
+  valueUsdt: number;
+}
+
+/** DustConversion is the result of converting dust balances to BNB */
+export interface DustConversion {
+  assets: string[];
+  receivedBnb: number;
+  fee: number; // Service charge in BNB
+  convertedAt: string;
+}
+
+/** DustReport lists dust balances and the threshold used to detect them */
+export interface DustReport {
+  threshold: number; // USDT value below which a balance is dust
+  totalValue: number;
+  balances: DustBalance[];
+}
+
+/**
+ * EntryConfirmationStats counts a strategy's entries held for confirmation and
+ * what became of them
+ */
+export interface EntryConfirmationStats {
+  strategy: string;
+  held: number;
+  confirmed: number;
+  rejected: number; // Conditions no longer held, or the wait was missed
+  lastRejected?: string; // Condition that failed last, e.g. "macd"
+  lastHeldAt?: string;
+}
+
+/** EntryStats counts how maker-first entries were filled */
+export interface EntryStats {
+  makerFilled: number; // Filled in full as maker
+  makerPartial: number; // Partly filled as maker, the rest at market
+  rejected: number; // Priced through the book when placed, sent at market
+  marketFallback: number; // Market orders sent for unfilled quantity
+}
+
+/** EntryStatus describes the entry policy and its outcomes */
+export interface EntryStatus {
+  makerFirst: boolean;
+  offsetBps: number;
+  timeout: string;
+  stats: EntryStats;
+}
+
+/** EquityCurvePoint represents a point on the equity curve */
+export interface EquityCurvePoint {
+  time: string;
+  equity: number;
+  drawdown: number;
+  return: number;
+}
+
+/** ErrorUpdate represents an error message */
+export interface ErrorUpdate {
+  code: string;
+  message: string;
+  details?: string;
+  time: string;
+}
+
+/** EvalPoolStats describes the pool's workers and queueing delays */
+export interface EvalPoolStats {
+  workers: number;
+  busy: number;
+  queues: EvalQueueStats[];
+}
+
+/** EvalQueueStats summarizes the queueing delay of one priority */
+export interface EvalQueueStats {
+  priority: string;
+  waiting: number;
+  evaluations: number;
+  lastWaitMs: number;
+  avgWaitMs: number;
+  maxWaitMs: number;
+}
+
+/** Event is one structured log event */
+export interface Event {
+  seq: number;
+  time: string;
+  level: string;
+  category: string;
+  message: string;
+  fields?: Record<string, unknown>;
+}
+
+/** ExecutionLeg is one side (live or shadow paper) of a reconciled trade */
+export interface ExecutionLeg {
+  orderId?: string;
+  positionId?: number;
+  quantity: number;
+  entryPrice: number;
+  entrySlippageBps: number; // Adverse slippage vs signal price
+  exitPrice?: number;
+  exitReason?: string;
+  exitTime?: string;
+  commission: number; // Entry plus exit commission
+  netPnl: number;
+  closed: boolean;
+  error?: string;
+}
+
+/** FeeSchedule prices fills the way the exchange charges the account */
+export interface FeeSchedule {
+  tiers: FeeTier[];
+  volume30d: number; // Account 30-day volume selecting the tier
+  bnbDiscount: boolean; // Commission paid in BNB at a discount
+}
+
+/** FeeTier is the maker/taker rate from a 30-day traded volume upward */
+export interface FeeTier {
+  name?: string;
+  minVolume: number; // 30-day volume (USDT) at which the tier starts
+  makerBps: number;
+  takerBps: number;
+}
+
+/** Fill is the estimated execution of a market order walking the book */
+export interface Fill {
+  quantity: number;
+  avgPrice: number;
+  worstPrice: number;
+  levels: number; // Price levels consumed
+  slippageBps: number; // Average price against the mid, always adverse
+  exhausted: boolean; // The book was too thin; the rest is priced at the worst level
+}
+
+/** FollowerRequest registers an endpoint to push signals to */
+export interface FollowerRequest {
+  name: string;
+  url: string; // Follower's POST /api/v1/copy/signals
+}
+
+/** FullSettingsResponse represents all settings */
+export interface FullSettingsResponse {
+  trading: TradingSettings;
+  binance: BinanceSettings;
+  risk: RiskSettings;
+  indicators: IndicatorSettings;
+  strategies: StrategySettings;
+  symbols: Record<string, SymbolConfig>; // Per-symbol overrides
+}
+
+/** FundingRate is the funding rate settled from a time onward */
+export interface FundingRate {
+  time: string;
+  rate: number;
+}
+
+/** HaltRequest represents a manual trading halt request */
+export interface HaltRequest {
+  reason: string;
+  durationMinutes?: number; // 0 = until resumed
+}
+
+/** HighWaterMark is the persisted equity peak drawdown is measured from */
+export interface HighWaterMark {
+  mode: HighWaterMarkMode;
+  peak: number;
+  peakAt: string;
+  periodStart: string; // Start of the current period (monthly mode)
+  netDeposits: number; // Cash flows recorded so far
+  updatedAt: string;
+}
+
+/** HighWaterMarkMode determines how the equity peak used for drawdown evolves */
+export type HighWaterMarkMode = string;
+
+/** HistoryImportReport describes an exchange order history import */
+export interface HistoryImportReport {
+  status: string; // running, completed or failed
+  symbols: string[];
+  until: string; // Fills from this time on are left to the bot's own records
+  startedAt: string;
+  finishedAt?: string;
+  fills: number; // Exchange fills read
+  orders: number; // Orders the fills belong to
+  imported: number; // Orders stored as trades
+  skipped: number; // Orders already stored
+  positions: number; // Closed positions rebuilt from the fills
+  openQuantity?: Record<string, number>; // Holdings still open at the end, by symbol
+  unmatchedSells?: Record<string, number>; // Quantity sold without a recorded buy, by symbol
+  unpricedCommission?: Record<string, number>; // Commission in assets other than the pair's, by asset
+  error?: string;
+}
+
+/** HistoryImportRequest selects what to import from the exchange */
+export interface HistoryImportRequest {
+  symbols: string[]; // Defaults to the traded symbol
+  until: number; // Unix ms; defaults to the bot's first recorded trade
+}
+
+/** HubStats describes the traffic served to dashboard clients */
+export interface HubStats {
+  clients: number;
+  users: number; // Distinct users connected
+  compression: boolean; // Offered to clients; used when they accept
+  since: string;
+  messagesIn: number;
+  bytesIn: number;
+  messagesOut: number;
+  bytesOut: number; // Payload bytes before compression
+}
+
+/** IchimokuValue represents Ichimoku cloud values */
+export interface IchimokuValue {
+  tenkan: number;
+  kijun: number;
+  senkouA: number;
+  senkouB: number;
+  position: string; // UP above the cloud, DOWN below, NEUTRAL inside
+}
+
+/** IdeaDecisionRequest optionally records why an idea was approved or rejected */
+export interface IdeaDecisionRequest {
+  note: string;
+}
+
+/** IdeaReasoning is why the bot proposed a trade */
+export interface IdeaReasoning {
+  regime: string;
+  regimeConfidence: number;
+  score: number;
+  confidence: number;
+  longSignals: number;
+  shortSignals: number;
+  hasConflict: boolean;
+  strategies: IdeaStrategyScore[];
+  riskLevel?: string;
+  riskRewardRatio?: number;
+  riskWarnings?: string[];
+  sizingModel?: string;
+  sizingDetail?: string;
+}
+
+/** IdeaStrategyScore is one strategy's contribution to a trade idea */
+export interface IdeaStrategyScore {
+  strategy: string;
+  direction: string;
+  score: number;
+  confidence: number;
+  factors?: Record<string, number>;
+}
+
+/** InboxResponse represents the trade idea inbox */
+export interface InboxResponse {
+  enabled: boolean;
+  ideas: TradeIdea[];
+}
+
+/** IndicatorColumnValue is one series with open times in Unix milliseconds */
+export interface IndicatorColumnValue {
+  name: string;
+  times: number[];
+  values: number[];
+}
+
+/** IndicatorCoverage describes what is stored for one indicator series */
+export interface IndicatorCoverage {
+  name: string;
+  count: number;
+  from: string;
+  to: string;
+}
+
+/** IndicatorCoverageResponse describes which series are stored */
+export interface IndicatorCoverageResponse {
+  timeframe: string;
+  available: string[];
+  stored: IndicatorCoverage[];
+  lastRuns: IndicatorPrecomputeResult[];
+}
+
+/** IndicatorData represents indicator values */
+export interface IndicatorData {
+  rsi?: number;
+  macd?: MACDData;
+  bb?: BollingerData;
+  adx?: ADXData;
+  atr?: number;
+  volume?: VolumeData;
+  regime: string;
+  timestamp: number;
+}
+
+/** IndicatorPrecomputeResult summarizes one precompute run */
+export interface IndicatorPrecomputeResult {
+  symbol: string;
+  timeframe: string;
+  columns: string[];
+  candles: number; // Stored candles the series were computed over
+  written: number; // Values upserted
+  full: boolean; // Rewrote every value rather than only new bars
+  startedAt: string;
+  duration: string;
+}
+
+/** IndicatorSeriesResponse holds precomputed series in columnar form */
+export interface IndicatorSeriesResponse {
+  timeframe: string;
+  columns: IndicatorColumnValue[];
+}
+
+/** IndicatorSettings represents indicator configuration */
+export interface IndicatorSettings {
+  rsiPeriod: number; // RSI period (default: 14)
+  rsiOversold: number; // RSI oversold level (default: 30)
+  rsiOverbought: number; // RSI overbought level (default: 70)
+  macdFast: number; // MACD fast period (default: 12)
+  macdSlow: number; // MACD slow period (default: 26)
+  macdSignal: number; // MACD signal period (default: 9)
+  bbPeriod: number; // Bollinger Band period (default: 20)
+  bbStdDev: number; // Bollinger Band std dev (default: 2.0)
+  adxPeriod: number; // ADX period (default: 14)
+  adxThreshold: number; // ADX trend threshold (default: 25)
+  atrPeriod: number; // ATR period (default: 14)
+  atrMultiplierSL: number; // ATR multiplier for stop loss (default: 2.0)
+  atrMultiplierTP: number; // ATR multiplier for take profit (default: 3.0)
+  keltnerPeriod: number; // Keltner Channel EMA and ATR period (default: 20)
+  keltnerMultiplier: number; // Keltner Channel ATR multiplier (default: 2.0)
+  superTrendPeriod: number; // SuperTrend ATR period (default: 10)
+  superTrendMultiplier: number; // SuperTrend ATR multiplier (default: 3.0)
+  ichimokuTenkan: number; // Ichimoku conversion line period (default: 9)
+  ichimokuKijun: number; // Ichimoku base line period and cloud displacement (default: 26)
+  ichimokuSenkouB: number; // Ichimoku leading span B period (default: 52)
+}
+
+/** IndicatorsUpdate represents indicators update message */
+export interface IndicatorsUpdate {
+  symbol: string;
+  timeframe: string;
+  timestamp: string;
+  rsi: number;
+  macd: MACDValue | null;
+  bb: BollingerValue | null;
+  adx: ADXValue | null;
+  atr: number;
+  vwap: VWAPValue | null;
+  supertrend: SuperTrendValue | null;
+  ichimoku: IchimokuValue | null;
+  keltner: KeltnerValue | null;
+  regime: string;
+}
+
+/** KeltnerValue represents Keltner Channel values */
+export interface KeltnerValue {
+  upper: number;
+  middle: number;
+  lower: number;
+}
+
+/** LatencyBreach is a pipeline run that overran a stage budget */
+export interface LatencyBreach {
+  correlationId: string;
+  stage: string;
+  elapsedMs: number;
+  budgetMs: number;
+  stages: Record<string, number>; // Timings of the run so far, in ms
+  timeframe: string;
+  candleClose: string;
+  at: string;
+}
+
+/** LatencyReport describes pipeline stage timings against their budgets */
+export interface LatencyReport {
+  stages: StageLatency[];
+  breaches: LatencyBreach[]; // Most recent first
+}
+
+/** Leaderboard ranks strategies by realized performance */
+export interface Leaderboard {
+  window: string; // e.g. "30d"; "all" = every persisted position
+  from: string;
+  to: string;
+  sort: LeaderboardSort;
+  strategies: StrategyStanding[];
+}
+
+/** LeaderboardSort is the metric strategies are ranked by */
+export type LeaderboardSort = string;
+
+/** Level is one price level of an order book */
+export interface Level {
+  price: number;
+  quantity: number;
+}
+
+/**
+ * LimitPeaks is the highest utilization of each risk limit over a day, as a
+ * fraction of the limit (1 = at the limit)
+ */
+export interface LimitPeaks {
+  dailyLoss: number;
+  weeklyLoss: number;
+  drawdown: number;
+  positions: number;
+}
+
+/** LimitUtilization represents usage of a single risk limit */
+export interface LimitUtilization {
+  name: string;
+  used: number;
+  limit: number;
+  utilization: number; // Percent of limit used (100 = at limit)
+  breached: boolean;
+}
+
+/** LiquidityCheck is the outcome of the liquidity filter for one signal */
+export interface LiquidityCheck {
+  passed: boolean;
+  quoteVolume24h: number; // 0 when the ticker was unavailable
+  minQuoteVolume24h: number;
+  candleVolume: number;
+  volumePercentile: number; // Share of recent candles with less volume, 0-100
+  minVolumePercentile: number;
+  reason?: string;
+}
+
+/** LoginRequest represents a login request */
+export interface LoginRequest {
+  email: string;
+  password: string;
+}
+
+/** LoginResponse represents a successful login response */
+export interface LoginResponse {
+  user: UserResponse | null;
+  access_token: string;
+  refresh_token: string;
+  expires_in: number; // seconds
+  accounts: (TradingAccountResponse | null)[];
+}
+
+/** MACDData represents MACD indicator values */
+export interface MACDData {
+  macd: number;
+  signal: number;
+  histogram: number;
+}
+
+/** MACDValue represents MACD values */
+export interface MACDValue {
+  macd: number;
+  signal: number;
+  histogram: number;
+}
+
+/** MaintenanceWindowRequest represents exchange downtime in a backtest */
+export interface MaintenanceWindowRequest {
+  start: string; // RFC 3339
+  end: string; // RFC 3339
+  reason?: string;
+}
+
+/** ModeRequest represents trading mode request */
+export interface ModeRequest {
+  mode: string;
+}
+
+/** ModeResponse represents trading mode response */
+export interface ModeResponse {
+  mode: string;
+}
+
+/** ModeUpdate represents a trading mode transition message */
+export interface ModeUpdate {
+  event: string; // "pending" or "switched"
+  from: string;
+  to: string;
+  window?: string;
+  policy: string;
+  reason?: string;
+  effectiveAt: string;
+}
+
+/** ModelsTradingMode represents the trading mode */
+export type ModelsTradingMode = string;
+
+/** OptimizationRunData represents one backtest of a parameter search */
+export interface OptimizationRunData {
+  rank: number;
+  params: Record<string, number>;
+  score: number;
+  qualified: boolean;
+  metrics?: BacktestMetricsData;
+  error?: string;
+}
+
+/**
+ * OptimizeRequest runs a parameter search over the backtest described by the
+ * embedded request
+ */
+export interface OptimizeRequest extends BacktestRequest {
+  params: Record<string, ParamRange>; // Parameter ranges keyed "<strategy>.<Field>" or "indicators.<Field>", e.g. {"trend_following.FastMAPeriod": {"min": 5, "max": 20, "step": 5}}
+  mode: string; // grid (default) or random
+  samples: number; // Random mode combinations
+  seed: number; // Random mode seed, to repeat a search
+  workers: number; // Parallel backtests, capped at the CPU count
+  objective: string; // sharpe (default), sortino, calmar, net_profit, profit_factor
+  minTrades: number; // Runs with fewer trades rank last
+  top: number; // Ranked runs returned
+}
+
+/** OptimizeResponse holds the ranked runs of a parameter search */
+export interface OptimizeResponse {
+  objective: string;
+  mode: string;
+  seed?: number;
+  combinations: number;
+  runs: number;
+  failed: number;
+  best?: OptimizationRunData;
+  results: OptimizationRunData[];
+  executionTime: string;
+  recorded?: string[]; // Strategies whose parameters of the best run were recorded as the optimized values drift is measured against
+}
+
+/**
+ * OptimizedParams are the best parameters the optimizer found for a strategy,
+ * with the search that found them
+ */
+export interface OptimizedParams {
+  strategy: string;
+  params: Record<string, number>; // By config field, e.g. FastMAPeriod
+  ranges: Record<string, ParamRange>; // Searched ranges, re-run on recent data
+  objective: string;
+  mode: string;
+  samples?: number;
+  minTrades?: number;
+  score: number;
+  symbol: string;
+  timeframe: string;
+  from: string;
+  to: string;
+  initialCapital: number;
+  riskPerTrade: number;
+  recordedAt: string;
+}
+
+/** OrchestratorTradingMode represents the trading mode */
+export type OrchestratorTradingMode = number;
+
+/**
+ * OrderBookView is the top of the local order book with optional fill estimates
+ * for a quantity
+ */
+export interface OrderBookView extends Quote {
+  bids: Level[];
+  asks: Level[];
+  buy?: Fill; // Estimated market buy of the quantity asked for
+  sell?: Fill; // Estimated market sell of the quantity asked for
+}
+
+/** OrderData represents order data for API */
+export interface OrderData {
+  id: string;
+  clientId?: string;
+  symbol: string;
+  side: string;
+  type: string;
+  quantity: number;
+  price: number;
+  stopPrice?: number;
+  status: string;
+  filledQuantity: number;
+  avgFillPrice: number;
+  strategy?: string;
+  createdAt: number;
+  updatedAt: number;
+  transitions: OrderTransitionData[];
+}
+
+/** OrderIntent is a durable record of an entry order moving through execution */
+export interface OrderIntent {
+  id: number;
+  clientOrderId: string;
+  symbol: string;
+  side: string;
+  quantity: number;
+  strategy: string;
+  signalPrice: number;
+  stopLoss: number;
+  takeProfit: number;
+  mode?: string; // Trading mode of the executor it was sent to
+  state: string;
+  orderId?: string;
+  positionId?: number;
+  fillPrice?: number;
+  filledQuantity?: number;
+  error?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+/** OrderSide represents order side */
+export type OrderSide = string;
+
+/** OrderTransitionData represents one status change of an order */
+export interface OrderTransitionData {
+  from?: string; // Empty for the first status
+  to: string;
+  reason?: string;
+  at: number;
+}
+
+/** ParamDeviation compares a configured parameter with a reference value */
+export interface ParamDeviation {
+  field: string;
+  configured: number;
+  reference: number;
+  deviation: number; // Relative to the reference; absolute when it is 0
+  drifted: boolean;
+}
+
+/**
+ * ParamDriftReport compares a strategy's configured parameters with the
+ * optimized and, when re-optimization ran, suggested ones
+ */
+export interface ParamDriftReport {
+  strategy: string;
+  optimized: OptimizedParams | null;
+  deviations: ParamDeviation[]; // Configured values against the optimized ones
+  drifted: boolean; // Configured values diverge from the optimized ones
+  reoptimized?: Reoptimization;
+  error?: string; // Why the configured values could not be read
+}
+
+/**
+ * ParamRange lists the values a parameter takes, either explicitly or from Min
+ * to Max in increments of Step
+ */
+export interface ParamRange {
+  values?: number[];
+  min?: number;
+  max?: number;
+  step?: number;
+}
+
+/**
+ * ParamTuningResult compares the latest parameter version with trades against
+ * the one before it
+ */
+export interface ParamTuningResult {
+  from: number;
+  to: number;
+  avgPnlChange: number;
+  winRateChange: number;
+  profitFactorChange: number;
+  improved: boolean; // Average P&L per trade went up
+}
+
+/**
+ * ParamVersionPerformance is the realized performance of trades entered under
+ * one parameter set version. Version 0 is the configuration the bot started
+ * with before any recorded settings change.
+ */
+export interface ParamVersionPerformance {
+  version: number;
+  section?: string; // Settings section whose change started the version
+  changedBy?: string;
+  changedAt?: string;
+  current: boolean;
+  trades: number; // Closing trades with realized P&L
+  wins: number;
+  losses: number;
+  winRate: number;
+  netPnl: number;
+  grossProfit: number;
+  grossLoss: number;
+  profitFactor: number;
+  avgPnl: number;
+  commission: number;
+  firstTrade?: string;
+  lastTrade?: string;
+}
+
+/** ParamVersionReport breaks realized performance down by parameter version */
+export interface ParamVersionReport {
+  currentVersion: number;
+  strategy?: string;
+  versions: ParamVersionPerformance[]; // Oldest first
+  lastTuning?: ParamTuningResult;
+}
+
+/** PasswordChangeRequest represents a password change request */
+export interface PasswordChangeRequest {
+  current_password: string;
+  new_password: string;
+}
+
+/** PasswordResetConfirm represents password reset confirmation */
+export interface PasswordResetConfirm {
+  token: string;
+  new_password: string;
+}
+
+/** PasswordResetRequest represents a password reset request */
+export interface PasswordResetRequest {
+  email: string;
+}
+
+/** PerformanceData represents performance metrics */
+export interface PerformanceData {
+  totalReturn: number;
+  dailyReturn: number;
+  weeklyReturn: number;
+  monthlyReturn: number;
+  sharpeRatio: number;
+  maxDrawdown: number;
+  winRate: number;
+  profitFactor: number;
+  totalTrades: number;
+  winningTrades: number;
+  losingTrades: number;
+  turnover: number;
+  tradesPerMonth: number;
+  avgExposureTime: string;
+  timeInMarket: number;
+  drawdownDuration: string; // Time below the equity high-water mark so far
+  maxDrawdownDuration: string;
+  longestFlatPeriod: string;
+  ulcerIndex: number;
+}
+
+/** PlaceOrderRequest represents order placement request */
+export interface PlaceOrderRequest {
+  symbol: string;
+  side: string;
+  type: string;
+  quantity: number;
+  price?: number;
+  stopPrice?: number;
+}
+
+/** PlaceOrderResponse represents order placement response */
+export interface PlaceOrderResponse {
+  orderId: string;
+  status: string;
+  message?: string;
+}
+
+/** PnLPeriodStatus is the realized P&L of the current calendar day and week */
+export interface PnLPeriodStatus {
+  timezone: string;
+  weekStarts: string;
+  dayStart: string;
+  weekStart: string;
+  nextDay: string;
+  nextWeek: string;
+  dailyPnl: number;
+  weeklyPnl: number;
+}
+
+/** PositionData represents a position for API response */
+export interface PositionData {
+  id: number;
+  symbol: string;
+  side: string;
+  quantity: number;
+  entryPrice: number;
+  currentPrice: number;
+  stopLoss: number;
+  takeProfit: number;
+  unrealizedPnL: number;
+  unrealizedPct: number;
+  strategy: string;
+  openTime: string;
+  duration: string;
+}
+
+/** PositionHistoryData represents a persisted position */
+export interface PositionHistoryData {
+  id: number;
+  symbol: string;
+  side: string;
+  entryPrice: number;
+  quantity: number;
+  currentPrice: number; // Exit price once closed
+  unrealizedPnl: number;
+  realizedPnl: number;
+  stopLoss: number;
+  takeProfit: number;
+  strategy: string;
+  status: string;
+  openedAt: number;
+  closedAt?: number;
+  session: string; // Trading session of the entry
+  pnl?: PositionPnLData;
+}
+
+/**
+ * PositionPnLData breaks a position's P&L into price move, fees, funding and
+ * slippage. Costs are positive; slippage is included in grossPnl.
+ */
+export interface PositionPnLData {
+  grossPnl: number;
+  entryCommission: number;
+  exitCommission: number;
+  fundingCost: number;
+  entrySlippage: number;
+  exitSlippage: number;
+  netPnl: number;
+}
+
+/** PositionSide represents position side */
+export type PositionSide = string;
+
+/** PositionUpdate represents a position update message */
+export interface PositionUpdate {
+  positionId: number;
+  symbol: string;
+  side: PositionSide;
+  quantity: number;
+  entryPrice: number;
+  currentPrice: number;
+  stopLoss: number;
+  takeProfit: number;
+  unrealizedPnL: number;
+  realizedPnL: number;
+  strategy: string;
+  openTime: string;
+  eventType: string; // opened, closed, updated
+}
+
+/** PrecomputeRequest selects what a precompute run computes */
+export interface PrecomputeRequest {
+  timeframe: string;
+  columns: string[]; // Empty = configured columns
+  full: boolean; // Rewrite all values, not only new bars
+}
+
+/** PriceArbiterStatus is the price the bot acts on and where it came from */
+export interface PriceArbiterStatus {
+  symbol: string;
+  price: number;
+  at: string;
+  source: PriceSource;
+  tradeStaleAfter: string;
+  sources: Record<string, PriceSourceStatus>;
+}
+
+/** PriceSource is a market data stream the bot takes prices from */
+export type PriceSource = string;
+
+/**
+ * PriceSourceStatus is the last price a stream reported and how many of its
+ * prices were taken
+ */
+export interface PriceSourceStatus {
+  price: number;
+  at: string; // Exchange time
+  receivedAt: string; // Local time
+  accepted: number;
+  rejected: number; // Out of order, or superseded by trades
+}
+
+/**
+ * PriceUpdate represents a real-time price update (lightweight for high
+ * frequency)
+ */
+export interface PriceUpdate {
+  symbol: string;
+  price: number;
+  timestamp: string;
+}
+
+/** Quote is the top of an order book */
+export interface Quote {
+  symbol: string;
+  bid: number;
+  bidQty: number;
+  ask: number;
+  askQty: number;
+  mid: number;
+  spread: number;
+  spreadBps: number; // Spread relative to the mid
+  lastUpdateId: number;
+  updatedAt: string;
+  synced: boolean;
+}
+
+/** ReconciliationEntry pairs the live and shadow paper execution of one signal */
+export interface ReconciliationEntry {
+  id: number;
+  timestamp: string;
+  strategy: string;
+  side: OrderSide;
+  signalPrice: number;
+  live: ExecutionLeg;
+  paper: ExecutionLeg;
+  slippageDiffBps: number; // Live minus paper entry slippage
+  pnlDivergence: number; // Live minus paper net P&L
+  reconciled: boolean; // Both legs closed
+}
+
+/** ReconciliationReport compares live fills with the shadow paper account */
+export interface ReconciliationReport {
+  summary: ReconciliationSummary;
+  entries: (ReconciliationEntry | null)[]; // Newest first
+}
+
+/** ReconciliationSummary aggregates divergence across reconciled trades */
+export interface ReconciliationSummary {
+  trades: number;
+  reconciled: number;
+  avgLiveSlippageBps: number;
+  avgPaperSlippageBps: number;
+  avgSlippageDiffBps: number;
+  liveCommission: number;
+  paperCommission: number;
+  liveNetPnl: number;
+  paperNetPnl: number;
+  totalPnlDivergence: number;
+  avgPnlDivergence: number;
+}
+
+/** RefreshTokenRequest represents a token refresh request */
+export interface RefreshTokenRequest {
+  refresh_token: string;
+}
+
+/** RefreshTokenResponse represents a token refresh response */
+export interface RefreshTokenResponse {
+  access_token: string;
+  refresh_token: string;
+  expires_in: number; // seconds
+}
+
+/** RegimeInfo represents market regime information */
+export interface RegimeInfo {
+  current: string;
+  confidence: number;
+  trend: string;
+  trendStrength: string;
+  adx: number;
+  rsi: number;
+  volatility: string;
+  description: string;
+  session: string; // Current trading session
+  routing: boolean; // Strategies are routed by regime
+  routes?: Record<string, string[]>; // Strategies per regime; regimes not listed trade all
+  strategies: StrategyEligibility[]; // Which strategies trade in the current regime
+}
+
+/**
+ * RegimeMatrix cross-tabulates closed positions by strategy and the market
+ * regime detected when they were entered
+ */
+export interface RegimeMatrix {
+  timeframe: string;
+  from: string;
+  to: string;
+  current: string; // Regime detected on the last candle
+  regimes: string[]; // Columns
+  strategies: string[]; // Rows
+  cells: RegimePerformance[]; // Strategy and regime pairs with trades
+  totals: RegimePerformance[]; // By regime, across strategies
+  best: Record<string, string>; // By regime, the strategy with the highest net PnL over enough trades
+  unclassified: number; // Positions entered without enough candles stored before them
+}
+
+/**
+ * RegimePerformance is the performance of positions entered in one regime, by
+ * one strategy or, with Strategy empty, by all of them
+ */
+export interface RegimePerformance {
+  strategy?: string;
+  regime: string;
+  trades: number;
+  wins: number;
+  losses: number;
+  winRate: number;
+  netPnl: number;
+  avgPnl: number;
+  profitFactor: number;
+}
+
+/** RegisterRequest represents a registration request */
+export interface RegisterRequest {
+  email: string;
+  password: string;
+  full_name: string;
+  account_type: AccountType; // Account setup (demo or live)
+  account_name: string;
+  demo_initial_capital?: number; // Demo account fields (required if account_type = demo)
+  binance_api_key?: string; // Live account fields (required if account_type = live)
+  binance_secret_key?: string;
+  binance_testnet: boolean;
+}
+
+/** Rejection records why a candidate was left out of the ranking */
+export interface Rejection {
+  symbol: string;
+  reason: string;
+}
+
+/** ReloadStatus describes the configuration changes applied since start */
+export interface ReloadStatus {
+  path: string;
+  watching: boolean;
+  version: number; // Changes applied since start
+  lastSource?: string; // "file" or "api"
+  lastAppliedAt?: string;
+  lastError?: string; // Of the last change rejected, which was rolled back
+  lastErrorAt?: string;
+  restartRequired: string[]; // Keys changed since start that apply only on restart, e.g. "trading.symbol"
+}
+
+/**
+ * Reoptimization is the outcome of re-running a strategy's recorded search on
+ * recent data
+ */
+export interface Reoptimization {
+  params: Record<string, number>;
+  score: number; // Objective of the suggested parameters
+  configuredScore: number; // Objective of the configured ones on the same data
+  from: string;
+  to: string;
+  ranAt: string;
+  deviations: ParamDeviation[]; // Configured values against the suggested ones
+  drifted: boolean; // Suggested optima differ materially and score better
+}
+
+/** Report is the outcome of a scan */
+export interface Report {
+  scannedAt: string;
+  timeframe: string;
+  pairs: number; // Trading pairs in the quote asset
+  candidates: number; // Pairs passing the volume filter that were scored
+  results: Result[];
+  rejected?: Rejection[];
+}
+
+/**
+ * ReportLocale is how generated reports and exports (CSV, HTML) format numbers,
+ * times and amounts for a user. API JSON is never localized.
+ */
+export interface ReportLocale {
+  locale: string; // Preset the fields default to, e.g. "de-DE"
+  decimalSeparator: string; // e.g. "," in de-DE
+  groupSeparator: string; // Thousands separator; empty = none
+  dateFormat: string; // Go time layout, e.g. "02.01.2006 15:04"
+  timezone: string; // IANA name; empty = UTC
+  currencySymbol: string; // Prefixed or suffixed to quote currency amounts
+  currencySuffix: boolean; // Symbol after the amount, e.g. "1.234,50 $"
+}
+
+/** ReportLocaleResponse is a user's report locale and the presets available */
+export interface ReportLocaleResponse extends ReportLocale {
+  locales: string[];
+}
+
+/** RequestWeight reports REST request weight usage per Binance client */
+export interface RequestWeight {
+  market?: WeightStats; // Candles, tickers and backtest data
+  trading?: WeightStats; // Live or futures executor
+}
+
+/** Result is one ranked symbol */
+export interface Result {
+  rank: number;
+  symbol: string;
+  price: number;
+  quoteVolume: number; // 24h, in the quote asset
+  priceChange: number; // 24h, percent
+  atrPercent: number; // On the scan timeframe
+  regime: string;
+  regimeConfidence: number;
+  adx: number;
+  bestStrategy: string;
+  score: number; // Best strategy fit
+  strategies: StrategyFit[];
+}
+
+/** RiskConfigResponse represents risk configuration */
+export interface RiskConfigResponse {
+  maxPositionSize: number;
+  maxPositionValue: number;
+  maxRiskPerTrade: number;
+  minRiskRewardRatio: number;
+  maxDailyLoss: number;
+  maxWeeklyLoss: number;
+  maxTotalDrawdown: number;
+  highWaterMarkMode: string;
+  maxOpenPositions: number;
+  maxLeverage: number;
+  enableCircuitBreaker: boolean;
+  consecutiveLossLimit: number;
+  adjustForVolatility: boolean;
+  tradingHoursOnly: boolean;
+}
+
+/** RiskEvent represents a risk-related event */
+export interface RiskEvent {
+  Type: RiskEventType;
+  Level: RiskLevel;
+  Message: string;
+  Details: Record<string, unknown>;
+  Timestamp: string;
+  Handled: boolean;
+}
+
+/** RiskEventResponse represents a risk event */
+export interface RiskEventResponse {
+  type: string;
+  level: string;
+  message: string;
+  timestamp: string;
+  handled: boolean;
+}
+
+/** RiskEventType represents types of risk events */
+export type RiskEventType = number;
+
+/** RiskLevel represents risk severity */
+export type RiskLevel = number;
+
+/** RiskLimitsResponse represents the consolidated risk limits view */
+export interface RiskLimitsResponse {
+  level: string;
+  isHalted: boolean;
+  haltReason?: string;
+  haltUntil?: string;
+  isWithinLimits: boolean;
+  breaches: string[];
+  limits: LimitUtilization[];
+  drawdown: DrawdownResponse;
+  recentEvents: RiskEventResponse[];
+  timestamp: string;
+}
+
+/**
+ * RiskReport is the risk digest of one calendar day: what the bot wanted to
+ * trade, what it blocked and why, and how close it ran to its limits
+ */
+export interface RiskReport {
+  day: string; // YYYY-MM-DD in the P&L timezone
+  timezone: string;
+  from: string; // Start of the tally; after the day start when the bot restarted mid-day
+  to: string;
+  signalsGenerated: number;
+  signalsApproved: number;
+  signalsExecuted: number;
+  signalsRejected: number;
+  rejections: Record<string, number>; // Rejected signals by stage and reason
+  nearMisses: Record<string, number>; // Approved signals by risk warning, e.g. "Approaching daily loss limit"
+  limitPeaks: LimitPeaks;
+  riskEvents: Record<string, number>; // Risk events by type
+  circuitBreakers: CircuitBreakerEvent[];
+}
+
+/** RiskReports is the digest of the day in progress and the last closed days */
+export interface RiskReports {
+  current: RiskReport;
+  reports: RiskReport[]; // Most recent first
+}
+
+/** RiskSettings represents risk management configuration */
+export interface RiskSettings {
+  maxPositionSize: number; // Max position as % of equity (0.1 = 10%)
+  maxRiskPerTrade: number; // Max risk per trade (0.02 = 2%)
+  maxDailyLoss: number; // Max daily loss (0.05 = 5%)
+  maxWeeklyLoss: number; // Max weekly loss (0.1 = 10%)
+  maxDrawdown: number; // Max total drawdown (0.2 = 20%)
+  highWaterMarkMode: string; // Drawdown peak: trailing, monthly or deposit_adjusted
+  maxOpenPositions: number; // Max concurrent positions
+  maxLeverage: number; // Max leverage (1.0 = no leverage)
+  minRiskRewardRatio: number; // Minimum R/R ratio
+  enableCircuitBreaker: boolean; // Enable circuit breaker
+  consecutiveLossLimit: number; // Halt after N losses
+  haltDurationHours: number; // Circuit breaker halt duration
+}
+
+/** RiskStatusResponse represents risk status */
+export interface RiskStatusResponse {
+  level: string;
+  currentDrawdown: number;
+  maxDrawdown: number;
+  dailyLossUsed: number;
+  dailyLossLimit: number;
+  dailyLossPercent: number;
+  weeklyLossUsed: number;
+  weeklyLossLimit: number;
+  openPositions: number;
+  maxPositions: number;
+  isHalted: boolean;
+  haltReason?: string;
+  isWithinLimits: boolean;
+  warnings?: string[];
+  periods?: PnLPeriodStatus; // Calendar day and week the loss limits cover
+}
+
+/** RiskUpdate represents a risk update message */
+export interface RiskUpdate {
+  level: RiskLevel;
+  drawdown: number;
+  maxDrawdown: number;
+  dailyLossUsed: number;
+  dailyLossLimit: number;
+  weeklyLossUsed: number;
+  weeklyLossLimit: number;
+  isHalted: boolean;
+  haltReason?: string;
+  events?: RiskEvent[];
+}
+
+/** SandboxMetrics are the headline results of a sandbox backtest */
+export interface SandboxMetrics {
+  totalReturn: number;
+  netProfit: number;
+  endingCapital: number;
+  maxDrawdown: number;
+  sharpeRatio: number;
+  totalTrades: number;
+  winRate: number;
+  profitFactor: number;
+  expectancy: number;
+}
+
+/** SandboxRequest is an inline strategy script and the range to test it over */
+export interface SandboxRequest {
+  script: string; // Lua source defining analyze(data), as in strategies/*.lua
+  symbol: string;
+  timeframe: string;
+  startDate: string; // YYYY-MM-DD; default 30 days ago
+  endDate: string; // YYYY-MM-DD; default today
+  initialCapital: number;
+  riskPerTrade: number;
+}
+
+/** SandboxResponse summarizes a sandbox backtest */
+export interface SandboxResponse {
+  strategy: string;
+  bars: number;
+  metrics: SandboxMetrics;
+  scriptError?: string; // Last runtime error raised by the script
+  disabled?: boolean; // The script ran out of time or memory and stopped trading
+  executionTime: string;
+}
+
+/** ScheduleStatus is the current state of the mode schedule */
+export interface ScheduleStatus {
+  enabled: boolean;
+  timezone?: string;
+  mode: string;
+  current?: ScheduledMode;
+  window?: string;
+  next?: ScheduledTransition;
+}
+
+/** ScheduledMode is the trading mode requested by a schedule */
+export type ScheduledMode = string;
+
+/** ScheduledTransition describes an upcoming mode switch */
+export interface ScheduledTransition {
+  at: string;
+  from: ScheduledMode;
+  to: ScheduledMode;
+  window?: string;
+  policy: CarryPolicy;
+}
+
+/** ScoreBreakdown explains a combined score against the entry thresholds */
+export interface ScoreBreakdown {
+  regime: string;
+  regimeConfidence: number;
+  conflictMode: string;
+  direction: string;
+  score: number;
+  confidence: number;
+  minScore: number;
+  minConfidence: number;
+  scoreProgress: number; // Score / minScore; 1 meets the threshold
+  confidenceProgress: number; // Confidence / minConfidence
+  shouldTrade: boolean;
+  bestStrategy?: string;
+  longSignals: number;
+  shortSignals: number;
+  hasConflict: boolean;
+  conflictLevel: number;
+  strategies: StrategyScoreBreakdown[];
+}
+
+/** ScoreUpdate is the scorer's breakdown of the latest analysis */
+export interface ScoreUpdate extends ScoreBreakdown {
+  symbol: string;
+  timeframe: string;
+  timestamp: string; // When the analysis ran
+  action: string; // Recommendation after regime filters
+  reason?: string;
+}
+
+/** SessionPerformance is the trading and market activity of one session */
+export interface SessionPerformance {
+  session: TradingSession;
+  trades: number; // Closed positions, attributed to the session they were entered in
+  wins: number;
+  losses: number;
+  winRate: number;
+  netPnl: number;
+  avgPnl: number;
+  profitFactor: number;
+  candles: number; // Primary timeframe candles opened in the session
+  volatility: number; // Standard deviation of close-to-close returns
+  avgRange: number; // Average high-low range as a fraction of the open
+  avgVolume: number; // Average candle volume
+  volumeShare: number; // Share of the period's volume
+}
+
+/** SessionReport breaks performance down by trading session */
+export interface SessionReport {
+  strategy?: string;
+  timeframe: string;
+  from: string;
+  to: string;
+  current: string;
+  sessions: SessionPerformance[];
+}
+
+/** SettingsChangeResponse represents an entry of the settings audit trail */
+export interface SettingsChangeResponse {
+  versionId: number;
+  section: string;
+  action: string;
+  oldValue?: Value;
+  newValue: Value;
+  changedBy: string;
+  rollbackOf?: number;
+  changedAt: string;
+}
+
+/**
+ * ShareBundle is a shareable record of trading results. It holds no order,
+ * position or account identifiers, and amounts are rescaled to a nominal
+ * starting equity; prices and times are kept so results can be charted.
+ */
+export interface ShareBundle {
+  format: string;
+  version: number;
+  name?: string;
+  symbol: string;
+  timeframe: string;
+  configHash?: string; // Fingerprint of the strategy settings
+  from: string;
+  to: string;
+  startingEquity: number;
+  summary: ShareSummary;
+  trades: SharedTrade[];
+  equityCurve: SharedEquityPoint[];
+  candles?: SharedCandle[];
+  createdAt: string;
+}
+
+/** ShareSummary is the headline performance of a bundle */
+export interface ShareSummary {
+  trades: number;
+  wins: number;
+  losses: number;
+  winRate: number;
+  netPnl: number;
+  returnPct: number;
+  maxDrawdownPct: number;
+  profitFactor: number;
+}
+
+/** SharedBundle is a result bundle shared by another user and imported here */
+export interface SharedBundle {
+  id: string; // Content hash of the bundle
+  name: string;
+  symbol: string;
+  timeframe: string;
+  config_hash: string;
+  trades: number;
+  return_pct: number;
+  period_start: string;
+  period_end: string;
+  bundle?: Value; // Only loaded by Get
+  imported_by: string;
+  imported_at: string;
+}
+
+/** SharedCandle is a primary timeframe candle of a bundle */
+export interface SharedCandle {
+  time: string; // Open time
+  open: number;
+  high: number;
+  low: number;
+  close: number;
+  volume: number;
+}
+
+/** SharedEquityPoint is a point of a bundle's equity curve */
+export interface SharedEquityPoint {
+  time: string;
+  equity: number; // Rescaled
+}
+
+/** SharedTrade is a closed position in a bundle */
+export interface SharedTrade {
+  strategy: string;
+  side: string;
+  entryPrice: number;
+  exitPrice: number;
+  quantity: number; // Rescaled
+  pnl: number; // Rescaled
+  returnPct: number;
+  openedAt: string;
+  closedAt: string;
+}
+
+/** Signal represents a trading signal */
+export interface Signal {
+  type: SignalType;
+  direction: string;
+  strength: number; // 0-1 signal strength
+  price: number; // Current price
+  stopLoss: number; // Suggested stop loss
+  takeProfit: number; // Suggested take profit
+  confidence: number; // 0-1 confidence level
+  reason: string; // Signal reason
+  strategy: string; // Strategy that generated signal
+  timestamp: string;
+  timeframe: string;
+  symbol: string;
+  indicators: SignalIndicators;
+  sizeFactor?: number; // Fraction of the usual size to trade; 0 = full size
+}
+
+/** SignalIndicators holds indicator values at signal time */
+export interface SignalIndicators {
+  RSI: number;
+  MACD: number;
+  MACDSignal: number;
+  ADX: number;
+  ATR: number;
+  BBPercentB: number;
+  Volume: number;
+}
+
+/** SignalInfo represents a trading signal */
+export interface SignalInfo {
+  timestamp: string;
+  strategy: string;
+  symbol: string;
+  direction: string;
+  entryPrice: number;
+  stopLoss: number;
+  takeProfit: number;
+  confidence: number;
+  reason: string;
+  approved: boolean;
+}
+
+/** SignalRecord stores a signal with its approval status for history */
+export interface SignalRecord {
+  signal: Signal | null;
+  approved: boolean;
+  reason?: string;
+  stopLossEnforcement?: StopLossEnforcement; // Set when the signal had no stop loss
+  liquidity?: LiquidityCheck; // Set when the liquidity filter is on
+  confluence?: ConfluenceCheck; // Set when confluence applies to the strategy
+  receivedAt: string;
+}
+
+/**
+ * SignalThrottleStats counts a strategy's entry signals let through and dropped
+ * by its cooldown
+ */
+export interface SignalThrottleStats {
+  strategy: string;
+  cooldown: number; // Candles between entry signals; 0 = unthrottled
+  passed: number;
+  throttled: number;
+  lastSignalAt?: string; // Close time of the candle of the last signal let through
+  lastThrottledAt?: string;
+}
+
+/** SignalType represents type of signal */
+export type SignalType = number;
+
+/** SignalUpdate represents a signal update message */
+export interface SignalUpdate {
+  signal: Signal | null;
+  approved: boolean;
+  rejectedBy?: string;
+  reason?: string;
+  liquidity?: LiquidityCheck; // Set when the liquidity filter is on
+  confluence?: ConfluenceCheck; // Set when confluence applies to the strategy
+}
+
+/** StageLatency summarizes the timings of one pipeline stage */
+export interface StageLatency {
+  stage: string;
+  budgetMs: number; // 0 = not enforced
+  count: number;
+  breaches: number;
+  lastMs: number;
+  avgMs: number;
+  maxMs: number;
+}
+
+/**
+ * StateSnapshot is the trading state at a version. Full snapshots carry every
+ * field; deltas only the fields changed after the requested version.
+ */
+export interface StateSnapshot {
+  version: number;
+  full: boolean;
+  state: Record<string, Value>; // TradingState fields by name
+}
+
+/** StateUpdate represents a state update message */
+export interface StateUpdate {
+  state: TradingState | null;
+  summary: AccountSummary | null;
+}
+
+/** StopLossEnforcement records how the stop-loss policy treated a signal */
+export interface StopLossEnforcement {
+  policy: StopLossPolicy;
+  action: string; // "none", "skipped", "rejected" or "derived"
+  stopLoss?: number;
+  detail?: string;
+}
+
+/** StopLossPolicy determines what happens to an entry signal without a stop loss */
+export type StopLossPolicy = string;
+
+/** StrategyAllocation is the capital assigned to a single strategy */
+export interface StrategyAllocation {
+  strategy: string;
+  weight: number;
+  capital: number;
+  used: number;
+  buyingPower: number;
+}
+
+/** StrategyConfig represents individual strategy config */
+export interface StrategyConfig {
+  name: string; // Strategy name
+  enabled: boolean; // Is strategy enabled
+  config: Record<string, unknown>; // Strategy-specific config
+  signalCooldown?: number; // Primary candles between entry signals (0 = unthrottled); omit to keep
+}
+
+/**
+ * StrategyEligibility describes whether a strategy takes part in scoring under
+ * a given regime
+ */
+export interface StrategyEligibility {
+  strategy: string;
+  enabled: boolean;
+  weight: number; // Regime-adjusted weight
+  disallowedRegimes: string[];
+  blocked: boolean; // Regime is disallowed for this strategy
+  routedOut: boolean; // Regime is routed to other strategies
+  eligible: boolean;
+}
+
+/** StrategyFit scores how well a strategy suits a symbol's current regime */
+export interface StrategyFit {
+  strategy: string;
+  weight: number; // Regime-adjusted strategy weight
+  fit: number; // Weight scaled by regime confidence
+}
+
+/** StrategyInfo represents strategy information */
+export interface StrategyInfo {
+  name: string;
+  description: string;
+  enabled: boolean;
+  config: Record<string, unknown>;
+  performance?: StrategyPerformance;
+  signals?: SignalThrottleStats;
+  spikes?: VolatilityThrottleStats; // Entries signaled on volatility spikes
+  confirmations?: EntryConfirmationStats; // Entries held for a confirmation candle
+}
+
+/** StrategyPerformance represents strategy performance metrics */
+export interface StrategyPerformance {
+  totalTrades: number;
+  winRate: number;
+  profitFactor: number;
+  netProfit: number;
+  contribution: number;
+}
+
+/** StrategyPreviewResponse shows which strategies take part in scoring */
+export interface StrategyPreviewResponse {
+  regime: string;
+  strategies: StrategyEligibility[];
+}
+
+/** StrategyScoreBreakdown describes one strategy's part in a combined score */
+export interface StrategyScoreBreakdown {
+  strategy: string;
+  status: string;
+  baseWeight: number;
+  regimeMultiplier: number; // 1 when regime weights are off
+  weight: number; // Base weight x regime multiplier
+  direction?: string;
+  rawStrength: number; // Signal strength before weighting
+  score: number; // Weighted strength
+  confidence: number; // Weighted confidence
+  signals: number;
+}
+
+/** StrategySettings represents strategy configuration */
+export interface StrategySettings {
+  enabled: StrategyConfig[]; // Enabled strategies with configs
+}
+
+/**
+ * StrategyStanding is one strategy's realized performance over the leaderboard
+ * window
+ */
+export interface StrategyStanding {
+  rank: number;
+  strategy: string;
+  enabled: boolean; // Running now; false for strategies that only have history
+  trades: number;
+  wins: number;
+  losses: number;
+  winRate: number;
+  netPnl: number;
+  expectancy: number; // Average P&L per trade
+  avgWin: number;
+  avgLoss: number; // Positive
+  profitFactor: number;
+  sharpe: number; // Annualized, from daily realized P&L
+  maxDrawdown: number; // Largest fall of cumulative realized P&L from its peak
+  lastTrade?: string;
+}
+
+/** StrategyStateUpdate is broadcast when a strategy is enabled or disabled */
+export interface StrategyStateUpdate {
+  strategy: string;
+  enabled: boolean;
+  changedBy?: string;
+}
+
+/** StreamBandwidth reports traffic on the exchange WebSocket connections */
+export interface StreamBandwidth {
+  market?: WSStats;
+  userData?: WSStats; // Live trading only
+}
+
+/** StreamStats holds traffic counters for one stream */
+export interface StreamStats {
+  stream: string;
+  messages: number;
+  bytes: number; // Payload bytes after decompression
+  messagesPerSec: number;
+  bytesPerSec: number;
+  lastMessage: string;
+}
+
+/** StreamSubscription is a Binance stream the WebSocket client subscribes to */
+export interface StreamSubscription {
+  stream: string;
+  core: boolean; // Subscribed by the bot itself; cannot be removed
+  addedBy?: string;
+  addedAt?: string;
+  messages: number;
+  lastMessage?: string;
+}
+
+/** SubBalanceStatus describes the bot's capital against the whole account */
+export interface SubBalanceStatus {
+  enabled: boolean;
+  allocated?: number;
+  realizedPnl?: number;
+  unrealizedPnl?: number;
+  equity: number; // What equity, drawdown and sizing use
+  accountEquity: number;
+  unallocated?: number;
+  since?: string;
+  transfers?: CapitalTransfer[];
+}
+
+/** SubscriptionChange is the data of subscribe and unsubscribe messages */
+export interface SubscriptionChange {
+  types: string[]; // Message types, e.g. "trade"
+}
+
+/** SubscriptionRequest names a Binance stream to subscribe to */
+export interface SubscriptionRequest {
+  stream: string; // e.g. ethusdt@depth20@100ms
+}
+
+/** Subscriptions are the message types a client receives */
+export interface Subscriptions {
+  types: string[]; // Subscribed to; empty when all is set
+  all: boolean;
+  excluded: string[]; // Unsubscribed from while receiving all
+}
+
+/** SuperTrendValue represents SuperTrend values */
+export interface SuperTrendValue {
+  value: number;
+  direction: string; // UP or DOWN
+}
+
+/**
+ * SymbolConfig represents overrides for one symbol, merged over the global
+ * settings. Unset fields keep the global value.
+ */
+export interface SymbolConfig {
+  risk: SymbolRiskConfig;
+  strategies: SymbolStrategyConfig;
+}
+
+/** SymbolRiskConfig overrides risk and position sizing limits */
+export interface SymbolRiskConfig {
+  maxPositionSize?: number;
+  maxRiskPerTrade?: number;
+  maxLeverage?: number;
+  minRiskRewardRatio?: number;
+  stopLossPolicy?: string; // "reject", "atr" or "skip"
+  stopLossATRMultiplier?: number; // Derived stop distance in ATRs
+}
+
+/**
+ * SymbolRules are the exchange's trading rules for a symbol, which an order's
+ * price and quantity must meet
+ */
+export interface SymbolRules {
+  symbol: string;
+  market: string; // "spot" or "futures"
+  status: string;
+  baseAsset: string;
+  quoteAsset: string;
+  baseAssetPrecision: number;
+  quoteAssetPrecision: number;
+  orderTypes: string[];
+  tickSize: number; // Prices are multiples of it
+  minPrice: number;
+  maxPrice: number; // 0 = no limit
+  stepSize: number; // Quantities are multiples of it
+  minQty: number;
+  maxQty: number;
+  minNotional: number; // Minimum price × quantity
+  pricePrecision: number; // Decimals of the tick size
+  quantityPrecision: number; // Decimals of the step size
+  fetchedAt: string;
+}
+
+/** SymbolStrategyConfig overrides strategy selection and parameters */
+export interface SymbolStrategyConfig {
+  enabled?: string[]; // Replaces the global list
+  disallowedRegimes?: Record<string, string[]>; // Replaces the entries of the strategies listed
+  signalCooldown?: number;
+  signalCooldowns?: Record<string, number>; // Replaces the entries of the strategies listed
+}
+
+/** Tape is the aggregated order-flow tape */
+export interface Tape {
+  symbol: string;
+  bucket: string;
+  window: string;
+  largeTradeValue: number;
+  from: string;
+  to: string;
+  buyVolume: number;
+  sellVolume: number;
+  delta: number;
+  buckets: TapeBucket[]; // Oldest first; buckets without trades are omitted
+}
+
+/** TapeBucket aggregates the trades within one bucket of the tape */
+export interface TapeBucket {
+  start: string;
+  trades: number;
+  buyVolume: number; // Base quantity bought by takers
+  sellVolume: number; // Base quantity sold by takers
+  buyCount: number;
+  sellCount: number;
+  delta: number; // BuyVolume - SellVolume
+  vwap: number;
+  high: number;
+  low: number;
+  largeTrades?: TapeTrade[];
+}
+
+/** TapeTrade is a single trade on the tape */
+export interface TapeTrade {
+  id: number;
+  time: string;
+  price: number;
+  quantity: number;
+  value: number; // Quote value (USDT)
+  side: string; // Aggressor side: "buy" or "sell"
+}
+
+/** TickerData represents ticker data */
+export interface TickerData {
+  symbol: string;
+  price: number;
+  priceChange: number;
+  percentChange: number;
+  high24h: number;
+  low24h: number;
+  volume24h: number;
+  timestamp: number;
+}
+
+/** TimeframeHealth describes how fresh the candle feed is for one timeframe */
+export interface TimeframeHealth {
+  timeframe: string;
+  lastCandleTime: string; // Close time of the last closed candle
+  expectedNextClose: string; // When the next candle should close
+  behindBy: number; // Closed bars that have not arrived yet
+  stale: boolean;
+}
+
+/** TimeframeStance is one higher timeframe's trend against an entry */
+export interface TimeframeStance {
+  timeframe: string;
+  trend: string; // UP, DOWN or NEUTRAL
+  strength: string; // WEAK to VERY_STRONG
+  stance: string;
+}
+
+/** TradeData represents a trade for API response */
+export interface TradeData {
+  id: string;
+  symbol: string;
+  side: string;
+  type: string;
+  quantity: number;
+  price: number;
+  realizedPnL: number;
+  commission: number;
+  strategy: string;
+  timestamp: string;
+}
+
+/**
+ * TradeHistoryData represents a persisted fill. Partial fills of one order are
+ * merged at their average price.
+ */
+export interface TradeHistoryData {
+  id: number;
+  orderId: string;
+  symbol: string;
+  side: string;
+  type: string;
+  quantity: number;
+  price: number;
+  commission: number;
+  commissionAsset: string;
+  strategy: string;
+  executedAt: number;
+  session: string; // Trading session of the fill
+}
+
+/** TradeIdea is an approved signal waiting for human confirmation */
+export interface TradeIdea {
+  id: string;
+  status: string;
+  signal: Signal;
+  reasoning: IdeaReasoning;
+  createdAt: string;
+  expiresAt: string;
+  decidedAt?: string;
+  decidedBy?: string;
+  note?: string;
+  orderId?: string;
+  error?: string;
+  entryPrice?: number; // Price the order was sized at on approval
+}
+
+/** TradeUpdate represents a trade update message */
+export interface TradeUpdate {
+  tradeId: string;
+  orderId: string;
+  symbol: string;
+  side: OrderSide;
+  type: string;
+  quantity: number;
+  price: number;
+  commission: number;
+  realizedPnL: number;
+  strategy: string;
+  timestamp: string;
+}
+
+/**
+ * TradingAccountCreateRequest represents a request to create a new trading
+ * account
+ */
+export interface TradingAccountCreateRequest {
+  account_type: AccountType;
+  account_name: string;
+  demo_initial_capital?: number; // Demo account fields (required if account_type = demo)
+  binance_api_key?: string; // Live account fields (required if account_type = live)
+  binance_secret_key?: string;
+  binance_testnet: boolean;
+  trading_symbol: string; // Trading configuration
+  enabled_strategies: string[];
+}
+
+/** TradingAccountResponse is the public response for a trading account */
+export interface TradingAccountResponse {
+  id: string;
+  user_id: string;
+  account_type: AccountType;
+  account_name: string;
+  demo_initial_capital?: number; // Demo account fields
+  demo_current_balance?: number;
+  binance_api_key_masked?: string; // Only show last 4 chars
+  binance_testnet: boolean;
+  trading_symbol: string; // Trading configuration
+  trading_mode: ModelsTradingMode;
+  enabled_strategies: string[];
+  risk_config: AccountRiskConfig; // Isolated executor
+  executor_enabled: boolean;
+  keys_linked: boolean; // A Binance secret is stored
+  is_active: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+/** TradingAccountUpdateRequest represents an account update request */
+export interface TradingAccountUpdateRequest {
+  account_name?: string;
+  trading_symbol?: string;
+  trading_mode?: ModelsTradingMode;
+  enabled_strategies?: string[];
+  risk_config?: AccountRiskConfig;
+  is_active?: boolean;
+}
+
+/**
+ * TradingSession is the regional session a time falls in. Sessions are fixed
+ * UTC windows and do not overlap, so every time has exactly one.
+ */
+export type TradingSession = string;
+
+/** TradingSettings represents trading configuration */
+export interface TradingSettings {
+  mode: string; // "paper" or "live"
+  symbol: string; // e.g., "ETHUSDT"
+  timeframes: string[]; // e.g., ["1m", "5m", "15m", "1h", "4h"]
+  primaryTimeframe: string; // e.g., "1h"
+  initialBalance: number; // Paper trading initial balance
+  commission: number; // Commission rate (0.001 = 0.1%)
+  slippage: number; // Slippage rate
+}
+
+/** TradingState represents the current trading state */
+export interface TradingState {
+  Mode: OrchestratorTradingMode; // General
+  IsRunning: boolean;
+  IsPaused: boolean;
+  Arming: ArmingState; // Live-mode arming phase (empty when not required)
+  StartTime: string;
+  LastUpdate: string;
+  CurrentPrice: number; // Market
+  DailyChange: number;
+  Volume24h: number;
+  Equity: number; // Account
+  AvailableBalance: number;
+  UnrealizedPnL: number;
+  RealizedPnL: number;
+  DailyPnL: number;
+  OpenPositions: number; // Positions
+  TotalTrades: number;
+  WinRate: number;
+  CurrentDrawdown: number; // Risk
+  MaxDrawdown: number; // Drawdown limit in quote currency, on PeakEquity
+  PeakEquity: number; // High-water mark drawdown is measured from
+  RiskLevel: RiskLevel;
+  IsHalted: boolean;
+  HaltReason: string;
+  ActiveStrategies: string[]; // Strategy
+  CurrentRegime: string;
+  LastSignal: Signal | null;
+  CandleCount: number; // System
+  LastCandleTime: string;
+  Timeframes: TimeframeHealth[]; // Candle feed freshness per timeframe
+  Errors: string[];
+}
+
+/** TradingStateResponse represents trading state response */
+export interface TradingStateResponse {
+  state: TradingState | null;
+}
+
+/** TwoFactorCodeRequest carries a code from the user's authenticator app */
+export interface TwoFactorCodeRequest {
+  code: string;
+}
+
+/**
+ * TwoFactorDisableRequest re-authenticates the user before removing two-factor
+ * authentication
+ */
+export interface TwoFactorDisableRequest {
+  password: string;
+  code: string;
+}
+
+/** TwoFactorEnrollment is a new TOTP secret for the user's authenticator app */
+export interface TwoFactorEnrollment {
+  secret: string; // Base32, for manual entry
+  uri: string; // otpauth:// URI, usually shown as a QR code
+}
+
+/** TwoFactorStatus describes a user's two-factor authentication */
+export interface TwoFactorStatus {
+  enabled: boolean;
+  pending: boolean; // Enrollment started but not confirmed
+  required: boolean; // Sensitive operations refuse users without it
+  available: boolean; // Secrets can be stored encrypted
+}
+
+/** UpdateConfigRequest represents risk config update request */
+export interface UpdateConfigRequest {
+  maxRiskPerTrade?: number;
+  maxDailyLoss?: number;
+  maxTotalDrawdown?: number;
+  highWaterMarkMode?: string;
+  maxOpenPositions?: number;
+  enableCircuitBreaker?: boolean;
+}
+
+/** UpdateStopLossRequest represents stop loss update request */
+export interface UpdateStopLossRequest {
+  stopLoss: number;
+}
+
+/** UpdateStrategyRequest represents strategy update request */
+export interface UpdateStrategyRequest {
+  config: Record<string, unknown>;
+}
+
+/** UpdateTakeProfitRequest represents take profit update request */
+export interface UpdateTakeProfitRequest {
+  takeProfit: number;
+}
+
+/** UserResponse is the public user response (no sensitive data) */
+export interface UserResponse {
+  id: string;
+  email: string;
+  full_name: string;
+  role: UserRole;
+  is_active: boolean;
+  is_email_verified: boolean;
+  two_factor_enabled: boolean;
+  last_login_at: string | null;
+  created_at: string;
+}
+
+/** UserRole represents user role types */
+export type UserRole = string;
+
+/** VWAPValue represents session VWAP values */
+export interface VWAPValue {
+  vwap: number;
+  distance: number; // Percent of the close from VWAP
+}
+
+export type Value = unknown;
+
+/**
+ * VolatilityThrottleStats counts a strategy's entries signaled on candles whose
+ * range was a volatility spike, and what became of them
+ */
+export interface VolatilityThrottleStats {
+  strategy: string;
+  spikes: number; // Entries signaled on a spike
+  downsized: number;
+  delayed: number;
+  released: number; // Delayed entries let through on the next candle
+  dropped: number; // Delayed entries the next candle did not bear out
+  lastSpikeAt?: string;
+}
+
+/** VolumeData represents volume indicator values */
+export interface VolumeData {
+  volume: number;
+  volumeSma: number;
+  obv: number;
+  vwap: number;
+}
+
+/** WSStats describes the bandwidth used by a WebSocket client */
+export interface WSStats {
+  connected: boolean;
+  compression: boolean; // permessage-deflate negotiated with the server
+  since: string;
+  wireBytesIn: number; // Bytes on the socket, including TLS and frame overhead
+  wireBytesOut: number;
+  payloadBytesIn: number; // Message payloads; compared with wire bytes this shows the saving
+  payloadBytesOut: number;
+  messagesIn: number;
+  messagesOut: number;
+  wireRatio?: number; // Wire bytes in / payload bytes in
+  streams: StreamStats[];
+}
+
+/** WeightStats reports a client's request weight usage */
+export interface WeightStats {
+  limit: number; // Weight allowed per minute
+  used: number; // Weight used this minute, as last reported by Binance
+  available: number; // Weight the limiter would spend right now
+  waits: number; // Requests delayed for weight or a pause
+  waitTime: Duration;
+  throttled: number; // 429 and 418 responses received
+  pausedUntil?: string;
+}
+
+// WebSocket message types
+export const MessageType = {
+  State: 'state', // Periodic trading state
+  Candle: 'candle', // Candle updates and closes
+  Signal: 'signal', // Strategy signals and their risk verdict
+  Trade: 'trade', // Fills
+  Position: 'position', // Positions opened, changed or closed
+  Risk: 'risk', // Risk state and risk events
+  Error: 'error', // Errors worth showing to users
+  Indicators: 'indicators', // Indicator values of the primary timeframe
+  Price: 'price', // Real-time prices
+  Mode: 'mode', // Scheduled trading mode transitions
+  Arming: 'arming', // Live-mode arming transitions
+  TradeIdea: 'trade_idea', // Signals queued for or decided in the approval inbox
+  Score: 'score', // Scorer breakdown of the latest analysis
+  Backtest: 'backtest', // Backtest job progress and completion
+  Strategy: 'strategy', // Strategies enabled or disabled at runtime
+  RiskReport: 'risk_report', // Daily risk digest
+  ParamDrift: 'param_drift', // Strategy parameters drifted from optimized values
+  Subscriptions: 'subscriptions', // The client's subscriptions, in reply to subscribe and unsubscribe
+  Pong: 'pong', // Reply to ping
+  Subscribe: 'subscribe', // Receive more message types; none = all
+  Unsubscribe: 'unsubscribe', // Stop receiving message types; none = all
+  Ping: 'ping', // Keepalive, answered with pong
+} as const;
+
+export type MessageType = (typeof MessageType)[keyof typeof MessageType];
+
+// Envelope of messages broadcast by the server
+export interface BroadcastMessage<T extends MessageType, D> {
+  type: T;
+  timestamp: string;
+  data: D;
+  accountId?: string; // Trading account the message concerns; absent = every user
+}
+
+// Messages sent by the server
+export type ServerMessage =
+  | BroadcastMessage<typeof MessageType.State, StateUpdate>
+  | BroadcastMessage<typeof MessageType.Candle, CandleUpdate>
+  | BroadcastMessage<typeof MessageType.Signal, SignalUpdate>
+  | BroadcastMessage<typeof MessageType.Trade, TradeUpdate>
+  | BroadcastMessage<typeof MessageType.Position, PositionUpdate>
+  | BroadcastMessage<typeof MessageType.Risk, RiskUpdate>
+  | BroadcastMessage<typeof MessageType.Error, ErrorUpdate>
+  | BroadcastMessage<typeof MessageType.Indicators, IndicatorsUpdate>
+  | BroadcastMessage<typeof MessageType.Price, PriceUpdate>
+  | BroadcastMessage<typeof MessageType.Mode, ModeUpdate>
+  | BroadcastMessage<typeof MessageType.Arming, ArmingStatus>
+  | BroadcastMessage<typeof MessageType.TradeIdea, TradeIdea>
+  | BroadcastMessage<typeof MessageType.Score, ScoreUpdate>
+  | BroadcastMessage<typeof MessageType.Backtest, BacktestJob>
+  | BroadcastMessage<typeof MessageType.Strategy, StrategyStateUpdate>
+  | BroadcastMessage<typeof MessageType.RiskReport, RiskReport>
+  | BroadcastMessage<typeof MessageType.ParamDrift, ParamDriftReport>
+  | { type: typeof MessageType.Subscriptions; data: Subscriptions }
+  | { type: typeof MessageType.Pong };
+
+// Messages sent by clients
+export type ClientMessage =
+  | { type: typeof MessageType.Subscribe; data: SubscriptionChange }
+  | { type: typeof MessageType.Unsubscribe; data: SubscriptionChange }
+  | { type: typeof MessageType.Ping };